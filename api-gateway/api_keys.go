@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+
+	// simulationRunLimitHeader forwards the caller's limit so the
+	// sim-engine can recheck it
+	simulationRunLimitHeader = "X-Simulation-Run-Limit"
+)
+
+// APITier defines what callers holding a key of this tier may request
+type APITier struct {
+	Name              string `json:"name"`
+	MaxSimulationRuns int    `json:"max_simulation_runs"` // 0 = unlimited
+}
+
+// apiTiers are the built-in tiers API keys can be assigned to
+var apiTiers = map[string]APITier{
+	"free":     {Name: "free", MaxSimulationRuns: 2000},
+	"standard": {Name: "standard", MaxSimulationRuns: 20000},
+	"internal": {Name: "internal", MaxSimulationRuns: 0},
+}
+
+// APIKeyStore maps API keys to tiers. Requests without a key use the default tier.
+type APIKeyStore struct {
+	keys        map[string]APITier
+	defaultTier APITier
+}
+
+// ParseAPIKeys builds a key store from "key:tier,key:tier" and a default tier name
+func ParseAPIKeys(spec, defaultTier string) (*APIKeyStore, error) {
+	def, ok := apiTiers[defaultTier]
+	if !ok {
+		return nil, fmt.Errorf("unknown default API tier %q", defaultTier)
+	}
+
+	store := &APIKeyStore{keys: make(map[string]APITier), defaultTier: def}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, tierName, found := strings.Cut(entry, ":")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid API key entry %q (expected key:tier)", entry)
+		}
+		tier, ok := apiTiers[tierName]
+		if !ok {
+			return nil, fmt.Errorf("unknown API tier %q for key", tierName)
+		}
+		store.keys[key] = tier
+	}
+
+	return store, nil
+}
+
+// TierFor returns the tier for the request's API key; ok is false when a
+// key is supplied but not recognised
+func (ks *APIKeyStore) TierFor(r *http.Request) (tier APITier, ok bool) {
+	key := r.Header.Get(apiKeyHeader)
+	if key == "" {
+		return ks.defaultTier, true
+	}
+	tier, ok = ks.keys[key]
+	return tier, ok
+}
+
+// authorizeAdmin allows only internal-tier keys through to administrative
+// writes. It writes a 403 and returns false otherwise.
+func (s *Server) authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	tier, ok := s.apiKeys.TierFor(r)
+	if !ok {
+		writeError(w, "Invalid API key", http.StatusForbidden)
+		return false
+	}
+	if tier.Name != "internal" {
+		writeErrorWithDetails(w, "Administrative endpoints require an internal API key", "admin_required",
+			map[string]interface{}{"tier": tier.Name}, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// authorizeSimulationRuns resolves the caller's tier and checks the requested
+// run count against it. It writes a 403 or 422 response and returns false
+// when the request must not be forwarded.
+func (s *Server) authorizeSimulationRuns(w http.ResponseWriter, r *http.Request, requested int) (APITier, bool) {
+	tier, ok := s.apiKeys.TierFor(r)
+	if !ok {
+		writeError(w, "Invalid API key", http.StatusForbidden)
+		return APITier{}, false
+	}
+
+	if requested < 0 {
+		writeErrorWithDetails(w, "simulation_runs must be a non-negative integer", "invalid_simulation_runs",
+			map[string]interface{}{"simulation_runs": requested}, http.StatusUnprocessableEntity)
+		return APITier{}, false
+	}
+
+	if tier.MaxSimulationRuns > 0 && requested > tier.MaxSimulationRuns {
+		writeErrorWithDetails(w,
+			fmt.Sprintf("simulation_runs %d exceeds the %s tier limit of %d", requested, tier.Name, tier.MaxSimulationRuns),
+			"simulation_limit_exceeded",
+			map[string]interface{}{
+				"tier":      tier.Name,
+				"limit":     tier.MaxSimulationRuns,
+				"requested": requested,
+			}, http.StatusForbidden)
+		return APITier{}, false
+	}
+
+	return tier, true
+}
+
+// postToSimEngine forwards a JSON body to the sim-engine with the caller's run limit
+func (s *Server) postToSimEngine(ctx context.Context, path string, body io.Reader, tier APITier) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.simEngineURL()+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if tier.MaxSimulationRuns > 0 {
+		req.Header.Set(simulationRunLimitHeader, strconv.Itoa(tier.MaxSimulationRuns))
+	}
+	return s.simEngineClient.Do(req)
+}
+
+// writeSimEngineBusy relays the sim-engine turning a run away because its
+// queue is full, keeping its Retry-After and the backlog it reported
+func writeSimEngineBusy(w http.ResponseWriter, resp *http.Response) {
+	var busy struct {
+		Error                string  `json:"error"`
+		QueueDepth           int     `json:"queue_depth"`
+		MaxQueueDepth        int     `json:"max_queue_depth"`
+		EstimatedWaitSeconds float64 `json:"estimated_wait_seconds"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&busy); err != nil || busy.Error == "" {
+		busy.Error = "simulation queue is full"
+	}
+
+	retryAfter := resp.Header.Get("Retry-After")
+	if _, err := strconv.Atoi(retryAfter); err != nil {
+		retryAfter = "1"
+	}
+	w.Header().Set("Retry-After", retryAfter)
+	writeErrorWithDetails(w, busy.Error, "simulation_engine_busy", map[string]interface{}{
+		"queue_depth":            busy.QueueDepth,
+		"max_queue_depth":        busy.MaxQueueDepth,
+		"estimated_wait_seconds": busy.EstimatedWaitSeconds,
+	}, http.StatusTooManyRequests)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseAPIKeys tests key configuration parsing
+func TestParseAPIKeys(t *testing.T) {
+	store, err := ParseAPIKeys("abc:internal, def:standard", "free")
+	assert.NoError(t, err)
+
+	r := httptest.NewRequest("POST", "/api/v1/simulations", nil)
+	tier, ok := store.TierFor(r)
+	assert.True(t, ok)
+	assert.Equal(t, "free", tier.Name)
+
+	r.Header.Set(apiKeyHeader, "abc")
+	tier, ok = store.TierFor(r)
+	assert.True(t, ok)
+	assert.Equal(t, "internal", tier.Name)
+
+	r.Header.Set(apiKeyHeader, "unknown")
+	_, ok = store.TierFor(r)
+	assert.False(t, ok)
+
+	_, err = ParseAPIKeys("abc:gold", "free")
+	assert.Error(t, err)
+
+	_, err = ParseAPIKeys("abc", "free")
+	assert.Error(t, err)
+
+	_, err = ParseAPIKeys("", "platinum")
+	assert.Error(t, err)
+}
+
+// TestAuthorizeSimulationRuns tests tier limits and error responses
+func TestAuthorizeSimulationRuns(t *testing.T) {
+	store, _ := ParseAPIKeys("internal-key:internal", "free")
+	s := &Server{apiKeys: store}
+
+	tests := []struct {
+		name       string
+		key        string
+		runs       int
+		wantOK     bool
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "free within limit", runs: 2000, wantOK: true},
+		{name: "free over limit", runs: 2001, wantStatus: http.StatusForbidden, wantCode: "simulation_limit_exceeded"},
+		{name: "internal unlimited", key: "internal-key", runs: 100000, wantOK: true},
+		{name: "unknown key", key: "nope", runs: 10, wantStatus: http.StatusForbidden},
+		{name: "negative runs", runs: -5, wantStatus: http.StatusUnprocessableEntity, wantCode: "invalid_simulation_runs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/v1/simulations", nil)
+			if tt.key != "" {
+				r.Header.Set(apiKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+
+			_, ok := s.authorizeSimulationRuns(w, r, tt.runs)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				return
+			}
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var apiErr APIError
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &apiErr))
+			assert.Equal(t, tt.wantCode, apiErr.Code)
+		})
+	}
+}
+
+// TestWriteSimEngineBusy tests a full sim-engine queue reaches callers as a
+// 429 with the engine's Retry-After and backlog
+func TestWriteSimEngineBusy(t *testing.T) {
+	engine := httptest.NewRecorder()
+	engine.Header().Set("Retry-After", "42")
+	engine.WriteHeader(http.StatusTooManyRequests)
+	engine.Body.WriteString(`{"error":"simulation queue is full (50 runs pending or running)","queue_depth":50,"max_queue_depth":50,"estimated_wait_seconds":41.3}`)
+
+	w := httptest.NewRecorder()
+	writeSimEngineBusy(w, engine.Result())
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "42", w.Header().Get("Retry-After"))
+
+	var apiErr APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &apiErr))
+	assert.Equal(t, "simulation_engine_busy", apiErr.Code)
+	assert.Equal(t, "simulation queue is full (50 runs pending or running)", apiErr.Error)
+	assert.Equal(t, float64(50), apiErr.Details["queue_depth"])
+	assert.Equal(t, 41.3, apiErr.Details["estimated_wait_seconds"])
+
+	// A body the gateway can't read still gets a usable error
+	engine = httptest.NewRecorder()
+	engine.WriteHeader(http.StatusTooManyRequests)
+	engine.Body.WriteString("busy")
+	w = httptest.NewRecorder()
+	writeSimEngineBusy(w, engine.Result())
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &apiErr))
+	assert.Equal(t, "simulation queue is full", apiErr.Error)
+}
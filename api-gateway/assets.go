@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/baseball-sim/shared/ids"
+)
+
+const (
+	// defaultHeadshotURLTemplate is MLB's image CDN, which serves a generic
+	// silhouette for players it has no photo of
+	defaultHeadshotURLTemplate = "https://img.mlbstatic.com/mlb-photos/image/upload/" +
+		"d_people:generic:headshot:67:current.png/w_{width},q_auto:best/v1/people/{mlb_id}/headshot/67/current"
+
+	// maxHeadshotIDs bounds one batch request
+	maxHeadshotIDs = 100
+)
+
+// headshotWidths are the image widths offered for each player, by size name
+var headshotWidths = map[string]int{"small": 60, "medium": 120, "large": 213}
+
+// AssetSource is an upstream image host and the URL template its images are
+// built from. {mlb_id} and {width} are replaced per image.
+type AssetSource struct {
+	Name        string `json:"name"`
+	URLTemplate string `json:"url_template"`
+}
+
+// headshotSource returns where headshots come from: MLB's CDN unless the
+// template is overridden, e.g. to point at a mirror
+func headshotSource(template string) AssetSource {
+	if template == "" {
+		return AssetSource{Name: "mlb_static", URLTemplate: defaultHeadshotURLTemplate}
+	}
+	return AssetSource{Name: "custom", URLTemplate: template}
+}
+
+// URL builds the source's image URL for a player at a width
+func (a AssetSource) URL(mlbID string, width int) string {
+	return strings.NewReplacer("{mlb_id}", mlbID, "{width}", strconv.Itoa(width)).Replace(a.URLTemplate)
+}
+
+// Headshot is a player's photo at each offered size
+type Headshot struct {
+	PlayerID string            `json:"player_id"`
+	MLBID    string            `json:"mlb_id"`
+	Name     string            `json:"name"`
+	URLs     map[string]string `json:"urls"` // by size: small, medium, large
+	Source   string            `json:"source"`
+}
+
+// headshotFor builds a player's headshot URLs from the source
+func headshotFor(source AssetSource, playerID, mlbID, name string) Headshot {
+	headshot := Headshot{PlayerID: playerID, MLBID: mlbID, Name: name, Source: source.Name,
+		URLs: make(map[string]string, len(headshotWidths))}
+	for size, width := range headshotWidths {
+		headshot.URLs[size] = source.URL(mlbID, width)
+	}
+	return headshot
+}
+
+// parseHeadshotIDs reads ?ids= as comma-separated player IDs,
+// dropping blanks and duplicates. It returns a message when invalid.
+func parseHeadshotIDs(value string) ([]string, string) {
+	var refs []string
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(value, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		refs = append(refs, id)
+	}
+	if len(refs) == 0 {
+		return nil, "ids is required (comma-separated player IDs)"
+	}
+	if len(refs) > maxHeadshotIDs {
+		return nil, fmt.Sprintf("At most %d ids per request", maxHeadshotIDs)
+	}
+	return refs, ""
+}
+
+// getPlayerHeadshotsHandler returns headshot URLs for a batch of players, so
+// clients don't build image URLs themselves. IDs are resolved like any
+// other player ID; those that match no player, or several, are listed under
+// missing.
+func (s *Server) getPlayerHeadshotsHandler(w http.ResponseWriter, r *http.Request) {
+	refs, msg := parseHeadshotIDs(r.URL.Query().Get("ids"))
+	if msg != "" {
+		writeError(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	playerIDs := make(map[string]string, len(refs)) // requested ID to UUID
+	var uuids []string
+	for _, ref := range refs {
+		resolved, err := s.ids.Resolve(ctx, ids.Player, ref)
+		var ambiguous *ids.AmbiguousError
+		switch {
+		case err == nil:
+			playerIDs[ref] = resolved.ID
+			uuids = append(uuids, resolved.ID)
+		case errors.Is(err, ids.ErrNotFound), errors.As(err, &ambiguous):
+		default:
+			log.Printf("ID resolution error: %v", err)
+			writeError(w, "Failed to look up player", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	rows, err := s.readDB().Query(ctx, `
+		SELECT id::text, player_id, COALESCE(full_name, CONCAT(first_name, ' ', last_name))
+		FROM players
+		WHERE id = ANY($1::uuid[])`, uuids)
+	if err != nil {
+		log.Printf("Headshot query error: %v", err)
+		writeError(w, "Failed to query players", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	source := headshotSource(s.config.HeadshotURLTemplate)
+	found := make(map[string]Headshot)
+	for rows.Next() {
+		var playerID, mlbID, name string
+		if err := rows.Scan(&playerID, &mlbID, &name); err != nil {
+			log.Printf("Error scanning player: %v", err)
+			continue
+		}
+		found[playerID] = headshotFor(source, playerID, mlbID, name)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Headshot query error: %v", err)
+		writeError(w, "Failed to query players", http.StatusInternalServerError)
+		return
+	}
+
+	// Headshots come back in the order asked for
+	headshots := []Headshot{}
+	missing := []string{}
+	for _, ref := range refs {
+		if headshot, ok := found[playerIDs[ref]]; ok {
+			headshots = append(headshots, headshot)
+		} else {
+			missing = append(missing, ref)
+		}
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"headshots": headshots,
+		"missing":   missing,
+		"source":    source,
+	})
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadshotSource(t *testing.T) {
+	source := headshotSource("")
+	assert.Equal(t, "mlb_static", source.Name)
+	url := source.URL("592450", 120)
+	assert.True(t, strings.HasPrefix(url, "https://img.mlbstatic.com/"))
+	assert.Contains(t, url, "/w_120,")
+	assert.Contains(t, url, "/people/592450/headshot/")
+
+	mirror := headshotSource("https://cdn.example.com/players/{mlb_id}-{width}.png")
+	assert.Equal(t, "custom", mirror.Name)
+	assert.Equal(t, "https://cdn.example.com/players/592450-60.png", mirror.URL("592450", 60))
+}
+
+func TestHeadshotFor(t *testing.T) {
+	source := headshotSource("https://cdn.example.com/{mlb_id}/{width}")
+	headshot := headshotFor(source, "4f1e", "592450", "Aaron Judge")
+
+	assert.Equal(t, "custom", headshot.Source)
+	assert.Equal(t, map[string]string{
+		"small":  "https://cdn.example.com/592450/60",
+		"medium": "https://cdn.example.com/592450/120",
+		"large":  "https://cdn.example.com/592450/213",
+	}, headshot.URLs)
+}
+
+func TestParseHeadshotIDs(t *testing.T) {
+	ids, msg := parseHeadshotIDs(" 592450, ,660271,592450 ")
+	assert.Empty(t, msg)
+	assert.Equal(t, []string{"592450", "660271"}, ids, "blanks and repeats are dropped")
+
+	_, msg = parseHeadshotIDs(" , ")
+	assert.NotEmpty(t, msg)
+
+	tooMany := strings.TrimSuffix(strings.Repeat("1,", maxHeadshotIDs), ",")
+	ids, msg = parseHeadshotIDs(tooMany)
+	assert.Empty(t, msg, "repeats don't count against the limit")
+	assert.Len(t, ids, 1)
+
+	var distinct []string
+	for i := 0; i <= maxHeadshotIDs; i++ {
+		distinct = append(distinct, strings.Repeat("9", i+1))
+	}
+	_, msg = parseHeadshotIDs(strings.Join(distinct, ","))
+	assert.NotEmpty(t, msg)
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// defaultAuditRetentionDays is how long audit entries are kept
+	defaultAuditRetentionDays = 90
+
+	// auditPruneInterval is how often entries past retention are deleted
+	auditPruneInterval = time.Hour
+
+	// defaultAuditLookback is the window listed when ?since= is omitted
+	defaultAuditLookback = 24 * time.Hour
+)
+
+// Audited actions
+const (
+	auditSimulationCreated      = "simulation.created"
+	auditSimulationBatchCreated = "simulation.batch_created"
+	auditSimulationDeleted      = "simulation.deleted"
+	auditDataRefreshTriggered   = "data.refresh_triggered"
+	auditCacheCleared           = "cache.cleared"
+	auditStadiumCoordinatesSet  = "stadium.coordinates_set"
+	auditContractsIngested      = "contracts.ingested"
+	auditAliasesIngested        = "id_aliases.ingested"
+	auditGameVenueMoved         = "game.venue_moved"
+	auditTeamBrandingSet        = "team.branding_set"
+	auditRankingsRefreshed      = "rankings.refreshed"
+	auditBoxScoresReconciled    = "box_scores.reconciled"
+	auditPrecomputeTriggered    = "precompute.triggered"
+	auditOddsIngested           = "odds.ingested"
+)
+
+// AuditEntry is one recorded call to an audited endpoint
+type AuditEntry struct {
+	ID         int64     `json:"id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	APIKeyHash *string   `json:"api_key_hash"` // SHA-256 of the key; null for anonymous callers
+	Tier       string    `json:"tier"`
+	Action     string    `json:"action"`
+	Target     *string   `json:"target"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Status     int       `json:"status"`
+	ClientIP   string    `json:"client_ip"` // empty when the address couldn't be parsed
+}
+
+// newAuditEntry describes a finished request. Keys that aren't recognised
+// are still hashed, under the "invalid" tier, so rejected attempts can be
+// traced.
+func (s *Server) newAuditEntry(r *http.Request, action string, status int) AuditEntry {
+	entry := AuditEntry{
+		OccurredAt: time.Now().UTC(),
+		Action:     action,
+		Method:     r.Method,
+		Path:       r.URL.RequestURI(),
+		Status:     status,
+	}
+	// Only well-formed addresses are kept; anything else is stored as NULL
+	if ip := net.ParseIP(s.clientIP(r)); ip != nil {
+		entry.ClientIP = ip.String()
+	}
+
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		sum := sha256.Sum256([]byte(key))
+		hash := hex.EncodeToString(sum[:])
+		entry.APIKeyHash = &hash
+	}
+	if tier, ok := s.apiKeys.TierFor(r); ok {
+		entry.Tier = tier.Name
+	} else {
+		entry.Tier = "invalid"
+	}
+
+	// The affected resource is whichever ID the route names, or the one the
+	// handler created
+	vars := mux.Vars(r)
+	for _, name := range []string{"id", "job"} {
+		if value := vars[name]; value != "" {
+			entry.Target = &value
+			break
+		}
+	}
+	return entry
+}
+
+// auditResponseWriter captures the status of an audited call and the ID of
+// any resource it created
+type auditResponseWriter struct {
+	loggingResponseWriter
+	target string
+}
+
+// setAuditTarget records the resource an audited handler created, such as a
+// new run's ID, when the route doesn't name one
+func setAuditTarget(w http.ResponseWriter, target string) {
+	if aw, ok := w.(*auditResponseWriter); ok && target != "" {
+		aw.target = target
+	}
+}
+
+// audited wraps a handler so every call to it, allowed or not, is recorded
+// under action once the response is written
+func (s *Server) audited(action string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		aw := &auditResponseWriter{loggingResponseWriter: loggingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}}
+		next(aw, r)
+		entry := s.newAuditEntry(r, action, aw.statusCode)
+		if entry.Target == nil && aw.target != "" {
+			entry.Target = &aw.target
+		}
+		s.recordAudit(entry)
+	}
+}
+
+// recordAudit stores an entry. While the primary is unavailable entries go
+// to the application log instead, so the trail isn't lost.
+func (s *Server) recordAudit(entry AuditEntry) {
+	logFields := map[string]interface{}{
+		"audit": true, "event": entry.Action, "tier": entry.Tier, "path": entry.Path,
+		"status": entry.Status, "ip": entry.ClientIP,
+	}
+	if entry.Target != nil {
+		logFields["target"] = *entry.Target
+	}
+	if !s.dbReady.Load() || !s.dbRouter.PrimaryHealthy() {
+		appLogger.Warn("Audit entry not stored, database unavailable", logFields)
+		return
+	}
+
+	var clientIP *string
+	if entry.ClientIP != "" {
+		clientIP = &entry.ClientIP
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err := s.dbRouter.Writer().Exec(ctx, `
+		INSERT INTO audit_log (occurred_at, api_key_hash, tier, action, target, method, path, status, client_ip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::inet)`,
+		entry.OccurredAt, entry.APIKeyHash, entry.Tier, entry.Action, entry.Target,
+		entry.Method, entry.Path, entry.Status, clientIP)
+	if err != nil {
+		logFields["error"] = err.Error()
+		appLogger.Warn("Failed to store audit entry", logFields)
+	}
+}
+
+// pruneAuditLogPeriodically deletes entries older than retentionDays every
+// auditPruneInterval
+func (s *Server) pruneAuditLogPeriodically(ctx context.Context, retentionDays int) {
+	ticker := time.NewTicker(auditPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !s.dbReady.Load() || !s.dbRouter.PrimaryHealthy() {
+			continue
+		}
+		pruneCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		tag, err := s.dbRouter.Writer().Exec(pruneCtx,
+			`DELETE FROM audit_log WHERE occurred_at < NOW() - make_interval(days => $1)`, retentionDays)
+		cancel()
+		if err != nil {
+			log.Printf("Audit log pruning failed: %v", err)
+			continue
+		}
+		if tag.RowsAffected() > 0 {
+			log.Printf("Pruned %d audit entries older than %d days", tag.RowsAffected(), retentionDays)
+		}
+	}
+}
+
+// parseAuditSince reads ?since= as RFC 3339 or YYYY-MM-DD, defaulting to
+// defaultAuditLookback before now. It returns a message when invalid.
+func parseAuditSince(value string, now time.Time) (time.Time, string) {
+	if value == "" {
+		return now.Add(-defaultAuditLookback), ""
+	}
+	if since, err := time.Parse(time.RFC3339, value); err == nil {
+		return since, ""
+	}
+	if since, err := time.Parse("2006-01-02", value); err == nil {
+		return since, ""
+	}
+	return time.Time{}, "Invalid since parameter, use RFC 3339 or YYYY-MM-DD"
+}
+
+// getAuditLogHandler lists audit entries since ?since=, newest first,
+// optionally for one ?action=. Internal API keys only.
+func (s *Server) getAuditLogHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	since, msg := parseAuditSince(r.URL.Query().Get("since"), time.Now())
+	if msg != "" {
+		writeError(w, msg, http.StatusBadRequest)
+		return
+	}
+	params := parseQueryParams(r)
+
+	where := " WHERE occurred_at >= $1"
+	args := []interface{}{since}
+	if action := r.URL.Query().Get("action"); action != "" {
+		args = append(args, action)
+		where += " AND action = $2"
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	var total int
+	entries := []AuditEntry{}
+	err := s.withSnapshot(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM audit_log"+where, args...).Scan(&total); err != nil {
+			return fmt.Errorf("count audit entries: %w", err)
+		}
+
+		rows, err := tx.Query(ctx, fmt.Sprintf(`
+			SELECT id, occurred_at, api_key_hash, tier, action, target, method, path, status, COALESCE(host(client_ip), '')
+			FROM audit_log%s
+			ORDER BY occurred_at DESC, id DESC
+			LIMIT %d OFFSET %d`, where, params.PageSize, calculateOffset(params.Page, params.PageSize)), args...)
+		if err != nil {
+			return fmt.Errorf("query audit entries: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var entry AuditEntry
+			if err := rows.Scan(&entry.ID, &entry.OccurredAt, &entry.APIKeyHash, &entry.Tier, &entry.Action,
+				&entry.Target, &entry.Method, &entry.Path, &entry.Status, &entry.ClientIP); err != nil {
+				return fmt.Errorf("scan audit entry: %w", err)
+			}
+			entries = append(entries, entry)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		log.Printf("Failed to query audit entries: %v", err)
+		writeError(w, "Failed to query audit entries", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, buildPaginatedResponse(entries, total, params))
+}
+
+// clearCacheHandler empties the gateway's query cache so the next requests
+// read fresh data. Responses kept for database outages are left alone.
+// Internal API keys only.
+func (s *Server) clearCacheHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+	s.queryCache.Clear()
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuditSince(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	since, msg := parseAuditSince("", now)
+	assert.Empty(t, msg)
+	assert.Equal(t, now.Add(-24*time.Hour), since)
+
+	since, msg = parseAuditSince("2026-06-15", now)
+	assert.Empty(t, msg)
+	assert.Equal(t, time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC), since)
+
+	since, msg = parseAuditSince("2026-06-30T08:30:00-04:00", now)
+	assert.Empty(t, msg)
+	assert.True(t, since.Equal(time.Date(2026, 6, 30, 12, 30, 0, 0, time.UTC)))
+
+	_, msg = parseAuditSince("yesterday", now)
+	assert.NotEmpty(t, msg)
+}
+
+func TestNewAuditEntry(t *testing.T) {
+	keys, err := ParseAPIKeys("admin-key:internal", "free")
+	require.NoError(t, err)
+	// httptest requests come from 192.0.2.1, standing in for the proxy
+	proxies, err := ParseIPList("192.0.2.1,10.0.0.0/8")
+	require.NoError(t, err)
+	s := &Server{apiKeys: keys, trustedProxies: proxies}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/simulations/abc?force=1", nil)
+	req.Header.Set(apiKeyHeader, "admin-key")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	req = mux.SetURLVars(req, map[string]string{"id": "abc"})
+
+	entry := s.newAuditEntry(req, auditSimulationDeleted, http.StatusNoContent)
+	sum := sha256.Sum256([]byte("admin-key"))
+	require.NotNil(t, entry.APIKeyHash)
+	assert.Equal(t, hex.EncodeToString(sum[:]), *entry.APIKeyHash)
+	assert.Equal(t, "internal", entry.Tier)
+	require.NotNil(t, entry.Target)
+	assert.Equal(t, "abc", *entry.Target)
+	assert.Equal(t, "/api/v1/simulations/abc?force=1", entry.Path)
+	assert.Equal(t, "203.0.113.7", entry.ClientIP)
+	assert.Equal(t, http.StatusNoContent, entry.Status)
+
+	// Anonymous callers have no hash; unknown keys are kept as invalid
+	anonymous := s.newAuditEntry(httptest.NewRequest(http.MethodPost, "/api/v1/data/refresh", nil), auditDataRefreshTriggered, 200)
+	assert.Nil(t, anonymous.APIKeyHash)
+	assert.Equal(t, "free", anonymous.Tier)
+	assert.Nil(t, anonymous.Target)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/cache/clear", nil)
+	req.Header.Set(apiKeyHeader, "guess")
+	assert.Equal(t, "invalid", s.newAuditEntry(req, auditCacheCleared, 403).Tier)
+
+	// Addresses that don't parse are dropped rather than stored
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/simulations", nil)
+	req.RemoteAddr = "not-an-address"
+	assert.Empty(t, s.newAuditEntry(req, auditSimulationCreated, 200).ClientIP)
+}
+
+func TestAuditedTarget(t *testing.T) {
+	var logged bytes.Buffer
+	appLogger = NewStructuredLogger(&logged)
+	keys, err := ParseAPIKeys("", "free")
+	require.NoError(t, err)
+	s := &Server{apiKeys: keys}
+
+	// Creating a run records its new ID, which the route doesn't name
+	handler := s.audited(auditSimulationCreated, func(w http.ResponseWriter, r *http.Request) {
+		setAuditTarget(w, "0b6c9c1e-4f5e-4a43-9d0e-3f7a3c8e2b11")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/simulations", nil))
+	assert.Contains(t, logged.String(), `"target":"0b6c9c1e-4f5e-4a43-9d0e-3f7a3c8e2b11"`)
+	assert.Contains(t, logged.String(), `"status":202`)
+}
+
+func TestAuditedClearCache(t *testing.T) {
+	var logged bytes.Buffer
+	appLogger = NewStructuredLogger(&logged)
+	keys, err := ParseAPIKeys("admin-key:internal,std-key:standard", "free")
+	require.NoError(t, err)
+	s := &Server{apiKeys: keys, queryCache: NewQueryCache()}
+	router := mux.NewRouter()
+	router.HandleFunc("/admin/cache/clear", s.audited(auditCacheCleared, s.clearCacheHandler)).Methods("POST")
+
+	clear := func(key string) int {
+		s.queryCache.Set("standings_2026", "cached", time.Minute)
+		req := httptest.NewRequest(http.MethodPost, "/admin/cache/clear", nil)
+		req.Header.Set(apiKeyHeader, key)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, clear("std-key"))
+	_, found := s.queryCache.Get("standings_2026")
+	assert.True(t, found)
+
+	assert.Equal(t, http.StatusNoContent, clear("admin-key"))
+	_, found = s.queryCache.Get("standings_2026")
+	assert.False(t, found)
+
+	// Without a database both attempts fall back to the application log
+	assert.Equal(t, 2, bytes.Count(logged.Bytes(), []byte(`"event":"cache.cleared"`)))
+	assert.Contains(t, logged.String(), `"status":403`)
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// boxScorePlay is the subset of a game_plays row needed to rebuild a box score
+type boxScorePlay struct {
+	PlayID      string
+	Inning      int
+	InningHalf  string
+	Outs        int
+	BatterID    string
+	BatterName  string
+	PitcherID   string
+	PitcherName string
+	EventType   string
+	RBI         int
+	RunsScored  int
+}
+
+// normalizeEventType folds MLB event names ("Home Run", "home_run", "homerun")
+// into a single lowercase token without separators
+func normalizeEventType(event string) string {
+	replacer := strings.NewReplacer(" ", "", "_", "", "-", "")
+	return replacer.Replace(strings.ToLower(event))
+}
+
+// loadBoxScorePlays loads a game's plays in batting order for box score computation
+func (s *Server) loadBoxScorePlays(ctx context.Context, gameID string) ([]boxScorePlay, error) {
+	rows, err := s.readDB().Query(ctx, `
+		SELECT
+			gp.play_id,
+			gp.inning,
+			gp.inning_half,
+			gp.outs,
+			COALESCE(b.player_id, ''),
+			COALESCE(b.full_name, 'Unknown'),
+			COALESCE(p.player_id, ''),
+			COALESCE(p.full_name, 'Unknown'),
+			COALESCE(gp.event_type, ''),
+			COALESCE(gp.rbi, 0),
+			COALESCE(gp.runs_scored, 0)
+		FROM game_plays gp
+		LEFT JOIN players b ON gp.batter_id = b.id
+		LEFT JOIN players p ON gp.pitcher_id = p.id
+		WHERE gp.game_id = $1
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plays []boxScorePlay
+	for rows.Next() {
+		var play boxScorePlay
+		if err := rows.Scan(
+			&play.PlayID, &play.Inning, &play.InningHalf, &play.Outs,
+			&play.BatterID, &play.BatterName, &play.PitcherID, &play.PitcherName,
+			&play.EventType, &play.RBI, &play.RunsScored,
+		); err == nil {
+			plays = append(plays, play)
+		}
+	}
+
+	return plays, rows.Err()
+}
+
+// computeBoxScoreFromPlays rebuilds batting and pitching lines from
+// play-by-play data for games whose box score rows were never ingested.
+// Plays don't record which runner scored, so batter runs only count home
+// runs and every run is charged to the pitcher as earned.
+func computeBoxScoreFromPlays(plays []boxScorePlay, homeTeamID, awayTeamID string) GameBoxScore {
+	sortPlays(plays)
+
+	batters := make(map[string]*BoxScoreBatting)
+	pitchers := make(map[string]*BoxScorePitching)
+	pitcherOuts := make(map[string]int)
+	var batterOrder, pitcherOrder []string
+	lineupSlots := map[string]int{homeTeamID: 0, awayTeamID: 0}
+
+	prevOuts := 0
+	prevHalf := ""
+	for _, play := range plays {
+		half := strings.ToLower(play.InningHalf)
+		battingTeam, fieldingTeam := awayTeamID, homeTeamID
+		if half == "bottom" {
+			battingTeam, fieldingTeam = homeTeamID, awayTeamID
+		}
+
+		halfKey := strconv.Itoa(play.Inning) + half
+		if halfKey != prevHalf {
+			prevOuts = 0
+			prevHalf = halfKey
+		}
+
+		event := normalizeEventType(play.EventType)
+
+		// Outs recorded on the play come from the running out count
+		outsOnPlay := play.Outs - prevOuts
+		if outsOnPlay < 0 {
+			outsOnPlay = 0
+		}
+		prevOuts = play.Outs
+
+		if play.BatterID != "" {
+			bat, ok := batters[play.BatterID]
+			if !ok {
+				bat = &BoxScoreBatting{PlayerID: play.BatterID, PlayerName: play.BatterName, TeamID: battingTeam}
+				if lineupSlots[battingTeam] < 9 {
+					lineupSlots[battingTeam]++
+					order := lineupSlots[battingTeam]
+					bat.BattingOrder = &order
+				}
+				batters[play.BatterID] = bat
+				batterOrder = append(batterOrder, play.BatterID)
+			}
+			applyBattingEvent(bat, event, play.RBI)
+		}
+
+		if play.PitcherID != "" {
+			pitch, ok := pitchers[play.PitcherID]
+			if !ok {
+				pitch = &BoxScorePitching{PlayerID: play.PitcherID, PlayerName: play.PitcherName, TeamID: fieldingTeam}
+				pitchers[play.PitcherID] = pitch
+				pitcherOrder = append(pitcherOrder, play.PitcherID)
+			}
+			applyPitchingEvent(pitch, event, play.RunsScored)
+			pitcherOuts[play.PitcherID] += outsOnPlay
+		}
+	}
+
+	boxScore := GameBoxScore{
+		HomeTeamBatting:  []BoxScoreBatting{},
+		AwayTeamBatting:  []BoxScoreBatting{},
+		HomeTeamPitching: []BoxScorePitching{},
+		AwayTeamPitching: []BoxScorePitching{},
+		Computed:         true,
+	}
+
+	for _, id := range batterOrder {
+		bat := *batters[id]
+		if bat.TeamID == homeTeamID {
+			boxScore.HomeTeamBatting = append(boxScore.HomeTeamBatting, bat)
+		} else {
+			boxScore.AwayTeamBatting = append(boxScore.AwayTeamBatting, bat)
+		}
+	}
+
+	for _, id := range pitcherOrder {
+		pitch := *pitchers[id]
+		outs := pitcherOuts[id]
+		// Baseball notation: .1 and .2 are thirds of an inning
+		pitch.InningsPitched = float64(outs/3) + float64(outs%3)/10
+		if pitch.TeamID == homeTeamID {
+			boxScore.HomeTeamPitching = append(boxScore.HomeTeamPitching, pitch)
+		} else {
+			boxScore.AwayTeamPitching = append(boxScore.AwayTeamPitching, pitch)
+		}
+	}
+
+	return boxScore
+}
+
+// applyBattingEvent adds one plate appearance to a batting line
+func applyBattingEvent(bat *BoxScoreBatting, event string, rbi int) {
+	bat.RBIs += rbi
+
+	switch {
+	case event == "walk" || event == "intentwalk" || event == "intentionalwalk":
+		bat.Walks++
+		return
+	case event == "hitbypitch", event == "sacfly", event == "sacbunt",
+		event == "sacflydoubleplay", event == "sacbuntdoubleplay", event == "catcherinterference":
+		return // not an at-bat
+	}
+
+	bat.AtBats++
+
+	switch {
+	case event == "single":
+		bat.Hits++
+	case event == "double":
+		bat.Hits++
+		bat.Doubles++
+	case event == "triple":
+		bat.Hits++
+		bat.Triples++
+	case event == "homerun":
+		bat.Hits++
+		bat.HomeRuns++
+		bat.Runs++
+	case strings.HasPrefix(event, "strikeout"):
+		bat.Strikeouts++
+	}
+}
+
+// applyPitchingEvent adds one batter faced to a pitching line
+func applyPitchingEvent(pitch *BoxScorePitching, event string, runs int) {
+	pitch.RunsAllowed += runs
+	pitch.EarnedRuns += runs
+
+	switch {
+	case event == "single", event == "double", event == "triple":
+		pitch.HitsAllowed++
+	case event == "homerun":
+		pitch.HitsAllowed++
+		pitch.HomeRunsAllowed++
+	case event == "walk" || event == "intentwalk" || event == "intentionalwalk":
+		pitch.WalksAllowed++
+	case strings.HasPrefix(event, "strikeout"):
+		pitch.Strikeouts++
+	}
+}
+
+// sortPlays orders plays by inning, top before bottom, then play sequence
+func sortPlays(plays []boxScorePlay) {
+	halfRank := func(half string) int {
+		if strings.ToLower(half) == "bottom" {
+			return 1
+		}
+		return 0
+	}
+
+	sort.SliceStable(plays, func(i, j int) bool {
+		if plays[i].Inning != plays[j].Inning {
+			return plays[i].Inning < plays[j].Inning
+		}
+		if hi, hj := halfRank(plays[i].InningHalf), halfRank(plays[j].InningHalf); hi != hj {
+			return hi < hj
+		}
+		ai, errI := strconv.Atoi(plays[i].PlayID)
+		aj, errJ := strconv.Atoi(plays[j].PlayID)
+		if errI == nil && errJ == nil {
+			return ai < aj
+		}
+		return plays[i].PlayID < plays[j].PlayID
+	})
+}
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// defaultBoxScoreReconcileInterval is how often (seconds) recent box
+	// scores are checked against their plays
+	defaultBoxScoreReconcileInterval = 6 * 60 * 60
+
+	// boxScoreReconcileLookback is how far back the scheduled check looks,
+	// covering the data fetcher's weekly re-fetch window
+	boxScoreReconcileLookback = 7 * 24 * time.Hour
+
+	// maxBoxScoreReconcileDays bounds an on-demand check
+	maxBoxScoreReconcileDays = 31
+)
+
+// boxScoreTotals are one team's batting totals in a game
+type boxScoreTotals struct {
+	Hits       int
+	Runs       int
+	Strikeouts int
+}
+
+// BoxScoreMismatch is one total that differs between a stored box score and
+// the game's plays
+type BoxScoreMismatch struct {
+	Team     string `json:"team"` // home or away
+	Field    string `json:"field"`
+	BoxScore int    `json:"box_score"`
+	Plays    int    `json:"plays"`
+	Diff     int    `json:"diff"` // box score minus plays
+}
+
+// compareBoxScoreTotals lists the fields where the box score and the plays
+// disagree for one team
+func compareBoxScoreTotals(team string, box, plays boxScoreTotals) []BoxScoreMismatch {
+	var mismatches []BoxScoreMismatch
+	for _, field := range []struct {
+		name       string
+		box, plays int
+	}{
+		{"hits", box.Hits, plays.Hits},
+		{"runs", box.Runs, plays.Runs},
+		{"strikeouts", box.Strikeouts, plays.Strikeouts},
+	} {
+		if field.box != field.plays {
+			mismatches = append(mismatches, BoxScoreMismatch{
+				Team: team, Field: field.name, BoxScore: field.box, Plays: field.plays, Diff: field.box - field.plays,
+			})
+		}
+	}
+	return mismatches
+}
+
+// playTotals derives each team's batting totals from a game's plays, counted
+// the way computed box scores charge them to the pitcher
+func playTotals(plays []boxScorePlay) (home, away boxScoreTotals) {
+	var againstHome, againstAway BoxScorePitching
+	for _, play := range plays {
+		fielding := &againstAway
+		if strings.ToLower(play.InningHalf) == "top" {
+			fielding = &againstHome
+		}
+		applyPitchingEvent(fielding, normalizeEventType(play.EventType), play.RunsScored)
+	}
+	home = boxScoreTotals{Hits: againstAway.HitsAllowed, Runs: againstAway.RunsAllowed, Strikeouts: againstAway.Strikeouts}
+	away = boxScoreTotals{Hits: againstHome.HitsAllowed, Runs: againstHome.RunsAllowed, Strikeouts: againstHome.Strikeouts}
+	return home, away
+}
+
+// BoxScoreReconciliation is one game's check
+type BoxScoreReconciliation struct {
+	GameID     string             `json:"game_id"`
+	GameDate   string             `json:"game_date"`
+	HomeTeam   string             `json:"home_team"`
+	AwayTeam   string             `json:"away_team"`
+	Mismatches []BoxScoreMismatch `json:"mismatches"`
+	CheckedAt  time.Time          `json:"checked_at"`
+}
+
+// BoxScoreReconciliationRun summarizes a check of every game in a date range
+type BoxScoreReconciliationRun struct {
+	From            string                   `json:"from"`
+	To              string                   `json:"to"`
+	GamesChecked    int                      `json:"games_checked"`
+	GamesMismatched int                      `json:"games_mismatched"`
+	Games           []BoxScoreReconciliation `json:"games"` // mismatched games only
+}
+
+// reconciledGame is a completed game with both box score rows and plays
+type reconciledGame struct {
+	BoxScoreReconciliation
+	box   map[string]boxScoreTotals // by home/away
+	plays []boxScorePlay
+}
+
+// reconcileBoxScores checks completed games dated from..to (inclusive) that
+// have both box scores and plays, and records each result so mismatches can
+// be listed later
+func (s *Server) reconcileBoxScores(ctx context.Context, from, to time.Time) (BoxScoreReconciliationRun, error) {
+	run := BoxScoreReconciliationRun{
+		From:  from.Format("2006-01-02"),
+		To:    to.Format("2006-01-02"),
+		Games: []BoxScoreReconciliation{},
+	}
+
+	rows, err := s.dbRouter.Writer().Query(ctx, `
+		SELECT g.id::text, g.game_date::text, ht.name, at.name,
+		       SUM(b.hits) FILTER (WHERE b.team_id = g.home_team_id)::int,
+		       SUM(b.runs) FILTER (WHERE b.team_id = g.home_team_id)::int,
+		       SUM(b.strikeouts) FILTER (WHERE b.team_id = g.home_team_id)::int,
+		       SUM(b.hits) FILTER (WHERE b.team_id = g.away_team_id)::int,
+		       SUM(b.runs) FILTER (WHERE b.team_id = g.away_team_id)::int,
+		       SUM(b.strikeouts) FILTER (WHERE b.team_id = g.away_team_id)::int
+		FROM games g
+		JOIN teams ht ON ht.id = g.home_team_id
+		JOIN teams at ON at.id = g.away_team_id
+		JOIN game_box_score_batting b ON b.game_id = g.id
+		WHERE g.game_date BETWEEN $1 AND $2 AND g.status = 'completed'
+		  AND EXISTS (SELECT 1 FROM game_plays gp WHERE gp.game_id = g.id)
+		GROUP BY g.id, ht.name, at.name
+		ORDER BY g.game_date, g.id`, from, to)
+	if err != nil {
+		return run, fmt.Errorf("failed to query box scores: %w", err)
+	}
+	defer rows.Close()
+
+	games := make(map[string]*reconciledGame)
+	var order []string
+	for rows.Next() {
+		game := &reconciledGame{box: make(map[string]boxScoreTotals)}
+		var home, away struct{ hits, runs, strikeouts *int }
+		if err := rows.Scan(&game.GameID, &game.GameDate, &game.HomeTeam, &game.AwayTeam,
+			&home.hits, &home.runs, &home.strikeouts, &away.hits, &away.runs, &away.strikeouts); err != nil {
+			return run, fmt.Errorf("failed to scan box score totals: %w", err)
+		}
+		game.box["home"] = boxScoreTotals{Hits: intOrZero(home.hits), Runs: intOrZero(home.runs), Strikeouts: intOrZero(home.strikeouts)}
+		game.box["away"] = boxScoreTotals{Hits: intOrZero(away.hits), Runs: intOrZero(away.runs), Strikeouts: intOrZero(away.strikeouts)}
+		games[game.GameID] = game
+		order = append(order, game.GameID)
+	}
+	if err := rows.Err(); err != nil {
+		return run, fmt.Errorf("failed to query box scores: %w", err)
+	}
+	if len(games) == 0 {
+		return run, nil
+	}
+
+	playRows, err := s.dbRouter.Writer().Query(ctx, `
+		SELECT gp.game_id::text, gp.inning_half, COALESCE(gp.event_type, ''), COALESCE(gp.runs_scored, 0)
+		FROM game_plays gp
+		WHERE gp.game_id = ANY($1::uuid[])`, order)
+	if err != nil {
+		return run, fmt.Errorf("failed to query plays: %w", err)
+	}
+	defer playRows.Close()
+	for playRows.Next() {
+		var gameID string
+		var play boxScorePlay
+		if err := playRows.Scan(&gameID, &play.InningHalf, &play.EventType, &play.RunsScored); err != nil {
+			return run, fmt.Errorf("failed to scan play: %w", err)
+		}
+		if game, ok := games[gameID]; ok {
+			game.plays = append(game.plays, play)
+		}
+	}
+	if err := playRows.Err(); err != nil {
+		return run, fmt.Errorf("failed to query plays: %w", err)
+	}
+
+	checkedAt := time.Now()
+	for _, gameID := range order {
+		game := games[gameID]
+		home, away := playTotals(game.plays)
+		game.Mismatches = append(compareBoxScoreTotals("home", game.box["home"], home),
+			compareBoxScoreTotals("away", game.box["away"], away)...)
+		if game.Mismatches == nil {
+			game.Mismatches = []BoxScoreMismatch{}
+		}
+		game.CheckedAt = checkedAt
+
+		if err := s.saveBoxScoreReconciliation(ctx, game.BoxScoreReconciliation); err != nil {
+			return run, err
+		}
+		run.GamesChecked++
+		if len(game.Mismatches) > 0 {
+			run.GamesMismatched++
+			run.Games = append(run.Games, game.BoxScoreReconciliation)
+		}
+	}
+	return run, nil
+}
+
+// intOrZero reads a nullable aggregate
+func intOrZero(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// saveBoxScoreReconciliation replaces a game's last check
+func (s *Server) saveBoxScoreReconciliation(ctx context.Context, result BoxScoreReconciliation) error {
+	mismatches, err := json.Marshal(result.Mismatches)
+	if err != nil {
+		return fmt.Errorf("failed to encode mismatches: %w", err)
+	}
+	_, err = s.dbRouter.Writer().Exec(ctx, `
+		INSERT INTO box_score_reconciliations (game_id, mismatch_count, mismatches, checked_at)
+		VALUES ($1::uuid, $2, $3, $4)
+		ON CONFLICT (game_id) DO UPDATE SET
+			mismatch_count = EXCLUDED.mismatch_count,
+			mismatches = EXCLUDED.mismatches,
+			checked_at = EXCLUDED.checked_at`,
+		result.GameID, len(result.Mismatches), mismatches, result.CheckedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save reconciliation for %s: %w", result.GameID, err)
+	}
+	return nil
+}
+
+// reconcileBoxScoresPeriodically checks the last week's box scores every
+// interval, so ingestion bugs show up without anyone asking
+func (s *Server) reconcileBoxScoresPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !s.dbReady.Load() || !s.dbRouter.PrimaryHealthy() {
+			continue
+		}
+		to := time.Now()
+		runCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		run, err := s.reconcileBoxScores(runCtx, to.Add(-boxScoreReconcileLookback), to)
+		cancel()
+		if err != nil {
+			log.Printf("Box score reconciliation failed: %v", err)
+			continue
+		}
+		if run.GamesMismatched > 0 {
+			log.Printf("Box score reconciliation: %d of %d games from %s to %s disagree with their plays",
+				run.GamesMismatched, run.GamesChecked, run.From, run.To)
+		}
+	}
+}
+
+// BoxScoreReconcileRequest is the date range to check; both default to the
+// last week
+type BoxScoreReconcileRequest struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// reconcileBoxScoresHandler checks box scores against plays for a date range
+// of up to 31 days and returns the games that disagree
+func (s *Server) reconcileBoxScoresHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	var req BoxScoreReconcileRequest
+	if !s.decodeJSONBody(w, r, &req, true) {
+		return
+	}
+	to := time.Now()
+	from := to.Add(-boxScoreReconcileLookback)
+	for _, bound := range []struct {
+		name  string
+		value string
+		dst   *time.Time
+	}{{"from", req.From, &from}, {"to", req.To, &to}} {
+		if bound.value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01-02", bound.value)
+		if err != nil {
+			writeError(w, fmt.Sprintf("invalid %s date, use YYYY-MM-DD", bound.name), http.StatusUnprocessableEntity)
+			return
+		}
+		*bound.dst = parsed
+	}
+	if to.Before(from) {
+		writeError(w, "to must not be before from", http.StatusUnprocessableEntity)
+		return
+	}
+	if to.Sub(from) > maxBoxScoreReconcileDays*24*time.Hour {
+		writeError(w, fmt.Sprintf("date range must be at most %d days", maxBoxScoreReconcileDays), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	run, err := s.reconcileBoxScores(ctx, from, to)
+	if err != nil {
+		log.Printf("Box score reconciliation failed: %v", err)
+		writeError(w, "Failed to reconcile box scores", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, run)
+}
+
+// getBoxScoreMismatchesHandler lists games whose last check found
+// mismatches, most recent games first, optionally for a ?season=
+func (s *Server) getBoxScoreMismatchesHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	params := parseQueryParams(r)
+	where := " WHERE bsr.mismatch_count > 0"
+	var args []interface{}
+	if params.Season != nil {
+		args = append(args, *params.Season)
+		where += fmt.Sprintf(" AND g.season = $%d", len(args))
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	var total int
+	results := []BoxScoreReconciliation{}
+	err := s.withSnapshot(ctx, func(tx pgx.Tx) error {
+		from := `
+			FROM box_score_reconciliations bsr
+			JOIN games g ON g.id = bsr.game_id
+			JOIN teams ht ON ht.id = g.home_team_id
+			JOIN teams at ON at.id = g.away_team_id`
+		if err := tx.QueryRow(ctx, "SELECT COUNT(*)"+from+where, args...).Scan(&total); err != nil {
+			return fmt.Errorf("count box score mismatches: %w", err)
+		}
+
+		rows, err := tx.Query(ctx, fmt.Sprintf(`
+			SELECT g.id::text, g.game_date::text, ht.name, at.name, bsr.mismatches, bsr.checked_at%s%s
+			ORDER BY g.game_date DESC, g.id
+			LIMIT %d OFFSET %d`, from, where, params.PageSize, calculateOffset(params.Page, params.PageSize)), args...)
+		if err != nil {
+			return fmt.Errorf("query box score mismatches: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var result BoxScoreReconciliation
+			var mismatches []byte
+			if err := rows.Scan(&result.GameID, &result.GameDate, &result.HomeTeam, &result.AwayTeam,
+				&mismatches, &result.CheckedAt); err != nil {
+				return fmt.Errorf("scan box score mismatch: %w", err)
+			}
+			if err := json.Unmarshal(mismatches, &result.Mismatches); err != nil {
+				log.Printf("Invalid mismatches for game %s: %v", result.GameID, err)
+			}
+			results = append(results, result)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		log.Printf("Failed to query box score mismatches: %v", err)
+		writeError(w, "Failed to query box score mismatches", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, buildPaginatedResponse(results, total, params))
+}
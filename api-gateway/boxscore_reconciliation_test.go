@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayTotals(t *testing.T) {
+	plays := []boxScorePlay{
+		{InningHalf: "top", EventType: "Single"},
+		{InningHalf: "top", EventType: "Home Run", RunsScored: 2},
+		{InningHalf: "top", EventType: "strikeout"},
+		{InningHalf: "bottom", EventType: "strikeout_double_play"},
+		{InningHalf: "bottom", EventType: "double"},
+		{InningHalf: "bottom", EventType: "sac_fly", RunsScored: 1},
+		{InningHalf: "bottom", EventType: "walk"},
+	}
+	home, away := playTotals(plays)
+	assert.Equal(t, boxScoreTotals{Hits: 2, Runs: 2, Strikeouts: 1}, away)
+	assert.Equal(t, boxScoreTotals{Hits: 1, Runs: 1, Strikeouts: 1}, home)
+}
+
+func TestCompareBoxScoreTotals(t *testing.T) {
+	assert.Empty(t, compareBoxScoreTotals("home", boxScoreTotals{8, 4, 9}, boxScoreTotals{8, 4, 9}))
+
+	mismatches := compareBoxScoreTotals("away", boxScoreTotals{Hits: 9, Runs: 3, Strikeouts: 7}, boxScoreTotals{Hits: 8, Runs: 3, Strikeouts: 8})
+	assert.Equal(t, []BoxScoreMismatch{
+		{Team: "away", Field: "hits", BoxScore: 9, Plays: 8, Diff: 1},
+		{Team: "away", Field: "strikeouts", BoxScore: 7, Plays: 8, Diff: -1},
+	}, mismatches)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CachePolicy is how long a CDN or browser may cache a successful response,
+// and how long after that it may keep serving it while revalidating
+type CachePolicy struct {
+	MaxAge               time.Duration
+	StaleWhileRevalidate time.Duration
+}
+
+var (
+	// referenceCachePolicy covers data that changes a few times a season:
+	// teams, stadiums and the stat glossary
+	referenceCachePolicy = CachePolicy{MaxAge: time.Hour, StaleWhileRevalidate: 24 * time.Hour}
+
+	// historicalCachePolicy covers data that no longer changes: completed
+	// seasons and final box scores. Corrections still arrive occasionally.
+	historicalCachePolicy = CachePolicy{MaxAge: 24 * time.Hour, StaleWhileRevalidate: 7 * 24 * time.Hour}
+)
+
+// String renders the policy as a Cache-Control value
+func (p CachePolicy) String() string {
+	return fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d",
+		int(p.MaxAge.Seconds()), int(p.StaleWhileRevalidate.Seconds()))
+}
+
+// setCachePolicy marks a response as publicly cacheable. Handlers call it
+// just before writing a successful response.
+func setCachePolicy(w http.ResponseWriter, policy CachePolicy) {
+	w.Header().Set("Cache-Control", policy.String())
+}
+
+// cachePolicyWriter adds Cache-Control to 2xx responses only, so errors and
+// not-found responses are never cached downstream
+type cachePolicyWriter struct {
+	http.ResponseWriter
+	policy      CachePolicy
+	wroteHeader bool
+}
+
+func (cw *cachePolicyWriter) WriteHeader(statusCode int) {
+	if !cw.wroteHeader {
+		cw.wroteHeader = true
+		if statusCode >= 200 && statusCode < 300 && cw.Header().Get("Cache-Control") == "" {
+			setCachePolicy(cw.ResponseWriter, cw.policy)
+		}
+	}
+	cw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (cw *cachePolicyWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+func (cw *cachePolicyWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// withCachePolicy registers a cache policy for a GET route's successful responses
+func withCachePolicy(policy CachePolicy, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next(w, r)
+			return
+		}
+		next(&cachePolicyWriter{ResponseWriter: w, policy: policy}, r)
+	}
+}
+
+// withSeasonCachePolicy caches a route's responses when ?season= names a
+// season before the current one. Current-season responses are left uncached.
+func withSeasonCachePolicy(next http.HandlerFunc) http.HandlerFunc {
+	cached := withCachePolicy(historicalCachePolicy, next)
+	return func(w http.ResponseWriter, r *http.Request) {
+		season, err := strconv.Atoi(r.URL.Query().Get("season"))
+		if err != nil || season >= getCurrentSeason() {
+			next(w, r)
+			return
+		}
+		cached(w, r)
+	}
+}
+
+// isCompletedGameStatus reports whether a game's stored status means it is over
+func isCompletedGameStatus(status string) bool {
+	registered, ok := gameStatusFor(status)
+	return ok && registered == GameStatusFinal
+}
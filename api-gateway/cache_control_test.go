@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachePolicyString(t *testing.T) {
+	assert.Equal(t, "public, max-age=3600, stale-while-revalidate=86400", referenceCachePolicy.String())
+	assert.Equal(t, "public, max-age=86400, stale-while-revalidate=604800", historicalCachePolicy.String())
+}
+
+func TestWithCachePolicyOnlyCachesSuccess(t *testing.T) {
+	handler := withCachePolicy(referenceCachePolicy, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("missing") != "" {
+			writeError(w, "Team not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]string{"team_id": "NYY"})
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/teams/NYY", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, referenceCachePolicy.String(), rec.Header().Get("Cache-Control"))
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/teams/NYY?missing=1", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Header().Get("Cache-Control"))
+}
+
+func TestWithCachePolicyKeepsHandlerPolicy(t *testing.T) {
+	handler := withCachePolicy(referenceCachePolicy, func(w http.ResponseWriter, r *http.Request) {
+		setCachePolicy(w, historicalCachePolicy)
+		writeJSON(w, map[string]string{})
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, historicalCachePolicy.String(), rec.Header().Get("Cache-Control"))
+}
+
+func TestWithSeasonCachePolicy(t *testing.T) {
+	handler := withSeasonCachePolicy(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{})
+	})
+	current := getCurrentSeason()
+
+	for _, tc := range []struct {
+		query  string
+		cached bool
+	}{
+		{"?season=" + strconv.Itoa(current-1), true},
+		{"?season=" + strconv.Itoa(current), false},
+		{"", false},
+		{"?season=last", false},
+	} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/standings"+tc.query, nil))
+		if tc.cached {
+			assert.Equal(t, historicalCachePolicy.String(), rec.Header().Get("Cache-Control"), tc.query)
+		} else {
+			assert.Empty(t, rec.Header().Get("Cache-Control"), tc.query)
+		}
+	}
+}
+
+func TestIsCompletedGameStatus(t *testing.T) {
+	assert.True(t, isCompletedGameStatus("Final"))
+	assert.True(t, isCompletedGameStatus("completed"))
+	assert.False(t, isCompletedGameStatus("live"))
+	assert.False(t, isCompletedGameStatus(""))
+}
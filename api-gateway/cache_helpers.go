@@ -35,7 +35,7 @@ func (s *Server) QueryRowWithCache(ctx context.Context, query string, ttl time.D
 	}
 
 	// Query database
-	return s.db.QueryRow(ctx, query, args...), false
+	return s.readDB().QueryRow(ctx, query, args...), false
 }
 
 // CachedQuery executes a query and caches the result
@@ -52,7 +52,7 @@ func (s *Server) CachedQuery(ctx context.Context, query string, ttl time.Duratio
 	}
 
 	// Query database
-	rows, err := s.db.Query(ctx, query, args...)
+	rows, err := s.readDB().Query(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, spec := range []string{"* * * * *", "*/10 * * * *", "0 9 * * 1-5", "5,35 */2 1 1,7 0", "30 3 * * 7"} {
+		_, err := parseCronSchedule(spec)
+		assert.NoError(t, err, spec)
+	}
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		_, err := parseCronSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, err := time.Parse("2006-01-02 15:04", value)
+		require.NoError(t, err)
+		return parsed
+	}
+	next := func(spec, from string) time.Time {
+		schedule, err := parseCronSchedule(spec)
+		require.NoError(t, err)
+		return schedule.Next(at(from))
+	}
+
+	assert.Equal(t, at("2026-10-15 12:01"), next("* * * * *", "2026-10-15 12:00"))
+	assert.Equal(t, at("2026-10-15 12:10"), next("*/10 * * * *", "2026-10-15 12:00"), "the current minute has already run")
+	assert.Equal(t, at("2026-10-15 13:00"), next("0 * * * *", "2026-10-15 12:00"))
+	assert.Equal(t, at("2026-10-16 09:00"), next("0 9 * * *", "2026-10-15 09:30"))
+	assert.Equal(t, at("2026-10-19 09:00"), next("0 9 * * 1-5", "2026-10-16 10:00"), "Friday after the run waits for Monday")
+	assert.Equal(t, at("2026-10-18 03:30"), next("30 3 * * 7", "2026-10-15 00:00"), "7 is Sunday")
+	assert.Equal(t, at("2027-01-01 00:00"), next("0 0 1 1 *", "2026-10-15 00:00"))
+
+	// Both day fields restricted: either matches
+	assert.Equal(t, at("2026-10-18 00:00"), next("0 0 20 * 0", "2026-10-15 00:00"))
+
+	assert.True(t, next("0 0 30 2 *", "2026-10-15 00:00").IsZero(), "February 30th never comes")
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dataAsOfHeader carries when the data behind a response was last updated
+const dataAsOfHeader = "X-Data-As-Of"
+
+// defaultFreshnessTTL is how long table update times are reused before the
+// database is asked again
+const defaultFreshnessTTL = 30 * time.Second
+
+// Tables whose update times the freshness status tracks
+const (
+	freshnessTeams      = "teams"
+	freshnessPlayers    = "players"
+	freshnessGames      = "games"
+	freshnessAggregates = "player_season_aggregates"
+)
+
+// freshnessRoutes maps API path prefixes to the tables their responses read.
+// Paths not listed report the newest of every tracked table.
+var freshnessRoutes = []struct {
+	prefix string
+	tables []string
+}{
+	{"/api/v1/players", []string{freshnessPlayers, freshnessAggregates}},
+	{"/api/v1/teams", []string{freshnessTeams, freshnessAggregates}},
+	{"/api/v1/games", []string{freshnessGames, freshnessTeams}},
+	{"/api/v1/leaders", []string{freshnessPlayers, freshnessAggregates}},
+	{"/api/v1/standings", []string{freshnessGames, freshnessTeams}},
+	{"/api/v1/rankings", []string{freshnessGames, freshnessTeams}},
+	{"/api/v1/franchises", []string{freshnessGames, freshnessTeams}},
+	{"/api/v1/search", []string{freshnessPlayers, freshnessTeams}},
+}
+
+// freshnessTables returns the tables a request's response reads, or nil for
+// every tracked table
+func freshnessTables(path string) []string {
+	for _, route := range freshnessRoutes {
+		if path == route.prefix || strings.HasPrefix(path, route.prefix+"/") {
+			return route.tables
+		}
+	}
+	return nil
+}
+
+// FreshnessStatus caches the last update time of each tracked table so every
+// response can report its data's age without querying per request. Stale
+// times are served while one goroutine reloads them, and when a reload fails
+// the last known times keep being served.
+type FreshnessStatus struct {
+	load func(ctx context.Context) (map[string]time.Time, error)
+	ttl  time.Duration
+
+	mu       sync.Mutex
+	updated  map[string]time.Time
+	loadedAt time.Time
+	loading  bool
+}
+
+// NewFreshnessStatus creates a status that reloads table times with load at
+// most once per ttl
+func NewFreshnessStatus(ttl time.Duration, load func(ctx context.Context) (map[string]time.Time, error)) *FreshnessStatus {
+	if ttl <= 0 {
+		ttl = defaultFreshnessTTL
+	}
+	return &FreshnessStatus{load: load, ttl: ttl}
+}
+
+// Tables returns the cached update time of each tracked table. Once they
+// are older than the TTL one caller starts a reload in the background and
+// everyone keeps getting the cached times; only the first load is waited for.
+func (fs *FreshnessStatus) Tables(ctx context.Context) map[string]time.Time {
+	fs.mu.Lock()
+	// Failures also wait out the TTL so an outage isn't hit per request
+	reload := !fs.loading && time.Since(fs.loadedAt) >= fs.ttl
+	if reload {
+		fs.loading = true
+		fs.loadedAt = time.Now()
+	}
+	updated := fs.updated
+	fs.mu.Unlock()
+
+	switch {
+	case !reload:
+		return updated
+	case updated == nil:
+		return fs.reload(ctx)
+	}
+	go fs.reload(ctx)
+	return updated
+}
+
+// reload loads the table times and returns the ones now cached. A caller
+// hanging up doesn't cut the reload short.
+func (fs *FreshnessStatus) reload(ctx context.Context) map[string]time.Time {
+	updated, err := fs.load(context.WithoutCancel(ctx))
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.loading = false
+	if err != nil {
+		log.Printf("Failed to load data freshness: %v", err)
+	} else {
+		fs.updated = updated
+	}
+	return fs.updated
+}
+
+// AsOf returns the newest update time among tables, or among every tracked
+// table when tables is empty. ok is false when none is known.
+func (fs *FreshnessStatus) AsOf(ctx context.Context, tables []string) (asOf time.Time, ok bool) {
+	updated := fs.Tables(ctx)
+	if len(tables) == 0 {
+		for _, t := range updated {
+			if t.After(asOf) {
+				asOf = t
+			}
+		}
+	} else {
+		for _, table := range tables {
+			if t := updated[table]; t.After(asOf) {
+				asOf = t
+			}
+		}
+	}
+	return asOf, !asOf.IsZero()
+}
+
+// loadTableFreshness reads each tracked table's most recent update
+func (s *Server) loadTableFreshness(ctx context.Context) (map[string]time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	rows, err := s.readDB().Query(ctx, `
+		SELECT 'teams', MAX(updated_at) FROM teams
+		UNION ALL
+		SELECT 'players', MAX(updated_at) FROM players
+		UNION ALL
+		SELECT 'games', MAX(updated_at) FROM games
+		UNION ALL
+		SELECT 'player_season_aggregates', MAX(last_updated) FROM player_season_aggregates
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	updated := make(map[string]time.Time)
+	for rows.Next() {
+		var table string
+		var at *time.Time
+		if err := rows.Scan(&table, &at); err != nil {
+			return nil, err
+		}
+		if at != nil {
+			updated[table] = at.UTC()
+		}
+	}
+	return updated, rows.Err()
+}
+
+type dataAsOfKey struct{}
+
+// dataAsOfFor returns the data time the freshness middleware found for the
+// request, if any
+func dataAsOfFor(r *http.Request) *time.Time {
+	if asOf, ok := r.Context().Value(dataAsOfKey{}).(time.Time); ok {
+		return &asOf
+	}
+	return nil
+}
+
+// dataFreshnessMiddleware sets X-Data-As-Of to the last update of the tables
+// behind the route and passes the time on for paginated responses
+func (s *Server) dataFreshnessMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.freshness == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		asOf, ok := s.freshness.AsOf(r.Context(), freshnessTables(r.URL.Path))
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set(dataAsOfHeader, asOf.Format(time.RFC3339))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), dataAsOfKey{}, asOf)))
+	})
+}
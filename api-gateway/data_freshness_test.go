@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	freshnessTeamsAt   = time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	freshnessPlayersAt = time.Date(2026, 4, 2, 8, 30, 0, 0, time.UTC)
+	freshnessGamesAt   = time.Date(2026, 4, 3, 23, 5, 0, 0, time.UTC)
+)
+
+func testFreshnessTables() map[string]time.Time {
+	return map[string]time.Time{
+		freshnessTeams:   freshnessTeamsAt,
+		freshnessPlayers: freshnessPlayersAt,
+		freshnessGames:   freshnessGamesAt,
+	}
+}
+
+// TestFreshnessStatusCaches tests table times are reloaded once per TTL and
+// the last known times survive a failed reload
+func TestFreshnessStatusCaches(t *testing.T) {
+	var loads atomic.Int32
+	var loadErr error
+	fs := NewFreshnessStatus(time.Minute, func(ctx context.Context) (map[string]time.Time, error) {
+		loads.Add(1)
+		return testFreshnessTables(), loadErr
+	})
+
+	asOf, ok := fs.AsOf(context.Background(), nil)
+	require.True(t, ok)
+	assert.Equal(t, freshnessGamesAt, asOf, "no tables means the newest of all")
+	fs.AsOf(context.Background(), nil)
+	assert.EqualValues(t, 1, loads.Load())
+
+	asOf, ok = fs.AsOf(context.Background(), []string{freshnessPlayers, freshnessAggregates})
+	require.True(t, ok)
+	assert.Equal(t, freshnessPlayersAt, asOf, "untracked times are skipped")
+
+	_, ok = fs.AsOf(context.Background(), []string{freshnessAggregates})
+	assert.False(t, ok)
+
+	fs.loadedAt = time.Now().Add(-2 * time.Minute)
+	loadErr = errors.New("connection refused")
+	asOf, ok = fs.AsOf(context.Background(), nil)
+	assert.True(t, ok)
+	assert.Equal(t, freshnessGamesAt, asOf)
+	require.Eventually(t, func() bool { return !fs.isLoading() }, time.Second, time.Millisecond)
+	assert.EqualValues(t, 2, loads.Load())
+	asOf, ok = fs.AsOf(context.Background(), nil)
+	assert.True(t, ok)
+	assert.Equal(t, freshnessGamesAt, asOf)
+}
+
+// TestFreshnessStatusReloadsInBackground tests a slow reload doesn't hold
+// up requests, which get the cached times meanwhile
+func TestFreshnessStatusReloadsInBackground(t *testing.T) {
+	var loads atomic.Int32
+	release := make(chan struct{})
+	fs := NewFreshnessStatus(time.Minute, func(ctx context.Context) (map[string]time.Time, error) {
+		if loads.Add(1) > 1 {
+			<-release
+			return map[string]time.Time{freshnessGames: freshnessGamesAt.Add(time.Hour)}, nil
+		}
+		return testFreshnessTables(), nil
+	})
+	fs.AsOf(context.Background(), nil)
+
+	fs.mu.Lock()
+	fs.loadedAt = time.Now().Add(-2 * time.Minute)
+	fs.mu.Unlock()
+	for i := 0; i < 3; i++ {
+		asOf, ok := fs.AsOf(context.Background(), nil)
+		assert.True(t, ok)
+		assert.Equal(t, freshnessGamesAt, asOf, "the cached time is served while reloading")
+	}
+	require.Eventually(t, func() bool { return loads.Load() == 2 }, time.Second, time.Millisecond)
+	fs.AsOf(context.Background(), nil)
+	assert.EqualValues(t, 2, loads.Load(), "one reload at a time")
+
+	close(release)
+	require.Eventually(t, func() bool { return !fs.isLoading() }, time.Second, time.Millisecond)
+	asOf, _ := fs.AsOf(context.Background(), nil)
+	assert.Equal(t, freshnessGamesAt.Add(time.Hour), asOf)
+}
+
+func (fs *FreshnessStatus) isLoading() bool {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.loading
+}
+
+// TestFreshnessTables tests routes map to the tables they read
+func TestFreshnessTables(t *testing.T) {
+	assert.Equal(t, []string{freshnessPlayers, freshnessAggregates}, freshnessTables("/api/v1/players/660271/stats"))
+	assert.Equal(t, []string{freshnessGames, freshnessTeams}, freshnessTables("/api/v1/games"))
+	assert.Nil(t, freshnessTables("/api/v1/gamesx"))
+	assert.Nil(t, freshnessTables("/api/v1/simulations/abc"))
+}
+
+// TestDataFreshnessMiddleware tests the header is set and paginated
+// responses carry the same time
+func TestDataFreshnessMiddleware(t *testing.T) {
+	s := &Server{freshness: NewFreshnessStatus(time.Minute, func(ctx context.Context) (map[string]time.Time, error) {
+		return testFreshnessTables(), nil
+	})}
+	handler := s.dataFreshnessMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := parseQueryParams(r)
+		writeJSON(w, buildPaginatedResponse([]string{"a"}, 1, params))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/teams", nil))
+
+	assert.Equal(t, "2026-04-01T12:00:00Z", rec.Header().Get(dataAsOfHeader))
+	var body PaginatedResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.NotNil(t, body.DataAsOf)
+	assert.True(t, freshnessTeamsAt.Equal(*body.DataAsOf))
+}
+
+// TestDataFreshnessMiddlewareUnknown tests nothing is reported before any
+// table time is known
+func TestDataFreshnessMiddlewareUnknown(t *testing.T) {
+	s := &Server{freshness: NewFreshnessStatus(time.Minute, func(ctx context.Context) (map[string]time.Time, error) {
+		return nil, errors.New("connection refused")
+	})}
+	handler := s.dataFreshnessMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, buildPaginatedResponse([]string{}, 0, parseQueryParams(r)))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/players", nil))
+
+	assert.Empty(t, rec.Header().Get(dataAsOfHeader))
+	assert.NotContains(t, rec.Body.String(), "data_as_of")
+}
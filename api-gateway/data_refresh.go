@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// RefreshStageProgress is one stage of a data refresh job
+type RefreshStageProgress struct {
+	Name         string `json:"name"`
+	Total        *int   `json:"total"` // nil until the stage knows how much it will fetch
+	Fetched      int    `json:"fetched"`
+	RowsUpserted int    `json:"rows_upserted"`
+	Errors       int    `json:"errors"`
+	Completed    bool   `json:"completed"`
+}
+
+// RefreshProgress is the data fetcher's latest progress snapshot for a job
+type RefreshProgress struct {
+	CurrentStage    *string                `json:"current_stage"`
+	PercentComplete float64                `json:"percent_complete"`
+	ETASeconds      *float64               `json:"eta_seconds"`
+	ElapsedSeconds  float64                `json:"elapsed_seconds"`
+	EntitiesFetched int                    `json:"entities_fetched"`
+	RowsUpserted    int                    `json:"rows_upserted"`
+	Errors          int                    `json:"errors"`
+	RecentErrors    []string               `json:"recent_errors"`
+	Stages          []RefreshStageProgress `json:"stages"`
+}
+
+// RefreshJob is a data refresh job and its progress
+type RefreshJob struct {
+	JobID        int              `json:"job_id"`
+	FetchType    *string          `json:"fetch_type"`
+	Status       *string          `json:"status"`
+	StartedAt    *time.Time       `json:"started_at"`
+	CompletedAt  *time.Time       `json:"completed_at"`
+	ErrorMessage *string          `json:"error_message"`
+	Progress     *RefreshProgress `json:"progress"`
+}
+
+// getRefreshJobHandler returns a data refresh job's progress so clients can
+// show how far along it is
+func (s *Server) getRefreshJobHandler(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["jobId"]
+	if id, err := strconv.Atoi(jobID); err != nil || id < 1 {
+		writeError(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.dataFetcherClient.Get(r.Context(), s.config.DataFetcherURL+"/fetch/"+jobID)
+	if err != nil {
+		writeError(w, "Failed to communicate with data fetcher", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		writeError(w, "Refresh job not found", http.StatusNotFound)
+		return
+	}
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var job RefreshJob
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		writeError(w, "Failed to parse data fetcher response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, job)
+}
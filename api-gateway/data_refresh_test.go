@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRefreshJobHandler(t *testing.T) {
+	fetcher := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fetch/7" {
+			http.Error(w, `{"detail":"Fetch job not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"job_id": 7, "fetch_type": "all", "status": "running",
+			"started_at": "2026-04-01T12:00:00+00:00", "completed_at": null, "error_message": null,
+			"progress": {"current_stage": "games", "percent_complete": 41.7, "eta_seconds": 84.0,
+				"elapsed_seconds": 60.0, "entities_fetched": 75, "rows_upserted": 1200, "errors": 2,
+				"recent_errors": ["Game 1: timeout", "Game 2: timeout"],
+				"stages": [{"name": "teams", "total": 30, "fetched": 30, "rows_upserted": 62, "errors": 0, "completed": true}]}}`))
+	}))
+	defer fetcher.Close()
+
+	s := &Server{
+		config:            &Config{DataFetcherURL: fetcher.URL},
+		dataFetcherClient: NewUpstreamClient("data-fetcher", 2),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/data/refresh/{jobId}", s.getRefreshJobHandler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/data/refresh/7", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var job RefreshJob
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, 7, job.JobID)
+	if assert.NotNil(t, job.Progress) {
+		assert.Equal(t, 41.7, job.Progress.PercentComplete)
+		assert.Equal(t, 84.0, *job.Progress.ETASeconds)
+		assert.Equal(t, 2, job.Progress.Errors)
+		assert.Len(t, job.Progress.Stages, 1)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/data/refresh/8", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/data/refresh/latest", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replicaHealthInterval is how often the pools are pinged to decide routing
+const replicaHealthInterval = 15 * time.Second
+
+// DBRouter routes read-only queries to an optional replica pool and falls
+// back to the primary whenever the replica fails its health check. It also
+// tracks whether the primary is reachable so the gateway can degrade to
+// stale responses during an outage.
+type DBRouter struct {
+	primary        *pgxpool.Pool
+	replica        *pgxpool.Pool
+	replicaHealthy atomic.Bool
+	primaryDown    atomic.Bool
+	stopCh         chan struct{}
+}
+
+// NewDBRouter creates a router; replica may be nil when no replica is configured
+func NewDBRouter(primary, replica *pgxpool.Pool) *DBRouter {
+	router := &DBRouter{
+		primary: primary,
+		replica: replica,
+		stopCh:  make(chan struct{}),
+	}
+
+	if replica != nil {
+		router.checkReplica()
+	}
+	go router.monitorReplica()
+
+	return router
+}
+
+// Reader returns the pool to use for read-only queries
+func (dr *DBRouter) Reader() *pgxpool.Pool {
+	if dr.replica != nil && dr.replicaHealthy.Load() {
+		return dr.replica
+	}
+	return dr.primary
+}
+
+// Writer returns the primary pool for writes and read-your-writes queries
+func (dr *DBRouter) Writer() *pgxpool.Pool {
+	return dr.primary
+}
+
+// HasReplica reports whether a replica is configured
+func (dr *DBRouter) HasReplica() bool {
+	return dr.replica != nil
+}
+
+// ReplicaHealthy reports whether reads are currently routed to the replica
+func (dr *DBRouter) ReplicaHealthy() bool {
+	return dr.replica != nil && dr.replicaHealthy.Load()
+}
+
+// PrimaryHealthy reports whether the primary answered its last health check
+func (dr *DBRouter) PrimaryHealthy() bool {
+	return !dr.primaryDown.Load()
+}
+
+// checkPrimary pings the primary and records outages and recoveries
+func (dr *DBRouter) checkPrimary() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	healthy := dr.primary.Ping(ctx) == nil
+	wasDown := dr.primaryDown.Swap(!healthy)
+
+	if healthy == wasDown {
+		if healthy {
+			appLogger.Info("Primary database reachable again, leaving degraded mode", nil)
+		} else {
+			appLogger.Warn("Primary database unreachable, serving stale responses", nil)
+		}
+	}
+	return healthy
+}
+
+// checkReplica pings the replica and updates routing on state changes
+func (dr *DBRouter) checkReplica() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	healthy := dr.replica.Ping(ctx) == nil
+	previous := dr.replicaHealthy.Swap(healthy)
+
+	if healthy != previous {
+		if healthy {
+			appLogger.Info("Read replica healthy, routing reads to replica", nil)
+		} else {
+			appLogger.Warn("Read replica unhealthy, failing reads back to primary", nil)
+		}
+	}
+}
+
+func (dr *DBRouter) monitorReplica() {
+	ticker := time.NewTicker(replicaHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			dr.checkPrimary()
+			if dr.replica != nil {
+				dr.checkReplica()
+			}
+		case <-dr.stopCh:
+			return
+		}
+	}
+}
+
+// Close stops health monitoring and closes both pools
+func (dr *DBRouter) Close() {
+	close(dr.stopCh)
+	if dr.replica != nil {
+		dr.replica.Close()
+	}
+	dr.primary.Close()
+}
+
+// newDBPool creates a connection pool for the given database host and port
+func newDBPool(config *Config, host, port string) (*pgxpool.Pool, error) {
+	dbURL := fmt.Sprintf("postgresql://%s:%s@%s:%s/%s",
+		config.DBUser, config.DBPassword, host, port, config.DBName)
+
+	dbConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse db config: %w", err)
+	}
+
+	// Optimized connection pool settings
+	dbConfig.MaxConns = 20                                // Reduced from 25 to prevent pool exhaustion
+	dbConfig.MinConns = 3                                 // Reduced from 5 for lower idle footprint
+	dbConfig.MaxConnLifetime = time.Minute * 30           // Reduced from 1h for faster connection refresh
+	dbConfig.MaxConnIdleTime = time.Minute * 10           // Reduced from 30min to close idle connections faster
+	dbConfig.HealthCheckPeriod = time.Minute              // Check connection health every minute
+	dbConfig.ConnConfig.ConnectTimeout = time.Second * 10 // 10s connection timeout
+
+	db, err := pgxpool.NewWithConfig(context.Background(), dbConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return db, nil
+}
+
+// readDB returns the pool read-only handlers should query
+func (s *Server) readDB() *pgxpool.Pool {
+	return s.dbRouter.Reader()
+}
+
+// withSnapshot runs fn in a read-only REPEATABLE READ transaction on the read
+// pool so a list endpoint's COUNT and page queries see the same data even
+// while a refresh is writing
+func (s *Server) withSnapshot(ctx context.Context, fn func(pgx.Tx) error) error {
+	tx, err := s.readDB().BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return fmt.Errorf("start read transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	return fn(tx)
+}
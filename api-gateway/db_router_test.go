@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDBRouterWithoutReplica tests that reads go to the primary when no replica is configured
+func TestDBRouterWithoutReplica(t *testing.T) {
+	primary := &pgxpool.Pool{}
+	router := &DBRouter{primary: primary}
+
+	assert.Same(t, primary, router.Reader())
+	assert.Same(t, primary, router.Writer())
+	assert.False(t, router.HasReplica())
+	assert.False(t, router.ReplicaHealthy())
+}
+
+// TestDBRouterReplicaFailback tests routing follows replica health
+func TestDBRouterReplicaFailback(t *testing.T) {
+	primary := &pgxpool.Pool{}
+	replica := &pgxpool.Pool{}
+	router := &DBRouter{primary: primary, replica: replica}
+
+	// Unhealthy until the first successful health check
+	assert.Same(t, primary, router.Reader())
+
+	router.replicaHealthy.Store(true)
+	assert.Same(t, replica, router.Reader())
+	assert.Same(t, primary, router.Writer())
+
+	router.replicaHealthy.Store(false)
+	assert.Same(t, primary, router.Reader())
+}
@@ -0,0 +1,484 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// defaultStaleCacheMaxAge is how long a successful GET response may be
+	// served while the database is unreachable
+	defaultStaleCacheMaxAge = 6 * 60 * 60
+
+	// defaultStaleCacheEntries bounds the number of stored responses
+	defaultStaleCacheEntries = 2000
+
+	// defaultSimulationQueueSize bounds simulations waiting for the database
+	defaultSimulationQueueSize = 100
+
+	// simulationQueueInterval is how often queued simulations are retried
+	simulationQueueInterval = 10 * time.Second
+
+	// simulationQueueRetention keeps finished queue entries around for status lookups
+	simulationQueueRetention = time.Hour
+)
+
+// staleCacheBypass lists GET endpoints that must always report live state
+var staleCacheBypass = map[string]bool{
+	"/api/v1/health":  true,
+	"/api/v1/metrics": true,
+	"/api/v1/status":  true,
+}
+
+// queuedSimulationPath prefixes status lookups for queued simulations, which
+// are served from memory and never need a stale copy
+const queuedSimulationPath = "/api/v1/simulations/queued/"
+
+// exportsPath prefixes export progress and downloads, which change as the job
+// runs and can be far too large to hold in memory
+const exportsPath = "/api/v1/exports/"
+
+// staleResponse is a stored copy of a successful GET response
+type staleResponse struct {
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+}
+
+// StaleCache keeps the last successful response for each cacheable GET so it
+// can be replayed when the database is down. Unlike QueryCache entries, these
+// are never served while the database is healthy.
+type StaleCache struct {
+	entries    map[string]*staleResponse
+	maxAge     time.Duration
+	maxEntries int
+	mu         sync.RWMutex
+}
+
+// NewStaleCache creates a cache holding up to maxEntries responses for maxAge
+func NewStaleCache(maxAge time.Duration, maxEntries int) *StaleCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultStaleCacheEntries
+	}
+	return &StaleCache{
+		entries:    make(map[string]*staleResponse),
+		maxAge:     maxAge,
+		maxEntries: maxEntries,
+	}
+}
+
+// Get returns the stored response for key if it is younger than maxAge
+func (sc *StaleCache) Get(key string) (*staleResponse, bool) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	entry, ok := sc.entries[key]
+	if !ok || time.Since(entry.storedAt) > sc.maxAge {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Set stores a response, evicting the oldest entry when the cache is full
+func (sc *StaleCache) Set(key string, header http.Header, body []byte) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if _, exists := sc.entries[key]; !exists && len(sc.entries) >= sc.maxEntries {
+		var oldestKey string
+		var oldest time.Time
+		for k, entry := range sc.entries {
+			if oldestKey == "" || entry.storedAt.Before(oldest) {
+				oldestKey, oldest = k, entry.storedAt
+			}
+		}
+		delete(sc.entries, oldestKey)
+	}
+
+	sc.entries[key] = &staleResponse{
+		header:   header,
+		body:     body,
+		storedAt: time.Now(),
+	}
+}
+
+// Len returns the number of stored responses
+func (sc *StaleCache) Len() int {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return len(sc.entries)
+}
+
+// isStaleCacheable reports whether a request's response may be stored and replayed
+func isStaleCacheable(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		strings.HasPrefix(r.URL.Path, "/api/v1/") &&
+		!staleCacheBypass[r.URL.Path] &&
+		!strings.HasPrefix(r.URL.Path, queuedSimulationPath) &&
+		!strings.HasPrefix(r.URL.Path, exportsPath) &&
+		!isNotesPath(r.URL.Path)
+}
+
+// isNotesPath reports whether a path lists notes. Listings mark the caller's
+// own notes, so a stored copy can't be replayed to other keys.
+func isNotesPath(path string) bool {
+	return path == "/api/v1/notes" || strings.HasSuffix(path, "/notes")
+}
+
+// staleCacheKey identifies a response by path and query string
+func staleCacheKey(r *http.Request) string {
+	if r.URL.RawQuery == "" {
+		return r.URL.Path
+	}
+	return r.URL.Path + "?" + r.URL.RawQuery
+}
+
+// bufferedResponseWriter holds a handler's response so it can be stored or
+// replaced with a stale copy before anything reaches the client
+type bufferedResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{header: make(http.Header), statusCode: http.StatusOK}
+}
+
+func (bw *bufferedResponseWriter) Header() http.Header {
+	return bw.header
+}
+
+func (bw *bufferedResponseWriter) WriteHeader(code int) {
+	bw.statusCode = code
+}
+
+func (bw *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return bw.body.Write(b)
+}
+
+// writeTo copies the buffered response to the real writer
+func (bw *bufferedResponseWriter) writeTo(w http.ResponseWriter) {
+	for key, values := range bw.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(bw.statusCode)
+	w.Write(bw.body.Bytes())
+}
+
+// writeStaleResponse replays a stored response with staleness headers
+func writeStaleResponse(w http.ResponseWriter, entry *staleResponse) {
+	for key, values := range entry.header {
+		w.Header()[key] = values
+	}
+	age := int(time.Since(entry.storedAt).Seconds())
+	w.Header().Set("Age", strconv.Itoa(age))
+	w.Header().Set("Warning", `110 - "Response is Stale"`)
+	w.Header().Set("X-Cache-Status", "STALE")
+	w.Header().Set("X-Stale-Since", entry.storedAt.UTC().Format(time.RFC3339))
+	w.WriteHeader(http.StatusOK)
+	w.Write(entry.body)
+}
+
+// staleCacheMiddleware records successful GET responses and replays them,
+// marked stale, while the primary database is unreachable. A server error
+// triggers an immediate health check so the first failed request after an
+// outage begins is also served from cache.
+func (s *Server) staleCacheMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.staleCache == nil || !isStaleCacheable(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := staleCacheKey(r)
+		if !s.dbRouter.PrimaryHealthy() {
+			entry, ok := s.staleCache.Get(key)
+			appMetrics.RecordCache("stale", ok)
+			if ok {
+				writeStaleResponse(w, entry)
+				return
+			}
+		}
+
+		bw := newBufferedResponseWriter()
+		next.ServeHTTP(bw, r)
+
+		switch {
+		case bw.statusCode == http.StatusOK:
+			s.staleCache.Set(key, bw.header.Clone(), append([]byte(nil), bw.body.Bytes()...))
+		case bw.statusCode >= 500:
+			if entry, ok := s.staleCache.Get(key); ok && !s.dbRouter.checkPrimary() {
+				appMetrics.RecordCache("stale", true)
+				writeStaleResponse(w, entry)
+				return
+			}
+		}
+
+		bw.writeTo(w)
+	})
+}
+
+// QueuedSimulation is a simulation request accepted while the database was down
+type QueuedSimulation struct {
+	QueueID     string     `json:"queue_id"`
+	Status      string     `json:"status"` // queued, submitted, failed
+	GameID      string     `json:"game_id"`
+	RunID       string     `json:"run_id,omitempty"`
+	RunURL      string     `json:"run_url,omitempty"`
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	QueuedAt    time.Time  `json:"queued_at"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+
+	request SimulationRequest
+	tier    APITier
+}
+
+// SimulationQueue holds simulation requests until the database recovers and
+// then forwards them to the sim-engine in arrival order. Entries live in
+// memory only, so a gateway restart drops anything still queued.
+type SimulationQueue struct {
+	entries map[string]*QueuedSimulation
+	order   []string
+	maxSize int
+	mu      sync.Mutex
+}
+
+// NewSimulationQueue creates a queue holding up to maxSize pending simulations
+func NewSimulationQueue(maxSize int) *SimulationQueue {
+	if maxSize <= 0 {
+		maxSize = defaultSimulationQueueSize
+	}
+	return &SimulationQueue{
+		entries: make(map[string]*QueuedSimulation),
+		maxSize: maxSize,
+	}
+}
+
+// Enqueue adds a request, returning false when the queue is full
+func (sq *SimulationQueue) Enqueue(req SimulationRequest, tier APITier) (*QueuedSimulation, bool) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+
+	if len(sq.order) >= sq.maxSize {
+		return nil, false
+	}
+
+	entry := &QueuedSimulation{
+		QueueID:  newQueueID(),
+		Status:   "queued",
+		GameID:   req.GameID,
+		QueuedAt: time.Now().UTC(),
+		request:  req,
+		tier:     tier,
+	}
+	sq.entries[entry.QueueID] = entry
+	sq.order = append(sq.order, entry.QueueID)
+	return entry, true
+}
+
+// Get returns a copy of a queue entry
+func (sq *SimulationQueue) Get(queueID string) (QueuedSimulation, bool) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+
+	entry, ok := sq.entries[queueID]
+	if !ok {
+		return QueuedSimulation{}, false
+	}
+	return *entry, true
+}
+
+// Pending returns the number of simulations still waiting to be submitted
+func (sq *SimulationQueue) Pending() int {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	return len(sq.order)
+}
+
+// next returns the oldest pending entry without removing it
+func (sq *SimulationQueue) next() (*QueuedSimulation, bool) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+
+	if len(sq.order) == 0 {
+		return nil, false
+	}
+	return sq.entries[sq.order[0]], true
+}
+
+// finish records the outcome of submitting the oldest pending entry
+func (sq *SimulationQueue) finish(entry *QueuedSimulation, runID string, err error) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+
+	entry.Attempts++
+	if err != nil {
+		entry.LastError = err.Error()
+		return
+	}
+
+	now := time.Now().UTC()
+	entry.Status = "submitted"
+	entry.RunID = runID
+	entry.RunURL = "/api/v1/simulations/" + runID
+	entry.LastError = ""
+	entry.SubmittedAt = &now
+	sq.order = sq.order[1:]
+}
+
+// reject marks the oldest pending entry as permanently failed
+func (sq *SimulationQueue) reject(entry *QueuedSimulation, reason string) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+
+	now := time.Now().UTC()
+	entry.Attempts++
+	entry.Status = "failed"
+	entry.LastError = reason
+	entry.SubmittedAt = &now
+	sq.order = sq.order[1:]
+}
+
+// prune drops finished entries older than the retention window
+func (sq *SimulationQueue) prune() {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+
+	for id, entry := range sq.entries {
+		if entry.SubmittedAt != nil && time.Since(*entry.SubmittedAt) > simulationQueueRetention {
+			delete(sq.entries, id)
+		}
+	}
+}
+
+// newQueueID returns a random identifier for a queued simulation
+func newQueueID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
+// processSimulationQueue submits queued simulations once the database is back
+func (s *Server) processSimulationQueue(ctx context.Context) {
+	ticker := time.NewTicker(simulationQueueInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.simulationQueue.prune()
+		if !s.dbRouter.PrimaryHealthy() {
+			continue
+		}
+
+		for {
+			entry, ok := s.simulationQueue.next()
+			if !ok {
+				break
+			}
+			if !s.submitQueuedSimulation(ctx, entry) {
+				break // retry on the next tick
+			}
+		}
+	}
+}
+
+// submitQueuedSimulation forwards one queued request, returning false when it
+// should be retried later
+func (s *Server) submitQueuedSimulation(ctx context.Context, entry *QueuedSimulation) bool {
+	reqBody, _ := json.Marshal(entry.request)
+
+	submitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.postToSimEngine(submitCtx, "/simulate", bytes.NewReader(reqBody), entry.tier)
+	if err != nil {
+		s.simulationQueue.finish(entry, "", err)
+		return false
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	switch {
+	case resp.StatusCode >= 500, resp.StatusCode == http.StatusTooManyRequests:
+		s.simulationQueue.finish(entry, "", fmt.Errorf("sim-engine returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body))))
+		return false
+	case resp.StatusCode >= 400:
+		// The engine rejected the request itself; retrying won't help
+		s.simulationQueue.reject(entry, strings.TrimSpace(string(body)))
+		log.Printf("Queued simulation %s rejected: %s", entry.QueueID, strings.TrimSpace(string(body)))
+		return true
+	}
+
+	var result struct {
+		RunID string `json:"run_id"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		s.simulationQueue.reject(entry, "invalid sim-engine response")
+		return true
+	}
+
+	s.simulationQueue.finish(entry, result.RunID, nil)
+	log.Printf("Queued simulation %s submitted as run %s", entry.QueueID, result.RunID)
+	return true
+}
+
+// queueSimulation accepts a simulation request while the database is down
+func (s *Server) queueSimulation(w http.ResponseWriter, req SimulationRequest, tier APITier) {
+	entry, ok := s.simulationQueue.Enqueue(req, tier)
+	if !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(simulationQueueInterval.Seconds())))
+		writeErrorWithDetails(w, "Database unavailable and simulation queue is full", "simulation_queue_full",
+			map[string]interface{}{"queue_size": s.simulationQueue.maxSize}, http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Location", queuedSimulationPath+entry.QueueID)
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, map[string]interface{}{
+		"queue_id":   entry.QueueID,
+		"status":     entry.Status,
+		"game_id":    entry.GameID,
+		"queued_at":  entry.QueuedAt,
+		"status_url": queuedSimulationPath + entry.QueueID,
+		"message":    "Database unavailable; the simulation will start once it recovers",
+	})
+}
+
+// getQueuedSimulationHandler reports the state of a simulation queued during an outage
+func (s *Server) getQueuedSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	queueID := mux.Vars(r)["id"]
+	if queueID == "" {
+		writeError(w, "Queue ID is required", http.StatusBadRequest)
+		return
+	}
+
+	entry, ok := s.simulationQueue.Get(queueID)
+	if !ok {
+		writeError(w, "Queued simulation not found", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, entry)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStaleCacheEviction tests that the oldest response is evicted when full
+func TestStaleCacheEviction(t *testing.T) {
+	cache := NewStaleCache(time.Hour, 2)
+	cache.Set("/a", http.Header{}, []byte("a"))
+	cache.entries["/a"].storedAt = time.Now().Add(-time.Minute)
+	cache.Set("/b", http.Header{}, []byte("b"))
+	cache.Set("/c", http.Header{}, []byte("c"))
+
+	_, ok := cache.Get("/a")
+	assert.False(t, ok)
+	assert.Equal(t, 2, cache.Len())
+
+	cache.entries["/b"].storedAt = time.Now().Add(-2 * time.Hour)
+	_, ok = cache.Get("/b")
+	assert.False(t, ok, "entries older than max age are not served")
+}
+
+// TestStaleCacheMiddleware tests responses are stored while healthy and
+// replayed with staleness headers once the primary is down
+func TestStaleCacheMiddleware(t *testing.T) {
+	s := &Server{
+		dbRouter:   &DBRouter{},
+		staleCache: NewStaleCache(time.Hour, 10),
+	}
+
+	calls := 0
+	handler := s.staleCacheMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(w, map[string]string{"team": "NYY"})
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/teams/NYY", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Warning"))
+
+	s.dbRouter.primaryDown.Store(true)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/teams/NYY", nil))
+
+	assert.Equal(t, 1, calls, "stale hit should not reach the handler")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `110 - "Response is Stale"`, w.Header().Get("Warning"))
+	assert.Equal(t, "STALE", w.Header().Get("X-Cache-Status"))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"team":"NYY"}`, w.Body.String())
+
+	// Uncached paths still go to the handler
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/teams/BOS", nil))
+	assert.Equal(t, 2, calls)
+
+	// Health must always report live state
+	assert.False(t, isStaleCacheable(httptest.NewRequest("GET", "/api/v1/health", nil)))
+	assert.False(t, isStaleCacheable(httptest.NewRequest("POST", "/api/v1/simulations", nil)))
+}
+
+// TestSimulationQueue tests queue capacity and submission bookkeeping
+func TestSimulationQueue(t *testing.T) {
+	queue := NewSimulationQueue(2)
+
+	first, ok := queue.Enqueue(SimulationRequest{GameID: "g1"}, APITier{})
+	assert.True(t, ok)
+	_, ok = queue.Enqueue(SimulationRequest{GameID: "g2"}, APITier{})
+	assert.True(t, ok)
+	_, ok = queue.Enqueue(SimulationRequest{GameID: "g3"}, APITier{})
+	assert.False(t, ok, "queue should be full")
+
+	next, ok := queue.next()
+	assert.True(t, ok)
+	assert.Equal(t, first.QueueID, next.QueueID)
+
+	queue.finish(next, "run-1", nil)
+	assert.Equal(t, 1, queue.Pending())
+
+	entry, ok := queue.Get(first.QueueID)
+	assert.True(t, ok)
+	assert.Equal(t, "submitted", entry.Status)
+	assert.Equal(t, "/api/v1/simulations/run-1", entry.RunURL)
+	assert.Equal(t, 1, entry.Attempts)
+
+	second, _ := queue.next()
+	queue.reject(second, "game not found")
+	assert.Equal(t, 0, queue.Pending())
+	entry, _ = queue.Get(second.QueueID)
+	assert.Equal(t, "failed", entry.Status)
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+)
+
+// hardHitExitVelocity is the Statcast hard-hit threshold in mph
+const hardHitExitVelocity = 95.0
+
+// battedBall is one recorded ball in play
+type battedBall struct {
+	ExitVelocity float64
+	LaunchAngle  float64
+}
+
+// expectedOutcome holds the hit probabilities for a batted ball
+type expectedOutcome struct {
+	Single, Double, Triple, HomeRun float64
+}
+
+func (eo expectedOutcome) hitProbability() float64 {
+	return eo.Single + eo.Double + eo.Triple + eo.HomeRun
+}
+
+func (eo expectedOutcome) wobaValue() float64 {
+	return eo.Single*wobaWeight1B + eo.Double*wobaWeight2B + eo.Triple*wobaWeight3B + eo.HomeRun*wobaWeightHR
+}
+
+func (eo expectedOutcome) totalBases() float64 {
+	return eo.Single + 2*eo.Double + 3*eo.Triple + 4*eo.HomeRun
+}
+
+// classifyLaunchAngle maps a launch angle to a batted ball type
+func classifyLaunchAngle(launchAngle float64) string {
+	switch {
+	case launchAngle < 10:
+		return "ground_ball"
+	case launchAngle < 25:
+		return "line_drive"
+	case launchAngle < 50:
+		return "fly_ball"
+	default:
+		return "popup"
+	}
+}
+
+// expectedOutcomeFor estimates hit probabilities from exit velocity and
+// launch angle. It mirrors the sim-engine's contact model so simulated and
+// real expected stats are on the same scale.
+func expectedOutcomeFor(exitVelocity, launchAngle float64) expectedOutcome {
+	clamp := func(v, lo, hi float64) float64 { return math.Max(lo, math.Min(hi, v)) }
+
+	switch classifyLaunchAngle(launchAngle) {
+	case "ground_ball":
+		hit := clamp(0.24+(exitVelocity-88)*0.009, 0.08, 0.55)
+		return expectedOutcome{Single: hit * 0.94, Double: hit * 0.06}
+
+	case "line_drive":
+		hit := clamp(0.66+(exitVelocity-90)*0.012, 0.35, 0.92)
+		hr := 0.0
+		if launchAngle >= 20 && exitVelocity >= 100 {
+			hr = clamp((exitVelocity-100)*0.04, 0, 0.35)
+		}
+		nonHR := math.Max(0, hit-hr)
+		extraBase := clamp(0.15+(exitVelocity-90)*0.015, 0.05, 0.45)
+		return expectedOutcome{
+			Single:  nonHR * (1 - extraBase),
+			Double:  nonHR * extraBase * 0.93,
+			Triple:  nonHR * extraBase * 0.07,
+			HomeRun: hr,
+		}
+
+	case "fly_ball":
+		angleFit := math.Max(0, 1-math.Abs(launchAngle-28)/22)
+		hr := angleFit / (1 + math.Exp(-(exitVelocity-100)*0.45))
+		other := clamp(0.12+(exitVelocity-90)*0.006, 0.03, 0.25) * (1 - hr)
+		return expectedOutcome{
+			Single:  other * 0.4,
+			Double:  other * 0.5,
+			Triple:  other * 0.1,
+			HomeRun: hr,
+		}
+
+	default:
+		return expectedOutcome{Single: 0.02}
+	}
+}
+
+// seasonBattingLine holds the counting stats needed to compare actual and expected rates
+type seasonBattingLine struct {
+	AB, H, Doubles, Triples, HR, BB, HBP, SF, SO int
+}
+
+// parseSeasonBattingLine reads MLB Stats API counting keys from aggregated stats
+func parseSeasonBattingLine(stats map[string]interface{}) seasonBattingLine {
+	get := func(key string) int {
+		v, _ := toFloat(stats[key])
+		return int(v)
+	}
+	return seasonBattingLine{
+		AB:      get("atBats"),
+		H:       get("hits"),
+		Doubles: get("doubles"),
+		Triples: get("triples"),
+		HR:      get("homeRuns"),
+		BB:      get("baseOnBalls"),
+		HBP:     get("hitByPitch"),
+		SF:      get("sacFlies"),
+		SO:      get("strikeOuts"),
+	}
+}
+
+// PA counts plate appearances the way the data fetcher's wOBA does
+func (l seasonBattingLine) PA() int {
+	return l.AB + l.BB + l.HBP + l.SF
+}
+
+// BattedBallProfile summarizes a hitter's recorded contact
+type BattedBallProfile struct {
+	BattedBalls     int     `json:"batted_balls"`
+	AvgExitVelocity float64 `json:"avg_exit_velocity"`
+	AvgLaunchAngle  float64 `json:"avg_launch_angle"`
+	HardHitRate     float64 `json:"hard_hit_rate"`
+	GroundBallRate  float64 `json:"ground_ball_rate"`
+	LineDriveRate   float64 `json:"line_drive_rate"`
+	FlyBallRate     float64 `json:"fly_ball_rate"`
+	PopupRate       float64 `json:"popup_rate"`
+}
+
+// ExpectedStatsLine holds expected rates; full-season rates need the actual line
+type ExpectedStatsLine struct {
+	XBAOnContact   float64  `json:"xba_on_contact"`
+	XWOBAOnContact float64  `json:"xwoba_on_contact"`
+	XBA            *float64 `json:"xba,omitempty"`
+	XSLG           *float64 `json:"xslg,omitempty"`
+	XWOBA          *float64 `json:"xwoba,omitempty"`
+}
+
+// ActualStatsLine holds the season's actual rates
+type ActualStatsLine struct {
+	PA   int     `json:"pa"`
+	AB   int     `json:"ab"`
+	AVG  float64 `json:"avg"`
+	SLG  float64 `json:"slg"`
+	WOBA float64 `json:"woba"`
+}
+
+// PlayerExpectedStats compares a hitter's actual and expected production
+type PlayerExpectedStats struct {
+	PlayerID string             `json:"player_id"`
+	Name     string             `json:"name"`
+	Season   int                `json:"season"`
+	Profile  BattedBallProfile  `json:"batted_ball_profile"`
+	Expected *ExpectedStatsLine `json:"expected,omitempty"`
+	Actual   *ActualStatsLine   `json:"actual,omitempty"`
+	// Actual minus expected; positive values suggest results ahead of contact quality
+	Differences map[string]float64 `json:"differences,omitempty"`
+	Message     string             `json:"message,omitempty"`
+}
+
+// computeExpectedStats builds the profile, expected and actual lines. Contact
+// rates from the recorded balls are scaled to all of the season's balls in
+// play (AB - SO), so partial tracking coverage doesn't drag xBA down.
+func computeExpectedStats(balls []battedBall, line *seasonBattingLine) (BattedBallProfile, *ExpectedStatsLine, *ActualStatsLine) {
+	var profile BattedBallProfile
+	var expected *ExpectedStatsLine
+	var actual *ActualStatsLine
+
+	if line != nil && line.PA() > 0 {
+		actual = &ActualStatsLine{PA: line.PA(), AB: line.AB}
+		singles := line.H - line.Doubles - line.Triples - line.HR
+		if line.AB > 0 {
+			actual.AVG = roundTo(float64(line.H)/float64(line.AB), 3)
+			actual.SLG = roundTo(float64(singles+2*line.Doubles+3*line.Triples+4*line.HR)/float64(line.AB), 3)
+		}
+		actual.WOBA = roundTo((wobaWeightBB*float64(line.BB)+wobaWeightHBP*float64(line.HBP)+
+			wobaWeight1B*float64(singles)+wobaWeight2B*float64(line.Doubles)+
+			wobaWeight3B*float64(line.Triples)+wobaWeightHR*float64(line.HR))/float64(line.PA()), 3)
+	}
+
+	if len(balls) == 0 {
+		return profile, nil, actual
+	}
+
+	var totalEV, totalLA, xHits, xWOBA, xBases float64
+	var hardHit int
+	typeCounts := make(map[string]int)
+	for _, ball := range balls {
+		totalEV += ball.ExitVelocity
+		totalLA += ball.LaunchAngle
+		if ball.ExitVelocity >= hardHitExitVelocity {
+			hardHit++
+		}
+		typeCounts[classifyLaunchAngle(ball.LaunchAngle)]++
+
+		outcome := expectedOutcomeFor(ball.ExitVelocity, ball.LaunchAngle)
+		xHits += outcome.hitProbability()
+		xWOBA += outcome.wobaValue()
+		xBases += outcome.totalBases()
+	}
+
+	n := float64(len(balls))
+	profile = BattedBallProfile{
+		BattedBalls:     len(balls),
+		AvgExitVelocity: roundTo(totalEV/n, 1),
+		AvgLaunchAngle:  roundTo(totalLA/n, 1),
+		HardHitRate:     roundTo(float64(hardHit)/n, 3),
+		GroundBallRate:  roundTo(float64(typeCounts["ground_ball"])/n, 3),
+		LineDriveRate:   roundTo(float64(typeCounts["line_drive"])/n, 3),
+		FlyBallRate:     roundTo(float64(typeCounts["fly_ball"])/n, 3),
+		PopupRate:       roundTo(float64(typeCounts["popup"])/n, 3),
+	}
+
+	expected = &ExpectedStatsLine{
+		XBAOnContact:   roundTo(xHits/n, 3),
+		XWOBAOnContact: roundTo(xWOBA/n, 3),
+	}
+
+	if line != nil && line.AB > 0 && line.PA() > 0 {
+		ballsInPlay := float64(line.AB - line.SO)
+		if ballsInPlay < 0 {
+			ballsInPlay = 0
+		}
+		xba := roundTo(xHits/n*ballsInPlay/float64(line.AB), 3)
+		xslg := roundTo(xBases/n*ballsInPlay/float64(line.AB), 3)
+		xwoba := roundTo((xWOBA/n*ballsInPlay+wobaWeightBB*float64(line.BB)+wobaWeightHBP*float64(line.HBP))/float64(line.PA()), 3)
+		expected.XBA, expected.XSLG, expected.XWOBA = &xba, &xslg, &xwoba
+	}
+
+	return profile, expected, actual
+}
+
+// loadBattedBalls loads a hitter's tracked balls in play for a season. Hit
+// data is stored on every pitch of the plate appearance, so rows collapse to
+// one per plate appearance.
+func (s *Server) loadBattedBalls(ctx context.Context, playerUUID string, season int) ([]battedBall, error) {
+	rows, err := s.readDB().Query(ctx, `
+		SELECT DISTINCT game_id, inning, inning_half, exit_velocity::float8, launch_angle::float8
+		FROM pitches
+		WHERE batter_id::text = $1
+		  AND game_date >= make_date($2, 1, 1) AND game_date < make_date($2 + 1, 1, 1)
+		  AND exit_velocity IS NOT NULL AND launch_angle IS NOT NULL
+	`, playerUUID, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var balls []battedBall
+	for rows.Next() {
+		var gameID, inningHalf string
+		var inning int
+		var ball battedBall
+		if err := rows.Scan(&gameID, &inning, &inningHalf, &ball.ExitVelocity, &ball.LaunchAngle); err != nil {
+			log.Printf("Error scanning batted ball: %v", err)
+			continue
+		}
+		balls = append(balls, ball)
+	}
+	return balls, rows.Err()
+}
+
+// getPlayerExpectedStatsHandler compares a hitter's actual season rates with
+// xBA, xSLG and xwOBA computed from tracked exit velocity and launch angle
+func (s *Server) getPlayerExpectedStatsHandler(w http.ResponseWriter, r *http.Request) {
+	playerID := mux.Vars(r)["id"]
+	if playerID == "" {
+		writeError(w, "Player ID is required", http.StatusBadRequest)
+		return
+	}
+
+	season := getCurrentSeason()
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		parsed, err := strconv.Atoi(seasonStr)
+		if err != nil {
+			writeError(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		season = parsed
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	resolved, ok := s.resolveEntity(ctx, w, ids.Player, playerID)
+	if !ok {
+		return
+	}
+	playerUUID := resolved.ID
+
+	var name string
+	err := s.readDB().QueryRow(ctx, `SELECT full_name FROM players WHERE id = $1`, playerUUID).Scan(&name)
+	if err != nil {
+		log.Printf("Player query error: %v", err)
+		writeError(w, "Failed to query player", http.StatusInternalServerError)
+		return
+	}
+
+	balls, err := s.loadBattedBalls(ctx, playerUUID, season)
+	if err != nil {
+		log.Printf("Batted ball query error: %v", err)
+		writeError(w, "Failed to query batted balls", http.StatusInternalServerError)
+		return
+	}
+
+	var line *seasonBattingLine
+	var statsJSON []byte
+	err = s.readDB().QueryRow(ctx, `
+		SELECT aggregated_stats FROM player_season_aggregates
+		WHERE player_id::text = $1 AND season = $2 AND stats_type = 'batting'
+	`, playerUUID, season).Scan(&statsJSON)
+	if err == nil {
+		var stats map[string]interface{}
+		if json.Unmarshal(statsJSON, &stats) == nil {
+			parsed := parseSeasonBattingLine(stats)
+			line = &parsed
+		}
+	}
+
+	profile, expected, actual := computeExpectedStats(balls, line)
+	response := PlayerExpectedStats{
+		PlayerID: playerUUID,
+		Name:     name,
+		Season:   season,
+		Profile:  profile,
+		Expected: expected,
+		Actual:   actual,
+	}
+
+	switch {
+	case expected == nil:
+		response.Message = "No tracked batted balls recorded for this season"
+	case expected.XBA != nil && actual != nil:
+		response.Differences = map[string]float64{
+			"ba":   roundTo(actual.AVG-*expected.XBA, 3),
+			"slg":  roundTo(actual.SLG-*expected.XSLG, 3),
+			"woba": roundTo(actual.WOBA-*expected.XWOBA, 3),
+		}
+	}
+
+	writeJSON(w, response)
+}
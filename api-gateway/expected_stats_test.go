@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExpectedOutcomeFor tests the contact model ranks batted balls sensibly
+func TestExpectedOutcomeFor(t *testing.T) {
+	barrel := expectedOutcomeFor(108, 28)
+	assert.Greater(t, barrel.HomeRun, 0.8)
+
+	hardLiner := expectedOutcomeFor(100, 15)
+	weakGrounder := expectedOutcomeFor(70, -10)
+	assert.Greater(t, hardLiner.hitProbability(), weakGrounder.hitProbability())
+
+	assert.Less(t, expectedOutcomeFor(85, 65).hitProbability(), 0.05)
+}
+
+// TestComputeExpectedStats tests expected and actual lines and their scaling
+func TestComputeExpectedStats(t *testing.T) {
+	balls := []battedBall{
+		{ExitVelocity: 108, LaunchAngle: 28},
+		{ExitVelocity: 100, LaunchAngle: 15},
+		{ExitVelocity: 70, LaunchAngle: -10},
+		{ExitVelocity: 85, LaunchAngle: 65},
+	}
+	line := &seasonBattingLine{AB: 100, H: 25, Doubles: 5, HR: 5, BB: 10, SO: 20}
+
+	profile, expected, actual := computeExpectedStats(balls, line)
+
+	assert.Equal(t, 4, profile.BattedBalls)
+	assert.Equal(t, 0.5, profile.HardHitRate)
+	assert.Equal(t, 0.25, profile.PopupRate)
+
+	assert.NotNil(t, expected)
+	assert.NotNil(t, expected.XBA)
+	// Contact rate scaled to 80 balls in play out of 100 at-bats
+	assert.InDelta(t, expected.XBAOnContact*0.8, *expected.XBA, 0.002)
+	assert.Greater(t, *expected.XWOBA, 0.0)
+
+	assert.Equal(t, 110, actual.PA)
+	assert.Equal(t, 0.25, actual.AVG)
+	assert.Equal(t, 0.45, actual.SLG)
+}
+
+// TestComputeExpectedStatsWithoutData tests missing inputs are omitted rather than zeroed
+func TestComputeExpectedStatsWithoutData(t *testing.T) {
+	profile, expected, actual := computeExpectedStats(nil, nil)
+	assert.Equal(t, 0, profile.BattedBalls)
+	assert.Nil(t, expected)
+	assert.Nil(t, actual)
+
+	// Contact-only rates without a season line
+	_, expected, _ = computeExpectedStats([]battedBall{{ExitVelocity: 95, LaunchAngle: 12}}, nil)
+	assert.NotNil(t, expected)
+	assert.Nil(t, expected.XBA)
+	assert.Greater(t, expected.XBAOnContact, 0.0)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// exportPinTTL keeps an export pinned to its replica when the job doesn't
+// say when it expires
+const exportPinTTL = 24 * time.Hour
+
+// Export kinds the sim-engine can build
+var exportKinds = map[string]bool{
+	"simulation_results": true, // every simulated game of one run
+	"season_simulations": true, // latest finished run for each game of a season
+}
+
+// ExportRequest starts an asynchronous export
+type ExportRequest struct {
+	Kind   string `json:"kind"`
+	RunID  string `json:"run_id,omitempty"`
+	Season int    `json:"season,omitempty"`
+}
+
+// validate checks the parameters each kind needs
+func (req ExportRequest) validate() string {
+	switch {
+	case !exportKinds[req.Kind]:
+		return "kind must be simulation_results or season_simulations"
+	case req.Kind == "simulation_results" && !validateUUID(req.RunID):
+		return "run_id must be a simulation run ID"
+	case req.Kind == "season_simulations" && req.Season < firstMLBSeason:
+		return "season is required"
+	}
+	return ""
+}
+
+// createExportHandler queues a long-running export on the sim-engine. The
+// response is the job; poll GET /exports/{id} until it has a download_url.
+// Jobs and artifacts live on the replica that built them, so later calls for
+// the job are pinned to it.
+func (s *Server) createExportHandler(w http.ResponseWriter, r *http.Request) {
+	var req ExportRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		writeError(w, msg, http.StatusUnprocessableEntity)
+		return
+	}
+
+	body, _ := json.Marshal(req)
+	base := s.simEngineURL()
+	resp, err := s.simEngineClient.Post(r.Context(), base+"/exports", "application/json", bytes.NewReader(body))
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+	if job := s.relayExportJob(w, resp); job != nil {
+		if id, _ := job["id"].(string); id != "" {
+			s.pinSimEngine(id, base, exportPinUntil(job))
+		}
+	}
+}
+
+// getExportHandler reports an export's progress. Finished exports carry a
+// signed download_url that expires after a few minutes; fetch the job again
+// for a fresh one.
+func (s *Server) getExportHandler(w http.ResponseWriter, r *http.Request) {
+	exportID := mux.Vars(r)["id"]
+	if !validateUUID(exportID) {
+		writeError(w, "Invalid export ID", http.StatusBadRequest)
+		return
+	}
+
+	base := s.simEngineURLFor(exportID)
+	resp, err := s.simEngineClient.Get(r.Context(), base+"/exports/"+url.PathEscape(exportID))
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+	if job := s.relayExportJob(w, resp); job != nil {
+		// Completed jobs report when they expire
+		s.pinSimEngine(exportID, base, exportPinUntil(job))
+	}
+}
+
+// relayExportJob passes a sim-engine export job response through, returning
+// the job when there was one
+func (s *Server) relayExportJob(w http.ResponseWriter, resp *http.Response) map[string]interface{} {
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return nil
+	}
+
+	var job map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	writeJSON(w, job)
+	return job
+}
+
+// exportPinUntil is when a job is deleted from its replica
+func exportPinUntil(job map[string]interface{}) time.Time {
+	if raw, _ := job["expires_at"].(string); raw != "" {
+		if expires, err := time.Parse(time.RFC3339, raw); err == nil && expires.After(time.Now()) {
+			return expires
+		}
+	}
+	return time.Now().Add(exportPinTTL)
+}
+
+// downloadExportHandler streams a finished export. The signed URL is the
+// credential, so the link works without an API key until it expires.
+func (s *Server) downloadExportHandler(w http.ResponseWriter, r *http.Request) {
+	exportID := mux.Vars(r)["id"]
+	if !validateUUID(exportID) {
+		writeError(w, "Invalid export ID", http.StatusBadRequest)
+		return
+	}
+	query := url.Values{}
+	query.Set("expires", r.URL.Query().Get("expires"))
+	query.Set("signature", r.URL.Query().Get("signature"))
+
+	resp, err := s.simEngineClient.Get(r.Context(),
+		s.simEngineURLFor(exportID)+"/exports/"+url.PathEscape(exportID)+"/download?"+query.Encode())
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	// Large artifacts take longer to send than the server's write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Failed to lift write deadline for export %s: %v", exportID, err)
+	}
+
+	for _, header := range []string{"Content-Type", "Content-Disposition", "Content-Length"} {
+		if value := resp.Header.Get(header); value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		log.Printf("Failed to stream export %s: %v", exportID, err)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportHandlers(t *testing.T) {
+	const exportID = "5d2f7a4e-9c1b-4e3a-8f6d-2b7c9e0a1f34"
+	const runID = "0b6c9c1e-4f5e-4a43-9d0e-3f7a3c8e2b11"
+	var forwarded []string
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = append(forwarded, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/exports":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "` + exportID + `", "status": "pending"}`))
+		case "/exports/" + exportID:
+			w.Write([]byte(`{"id": "` + exportID + `", "status": "completed", "download_url": "/api/v1/exports/` + exportID + `/download?expires=1&signature=ab"}`))
+		case "/exports/" + exportID + "/download":
+			if r.URL.Query().Get("signature") != "ab" {
+				http.Error(w, "invalid download signature", http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="simulation_results.csv"`)
+			w.Write([]byte("simulation_number,home_score\n1,4\n"))
+		default:
+			http.Error(w, "Export not found", http.StatusNotFound)
+		}
+	}))
+	defer engine.Close()
+
+	s := &Server{
+		config:          &Config{SimEngineURL: engine.URL},
+		queryCache:      NewQueryCache(),
+		simEngineClient: NewUpstreamClient("sim-engine", 2),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/exports", s.createExportHandler).Methods("POST")
+	router.HandleFunc("/exports/{id}", s.getExportHandler).Methods("GET")
+	router.HandleFunc("/exports/{id}/download", s.downloadExportHandler).Methods("GET")
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("POST", "/exports", `{"kind": "simulation_results", "run_id": "`+runID+`"}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"pending"`)
+
+	rec = serve("GET", "/exports/"+exportID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "download_url")
+
+	// Downloads stream the artifact and forward only the signed parameters
+	forwarded = nil
+	rec = serve("GET", "/exports/"+exportID+"/download?expires=1&signature=ab&extra=1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "simulation_results.csv")
+	assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "simulation_number,home_score\n1,4\n", rec.Body.String())
+	assert.Equal(t, []string{"GET /exports/" + exportID + "/download?expires=1&signature=ab"}, forwarded)
+
+	// Engine errors pass through
+	assert.Equal(t, http.StatusForbidden, serve("GET", "/exports/"+exportID+"/download?expires=1&signature=00", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve("GET", "/exports/1f0e9c1e-4f5e-4a43-9d0e-3f7a3c8e2b11", "").Code)
+
+	// Bad requests are rejected before forwarding
+	forwarded = nil
+	assert.Equal(t, http.StatusUnprocessableEntity, serve("POST", "/exports", `{"kind": "box_scores"}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, serve("POST", "/exports", `{"kind": "simulation_results", "run_id": "42"}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, serve("POST", "/exports", `{"kind": "season_simulations"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve("GET", "/exports/not-an-export", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("GET", "/exports/not-an-export/download", "").Code)
+	assert.Empty(t, forwarded)
+}
+
+func TestExportsBypassStaleCache(t *testing.T) {
+	assert.False(t, isStaleCacheable(httptest.NewRequest("GET", "/api/v1/exports/5d2f7a4e-9c1b-4e3a-8f6d-2b7c9e0a1f34", nil)))
+	assert.False(t, isStaleCacheable(httptest.NewRequest("GET", "/api/v1/exports/5d2f7a4e-9c1b-4e3a-8f6d-2b7c9e0a1f34/download", nil)))
+}
+
+// deadlineRecorder records whether a handler lifted the write deadline,
+// which only works when no middleware holds the response back
+type deadlineRecorder struct {
+	*httptest.ResponseRecorder
+	deadlineLifted bool
+}
+
+func (dr *deadlineRecorder) SetWriteDeadline(deadline time.Time) error {
+	dr.deadlineLifted = deadline.IsZero()
+	return nil
+}
+
+// TestExportDownloadStreamsThroughMiddleware tests downloads reach the
+// client through the router's middleware unbuffered, with the write
+// deadline lifted
+func TestExportDownloadStreamsThroughMiddleware(t *testing.T) {
+	const exportID = "5d2f7a4e-9c1b-4e3a-8f6d-2b7c9e0a1f34"
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("simulation_number,home_score\n1,4\n"))
+	}))
+	defer engine.Close()
+
+	var logged bytes.Buffer
+	appLogger = NewStructuredLogger(&logged)
+	s := &Server{
+		config:          &Config{SimEngineURL: engine.URL},
+		staleCache:      NewStaleCache(time.Hour, 10),
+		simEngineClient: NewUpstreamClient("sim-engine", 2),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/exports/{id}/download", withCachePolicy(referenceCachePolicy, s.downloadExportHandler)).Methods("GET")
+	router.Use(s.loggingMiddleware, s.staleCacheMiddleware, s.jsonCaseMiddleware)
+
+	rec := &deadlineRecorder{ResponseRecorder: httptest.NewRecorder()}
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/exports/"+exportID+"/download?expires=1&signature=ab&case=snake", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "simulation_number,home_score\n1,4\n", rec.Body.String())
+	assert.True(t, rec.deadlineLifted, "the deadline reaches the connection through every wrapper")
+}
+
+// TestExportHandlersPinReplica tests calls about an export go to the replica
+// that holds it, not round-robin
+func TestExportHandlersPinReplica(t *testing.T) {
+	const exportID = "5d2f7a4e-9c1b-4e3a-8f6d-2b7c9e0a1f34"
+	const runID = "0b6c9c1e-4f5e-4a43-9d0e-3f7a3c8e2b11"
+
+	// Each replica only knows the jobs it created
+	newReplica := func(hits *int) *httptest.Server {
+		created := false
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*hits++
+			switch {
+			case r.URL.Path == "/exports":
+				created = true
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"id": "` + exportID + `", "status": "pending", "expires_at": "` +
+					time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`))
+			case created && r.URL.Path == "/exports/"+exportID:
+				w.Write([]byte(`{"id": "` + exportID + `", "status": "completed"}`))
+			case created && r.URL.Path == "/exports/"+exportID+"/download":
+				w.Header().Set("Content-Type", "text/csv")
+				w.Write([]byte("simulation_number,home_score\n"))
+			default:
+				http.Error(w, "Export not found", http.StatusNotFound)
+			}
+		}))
+	}
+	var hitsA, hitsB int
+	replicaA, replicaB := newReplica(&hitsA), newReplica(&hitsB)
+	defer replicaA.Close()
+	defer replicaB.Close()
+
+	pool, err := ParseUpstreamTopology("sim-engine", replicaA.URL+","+replicaB.URL, "")
+	require.NoError(t, err)
+	s := &Server{
+		config:          &Config{},
+		simEngines:      pool,
+		simEngineClient: NewUpstreamClient("sim-engine", 2),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/exports", s.createExportHandler).Methods("POST")
+	router.HandleFunc("/exports/{id}", s.getExportHandler).Methods("GET")
+	router.HandleFunc("/exports/{id}/download", s.downloadExportHandler).Methods("GET")
+	serve := func(method, path, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusAccepted, serve("POST", "/exports", `{"kind": "simulation_results", "run_id": "`+runID+`"}`))
+	creatorHits := &hitsA
+	if hitsB == 1 {
+		creatorHits = &hitsB
+	}
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, http.StatusOK, serve("GET", "/exports/"+exportID, ""))
+		assert.Equal(t, http.StatusOK, serve("GET", "/exports/"+exportID+"/download?expires=1&signature=ab", ""))
+	}
+	assert.Equal(t, 9, *creatorHits, "every call about the job goes to the replica that created it")
+	assert.Equal(t, 9, hitsA+hitsB)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+)
+
+// FranchiseIdentity is one name a franchise played under, for the seasons
+// from FirstSeason to LastSeason. A nil FirstSeason covers every earlier
+// season; a nil LastSeason is the current identity.
+type FranchiseIdentity struct {
+	Name         string  `json:"name"`
+	City         *string `json:"city"`
+	Abbreviation *string `json:"abbreviation"`
+	FirstSeason  *int    `json:"first_season"`
+	LastSeason   *int    `json:"last_season"`
+
+	// Record over the identity's seasons with games on file
+	Seasons    []int   `json:"seasons"`
+	Wins       int     `json:"wins"`
+	Losses     int     `json:"losses"`
+	WinningPct float64 `json:"winning_pct"`
+}
+
+// covers reports whether the identity was used in season
+func (fi FranchiseIdentity) covers(season int) bool {
+	return (fi.FirstSeason == nil || season >= *fi.FirstSeason) &&
+		(fi.LastSeason == nil || season <= *fi.LastSeason)
+}
+
+// FranchiseHistory lists a franchise's identities, oldest first, with the
+// franchise's record across every team row that belongs to it
+type FranchiseHistory struct {
+	FranchiseID string              `json:"franchise_id"`
+	Name        string              `json:"name"` // current identity
+	Identities  []FranchiseIdentity `json:"identities"`
+	Seasons     int                 `json:"seasons"`
+	Wins        int                 `json:"wins"`
+	Losses      int                 `json:"losses"`
+	WinningPct  float64             `json:"winning_pct"`
+	GameTypes   []string            `json:"game_types"`
+}
+
+// franchiseSeason is a franchise's record in one season
+type franchiseSeason struct {
+	Season int
+	Wins   int
+	Losses int
+}
+
+// winningPct is wins over decisions, or 0 before any
+func winningPct(wins, losses int) float64 {
+	if wins+losses == 0 {
+		return 0
+	}
+	return float64(wins) / float64(wins+losses)
+}
+
+// buildFranchiseHistory credits each season's record to the identity in use
+// that season. Identities are ordered oldest first.
+func buildFranchiseHistory(franchiseID string, identities []FranchiseIdentity, seasons []franchiseSeason) FranchiseHistory {
+	sort.SliceStable(identities, func(i, j int) bool {
+		a, b := identities[i].FirstSeason, identities[j].FirstSeason
+		return a == nil && b != nil || a != nil && b != nil && *a < *b
+	})
+	sort.Slice(seasons, func(i, j int) bool { return seasons[i].Season < seasons[j].Season })
+
+	history := FranchiseHistory{FranchiseID: franchiseID, Identities: identities}
+	for i := range identities {
+		identities[i].Seasons = []int{}
+		if identities[i].LastSeason == nil {
+			history.Name = identities[i].Name
+		}
+	}
+	for _, season := range seasons {
+		history.Seasons++
+		history.Wins += season.Wins
+		history.Losses += season.Losses
+		for i := range identities {
+			if identities[i].covers(season.Season) {
+				identities[i].Seasons = append(identities[i].Seasons, season.Season)
+				identities[i].Wins += season.Wins
+				identities[i].Losses += season.Losses
+				break
+			}
+		}
+	}
+	for i := range identities {
+		identities[i].WinningPct = winningPct(identities[i].Wins, identities[i].Losses)
+	}
+	history.WinningPct = winningPct(history.Wins, history.Losses)
+	return history
+}
+
+// resolveFranchise returns the franchise ID for a franchise ID or any team
+// identifier, writing a 404 when there is none
+func (s *Server) resolveFranchise(ctx context.Context, w http.ResponseWriter, raw string) (string, bool) {
+	var franchiseID string
+	err := s.readDB().QueryRow(ctx,
+		`SELECT franchise_id FROM franchise_identities WHERE franchise_id = $1 LIMIT 1`, raw).Scan(&franchiseID)
+	if err == nil {
+		return franchiseID, true
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		log.Printf("Franchise query error: %v", err)
+		writeError(w, "Failed to query franchise", http.StatusInternalServerError)
+		return "", false
+	}
+
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, raw)
+	if !ok {
+		return "", false
+	}
+	var teamFranchise *string
+	if err := s.readDB().QueryRow(ctx, `SELECT franchise_id FROM teams WHERE id = $1`, resolved.ID).Scan(&teamFranchise); err != nil {
+		log.Printf("Franchise query error: %v", err)
+		writeError(w, "Failed to query franchise", http.StatusInternalServerError)
+		return "", false
+	}
+	if teamFranchise == nil {
+		writeError(w, "Team has no franchise history", http.StatusNotFound)
+		return "", false
+	}
+	return *teamFranchise, true
+}
+
+// franchiseSeasonTeam returns the team row of teamID's franchise that played
+// season, so a season before a relocation or rename finds the old identity's
+// games. It falls back to teamID when the team has no franchise or the
+// lookup fails.
+func (s *Server) franchiseSeasonTeam(ctx context.Context, teamID string, season int) string {
+	var seasonTeam string
+	err := s.readDB().QueryRow(ctx, `
+		SELECT t.id::text
+		FROM teams t
+		JOIN teams requested ON requested.id = $1 AND requested.franchise_id = t.franchise_id
+		WHERE EXISTS (
+			SELECT 1 FROM games g
+			WHERE g.season = $2 AND (g.home_team_id = t.id OR g.away_team_id = t.id)
+		)
+		ORDER BY t.id = requested.id DESC
+		LIMIT 1
+	`, teamID, season).Scan(&seasonTeam)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Franchise season lookup error: %v", err)
+		}
+		return teamID
+	}
+	return seasonTeam
+}
+
+// getFranchiseHistoryHandler lists a franchise's identities per era with its
+// record under each. {id} is a franchise ID or any team identifier.
+func (s *Server) getFranchiseHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	gameTypes, err := parseGameTypes(r.URL.Query(), regularSeasonOnly)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	franchiseID, ok := s.resolveFranchise(ctx, w, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	rows, err := s.readDB().Query(ctx, `
+		SELECT name, city, abbreviation, first_season, last_season
+		FROM franchise_identities
+		WHERE franchise_id = $1`, franchiseID)
+	if err != nil {
+		log.Printf("Franchise identities query error: %v", err)
+		writeError(w, "Failed to query franchise history", http.StatusInternalServerError)
+		return
+	}
+	identities := []FranchiseIdentity{}
+	for rows.Next() {
+		var identity FranchiseIdentity
+		if err := rows.Scan(&identity.Name, &identity.City, &identity.Abbreviation,
+			&identity.FirstSeason, &identity.LastSeason); err != nil {
+			rows.Close()
+			log.Printf("Franchise identity scan error: %v", err)
+			writeError(w, "Failed to query franchise history", http.StatusInternalServerError)
+			return
+		}
+		identities = append(identities, identity)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Printf("Franchise identities query error: %v", err)
+		writeError(w, "Failed to query franchise history", http.StatusInternalServerError)
+		return
+	}
+
+	gameTypeClause, gameTypeArgs := gameTypeCondition("g.game_type", gameTypes, 2)
+	rows, err = s.readDB().Query(ctx, `
+		WITH franchise AS (
+			SELECT id FROM teams WHERE franchise_id = $1
+		)
+		SELECT g.season,
+			COUNT(*) FILTER (WHERE
+				(g.home_team_id IN (SELECT id FROM franchise) AND g.final_score_home > g.final_score_away) OR
+				(g.away_team_id IN (SELECT id FROM franchise) AND g.final_score_away > g.final_score_home)),
+			COUNT(*) FILTER (WHERE
+				(g.home_team_id IN (SELECT id FROM franchise) AND g.final_score_home < g.final_score_away) OR
+				(g.away_team_id IN (SELECT id FROM franchise) AND g.final_score_away < g.final_score_home))
+		FROM games g
+		WHERE (g.home_team_id IN (SELECT id FROM franchise) OR g.away_team_id IN (SELECT id FROM franchise))
+			AND `+completedGameCondition+`
+			AND `+gameTypeClause+`
+		GROUP BY g.season`, franchiseID, gameTypeArgs)
+	if err != nil {
+		log.Printf("Franchise record query error: %v", err)
+		writeError(w, "Failed to query franchise history", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var seasons []franchiseSeason
+	for rows.Next() {
+		var season franchiseSeason
+		if err := rows.Scan(&season.Season, &season.Wins, &season.Losses); err != nil {
+			log.Printf("Franchise record scan error: %v", err)
+			writeError(w, "Failed to query franchise history", http.StatusInternalServerError)
+			return
+		}
+		seasons = append(seasons, season)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Franchise record query error: %v", err)
+		writeError(w, "Failed to query franchise history", http.StatusInternalServerError)
+		return
+	}
+
+	history := buildFranchiseHistory(franchiseID, identities, seasons)
+	history.GameTypes = gameTypes
+	writeJSON(w, history)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int { return &v }
+
+// TestBuildFranchiseHistory tests seasons are credited to the identity in use
+// and the franchise totals span every identity
+func TestBuildFranchiseHistory(t *testing.T) {
+	identities := []FranchiseIdentity{
+		{Name: "Washington Nationals", FirstSeason: intPtr(2005)},
+		{Name: "Montreal Expos", LastSeason: intPtr(2004)},
+	}
+	seasons := []franchiseSeason{
+		{Season: 2005, Wins: 81, Losses: 81},
+		{Season: 2003, Wins: 83, Losses: 79},
+		{Season: 2004, Wins: 67, Losses: 95},
+	}
+
+	history := buildFranchiseHistory("washington-nationals", identities, seasons)
+
+	assert.Equal(t, "Washington Nationals", history.Name)
+	require.Len(t, history.Identities, 2)
+
+	expos := history.Identities[0]
+	assert.Equal(t, "Montreal Expos", expos.Name, "identities are oldest first")
+	assert.Equal(t, []int{2003, 2004}, expos.Seasons)
+	assert.Equal(t, 150, expos.Wins)
+	assert.Equal(t, 174, expos.Losses)
+
+	nationals := history.Identities[1]
+	assert.Equal(t, []int{2005}, nationals.Seasons)
+	assert.InDelta(t, 0.5, nationals.WinningPct, 1e-9)
+
+	assert.Equal(t, 3, history.Seasons)
+	assert.Equal(t, 231, history.Wins)
+	assert.Equal(t, 255, history.Losses)
+}
+
+// TestBuildFranchiseHistoryWithoutGames tests identities without games on
+// file still list with empty seasons
+func TestBuildFranchiseHistoryWithoutGames(t *testing.T) {
+	history := buildFranchiseHistory("seattle-mariners", []FranchiseIdentity{{Name: "Seattle Mariners"}}, nil)
+
+	require.Len(t, history.Identities, 1)
+	assert.Equal(t, []int{}, history.Identities[0].Seasons)
+	assert.Zero(t, history.WinningPct)
+	assert.Equal(t, "Seattle Mariners", history.Name)
+}
+
+// TestFranchiseIdentityCovers tests open-ended eras
+func TestFranchiseIdentityCovers(t *testing.T) {
+	kansasCity := FranchiseIdentity{FirstSeason: intPtr(1955), LastSeason: intPtr(1967)}
+	assert.True(t, kansasCity.covers(1955))
+	assert.True(t, kansasCity.covers(1967))
+	assert.False(t, kansasCity.covers(1968))
+	assert.True(t, FranchiseIdentity{LastSeason: intPtr(1954)}.covers(1901))
+	assert.True(t, FranchiseIdentity{FirstSeason: intPtr(2025)}.covers(2030))
+}
@@ -3,9 +3,11 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"log"
 	"net/http"
 	"time"
 
+	"github.com/baseball-sim/shared/ids"
 	"github.com/gorilla/mux"
 )
 
@@ -77,6 +79,7 @@ type GameBoxScore struct {
 	AwayTeamBatting []BoxScoreBatting  `json:"away_team_batting"`
 	HomeTeamPitching []BoxScorePitching `json:"home_team_pitching"`
 	AwayTeamPitching []BoxScorePitching `json:"away_team_pitching"`
+	Computed         bool               `json:"computed,omitempty"` // Rebuilt from plays
 }
 
 // getGameBoxScore handles GET /api/v1/games/{id}/boxscore
@@ -87,13 +90,19 @@ func (s *Server) getGameBoxScore(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
+	resolved, ok := s.resolveEntity(ctx, w, ids.Game, gameID)
+	if !ok {
+		return
+	}
+	gameID = resolved.ID
+
 	// Get home and away team IDs
-	var homeTeamID, awayTeamID string
-	err := s.db.QueryRow(ctx, `
-		SELECT home_team_id, away_team_id
+	var homeTeamID, awayTeamID, status string
+	err := s.readDB().QueryRow(ctx, `
+		SELECT home_team_id, away_team_id, COALESCE(status, '')
 		FROM games
 		WHERE id = $1
-	`, gameID).Scan(&homeTeamID, &awayTeamID)
+	`, gameID).Scan(&homeTeamID, &awayTeamID, &status)
 
 	if err != nil {
 		writeError(w, "Game not found", http.StatusNotFound)
@@ -103,7 +112,7 @@ func (s *Server) getGameBoxScore(w http.ResponseWriter, r *http.Request) {
 	boxScore := GameBoxScore{}
 
 	// Fetch home team batting
-	rows, err := s.db.Query(ctx, `
+	rows, err := s.readDB().Query(ctx, `
 		SELECT
 			p.player_id,
 			p.full_name as player_name,
@@ -144,7 +153,7 @@ func (s *Server) getGameBoxScore(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fetch away team batting
-	rows, err = s.db.Query(ctx, `
+	rows, err = s.readDB().Query(ctx, `
 		SELECT
 			p.player_id,
 			p.full_name as player_name,
@@ -185,7 +194,7 @@ func (s *Server) getGameBoxScore(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fetch home team pitching
-	rows, err = s.db.Query(ctx, `
+	rows, err = s.readDB().Query(ctx, `
 		SELECT
 			p.player_id,
 			p.full_name as player_name,
@@ -227,7 +236,7 @@ func (s *Server) getGameBoxScore(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fetch away team pitching
-	rows, err = s.db.Query(ctx, `
+	rows, err = s.readDB().Query(ctx, `
 		SELECT
 			p.player_id,
 			p.full_name as player_name,
@@ -268,6 +277,21 @@ func (s *Server) getGameBoxScore(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Older games may have plays but no box score rows; rebuild from plays
+	if len(boxScore.HomeTeamBatting) == 0 && len(boxScore.AwayTeamBatting) == 0 &&
+		len(boxScore.HomeTeamPitching) == 0 && len(boxScore.AwayTeamPitching) == 0 {
+		plays, err := s.loadBoxScorePlays(ctx, gameID)
+		if err != nil {
+			log.Printf("Failed to load plays for computed box score: %v (gameID=%s)", err, gameID)
+		} else if len(plays) > 0 {
+			boxScore = computeBoxScoreFromPlays(plays, homeTeamID, awayTeamID)
+		}
+	}
+
+	// A final box score only changes with a scoring correction
+	if isCompletedGameStatus(status) {
+		setCachePolicy(w, historicalCachePolicy)
+	}
 	writeJSON(w, boxScore)
 }
 
@@ -279,7 +303,13 @@ func (s *Server) getGamePlays(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	rows, err := s.db.Query(ctx, `
+	resolved, ok := s.resolveEntity(ctx, w, ids.Game, gameID)
+	if !ok {
+		return
+	}
+	gameID = resolved.ID
+
+	rows, err := s.readDB().Query(ctx, `
 		SELECT
 			gp.id,
 			gp.play_id,
@@ -333,8 +363,14 @@ func (s *Server) getGameWeather(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
+	resolved, ok := s.resolveEntity(ctx, w, ids.Game, gameID)
+	if !ok {
+		return
+	}
+	gameID = resolved.ID
+
 	var weatherData []byte
-	err := s.db.QueryRow(ctx, `
+	err := s.readDB().QueryRow(ctx, `
 		SELECT COALESCE(weather_data, '{}'::jsonb)
 		FROM games
 		WHERE id = $1
@@ -373,3 +373,54 @@ func TestEmptyBoxScore(t *testing.T) {
 	assert.Nil(t, boxScore.HomeTeamPitching)
 	assert.Nil(t, boxScore.AwayTeamPitching)
 }
+
+// TestComputeBoxScoreFromPlays tests rebuilding a box score from play-by-play
+func TestComputeBoxScoreFromPlays(t *testing.T) {
+	plays := []boxScorePlay{
+		// Deliberately out of order to exercise sorting
+		{PlayID: "3", Inning: 1, InningHalf: "bottom", Outs: 1, BatterID: "h1", BatterName: "Home One", PitcherID: "ap", PitcherName: "Away Pitcher", EventType: "Strikeout"},
+		{PlayID: "0", Inning: 1, InningHalf: "top", Outs: 0, BatterID: "a1", BatterName: "Away One", PitcherID: "hp", PitcherName: "Home Pitcher", EventType: "Single"},
+		{PlayID: "1", Inning: 1, InningHalf: "top", Outs: 0, BatterID: "a2", BatterName: "Away Two", PitcherID: "hp", PitcherName: "Home Pitcher", EventType: "Home Run", RBI: 2, RunsScored: 2},
+		{PlayID: "2", Inning: 1, InningHalf: "top", Outs: 1, BatterID: "a3", BatterName: "Away Three", PitcherID: "hp", PitcherName: "Home Pitcher", EventType: "Walk"},
+		{PlayID: "4", Inning: 1, InningHalf: "bottom", Outs: 3, BatterID: "h2", BatterName: "Home Two", PitcherID: "ap", PitcherName: "Away Pitcher", EventType: "Grounded Into DP"},
+	}
+
+	box := computeBoxScoreFromPlays(plays, "home", "away")
+	assert.True(t, box.Computed)
+	assert.Len(t, box.AwayTeamBatting, 3)
+	assert.Len(t, box.HomeTeamBatting, 2)
+
+	leadoff := box.AwayTeamBatting[0]
+	assert.Equal(t, "a1", leadoff.PlayerID)
+	assert.Equal(t, 1, *leadoff.BattingOrder)
+	assert.Equal(t, 1, leadoff.AtBats)
+	assert.Equal(t, 1, leadoff.Hits)
+
+	slugger := box.AwayTeamBatting[1]
+	assert.Equal(t, 1, slugger.HomeRuns)
+	assert.Equal(t, 2, slugger.RBIs)
+	assert.Equal(t, 1, slugger.Runs)
+
+	walker := box.AwayTeamBatting[2]
+	assert.Equal(t, 0, walker.AtBats)
+	assert.Equal(t, 1, walker.Walks)
+
+	assert.Len(t, box.HomeTeamPitching, 1)
+	homePitcher := box.HomeTeamPitching[0]
+	assert.Equal(t, "home", homePitcher.TeamID)
+	assert.Equal(t, 2, homePitcher.HitsAllowed)
+	assert.Equal(t, 2, homePitcher.RunsAllowed)
+	assert.Equal(t, 1, homePitcher.HomeRunsAllowed)
+	assert.Equal(t, 1, homePitcher.WalksAllowed)
+
+	awayPitcher := box.AwayTeamPitching[0]
+	assert.Equal(t, 1, awayPitcher.Strikeouts)
+	assert.Equal(t, 1.0, awayPitcher.InningsPitched)
+}
+
+// TestNormalizeEventType tests event name folding
+func TestNormalizeEventType(t *testing.T) {
+	assert.Equal(t, "homerun", normalizeEventType("Home Run"))
+	assert.Equal(t, "homerun", normalizeEventType("home_run"))
+	assert.Equal(t, "strikeoutdoubleplay", normalizeEventType("Strikeout Double Play"))
+}
@@ -0,0 +1,60 @@
+package main
+
+import "time"
+
+// GameLiveState is the running state of a game in progress. Final scores
+// stay null until the game is completed.
+type GameLiveState struct {
+	HomeScore  int        `json:"home_score"`
+	AwayScore  int        `json:"away_score"`
+	Inning     *int       `json:"inning"`
+	InningHalf *string    `json:"inning_half"` // top, middle, bottom or end
+	Outs       *int       `json:"outs"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// gameLiveColumns selects the live state of games (or games_read_model) g,
+// scanned into a gameLiveRow
+const gameLiveColumns = `g.current_score_home, g.current_score_away, g.current_inning,
+		       g.current_inning_half, g.current_outs, g.live_updated_at`
+
+// gameLiveRow is the live state as stored, null for games that aren't live
+type gameLiveRow struct {
+	homeScore, awayScore *int
+	inning               *int
+	inningHalf           *string
+	outs                 *int
+	updatedAt            *time.Time
+}
+
+// applyGameState makes a game's scores follow its status: final scores only
+// for completed games, and the live state only for live ones
+func (g *Game) applyGameState(live gameLiveRow) {
+	status, _ := gameStatusFor(g.Status)
+	if status != GameStatusFinal {
+		g.HomeScore, g.AwayScore = nil, nil
+	}
+	if status != GameStatusLive {
+		return
+	}
+
+	g.Live = &GameLiveState{
+		Inning:     live.inning,
+		InningHalf: live.inningHalf,
+		Outs:       live.outs,
+		UpdatedAt:  live.updatedAt,
+	}
+	if live.homeScore != nil {
+		g.Live.HomeScore = *live.homeScore
+	}
+	if live.awayScore != nil {
+		g.Live.AwayScore = *live.awayScore
+	}
+}
+
+// completedGameCondition limits a stats query to finished games g. Live
+// games never have final scores, but the status check keeps them out
+// explicitly.
+const completedGameCondition = `g.status = 'completed'
+			AND g.final_score_home IS NOT NULL
+			AND g.final_score_away IS NOT NULL`
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyGameState(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	half := "bottom"
+	live := gameLiveRow{homeScore: intPtr(2), awayScore: intPtr(4), inning: intPtr(6), inningHalf: &half, outs: intPtr(1)}
+
+	t.Run("completed keeps final score", func(t *testing.T) {
+		g := Game{Status: "completed", HomeScore: intPtr(5), AwayScore: intPtr(3)}
+		g.applyGameState(gameLiveRow{})
+		assert.Equal(t, 5, *g.HomeScore)
+		assert.Equal(t, 3, *g.AwayScore)
+		assert.Nil(t, g.Live)
+	})
+
+	t.Run("live game reports its running state", func(t *testing.T) {
+		g := Game{Status: "In Progress", HomeScore: intPtr(2), AwayScore: intPtr(4)}
+		g.applyGameState(live)
+		assert.Nil(t, g.HomeScore, "a running score is not a final score")
+		assert.Nil(t, g.AwayScore)
+		require.NotNil(t, g.Live)
+		assert.Equal(t, 2, g.Live.HomeScore)
+		assert.Equal(t, 4, g.Live.AwayScore)
+		assert.Equal(t, 6, *g.Live.Inning)
+		assert.Equal(t, "bottom", *g.Live.InningHalf)
+		assert.Equal(t, 1, *g.Live.Outs)
+	})
+
+	t.Run("live game before first pitch", func(t *testing.T) {
+		g := Game{Status: "live"}
+		g.applyGameState(gameLiveRow{})
+		require.NotNil(t, g.Live)
+		assert.Equal(t, 0, g.Live.HomeScore)
+		assert.Nil(t, g.Live.Inning)
+	})
+
+	t.Run("scheduled game has no scores", func(t *testing.T) {
+		g := Game{Status: "scheduled", HomeScore: intPtr(0), AwayScore: intPtr(0)}
+		g.applyGameState(live)
+		assert.Nil(t, g.HomeScore)
+		assert.Nil(t, g.Live)
+
+		body, err := json.Marshal(g)
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), "home_score")
+		assert.NotContains(t, string(body), `"live"`)
+	})
+}
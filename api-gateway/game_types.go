@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Game types accepted by ?game_type=, as the MLB Stats API codes them
+const (
+	GameTypeRegular    = "R"
+	GameTypePostseason = "P"
+	GameTypeSpring     = "S"
+)
+
+// gameTypeCodes are the stored games.game_type values each game type covers.
+// Postseason rounds (wild card, division, LCS, World Series) have their own
+// codes; the spelled-out values come from older rows.
+var gameTypeCodes = map[string][]string{
+	GameTypeRegular:    {"R", "regular"},
+	GameTypePostseason: {"P", "F", "D", "L", "W", "playoff"},
+	GameTypeSpring:     {"S", "spring"},
+}
+
+// gameTypeAliases lets ?game_type= take names as well as codes
+var gameTypeAliases = map[string]string{
+	"regular":    GameTypeRegular,
+	"postseason": GameTypePostseason,
+	"playoff":    GameTypePostseason,
+	"playoffs":   GameTypePostseason,
+	"spring":     GameTypeSpring,
+}
+
+// regularSeasonOnly is the default for records and team stats
+var regularSeasonOnly = []string{GameTypeRegular}
+
+// parseGameTypes reads ?game_type=R,P,S (codes or names). Without it the
+// defaults apply, widened by include_postseason=true and include_spring=true.
+// A nil result means no game type filter.
+func parseGameTypes(query url.Values, defaults []string) ([]string, error) {
+	var types []string
+	if raw := query.Get("game_type"); raw != "" {
+		for _, value := range strings.Split(raw, ",") {
+			value = strings.TrimSpace(value)
+			gameType, ok := gameTypeAliases[strings.ToLower(value)]
+			if !ok {
+				gameType = strings.ToUpper(value)
+			}
+			if _, ok := gameTypeCodes[gameType]; !ok {
+				return nil, fmt.Errorf("invalid game_type %q (expected R, P or S)", value)
+			}
+			types = appendGameType(types, gameType)
+		}
+	} else {
+		types = append(types, defaults...)
+	}
+
+	for _, include := range []struct {
+		param    string
+		gameType string
+	}{
+		{"include_postseason", GameTypePostseason},
+		{"include_spring", GameTypeSpring},
+	} {
+		raw := query.Get(include.param)
+		if raw == "" {
+			continue
+		}
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s parameter (expected true or false)", include.param)
+		}
+		if enabled && types != nil {
+			types = appendGameType(types, include.gameType)
+		}
+	}
+	return types, nil
+}
+
+func appendGameType(types []string, gameType string) []string {
+	for _, existing := range types {
+		if existing == gameType {
+			return types
+		}
+	}
+	return append(types, gameType)
+}
+
+// gameTypeCondition restricts column to the game types using placeholder
+// $argIndex, returning the condition and the stored codes to bind. Games with
+// no stored type are counted as regular season.
+func gameTypeCondition(column string, types []string, argIndex int) (string, []string) {
+	var codes []string
+	regular := false
+	for _, gameType := range types {
+		codes = append(codes, gameTypeCodes[gameType]...)
+		if gameType == GameTypeRegular {
+			regular = true
+		}
+	}
+
+	condition := fmt.Sprintf("%s = ANY($%d)", column, argIndex)
+	if regular {
+		condition = fmt.Sprintf("(%s OR %s IS NULL)", condition, column)
+	}
+	return condition, codes
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseGameTypes tests explicit game types, defaults and include flags
+func TestParseGameTypes(t *testing.T) {
+	cases := []struct {
+		query    string
+		defaults []string
+		want     []string
+	}{
+		{"", regularSeasonOnly, []string{"R"}},
+		{"include_postseason=true", regularSeasonOnly, []string{"R", "P"}},
+		{"include_postseason=true&include_spring=1", regularSeasonOnly, []string{"R", "P", "S"}},
+		{"include_spring=false", regularSeasonOnly, []string{"R"}},
+		{"game_type=P", regularSeasonOnly, []string{"P"}},
+		{"game_type=r,postseason,R", regularSeasonOnly, []string{"R", "P"}},
+		{"game_type=S&include_postseason=true", regularSeasonOnly, []string{"S", "P"}},
+		// Lists show every game type unless filtered
+		{"", nil, nil},
+		{"include_spring=true", nil, nil},
+		{"game_type=spring", nil, []string{"S"}},
+	}
+
+	for _, c := range cases {
+		query, _ := url.ParseQuery(c.query)
+		got, err := parseGameTypes(query, c.defaults)
+		assert.NoError(t, err, c.query)
+		assert.Equal(t, c.want, got, c.query)
+	}
+
+	for _, bad := range []string{"game_type=X", "game_type=R,", "include_postseason=maybe"} {
+		query, _ := url.ParseQuery(bad)
+		_, err := parseGameTypes(query, regularSeasonOnly)
+		assert.Error(t, err, bad)
+	}
+}
+
+// TestGameTypeCondition tests that regular season takes untyped games and
+// postseason covers every round
+func TestGameTypeCondition(t *testing.T) {
+	condition, codes := gameTypeCondition("g.game_type", []string{GameTypeRegular}, 3)
+	assert.Equal(t, "(g.game_type = ANY($3) OR g.game_type IS NULL)", condition)
+	assert.Equal(t, []string{"R", "regular"}, codes)
+
+	condition, codes = gameTypeCondition("g.game_type", []string{GameTypePostseason}, 1)
+	assert.Equal(t, "g.game_type = ANY($1)", condition)
+	assert.Subset(t, codes, []string{"P", "F", "D", "L", "W"})
+	assert.NotContains(t, codes, "R")
+}
+
+// TestBuildGamesWhereClauseGameTypes tests the game type filter on /games
+func TestBuildGamesWhereClauseGameTypes(t *testing.T) {
+	season := 2024
+	where, args := buildGamesWhereClause(QueryParams{Season: &season, GameTypes: []string{GameTypeSpring}})
+	assert.Contains(t, where, "g.game_type = ANY($2)")
+	assert.Len(t, args, 2)
+	assert.Equal(t, []string{"S", "spring"}, args[1])
+}
+
+// TestGameTypeValidation tests that bad game types are rejected before any
+// query runs
+func TestGameTypeValidation(t *testing.T) {
+	s := &Server{}
+	router := mux.NewRouter()
+	router.HandleFunc("/teams/{id}/stats", s.getTeamStatsHandler)
+	router.HandleFunc("/teams/{id}/games", s.getTeamGamesHandler)
+	router.HandleFunc("/games", s.getGamesHandler)
+
+	for _, path := range []string{
+		"/teams/nyy/stats?game_type=Z",
+		"/teams/nyy/stats?include_postseason=yes",
+		"/teams/nyy/games?game_type=exhibition",
+		"/games?game_type=Q",
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, path)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// GameVenueRequest moves a game to another stadium, by UUID or MLB venue ID
+type GameVenueRequest struct {
+	StadiumID string `json:"stadium_id"`
+}
+
+// GameVenue is a game's stored venue as returned by the data fetcher
+type GameVenue struct {
+	ID          string `json:"id"`
+	GameID      string `json:"game_id"`
+	StadiumID   string `json:"stadium_id"`
+	StadiumName string `json:"stadium_name"`
+	VenueSource string `json:"venue_source"` // home_team, mlb or manual
+	NeutralSite bool   `json:"neutral_site"` // not the home team's park
+}
+
+// putGameVenueHandler overrides where a game is played, for neutral-site
+// games the schedule gets wrong. Simulations of the game then use the new
+// park's factors, altitude and weather. The data fetcher stores it as a
+// manual override, which later schedule fetches leave alone.
+func (s *Server) putGameVenueHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	gameID := mux.Vars(r)["id"]
+	var req GameVenueRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+	if req.StadiumID == "" {
+		writeError(w, "stadium_id is required", http.StatusUnprocessableEntity)
+		return
+	}
+
+	body, _ := json.Marshal(req)
+	upstreamReq, err := http.NewRequestWithContext(r.Context(), http.MethodPut,
+		s.config.DataFetcherURL+"/games/"+url.PathEscape(gameID)+"/venue", bytes.NewReader(body))
+	if err != nil {
+		writeError(w, "Failed to build data fetcher request", http.StatusInternalServerError)
+		return
+	}
+	upstreamReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.dataFetcherClient.Do(upstreamReq)
+	if err != nil {
+		writeError(w, "Failed to communicate with data fetcher", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		writeError(w, "Game not found", http.StatusNotFound)
+		return
+	case resp.StatusCode == http.StatusUnprocessableEntity:
+		writeError(w, "Stadium not found", http.StatusUnprocessableEntity)
+		return
+	case resp.StatusCode >= 400:
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	var venue GameVenue
+	if err := json.NewDecoder(resp.Body).Decode(&venue); err != nil {
+		writeError(w, "Failed to parse data fetcher response", http.StatusInternalServerError)
+		return
+	}
+
+	// Cached game and weather responses carry the old venue
+	s.queryCache.Clear()
+	writeJSON(w, venue)
+}
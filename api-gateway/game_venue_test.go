@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutGameVenueHandler(t *testing.T) {
+	var forwarded map[string]string
+	fetcher := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/games/778899/venue" {
+			http.Error(w, `{"detail":"Game not found"}`, http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &forwarded)
+		if forwarded["stadium_id"] != "2681" {
+			http.Error(w, `{"detail":"Stadium not found"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		w.Write([]byte(`{"id": "4f1e", "game_id": "778899", "stadium_id": "c2a0",
+			"stadium_name": "London Stadium", "venue_source": "manual", "neutral_site": true}`))
+	}))
+	defer fetcher.Close()
+
+	keys, err := ParseAPIKeys("admin-key:internal,std-key:standard", "free")
+	assert.NoError(t, err)
+	s := &Server{
+		config:            &Config{DataFetcherURL: fetcher.URL},
+		apiKeys:           keys,
+		queryCache:        NewQueryCache(),
+		dataFetcherClient: NewUpstreamClient("data-fetcher", 2),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/admin/games/{id}/venue", s.putGameVenueHandler).Methods("PUT")
+
+	put := func(id, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/games/"+id+"/venue", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	valid := `{"stadium_id": "2681"}`
+
+	rec := put("778899", "admin-key", valid)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var venue GameVenue
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &venue))
+	assert.Equal(t, "London Stadium", venue.StadiumName)
+	assert.True(t, venue.NeutralSite)
+	assert.Equal(t, "2681", forwarded["stadium_id"])
+
+	// Only internal keys may move games
+	assert.Equal(t, http.StatusForbidden, put("778899", "", valid).Code)
+	assert.Equal(t, http.StatusForbidden, put("778899", "std-key", valid).Code)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, put("778899", "admin-key", `{}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, put("778899", "admin-key", `{"stadium_id": "9999"}`).Code)
+	assert.Equal(t, http.StatusNotFound, put("1", "admin-key", valid).Code)
+}
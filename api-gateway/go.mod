@@ -3,10 +3,12 @@ module github.com/baseball-sim/api-gateway
 go 1.24
 
 require (
+	github.com/baseball-sim/shared v0.0.0
 	github.com/gorilla/handlers v1.5.2
 	github.com/gorilla/mux v1.8.1
 	github.com/jackc/pgx/v5 v5.7.4
 	github.com/rs/cors v1.11.0
+	github.com/stretchr/testify v1.11.1
 )
 
 require (
@@ -17,9 +19,11 @@ require (
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/pashagolub/pgxmock/v4 v4.8.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.11.1 // indirect
 	golang.org/x/crypto v0.37.0 // indirect
 	golang.org/x/sync v0.13.0 // indirect
 	golang.org/x/text v0.24.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// The ID resolver is shared with the sim engine
+replace github.com/baseball-sim/shared => ../shared
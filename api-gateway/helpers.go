@@ -3,17 +3,20 @@ package main
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// parseQueryParams extracts common query parameters from HTTP request
+// parseQueryParams extracts common query parameters from HTTP request.
+// page_size defaults to the route's PageLimits and is capped at its Max.
 func parseQueryParams(r *http.Request) QueryParams {
+	limits := pageLimitsFor(r)
 	params := QueryParams{
 		Page:     1,
-		PageSize: 50,
+		PageSize: limits.Default,
 	}
 
 	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
@@ -23,8 +26,11 @@ func parseQueryParams(r *http.Request) QueryParams {
 	}
 
 	if pageSizeStr := r.URL.Query().Get("page_size"); pageSizeStr != "" {
-		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize > 0 && pageSize <= 200 {
+		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize > 0 {
 			params.PageSize = pageSize
+			if pageSize > limits.Max {
+				params.PageSize = limits.Max
+			}
 		}
 	}
 
@@ -41,6 +47,7 @@ func parseQueryParams(r *http.Request) QueryParams {
 	params.Sort = r.URL.Query().Get("sort")
 	params.Order = r.URL.Query().Get("order")
 	params.Name = r.URL.Query().Get("name")
+	params.DataAsOf = dataAsOfFor(r)
 
 	// Default order to ASC if not specified
 	if params.Order != "desc" {
@@ -55,15 +62,17 @@ func calculateOffset(page, pageSize int) int {
 	return (page - 1) * pageSize
 }
 
-// buildPaginatedResponse creates a paginated response
-func buildPaginatedResponse(data interface{}, total, page, pageSize int) PaginatedResponse {
-	totalPages := (total + pageSize - 1) / pageSize
+// buildPaginatedResponse creates a paginated response for the page params
+// selected
+func buildPaginatedResponse(data interface{}, total int, params QueryParams) PaginatedResponse {
+	totalPages := (total + params.PageSize - 1) / params.PageSize
 	return PaginatedResponse{
 		Data:       data,
 		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
 		TotalPages: totalPages,
+		DataAsOf:   params.DataAsOf,
 	}
 }
 
@@ -131,29 +140,40 @@ func buildWhereClause(params QueryParams, tableName string) (string, []interface
 	return whereClause, args
 }
 
-// buildOrderClause builds SQL ORDER BY clause
-func buildOrderClause(params QueryParams, tableName string, defaultSort string) string {
-	sortField := defaultSort
-	if params.Sort != "" {
-		// Validate sort field to prevent SQL injection
-		allowedSorts := map[string]bool{
-			"name":          true,
-			"created_at":    true,
-			"updated_at":    true,
-			"game_date":     true,
-			"season":        true,
-			"position":      true,
-			"last_name":     true,
-			"first_name":    true,
-			"jersey_number": true,
-			"team_id":       true,
-		}
-		if allowedSorts[params.Sort] {
-			sortField = params.Sort
-		}
+// teamListSorts, playerListSorts and gameListSorts map the ?sort= values each
+// list accepts to the columns they order by; anything else falls back to the
+// list's default order
+var (
+	teamListSorts = map[string]string{
+		"name":       "t.name",
+		"created_at": "t.created_at",
+		"updated_at": "t.updated_at",
+	}
+	playerListSorts = map[string]string{
+		"last_name":     "p.last_name",
+		"first_name":    "p.first_name",
+		"position":      "p.position",
+		"jersey_number": "p.jersey_number",
+		"team_id":       "p.team_id",
+		"created_at":    "p.created_at",
+		"updated_at":    "p.updated_at",
+	}
+	gameListSorts = map[string]string{
+		"game_date":  "g.game_date",
+		"season":     "g.season",
+		"created_at": "g.created_at",
+		"updated_at": "g.updated_at",
 	}
+)
 
-	return " ORDER BY " + tableName + "." + sortField + " " + strings.ToUpper(params.Order)
+// buildOrderClause builds SQL ORDER BY clause from a list's sorts, so only
+// known columns reach the query
+func buildOrderClause(params QueryParams, sorts map[string]string, defaultSort string) string {
+	column, ok := sorts[params.Sort]
+	if !ok {
+		column = sorts[defaultSort]
+	}
+	return " ORDER BY " + column + " " + strings.ToUpper(params.Order)
 }
 
 // contextWithTimeout creates a context with a default timeout
@@ -208,8 +228,8 @@ func sanitizeStringParam(param string) string {
 // validateSeasonParam validates season parameter
 func validateSeasonParam(season int) error {
 	currentYear := time.Now().Year()
-	if season < 1876 || season > currentYear+1 {
-		return fmt.Errorf("invalid season: must be between 1876 and %d", currentYear+1)
+	if season < firstMLBSeason || season > currentYear+1 {
+		return fmt.Errorf("invalid season: must be between %d and %d", firstMLBSeason, currentYear+1)
 	}
 	return nil
 }
@@ -253,39 +273,21 @@ func parseIntParam(param string, defaultValue int) int {
 
 // formatGameStatus formats game status for display
 func formatGameStatus(status string) string {
-	switch strings.ToUpper(status) {
-	case "SCHEDULED":
-		return "Scheduled"
-	case "LIVE":
-		return "Live"
-	case "FINAL":
-		return "Final"
-	case "POSTPONED":
-		return "Postponed"
-	case "CANCELLED":
-		return "Cancelled"
-	default:
+	registered, ok := gameStatusFor(status)
+	if !ok {
 		return status
 	}
+	for _, v := range gameStatusRegistry {
+		if v.Value == registered {
+			return v.Label
+		}
+	}
+	return status
 }
 
 // isValidPosition validates baseball position
 func isValidPosition(position string) bool {
-	validPositions := map[string]bool{
-		"P":  true, // Pitcher
-		"C":  true, // Catcher
-		"1B": true, // First Base
-		"2B": true, // Second Base
-		"3B": true, // Third Base
-		"SS": true, // Shortstop
-		"LF": true, // Left Field
-		"CF": true, // Center Field
-		"RF": true, // Right Field
-		"DH": true, // Designated Hitter
-		"OF": true, // Outfield (general)
-		"IF": true, // Infield (general)
-	}
-	return validPositions[strings.ToUpper(position)]
+	return enumContains(positionRegistry, position)
 }
 
 // formatTeamName formats team name for display
@@ -299,7 +301,8 @@ func formatTeamName(city, name string) string {
 	return city
 }
 
-// buildPlayersWhereClause builds SQL WHERE clause specifically for players queries
+// buildPlayersWhereClause builds SQL WHERE clause specifically for players
+// queries. params.Team must already be resolved to a team UUID.
 func buildPlayersWhereClause(params QueryParams) (string, []interface{}) {
 	var conditions []string
 	var args []interface{}
@@ -319,7 +322,7 @@ func buildPlayersWhereClause(params QueryParams) (string, []interface{}) {
 	}
 
 	if params.Team != "" {
-		conditions = append(conditions, "(t.id::text = $"+strconv.Itoa(argIndex)+" OR t.team_id = $"+strconv.Itoa(argIndex)+" OR UPPER(t.abbreviation) = UPPER($"+strconv.Itoa(argIndex)+"))")
+		conditions = append(conditions, "t.id = $"+strconv.Itoa(argIndex))
 		args = append(args, params.Team)
 		argIndex++
 	}
@@ -339,6 +342,8 @@ func buildPlayersWhereClause(params QueryParams) (string, []interface{}) {
 }
 
 // buildGamesWhereClause builds SQL WHERE clause specifically for games queries
+// against the denormalized games_read_model table (aliased as g).
+// params.Team must already be resolved to a team UUID.
 func buildGamesWhereClause(params QueryParams) (string, []interface{}) {
 	var conditions []string
 	var args []interface{}
@@ -351,14 +356,21 @@ func buildGamesWhereClause(params QueryParams) (string, []interface{}) {
 	}
 
 	if params.Team != "" {
-		conditions = append(conditions, "(ht.id::text = $"+strconv.Itoa(argIndex)+" OR ht.team_id = $"+strconv.Itoa(argIndex)+" OR UPPER(ht.abbreviation) = UPPER($"+strconv.Itoa(argIndex)+") OR at.id::text = $"+strconv.Itoa(argIndex)+" OR at.team_id = $"+strconv.Itoa(argIndex)+" OR UPPER(at.abbreviation) = UPPER($"+strconv.Itoa(argIndex)+"))")
+		conditions = append(conditions, "(g.home_team_id = $"+strconv.Itoa(argIndex)+" OR g.away_team_id = $"+strconv.Itoa(argIndex)+")")
 		args = append(args, params.Team)
 		argIndex++
 	}
 
 	if params.Status != "" {
-		conditions = append(conditions, "g.status = $"+strconv.Itoa(argIndex))
-		args = append(args, params.Status)
+		conditions = append(conditions, "LOWER(g.status) = ANY($"+strconv.Itoa(argIndex)+")")
+		args = append(args, gameStatusFilterCodes(params.Status))
+		argIndex++
+	}
+
+	if params.GameTypes != nil {
+		condition, codes := gameTypeCondition("g.game_type", params.GameTypes, argIndex)
+		conditions = append(conditions, condition)
+		args = append(args, codes)
 		argIndex++
 	}
 
@@ -379,3 +391,9 @@ func buildGamesWhereClause(params QueryParams) (string, []interface{}) {
 
 	return whereClause, args
 }
+
+// roundTo rounds v to the given number of decimal places
+func roundTo(v float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(v*factor) / factor
+}
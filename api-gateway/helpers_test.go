@@ -197,3 +197,33 @@ func TestParseIntParam(t *testing.T) {
 		})
 	}
 }
+
+// TestBuildGamesWhereClause tests games filters against the read model columns
+func TestBuildGamesWhereClause(t *testing.T) {
+	season := 2024
+	teamID := "7d0e5c1a-2f4b-4c3d-9e8f-1a2b3c4d5e6f"
+	params := QueryParams{Season: &season, Team: teamID, Status: "final", Date: "2024-06-01"}
+
+	where, args := buildGamesWhereClause(params)
+
+	assert.Contains(t, where, "g.season = $1")
+	assert.Contains(t, where, "(g.home_team_id = $2 OR g.away_team_id = $2)")
+	assert.Contains(t, where, "LOWER(g.status) = ANY($3)")
+	assert.Equal(t, gameStatusCodes[GameStatusFinal], args[2], "final matches the stored completed status")
+	assert.Contains(t, where, "g.game_date >= $4 AND g.game_date < $5")
+	assert.NotContains(t, where, "ht.")
+	assert.Len(t, args, 5)
+
+	where, args = buildGamesWhereClause(QueryParams{})
+	assert.Empty(t, where)
+	assert.Empty(t, args)
+}
+
+// TestBuildOrderClause tests sorts map to their list's columns and unknown
+// or other lists' fields fall back to the default
+func TestBuildOrderClause(t *testing.T) {
+	assert.Equal(t, " ORDER BY t.updated_at DESC", buildOrderClause(QueryParams{Sort: "updated_at", Order: "desc"}, teamListSorts, "name"))
+	assert.Equal(t, " ORDER BY t.name ASC", buildOrderClause(QueryParams{Sort: "game_date", Order: "asc"}, teamListSorts, "name"))
+	assert.Equal(t, " ORDER BY p.last_name ASC", buildOrderClause(QueryParams{Sort: "name; DROP TABLE players", Order: "asc"}, playerListSorts, "last_name"))
+	assert.Equal(t, " ORDER BY g.game_date DESC", buildOrderClause(QueryParams{Order: "desc"}, gameListSorts, "game_date"))
+}
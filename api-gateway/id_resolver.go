@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/baseball-sim/shared/ids"
+)
+
+// resolveTeamFilter resolves a ?team= filter in place so list queries can
+// match it by UUID
+func (s *Server) resolveTeamFilter(ctx context.Context, w http.ResponseWriter, params *QueryParams) bool {
+	if params.Team == "" {
+		return true
+	}
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, params.Team)
+	params.Team = resolved.ID
+	return ok
+}
+
+// resolveEntity resolves a client-supplied identifier, writing a 404 when
+// nothing matches and a 409 listing the candidates when several do
+func (s *Server) resolveEntity(ctx context.Context, w http.ResponseWriter, kind ids.Kind, raw string) (ids.Resolved, bool) {
+	resolved, err := s.ids.Resolve(ctx, kind, raw)
+	var ambiguous *ids.AmbiguousError
+	switch {
+	case err == nil:
+		return resolved, true
+	case errors.Is(err, ids.ErrNotFound):
+		writeError(w, kind.Label+" not found", http.StatusNotFound)
+	case errors.As(err, &ambiguous):
+		writeErrorWithDetails(w, fmt.Sprintf("%s ID %q is ambiguous; prefix it with mlbam: or retrosheet:", kind.Label, raw),
+			"ambiguous_id", map[string]interface{}{"matches": ambiguous.Matches}, http.StatusConflict)
+	default:
+		log.Printf("ID resolution error: %v", err)
+		writeError(w, "Failed to look up "+strings.ToLower(kind.Label), http.StatusInternalServerError)
+	}
+	return ids.Resolved{}, false
+}
+
+// EntityAlias maps an extra identifier, e.g. a Retrosheet ID, to an entity
+type EntityAlias struct {
+	EntityType string `json:"entity_type"` // team, player, game, umpire or stadium
+	EntityID   string `json:"entity_id"`   // UUID or MLB ID
+	AliasType  string `json:"alias_type"`  // namespace, e.g. retrosheet
+	Alias      string `json:"alias"`
+}
+
+// AliasesIngestRequest loads aliases in bulk
+type AliasesIngestRequest struct {
+	Aliases []EntityAlias `json:"aliases"`
+	Source  *string       `json:"source,omitempty"`
+}
+
+// maxAliasesPerRequest bounds one ingestion request
+const maxAliasesPerRequest = 5000
+
+// aliasEntityTypes are the entity types aliases can name
+var aliasEntityTypes = map[string]bool{
+	ids.Team.Name: true, ids.Player.Name: true, ids.Game.Name: true,
+	ids.Umpire.Name: true, ids.Stadium.Name: true,
+}
+
+// validate checks each alias before it goes to the data fetcher and returns
+// the index of the first bad one in details. Alias types default to
+// retrosheet; the namespaces resolved from the entity's own columns can't be
+// used.
+func (req *AliasesIngestRequest) validate() (string, map[string]interface{}) {
+	if len(req.Aliases) == 0 {
+		return "aliases is required", nil
+	}
+	if len(req.Aliases) > maxAliasesPerRequest {
+		return "Too many aliases in one request", map[string]interface{}{"max": maxAliasesPerRequest}
+	}
+	for i := range req.Aliases {
+		a := &req.Aliases[i]
+		a.Alias = strings.TrimSpace(a.Alias)
+		a.AliasType = strings.ToLower(strings.TrimSpace(a.AliasType))
+		if a.AliasType == "" {
+			a.AliasType = ids.NamespaceRetrosheet
+		}
+		details := map[string]interface{}{"index": i}
+		switch {
+		case !aliasEntityTypes[a.EntityType]:
+			return "entity_type must be team, player, game, umpire or stadium", details
+		case a.EntityID == "":
+			return "entity_id is required", details
+		case a.Alias == "" || strings.Contains(a.Alias, ":"):
+			return "alias is required and cannot contain ':'", details
+		case a.AliasType == ids.NamespaceUUID || a.AliasType == ids.NamespaceMLBAM || a.AliasType == ids.NamespaceCode:
+			return fmt.Sprintf("%s is not an alias type", a.AliasType), details
+		}
+	}
+	return "", nil
+}
+
+// ingestAliasesHandler stores entity aliases through the data fetcher.
+// Internal keys only.
+func (s *Server) ingestAliasesHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	var req AliasesIngestRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+	if msg, details := req.validate(); msg != "" {
+		writeErrorWithDetails(w, msg, "invalid_alias", details, http.StatusUnprocessableEntity)
+		return
+	}
+
+	body, _ := json.Marshal(req)
+	resp, err := s.dataFetcherClient.Post(r.Context(), s.config.DataFetcherURL+"/aliases", "application/json", bytes.NewReader(body))
+	if err != nil {
+		writeError(w, "Failed to communicate with data fetcher", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse data fetcher response", http.StatusInternalServerError)
+		return
+	}
+
+	// A repointed alias must not keep resolving to the old entity
+	s.ids.Clear()
+	s.queryCache.Clear()
+	writeJSON(w, result)
+}
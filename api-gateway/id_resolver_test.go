@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubIDs resolves from a fixed table and counts lookups
+type stubIDs struct {
+	matches map[string][]ids.Resolved
+	calls   int
+}
+
+func (s *stubIDs) lookup(ctx context.Context, kind ids.Kind, ref ids.Ref) ([]ids.Resolved, error) {
+	s.calls++
+	if ref.Value == "broken" {
+		return nil, errors.New("connection refused")
+	}
+	return s.matches[ref.Value], nil
+}
+
+func TestResolveEntityResponses(t *testing.T) {
+	stub := &stubIDs{matches: map[string][]ids.Resolved{
+		"147": {{ID: "a", ExternalID: "147", MatchedBy: ids.NamespaceMLBAM}},
+		"BOS": {
+			{ID: "b", ExternalID: "111", MatchedBy: ids.NamespaceCode},
+			{ID: "c", ExternalID: "999", MatchedBy: ids.NamespaceRetrosheet},
+		},
+	}}
+	s := &Server{ids: ids.New(stub.lookup, time.Minute)}
+
+	resolve := func(raw string) (ids.Resolved, bool, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		resolved, ok := s.resolveEntity(context.Background(), rec, ids.Team, raw)
+		return resolved, ok, rec
+	}
+
+	resolved, ok, _ := resolve("147")
+	assert.True(t, ok)
+	assert.Equal(t, "a", resolved.ID)
+
+	_, ok, rec := resolve("999999")
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Team not found")
+
+	_, ok, rec = resolve("BOS")
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"ambiguous_id"`)
+	assert.Contains(t, rec.Body.String(), `"matched_by":"retrosheet"`)
+
+	_, ok, rec = resolve("broken")
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// A team filter is replaced by the UUID
+	params := QueryParams{Team: "147"}
+	assert.True(t, s.resolveTeamFilter(context.Background(), httptest.NewRecorder(), &params))
+	assert.Equal(t, "a", params.Team)
+}
+
+func TestIngestAliasesHandler(t *testing.T) {
+	var forwarded AliasesIngestRequest
+	fetcher := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/aliases" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&forwarded)
+		w.Write([]byte(`{"updated": 1, "unknown_entities": []}`))
+	}))
+	defer fetcher.Close()
+
+	keys, err := ParseAPIKeys("admin-key:internal,std-key:standard", "free")
+	assert.NoError(t, err)
+	stub := &stubIDs{matches: map[string][]ids.Resolved{"judga001": {{ID: "a", MatchedBy: ids.NamespaceRetrosheet}}}}
+	s := &Server{
+		config:            &Config{DataFetcherURL: fetcher.URL},
+		apiKeys:           keys,
+		queryCache:        NewQueryCache(),
+		dataFetcherClient: NewUpstreamClient("data-fetcher", 2),
+		ids:               ids.New(stub.lookup, time.Minute),
+	}
+	s.ids.Resolve(context.Background(), ids.Player, "judga001")
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/id-aliases", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		s.ingestAliasesHandler(rec, req)
+		return rec
+	}
+	valid := `{"source": "retrosheet", "aliases": [{"entity_type": "player", "entity_id": "592450", "alias": " judga001 "}]}`
+
+	rec := post("admin-key", valid)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"updated":1`)
+	assert.Equal(t, EntityAlias{EntityType: "player", EntityID: "592450", AliasType: "retrosheet", Alias: "judga001"},
+		forwarded.Aliases[0])
+
+	// Resolved identifiers are looked up again after aliases change
+	calls := stub.calls
+	s.ids.Resolve(context.Background(), ids.Player, "judga001")
+	assert.Equal(t, calls+1, stub.calls)
+
+	// Only internal keys may load aliases
+	assert.Equal(t, http.StatusForbidden, post("std-key", valid).Code)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, post("admin-key", `{"aliases": []}`).Code)
+	rec = post("admin-key", `{"aliases": [{"entity_type": "league", "entity_id": "1", "alias": "AL"}]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "entity_type")
+	rec = post("admin-key", `{"aliases": [{"entity_type": "player", "entity_id": "1", "alias_type": "mlbam", "alias": "2"}]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
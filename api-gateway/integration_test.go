@@ -0,0 +1,430 @@
+//go:build integration
+
+package main
+
+// End-to-end tests of the gateway and sim-engine against a real Postgres.
+// Run them with scripts/integration-test.sh, which starts the database from
+// docker-compose.test.yml and applies the migrations and seed data first.
+//
+// The gateway runs in-process. The sim-engine is a main package in another
+// module, so it can't be imported; it is built from ../sim-engine and started
+// as a child process against the same database.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// integrationEnv is where the harness finds the test database
+type integrationEnv struct {
+	Host, Port, User, Password, Name string
+}
+
+func loadIntegrationEnv() integrationEnv {
+	return integrationEnv{
+		Host:     getEnv("INTEGRATION_DB_HOST", "localhost"),
+		Port:     getEnv("INTEGRATION_DB_PORT", "55432"),
+		User:     getEnv("INTEGRATION_DB_USER", "baseball_user"),
+		Password: getEnv("INTEGRATION_DB_PASSWORD", "baseball_pass"),
+		Name:     getEnv("INTEGRATION_DB_NAME", "baseball_sim_test"),
+	}
+}
+
+func (e integrationEnv) dsn() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", e.User, e.Password, e.Host, e.Port, e.Name)
+}
+
+// integration holds the services every test talks to
+var integration struct {
+	env     integrationEnv
+	db      *pgxpool.Pool
+	engine  string // sim-engine base URL
+	gateway *httptest.Server
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(runIntegration(m))
+}
+
+func runIntegration(m *testing.M) int {
+	integration.env = loadIntegrationEnv()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	db, err := pgxpool.New(ctx, integration.env.dsn())
+	if err == nil {
+		err = db.Ping(ctx)
+	}
+	cancel()
+	if err != nil {
+		log.Printf("Integration database unavailable at %s:%s (start it with scripts/integration-test.sh): %v",
+			integration.env.Host, integration.env.Port, err)
+		return 1
+	}
+	defer db.Close()
+	integration.db = db
+
+	workDir, err := os.MkdirTemp("", "baseball-integration-")
+	if err != nil {
+		log.Printf("Failed to create work dir: %v", err)
+		return 1
+	}
+	defer os.RemoveAll(workDir)
+
+	engine, stopEngine, err := startSimEngine(workDir, integration.env)
+	if err != nil {
+		log.Printf("Failed to start sim-engine: %v", err)
+		return 1
+	}
+	defer stopEngine()
+	integration.engine = engine
+
+	gateway, err := newIntegrationGateway(integration.env, engine)
+	if err != nil {
+		log.Printf("Failed to start gateway: %v", err)
+		return 1
+	}
+	integration.gateway = httptest.NewServer(gateway.handler())
+	defer func() {
+		integration.gateway.Close()
+		gateway.Shutdown(context.Background())
+	}()
+
+	return m.Run()
+}
+
+// freePort returns a TCP port nothing is listening on
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// startSimEngine builds the sim-engine and runs it against the test database,
+// returning its URL once /health answers
+func startSimEngine(workDir string, env integrationEnv) (string, func(), error) {
+	binary := filepath.Join(workDir, "sim-engine")
+	build := exec.Command("go", "build", "-o", binary, ".")
+	build.Dir = filepath.Join("..", "sim-engine")
+	if out, err := build.CombinedOutput(); err != nil {
+		return "", nil, fmt.Errorf("build failed: %v\n%s", err, out)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return "", nil, err
+	}
+	cmd := exec.Command(binary)
+	cmd.Env = append(os.Environ(),
+		"PORT="+strconv.Itoa(port),
+		"DB_HOST="+env.Host,
+		"DB_PORT="+env.Port,
+		"DB_USER="+env.User,
+		"DB_PASSWORD="+env.Password,
+		"DB_NAME="+env.Name,
+		"WORKERS=2",
+		"OPENWEATHER_API_KEY=",
+		"DEAD_LETTER_DIR="+filepath.Join(workDir, "dead-letters"),
+		"EXPORT_DIR="+filepath.Join(workDir, "exports"),
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return "", nil, err
+	}
+	stop := func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		if resp, err := http.Get(url + "/health"); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return url, stop, nil
+			}
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	stop()
+	return "", nil, fmt.Errorf("sim-engine did not become healthy at %s", url)
+}
+
+// newIntegrationGateway creates a gateway against the test database that
+// forwards simulations to engineURL
+func newIntegrationGateway(env integrationEnv, engineURL string) (*Server, error) {
+	config := NewConfig()
+	config.DBHost, config.DBPort = env.Host, env.Port
+	config.DBUser, config.DBPassword, config.DBName = env.User, env.Password, env.Name
+	config.DBReplicaHost = ""
+	config.SimEngineURL = engineURL
+	config.SimEngineURLs = ""
+	config.APIKeys = ""
+	config.DBStartupMaxWait = 5
+	config.BoxScoreReconcileInterval = 0
+	config.AuditRetentionDays = 0
+	return NewServer(config)
+}
+
+// createScheduledGame adds a game between two seeded teams for today and
+// returns its MLB game ID. The database is disposable, so games are left.
+func createScheduledGame(t *testing.T) string {
+	t.Helper()
+	gameID := "it-" + newQueueID()[:8]
+	tag, err := integration.db.Exec(context.Background(), `
+		INSERT INTO games (game_id, game_date, game_time, home_team_id, away_team_id, stadium_id,
+		                   season, game_type, status)
+		SELECT $1, CURRENT_DATE, '19:05', h.id, a.id, h.stadium_id,
+		       EXTRACT(YEAR FROM CURRENT_DATE)::int, 'R', 'scheduled'
+		FROM teams h, teams a
+		WHERE h.team_id = 'BOS' AND a.team_id = 'NYY'
+	`, gameID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, tag.RowsAffected(), "seed data should include BOS and NYY")
+	return gameID
+}
+
+// randomRunID returns a UUID no run has
+func randomRunID() string {
+	id := newQueueID()
+	return id[0:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:32]
+}
+
+// apiRequest sends a request to the gateway, decoding a JSON response body
+// into out when it is non-nil
+func apiRequest(t *testing.T, baseURL, method, path string, body interface{}, out interface{}) int {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, baseURL+"/api/v1"+path, reader)
+	require.NoError(t, err)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	if out != nil && len(bytes.TrimSpace(raw)) > 0 {
+		require.NoError(t, json.Unmarshal(raw, out), "response: %s", raw)
+	}
+	return resp.StatusCode
+}
+
+// waitForRun polls the run's status through the gateway until it finishes
+func waitForRun(t *testing.T, runID string) map[string]interface{} {
+	t.Helper()
+	deadline := time.Now().Add(90 * time.Second)
+	for time.Now().Before(deadline) {
+		var status map[string]interface{}
+		code := apiRequest(t, integration.gateway.URL, http.MethodGet, "/simulations/"+runID+"/status", nil, &status)
+		require.Equal(t, http.StatusOK, code)
+		switch status["status"] {
+		case "completed", "partial", "failed":
+			return status
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	t.Fatalf("run %s did not finish", runID)
+	return nil
+}
+
+// TestIntegrationSimulationHappyPath simulates a game through the gateway
+// and reads its status and result back
+func TestIntegrationSimulationHappyPath(t *testing.T) {
+	gameID := createScheduledGame(t)
+
+	var created map[string]interface{}
+	code := apiRequest(t, integration.gateway.URL, http.MethodPost, "/simulations",
+		SimulationRequest{GameID: gameID, SimulationRuns: 50}, &created)
+	require.Equal(t, http.StatusOK, code, "create: %v", created)
+	runID, _ := created["run_id"].(string)
+	require.NotEmpty(t, runID)
+	assert.Equal(t, "started", created["status"])
+
+	status := waitForRun(t, runID)
+	assert.Equal(t, "completed", status["status"])
+	assert.Equal(t, gameID, status["game_id"])
+	assert.EqualValues(t, 50, status["total_runs"])
+	assert.EqualValues(t, 50, status["completed_runs"])
+	assert.EqualValues(t, 1, status["progress"])
+
+	var result map[string]interface{}
+	code = apiRequest(t, integration.gateway.URL, http.MethodGet, "/simulations/"+runID, nil, &result)
+	require.Equal(t, http.StatusOK, code, "result: %v", result)
+	assert.Equal(t, runID, result["run_id"])
+	assert.EqualValues(t, 50, result["total_simulations"])
+
+	home, _ := result["home_win_probability"].(float64)
+	away, _ := result["away_win_probability"].(float64)
+	assert.InDelta(t, 1.0, home+away, 1e-6, "win probabilities should sum to 1")
+	assert.EqualValues(t, 50, result["home_wins"].(float64)+result["away_wins"].(float64))
+	assert.Greater(t, result["expected_home_score"].(float64)+result["expected_away_score"].(float64), 0.0)
+
+	// The run is listed for its game
+	var listed map[string]interface{}
+	code = apiRequest(t, integration.gateway.URL, http.MethodGet, "/simulations?game_id="+gameID, nil, &listed)
+	require.Equal(t, http.StatusOK, code)
+	assert.Contains(t, fmt.Sprint(listed), runID)
+}
+
+// TestIntegrationSimulationUnknownGame tests the engine's 404 for a game it
+// can't resolve reaches the client as a JSON error
+func TestIntegrationSimulationUnknownGame(t *testing.T) {
+	var apiErr APIError
+	code := apiRequest(t, integration.gateway.URL, http.MethodPost, "/simulations",
+		SimulationRequest{GameID: "it-missing-" + newQueueID()[:8], SimulationRuns: 10}, &apiErr)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.NotEmpty(t, apiErr.Error)
+}
+
+// TestIntegrationSimulationMissingGameID tests the gateway rejects a request
+// without a game before calling the engine
+func TestIntegrationSimulationMissingGameID(t *testing.T) {
+	var apiErr APIError
+	code := apiRequest(t, integration.gateway.URL, http.MethodPost, "/simulations",
+		SimulationRequest{SimulationRuns: 10}, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Game ID is required", apiErr.Error)
+}
+
+// TestIntegrationSimulationOverTierLimit tests anonymous callers are held to
+// the free tier's run limit
+func TestIntegrationSimulationOverTierLimit(t *testing.T) {
+	gameID := createScheduledGame(t)
+
+	var apiErr APIError
+	code := apiRequest(t, integration.gateway.URL, http.MethodPost, "/simulations",
+		SimulationRequest{GameID: gameID, SimulationRuns: apiTiers["free"].MaxSimulationRuns + 1}, &apiErr)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Equal(t, "simulation_limit_exceeded", apiErr.Code)
+}
+
+// TestIntegrationSimulationInvalidConfig tests engine config validation
+// errors are surfaced with the engine's status
+func TestIntegrationSimulationInvalidConfig(t *testing.T) {
+	gameID := createScheduledGame(t)
+	seconds := -5.0
+
+	var apiErr APIError
+	code := apiRequest(t, integration.gateway.URL, http.MethodPost, "/simulations", SimulationRequest{
+		GameID:         gameID,
+		SimulationRuns: 10,
+		Config:         RunConfig{"max_duration_seconds": seconds},
+	}, &apiErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Contains(t, apiErr.Error, "max_duration_seconds")
+}
+
+// TestIntegrationSimulationUnknownConfigKey tests the gateway rejects config
+// keys the engine doesn't read before calling the engine
+func TestIntegrationSimulationUnknownConfigKey(t *testing.T) {
+	gameID := createScheduledGame(t)
+
+	var apiErr APIError
+	code := apiRequest(t, integration.gateway.URL, http.MethodPost, "/simulations", map[string]interface{}{
+		"game_id":         gameID,
+		"simulation_runs": 10,
+		"config":          map[string]interface{}{"no_such_option": true},
+	}, &apiErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Equal(t, "unknown_field", apiErr.Code)
+}
+
+// TestIntegrationSimulationEngineConfigKey tests engine config keys reach
+// the engine through the gateway, so a bad value gets the engine's 422
+func TestIntegrationSimulationEngineConfigKey(t *testing.T) {
+	gameID := createScheduledGame(t)
+
+	var apiErr APIError
+	code := apiRequest(t, integration.gateway.URL, http.MethodPost, "/simulations", map[string]interface{}{
+		"game_id":         gameID,
+		"simulation_runs": 10,
+		"config":          map[string]interface{}{"stadium_id": "no-such-stadium"},
+	}, &apiErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Contains(t, apiErr.Error, "stadium")
+}
+
+// TestIntegrationUnknownRun tests status and result lookups for a run that
+// doesn't exist
+func TestIntegrationUnknownRun(t *testing.T) {
+	runID := randomRunID()
+	for _, path := range []string{"/simulations/" + runID + "/status", "/simulations/" + runID} {
+		code := apiRequest(t, integration.gateway.URL, http.MethodGet, path, nil, nil)
+		assert.Equal(t, http.StatusNotFound, code, path)
+	}
+}
+
+// TestIntegrationSimEngineUnavailable tests a gateway whose engine is down
+// answers 503 instead of hanging or failing with a 500
+func TestIntegrationSimEngineUnavailable(t *testing.T) {
+	port, err := freePort()
+	require.NoError(t, err)
+	gateway, err := newIntegrationGateway(integration.env, fmt.Sprintf("http://127.0.0.1:%d", port))
+	require.NoError(t, err)
+	server := httptest.NewServer(gateway.handler())
+	defer func() {
+		server.Close()
+		gateway.Shutdown(context.Background())
+	}()
+
+	gameID := createScheduledGame(t)
+	var apiErr APIError
+	code := apiRequest(t, server.URL, http.MethodPost, "/simulations",
+		SimulationRequest{GameID: gameID, SimulationRuns: 10}, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "Failed to communicate with simulation engine", apiErr.Error)
+
+	code = apiRequest(t, server.URL, http.MethodGet, "/simulations/"+randomRunID()+"/status", nil, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+// TestIntegrationAdvertisedSortFields tests every sort field /meta/enums
+// lists for an endpoint actually sorts it
+func TestIntegrationAdvertisedSortFields(t *testing.T) {
+	var enums struct {
+		SortFields map[string][]string `json:"sort_fields"`
+	}
+	require.Equal(t, http.StatusOK, apiRequest(t, integration.gateway.URL, http.MethodGet, "/meta/enums", nil, &enums))
+	require.NotEmpty(t, enums.SortFields)
+
+	for endpoint, fields := range enums.SortFields {
+		path := strings.TrimPrefix(endpoint, "/api/v1")
+		for _, field := range fields {
+			for _, order := range []string{"asc", "desc"} {
+				code := apiRequest(t, integration.gateway.URL, http.MethodGet, path+"?sort="+field+"&order="+order, nil, nil)
+				assert.Equal(t, http.StatusOK, code, "%s sorted by %s %s", path, field, order)
+			}
+		}
+	}
+}
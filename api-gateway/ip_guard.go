@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// A client is banned when, within one window, it sends at least
+	// defaultAnomalyBurstRequests requests spread over at least
+	// defaultAnomalyBurstPaths distinct paths
+	defaultAnomalyBurstRequests = 300
+	defaultAnomalyBurstPaths    = 30
+	defaultAnomalyWindowSeconds = 10
+
+	// defaultAnomalyBanSeconds is how long an anomalous client stays banned
+	defaultAnomalyBanSeconds = 15 * 60
+
+	// ipGuardCleanupInterval is how often expired windows and bans are dropped
+	ipGuardCleanupInterval = time.Minute
+)
+
+// Reasons a request is blocked by the IP guard
+const (
+	ipBlockDenied     = "deny_list"
+	ipBlockNotAllowed = "not_on_allow_list"
+	ipBlockBanned     = "temporary_ban"
+)
+
+// IPGuardConfig configures anomaly detection. BurstRequests <= 0 disables it.
+type IPGuardConfig struct {
+	BurstRequests int
+	BurstPaths    int
+	Window        time.Duration
+	BanDuration   time.Duration
+}
+
+// ipActivity counts one client's requests in the current window
+type ipActivity struct {
+	windowStart time.Time
+	requests    int
+	paths       map[string]struct{}
+}
+
+// IPGuard enforces static allow/deny lists and temporarily bans clients that
+// burst across many endpoints, the pattern of scrapers and scanners rather
+// than a busy dashboard. It runs in front of the per-IP token bucket.
+type IPGuard struct {
+	allow  []*net.IPNet // empty = every address not denied is allowed
+	deny   []*net.IPNet
+	config IPGuardConfig
+
+	mu       sync.Mutex
+	activity map[string]*ipActivity
+	bans     map[string]time.Time // ban expiry by IP
+
+	now func() time.Time
+}
+
+// ParseIPList parses a comma-separated list of IPs and CIDR ranges
+func ParseIPList(spec string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP %q", entry)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q", entry)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// NewIPGuard builds a guard from comma-separated allow and deny lists
+func NewIPGuard(allowSpec, denySpec string, config IPGuardConfig) (*IPGuard, error) {
+	allow, err := ParseIPList(allowSpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP allow list: %w", err)
+	}
+	deny, err := ParseIPList(denySpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP deny list: %w", err)
+	}
+
+	return &IPGuard{
+		allow:    allow,
+		deny:     deny,
+		config:   config,
+		activity: make(map[string]*ipActivity),
+		bans:     make(map[string]time.Time),
+		now:      time.Now,
+	}, nil
+}
+
+// containsIP reports whether any network contains ip
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check decides whether a request from ip for path may proceed. When it may
+// not, reason says why and retryAfter is set for temporary bans.
+func (g *IPGuard) Check(ip, path string) (allowed bool, reason string, retryAfter time.Duration) {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		if containsIP(g.deny, parsed) {
+			return false, ipBlockDenied, 0
+		}
+		if len(g.allow) > 0 && !containsIP(g.allow, parsed) {
+			return false, ipBlockNotAllowed, 0
+		}
+	} else if len(g.allow) > 0 {
+		return false, ipBlockNotAllowed, 0
+	}
+
+	if g.config.BurstRequests <= 0 {
+		return true, "", 0
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	if until, banned := g.bans[ip]; banned {
+		if now.Before(until) {
+			return false, ipBlockBanned, until.Sub(now)
+		}
+		delete(g.bans, ip)
+		appLogger.Info("IP ban expired", map[string]interface{}{"audit": true, "event": "ip_ban_expired", "ip": ip})
+	}
+
+	a, ok := g.activity[ip]
+	if !ok || now.Sub(a.windowStart) >= g.config.Window {
+		a = &ipActivity{windowStart: now, paths: make(map[string]struct{})}
+		g.activity[ip] = a
+	}
+	a.requests++
+	// Only track paths up to the threshold so a scanner can't grow the set
+	if len(a.paths) < g.config.BurstPaths {
+		a.paths[path] = struct{}{}
+	}
+
+	if a.requests >= g.config.BurstRequests && len(a.paths) >= g.config.BurstPaths {
+		until := now.Add(g.config.BanDuration)
+		g.bans[ip] = until
+		delete(g.activity, ip)
+		appLogger.Warn("IP temporarily banned for anomalous traffic", map[string]interface{}{
+			"audit":          true,
+			"event":          "ip_banned",
+			"ip":             ip,
+			"requests":       a.requests,
+			"distinct_paths": len(a.paths),
+			"window_seconds": g.config.Window.Seconds(),
+			"banned_until":   until.UTC().Format(time.RFC3339),
+		})
+		return false, ipBlockBanned, g.config.BanDuration
+	}
+	return true, "", 0
+}
+
+// cleanup drops finished windows and expired bans
+func (g *IPGuard) cleanup() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	for ip, a := range g.activity {
+		if now.Sub(a.windowStart) >= g.config.Window {
+			delete(g.activity, ip)
+		}
+	}
+	for ip, until := range g.bans {
+		if !now.Before(until) {
+			delete(g.bans, ip)
+		}
+	}
+}
+
+// runCleanup periodically drops stale state until the process exits
+func (g *IPGuard) runCleanup() {
+	for {
+		time.Sleep(ipGuardCleanupInterval)
+		g.cleanup()
+	}
+}
+
+// clientIP returns the caller's address without the port. X-Forwarded-For
+// is only read when the connection comes from a trusted proxy, and then
+// from the right: the client is the last hop not added by a trusted proxy,
+// since anything further left is whatever the client sent.
+func clientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	peer := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		peer = host
+	}
+	if ip := net.ParseIP(peer); ip == nil || !containsIP(trustedProxies, ip) {
+		return peer
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// A malformed hop can't be traced further than the proxy that sent it
+			return peer
+		}
+		peer = ip.String()
+		if !containsIP(trustedProxies, ip) {
+			return peer
+		}
+	}
+	return peer
+}
+
+// clientIP is the caller's address as seen through the configured proxies
+func (s *Server) clientIP(r *http.Request) string {
+	return clientIP(r, s.trustedProxies)
+}
+
+// ipGuardMiddleware blocks denied, unlisted and temporarily banned clients
+// before routing, so probes of unknown paths count toward anomaly detection
+func (s *Server) ipGuardMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := s.clientIP(r)
+		allowed, reason, retryAfter := s.ipGuard.Check(ip, r.URL.Path)
+		if !allowed {
+			if reason == ipBlockBanned {
+				w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+				writeError(w, "Too many requests across endpoints; temporarily blocked", http.StatusForbidden)
+				return
+			}
+			appLogger.Warn("Request blocked by IP list", map[string]interface{}{
+				"audit": true, "event": "ip_blocked", "ip": ip, "reason": reason, "path": r.URL.Path,
+			})
+			writeError(w, "Access denied", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIPGuard(t *testing.T, allow, deny string, config IPGuardConfig) (*IPGuard, *time.Time) {
+	appLogger = NewStructuredLogger(io.Discard)
+	guard, err := NewIPGuard(allow, deny, config)
+	assert.NoError(t, err)
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	guard.now = func() time.Time { return now }
+	return guard, &now
+}
+
+// TestParseIPList tests single addresses and CIDR ranges
+func TestParseIPList(t *testing.T) {
+	nets, err := ParseIPList(" 10.0.0.0/8, 192.168.1.5 ,2001:db8::1,")
+	assert.NoError(t, err)
+	assert.Len(t, nets, 3)
+	assert.True(t, containsIP(nets, []byte{10, 1, 2, 3}))
+	assert.False(t, containsIP(nets, []byte{192, 168, 1, 6}))
+
+	_, err = ParseIPList("10.0.0.0/33")
+	assert.Error(t, err)
+	_, err = ParseIPList("not-an-ip")
+	assert.Error(t, err)
+}
+
+// TestIPGuardLists tests deny precedence and allow-list enforcement
+func TestIPGuardLists(t *testing.T) {
+	guard, _ := newTestIPGuard(t, "10.0.0.0/8", "10.0.0.66", IPGuardConfig{})
+
+	allowed, _, _ := guard.Check("10.1.1.1", "/api/v1/teams")
+	assert.True(t, allowed)
+
+	allowed, reason, _ := guard.Check("10.0.0.66", "/api/v1/teams")
+	assert.False(t, allowed)
+	assert.Equal(t, ipBlockDenied, reason)
+
+	allowed, reason, _ = guard.Check("203.0.113.9", "/api/v1/teams")
+	assert.False(t, allowed)
+	assert.Equal(t, ipBlockNotAllowed, reason)
+}
+
+// TestIPGuardBansBurstAcrossEndpoints tests that only bursts spread over many
+// paths trigger a ban, and that the ban expires
+func TestIPGuardBansBurstAcrossEndpoints(t *testing.T) {
+	config := IPGuardConfig{BurstRequests: 20, BurstPaths: 5, Window: 10 * time.Second, BanDuration: time.Minute}
+	guard, now := newTestIPGuard(t, "", "", config)
+
+	// A dashboard polling one endpoint hard is left to the rate limiter
+	for i := 0; i < 50; i++ {
+		allowed, _, _ := guard.Check("198.51.100.1", "/api/v1/games")
+		assert.True(t, allowed)
+	}
+
+	// A scanner walking many paths is banned at the threshold
+	var reason string
+	var retryAfter time.Duration
+	allowed := true
+	for i := 0; i < config.BurstRequests && allowed; i++ {
+		allowed, reason, retryAfter = guard.Check("198.51.100.2", fmt.Sprintf("/api/v1/players/%d", i%8))
+	}
+	assert.False(t, allowed)
+	assert.Equal(t, ipBlockBanned, reason)
+	assert.Equal(t, time.Minute, retryAfter)
+
+	*now = now.Add(30 * time.Second)
+	allowed, reason, retryAfter = guard.Check("198.51.100.2", "/api/v1/teams")
+	assert.False(t, allowed)
+	assert.Equal(t, ipBlockBanned, reason)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	*now = now.Add(31 * time.Second)
+	allowed, _, _ = guard.Check("198.51.100.2", "/api/v1/teams")
+	assert.True(t, allowed)
+}
+
+// TestIPGuardWindowResets tests that requests spread over windows don't accumulate
+func TestIPGuardWindowResets(t *testing.T) {
+	config := IPGuardConfig{BurstRequests: 10, BurstPaths: 3, Window: 10 * time.Second, BanDuration: time.Minute}
+	guard, now := newTestIPGuard(t, "", "", config)
+
+	for i := 0; i < 30; i++ {
+		allowed, _, _ := guard.Check("198.51.100.3", fmt.Sprintf("/p/%d", i))
+		assert.True(t, allowed)
+		if i%9 == 8 {
+			*now = now.Add(11 * time.Second)
+		}
+	}
+
+	guard.cleanup()
+	assert.Empty(t, guard.bans)
+}
+
+// TestIPGuardMiddleware tests the response for blocked clients
+func TestIPGuardMiddleware(t *testing.T) {
+	guard, _ := newTestIPGuard(t, "", "203.0.113.0/24", IPGuardConfig{})
+	s := &Server{ipGuard: guard}
+	handler := s.ipGuardMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/api/v1/teams", nil)
+	req.RemoteAddr = "203.0.113.7:52311"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/teams", nil)
+	req.RemoteAddr = "198.51.100.7:52311"
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// TestClientIP tests address extraction with and without a proxy
+func TestClientIP(t *testing.T) {
+	trusted, err := ParseIPList("10.0.0.0/8")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "198.51.100.7:52311"
+	assert.Equal(t, "198.51.100.7", clientIP(req, trusted))
+
+	// Through the proxy, the client is the hop it appended
+	req.RemoteAddr = "10.0.0.2:41000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.1")
+	assert.Equal(t, "203.0.113.1", clientIP(req, trusted))
+
+	// Chained proxies are skipped from the right
+	req.Header.Set("X-Forwarded-For", "203.0.113.1, 10.0.0.9")
+	assert.Equal(t, "203.0.113.1", clientIP(req, trusted))
+}
+
+// TestClientIPSpoofing tests a client can't pick its own address with
+// X-Forwarded-For
+func TestClientIPSpoofing(t *testing.T) {
+	trusted, err := ParseIPList("10.0.0.0/8")
+	require.NoError(t, err)
+
+	// Sent straight to the gateway, the header is ignored
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "198.51.100.7:52311"
+	req.Header.Set("X-Forwarded-For", "192.0.2.1")
+	assert.Equal(t, "198.51.100.7", clientIP(req, trusted))
+	assert.Equal(t, "198.51.100.7", clientIP(req, nil), "no proxies are trusted by default")
+
+	// Sent through the proxy, a forged first hop is left of the real one
+	req.RemoteAddr = "10.0.0.2:41000"
+	req.Header.Set("X-Forwarded-For", "192.0.2.1, 198.51.100.7")
+	assert.Equal(t, "198.51.100.7", clientIP(req, trusted))
+
+	// Junk can't stand in for an address
+	req.Header.Set("X-Forwarded-For", "198.51.100.7, "+strings.Repeat("x", 80))
+	assert.Equal(t, "10.0.0.2", clientIP(req, trusted))
+
+	// A denied client stays denied whatever it claims
+	guard, err := NewIPGuard("", "198.51.100.0/24", IPGuardConfig{})
+	require.NoError(t, err)
+	s := &Server{ipGuard: guard, trustedProxies: trusted}
+	handler := s.ipGuardMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req = httptest.NewRequest("GET", "/api/v1/teams", nil)
+	req.RemoteAddr = "198.51.100.7:52311"
+	req.Header.Set("X-Forwarded-For", "192.0.2.1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Key cases accepted by ?case=
+const (
+	caseSnake = "snake"
+	caseCamel = "camel"
+)
+
+// Only identifier-style keys are converted. Stat abbreviations (AVG, wOBA,
+// K/9, WAR_batting_runs), codes and IDs used as map keys are data, not field
+// names, and pass through unchanged.
+var (
+	camelKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9]*(?:[A-Z][a-z0-9]+)+$`)
+	snakeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9]*(?:_[a-z0-9]+)+$`)
+)
+
+// toSnakeKey converts a camelCase key such as homeRuns to home_runs
+func toSnakeKey(key string) string {
+	if !camelKeyPattern.MatchString(key) {
+		return key
+	}
+	var b strings.Builder
+	for _, r := range key {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// toCamelKey converts a snake_case key such as home_team to homeTeam
+func toCamelKey(key string) string {
+	if !snakeKeyPattern.MatchString(key) {
+		return key
+	}
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// convertKeys rewrites object keys throughout a decoded JSON value
+func convertKeys(value interface{}, convert func(string) string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[convert(key)] = convertKeys(item, convert)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertKeys(item, convert)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// jsonCaseMiddleware applies ?case=snake|camel to JSON responses, including
+// those proxied from the simulation engine. Without the parameter responses
+// are sent as the handler wrote them.
+func (s *Server) jsonCaseMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Export downloads stream files, which aren't JSON to rewrite and
+		// mustn't be held in memory
+		if r.URL.Query().Get("case") == "" || strings.HasPrefix(r.URL.Path, exportsPath) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var convert func(string) string
+		switch r.URL.Query().Get("case") {
+		case caseSnake:
+			convert = toSnakeKey
+		case caseCamel:
+			convert = toCamelKey
+		default:
+			writeError(w, "Invalid case parameter: use snake or camel", http.StatusBadRequest)
+			return
+		}
+
+		bw := newBufferedResponseWriter()
+		next.ServeHTTP(bw, r)
+
+		if strings.HasPrefix(bw.header.Get("Content-Type"), "application/json") {
+			decoder := json.NewDecoder(bytes.NewReader(bw.body.Bytes()))
+			decoder.UseNumber()
+			var body interface{}
+			if err := decoder.Decode(&body); err == nil {
+				var out bytes.Buffer
+				if err := json.NewEncoder(&out).Encode(convertKeys(body, convert)); err == nil {
+					bw.body = out
+					bw.header.Del("Content-Length")
+				}
+			}
+		}
+		bw.writeTo(w)
+	})
+}
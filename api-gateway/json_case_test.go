@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestKeyCaseConversion tests identifier keys convert while stat names and
+// codes are left alone
+func TestKeyCaseConversion(t *testing.T) {
+	for key, want := range map[string]string{
+		"homeRuns":    "home_runs",
+		"gamesPlayed": "games_played",
+		"home_team":   "home_team",
+		"wOBA":        "wOBA",
+		"xFIP":        "xFIP",
+		"AVG":         "AVG",
+		"K/9":         "K/9",
+		"NYY":         "NYY",
+		"660271":      "660271",
+	} {
+		assert.Equal(t, want, toSnakeKey(key), key)
+	}
+
+	for key, want := range map[string]string{
+		"home_team":        "homeTeam",
+		"last_10_wins":     "last10Wins",
+		"created_at":       "createdAt",
+		"status":           "status",
+		"WAR_batting_runs": "WAR_batting_runs",
+		"wRC+":             "wRC+",
+	} {
+		assert.Equal(t, want, toCamelKey(key), key)
+	}
+}
+
+// TestJSONCaseMiddleware tests nested keys are rewritten on request and
+// responses are untouched otherwise
+func TestJSONCaseMiddleware(t *testing.T) {
+	s := &Server{}
+	handler := s.jsonCaseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"home_team": map[string]interface{}{"created_at": "2024-04-01"},
+			"stats":     []interface{}{map[string]interface{}{"homeRuns": 42, "OPS": 1.036}},
+		})
+	}))
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/api/v1/games/1", `{"home_team":{"created_at":"2024-04-01"},"stats":[{"homeRuns":42,"OPS":1.036}]}`},
+		{"/api/v1/games/1?case=snake", `{"home_team":{"created_at":"2024-04-01"},"stats":[{"home_runs":42,"OPS":1.036}]}`},
+		{"/api/v1/games/1?case=camel", `{"homeTeam":{"createdAt":"2024-04-01"},"stats":[{"homeRuns":42,"OPS":1.036}]}`},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, tt.path)
+		assert.JSONEq(t, tt.want, w.Body.String(), tt.path)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/games/1?case=kebab", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
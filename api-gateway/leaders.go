@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultLeadersLimit = 10
+	maxLeadersLimit     = 50
+)
+
+// leaderStat is a season aggregate that can be led, with the direction that
+// is better. Rate stats name the playing time that qualifies a player.
+type leaderStat struct {
+	Key           string // key in player_season_aggregates.aggregated_stats
+	LowerIsBetter bool
+	Qualifier     string // PA or IP; empty for counting stats
+}
+
+// defaultLeaderQualifiers are the plate appearances and innings a player
+// needs per team game to lead a rate stat
+var defaultLeaderQualifiers = map[string]float64{"PA": 3.1, "IP": 1.0}
+
+// parseLeaderQualifiers overrides the default qualifiers from "PA=3.1,IP=1"
+func parseLeaderQualifiers(spec string) (map[string]float64, error) {
+	qualifiers := make(map[string]float64, len(defaultLeaderQualifiers))
+	for key, perGame := range defaultLeaderQualifiers {
+		qualifiers[key] = perGame
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, value, found := strings.Cut(entry, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		if _, known := defaultLeaderQualifiers[key]; !found || !known {
+			return nil, fmt.Errorf("invalid leader qualifier %q (expected PA=n or IP=n)", entry)
+		}
+		perGame, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || perGame < 0 {
+			return nil, fmt.Errorf("invalid leader qualifier %q (expected a non-negative number)", entry)
+		}
+		qualifiers[key] = perGame
+	}
+	return qualifiers, nil
+}
+
+// leaderStats lists the stats each group can be led in, by lower-case
+// ?stat= value
+var leaderStats = map[string]map[string]leaderStat{
+	"batting": {
+		"hr":   {Key: "HR"},
+		"avg":  {Key: "AVG", Qualifier: "PA"},
+		"obp":  {Key: "OBP", Qualifier: "PA"},
+		"slg":  {Key: "SLG", Qualifier: "PA"},
+		"h":    {Key: "H"},
+		"rbi":  {Key: "RBI"},
+		"sb":   {Key: "SB"},
+		"woba": {Key: "wOBA", Qualifier: "PA"},
+		"wrc+": {Key: "wRC+", Qualifier: "PA"},
+		"bb%":  {Key: "BB%", Qualifier: "PA"},
+		"k%":   {Key: "K%", LowerIsBetter: true, Qualifier: "PA"},
+	},
+	"pitching": {
+		"era":  {Key: "ERA", LowerIsBetter: true, Qualifier: "IP"},
+		"so":   {Key: "SO"},
+		"w":    {Key: "W"},
+		"sv":   {Key: "SV"},
+		"whip": {Key: "WHIP", LowerIsBetter: true, Qualifier: "IP"},
+		"fip":  {Key: "FIP", LowerIsBetter: true, Qualifier: "IP"},
+		"k/9":  {Key: "K/9", Qualifier: "IP"},
+		"ip":   {Key: "IP"},
+	},
+}
+
+// defaultLeaderStats are each group's stat when ?stat= is omitted
+var defaultLeaderStats = map[string]string{"batting": "hr", "pitching": "era"}
+
+// leadersRequest is a validated leaderboard query
+type leadersRequest struct {
+	Season              int
+	Group               string
+	Stat                string
+	Limit               int
+	IncludeNonQualified bool
+}
+
+// parseLeadersRequest reads ?season= (default current), ?group= (batting or
+// pitching), ?stat=, ?limit= and ?include_non_qualified=, returning a message
+// for the first invalid one
+func parseLeadersRequest(query url.Values) (leadersRequest, string) {
+	req := leadersRequest{Season: getCurrentSeason(), Group: "batting", Limit: defaultLeadersLimit}
+
+	if seasonStr := query.Get("season"); seasonStr != "" {
+		season, err := strconv.Atoi(seasonStr)
+		if err != nil || season < firstMLBSeason {
+			return req, "Invalid season parameter"
+		}
+		req.Season = season
+	}
+
+	if group := strings.ToLower(query.Get("group")); group != "" {
+		if _, ok := leaderStats[group]; !ok {
+			return req, "Invalid group parameter (expected batting or pitching)"
+		}
+		req.Group = group
+	}
+
+	req.Stat = defaultLeaderStats[req.Group]
+	if stat := strings.ToLower(query.Get("stat")); stat != "" {
+		if _, ok := leaderStats[req.Group][stat]; !ok {
+			return req, fmt.Sprintf("Invalid stat parameter for %s", req.Group)
+		}
+		req.Stat = stat
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxLeadersLimit {
+			return req, fmt.Sprintf("Invalid limit parameter (expected 1 to %d)", maxLeadersLimit)
+		}
+		req.Limit = limit
+	}
+
+	if includeStr := query.Get("include_non_qualified"); includeStr != "" {
+		include, err := strconv.ParseBool(includeStr)
+		if err != nil {
+			return req, "Invalid include_non_qualified parameter (expected true or false)"
+		}
+		req.IncludeNonQualified = include
+	}
+	return req, ""
+}
+
+// LeaderQualifier is the playing time a leaderboard required: Stat (PA or
+// IP) of at least PerTeamGame times the player's team's games played
+type LeaderQualifier struct {
+	Stat        string  `json:"stat"`
+	PerTeamGame float64 `json:"per_team_game"`
+	Applied     bool    `json:"applied"` // false when include_non_qualified was set
+}
+
+// Leader is one player on a leaderboard
+type Leader struct {
+	Rank       int     `json:"rank"`
+	PlayerID   string  `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	TeamID     *string `json:"team_id"`
+	TeamName   *string `json:"team_name"`
+	Value      float64 `json:"value"`
+}
+
+// rankLeaders numbers leaders already sorted by value, giving ties the same
+// rank
+func rankLeaders(leaders []Leader) {
+	for i := range leaders {
+		if i > 0 && leaders[i].Value == leaders[i-1].Value {
+			leaders[i].Rank = leaders[i-1].Rank
+		} else {
+			leaders[i].Rank = i + 1
+		}
+	}
+}
+
+// leadersQuery selects the players with a numeric value for stat key $3 in
+// season $1's aggregates of type $2, best first. The qualifier clause is
+// appended to the WHERE.
+const leadersQuery = `
+	%s
+	SELECT p.id::text, COALESCE(p.full_name, CONCAT(p.first_name, ' ', p.last_name)),
+	       t.id::text, t.name, (psa.aggregated_stats->>$3)::float8 AS value
+	FROM player_season_aggregates psa
+	JOIN players p ON p.id = psa.player_id
+	LEFT JOIN teams t ON t.id = p.team_id
+	WHERE psa.season = $1 AND psa.stats_type = $2
+	  AND psa.aggregated_stats->>$3 ~ '^-?[0-9]*\.?[0-9]+$'%s
+	ORDER BY value %s, p.last_name
+	LIMIT $4`
+
+// leaderQualifierCTE counts each team's completed regular-season games in
+// season $1, with $7 the regular-season game type codes, and finds the team
+// each player last appeared for that season, so a player who has since moved
+// qualifies on the games of the team the stats were compiled with
+const leaderQualifierCTE = `
+	WITH team_games AS (
+		SELECT t.id AS team_id, COUNT(g.id) AS games
+		FROM teams t
+		LEFT JOIN games g ON (g.home_team_id = t.id OR g.away_team_id = t.id)
+			AND g.season = $1 AND g.status = 'completed' AND %s
+		GROUP BY t.id
+	),
+	season_teams AS (
+		SELECT DISTINCT ON (a.player_id) a.player_id, a.team_id
+		FROM (
+			SELECT player_id, team_id, game_id FROM game_box_score_batting
+			UNION ALL
+			SELECT player_id, team_id, game_id FROM game_box_score_pitching
+		) a
+		JOIN games g ON g.id = a.game_id
+		WHERE g.season = $1
+		ORDER BY a.player_id, g.game_date DESC
+	)`
+
+// leaderQualifierClause keeps players whose stat $5 reaches $6 per game of
+// their season's team, or of the team with the most games for players with
+// no box score appearances that season
+const leaderQualifierClause = `
+	  AND CASE WHEN psa.aggregated_stats->>$5 ~ '^-?[0-9]*\.?[0-9]+$'
+	           THEN (psa.aggregated_stats->>$5)::float8 ELSE 0 END
+	      >= $6 * COALESCE((SELECT tg.games FROM season_teams st
+	                        JOIN team_games tg ON tg.team_id = st.team_id
+	                        WHERE st.player_id = p.id),
+	                       (SELECT MAX(games) FROM team_games))`
+
+// getLeadersHandler lists a season's league leaders in one stat from the
+// players' season aggregates
+func (s *Server) getLeadersHandler(w http.ResponseWriter, r *http.Request) {
+	req, msg := parseLeadersRequest(r.URL.Query())
+	if msg != "" {
+		writeError(w, msg, http.StatusBadRequest)
+		return
+	}
+	stat := leaderStats[req.Group][req.Stat]
+
+	cacheKey := fmt.Sprintf("leaders_%d_%s_%s_%d_%t", req.Season, req.Group, req.Stat, req.Limit, req.IncludeNonQualified)
+	if cached, found := s.queryCache.Get(cacheKey); found {
+		writeJSON(w, cached)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	// Direction comes from the allowlist above
+	order := "DESC"
+	if stat.LowerIsBetter {
+		order = "ASC"
+	}
+	// Rate stats only rank players with enough playing time
+	var qualifier *LeaderQualifier
+	cte, clause := "", ""
+	args := []interface{}{req.Season, req.Group, stat.Key, req.Limit}
+	if stat.Qualifier != "" {
+		qualifier = &LeaderQualifier{Stat: stat.Qualifier, PerTeamGame: s.leaderQualifiers[stat.Qualifier]}
+		qualifier.Applied = !req.IncludeNonQualified && qualifier.PerTeamGame > 0
+	}
+	if qualifier != nil && qualifier.Applied {
+		gameTypeClause, gameTypeArgs := gameTypeCondition("g.game_type", []string{GameTypeRegular}, 7)
+		cte, clause = fmt.Sprintf(leaderQualifierCTE, gameTypeClause), leaderQualifierClause
+		args = append(args, qualifier.Stat, qualifier.PerTeamGame, gameTypeArgs)
+	}
+
+	rows, err := s.readDB().Query(ctx, fmt.Sprintf(leadersQuery, cte, clause, order), args...)
+	if err != nil {
+		log.Printf("Leaders query error: %v", err)
+		writeError(w, "Failed to query leaders", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	leaders := []Leader{}
+	for rows.Next() {
+		var leader Leader
+		if err := rows.Scan(&leader.PlayerID, &leader.PlayerName, &leader.TeamID, &leader.TeamName, &leader.Value); err != nil {
+			log.Printf("Error scanning leader: %v", err)
+			continue
+		}
+		leaders = append(leaders, leader)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Leaders query error: %v", err)
+		writeError(w, "Failed to query leaders", http.StatusInternalServerError)
+		return
+	}
+	rankLeaders(leaders)
+
+	response := map[string]interface{}{
+		"season":    req.Season,
+		"group":     req.Group,
+		"stat":      stat.Key,
+		"qualifier": qualifier,
+		"leaders":   leaders,
+	}
+	s.queryCache.Set(cacheKey, response, 10*time.Minute)
+	writeJSON(w, response)
+}
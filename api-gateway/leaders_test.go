@@ -0,0 +1,88 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLeadersRequest(t *testing.T) {
+	req, msg := parseLeadersRequest(url.Values{})
+	assert.Empty(t, msg)
+	assert.Equal(t, leadersRequest{Season: getCurrentSeason(), Group: "batting", Stat: "hr", Limit: defaultLeadersLimit}, req)
+
+	req, msg = parseLeadersRequest(url.Values{"group": {"Pitching"}, "season": {"2024"}, "limit": {"25"}})
+	assert.Empty(t, msg)
+	assert.Equal(t, leadersRequest{Season: 2024, Group: "pitching", Stat: "era", Limit: 25}, req)
+
+	req, msg = parseLeadersRequest(url.Values{"stat": {"wRC+"}})
+	assert.Empty(t, msg)
+	assert.Equal(t, "wrc+", req.Stat)
+
+	req, msg = parseLeadersRequest(url.Values{"stat": {"avg"}, "include_non_qualified": {"true"}})
+	assert.Empty(t, msg)
+	assert.True(t, req.IncludeNonQualified)
+
+	for _, query := range []url.Values{
+		{"season": {"1800"}},
+		{"group": {"fielding"}},
+		{"stat": {"era"}}, // a pitching stat on the batting board
+		{"limit": {"0"}},
+		{"limit": {"51"}},
+		{"include_non_qualified": {"sometimes"}},
+	} {
+		_, msg := parseLeadersRequest(query)
+		assert.NotEmpty(t, msg, query.Encode())
+	}
+}
+
+func TestLeaderStatsDefaults(t *testing.T) {
+	for group, stat := range defaultLeaderStats {
+		_, ok := leaderStats[group][stat]
+		assert.True(t, ok, "default %s stat %s is a leader stat", group, stat)
+	}
+	assert.True(t, leaderStats["pitching"]["era"].LowerIsBetter)
+	assert.False(t, leaderStats["batting"]["hr"].LowerIsBetter)
+}
+
+func TestLeaderStatQualifiers(t *testing.T) {
+	for group, stats := range leaderStats {
+		for name, stat := range stats {
+			if stat.Qualifier != "" {
+				_, ok := defaultLeaderQualifiers[stat.Qualifier]
+				assert.True(t, ok, "%s %s qualifier %s has a default", group, name, stat.Qualifier)
+			}
+		}
+	}
+	assert.Equal(t, "PA", leaderStats["batting"]["avg"].Qualifier)
+	assert.Equal(t, "IP", leaderStats["pitching"]["era"].Qualifier)
+	assert.Empty(t, leaderStats["batting"]["hr"].Qualifier, "counting stats need no qualifier")
+}
+
+func TestParseLeaderQualifiers(t *testing.T) {
+	qualifiers, err := parseLeaderQualifiers("")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"PA": 3.1, "IP": 1.0}, qualifiers)
+
+	qualifiers, err = parseLeaderQualifiers(" ip=0.8 ")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"PA": 3.1, "IP": 0.8}, qualifiers)
+	assert.Equal(t, 1.0, defaultLeaderQualifiers["IP"], "overrides leave the defaults alone")
+
+	for _, spec := range []string{"AB=3", "PA", "PA=lots", "IP=-1"} {
+		_, err := parseLeaderQualifiers(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestRankLeaders(t *testing.T) {
+	leaders := []Leader{{Value: 41}, {Value: 38}, {Value: 38}, {Value: 35}}
+	rankLeaders(leaders)
+
+	var ranks []int
+	for _, leader := range leaders {
+		ranks = append(ranks, leader.Rank)
+	}
+	assert.Equal(t, []int{1, 2, 2, 4}, ranks, "tied players share a rank")
+}
@@ -3,18 +3,23 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/baseball-sim/shared/ids"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/jackc/pgx/v5"
@@ -73,11 +78,47 @@ var appLogger *StructuredLogger
 
 type Server struct {
 	db         *pgxpool.Pool
+	dbRouter   *DBRouter
 	router     *mux.Router
 	httpServer *http.Server
 	config     *Config
 	rateLimiter *RateLimiter
+	ipGuard     *IPGuard
+
+	// Peers whose X-Forwarded-For is believed
+	trustedProxies []*net.IPNet
 	queryCache *QueryCache
+
+	// Pooled clients for proxied calls
+	simEngineClient   *UpstreamClient
+	dataFetcherClient *UpstreamClient
+
+	apiKeys *APIKeyStore
+
+	// Resolves the UUIDs, MLBAM IDs and aliases clients send for entities
+	ids *ids.Resolver
+
+	// Degraded-mode state used while the primary database is unreachable
+	staleCache      *StaleCache
+	simulationQueue *SimulationQueue
+	stopBackground  context.CancelFunc
+
+	// Refreshes popular responses on cron schedules
+	precompute *PrecomputeScheduler
+
+	// Last update of the core tables, reported as X-Data-As-Of
+	freshness *FreshnessStatus
+
+	// Playing time per team game that qualifies players for rate-stat
+	// leaderboards, by PA or IP
+	leaderQualifiers map[string]float64
+
+	// simEngines balances requests over sim-engine replicas; nil when
+	// SIM_ENGINE_URLS is unset and SimEngineURL is used directly
+	simEngines *UpstreamPool
+
+	// False during a degraded start-up, when only /health is served
+	dbReady atomic.Bool
 }
 
 // QueryCache implements in-memory caching for database query results
@@ -106,15 +147,12 @@ func (qc *QueryCache) Get(key string) (interface{}, bool) {
 	defer qc.mu.RUnlock()
 
 	entry, exists := qc.cache[key]
-	if !exists {
-		return nil, false
-	}
-
-	// Check if expired
-	if time.Since(entry.timestamp) > entry.ttl {
+	if !exists || time.Since(entry.timestamp) > entry.ttl {
+		appMetrics.RecordCache("query", false)
 		return nil, false
 	}
 
+	appMetrics.RecordCache("query", true)
 	return entry.data, true
 }
 
@@ -184,6 +222,12 @@ func NewRateLimiter(rate, burst int) *RateLimiter {
 }
 
 func (rl *RateLimiter) Allow(ip string) bool {
+	return rl.AllowN(ip, 1)
+}
+
+// AllowN spends cost tokens from the client's bucket, or none if it holds
+// fewer than cost
+func (rl *RateLimiter) AllowN(ip string, cost int) bool {
 	rl.mu.Lock()
 	v, exists := rl.visitors[ip]
 	if !exists {
@@ -206,8 +250,8 @@ func (rl *RateLimiter) Allow(ip string) bool {
 	tokensToAdd := int(elapsed.Minutes() * float64(rl.rate))
 	v.tokens = min(v.tokens+tokensToAdd, rl.burst)
 
-	if v.tokens > 0 {
-		v.tokens--
+	if v.tokens >= cost {
+		v.tokens -= cost
 		return true
 	}
 	return false
@@ -240,8 +284,72 @@ type Config struct {
 	DBUser         string
 	DBPassword     string
 	DBName         string
+	DBReplicaHost  string
+	DBReplicaPort  string
 	SimEngineURL   string
 	DataFetcherURL string
+
+	// Comma-separated sim-engine replicas, optionally "region=url", which
+	// replace SimEngineURL; Region is the gateway's own region
+	SimEngineURLs         string
+	Region                string
+	ReplicaHealthInterval int
+
+	// Max concurrent in-flight requests per upstream service
+	UpstreamMaxConcurrency int
+
+	// API keys as "key:tier,..." and the tier used for requests without a key
+	APIKeys        string
+	DefaultAPITier string
+
+	// Largest accepted POST body
+	MaxRequestBodyBytes int
+
+	// How long (seconds) GET responses may be served stale during a database
+	// outage, and how many simulations may wait for it to recover
+	StaleCacheMaxAge    int
+	SimulationQueueSize int
+
+	// Comma-separated IPs/CIDRs; an empty allow list admits every address
+	// that isn't denied
+	IPAllowList string
+	IPDenyList  string
+
+	// Comma-separated IPs/CIDRs of the proxies in front of the gateway;
+	// X-Forwarded-For is ignored on connections from anywhere else
+	TrustedProxies string
+
+	// Ban clients sending AnomalyBurstRequests requests over AnomalyBurstPaths
+	// distinct paths within AnomalyWindowSeconds (0 requests disables)
+	AnomalyBurstRequests int
+	AnomalyBurstPaths    int
+	AnomalyWindowSeconds int
+	AnomalyBanSeconds    int
+
+	// Start-up waits up to DBStartupMaxWait seconds for the database, retrying
+	// with exponential backoff from DBStartupRetryMs. With DBStartupDegraded
+	// the gateway then starts anyway, serving only /health until it connects.
+	DBStartupMaxWait  int
+	DBStartupRetryMs  int
+	DBStartupDegraded bool
+
+	// How often (seconds) recent box scores are checked against their plays;
+	// 0 disables the scheduled check
+	BoxScoreReconcileInterval int
+
+	// Cron overrides for the precompute jobs as "name=cron;...", "off"
+	// disabling a job
+	PrecomputeSchedules string
+
+	// Days audit log entries are kept; 0 keeps them forever
+	AuditRetentionDays int
+
+	// Leaderboard qualifier overrides as "PA=3.1,IP=1", per team game
+	LeaderQualifiers string
+
+	// Player photo URL template with {mlb_id} and {width}; empty uses MLB's
+	// image CDN
+	HeadshotURLTemplate string
 }
 
 func NewConfig() *Config {
@@ -252,48 +360,166 @@ func NewConfig() *Config {
 		DBUser:         getEnv("DB_USER", "baseball_user"),
 		DBPassword:     getEnv("DB_PASSWORD", "baseball_pass"),
 		DBName:         getEnv("DB_NAME", "baseball_sim"),
+		DBReplicaHost:  getEnv("DB_REPLICA_HOST", ""),
+		DBReplicaPort:  getEnv("DB_REPLICA_PORT", getEnv("DB_PORT", "5432")),
 		SimEngineURL:   getEnv("SIM_ENGINE_URL", "http://localhost:8081"),
 		DataFetcherURL: getEnv("DATA_FETCHER_URL", "http://localhost:8082"),
+
+		SimEngineURLs:         getEnv("SIM_ENGINE_URLS", ""),
+		Region:                getEnv("GATEWAY_REGION", ""),
+		ReplicaHealthInterval: getEnvInt("REPLICA_HEALTH_INTERVAL", defaultReplicaHealthInterval),
+
+		UpstreamMaxConcurrency: getEnvInt("UPSTREAM_MAX_CONCURRENCY", defaultUpstreamConcurrency),
+
+		APIKeys:        getEnv("API_KEYS", ""),
+		DefaultAPITier: getEnv("DEFAULT_API_TIER", "free"),
+
+		MaxRequestBodyBytes: getEnvInt("MAX_REQUEST_BODY_BYTES", defaultMaxRequestBodyBytes),
+
+		StaleCacheMaxAge:    getEnvInt("STALE_CACHE_MAX_AGE", defaultStaleCacheMaxAge),
+		SimulationQueueSize: getEnvInt("SIMULATION_QUEUE_SIZE", defaultSimulationQueueSize),
+
+		IPAllowList: getEnv("IP_ALLOW_LIST", ""),
+		IPDenyList:  getEnv("IP_DENY_LIST", ""),
+
+		TrustedProxies: getEnv("TRUSTED_PROXIES", ""),
+
+		AnomalyBurstRequests: getEnvInt("ANOMALY_BURST_REQUESTS", defaultAnomalyBurstRequests),
+		AnomalyBurstPaths:    getEnvInt("ANOMALY_BURST_PATHS", defaultAnomalyBurstPaths),
+		AnomalyWindowSeconds: getEnvInt("ANOMALY_WINDOW_SECONDS", defaultAnomalyWindowSeconds),
+		AnomalyBanSeconds:    getEnvInt("ANOMALY_BAN_SECONDS", defaultAnomalyBanSeconds),
+
+		DBStartupMaxWait:  getEnvInt("DB_STARTUP_MAX_WAIT", defaultDBStartupMaxWait),
+		DBStartupRetryMs:  getEnvInt("DB_STARTUP_RETRY_MS", defaultDBStartupRetryMs),
+		DBStartupDegraded: getEnvBool("DB_STARTUP_DEGRADED", false),
+
+		BoxScoreReconcileInterval: getEnvInt("BOX_SCORE_RECONCILE_INTERVAL", defaultBoxScoreReconcileInterval),
+
+		PrecomputeSchedules: getEnv("PRECOMPUTE_SCHEDULES", ""),
+
+		AuditRetentionDays: getEnvInt("AUDIT_RETENTION_DAYS", defaultAuditRetentionDays),
+
+		LeaderQualifiers: getEnv("LEADER_QUALIFIERS", ""),
+
+		HeadshotURLTemplate: getEnv("HEADSHOT_URL_TEMPLATE", ""),
 	}
 }
 
 func NewServer(config *Config) (*Server, error) {
 	// Database connection
-	dbURL := fmt.Sprintf("postgresql://%s:%s@%s:%s/%s",
-		config.DBUser, config.DBPassword, config.DBHost, config.DBPort, config.DBName)
+	db, err := newDBPool(config, config.DBHost, config.DBPort)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait for the database, which may still be starting under docker-compose
+	dbErr := waitForDatabase(context.Background(), db.Ping, startupRetryPolicy(config))
+	if dbErr != nil && !config.DBStartupDegraded {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", dbErr)
+	}
 
-	dbConfig, err := pgxpool.ParseConfig(dbURL)
+	apiKeys, err := ParseAPIKeys(config.APIKeys, config.DefaultAPITier)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse db config: %w", err)
+		return nil, fmt.Errorf("invalid API key configuration: %w", err)
 	}
 
-	// Optimized connection pool settings
-	dbConfig.MaxConns = 20                            // Reduced from 25 to prevent pool exhaustion
-	dbConfig.MinConns = 3                             // Reduced from 5 for lower idle footprint
-	dbConfig.MaxConnLifetime = time.Minute * 30       // Reduced from 1h for faster connection refresh
-	dbConfig.MaxConnIdleTime = time.Minute * 10       // Reduced from 30min to close idle connections faster
-	dbConfig.HealthCheckPeriod = time.Minute          // Check connection health every minute
-	dbConfig.ConnConfig.ConnectTimeout = time.Second * 10 // 10s connection timeout
+	ipGuard, err := NewIPGuard(config.IPAllowList, config.IPDenyList, IPGuardConfig{
+		BurstRequests: config.AnomalyBurstRequests,
+		BurstPaths:    config.AnomalyBurstPaths,
+		Window:        time.Duration(config.AnomalyWindowSeconds) * time.Second,
+		BanDuration:   time.Duration(config.AnomalyBanSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	go ipGuard.runCleanup()
 
-	db, err := pgxpool.NewWithConfig(context.Background(), dbConfig)
+	trustedProxies, err := ParseIPList(config.TrustedProxies)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("invalid trusted proxy list: %w", err)
 	}
 
-	// Test connection
-	if err := db.Ping(context.Background()); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	// Optional read replica; reads fall back to the primary while it is unhealthy
+	var replica *pgxpool.Pool
+	if config.DBReplicaHost != "" {
+		replica, err = newDBPool(config, config.DBReplicaHost, config.DBReplicaPort)
+		if err != nil {
+			log.Printf("Warning: read replica unavailable, using primary for reads: %v", err)
+			replica = nil
+		}
+	}
+
+	precompute, err := NewPrecomputeScheduler(config.PrecomputeSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid precompute configuration: %w", err)
+	}
+	leaderQualifiers, err := parseLeaderQualifiers(config.LeaderQualifiers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid leaderboard configuration: %w", err)
+	}
+	var simEngines *UpstreamPool
+	if config.SimEngineURLs != "" {
+		simEngines, err = ParseUpstreamTopology("sim_engine", config.SimEngineURLs, config.Region)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sim-engine topology: %w", err)
+		}
 	}
 
 	s := &Server{
 		db:          db,
+		dbRouter:    NewDBRouter(db, replica),
 		config:      config,
 		router:      mux.NewRouter(),
 		rateLimiter: NewRateLimiter(100, 200), // 100 requests/min, burst of 200
+		ipGuard:     ipGuard,
+
+		trustedProxies: trustedProxies,
 		queryCache:  NewQueryCache(),
+
+		simEngineClient:   NewUpstreamClient("sim_engine", config.UpstreamMaxConcurrency),
+		dataFetcherClient: NewUpstreamClient("data_fetcher", config.UpstreamMaxConcurrency),
+
+		apiKeys: apiKeys,
+
+		staleCache:      NewStaleCache(time.Duration(config.StaleCacheMaxAge)*time.Second, defaultStaleCacheEntries),
+		simulationQueue: NewSimulationQueue(config.SimulationQueueSize),
+		precompute:      precompute,
+
+		leaderQualifiers: leaderQualifiers,
+		simEngines:       simEngines,
+	}
+	if simEngines != nil {
+		s.simEngineClient.observe = simEngines.observe
+	}
+	s.ids = ids.NewResolver(s.readDB)
+	s.freshness = NewFreshnessStatus(defaultFreshnessTTL, s.loadTableFreshness)
+	precompute.handler = s.router
+	precompute.ready = func() bool { return s.dbReady.Load() && s.dbRouter.PrimaryHealthy() }
+
+	// Submit simulations queued during a database outage once it recovers
+	queueCtx, stopQueue := context.WithCancel(context.Background())
+	s.stopBackground = stopQueue
+	go s.processSimulationQueue(queueCtx)
+	if config.BoxScoreReconcileInterval > 0 {
+		go s.reconcileBoxScoresPeriodically(queueCtx, time.Duration(config.BoxScoreReconcileInterval)*time.Second)
+	}
+	if config.AuditRetentionDays > 0 {
+		go s.pruneAuditLogPeriodically(queueCtx, config.AuditRetentionDays)
+	}
+	if simEngines != nil && config.ReplicaHealthInterval > 0 {
+		go s.checkReplicasPeriodically(queueCtx, time.Duration(config.ReplicaHealthInterval)*time.Second)
+	}
+
+	if dbErr != nil {
+		log.Printf("Warning: starting in degraded mode, serving only /health until the database connects: %v", dbErr)
+		go s.connectInBackground(queueCtx)
+	} else {
+		s.dbReady.Store(true)
 	}
 
 	s.setupRoutes()
+	go precompute.Run(queueCtx)
 	return s, nil
 }
 
@@ -307,42 +533,123 @@ func (s *Server) setupRoutes() {
 	// Health check and metrics
 	api.HandleFunc("/health", s.healthHandler).Methods("GET")
 	api.HandleFunc("/metrics", s.handleMetrics).Methods("GET")
+	s.router.HandleFunc("/metrics", s.handlePrometheusMetrics).Methods("GET")
 
 	// Search endpoint
 	api.HandleFunc("/search", s.searchHandler).Methods("GET")
 
+	// Metadata endpoints
+	api.HandleFunc("/meta/stats", withCachePolicy(referenceCachePolicy, s.getStatGlossaryHandler)).Methods("GET")
+	api.HandleFunc("/meta/enums", withCachePolicy(referenceCachePolicy, s.getMetaEnumsHandler)).Methods("GET")
+	api.HandleFunc("/meta/win-expectancy", withCachePolicy(referenceCachePolicy, s.getWinExpectancyHandler)).Methods("GET")
+
 	// Teams endpoints
-	api.HandleFunc("/teams", s.getTeamsHandler).Methods("GET")
-	api.HandleFunc("/teams/{id}", s.getTeamHandler).Methods("GET")
-	api.HandleFunc("/teams/{id}/stats", s.getTeamStatsHandler).Methods("GET")
-	api.HandleFunc("/teams/{id}/games", s.getTeamGamesHandler).Methods("GET")
+	api.HandleFunc("/teams", withCachePolicy(referenceCachePolicy, withPageLimits(PageLimits{Default: 50, Max: 100}, s.getTeamsHandler))).Methods("GET")
+	api.HandleFunc("/teams/{id}", withCachePolicy(referenceCachePolicy, s.getTeamHandler)).Methods("GET")
+	api.HandleFunc("/teams/{id}/stats", withSeasonCachePolicy(s.getTeamStatsHandler)).Methods("GET")
+	api.HandleFunc("/teams/{id}/games", withSeasonCachePolicy(withPageLimits(PageLimits{Default: 50, Max: 200}, s.getTeamGamesHandler))).Methods("GET")
+	api.HandleFunc("/teams/{id}/platoon-report", s.getTeamPlatoonReportHandler).Methods("GET")
+	api.HandleFunc("/teams/{id}/simulation-readiness", s.getTeamSimulationReadinessHandler).Methods("GET")
+	api.HandleFunc("/teams/{id}/schedule-strength", s.getTeamScheduleStrengthHandler).Methods("GET")
+	api.HandleFunc("/teams/{id}/defense", withSeasonCachePolicy(s.getTeamDefenseHandler)).Methods("GET")
+	api.HandleFunc("/teams/{id}/payroll", s.getTeamPayrollHandler).Methods("GET")
+	api.HandleFunc("/franchises/{id}/history", s.getFranchiseHistoryHandler).Methods("GET")
+	api.HandleFunc("/standings", withSeasonCachePolicy(s.getStandingsHandler)).Methods("GET")
+	api.HandleFunc("/rankings", s.getRankingsHandler).Methods("GET")
+	api.HandleFunc("/leaders", withSeasonCachePolicy(s.getLeadersHandler)).Methods("GET")
+
+	// Stadiums endpoints
+	api.HandleFunc("/stadiums/{id}/dimensions", withCachePolicy(referenceCachePolicy, s.getStadiumDimensionsHandler)).Methods("GET")
+
+	// Admin endpoints (internal API keys only)
+	api.HandleFunc("/admin/stadiums/coordinates/missing", s.getStadiumsMissingCoordinatesHandler).Methods("GET")
+	api.HandleFunc("/admin/stadiums/{id}/coordinates", s.audited(auditStadiumCoordinatesSet, s.putStadiumCoordinatesHandler)).Methods("PUT")
+	api.HandleFunc("/admin/contracts", s.audited(auditContractsIngested, s.ingestContractsHandler)).Methods("POST")
+	api.HandleFunc("/admin/id-aliases", s.audited(auditAliasesIngested, s.ingestAliasesHandler)).Methods("POST")
+	api.HandleFunc("/admin/games/{id}/venue", s.audited(auditGameVenueMoved, s.putGameVenueHandler)).Methods("PUT")
+	api.HandleFunc("/admin/teams/{id}/branding", s.audited(auditTeamBrandingSet, s.putTeamBrandingHandler)).Methods("PUT")
+	api.HandleFunc("/admin/rankings", s.audited(auditRankingsRefreshed, s.refreshRankingsHandler)).Methods("POST")
+	api.HandleFunc("/admin/box-scores/reconcile", s.audited(auditBoxScoresReconciled, s.reconcileBoxScoresHandler)).Methods("POST")
+	api.HandleFunc("/admin/box-scores/mismatches", withPageLimits(PageLimits{Default: 50, Max: 200}, s.getBoxScoreMismatchesHandler)).Methods("GET")
+	api.HandleFunc("/admin/odds", s.audited(auditOddsIngested, s.ingestOddsHandler)).Methods("POST")
+	api.HandleFunc("/admin/precompute", s.getPrecomputeJobsHandler).Methods("GET")
+	api.HandleFunc("/admin/precompute/{job}", s.audited(auditPrecomputeTriggered, s.runPrecomputeJobHandler)).Methods("POST")
+	api.HandleFunc("/admin/cache/clear", s.audited(auditCacheCleared, s.clearCacheHandler)).Methods("POST")
+	api.HandleFunc("/admin/audit", withPageLimits(PageLimits{Default: 100, Max: 500}, s.getAuditLogHandler)).Methods("GET")
 
 	// Players endpoints
-	api.HandleFunc("/players", s.getPlayersHandler).Methods("GET")
+	api.HandleFunc("/players", withPageLimits(PageLimits{Default: 50, Max: 200}, s.getPlayersHandler)).Methods("GET")
+	api.HandleFunc("/players/headshots", withCachePolicy(referenceCachePolicy, s.getPlayerHeadshotsHandler)).Methods("GET")
 	api.HandleFunc("/players/{id}", s.getPlayerHandler).Methods("GET")
-	api.HandleFunc("/players/{id}/stats", s.getPlayerStatsHandler).Methods("GET")
+	api.HandleFunc("/players/{id}/stats", withSeasonCachePolicy(s.getPlayerStatsHandler)).Methods("GET")
+	api.HandleFunc("/players/{id}/expected-stats", s.getPlayerExpectedStatsHandler).Methods("GET")
 
 	// Umpires endpoints
-	api.HandleFunc("/umpires", s.getUmpiresHandler).Methods("GET")
+	api.HandleFunc("/umpires", withPageLimits(PageLimits{Default: 50, Max: 200}, s.getUmpiresHandler)).Methods("GET")
+	api.HandleFunc("/umpires/leaderboard", s.getUmpireLeaderboardHandler).Methods("GET")
 	api.HandleFunc("/umpires/{id}", s.getUmpireHandler).Methods("GET")
-	api.HandleFunc("/umpires/{id}/stats", s.getUmpireStatsHandler).Methods("GET")
+	api.HandleFunc("/umpires/{id}/stats", withSeasonCachePolicy(s.getUmpireStatsHandler)).Methods("GET")
+	api.HandleFunc("/umpires/{id}/zone", s.getUmpireZoneHandler).Methods("GET")
+	api.HandleFunc("/umpires/{id}/games", withPageLimits(PageLimits{Default: 25, Max: 100}, s.getUmpireGamesHandler)).Methods("GET")
 
 	// Games endpoints
-	api.HandleFunc("/games", s.getGamesHandler).Methods("GET")
+	api.HandleFunc("/games", withPageLimits(PageLimits{Default: 50, Max: 500}, s.getGamesHandler)).Methods("GET")
 	api.HandleFunc("/games/{id}", s.getGameHandler).Methods("GET")
 	api.HandleFunc("/games/date/{date}", s.getGamesByDateHandler).Methods("GET")
 	api.HandleFunc("/games/{id}/boxscore", s.getGameBoxScore).Methods("GET")
 	api.HandleFunc("/games/{id}/plays", s.getGamePlays).Methods("GET")
 	api.HandleFunc("/games/{id}/weather", s.getGameWeather).Methods("GET")
+	api.HandleFunc("/games/{id}/weather/verification", s.getGameWeatherVerification).Methods("GET")
+	api.HandleFunc("/games/{id}/odds", s.getGameOddsHandler).Methods("GET")
 
 	// Simulation endpoints
-	api.HandleFunc("/simulations", s.createSimulationHandler).Methods("POST")
+	api.HandleFunc("/simulations", s.listSimulationsHandler).Methods("GET")
+	api.Handle("/simulations", withRateCost(simulationRouteCost, s.audited(auditSimulationCreated, s.createSimulationHandler))).Methods("POST")
+	api.HandleFunc("/simulations", s.audited(auditSimulationDeleted, s.deleteSimulationsHandler)).Methods("DELETE")
+	api.HandleFunc("/simulations/accuracy", s.getSimulationAccuracyHandler).Methods("GET")
+	api.HandleFunc("/simulations/clv", s.getClosingLineValueHandler).Methods("GET")
+	api.HandleFunc("/simulations/events", s.getArchivedEventsHandler).Methods("GET")
 	api.HandleFunc("/simulations/{id}", s.getSimulationHandler).Methods("GET")
+	api.HandleFunc("/simulations/{id}", s.audited(auditSimulationDeleted, s.deleteSimulationHandler)).Methods("DELETE")
 	api.HandleFunc("/simulations/{id}/status", s.getSimulationStatusHandler).Methods("GET")
+	api.HandleFunc("/simulations/{id}/samples", s.getSimulationSamplesHandler).Methods("GET")
+	api.HandleFunc("/simulations/{id}/events", s.getSimulationEventsHandler).Methods("GET")
+	api.HandleFunc("/simulations/{id}/explain", s.getSimulationExplainHandler).Methods("GET")
+	api.HandleFunc("/simulations/{id}/fantasy", s.getSimulationFantasyHandler).Methods("GET")
+	api.HandleFunc("/simulations/{id}/config", s.getSimulationConfigHandler).Methods("GET")
+	api.Handle("/simulations/{id}/sensitivity", withRateCost(sensitivityRouteCost, s.simulationSensitivityHandler)).Methods("POST")
+	api.HandleFunc("/simulations/{id}/summary", s.getSimulationSummaryHandler).Methods("GET")
+	api.HandleFunc("/simulations/{id}/odds", s.getSimulationOddsHandler).Methods("GET")
+	api.HandleFunc("/simulations/estimate", s.estimateSimulationHandler).Methods("POST")
+	api.Handle("/simulations/batch", withRateCost(batchRouteCost, s.audited(auditSimulationBatchCreated, s.createSimulationBatchHandler))).Methods("POST")
+	api.HandleFunc("/simulations/batch/{id}", s.getSimulationBatchHandler).Methods("GET")
+	api.HandleFunc("/simulations/daily/{date}", s.getDailyDigestHandler).Methods("GET")
+	api.HandleFunc("/simulations/queued/{id}", s.getQueuedSimulationHandler).Methods("GET")
+
+	// Export endpoints (long-running, polled; downloads use signed URLs)
+	api.Handle("/exports", withRateCost(exportRouteCost, s.createExportHandler)).Methods("POST")
+	api.HandleFunc("/exports/{id}", s.getExportHandler).Methods("GET")
+	api.HandleFunc("/exports/{id}/download", s.downloadExportHandler).Methods("GET")
+
+	// Analyst notes on games, players and simulation runs; require an API key
+	api.HandleFunc("/notes", s.searchNotesHandler).Methods("GET")
+	api.HandleFunc("/notes/{id}", s.deleteNoteHandler).Methods("DELETE")
+	api.HandleFunc("/games/{id}/notes", s.listEntityNotesHandler(noteEntityGame)).Methods("GET")
+	api.HandleFunc("/games/{id}/notes", s.createNoteHandler(noteEntityGame)).Methods("POST")
+	api.HandleFunc("/players/{id}/notes", s.listEntityNotesHandler(noteEntityPlayer)).Methods("GET")
+	api.HandleFunc("/players/{id}/notes", s.createNoteHandler(noteEntityPlayer)).Methods("POST")
+	api.HandleFunc("/simulations/{id}/notes", s.listEntityNotesHandler(noteEntityRun)).Methods("GET")
+	api.HandleFunc("/simulations/{id}/notes", s.createNoteHandler(noteEntityRun)).Methods("POST")
+
+	// Daily digest notification targets, per API key
+	api.HandleFunc("/notifications/targets", s.listNotificationTargetsHandler).Methods("GET")
+	api.HandleFunc("/notifications/targets", s.createNotificationTargetHandler).Methods("POST")
+	api.HandleFunc("/notifications/targets/{id}", s.deleteNotificationTargetHandler).Methods("DELETE")
 
 	// Data update endpoints
-	api.HandleFunc("/data/refresh", s.refreshDataHandler).Methods("POST")
+	api.Handle("/data/refresh", withRateCost(refreshRouteCost, s.audited(auditDataRefreshTriggered, s.refreshDataHandler))).Methods("POST")
 	api.HandleFunc("/data/status", s.dataStatusHandler).Methods("GET")
+	api.HandleFunc("/data/refresh/{jobId}", s.getRefreshJobHandler).Methods("GET")
 	
 	// API status endpoint
 	api.HandleFunc("/status", s.apiStatusHandler).Methods("GET")
@@ -351,26 +658,34 @@ func (s *Server) setupRoutes() {
 	s.router.Use(s.rateLimitMiddleware)
 	s.router.Use(s.loggingMiddleware)
 	s.router.Use(s.recoveryMiddleware)
+	s.router.Use(s.dataFreshnessMiddleware)
+	s.router.Use(s.staleCacheMiddleware)
+	s.router.Use(s.jsonCaseMiddleware)
+	s.router.Use(s.precomputedMiddleware)
 }
 
-func (s *Server) Start() error {
+// handler wraps the router in the middleware that applies outside routing
+func (s *Server) handler() http.Handler {
 	// Setup CORS with restricted headers for security
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:8080", "http://localhost:5173"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Accept", "Authorization"},
-		ExposedHeaders:   []string{"Content-Length", "Content-Type"},
+		AllowedHeaders:   []string{"Content-Type", "Accept", "Authorization", "X-API-Key"},
+		ExposedHeaders:   []string{"Content-Length", "Content-Type", dataAsOfHeader},
 		AllowCredentials: true,
 		MaxAge:           600, // 10 minutes
 	})
 
-	// Add security headers middleware and compression
-	handler := s.securityHeadersMiddleware(c.Handler(s.router))
-	handler = handlers.CompressHandler(handler) // Add gzip compression
+	// Add security headers middleware and compression. The IP guard sits
+	// outside the router so unmatched paths still count toward bans.
+	handler := s.securityHeadersMiddleware(c.Handler(s.ipGuardMiddleware(s.startupGateMiddleware(s.router))))
+	return handlers.CompressHandler(handler) // Add gzip compression
+}
 
+func (s *Server) Start() error {
 	s.httpServer = &http.Server{
 		Addr:              ":" + s.config.Port,
-		Handler:           handler,
+		Handler:           s.handler(),
 		ReadTimeout:       15 * time.Second,
 		WriteTimeout:      15 * time.Second,
 		IdleTimeout:       60 * time.Second,
@@ -385,10 +700,22 @@ func (s *Server) Start() error {
 func (s *Server) Shutdown(ctx context.Context) error {
 	log.Println("Shutting down API Gateway...")
 
-	// Close database connection
-	s.db.Close()
+	// Stop retrying queued simulations
+	if s.stopBackground != nil {
+		s.stopBackground()
+	}
+
+	// Close database connections
+	s.dbRouter.Close()
+
+	// Drop pooled upstream connections
+	s.simEngineClient.CloseIdleConnections()
+	s.dataFetcherClient.CloseIdleConnections()
 
-	// Shutdown HTTP server
+	// Shutdown HTTP server, unless it was served through handler() alone
+	if s.httpServer == nil {
+		return nil
+	}
 	return s.httpServer.Shutdown(ctx)
 }
 
@@ -408,13 +735,7 @@ func (s *Server) securityHeadersMiddleware(next http.Handler) http.Handler {
 
 func (s *Server) rateLimitMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Extract IP address
-		ip := r.RemoteAddr
-		if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
-			ip = strings.Split(forwardedFor, ",")[0]
-		}
-
-		if !s.rateLimiter.Allow(ip) {
+		if !isPrecomputeRequest(r) && !s.rateLimiter.AllowN(s.clientIP(r), routeCost(r)) {
 			http.Error(w, "Rate limit exceeded. Please try again later.", http.StatusTooManyRequests)
 			return
 		}
@@ -434,12 +755,14 @@ func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 
 		duration := time.Since(start)
 
-		// Track metrics
-		appMetrics.IncrementRequests()
-		appMetrics.AddResponseTime(duration)
-		if lrw.statusCode >= 400 {
-			appMetrics.IncrementErrors()
+		// Track metrics by route template so IDs don't create new series
+		route := "unmatched"
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
 		}
+		appMetrics.ObserveRequest(route, r.Method, lrw.statusCode, duration)
 
 		// Structured JSON logging
 		appLogger.Info("HTTP Request", map[string]interface{}{
@@ -508,9 +831,18 @@ func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
 	defer cancel()
 
+	if !s.dbReady.Load() {
+		health["startup"] = "waiting_for_database"
+	}
+
 	if err := s.db.Ping(ctx); err != nil {
 		health["database"] = "disconnected"
 		health["status"] = "unhealthy"
+		health["degraded"] = map[string]interface{}{
+			"stale_responses":       s.staleCache.Len(),
+			"queued_simulations":    s.simulationQueue.Pending(),
+			"stale_max_age_seconds": s.config.StaleCacheMaxAge,
+		}
 		w.WriteHeader(http.StatusServiceUnavailable)
 	}
 
@@ -546,11 +878,17 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 	gamesChan := make(chan searchResults, 1)
 	umpiresChan := make(chan searchResults, 1)
 
+	type shortcutResult struct {
+		match *SearchBestMatch
+		err   error
+	}
+	shortcutChan := make(chan shortcutResult, 1)
+
 	searchPattern := "%" + query + "%"
 
 	// Search players in parallel
 	go func() {
-		results, err := s.searchPlayers(ctx, searchPattern)
+		results, err := s.searchPlayers(ctx, query)
 		playersChan <- searchResults{results: results, err: err}
 	}()
 
@@ -572,6 +910,13 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 		umpiresChan <- searchResults{results: results, err: err}
 	}()
 
+	// Resolve structured shortcuts ("NYY vs BOS 2024-07-04", "#99 yankees",
+	// "umpire angel hernandez 2023") in parallel
+	go func() {
+		match, err := s.resolveSearchShortcut(ctx, query)
+		shortcutChan <- shortcutResult{match: match, err: err}
+	}()
+
 	// Collect all results
 	var allResults []SearchResult
 
@@ -616,29 +961,67 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 	if len(allResults) > 50 {
 		allResults = allResults[:50]
 	}
+	if allResults == nil {
+		allResults = []SearchResult{}
+	}
+
+	response := SearchResponse{Query: query, Results: allResults}
+	shortcutRes := <-shortcutChan
+	if shortcutRes.err != nil {
+		appLogger.Error("Failed to resolve search shortcut", map[string]interface{}{"error": shortcutRes.err.Error()})
+	}
+	response.BestMatch = shortcutRes.match
+	if response.BestMatch == nil {
+		response.BestMatch = exactSearchMatch(allResults)
+	}
 
-	writeJSON(w, allResults)
+	writeJSON(w, response)
 }
 
-// searchPlayers searches for players by name
-func (s *Server) searchPlayers(ctx context.Context, pattern string) ([]SearchResult, error) {
+// searchPlayers searches for players by name, ignoring accents and
+// punctuation, swapping a leading nickname for its formal name and back, and
+// matching known aliases. Names match anywhere, which the trigram indexes
+// from migration 054 serve.
+func (s *Server) searchPlayers(ctx context.Context, term string) ([]SearchResult, error) {
 	query := `
+		WITH q AS (
+			SELECT normalize_name($1) AS norm
+		),
+		variants AS (
+			SELECT norm FROM q WHERE norm <> ''
+			UNION
+			SELECT n.other || substr(q.norm, length(n.name) + 1)
+			FROM q
+			JOIN (SELECT alias AS name, canonical AS other FROM first_name_aliases
+			      UNION ALL
+			      SELECT canonical, alias FROM first_name_aliases) n
+			  ON q.norm = n.name OR q.norm LIKE n.name || ' %'
+		),
+		matches AS (
+			SELECT p.id,
+			       CASE
+			           WHEN p.normalized_name = (SELECT norm FROM q) THEN 100
+			           WHEN p.normalized_name = v.norm THEN 90
+			           ELSE 80
+			       END AS relevance
+			FROM players p
+			JOIN variants v ON p.normalized_name LIKE '%' || v.norm || '%'
+			UNION ALL
+			SELECT pa.player_id,
+			       CASE WHEN pa.normalized_alias = v.norm THEN 90 ELSE 60 END
+			FROM player_aliases pa
+			JOIN variants v ON pa.normalized_alias LIKE '%' || v.norm || '%'
+		)
 		SELECT p.id::text, p.full_name, p.position, t.name as team_name, t.city as team_city,
-		       CASE
-		           WHEN LOWER(p.full_name) = LOWER(TRIM('%' FROM $1)) THEN 100
-		           WHEN LOWER(p.full_name) LIKE LOWER($1) THEN 80
-		           WHEN LOWER(p.last_name) LIKE LOWER($1) THEN 70
-		           ELSE 50
-		       END as relevance
-		FROM players p
+		       MAX(m.relevance) as relevance
+		FROM matches m
+		JOIN players p ON p.id = m.id
 		LEFT JOIN teams t ON p.team_id = t.id
-		WHERE p.full_name ILIKE $1
-		   OR p.first_name ILIKE $1
-		   OR p.last_name ILIKE $1
-		ORDER BY relevance DESC
+		GROUP BY p.id, p.full_name, p.position, t.name, t.city
+		ORDER BY relevance DESC, p.full_name
 		LIMIT 25`
 
-	rows, err := s.db.Query(ctx, query, pattern)
+	rows, err := s.readDB().Query(ctx, query, term)
 	if err != nil {
 		return nil, err
 	}
@@ -693,7 +1076,7 @@ func (s *Server) searchTeams(ctx context.Context, pattern string) ([]SearchResul
 		ORDER BY relevance DESC
 		LIMIT 10`
 
-	rows, err := s.db.Query(ctx, query, pattern)
+	rows, err := s.readDB().Query(ctx, query, pattern)
 	if err != nil {
 		return nil, err
 	}
@@ -753,7 +1136,7 @@ func (s *Server) searchGames(ctx context.Context, pattern string) ([]SearchResul
 		ORDER BY g.game_date DESC, relevance DESC
 		LIMIT 10`
 
-	rows, err := s.db.Query(ctx, query, pattern)
+	rows, err := s.readDB().Query(ctx, query, pattern)
 	if err != nil {
 		return nil, err
 	}
@@ -816,7 +1199,7 @@ func (s *Server) searchUmpires(ctx context.Context, pattern string) ([]SearchRes
 		ORDER BY relevance DESC
 		LIMIT 10`
 
-	rows, err := s.db.Query(ctx, query, pattern)
+	rows, err := s.readDB().Query(ctx, query, pattern)
 	if err != nil {
 		return nil, err
 	}
@@ -853,7 +1236,8 @@ func (s *Server) getTeamsHandler(w http.ResponseWriter, r *http.Request) {
 	// Build base query
 	baseQuery := `
 		SELECT t.id, t.team_id, t.name, t.city, t.abbreviation, t.league,
-		       t.division, t.stadium_id::text, t.created_at, t.updated_at
+		       t.division, t.stadium_id::text, t.created_at, t.updated_at,
+		       t.primary_color, t.secondary_color, t.logo_slug, t.franchise_id
 		FROM teams t`
 
 	// Count query for pagination
@@ -862,44 +1246,47 @@ func (s *Server) getTeamsHandler(w http.ResponseWriter, r *http.Request) {
 	// Build WHERE clause
 	whereClause, args := buildWhereClause(params, "t")
 
-	// Get total count
 	var total int
-	err := s.db.QueryRow(ctx, countQuery+whereClause, args...).Scan(&total)
-	if err != nil {
-		writeError(w, "Failed to count teams", http.StatusInternalServerError)
-		return
-	}
+	var teams []Team
+	err := s.withSnapshot(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, countQuery+whereClause, args...).Scan(&total); err != nil {
+			return fmt.Errorf("count teams: %w", err)
+		}
 
-	// Build ORDER and LIMIT clause
-	orderClause := buildOrderClause(params, "t", "name")
-	offset := calculateOffset(params.Page, params.PageSize)
-	limitClause := fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, offset)
+		// Build ORDER and LIMIT clause
+		orderClause := buildOrderClause(params, teamListSorts, "name")
+		offset := calculateOffset(params.Page, params.PageSize)
+		limitClause := fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, offset)
 
-	// Execute main query
-	finalQuery := baseQuery + whereClause + orderClause + limitClause
-	rows, err := s.db.Query(ctx, finalQuery, args...)
+		// Execute main query
+		finalQuery := baseQuery + whereClause + orderClause + limitClause
+		rows, err := tx.Query(ctx, finalQuery, args...)
+		if err != nil {
+			return fmt.Errorf("query teams: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			team := Team{Branding: &TeamBranding{}}
+			err := rows.Scan(
+				&team.ID, &team.TeamID, &team.Name, &team.City, &team.Abbreviation,
+				&team.League, &team.Division, &team.Stadium, &team.CreatedAt, &team.UpdatedAt,
+				&team.Branding.PrimaryColor, &team.Branding.SecondaryColor, &team.Branding.LogoSlug, &team.FranchiseID,
+			)
+			if err != nil {
+				return fmt.Errorf("scan team: %w", err)
+			}
+			teams = append(teams, team)
+		}
+		return rows.Err()
+	})
 	if err != nil {
+		log.Printf("Failed to query teams: %v", err)
 		writeError(w, "Failed to query teams", http.StatusInternalServerError)
 		return
 	}
-	defer rows.Close()
-
-	var teams []Team
-	for rows.Next() {
-		var team Team
-		err := rows.Scan(
-			&team.ID, &team.TeamID, &team.Name, &team.City, &team.Abbreviation,
-			&team.League, &team.Division, &team.Stadium, &team.CreatedAt, &team.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Team scan error: %v", err)
-			writeError(w, "Failed to scan team", http.StatusInternalServerError)
-			return
-		}
-		teams = append(teams, team)
-	}
 
-	response := buildPaginatedResponse(teams, total, params.Page, params.PageSize)
+	response := buildPaginatedResponse(teams, total, params)
 	writeJSON(w, response)
 }
 
@@ -915,16 +1302,23 @@ func (s *Server) getTeamHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := contextWithTimeout(r.Context())
 	defer cancel()
 
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, teamID)
+	if !ok {
+		return
+	}
+
 	query := `
 		SELECT t.id, t.team_id, t.name, t.city, t.abbreviation, t.league,
-		       t.division, t.stadium_id::text, t.created_at, t.updated_at
+		       t.division, t.stadium_id::text, t.created_at, t.updated_at,
+		       t.primary_color, t.secondary_color, t.logo_slug, t.franchise_id
 		FROM teams t
-		WHERE t.id::text = $1 OR t.team_id = $1`
+		WHERE t.id = $1`
 
-	var team Team
-	err := s.db.QueryRow(ctx, query, teamID).Scan(
+	team := Team{Branding: &TeamBranding{}}
+	err := s.readDB().QueryRow(ctx, query, resolved.ID).Scan(
 		&team.ID, &team.TeamID, &team.Name, &team.City, &team.Abbreviation,
 		&team.League, &team.Division, &team.Stadium, &team.CreatedAt, &team.UpdatedAt,
+		&team.Branding.PrimaryColor, &team.Branding.SecondaryColor, &team.Branding.LogoSlug, &team.FranchiseID,
 	)
 
 	if err != nil {
@@ -940,7 +1334,8 @@ func (s *Server) getTeamHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, team)
 }
 
-// getTeamStatsHandler returns team statistics including W-L record
+// getTeamStatsHandler returns team statistics including W-L record, with
+// home, away and division splits. ?from=&to= or ?split= limit the games.
 func (s *Server) getTeamStatsHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamID := vars["id"]
@@ -950,17 +1345,62 @@ func (s *Server) getTeamStatsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse season parameter (default to current season)
+	adjustPark, ok := parseAdjustedParam(r)
+	if !ok {
+		writeError(w, "Invalid adjusted parameter, supported: park", http.StatusBadRequest)
+		return
+	}
+
+	dateRange, err := parseStatsDateRange(r.URL.Query())
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Parse season parameter (default to the range's season, then the current
+	// one; parseStatsDateRange rejects a season the range isn't in)
 	season := getCurrentSeason()
+	if rangeSeason, ok := dateRange.season(); ok {
+		season = rangeSeason
+	}
 	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
 		if s, err := strconv.Atoi(seasonStr); err == nil {
 			season = s
 		}
 	}
 
+	// Records are regular season unless asked otherwise
+	gameTypes, err := parseGameTypes(r.URL.Query(), regularSeasonOnly)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	gameTypeClause, gameTypeArgs := gameTypeCondition("g.game_type", gameTypes, 3)
+
 	ctx, cancel := contextWithTimeout(r.Context())
 	defer cancel()
 
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, teamID)
+	if !ok {
+		return
+	}
+	teamID = s.franchiseSeasonTeam(ctx, resolved.ID, season)
+
+	if dateRange.Split != "" {
+		allStarBreak, err := s.findAllStarBreak(ctx, season)
+		if errors.Is(err, errAllStarBreakUnknown) {
+			writeError(w, fmt.Sprintf("No All-Star break found in the %d schedule", season), http.StatusUnprocessableEntity)
+			return
+		}
+		if err != nil {
+			log.Printf("All-Star break query error: %v", err)
+			writeError(w, "Failed to query team stats", http.StatusInternalServerError)
+			return
+		}
+		dateRange.resolveSplit(allStarBreak)
+	}
+	dateClause, dateArgs := dateRange.condition("g.game_date", 4)
+
 	query := `
 		SELECT
 			COUNT(*) FILTER (WHERE
@@ -980,18 +1420,34 @@ func (s *Server) getTeamStatsHandler(w http.ResponseWriter, r *http.Request) {
 				WHEN g.home_team_id = t.id THEN g.final_score_away
 				WHEN g.away_team_id = t.id THEN g.final_score_home
 				ELSE 0
-			END), 0) as runs_allowed
+			END), 0) as runs_allowed,
+			COUNT(*) FILTER (WHERE opp.league <> t.league AND (
+				(g.home_team_id = t.id AND g.final_score_home > g.final_score_away) OR
+				(g.away_team_id = t.id AND g.final_score_away > g.final_score_home)
+			)) as interleague_wins,
+			COUNT(*) FILTER (WHERE opp.league <> t.league AND (
+				(g.home_team_id = t.id AND g.final_score_home < g.final_score_away) OR
+				(g.away_team_id = t.id AND g.final_score_away < g.final_score_home)
+			)) as interleague_losses,` +
+		splitRecordColumns(homeSplitCondition) + `,` +
+		splitRecordColumns(awaySplitCondition) + `,` +
+		splitRecordColumns(divisionSplitCondition) + `
 		FROM teams t
 		LEFT JOIN games g ON (g.home_team_id = t.id OR g.away_team_id = t.id)
 			AND g.season = $2
-			AND g.status = 'completed'
-			AND g.final_score_home IS NOT NULL
-			AND g.final_score_away IS NOT NULL
-		WHERE t.id::text = $1 OR t.team_id = $1
+			AND ` + completedGameCondition + `
+			AND ` + gameTypeClause + dateClause + `
+		LEFT JOIN teams opp ON opp.id = CASE WHEN g.home_team_id = t.id THEN g.away_team_id ELSE g.home_team_id END
+		WHERE t.id = $1
 		GROUP BY t.id`
 
-	var wins, losses, runsScored, runsAllowed int
-	err := s.db.QueryRow(ctx, query, teamID, season).Scan(&wins, &losses, &runsScored, &runsAllowed)
+	var wins, losses, runsScored, runsAllowed, interleagueWins, interleagueLosses int
+	var home, away, division TeamSplitRecord
+	err = s.readDB().QueryRow(ctx, query, append([]interface{}{teamID, season, gameTypeArgs}, dateArgs...)...).
+		Scan(&wins, &losses, &runsScored, &runsAllowed, &interleagueWins, &interleagueLosses,
+			&home.Wins, &home.Losses, &home.RunsScored, &home.RunsAllowed,
+			&away.Wins, &away.Losses, &away.RunsScored, &away.RunsAllowed,
+			&division.Wins, &division.Losses, &division.RunsScored, &division.RunsAllowed)
 
 	if err != nil {
 		log.Printf("Team stats query error: %v", err)
@@ -1000,18 +1456,70 @@ func (s *Server) getTeamStatsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	stats := map[string]interface{}{
-		"season":       season,
-		"wins":         wins,
-		"losses":       losses,
-		"games_played": wins + losses,
-		"winning_pct":  0.0,
-		"runs_scored":  runsScored,
-		"runs_allowed": runsAllowed,
-		"run_diff":     runsScored - runsAllowed,
+		StatSeason:      season,
+		StatWins:        wins,
+		StatLosses:      losses,
+		StatGamesPlayed: wins + losses,
+		StatWinningPct:  0.0,
+		StatRunsScored:  runsScored,
+		StatRunsAllowed: runsAllowed,
+		StatRunDiff:     runsScored - runsAllowed,
+
+		StatInterleagueWins:   interleagueWins,
+		StatInterleagueLosses: interleagueLosses,
+		"game_types":          gameTypes,
+	}
+
+	home.finish()
+	away.finish()
+	division.finish()
+	stats["home"] = home
+	stats["away"] = away
+	stats["vs_division"] = division
+	if dateRange.Split != "" {
+		stats["split"] = dateRange.Split
+	}
+	if dateRange.From != nil {
+		stats["from"] = dateRange.From.Format("2006-01-02")
+	}
+	if dateRange.To != nil {
+		stats["to"] = dateRange.To.Format("2006-01-02")
 	}
 
 	if wins+losses > 0 {
-		stats["winning_pct"] = float64(wins) / float64(wins+losses)
+		stats[StatWinningPct] = float64(wins) / float64(wins+losses)
+	}
+
+	// Recent form over the same games
+	results, err := s.loadTeamResults(ctx, season, gameTypes, teamID)
+	if err != nil {
+		log.Printf("Team form query error: %v", err)
+	}
+	var form TeamForm
+	for _, teamResults := range results {
+		// Form as of the end of the range
+		var inRange []teamResult
+		for _, result := range teamResults {
+			if dateRange.contains(result.Date) {
+				inRange = append(inRange, result)
+			}
+		}
+		form = teamFormFrom(inRange)
+	}
+	stats[StatStreak] = form.Streak
+	stats[StatLast10Wins] = form.Last10Wins
+	stats[StatLast10Losses] = form.Last10Losses
+	stats[StatRunDiffLast14] = form.RunDiffLast14
+
+	if adjustPark {
+		if adj := s.loadParkAdjustment(ctx, teamParkQuery, teamID, season); adj != nil {
+			factor := adj.Factors.multiplier(adj.Factors.RunsFactor)
+			stats["park_adjusted_stats"] = map[string]interface{}{
+				StatRunsScored:  math.Round(float64(runsScored)/factor*10) / 10,
+				StatRunsAllowed: math.Round(float64(runsAllowed)/factor*10) / 10,
+			}
+			stats["park_adjustment"] = adj
+		}
 	}
 
 	writeJSON(w, stats)
@@ -1035,95 +1543,128 @@ func (s *Server) getTeamGamesHandler(w http.ResponseWriter, r *http.Request) {
 		params.Season = &currentSeason
 	}
 
-	ctx, cancel := contextWithTimeout(r.Context())
-	defer cancel()
-
-	// Count query
-	countQuery := `
-		SELECT COUNT(*)
-		FROM games g
-		LEFT JOIN teams ht ON g.home_team_id = ht.id
-		LEFT JOIN teams at ON g.away_team_id = at.id
-		WHERE (ht.id::text = $1 OR ht.team_id = $1 OR at.id::text = $1 OR at.team_id = $1)
-			AND g.season = $2`
-
-	var total int
-	err := s.db.QueryRow(ctx, countQuery, teamID, *params.Season).Scan(&total)
+	// Every game type unless filtered
+	gameTypes, err := parseGameTypes(r.URL.Query(), nil)
 	if err != nil {
-		writeError(w, "Failed to count games", http.StatusInternalServerError)
+		writeError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	countArgs := []interface{}{teamID, *params.Season}
+	countFilter, pageFilter := "", ""
+	var gameTypeArgs []string
+	if gameTypes != nil {
+		var condition string
+		condition, gameTypeArgs = gameTypeCondition("g.game_type", gameTypes, 3)
+		countFilter = " AND " + condition
+		condition, _ = gameTypeCondition("g.game_type", gameTypes, 5)
+		pageFilter = " AND " + condition
+		countArgs = append(countArgs, gameTypeArgs)
+	}
 
-	// Build main query
-	query := `
-		SELECT g.id::text, g.game_id, g.season, COALESCE(g.game_type, ''), g.game_date,
-		       g.home_team_id::text, g.away_team_id::text, g.final_score_home, g.final_score_away,
-		       COALESCE(g.status, ''), COALESCE(g.stadium_id::text, ''), g.created_at, g.updated_at,
-		       COALESCE(ht.name, ''), COALESCE(ht.city, ''), COALESCE(ht.abbreviation, ''),
-		       COALESCE(at.name, ''), COALESCE(at.city, ''), COALESCE(at.abbreviation, ''),
-		       COALESCE(s.name, ''), COALESCE(s.location, '')
-		FROM games g
-		LEFT JOIN teams ht ON g.home_team_id = ht.id
-		LEFT JOIN teams at ON g.away_team_id = at.id
-		LEFT JOIN stadiums s ON g.stadium_id = s.id
-		WHERE (ht.id::text = $1 OR ht.team_id = $1 OR at.id::text = $1 OR at.team_id = $1)
-			AND g.season = $2
-		ORDER BY g.game_date DESC
-		LIMIT $3 OFFSET $4`
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
 
-	offset := calculateOffset(params.Page, params.PageSize)
-	rows, err := s.db.Query(ctx, query, teamID, *params.Season, params.PageSize, offset)
-	if err != nil {
-		log.Printf("Team games query error: %v", err)
-		writeError(w, "Failed to query team games", http.StatusInternalServerError)
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, teamID)
+	if !ok {
 		return
 	}
-	defer rows.Close()
+	seasonTeamID := s.franchiseSeasonTeam(ctx, resolved.ID, *params.Season)
+	countArgs[0] = seasonTeamID
+
+	// Count query
+	countQuery := `
+		SELECT COUNT(*)
+		FROM games_read_model g
+		WHERE (g.home_team_id = $1 OR g.away_team_id = $1)
+			AND g.season = $2` + countFilter
 
+	var total int
 	var games []GameWithTeams
-	for rows.Next() {
-		var g GameWithTeams
-		var homeTeamName, homeTeamCity, homeTeamAbbr string
-		var awayTeamName, awayTeamCity, awayTeamAbbr string
-		var stadiumName, stadiumCity string
+	err = s.withSnapshot(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+			return fmt.Errorf("count games: %w", err)
+		}
 
-		err := rows.Scan(
-			&g.ID, &g.GameID, &g.Season, &g.GameType, &g.GameDate,
-			&g.HomeTeamID, &g.AwayTeamID, &g.HomeScore, &g.AwayScore,
-			&g.Status, &g.StadiumID, &g.CreatedAt, &g.UpdatedAt,
-			&homeTeamName, &homeTeamCity, &homeTeamAbbr,
-			&awayTeamName, &awayTeamCity, &awayTeamAbbr,
-			&stadiumName, &stadiumCity,
-		)
+		// Build main query
+		query := `
+			SELECT g.id::text, g.game_id, g.season, COALESCE(g.game_type, ''), g.game_date,
+			       g.home_team_id::text, g.away_team_id::text, g.final_score_home, g.final_score_away,
+			       COALESCE(g.status, ''), COALESCE(g.stadium_id::text, ''), g.created_at, g.updated_at,
+			       COALESCE(g.home_team_name, ''), COALESCE(g.home_team_city, ''), COALESCE(g.home_team_abbr, ''),
+			       COALESCE(g.away_team_name, ''), COALESCE(g.away_team_city, ''), COALESCE(g.away_team_abbr, ''),
+			       COALESCE(g.stadium_name, ''), COALESCE(g.stadium_location, ''),
+			       ` + gameLiveColumns + `
+			FROM games_read_model g
+			WHERE (g.home_team_id = $1 OR g.away_team_id = $1)
+				AND g.season = $2` + pageFilter + `
+			ORDER BY g.game_date DESC
+			LIMIT $3 OFFSET $4`
+
+		offset := calculateOffset(params.Page, params.PageSize)
+		args := []interface{}{seasonTeamID, *params.Season, params.PageSize, offset}
+		if gameTypes != nil {
+			args = append(args, gameTypeArgs)
+		}
+		rows, err := tx.Query(ctx, query, args...)
 		if err != nil {
-			log.Printf("Failed to scan game row: %v", err)
-			continue
+			return fmt.Errorf("query team games: %w", err)
 		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var g GameWithTeams
+			var homeTeamName, homeTeamCity, homeTeamAbbr string
+			var awayTeamName, awayTeamCity, awayTeamAbbr string
+			var stadiumName, stadiumCity string
+			var live gameLiveRow
+
+			err := rows.Scan(
+				&g.ID, &g.GameID, &g.Season, &g.GameType, &g.GameDate,
+				&g.HomeTeamID, &g.AwayTeamID, &g.HomeScore, &g.AwayScore,
+				&g.Status, &g.StadiumID, &g.CreatedAt, &g.UpdatedAt,
+				&homeTeamName, &homeTeamCity, &homeTeamAbbr,
+				&awayTeamName, &awayTeamCity, &awayTeamAbbr,
+				&stadiumName, &stadiumCity,
+				&live.homeScore, &live.awayScore, &live.inning, &live.inningHalf, &live.outs, &live.updatedAt,
+			)
+			if err != nil {
+				log.Printf("Failed to scan game row: %v", err)
+				continue
+			}
 
-		// Populate flat team name fields for frontend compatibility
-		// Use name from database as-is (already contains full team name)
-		g.HomeTeamName = homeTeamName
-		g.AwayTeamName = awayTeamName
+			g.applyGameState(live)
 
-		g.HomeTeam = &Team{
-			Name:         homeTeamName,
-			City:         &homeTeamCity,
-			Abbreviation: homeTeamAbbr,
-		}
-		g.AwayTeam = &Team{
-			Name:         awayTeamName,
-			City:         &awayTeamCity,
-			Abbreviation: awayTeamAbbr,
-		}
-		g.Stadium = &Stadium{
-			Name: stadiumName,
-			City: stadiumCity,
-		}
+			// Populate flat team name fields for frontend compatibility
+			// Use name from database as-is (already contains full team name)
+			g.HomeTeamName = homeTeamName
+			g.AwayTeamName = awayTeamName
 
-		games = append(games, g)
+			g.HomeTeam = &Team{
+				Name:         homeTeamName,
+				City:         &homeTeamCity,
+				Abbreviation: homeTeamAbbr,
+			}
+			g.AwayTeam = &Team{
+				Name:         awayTeamName,
+				City:         &awayTeamCity,
+				Abbreviation: awayTeamAbbr,
+			}
+			g.Stadium = &Stadium{
+				Name: stadiumName,
+				City: stadiumCity,
+			}
+
+			games = append(games, g)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		log.Printf("Failed to query team games: %v", err)
+		writeError(w, "Failed to query team games", http.StatusInternalServerError)
+		return
 	}
 
-	response := buildPaginatedResponse(games, total, params.Page, params.PageSize)
+	response := buildPaginatedResponse(games, total, params)
 	writeJSON(w, response)
 }
 
@@ -1133,6 +1674,9 @@ func (s *Server) getPlayersHandler(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	params := parseQueryParams(r)
+	if !s.resolveTeamFilter(ctx, w, &params) {
+		return
+	}
 
 	// Build base query with team information
 	baseQuery := `
@@ -1154,66 +1698,67 @@ func (s *Server) getPlayersHandler(w http.ResponseWriter, r *http.Request) {
 	// Build WHERE clause
 	whereClause, args := buildPlayersWhereClause(params)
 
-	// Get total count
 	var total int
-	err := s.db.QueryRow(ctx, countQuery+whereClause, args...).Scan(&total)
-	if err != nil {
-		writeError(w, "Failed to count players", http.StatusInternalServerError)
-		return
-	}
-
-	// Build ORDER and LIMIT clause
-	orderClause := buildOrderClause(params, "p", "last_name")
-	offset := calculateOffset(params.Page, params.PageSize)
-	limitClause := fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, offset)
-
-	// Execute main query
-	finalQuery := baseQuery + whereClause + orderClause + limitClause
-	rows, err := s.db.Query(ctx, finalQuery, args...)
-	if err != nil {
-		writeError(w, "Failed to query players", http.StatusInternalServerError)
-		return
-	}
-	defer rows.Close()
-
 	var players []PlayerWithTeam
-	for rows.Next() {
-		var p PlayerWithTeam
-		var teamName, teamCity, teamAbbr *string
-		var jerseyNumber *string  // Add this for nullable jersey_number
+	err := s.withSnapshot(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, countQuery+whereClause, args...).Scan(&total); err != nil {
+			return fmt.Errorf("count players: %w", err)
+		}
 
-		err := rows.Scan(
-			&p.ID, &p.PlayerID, &p.FirstName, &p.LastName, &p.FullName,
-			&p.Position, &p.TeamID, &jerseyNumber, &p.Height, &p.Weight,  // Use &jerseyNumber instead of &p.JerseyNumber
-			&p.BirthDate, &p.BirthCity, &p.BirthCountry, &p.Bats, &p.Throws,
-			&p.DebutDate, &p.Status, &p.CreatedAt, &p.UpdatedAt,
-			&teamName, &teamCity, &teamAbbr,
-		)
+		// Build ORDER and LIMIT clause
+		orderClause := buildOrderClause(params, playerListSorts, "last_name")
+		offset := calculateOffset(params.Page, params.PageSize)
+		limitClause := fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, offset)
+
+		// Execute main query
+		finalQuery := baseQuery + whereClause + orderClause + limitClause
+		rows, err := tx.Query(ctx, finalQuery, args...)
 		if err != nil {
-			log.Printf("Failed to scan player: %v", err)
-			log.Printf("Query: %s", finalQuery)
-			writeError(w, fmt.Sprintf("Failed to scan player: %v", err), http.StatusInternalServerError)
-			return
+			return fmt.Errorf("query players: %w", err)
 		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var p PlayerWithTeam
+			var teamName, teamCity, teamAbbr *string
+			var jerseyNumber *string  // Add this for nullable jersey_number
+
+			err := rows.Scan(
+				&p.ID, &p.PlayerID, &p.FirstName, &p.LastName, &p.FullName,
+				&p.Position, &p.TeamID, &jerseyNumber, &p.Height, &p.Weight,  // Use &jerseyNumber instead of &p.JerseyNumber
+				&p.BirthDate, &p.BirthCity, &p.BirthCountry, &p.Bats, &p.Throws,
+				&p.DebutDate, &p.Status, &p.CreatedAt, &p.UpdatedAt,
+				&teamName, &teamCity, &teamAbbr,
+			)
+			if err != nil {
+				return fmt.Errorf("scan player: %w", err)
+			}
 
-		// Handle nullable jersey_number
-		if jerseyNumber != nil {
-			p.JerseyNumber = *jerseyNumber
-		}
+			// Handle nullable jersey_number
+			if jerseyNumber != nil {
+				p.JerseyNumber = *jerseyNumber
+			}
 
-		// Add team information if available
-		if teamName != nil {
-			p.Team = &Team{
-				ID:           p.TeamID,
-				Name:         *teamName,
-				Abbreviation: *teamAbbr,
+			// Add team information if available
+			if teamName != nil {
+				p.Team = &Team{
+					ID:           p.TeamID,
+					Name:         *teamName,
+					Abbreviation: *teamAbbr,
+				}
 			}
-		}
 
-		players = append(players, p)
+			players = append(players, p)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		log.Printf("Failed to query players: %v", err)
+		writeError(w, "Failed to query players", http.StatusInternalServerError)
+		return
 	}
 
-	response := buildPaginatedResponse(players, total, params.Page, params.PageSize)
+	response := buildPaginatedResponse(players, total, params)
 	writeJSON(w, response)
 }
 
@@ -1229,6 +1774,11 @@ func (s *Server) getPlayerHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := contextWithTimeout(r.Context())
 	defer cancel()
 
+	resolved, ok := s.resolveEntity(ctx, w, ids.Player, playerID)
+	if !ok {
+		return
+	}
+
 	query := `
 		SELECT p.id::text, p.player_id, p.first_name, p.last_name,
 		       COALESCE(p.full_name, CONCAT(p.first_name, ' ', p.last_name)) as full_name,
@@ -1239,13 +1789,13 @@ func (s *Server) getPlayerHandler(w http.ResponseWriter, r *http.Request) {
 		       t.city as team_city, t.abbreviation as team_abbreviation
 		FROM players p
 		LEFT JOIN teams t ON p.team_id = t.id
-		WHERE p.id::text = $1 OR p.player_id = $1`
+		WHERE p.id = $1`
 
 	var p PlayerWithTeam
 	var teamInternalID, teamID, teamName, teamCity, teamAbbr *string
 	var jerseyNumber *string  // Add this for nullable jersey_number
 
-	err := s.db.QueryRow(ctx, query, playerID).Scan(
+	err := s.readDB().QueryRow(ctx, query, resolved.ID).Scan(
 		&p.ID, &p.PlayerID, &p.FirstName, &p.LastName, &p.FullName,
 		&p.Position, &p.TeamID, &jerseyNumber, &p.Height, &p.Weight,  // Use &jerseyNumber
 		&p.BirthDate, &p.BirthCity, &p.BirthCountry, &p.Bats, &p.Throws,
@@ -1290,9 +1840,21 @@ func (s *Server) getPlayerStatsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	adjustPark, ok := parseAdjustedParam(r)
+	if !ok {
+		writeError(w, "Invalid adjusted parameter, supported: park", http.StatusBadRequest)
+		return
+	}
+
 	ctx, cancel := contextWithTimeout(r.Context())
 	defer cancel()
 
+	resolved, ok := s.resolveEntity(ctx, w, ids.Player, playerID)
+	if !ok {
+		return
+	}
+	playerID = resolved.ID
+
 	// Get season parameter - if not specified, return all seasons
 	var query string
 	var rows pgx.Rows
@@ -1309,28 +1871,20 @@ func (s *Server) getPlayerStatsHandler(w http.ResponseWriter, r *http.Request) {
 		query = `
 			SELECT player_id, season, stats_type, aggregated_stats, games_played, last_updated
 			FROM player_season_aggregates
-			WHERE player_id = (
-				SELECT id FROM players
-				WHERE id::text = $1 OR player_id = $1
-				LIMIT 1
-			)
+			WHERE player_id = $1
 			AND season = $2
 			ORDER BY stats_type`
 
-		rows, err = s.db.Query(ctx, query, playerID, season)
+		rows, err = s.readDB().Query(ctx, query, playerID, season)
 	} else {
 		// Query all seasons
 		query = `
 			SELECT player_id, season, stats_type, aggregated_stats, games_played, last_updated
 			FROM player_season_aggregates
-			WHERE player_id = (
-				SELECT id FROM players
-				WHERE id::text = $1 OR player_id = $1
-				LIMIT 1
-			)
+			WHERE player_id = $1
 			ORDER BY season DESC, stats_type`
 
-		rows, err = s.db.Query(ctx, query, playerID)
+		rows, err = s.readDB().Query(ctx, query, playerID)
 	}
 
 	if err != nil {
@@ -1373,80 +1927,104 @@ func (s *Server) getPlayerStatsHandler(w http.ResponseWriter, r *http.Request) {
 		stats = []PlayerStats{}
 	}
 
+	// Park-neutralized lines relative to each season's home park
+	if adjustPark {
+		parks := make(map[int]*ParkAdjustment)
+		for i := range stats {
+			adj, ok := parks[stats[i].Season]
+			if !ok {
+				adj = s.loadParkAdjustment(ctx, playerParkQuery, playerID, stats[i].Season)
+				parks[stats[i].Season] = adj
+			}
+			if adj != nil {
+				stats[i].ParkAdjustedStats = parkAdjustStats(stats[i].AggregatedStats, stats[i].StatsType, adj.Factors)
+				stats[i].ParkAdjustment = adj
+			}
+		}
+	}
+
 	// Return array directly, not wrapped
 	writeJSON(w, stats)
 }
 
 // Umpires handlers
-func (s *Server) getUmpiresHandler(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := contextWithTimeout(r.Context())
-	defer cancel()
 
+// getUmpiresHandler lists umpires with one season of stats each: ?season= or
+// their latest. ?sort= orders by name or a stat, and ?min_games= drops
+// umpires with fewer games that season.
+func (s *Server) getUmpiresHandler(w http.ResponseWriter, r *http.Request) {
 	params := parseQueryParams(r)
+	filters, msg := parseUmpireListFilters(r.URL.Query())
+	if msg != "" {
+		writeError(w, msg, http.StatusBadRequest)
+		return
+	}
 
-	// Build base query - umpires table only has basic info
-	baseQuery := `
-		SELECT id, umpire_id, name, tendencies, created_at
-		FROM umpires`
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
 
-	// Count query for pagination
-	countQuery := "SELECT COUNT(*) FROM umpires"
+	// Each umpire is listed with the requested season's stats, or the latest
+	from, where, orderBy, args := filters.query()
 
-	// Get total count
 	var total int
-	err := s.db.QueryRow(ctx, countQuery).Scan(&total)
-	if err != nil {
-		writeError(w, "Failed to count umpires", http.StatusInternalServerError)
-		return
-	}
-
-	// Build ORDER and LIMIT clause
-	orderClause := " ORDER BY name ASC"
-	if params.Sort != "" {
-		allowedSorts := map[string]bool{
-			"name": true,
-		}
-		if allowedSorts[params.Sort] {
-			orderClause = fmt.Sprintf(" ORDER BY %s %s", params.Sort, strings.ToUpper(params.Order))
+	umpires := []Umpire{}
+	err := s.withSnapshot(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, "SELECT COUNT(*)"+from+where, args...).Scan(&total); err != nil {
+			return fmt.Errorf("count umpires: %w", err)
 		}
-	}
-
-	offset := calculateOffset(params.Page, params.PageSize)
-	limitClause := fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, offset)
 
-	// Execute main query
-	finalQuery := baseQuery + orderClause + limitClause
-	rows, err := s.db.Query(ctx, finalQuery)
-	if err != nil {
-		writeError(w, "Failed to query umpires", http.StatusInternalServerError)
-		return
-	}
-	defer rows.Close()
+		offset := calculateOffset(params.Page, params.PageSize)
+		limitClause := fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, offset)
 
-	var umpires []Umpire
-	for rows.Next() {
-		var umpire Umpire
-		var tendenciesJSON []byte
-		err := rows.Scan(
-			&umpire.ID, &umpire.UmpireID, &umpire.Name, &tendenciesJSON, &umpire.CreatedAt,
-		)
+		finalQuery := `
+			SELECT u.id, u.umpire_id, u.name, u.tendencies, u.created_at,
+			       s.season, s.games_umped, s.accuracy_pct, s.consistency_pct, s.favor_home,
+			       s.strike_pct, s.k_pct_above_avg, s.bb_pct_above_avg` + from + where + orderBy + limitClause
+		rows, err := tx.Query(ctx, finalQuery, args...)
 		if err != nil {
-			writeError(w, "Failed to scan umpire", http.StatusInternalServerError)
-			return
+			return fmt.Errorf("query umpires: %w", err)
 		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var umpire Umpire
+			var tendenciesJSON []byte
+			var season, gamesUmped *int
+			var stats UmpireListStats
+			err := rows.Scan(
+				&umpire.ID, &umpire.UmpireID, &umpire.Name, &tendenciesJSON, &umpire.CreatedAt,
+				&season, &gamesUmped, &stats.AccuracyPct, &stats.ConsistencyPct, &stats.FavorHome,
+				&stats.StrikePct, &stats.KPctAboveAvg, &stats.BBPctAboveAvg,
+			)
+			if err != nil {
+				return fmt.Errorf("scan umpire: %w", err)
+			}
 
-		// Parse tendencies JSON if present
-		if len(tendenciesJSON) > 0 {
-			if err := json.Unmarshal(tendenciesJSON, &umpire.Tendencies); err != nil {
-				log.Printf("Failed to parse tendencies: %v", err)
-				umpire.Tendencies = make(map[string]interface{})
+			// Parse tendencies JSON if present
+			if len(tendenciesJSON) > 0 {
+				if err := json.Unmarshal(tendenciesJSON, &umpire.Tendencies); err != nil {
+					log.Printf("Failed to parse tendencies: %v", err)
+					umpire.Tendencies = make(map[string]interface{})
+				}
 			}
-		}
 
-		umpires = append(umpires, umpire)
+			if season != nil {
+				stats.Season = *season
+				stats.GamesUmped = intOrZero(gamesUmped)
+				umpire.SeasonStats = &stats
+			}
+
+			umpires = append(umpires, umpire)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		log.Printf("Failed to query umpires: %v", err)
+		writeError(w, "Failed to query umpires", http.StatusInternalServerError)
+		return
 	}
 
-	response := buildPaginatedResponse(umpires, total, params.Page, params.PageSize)
+	response := buildPaginatedResponse(umpires, total, params)
 	writeJSON(w, response)
 }
 
@@ -1462,14 +2040,19 @@ func (s *Server) getUmpireHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := contextWithTimeout(r.Context())
 	defer cancel()
 
+	resolved, ok := s.resolveEntity(ctx, w, ids.Umpire, umpireID)
+	if !ok {
+		return
+	}
+
 	query := `
 		SELECT id, umpire_id, name, tendencies, created_at
 		FROM umpires
-		WHERE umpire_id = $1 OR (id::text = $1 AND $1 ~ '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$')`
+		WHERE id = $1`
 
 	var umpire Umpire
 	var tendenciesJSON []byte
-	err := s.db.QueryRow(ctx, query, umpireID).Scan(
+	err := s.readDB().QueryRow(ctx, query, resolved.ID).Scan(
 		&umpire.ID, &umpire.UmpireID, &umpire.Name, &tendenciesJSON, &umpire.CreatedAt,
 	)
 	if err != nil {
@@ -1504,6 +2087,12 @@ func (s *Server) getUmpireStatsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := contextWithTimeout(r.Context())
 	defer cancel()
 
+	resolved, ok := s.resolveEntity(ctx, w, ids.Umpire, umpireID)
+	if !ok {
+		return
+	}
+	umpireID = resolved.ID
+
 	// Get season parameter - if not specified, return all seasons
 	var query string
 	var rows pgx.Rows
@@ -1517,32 +2106,16 @@ func (s *Server) getUmpireStatsHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		query = `
-			SELECT uss.season, uss.games_umped, uss.accuracy_pct, uss.consistency_pct,
-			       uss.favor_home, uss.expected_accuracy, uss.expected_consistency,
-			       uss.correct_calls, uss.incorrect_calls, uss.total_calls,
-			       uss.strike_pct, uss.ball_pct, uss.k_pct_above_avg, uss.bb_pct_above_avg,
-			       uss.home_plate_calls_per_game, uss.created_at, uss.updated_at
-			FROM umpire_season_stats uss
-			JOIN umpires u ON uss.umpire_id = u.id
-			WHERE (u.id::text = $1 OR u.umpire_id = $1)
-			  AND uss.season = $2`
-
-		rows, err = s.db.Query(ctx, query, umpireID, season)
+		query = umpireSeasonStatsRankedQuery + `
+			  AND r.season = $2`
+
+		rows, err = s.readDB().Query(ctx, query, umpireID, season)
 	} else {
 		// Query all seasons
-		query = `
-			SELECT uss.season, uss.games_umped, uss.accuracy_pct, uss.consistency_pct,
-			       uss.favor_home, uss.expected_accuracy, uss.expected_consistency,
-			       uss.correct_calls, uss.incorrect_calls, uss.total_calls,
-			       uss.strike_pct, uss.ball_pct, uss.k_pct_above_avg, uss.bb_pct_above_avg,
-			       uss.home_plate_calls_per_game, uss.created_at, uss.updated_at
-			FROM umpire_season_stats uss
-			JOIN umpires u ON uss.umpire_id = u.id
-			WHERE (u.id::text = $1 OR u.umpire_id = $1)
-			ORDER BY uss.season DESC`
+		query = umpireSeasonStatsRankedQuery + `
+			ORDER BY r.season DESC`
 
-		rows, err = s.db.Query(ctx, query, umpireID)
+		rows, err = s.readDB().Query(ctx, query, umpireID)
 	}
 
 	if err != nil {
@@ -1555,6 +2128,7 @@ func (s *Server) getUmpireStatsHandler(w http.ResponseWriter, r *http.Request) {
 	var statsList []UmpireSeasonStats
 	for rows.Next() {
 		var stats UmpireSeasonStats
+		var pctiles UmpirePercentiles
 		err := rows.Scan(
 			&stats.Season, &stats.GamesUmped, &stats.AccuracyPct, &stats.ConsistencyPct,
 			&stats.FavorHome, &stats.ExpectedAccuracy, &stats.ExpectedConsistency,
@@ -1562,12 +2136,14 @@ func (s *Server) getUmpireStatsHandler(w http.ResponseWriter, r *http.Request) {
 			&stats.StrikePct, &stats.BallPct, &stats.KPctAboveAvg,
 			&stats.BBPctAboveAvg, &stats.HomePlateCallsPerGame,
 			&stats.CreatedAt, &stats.UpdatedAt,
+			&pctiles.AccuracyPct, &pctiles.ConsistencyPct, &pctiles.FavorHome, &pctiles.UmpiresRanked,
 		)
 		if err != nil {
 			log.Printf("Failed to scan umpire stats: %v", err)
 			writeError(w, "Failed to scan umpire stats", http.StatusInternalServerError)
 			return
 		}
+		stats.Percentiles = &pctiles
 		statsList = append(statsList, stats)
 	}
 
@@ -1580,6 +2156,119 @@ func (s *Server) getUmpireStatsHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, statsList)
 }
 
+// getUmpireGamesHandler lists the games an umpire worked (see migration 013),
+// optionally filtered by ?season= and ?position=, newest first
+func (s *Server) getUmpireGamesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	umpireID := vars["id"]
+
+	if umpireID == "" {
+		writeError(w, "Umpire ID is required", http.StatusBadRequest)
+		return
+	}
+
+	params := parseQueryParams(r)
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" && params.Season == nil {
+		writeError(w, "Invalid season parameter", http.StatusBadRequest)
+		return
+	}
+
+	position := strings.ToUpper(r.URL.Query().Get("position"))
+	if position != "" && !validUmpirePositions[position] {
+		writeError(w, "Invalid position parameter (expected HP, 1B, 2B, 3B, LF or RF)", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	// Resolve the umpire first so unknown IDs return 404 rather than an empty page
+	resolved, ok := s.resolveEntity(ctx, w, ids.Umpire, umpireID)
+	if !ok {
+		return
+	}
+
+	whereClause := " WHERE gu.umpire_id = $1"
+	args := []interface{}{resolved.ID}
+	if params.Season != nil {
+		args = append(args, *params.Season)
+		whereClause += fmt.Sprintf(" AND g.season = $%d", len(args))
+	}
+	if position != "" {
+		args = append(args, position)
+		whereClause += fmt.Sprintf(" AND gu.position = $%d", len(args))
+	}
+
+	var total int
+	games := []UmpireGame{}
+	err := s.withSnapshot(ctx, func(tx pgx.Tx) error {
+		countQuery := `
+			SELECT COUNT(*)
+			FROM game_umpires gu
+			JOIN games_read_model g ON g.id = gu.game_id` + whereClause
+		if err := tx.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+			return fmt.Errorf("count umpire games: %w", err)
+		}
+
+		offset := calculateOffset(params.Page, params.PageSize)
+		query := `
+			SELECT g.game_id, g.game_date, g.season, COALESCE(g.status, ''), gu.position,
+			       gu.accuracy_pct, gu.correct_calls, gu.incorrect_calls,
+			       g.home_team_id::text, g.home_team_name, g.home_team_abbr,
+			       g.away_team_id::text, g.away_team_name, g.away_team_abbr,
+			       g.final_score_home, g.final_score_away,
+			       hb.strikeouts, hb.walks, ab.strikeouts, ab.walks
+			FROM game_umpires gu
+			JOIN games_read_model g ON g.id = gu.game_id
+			LEFT JOIN LATERAL (
+				SELECT SUM(b.strikeouts)::int AS strikeouts, SUM(b.walks)::int AS walks
+				FROM game_box_score_batting b
+				WHERE b.game_id = g.id AND b.team_id = g.home_team_id
+			) hb ON true
+			LEFT JOIN LATERAL (
+				SELECT SUM(b.strikeouts)::int AS strikeouts, SUM(b.walks)::int AS walks
+				FROM game_box_score_batting b
+				WHERE b.game_id = g.id AND b.team_id = g.away_team_id
+			) ab ON true` + whereClause +
+			fmt.Sprintf(" ORDER BY g.game_date DESC, g.game_id DESC LIMIT %d OFFSET %d", params.PageSize, offset)
+
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("query umpire games: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var game UmpireGame
+			err := rows.Scan(
+				&game.GameID, &game.GameDate, &game.Season, &game.Status, &game.Position,
+				&game.AccuracyPct, &game.CorrectCalls, &game.IncorrectCalls,
+				&game.HomeTeamID, &game.HomeTeamName, &game.HomeTeamAbbr,
+				&game.AwayTeamID, &game.AwayTeamName, &game.AwayTeamAbbr,
+				&game.HomeScore, &game.AwayScore,
+				&game.HomeStrikeouts, &game.HomeWalks, &game.AwayStrikeouts, &game.AwayWalks,
+			)
+			if err != nil {
+				return fmt.Errorf("scan umpire game: %w", err)
+			}
+			games = append(games, game)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		log.Printf("Failed to query umpire games: %v (umpireID=%s)", err, umpireID)
+		writeError(w, "Failed to query umpire games", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, buildPaginatedResponse(games, total, params))
+}
+
+// validUmpirePositions are the crew positions stored in game_umpires
+var validUmpirePositions = map[string]bool{
+	"HP": true, "1B": true, "2B": true, "3B": true, "LF": true, "RF": true,
+}
+
 // Games handlers
 func (s *Server) getGamesHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := contextWithTimeout(r.Context())
@@ -1587,120 +2276,137 @@ func (s *Server) getGamesHandler(w http.ResponseWriter, r *http.Request) {
 
 	params := parseQueryParams(r)
 
-	// Build base query with team information
+	gameTypes, err := parseGameTypes(r.URL.Query(), nil)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params.GameTypes = gameTypes
+	if _, ok := gameStatusFor(params.Status); params.Status != "" && !ok {
+		writeError(w, "Invalid status parameter (expected scheduled, live, final, postponed or cancelled)", http.StatusBadRequest)
+		return
+	}
+	if !s.resolveTeamFilter(ctx, w, &params) {
+		return
+	}
+
+	// Build base query against the denormalized read model (see migration 011)
 	baseQuery := `
 		SELECT g.id::text, g.game_id, g.season, COALESCE(g.game_type, ''), g.game_date,
 		       g.home_team_id::text, g.away_team_id::text, g.final_score_home, g.final_score_away,
 		       COALESCE(g.status, ''), COALESCE(g.stadium_id::text, ''), g.created_at, g.updated_at,
-		       ht.name as home_team_name, ht.city as home_team_city, ht.abbreviation as home_team_abbr,
-		       at.name as away_team_name, at.city as away_team_city, at.abbreviation as away_team_abbr,
-		       s.name as stadium_name, s.location as stadium_location
-		FROM games g
-		LEFT JOIN teams ht ON g.home_team_id = ht.id
-		LEFT JOIN teams at ON g.away_team_id = at.id
-		LEFT JOIN stadiums s ON g.stadium_id = s.id`
+		       g.home_team_name, g.home_team_city, g.home_team_abbr,
+		       g.away_team_name, g.away_team_city, g.away_team_abbr,
+		       g.stadium_name, g.stadium_location,
+		       ` + gameLiveColumns + `
+		FROM games_read_model g`
 
 	// Count query
 	countQuery := `
-		SELECT COUNT(*) 
-		FROM games g
-		LEFT JOIN teams ht ON g.home_team_id = ht.id
-		LEFT JOIN teams at ON g.away_team_id = at.id`
+		SELECT COUNT(*)
+		FROM games_read_model g`
 
 	// Build WHERE clause
 	whereClause, args := buildGamesWhereClause(params)
 
-	// Get total count
 	var total int
-	err := s.db.QueryRow(ctx, countQuery+whereClause, args...).Scan(&total)
-	if err != nil {
-		writeError(w, "Failed to count games", http.StatusInternalServerError)
-		return
-	}
-
-	// Build ORDER and LIMIT clause
-	// Default to DESC for games (show most recent first) if order not specified
-	if params.Order == "asc" && r.URL.Query().Get("order") == "" {
-		params.Order = "desc"
-	}
-	orderClause := buildOrderClause(params, "g", "game_date")
-	offset := calculateOffset(params.Page, params.PageSize)
-	limitClause := fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, offset)
-
-	// Execute main query
-	finalQuery := baseQuery + whereClause + orderClause + limitClause
-	rows, err := s.db.Query(ctx, finalQuery, args...)
-	if err != nil {
-		writeError(w, "Failed to query games", http.StatusInternalServerError)
-		return
-	}
-	defer rows.Close()
-
 	var games []GameWithTeams
-	for rows.Next() {
-		var g GameWithTeams
-		var homeTeamName, homeTeamCity, homeTeamAbbr *string
-		var awayTeamName, awayTeamCity, awayTeamAbbr *string
-		var stadiumName, stadiumLocation *string
+	err = s.withSnapshot(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, countQuery+whereClause, args...).Scan(&total); err != nil {
+			return fmt.Errorf("count games: %w", err)
+		}
 
-		err := rows.Scan(
-			&g.ID, &g.GameID, &g.Season, &g.GameType, &g.GameDate,
-			&g.HomeTeamID, &g.AwayTeamID, &g.HomeScore, &g.AwayScore,
-			&g.Status, &g.StadiumID, &g.CreatedAt, &g.UpdatedAt,
-			&homeTeamName, &homeTeamCity, &homeTeamAbbr,
-			&awayTeamName, &awayTeamCity, &awayTeamAbbr,
-			&stadiumName, &stadiumLocation,
-		)
-		if err != nil {
-			writeError(w, "Failed to scan game", http.StatusInternalServerError)
-			return
+		// Build ORDER and LIMIT clause
+		// Default to DESC for games (show most recent first) if order not specified
+		if params.Order == "asc" && r.URL.Query().Get("order") == "" {
+			params.Order = "desc"
 		}
+		orderClause := buildOrderClause(params, gameListSorts, "game_date")
+		offset := calculateOffset(params.Page, params.PageSize)
+		limitClause := fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, offset)
 
-		// Add team information
-		if homeTeamName != nil {
-			// Use the full name from database as-is
-			g.HomeTeamName = *homeTeamName
-			abbr := ""
-			if homeTeamAbbr != nil {
-				abbr = *homeTeamAbbr
-			}
-			g.HomeTeam = &Team{
-				ID:           g.HomeTeamID,
-				Name:         *homeTeamName,
-				City:         homeTeamCity,
-				Abbreviation: abbr,
-			}
+		// Execute main query
+		finalQuery := baseQuery + whereClause + orderClause + limitClause
+		rows, err := tx.Query(ctx, finalQuery, args...)
+		if err != nil {
+			return fmt.Errorf("query games: %w", err)
 		}
-		if awayTeamName != nil {
-			// Use the full name from database as-is
-			g.AwayTeamName = *awayTeamName
-			abbr := ""
-			if awayTeamAbbr != nil {
-				abbr = *awayTeamAbbr
+		defer rows.Close()
+
+		for rows.Next() {
+			var g GameWithTeams
+			var homeTeamName, homeTeamCity, homeTeamAbbr *string
+			var awayTeamName, awayTeamCity, awayTeamAbbr *string
+			var stadiumName, stadiumLocation *string
+			var live gameLiveRow
+
+			err := rows.Scan(
+				&g.ID, &g.GameID, &g.Season, &g.GameType, &g.GameDate,
+				&g.HomeTeamID, &g.AwayTeamID, &g.HomeScore, &g.AwayScore,
+				&g.Status, &g.StadiumID, &g.CreatedAt, &g.UpdatedAt,
+				&homeTeamName, &homeTeamCity, &homeTeamAbbr,
+				&awayTeamName, &awayTeamCity, &awayTeamAbbr,
+				&stadiumName, &stadiumLocation,
+				&live.homeScore, &live.awayScore, &live.inning, &live.inningHalf, &live.outs, &live.updatedAt,
+			)
+			if err != nil {
+				return fmt.Errorf("scan game: %w", err)
 			}
-			g.AwayTeam = &Team{
-				ID:           g.AwayTeamID,
-				Name:         *awayTeamName,
-				City:         awayTeamCity,
-				Abbreviation: abbr,
+
+			g.applyGameState(live)
+
+			// Add team information
+			if homeTeamName != nil {
+				// Use the full name from database as-is
+				g.HomeTeamName = *homeTeamName
+				abbr := ""
+				if homeTeamAbbr != nil {
+					abbr = *homeTeamAbbr
+				}
+				g.HomeTeam = &Team{
+					ID:           g.HomeTeamID,
+					Name:         *homeTeamName,
+					City:         homeTeamCity,
+					Abbreviation: abbr,
+				}
 			}
-		}
-		if stadiumName != nil {
-			location := ""
-			if stadiumLocation != nil {
-				location = *stadiumLocation
+			if awayTeamName != nil {
+				// Use the full name from database as-is
+				g.AwayTeamName = *awayTeamName
+				abbr := ""
+				if awayTeamAbbr != nil {
+					abbr = *awayTeamAbbr
+				}
+				g.AwayTeam = &Team{
+					ID:           g.AwayTeamID,
+					Name:         *awayTeamName,
+					City:         awayTeamCity,
+					Abbreviation: abbr,
+				}
 			}
-			g.Stadium = &Stadium{
-				ID:   g.StadiumID,
-				Name: *stadiumName,
-				City: location,
+			if stadiumName != nil {
+				location := ""
+				if stadiumLocation != nil {
+					location = *stadiumLocation
+				}
+				g.Stadium = &Stadium{
+					ID:   g.StadiumID,
+					Name: *stadiumName,
+					City: location,
+				}
 			}
-		}
 
-		games = append(games, g)
+			games = append(games, g)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		log.Printf("Failed to query games: %v", err)
+		writeError(w, "Failed to query games", http.StatusInternalServerError)
+		return
 	}
 
-	response := buildPaginatedResponse(games, total, params.Page, params.PageSize)
+	response := buildPaginatedResponse(games, total, params)
 	writeJSON(w, response)
 }
 
@@ -1716,6 +2422,11 @@ func (s *Server) getGameHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := contextWithTimeout(r.Context())
 	defer cancel()
 
+	resolved, ok := s.resolveEntity(ctx, w, ids.Game, gameID)
+	if !ok {
+		return
+	}
+
 	query := `
 		SELECT g.id::text, g.game_id, g.season, COALESCE(g.game_type, ''), g.game_date,
 		       g.home_team_id::text, g.away_team_id::text, g.final_score_home, g.final_score_away,
@@ -1724,26 +2435,29 @@ func (s *Server) getGameHandler(w http.ResponseWriter, r *http.Request) {
 		       ht.city as home_team_city, ht.abbreviation as home_team_abbr,
 		       at.team_id as away_team_external_id, at.name as away_team_name,
 		       at.city as away_team_city, at.abbreviation as away_team_abbr,
-		       s.name as stadium_name, s.location as stadium_location, s.capacity as stadium_capacity
+		       s.name as stadium_name, s.location as stadium_location, s.capacity as stadium_capacity,
+		       ` + gameLiveColumns + `
 		FROM games g
 		LEFT JOIN teams ht ON g.home_team_id = ht.id
 		LEFT JOIN teams at ON g.away_team_id = at.id
 		LEFT JOIN stadiums s ON g.stadium_id = s.id
-		WHERE g.id::text = $1 OR g.game_id = $1`
+		WHERE g.id = $1`
 
 	var g GameWithTeams
 	var homeTeamExternalID, homeTeamName, homeTeamCity, homeTeamAbbr *string
 	var awayTeamExternalID, awayTeamName, awayTeamCity, awayTeamAbbr *string
 	var stadiumName, stadiumLocation *string
 	var stadiumCapacity *int
+	var live gameLiveRow
 
-	err := s.db.QueryRow(ctx, query, gameID).Scan(
+	err := s.readDB().QueryRow(ctx, query, resolved.ID).Scan(
 		&g.ID, &g.GameID, &g.Season, &g.GameType, &g.GameDate,
 		&g.HomeTeamID, &g.AwayTeamID, &g.HomeScore, &g.AwayScore,
 		&g.Status, &g.StadiumID, &g.CreatedAt, &g.UpdatedAt,
 		&homeTeamExternalID, &homeTeamName, &homeTeamCity, &homeTeamAbbr,
 		&awayTeamExternalID, &awayTeamName, &awayTeamCity, &awayTeamAbbr,
 		&stadiumName, &stadiumLocation, &stadiumCapacity,
+		&live.homeScore, &live.awayScore, &live.inning, &live.inningHalf, &live.outs, &live.updatedAt,
 	)
 
 	if err != nil {
@@ -1755,6 +2469,8 @@ func (s *Server) getGameHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	g.applyGameState(live)
+
 	// Add team and stadium information
 	if homeTeamName != nil {
 		g.HomeTeam = &Team{
@@ -1802,15 +2518,14 @@ func (s *Server) getGamesByDateHandler(w http.ResponseWriter, r *http.Request) {
 		SELECT g.id::text, g.game_id, g.season, COALESCE(g.game_type, ''), g.game_date,
 		       g.home_team_id::text, g.away_team_id::text, g.final_score_home, g.final_score_away,
 		       COALESCE(g.status, ''), COALESCE(g.stadium_id::text, ''), g.created_at, g.updated_at,
-		       ht.name as home_team_name, ht.city as home_team_city, ht.abbreviation as home_team_abbr,
-		       at.name as away_team_name, at.city as away_team_city, at.abbreviation as away_team_abbr
-		FROM games g
-		LEFT JOIN teams ht ON g.home_team_id = ht.id
-		LEFT JOIN teams at ON g.away_team_id = at.id
+		       g.home_team_name, g.home_team_city, g.home_team_abbr,
+		       g.away_team_name, g.away_team_city, g.away_team_abbr,
+		       ` + gameLiveColumns + `
+		FROM games_read_model g
 		WHERE g.game_date >= $1 AND g.game_date < $2
 		ORDER BY g.game_date ASC`
 
-	rows, err := s.db.Query(ctx, query, date, nextDate)
+	rows, err := s.readDB().Query(ctx, query, date, nextDate)
 	if err != nil {
 		writeError(w, "Failed to query games", http.StatusInternalServerError)
 		return
@@ -1822,6 +2537,7 @@ func (s *Server) getGamesByDateHandler(w http.ResponseWriter, r *http.Request) {
 		var g GameWithTeams
 		var homeTeamName, homeTeamCity, homeTeamAbbr *string
 		var awayTeamName, awayTeamCity, awayTeamAbbr *string
+		var live gameLiveRow
 
 		err := rows.Scan(
 			&g.ID, &g.GameID, &g.Season, &g.GameType, &g.GameDate,
@@ -1829,12 +2545,15 @@ func (s *Server) getGamesByDateHandler(w http.ResponseWriter, r *http.Request) {
 			&g.Status, &g.StadiumID, &g.CreatedAt, &g.UpdatedAt,
 			&homeTeamName, &homeTeamCity, &homeTeamAbbr,
 			&awayTeamName, &awayTeamCity, &awayTeamAbbr,
+			&live.homeScore, &live.awayScore, &live.inning, &live.inningHalf, &live.outs, &live.updatedAt,
 		)
 		if err != nil {
 			writeError(w, "Failed to scan game", http.StatusInternalServerError)
 			return
 		}
 
+		g.applyGameState(live)
+
 		// Add team information
 		if homeTeamName != nil {
 			g.HomeTeam = &Team{
@@ -1864,8 +2583,7 @@ func (s *Server) getGamesByDateHandler(w http.ResponseWriter, r *http.Request) {
 // Simulation proxy handlers
 func (s *Server) createSimulationHandler(w http.ResponseWriter, r *http.Request) {
 	var req SimulationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, "Invalid request body", http.StatusBadRequest)
+	if !s.decodeJSONBody(w, r, &req, false) {
 		return
 	}
 
@@ -1874,15 +2592,44 @@ func (s *Server) createSimulationHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	tier, ok := s.authorizeSimulationRuns(w, r, req.SimulationRuns)
+	if !ok {
+		return
+	}
+
+	// The engine can't record a run without the database; hold the request
+	// and submit it after recovery instead of failing it
+	if !s.dbRouter.PrimaryHealthy() {
+		s.queueSimulation(w, req, tier)
+		return
+	}
+
 	// Forward request to simulation engine
 	reqBody, _ := json.Marshal(req)
-	resp, err := http.Post(s.config.SimEngineURL+"/simulate", "application/json", strings.NewReader(string(reqBody)))
+	resp, err := s.postToSimEngine(r.Context(), "/simulate", strings.NewReader(string(reqBody)), tier)
 	if err != nil {
 		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
 		return
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= 500 && !s.dbRouter.checkPrimary() {
+		s.queueSimulation(w, req, tier)
+		return
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		writeSimEngineBusy(w, resp)
+		return
+	}
+
+	// Surface engine validation errors (e.g. run limit rechecks) as JSON
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
 	// Forward response status and body
 	w.WriteHeader(resp.StatusCode)
 	w.Header().Set("Content-Type", "application/json")
@@ -1892,6 +2639,9 @@ func (s *Server) createSimulationHandler(w http.ResponseWriter, r *http.Request)
 		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
 		return
 	}
+	if runID, ok := result["run_id"].(string); ok {
+		setAuditTarget(w, runID)
+	}
 
 	writeJSON(w, result)
 }
@@ -1906,7 +2656,7 @@ func (s *Server) getSimulationHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Forward request to simulation engine
-	resp, err := http.Get(s.config.SimEngineURL + "/simulation/" + simID + "/result")
+	resp, err := s.simEngineClient.Get(r.Context(), s.simEngineURL() + "/simulation/" + simID + "/result")
 	if err != nil {
 		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
 		return
@@ -1936,7 +2686,7 @@ func (s *Server) getSimulationStatusHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Forward request to simulation engine
-	resp, err := http.Get(s.config.SimEngineURL + "/simulation/" + simID + "/status")
+	resp, err := s.simEngineClient.Get(r.Context(), s.simEngineURL() + "/simulation/" + simID + "/status")
 	if err != nil {
 		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
 		return
@@ -1956,10 +2706,438 @@ func (s *Server) getSimulationStatusHandler(w http.ResponseWriter, r *http.Reque
 	writeJSON(w, result)
 }
 
+// getSimulationSamplesHandler proxies a random sample of raw per-simulation outcomes
+func (s *Server) getSimulationSamplesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	simID := vars["id"]
+
+	if simID == "" {
+		writeError(w, "Simulation ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Forward request to simulation engine, preserving ?n= and ?seed=
+	url := s.simEngineURL() + "/simulation/" + simID + "/samples"
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+	resp, err := s.simEngineClient.Get(r.Context(), url)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// getSimulationEventsHandler returns a run's key events above a leverage threshold
+func (s *Server) getSimulationEventsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	simID := vars["id"]
+
+	if simID == "" {
+		writeError(w, "Simulation ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Forward request to simulation engine, preserving ?min_leverage= and ?limit=
+	url := s.simEngineURL() + "/simulation/" + simID + "/events"
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+	resp, err := s.simEngineClient.Get(r.Context(), url)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// getArchivedEventsHandler returns high-leverage events across every stored
+// run, forwarding ?game_id=, ?run_id=, ?event_type=, ?inning=, ?min_leverage=
+// and ?limit= to the simulation engine
+func (s *Server) getArchivedEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if eventType := r.URL.Query().Get("event_type"); eventType != "" && !enumContains(simulationEventTypes, eventType) {
+		writeError(w, "Invalid event_type parameter (see /api/v1/meta/enums)", http.StatusBadRequest)
+		return
+	}
+	url := s.simEngineURL() + "/simulations/events"
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+	resp, err := s.simEngineClient.Get(r.Context(), url)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// getSimulationFantasyHandler returns a run's projected fantasy points per player
+func (s *Server) getSimulationFantasyHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	simID := vars["id"]
+
+	if simID == "" {
+		writeError(w, "Simulation ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Forward request to simulation engine, preserving ?system= and ?scoring=
+	url := s.simEngineURL() + "/simulation/" + simID + "/fantasy"
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+	resp, err := s.simEngineClient.Get(r.Context(), url)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	// The engine answers 202 while the run is still going
+	if resp.StatusCode >= 400 || resp.StatusCode == http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// getSimulationConfigHandler returns the full configuration a run used
+func (s *Server) getSimulationConfigHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	simID := vars["id"]
+
+	if simID == "" {
+		writeError(w, "Simulation ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.simEngineClient.Get(r.Context(), s.simEngineURL()+"/simulation/"+simID+"/config")
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// getSimulationExplainHandler explains a run's win probability change against
+// an earlier run of the same game
+func (s *Server) getSimulationExplainHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	simID := vars["id"]
+
+	if simID == "" {
+		writeError(w, "Simulation ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Forward request to simulation engine, preserving ?baseline= and ?simulations=
+	url := s.simEngineURL() + "/simulation/" + simID + "/explain"
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+	resp, err := s.simEngineClient.Get(r.Context(), url)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// simulationSensitivityHandler returns tornado chart data showing which of a
+// run's inputs move its win probability most
+func (s *Server) simulationSensitivityHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	simID := vars["id"]
+
+	if simID == "" {
+		writeError(w, "Simulation ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Forward request to simulation engine, preserving ?simulations=
+	url := s.simEngineURL() + "/simulation/" + simID + "/sensitivity"
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+	resp, err := s.simEngineClient.Post(r.Context(), url, "", nil)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// getSimulationAccuracyHandler returns projection accuracy grouped by engine
+// version and model parameter hash
+func (s *Server) getSimulationAccuracyHandler(w http.ResponseWriter, r *http.Request) {
+	// Forward request to simulation engine, preserving ?season=
+	url := s.simEngineURL() + "/accuracy"
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+	resp, err := s.simEngineClient.Get(r.Context(), url)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// estimateSimulationHandler returns the projected cost of a simulation job
+// so clients can warn before launching very large runs
+func (s *Server) estimateSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	var req EstimateRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+
+	// Forward request to simulation engine
+	reqBody, _ := json.Marshal(req)
+	resp, err := s.simEngineClient.Post(r.Context(), s.simEngineURL()+"/simulate/estimate", "application/json", strings.NewReader(string(reqBody)))
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+func (s *Server) createSimulationBatchHandler(w http.ResponseWriter, r *http.Request) {
+	var req BatchSimulationRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+
+	tier, ok := s.authorizeSimulationRuns(w, r, req.SimulationRuns)
+	if !ok {
+		return
+	}
+
+	// Forward request to simulation engine
+	reqBody, _ := json.Marshal(req)
+	resp, err := s.postToSimEngine(r.Context(), "/simulate/batch", strings.NewReader(string(reqBody)), tier)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		writeSimEngineBusy(w, resp)
+		return
+	}
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	writeJSON(w, result)
+}
+
+func (s *Server) getSimulationBatchHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	batchID := vars["id"]
+
+	if batchID == "" {
+		writeError(w, "Batch ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Forward request to simulation engine
+	resp, err := s.simEngineClient.Get(r.Context(), s.simEngineURL() + "/simulate/batch/" + batchID)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// getDailyDigestHandler returns the league-wide digest of a date's daily simulations
+func (s *Server) getDailyDigestHandler(w http.ResponseWriter, r *http.Request) {
+	date := mux.Vars(r)["date"]
+
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		writeError(w, "Invalid date format, use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	// Forward request to simulation engine
+	resp, err := s.simEngineClient.Get(r.Context(), s.simEngineURL()+"/simulate/daily/"+date)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
 // Data management handlers
 func (s *Server) refreshDataHandler(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if !s.decodeJSONBody(w, r, &req, true) {
+		return
+	}
+
 	// Forward request to data fetcher
-	resp, err := http.Post(s.config.DataFetcherURL+"/fetch", "application/json", nil)
+	reqBody, _ := json.Marshal(req)
+	resp, err := s.dataFetcherClient.Post(r.Context(), s.config.DataFetcherURL+"/fetch", "application/json", strings.NewReader(string(reqBody)))
 	if err != nil {
 		writeError(w, "Failed to communicate with data fetcher", http.StatusServiceUnavailable)
 		return
@@ -1997,19 +3175,19 @@ func (s *Server) dataStatusHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get team count
-	err := s.db.QueryRow(ctx, countQueries["teams"]).Scan(&status.TotalTeams)
+	err := s.readDB().QueryRow(ctx, countQueries["teams"]).Scan(&status.TotalTeams)
 	if err != nil {
 		log.Printf("Failed to get team count: %v", err)
 	}
 
 	// Get player count
-	err = s.db.QueryRow(ctx, countQueries["players"]).Scan(&status.TotalPlayers)
+	err = s.readDB().QueryRow(ctx, countQueries["players"]).Scan(&status.TotalPlayers)
 	if err != nil {
 		log.Printf("Failed to get player count: %v", err)
 	}
 
 	// Get games count for current season
-	err = s.db.QueryRow(ctx, countQueries["games"], status.CurrentSeason).Scan(&status.TotalGames)
+	err = s.readDB().QueryRow(ctx, countQueries["games"], status.CurrentSeason).Scan(&status.TotalGames)
 	if err != nil {
 		log.Printf("Failed to get games count: %v", err)
 	}
@@ -2025,7 +3203,7 @@ func (s *Server) dataStatusHandler(w http.ResponseWriter, r *http.Request) {
 			SELECT updated_at FROM games
 		) combined`
 
-	err = s.db.QueryRow(ctx, lastUpdateQuery).Scan(&status.LastUpdate)
+	err = s.readDB().QueryRow(ctx, lastUpdateQuery).Scan(&status.LastUpdate)
 	if err != nil {
 		log.Printf("Failed to get last update: %v", err)
 		status.LastUpdate = time.Now()
@@ -2040,7 +3218,7 @@ func (s *Server) dataStatusHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Also try to get status from data fetcher
 	dataFetcherStatus := make(map[string]interface{})
-	resp, err := http.Get(s.config.DataFetcherURL + "/status")
+	resp, err := s.dataFetcherClient.Get(ctx, s.config.DataFetcherURL+"/status")
 	if err == nil {
 		defer resp.Body.Close()
 		json.NewDecoder(resp.Body).Decode(&dataFetcherStatus)
@@ -2049,6 +3227,7 @@ func (s *Server) dataStatusHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, map[string]interface{}{
 		"database_status":     status,
 		"data_fetcher_status": dataFetcherStatus,
+		"refresh_job":         dataFetcherStatus["current_job"], // latest job's progress, or null
 	})
 }
 
@@ -2070,21 +3249,38 @@ func (s *Server) apiStatusHandler(w http.ResponseWriter, r *http.Request) {
 	} else {
 		status["database"] = "connected"
 	}
+	if s.dbRouter.HasReplica() {
+		if s.dbRouter.ReplicaHealthy() {
+			status["database_replica"] = "connected"
+		} else {
+			status["database_replica"] = "disconnected"
+		}
+	}
 
 	// Check external services
-	services := map[string]string{
-		"sim_engine":   s.config.SimEngineURL + "/health",
-		"data_fetcher": s.config.DataFetcherURL + "/health",
+	services := map[string]struct {
+		client *UpstreamClient
+		url    string
+	}{
+		"sim_engine":   {s.simEngineClient, s.simEngineURL() + "/health"},
+		"data_fetcher": {s.dataFetcherClient, s.config.DataFetcherURL + "/health"},
 	}
 
-	for name, url := range services {
-		_, err := http.Get(url)
+	for name, svc := range services {
+		resp, err := svc.client.Get(ctx, svc.url)
 		if err != nil {
 			status[name] = "offline"
 		} else {
+			resp.Body.Close()
 			status[name] = "online"
 		}
 	}
+	if s.simEngines != nil {
+		status["sim_engine_replicas"] = s.simEngines.Status()
+	}
+	if s.freshness != nil {
+		status["data_as_of"] = s.freshness.Tables(ctx)
+	}
 
 	writeJSON(w, status)
 }
@@ -2100,6 +3296,12 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the connection, e.g. to lift
+// the write deadline for a streamed download
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
 func writeJSON(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(data); err != nil {
@@ -2115,6 +3317,26 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvInt reads an integer environment variable, falling back on parse errors
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvBool reads a boolean environment variable, falling back on parse errors
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 func main() {
 	// Initialize structured logger
 	appLogger = NewStructuredLogger(os.Stdout)
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnumValue is one accepted value of a query parameter with its display label
+type EnumValue struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// firstMLBSeason is the earliest season any endpoint accepts
+const firstMLBSeason = 1876
+
+// positionRegistry lists the ?position= values the players list filters on
+var positionRegistry = []EnumValue{
+	{Value: "P", Label: "Pitcher"},
+	{Value: "C", Label: "Catcher"},
+	{Value: "1B", Label: "First Base"},
+	{Value: "2B", Label: "Second Base"},
+	{Value: "3B", Label: "Third Base"},
+	{Value: "SS", Label: "Shortstop"},
+	{Value: "LF", Label: "Left Field"},
+	{Value: "CF", Label: "Center Field"},
+	{Value: "RF", Label: "Right Field"},
+	{Value: "DH", Label: "Designated Hitter"},
+	{Value: "OF", Label: "Outfield"},
+	{Value: "IF", Label: "Infield"},
+}
+
+// Game statuses accepted by ?status= on /games
+const (
+	GameStatusScheduled = "scheduled"
+	GameStatusLive      = "live"
+	GameStatusFinal     = "final"
+	GameStatusPostponed = "postponed"
+	GameStatusCancelled = "cancelled"
+)
+
+// gameStatusRegistry lists the game statuses in the order a game moves through them
+var gameStatusRegistry = []EnumValue{
+	{Value: GameStatusScheduled, Label: "Scheduled"},
+	{Value: GameStatusLive, Label: "Live"},
+	{Value: GameStatusFinal, Label: "Final"},
+	{Value: GameStatusPostponed, Label: "Postponed"},
+	{Value: GameStatusCancelled, Label: "Cancelled"},
+}
+
+// gameStatusCodes are the lower-cased games.status values each status
+// covers. The loaders write "completed"; MLB detailed states come through
+// for games the schedule import didn't normalise.
+var gameStatusCodes = map[string][]string{
+	GameStatusScheduled: {"scheduled", "pre-game", "warmup"},
+	GameStatusLive:      {"live", "in_progress", "in progress"},
+	GameStatusFinal:     {"final", "completed", "game over", "completed early"},
+	GameStatusPostponed: {"postponed", "suspended"},
+	GameStatusCancelled: {"cancelled"},
+}
+
+// simulationEventTypes lists the play results the simulation engine records,
+// which ?event_type= on /simulations/events filters on
+var simulationEventTypes = []EnumValue{
+	{Value: "single", Label: "Single"},
+	{Value: "double", Label: "Double"},
+	{Value: "triple", Label: "Triple"},
+	{Value: "home_run", Label: "Home Run"},
+	{Value: "walk", Label: "Walk"},
+	{Value: "hit_by_pitch", Label: "Hit By Pitch"},
+	{Value: "strikeout", Label: "Strikeout"},
+	{Value: "out", Label: "Out"},
+	{Value: "error", Label: "Error"},
+}
+
+// enumContains reports whether value is in values, ignoring case
+func enumContains(values []EnumValue, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v.Value, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// gameStatusFor returns the registered status a ?status= value or stored
+// status code belongs to
+func gameStatusFor(status string) (string, bool) {
+	status = strings.ToLower(status)
+	for value, codes := range gameStatusCodes {
+		if status == value {
+			return value, true
+		}
+		for _, code := range codes {
+			if status == code {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// gameStatusFilterCodes returns the stored statuses ?status= matches. An
+// unregistered status only matches itself.
+func gameStatusFilterCodes(status string) []string {
+	if registered, ok := gameStatusFor(status); ok {
+		return gameStatusCodes[registered]
+	}
+	return []string{strings.ToLower(status)}
+}
+
+// sortedKeys returns a map's keys in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// staticMetaEnums builds the registry-backed part of /meta/enums once; the
+// registries only change on deploy
+var staticMetaEnums = sync.OnceValue(func() map[string]interface{} {
+	leaderStatKeys := make(map[string][]string, len(leaderStats))
+	for group, stats := range leaderStats {
+		leaderStatKeys[group] = sortedKeys(stats)
+	}
+
+	return map[string]interface{}{
+		"positions":     positionRegistry,
+		"game_statuses": gameStatusRegistry,
+		"event_types":   simulationEventTypes,
+		"game_types":    sortedKeys(gameTypeCodes),
+		"sort_fields": map[string][]string{
+			"/api/v1/teams":   sortedKeys(teamListSorts),
+			"/api/v1/players": sortedKeys(playerListSorts),
+			"/api/v1/games":   sortedKeys(gameListSorts),
+			"/api/v1/umpires": sortedKeys(umpireListSorts),
+		},
+		"leader_stats": leaderStatKeys,
+	}
+})
+
+// getMetaEnumsHandler returns the values the API's validators accept, so
+// clients can build filters from the same lists the backend checks against
+func (s *Server) getMetaEnumsHandler(w http.ResponseWriter, r *http.Request) {
+	enums := make(map[string]interface{})
+	for key, value := range staticMetaEnums() {
+		enums[key] = value
+	}
+	enums["seasons"] = s.supportedSeasons(r.Context())
+	writeJSON(w, enums)
+}
+
+// supportedSeasons reports the season range validators accept and the
+// seasons with games on file, caching the lookup for an hour
+func (s *Server) supportedSeasons(ctx context.Context) map[string]interface{} {
+	seasons := map[string]interface{}{
+		"min":     firstMLBSeason,
+		"max":     time.Now().Year() + 1,
+		"current": getCurrentSeason(),
+	}
+
+	const cacheKey = "meta:seasons_with_games"
+	if cached, ok := s.queryCache.Get(cacheKey); ok {
+		seasons["with_games"] = cached.([]int)
+		return seasons
+	}
+
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+
+	withGames := []int{}
+	rows, err := s.readDB().Query(ctx, `SELECT DISTINCT season FROM games ORDER BY season DESC`)
+	if err != nil {
+		log.Printf("Failed to load seasons with games: %v", err)
+		seasons["with_games"] = withGames
+		return seasons
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var season int
+		if err := rows.Scan(&season); err == nil {
+			withGames = append(withGames, season)
+		}
+	}
+	if rows.Err() == nil {
+		s.queryCache.Set(cacheKey, withGames, time.Hour)
+	}
+	seasons["with_games"] = withGames
+	return seasons
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGameStatusFor tests ?status= values and stored codes map to the
+// registered status
+func TestGameStatusFor(t *testing.T) {
+	tests := []struct {
+		status   string
+		expected string
+		ok       bool
+	}{
+		{"final", GameStatusFinal, true},
+		{"completed", GameStatusFinal, true},
+		{"Game Over", GameStatusFinal, true},
+		{"In Progress", GameStatusLive, true},
+		{"SCHEDULED", GameStatusScheduled, true},
+		{"suspended", GameStatusPostponed, true},
+		{"delayed", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			status, ok := gameStatusFor(tt.status)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
+
+// TestGameStatusRegistryCoversCodes tests every registered status has stored
+// codes and a display label
+func TestGameStatusRegistryCoversCodes(t *testing.T) {
+	assert.Len(t, gameStatusCodes, len(gameStatusRegistry))
+	for _, status := range gameStatusRegistry {
+		assert.Contains(t, gameStatusCodes[status.Value], status.Value)
+		assert.Equal(t, status.Label, formatGameStatus(status.Value))
+	}
+	assert.Equal(t, "Final", formatGameStatus("completed"))
+	assert.Equal(t, "Delayed", formatGameStatus("Delayed"))
+	assert.Equal(t, []string{"delayed"}, gameStatusFilterCodes("Delayed"))
+}
+
+// TestStaticMetaEnums tests the enums come from the validators' registries
+func TestStaticMetaEnums(t *testing.T) {
+	enums := staticMetaEnums()
+
+	assert.Equal(t, positionRegistry, enums["positions"])
+	assert.Equal(t, simulationEventTypes, enums["event_types"])
+	assert.Equal(t, []string{GameTypePostseason, GameTypeRegular, GameTypeSpring}, enums["game_types"])
+
+	sortFields := enums["sort_fields"].(map[string][]string)
+	for path, sorts := range map[string]map[string]string{
+		"/api/v1/teams":   teamListSorts,
+		"/api/v1/players": playerListSorts,
+		"/api/v1/games":   gameListSorts,
+		"/api/v1/umpires": umpireListSorts,
+	} {
+		assert.Equal(t, sortedKeys(sorts), sortFields[path], path)
+	}
+	assert.NotContains(t, sortFields["/api/v1/teams"], "game_date", "each list only advertises its own columns")
+
+	leaders := enums["leader_stats"].(map[string][]string)
+	for group, stats := range leaderStats {
+		assert.Len(t, leaders[group], len(stats))
+	}
+	assert.Contains(t, leaders["pitching"], "era")
+}
@@ -4,27 +4,51 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
-	"sync"
+	"strconv"
 	"time"
 )
 
-// Metrics tracks system and application metrics
+// Metrics holds the gateway's request and cache metrics. It is shared by
+// the middleware that records requests and the handlers that report them.
 type Metrics struct {
-	mu                sync.RWMutex
-	requestCount      int64
-	errorCount        int64
-	totalResponseTime int64
-	cacheHits         int64
-	cacheMisses       int64
-	startTime         time.Time
+	registry  *MetricsRegistry
+	requests  *CounterVec   // route, method, status_class
+	durations *HistogramVec // route
+	cache     *CounterVec   // cache, result
+	startTime time.Time
+
+	precomputeRuns      *CounterVec   // job, result
+	precomputeDurations *HistogramVec // job
+}
+
+// newMetrics registers the gateway's metrics in a fresh registry
+func newMetrics() *Metrics {
+	registry := NewMetricsRegistry()
+	return &Metrics{
+		registry: registry,
+		requests: registry.NewCounter("gateway_http_requests_total",
+			"HTTP requests by route template, method and status class", "route", "method", "status_class"),
+		durations: registry.NewHistogram("gateway_http_request_duration_seconds",
+			"HTTP request duration by route template", defaultDurationBuckets, "route"),
+		cache: registry.NewCounter("gateway_cache_requests_total",
+			"Cache lookups by cache (query, stale, precomputed) and result (hit, miss)", "cache", "result"),
+		startTime: time.Now(),
+
+		precomputeRuns: registry.NewCounter("gateway_precompute_runs_total",
+			"Precompute job runs by job and result (success, failure, skipped)", "job", "result"),
+		precomputeDurations: registry.NewHistogram("gateway_precompute_duration_seconds",
+			"Precompute job run duration by job", defaultDurationBuckets, "job"),
+	}
 }
 
 type MetricsResponse struct {
-	System      SystemMetrics      `json:"system"`
-	Application ApplicationMetrics `json:"application"`
-	Cache       CacheMetrics       `json:"cache"`
-	Database    DatabaseMetrics    `json:"database"`
-	Uptime      string             `json:"uptime"`
+	System      SystemMetrics              `json:"system"`
+	Application ApplicationMetrics         `json:"application"`
+	Cache       CacheMetrics               `json:"cache"`
+	Database    DatabaseMetrics            `json:"database"`
+	Upstreams   map[string]UpstreamMetrics `json:"upstreams"`
+	Uptime      string                     `json:"uptime"`
+	Metrics     []MetricSnapshot           `json:"metrics"`
 }
 
 type SystemMetrics struct {
@@ -38,10 +62,10 @@ type SystemMetrics struct {
 }
 
 type ApplicationMetrics struct {
-	TotalRequests    int64   `json:"total_requests"`
-	TotalErrors      int64   `json:"total_errors"`
-	ErrorRate        float64 `json:"error_rate_percent"`
-	AvgResponseTime  float64 `json:"avg_response_time_ms"`
+	TotalRequests     int64   `json:"total_requests"`
+	TotalErrors       int64   `json:"total_errors"`
+	ErrorRate         float64 `json:"error_rate_percent"`
+	AvgResponseTime   float64 `json:"avg_response_time_ms"`
 	RequestsPerSecond float64 `json:"requests_per_second"`
 }
 
@@ -53,58 +77,80 @@ type CacheMetrics struct {
 }
 
 type DatabaseMetrics struct {
-	MaxConns      int32 `json:"max_connections"`
-	AcquireCount  int64 `json:"acquire_count"`
-	IdleConns     int32 `json:"idle_connections"`
-	TotalConns    int32 `json:"total_connections"`
+	MaxConns     int32 `json:"max_connections"`
+	AcquireCount int64 `json:"acquire_count"`
+	IdleConns    int32 `json:"idle_connections"`
+	TotalConns   int32 `json:"total_connections"`
+}
+
+var appMetrics = newMetrics()
+
+// statusClass buckets a status code as 2xx, 3xx, 4xx or 5xx
+func statusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
 }
 
-var appMetrics = &Metrics{
-	startTime: time.Now(),
+// ObserveRequest records a completed request. route is the matched route
+// template, so paths with IDs share one series.
+func (m *Metrics) ObserveRequest(route, method string, status int, duration time.Duration) {
+	m.requests.Inc(route, method, statusClass(status))
+	m.durations.Observe(duration.Seconds(), route)
 }
 
-func (m *Metrics) IncrementRequests() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.requestCount++
+// RecordCache records a lookup in one of the gateway's caches
+func (m *Metrics) RecordCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cache.Inc(cache, result)
 }
 
-func (m *Metrics) IncrementErrors() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.errorCount++
+// ObservePrecompute records a precompute job run
+func (m *Metrics) ObservePrecompute(job, result string, duration time.Duration) {
+	m.precomputeRuns.Inc(job, result)
+	if result != "skipped" {
+		m.precomputeDurations.Observe(duration.Seconds(), job)
+	}
 }
 
-func (m *Metrics) AddResponseTime(duration time.Duration) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.totalResponseTime += duration.Milliseconds()
+// requestTotals returns total and error (4xx/5xx) request counts
+func (m *Metrics) requestTotals() (requests, errors int64) {
+	for _, series := range m.requests.snapshot().Series {
+		requests += *series.Value
+		if class := series.Labels["status_class"]; class == "4xx" || class == "5xx" {
+			errors += *series.Value
+		}
+	}
+	return requests, errors
 }
 
-func (m *Metrics) IncrementCacheHit() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.cacheHits++
+// cacheTotals returns hits and misses across every cache
+func (m *Metrics) cacheTotals() (hits, misses int64) {
+	for _, series := range m.cache.snapshot().Series {
+		if series.Labels["result"] == "hit" {
+			hits += *series.Value
+		} else {
+			misses += *series.Value
+		}
+	}
+	return hits, misses
 }
 
-func (m *Metrics) IncrementCacheMiss() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.cacheMisses++
+// handlePrometheusMetrics serves the registry in the Prometheus text format
+func (s *Server) handlePrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	appMetrics.registry.WritePrometheus(w)
 }
 
 func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
-	appMetrics.mu.RLock()
-	requestCount := appMetrics.requestCount
-	errorCount := appMetrics.errorCount
-	totalResponseTime := appMetrics.totalResponseTime
-	cacheHits := appMetrics.cacheHits
-	cacheMisses := appMetrics.cacheMisses
+	requestCount, errorCount := appMetrics.requestTotals()
+	durationCount, durationSum := appMetrics.durations.Totals()
+	cacheHits, cacheMisses := appMetrics.cacheTotals()
 	startTime := appMetrics.startTime
-	appMetrics.mu.RUnlock()
 
 	// Calculate rates
 	uptime := time.Since(startTime)
@@ -116,8 +162,8 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var avgResponseTime float64
-	if requestCount > 0 {
-		avgResponseTime = float64(totalResponseTime) / float64(requestCount)
+	if durationCount > 0 {
+		avgResponseTime = durationSum / float64(durationCount) * 1000
 	}
 
 	var requestsPerSecond float64
@@ -150,10 +196,10 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 			NumGC:         memStats.NumGC,
 		},
 		Application: ApplicationMetrics{
-			TotalRequests:    requestCount,
-			TotalErrors:      errorCount,
-			ErrorRate:        errorRate,
-			AvgResponseTime:  avgResponseTime,
+			TotalRequests:     requestCount,
+			TotalErrors:       errorCount,
+			ErrorRate:         errorRate,
+			AvgResponseTime:   avgResponseTime,
 			RequestsPerSecond: requestsPerSecond,
 		},
 		Cache: CacheMetrics{
@@ -163,12 +209,17 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 			CacheSize: cacheSize,
 		},
 		Database: DatabaseMetrics{
-			MaxConns:      dbStats.MaxConns(),
-			AcquireCount:  dbStats.AcquireCount(),
-			IdleConns:     dbStats.IdleConns(),
-			TotalConns:    dbStats.TotalConns(),
+			MaxConns:     dbStats.MaxConns(),
+			AcquireCount: dbStats.AcquireCount(),
+			IdleConns:    dbStats.IdleConns(),
+			TotalConns:   dbStats.TotalConns(),
+		},
+		Upstreams: map[string]UpstreamMetrics{
+			"sim_engine":   s.simEngineClient.Metrics(),
+			"data_fetcher": s.dataFetcherClient.Metrics(),
 		},
-		Uptime: formatUptime(uptime),
+		Uptime:  formatUptime(uptime),
+		Metrics: appMetrics.registry.Snapshot(),
 	}
 
 	writeJSON(w, response)
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultDurationBuckets are the upper bounds, in seconds, of request
+// duration histograms
+var defaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// MetricsRegistry holds labeled counters and histograms. It is safe for
+// concurrent use and renders both a JSON snapshot and the Prometheus text
+// exposition format.
+type MetricsRegistry struct {
+	mu      sync.RWMutex
+	metrics []registeredMetric
+	names   map[string]bool
+}
+
+// registeredMetric is implemented by CounterVec and HistogramVec
+type registeredMetric interface {
+	snapshot() MetricSnapshot
+	writePrometheus(w io.Writer)
+}
+
+// MetricSnapshot is one metric family in the JSON snapshot
+type MetricSnapshot struct {
+	Name   string           `json:"name"`
+	Help   string           `json:"help"`
+	Type   string           `json:"type"` // counter or histogram
+	Series []SeriesSnapshot `json:"series"`
+}
+
+// SeriesSnapshot is one label combination of a metric
+type SeriesSnapshot struct {
+	Labels    map[string]string  `json:"labels"`
+	Value     *int64             `json:"value,omitempty"` // counters
+	Histogram *HistogramSnapshot `json:"histogram,omitempty"`
+}
+
+// HistogramSnapshot holds cumulative bucket counts as in Prometheus
+type HistogramSnapshot struct {
+	Count   uint64           `json:"count"`
+	Sum     float64          `json:"sum"`
+	Buckets []BucketSnapshot `json:"buckets"`
+}
+
+// BucketSnapshot counts observations less than or equal to LE
+type BucketSnapshot struct {
+	LE    string `json:"le"`
+	Count uint64 `json:"count"`
+}
+
+// NewMetricsRegistry creates an empty registry
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{names: make(map[string]bool)}
+}
+
+func (r *MetricsRegistry) register(name string, metric registeredMetric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names[name] {
+		panic("metric registered twice: " + name)
+	}
+	r.names[name] = true
+	r.metrics = append(r.metrics, metric)
+}
+
+// NewCounter registers a counter with the given label names
+func (r *MetricsRegistry) NewCounter(name, help string, labels ...string) *CounterVec {
+	counter := &CounterVec{
+		name:   name,
+		help:   help,
+		labels: labels,
+		series: make(map[string]*counterSeries),
+	}
+	r.register(name, counter)
+	return counter
+}
+
+// NewHistogram registers a histogram with the given bucket upper bounds,
+// which must be sorted ascending
+func (r *MetricsRegistry) NewHistogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	histogram := &HistogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*histogramSeries),
+	}
+	r.register(name, histogram)
+	return histogram
+}
+
+// Snapshot returns every metric in registration order
+func (r *MetricsRegistry) Snapshot() []MetricSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshots := make([]MetricSnapshot, 0, len(r.metrics))
+	for _, metric := range r.metrics {
+		snapshots = append(snapshots, metric.snapshot())
+	}
+	return snapshots
+}
+
+// WritePrometheus writes every metric in the Prometheus text format
+func (r *MetricsRegistry) WritePrometheus(w io.Writer) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, metric := range r.metrics {
+		metric.writePrometheus(w)
+	}
+}
+
+// seriesKey joins label values into a map key; the separator can't appear
+// in a route or status class
+func seriesKey(values []string) string {
+	return strings.Join(values, "\xff")
+}
+
+func labelMap(names, values []string) map[string]string {
+	labels := make(map[string]string, len(names))
+	for i, name := range names {
+		labels[name] = values[i]
+	}
+	return labels
+}
+
+// labelEscaper escapes label values as the exposition format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels renders {name="value",...} with Prometheus escaping
+func formatLabels(names, values []string, extra ...string) string {
+	var pairs []string
+	for i, name := range names {
+		pairs = append(pairs, name+`="`+labelEscaper.Replace(values[i])+`"`)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+labelEscaper.Replace(extra[i+1])+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// CounterVec is a monotonically increasing count per label combination
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	values []string
+	count  int64
+}
+
+// Inc adds one to the series with the given label values
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add adds n to the series with the given label values
+func (c *CounterVec) Add(n int64, values ...string) {
+	if len(values) != len(c.labels) {
+		panic(fmt.Sprintf("%s: got %d label values, want %d", c.name, len(values), len(c.labels)))
+	}
+	key := seriesKey(values)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	series, ok := c.series[key]
+	if !ok {
+		series = &counterSeries{values: append([]string(nil), values...)}
+		c.series[key] = series
+	}
+	series.count += n
+}
+
+// Value returns the count for the given label values
+func (c *CounterVec) Value(values ...string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if series, ok := c.series[seriesKey(values)]; ok {
+		return series.count
+	}
+	return 0
+}
+
+// Total returns the count across every label combination
+func (c *CounterVec) Total() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var total int64
+	for _, series := range c.series {
+		total += series.count
+	}
+	return total
+}
+
+// sorted returns copies of the series ordered by label values
+func (c *CounterVec) sorted() []counterSeries {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.series))
+	for key := range c.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	series := make([]counterSeries, len(keys))
+	for i, key := range keys {
+		series[i] = *c.series[key]
+	}
+	return series
+}
+
+func (c *CounterVec) snapshot() MetricSnapshot {
+	snapshot := MetricSnapshot{Name: c.name, Help: c.help, Type: "counter", Series: []SeriesSnapshot{}}
+	for _, series := range c.sorted() {
+		value := series.count
+		snapshot.Series = append(snapshot.Series, SeriesSnapshot{
+			Labels: labelMap(c.labels, series.values),
+			Value:  &value,
+		})
+	}
+	return snapshot
+}
+
+func (c *CounterVec) writePrometheus(w io.Writer) {
+	writeHeader(w, c.name, c.help, "counter")
+	for _, series := range c.sorted() {
+		fmt.Fprintf(w, "%s%s %d\n", c.name, formatLabels(c.labels, series.values), series.count)
+	}
+}
+
+// HistogramVec counts observations into buckets per label combination
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	values []string
+	counts []uint64 // per bucket, not cumulative; the last is +Inf
+	count  uint64
+	sum    float64
+}
+
+// Observe records a value in the series with the given label values
+func (h *HistogramVec) Observe(value float64, values ...string) {
+	if len(values) != len(h.labels) {
+		panic(fmt.Sprintf("%s: got %d label values, want %d", h.name, len(values), len(h.labels)))
+	}
+	key := seriesKey(values)
+	bucket := sort.SearchFloat64s(h.buckets, value)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogramSeries{
+			values: append([]string(nil), values...),
+			counts: make([]uint64, len(h.buckets)+1),
+		}
+		h.series[key] = series
+	}
+	series.counts[bucket]++
+	series.count++
+	series.sum += value
+}
+
+// Totals returns the observation count and sum across every label combination
+func (h *HistogramVec) Totals() (uint64, float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var count uint64
+	var sum float64
+	for _, series := range h.series {
+		count += series.count
+		sum += series.sum
+	}
+	return count, sum
+}
+
+// sorted returns copies of the series ordered by label values
+func (h *HistogramVec) sorted() []histogramSeries {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	series := make([]histogramSeries, len(keys))
+	for i, key := range keys {
+		s := *h.series[key]
+		s.counts = append([]uint64(nil), s.counts...)
+		series[i] = s
+	}
+	return series
+}
+
+// cumulative returns each bucket's upper bound and cumulative count,
+// ending with +Inf
+func (h *HistogramVec) cumulative(series histogramSeries) []BucketSnapshot {
+	buckets := make([]BucketSnapshot, 0, len(series.counts))
+	var running uint64
+	for i, count := range series.counts {
+		running += count
+		le := "+Inf"
+		if i < len(h.buckets) {
+			le = strconv.FormatFloat(h.buckets[i], 'g', -1, 64)
+		}
+		buckets = append(buckets, BucketSnapshot{LE: le, Count: running})
+	}
+	return buckets
+}
+
+func (h *HistogramVec) snapshot() MetricSnapshot {
+	snapshot := MetricSnapshot{Name: h.name, Help: h.help, Type: "histogram", Series: []SeriesSnapshot{}}
+	for _, series := range h.sorted() {
+		snapshot.Series = append(snapshot.Series, SeriesSnapshot{
+			Labels: labelMap(h.labels, series.values),
+			Histogram: &HistogramSnapshot{
+				Count:   series.count,
+				Sum:     math.Round(series.sum*1e6) / 1e6,
+				Buckets: h.cumulative(series),
+			},
+		})
+	}
+	return snapshot
+}
+
+func (h *HistogramVec) writePrometheus(w io.Writer) {
+	writeHeader(w, h.name, h.help, "histogram")
+	for _, series := range h.sorted() {
+		for _, bucket := range h.cumulative(series) {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labels, series.values, "le", bucket.LE), bucket.Count)
+		}
+		labels := formatLabels(h.labels, series.values)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, labels, strconv.FormatFloat(series.sum, 'g', -1, 64))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labels, series.count)
+	}
+}
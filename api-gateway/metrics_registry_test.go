@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCounterConcurrentIncrements tests that labeled counters are safe to
+// update from many goroutines
+func TestCounterConcurrentIncrements(t *testing.T) {
+	registry := NewMetricsRegistry()
+	counter := registry.NewCounter("test_total", "Test counter", "route")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			route := "/a"
+			if i%2 == 1 {
+				route = "/b"
+			}
+			for j := 0; j < 100; j++ {
+				counter.Inc(route)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(2500), counter.Value("/a"))
+	assert.Equal(t, int64(2500), counter.Value("/b"))
+	assert.Equal(t, int64(5000), counter.Total())
+	assert.Panics(t, func() { counter.Inc() }, "label count must match")
+}
+
+// TestHistogramBuckets tests cumulative bucket counts, including values on a
+// bucket boundary and past the last bound
+func TestHistogramBuckets(t *testing.T) {
+	registry := NewMetricsRegistry()
+	histogram := registry.NewHistogram("test_seconds", "Test histogram", []float64{0.1, 1}, "route")
+	for _, value := range []float64{0.05, 0.1, 0.5, 3} {
+		histogram.Observe(value, "/a")
+	}
+
+	snapshot := registry.Snapshot()
+	if assert.Len(t, snapshot, 1) && assert.Len(t, snapshot[0].Series, 1) {
+		h := snapshot[0].Series[0].Histogram
+		assert.Equal(t, uint64(4), h.Count)
+		assert.InDelta(t, 3.65, h.Sum, 1e-9)
+		assert.Equal(t, []BucketSnapshot{{"0.1", 2}, {"1", 3}, {"+Inf", 4}}, h.Buckets)
+	}
+
+	count, sum := histogram.Totals()
+	assert.Equal(t, uint64(4), count)
+	assert.InDelta(t, 3.65, sum, 1e-9)
+}
+
+// TestWritePrometheus tests the text exposition format
+func TestWritePrometheus(t *testing.T) {
+	registry := NewMetricsRegistry()
+	counter := registry.NewCounter("requests_total", "Requests", "route", "status_class")
+	counter.Add(3, "/teams/{id}", "2xx")
+	counter.Inc(`/odd"route`, "5xx")
+	registry.NewHistogram("duration_seconds", "Duration", []float64{0.5}, "route").Observe(0.25, "/teams")
+
+	var buf bytes.Buffer
+	registry.WritePrometheus(&buf)
+	assert.Equal(t, `# HELP requests_total Requests
+# TYPE requests_total counter
+requests_total{route="/odd\"route",status_class="5xx"} 1
+requests_total{route="/teams/{id}",status_class="2xx"} 3
+# HELP duration_seconds Duration
+# TYPE duration_seconds histogram
+duration_seconds_bucket{route="/teams",le="0.5"} 1
+duration_seconds_bucket{route="/teams",le="+Inf"} 1
+duration_seconds_sum{route="/teams"} 0.25
+duration_seconds_count{route="/teams"} 1
+`, buf.String())
+
+	assert.Panics(t, func() { registry.NewCounter("requests_total", "Again") })
+}
+
+// TestRequestMetricsUseRouteTemplates tests that the logging middleware
+// labels requests by route template and status class
+func TestRequestMetricsUseRouteTemplates(t *testing.T) {
+	s := &Server{}
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/teams/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["id"] == "missing" {
+			writeError(w, "Team not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]string{"id": mux.Vars(r)["id"]})
+	})
+	router.Use(s.loggingMiddleware)
+
+	route := "/api/v1/teams/{id}"
+	before2xx := appMetrics.requests.Value(route, http.MethodGet, "2xx")
+	before4xx := appMetrics.requests.Value(route, http.MethodGet, "4xx")
+	for _, path := range []string{"/api/v1/teams/NYY", "/api/v1/teams/BOS", "/api/v1/teams/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, before2xx+2, appMetrics.requests.Value(route, http.MethodGet, "2xx"))
+	assert.Equal(t, before4xx+1, appMetrics.requests.Value(route, http.MethodGet, "4xx"))
+	assert.Equal(t, "5xx", statusClass(http.StatusServiceUnavailable))
+
+	rec := httptest.NewRecorder()
+	s.handlePrometheusMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, rec.Body.String(), `gateway_http_requests_total{route="/api/v1/teams/{id}",method="GET",status_class="2xx"}`)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+}
@@ -6,16 +6,18 @@ import (
 
 // Team represents a baseball team
 type Team struct {
-	ID           string    `json:"id" db:"id"`
-	TeamID       string    `json:"team_id" db:"team_id"`
-	Name         string    `json:"name" db:"name"`
-	City         *string    `json:"city,omitempty" db:"city"`
-	Abbreviation string    `json:"abbreviation" db:"abbreviation"`
-	League       string    `json:"league" db:"league"`
-	Division     string    `json:"division" db:"division"`
-	Stadium      string    `json:"stadium_id,omitempty" db:"stadium_id"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           string        `json:"id" db:"id"`
+	TeamID       string        `json:"team_id" db:"team_id"`
+	Name         string        `json:"name" db:"name"`
+	City         *string       `json:"city,omitempty" db:"city"`
+	Abbreviation string        `json:"abbreviation" db:"abbreviation"`
+	League       string        `json:"league" db:"league"`
+	Division     string        `json:"division" db:"division"`
+	Stadium      string        `json:"stadium_id,omitempty" db:"stadium_id"`
+	Branding     *TeamBranding `json:"branding,omitempty"`     // set on team responses
+	FranchiseID  *string       `json:"franchise_id,omitempty"` // set on team responses with franchise lineage
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at" db:"updated_at"`
 }
 
 // Player represents a baseball player
@@ -27,7 +29,7 @@ type Player struct {
 	FullName     string     `json:"full_name" db:"full_name"`
 	Position     string     `json:"position" db:"position"`
 	TeamID       string     `json:"team_id" db:"team_id"`
-	JerseyNumber string       `json:"jersey_number,omitempty" db:"jersey_number"`
+	JerseyNumber string     `json:"jersey_number,omitempty" db:"jersey_number"`
 	Height       string     `json:"height,omitempty" db:"height"`
 	Weight       *int       `json:"weight,omitempty" db:"weight"`
 	BirthDate    *time.Time `json:"birth_date,omitempty" db:"birth_date"`
@@ -49,24 +51,23 @@ type PlayerWithTeam struct {
 
 // Game represents a baseball game
 type Game struct {
-	ID           string    `json:"id" db:"id"`
-	GameID       string    `json:"game_id" db:"game_id"`
-	Season       int       `json:"season" db:"season"`
-	GameType     string    `json:"game_type" db:"game_type"`
-	GameDate     time.Time `json:"game_date" db:"game_date"`
-	HomeTeamID   string    `json:"home_team_id" db:"home_team_id"`
-	AwayTeamID   string    `json:"away_team_id" db:"away_team_id"`
-	HomeScore    *int      `json:"home_score,omitempty" db:"home_score"`
-	AwayScore    *int      `json:"away_score,omitempty" db:"away_score"`
-	Status       string    `json:"status" db:"status"`
-	Inning       *int      `json:"inning,omitempty" db:"inning"`
-	InningHalf   string    `json:"inning_half,omitempty" db:"inning_half"`
-	StadiumID    string    `json:"stadium_id,omitempty" db:"stadium_id"`
-	WeatherData  *string   `json:"weather_data,omitempty" db:"weather_data"`
-	Attendance   *int      `json:"attendance,omitempty" db:"attendance"`
-	GameDuration *int      `json:"game_duration,omitempty" db:"game_duration"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           string         `json:"id" db:"id"`
+	GameID       string         `json:"game_id" db:"game_id"`
+	Season       int            `json:"season" db:"season"`
+	GameType     string         `json:"game_type" db:"game_type"`
+	GameDate     time.Time      `json:"game_date" db:"game_date"`
+	HomeTeamID   string         `json:"home_team_id" db:"home_team_id"`
+	AwayTeamID   string         `json:"away_team_id" db:"away_team_id"`
+	HomeScore    *int           `json:"home_score,omitempty" db:"home_score"`
+	AwayScore    *int           `json:"away_score,omitempty" db:"away_score"`
+	Status       string         `json:"status" db:"status"`
+	StadiumID    string         `json:"stadium_id,omitempty" db:"stadium_id"`
+	WeatherData  *string        `json:"weather_data,omitempty" db:"weather_data"`
+	Attendance   *int           `json:"attendance,omitempty" db:"attendance"`
+	GameDuration *int           `json:"game_duration,omitempty" db:"game_duration"`
+	Live         *GameLiveState `json:"live,omitempty"` // live games only
+	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at" db:"updated_at"`
 }
 
 // GameWithTeams represents a game with team information
@@ -101,6 +102,9 @@ type PlayerStats struct {
 	AggregatedStats map[string]interface{} `json:"aggregated_stats" db:"aggregated_stats"`
 	GamesPlayed     int                    `json:"games_played" db:"games_played"`
 	UpdatedAt       time.Time              `json:"updated_at" db:"updated_at"`
+	// Populated only when requested with ?adjusted=park
+	ParkAdjustedStats map[string]interface{} `json:"park_adjusted_stats,omitempty"`
+	ParkAdjustment    *ParkAdjustment        `json:"park_adjustment,omitempty"`
 }
 
 // SimulationRun represents a simulation run
@@ -152,6 +156,7 @@ type PaginatedResponse struct {
 	Page       int         `json:"page"`
 	PageSize   int         `json:"page_size"`
 	TotalPages int         `json:"total_pages"`
+	DataAsOf   *time.Time  `json:"data_as_of,omitempty"` // last update of the tables behind the data
 }
 
 // QueryParams represents common query parameters
@@ -166,13 +171,19 @@ type QueryParams struct {
 	Sort     string `json:"sort,omitempty"`
 	Order    string `json:"order,omitempty"`
 	Name     string `json:"name,omitempty"`
+
+	GameTypes []string `json:"game_types,omitempty"` // R, P or S; nil for every type
+
+	DataAsOf *time.Time `json:"-"` // set by dataFreshnessMiddleware
 }
 
-// SimulationRequest represents a request to create a simulation
+// SimulationRequest represents a request to create a simulation. Config
+// keys are checked against the engine's options; the engine validates
+// their values.
 type SimulationRequest struct {
-	GameID         string                 `json:"game_id"`
-	SimulationRuns int                    `json:"simulation_runs,omitempty"`
-	Config         map[string]interface{} `json:"config,omitempty"`
+	GameID         string    `json:"game_id"`
+	SimulationRuns int       `json:"simulation_runs,omitempty"`
+	Config         RunConfig `json:"config,omitempty"`
 }
 
 // ServiceHealth represents the health status of external services
@@ -190,27 +201,58 @@ type Umpire struct {
 	Name       string                 `json:"name" db:"name"`
 	Tendencies map[string]interface{} `json:"tendencies,omitempty" db:"tendencies"`
 	CreatedAt  time.Time              `json:"created_at" db:"created_at"`
+
+	// Season stats joined into the umpire list; nil without any
+	SeasonStats *UmpireListStats `json:"season_stats,omitempty" db:"-"`
 }
 
 // UmpireSeasonStats represents season-specific umpire performance metrics
 type UmpireSeasonStats struct {
-	Season                   int        `json:"season" db:"season"`
-	GamesUmped               int        `json:"games_umped" db:"games_umped"`
-	AccuracyPct              *float64   `json:"accuracy_pct,omitempty" db:"accuracy_pct"`
-	ConsistencyPct           *float64   `json:"consistency_pct,omitempty" db:"consistency_pct"`
-	FavorHome                *float64   `json:"favor_home,omitempty" db:"favor_home"`
-	ExpectedAccuracy         *float64   `json:"expected_accuracy,omitempty" db:"expected_accuracy"`
-	ExpectedConsistency      *float64   `json:"expected_consistency,omitempty" db:"expected_consistency"`
-	CorrectCalls             int        `json:"correct_calls" db:"correct_calls"`
-	IncorrectCalls           int        `json:"incorrect_calls" db:"incorrect_calls"`
-	TotalCalls               int        `json:"total_calls" db:"total_calls"`
-	StrikePct                *float64   `json:"strike_pct,omitempty" db:"strike_pct"`
-	BallPct                  *float64   `json:"ball_pct,omitempty" db:"ball_pct"`
-	KPctAboveAvg             *float64   `json:"k_pct_above_avg,omitempty" db:"k_pct_above_avg"`
-	BBPctAboveAvg            *float64   `json:"bb_pct_above_avg,omitempty" db:"bb_pct_above_avg"`
-	HomePlateCallsPerGame    *float64   `json:"home_plate_calls_per_game,omitempty" db:"home_plate_calls_per_game"`
-	CreatedAt                time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt                time.Time  `json:"updated_at" db:"updated_at"`
+	Season                int       `json:"season" db:"season"`
+	GamesUmped            int       `json:"games_umped" db:"games_umped"`
+	AccuracyPct           *float64  `json:"accuracy_pct,omitempty" db:"accuracy_pct"`
+	ConsistencyPct        *float64  `json:"consistency_pct,omitempty" db:"consistency_pct"`
+	FavorHome             *float64  `json:"favor_home,omitempty" db:"favor_home"`
+	ExpectedAccuracy      *float64  `json:"expected_accuracy,omitempty" db:"expected_accuracy"`
+	ExpectedConsistency   *float64  `json:"expected_consistency,omitempty" db:"expected_consistency"`
+	CorrectCalls          int       `json:"correct_calls" db:"correct_calls"`
+	IncorrectCalls        int       `json:"incorrect_calls" db:"incorrect_calls"`
+	TotalCalls            int       `json:"total_calls" db:"total_calls"`
+	StrikePct             *float64  `json:"strike_pct,omitempty" db:"strike_pct"`
+	BallPct               *float64  `json:"ball_pct,omitempty" db:"ball_pct"`
+	KPctAboveAvg          *float64  `json:"k_pct_above_avg,omitempty" db:"k_pct_above_avg"`
+	BBPctAboveAvg         *float64  `json:"bb_pct_above_avg,omitempty" db:"bb_pct_above_avg"`
+	HomePlateCallsPerGame *float64  `json:"home_plate_calls_per_game,omitempty" db:"home_plate_calls_per_game"`
+	CreatedAt             time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at" db:"updated_at"`
+
+	// League percentile ranks for the season
+	Percentiles *UmpirePercentiles `json:"percentiles,omitempty" db:"-"`
+}
+
+// UmpireGame represents one game an umpire worked, with team K/BB outcomes
+// taken from the batting box score (nil when no box score is loaded)
+type UmpireGame struct {
+	GameID         string    `json:"game_id"`
+	GameDate       time.Time `json:"game_date"`
+	Season         int       `json:"season"`
+	Status         string    `json:"status"`
+	Position       string    `json:"position"`
+	AccuracyPct    *float64  `json:"accuracy_pct,omitempty"`
+	CorrectCalls   *int      `json:"correct_calls,omitempty"`
+	IncorrectCalls *int      `json:"incorrect_calls,omitempty"`
+	HomeTeamID     string    `json:"home_team_id"`
+	HomeTeamName   string    `json:"home_team_name"`
+	HomeTeamAbbr   string    `json:"home_team_abbr"`
+	AwayTeamID     string    `json:"away_team_id"`
+	AwayTeamName   string    `json:"away_team_name"`
+	AwayTeamAbbr   string    `json:"away_team_abbr"`
+	HomeScore      *int      `json:"home_score,omitempty"`
+	AwayScore      *int      `json:"away_score,omitempty"`
+	HomeStrikeouts *int      `json:"home_strikeouts,omitempty"`
+	HomeWalks      *int      `json:"home_walks,omitempty"`
+	AwayStrikeouts *int      `json:"away_strikeouts,omitempty"`
+	AwayWalks      *int      `json:"away_walks,omitempty"`
 }
 
 // SearchResult represents a unified search result across all entity types
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+)
+
+// Entity types notes can be attached to
+const (
+	noteEntityGame   = "game"
+	noteEntityPlayer = "player"
+	noteEntityRun    = "simulation_run"
+)
+
+const (
+	maxNoteLength = 4000
+	maxNoteTags   = 10
+	maxTagLength  = 32
+
+	// noteAuthorLength is how much of the author's key hash is shown
+	noteAuthorLength = 12
+)
+
+// tagPattern is a lowercase tag such as "lineup" or "day-off"
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// NoteRequest is a freeform note with optional tags
+type NoteRequest struct {
+	Body string   `json:"body"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// validate trims the body and lowercases and de-duplicates tags before
+// checking them
+func (req *NoteRequest) validate() (string, map[string]interface{}) {
+	req.Body = strings.TrimSpace(req.Body)
+	if req.Body == "" {
+		return "body is required", nil
+	}
+	if len([]rune(req.Body)) > maxNoteLength {
+		return "body is too long", map[string]interface{}{"max": maxNoteLength}
+	}
+
+	tags := make([]string, 0, len(req.Tags))
+	seen := make(map[string]bool)
+	for _, tag := range req.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if len(tag) > maxTagLength || !tagPattern.MatchString(tag) {
+			return "tags must be letters, digits, '-' or '_'", map[string]interface{}{"tag": tag, "max_length": maxTagLength}
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > maxNoteTags {
+		return "Too many tags", map[string]interface{}{"max": maxNoteTags}
+	}
+	req.Tags = tags
+	return "", nil
+}
+
+// Note is a stored note. Author is a short fingerprint of the writer's API
+// key; Mine marks the caller's own notes.
+type Note struct {
+	ID         string    `json:"id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Body       string    `json:"body"`
+	Tags       []string  `json:"tags"`
+	Author     string    `json:"author"`
+	Mine       bool      `json:"mine"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// noteFilters narrows a note listing or search
+type noteFilters struct {
+	EntityType string
+	EntityID   string
+	Query      string // full-text search of the body
+	Tag        string
+	Author     string // full key hash, for ?mine=true
+}
+
+// buildNotesWhereClause builds the WHERE clause for a note listing
+func buildNotesWhereClause(f noteFilters) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if f.EntityType != "" {
+		add("entity_type = $%d", f.EntityType)
+	}
+	if f.EntityID != "" {
+		add("entity_id = $%d::uuid", f.EntityID)
+	}
+	if f.Query != "" {
+		add("to_tsvector('english', body) @@ plainto_tsquery('english', $%d)", f.Query)
+	}
+	if f.Tag != "" {
+		add("$%d = ANY(tags)", strings.ToLower(f.Tag))
+	}
+	if f.Author != "" {
+		add("author_hash = $%d", f.Author)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// noteTarget resolves the {id} of a note route to the annotated row's UUID,
+// writing a 400 or 404 when there is none
+func (s *Server) noteTarget(ctx context.Context, w http.ResponseWriter, r *http.Request, entityType string) (string, bool) {
+	id := mux.Vars(r)["id"]
+	switch entityType {
+	case noteEntityGame:
+		resolved, ok := s.resolveEntity(ctx, w, ids.Game, id)
+		return resolved.ID, ok
+	case noteEntityPlayer:
+		resolved, ok := s.resolveEntity(ctx, w, ids.Player, id)
+		return resolved.ID, ok
+	}
+
+	if !validateUUID(id) {
+		writeError(w, "Invalid simulation ID", http.StatusBadRequest)
+		return "", false
+	}
+	var exists bool
+	err := s.readDB().QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM simulation_runs WHERE id = $1::uuid)`, id).Scan(&exists)
+	if err != nil {
+		writeError(w, "Failed to look up simulation", http.StatusInternalServerError)
+		return "", false
+	}
+	if !exists {
+		writeError(w, "Simulation not found", http.StatusNotFound)
+		return "", false
+	}
+	return id, true
+}
+
+// createNoteHandler attaches a note to the game, player or simulation run
+// in the path. Requires an API key.
+func (s *Server) createNoteHandler(entityType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		author, ok := s.apiKeyOwner(w, r, "Notes")
+		if !ok {
+			return
+		}
+		var req NoteRequest
+		if !s.decodeJSONBody(w, r, &req, false) {
+			return
+		}
+		if msg, details := req.validate(); msg != "" {
+			writeErrorWithDetails(w, msg, "invalid_note", details, http.StatusUnprocessableEntity)
+			return
+		}
+
+		ctx, cancel := contextWithTimeout(r.Context())
+		defer cancel()
+
+		entityID, ok := s.noteTarget(ctx, w, r, entityType)
+		if !ok {
+			return
+		}
+		if !s.dbRouter.PrimaryHealthy() {
+			writeError(w, "Notes can't be saved while the database is unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		note := Note{EntityType: entityType, EntityID: entityID, Body: req.Body, Tags: req.Tags,
+			Author: author[:noteAuthorLength], Mine: true}
+		err := s.dbRouter.Writer().QueryRow(ctx, `
+			INSERT INTO notes (entity_type, entity_id, author_hash, body, tags)
+			VALUES ($1, $2::uuid, $3, $4, $5)
+			RETURNING id::text, created_at
+		`, entityType, entityID, author, req.Body, req.Tags).Scan(&note.ID, &note.CreatedAt)
+		if err != nil {
+			log.Printf("Failed to save note: %v", err)
+			writeError(w, "Failed to save note", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, note)
+	}
+}
+
+// listEntityNotesHandler lists the notes on the game, player or simulation
+// run in the path, newest first. Supports ?q= and ?tag=.
+func (s *Server) listEntityNotesHandler(entityType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		caller, ok := s.apiKeyOwner(w, r, "Notes")
+		if !ok {
+			return
+		}
+		ctx, cancel := contextWithTimeout(r.Context())
+		defer cancel()
+
+		entityID, ok := s.noteTarget(ctx, w, r, entityType)
+		if !ok {
+			return
+		}
+		filters := noteFilters{EntityType: entityType, EntityID: entityID,
+			Query: r.URL.Query().Get("q"), Tag: r.URL.Query().Get("tag")}
+		s.writeNotes(ctx, w, r, filters, caller)
+	}
+}
+
+// searchNotesHandler searches every note: ?q= matches words in the body,
+// ?tag=, ?entity_type= and ?mine=true narrow the results
+func (s *Server) searchNotesHandler(w http.ResponseWriter, r *http.Request) {
+	caller, ok := s.apiKeyOwner(w, r, "Notes")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	filters := noteFilters{Query: query.Get("q"), Tag: query.Get("tag"), EntityType: query.Get("entity_type")}
+	switch filters.EntityType {
+	case "", noteEntityGame, noteEntityPlayer, noteEntityRun:
+	default:
+		writeError(w, "entity_type must be game, player or simulation_run", http.StatusBadRequest)
+		return
+	}
+	if query.Get("mine") == "true" {
+		filters.Author = caller
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+	s.writeNotes(ctx, w, r, filters, caller)
+}
+
+// writeNotes writes one page of matching notes, newest first. Notes change
+// as analysts work, so they skip the query cache.
+func (s *Server) writeNotes(ctx context.Context, w http.ResponseWriter, r *http.Request, filters noteFilters, caller string) {
+	params := parseQueryParams(r)
+	where, args := buildNotesWhereClause(filters)
+
+	var total int
+	notes := []Note{}
+	err := s.withSnapshot(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM notes"+where, args...).Scan(&total); err != nil {
+			return fmt.Errorf("count notes: %w", err)
+		}
+
+		rows, err := tx.Query(ctx, fmt.Sprintf(`
+			SELECT id::text, entity_type, entity_id::text, body, tags, author_hash, created_at
+			FROM notes%s
+			ORDER BY created_at DESC
+			LIMIT %d OFFSET %d`, where, params.PageSize, calculateOffset(params.Page, params.PageSize)), args...)
+		if err != nil {
+			return fmt.Errorf("query notes: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var note Note
+			var authorHash string
+			if err := rows.Scan(&note.ID, &note.EntityType, &note.EntityID, &note.Body, &note.Tags,
+				&authorHash, &note.CreatedAt); err != nil {
+				return fmt.Errorf("scan note: %w", err)
+			}
+			note.Author = authorHash[:noteAuthorLength]
+			note.Mine = authorHash == caller
+			notes = append(notes, note)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		log.Printf("Failed to query notes: %v", err)
+		writeError(w, "Failed to query notes", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, buildPaginatedResponse(notes, total, params))
+}
+
+// deleteNoteHandler deletes a note. Only its author or an internal key may
+// delete it.
+func (s *Server) deleteNoteHandler(w http.ResponseWriter, r *http.Request) {
+	caller, ok := s.apiKeyOwner(w, r, "Notes")
+	if !ok {
+		return
+	}
+	noteID := mux.Vars(r)["id"]
+	if !validateUUID(noteID) {
+		writeError(w, "Invalid note ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	var authorHash string
+	err := s.dbRouter.Writer().QueryRow(ctx, `SELECT author_hash FROM notes WHERE id = $1::uuid`, noteID).Scan(&authorHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(w, "Note not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeError(w, "Failed to look up note", http.StatusInternalServerError)
+		return
+	}
+	if tier, _ := s.apiKeys.TierFor(r); authorHash != caller && tier.Name != "internal" {
+		writeError(w, "Only the note's author can delete it", http.StatusForbidden)
+		return
+	}
+
+	if _, err := s.dbRouter.Writer().Exec(ctx, `DELETE FROM notes WHERE id = $1::uuid`, noteID); err != nil {
+		log.Printf("Failed to delete note: %v", err)
+		writeError(w, "Failed to delete note", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteRequestValidate(t *testing.T) {
+	req := NoteRequest{Body: "  lineup missing Betts — day off ", Tags: []string{"Lineup", "day-off", "lineup "}}
+	msg, _ := req.validate()
+	assert.Empty(t, msg)
+	assert.Equal(t, "lineup missing Betts — day off", req.Body)
+	assert.Equal(t, []string{"lineup", "day-off"}, req.Tags)
+
+	msg, _ = (&NoteRequest{Body: "   "}).validate()
+	assert.Equal(t, "body is required", msg)
+
+	msg, _ = (&NoteRequest{Body: strings.Repeat("x", maxNoteLength+1)}).validate()
+	assert.Equal(t, "body is too long", msg)
+	// Length counts characters, not bytes
+	msg, _ = (&NoteRequest{Body: strings.Repeat("—", maxNoteLength)}).validate()
+	assert.Empty(t, msg)
+
+	msg, details := (&NoteRequest{Body: "x", Tags: []string{"day off"}}).validate()
+	assert.Contains(t, msg, "tags")
+	assert.Equal(t, "day off", details["tag"])
+
+	tags := make([]string, maxNoteTags+1)
+	for i := range tags {
+		tags[i] = strings.Repeat("t", i+1)
+	}
+	msg, _ = (&NoteRequest{Body: "x", Tags: tags}).validate()
+	assert.Equal(t, "Too many tags", msg)
+}
+
+func TestBuildNotesWhereClause(t *testing.T) {
+	where, args := buildNotesWhereClause(noteFilters{})
+	assert.Empty(t, where)
+	assert.Empty(t, args)
+
+	where, args = buildNotesWhereClause(noteFilters{
+		EntityType: noteEntityGame,
+		EntityID:   "3f2b8a7e-6c1d-4e5f-8a9b-0c1d2e3f4a5b",
+		Query:      "betts",
+		Tag:        "Lineup",
+		Author:     "abc",
+	})
+	assert.Equal(t, " WHERE entity_type = $1 AND entity_id = $2::uuid"+
+		" AND to_tsvector('english', body) @@ plainto_tsquery('english', $3)"+
+		" AND $4 = ANY(tags) AND author_hash = $5", where)
+	assert.Equal(t, []interface{}{"game", "3f2b8a7e-6c1d-4e5f-8a9b-0c1d2e3f4a5b", "betts", "lineup", "abc"}, args)
+}
+
+func TestNoteHandlersRejectBeforeQuerying(t *testing.T) {
+	keys, err := ParseAPIKeys("analyst-key:standard", "free")
+	assert.NoError(t, err)
+	s := &Server{config: &Config{}, apiKeys: keys}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/notes", s.searchNotesHandler).Methods("GET")
+	router.HandleFunc("/notes/{id}", s.deleteNoteHandler).Methods("DELETE")
+	router.HandleFunc("/games/{id}/notes", s.listEntityNotesHandler(noteEntityGame)).Methods("GET")
+	router.HandleFunc("/games/{id}/notes", s.createNoteHandler(noteEntityGame)).Methods("POST")
+
+	serve := func(method, path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Notes belong to API keys
+	rec := serve("POST", "/games/745340/notes", "", `{"body": "rain expected"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Notes require an API key")
+	assert.Equal(t, http.StatusUnauthorized, serve("GET", "/games/745340/notes", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("GET", "/notes?q=rain", "", "").Code)
+	assert.Equal(t, http.StatusForbidden, serve("DELETE", "/notes/3f2b8a7e-6c1d-4e5f-8a9b-0c1d2e3f4a5b", "unknown", "").Code)
+
+	rec = serve("POST", "/games/745340/notes", "analyst-key", `{"body": "", "tags": ["x"]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_note")
+	assert.Equal(t, http.StatusBadRequest, serve("GET", "/notes?entity_type=team", "analyst-key", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("DELETE", "/notes/42", "analyst-key", "").Code)
+}
+
+func TestNotesBypassStaleCache(t *testing.T) {
+	assert.False(t, isStaleCacheable(httptest.NewRequest("GET", "/api/v1/notes?q=rain", nil)))
+	assert.False(t, isStaleCacheable(httptest.NewRequest("GET", "/api/v1/games/745340/notes", nil)))
+	assert.True(t, isStaleCacheable(httptest.NewRequest("GET", "/api/v1/games/745340", nil)))
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// apiKeyHashHeader tells the sim-engine which API key owns a notification
+// target without forwarding the key itself
+const apiKeyHashHeader = "X-API-Key-Hash"
+
+// NotificationTargetRequest registers a Slack, Discord or generic webhook
+// target for the daily digest
+type NotificationTargetRequest struct {
+	Kind string `json:"kind"` // slack, discord or webhook
+	URL  string `json:"url"`
+}
+
+// notificationOwner returns the SHA-256 of the caller's API key. Targets are
+// per key, so anonymous callers and unknown keys get a 401 or 403.
+func (s *Server) notificationOwner(w http.ResponseWriter, r *http.Request) (string, bool) {
+	return s.apiKeyOwner(w, r, "Notification targets")
+}
+
+// apiKeyOwner returns the SHA-256 of the caller's API key for features that
+// belong to a key. Anonymous callers get a 401 naming the feature and
+// unknown keys a 403.
+func (s *Server) apiKeyOwner(w http.ResponseWriter, r *http.Request, feature string) (string, bool) {
+	key := r.Header.Get(apiKeyHeader)
+	if key == "" {
+		writeError(w, feature+" require an API key", http.StatusUnauthorized)
+		return "", false
+	}
+	if _, ok := s.apiKeys.TierFor(r); !ok {
+		writeError(w, "Invalid API key", http.StatusForbidden)
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]), true
+}
+
+// forwardNotificationRequest sends a notification target request to the
+// sim-engine on behalf of the key owner and relays the response
+func (s *Server) forwardNotificationRequest(w http.ResponseWriter, r *http.Request, method, path string, body []byte, owner string) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(r.Context(), method, s.simEngineURL()+path, reader)
+	if err != nil {
+		writeError(w, "Failed to build simulation engine request", http.StatusInternalServerError)
+		return
+	}
+	req.Header.Set(apiKeyHashHeader, owner)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.simEngineClient.Do(req)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	writeJSON(w, result)
+}
+
+// listNotificationTargetsHandler lists the daily digest targets registered
+// with the caller's API key
+func (s *Server) listNotificationTargetsHandler(w http.ResponseWriter, r *http.Request) {
+	owner, ok := s.notificationOwner(w, r)
+	if !ok {
+		return
+	}
+	s.forwardNotificationRequest(w, r, http.MethodGet, "/notifications/targets", nil, owner)
+}
+
+// createNotificationTargetHandler registers a target that receives the
+// daily digest when the day's batch completes
+func (s *Server) createNotificationTargetHandler(w http.ResponseWriter, r *http.Request) {
+	owner, ok := s.notificationOwner(w, r)
+	if !ok {
+		return
+	}
+
+	var req NotificationTargetRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+	if req.Kind == "" || req.URL == "" {
+		writeError(w, "kind and url are required", http.StatusUnprocessableEntity)
+		return
+	}
+
+	body, _ := json.Marshal(req)
+	s.forwardNotificationRequest(w, r, http.MethodPost, "/notifications/targets", body, owner)
+}
+
+// deleteNotificationTargetHandler removes one of the caller's targets
+func (s *Server) deleteNotificationTargetHandler(w http.ResponseWriter, r *http.Request) {
+	owner, ok := s.notificationOwner(w, r)
+	if !ok {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	if !validateUUID(id) {
+		writeError(w, "Invalid target ID", http.StatusBadRequest)
+		return
+	}
+	s.forwardNotificationRequest(w, r, http.MethodDelete, "/notifications/targets/"+url.PathEscape(id), nil, owner)
+}
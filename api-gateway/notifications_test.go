@@ -0,0 +1,91 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationTargetHandlers(t *testing.T) {
+	var forwardedHash, forwardedBody string
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwardedHash = r.Header.Get(apiKeyHashHeader)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/notifications/targets":
+			w.Write([]byte(`{"targets": []}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/notifications/targets":
+			var req NotificationTargetRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			forwardedBody = req.Kind + " " + req.URL
+			if req.Kind == "sms" {
+				http.Error(w, `unknown target kind "sms"`, http.StatusUnprocessableEntity)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "5d1e4f7a-0000-4000-8000-000000000001", "kind": "slack", "url": "https://hooks.slack.com/***abcd"}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer engine.Close()
+
+	keys, err := ParseAPIKeys("std-key:standard", "free")
+	assert.NoError(t, err)
+	s := &Server{
+		config:          &Config{SimEngineURL: engine.URL},
+		apiKeys:         keys,
+		simEngineClient: NewUpstreamClient("sim-engine", 2),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/notifications/targets", s.listNotificationTargetsHandler).Methods("GET")
+	router.HandleFunc("/notifications/targets", s.createNotificationTargetHandler).Methods("POST")
+	router.HandleFunc("/notifications/targets/{id}", s.deleteNotificationTargetHandler).Methods("DELETE")
+
+	do := func(method, path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	sum := sha256.Sum256([]byte("std-key"))
+	wantHash := hex.EncodeToString(sum[:])
+
+	// Targets belong to a key, so anonymous and unknown callers are rejected
+	assert.Equal(t, http.StatusUnauthorized, do("GET", "/notifications/targets", "", "").Code)
+	assert.Equal(t, http.StatusForbidden, do("GET", "/notifications/targets", "bogus", "").Code)
+
+	rec := do("GET", "/notifications/targets", "std-key", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, wantHash, forwardedHash)
+	assert.NotContains(t, forwardedHash, "std-key")
+
+	rec = do("POST", "/notifications/targets", "std-key", `{"kind": "slack", "url": "https://hooks.slack.com/services/T0/B0/abcd"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "slack https://hooks.slack.com/services/T0/B0/abcd", forwardedBody)
+
+	rec = do("POST", "/notifications/targets", "std-key", `{"kind": "sms", "url": "https://example.com"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unknown target kind")
+
+	rec = do("POST", "/notifications/targets", "std-key", `{"kind": "slack"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	assert.Equal(t, http.StatusBadRequest, do("DELETE", "/notifications/targets/not-a-uuid", "std-key", "").Code)
+	assert.Equal(t, http.StatusNoContent, do("DELETE", "/notifications/targets/5d1e4f7a-0000-4000-8000-000000000001", "std-key", "").Code)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// OddsLine is one bookmaker's prices for a game, sent to the simulation
+// engine for storage. Prices are American odds.
+type OddsLine struct {
+	GameID        string     `json:"game_id"` // UUID or MLB game ID
+	Bookmaker     string     `json:"bookmaker"`
+	HomeMoneyline *int       `json:"home_moneyline,omitempty"`
+	AwayMoneyline *int       `json:"away_moneyline,omitempty"`
+	Total         *float64   `json:"total,omitempty"`
+	OverPrice     *int       `json:"over_price,omitempty"`
+	UnderPrice    *int       `json:"under_price,omitempty"`
+	CapturedAt    *time.Time `json:"captured_at,omitempty"` // defaults to now
+}
+
+// OddsIngestRequest loads market lines in bulk
+type OddsIngestRequest struct {
+	Lines []OddsLine `json:"lines"`
+}
+
+// maxOddsLinesPerRequest bounds one ingestion request, matching the engine
+const maxOddsLinesPerRequest = 1000
+
+// validate checks each line names its game and bookmaker and prices a
+// market; the engine checks the prices themselves
+func (req OddsIngestRequest) validate() (string, map[string]interface{}) {
+	if len(req.Lines) == 0 {
+		return "lines is required", nil
+	}
+	if len(req.Lines) > maxOddsLinesPerRequest {
+		return "Too many lines in one request", map[string]interface{}{"max": maxOddsLinesPerRequest}
+	}
+	for i, line := range req.Lines {
+		details := map[string]interface{}{"index": i}
+		switch {
+		case line.GameID == "":
+			return "game_id is required", details
+		case line.Bookmaker == "":
+			return "bookmaker is required", details
+		case line.HomeMoneyline == nil && line.AwayMoneyline == nil && line.Total == nil:
+			return "a moneyline or total is required", details
+		}
+	}
+	return "", nil
+}
+
+// ingestOddsHandler stores market lines through the simulation engine.
+// Internal API keys only.
+func (s *Server) ingestOddsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	var req OddsIngestRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+	if msg, details := req.validate(); msg != "" {
+		writeErrorWithDetails(w, msg, "invalid_odds", details, http.StatusUnprocessableEntity)
+		return
+	}
+
+	body, _ := json.Marshal(req)
+	resp, err := s.simEngineClient.Post(r.Context(), s.simEngineURL()+"/odds", "application/json", bytes.NewReader(body))
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
+
+// getGameOddsHandler returns a game's stored lines and closing line,
+// forwarding ?bookmaker=
+func (s *Server) getGameOddsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	query.Set("game_id", mux.Vars(r)["id"])
+	s.forwardOddsRequest(w, r, "/odds", query)
+}
+
+// getSimulationOddsHandler compares a run with the market, forwarding
+// ?line= and ?bookmaker=
+func (s *Server) getSimulationOddsHandler(w http.ResponseWriter, r *http.Request) {
+	s.forwardOddsRequest(w, r, "/simulation/"+url.PathEscape(mux.Vars(r)["id"])+"/odds", r.URL.Query())
+}
+
+// getClosingLineValueHandler returns the engine's closing line value report,
+// forwarding ?start_date=, ?end_date=, ?bookmaker= and ?min_edge=
+func (s *Server) getClosingLineValueHandler(w http.ResponseWriter, r *http.Request) {
+	s.forwardOddsRequest(w, r, "/odds/clv", r.URL.Query())
+}
+
+// forwardOddsRequest relays a GET to the engine's odds endpoints
+func (s *Server) forwardOddsRequest(w http.ResponseWriter, r *http.Request, path string, query url.Values) {
+	target := s.simEngineURL() + path
+	if encoded := query.Encode(); encoded != "" {
+		target += "?" + encoded
+	}
+	resp, err := s.simEngineClient.Get(r.Context(), target)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	// The engine answers 202 while the run is still going
+	if resp.StatusCode >= 400 || resp.StatusCode == http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOddsIngestRequestValidate(t *testing.T) {
+	price, total := -150, 8.5
+
+	tests := []struct {
+		name string
+		req  OddsIngestRequest
+		msg  string
+	}{
+		{"empty", OddsIngestRequest{}, "lines is required"},
+		{"valid moneyline", OddsIngestRequest{Lines: []OddsLine{{GameID: "745123", Bookmaker: "draftkings", HomeMoneyline: &price, AwayMoneyline: &price}}}, ""},
+		{"valid total", OddsIngestRequest{Lines: []OddsLine{{GameID: "745123", Bookmaker: "draftkings", Total: &total}}}, ""},
+		{"missing game", OddsIngestRequest{Lines: []OddsLine{{Bookmaker: "draftkings", Total: &total}}}, "game_id is required"},
+		{"missing bookmaker", OddsIngestRequest{Lines: []OddsLine{{GameID: "745123", Total: &total}}}, "bookmaker is required"},
+		{"no market", OddsIngestRequest{Lines: []OddsLine{{GameID: "745123", Bookmaker: "draftkings"}}}, "a moneyline or total is required"},
+		{"too many", OddsIngestRequest{Lines: make([]OddsLine, maxOddsLinesPerRequest+1)}, "Too many lines in one request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, _ := tt.req.validate()
+			assert.Equal(t, tt.msg, msg)
+		})
+	}
+}
+
+func TestOddsProxyHandlers(t *testing.T) {
+	var forwarded string
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.URL.RequestURI()
+		if r.URL.Path == "/simulation/running/odds" {
+			http.Error(w, "Simulation not yet complete", http.StatusAccepted)
+			return
+		}
+		w.Write([]byte(`{"game_id": "g1", "lines": []}`))
+	}))
+	defer engine.Close()
+
+	s := &Server{
+		config:          &Config{SimEngineURL: engine.URL},
+		simEngineClient: NewUpstreamClient("sim-engine", 2),
+	}
+
+	rec := httptest.NewRecorder()
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/api/v1/games/745123/odds?bookmaker=draftkings", nil), map[string]string{"id": "745123"})
+	s.getGameOddsHandler(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "/odds?bookmaker=draftkings&game_id=745123", forwarded)
+
+	rec = httptest.NewRecorder()
+	s.getClosingLineValueHandler(rec, httptest.NewRequest("GET", "/api/v1/simulations/clv?min_edge=0.02", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "/odds/clv?min_edge=0.02", forwarded)
+
+	rec = httptest.NewRecorder()
+	req = mux.SetURLVars(httptest.NewRequest("GET", "/api/v1/simulations/running/odds?line=closing", nil), map[string]string{"id": "running"})
+	s.getSimulationOddsHandler(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "/simulation/running/odds?line=closing", forwarded)
+	assert.Contains(t, rec.Body.String(), "Simulation not yet complete")
+}
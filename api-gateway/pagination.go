@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"net/http"
+)
+
+// PageLimits are a route's default page_size and the most it will return
+// in one page
+type PageLimits struct {
+	Default int
+	Max     int
+}
+
+// defaultPageLimits apply to routes registered without their own limits
+var defaultPageLimits = PageLimits{Default: 50, Max: 200}
+
+type pageLimitsKey struct{}
+
+// withPageLimits sets the pagination limits parseQueryParams applies for a
+// route. Register it alongside the route so each endpoint's limits are
+// visible in setupRoutes.
+func withPageLimits(limits PageLimits, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), pageLimitsKey{}, limits)))
+	}
+}
+
+// pageLimitsFor returns the limits registered for the request's route
+func pageLimitsFor(r *http.Request) PageLimits {
+	if limits, ok := r.Context().Value(pageLimitsKey{}).(PageLimits); ok {
+		return limits
+	}
+	return defaultPageLimits
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseQueryParamsPageLimits tests per-route page_size defaults and caps
+func TestParseQueryParamsPageLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   *PageLimits
+		query    string
+		expected int
+	}{
+		{"global default", nil, "", 50},
+		{"global cap", nil, "?page_size=1000", 200},
+		{"route default", &PageLimits{Default: 25, Max: 100}, "", 25},
+		{"route allows requested size", &PageLimits{Default: 25, Max: 100}, "?page_size=80", 80},
+		{"route cap", &PageLimits{Default: 50, Max: 500}, "?page_size=5000", 500},
+		{"invalid size uses route default", &PageLimits{Default: 25, Max: 100}, "?page_size=-3", 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params QueryParams
+			handler := func(w http.ResponseWriter, r *http.Request) { params = parseQueryParams(r) }
+			if tt.limits != nil {
+				handler = withPageLimits(*tt.limits, handler)
+			}
+
+			handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/games"+tt.query, nil))
+			assert.Equal(t, tt.expected, params.PageSize)
+		})
+	}
+}
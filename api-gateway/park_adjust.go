@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+)
+
+// ParkFactors are a park's factors for one season (100 = neutral), read from
+// the park_factors table
+type ParkFactors struct {
+	RunsFactor      float64 `json:"runs_factor"`
+	HRFactor        float64 `json:"hr_factor"`
+	HitsFactor      float64 `json:"hits_factor"`
+	DoublesFactor   float64 `json:"doubles_factor"`
+	TriplesFactor   float64 `json:"triples_factor"`
+	BABIPFactor     float64 `json:"babip_factor"`
+	StrikeoutFactor float64 `json:"strikeout_factor"`
+	WalkFactor      float64 `json:"walk_factor"`
+}
+
+// ParkAdjustment describes the park context applied to adjusted stats
+type ParkAdjustment struct {
+	StadiumID   string      `json:"stadium_id"`
+	StadiumName string      `json:"stadium_name"`
+	Factors     ParkFactors `json:"factors"`
+	Method      string      `json:"method"`
+}
+
+// parkFactorsFromRows converts park_factors values (1.000 = neutral) keyed by
+// factor type to ParkFactors. Types that don't affect offensive stats, such
+// as errors, are skipped; ok is false when none apply.
+func parkFactorsFromRows(values map[string]float64) (pf ParkFactors, ok bool) {
+	for factorType, value := range values {
+		var field *float64
+		switch factorType {
+		case "runs":
+			field = &pf.RunsFactor
+		case "hr":
+			field = &pf.HRFactor
+		case "hits", "batting_avg":
+			field = &pf.HitsFactor
+		case "doubles":
+			field = &pf.DoublesFactor
+		case "triples":
+			field = &pf.TriplesFactor
+		case "babip":
+			field = &pf.BABIPFactor
+		case "strikeouts":
+			field = &pf.StrikeoutFactor
+		case "walks":
+			field = &pf.WalkFactor
+		}
+		if field == nil || value <= 0 {
+			continue
+		}
+		*field = math.Round(value*1000) / 10
+		ok = true
+	}
+	return pf, ok
+}
+
+// multiplier returns the half-home/half-road multiplier for a factor.
+// Players play roughly half their games at home, so only half of the park
+// effect is removed; missing factors are treated as neutral.
+func (pf ParkFactors) multiplier(factor float64) float64 {
+	if factor <= 0 {
+		return 1.0
+	}
+	return (factor/100.0 + 1.0) / 2.0
+}
+
+// batting stat keys and the park factor used to neutralize them
+func (pf ParkFactors) battingFactors() map[string]float64 {
+	return map[string]float64{
+		"H":     pf.multiplier(pf.HitsFactor),
+		"AVG":   pf.multiplier(pf.HitsFactor),
+		"BABIP": pf.multiplier(pf.BABIPFactor),
+		"2B":    pf.multiplier(pf.DoublesFactor),
+		"3B":    pf.multiplier(pf.TriplesFactor),
+		"HR":    pf.multiplier(pf.HRFactor),
+		"R":     pf.multiplier(pf.RunsFactor),
+		"RBI":   pf.multiplier(pf.RunsFactor),
+		"SLG":   pf.multiplier(pf.RunsFactor),
+		"OBP":   pf.multiplier(pf.RunsFactor),
+		"OPS":   pf.multiplier(pf.RunsFactor),
+		"ISO":   pf.multiplier(pf.HRFactor),
+		"BB%":   pf.multiplier(pf.WalkFactor),
+		"K%":    pf.multiplier(pf.StrikeoutFactor),
+	}
+}
+
+// pitching stat keys and the park factor used to neutralize them. FIP is
+// adjusted separately, as only its home run term depends on the park.
+func (pf ParkFactors) pitchingFactors() map[string]float64 {
+	return map[string]float64{
+		"ERA":  pf.multiplier(pf.RunsFactor),
+		"WHIP": pf.multiplier(pf.HitsFactor),
+		"H":    pf.multiplier(pf.HitsFactor),
+		"HR":   pf.multiplier(pf.HRFactor),
+		"ER":   pf.multiplier(pf.RunsFactor),
+		"HR/9": pf.multiplier(pf.HRFactor),
+		"K/9":  pf.multiplier(pf.StrikeoutFactor),
+		"BB/9": pf.multiplier(pf.WalkFactor),
+	}
+}
+
+// parkAdjustStats returns a copy of the numeric stats divided by the park
+// multiplier for each known key; counting stats are rounded to one decimal
+func parkAdjustStats(stats map[string]interface{}, statsType string, pf ParkFactors) map[string]interface{} {
+	var factors map[string]float64
+	switch statsType {
+	case "batting":
+		factors = pf.battingFactors()
+	case "pitching":
+		factors = pf.pitchingFactors()
+	default:
+		return nil
+	}
+
+	adjusted := make(map[string]interface{})
+	for key, factor := range factors {
+		raw, ok := stats[key]
+		if !ok {
+			continue
+		}
+		value, ok := toFloat(raw)
+		if !ok || factor == 0 {
+			continue
+		}
+
+		neutral := value / factor
+		switch key {
+		case "H", "2B", "3B", "HR", "R", "RBI", "ER":
+			adjusted[key] = math.Round(neutral*10) / 10
+		default:
+			adjusted[key] = math.Round(neutral*1000) / 1000
+		}
+	}
+
+	if statsType == "pitching" {
+		if fip, ok := parkAdjustFIP(stats, pf.multiplier(pf.HRFactor)); ok {
+			adjusted["FIP"] = math.Round(fip*1000) / 1000
+		}
+	}
+
+	return adjusted
+}
+
+// parkAdjustFIP neutralizes the home run term of FIP,
+// (13*HR + 3*(BB+HBP) - 2*K) / IP + constant, leaving the walk and strikeout
+// terms alone. It needs FIP, HR and IP in the stats.
+func parkAdjustFIP(stats map[string]interface{}, hrFactor float64) (float64, bool) {
+	fip, ok := toFloat(stats["FIP"])
+	if !ok {
+		return 0, false
+	}
+	hr, hrOK := toFloat(stats["HR"])
+	ip, ipOK := toFloat(stats["IP"])
+	innings := inningsFromNotation(ip)
+	if !hrOK || !ipOK || innings <= 0 || hrFactor <= 0 {
+		return 0, false
+	}
+	return fip - 13*(hr-hr/hrFactor)/innings, true
+}
+
+// inningsFromNotation converts innings pitched in baseball notation, where
+// .1 and .2 are thirds of an inning, to innings
+func inningsFromNotation(ip float64) float64 {
+	whole := math.Floor(ip)
+	return whole + math.Round((ip-whole)*10)/3
+}
+
+// toFloat converts JSON-decoded numeric values (including numeric strings)
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		var f float64
+		if err := json.Unmarshal([]byte(n), &f); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// parseAdjustedParam reads ?adjusted=; returns ok=false for unsupported values
+func parseAdjustedParam(r *http.Request) (adjustPark bool, ok bool) {
+	switch r.URL.Query().Get("adjusted") {
+	case "":
+		return false, true
+	case "park":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// loadParkAdjustment loads the season's factors of the park a resolved player
+// or team UUID called home that season. Returns nil when no home games are
+// recorded for that season or the park has no factors for it.
+func (s *Server) loadParkAdjustment(ctx context.Context, query string, id string, season int) *ParkAdjustment {
+	var adj ParkAdjustment
+	var factorsJSON []byte
+
+	err := s.readDB().QueryRow(ctx, query, id, season).Scan(&adj.StadiumID, &adj.StadiumName, &factorsJSON)
+	if err != nil || len(factorsJSON) == 0 {
+		return nil
+	}
+
+	var values map[string]float64
+	if err := json.Unmarshal(factorsJSON, &values); err != nil {
+		return nil
+	}
+	factors, ok := parkFactorsFromRows(values)
+	if !ok {
+		return nil
+	}
+	adj.Factors = factors
+
+	adj.Method = "half_home_half_road"
+	return &adj
+}
+
+// seasonParkFactors collects stadium s's factors for season $2 as a JSON
+// object keyed by factor type. Splits by batter handedness are skipped.
+const seasonParkFactors = `
+		SELECT jsonb_object_agg(pf.factor_type, pf.factor_value)
+		FROM park_factors pf
+		WHERE pf.stadium_id = s.id AND pf.season = $2 AND pf.handedness IS NULL`
+
+// playerParkQuery finds the team a player appeared for most that season,
+// then the park that team hosted most of its home games in
+const playerParkQuery = `
+	WITH season_team AS (
+		SELECT a.team_id
+		FROM (
+			SELECT game_id, team_id FROM game_box_score_batting WHERE player_id = $1
+			UNION
+			SELECT game_id, team_id FROM game_box_score_pitching WHERE player_id = $1
+		) a
+		JOIN games g ON g.id = a.game_id
+		WHERE g.season = $2
+		GROUP BY a.team_id
+		ORDER BY COUNT(*) DESC
+		LIMIT 1
+	)
+	SELECT s.id::text, s.name, (` + seasonParkFactors + `)
+	FROM season_team st
+	JOIN games g ON g.home_team_id = st.team_id AND g.season = $2
+	JOIN stadiums s ON g.stadium_id = s.id
+	GROUP BY s.id
+	ORDER BY COUNT(*) DESC
+	LIMIT 1`
+
+// teamParkQuery finds the park a team hosted most of its home games in that
+// season, so relocations and temporary homes are respected
+const teamParkQuery = `
+	SELECT s.id::text, s.name, (` + seasonParkFactors + `)
+	FROM games g
+	JOIN stadiums s ON g.stadium_id = s.id
+	WHERE g.home_team_id = $1 AND g.season = $2
+	GROUP BY s.id
+	ORDER BY COUNT(*) DESC
+	LIMIT 1`
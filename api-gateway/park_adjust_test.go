@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParkMultiplier tests the half-home/half-road park multiplier
+func TestParkMultiplier(t *testing.T) {
+	pf := ParkFactors{}
+	assert.Equal(t, 1.0, pf.multiplier(0))
+	assert.Equal(t, 1.0, pf.multiplier(100))
+	assert.InDelta(t, 1.15, pf.multiplier(130), 0.0001)
+	assert.InDelta(t, 0.95, pf.multiplier(90), 0.0001)
+}
+
+// TestParkAdjustBattingStats tests neutralizing a hitter's line in a hitter's park
+func TestParkAdjustBattingStats(t *testing.T) {
+	coors := ParkFactors{RunsFactor: 115, HRFactor: 120, HitsFactor: 110}
+	stats := map[string]interface{}{
+		"HR":  float64(33),
+		"AVG": float64(0.300),
+		"PA":  float64(600),
+	}
+
+	adjusted := parkAdjustStats(stats, "batting", coors)
+
+	assert.InDelta(t, 30.0, adjusted["HR"], 0.05)
+	assert.InDelta(t, 0.286, adjusted["AVG"], 0.001)
+	_, hasPA := adjusted["PA"]
+	assert.False(t, hasPA, "volume stats are not park adjusted")
+}
+
+// TestParkAdjustPitchingStats tests neutralizing a pitcher's line in a pitcher's park
+func TestParkAdjustPitchingStats(t *testing.T) {
+	pitchersPark := ParkFactors{RunsFactor: 90}
+	stats := map[string]interface{}{"ERA": "3.00"}
+
+	adjusted := parkAdjustStats(stats, "pitching", pitchersPark)
+
+	assert.InDelta(t, 3.158, adjusted["ERA"], 0.001)
+	assert.Nil(t, parkAdjustStats(stats, "fielding", pitchersPark))
+}
+
+// TestParkAdjustFIP tests only FIP's home run term is park adjusted
+func TestParkAdjustFIP(t *testing.T) {
+	hrPark := ParkFactors{HRFactor: 120}
+	stats := map[string]interface{}{"FIP": float64(4.00), "HR": float64(22), "IP": float64(180.1)}
+
+	adjusted := parkAdjustStats(stats, "pitching", hrPark)
+
+	// 22 HR become 20 in a neutral park, worth 13*2/180.33 of FIP
+	assert.InDelta(t, 3.856, adjusted["FIP"], 0.001)
+
+	delete(stats, "IP")
+	_, hasFIP := parkAdjustStats(stats, "pitching", hrPark)["FIP"]
+	assert.False(t, hasFIP, "FIP needs innings pitched to adjust")
+}
+
+// TestParkFactorsFromRows tests converting season park_factors rows
+func TestParkFactorsFromRows(t *testing.T) {
+	pf, ok := parkFactorsFromRows(map[string]float64{"hr": 1.15, "batting_avg": 0.98, "errors": 1.2})
+	assert.True(t, ok)
+	assert.Equal(t, ParkFactors{HRFactor: 115, HitsFactor: 98}, pf)
+
+	_, ok = parkFactorsFromRows(map[string]float64{"errors": 1.2})
+	assert.False(t, ok)
+}
+
+// TestParseAdjustedParam tests ?adjusted= validation
+func TestParseAdjustedParam(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantPark bool
+		wantOK   bool
+	}{
+		{"", false, true},
+		{"?adjusted=park", true, true},
+		{"?adjusted=era", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/players/1/stats"+tt.query, nil)
+			park, ok := parseAdjustedParam(r)
+			assert.Equal(t, tt.wantPark, park)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
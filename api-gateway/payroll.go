@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+)
+
+// PlayerContract is one player-season salary sent to the data fetcher for storage
+type PlayerContract struct {
+	PlayerID          string `json:"player_id"` // UUID or MLB player ID
+	Season            int    `json:"season"`
+	Salary            *int64 `json:"salary"` // whole US dollars for the season
+	ContractYears     *int   `json:"contract_years,omitempty"`
+	ContractEndSeason *int   `json:"contract_end_season,omitempty"`
+}
+
+// ContractsIngestRequest loads salaries and contract lengths in bulk
+type ContractsIngestRequest struct {
+	Contracts []PlayerContract `json:"contracts"`
+	Source    *string          `json:"source,omitempty"` // where the figures came from
+}
+
+// maxContractsPerRequest bounds one ingestion request
+const maxContractsPerRequest = 2000
+
+// validate checks each contract before it goes to the data fetcher and
+// returns the index of the first bad one in details
+func (req ContractsIngestRequest) validate() (string, map[string]interface{}) {
+	if len(req.Contracts) == 0 {
+		return "contracts is required", nil
+	}
+	if len(req.Contracts) > maxContractsPerRequest {
+		return "Too many contracts in one request", map[string]interface{}{"max": maxContractsPerRequest}
+	}
+	for i, c := range req.Contracts {
+		details := map[string]interface{}{"index": i}
+		switch {
+		case c.PlayerID == "":
+			return "player_id is required", details
+		case c.Season < firstMLBSeason:
+			return "season is required", details
+		case c.Salary == nil || *c.Salary < 0:
+			return "salary must be a non-negative number of dollars", details
+		case c.ContractYears != nil && *c.ContractYears < 1:
+			return "contract_years must be at least 1", details
+		case c.ContractEndSeason != nil && *c.ContractEndSeason < c.Season:
+			return "contract_end_season cannot be before season", details
+		}
+	}
+	return "", nil
+}
+
+// ingestContractsHandler stores player salaries through the data fetcher.
+// Internal API keys only.
+func (s *Server) ingestContractsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	var req ContractsIngestRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+	if msg, details := req.validate(); msg != "" {
+		writeErrorWithDetails(w, msg, "invalid_contract", details, http.StatusUnprocessableEntity)
+		return
+	}
+
+	body, _ := json.Marshal(req)
+	resp, err := s.dataFetcherClient.Post(r.Context(), s.config.DataFetcherURL+"/contracts", "application/json", bytes.NewReader(body))
+	if err != nil {
+		writeError(w, "Failed to communicate with data fetcher", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse data fetcher response", http.StatusInternalServerError)
+		return
+	}
+
+	// Cached payroll responses carry the old salaries
+	s.queryCache.Clear()
+	writeJSON(w, result)
+}
+
+// PayrollPlayer is one rostered player's salary and value
+type PayrollPlayer struct {
+	PlayerID          string   `json:"player_id"`
+	Name              string   `json:"name"`
+	Position          string   `json:"position"`
+	Salary            *int64   `json:"salary"`
+	ContractYears     *int     `json:"contract_years,omitempty"`
+	ContractEndSeason *int     `json:"contract_end_season,omitempty"`
+	YearsRemaining    *int     `json:"years_remaining,omitempty"` // seasons after this one
+	WAR               *float64 `json:"WAR"`                       // batting plus pitching WAR
+	DollarsPerWAR     *float64 `json:"dollars_per_WAR"`           // null unless WAR is positive
+}
+
+// TeamPayroll is a team's payroll and what it bought
+type TeamPayroll struct {
+	TeamID               string          `json:"team_id"`
+	TeamName             string          `json:"team_name"`
+	Season               int             `json:"season"`
+	TotalPayroll         int64           `json:"total_payroll"`
+	PayrollWAR           float64         `json:"payroll_WAR"` // WAR of the players with a salary
+	DollarsPerWAR        *float64        `json:"dollars_per_WAR"`
+	PlayersWithSalary    int             `json:"players_with_salary"`
+	PlayersWithoutSalary int             `json:"players_without_salary"`
+	Players              []PayrollPlayer `json:"players"`
+}
+
+// dollarsPerWAR is salary divided by WAR, rounded to the dollar. Players at or
+// below replacement level have no meaningful cost per win.
+func dollarsPerWAR(salary int64, war float64) *float64 {
+	if war <= 0 {
+		return nil
+	}
+	value := math.Round(float64(salary) / war)
+	return &value
+}
+
+// buildTeamPayroll fills in each player's value metric and the team totals,
+// and orders players by salary, highest first, with unsigned players last
+func buildTeamPayroll(payroll *TeamPayroll) {
+	payroll.TotalPayroll, payroll.PayrollWAR = 0, 0
+	payroll.PlayersWithSalary, payroll.PlayersWithoutSalary = 0, 0
+
+	for i := range payroll.Players {
+		p := &payroll.Players[i]
+		if p.Salary == nil {
+			payroll.PlayersWithoutSalary++
+			continue
+		}
+		payroll.PlayersWithSalary++
+		payroll.TotalPayroll += *p.Salary
+		if p.ContractEndSeason != nil {
+			remaining := *p.ContractEndSeason - payroll.Season
+			p.YearsRemaining = &remaining
+		}
+		if p.WAR != nil {
+			payroll.PayrollWAR += *p.WAR
+			p.DollarsPerWAR = dollarsPerWAR(*p.Salary, *p.WAR)
+		}
+	}
+	payroll.PayrollWAR = math.Round(payroll.PayrollWAR*10) / 10
+	payroll.DollarsPerWAR = dollarsPerWAR(payroll.TotalPayroll, payroll.PayrollWAR)
+
+	sort.SliceStable(payroll.Players, func(i, j int) bool {
+		a, b := payroll.Players[i].Salary, payroll.Players[j].Salary
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a > *b
+	})
+}
+
+// getTeamPayrollHandler reports a team's payroll for a season and the
+// salary paid per win above replacement, player by player. Players are the
+// team's current active roster.
+func (s *Server) getTeamPayrollHandler(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+	if teamID == "" {
+		writeError(w, "Team ID is required", http.StatusBadRequest)
+		return
+	}
+
+	payroll := TeamPayroll{Season: getCurrentSeason(), Players: []PayrollPlayer{}}
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		season, err := strconv.Atoi(seasonStr)
+		if err != nil || season < firstMLBSeason {
+			writeError(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		payroll.Season = season
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, teamID)
+	if !ok {
+		return
+	}
+	payroll.TeamID = resolved.ID
+	err := s.readDB().QueryRow(ctx, `SELECT name FROM teams WHERE id = $1`, payroll.TeamID).Scan(&payroll.TeamName)
+	if err != nil {
+		log.Printf("Team query error: %v", err)
+		writeError(w, "Failed to query team", http.StatusInternalServerError)
+		return
+	}
+
+	rows, err := s.readDB().Query(ctx, `
+		SELECT p.id::text, p.full_name, COALESCE(p.position, ''),
+		       c.salary, c.contract_years, c.contract_end_season,
+		       CASE WHEN bat.player_id IS NULL AND pit.player_id IS NULL THEN NULL
+		            ELSE COALESCE((bat.aggregated_stats->>'WAR')::float8, 0)
+		               + COALESCE((pit.aggregated_stats->>'WAR')::float8, 0)
+		       END
+		FROM players p
+		LEFT JOIN player_contracts c ON c.player_id = p.id AND c.season = $2
+		LEFT JOIN player_season_aggregates bat
+		  ON bat.player_id = p.id AND bat.season = $2 AND bat.stats_type = 'batting'
+		LEFT JOIN player_season_aggregates pit
+		  ON pit.player_id = p.id AND pit.season = $2 AND pit.stats_type = 'pitching'
+		WHERE p.team_id = $1 AND p.status IN ('A', '40M')
+		ORDER BY p.full_name
+	`, payroll.TeamID, payroll.Season)
+	if err != nil {
+		log.Printf("Payroll query error: %v", err)
+		writeError(w, "Failed to query payroll", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p PayrollPlayer
+		if err := rows.Scan(&p.PlayerID, &p.Name, &p.Position,
+			&p.Salary, &p.ContractYears, &p.ContractEndSeason, &p.WAR); err != nil {
+			log.Printf("Error scanning payroll row: %v", err)
+			continue
+		}
+		payroll.Players = append(payroll.Players, p)
+	}
+
+	buildTeamPayroll(&payroll)
+	writeJSON(w, payroll)
+}
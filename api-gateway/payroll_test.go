@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func TestDollarsPerWAR(t *testing.T) {
+	assert.Equal(t, 5_000_000.0, *dollarsPerWAR(40_000_000, 8))
+	assert.Nil(t, dollarsPerWAR(20_000_000, 0))
+	assert.Nil(t, dollarsPerWAR(20_000_000, -0.4))
+}
+
+func TestBuildTeamPayroll(t *testing.T) {
+	judgeWAR, coleWAR, rookieWAR, benchWAR := 8.1, 4.0, 2.5, -0.3
+	endSeason := 2031
+	payroll := TeamPayroll{
+		Season: 2026,
+		Players: []PayrollPlayer{
+			{Name: "Rookie", Salary: nil, WAR: &rookieWAR},
+			{Name: "Cole", Salary: int64Ptr(36_000_000), WAR: &coleWAR},
+			{Name: "Bench", Salary: int64Ptr(1_000_000), WAR: &benchWAR},
+			{Name: "Judge", Salary: int64Ptr(40_000_000), WAR: &judgeWAR, ContractEndSeason: &endSeason},
+			{Name: "Injured", Salary: int64Ptr(10_000_000)},
+		},
+	}
+	buildTeamPayroll(&payroll)
+
+	assert.Equal(t, int64(87_000_000), payroll.TotalPayroll)
+	assert.Equal(t, 11.8, payroll.PayrollWAR)
+	assert.Equal(t, 4, payroll.PlayersWithSalary)
+	assert.Equal(t, 1, payroll.PlayersWithoutSalary)
+	assert.InDelta(t, 87_000_000/11.8, *payroll.DollarsPerWAR, 1)
+
+	names := make([]string, len(payroll.Players))
+	for i, p := range payroll.Players {
+		names[i] = p.Name
+	}
+	assert.Equal(t, []string{"Judge", "Cole", "Injured", "Bench", "Rookie"}, names)
+
+	judge := payroll.Players[0]
+	assert.Equal(t, 5, *judge.YearsRemaining)
+	assert.Equal(t, 4_938_272.0, *judge.DollarsPerWAR)
+	assert.Nil(t, payroll.Players[2].DollarsPerWAR) // no stats
+	assert.Nil(t, payroll.Players[3].DollarsPerWAR) // below replacement
+	assert.Nil(t, payroll.Players[4].DollarsPerWAR) // no salary
+}
+
+func TestIngestContractsHandler(t *testing.T) {
+	var forwarded ContractsIngestRequest
+	fetcher := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/contracts" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&forwarded)
+		w.Write([]byte(`{"updated": 1, "unknown_players": []}`))
+	}))
+	defer fetcher.Close()
+
+	keys, err := ParseAPIKeys("admin-key:internal,std-key:standard", "free")
+	assert.NoError(t, err)
+	s := &Server{
+		config:            &Config{DataFetcherURL: fetcher.URL},
+		apiKeys:           keys,
+		queryCache:        NewQueryCache(),
+		dataFetcherClient: NewUpstreamClient("data-fetcher", 2),
+	}
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/contracts", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		s.ingestContractsHandler(rec, req)
+		return rec
+	}
+	valid := `{"source": "spotrac", "contracts": [{"player_id": "592450", "season": 2026, "salary": 40000000, "contract_years": 9, "contract_end_season": 2031}]}`
+
+	rec := post("admin-key", valid)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"updated":1`)
+	assert.Equal(t, "592450", forwarded.Contracts[0].PlayerID)
+	assert.Equal(t, int64(40_000_000), *forwarded.Contracts[0].Salary)
+
+	// Only internal keys may load salaries
+	assert.Equal(t, http.StatusForbidden, post("std-key", valid).Code)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, post("admin-key", `{"contracts": []}`).Code)
+	rec = post("admin-key", `{"contracts": [{"player_id": "592450", "season": 2026}]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "salary")
+	rec = post("admin-key", `{"contracts": [{"player_id": "592450", "season": 2026, "salary": 1, "contract_end_season": 2025}]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
@@ -0,0 +1,405 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// plateAppearancesPerGame converts per-PA run values to runs per game
+	plateAppearancesPerGame = 38.0
+
+	// minPlatoonPA is the plate appearances needed against each hand before
+	// a hitter is ranked as a split driver
+	minPlatoonPA = 20
+
+	platoonDrivers = 5
+)
+
+// wOBA linear weights, matching the data fetcher's stats calculator
+const (
+	wobaWeightBB  = 0.707
+	wobaWeightHBP = 0.739
+	wobaWeight1B  = 0.902
+	wobaWeight2B  = 1.28
+	wobaWeight3B  = 1.62
+	wobaWeightHR  = 2.07
+)
+
+// LeagueEnvironment holds a season's run environment constants
+type LeagueEnvironment struct {
+	Season    int     `json:"season"`
+	WOBA      float64 `json:"league_woba"`
+	WOBAScale float64 `json:"woba_scale"`
+	LeagueFIP float64 `json:"league_fip"`
+	RunsPerPA float64 `json:"runs_per_pa"`
+}
+
+// PlatoonSplit is batting production against one pitcher hand
+type PlatoonSplit struct {
+	PA          int     `json:"pa"`
+	AB          int     `json:"ab"`
+	H           int     `json:"h"`
+	HR          int     `json:"hr"`
+	BB          int     `json:"bb"`
+	K           int     `json:"k"`
+	AVG         float64 `json:"avg"`
+	OBP         float64 `json:"obp"`
+	SLG         float64 `json:"slg"`
+	WOBA        float64 `json:"woba"`
+	RunsPerGame float64 `json:"runs_per_game"`
+	hbp, sf, sh int
+	singles     int
+	doubles     int
+	triples     int
+	wobaDenom   int
+}
+
+// PlatoonHitter is one hitter's split and share of the team split
+type PlatoonHitter struct {
+	PlayerID     string       `json:"player_id"`
+	Name         string       `json:"name"`
+	Bats         string       `json:"bats"`
+	VsLHP        PlatoonSplit `json:"vs_lhp"`
+	VsRHP        PlatoonSplit `json:"vs_rhp"`
+	WOBASplit    float64      `json:"woba_split"`   // vs LHP minus vs RHP
+	Contribution float64      `json:"contribution"` // PA-weighted share of the team's wOBA split
+}
+
+// StarterMatchup projects the team's offense against a specific starter
+type StarterMatchup struct {
+	PitcherID           string  `json:"pitcher_id"`
+	Name                string  `json:"name"`
+	Throws              string  `json:"throws"`
+	FIP                 float64 `json:"fip"`
+	TeamWOBAVsHand      float64 `json:"team_woba_vs_hand"`
+	ExpectedWOBA        float64 `json:"expected_woba"`
+	ExpectedRunsPerGame float64 `json:"expected_runs_per_game"`
+	BaselineRunsPerGame float64 `json:"baseline_runs_per_game"` // vs a league-average starter of that hand
+	RunDelta            float64 `json:"run_delta"`
+}
+
+// PlatoonReport describes how a team's offense shifts by opposing pitcher hand
+type PlatoonReport struct {
+	TeamID    string            `json:"team_id"`
+	TeamName  string            `json:"team_name"`
+	Season    int               `json:"season"`
+	League    LeagueEnvironment `json:"league"`
+	VsLHP     PlatoonSplit      `json:"vs_lhp"`
+	VsRHP     PlatoonSplit      `json:"vs_rhp"`
+	WOBASplit float64           `json:"woba_split"`
+	RunSplit  float64           `json:"run_split"` // runs per game vs LHP minus vs RHP
+	Drivers   []PlatoonHitter   `json:"drivers"`
+	Hitters   []PlatoonHitter   `json:"hitters"`
+	Matchup   *StarterMatchup   `json:"matchup,omitempty"`
+}
+
+// defaultLeagueEnvironment matches the sim-engine's fallback constants
+func defaultLeagueEnvironment(season int) LeagueEnvironment {
+	return LeagueEnvironment{Season: season, WOBA: 0.320, WOBAScale: 1.25, LeagueFIP: 4.20, RunsPerPA: 0.118}
+}
+
+// loadLeagueEnvironment loads the nearest season's league constants at or before season
+func (s *Server) loadLeagueEnvironment(ctx context.Context, season int) LeagueEnvironment {
+	env := defaultLeagueEnvironment(season)
+	err := s.readDB().QueryRow(ctx, `
+		SELECT season, league_woba::float8, woba_scale::float8, league_fip::float8, runs_per_pa::float8
+		FROM league_environment
+		WHERE season <= $1
+		ORDER BY season DESC
+		LIMIT 1
+	`, season).Scan(&env.Season, &env.WOBA, &env.WOBAScale, &env.LeagueFIP, &env.RunsPerPA)
+	if err != nil {
+		return defaultLeagueEnvironment(season)
+	}
+	return env
+}
+
+// runsPerGame converts a wOBA into runs per game in a league environment
+func (le LeagueEnvironment) runsPerGame(woba float64) float64 {
+	if le.WOBAScale <= 0 {
+		return 0
+	}
+	return ((woba-le.WOBA)/le.WOBAScale + le.RunsPerPA) * plateAppearancesPerGame
+}
+
+// addEvent counts n plate appearances ending in event
+func (ps *PlatoonSplit) addEvent(event string, n int) {
+	ps.PA += n
+	switch {
+	case event == "walk" || event == "intentwalk" || event == "intentionalwalk":
+		ps.BB += n
+	case event == "hitbypitch":
+		ps.hbp += n
+	case event == "sacfly" || event == "sacflydoubleplay":
+		ps.sf += n
+	case event == "sacbunt" || event == "sacbuntdoubleplay" || event == "catcherinterference":
+		ps.sh += n
+	default:
+		ps.AB += n
+		switch {
+		case event == "single":
+			ps.singles += n
+		case event == "double":
+			ps.doubles += n
+		case event == "triple":
+			ps.triples += n
+		case event == "homerun":
+			ps.HR += n
+		case strings.HasPrefix(event, "strikeout"):
+			ps.K += n
+		}
+	}
+}
+
+// merge adds another split's counts into this one
+func (ps *PlatoonSplit) merge(other PlatoonSplit) {
+	ps.PA += other.PA
+	ps.AB += other.AB
+	ps.BB += other.BB
+	ps.K += other.K
+	ps.HR += other.HR
+	ps.hbp += other.hbp
+	ps.sf += other.sf
+	ps.sh += other.sh
+	ps.singles += other.singles
+	ps.doubles += other.doubles
+	ps.triples += other.triples
+}
+
+// finalize derives rate stats from the counts
+func (ps *PlatoonSplit) finalize(league LeagueEnvironment) {
+	ps.H = ps.singles + ps.doubles + ps.triples + ps.HR
+	if ps.AB > 0 {
+		ps.AVG = roundTo(float64(ps.H)/float64(ps.AB), 3)
+		totalBases := ps.singles + 2*ps.doubles + 3*ps.triples + 4*ps.HR
+		ps.SLG = roundTo(float64(totalBases)/float64(ps.AB), 3)
+	}
+	if obpDenom := ps.AB + ps.BB + ps.hbp + ps.sf; obpDenom > 0 {
+		ps.OBP = roundTo(float64(ps.H+ps.BB+ps.hbp)/float64(obpDenom), 3)
+	}
+
+	wobaNumer := wobaWeightBB*float64(ps.BB) + wobaWeightHBP*float64(ps.hbp) +
+		wobaWeight1B*float64(ps.singles) + wobaWeight2B*float64(ps.doubles) +
+		wobaWeight3B*float64(ps.triples) + wobaWeightHR*float64(ps.HR)
+	ps.wobaDenom = ps.AB + ps.BB + ps.sf + ps.hbp
+	if ps.wobaDenom > 0 {
+		ps.WOBA = roundTo(wobaNumer/float64(ps.wobaDenom), 3)
+		ps.RunsPerGame = roundTo(league.runsPerGame(ps.WOBA), 2)
+	}
+}
+
+// buildPlatoonReport finalizes hitter splits, team totals and split drivers
+func buildPlatoonReport(hitters map[string]*PlatoonHitter, league LeagueEnvironment) PlatoonReport {
+	report := PlatoonReport{League: league, Drivers: []PlatoonHitter{}, Hitters: []PlatoonHitter{}}
+
+	for _, hitter := range hitters {
+		report.VsLHP.merge(hitter.VsLHP)
+		report.VsRHP.merge(hitter.VsRHP)
+	}
+	report.VsLHP.finalize(league)
+	report.VsRHP.finalize(league)
+	report.WOBASplit = roundTo(report.VsLHP.WOBA-report.VsRHP.WOBA, 3)
+	report.RunSplit = roundTo(report.VsLHP.RunsPerGame-report.VsRHP.RunsPerGame, 2)
+
+	teamPA := report.VsLHP.PA + report.VsRHP.PA
+	for _, hitter := range hitters {
+		hitter.VsLHP.finalize(league)
+		hitter.VsRHP.finalize(league)
+		if hitter.VsLHP.PA >= minPlatoonPA && hitter.VsRHP.PA >= minPlatoonPA {
+			hitter.WOBASplit = roundTo(hitter.VsLHP.WOBA-hitter.VsRHP.WOBA, 3)
+			if teamPA > 0 {
+				share := float64(hitter.VsLHP.PA+hitter.VsRHP.PA) / float64(teamPA)
+				hitter.Contribution = roundTo(hitter.WOBASplit*share, 4)
+			}
+		}
+		report.Hitters = append(report.Hitters, *hitter)
+	}
+
+	sort.Slice(report.Hitters, func(i, j int) bool {
+		pi := report.Hitters[i].VsLHP.PA + report.Hitters[i].VsRHP.PA
+		pj := report.Hitters[j].VsLHP.PA + report.Hitters[j].VsRHP.PA
+		if pi != pj {
+			return pi > pj
+		}
+		return report.Hitters[i].Name < report.Hitters[j].Name
+	})
+
+	// Drivers push the team in the same direction as its overall split
+	direction := 1.0
+	if report.WOBASplit < 0 {
+		direction = -1.0
+	}
+	for _, hitter := range report.Hitters {
+		if hitter.Contribution*direction > 0 {
+			report.Drivers = append(report.Drivers, hitter)
+		}
+	}
+	sort.SliceStable(report.Drivers, func(i, j int) bool {
+		return math.Abs(report.Drivers[i].Contribution) > math.Abs(report.Drivers[j].Contribution)
+	})
+	if len(report.Drivers) > platoonDrivers {
+		report.Drivers = report.Drivers[:platoonDrivers]
+	}
+
+	return report
+}
+
+// projectStarterMatchup applies the sim-engine's matchup math (average of
+// batter wOBA and the pitcher's FIP-implied wOBA allowed) to the team's
+// split against the starter's hand
+func projectStarterMatchup(matchup *StarterMatchup, split PlatoonSplit, league LeagueEnvironment) {
+	teamWOBA := league.WOBA
+	if split.wobaDenom > 0 {
+		teamWOBA = split.WOBA
+	}
+
+	pitcherWOBA := math.Max(0.200, math.Min(0.500, league.WOBA+(matchup.FIP-league.LeagueFIP)*0.03))
+	expected := (teamWOBA + pitcherWOBA) / 2
+	baseline := (teamWOBA + league.WOBA) / 2
+
+	matchup.TeamWOBAVsHand = roundTo(teamWOBA, 3)
+	matchup.ExpectedWOBA = roundTo(expected, 3)
+	matchup.ExpectedRunsPerGame = roundTo(league.runsPerGame(expected), 2)
+	matchup.BaselineRunsPerGame = roundTo(league.runsPerGame(baseline), 2)
+	matchup.RunDelta = roundTo(matchup.ExpectedRunsPerGame-matchup.BaselineRunsPerGame, 2)
+}
+
+// getTeamPlatoonReportHandler reports how a team's offense shifts vs LHP and
+// RHP, which hitters drive the split and, with ?pitcher=, how a probable
+// starter changes the team's expected runs
+func (s *Server) getTeamPlatoonReportHandler(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+	if teamID == "" {
+		writeError(w, "Team ID is required", http.StatusBadRequest)
+		return
+	}
+
+	season := getCurrentSeason()
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		parsed, err := strconv.Atoi(seasonStr)
+		if err != nil {
+			writeError(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		season = parsed
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, teamID)
+	if !ok {
+		return
+	}
+	teamUUID := resolved.ID
+
+	var teamName string
+	err := s.readDB().QueryRow(ctx, `SELECT name FROM teams WHERE id = $1`, teamUUID).Scan(&teamName)
+	if err != nil {
+		log.Printf("Team query error: %v", err)
+		writeError(w, "Failed to query team", http.StatusInternalServerError)
+		return
+	}
+
+	rows, err := s.readDB().Query(ctx, `
+		SELECT b.id::text, b.full_name, COALESCE(b.bats, ''),
+		       UPPER(LEFT(COALESCE(p.throws, ''), 1)), COALESCE(gp.event_type, ''), COUNT(*)
+		FROM game_plays gp
+		JOIN games g ON gp.game_id = g.id
+		JOIN players b ON gp.batter_id = b.id
+		JOIN players p ON gp.pitcher_id = p.id
+		WHERE g.season = $2
+		  AND ((LOWER(gp.inning_half) = 'top' AND g.away_team_id = $1)
+		    OR (LOWER(gp.inning_half) = 'bottom' AND g.home_team_id = $1))
+		GROUP BY 1, 2, 3, 4, 5
+	`, teamUUID, season)
+	if err != nil {
+		log.Printf("Platoon report query error: %v", err)
+		writeError(w, "Failed to query platoon splits", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	hitters := make(map[string]*PlatoonHitter)
+	for rows.Next() {
+		var playerID, name, bats, hand, event string
+		var count int
+		if err := rows.Scan(&playerID, &name, &bats, &hand, &event, &count); err != nil {
+			log.Printf("Error scanning platoon split: %v", err)
+			continue
+		}
+
+		hitter, ok := hitters[playerID]
+		if !ok {
+			hitter = &PlatoonHitter{PlayerID: playerID, Name: name, Bats: bats}
+			hitters[playerID] = hitter
+		}
+
+		event = normalizeEventType(event)
+		switch hand {
+		case "L":
+			hitter.VsLHP.addEvent(event, count)
+		case "R":
+			hitter.VsRHP.addEvent(event, count)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Platoon report rows error: %v", err)
+		writeError(w, "Failed to query platoon splits", http.StatusInternalServerError)
+		return
+	}
+
+	league := s.loadLeagueEnvironment(ctx, season)
+	report := buildPlatoonReport(hitters, league)
+	report.TeamID = teamUUID
+	report.TeamName = teamName
+	report.Season = season
+
+	if pitcherID := r.URL.Query().Get("pitcher"); pitcherID != "" {
+		pitcher, ok := s.resolveEntity(ctx, w, ids.Player, pitcherID)
+		if !ok {
+			return
+		}
+
+		matchup := &StarterMatchup{FIP: league.LeagueFIP}
+		var statsJSON []byte
+		err := s.readDB().QueryRow(ctx, `
+			SELECT p.id::text, p.full_name, UPPER(LEFT(COALESCE(p.throws, 'R'), 1)), psa.aggregated_stats
+			FROM players p
+			LEFT JOIN player_season_aggregates psa
+			  ON psa.player_id = p.id AND psa.season = $2 AND psa.stats_type = 'pitching'
+			WHERE p.id = $1
+		`, pitcher.ID, season).Scan(&matchup.PitcherID, &matchup.Name, &matchup.Throws, &statsJSON)
+		if err != nil {
+			log.Printf("Pitcher query error: %v", err)
+			writeError(w, "Failed to query pitcher", http.StatusInternalServerError)
+			return
+		}
+
+		var stats map[string]interface{}
+		if len(statsJSON) > 0 && json.Unmarshal(statsJSON, &stats) == nil {
+			if fip, ok := toFloat(stats["FIP"]); ok {
+				matchup.FIP = fip
+			}
+		}
+
+		split := report.VsRHP
+		if matchup.Throws == "L" {
+			split = report.VsLHP
+		}
+		projectStarterMatchup(matchup, split, league)
+		report.Matchup = matchup
+	}
+
+	writeJSON(w, report)
+}
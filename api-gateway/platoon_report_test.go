@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPlatoonSplitFinalize tests rate stats derived from play events
+func TestPlatoonSplitFinalize(t *testing.T) {
+	var split PlatoonSplit
+	split.addEvent("single", 3)
+	split.addEvent("homerun", 1)
+	split.addEvent("walk", 1)
+	split.addEvent("strikeout", 4)
+	split.addEvent("sacfly", 1)
+	split.finalize(defaultLeagueEnvironment(2024))
+
+	assert.Equal(t, 10, split.PA)
+	assert.Equal(t, 8, split.AB)
+	assert.Equal(t, 4, split.H)
+	assert.Equal(t, 4, split.K)
+	assert.Equal(t, 0.5, split.AVG)
+	assert.Equal(t, 0.875, split.SLG)
+	assert.Equal(t, 0.5, split.OBP)
+	// (0.707 + 3*0.902 + 2.07) / 10
+	assert.InDelta(t, 0.548, split.WOBA, 0.001)
+}
+
+// TestBuildPlatoonReport tests team totals and split drivers
+func TestBuildPlatoonReport(t *testing.T) {
+	masher := &PlatoonHitter{PlayerID: "1", Name: "Lefty Masher"}
+	masher.VsLHP.addEvent("homerun", 10)
+	masher.VsLHP.addEvent("out", 20)
+	masher.VsRHP.addEvent("single", 5)
+	masher.VsRHP.addEvent("out", 25)
+
+	steady := &PlatoonHitter{PlayerID: "2", Name: "Steady"}
+	steady.VsLHP.addEvent("single", 8)
+	steady.VsLHP.addEvent("out", 22)
+	steady.VsRHP.addEvent("single", 8)
+	steady.VsRHP.addEvent("out", 22)
+
+	bench := &PlatoonHitter{PlayerID: "3", Name: "Bench"}
+	bench.VsLHP.addEvent("single", 2)
+
+	report := buildPlatoonReport(map[string]*PlatoonHitter{"1": masher, "2": steady, "3": bench}, defaultLeagueEnvironment(2024))
+
+	assert.Equal(t, 62, report.VsLHP.PA)
+	assert.Equal(t, 60, report.VsRHP.PA)
+	assert.Greater(t, report.WOBASplit, 0.0)
+	assert.Greater(t, report.RunSplit, 0.0)
+	assert.Len(t, report.Hitters, 3)
+
+	// Only the masher pushes the team toward its split vs LHP
+	assert.Len(t, report.Drivers, 1)
+	assert.Equal(t, "1", report.Drivers[0].PlayerID)
+}
+
+// TestProjectStarterMatchup tests the effect of a starter's FIP on expected runs
+func TestProjectStarterMatchup(t *testing.T) {
+	league := defaultLeagueEnvironment(2024)
+	split := PlatoonSplit{}
+	split.addEvent("single", 30)
+	split.addEvent("out", 70)
+	split.finalize(league)
+
+	ace := &StarterMatchup{FIP: 2.80}
+	projectStarterMatchup(ace, split, league)
+	assert.Less(t, ace.RunDelta, 0.0)
+
+	average := &StarterMatchup{FIP: league.LeagueFIP}
+	projectStarterMatchup(average, split, league)
+	assert.InDelta(t, 0.0, average.RunDelta, 0.001)
+	assert.InDelta(t, average.BaselineRunsPerGame, average.ExpectedRunsPerGame, 0.001)
+}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// precomputeJobTimeout bounds one run of a precompute job
+const precomputeJobTimeout = 2 * time.Minute
+
+// precomputeRequestKey marks the scheduler's own requests, which must reach
+// the handlers rather than be answered from the precomputed copies
+type precomputeRequestKey struct{}
+
+// isPrecomputeRequest reports whether r was made by the precompute scheduler
+func isPrecomputeRequest(r *http.Request) bool {
+	marked, _ := r.Context().Value(precomputeRequestKey{}).(bool)
+	return marked
+}
+
+// precomputeJobDefinition is a built-in job: the responses it refreshes and
+// how often
+type precomputeJobDefinition struct {
+	Name     string
+	Schedule string        // default cron schedule, in the gateway's local time
+	TTL      time.Duration // how long a refreshed response is served
+	Paths    func(now time.Time) []string
+}
+
+// precomputeJobDefinitions are the popular, expensive responses kept warm
+var precomputeJobDefinitions = []precomputeJobDefinition{
+	{
+		Name:     "standings",
+		Schedule: "*/10 * * * *",
+		TTL:      15 * time.Minute,
+		Paths: func(time.Time) []string {
+			return []string{"/api/v1/standings"}
+		},
+	},
+	{
+		Name:     "scoreboard",
+		Schedule: "*/2 * * * *",
+		TTL:      5 * time.Minute,
+		Paths: func(now time.Time) []string {
+			return []string{"/api/v1/games/date/" + now.Format("2006-01-02")}
+		},
+	},
+	{
+		Name:     "leaders",
+		Schedule: "0 * * * *",
+		TTL:      90 * time.Minute,
+		Paths: func(time.Time) []string {
+			return []string{"/api/v1/leaders", "/api/v1/leaders?group=pitching"}
+		},
+	},
+}
+
+// parsePrecomputeSchedules reads "name=cron;name=cron" overrides of the
+// built-in schedules; "off" disables a job
+func parsePrecomputeSchedules(overrides string) (map[string]string, error) {
+	known := make(map[string]bool)
+	for _, def := range precomputeJobDefinitions {
+		known[def.Name] = true
+	}
+
+	schedules := make(map[string]string)
+	for _, entry := range strings.Split(overrides, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, spec, ok := strings.Cut(entry, "=")
+		name, spec = strings.TrimSpace(name), strings.TrimSpace(spec)
+		if !ok || spec == "" {
+			return nil, fmt.Errorf("invalid precompute schedule %q (expected name=cron)", entry)
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("unknown precompute job %q", name)
+		}
+		schedules[name] = spec
+	}
+	return schedules, nil
+}
+
+// PrecomputeJobStatus reports a job's schedule and its last run
+type PrecomputeJobStatus struct {
+	Name           string     `json:"name"`
+	Schedule       string     `json:"schedule"`
+	TTLSeconds     int        `json:"ttl_seconds"`
+	Paths          []string   `json:"paths"`
+	Running        bool       `json:"running"`
+	NextRun        *time.Time `json:"next_run,omitempty"`
+	LastRun        *time.Time `json:"last_run,omitempty"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	LastError      string     `json:"last_error,omitempty"`
+	Runs           int64      `json:"runs"`
+	Failures       int64      `json:"failures"`
+}
+
+// precomputeJob is a scheduled job and its state
+type precomputeJob struct {
+	precomputeJobDefinition
+	schedule cronSchedule
+
+	mu     sync.Mutex
+	status PrecomputeJobStatus
+}
+
+// precomputedResponse is a stored copy of a job's response
+type precomputedResponse struct {
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+	ttl      time.Duration
+}
+
+// PrecomputeScheduler refreshes popular GET responses on cron schedules by
+// replaying them through the router, and keeps the copies so they can be
+// served without touching the database
+type PrecomputeScheduler struct {
+	jobs    []*precomputeJob
+	handler http.Handler
+	ready   func() bool // false skips runs, e.g. while the database is down
+
+	responses map[string]*precomputedResponse
+	mu        sync.RWMutex
+}
+
+// NewPrecomputeScheduler builds the built-in jobs with any schedule overrides
+func NewPrecomputeScheduler(overrides string) (*PrecomputeScheduler, error) {
+	schedules, err := parsePrecomputeSchedules(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := &PrecomputeScheduler{responses: make(map[string]*precomputedResponse)}
+	for _, def := range precomputeJobDefinitions {
+		spec := def.Schedule
+		if override, ok := schedules[def.Name]; ok {
+			spec = override
+		}
+		if spec == "off" {
+			continue
+		}
+		schedule, err := parseCronSchedule(spec)
+		if err != nil {
+			return nil, fmt.Errorf("precompute job %s: %w", def.Name, err)
+		}
+		job := &precomputeJob{precomputeJobDefinition: def, schedule: schedule}
+		job.Schedule = spec
+		job.status = PrecomputeJobStatus{Name: def.Name, Schedule: spec, TTLSeconds: int(def.TTL.Seconds())}
+		ps.jobs = append(ps.jobs, job)
+	}
+	return ps, nil
+}
+
+// job returns the named job, or nil
+func (ps *PrecomputeScheduler) job(name string) *precomputeJob {
+	for _, job := range ps.jobs {
+		if job.Name == name {
+			return job
+		}
+	}
+	return nil
+}
+
+// Statuses reports every job, in definition order
+func (ps *PrecomputeScheduler) Statuses(now time.Time) []PrecomputeJobStatus {
+	statuses := make([]PrecomputeJobStatus, 0, len(ps.jobs))
+	for _, job := range ps.jobs {
+		job.mu.Lock()
+		status := job.status
+		job.mu.Unlock()
+		if next := job.schedule.Next(now); !next.IsZero() {
+			status.NextRun = &next
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Run runs each job at its scheduled times until ctx is cancelled. Jobs due
+// at the same minute run one after another.
+func (ps *PrecomputeScheduler) Run(ctx context.Context) {
+	for {
+		now := time.Now()
+		var next time.Time
+		for _, job := range ps.jobs {
+			if at := job.schedule.Next(now); !at.IsZero() && (next.IsZero() || at.Before(next)) {
+				next = at
+			}
+		}
+		if next.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		for _, job := range ps.jobs {
+			if job.schedule.Next(next.Add(-time.Minute)).Equal(next) {
+				if err := ps.RunJob(ctx, job.Name); err != nil {
+					log.Printf("Precompute job %s failed: %v", job.Name, err)
+				}
+			}
+		}
+	}
+}
+
+// errPrecomputeJobRunning is returned when a job is started while it runs
+var errPrecomputeJobRunning = errors.New("precompute job is already running")
+
+// RunJob refreshes every response of the named job now
+func (ps *PrecomputeScheduler) RunJob(ctx context.Context, name string) error {
+	job := ps.job(name)
+	if job == nil {
+		return fmt.Errorf("unknown precompute job %q", name)
+	}
+	if !job.claim() {
+		return errPrecomputeJobRunning
+	}
+	return ps.runClaimed(ctx, job)
+}
+
+// StartJob claims the named job and runs it in the background, so a job
+// already running is reported to the caller rather than skipped later
+func (ps *PrecomputeScheduler) StartJob(name string) error {
+	job := ps.job(name)
+	if job == nil {
+		return fmt.Errorf("unknown precompute job %q", name)
+	}
+	if !job.claim() {
+		return errPrecomputeJobRunning
+	}
+	go func() {
+		if err := ps.runClaimed(context.Background(), job); err != nil {
+			log.Printf("Precompute job %s failed: %v", name, err)
+		}
+	}()
+	return nil
+}
+
+// claim marks the job running, reporting false if it already was
+func (job *precomputeJob) claim() bool {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if job.status.Running {
+		return false
+	}
+	job.status.Running = true
+	return true
+}
+
+// runClaimed runs a job claimed by the caller and releases it when done
+func (ps *PrecomputeScheduler) runClaimed(ctx context.Context, job *precomputeJob) error {
+	if ps.ready != nil && !ps.ready() {
+		appMetrics.ObservePrecompute(job.Name, "skipped", 0)
+		job.mu.Lock()
+		job.status.Running = false
+		job.mu.Unlock()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithValue(ctx, precomputeRequestKey{}, true), precomputeJobTimeout)
+	defer cancel()
+
+	start := time.Now()
+	paths := job.Paths(start)
+	var failures []string
+	for _, path := range paths {
+		if err := ps.refresh(ctx, path, job.TTL); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	duration := time.Since(start)
+
+	result := "success"
+	if len(failures) > 0 {
+		result = "failure"
+	}
+	appMetrics.ObservePrecompute(job.Name, result, duration)
+
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	job.status.Running = false
+	job.status.Paths = paths
+	job.status.LastRun = &start
+	job.status.LastDurationMs = duration.Milliseconds()
+	job.status.LastError = strings.Join(failures, "; ")
+	job.status.Runs++
+	if len(failures) > 0 {
+		job.status.Failures++
+		return fmt.Errorf("%s", job.status.LastError)
+	}
+	return nil
+}
+
+// refresh replays a GET through the router and stores a successful response
+func (ps *PrecomputeScheduler) refresh(ctx context.Context, path string, ttl time.Duration) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	req.RemoteAddr = "127.0.0.1:0"
+
+	bw := newBufferedResponseWriter()
+	ps.handler.ServeHTTP(bw, req)
+	if bw.statusCode != http.StatusOK {
+		return fmt.Errorf("%s: status %d", path, bw.statusCode)
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.responses[precomputeKey(req)] = &precomputedResponse{
+		header:   bw.header.Clone(),
+		body:     append([]byte(nil), bw.body.Bytes()...),
+		storedAt: time.Now(),
+		ttl:      ttl,
+	}
+	return nil
+}
+
+// Get returns the stored response for key; ok is false once it has expired.
+// A nil response means the key isn't precomputed.
+func (ps *PrecomputeScheduler) Get(key string) (entry *precomputedResponse, ok bool) {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	entry = ps.responses[key]
+	if entry == nil {
+		return nil, false
+	}
+	return entry, time.Since(entry.storedAt) <= entry.ttl
+}
+
+// precomputeKey identifies a response by path and sorted query, ignoring
+// ?case=, which the JSON case middleware applies around the stored copy
+func precomputeKey(r *http.Request) string {
+	query := r.URL.Query()
+	query.Del("case")
+	if len(query) == 0 {
+		return r.URL.Path
+	}
+	return r.URL.Path + "?" + query.Encode()
+}
+
+// precomputedMiddleware answers GETs from a fresh precomputed copy when one
+// exists. It sits innermost so the stale cache and JSON case conversion
+// still apply around it.
+func (s *Server) precomputedMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.precompute == nil || r.Method != http.MethodGet || isPrecomputeRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		entry, ok := s.precompute.Get(precomputeKey(r))
+		if entry != nil {
+			appMetrics.RecordCache("precomputed", ok)
+		}
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		for key, values := range entry.header {
+			w.Header()[key] = values
+		}
+		w.Header().Set("Age", strconv.Itoa(int(time.Since(entry.storedAt).Seconds())))
+		w.Header().Set("X-Cache-Status", "PRECOMPUTED")
+		w.WriteHeader(http.StatusOK)
+		w.Write(entry.body)
+	})
+}
+
+// getPrecomputeJobsHandler lists the precompute jobs with their schedules,
+// next and last runs
+func (s *Server) getPrecomputeJobsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	jobs := []PrecomputeJobStatus{}
+	if s.precompute != nil {
+		jobs = s.precompute.Statuses(time.Now())
+	}
+	writeJSON(w, map[string]interface{}{"jobs": jobs})
+}
+
+// runPrecomputeJobHandler starts a precompute job now in the background and
+// returns 202; the job's status shows when it finishes
+func (s *Server) runPrecomputeJobHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	name := mux.Vars(r)["job"]
+	if s.precompute == nil || s.precompute.job(name) == nil {
+		writeError(w, fmt.Sprintf("Unknown precompute job %q", name), http.StatusNotFound)
+		return
+	}
+
+	if err := s.precompute.StartJob(name); err != nil {
+		writeError(w, "Precompute job is already running", http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, map[string]interface{}{"job": name, "status": "started"})
+}
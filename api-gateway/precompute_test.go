@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrecomputeSchedules(t *testing.T) {
+	schedules, err := parsePrecomputeSchedules("")
+	require.NoError(t, err)
+	assert.Empty(t, schedules)
+
+	schedules, err = parsePrecomputeSchedules(" standings=*/5 * * * * ; leaders=off;")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"standings": "*/5 * * * *", "leaders": "off"}, schedules)
+
+	_, err = parsePrecomputeSchedules("boxscores=* * * * *")
+	assert.Error(t, err, "unknown job")
+	_, err = parsePrecomputeSchedules("standings")
+	assert.Error(t, err, "missing schedule")
+}
+
+func TestNewPrecomputeScheduler(t *testing.T) {
+	ps, err := NewPrecomputeScheduler("leaders=off;standings=0 6 * * *")
+	require.NoError(t, err)
+	assert.Nil(t, ps.job("leaders"), "disabled jobs are dropped")
+	require.NotNil(t, ps.job("standings"))
+	assert.Equal(t, "0 6 * * *", ps.job("standings").Schedule)
+	require.NotNil(t, ps.job("scoreboard"))
+
+	_, err = NewPrecomputeScheduler("standings=61 * * * *")
+	assert.Error(t, err)
+}
+
+func TestPrecomputeRunJobAndServe(t *testing.T) {
+	ps, err := NewPrecomputeScheduler("")
+	require.NoError(t, err)
+
+	calls := 0
+	ps.handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.True(t, isPrecomputeRequest(r))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Write([]byte(`{"standings":[]}`))
+	})
+
+	require.NoError(t, ps.RunJob(context.Background(), "standings"))
+	assert.Equal(t, 1, calls)
+
+	statuses := ps.Statuses(time.Now())
+	require.Equal(t, "standings", statuses[0].Name)
+	assert.Equal(t, int64(1), statuses[0].Runs)
+	assert.Equal(t, []string{"/api/v1/standings"}, statuses[0].Paths)
+	assert.NotNil(t, statuses[0].LastRun)
+	assert.NotNil(t, statuses[0].NextRun)
+
+	s := &Server{precompute: ps}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"live":true}`))
+	})
+	handler := s.precomputedMiddleware(next)
+
+	// ?case= is applied around the stored copy, so it still matches
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/standings?case=camel", nil))
+	assert.Equal(t, `{"standings":[]}`, rec.Body.String())
+	assert.Equal(t, "PRECOMPUTED", rec.Header().Get("X-Cache-Status"))
+	assert.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+
+	// Other queries and expired copies go to the handler
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/standings?season=2023", nil))
+	assert.Equal(t, `{"live":true}`, rec.Body.String())
+
+	ps.responses["/api/v1/standings"].storedAt = time.Now().Add(-time.Hour)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/standings", nil))
+	assert.Equal(t, `{"live":true}`, rec.Body.String())
+}
+
+func TestPrecomputeRunJobFailures(t *testing.T) {
+	ps, err := NewPrecomputeScheduler("")
+	require.NoError(t, err)
+	ps.handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, "Failed to query leaders", http.StatusInternalServerError)
+	})
+
+	assert.Error(t, ps.RunJob(context.Background(), "leaders"))
+	status := ps.Statuses(time.Now())[2]
+	assert.Equal(t, int64(1), status.Failures)
+	assert.Contains(t, status.LastError, "status 500")
+	assert.Empty(t, ps.responses, "failed responses are not stored")
+
+	assert.Error(t, ps.RunJob(context.Background(), "missing"))
+
+	// Runs are skipped while the database is unavailable
+	ps.ready = func() bool { return false }
+	assert.NoError(t, ps.RunJob(context.Background(), "leaders"))
+	assert.Equal(t, int64(1), ps.Statuses(time.Now())[2].Runs)
+}
+
+func TestPrecomputeStartJobClaimsOnce(t *testing.T) {
+	ps, err := NewPrecomputeScheduler("")
+	require.NoError(t, err)
+	release := make(chan struct{})
+	ps.handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"standings":[]}`))
+	})
+
+	// The second start sees the first's claim before its goroutine runs
+	require.NoError(t, ps.StartJob("standings"))
+	assert.ErrorIs(t, ps.StartJob("standings"), errPrecomputeJobRunning)
+	assert.ErrorIs(t, ps.RunJob(context.Background(), "standings"), errPrecomputeJobRunning)
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		return !ps.Statuses(time.Now())[0].Running
+	}, time.Second, 10*time.Millisecond)
+	assert.Error(t, ps.StartJob("missing"))
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Synthetic opponents teams are rated against by the sim-engine
+const (
+	opponentLeagueAverage = "league_average"
+	opponentReplacement   = "replacement"
+)
+
+// parseRankingOpponent reads ?opponent=, defaulting to league average
+func parseRankingOpponent(raw string) (string, error) {
+	switch opponent := strings.ToLower(raw); opponent {
+	case "":
+		return opponentLeagueAverage, nil
+	case opponentLeagueAverage, opponentReplacement:
+		return opponent, nil
+	}
+	return "", fmt.Errorf("invalid opponent, supported: %s, %s", opponentLeagueAverage, opponentReplacement)
+}
+
+// PowerRanking is a team's context-free strength: its simulated record
+// against a synthetic opponent in a neutral park, half the games at home
+type PowerRanking struct {
+	Rank               int       `json:"rank"`
+	TeamID             string    `json:"team_id"`
+	TeamName           string    `json:"team_name"`
+	Abbreviation       string    `json:"abbreviation"`
+	Games              int       `json:"games"`
+	Wins               int       `json:"wins"`
+	WinPct             float64   `json:"win_pct"`
+	RunsScoredPerGame  float64   `json:"runs_scored_per_game"`
+	RunsAllowedPerGame float64   `json:"runs_allowed_per_game"`
+	RunDiffPerGame     float64   `json:"run_diff_per_game"`
+	ModelParamHash     *string   `json:"model_param_hash"`
+	ComputedAt         time.Time `json:"computed_at"`
+}
+
+// rankPowerRankings numbers rankings already sorted by win_pct, giving tied
+// teams the same rank
+func rankPowerRankings(rankings []PowerRanking) {
+	for i := range rankings {
+		rankings[i].RunDiffPerGame = roundTo(rankings[i].RunsScoredPerGame-rankings[i].RunsAllowedPerGame, 2)
+		if i > 0 && rankings[i].WinPct == rankings[i-1].WinPct {
+			rankings[i].Rank = rankings[i-1].Rank
+		} else {
+			rankings[i].Rank = i + 1
+		}
+	}
+}
+
+// getRankingsHandler lists power rankings for a ?season= (default current)
+// against an ?opponent= of league_average (default) or replacement, from the
+// sim-engine's last team ratings
+func (s *Server) getRankingsHandler(w http.ResponseWriter, r *http.Request) {
+	season := getCurrentSeason()
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		parsed, err := strconv.Atoi(seasonStr)
+		if err != nil || parsed < firstMLBSeason {
+			writeError(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		season = parsed
+	}
+	opponent, err := parseRankingOpponent(r.URL.Query().Get("opponent"))
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cacheKey := fmt.Sprintf("rankings_%d_%s", season, opponent)
+	if cached, found := s.queryCache.Get(cacheKey); found {
+		writeJSON(w, cached)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	rows, err := s.readDB().Query(ctx, `
+		SELECT t.id::text, t.name, t.abbreviation, tr.games, tr.wins, tr.win_pct,
+		       tr.runs_scored_per_game, tr.runs_allowed_per_game, tr.model_param_hash, tr.computed_at
+		FROM team_ratings tr
+		JOIN teams t ON t.id = tr.team_id
+		WHERE tr.season = $1 AND tr.opponent = $2
+		ORDER BY tr.win_pct DESC, tr.runs_scored_per_game - tr.runs_allowed_per_game DESC, t.name`,
+		season, opponent)
+	if err != nil {
+		log.Printf("Rankings query error: %v", err)
+		writeError(w, "Failed to query rankings", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	rankings := []PowerRanking{}
+	for rows.Next() {
+		var ranking PowerRanking
+		if err := rows.Scan(&ranking.TeamID, &ranking.TeamName, &ranking.Abbreviation, &ranking.Games,
+			&ranking.Wins, &ranking.WinPct, &ranking.RunsScoredPerGame, &ranking.RunsAllowedPerGame,
+			&ranking.ModelParamHash, &ranking.ComputedAt); err != nil {
+			log.Printf("Error scanning ranking: %v", err)
+			continue
+		}
+		rankings = append(rankings, ranking)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Rankings query error: %v", err)
+		writeError(w, "Failed to query rankings", http.StatusInternalServerError)
+		return
+	}
+	rankPowerRankings(rankings)
+
+	response := map[string]interface{}{
+		"season":   season,
+		"opponent": opponent,
+		"rankings": rankings,
+	}
+	s.queryCache.Set(cacheKey, response, 5*time.Minute)
+	writeJSON(w, response)
+}
+
+// RankingsRefreshRequest asks the sim-engine to re-rate teams. Omitted
+// fields take the engine's defaults: the current season, league_average,
+// 200 games per team and every team.
+type RankingsRefreshRequest struct {
+	Season   int      `json:"season,omitempty"`
+	Opponent string   `json:"opponent,omitempty"`
+	Games    int      `json:"games,omitempty"`
+	TeamIDs  []string `json:"team_ids,omitempty"`
+}
+
+// refreshRankingsHandler starts a sim-engine ratings job. It runs in the
+// background; GET /rankings shows the new ratings once computed_at moves.
+func (s *Server) refreshRankingsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	var req RankingsRefreshRequest
+	if !s.decodeJSONBody(w, r, &req, true) {
+		return
+	}
+	if req.Opponent != "" {
+		if _, err := parseRankingOpponent(req.Opponent); err != nil {
+			writeError(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	body, _ := json.Marshal(req)
+	resp, err := s.simEngineClient.Post(r.Context(), s.simEngineURL()+"/ratings", "application/json", bytes.NewReader(body))
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	// Cached rankings would keep serving the old ratings
+	s.queryCache.Clear()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, result)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRankingOpponent(t *testing.T) {
+	opponent, err := parseRankingOpponent("")
+	assert.NoError(t, err)
+	assert.Equal(t, "league_average", opponent)
+
+	opponent, err = parseRankingOpponent("Replacement")
+	assert.NoError(t, err)
+	assert.Equal(t, "replacement", opponent)
+
+	_, err = parseRankingOpponent("yankees")
+	assert.Error(t, err)
+}
+
+func TestRankPowerRankings(t *testing.T) {
+	rankings := []PowerRanking{
+		{TeamID: "lad", WinPct: 0.62, RunsScoredPerGame: 5.4, RunsAllowedPerGame: 3.9},
+		{TeamID: "nyy", WinPct: 0.58, RunsScoredPerGame: 5.1, RunsAllowedPerGame: 4.2},
+		{TeamID: "bos", WinPct: 0.58, RunsScoredPerGame: 4.9, RunsAllowedPerGame: 4.3},
+		{TeamID: "oak", WinPct: 0.41, RunsScoredPerGame: 3.8, RunsAllowedPerGame: 4.9},
+	}
+	rankPowerRankings(rankings)
+
+	var ranks []int
+	for _, ranking := range rankings {
+		ranks = append(ranks, ranking.Rank)
+	}
+	assert.Equal(t, []int{1, 2, 2, 4}, ranks, "tied teams share a rank")
+	assert.Equal(t, 1.5, rankings[0].RunDiffPerGame)
+	assert.Equal(t, -1.1, rankings[3].RunDiffPerGame)
+}
+
+// TestRefreshRankingsClearsCache tests that starting a ratings job drops
+// cached rankings
+func TestRefreshRankingsClearsCache(t *testing.T) {
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "started"}`))
+	}))
+	defer engine.Close()
+
+	keys, err := ParseAPIKeys("admin-key:internal", "free")
+	assert.NoError(t, err)
+	s := &Server{
+		config:          &Config{SimEngineURL: engine.URL},
+		apiKeys:         keys,
+		queryCache:      NewQueryCache(),
+		simEngineClient: NewUpstreamClient("sim-engine", 2),
+	}
+	s.queryCache.Set("rankings_2024_league_average", []PowerRanking{}, time.Minute)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/rankings", strings.NewReader(`{"season": 2024}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(apiKeyHeader, "admin-key")
+	rec := httptest.NewRecorder()
+	s.refreshRankingsHandler(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	_, found := s.queryCache.Get("rankings_2024_league_average")
+	assert.False(t, found)
+}
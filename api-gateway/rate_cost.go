@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// defaultRouteCost is the rate limiter tokens a request spends unless its
+// route was registered with withRateCost
+const defaultRouteCost = 1
+
+// Route costs for endpoints that start work in the sim-engine or
+// data-fetcher rather than reading the database
+const (
+	simulationRouteCost  = 20
+	batchRouteCost       = 50
+	sensitivityRouteCost = 10
+	exportRouteCost      = 10
+	refreshRouteCost     = 20
+)
+
+// costedHandler is a route handler that spends more than one rate limiter
+// token per request
+type costedHandler struct {
+	cost int
+	next http.HandlerFunc
+}
+
+func (h costedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.next(w, r)
+}
+
+// withRateCost sets the tokens a request to the route spends from the
+// caller's rate limiter bucket. Register it alongside the route so each
+// endpoint's cost is visible in setupRoutes; the cost must not exceed the
+// limiter's burst or the route can never be called.
+func withRateCost(cost int, next http.HandlerFunc) http.Handler {
+	return costedHandler{cost: cost, next: next}
+}
+
+// routeCost returns the tokens registered for the request's matched route
+func routeCost(r *http.Request) int {
+	if route := mux.CurrentRoute(r); route != nil {
+		if h, ok := route.GetHandler().(costedHandler); ok {
+			return h.cost
+		}
+	}
+	return defaultRouteCost
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterAllowN(t *testing.T) {
+	rl := NewRateLimiter(5, 25)
+
+	assert.True(t, rl.AllowN("client", 20))
+	assert.False(t, rl.AllowN("client", 20), "a partial bucket isn't spent")
+	for i := 0; i < 5; i++ {
+		assert.True(t, rl.AllowN("client", 1), "Request %d should be allowed", i+1)
+	}
+	assert.False(t, rl.Allow("client"))
+}
+
+func TestRateLimitMiddlewareRouteCost(t *testing.T) {
+	s := &Server{router: mux.NewRouter(), rateLimiter: NewRateLimiter(5, 25)}
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	api := s.router.PathPrefix("/api/v1").Subrouter()
+	api.HandleFunc("/teams", ok).Methods("GET")
+	api.Handle("/simulations", withRateCost(simulationRouteCost, ok)).Methods("POST")
+	s.router.Use(s.rateLimitMiddleware)
+
+	call := func(method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "203.0.113.7:5000"
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, call(http.MethodPost, "/api/v1/simulations"))
+	assert.Equal(t, http.StatusTooManyRequests, call(http.MethodPost, "/api/v1/simulations"))
+
+	// The cheap route still has the five tokens the simulation left
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, call(http.MethodGet, "/api/v1/teams"), "Request %d should be allowed", i+1)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, call(http.MethodGet, "/api/v1/teams"))
+}
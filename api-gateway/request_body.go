@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/baseball-sim/shared/runconfig"
+)
+
+// defaultMaxRequestBodyBytes caps POST bodies when MAX_REQUEST_BODY_BYTES is unset
+const defaultMaxRequestBodyBytes = 64 << 10
+
+// RunConfig is a simulation's engine options. Keys the engine doesn't read
+// are rejected rather than stored and silently ignored.
+type RunConfig map[string]interface{}
+
+// unknownConfigKeyError names a run config key the engine doesn't read
+type unknownConfigKeyError struct {
+	key string
+}
+
+func (e *unknownConfigKeyError) Error() string {
+	return fmt.Sprintf("unknown config key %q", e.key)
+}
+
+// UnmarshalJSON decodes a config object, rejecting keys the engine doesn't read
+func (c *RunConfig) UnmarshalJSON(data []byte) error {
+	var config map[string]interface{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		// The outer decoder doesn't add the field to an unmarshaler's error
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field == "" {
+			typeErr.Field = "config"
+		}
+		return err
+	}
+	if unknown := runconfig.UnknownKeys(config); len(unknown) > 0 {
+		return &unknownConfigKeyError{key: unknown[0]}
+	}
+	*c = config
+	return nil
+}
+
+// EstimateRequest describes a prospective simulation job to cost
+type EstimateRequest struct {
+	SimulationRuns int       `json:"simulation_runs,omitempty"`
+	Games          int       `json:"games,omitempty"`
+	GameIDs        []string  `json:"game_ids,omitempty"`
+	Config         RunConfig `json:"config,omitempty"`
+}
+
+// BatchSimulationRequest creates simulations for every game matching the filters
+type BatchSimulationRequest struct {
+	Date           string          `json:"date,omitempty"`
+	StartDate      string          `json:"start_date,omitempty"`
+	EndDate        string          `json:"end_date,omitempty"`
+	Team           string          `json:"team,omitempty"`
+	GameType       string          `json:"game_type,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	SimulationRuns int             `json:"simulation_runs,omitempty"`
+	Config         RunConfig       `json:"config,omitempty"`
+	BullpenFatigue *BullpenFatigue `json:"bullpen_fatigue,omitempty"`
+}
+
+// BullpenFatigue carries reliever workload between a batch's games, from real
+// box scores ("actual") or the batch's own earlier days ("simulated")
+type BullpenFatigue struct {
+	Source               string   `json:"source"`
+	DayAfterAvailability *float64 `json:"day_after_availability,omitempty"`
+	MaxConsecutiveDays   int      `json:"max_consecutive_days,omitempty"`
+	HeavyUsagePitches    float64  `json:"heavy_usage_pitches,omitempty"`
+}
+
+// RefreshRequest mirrors the data fetcher's manual fetch parameters
+type RefreshRequest struct {
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+	FetchType string `json:"fetch_type,omitempty"`
+	Season    *int   `json:"season,omitempty"`
+}
+
+// decodeJSONBody strictly decodes a request body into dst. It writes a 415 for
+// a non-JSON Content-Type, 413 for an oversized body, 422 for unknown or
+// mistyped fields and 400 for malformed JSON, returning false after any of
+// them. An empty body is accepted when allowEmpty is set.
+func (s *Server) decodeJSONBody(w http.ResponseWriter, r *http.Request, dst interface{}, allowEmpty bool) bool {
+	if allowEmpty && r.ContentLength == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		writeErrorWithDetails(w, "Content-Type must be application/json", "unsupported_media_type",
+			map[string]interface{}{"content_type": r.Header.Get("Content-Type")}, http.StatusUnsupportedMediaType)
+		return false
+	}
+
+	limit := int64(s.config.MaxRequestBodyBytes)
+	if limit <= 0 {
+		limit = defaultMaxRequestBodyBytes
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	err = decoder.Decode(dst)
+	if err == nil {
+		// A second value (or trailing garbage) means the body wasn't a single object
+		if decoder.Decode(&struct{}{}) != io.EOF {
+			writeError(w, "Request body must contain a single JSON object", http.StatusBadRequest)
+			return false
+		}
+		return true
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	var typeErr *json.UnmarshalTypeError
+	var configKeyErr *unknownConfigKeyError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		writeErrorWithDetails(w, fmt.Sprintf("Request body exceeds %d bytes", limit), "request_too_large",
+			map[string]interface{}{"limit_bytes": limit}, http.StatusRequestEntityTooLarge)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		writeErrorWithDetails(w, fmt.Sprintf("Unknown field %q", field), "unknown_field",
+			map[string]interface{}{"field": field}, http.StatusUnprocessableEntity)
+	case errors.As(err, &configKeyErr):
+		field := "config." + configKeyErr.key
+		writeErrorWithDetails(w, fmt.Sprintf("Unknown field %q", field), "unknown_field",
+			map[string]interface{}{"field": field}, http.StatusUnprocessableEntity)
+	case errors.As(err, &typeErr):
+		writeErrorWithDetails(w, fmt.Sprintf("Field %q must be of type %s", typeErr.Field, typeErr.Type), "invalid_field",
+			map[string]interface{}{"field": typeErr.Field, "expected": typeErr.Type.String()}, http.StatusUnprocessableEntity)
+	case allowEmpty && errors.Is(err, io.EOF):
+		return true
+	default:
+		writeError(w, "Invalid request body", http.StatusBadRequest)
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDecodeJSONBody tests strict body decoding and its error responses
+func TestDecodeJSONBody(t *testing.T) {
+	s := &Server{config: &Config{MaxRequestBodyBytes: 64}}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		allowEmpty  bool
+		wantOK      bool
+		wantStatus  int
+		wantCode    string
+	}{
+		{name: "valid", contentType: "application/json", body: `{"game_id":"g1","simulation_runs":100}`, wantOK: true},
+		{name: "charset parameter", contentType: "application/json; charset=utf-8", body: `{"game_id":"g1"}`, wantOK: true},
+		{name: "wrong content type", contentType: "text/plain", body: `{"game_id":"g1"}`, wantStatus: http.StatusUnsupportedMediaType, wantCode: "unsupported_media_type"},
+		{name: "too large", contentType: "application/json", body: `{"game_id":"` + strings.Repeat("x", 100) + `"}`, wantStatus: http.StatusRequestEntityTooLarge, wantCode: "request_too_large"},
+		{name: "unknown field", contentType: "application/json", body: `{"game_id":"g1","simulaton_runs":5}`, wantStatus: http.StatusUnprocessableEntity, wantCode: "unknown_field"},
+		{name: "unknown config key", contentType: "application/json", body: `{"game_id":"g1","config":{"wether_effects":true}}`, wantStatus: http.StatusUnprocessableEntity, wantCode: "unknown_field"},
+		{name: "engine config keys", contentType: "application/json", body: `{"game_id":"g1","config":{"stadium_id":"15","rain_delays":true}}`, wantOK: true},
+		{name: "time budget", contentType: "application/json", body: `{"game_id":"g1","config":{"max_duration_seconds":30}}`, wantOK: true},
+		{name: "config not an object", contentType: "application/json", body: `{"config":["rain_delays"]}`, wantStatus: http.StatusUnprocessableEntity, wantCode: "invalid_field"},
+		{name: "wrong type", contentType: "application/json", body: `{"game_id":"g1","simulation_runs":"many"}`, wantStatus: http.StatusUnprocessableEntity, wantCode: "invalid_field"},
+		{name: "malformed", contentType: "application/json", body: `{"game_id":`, wantStatus: http.StatusBadRequest},
+		{name: "trailing data", contentType: "application/json", body: `{"game_id":"g1"}{}`, wantStatus: http.StatusBadRequest},
+		{name: "empty allowed", body: "", allowEmpty: true, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/v1/simulations", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			var req SimulationRequest
+			ok := s.decodeJSONBody(w, r, &req, tt.allowEmpty)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				return
+			}
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var apiErr APIError
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &apiErr))
+			assert.Equal(t, tt.wantCode, apiErr.Code)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+)
+
+// ScheduleStrengthOpponent is one opponent on a team's schedule
+type ScheduleStrengthOpponent struct {
+	TeamID       string   `json:"team_id"`
+	Name         string   `json:"name"`
+	Abbreviation string   `json:"abbreviation"`
+	Strength     *float64 `json:"strength"` // mean simulated win probability; null when never simulated
+	Played       int      `json:"played"`
+	Remaining    int      `json:"remaining"`
+}
+
+// ScheduleStrengthSplit is the strength of a set of games
+type ScheduleStrengthSplit struct {
+	SOS        *float64 `json:"sos"` // mean opponent strength; null without rated opponents
+	Games      int      `json:"games"`
+	RatedGames int      `json:"rated_games"` // games against opponents with simulations
+
+	sum float64
+}
+
+// add counts a game against an opponent of the given strength
+func (split *ScheduleStrengthSplit) add(strength *float64) {
+	split.Games++
+	if strength != nil {
+		split.RatedGames++
+		split.sum += *strength
+	}
+}
+
+// finish sets the mean opponent strength
+func (split *ScheduleStrengthSplit) finish() {
+	if split.RatedGames > 0 {
+		sos := roundTo(split.sum/float64(split.RatedGames), 3)
+		split.SOS = &sos
+	}
+}
+
+// ScheduleStrength is a team's played and remaining strength of schedule.
+// An opponent's strength is its mean simulated win probability over every
+// simulated game of the season, so .500 is an average opponent.
+type ScheduleStrength struct {
+	TeamID    string                     `json:"team_id"`
+	TeamName  string                     `json:"team_name"`
+	Season    int                        `json:"season"`
+	Played    ScheduleStrengthSplit      `json:"played"`
+	Remaining ScheduleStrengthSplit      `json:"remaining"`
+	Overall   ScheduleStrengthSplit      `json:"overall"`
+	Opponents []ScheduleStrengthOpponent `json:"opponents"`
+}
+
+// scheduleGame is one game on a team's schedule
+type scheduleGame struct {
+	Played           bool
+	OpponentID       string
+	OpponentName     string
+	OpponentAbbrev   string
+	OpponentStrength *float64
+}
+
+// summarizeScheduleStrength averages opponent strength over played,
+// remaining and all games. Games against unrated opponents count toward
+// games but not the average.
+func summarizeScheduleStrength(games []scheduleGame) (played, remaining, overall ScheduleStrengthSplit, opponents []ScheduleStrengthOpponent) {
+	byOpponent := make(map[string]int)
+	opponents = []ScheduleStrengthOpponent{}
+
+	for _, game := range games {
+		overall.add(game.OpponentStrength)
+
+		i, seen := byOpponent[game.OpponentID]
+		if !seen {
+			i = len(opponents)
+			byOpponent[game.OpponentID] = i
+			opponent := ScheduleStrengthOpponent{TeamID: game.OpponentID, Name: game.OpponentName,
+				Abbreviation: game.OpponentAbbrev}
+			if game.OpponentStrength != nil {
+				strength := roundTo(*game.OpponentStrength, 3)
+				opponent.Strength = &strength
+			}
+			opponents = append(opponents, opponent)
+		}
+
+		if game.Played {
+			played.add(game.OpponentStrength)
+			opponents[i].Played++
+		} else {
+			remaining.add(game.OpponentStrength)
+			opponents[i].Remaining++
+		}
+	}
+
+	played.finish()
+	remaining.finish()
+	overall.finish()
+	return played, remaining, overall, opponents
+}
+
+// teamStrengthCTE rates every team in season $1 by its mean win probability
+// in the latest finished simulation of each game
+const teamStrengthCTE = `
+	WITH simulated AS (
+		SELECT g.home_team_id, g.away_team_id,
+		       sa.home_win_probability::float8 AS home_p, sa.away_win_probability::float8 AS away_p
+		FROM games g
+		JOIN LATERAL (
+			SELECT r.id FROM simulation_runs r
+			WHERE r.game_id = g.id AND r.status IN ('completed', 'partial')
+			ORDER BY r.created_at DESC
+			LIMIT 1
+		) sr ON true
+		JOIN simulation_aggregates sa ON sa.run_id = sr.id
+		WHERE g.season = $1
+	), strength AS (
+		SELECT team_id, AVG(p) AS strength
+		FROM (
+			SELECT home_team_id AS team_id, home_p AS p FROM simulated
+			UNION ALL
+			SELECT away_team_id, away_p FROM simulated
+		) sides
+		GROUP BY team_id
+	)`
+
+// getTeamScheduleStrengthHandler reports a team's strength of schedule for
+// a ?season= (default current), regular season only
+func (s *Server) getTeamScheduleStrengthHandler(w http.ResponseWriter, r *http.Request) {
+	season := getCurrentSeason()
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		parsed, err := strconv.Atoi(seasonStr)
+		if err != nil || parsed < firstMLBSeason {
+			writeError(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		season = parsed
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	cacheKey := fmt.Sprintf("schedule_strength_%s_%d", resolved.ID, season)
+	if cached, found := s.queryCache.Get(cacheKey); found {
+		writeJSON(w, cached)
+		return
+	}
+
+	report := ScheduleStrength{TeamID: resolved.ID, Season: season}
+	if err := s.readDB().QueryRow(ctx, `SELECT name FROM teams WHERE id = $1`, resolved.ID).Scan(&report.TeamName); err != nil {
+		log.Printf("Team query error: %v", err)
+		writeError(w, "Failed to query team", http.StatusInternalServerError)
+		return
+	}
+
+	gameTypeClause, gameTypeArgs := gameTypeCondition("g.game_type", []string{GameTypeRegular}, 3)
+	rows, err := s.readDB().Query(ctx, teamStrengthCTE+`
+		SELECT COALESCE(g.status, '') = 'completed',
+		       opp.id::text, opp.name, COALESCE(opp.abbreviation, ''), st.strength
+		FROM games g
+		JOIN teams opp ON opp.id = CASE WHEN g.home_team_id = $2 THEN g.away_team_id ELSE g.home_team_id END
+		LEFT JOIN strength st ON st.team_id = opp.id
+		WHERE g.season = $1
+		  AND (g.home_team_id = $2 OR g.away_team_id = $2)
+		  AND LOWER(COALESCE(g.status, 'scheduled')) NOT IN ('cancelled', 'postponed')
+		  AND `+gameTypeClause+`
+		ORDER BY g.game_date, g.game_number`, season, resolved.ID, gameTypeArgs)
+	if err != nil {
+		log.Printf("Schedule strength query error: %v", err)
+		writeError(w, "Failed to query schedule", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var games []scheduleGame
+	for rows.Next() {
+		var game scheduleGame
+		if err := rows.Scan(&game.Played, &game.OpponentID, &game.OpponentName,
+			&game.OpponentAbbrev, &game.OpponentStrength); err != nil {
+			log.Printf("Error scanning schedule row: %v", err)
+			continue
+		}
+		games = append(games, game)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Schedule strength query error: %v", err)
+		writeError(w, "Failed to query schedule", http.StatusInternalServerError)
+		return
+	}
+
+	report.Played, report.Remaining, report.Overall, report.Opponents = summarizeScheduleStrength(games)
+	s.queryCache.Set(cacheKey, report, 10*time.Minute)
+	writeJSON(w, report)
+}
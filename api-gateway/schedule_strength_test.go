@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeScheduleStrength(t *testing.T) {
+	strong, weak := 0.6123, 0.42
+	games := []scheduleGame{
+		{Played: true, OpponentID: "lad", OpponentName: "Dodgers", OpponentStrength: &strong},
+		{Played: true, OpponentID: "col", OpponentName: "Rockies", OpponentStrength: &weak},
+		{Played: false, OpponentID: "lad", OpponentName: "Dodgers", OpponentStrength: &strong},
+		{Played: false, OpponentID: "lad", OpponentName: "Dodgers", OpponentStrength: &strong},
+		// Never simulated: counted, but not rated
+		{Played: false, OpponentID: "oak", OpponentName: "Athletics"},
+	}
+
+	played, remaining, overall, opponents := summarizeScheduleStrength(games)
+
+	assert.Equal(t, 2, played.Games)
+	assert.Equal(t, 0.516, *played.SOS)
+	assert.Equal(t, 3, remaining.Games)
+	assert.Equal(t, 2, remaining.RatedGames)
+	assert.Equal(t, 0.612, *remaining.SOS)
+	assert.Equal(t, 5, overall.Games)
+	assert.Equal(t, 0.564, *overall.SOS)
+
+	if assert.Len(t, opponents, 3) {
+		assert.Equal(t, "lad", opponents[0].TeamID)
+		assert.Equal(t, 1, opponents[0].Played)
+		assert.Equal(t, 2, opponents[0].Remaining)
+		assert.Equal(t, 0.612, *opponents[0].Strength)
+		assert.Nil(t, opponents[2].Strength)
+	}
+
+	// A finished season has no remaining SOS
+	played, remaining, _, _ = summarizeScheduleStrength(games[:2])
+	assert.NotNil(t, played.SOS)
+	assert.Nil(t, remaining.SOS)
+	assert.Equal(t, 0, remaining.Games)
+
+	_, _, overall, opponents = summarizeScheduleStrength(nil)
+	assert.Nil(t, overall.SOS)
+	assert.NotNil(t, opponents)
+}
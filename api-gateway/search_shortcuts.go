@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Shortcut kinds recognized by /search
+const (
+	shortcutGame         = "game_matchup"
+	shortcutPlayerNumber = "player_number"
+	shortcutUmpireSeason = "umpire_season"
+	shortcutExactMatch   = "exact_match"
+)
+
+var (
+	// "NYY vs BOS 2024-07-04" or "NYY @ BOS 2024-07-04"; "@" and "at" fix
+	// the first team as the visitor, "vs" matches either way round
+	gameShortcutPattern = regexp.MustCompile(`(?i)^([a-z]{2,3})\s+(vs\.?|v|@|at)\s+([a-z]{2,3})\s+(\d{4}-\d{2}-\d{2})$`)
+
+	// "#99 yankees"
+	playerNumberShortcutPattern = regexp.MustCompile(`^#(\d{1,2})\s+(.+)$`)
+
+	// "umpire angel hernandez 2023"
+	umpireShortcutPattern = regexp.MustCompile(`(?i)^(?:umpire|ump)\s+(.+?)(?:\s+(\d{4}))?$`)
+)
+
+// SearchBestMatch is the entity a search resolved to outright, with the UI
+// route to open it
+type SearchBestMatch struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Route       string `json:"route"`
+	Shortcut    string `json:"shortcut"` // which shortcut resolved it, or exact_match
+}
+
+// SearchResponse is the /search payload. BestMatch is null unless the query
+// was a shortcut that resolved or matched exactly one entity exactly.
+type SearchResponse struct {
+	Query     string           `json:"query"`
+	Results   []SearchResult   `json:"results"`
+	BestMatch *SearchBestMatch `json:"best_match"`
+}
+
+// gameShortcut is a parsed "AAA vs BBB yyyy-mm-dd" query
+type gameShortcut struct {
+	first, second string
+	date          time.Time
+	ordered       bool // first is the away team
+}
+
+// playerNumberShortcut is a parsed "#NN team" query
+type playerNumberShortcut struct {
+	number int
+	team   string
+}
+
+// umpireShortcut is a parsed "umpire name [season]" query
+type umpireShortcut struct {
+	name   string
+	season int // 0 when not given
+}
+
+func parseGameShortcut(query string) (gameShortcut, bool) {
+	m := gameShortcutPattern.FindStringSubmatch(strings.TrimSpace(query))
+	if m == nil {
+		return gameShortcut{}, false
+	}
+	date, err := time.Parse("2006-01-02", m[4])
+	if err != nil {
+		return gameShortcut{}, false
+	}
+	separator := strings.ToLower(m[2])
+	return gameShortcut{
+		first:   strings.ToUpper(m[1]),
+		second:  strings.ToUpper(m[3]),
+		date:    date,
+		ordered: separator == "@" || separator == "at",
+	}, true
+}
+
+func parsePlayerNumberShortcut(query string) (playerNumberShortcut, bool) {
+	m := playerNumberShortcutPattern.FindStringSubmatch(strings.TrimSpace(query))
+	if m == nil {
+		return playerNumberShortcut{}, false
+	}
+	number, err := strconv.Atoi(m[1])
+	if err != nil {
+		return playerNumberShortcut{}, false
+	}
+	return playerNumberShortcut{number: number, team: strings.TrimSpace(m[2])}, true
+}
+
+func parseUmpireShortcut(query string) (umpireShortcut, bool) {
+	m := umpireShortcutPattern.FindStringSubmatch(strings.TrimSpace(query))
+	if m == nil {
+		return umpireShortcut{}, false
+	}
+	shortcut := umpireShortcut{name: strings.TrimSpace(m[1])}
+	if m[2] != "" {
+		shortcut.season, _ = strconv.Atoi(m[2])
+	}
+	return shortcut, true
+}
+
+// searchRoute is the UI page for a search result
+func searchRoute(resultType, id string) string {
+	switch resultType {
+	case "player":
+		return "/players/" + id
+	case "team":
+		return "/teams/" + id
+	case "game":
+		return "/games/" + id
+	case "umpire":
+		return "/umpires/" + id
+	}
+	return ""
+}
+
+// resolveSearchShortcut returns the entity a shortcut query names, or nil
+// when the query isn't a shortcut or names nothing
+func (s *Server) resolveSearchShortcut(ctx context.Context, query string) (*SearchBestMatch, error) {
+	if shortcut, ok := parseGameShortcut(query); ok {
+		return s.resolveGameShortcut(ctx, shortcut)
+	}
+	if shortcut, ok := parsePlayerNumberShortcut(query); ok {
+		return s.resolvePlayerNumberShortcut(ctx, shortcut)
+	}
+	if shortcut, ok := parseUmpireShortcut(query); ok {
+		return s.resolveUmpireShortcut(ctx, shortcut)
+	}
+	return nil, nil
+}
+
+func (s *Server) resolveGameShortcut(ctx context.Context, shortcut gameShortcut) (*SearchBestMatch, error) {
+	query := `
+		SELECT g.id::text, g.game_date, at.abbreviation, ht.abbreviation, g.status
+		FROM games g
+		JOIN teams ht ON g.home_team_id = ht.id
+		JOIN teams at ON g.away_team_id = at.id
+		WHERE g.game_date::date = $3::date
+		  AND ((UPPER(at.abbreviation) = $1 AND UPPER(ht.abbreviation) = $2)
+		       OR (NOT $4 AND UPPER(at.abbreviation) = $2 AND UPPER(ht.abbreviation) = $1))
+		ORDER BY g.game_date
+		LIMIT 1`
+
+	var id, away, home, status string
+	var gameDate time.Time
+	err := s.readDB().QueryRow(ctx, query, shortcut.first, shortcut.second, shortcut.date, shortcut.ordered).
+		Scan(&id, &gameDate, &away, &home, &status)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &SearchBestMatch{
+		Type:        "game",
+		ID:          id,
+		Name:        fmt.Sprintf("%s @ %s", away, home),
+		Description: fmt.Sprintf("%s - %s", gameDate.Format("2006-01-02"), status),
+		Route:       searchRoute("game", id),
+		Shortcut:    shortcutGame,
+	}, nil
+}
+
+func (s *Server) resolvePlayerNumberShortcut(ctx context.Context, shortcut playerNumberShortcut) (*SearchBestMatch, error) {
+	query := `
+		SELECT p.id::text, p.full_name, p.position, t.abbreviation
+		FROM players p
+		JOIN teams t ON p.team_id = t.id
+		WHERE p.jersey_number::text = $1
+		  AND (t.name ILIKE $2 OR t.city ILIKE $2 OR t.abbreviation ILIKE $3)
+		ORDER BY (p.status = 'active') DESC, p.full_name
+		LIMIT 1`
+
+	var id, fullName string
+	var position, team *string
+	err := s.readDB().QueryRow(ctx, query, strconv.Itoa(shortcut.number), "%"+shortcut.team+"%", shortcut.team).
+		Scan(&id, &fullName, &position, &team)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	description := fmt.Sprintf("#%d", shortcut.number)
+	if position != nil {
+		description += " " + *position
+	}
+	if team != nil {
+		description += " - " + *team
+	}
+	return &SearchBestMatch{
+		Type:        "player",
+		ID:          id,
+		Name:        fullName,
+		Description: description,
+		Route:       searchRoute("player", id),
+		Shortcut:    shortcutPlayerNumber,
+	}, nil
+}
+
+func (s *Server) resolveUmpireShortcut(ctx context.Context, shortcut umpireShortcut) (*SearchBestMatch, error) {
+	query := `
+		SELECT id::text, name
+		FROM umpires
+		WHERE name ILIKE $1
+		ORDER BY (LOWER(name) = LOWER($2)) DESC, name
+		LIMIT 1`
+
+	var id, name string
+	err := s.readDB().QueryRow(ctx, query, "%"+shortcut.name+"%", shortcut.name).Scan(&id, &name)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	match := &SearchBestMatch{
+		Type:        "umpire",
+		ID:          id,
+		Name:        name,
+		Description: "Umpire",
+		Route:       searchRoute("umpire", id),
+		Shortcut:    shortcutUmpireSeason,
+	}
+	if shortcut.season > 0 {
+		match.Description = fmt.Sprintf("Umpire - %d season", shortcut.season)
+		match.Route += fmt.Sprintf("?season=%d", shortcut.season)
+	}
+	return match, nil
+}
+
+// exactSearchMatch picks the best match from plain search results: the only
+// result at the top relevance, when that relevance is an exact match
+func exactSearchMatch(results []SearchResult) *SearchBestMatch {
+	const exactRelevance = 100
+	if len(results) == 0 || results[0].Relevance < exactRelevance {
+		return nil
+	}
+	if len(results) > 1 && results[1].Relevance >= results[0].Relevance {
+		return nil
+	}
+	top := results[0]
+	return &SearchBestMatch{
+		Type:        top.Type,
+		ID:          top.ID,
+		Name:        top.Name,
+		Description: top.Description,
+		Route:       searchRoute(top.Type, top.ID),
+		Shortcut:    shortcutExactMatch,
+	}
+}
@@ -247,3 +247,74 @@ func TestSearchConcurrentExecution(t *testing.T) {
 	// If we get here, concurrent execution worked
 	assert.True(t, true, "Concurrent searches should complete")
 }
+
+// TestParseGameShortcut tests matchup shortcut parsing
+func TestParseGameShortcut(t *testing.T) {
+	shortcut, ok := parseGameShortcut("nyy vs BOS 2024-07-04")
+	assert.True(t, ok)
+	assert.Equal(t, "NYY", shortcut.first)
+	assert.Equal(t, "BOS", shortcut.second)
+	assert.Equal(t, "2024-07-04", shortcut.date.Format("2006-01-02"))
+	assert.False(t, shortcut.ordered, "vs should match either home team")
+
+	shortcut, ok = parseGameShortcut("NYY @ BOS 2024-07-04")
+	assert.True(t, ok)
+	assert.True(t, shortcut.ordered, "@ should fix the first team as the visitor")
+
+	for _, query := range []string{"NYY vs BOS", "Yankees vs Red Sox 2024-07-04", "NYY vs BOS 2024-13-40"} {
+		_, ok := parseGameShortcut(query)
+		assert.False(t, ok, query)
+	}
+}
+
+// TestParsePlayerNumberShortcut tests jersey number shortcut parsing
+func TestParsePlayerNumberShortcut(t *testing.T) {
+	shortcut, ok := parsePlayerNumberShortcut("#99 yankees")
+	assert.True(t, ok)
+	assert.Equal(t, 99, shortcut.number)
+	assert.Equal(t, "yankees", shortcut.team)
+
+	shortcut, ok = parsePlayerNumberShortcut("#2 new york yankees")
+	assert.True(t, ok)
+	assert.Equal(t, "new york yankees", shortcut.team)
+
+	for _, query := range []string{"99 yankees", "#999 yankees", "#99"} {
+		_, ok := parsePlayerNumberShortcut(query)
+		assert.False(t, ok, query)
+	}
+}
+
+// TestParseUmpireShortcut tests umpire season shortcut parsing
+func TestParseUmpireShortcut(t *testing.T) {
+	shortcut, ok := parseUmpireShortcut("umpire angel hernandez 2023")
+	assert.True(t, ok)
+	assert.Equal(t, "angel hernandez", shortcut.name)
+	assert.Equal(t, 2023, shortcut.season)
+
+	shortcut, ok = parseUmpireShortcut("Umpire Joe West")
+	assert.True(t, ok)
+	assert.Equal(t, "Joe West", shortcut.name)
+	assert.Equal(t, 0, shortcut.season)
+
+	_, ok = parseUmpireShortcut("angel hernandez")
+	assert.False(t, ok)
+}
+
+// TestExactSearchMatch tests picking a best match from plain results
+func TestExactSearchMatch(t *testing.T) {
+	match := exactSearchMatch([]SearchResult{
+		{Type: "player", ID: "p1", Name: "Mike Trout", Relevance: 100},
+		{Type: "team", ID: "t1", Name: "Angels", Relevance: 85},
+	})
+	if assert.NotNil(t, match) {
+		assert.Equal(t, "/players/p1", match.Route)
+		assert.Equal(t, shortcutExactMatch, match.Shortcut)
+	}
+
+	assert.Nil(t, exactSearchMatch([]SearchResult{
+		{Type: "player", ID: "p1", Name: "Will Smith", Relevance: 100},
+		{Type: "player", ID: "p2", Name: "Will Smith", Relevance: 100},
+	}), "tied exact matches are ambiguous")
+	assert.Nil(t, exactSearchMatch([]SearchResult{{Type: "team", ID: "t1", Relevance: 90}}))
+	assert.Nil(t, exactSearchMatch(nil))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// deleteSimulationHandler deletes a finished run and everything stored for it.
+// The sim-engine owns the simulation tables, so the delete is proxied there.
+// Internal API keys only.
+func (s *Server) deleteSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	simID := mux.Vars(r)["id"]
+	if !validateUUID(simID) {
+		writeError(w, "Invalid simulation ID", http.StatusBadRequest)
+		return
+	}
+
+	s.forwardSimulationDelete(w, r, "/simulation/"+url.PathEscape(simID))
+}
+
+// deleteSimulationsHandler deletes every finished run created before
+// ?before=YYYY-MM-DD. Internal API keys only.
+func (s *Server) deleteSimulationsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	before := r.URL.Query().Get("before")
+	if before == "" {
+		writeError(w, "before is required (YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	if _, err := time.Parse("2006-01-02", before); err != nil {
+		writeError(w, "Invalid before date, use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	s.forwardSimulationDelete(w, r, "/simulations?before="+url.QueryEscape(before))
+}
+
+// forwardSimulationDelete sends a DELETE to the sim-engine and relays the result
+func (s *Server) forwardSimulationDelete(w http.ResponseWriter, r *http.Request, path string) {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodDelete, s.simEngineURL()+path, nil)
+	if err != nil {
+		writeError(w, "Failed to build simulation engine request", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := s.simEngineClient.Do(req)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	// Cached accuracy and simulation listings may include the deleted runs
+	s.queryCache.Clear()
+
+	if resp.StatusCode == http.StatusNoContent {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, result)
+}
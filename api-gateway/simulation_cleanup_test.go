@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteSimulationHandlers(t *testing.T) {
+	const runID = "0b6c9c1e-4f5e-4a43-9d0e-3f7a3c8e2b11"
+	var forwarded []string
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = append(forwarded, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/simulation/" + runID:
+			w.WriteHeader(http.StatusNoContent)
+		case "/simulations":
+			w.Write([]byte(`{"deleted": 12, "before": "2026-09-01"}`))
+		default:
+			http.Error(w, "Simulation run is still in progress", http.StatusConflict)
+		}
+	}))
+	defer engine.Close()
+
+	keys, err := ParseAPIKeys("admin-key:internal,std-key:standard", "free")
+	assert.NoError(t, err)
+	s := &Server{
+		config:          &Config{SimEngineURL: engine.URL},
+		apiKeys:         keys,
+		queryCache:      NewQueryCache(),
+		simEngineClient: NewUpstreamClient("sim-engine", 2),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/simulations", s.deleteSimulationsHandler).Methods("DELETE")
+	router.HandleFunc("/simulations/{id}", s.deleteSimulationHandler).Methods("DELETE")
+
+	del := func(path, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusNoContent, del("/simulations/"+runID, "admin-key").Code)
+
+	rec := del("/simulations?before=2026-09-01", "admin-key")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"deleted":12`)
+	assert.Equal(t, []string{"DELETE /simulation/" + runID, "DELETE /simulations?before=2026-09-01"}, forwarded)
+
+	// Engine errors pass through
+	rec = del("/simulations/1f0e9c1e-4f5e-4a43-9d0e-3f7a3c8e2b11", "admin-key")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	// Only internal keys may delete, and requests are checked before forwarding
+	forwarded = nil
+	assert.Equal(t, http.StatusForbidden, del("/simulations/"+runID, "std-key").Code)
+	assert.Equal(t, http.StatusForbidden, del("/simulations?before=2026-09-01", "").Code)
+	assert.Equal(t, http.StatusBadRequest, del("/simulations/not-a-run", "admin-key").Code)
+	assert.Equal(t, http.StatusBadRequest, del("/simulations", "admin-key").Code)
+	assert.Equal(t, http.StatusBadRequest, del("/simulations?before=09/01/2026", "admin-key").Code)
+	assert.Empty(t, forwarded)
+}
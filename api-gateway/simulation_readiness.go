@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// The engine only builds a batting order from nine position players
+	minReadyPositionPlayers = 9
+	// Five pitchers fill the engine's rotation before any go to the bullpen
+	minReadyPitchers = 5
+)
+
+// Readiness check names
+const (
+	readinessCheckPositionPlayers = "position_players"
+	readinessCheckPitchers        = "pitchers"
+	readinessCheckStarter         = "starter"
+	readinessCheckUpcomingGame    = "upcoming_game"
+	readinessCheckPark            = "park"
+	readinessCheckUmpire          = "umpire"
+)
+
+// ReadinessCheck is one input a simulation needs and whether it's present
+type ReadinessCheck struct {
+	Name     string `json:"name"`
+	Passed   bool   `json:"passed"`
+	Detail   string `json:"detail"`
+	Fallback string `json:"fallback,omitempty"` // what the engine does instead when the check fails
+}
+
+// SimulationReadiness reports whether a team's next simulation will run on
+// real data or fall back to defaults
+type SimulationReadiness struct {
+	TeamID   string           `json:"team_id"`
+	TeamName string           `json:"team_name"`
+	Season   int              `json:"season"`
+	GameID   *string          `json:"game_id,omitempty"`
+	GameDate *string          `json:"game_date,omitempty"`
+	Ready    bool             `json:"ready"`
+	Checks   []ReadinessCheck `json:"checks"`
+}
+
+// readinessStarter is the pitcher the engine would start: the lowest FIP
+// among active pitchers with current-season stats
+type readinessStarter struct {
+	name         string
+	fip          float64
+	gamesStarted int
+}
+
+// readinessGame is the team's next scheduled game
+type readinessGame struct {
+	id, date            string
+	stadiumName         *string
+	hasParkFactors      bool
+	hasDimensions       bool
+	umpireName          *string
+	umpireHasTendencies bool
+}
+
+// readinessInputs is everything the checks are computed from
+type readinessInputs struct {
+	positionPlayers, positionPlayersWithStats int
+	pitchers, pitchersWithStats               int
+	starter                                   *readinessStarter
+	game                                      *readinessGame
+}
+
+// buildReadinessChecks evaluates each simulation input in the order the
+// engine loads them
+func buildReadinessChecks(in readinessInputs) []ReadinessCheck {
+	checks := []ReadinessCheck{
+		{
+			Name:   readinessCheckPositionPlayers,
+			Passed: in.positionPlayersWithStats >= minReadyPositionPlayers,
+			Detail: fmt.Sprintf("%d of %d active position players have current-season batting stats (need %d)",
+				in.positionPlayersWithStats, in.positionPlayers, minReadyPositionPlayers),
+			Fallback: "Hitters without stats are simulated with league-average batting lines",
+		},
+		{
+			Name:   readinessCheckPitchers,
+			Passed: in.pitchersWithStats >= minReadyPitchers,
+			Detail: fmt.Sprintf("%d of %d active pitchers have current-season pitching stats (need %d)",
+				in.pitchersWithStats, in.pitchers, minReadyPitchers),
+			Fallback: "Pitchers without stats are simulated with league-average pitching lines",
+		},
+	}
+
+	starter := ReadinessCheck{
+		Name:     readinessCheckStarter,
+		Fallback: "The engine starts its best-FIP pitcher regardless of role",
+	}
+	switch {
+	case in.starter == nil:
+		starter.Detail = "No active pitcher has current-season stats"
+	case in.starter.gamesStarted == 0:
+		starter.Detail = fmt.Sprintf("%s (%.2f FIP) would start but has no starts this season", in.starter.name, in.starter.fip)
+	default:
+		starter.Passed = true
+		starter.Detail = fmt.Sprintf("%s (%.2f FIP, %d starts)", in.starter.name, in.starter.fip, in.starter.gamesStarted)
+	}
+	checks = append(checks, starter)
+
+	if in.game == nil {
+		noGame := "No upcoming game is scheduled"
+		return append(checks,
+			ReadinessCheck{Name: readinessCheckUpcomingGame, Detail: noGame, Fallback: "Simulations need a scheduled game"},
+			ReadinessCheck{Name: readinessCheckPark, Detail: noGame, Fallback: "Neutral park factors and dimensions"},
+			ReadinessCheck{Name: readinessCheckUmpire, Detail: noGame, Fallback: "A league-average strike zone"},
+		)
+	}
+
+	checks = append(checks, ReadinessCheck{
+		Name:   readinessCheckUpcomingGame,
+		Passed: true,
+		Detail: fmt.Sprintf("Game %s on %s", in.game.id, in.game.date),
+	})
+
+	park := ReadinessCheck{Name: readinessCheckPark, Fallback: "Neutral park factors and dimensions"}
+	switch {
+	case in.game.stadiumName == nil:
+		park.Detail = "The game has no stadium assigned"
+	case !in.game.hasParkFactors || !in.game.hasDimensions:
+		var missing string
+		switch {
+		case !in.game.hasParkFactors && !in.game.hasDimensions:
+			missing = "park factors or dimensions"
+		case !in.game.hasParkFactors:
+			missing = "park factors"
+		default:
+			missing = "dimensions"
+		}
+		park.Detail = fmt.Sprintf("%s has no %s", *in.game.stadiumName, missing)
+	default:
+		park.Passed = true
+		park.Detail = fmt.Sprintf("%s has park factors and dimensions", *in.game.stadiumName)
+	}
+	checks = append(checks, park)
+
+	umpire := ReadinessCheck{Name: readinessCheckUmpire, Fallback: "A league-average strike zone"}
+	switch {
+	case in.game.umpireName == nil:
+		umpire.Detail = "No home plate umpire is assigned yet"
+	case !in.game.umpireHasTendencies:
+		umpire.Detail = fmt.Sprintf("%s has no recorded tendencies", *in.game.umpireName)
+	default:
+		umpire.Passed = true
+		umpire.Detail = fmt.Sprintf("%s has recorded tendencies", *in.game.umpireName)
+	}
+	return append(checks, umpire)
+}
+
+// getTeamSimulationReadinessHandler reports, check by check, whether the
+// team's next simulation has the data it needs or will fall back to defaults
+func (s *Server) getTeamSimulationReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+	if teamID == "" {
+		writeError(w, "Team ID is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	readiness := SimulationReadiness{
+		// The engine loads stats for the calendar year, not getCurrentSeason()
+		Season: time.Now().Year(),
+	}
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, teamID)
+	if !ok {
+		return
+	}
+	readiness.TeamID = resolved.ID
+	err := s.readDB().QueryRow(ctx, `SELECT name FROM teams WHERE id = $1`, readiness.TeamID).Scan(&readiness.TeamName)
+	if err != nil {
+		log.Printf("Team query error: %v", err)
+		writeError(w, "Failed to query team", http.StatusInternalServerError)
+		return
+	}
+
+	// Same roster the engine loads
+	var in readinessInputs
+	err = s.readDB().QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE p.position <> 'P'),
+		       COUNT(*) FILTER (WHERE p.position <> 'P' AND bat.player_id IS NOT NULL),
+		       COUNT(*) FILTER (WHERE p.position = 'P'),
+		       COUNT(*) FILTER (WHERE p.position = 'P' AND pit.player_id IS NOT NULL)
+		FROM players p
+		LEFT JOIN player_season_aggregates bat
+		  ON bat.player_id = p.id AND bat.season = $2 AND bat.stats_type = 'batting'
+		LEFT JOIN player_season_aggregates pit
+		  ON pit.player_id = p.id AND pit.season = $2 AND pit.stats_type = 'pitching'
+		WHERE p.team_id = $1 AND p.status IN ('A', '40M')
+	`, readiness.TeamID, readiness.Season).Scan(&in.positionPlayers, &in.positionPlayersWithStats,
+		&in.pitchers, &in.pitchersWithStats)
+	if err != nil {
+		log.Printf("Readiness roster query error: %v", err)
+		writeError(w, "Failed to query roster", http.StatusInternalServerError)
+		return
+	}
+
+	var starter readinessStarter
+	err = s.readDB().QueryRow(ctx, `
+		SELECT p.full_name,
+		       COALESCE((psa.aggregated_stats->>'FIP')::float8, 99),
+		       COALESCE((psa.aggregated_stats->>'gamesStarted')::int, 0)
+		FROM players p
+		JOIN player_season_aggregates psa
+		  ON psa.player_id = p.id AND psa.season = $2 AND psa.stats_type = 'pitching'
+		WHERE p.team_id = $1 AND p.status IN ('A', '40M') AND p.position = 'P'
+		ORDER BY 2, 3 DESC
+		LIMIT 1
+	`, readiness.TeamID, readiness.Season).Scan(&starter.name, &starter.fip, &starter.gamesStarted)
+	if err == nil {
+		in.starter = &starter
+	} else if err.Error() != "no rows in result set" {
+		log.Printf("Readiness starter query error: %v", err)
+		writeError(w, "Failed to query starter", http.StatusInternalServerError)
+		return
+	}
+
+	var game readinessGame
+	err = s.readDB().QueryRow(ctx, `
+		SELECT g.game_id, g.game_date::text, s.name,
+		       s.park_factors IS NOT NULL, s.dimensions IS NOT NULL,
+		       u.name, COALESCE(u.tendencies::text, '{}') NOT IN ('{}', 'null')
+		FROM games g
+		LEFT JOIN stadiums s ON g.stadium_id = s.id
+		LEFT JOIN umpires u ON g.home_plate_umpire_id = u.id
+		WHERE (g.home_team_id = $1 OR g.away_team_id = $1)
+		  AND g.game_date >= CURRENT_DATE
+		  AND LOWER(COALESCE(g.status, 'scheduled')) NOT IN ('completed', 'final', 'cancelled', 'postponed')
+		ORDER BY g.game_date, g.game_time NULLS LAST
+		LIMIT 1
+	`, readiness.TeamID).Scan(&game.id, &game.date, &game.stadiumName,
+		&game.hasParkFactors, &game.hasDimensions, &game.umpireName, &game.umpireHasTendencies)
+	if err == nil {
+		in.game = &game
+		readiness.GameID = &game.id
+		readiness.GameDate = &game.date
+	} else if err.Error() != "no rows in result set" {
+		log.Printf("Readiness game query error: %v", err)
+		writeError(w, "Failed to query upcoming game", http.StatusInternalServerError)
+		return
+	}
+
+	readiness.Checks = buildReadinessChecks(in)
+	readiness.Ready = true
+	for _, check := range readiness.Checks {
+		if !check.Passed {
+			readiness.Ready = false
+		}
+	}
+
+	writeJSON(w, readiness)
+}
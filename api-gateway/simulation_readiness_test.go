@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuildReadinessChecksReady tests a fully stocked team with a staffed game
+func TestBuildReadinessChecksReady(t *testing.T) {
+	stadium, umpire := "Fenway Park", "Pat Hoberg"
+	checks := buildReadinessChecks(readinessInputs{
+		positionPlayers: 14, positionPlayersWithStats: 12,
+		pitchers: 13, pitchersWithStats: 11,
+		starter: &readinessStarter{name: "Garrett Crochet", fip: 2.71, gamesStarted: 20},
+		game: &readinessGame{
+			id: "745123", date: "2026-10-16", stadiumName: &stadium,
+			hasParkFactors: true, hasDimensions: true,
+			umpireName: &umpire, umpireHasTendencies: true,
+		},
+	})
+
+	assert.Len(t, checks, 6)
+	for _, check := range checks {
+		assert.True(t, check.Passed, check.Name)
+	}
+	assert.Equal(t, "Garrett Crochet (2.71 FIP, 20 starts)", checks[2].Detail)
+}
+
+// TestBuildReadinessChecksFallbacks tests the reasons given for each failure
+func TestBuildReadinessChecksFallbacks(t *testing.T) {
+	stadium := "Sutter Health Park"
+	checks := buildReadinessChecks(readinessInputs{
+		positionPlayers: 10, positionPlayersWithStats: 8,
+		pitchers: 4, pitchersWithStats: 4,
+		starter: &readinessStarter{name: "Closer", fip: 1.90},
+		game: &readinessGame{
+			id: "745124", date: "2026-10-16", stadiumName: &stadium,
+			hasDimensions: true,
+		},
+	})
+
+	byName := make(map[string]ReadinessCheck)
+	for _, check := range checks {
+		byName[check.Name] = check
+	}
+
+	assert.False(t, byName[readinessCheckPositionPlayers].Passed)
+	assert.Contains(t, byName[readinessCheckPositionPlayers].Detail, "8 of 10")
+	assert.False(t, byName[readinessCheckPitchers].Passed)
+	assert.False(t, byName[readinessCheckStarter].Passed)
+	assert.Contains(t, byName[readinessCheckStarter].Detail, "no starts")
+	assert.True(t, byName[readinessCheckUpcomingGame].Passed)
+	assert.False(t, byName[readinessCheckPark].Passed)
+	assert.Equal(t, "Sutter Health Park has no park factors", byName[readinessCheckPark].Detail)
+	assert.False(t, byName[readinessCheckUmpire].Passed)
+	assert.NotEmpty(t, byName[readinessCheckUmpire].Fallback)
+}
+
+// TestBuildReadinessChecksNoGame tests game-dependent checks without a game
+func TestBuildReadinessChecksNoGame(t *testing.T) {
+	checks := buildReadinessChecks(readinessInputs{})
+
+	assert.Len(t, checks, 6)
+	for _, check := range checks {
+		assert.False(t, check.Passed, check.Name)
+	}
+	assert.Equal(t, "No active pitcher has current-season stats", checks[2].Detail)
+	assert.Equal(t, "No upcoming game is scheduled", checks[4].Detail)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// listSimulationsHandler lists simulation runs newest first, filtered by
+// ?status=, ?game_id=, ?batch_id=, ?created_after= and ?created_before= and
+// paged with ?limit= and ?offset=. The sim-engine owns the run tables and
+// validates the filters, so the query string is forwarded as is.
+func (s *Server) listSimulationsHandler(w http.ResponseWriter, r *http.Request) {
+	url := s.simEngineURL() + "/simulations"
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+	resp, err := s.simEngineClient.Get(r.Context(), url)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, result)
+}
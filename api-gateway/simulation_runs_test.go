@@ -0,0 +1,39 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSimulationsHandler(t *testing.T) {
+	var forwarded string
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.URL.RequestURI()
+		if r.URL.Query().Get("status") == "finished" {
+			http.Error(w, `unknown status "finished"`, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"runs": [{"run_id": "0b6c9c1e-4f5e-4a43-9d0e-3f7a3c8e2b11", "status": "running"}], "total": 1, "limit": 50, "offset": 0}`))
+	}))
+	defer engine.Close()
+
+	s := &Server{
+		config:          &Config{SimEngineURL: engine.URL},
+		simEngineClient: NewUpstreamClient("sim-engine", 2),
+	}
+
+	rec := httptest.NewRecorder()
+	s.listSimulationsHandler(rec, httptest.NewRequest("GET", "/api/v1/simulations?status=active&game_id=745340&limit=10", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "/simulations?status=active&game_id=745340&limit=10", forwarded)
+	assert.Contains(t, rec.Body.String(), `"status":"running"`)
+
+	// Engine validation errors pass through
+	rec = httptest.NewRecorder()
+	s.listSimulationsHandler(rec, httptest.NewRequest("GET", "/api/v1/simulations?status=finished", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unknown status")
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// Below this favorite probability a game is described as a toss-up
+	tossUpProbability = 0.55
+
+	// Wind at or above this speed is worth mentioning
+	notableWindMPH = 10
+
+	// Temperatures outside this range are worth mentioning
+	coldGameTemp = 50
+	hotGameTemp  = 90
+
+	// Projected totals are called high or low against a typical game
+	highTotalRuns = 9.5
+	lowTotalRuns  = 7.5
+)
+
+// multiWordNicknames are team nicknames longer than the last word of the name
+var multiWordNicknames = []string{"Red Sox", "White Sox", "Blue Jays"}
+
+// summaryPitcher is the subset of aggregated pitching stats the summary uses
+type summaryPitcher struct {
+	PlayerName string  `json:"player_name"`
+	IP         float64 `json:"ip"`
+}
+
+// summaryResult is the subset of a sim-engine result the summary uses
+type summaryResult struct {
+	RunID              string                 `json:"run_id"`
+	HomeTeam           string                 `json:"home_team"`
+	AwayTeam           string                 `json:"away_team"`
+	TotalSimulations   int                    `json:"total_simulations"`
+	HomeWinProbability float64                `json:"home_win_probability"`
+	AwayWinProbability float64                `json:"away_win_probability"`
+	ExpectedHomeScore  float64                `json:"expected_home_score"`
+	ExpectedAwayScore  float64                `json:"expected_away_score"`
+	Weather            map[string]interface{} `json:"weather"`
+	Metadata           map[string]interface{} `json:"metadata"`
+	PlayerPerformance  *struct {
+		HomeTeam struct {
+			Pitching map[string]summaryPitcher `json:"pitching"`
+		} `json:"home_team"`
+		AwayTeam struct {
+			Pitching map[string]summaryPitcher `json:"pitching"`
+		} `json:"away_team"`
+	} `json:"player_performance"`
+}
+
+// SimulationSummary is a short fan-facing narrative of a simulation result
+type SimulationSummary struct {
+	RunID     string   `json:"run_id"`
+	Headline  string   `json:"headline"`
+	Sentences []string `json:"sentences"`
+	Text      string   `json:"text"`
+}
+
+// teamNickname shortens "Los Angeles Dodgers" to "Dodgers"
+func teamNickname(name string) string {
+	for _, nickname := range multiWordNicknames {
+		if strings.HasSuffix(name, nickname) {
+			return nickname
+		}
+	}
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// lastName returns the last word of a player name
+func lastName(name string) string {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// likelyStarter returns the pitcher who averaged the most innings, which in
+// the engine is the starter
+func likelyStarter(pitching map[string]summaryPitcher) *summaryPitcher {
+	var starter *summaryPitcher
+	for id := range pitching {
+		p := pitching[id]
+		if p.PlayerName == "" {
+			continue
+		}
+		if starter == nil || p.IP > starter.IP || (p.IP == starter.IP && p.PlayerName < starter.PlayerName) {
+			starter = &p
+		}
+	}
+	return starter
+}
+
+// gameWeather is the conditions a summary describes
+type gameWeather struct {
+	temp    float64
+	wind    float64
+	windDir string // "in", "out" or a crosswind direction
+}
+
+// summaryWeather prefers the weather the engine simulated and falls back to
+// the game's stored MLB conditions ("temp": "72", "wind": "12 mph, Out To CF")
+func summaryWeather(result summaryResult) (gameWeather, bool) {
+	if simulated, ok := result.Metadata["simulated_weather"].(map[string]interface{}); ok {
+		temp, _ := toFloat(simulated["temperature"])
+		wind, _ := toFloat(simulated["wind_speed"])
+		windDir, _ := simulated["wind_dir"].(string)
+		return gameWeather{temp: temp, wind: wind, windDir: windDir}, true
+	}
+	if result.Weather == nil {
+		return gameWeather{}, false
+	}
+
+	var weather gameWeather
+	weather.temp, _ = toFloat(result.Weather["temp"])
+	if wind, ok := result.Weather["wind"].(string); ok {
+		weather.wind, weather.windDir = parseMLBWind(wind)
+	}
+	return weather, true
+}
+
+// weatherClause describes notable weather and how it bears on the total, or
+// returns "" when conditions are unremarkable
+func weatherClause(weather gameWeather, total float64) string {
+	switch {
+	case weather.wind >= notableWindMPH && weather.windDir == "out" && total >= highTotalRuns-1:
+		return fmt.Sprintf("wind blowing out %.0f mph pushes the projected total to %.1f runs", weather.wind, total)
+	case weather.wind >= notableWindMPH && weather.windDir == "in" && total <= lowTotalRuns+1:
+		return fmt.Sprintf("wind blowing in %.0f mph holds the projected total to %.1f runs", weather.wind, total)
+	case weather.temp > 0 && weather.temp < coldGameTemp && total <= lowTotalRuns+1:
+		return fmt.Sprintf("a cold %.0f°F night keeps the projected total to %.1f runs", weather.temp, total)
+	case weather.temp >= hotGameTemp && total >= highTotalRuns-1:
+		return fmt.Sprintf("%.0f°F heat lifts the projected total to %.1f runs", weather.temp, total)
+	}
+	return ""
+}
+
+// buildSimulationSummary turns an aggregate result into a templated narrative
+func buildSimulationSummary(result summaryResult) SimulationSummary {
+	home, away := teamNickname(result.HomeTeam), teamNickname(result.AwayTeam)
+	if home == "" {
+		home = "home team"
+	}
+	if away == "" {
+		away = "road team"
+	}
+
+	favorite, underdog, favProb := home, away, result.HomeWinProbability
+	favoriteScore, underdogScore := result.ExpectedHomeScore, result.ExpectedAwayScore
+	var favoritePitching map[string]summaryPitcher
+	if result.PlayerPerformance != nil {
+		favoritePitching = result.PlayerPerformance.HomeTeam.Pitching
+	}
+	if result.AwayWinProbability > result.HomeWinProbability {
+		favorite, underdog, favProb = away, home, result.AwayWinProbability
+		favoriteScore, underdogScore = result.ExpectedAwayScore, result.ExpectedHomeScore
+		if result.PlayerPerformance != nil {
+			favoritePitching = result.PlayerPerformance.AwayTeam.Pitching
+		}
+	}
+	total := roundTo(result.ExpectedHomeScore+result.ExpectedAwayScore, 1)
+
+	var headline string
+	if favProb < tossUpProbability {
+		headline = fmt.Sprintf("The %s and %s are close to a coin flip, with the %s winning %.0f%% of simulations",
+			favorite, underdog, favorite, favProb*100)
+	} else {
+		headline = fmt.Sprintf("The %s are %.0f%% favorites over the %s", favorite, favProb*100, underdog)
+	}
+	if starter := likelyStarter(favoritePitching); starter != nil {
+		headline += " behind " + lastName(starter.PlayerName)
+	}
+
+	if weather, ok := summaryWeather(result); ok {
+		if clause := weatherClause(weather, total); clause != "" {
+			headline += "; " + clause
+		}
+	}
+	sentences := []string{headline + "."}
+
+	sentences = append(sentences, fmt.Sprintf("Simulations project a %.1f-%.1f %s win, %.1f total runs.",
+		favoriteScore, underdogScore, favorite, total))
+
+	switch {
+	case total >= highTotalRuns:
+		sentences = append(sentences, "Expect a slugfest.")
+	case total <= lowTotalRuns:
+		sentences = append(sentences, "Runs should be hard to come by.")
+	}
+
+	if status, _ := result.Metadata["status"].(string); status == "partial" {
+		sentences = append(sentences, fmt.Sprintf("Based on %d simulations; the run stopped early, so treat the odds as rougher than usual.",
+			result.TotalSimulations))
+	}
+
+	return SimulationSummary{
+		RunID:     result.RunID,
+		Headline:  headline,
+		Sentences: sentences,
+		Text:      strings.Join(sentences, " "),
+	}
+}
+
+// getSimulationSummaryHandler returns a short narrative of a completed
+// simulation for fans
+func (s *Server) getSimulationSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	simID := vars["id"]
+
+	if simID == "" {
+		writeError(w, "Simulation ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.simEngineClient.Get(r.Context(), s.simEngineURL()+"/simulation/"+simID+"/result")
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusAccepted {
+		writeError(w, "Simulation not yet complete", http.StatusConflict)
+		return
+	}
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result summaryResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+	if result.TotalSimulations == 0 {
+		writeError(w, "Simulation has no results to summarize", http.StatusConflict)
+		return
+	}
+
+	writeJSON(w, buildSimulationSummary(result))
+}
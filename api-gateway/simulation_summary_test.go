@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTeamNickname tests shortening full team names
+func TestTeamNickname(t *testing.T) {
+	assert.Equal(t, "Dodgers", teamNickname("Los Angeles Dodgers"))
+	assert.Equal(t, "Red Sox", teamNickname("Boston Red Sox"))
+	assert.Equal(t, "Blue Jays", teamNickname("Toronto Blue Jays"))
+	assert.Equal(t, "Athletics", teamNickname("Athletics"))
+}
+
+// TestBuildSimulationSummary tests the favorite, starter and weather clauses
+func TestBuildSimulationSummary(t *testing.T) {
+	result := summaryResult{
+		RunID:              "run-1",
+		HomeTeam:           "Los Angeles Dodgers",
+		AwayTeam:           "San Francisco Giants",
+		TotalSimulations:   1000,
+		HomeWinProbability: 0.62,
+		AwayWinProbability: 0.38,
+		ExpectedHomeScore:  5.3,
+		ExpectedAwayScore:  4.1,
+		Metadata: map[string]interface{}{
+			"simulated_weather": map[string]interface{}{"temperature": 75.0, "wind_speed": 15.0, "wind_dir": "out"},
+		},
+	}
+	result.PlayerPerformance = &struct {
+		HomeTeam struct {
+			Pitching map[string]summaryPitcher `json:"pitching"`
+		} `json:"home_team"`
+		AwayTeam struct {
+			Pitching map[string]summaryPitcher `json:"pitching"`
+		} `json:"away_team"`
+	}{}
+	result.PlayerPerformance.HomeTeam.Pitching = map[string]summaryPitcher{
+		"1": {PlayerName: "Tyler Glasnow", IP: 6.1},
+		"2": {PlayerName: "Evan Phillips", IP: 1.0},
+	}
+
+	summary := buildSimulationSummary(result)
+	assert.Equal(t, "The Dodgers are 62% favorites over the Giants behind Glasnow; wind blowing out 15 mph pushes the projected total to 9.4 runs", summary.Headline)
+	assert.Contains(t, summary.Text, "Simulations project a 5.3-4.1 Dodgers win, 9.4 total runs.")
+}
+
+// TestSummaryWeatherStoredConditions tests parsing MLB's stored weather format
+func TestSummaryWeatherStoredConditions(t *testing.T) {
+	weather, ok := summaryWeather(summaryResult{Weather: map[string]interface{}{"temp": "45", "wind": "12 mph, In From CF"}})
+	assert.True(t, ok)
+	assert.Equal(t, 45.0, weather.temp)
+	assert.Equal(t, 12.0, weather.wind)
+	assert.Equal(t, "in", weather.windDir)
+
+	assert.Equal(t, "wind blowing in 12 mph holds the projected total to 7.2 runs", weatherClause(weather, 7.2))
+	assert.Equal(t, "", weatherClause(gameWeather{temp: 72}, 8.8))
+}
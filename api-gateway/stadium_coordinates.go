@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// StadiumCoordinatesRequest manually sets a stadium's location. Both fields
+// are required; pointers tell a missing field from a zero.
+type StadiumCoordinatesRequest struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+// StadiumCoordinates is a stadium's stored location as returned by the data fetcher
+type StadiumCoordinates struct {
+	ID                   string     `json:"id"`
+	StadiumID            string     `json:"stadium_id"`
+	Name                 string     `json:"name"`
+	Latitude             *float64   `json:"latitude"`
+	Longitude            *float64   `json:"longitude"`
+	CoordinatesSource    *string    `json:"coordinates_source"` // mlb, a geocoding provider, or manual
+	CoordinatesUpdatedAt *time.Time `json:"coordinates_updated_at"`
+}
+
+// validate checks the coordinates are on the globe. (0, 0) is refused
+// because the weather service reads it as "no coordinates".
+func (req StadiumCoordinatesRequest) validate() (string, map[string]interface{}) {
+	switch {
+	case req.Latitude == nil || req.Longitude == nil:
+		return "latitude and longitude are required", nil
+	case *req.Latitude < -90 || *req.Latitude > 90:
+		return "latitude must be between -90 and 90", map[string]interface{}{"latitude": *req.Latitude}
+	case *req.Longitude < -180 || *req.Longitude > 180:
+		return "longitude must be between -180 and 180", map[string]interface{}{"longitude": *req.Longitude}
+	case *req.Latitude == 0 && *req.Longitude == 0:
+		return "latitude and longitude cannot both be 0", nil
+	}
+	return "", nil
+}
+
+// putStadiumCoordinatesHandler overrides a stadium's coordinates. The data
+// fetcher stores them as a manual override, which later venue fetches and
+// geocoding backfills leave alone.
+func (s *Server) putStadiumCoordinatesHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	stadiumID := mux.Vars(r)["id"]
+	var req StadiumCoordinatesRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+	if msg, details := req.validate(); msg != "" {
+		writeErrorWithDetails(w, msg, "invalid_coordinates", details, http.StatusUnprocessableEntity)
+		return
+	}
+
+	body, _ := json.Marshal(req)
+	upstreamReq, err := http.NewRequestWithContext(r.Context(), http.MethodPut,
+		s.config.DataFetcherURL+"/stadiums/"+url.PathEscape(stadiumID)+"/coordinates", bytes.NewReader(body))
+	if err != nil {
+		writeError(w, "Failed to build data fetcher request", http.StatusInternalServerError)
+		return
+	}
+	upstreamReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.dataFetcherClient.Do(upstreamReq)
+	if err != nil {
+		writeError(w, "Failed to communicate with data fetcher", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		writeError(w, "Stadium not found", http.StatusNotFound)
+		return
+	}
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	var stadium StadiumCoordinates
+	if err := json.NewDecoder(resp.Body).Decode(&stadium); err != nil {
+		writeError(w, "Failed to parse data fetcher response", http.StatusInternalServerError)
+		return
+	}
+
+	// Cached game and weather responses may carry the old location
+	s.queryCache.Clear()
+	writeJSON(w, stadium)
+}
+
+// getStadiumsMissingCoordinatesHandler lists open-air and retractable-roof
+// stadiums without coordinates, whose games are simulated with default weather
+func (s *Server) getStadiumsMissingCoordinatesHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	resp, err := s.dataFetcherClient.Get(r.Context(), s.config.DataFetcherURL+"/stadiums/coordinates/missing")
+	if err != nil {
+		writeError(w, "Failed to communicate with data fetcher", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse data fetcher response", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, result)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStadiumCoordinatesRequestValidate(t *testing.T) {
+	lat, lon, zero, far := 42.3467, -71.0972, 0.0, 200.0
+
+	msg, _ := StadiumCoordinatesRequest{Latitude: &lat, Longitude: &lon}.validate()
+	assert.Empty(t, msg)
+
+	msg, _ = StadiumCoordinatesRequest{Latitude: &lat}.validate()
+	assert.Equal(t, "latitude and longitude are required", msg)
+
+	msg, details := StadiumCoordinatesRequest{Latitude: &lat, Longitude: &far}.validate()
+	assert.Equal(t, "longitude must be between -180 and 180", msg)
+	assert.Equal(t, far, details["longitude"])
+
+	msg, _ = StadiumCoordinatesRequest{Latitude: &far, Longitude: &lon}.validate()
+	assert.Equal(t, "latitude must be between -90 and 90", msg)
+
+	msg, _ = StadiumCoordinatesRequest{Latitude: &zero, Longitude: &zero}.validate()
+	assert.Equal(t, "latitude and longitude cannot both be 0", msg)
+}
+
+func TestPutStadiumCoordinatesHandler(t *testing.T) {
+	var forwarded map[string]float64
+	fetcher := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/stadiums/3/coordinates" {
+			http.Error(w, `{"detail":"Stadium not found"}`, http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &forwarded)
+		w.Write([]byte(`{"id": "9b7c", "stadium_id": "3", "name": "Fenway Park",
+			"latitude": 42.3467, "longitude": -71.0972, "coordinates_source": "manual",
+			"coordinates_updated_at": "2026-04-01T12:00:00+00:00"}`))
+	}))
+	defer fetcher.Close()
+
+	keys, err := ParseAPIKeys("admin-key:internal,std-key:standard", "free")
+	assert.NoError(t, err)
+	s := &Server{
+		config:            &Config{DataFetcherURL: fetcher.URL},
+		apiKeys:           keys,
+		queryCache:        NewQueryCache(),
+		dataFetcherClient: NewUpstreamClient("data-fetcher", 2),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/admin/stadiums/{id}/coordinates", s.putStadiumCoordinatesHandler).Methods("PUT")
+
+	put := func(id, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/stadiums/"+id+"/coordinates", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	valid := `{"latitude": 42.3467, "longitude": -71.0972}`
+
+	rec := put("3", "admin-key", valid)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var stadium StadiumCoordinates
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stadium))
+	assert.Equal(t, "manual", *stadium.CoordinatesSource)
+	assert.Equal(t, 42.3467, forwarded["latitude"])
+
+	// Only internal keys may override coordinates
+	assert.Equal(t, http.StatusForbidden, put("3", "", valid).Code)
+	assert.Equal(t, http.StatusForbidden, put("3", "std-key", valid).Code)
+	assert.Equal(t, http.StatusForbidden, put("3", "unknown", valid).Code)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, put("3", "admin-key", `{"latitude": 95, "longitude": -71}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, put("3", "admin-key", `{"latitude": 42.3}`).Code)
+	assert.Equal(t, http.StatusNotFound, put("404", "admin-key", valid).Code)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+)
+
+// StadiumDimensions mirrors the sim-engine's fence distances and wall heights (feet)
+type StadiumDimensions struct {
+	LeftField       int `json:"left_field"`
+	LeftCenter      int `json:"left_center"`
+	Center          int `json:"center"`
+	RightCenter     int `json:"right_center"`
+	RightField      int `json:"right_field"`
+	LeftFieldWall   int `json:"left_field_wall"`
+	CenterFieldWall int `json:"center_field_wall"`
+	RightFieldWall  int `json:"right_field_wall"`
+}
+
+// StadiumDimensionsResponse is a stadium's stored field dimensions
+type StadiumDimensionsResponse struct {
+	StadiumID  string            `json:"stadium_id"`
+	Name       string            `json:"name"`
+	Dimensions StadiumDimensions `json:"dimensions"`
+}
+
+// getStadiumDimensionsHandler returns the fence distances and wall heights for a stadium
+func (s *Server) getStadiumDimensionsHandler(w http.ResponseWriter, r *http.Request) {
+	stadiumID := mux.Vars(r)["id"]
+	if stadiumID == "" {
+		writeError(w, "Stadium ID is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	resolved, ok := s.resolveEntity(ctx, w, ids.Stadium, stadiumID)
+	if !ok {
+		return
+	}
+
+	var response StadiumDimensionsResponse
+	var dimensionsJSON []byte
+	err := s.readDB().QueryRow(ctx, `
+		SELECT s.id::text, s.name, s.dimensions
+		FROM stadiums s
+		WHERE s.id = $1`, resolved.ID).Scan(
+		&response.StadiumID, &response.Name, &dimensionsJSON,
+	)
+	if err != nil {
+		log.Printf("Stadium dimensions query error: %v", err)
+		writeError(w, "Failed to query stadium", http.StatusInternalServerError)
+		return
+	}
+
+	if len(dimensionsJSON) == 0 {
+		writeError(w, "Stadium dimensions not available", http.StatusNotFound)
+		return
+	}
+
+	if err := json.Unmarshal(dimensionsJSON, &response.Dimensions); err != nil {
+		log.Printf("Failed to parse dimensions for stadium %s: %v", stadiumID, err)
+		writeError(w, "Stored stadium dimensions are invalid", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, response)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	// defaultDBStartupMaxWait is how long (seconds) start-up waits for the
+	// database before giving up or falling back to degraded start-up
+	defaultDBStartupMaxWait = 60
+
+	// defaultDBStartupRetryMs is the first retry delay; it doubles per attempt
+	defaultDBStartupRetryMs = 500
+
+	// dbStartupMaxDelay caps the backoff between connection attempts
+	dbStartupMaxDelay = 15 * time.Second
+)
+
+// StartupRetryPolicy controls how start-up waits for the database
+type StartupRetryPolicy struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	MaxWait      time.Duration // 0 retries until ctx is cancelled
+}
+
+// startupRetryPolicy builds the retry policy from configuration
+func startupRetryPolicy(config *Config) StartupRetryPolicy {
+	return StartupRetryPolicy{
+		InitialDelay: time.Duration(config.DBStartupRetryMs) * time.Millisecond,
+		MaxDelay:     dbStartupMaxDelay,
+		MaxWait:      time.Duration(config.DBStartupMaxWait) * time.Second,
+	}
+}
+
+// nextDelay doubles the delay up to the policy's cap
+func (p StartupRetryPolicy) nextDelay(delay time.Duration) time.Duration {
+	delay *= 2
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// waitForDatabase calls ping until it succeeds, backing off exponentially.
+// It gives up once MaxWait has passed or ctx is cancelled, returning the last
+// ping error.
+func waitForDatabase(ctx context.Context, ping func(context.Context) error, policy StartupRetryPolicy) error {
+	start := time.Now()
+	delay := policy.InitialDelay
+	if delay <= 0 {
+		delay = defaultDBStartupRetryMs * time.Millisecond
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx)
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("Database reachable after %d attempts", attempt)
+			}
+			return nil
+		}
+
+		elapsed := time.Since(start)
+		if policy.MaxWait > 0 {
+			if elapsed >= policy.MaxWait {
+				return fmt.Errorf("database not reachable after %d attempts over %s: %w", attempt, elapsed.Round(time.Millisecond), err)
+			}
+			if remaining := policy.MaxWait - elapsed; delay > remaining {
+				delay = remaining
+			}
+		}
+
+		log.Printf("Database not ready (attempt %d): %v; retrying in %s", attempt, err, delay)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for database: %w", err)
+		case <-time.After(delay):
+		}
+		delay = policy.nextDelay(delay)
+	}
+}
+
+// connectInBackground keeps retrying the database after a degraded start and
+// opens the full API once it answers
+func (s *Server) connectInBackground(ctx context.Context) {
+	policy := startupRetryPolicy(s.config)
+	policy.MaxWait = 0
+
+	if err := waitForDatabase(ctx, s.db.Ping, policy); err != nil {
+		log.Printf("Gave up waiting for database: %v", err)
+		return
+	}
+	s.dbReady.Store(true)
+	log.Println("Database connected, leaving degraded start-up")
+}
+
+// startupGateMiddleware answers 503 for everything but /health until the
+// database has been reached at least once
+func (s *Server) startupGateMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.dbReady.Load() && r.URL.Path != "/api/v1/health" {
+			w.Header().Set("Retry-After", "5")
+			writeError(w, "Service starting: waiting for database", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWaitForDatabaseRetries tests that start-up keeps pinging until the
+// database answers
+func TestWaitForDatabaseRetries(t *testing.T) {
+	attempts := 0
+	ping := func(context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	policy := StartupRetryPolicy{InitialDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond, MaxWait: time.Second}
+	assert.NoError(t, waitForDatabase(context.Background(), ping, policy))
+	assert.Equal(t, 3, attempts)
+}
+
+// TestWaitForDatabaseGivesUp tests the maximum wait and cancellation
+func TestWaitForDatabaseGivesUp(t *testing.T) {
+	down := func(context.Context) error { return errors.New("connection refused") }
+
+	policy := StartupRetryPolicy{InitialDelay: 5 * time.Millisecond, MaxDelay: 10 * time.Millisecond, MaxWait: 30 * time.Millisecond}
+	start := time.Now()
+	err := waitForDatabase(context.Background(), down, policy)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.Less(t, time.Since(start), time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	policy.MaxWait = 0
+	assert.Error(t, waitForDatabase(ctx, down, policy))
+}
+
+// TestStartupRetryBackoff tests that the delay doubles up to the cap
+func TestStartupRetryBackoff(t *testing.T) {
+	policy := StartupRetryPolicy{MaxDelay: 3 * time.Second}
+	assert.Equal(t, 2*time.Second, policy.nextDelay(time.Second))
+	assert.Equal(t, 3*time.Second, policy.nextDelay(2*time.Second))
+}
+
+// TestStartupGateMiddleware tests that only /health is served before the
+// database connects
+func TestStartupGateMiddleware(t *testing.T) {
+	s := &Server{}
+	handler := s.startupGateMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for path, want := range map[string]int{
+		"/api/v1/health": http.StatusOK,
+		"/api/v1/teams":  http.StatusServiceUnavailable,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, want, rec.Code, path)
+	}
+
+	s.dbReady.Store(true)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/teams", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// StatDefinition describes a stat key the API emits
+type StatDefinition struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	Formula     string `json:"formula,omitempty"`
+	Direction   string `json:"direction"` // higher, lower or neutral
+	Format      string `json:"format"`    // percent, decimal, integer, rate, text
+	Precision   int    `json:"precision"`
+}
+
+// Team stat keys emitted by getTeamStatsHandler
+const (
+	StatSeason      = "season"
+	StatWins        = "wins"
+	StatLosses      = "losses"
+	StatGamesPlayed = "games_played"
+	StatWinningPct  = "winning_pct"
+	StatRunsScored  = "runs_scored"
+	StatRunsAllowed = "runs_allowed"
+	StatRunDiff     = "run_diff"
+
+	StatInterleagueWins   = "interleague_wins"
+	StatInterleagueLosses = "interleague_losses"
+
+	// Recent form, also reported by /standings
+	StatStreak        = "streak"
+	StatLast10Wins    = "last_10_wins"
+	StatLast10Losses  = "last_10_losses"
+	StatRunDiffLast14 = "run_diff_last_14"
+)
+
+// statRegistry is the gateway's source of truth for stat metadata. Simulation
+// stats are owned by the sim-engine and merged in at request time.
+var statRegistry = []StatDefinition{
+	// Team stats
+	{Key: StatSeason, Name: "Season", Category: "team", Description: "Season the team stats cover", Direction: "neutral", Format: "integer"},
+	{Key: StatWins, Name: "Wins", Category: "team", Description: "Completed games won", Direction: "higher", Format: "integer"},
+	{Key: StatLosses, Name: "Losses", Category: "team", Description: "Completed games lost", Direction: "lower", Format: "integer"},
+	{Key: StatGamesPlayed, Name: "Games Played", Category: "team", Description: "Completed games with a final score", Formula: "wins + losses", Direction: "neutral", Format: "integer"},
+	{Key: StatWinningPct, Name: "Win %", Category: "team", Description: "Share of completed games won", Formula: "wins / (wins + losses)", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: StatRunsScored, Name: "Runs Scored", Category: "team", Description: "Total runs scored in completed games", Direction: "higher", Format: "integer"},
+	{Key: StatRunsAllowed, Name: "Runs Allowed", Category: "team", Description: "Total runs allowed in completed games", Direction: "lower", Format: "integer"},
+	{Key: StatRunDiff, Name: "Run Differential", Category: "team", Description: "Runs scored minus runs allowed", Formula: "runs_scored - runs_allowed", Direction: "higher", Format: "integer"},
+	{Key: StatInterleagueWins, Name: "Interleague Wins", Category: "team", Description: "Completed games won against the other league", Direction: "higher", Format: "integer"},
+	{Key: StatInterleagueLosses, Name: "Interleague Losses", Category: "team", Description: "Completed games lost against the other league", Direction: "lower", Format: "integer"},
+	{Key: StatStreak, Name: "Streak", Category: "team", Description: "Current run of consecutive wins (W) or losses (L), such as W3", Direction: "neutral", Format: "text"},
+	{Key: StatLast10Wins, Name: "Last 10 Wins", Category: "team", Description: "Wins in the team's last 10 completed games", Direction: "higher", Format: "integer"},
+	{Key: StatLast10Losses, Name: "Last 10 Losses", Category: "team", Description: "Losses in the team's last 10 completed games", Direction: "lower", Format: "integer"},
+	{Key: StatRunDiffLast14, Name: "Run Diff (14 days)", Category: "team", Description: "Run differential over the 14 days ending with the team's latest game", Direction: "higher", Format: "integer"},
+
+	// Batting aggregates (player_season_aggregates.aggregated_stats)
+	{Key: "AVG", Name: "Batting Average", Category: "batting", Description: "Hits per at-bat", Formula: "H / AB", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "OBP", Name: "On-Base Percentage", Category: "batting", Description: "Rate of reaching base per plate appearance", Formula: "(H + BB + HBP) / (AB + BB + HBP + SF)", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "SLG", Name: "Slugging Percentage", Category: "batting", Description: "Total bases per at-bat", Formula: "TB / AB", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "OPS", Name: "OPS", Category: "batting", Description: "On-base plus slugging", Formula: "OBP + SLG", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "wOBA", Name: "Weighted On-Base Average", Category: "batting", Description: "Linear-weighted on-base rate", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "wRC+", Name: "wRC+", Category: "batting", Description: "Park and league adjusted runs created, 100 is league average", Direction: "higher", Format: "integer"},
+	{Key: "ISO", Name: "Isolated Power", Category: "batting", Description: "Extra bases per at-bat", Formula: "SLG - AVG", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "BABIP", Name: "BABIP", Category: "batting", Description: "Batting average on balls in play", Formula: "(H - HR) / (AB - K - HR + SF)", Direction: "neutral", Format: "rate", Precision: 3},
+	{Key: "BB%", Name: "Walk Rate", Category: "batting", Description: "Walks per plate appearance", Formula: "BB / PA", Direction: "higher", Format: "percent", Precision: 1},
+	{Key: "K%", Name: "Strikeout Rate", Category: "batting", Description: "Strikeouts per plate appearance", Formula: "K / PA", Direction: "lower", Format: "percent", Precision: 1},
+	{Key: "PA", Name: "Plate Appearances", Category: "batting", Description: "Completed trips to the plate", Direction: "neutral", Format: "integer"},
+	{Key: "HR", Name: "Home Runs", Category: "batting", Description: "Home runs hit", Direction: "higher", Format: "integer"},
+	{Key: "RBI", Name: "Runs Batted In", Category: "batting", Description: "Runs driven in", Direction: "higher", Format: "integer"},
+	{Key: "SB", Name: "Stolen Bases", Category: "batting", Description: "Successful stolen base attempts", Direction: "higher", Format: "integer"},
+
+	// Expected stats (players/{id}/expected-stats)
+	{Key: "xba", Name: "Expected Batting Average", Category: "expected", Description: "Batting average implied by exit velocity and launch angle", Formula: "xH / AB", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "xslg", Name: "Expected Slugging", Category: "expected", Description: "Slugging implied by exit velocity and launch angle", Formula: "xTB / AB", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "xwoba", Name: "Expected wOBA", Category: "expected", Description: "wOBA crediting contact quality instead of batted ball outcomes", Formula: "(xwOBAcon + wBB*BB + wHBP*HBP) / PA", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "hard_hit_rate", Name: "Hard-Hit Rate", Category: "expected", Description: "Share of batted balls hit 95 mph or harder", Direction: "higher", Format: "rate", Precision: 3},
+
+	// Pitching aggregates
+	{Key: "ERA", Name: "Earned Run Average", Category: "pitching", Description: "Earned runs allowed per nine innings", Formula: "9 * ER / IP", Direction: "lower", Format: "decimal", Precision: 2},
+	{Key: "WHIP", Name: "WHIP", Category: "pitching", Description: "Walks plus hits per inning pitched", Formula: "(BB + H) / IP", Direction: "lower", Format: "decimal", Precision: 2},
+	{Key: "FIP", Name: "Fielding Independent Pitching", Category: "pitching", Description: "ERA estimator from strikeouts, walks and home runs", Formula: "(13*HR + 3*BB - 2*K) / IP + constant", Direction: "lower", Format: "decimal", Precision: 2},
+	{Key: "xFIP", Name: "Expected FIP", Category: "pitching", Description: "FIP with league-average home run per fly ball rate", Direction: "lower", Format: "decimal", Precision: 2},
+	{Key: "K/9", Name: "Strikeouts per 9", Category: "pitching", Description: "Strikeouts per nine innings", Formula: "9 * K / IP", Direction: "higher", Format: "decimal", Precision: 1},
+	{Key: "BB/9", Name: "Walks per 9", Category: "pitching", Description: "Walks per nine innings", Formula: "9 * BB / IP", Direction: "lower", Format: "decimal", Precision: 1},
+	{Key: "HR/9", Name: "Home Runs per 9", Category: "pitching", Description: "Home runs allowed per nine innings", Formula: "9 * HR / IP", Direction: "lower", Format: "decimal", Precision: 1},
+	{Key: "IP", Name: "Innings Pitched", Category: "pitching", Description: "Innings pitched, thirds shown as .1 and .2", Direction: "neutral", Format: "decimal", Precision: 1},
+
+	// Value (simplified WAR, method bsim-war-v1, stored with batting and pitching aggregates)
+	{Key: "WAR", Name: "Wins Above Replacement", Category: "value", Description: "Simplified WAR (bsim-war-v1): position players from batting, baserunning, fielding, positional and replacement runs; pitchers from FIP", Formula: "runs above replacement / 10", Direction: "higher", Format: "decimal", Precision: 1},
+	{Key: "WAR_batting_runs", Name: "Batting Runs", Category: "value", Description: "Batting runs above average (wRAA)", Direction: "higher", Format: "decimal", Precision: 1},
+	{Key: "WAR_baserunning_runs", Name: "Baserunning Runs", Category: "value", Description: "Baserunning runs above average (BSR)", Direction: "higher", Format: "decimal", Precision: 1},
+	{Key: "WAR_fielding_runs", Name: "Fielding Runs", Category: "value", Description: "Fielding runs above average (UZR estimate)", Direction: "higher", Format: "decimal", Precision: 1},
+	{Key: "WAR_positional_runs", Name: "Positional Adjustment", Category: "value", Description: "Runs credited for the difficulty of the primary position, prorated over 162 games", Direction: "neutral", Format: "decimal", Precision: 1},
+	{Key: "WAR_pitching_runs", Name: "Pitching Runs", Category: "value", Description: "Runs saved versus a league-average FIP", Formula: "(league FIP - FIP) * IP / 9", Direction: "higher", Format: "decimal", Precision: 1},
+	{Key: "WAR_replacement_runs", Name: "Replacement Runs", Category: "value", Description: "Runs separating a league-average player from a replacement-level one over the same playing time", Direction: "neutral", Format: "decimal", Precision: 1},
+
+	// Fielding aggregates
+	{Key: "FPCT", Name: "Fielding Percentage", Category: "fielding", Description: "Share of chances handled without an error", Formula: "(PO + A) / (PO + A + E)", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "E", Name: "Errors", Category: "fielding", Description: "Fielding errors committed", Direction: "lower", Format: "integer"},
+}
+
+// getStatGlossaryHandler returns metadata for every stat key the API emits
+func (s *Server) getStatGlossaryHandler(w http.ResponseWriter, r *http.Request) {
+	stats := make([]StatDefinition, 0, len(statRegistry))
+	stats = append(stats, statRegistry...)
+	stats = append(stats, s.fetchSimulationStatRegistry()...)
+
+	category := r.URL.Query().Get("category")
+	if category != "" {
+		filtered := stats[:0]
+		for _, def := range stats {
+			if def.Category == category {
+				filtered = append(filtered, def)
+			}
+		}
+		stats = filtered
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Category < stats[j].Category
+	})
+
+	writeJSON(w, map[string]interface{}{
+		"stats": stats,
+		"count": len(stats),
+	})
+}
+
+// fetchSimulationStatRegistry loads the simulation stat registry from the
+// sim-engine, caching it for an hour since it only changes on deploy
+func (s *Server) fetchSimulationStatRegistry() []StatDefinition {
+	const cacheKey = "meta:simulation_stats"
+	if cached, ok := s.queryCache.Get(cacheKey); ok {
+		return cached.([]StatDefinition)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := s.simEngineClient.Get(ctx, s.simEngineURL()+"/meta/stats")
+	if err != nil {
+		log.Printf("Failed to fetch simulation stat registry: %v", err)
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Simulation stat registry returned status %d", resp.StatusCode)
+		return nil
+	}
+
+	var payload struct {
+		Stats []StatDefinition `json:"stats"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		log.Printf("Failed to decode simulation stat registry: %v", err)
+		return nil
+	}
+
+	s.queryCache.Set(cacheKey, payload.Stats, time.Hour)
+	return payload.Stats
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStatRegistryKeysUnique tests that no stat key is registered twice
+func TestStatRegistryKeysUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, def := range statRegistry {
+		assert.False(t, seen[def.Key], "duplicate stat key %s", def.Key)
+		seen[def.Key] = true
+	}
+}
+
+// TestStatRegistryDefinitions tests that every entry is fully described
+func TestStatRegistryDefinitions(t *testing.T) {
+	validDirections := map[string]bool{"higher": true, "lower": true, "neutral": true}
+	validFormats := map[string]bool{"percent": true, "decimal": true, "integer": true, "rate": true, "text": true}
+
+	for _, def := range statRegistry {
+		t.Run(def.Key, func(t *testing.T) {
+			assert.NotEmpty(t, def.Name)
+			assert.NotEmpty(t, def.Category)
+			assert.NotEmpty(t, def.Description)
+			assert.True(t, validDirections[def.Direction], "invalid direction %q", def.Direction)
+			assert.True(t, validFormats[def.Format], "invalid format %q", def.Format)
+		})
+	}
+}
+
+// TestTeamStatKeysRegistered tests that team stats output keys have glossary entries
+func TestTeamStatKeysRegistered(t *testing.T) {
+	keys := []string{StatSeason, StatWins, StatLosses, StatGamesPlayed, StatWinningPct, StatRunsScored, StatRunsAllowed, StatRunDiff,
+		StatInterleagueWins, StatInterleagueLosses, StatStreak, StatLast10Wins, StatLast10Losses, StatRunDiffLast14}
+	registered := make(map[string]bool)
+	for _, def := range statRegistry {
+		registered[def.Key] = true
+	}
+	for _, key := range keys {
+		assert.True(t, registered[key], "team stat %s missing from registry", key)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+var (
+	hexColorPattern = regexp.MustCompile(`^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	logoSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// TeamBranding is a team's colors, as uppercase #RRGGBB, and logo slug.
+// Fields are null until set.
+type TeamBranding struct {
+	PrimaryColor   *string `json:"primary_color"`
+	SecondaryColor *string `json:"secondary_color"`
+	LogoSlug       *string `json:"logo_slug"`
+}
+
+// TeamBrandingRequest replaces a team's branding; omitted fields are cleared
+type TeamBrandingRequest struct {
+	PrimaryColor   *string `json:"primary_color"`
+	SecondaryColor *string `json:"secondary_color"`
+	LogoSlug       *string `json:"logo_slug"`
+}
+
+// TeamBrandingResult is a team's stored branding as returned by the data fetcher
+type TeamBrandingResult struct {
+	ID                string     `json:"id"`
+	TeamID            string     `json:"team_id"`
+	Name              string     `json:"name"`
+	PrimaryColor      *string    `json:"primary_color"`
+	SecondaryColor    *string    `json:"secondary_color"`
+	LogoSlug          *string    `json:"logo_slug"`
+	BrandingUpdatedAt *time.Time `json:"branding_updated_at"`
+}
+
+// normalizeHexColor returns a color as uppercase #RRGGBB, accepting #RGB
+// shorthand and a missing '#', or false when it isn't a hex color
+func normalizeHexColor(color string) (string, bool) {
+	match := hexColorPattern.FindStringSubmatch(strings.TrimSpace(color))
+	if match == nil {
+		return "", false
+	}
+	digits := strings.ToUpper(match[1])
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	return "#" + digits, true
+}
+
+// normalize checks the request and normalizes its colors and slug in place
+func (req *TeamBrandingRequest) normalize() (string, map[string]interface{}) {
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{{"primary_color", req.PrimaryColor}, {"secondary_color", req.SecondaryColor}} {
+		if field.value == nil {
+			continue
+		}
+		color, ok := normalizeHexColor(*field.value)
+		if !ok {
+			return field.name + " must be a hex color like #0C2340", map[string]interface{}{field.name: *field.value}
+		}
+		*field.value = color
+	}
+	if req.LogoSlug != nil {
+		slug := strings.ToLower(strings.TrimSpace(*req.LogoSlug))
+		if !logoSlugPattern.MatchString(slug) || len(slug) > 50 {
+			return "logo_slug must be lowercase words joined by hyphens, like boston-red-sox",
+				map[string]interface{}{"logo_slug": *req.LogoSlug}
+		}
+		*req.LogoSlug = slug
+	}
+	return "", nil
+}
+
+// putTeamBrandingHandler replaces a team's colors and logo slug. The data
+// fetcher stores them; team responses carry them from then on.
+func (s *Server) putTeamBrandingHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	teamID := mux.Vars(r)["id"]
+	var req TeamBrandingRequest
+	if !s.decodeJSONBody(w, r, &req, false) {
+		return
+	}
+	if msg, details := req.normalize(); msg != "" {
+		writeErrorWithDetails(w, msg, "invalid_branding", details, http.StatusUnprocessableEntity)
+		return
+	}
+
+	body, _ := json.Marshal(req)
+	upstreamReq, err := http.NewRequestWithContext(r.Context(), http.MethodPut,
+		s.config.DataFetcherURL+"/teams/"+url.PathEscape(teamID)+"/branding", bytes.NewReader(body))
+	if err != nil {
+		writeError(w, "Failed to build data fetcher request", http.StatusInternalServerError)
+		return
+	}
+	upstreamReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.dataFetcherClient.Do(upstreamReq)
+	if err != nil {
+		writeError(w, "Failed to communicate with data fetcher", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		writeError(w, "Team not found", http.StatusNotFound)
+		return
+	}
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(respBody)), resp.StatusCode)
+		return
+	}
+
+	var team TeamBrandingResult
+	if err := json.NewDecoder(resp.Body).Decode(&team); err != nil {
+		writeError(w, "Failed to parse data fetcher response", http.StatusInternalServerError)
+		return
+	}
+
+	// Cached team responses carry the old branding
+	s.queryCache.Clear()
+	writeJSON(w, team)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeHexColor(t *testing.T) {
+	for input, want := range map[string]string{
+		"#0c2340": "#0C2340",
+		"BD3039":  "#BD3039",
+		" #fa0 ":  "#FFAA00",
+	} {
+		color, ok := normalizeHexColor(input)
+		assert.True(t, ok, input)
+		assert.Equal(t, want, color, input)
+	}
+	for _, bad := range []string{"navy", "#12345", "#GGGGGG", ""} {
+		_, ok := normalizeHexColor(bad)
+		assert.False(t, ok, bad)
+	}
+}
+
+func TestTeamBrandingRequestNormalize(t *testing.T) {
+	primary, slug := "bd3039", " Boston-Red-Sox "
+	req := TeamBrandingRequest{PrimaryColor: &primary, LogoSlug: &slug}
+	msg, _ := req.normalize()
+	assert.Empty(t, msg)
+	assert.Equal(t, "#BD3039", *req.PrimaryColor)
+	assert.Equal(t, "boston-red-sox", *req.LogoSlug)
+	assert.Nil(t, req.SecondaryColor)
+
+	bad := "navy"
+	msg, details := (&TeamBrandingRequest{SecondaryColor: &bad}).normalize()
+	assert.Equal(t, "secondary_color must be a hex color like #0C2340", msg)
+	assert.Equal(t, "navy", details["secondary_color"])
+
+	badSlug := "red sox"
+	msg, _ = (&TeamBrandingRequest{LogoSlug: &badSlug}).normalize()
+	assert.NotEmpty(t, msg)
+}
+
+func TestPutTeamBrandingHandler(t *testing.T) {
+	var forwarded map[string]*string
+	fetcher := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/teams/111/branding" {
+			http.Error(w, `{"detail":"Team not found"}`, http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &forwarded)
+		w.Write([]byte(`{"id": "4f1e", "team_id": "111", "name": "Boston Red Sox",
+			"primary_color": "#BD3039", "secondary_color": null, "logo_slug": "boston-red-sox",
+			"branding_updated_at": "2026-04-01T12:00:00+00:00"}`))
+	}))
+	defer fetcher.Close()
+
+	keys, err := ParseAPIKeys("admin-key:internal,std-key:standard", "free")
+	assert.NoError(t, err)
+	s := &Server{
+		config:            &Config{DataFetcherURL: fetcher.URL},
+		apiKeys:           keys,
+		queryCache:        NewQueryCache(),
+		dataFetcherClient: NewUpstreamClient("data-fetcher", 2),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/admin/teams/{id}/branding", s.putTeamBrandingHandler).Methods("PUT")
+
+	put := func(id, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/teams/"+id+"/branding", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	valid := `{"primary_color": "bd3039", "logo_slug": "boston-red-sox"}`
+
+	rec := put("111", "admin-key", valid)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var team TeamBrandingResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &team))
+	assert.Equal(t, "#BD3039", *team.PrimaryColor)
+	assert.Equal(t, "#BD3039", *forwarded["primary_color"], "colors are normalized before forwarding")
+	assert.Nil(t, forwarded["secondary_color"])
+
+	assert.Equal(t, http.StatusForbidden, put("111", "std-key", valid).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, put("111", "admin-key", `{"primary_color": "red"}`).Code)
+	assert.Equal(t, http.StatusNotFound, put("999", "admin-key", valid).Code)
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+)
+
+// teamDefenseTotals are one team's defensive counts over a season, from box
+// scores: outs from its pitchers, balls in play from the opposing batters,
+// and errors, putouts and assists from its fielders' game logs
+type teamDefenseTotals struct {
+	TeamID     string
+	TeamName   string
+	Games      int
+	Outs       int
+	AtBats     int
+	Hits       int
+	HomeRuns   int
+	Strikeouts int
+	Errors     int
+	PutOuts    int
+	Assists    int
+}
+
+// add accumulates another team's counts, for league totals
+func (t *teamDefenseTotals) add(other teamDefenseTotals) {
+	t.Games += other.Games
+	t.Outs += other.Outs
+	t.AtBats += other.AtBats
+	t.Hits += other.Hits
+	t.HomeRuns += other.HomeRuns
+	t.Strikeouts += other.Strikeouts
+	t.Errors += other.Errors
+	t.PutOuts += other.PutOuts
+	t.Assists += other.Assists
+}
+
+// ballsInPlay are at-bats against that ended with the ball in the field
+func (t teamDefenseTotals) ballsInPlay() int {
+	return t.AtBats - t.Strikeouts - t.HomeRuns
+}
+
+// metrics computes the rates, nil where there is nothing to divide by
+func (t teamDefenseTotals) metrics() TeamDefenseMetrics {
+	var m TeamDefenseMetrics
+	if bip := t.ballsInPlay(); bip > 0 {
+		der := roundTo(1-float64(t.Hits-t.HomeRuns+t.Errors)/float64(bip), 3)
+		m.DefensiveEfficiency = &der
+	}
+	if t.Outs > 0 {
+		perNine := roundTo(float64(t.Errors)*27/float64(t.Outs), 2)
+		m.ErrorsPer9 = &perNine
+	}
+	if chances := t.PutOuts + t.Assists + t.Errors; chances > 0 {
+		fpct := roundTo(float64(t.PutOuts+t.Assists)/float64(chances), 3)
+		m.FieldingPct = &fpct
+	}
+	return m
+}
+
+// TeamDefenseMetrics are defensive rates
+type TeamDefenseMetrics struct {
+	DefensiveEfficiency *float64 `json:"defensive_efficiency"` // share of balls in play turned into outs
+	ErrorsPer9          *float64 `json:"errors_per_9"`
+	FieldingPct         *float64 `json:"fielding_pct"`
+}
+
+// TeamDefense is a team's real defense over a season, the counterpart of
+// the simulation's home/away_defensive_efficiency and errors_per_9
+type TeamDefense struct {
+	TeamID      string  `json:"team_id"`
+	TeamName    string  `json:"team_name"`
+	Season      int     `json:"season"`
+	Games       int     `json:"games"`
+	Innings     float64 `json:"innings"`
+	BallsInPlay int     `json:"balls_in_play"`
+	HitsInPlay  int     `json:"hits_in_play"`
+	Errors      int     `json:"errors"`
+	TeamDefenseMetrics
+	// Rank by defensive efficiency, 1 is best; null without balls in play
+	Rank   *int               `json:"rank"`
+	League TeamDefenseMetrics `json:"league"`
+}
+
+// buildTeamDefense reports one team against the league's totals
+func buildTeamDefense(all []teamDefenseTotals, teamID string, season int) TeamDefense {
+	var team, league teamDefenseTotals
+	for _, totals := range all {
+		league.add(totals)
+		if totals.TeamID == teamID {
+			team = totals
+		}
+	}
+
+	report := TeamDefense{
+		TeamID:             teamID,
+		TeamName:           team.TeamName,
+		Season:             season,
+		Games:              team.Games,
+		Innings:            roundTo(float64(team.Outs)/3, 1),
+		BallsInPlay:        team.ballsInPlay(),
+		HitsInPlay:         team.Hits - team.HomeRuns,
+		Errors:             team.Errors,
+		TeamDefenseMetrics: team.metrics(),
+		League:             league.metrics(),
+	}
+
+	if report.DefensiveEfficiency != nil {
+		var efficiencies []float64
+		for _, totals := range all {
+			if der := totals.metrics().DefensiveEfficiency; der != nil {
+				efficiencies = append(efficiencies, *der)
+			}
+		}
+		sort.Sort(sort.Reverse(sort.Float64Slice(efficiencies)))
+		rank := sort.Search(len(efficiencies), func(i int) bool {
+			return efficiencies[i] <= *report.DefensiveEfficiency
+		}) + 1
+		report.Rank = &rank
+	}
+	return report
+}
+
+// teamDefenseQuery totals every team's defense in season $1, regular season
+// games with box scores only. Innings pitched are stored as x.1 and x.2 for
+// thirds.
+const teamDefenseQuery = `
+	WITH team_games AS (
+		SELECT g.id AS game_id, sides.team_id
+		FROM games g
+		CROSS JOIN LATERAL (VALUES (g.home_team_id), (g.away_team_id)) sides(team_id)
+		WHERE g.season = $1 AND g.status = 'completed' AND %s
+	), pitching AS (
+		SELECT p.team_id, COUNT(DISTINCT p.game_id) AS games,
+		       SUM(FLOOR(p.innings_pitched) * 3 + ROUND((p.innings_pitched - FLOOR(p.innings_pitched)) * 10))::int AS outs
+		FROM game_box_score_pitching p
+		JOIN team_games tg ON tg.game_id = p.game_id AND tg.team_id = p.team_id
+		GROUP BY p.team_id
+	), opposing AS (
+		SELECT tg.team_id, SUM(b.at_bats)::int AS at_bats, SUM(b.hits)::int AS hits,
+		       SUM(b.home_runs)::int AS home_runs, SUM(b.strikeouts)::int AS strikeouts
+		FROM team_games tg
+		JOIN game_box_score_batting b ON b.game_id = tg.game_id AND b.team_id <> tg.team_id
+		GROUP BY tg.team_id
+	), appearances AS (
+		SELECT game_id, player_id, team_id FROM game_box_score_batting
+		UNION
+		SELECT game_id, player_id, team_id FROM game_box_score_pitching
+	), fielding AS (
+		SELECT a.team_id,
+		       SUM(COALESCE((ps.stats->>'errors')::int, 0))::int AS errors,
+		       SUM(COALESCE((ps.stats->>'putOuts')::int, 0))::int AS put_outs,
+		       SUM(COALESCE((ps.stats->>'assists')::int, 0))::int AS assists
+		FROM appearances a
+		JOIN team_games tg ON tg.game_id = a.game_id AND tg.team_id = a.team_id
+		JOIN player_stats ps ON ps.game_id = a.game_id AND ps.player_id = a.player_id
+			AND ps.season = $1 AND ps.stats_type = 'fielding'
+		GROUP BY a.team_id
+	)
+	SELECT t.id::text, t.name, p.games, COALESCE(p.outs, 0),
+	       COALESCE(o.at_bats, 0), COALESCE(o.hits, 0), COALESCE(o.home_runs, 0), COALESCE(o.strikeouts, 0),
+	       COALESCE(f.errors, 0), COALESCE(f.put_outs, 0), COALESCE(f.assists, 0)
+	FROM pitching p
+	JOIN teams t ON t.id = p.team_id
+	LEFT JOIN opposing o ON o.team_id = p.team_id
+	LEFT JOIN fielding f ON f.team_id = p.team_id`
+
+// getTeamDefenseHandler reports a team's defensive efficiency, errors per 9
+// and fielding percentage for a ?season= (default current) from box scores,
+// with the league's rates and the team's rank
+func (s *Server) getTeamDefenseHandler(w http.ResponseWriter, r *http.Request) {
+	season := getCurrentSeason()
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		parsed, err := strconv.Atoi(seasonStr)
+		if err != nil || parsed < firstMLBSeason {
+			writeError(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		season = parsed
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	resolved, ok := s.resolveEntity(ctx, w, ids.Team, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	// Every team's totals are needed for the league rates, so cache them once
+	cacheKey := fmt.Sprintf("team_defense_%d", season)
+	var all []teamDefenseTotals
+	if cached, found := s.queryCache.Get(cacheKey); found {
+		all = cached.([]teamDefenseTotals)
+	} else {
+		gameTypeClause, gameTypeArgs := gameTypeCondition("g.game_type", []string{GameTypeRegular}, 2)
+		rows, err := s.readDB().Query(ctx, fmt.Sprintf(teamDefenseQuery, gameTypeClause), season, gameTypeArgs)
+		if err != nil {
+			log.Printf("Team defense query error: %v", err)
+			writeError(w, "Failed to query team defense", http.StatusInternalServerError)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var totals teamDefenseTotals
+			if err := rows.Scan(&totals.TeamID, &totals.TeamName, &totals.Games, &totals.Outs,
+				&totals.AtBats, &totals.Hits, &totals.HomeRuns, &totals.Strikeouts,
+				&totals.Errors, &totals.PutOuts, &totals.Assists); err != nil {
+				log.Printf("Error scanning team defense: %v", err)
+				continue
+			}
+			all = append(all, totals)
+		}
+		if err := rows.Err(); err != nil {
+			log.Printf("Team defense query error: %v", err)
+			writeError(w, "Failed to query team defense", http.StatusInternalServerError)
+			return
+		}
+		s.queryCache.Set(cacheKey, all, 10*time.Minute)
+	}
+
+	report := buildTeamDefense(all, resolved.ID, season)
+	if report.TeamName == "" {
+		if err := s.readDB().QueryRow(ctx, `SELECT name FROM teams WHERE id = $1`, resolved.ID).Scan(&report.TeamName); err != nil {
+			log.Printf("Team query error: %v", err)
+		}
+	}
+	writeJSON(w, report)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTeamDefense(t *testing.T) {
+	all := []teamDefenseTotals{
+		// 4,000 balls in play, 1,150 hits in play and 80 errors over 1,400 innings
+		{TeamID: "nyy", TeamName: "New York Yankees", Games: 162, Outs: 4200, AtBats: 5500, Hits: 1300,
+			HomeRuns: 150, Strikeouts: 1350, Errors: 80, PutOuts: 4200, Assists: 1500},
+		{TeamID: "bos", TeamName: "Boston Red Sox", Games: 162, Outs: 4200, AtBats: 5500, Hits: 1400,
+			HomeRuns: 150, Strikeouts: 1350, Errors: 100, PutOuts: 4200, Assists: 1450},
+		{TeamID: "new", TeamName: "Expansion", Games: 1, Outs: 27},
+	}
+
+	report := buildTeamDefense(all, "nyy", 2024)
+	assert.Equal(t, "New York Yankees", report.TeamName)
+	assert.Equal(t, 1400.0, report.Innings)
+	assert.Equal(t, 4000, report.BallsInPlay)
+	assert.Equal(t, 1150, report.HitsInPlay)
+	assert.Equal(t, 0.693, *report.DefensiveEfficiency)
+	assert.Equal(t, 0.51, *report.ErrorsPer9)
+	assert.Equal(t, 0.986, *report.FieldingPct)
+	assert.Equal(t, 1, *report.Rank)
+	assert.Equal(t, 0.678, *report.League.DefensiveEfficiency)
+
+	assert.Equal(t, 2, *buildTeamDefense(all, "bos", 2024).Rank)
+
+	// Without balls in play there are no rates to rank
+	empty := buildTeamDefense(all, "new", 2024)
+	assert.Nil(t, empty.DefensiveEfficiency)
+	assert.Nil(t, empty.FieldingPct)
+	assert.Nil(t, empty.Rank)
+	assert.Equal(t, 0.0, *empty.ErrorsPer9)
+
+	missing := buildTeamDefense(all, "sea", 2024)
+	assert.Zero(t, missing.Games)
+	assert.NotNil(t, missing.League.ErrorsPer9)
+}
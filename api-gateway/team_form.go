@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formRunDiffDays is the window for the recent run differential, ending on
+// the team's latest game
+const formRunDiffDays = 14
+
+// formLastGames is the window for the recent record
+const formLastGames = 10
+
+// teamResult is one completed game from a team's point of view
+type teamResult struct {
+	Date        time.Time
+	RunsFor     int
+	RunsAgainst int
+}
+
+// TeamForm summarizes how a team has played lately
+type TeamForm struct {
+	Streak        string `json:"streak"` // e.g. W3 or L2; empty before any games
+	Last10Wins    int    `json:"last_10_wins"`
+	Last10Losses  int    `json:"last_10_losses"`
+	RunDiffLast14 int    `json:"run_diff_last_14"`
+	LastGameDate  string `json:"last_game_date,omitempty"`
+}
+
+// teamFormFrom computes form from a team's results, newest first
+func teamFormFrom(results []teamResult) TeamForm {
+	var form TeamForm
+	if len(results) == 0 {
+		return form
+	}
+	latest := results[0].Date
+	form.LastGameDate = latest.Format("2006-01-02")
+
+	// Streak: consecutive wins or losses from the latest game back
+	streak := 0
+	streakWon := results[0].RunsFor > results[0].RunsAgainst
+	for _, result := range results {
+		if result.RunsFor == result.RunsAgainst || (result.RunsFor > result.RunsAgainst) != streakWon {
+			break
+		}
+		streak++
+	}
+	if streak > 0 {
+		prefix := "L"
+		if streakWon {
+			prefix = "W"
+		}
+		form.Streak = prefix + strconv.Itoa(streak)
+	}
+
+	for i, result := range results {
+		if i < formLastGames {
+			if result.RunsFor > result.RunsAgainst {
+				form.Last10Wins++
+			} else if result.RunsFor < result.RunsAgainst {
+				form.Last10Losses++
+			}
+		}
+		if latest.Sub(result.Date) < formRunDiffDays*24*time.Hour {
+			form.RunDiffLast14 += result.RunsFor - result.RunsAgainst
+		}
+	}
+	return form
+}
+
+// loadTeamResults returns each team's completed games in a season, newest
+// first, keyed by team UUID. teamID is a resolved team UUID, or "" for every
+// team.
+func (s *Server) loadTeamResults(ctx context.Context, season int, gameTypes []string, teamID string) (map[string][]teamResult, error) {
+	gameTypeClause, gameTypeArgs := gameTypeCondition("g.game_type", gameTypes, 2)
+	args := []interface{}{season, gameTypeArgs}
+
+	teamFilter := ""
+	if teamID != "" {
+		teamFilter = " AND t.id = $3"
+		args = append(args, teamID)
+	}
+
+	rows, err := s.readDB().Query(ctx, `
+		SELECT t.id::text, g.game_date,
+			CASE WHEN g.home_team_id = t.id THEN g.final_score_home ELSE g.final_score_away END,
+			CASE WHEN g.home_team_id = t.id THEN g.final_score_away ELSE g.final_score_home END
+		FROM teams t
+		JOIN games g ON g.home_team_id = t.id OR g.away_team_id = t.id
+		WHERE g.season = $1
+			AND `+completedGameCondition+`
+			AND `+gameTypeClause+teamFilter+`
+		ORDER BY g.game_date DESC, g.game_number DESC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make(map[string][]teamResult)
+	for rows.Next() {
+		var id string
+		var result teamResult
+		if err := rows.Scan(&id, &result.Date, &result.RunsFor, &result.RunsAgainst); err != nil {
+			return nil, err
+		}
+		results[id] = append(results[id], result)
+	}
+	return results, rows.Err()
+}
+
+// StandingsTeam is one team's line in the standings
+type StandingsTeam struct {
+	ID           string  `json:"id"`
+	TeamID       string  `json:"team_id"`
+	Name         string  `json:"name"`
+	Abbreviation string  `json:"abbreviation"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	WinningPct   float64 `json:"winning_pct"`
+	GamesBack    float64 `json:"games_back"`
+	RunsScored   int     `json:"runs_scored"`
+	RunsAllowed  int     `json:"runs_allowed"`
+	RunDiff      int     `json:"run_diff"`
+	TeamForm
+
+	league   string // reported on the division
+	division string
+}
+
+// StandingsDivision is one division's standings, leader first
+type StandingsDivision struct {
+	League   string          `json:"league"`
+	Division string          `json:"division"`
+	Teams    []StandingsTeam `json:"teams"`
+}
+
+// buildStandings groups teams into divisions, orders them by winning
+// percentage and computes games back from each division leader
+func buildStandings(teams []StandingsTeam, results map[string][]teamResult) []StandingsDivision {
+	byDivision := make(map[[2]string]*StandingsDivision)
+	var order [][2]string
+
+	for _, team := range teams {
+		for _, result := range results[team.ID] {
+			team.RunsScored += result.RunsFor
+			team.RunsAllowed += result.RunsAgainst
+			if result.RunsFor > result.RunsAgainst {
+				team.Wins++
+			} else if result.RunsFor < result.RunsAgainst {
+				team.Losses++
+			}
+		}
+		team.RunDiff = team.RunsScored - team.RunsAllowed
+		if games := team.Wins + team.Losses; games > 0 {
+			team.WinningPct = roundTo(float64(team.Wins)/float64(games), 3)
+		}
+		team.TeamForm = teamFormFrom(results[team.ID])
+
+		key := [2]string{team.league, team.division}
+		division, ok := byDivision[key]
+		if !ok {
+			division = &StandingsDivision{League: key[0], Division: key[1], Teams: []StandingsTeam{}}
+			byDivision[key] = division
+			order = append(order, key)
+		}
+		division.Teams = append(division.Teams, team)
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		if order[i][0] != order[j][0] {
+			return order[i][0] < order[j][0]
+		}
+		return order[i][1] < order[j][1]
+	})
+
+	divisions := make([]StandingsDivision, 0, len(order))
+	for _, key := range order {
+		division := byDivision[key]
+		sort.SliceStable(division.Teams, func(i, j int) bool {
+			a, b := division.Teams[i], division.Teams[j]
+			if a.WinningPct != b.WinningPct {
+				return a.WinningPct > b.WinningPct
+			}
+			return a.Wins-a.Losses > b.Wins-b.Losses
+		})
+		leader := division.Teams[0]
+		for i := range division.Teams {
+			team := &division.Teams[i]
+			team.GamesBack = float64((leader.Wins-team.Wins)+(team.Losses-leader.Losses)) / 2
+		}
+		divisions = append(divisions, *division)
+	}
+	return divisions
+}
+
+// getStandingsHandler returns division standings with each team's form
+func (s *Server) getStandingsHandler(w http.ResponseWriter, r *http.Request) {
+	season := getCurrentSeason()
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		parsed, err := strconv.Atoi(seasonStr)
+		if err != nil {
+			writeError(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		season = parsed
+	}
+
+	// Standings are regular season unless asked otherwise
+	gameTypes, err := parseGameTypes(r.URL.Query(), regularSeasonOnly)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cacheKey := fmt.Sprintf("standings:%d:%s", season, strings.Join(gameTypes, ","))
+	if cached, ok := s.queryCache.Get(cacheKey); ok {
+		writeJSON(w, cached)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	rows, err := s.readDB().Query(ctx, `
+		SELECT id::text, team_id, name, abbreviation, COALESCE(league, ''), COALESCE(division, '')
+		FROM teams
+		ORDER BY name`)
+	if err != nil {
+		log.Printf("Standings teams query error: %v", err)
+		writeError(w, "Failed to query teams", http.StatusInternalServerError)
+		return
+	}
+	var teams []StandingsTeam
+	for rows.Next() {
+		var team StandingsTeam
+		if err := rows.Scan(&team.ID, &team.TeamID, &team.Name, &team.Abbreviation, &team.league, &team.division); err != nil {
+			rows.Close()
+			log.Printf("Standings teams scan error: %v", err)
+			writeError(w, "Failed to scan teams", http.StatusInternalServerError)
+			return
+		}
+		teams = append(teams, team)
+	}
+	rows.Close()
+
+	results, err := s.loadTeamResults(ctx, season, gameTypes, "")
+	if err != nil {
+		log.Printf("Standings results query error: %v", err)
+		writeError(w, "Failed to query standings", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"season":     season,
+		"game_types": gameTypes,
+		"divisions":  buildStandings(teams, results),
+	}
+	s.queryCache.Set(cacheKey, response, 5*time.Minute)
+	writeJSON(w, response)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// formResults builds results newest first, one day apart, from run pairs
+func formResults(latest time.Time, scores ...[2]int) []teamResult {
+	results := make([]teamResult, len(scores))
+	for i, score := range scores {
+		results[i] = teamResult{Date: latest.AddDate(0, 0, -i), RunsFor: score[0], RunsAgainst: score[1]}
+	}
+	return results
+}
+
+// TestTeamFormFrom tests streak, last-10 record and recent run differential
+func TestTeamFormFrom(t *testing.T) {
+	latest := time.Date(2024, 9, 29, 0, 0, 0, 0, time.UTC)
+	scores := [][2]int{{5, 2}, {3, 1}, {4, 3}, {1, 6}, {2, 0}, {0, 1}, {7, 7}, {5, 4}, {2, 3}, {6, 1}, {9, 0}}
+	results := formResults(latest, scores...)
+	// The 11th game falls outside the 14-day run differential window
+	results[10].Date = latest.AddDate(0, 0, -14)
+
+	form := teamFormFrom(results)
+	assert.Equal(t, "W3", form.Streak)
+	assert.Equal(t, 6, form.Last10Wins)
+	assert.Equal(t, 3, form.Last10Losses) // the tie counts as neither
+	assert.Equal(t, 7, form.RunDiffLast14)
+	assert.Equal(t, "2024-09-29", form.LastGameDate)
+
+	form = teamFormFrom(formResults(latest, [2]int{1, 2}, [2]int{0, 4}, [2]int{3, 2}))
+	assert.Equal(t, "L2", form.Streak)
+
+	assert.Equal(t, TeamForm{}, teamFormFrom(nil))
+}
+
+// TestBuildStandings tests division grouping, ordering and games back
+func TestBuildStandings(t *testing.T) {
+	latest := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	teams := []StandingsTeam{
+		{ID: "bos", Name: "Boston", league: "AL", division: "East"},
+		{ID: "nyy", Name: "New York", league: "AL", division: "East"},
+		{ID: "lad", Name: "Los Angeles", league: "NL", division: "West"},
+	}
+	results := map[string][]teamResult{
+		"nyy": formResults(latest, [2]int{5, 1}, [2]int{4, 2}, [2]int{3, 2}),
+		"bos": formResults(latest, [2]int{1, 5}, [2]int{6, 2}, [2]int{2, 3}),
+	}
+
+	divisions := buildStandings(teams, results)
+	if assert.Len(t, divisions, 2) {
+		east := divisions[0]
+		assert.Equal(t, "AL", east.League)
+		assert.Equal(t, "East", east.Division)
+		assert.Equal(t, "nyy", east.Teams[0].ID)
+		assert.Equal(t, 0.0, east.Teams[0].GamesBack)
+		assert.Equal(t, 2.0, east.Teams[1].GamesBack)
+		assert.Equal(t, 0.333, east.Teams[1].WinningPct)
+		assert.Equal(t, "W3", east.Teams[0].Streak)
+		assert.Equal(t, 7, east.Teams[0].RunDiff)
+
+		// Teams without games still appear
+		assert.Equal(t, "lad", divisions[1].Teams[0].ID)
+		assert.Equal(t, 0, divisions[1].Teams[0].Wins)
+	}
+}
+
+// TestStandingsValidation tests that bad parameters are rejected before any
+// query runs
+func TestStandingsValidation(t *testing.T) {
+	s := &Server{}
+	router := mux.NewRouter()
+	router.HandleFunc("/standings", s.getStandingsHandler)
+
+	for _, path := range []string{"/standings?season=abc", "/standings?game_type=X"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, path)
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Split shortcuts accepted by ?split= on team stats
+const (
+	splitPreAllStar  = "pre_all_star"
+	splitPostAllStar = "post_all_star"
+)
+
+// errAllStarBreakUnknown means a season's schedule doesn't show its break
+var errAllStarBreakUnknown = errors.New("All-Star break not found for season")
+
+// statsDateRange limits team stats to games between two dates, inclusive.
+// A split is resolved into dates once the season's All-Star break is known.
+type statsDateRange struct {
+	From  *time.Time
+	To    *time.Time
+	Split string
+}
+
+// parseStatsDateRange reads ?from= and ?to= (YYYY-MM-DD, either may be
+// omitted) or ?split=pre_all_star|post_all_star. A ?season= that disagrees
+// with the dates is an error rather than silently winning.
+func parseStatsDateRange(query url.Values) (statsDateRange, error) {
+	var dr statsDateRange
+	for _, bound := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &dr.From}, {"to", &dr.To}} {
+		value := query.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return dr, fmt.Errorf("invalid %s date, use YYYY-MM-DD", bound.name)
+		}
+		*bound.dst = &parsed
+	}
+	if dr.From != nil && dr.To != nil {
+		if dr.To.Before(*dr.From) {
+			return dr, fmt.Errorf("to must not be before from")
+		}
+		if dr.From.Year() != dr.To.Year() {
+			return dr, fmt.Errorf("from and to must be in the same season")
+		}
+	}
+	if rangeSeason, ok := dr.season(); ok {
+		if season, err := strconv.Atoi(query.Get("season")); err == nil && season != rangeSeason {
+			return dr, fmt.Errorf("season %d doesn't match from and to, which are in %d", season, rangeSeason)
+		}
+	}
+
+	if split := strings.ToLower(query.Get("split")); split != "" {
+		if split != splitPreAllStar && split != splitPostAllStar {
+			return dr, fmt.Errorf("invalid split, supported: %s, %s", splitPreAllStar, splitPostAllStar)
+		}
+		if dr.From != nil || dr.To != nil {
+			return dr, fmt.Errorf("split can't be combined with from or to")
+		}
+		dr.Split = split
+	}
+	return dr, nil
+}
+
+// season is the season the range falls in, if it names one
+func (dr statsDateRange) season() (int, bool) {
+	switch {
+	case dr.From != nil:
+		return dr.From.Year(), true
+	case dr.To != nil:
+		return dr.To.Year(), true
+	}
+	return 0, false
+}
+
+// resolveSplit turns a split into dates around the All-Star break: games
+// before it, or games after it
+func (dr *statsDateRange) resolveSplit(allStarBreak time.Time) {
+	switch dr.Split {
+	case splitPreAllStar:
+		to := allStarBreak.AddDate(0, 0, -1)
+		dr.To = &to
+	case splitPostAllStar:
+		from := allStarBreak.AddDate(0, 0, 1)
+		dr.From = &from
+	}
+}
+
+// condition restricts column to the range, numbering arguments from argIdx
+func (dr statsDateRange) condition(column string, argIdx int) (string, []interface{}) {
+	var clause string
+	var args []interface{}
+	if dr.From != nil {
+		clause += fmt.Sprintf(" AND %s >= $%d", column, argIdx+len(args))
+		args = append(args, *dr.From)
+	}
+	if dr.To != nil {
+		clause += fmt.Sprintf(" AND %s <= $%d", column, argIdx+len(args))
+		args = append(args, *dr.To)
+	}
+	return clause, args
+}
+
+// contains reports whether a date is in the range
+func (dr statsDateRange) contains(date time.Time) bool {
+	if dr.From != nil && date.Before(*dr.From) {
+		return false
+	}
+	return dr.To == nil || !date.After(*dr.To)
+}
+
+// TeamSplitRecord is a team's record in a subset of its games
+type TeamSplitRecord struct {
+	Wins        int     `json:"wins"`
+	Losses      int     `json:"losses"`
+	WinningPct  float64 `json:"winning_pct"`
+	RunsScored  int     `json:"runs_scored"`
+	RunsAllowed int     `json:"runs_allowed"`
+	RunDiff     int     `json:"run_diff"`
+}
+
+// finish fills in the derived fields
+func (record *TeamSplitRecord) finish() {
+	if record.Wins+record.Losses > 0 {
+		record.WinningPct = roundTo(float64(record.Wins)/float64(record.Wins+record.Losses), 3)
+	}
+	record.RunDiff = record.RunsScored - record.RunsAllowed
+}
+
+// splitRecordColumns selects wins, losses, runs scored and runs allowed in
+// the games matching condition, for the team t in game g
+func splitRecordColumns(condition string) string {
+	return fmt.Sprintf(`
+			COUNT(*) FILTER (WHERE (%[1]s) AND (
+				(g.home_team_id = t.id AND g.final_score_home > g.final_score_away) OR
+				(g.away_team_id = t.id AND g.final_score_away > g.final_score_home))),
+			COUNT(*) FILTER (WHERE (%[1]s) AND (
+				(g.home_team_id = t.id AND g.final_score_home < g.final_score_away) OR
+				(g.away_team_id = t.id AND g.final_score_away < g.final_score_home))),
+			COALESCE(SUM(CASE WHEN g.home_team_id = t.id THEN g.final_score_home ELSE g.final_score_away END)
+				FILTER (WHERE %[1]s), 0),
+			COALESCE(SUM(CASE WHEN g.home_team_id = t.id THEN g.final_score_away ELSE g.final_score_home END)
+				FILTER (WHERE %[1]s), 0)`, condition)
+}
+
+// Conditions for the home, away and division splits of team stats
+const (
+	homeSplitCondition     = "g.home_team_id = t.id"
+	awaySplitCondition     = "g.away_team_id = t.id"
+	divisionSplitCondition = "opp.league = t.league AND opp.division = t.division"
+)
+
+// findAllStarBreak returns the date of a season's All-Star Game, or when it
+// isn't loaded, the first July day without regular season games between
+// July games (the Monday of the break)
+func (s *Server) findAllStarBreak(ctx context.Context, season int) (time.Time, error) {
+	var date *time.Time
+	err := s.readDB().QueryRow(ctx, `
+		WITH july AS (
+			SELECT MIN(game_date) AS first_game, MAX(game_date) AS last_game
+			FROM games
+			WHERE season = $1 AND game_type = ANY($2) AND EXTRACT(MONTH FROM game_date) = 7
+		)
+		SELECT COALESCE(
+			(SELECT MIN(game_date) FROM games WHERE season = $1 AND game_type = 'A'),
+			(SELECT MIN(d::date)
+			 FROM july, generate_series(july.first_game, july.last_game, INTERVAL '1 day') d
+			 WHERE NOT EXISTS (
+				SELECT 1 FROM games g WHERE g.game_date = d::date AND g.game_type = ANY($2)
+			 ))
+		)`, season, gameTypeCodes[GameTypeRegular]).Scan(&date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if date == nil {
+		return time.Time{}, errAllStarBreakUnknown
+	}
+	return *date, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatsDateRange(t *testing.T) {
+	dr, err := parseStatsDateRange(url.Values{"from": {"2024-05-01"}, "to": {"2024-05-31"}})
+	assert.NoError(t, err)
+	season, ok := dr.season()
+	assert.True(t, ok)
+	assert.Equal(t, 2024, season)
+
+	clause, args := dr.condition("g.game_date", 4)
+	assert.Equal(t, " AND g.game_date >= $4 AND g.game_date <= $5", clause)
+	assert.Len(t, args, 2)
+	assert.True(t, dr.contains(time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, dr.contains(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)))
+
+	// Open-ended ranges
+	dr, err = parseStatsDateRange(url.Values{"to": {"2024-06-30"}})
+	assert.NoError(t, err)
+	clause, _ = dr.condition("g.game_date", 4)
+	assert.Equal(t, " AND g.game_date <= $4", clause)
+
+	// A season must agree with the dates
+	_, err = parseStatsDateRange(url.Values{"from": {"2024-05-01"}, "season": {"2024"}})
+	assert.NoError(t, err)
+	_, err = parseStatsDateRange(url.Values{"to": {"2024-06-30"}, "season": {"2023"}})
+	assert.EqualError(t, err, "season 2023 doesn't match from and to, which are in 2024")
+
+	dr, err = parseStatsDateRange(url.Values{})
+	assert.NoError(t, err)
+	_, ok = dr.season()
+	assert.False(t, ok)
+	clause, args = dr.condition("g.game_date", 4)
+	assert.Empty(t, clause)
+	assert.Empty(t, args)
+
+	for _, query := range []url.Values{
+		{"from": {"May 1"}},
+		{"from": {"2024-06-01"}, "to": {"2024-05-01"}},
+		{"from": {"2023-09-01"}, "to": {"2024-05-01"}},
+		{"split": {"second_half"}},
+		{"split": {"pre_all_star"}, "from": {"2024-04-01"}},
+	} {
+		_, err := parseStatsDateRange(query)
+		assert.Error(t, err, query.Encode())
+	}
+}
+
+func TestResolveAllStarSplit(t *testing.T) {
+	allStarBreak := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	pre := statsDateRange{Split: splitPreAllStar}
+	pre.resolveSplit(allStarBreak)
+	assert.Nil(t, pre.From)
+	assert.Equal(t, "2024-07-14", pre.To.Format("2006-01-02"))
+
+	post := statsDateRange{Split: splitPostAllStar}
+	post.resolveSplit(allStarBreak)
+	assert.Nil(t, post.To)
+	assert.Equal(t, "2024-07-16", post.From.Format("2006-01-02"))
+}
+
+func TestTeamSplitRecordFinish(t *testing.T) {
+	record := TeamSplitRecord{Wins: 49, Losses: 32, RunsScored: 410, RunsAllowed: 350}
+	record.finish()
+	assert.Equal(t, 0.605, record.WinningPct)
+	assert.Equal(t, 60, record.RunDiff)
+
+	empty := TeamSplitRecord{}
+	empty.finish()
+	assert.Zero(t, empty.WinningPct)
+
+	columns := splitRecordColumns(homeSplitCondition)
+	assert.Equal(t, 4, strings.Count(columns, "FILTER (WHERE"))
+}
+
+func TestTeamStatsRejectsBadRange(t *testing.T) {
+	s := &Server{}
+	router := mux.NewRouter()
+	router.HandleFunc("/teams/{id}/stats", s.getTeamStatsHandler)
+
+	for _, path := range []string{
+		"/teams/nyy/stats?from=2024-13-01",
+		"/teams/nyy/stats?split=first_half",
+		"/teams/nyy/stats?split=post_all_star&to=2024-09-01",
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, path)
+	}
+}
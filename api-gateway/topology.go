@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// replicaMaxWeight is a healthy replica's share of requests. Each
+	// consecutive failure takes one off; a replica at zero gets no requests
+	// until a health check passes.
+	replicaMaxWeight = 3
+
+	// defaultReplicaHealthInterval is how often (seconds) replicas are probed
+	defaultReplicaHealthInterval = 10
+)
+
+// UpstreamReplica is one instance of a replicated upstream service
+type UpstreamReplica struct {
+	URL    string
+	Region string
+
+	failures      int // consecutive failed requests or health checks
+	currentWeight int // smooth weighted round-robin state
+	lastError     string
+	lastChecked   time.Time
+}
+
+// weight is the replica's share of requests given its recent failures
+func (r *UpstreamReplica) weight() int {
+	return max(replicaMaxWeight-r.failures, 0)
+}
+
+// ReplicaStatus reports one replica's health
+type ReplicaStatus struct {
+	URL         string     `json:"url"`
+	Region      string     `json:"region"`
+	Healthy     bool       `json:"healthy"`
+	Weight      int        `json:"weight"`
+	Failures    int        `json:"consecutive_failures"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastChecked *time.Time `json:"last_checked,omitempty"`
+}
+
+// UpstreamPool spreads requests over an upstream's replicas with smooth
+// weighted round-robin, so healthy replicas share requests evenly and
+// failing ones get fewer. Replicas in the gateway's own region are used
+// while any is healthy; other regions take over only when none is.
+type UpstreamPool struct {
+	name     string
+	region   string
+	mu       sync.Mutex
+	replicas []*UpstreamReplica
+	pins     map[string]upstreamPin
+}
+
+// upstreamPin routes requests about state held by one replica, such as an
+// export job, back to that replica
+type upstreamPin struct {
+	url   string
+	until time.Time
+}
+
+// ParseUpstreamTopology reads replicas as comma-separated URLs, each
+// optionally prefixed with its region: "us-east=http://sim-1:8081,
+// eu-west=http://sim-2:8081". URLs without a region are in the gateway's.
+func ParseUpstreamTopology(name, spec, region string) (*UpstreamPool, error) {
+	pool := &UpstreamPool{name: name, region: region}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		replicaRegion, rawURL := region, entry
+		if prefix, rest, ok := strings.Cut(entry, "="); ok && !strings.Contains(prefix, "://") {
+			replicaRegion, rawURL = strings.TrimSpace(prefix), strings.TrimSpace(rest)
+		}
+		parsed, err := url.Parse(rawURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("%s replica %q is not an absolute URL", name, entry)
+		}
+		pool.replicas = append(pool.replicas, &UpstreamReplica{
+			URL:    strings.TrimSuffix(rawURL, "/"),
+			Region: replicaRegion,
+		})
+	}
+	if len(pool.replicas) == 0 {
+		return nil, fmt.Errorf("%s needs at least one replica URL", name)
+	}
+	return pool, nil
+}
+
+// URL picks the base URL for the next request
+func (p *UpstreamPool) URL() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	candidates := p.candidates()
+	var chosen *UpstreamReplica
+	total := 0
+	for _, r := range candidates {
+		w := max(r.weight(), 1) // every candidate gets a turn when all are down
+		r.currentWeight += w
+		total += w
+		if chosen == nil || r.currentWeight > chosen.currentWeight {
+			chosen = r
+		}
+	}
+	chosen.currentWeight -= total
+	return chosen.URL
+}
+
+// Pin sends later requests for key to the replica at base until the given
+// time. Expired pins are dropped as new ones are added.
+func (p *UpstreamPool) Pin(key, base string, until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for k, pin := range p.pins {
+		if now.After(pin.until) {
+			delete(p.pins, k)
+		}
+	}
+	if p.pins == nil {
+		p.pins = make(map[string]upstreamPin)
+	}
+	p.pins[key] = upstreamPin{url: base, until: until}
+}
+
+// PinnedURL is the base URL of the replica key is pinned to, whatever its
+// health, since no other replica can answer for it. Unpinned keys are
+// balanced like any other request.
+func (p *UpstreamPool) PinnedURL(key string) string {
+	p.mu.Lock()
+	pin, ok := p.pins[key]
+	p.mu.Unlock()
+	if ok && time.Now().Before(pin.until) {
+		return pin.url
+	}
+	return p.URL()
+}
+
+// candidates are the healthy local replicas, else the healthy replicas in
+// other regions, else every replica so requests still go somewhere
+func (p *UpstreamPool) candidates() []*UpstreamReplica {
+	var local, remote []*UpstreamReplica
+	for _, r := range p.replicas {
+		if r.weight() == 0 {
+			continue
+		}
+		if r.Region == p.region {
+			local = append(local, r)
+		} else {
+			remote = append(remote, r)
+		}
+	}
+	switch {
+	case len(local) > 0:
+		return local
+	case len(remote) > 0:
+		return remote
+	}
+	return p.replicas
+}
+
+// observe records a request's outcome against the replica that served it.
+// Transport errors and gateway-level 5xx responses count as failures.
+func (p *UpstreamPool) observe(requestURL *url.URL, statusCode int, err error) {
+	failed := err != nil || statusCode == http.StatusBadGateway ||
+		statusCode == http.StatusServiceUnavailable || statusCode == http.StatusGatewayTimeout
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	} else if failed {
+		msg = http.StatusText(statusCode)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, r := range p.replicas {
+		if r.serves(requestURL.String()) {
+			p.record(r, failed, msg)
+			return
+		}
+	}
+}
+
+// serves reports whether a request URL is under the replica's base URL
+func (r *UpstreamReplica) serves(requestURL string) bool {
+	rest, ok := strings.CutPrefix(requestURL, r.URL)
+	return ok && (rest == "" || rest[0] == '/' || rest[0] == '?')
+}
+
+func (p *UpstreamPool) record(r *UpstreamReplica, failed bool, msg string) {
+	if !failed {
+		r.failures = 0
+		r.lastError = ""
+		return
+	}
+	if r.weight() > 0 && r.failures+1 >= replicaMaxWeight {
+		log.Printf("%s replica %s marked unhealthy: %s", p.name, r.URL, msg)
+	}
+	r.failures = min(r.failures+1, replicaMaxWeight)
+	r.lastError = msg
+}
+
+// checkHealth probes every replica's /health. A failed probe takes a
+// replica out of rotation at once; a passing one restores its full weight.
+func (p *UpstreamPool) checkHealth(ctx context.Context, client *http.Client) {
+	p.mu.Lock()
+	urls := make([]string, len(p.replicas))
+	for i, r := range p.replicas {
+		urls[i] = r.URL
+	}
+	p.mu.Unlock()
+
+	for i, base := range urls {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/health", nil)
+		var resp *http.Response
+		if err == nil {
+			resp, err = client.Do(req)
+		}
+		healthy, msg := err == nil && resp.StatusCode < 400, ""
+		if err != nil {
+			msg = err.Error()
+		} else {
+			resp.Body.Close()
+			if !healthy {
+				msg = fmt.Sprintf("health check returned %d", resp.StatusCode)
+			}
+		}
+
+		p.mu.Lock()
+		r := p.replicas[i]
+		r.lastChecked = time.Now()
+		if healthy {
+			if r.weight() == 0 {
+				log.Printf("%s replica %s is healthy again", p.name, r.URL)
+			}
+			p.record(r, false, "")
+		} else {
+			if r.weight() > 0 {
+				log.Printf("%s replica %s marked unhealthy: %s", p.name, r.URL, msg)
+			}
+			r.failures = replicaMaxWeight
+			r.lastError = msg
+		}
+		p.mu.Unlock()
+	}
+}
+
+// Status reports each replica's health
+func (p *UpstreamPool) Status() []ReplicaStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	statuses := make([]ReplicaStatus, 0, len(p.replicas))
+	for _, r := range p.replicas {
+		status := ReplicaStatus{
+			URL:       r.URL,
+			Region:    r.Region,
+			Healthy:   r.weight() > 0,
+			Weight:    r.weight(),
+			Failures:  r.failures,
+			LastError: r.lastError,
+		}
+		if !r.lastChecked.IsZero() {
+			checked := r.lastChecked
+			status.LastChecked = &checked
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// simEngineURL is the base URL of the sim-engine replica for the next request
+func (s *Server) simEngineURL() string {
+	if s.simEngines == nil {
+		return s.config.SimEngineURL
+	}
+	return s.simEngines.URL()
+}
+
+// simEngineURLFor is the base URL of the sim-engine replica holding key's
+// state, or the next replica when key isn't pinned
+func (s *Server) simEngineURLFor(key string) string {
+	if s.simEngines == nil {
+		return s.config.SimEngineURL
+	}
+	return s.simEngines.PinnedURL(key)
+}
+
+// pinSimEngine routes requests for key to the replica at base until the
+// given time
+func (s *Server) pinSimEngine(key, base string, until time.Time) {
+	if s.simEngines != nil {
+		s.simEngines.Pin(key, base, until)
+	}
+}
+
+// checkReplicasPeriodically probes the sim-engine replicas until ctx is done
+func (s *Server) checkReplicasPeriodically(ctx context.Context, interval time.Duration) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.simEngines.checkHealth(ctx, client)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpstreamTopology(t *testing.T) {
+	pool, err := ParseUpstreamTopology("sim-engine", " http://sim-1:8081/, eu-west=http://sim-2:8081 ,", "us-east")
+	require.NoError(t, err)
+	require.Len(t, pool.replicas, 2)
+	assert.Equal(t, "http://sim-1:8081", pool.replicas[0].URL)
+	assert.Equal(t, "us-east", pool.replicas[0].Region, "bare URLs are in the gateway's region")
+	assert.Equal(t, "http://sim-2:8081", pool.replicas[1].URL)
+	assert.Equal(t, "eu-west", pool.replicas[1].Region)
+
+	_, err = ParseUpstreamTopology("sim-engine", "sim-1:8081", "")
+	assert.Error(t, err)
+	_, err = ParseUpstreamTopology("sim-engine", " , ", "")
+	assert.Error(t, err)
+}
+
+func TestUpstreamPoolRoundRobin(t *testing.T) {
+	pool, err := ParseUpstreamTopology("sim-engine", "http://a,http://b,http://c", "")
+	require.NoError(t, err)
+
+	counts := map[string]int{}
+	for i := 0; i < 9; i++ {
+		counts[pool.URL()]++
+	}
+	assert.Equal(t, map[string]int{"http://a": 3, "http://b": 3, "http://c": 3}, counts)
+}
+
+func TestUpstreamPoolFailures(t *testing.T) {
+	pool, err := ParseUpstreamTopology("sim-engine", "http://a,http://b", "")
+	require.NoError(t, err)
+	failing, _ := url.Parse("http://a/simulate")
+
+	pool.observe(failing, http.StatusBadGateway, nil)
+	counts := map[string]int{}
+	for i := 0; i < 5; i++ {
+		counts[pool.URL()]++
+	}
+	assert.Equal(t, 2, counts["http://a"], "one failure drops a replica to weight 2")
+	assert.Equal(t, 3, counts["http://b"])
+
+	pool.observe(failing, 0, errors.New("connection refused"))
+	pool.observe(failing, http.StatusGatewayTimeout, nil)
+	for i := 0; i < 6; i++ {
+		assert.Equal(t, "http://b", pool.URL(), "a replica out of weight gets no requests")
+	}
+	assert.False(t, pool.Status()[0].Healthy)
+	assert.Equal(t, "Gateway Timeout", pool.Status()[0].LastError)
+
+	pool.observe(failing, http.StatusNotFound, nil)
+	status := pool.Status()[0]
+	assert.True(t, status.Healthy, "client errors are the replica working")
+	assert.Equal(t, replicaMaxWeight, status.Weight)
+}
+
+func TestUpstreamPoolRegions(t *testing.T) {
+	pool, err := ParseUpstreamTopology("sim-engine", "us-east=http://east,eu-west=http://west", "us-east")
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, "http://east", pool.URL())
+	}
+
+	east, _ := url.Parse("http://east/health")
+	for i := 0; i < replicaMaxWeight; i++ {
+		pool.observe(east, http.StatusServiceUnavailable, nil)
+	}
+	assert.Equal(t, "http://west", pool.URL(), "other regions take over when the local one is down")
+
+	west, _ := url.Parse("http://west/health")
+	for i := 0; i < replicaMaxWeight; i++ {
+		pool.observe(west, http.StatusServiceUnavailable, nil)
+	}
+	seen := map[string]bool{}
+	for i := 0; i < 4; i++ {
+		seen[pool.URL()] = true
+	}
+	assert.Len(t, seen, 2, "with every replica down requests still go somewhere")
+}
+
+func TestUpstreamPoolCheckHealth(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/health", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	pool, err := ParseUpstreamTopology("sim-engine", healthy.URL+","+unhealthy.URL, "")
+	require.NoError(t, err)
+	failing, _ := url.Parse(healthy.URL + "/simulate")
+	pool.observe(failing, 0, errors.New("timeout"))
+
+	pool.checkHealth(context.Background(), healthy.Client())
+	status := pool.Status()
+	assert.True(t, status[0].Healthy)
+	assert.Equal(t, 0, status[0].Failures, "a passing check restores full weight")
+	assert.NotNil(t, status[0].LastChecked)
+	assert.False(t, status[1].Healthy, "a failed check takes a replica out at once")
+	assert.Equal(t, "health check returned 503", status[1].LastError)
+}
+
+func TestUpstreamReplicaServes(t *testing.T) {
+	r := &UpstreamReplica{URL: "http://sim-1:8081"}
+	assert.True(t, r.serves("http://sim-1:8081"))
+	assert.True(t, r.serves("http://sim-1:8081/simulate"))
+	assert.True(t, r.serves("http://sim-1:8081?x=1"))
+	assert.False(t, r.serves("http://sim-1:80810/simulate"))
+	assert.False(t, r.serves("http://sim-2:8081/simulate"))
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultLeaderboardLimit = 25
+	maxLeaderboardLimit     = 100
+)
+
+// umpireLeaderboardMetrics maps leaderboard metric names to their
+// umpire_season_stats columns
+var umpireLeaderboardMetrics = map[string]string{
+	"accuracy_pct":     "accuracy_pct",
+	"consistency_pct":  "consistency_pct",
+	"favor_home":       "favor_home",
+	"strike_pct":       "strike_pct",
+	"k_pct_above_avg":  "k_pct_above_avg",
+	"bb_pct_above_avg": "bb_pct_above_avg",
+}
+
+// UmpirePercentiles ranks an umpire's season against every umpire with the
+// same stat that season. 100 is the highest value in the league and 0 the
+// lowest; for favor_home a high percentile means the most home-friendly zone.
+type UmpirePercentiles struct {
+	AccuracyPct    *float64 `json:"accuracy_pct,omitempty"`
+	ConsistencyPct *float64 `json:"consistency_pct,omitempty"`
+	FavorHome      *float64 `json:"favor_home,omitempty"`
+	UmpiresRanked  int      `json:"umpires_ranked"`
+}
+
+// UmpireLeaderboardEntry is one umpire's position on a season leaderboard
+type UmpireLeaderboardEntry struct {
+	Rank       int     `json:"rank"`
+	ID         string  `json:"id"`
+	UmpireID   string  `json:"umpire_id"`
+	Name       string  `json:"name"`
+	GamesUmped int     `json:"games_umped"`
+	Value      float64 `json:"value"`
+	Percentile float64 `json:"percentile"`
+}
+
+// UmpireLeaderboard ranks a season's umpires by one metric
+type UmpireLeaderboard struct {
+	Season        int                      `json:"season"`
+	Metric        string                   `json:"metric"`
+	Order         string                   `json:"order"`
+	MinGames      int                      `json:"min_games"`
+	UmpiresRanked int                      `json:"umpires_ranked"`
+	Umpires       []UmpireLeaderboardEntry `json:"umpires"`
+}
+
+// umpirePercentileExpr returns a window expression giving a column's 0-100
+// percentile rank within its season. Rows missing the stat are partitioned
+// off so they neither get a rank nor push everyone else down.
+func umpirePercentileExpr(column string) string {
+	return fmt.Sprintf(`CASE WHEN %[1]s IS NOT NULL THEN
+		ROUND((PERCENT_RANK() OVER (PARTITION BY season, %[1]s IS NULL ORDER BY %[1]s) * 100)::numeric, 1)::float8
+	END`, column)
+}
+
+// umpireSeasonStatsRankedQuery selects umpire season stats alongside their
+// league percentiles for a resolved umpire UUID; callers append any season
+// filter
+var umpireSeasonStatsRankedQuery = `
+	WITH ranked AS (
+		SELECT uss.*,
+		       ` + umpirePercentileExpr("accuracy_pct") + ` AS accuracy_pctile,
+		       ` + umpirePercentileExpr("consistency_pct") + ` AS consistency_pctile,
+		       ` + umpirePercentileExpr("favor_home") + ` AS favor_home_pctile,
+		       COUNT(*) OVER (PARTITION BY season) AS umpires_ranked
+		FROM umpire_season_stats uss
+	)
+	SELECT r.season, r.games_umped, r.accuracy_pct, r.consistency_pct,
+	       r.favor_home, r.expected_accuracy, r.expected_consistency,
+	       r.correct_calls, r.incorrect_calls, r.total_calls,
+	       r.strike_pct, r.ball_pct, r.k_pct_above_avg, r.bb_pct_above_avg,
+	       r.home_plate_calls_per_game, r.created_at, r.updated_at,
+	       r.accuracy_pctile, r.consistency_pctile, r.favor_home_pctile, r.umpires_ranked
+	FROM ranked r
+	JOIN umpires u ON r.umpire_id = u.id
+	WHERE u.id = $1`
+
+// parseLeaderboardOrder validates ?order=, defaulting to highest first
+func parseLeaderboardOrder(order string) (string, bool) {
+	switch strings.ToLower(order) {
+	case "", "desc":
+		return "desc", true
+	case "asc":
+		return "asc", true
+	}
+	return "", false
+}
+
+// getUmpireLeaderboardHandler ranks a season's umpires by one metric, e.g.
+// /umpires/leaderboard?season=2024&metric=accuracy_pct&min_games=10
+func (s *Server) getUmpireLeaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	season := getCurrentSeason()
+	if seasonStr := query.Get("season"); seasonStr != "" {
+		parsed, err := strconv.Atoi(seasonStr)
+		if err != nil {
+			writeError(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		season = parsed
+	}
+
+	metric := query.Get("metric")
+	if metric == "" {
+		metric = "accuracy_pct"
+	}
+	column, ok := umpireLeaderboardMetrics[metric]
+	if !ok {
+		writeError(w, "Invalid metric parameter (expected accuracy_pct, consistency_pct, favor_home, strike_pct, k_pct_above_avg or bb_pct_above_avg)", http.StatusBadRequest)
+		return
+	}
+
+	order, ok := parseLeaderboardOrder(query.Get("order"))
+	if !ok {
+		writeError(w, "Invalid order parameter (expected asc or desc)", http.StatusBadRequest)
+		return
+	}
+
+	minGames := 0
+	if minStr := query.Get("min_games"); minStr != "" {
+		parsed, err := strconv.Atoi(minStr)
+		if err != nil || parsed < 0 {
+			writeError(w, "Invalid min_games parameter", http.StatusBadRequest)
+			return
+		}
+		minGames = parsed
+	}
+
+	limit := defaultLeaderboardLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > maxLeaderboardLimit {
+			writeError(w, fmt.Sprintf("Invalid limit parameter (1-%d)", maxLeaderboardLimit), http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	// Column and order come from the allowlists above
+	sql := fmt.Sprintf(`
+		WITH ranked AS (
+			SELECT u.id::text AS id, u.umpire_id, u.name, uss.games_umped,
+			       uss.%[1]s::float8 AS value,
+			       RANK() OVER (ORDER BY uss.%[1]s %[2]s) AS rank,
+			       ROUND((PERCENT_RANK() OVER (ORDER BY uss.%[1]s) * 100)::numeric, 1)::float8 AS percentile,
+			       COUNT(*) OVER () AS ranked_count
+			FROM umpire_season_stats uss
+			JOIN umpires u ON uss.umpire_id = u.id
+			WHERE uss.season = $1 AND uss.%[1]s IS NOT NULL AND uss.games_umped >= $2
+		)
+		SELECT rank, id, umpire_id, name, games_umped, value, percentile, ranked_count
+		FROM ranked
+		ORDER BY rank, name
+		LIMIT $3`, column, strings.ToUpper(order))
+
+	rows, err := s.readDB().Query(ctx, sql, season, minGames, limit)
+	if err != nil {
+		log.Printf("Failed to query umpire leaderboard: %v (season=%d, metric=%s)", err, season, metric)
+		writeError(w, "Failed to query umpire leaderboard", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	leaderboard := UmpireLeaderboard{
+		Season:   season,
+		Metric:   metric,
+		Order:    order,
+		MinGames: minGames,
+		Umpires:  []UmpireLeaderboardEntry{},
+	}
+	for rows.Next() {
+		var entry UmpireLeaderboardEntry
+		if err := rows.Scan(&entry.Rank, &entry.ID, &entry.UmpireID, &entry.Name,
+			&entry.GamesUmped, &entry.Value, &entry.Percentile, &leaderboard.UmpiresRanked); err != nil {
+			log.Printf("Failed to scan umpire leaderboard row: %v", err)
+			writeError(w, "Failed to scan umpire leaderboard", http.StatusInternalServerError)
+			return
+		}
+		leaderboard.Umpires = append(leaderboard.Umpires, entry)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to read umpire leaderboard: %v", err)
+		writeError(w, "Failed to query umpire leaderboard", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, leaderboard)
+}
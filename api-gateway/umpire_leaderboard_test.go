@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseLeaderboardOrder tests order validation and its default
+func TestParseLeaderboardOrder(t *testing.T) {
+	order, ok := parseLeaderboardOrder("")
+	assert.True(t, ok)
+	assert.Equal(t, "desc", order)
+
+	order, ok = parseLeaderboardOrder("ASC")
+	assert.True(t, ok)
+	assert.Equal(t, "asc", order)
+
+	_, ok = parseLeaderboardOrder("sideways")
+	assert.False(t, ok)
+}
+
+// TestUmpireLeaderboardMetrics tests that every metric maps to a stats column
+// and that the percentile query ranks the headline metrics
+func TestUmpireLeaderboardMetrics(t *testing.T) {
+	for metric, column := range umpireLeaderboardMetrics {
+		assert.Equal(t, metric, column)
+		assert.NotContains(t, column, " ")
+	}
+
+	for _, column := range []string{"accuracy_pct", "consistency_pct", "favor_home"} {
+		assert.Contains(t, umpireSeasonStatsRankedQuery, "ORDER BY "+column+")")
+	}
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(umpireSeasonStatsRankedQuery), "u.id = $1"))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// umpireListSorts maps ?sort= on the umpire list to the columns it orders by.
+// Stats come from the season joined into each row.
+var umpireListSorts = map[string]string{
+	"name":             "u.name",
+	"games":            "s.games_umped",
+	"games_umped":      "s.games_umped",
+	"accuracy_pct":     "s.accuracy_pct",
+	"consistency_pct":  "s.consistency_pct",
+	"favor_home":       "s.favor_home",
+	"strike_pct":       "s.strike_pct",
+	"k_pct_above_avg":  "s.k_pct_above_avg",
+	"bb_pct_above_avg": "s.bb_pct_above_avg",
+}
+
+// UmpireListStats is the season of stats shown with an umpire in the list
+type UmpireListStats struct {
+	Season         int      `json:"season"`
+	GamesUmped     int      `json:"games_umped"`
+	AccuracyPct    *float64 `json:"accuracy_pct,omitempty"`
+	ConsistencyPct *float64 `json:"consistency_pct,omitempty"`
+	FavorHome      *float64 `json:"favor_home,omitempty"`
+	StrikePct      *float64 `json:"strike_pct,omitempty"`
+	KPctAboveAvg   *float64 `json:"k_pct_above_avg,omitempty"`
+	BBPctAboveAvg  *float64 `json:"bb_pct_above_avg,omitempty"`
+}
+
+// umpireListFilters are the umpire list's query parameters
+type umpireListFilters struct {
+	Season   *int // nil joins each umpire's latest season
+	MinGames int
+	Name     string
+	Sort     string
+	Order    string
+}
+
+// parseUmpireListFilters reads ?season=, ?min_games=, ?name=, ?sort= and
+// ?order=, returning a message for the first invalid one. Name sorts default
+// to ascending and stat sorts to descending.
+func parseUmpireListFilters(query url.Values) (umpireListFilters, string) {
+	filters := umpireListFilters{Name: strings.TrimSpace(query.Get("name")), Sort: "name"}
+
+	if seasonStr := query.Get("season"); seasonStr != "" {
+		season, err := strconv.Atoi(seasonStr)
+		if err != nil || season < firstMLBSeason {
+			return filters, "Invalid season parameter"
+		}
+		filters.Season = &season
+	}
+
+	if minStr := query.Get("min_games"); minStr != "" {
+		minGames, err := strconv.Atoi(minStr)
+		if err != nil || minGames < 0 {
+			return filters, "Invalid min_games parameter"
+		}
+		filters.MinGames = minGames
+	}
+
+	if sort := strings.ToLower(query.Get("sort")); sort != "" {
+		if _, ok := umpireListSorts[sort]; !ok {
+			return filters, "Invalid sort parameter (expected name, games_umped, accuracy_pct, consistency_pct, favor_home, strike_pct, k_pct_above_avg or bb_pct_above_avg)"
+		}
+		filters.Sort = sort
+	}
+
+	filters.Order = "desc"
+	if filters.Sort == "name" {
+		filters.Order = "asc"
+	}
+	if orderStr := query.Get("order"); orderStr != "" {
+		order, ok := parseLeaderboardOrder(orderStr)
+		if !ok {
+			return filters, "Invalid order parameter (expected asc or desc)"
+		}
+		filters.Order = order
+	}
+	return filters, ""
+}
+
+// query builds the FROM, WHERE and ORDER BY clauses selecting umpires with
+// their season stats. Umpires without stats for the season are kept unless
+// min_games requires some, and sort last on stat sorts.
+func (f umpireListFilters) query() (from, where, orderBy string, args []interface{}) {
+	seasonCondition := ""
+	if f.Season != nil {
+		args = append(args, *f.Season)
+		seasonCondition = fmt.Sprintf(" AND uss.season = $%d", len(args))
+	}
+	from = fmt.Sprintf(`
+		FROM umpires u
+		LEFT JOIN LATERAL (
+			SELECT uss.season, uss.games_umped, uss.accuracy_pct, uss.consistency_pct, uss.favor_home,
+			       uss.strike_pct, uss.k_pct_above_avg, uss.bb_pct_above_avg
+			FROM umpire_season_stats uss
+			WHERE uss.umpire_id = u.id%s
+			ORDER BY uss.season DESC
+			LIMIT 1
+		) s ON true`, seasonCondition)
+
+	var conditions []string
+	if f.MinGames > 0 {
+		args = append(args, f.MinGames)
+		conditions = append(conditions, fmt.Sprintf("s.games_umped >= $%d", len(args)))
+	}
+	if f.Name != "" {
+		args = append(args, "%"+f.Name+"%")
+		conditions = append(conditions, fmt.Sprintf("u.name ILIKE $%d", len(args)))
+	}
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Column and order come from the allowlists above
+	orderBy = fmt.Sprintf(" ORDER BY %s %s NULLS LAST", umpireListSorts[f.Sort], strings.ToUpper(f.Order))
+	if f.Sort != "name" {
+		orderBy += ", u.name ASC"
+	}
+	return from, where, orderBy, args
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUmpireListFilters(t *testing.T) {
+	filters, msg := parseUmpireListFilters(url.Values{})
+	assert.Empty(t, msg)
+	assert.Nil(t, filters.Season)
+	assert.Equal(t, "name", filters.Sort)
+	assert.Equal(t, "asc", filters.Order)
+
+	filters, msg = parseUmpireListFilters(url.Values{"sort": {"accuracy_pct"}, "min_games": {"10"}, "season": {"2024"}})
+	assert.Empty(t, msg)
+	assert.Equal(t, 2024, *filters.Season)
+	assert.Equal(t, 10, filters.MinGames)
+	assert.Equal(t, "desc", filters.Order, "stat sorts default to highest first")
+
+	for _, query := range []url.Values{
+		{"sort": {"tendencies"}},
+		{"order": {"sideways"}},
+		{"min_games": {"-1"}},
+		{"season": {"last"}},
+	} {
+		_, msg := parseUmpireListFilters(query)
+		assert.NotEmpty(t, msg, "expected %v to be rejected", query)
+	}
+}
+
+func TestUmpireListQuery(t *testing.T) {
+	season := 2024
+	from, where, orderBy, args := umpireListFilters{
+		Season: &season, MinGames: 10, Name: "west", Sort: "accuracy_pct", Order: "desc",
+	}.query()
+
+	assert.Contains(t, from, "uss.season = $1")
+	assert.Equal(t, " WHERE s.games_umped >= $2 AND u.name ILIKE $3", where)
+	assert.Equal(t, " ORDER BY s.accuracy_pct DESC NULLS LAST, u.name ASC", orderBy)
+	assert.Equal(t, []interface{}{2024, 10, "%west%"}, args)
+
+	from, where, orderBy, args = umpireListFilters{Sort: "name", Order: "asc"}.query()
+	assert.NotContains(t, from, "uss.season =")
+	assert.Empty(t, where)
+	assert.Equal(t, " ORDER BY u.name ASC NULLS LAST", orderBy)
+	assert.Empty(t, args)
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultZoneGridSize = 5
+	minZoneGridSize     = 3
+	maxZoneGridSize     = 9
+
+	// Zone grids are cached for an hour; pitch data only changes on fetch
+	umpireZoneCacheTTL = time.Hour
+)
+
+// umpireZoneBounds is the area the grid covers, in feet from the catcher's
+// view: plate_x across the plate (negative is the third base side) and
+// plate_z above the ground. It spans the rulebook zone plus a ball's width
+// or so on every side, where the calls are made.
+var umpireZoneBounds = ZoneBounds{XMin: -1.25, XMax: 1.25, ZMin: 1.0, ZMax: 4.0}
+
+// calledPitchResults are the pitch results that are umpire calls
+var calledPitchResults = []string{"Called Strike", "Ball", "Ball In Dirt"}
+
+// ZoneBounds is a rectangle of the hitting area in feet
+type ZoneBounds struct {
+	XMin float64 `json:"x_min"`
+	XMax float64 `json:"x_max"`
+	ZMin float64 `json:"z_min"`
+	ZMax float64 `json:"z_max"`
+}
+
+// UmpireZoneCell is one zone of the grid. Probabilities are null when the
+// zone has no called pitches.
+type UmpireZoneCell struct {
+	Row                       int        `json:"row"`    // 1 is the top of the grid
+	Column                    int        `json:"column"` // 1 is the third base side
+	Bounds                    ZoneBounds `json:"bounds"`
+	CalledPitches             int        `json:"called_pitches"`
+	CalledStrikes             int        `json:"called_strikes"`
+	StrikeProbability         *float64   `json:"strike_probability"`
+	LeagueStrikeProbability   *float64   `json:"league_strike_probability"`
+	StrikeProbabilityAboveAvg *float64   `json:"strike_probability_above_avg"`
+}
+
+// UmpireZoneGrid is an umpire's called-strike grid against one batter hand,
+// with cells ordered row by row from the top left
+type UmpireZoneGrid struct {
+	CalledPitches int              `json:"called_pitches"`
+	Cells         []UmpireZoneCell `json:"cells"`
+}
+
+// UmpireZone is an umpire's called-strike probability by zone for a season,
+// split by batter hand. Switch hitters are left out since the side they
+// batted from isn't stored with the pitch.
+type UmpireZone struct {
+	ID           string                     `json:"id"`
+	UmpireID     string                     `json:"umpire_id"`
+	Name         string                     `json:"name"`
+	Season       int                        `json:"season"`
+	GridSize     int                        `json:"grid_size"`
+	Bounds       ZoneBounds                 `json:"bounds"`
+	ByBatterHand map[string]*UmpireZoneGrid `json:"by_batter_hand"` // "L" and "R"
+}
+
+// zoneCellCount is the called pitches in one cell, for the umpire and for
+// the whole league. Row and column are width_bucket numbers, so row 1 is the
+// bottom of the grid.
+type zoneCellCount struct {
+	Hand          string
+	Row, Column   int
+	Pitches       int
+	Strikes       int
+	LeaguePitches int
+	LeagueStrikes int
+}
+
+// cellBounds returns the rectangle of a cell, with row 1 at the top
+func (b ZoneBounds) cellBounds(row, column, size int) ZoneBounds {
+	width := (b.XMax - b.XMin) / float64(size)
+	height := (b.ZMax - b.ZMin) / float64(size)
+	top := b.ZMax - float64(row-1)*height
+	return ZoneBounds{
+		XMin: roundTo(b.XMin+float64(column-1)*width, 3),
+		XMax: roundTo(b.XMin+float64(column)*width, 3),
+		ZMin: roundTo(top-height, 3),
+		ZMax: roundTo(top, 3),
+	}
+}
+
+func zoneProbability(strikes, pitches int) *float64 {
+	if pitches == 0 {
+		return nil
+	}
+	p := roundTo(float64(strikes)/float64(pitches), 3)
+	return &p
+}
+
+// buildUmpireZoneGrids fills a full grid for each batter hand from the cell
+// counts, so every cell is present even when nothing was called there
+func buildUmpireZoneGrids(counts []zoneCellCount, size int, bounds ZoneBounds) map[string]*UmpireZoneGrid {
+	grids := make(map[string]*UmpireZoneGrid)
+	for _, hand := range []string{"L", "R"} {
+		grid := &UmpireZoneGrid{Cells: make([]UmpireZoneCell, 0, size*size)}
+		for row := 1; row <= size; row++ {
+			for column := 1; column <= size; column++ {
+				grid.Cells = append(grid.Cells, UmpireZoneCell{
+					Row:    row,
+					Column: column,
+					Bounds: bounds.cellBounds(row, column, size),
+				})
+			}
+		}
+		grids[hand] = grid
+	}
+
+	for _, count := range counts {
+		grid, ok := grids[count.Hand]
+		if !ok || count.Row < 1 || count.Row > size || count.Column < 1 || count.Column > size {
+			continue
+		}
+		row := size + 1 - count.Row // width_bucket counts up from the bottom
+		cell := &grid.Cells[(row-1)*size+count.Column-1]
+		cell.CalledPitches += count.Pitches
+		cell.CalledStrikes += count.Strikes
+		cell.StrikeProbability = zoneProbability(cell.CalledStrikes, cell.CalledPitches)
+		cell.LeagueStrikeProbability = zoneProbability(count.LeagueStrikes, count.LeaguePitches)
+		if cell.StrikeProbability != nil && cell.LeagueStrikeProbability != nil {
+			above := roundTo(*cell.StrikeProbability-*cell.LeagueStrikeProbability, 3)
+			cell.StrikeProbabilityAboveAvg = &above
+		}
+		grid.CalledPitches += count.Pitches
+	}
+	return grids
+}
+
+// umpireZoneQuery counts called pitches by batter hand and grid cell for a
+// season, for one umpire ($1) and for every umpire. Pitches outside the grid
+// land in width_bucket's overflow buckets and are dropped.
+const umpireZoneQuery = `
+	WITH called AS (
+		SELECT g.home_plate_umpire_id AS umpire_id,
+		       b.bats AS hand,
+		       width_bucket((p.plate_location->>'z')::float8, $3, $4, $7) AS row_bucket,
+		       width_bucket((p.plate_location->>'x')::float8, $5, $6, $7) AS col_bucket,
+		       p.result = 'Called Strike' AS strike
+		FROM pitches p
+		JOIN games g ON p.game_id = g.id
+		JOIN players b ON p.batter_id = b.id
+		WHERE p.game_date >= make_date($2, 1, 1)
+		  AND p.game_date < make_date($2 + 1, 1, 1)
+		  AND p.result = ANY($8)
+		  AND b.bats IN ('L', 'R')
+		  AND p.plate_location ? 'x'
+		  AND p.plate_location ? 'z'
+	)
+	SELECT hand, row_bucket, col_bucket,
+	       COUNT(*) FILTER (WHERE umpire_id = $1),
+	       COUNT(*) FILTER (WHERE umpire_id = $1 AND strike),
+	       COUNT(*),
+	       COUNT(*) FILTER (WHERE strike)
+	FROM called
+	WHERE row_bucket BETWEEN 1 AND $7
+	  AND col_bucket BETWEEN 1 AND $7
+	GROUP BY hand, row_bucket, col_bucket`
+
+// getUmpireZoneHandler returns an umpire's called-strike probability grid
+// for a season, e.g. /umpires/{id}/zone?season=2024&grid=5, alongside the
+// league rate in each cell
+func (s *Server) getUmpireZoneHandler(w http.ResponseWriter, r *http.Request) {
+	umpireID := mux.Vars(r)["id"]
+	query := r.URL.Query()
+
+	season := getCurrentSeason()
+	if seasonStr := query.Get("season"); seasonStr != "" {
+		parsed, err := strconv.Atoi(seasonStr)
+		if err != nil {
+			writeError(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		season = parsed
+	}
+
+	size := defaultZoneGridSize
+	if gridStr := query.Get("grid"); gridStr != "" {
+		parsed, err := strconv.Atoi(gridStr)
+		if err != nil || parsed < minZoneGridSize || parsed > maxZoneGridSize {
+			writeError(w, fmt.Sprintf("Invalid grid parameter (%d-%d)", minZoneGridSize, maxZoneGridSize), http.StatusBadRequest)
+			return
+		}
+		size = parsed
+	}
+
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	resolved, ok := s.resolveEntity(ctx, w, ids.Umpire, umpireID)
+	if !ok {
+		return
+	}
+
+	zone := UmpireZone{Season: season, GridSize: size, Bounds: umpireZoneBounds,
+		ID: resolved.ID, UmpireID: resolved.ExternalID}
+	err := s.readDB().QueryRow(ctx, `SELECT name FROM umpires WHERE id = $1`, zone.ID).Scan(&zone.Name)
+	if err != nil {
+		log.Printf("Failed to query umpire: %v (umpireID=%s)", err, umpireID)
+		writeError(w, "Failed to query umpire", http.StatusInternalServerError)
+		return
+	}
+
+	cacheKey := fmt.Sprintf("umpire_zone:%s:%d:%d", zone.ID, season, size)
+	if cached, ok := s.queryCache.Get(cacheKey); ok {
+		writeJSON(w, cached)
+		return
+	}
+
+	b := umpireZoneBounds
+	rows, err := s.readDB().Query(ctx, umpireZoneQuery,
+		zone.ID, season, b.ZMin, b.ZMax, b.XMin, b.XMax, size, calledPitchResults)
+	if err != nil {
+		log.Printf("Failed to query umpire zone: %v (umpireID=%s)", err, umpireID)
+		writeError(w, "Failed to query umpire zone", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var counts []zoneCellCount
+	for rows.Next() {
+		var count zoneCellCount
+		if err := rows.Scan(&count.Hand, &count.Row, &count.Column,
+			&count.Pitches, &count.Strikes, &count.LeaguePitches, &count.LeagueStrikes); err != nil {
+			log.Printf("Failed to scan umpire zone: %v", err)
+			writeError(w, "Failed to scan umpire zone", http.StatusInternalServerError)
+			return
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to read umpire zone: %v", err)
+		writeError(w, "Failed to query umpire zone", http.StatusInternalServerError)
+		return
+	}
+
+	zone.ByBatterHand = buildUmpireZoneGrids(counts, size, umpireZoneBounds)
+	s.queryCache.Set(cacheKey, zone, umpireZoneCacheTTL)
+	writeJSON(w, zone)
+}
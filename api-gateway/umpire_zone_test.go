@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUmpireZoneCellBounds tests that cells tile the grid from the top left
+func TestUmpireZoneCellBounds(t *testing.T) {
+	bounds := ZoneBounds{XMin: -1.25, XMax: 1.25, ZMin: 1.0, ZMax: 4.0}
+
+	topLeft := bounds.cellBounds(1, 1, 5)
+	assert.Equal(t, ZoneBounds{XMin: -1.25, XMax: -0.75, ZMin: 3.4, ZMax: 4.0}, topLeft)
+
+	bottomRight := bounds.cellBounds(5, 5, 5)
+	assert.Equal(t, ZoneBounds{XMin: 0.75, XMax: 1.25, ZMin: 1.0, ZMax: 1.6}, bottomRight)
+}
+
+// TestBuildUmpireZoneGrids tests that counts land in the right cell and are
+// compared with the league rate
+func TestBuildUmpireZoneGrids(t *testing.T) {
+	counts := []zoneCellCount{
+		// Bottom bucket, so the last row of the grid
+		{Hand: "R", Row: 1, Column: 3, Pitches: 10, Strikes: 4, LeaguePitches: 1000, LeagueStrikes: 300},
+		// Middle of the zone
+		{Hand: "L", Row: 3, Column: 3, Pitches: 20, Strikes: 20, LeaguePitches: 2000, LeagueStrikes: 1960},
+		// Seen league-wide but never by this umpire
+		{Hand: "L", Row: 5, Column: 1, LeaguePitches: 500, LeagueStrikes: 25},
+		// Overflow bucket and an unknown hand are ignored
+		{Hand: "R", Row: 6, Column: 1, Pitches: 3, LeaguePitches: 3},
+		{Hand: "S", Row: 2, Column: 2, Pitches: 3, LeaguePitches: 3},
+	}
+
+	grids := buildUmpireZoneGrids(counts, 5, umpireZoneBounds)
+	assert.Len(t, grids, 2)
+	assert.Len(t, grids["L"].Cells, 25)
+	assert.Len(t, grids["R"].Cells, 25)
+
+	low := grids["R"].Cells[4*5+2]
+	assert.Equal(t, 5, low.Row)
+	assert.Equal(t, 3, low.Column)
+	assert.Equal(t, 10, low.CalledPitches)
+	assert.Equal(t, 0.4, *low.StrikeProbability)
+	assert.Equal(t, 0.3, *low.LeagueStrikeProbability)
+	assert.Equal(t, 0.1, *low.StrikeProbabilityAboveAvg)
+	assert.Equal(t, 10, grids["R"].CalledPitches)
+
+	middle := grids["L"].Cells[2*5+2]
+	assert.Equal(t, 1.0, *middle.StrikeProbability)
+	assert.Equal(t, 0.02, *middle.StrikeProbabilityAboveAvg)
+
+	unseen := grids["L"].Cells[0]
+	assert.Equal(t, 1, unseen.Row)
+	assert.Nil(t, unseen.StrikeProbability)
+	assert.Equal(t, 0.05, *unseen.LeagueStrikeProbability)
+	assert.Nil(t, unseen.StrikeProbabilityAboveAvg)
+	assert.Equal(t, 20, grids["L"].CalledPitches)
+}
+
+// TestUmpireZoneHandlerValidation tests parameter checks that fail before
+// any query runs
+func TestUmpireZoneHandlerValidation(t *testing.T) {
+	s := &Server{}
+	router := mux.NewRouter()
+	router.HandleFunc("/umpires/{id}/zone", s.getUmpireZoneHandler)
+
+	for _, path := range []string{
+		"/umpires/123/zone?season=abc",
+		"/umpires/123/zone?grid=2",
+		"/umpires/123/zone?grid=10",
+		"/umpires/123/zone?grid=five",
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, path)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultUpstreamConcurrency caps in-flight requests per upstream service
+const defaultUpstreamConcurrency = 32
+
+// UpstreamClient is a pooled HTTP client for one backend service (sim-engine,
+// data-fetcher). Connections are kept alive and reused across requests,
+// HTTP/2 is negotiated when the upstream is served over TLS, and the number
+// of concurrent requests is capped so a slow upstream cannot exhaust the gateway.
+type UpstreamClient struct {
+	name    string
+	client  *http.Client
+	sem     chan struct{}
+	maxConc int
+
+	requests    atomic.Int64
+	errors      atomic.Int64
+	newConns    atomic.Int64
+	reusedConns atomic.Int64
+	inFlight    atomic.Int64
+
+	// observe, when set, is told each request's outcome so a replica pool
+	// can track the health of the replica that served it
+	observe func(requestURL *url.URL, statusCode int, err error)
+}
+
+// UpstreamMetrics reports connection reuse and load for one upstream
+type UpstreamMetrics struct {
+	Requests          int64   `json:"requests"`
+	Errors            int64   `json:"errors"`
+	NewConnections    int64   `json:"new_connections"`
+	ReusedConnections int64   `json:"reused_connections"`
+	ReuseRate         float64 `json:"reuse_rate_percent"`
+	InFlight          int64   `json:"in_flight"`
+	MaxConcurrent     int     `json:"max_concurrent"`
+}
+
+// newUpstreamTransport builds the shared transport used for an upstream
+func newUpstreamTransport(maxConcurrent int) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   maxConcurrent,
+		MaxConnsPerHost:       maxConcurrent,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+	}
+}
+
+// NewUpstreamClient creates a pooled client allowing maxConcurrent in-flight requests
+func NewUpstreamClient(name string, maxConcurrent int) *UpstreamClient {
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultUpstreamConcurrency
+	}
+
+	return &UpstreamClient{
+		name:    name,
+		client:  &http.Client{Transport: newUpstreamTransport(maxConcurrent)},
+		sem:     make(chan struct{}, maxConcurrent),
+		maxConc: maxConcurrent,
+	}
+}
+
+// Get issues a GET request to the upstream
+func (uc *UpstreamClient) Get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return uc.Do(req)
+}
+
+// Post issues a POST request to the upstream
+func (uc *UpstreamClient) Post(ctx context.Context, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return uc.Do(req)
+}
+
+// Do sends the request once a concurrency slot is free. The slot is held
+// until the response body is closed.
+func (uc *UpstreamClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	select {
+	case uc.sem <- struct{}{}:
+	case <-ctx.Done():
+		uc.errors.Add(1)
+		return nil, ctx.Err()
+	}
+
+	uc.requests.Add(1)
+	uc.inFlight.Add(1)
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				uc.reusedConns.Add(1)
+			} else {
+				uc.newConns.Add(1)
+			}
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(ctx, trace))
+
+	resp, err := uc.client.Do(req)
+	if uc.observe != nil {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		uc.observe(req.URL, statusCode, err)
+	}
+	if err != nil {
+		uc.release()
+		uc.errors.Add(1)
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: uc.release}
+	return resp, nil
+}
+
+func (uc *UpstreamClient) release() {
+	uc.inFlight.Add(-1)
+	<-uc.sem
+}
+
+// Metrics returns a snapshot of the client's counters
+func (uc *UpstreamClient) Metrics() UpstreamMetrics {
+	newConns := uc.newConns.Load()
+	reused := uc.reusedConns.Load()
+
+	var reuseRate float64
+	if total := newConns + reused; total > 0 {
+		reuseRate = float64(reused) / float64(total) * 100
+	}
+
+	return UpstreamMetrics{
+		Requests:          uc.requests.Load(),
+		Errors:            uc.errors.Load(),
+		NewConnections:    newConns,
+		ReusedConnections: reused,
+		ReuseRate:         reuseRate,
+		InFlight:          uc.inFlight.Load(),
+		MaxConcurrent:     uc.maxConc,
+	}
+}
+
+// CloseIdleConnections closes pooled connections on shutdown
+func (uc *UpstreamClient) CloseIdleConnections() {
+	uc.client.CloseIdleConnections()
+}
+
+// releasingBody frees the concurrency slot exactly once when closed
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (rb *releasingBody) Close() error {
+	err := rb.ReadCloser.Close()
+	rb.once.Do(rb.release)
+	return err
+}
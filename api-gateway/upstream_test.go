@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUpstreamClientReusesConnections tests keep-alive reuse across requests
+func TestUpstreamClientReusesConnections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := NewUpstreamClient("test", 4)
+	defer client.CloseIdleConnections()
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(context.Background(), server.URL)
+		assert.NoError(t, err)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	metrics := client.Metrics()
+	assert.Equal(t, int64(3), metrics.Requests)
+	assert.Equal(t, int64(1), metrics.NewConnections)
+	assert.Equal(t, int64(2), metrics.ReusedConnections)
+	assert.Equal(t, int64(0), metrics.InFlight)
+	assert.Equal(t, 4, metrics.MaxConcurrent)
+}
+
+// TestUpstreamClientConcurrencyCap tests that requests wait for a free slot
+func TestUpstreamClientConcurrencyCap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewUpstreamClient("test", 1)
+	defer client.CloseIdleConnections()
+
+	// Hold the only slot by leaving the body open
+	held, err := client.Get(context.Background(), server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), client.Metrics().InFlight)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Get(ctx, server.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Closing twice must only release the slot once
+	held.Body.Close()
+	held.Body.Close()
+	assert.Equal(t, int64(0), client.Metrics().InFlight)
+
+	resp, err := client.Get(context.Background(), server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int64(1), client.Metrics().Errors)
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+)
+
+// weatherAdjustment mirrors the sim-engine's batter-side weather tuning,
+// which shifts expected wOBA for wind, temperature and humidity
+type weatherAdjustment struct {
+	WindPerMPH         float64
+	ColdTemperature    float64
+	ColdAdjustment     float64
+	HotTemperature     float64
+	HotAdjustment      float64
+	HumidityThreshold  float64
+	HumidityAdjustment float64
+}
+
+// defaultWeatherAdjustment matches the sim-engine's built-in tuning
+func defaultWeatherAdjustment() weatherAdjustment {
+	return weatherAdjustment{
+		WindPerMPH:         0.001,
+		ColdTemperature:    50,
+		ColdAdjustment:     -0.010,
+		HotTemperature:     80,
+		HotAdjustment:      0.005,
+		HumidityThreshold:  80,
+		HumidityAdjustment: -0.005,
+	}
+}
+
+// loadWeatherAdjustment reads the engine's weather tuning from
+// engine_parameters, keeping the defaults for anything not stored
+func (s *Server) loadWeatherAdjustment(ctx context.Context) weatherAdjustment {
+	adjustment := defaultWeatherAdjustment()
+	fields := map[string]*float64{
+		"weather_wind_per_mph":        &adjustment.WindPerMPH,
+		"weather_cold_temperature":    &adjustment.ColdTemperature,
+		"weather_cold_adjustment":     &adjustment.ColdAdjustment,
+		"weather_hot_temperature":     &adjustment.HotTemperature,
+		"weather_hot_adjustment":      &adjustment.HotAdjustment,
+		"weather_humidity_threshold":  &adjustment.HumidityThreshold,
+		"weather_humidity_adjustment": &adjustment.HumidityAdjustment,
+	}
+
+	rows, err := s.readDB().Query(ctx, `SELECT name, value FROM engine_parameters WHERE name LIKE 'weather_%'`)
+	if err != nil {
+		return adjustment
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var value float64
+		if err := rows.Scan(&name, &value); err != nil {
+			continue
+		}
+		if field, ok := fields[name]; ok {
+			*field = value
+		}
+	}
+	return adjustment
+}
+
+// WeatherConditions are the conditions a game was simulated with or played in
+type WeatherConditions struct {
+	Temperature float64  `json:"temperature"` // Fahrenheit
+	WindSpeed   float64  `json:"wind_speed"`  // MPH
+	WindDir     string   `json:"wind_dir"`    // "in", "out" or a crosswind direction
+	Humidity    *float64 `json:"humidity,omitempty"`
+	Condition   string   `json:"condition,omitempty"`
+	RoofClosed  bool     `json:"roof_closed,omitempty"`
+}
+
+// wobaShift is the expected wOBA shift the engine applies to batters in
+// these conditions
+func (a weatherAdjustment) wobaShift(c WeatherConditions) float64 {
+	shift := 0.0
+	switch c.WindDir {
+	case "out":
+		shift += c.WindSpeed * a.WindPerMPH
+	case "in":
+		shift -= c.WindSpeed * a.WindPerMPH
+	}
+	if c.Temperature < a.ColdTemperature {
+		shift += a.ColdAdjustment
+	} else if c.Temperature > a.HotTemperature {
+		shift += a.HotAdjustment
+	}
+	if c.Humidity != nil && *c.Humidity > a.HumidityThreshold {
+		shift += a.HumidityAdjustment
+	}
+	return shift
+}
+
+// forecastConditions reads the weather a run simulated, as stored in its inputs
+func forecastConditions(weather map[string]interface{}) WeatherConditions {
+	var c WeatherConditions
+	c.Temperature, _ = toFloat(weather["temperature"])
+	c.WindSpeed, _ = toFloat(weather["wind_speed"])
+	c.WindDir, _ = weather["wind_dir"].(string)
+	if humidity, ok := toFloat(weather["humidity"]); ok {
+		c.Humidity = &humidity
+	}
+	return c
+}
+
+// observedConditions reads a game's stored MLB conditions ("temp": "72",
+// "wind": "12 mph, Out To CF"). It returns false when no temperature was
+// recorded, as for games not yet played.
+func observedConditions(weather map[string]interface{}) (WeatherConditions, bool) {
+	var c WeatherConditions
+	temp, ok := toFloat(weather["temp"])
+	if !ok {
+		return c, false
+	}
+	c.Temperature = temp
+	c.Condition, _ = weather["condition"].(string)
+	if humidity, ok := toFloat(weather["humidity"]); ok {
+		c.Humidity = &humidity
+	}
+	isDome, _ := weather["is_dome"].(bool)
+	roofClosed, _ := weather["roof_closed"].(bool)
+	c.RoofClosed = isDome || roofClosed
+	if c.RoofClosed {
+		c.WindDir = "calm"
+		return c, true
+	}
+	if wind, ok := weather["wind"].(string); ok {
+		c.WindSpeed, c.WindDir = parseMLBWind(wind)
+	}
+	return c, true
+}
+
+// parseMLBWind reads MLB's wind description, e.g. "12 mph, Out To CF", as a
+// speed and an "in" or "out" direction. Crosswinds and calm have no direction.
+func parseMLBWind(wind string) (float64, string) {
+	var speed float64
+	fmt.Sscanf(wind, "%f mph", &speed)
+	lower := strings.ToLower(wind)
+	switch {
+	case strings.Contains(lower, "out to"):
+		return speed, "out"
+	case strings.Contains(lower, "in from"):
+		return speed, "in"
+	}
+	return speed, ""
+}
+
+// WeatherDelta is the actual conditions less the forecast
+type WeatherDelta struct {
+	Temperature    float64 `json:"temperature"`
+	WindSpeed      float64 `json:"wind_speed"`
+	WindDirChanged bool    `json:"wind_dir_changed"`
+}
+
+// WeatherImpact estimates how much the forecast error moved scoring, using
+// the engine's weather tuning and the season's run environment
+type WeatherImpact struct {
+	ForecastWOBAShift float64 `json:"forecast_woba_shift"`
+	ActualWOBAShift   float64 `json:"actual_woba_shift"`
+	WOBADelta         float64 `json:"woba_delta"`          // actual less forecast
+	RunsPerTeamDelta  float64 `json:"runs_per_team_delta"` // per team per game
+	TotalRunsDelta    float64 `json:"total_runs_delta"`    // both teams
+}
+
+// WeatherVerification compares a run's forecast with the observed weather
+type WeatherVerification struct {
+	GameID      string            `json:"game_id"`
+	RunID       string            `json:"run_id"`
+	SimulatedAt time.Time         `json:"simulated_at"`
+	Forecast    WeatherConditions `json:"forecast"`
+	Actual      WeatherConditions `json:"actual"`
+	Delta       WeatherDelta      `json:"delta"`
+	Impact      WeatherImpact     `json:"impact"`
+}
+
+// verifyWeather compares forecast and actual conditions
+func verifyWeather(forecast, actual WeatherConditions, adjustment weatherAdjustment, league LeagueEnvironment) (WeatherDelta, WeatherImpact) {
+	delta := WeatherDelta{
+		Temperature:    roundTo(actual.Temperature-forecast.Temperature, 1),
+		WindSpeed:      roundTo(actual.WindSpeed-forecast.WindSpeed, 1),
+		WindDirChanged: actual.WindDir != forecast.WindDir,
+	}
+
+	forecastShift, actualShift := adjustment.wobaShift(forecast), adjustment.wobaShift(actual)
+	impact := WeatherImpact{
+		ForecastWOBAShift: roundTo(forecastShift, 4),
+		ActualWOBAShift:   roundTo(actualShift, 4),
+		WOBADelta:         roundTo(actualShift-forecastShift, 4),
+	}
+	if league.WOBAScale > 0 {
+		perTeam := (actualShift - forecastShift) / league.WOBAScale * plateAppearancesPerGame
+		impact.RunsPerTeamDelta = roundTo(perTeam, 2)
+		impact.TotalRunsDelta = roundTo(2*perTeam, 2)
+	}
+	return delta, impact
+}
+
+// getGameWeatherVerification compares the weather a simulation of the game
+// used with the conditions recorded after it was played. ?run_id= picks the
+// run; by default it's the game's latest finished run.
+func (s *Server) getGameWeatherVerification(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := contextWithTimeout(r.Context())
+	defer cancel()
+
+	resolved, ok := s.resolveEntity(ctx, w, ids.Game, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+	gameID := resolved.ID
+
+	var season int
+	var observedJSON []byte
+	err := s.readDB().QueryRow(ctx, `
+		SELECT EXTRACT(YEAR FROM game_date)::int, COALESCE(weather_data, '{}'::jsonb)
+		FROM games
+		WHERE id = $1
+	`, gameID).Scan(&season, &observedJSON)
+	if err != nil {
+		writeError(w, "Game not found", http.StatusNotFound)
+		return
+	}
+
+	var runID string
+	var simulatedAt time.Time
+	var forecastJSON []byte
+	err = s.readDB().QueryRow(ctx, `
+		SELECT id::text, created_at, inputs->'weather'
+		FROM simulation_runs
+		WHERE game_id = $1
+		  AND status IN ('completed', 'partial')
+		  AND inputs->'weather' IS NOT NULL
+		  AND ($2 = '' OR id::text = $2)
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, gameID, r.URL.Query().Get("run_id")).Scan(&runID, &simulatedAt, &forecastJSON)
+	if err != nil {
+		writeError(w, "No finished simulation of this game recorded its weather", http.StatusNotFound)
+		return
+	}
+
+	var forecastWeather, observedWeather map[string]interface{}
+	if err := json.Unmarshal(forecastJSON, &forecastWeather); err != nil {
+		log.Printf("Invalid forecast weather for run %s: %v", runID, err)
+		writeError(w, "Invalid forecast weather", http.StatusInternalServerError)
+		return
+	}
+	if err := json.Unmarshal(observedJSON, &observedWeather); err != nil {
+		writeError(w, "Invalid weather data", http.StatusInternalServerError)
+		return
+	}
+
+	actual, ok := observedConditions(observedWeather)
+	if !ok {
+		writeError(w, "Game has no observed weather yet", http.StatusConflict)
+		return
+	}
+
+	verification := WeatherVerification{
+		GameID:      gameID,
+		RunID:       runID,
+		SimulatedAt: simulatedAt,
+		Forecast:    forecastConditions(forecastWeather),
+		Actual:      actual,
+	}
+	verification.Delta, verification.Impact = verifyWeather(verification.Forecast, actual,
+		s.loadWeatherAdjustment(ctx), s.loadLeagueEnvironment(ctx, season))
+	writeJSON(w, verification)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMLBWind(t *testing.T) {
+	speed, dir := parseMLBWind("12 mph, Out To CF")
+	assert.Equal(t, 12.0, speed)
+	assert.Equal(t, "out", dir)
+
+	speed, dir = parseMLBWind("7 mph, In From LF")
+	assert.Equal(t, 7.0, speed)
+	assert.Equal(t, "in", dir)
+
+	speed, dir = parseMLBWind("5 mph, L To R")
+	assert.Equal(t, 5.0, speed)
+	assert.Empty(t, dir)
+}
+
+func TestObservedConditions(t *testing.T) {
+	open, ok := observedConditions(map[string]interface{}{
+		"temp": "48", "condition": "Cloudy", "wind": "15 mph, In From CF", "is_dome": false, "roof_closed": false,
+	})
+	require.True(t, ok)
+	assert.Equal(t, WeatherConditions{Temperature: 48, WindSpeed: 15, WindDir: "in", Condition: "Cloudy"}, open)
+
+	dome, ok := observedConditions(map[string]interface{}{"temp": 72.0, "wind": "0 mph", "is_dome": true})
+	require.True(t, ok)
+	assert.True(t, dome.RoofClosed)
+	assert.Equal(t, "calm", dome.WindDir)
+
+	_, ok = observedConditions(map[string]interface{}{})
+	assert.False(t, ok, "unplayed games have no temperature")
+}
+
+func TestVerifyWeather(t *testing.T) {
+	humidity := 60.0
+	forecast := forecastConditions(map[string]interface{}{
+		"temperature": 68.0, "wind_speed": 10.0, "wind_dir": "out", "humidity": humidity,
+	})
+	actual := WeatherConditions{Temperature: 48, WindSpeed: 15, WindDir: "in"}
+
+	delta, impact := verifyWeather(forecast, actual, defaultWeatherAdjustment(), defaultLeagueEnvironment(2026))
+	assert.Equal(t, WeatherDelta{Temperature: -20, WindSpeed: 5, WindDirChanged: true}, delta)
+
+	// +.010 forecast (10 mph out); actual is -.015 wind in and -.010 cold
+	assert.Equal(t, 0.01, impact.ForecastWOBAShift)
+	assert.Equal(t, -0.025, impact.ActualWOBAShift)
+	assert.Equal(t, -0.035, impact.WOBADelta)
+	assert.Equal(t, -1.06, impact.RunsPerTeamDelta) // -.035 / 1.25 * 38
+	assert.Equal(t, -2.13, impact.TotalRunsDelta)
+
+	// Matching conditions leave nothing to explain
+	_, impact = verifyWeather(forecast, forecast, defaultWeatherAdjustment(), defaultLeagueEnvironment(2026))
+	assert.Zero(t, impact.TotalRunsDelta)
+}
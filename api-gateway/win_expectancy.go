@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// getWinExpectancyHandler returns the sim-engine's win expectancy for a game
+// situation, forwarding ?inning=, ?half=, ?outs=, ?base_state= and ?diff=
+func (s *Server) getWinExpectancyHandler(w http.ResponseWriter, r *http.Request) {
+	url := s.simEngineURL() + "/meta/win-expectancy"
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+	resp, err := s.simEngineClient.Get(r.Context(), url)
+	if err != nil {
+		writeError(w, "Failed to communicate with simulation engine", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		writeError(w, strings.TrimSpace(string(body)), resp.StatusCode)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, "Failed to parse simulation response", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWinExpectancyHandler(t *testing.T) {
+	var forwarded string
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.URL.RequestURI()
+		if r.URL.Query().Get("outs") == "3" {
+			http.Error(w, "outs must be 0, 1 or 2", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"home_win_probability": 0.4392, "batting_team_win_probability": 0.4392, "leverage": 2.1}`))
+	}))
+	defer engine.Close()
+
+	s := &Server{
+		config:          &Config{SimEngineURL: engine.URL},
+		simEngineClient: NewUpstreamClient("sim-engine", 2),
+	}
+
+	rec := httptest.NewRecorder()
+	s.getWinExpectancyHandler(rec, httptest.NewRequest("GET", "/api/v1/meta/win-expectancy?inning=7&half=bottom&outs=1&base_state=110&diff=-1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "/meta/win-expectancy?inning=7&half=bottom&outs=1&base_state=110&diff=-1", forwarded)
+	assert.Contains(t, rec.Body.String(), `"home_win_probability":0.4392`)
+
+	rec = httptest.NewRecorder()
+	s.getWinExpectancyHandler(rec, httptest.NewRequest("GET", "/api/v1/meta/win-expectancy?outs=3", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "outs must be 0, 1 or 2")
+}
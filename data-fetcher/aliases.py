@@ -0,0 +1,149 @@
+"""
+Entity alias ingestion
+Stores extra identifiers (e.g. Retrosheet IDs) for teams, players, games,
+umpires and stadiums, so clients can address them by any ID they hold. The
+gateway, sim engine and the data fetcher's own write endpoints resolve
+aliases to internal UUIDs.
+"""
+import logging
+import re
+from typing import Dict, List, Optional, Tuple
+
+import asyncpg
+
+logger = logging.getLogger(__name__)
+
+# Table and MLB ID column per entity type
+ENTITY_TABLES = {
+    'team': ('teams', 'team_id'),
+    'player': ('players', 'player_id'),
+    'game': ('games', 'game_id'),
+    'umpire': ('umpires', 'umpire_id'),
+    'stadium': ('stadiums', 'stadium_id'),
+}
+
+# Short codes matched case-insensitively, per entity type
+CODE_COLUMNS = {'team': 'abbreviation'}
+
+# Namespace prefixes an identifier may carry, e.g. "retrosheet:NYA". These
+# follow the Go resolver in shared/ids so an ID the gateway accepts resolves
+# the same way here.
+ID_PREFIXES = {
+    'uuid': 'uuid',
+    'mlbam': 'mlbam',
+    'mlb': 'mlbam',
+    'code': 'code',
+    'retrosheet': 'retrosheet',
+    'retro': 'retrosheet',
+}
+
+UUID_PATTERN = re.compile(r'^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$')
+
+# Bounds how many candidates one lookup reads
+MAX_ID_MATCHES = 10
+
+
+class AmbiguousIDError(Exception):
+    """An unprefixed identifier matches different entities in different
+    namespaces"""
+
+    def __init__(self, entity_type: str, ref: str, matches: List[str]):
+        self.entity_type = entity_type
+        self.ref = ref
+        self.matches = matches
+        super().__init__(f"{entity_type} ID {ref!r} is ambiguous; "
+                         f"prefix it with mlbam: or retrosheet:")
+
+
+def parse_id_ref(raw: str) -> Tuple[str, str]:
+    """Split off a known namespace prefix, returning (namespace, value); the
+    namespace is '' for any. An unknown prefix is part of the value."""
+    raw = (raw or '').strip()
+    prefix, sep, value = raw.partition(':')
+    if sep and prefix.lower() in ID_PREFIXES:
+        return ID_PREFIXES[prefix.lower()], value.strip()
+    return '', raw
+
+
+def id_lookup_query(entity_type: str, namespace: str, value: str) -> Tuple[Optional[str], List]:
+    """The query matching a reference by primary key when it's a UUID, and
+    otherwise against the MLB ID column, any code column and entity_aliases.
+    Returns None when the reference can't match."""
+    table, external_column = ENTITY_TABLES[entity_type]
+    is_uuid = bool(UUID_PATTERN.match(value))
+    if namespace == 'uuid' and not is_uuid:
+        return None, []
+    if namespace == 'uuid' or (namespace == '' and is_uuid):
+        return f"SELECT id::text AS id FROM {table} WHERE id = $1::uuid", [value]
+
+    args = [value]
+    parts = []
+    if namespace in ('', 'mlbam'):
+        parts.append(f"SELECT id::text AS id FROM {table} WHERE {external_column} = $1")
+    code_column = CODE_COLUMNS.get(entity_type)
+    if code_column and namespace in ('', 'code'):
+        parts.append(f"SELECT id::text AS id FROM {table} WHERE UPPER({code_column}) = UPPER($1)")
+    if namespace not in ('mlbam', 'code'):
+        alias_query = (f"SELECT t.id::text AS id FROM entity_aliases a JOIN {table} t ON t.id = a.entity_id "
+                       "WHERE a.entity_type = $2 AND a.alias = $1")
+        args.append(entity_type)
+        if namespace:
+            alias_query += " AND a.alias_type = $3"
+            args.append(namespace)
+        parts.append(alias_query)
+    if not parts:
+        return None, []
+    return " UNION ALL ".join(parts) + f" LIMIT {MAX_ID_MATCHES}", args
+
+
+async def resolve_entity_id(db_pool: asyncpg.Pool, entity_type: str, raw: str) -> Optional[str]:
+    """Resolve a UUID, MLB ID, team abbreviation or alias to the entity's UUID,
+    or None when nothing matches. Raises AmbiguousIDError when several
+    entities do."""
+    namespace, value = parse_id_ref(raw)
+    if not value:
+        return None
+    query, args = id_lookup_query(entity_type, namespace, value)
+    if query is None:
+        return None
+
+    matches = sorted({row['id'] for row in await db_pool.fetch(query, *args)})
+    if len(matches) > 1:
+        raise AmbiguousIDError(entity_type, raw, matches)
+    return matches[0] if matches else None
+
+
+async def upsert_entity_aliases(db_pool: asyncpg.Pool, aliases: List[Dict],
+                                source: str = None) -> Dict:
+    """Store aliases, repointing any alias already stored. entity_id may be
+    any identifier resolve_entity_id accepts; aliases for unknown or
+    ambiguous entities are skipped and reported."""
+    updated = 0
+    unknown_entities = []
+    for alias in aliases:
+        try:
+            entity_uuid = await resolve_entity_id(db_pool, alias['entity_type'], alias['entity_id'])
+        except AmbiguousIDError as e:
+            logger.warning(str(e))
+            entity_uuid = None
+        if entity_uuid is None:
+            unknown_entities.append({'entity_type': alias['entity_type'],
+                                     'entity_id': alias['entity_id']})
+            continue
+
+        await db_pool.execute("""
+            INSERT INTO entity_aliases (entity_type, alias_type, alias, entity_id,
+                                        source, updated_at)
+            VALUES ($2, $3, $4, $1::uuid, $5, NOW())
+            ON CONFLICT (entity_type, alias_type, alias) DO UPDATE SET
+                entity_id = EXCLUDED.entity_id,
+                source = EXCLUDED.source,
+                updated_at = NOW()
+        """, entity_uuid, alias['entity_type'], alias['alias_type'],
+            alias['alias'], source)
+        updated += 1
+
+    if unknown_entities:
+        logger.warning(f"Skipped aliases for {len(unknown_entities)} unknown entities")
+    logger.info(f"Stored {updated} entity aliases")
+    return {"updated": updated, "unknown_entities": unknown_entities}
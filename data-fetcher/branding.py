@@ -0,0 +1,65 @@
+"""
+Team branding
+Each team's primary and secondary colors and logo slug, so the frontend and
+generated reports theme teams from the API rather than a hardcoded mapping.
+MLB clubs get defaults from the migration; operators maintain them through
+the admin endpoint.
+"""
+import logging
+import re
+from typing import Dict, Optional
+
+import asyncpg
+
+from aliases import resolve_entity_id
+
+logger = logging.getLogger(__name__)
+
+HEX_COLOR = re.compile(r'^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$')
+LOGO_SLUG = re.compile(r'^[a-z0-9]+(-[a-z0-9]+)*$')
+
+
+def normalize_color(value: Optional[str]) -> Optional[str]:
+    """Normalize a hex color to uppercase #RRGGBB, accepting #RGB shorthand
+    and a missing '#'. None stays None; anything else raises ValueError."""
+    if value is None:
+        return None
+    match = HEX_COLOR.match(value.strip())
+    if not match:
+        raise ValueError(f'{value!r} is not a hex color like #0C2340')
+    digits = match.group(1).upper()
+    if len(digits) == 3:
+        digits = ''.join(d * 2 for d in digits)
+    return '#' + digits
+
+
+def normalize_logo_slug(value: Optional[str]) -> Optional[str]:
+    """Lowercase a logo slug; it must be words joined by hyphens"""
+    if value is None:
+        return None
+    slug = value.strip().lower()
+    if not LOGO_SLUG.match(slug):
+        raise ValueError(f'{value!r} is not a slug like boston-red-sox')
+    return slug
+
+
+async def set_team_branding(db_pool: asyncpg.Pool, team_id: str, primary_color: Optional[str],
+                            secondary_color: Optional[str], logo_slug: Optional[str]) -> Optional[Dict]:
+    """Replace a team's branding; team_id may be any identifier
+    resolve_entity_id accepts, and None clears a field. Returns the updated
+    team, or None when it doesn't exist."""
+    team_uuid = await resolve_entity_id(db_pool, 'team', team_id)
+    if team_uuid is None:
+        return None
+    row = await db_pool.fetchrow("""
+        UPDATE teams
+        SET primary_color = $2, secondary_color = $3, logo_slug = $4,
+            branding_updated_at = NOW(), updated_at = NOW()
+        WHERE id = $1::uuid
+        RETURNING id::text AS id, team_id, name, primary_color, secondary_color,
+                  logo_slug, branding_updated_at
+    """, team_uuid, primary_color, secondary_color, logo_slug)
+    if row is None:
+        return None
+    logger.info(f"Branding set for {row['name']}")
+    return dict(row)
@@ -18,6 +18,13 @@ class Settings(BaseSettings):
     fetch_interval: int = 86400  # 24 hours in seconds
     initial_years: int = 5  # Years of history to fetch on first run
     
+    # Sim engine, told to drop cached rosters after each completed fetch
+    sim_engine_url: str = "http://localhost:8081"
+
+    # Stadium coordinate backfill: "static" (built-in park table) or
+    # "nominatim" (table first, then OpenStreetMap search)
+    geocoding_provider: str = "static"
+
     # MLB API settings
     mlb_api_base_url: str = "https://statsapi.mlb.com/api/v1"
     request_timeout: int = 30
@@ -32,6 +39,10 @@ class Settings(BaseSettings):
     skip_incomplete_games: bool = True
     fetch_spring_training: bool = False
     game_fetch_retry_on_404: bool = False
+
+    # Demo mode seeds a small fictional dataset on first boot and skips the
+    # scheduled MLB API fetch
+    demo_mode: bool = False
     
     # CORS settings
     cors_origins: str = "http://localhost:3000,http://localhost:5173"
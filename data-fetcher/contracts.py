@@ -0,0 +1,51 @@
+"""
+Player contract ingestion
+The MLB Stats API doesn't publish salaries, so contract data (salary and
+deal length per player-season) is loaded from outside sources through the
+ingestion endpoint and used for payroll and $/WAR reporting.
+"""
+import logging
+from typing import Dict, List
+
+import asyncpg
+
+from aliases import AmbiguousIDError, resolve_entity_id
+
+logger = logging.getLogger(__name__)
+
+
+async def upsert_player_contracts(db_pool: asyncpg.Pool, contracts: List[Dict],
+                                  source: str = None) -> Dict:
+    """Store contracts, replacing any already stored for the same player and
+    season. player_id may be any identifier resolve_entity_id accepts;
+    contracts for unknown or ambiguous players are skipped and reported."""
+    updated = 0
+    unknown_players = []
+    for contract in contracts:
+        try:
+            player_uuid = await resolve_entity_id(db_pool, 'player', contract['player_id'])
+        except AmbiguousIDError as e:
+            logger.warning(str(e))
+            player_uuid = None
+        if player_uuid is None:
+            unknown_players.append(contract['player_id'])
+            continue
+
+        await db_pool.execute("""
+            INSERT INTO player_contracts (player_id, season, salary, contract_years,
+                                          contract_end_season, source, updated_at)
+            VALUES ($1::uuid, $2, $3, $4, $5, $6, NOW())
+            ON CONFLICT (player_id, season) DO UPDATE SET
+                salary = EXCLUDED.salary,
+                contract_years = EXCLUDED.contract_years,
+                contract_end_season = EXCLUDED.contract_end_season,
+                source = EXCLUDED.source,
+                updated_at = NOW()
+        """, player_uuid, contract['season'], contract['salary'],
+            contract.get('contract_years'), contract.get('contract_end_season'), source)
+        updated += 1
+
+    if unknown_players:
+        logger.warning(f"Skipped contracts for {len(unknown_players)} unknown players")
+    logger.info(f"Stored {updated} player contracts")
+    return {"updated": updated, "unknown_players": unknown_players}
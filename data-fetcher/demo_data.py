@@ -0,0 +1,519 @@
+"""
+Demo dataset seeded on first boot when DEMO_MODE is enabled.
+
+Two fictional teams with full rosters, their parks, an upcoming schedule and
+a couple of finished games with box scores and play-by-play, so the whole
+stack can be tried without the MLB Stats API. Everything is generated
+deterministically and tagged with a ``demo-`` ID prefix.
+"""
+import json
+import logging
+import random
+from datetime import date, time, timedelta
+from typing import Dict, List, Optional
+
+logger = logging.getLogger(__name__)
+
+DEMO_PREFIX = "demo-"
+
+# Upcoming games scheduled from the seed date, and finished games before it
+DEMO_SCHEDULED_GAMES = 6
+DEMO_FINAL_GAMES = 2
+
+# Stadiums: stadium_id, name, location, capacity, dimensions, roof_type, surface
+DEMO_STADIUMS = [
+    {
+        'stadium_id': 'demo-1', 'name': 'Harbor Point Park', 'location': 'Harbor City, CA',
+        'capacity': 41200, 'roof_type': 'open', 'surface': 'grass',
+        'dimensions': {'left_field': 330, 'left_center': 375, 'center': 400,
+                       'right_center': 375, 'right_field': 328},
+    },
+    {
+        'stadium_id': 'demo-2', 'name': 'Ridgeline Field', 'location': 'Ridgeline, CO',
+        'capacity': 38500, 'roof_type': 'open', 'surface': 'grass',
+        'dimensions': {'left_field': 345, 'left_center': 385, 'center': 410,
+                       'right_center': 380, 'right_field': 340},
+    },
+]
+
+DEMO_TEAMS = [
+    {'team_id': 'hbr', 'name': 'Harbor City Herons', 'abbreviation': 'HBR',
+     'league': 'Demo League', 'division': 'Demo West', 'stadium_id': 'demo-1'},
+    {'team_id': 'rdg', 'name': 'Ridgeline Miners', 'abbreviation': 'RDG',
+     'league': 'Demo League', 'division': 'Demo West', 'stadium_id': 'demo-2'},
+]
+
+DEMO_UMPIRES = [
+    {'umpire_id': 'demo-ump-1', 'name': 'Walt Ferris'},
+    {'umpire_id': 'demo-ump-2', 'name': 'Gina Okafor'},
+]
+
+# Lineup positions in batting order, then the bench
+POSITION_PLAYER_SLOTS = ['CF', 'SS', '1B', 'RF', '3B', 'LF', 'C', '2B', 'DH', 'C', 'IF', 'OF']
+STARTING_PITCHERS = 5
+RELIEF_PITCHERS = 8
+
+FIRST_NAMES = [
+    'Alex', 'Ben', 'Carlos', 'Danny', 'Eli', 'Felix', 'Gabe', 'Hector', 'Isaac', 'Jake',
+    'Kenji', 'Luis', 'Marco', 'Nate', 'Omar', 'Pedro', 'Quinn', 'Rafael', 'Sam', 'Tyler',
+    'Victor', 'Wes', 'Xavier', 'Yuki', 'Zach',
+]
+LAST_NAMES = [
+    'Alvarez', 'Brooks', 'Castillo', 'Dawson', 'Estrada', 'Fletcher', 'Garner', 'Holt',
+    'Ibarra', 'Jensen', 'Kowalski', 'Lindqvist', 'Moreno', 'Nakamura', 'Ortega', 'Pryor',
+    'Quintana', 'Reyes', 'Salazar', 'Tanaka', 'Underwood', 'Vance', 'Whitaker', 'Yates',
+    'Zamora',
+]
+
+# Plate appearance outcomes and their weights, roughly league average
+PLATE_APPEARANCE_OUTCOMES = [
+    ('strikeout', 22), ('groundout', 22), ('flyout', 21), ('walk', 9),
+    ('single', 15), ('double', 5), ('triple', 1), ('home_run', 3),
+]
+HIT_BASES = {'single': 1, 'double': 2, 'triple': 3, 'home_run': 4}
+
+# Innings the starter works; relievers take one inning each after that
+STARTER_INNINGS = 6
+MAX_GAME_INNINGS = 15
+
+
+def demo_season(today: date) -> int:
+    """Season the demo stats belong to; the sim engine reads the current year"""
+    return today.year
+
+
+def demo_players(seed: int = 7) -> List[Dict]:
+    """Both teams' rosters: position players in lineup order, then pitchers"""
+    rng = random.Random(seed)
+    players = []
+    number = 100
+    for team in DEMO_TEAMS:
+        jerseys = iter(rng.sample(range(1, 100), len(POSITION_PLAYER_SLOTS) + STARTING_PITCHERS + RELIEF_PITCHERS))
+        roles = ([('batter', slot) for slot in POSITION_PLAYER_SLOTS]
+                 + [('starter', 'P')] * STARTING_PITCHERS
+                 + [('reliever', 'P')] * RELIEF_PITCHERS)
+        for role, position in roles:
+            number += 1
+            first, last = rng.choice(FIRST_NAMES), rng.choice(LAST_NAMES)
+            throws = 'L' if rng.random() < 0.28 else 'R'
+            bats = throws if role != 'batter' else rng.choice(['R', 'R', 'L', 'S'])
+            players.append({
+                'player_id': f'{DEMO_PREFIX}{number}',
+                'first_name': first,
+                'last_name': last,
+                'full_name': f'{first} {last}',
+                'birth_date': date(rng.randint(1990, 2001), rng.randint(1, 12), rng.randint(1, 28)),
+                'position': position,
+                'bats': bats,
+                'throws': throws,
+                'jersey_number': next(jerseys),
+                'team_id': team['team_id'],
+                'role': role,
+            })
+    return players
+
+
+def batting_line(rng: random.Random) -> Dict:
+    """Season batting line in the player_season_aggregates format"""
+    pa = rng.randint(380, 650)
+    bb = round(pa * rng.uniform(0.06, 0.12))
+    so = round(pa * rng.uniform(0.15, 0.28))
+    ab = pa - bb - rng.randint(2, 8)
+    h = round(ab * rng.uniform(0.225, 0.300))
+    hr = round(h * rng.uniform(0.06, 0.20))
+    doubles = round(h * rng.uniform(0.17, 0.24))
+    triples = rng.randint(0, 4)
+    singles = h - hr - doubles - triples
+    tb = singles + 2 * doubles + 3 * triples + 4 * hr
+    avg, obp, slg = h / ab, (h + bb) / pa, tb / ab
+    return {
+        'PA': pa, 'AB': ab, 'H': h, '2B': doubles, '3B': triples, 'HR': hr,
+        'RBI': round(hr * 2.6 + h * 0.25), 'BB': bb, 'SO': so,
+        'SB': rng.randint(0, 25), 'CS': rng.randint(0, 6),
+        'AVG': round(avg, 3), 'OBP': round(obp, 3), 'SLG': round(slg, 3),
+        'OPS': round(obp + slg, 3), 'ISO': round(slg - avg, 3),
+        'BABIP': round((h - hr) / max(ab - so - hr, 1), 3),
+        'BB%': round(100 * bb / pa, 1), 'K%': round(100 * so / pa, 1),
+        'wOBA': round(0.69 * bb / pa + 0.88 * singles / pa + 1.25 * doubles / pa
+                      + 1.58 * triples / pa + 2.03 * hr / pa, 3),
+        'wRC+': rng.randint(75, 145),
+    }
+
+
+def pitching_line(rng: random.Random, starter: bool) -> Dict:
+    """Season pitching line in the player_season_aggregates format"""
+    ip = rng.uniform(140, 190) if starter else rng.uniform(45, 70)
+    k9, bb9, hr9 = rng.uniform(7.0, 11.0), rng.uniform(2.2, 4.0), rng.uniform(0.8, 1.5)
+    era = rng.uniform(2.9, 5.0)
+    fip = round(era + rng.uniform(-0.4, 0.4), 2)
+    return {
+        'G': rng.randint(26, 32) if starter else rng.randint(50, 70),
+        'GS': rng.randint(26, 32) if starter else 0,
+        'IP': round(ip, 1), 'ERA': round(era, 2),
+        'WHIP': round(rng.uniform(1.05, 1.45), 2), 'FIP': fip,
+        'xFIP': round(fip + rng.uniform(-0.2, 0.2), 2),
+        'ERA+': round(100 * 4.2 / era), 'K/9': round(k9, 1), 'BB/9': round(bb9, 1),
+        'HR/9': round(hr9, 1), 'SO': round(ip * k9 / 9), 'BB': round(ip * bb9 / 9),
+        'HR': round(ip * hr9 / 9),
+    }
+
+
+def demo_season_stats(players: List[Dict], seed: int = 11) -> List[Dict]:
+    """Season aggregates for every demo player, keyed by player_id and stats_type"""
+    rng = random.Random(seed)
+    stats = []
+    for player in players:
+        if player['role'] == 'batter':
+            line = batting_line(rng)
+            stats.append({'player_id': player['player_id'], 'stats_type': 'batting',
+                          'stats': line, 'games_played': round(line['PA'] / 4.1)})
+        else:
+            line = pitching_line(rng, player['role'] == 'starter')
+            stats.append({'player_id': player['player_id'], 'stats_type': 'pitching',
+                          'stats': line, 'games_played': line['G']})
+    return stats
+
+
+def demo_schedule(today: date) -> List[Dict]:
+    """Games between the demo teams: finished ones before today, scheduled ones from today"""
+    games = []
+    for i in range(DEMO_FINAL_GAMES + DEMO_SCHEDULED_GAMES):
+        offset = i - DEMO_FINAL_GAMES
+        home, away = DEMO_TEAMS[i % 2], DEMO_TEAMS[(i + 1) % 2]
+        games.append({
+            'game_id': f'{DEMO_PREFIX}{i + 1}',
+            'game_date': today + timedelta(days=offset),
+            'game_time': time(19, 5) if i % 3 else time(13, 10),
+            'home_team_id': home['team_id'],
+            'away_team_id': away['team_id'],
+            'stadium_id': home['stadium_id'],
+            'umpire_id': DEMO_UMPIRES[i % len(DEMO_UMPIRES)]['umpire_id'],
+            'status': 'completed' if offset < 0 else 'scheduled',
+            'weather_data': {'temperature': 68 + 3 * (i % 4), 'condition': 'Clear',
+                             'wind_speed': 4 + i % 6, 'wind_direction': 'Out to CF',
+                             'humidity': 45 + 5 * (i % 3)},
+        })
+    return games
+
+
+def _new_batting_line(player_id: str, team_id: str, order: Optional[int], position: str) -> Dict:
+    return {'player_id': player_id, 'team_id': team_id, 'batting_order': order, 'position': position,
+            'at_bats': 0, 'runs': 0, 'hits': 0, 'rbis': 0, 'walks': 0, 'strikeouts': 0,
+            'doubles': 0, 'triples': 0, 'home_runs': 0}
+
+
+def _new_pitching_line(player_id: str, team_id: str) -> Dict:
+    return {'player_id': player_id, 'team_id': team_id, 'outs': 0, 'hits_allowed': 0,
+            'runs_allowed': 0, 'earned_runs': 0, 'walks_allowed': 0, 'strikeouts': 0,
+            'home_runs_allowed': 0, 'pitches_thrown': 0, 'strikes': 0,
+            'win': False, 'loss': False}
+
+
+def innings_pitched(outs: int) -> float:
+    """Outs in box score notation, where 6.1 is six and a third innings"""
+    return outs // 3 + (outs % 3) / 10
+
+
+def play_game(game: Dict, players: List[Dict], seed: int) -> Dict:
+    """Play a finished demo game out plate appearance by plate appearance.
+
+    Returns the plays plus batting and pitching box scores that agree with
+    them, and the final score.
+    """
+    rng = random.Random(seed)
+    outcomes = [name for name, _ in PLATE_APPEARANCE_OUTCOMES]
+    weights = [weight for _, weight in PLATE_APPEARANCE_OUTCOMES]
+
+    sides = {}
+    for side in ('away', 'home'):
+        team_id = game[f'{side}_team_id']
+        roster = [p for p in players if p['team_id'] == team_id]
+        lineup = [p for p in roster if p['role'] == 'batter'][:9]
+        starters = [p for p in roster if p['role'] == 'starter']
+        sides[side] = {
+            'team_id': team_id,
+            'lineup': lineup,
+            'starter': starters[seed % len(starters)],
+            'bullpen': [p for p in roster if p['role'] == 'reliever'],
+            'next_batter': 0,
+        }
+
+    batting = {}
+    pitching = {}
+    plays = []
+    score = {'away': 0, 'home': 0}
+
+    for side in ('away', 'home'):
+        for order, player in enumerate(sides[side]['lineup'], start=1):
+            batting[player['player_id']] = _new_batting_line(
+                player['player_id'], sides[side]['team_id'], order, player['position'])
+
+    inning = 0
+    while True:
+        inning += 1
+        for half, offense, defense in (('top', 'away', 'home'), ('bottom', 'home', 'away')):
+            # The home team doesn't bat in the ninth or later when already ahead
+            if half == 'bottom' and inning >= 9 and score['home'] > score['away']:
+                break
+
+            fielding = sides[defense]
+            relievers_used = max(0, inning - STARTER_INNINGS)
+            if relievers_used == 0:
+                pitcher = fielding['starter']
+            else:
+                pitcher = fielding['bullpen'][(relievers_used - 1) % len(fielding['bullpen'])]
+            line = pitching.setdefault(pitcher['player_id'],
+                                       _new_pitching_line(pitcher['player_id'], fielding['team_id']))
+
+            outs = 0
+            bases = [None, None, None]
+            while outs < 3:
+                batting_side = sides[offense]
+                batter = batting_side['lineup'][batting_side['next_batter'] % 9]
+                batting_side['next_batter'] += 1
+                box = batting[batter['player_id']]
+
+                event = rng.choices(outcomes, weights)[0]
+                pitches = rng.randint(1, 7) if event not in ('walk', 'strikeout') else rng.randint(4, 8)
+                runners_on = [b for b in bases]
+                scored = []
+
+                if event in ('strikeout', 'groundout', 'flyout'):
+                    outs += 1
+                    box['at_bats'] += 1
+                    if event == 'strikeout':
+                        box['strikeouts'] += 1
+                        line['strikeouts'] += 1
+                elif event == 'walk':
+                    box['walks'] += 1
+                    line['walks_allowed'] += 1
+                    # Force runners along
+                    runner = batter['player_id']
+                    for base in range(3):
+                        runner, bases[base] = bases[base], runner
+                        if runner is None:
+                            break
+                    if runner is not None:
+                        scored.append(runner)
+                else:
+                    advance = HIT_BASES[event]
+                    box['at_bats'] += 1
+                    box['hits'] += 1
+                    line['hits_allowed'] += 1
+                    if event == 'double':
+                        box['doubles'] += 1
+                    elif event == 'triple':
+                        box['triples'] += 1
+                    elif event == 'home_run':
+                        box['home_runs'] += 1
+                        line['home_runs_allowed'] += 1
+                    moved = [None, None, None]
+                    for base in (2, 1, 0):
+                        if bases[base] is None:
+                            continue
+                        if base + advance >= 3:
+                            scored.append(bases[base])
+                        else:
+                            moved[base + advance] = bases[base]
+                    if advance >= 4:
+                        scored.append(batter['player_id'])
+                    else:
+                        moved[advance - 1] = batter['player_id']
+                    bases = moved
+
+                for runner in scored:
+                    batting[runner]['runs'] += 1
+                box['rbis'] += len(scored)
+                score[offense] += len(scored)
+                line['runs_allowed'] += len(scored)
+                line['earned_runs'] += len(scored)
+                line['pitches_thrown'] += pitches
+                line['strikes'] += max(1, round(pitches * 0.63))
+                if event in ('strikeout', 'groundout', 'flyout'):
+                    line['outs'] += 1
+
+                plays.append({
+                    'play_id': f"{game['game_id']}-{len(plays) + 1}",
+                    'inning': inning,
+                    'inning_half': half,
+                    'outs': outs,
+                    'batter_id': batter['player_id'],
+                    'pitcher_id': pitcher['player_id'],
+                    'event_type': event,
+                    'description': f"{batter['full_name']} {event.replace('_', ' ')}",
+                    'rbi': len(scored),
+                    'runs_scored': len(scored),
+                    'runners_on': runners_on,
+                    'runners_after': [b for b in bases],
+                    'home_score': score['home'],
+                    'away_score': score['away'],
+                })
+
+                # Walk-off: the game ends as soon as the home team leads late
+                if half == 'bottom' and inning >= 9 and score['home'] > score['away']:
+                    break
+
+        if inning >= 9 and score['home'] != score['away']:
+            break
+        if inning >= MAX_GAME_INNINGS:
+            break
+
+    # Decisions go to the starters
+    if score['home'] != score['away']:
+        winner = 'home' if score['home'] > score['away'] else 'away'
+        loser = 'away' if winner == 'home' else 'home'
+        pitching[sides[winner]['starter']['player_id']]['win'] = True
+        pitching[sides[loser]['starter']['player_id']]['loss'] = True
+
+    for line in pitching.values():
+        line['innings_pitched'] = innings_pitched(line.pop('outs'))
+
+    return {
+        'plays': plays,
+        'batting': list(batting.values()),
+        'pitching': list(pitching.values()),
+        'home_score': score['home'],
+        'away_score': score['away'],
+    }
+
+
+async def seed_demo_data(db_pool, today: Optional[date] = None) -> bool:
+    """Seed the demo dataset into an empty database.
+
+    Only runs on first boot: if any team exists the database is left alone.
+    Returns whether anything was seeded.
+    """
+    today = today or date.today()
+    async with db_pool.acquire() as conn:
+        if await conn.fetchval("SELECT COUNT(*) FROM teams") > 0:
+            logger.info("Database already has teams; skipping demo data seeding")
+            return False
+
+        season = demo_season(today)
+        players = demo_players()
+        schedule = demo_schedule(today)
+
+        async with conn.transaction():
+            stadium_ids = {}
+            for stadium in DEMO_STADIUMS:
+                stadium_ids[stadium['stadium_id']] = await conn.fetchval("""
+                    INSERT INTO stadiums (stadium_id, name, location, capacity, dimensions, roof_type, surface)
+                    VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7)
+                    ON CONFLICT (stadium_id) DO UPDATE SET name = EXCLUDED.name
+                    RETURNING id
+                """, stadium['stadium_id'], stadium['name'], stadium['location'], stadium['capacity'],
+                    json.dumps(stadium['dimensions']), stadium['roof_type'], stadium['surface'])
+
+            team_ids = {}
+            for team in DEMO_TEAMS:
+                team_ids[team['team_id']] = await conn.fetchval("""
+                    INSERT INTO teams (team_id, name, abbreviation, league, division, stadium_id)
+                    VALUES ($1, $2, $3, $4, $5, $6)
+                    ON CONFLICT (team_id) DO UPDATE SET name = EXCLUDED.name
+                    RETURNING id
+                """, team['team_id'], team['name'], team['abbreviation'], team['league'],
+                    team['division'], stadium_ids[team['stadium_id']])
+
+            umpire_ids = {}
+            for umpire in DEMO_UMPIRES:
+                umpire_ids[umpire['umpire_id']] = await conn.fetchval("""
+                    INSERT INTO umpires (umpire_id, name)
+                    VALUES ($1, $2)
+                    ON CONFLICT (umpire_id) DO UPDATE SET name = EXCLUDED.name
+                    RETURNING id
+                """, umpire['umpire_id'], umpire['name'])
+
+            player_ids = {}
+            for player in players:
+                player_ids[player['player_id']] = await conn.fetchval("""
+                    INSERT INTO players (
+                        player_id, first_name, last_name, full_name, birth_date,
+                        position, bats, throws, team_id, status, jersey_number
+                    )
+                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 'A', $10)
+                    ON CONFLICT (player_id) DO UPDATE SET team_id = EXCLUDED.team_id
+                    RETURNING id
+                """, player['player_id'], player['first_name'], player['last_name'],
+                    player['full_name'], player['birth_date'], player['position'], player['bats'],
+                    player['throws'], team_ids[player['team_id']], player['jersey_number'])
+
+            for line in demo_season_stats(players):
+                await conn.execute("""
+                    INSERT INTO player_season_aggregates
+                    (player_id, season, stats_type, aggregated_stats, games_played)
+                    VALUES ($1, $2, $3, $4, $5)
+                    ON CONFLICT (player_id, season, stats_type) DO NOTHING
+                """, player_ids[line['player_id']], season, line['stats_type'],
+                    json.dumps(line['stats']), line['games_played'])
+
+            for i, game in enumerate(schedule):
+                result = play_game(game, players, seed=i) if game['status'] == 'completed' else None
+                game_uuid = await conn.fetchval("""
+                    INSERT INTO games (
+                        game_id, game_date, game_time, home_team_id, away_team_id, stadium_id,
+                        home_plate_umpire_id, weather_data, season, game_type, status,
+                        final_score_home, final_score_away, first_pitch_at
+                    )
+                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8::jsonb, $9, 'R', $10, $11, $12,
+                            ($2::date + $3::time) AT TIME ZONE 'America/New_York')
+                    ON CONFLICT (game_id) DO UPDATE SET status = EXCLUDED.status
+                    RETURNING id
+                """, game['game_id'], game['game_date'], game['game_time'],
+                    team_ids[game['home_team_id']], team_ids[game['away_team_id']],
+                    stadium_ids[game['stadium_id']], umpire_ids[game['umpire_id']],
+                    json.dumps(game['weather_data']), game['game_date'].year, game['status'],
+                    result['home_score'] if result else None, result['away_score'] if result else None)
+                if result:
+                    await _insert_game_details(conn, game_uuid, result, team_ids, player_ids)
+
+    logger.info(f"Seeded demo data: {len(DEMO_TEAMS)} teams, {len(players)} players, "
+                f"{len(schedule)} games ({DEMO_FINAL_GAMES} final)")
+    return True
+
+
+async def _insert_game_details(conn, game_uuid, result: Dict, team_ids: Dict, player_ids: Dict):
+    """Box scores and play-by-play for a finished demo game"""
+    for line in result['batting']:
+        await conn.execute("""
+            INSERT INTO game_box_score_batting (
+                game_id, player_id, team_id, batting_order, position, at_bats, runs, hits,
+                rbis, walks, strikeouts, doubles, triples, home_runs
+            )
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+            ON CONFLICT (game_id, player_id) DO NOTHING
+        """, game_uuid, player_ids[line['player_id']], team_ids[line['team_id']],
+            line['batting_order'], line['position'], line['at_bats'], line['runs'], line['hits'],
+            line['rbis'], line['walks'], line['strikeouts'], line['doubles'], line['triples'],
+            line['home_runs'])
+
+    for line in result['pitching']:
+        await conn.execute("""
+            INSERT INTO game_box_score_pitching (
+                game_id, player_id, team_id, innings_pitched, hits_allowed, runs_allowed,
+                earned_runs, walks_allowed, strikeouts, home_runs_allowed, pitches_thrown,
+                strikes, win, loss
+            )
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+            ON CONFLICT (game_id, player_id) DO NOTHING
+        """, game_uuid, player_ids[line['player_id']], team_ids[line['team_id']],
+            line['innings_pitched'], line['hits_allowed'], line['runs_allowed'], line['earned_runs'],
+            line['walks_allowed'], line['strikeouts'], line['home_runs_allowed'],
+            line['pitches_thrown'], line['strikes'], line['win'], line['loss'])
+
+    def runner_uuids(bases):
+        return [str(player_ids[b]) if b else None for b in bases]
+
+    for play in result['plays']:
+        await conn.execute("""
+            INSERT INTO game_plays (
+                game_id, play_id, inning, inning_half, outs, batter_id, pitcher_id,
+                event_type, description, rbi, runs_scored, runners_on, runners_after,
+                home_score, away_score
+            )
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12::jsonb, $13::jsonb, $14, $15)
+            ON CONFLICT (game_id, play_id) DO NOTHING
+        """, game_uuid, play['play_id'], play['inning'], play['inning_half'], play['outs'],
+            player_ids[play['batter_id']], player_ids[play['pitcher_id']], play['event_type'],
+            play['description'], play['rbi'], play['runs_scored'],
+            json.dumps(runner_uuids(play['runners_on'])), json.dumps(runner_uuids(play['runners_after'])),
+            play['home_score'], play['away_score'])
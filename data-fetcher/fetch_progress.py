@@ -0,0 +1,158 @@
+"""
+Data fetch job progress
+Counts entities fetched, rows upserted and errors for each stage of a fetch
+and periodically saves a snapshot to data_fetch_status.progress so the
+gateway can report it while the job runs
+"""
+import json
+import logging
+import time
+from typing import Callable, Dict, List, Optional
+
+import asyncpg
+
+logger = logging.getLogger(__name__)
+
+# How often a running job writes its progress to the database
+PERSIST_INTERVAL_SECONDS = 2.0
+
+# Only the most recent error messages are kept; the count covers all of them
+MAX_RECORDED_ERRORS = 20
+
+# Stages each fetch type runs, in order
+FETCH_STAGES = {
+    'all': ['teams', 'players', 'games', 'park_factors', 'umpires', 'stats'],
+    'teams': ['teams'],
+    'players': ['players'],
+    'games': ['games'],
+    'stats': ['stats'],
+}
+
+
+class StageProgress:
+    """Counters for one stage of a fetch"""
+
+    def __init__(self, name: str):
+        self.name = name
+        self.total: Optional[int] = None
+        self.fetched = 0
+        self.rows_upserted = 0
+        self.errors = 0
+        self.completed = False
+
+    def fraction(self) -> float:
+        if self.completed:
+            return 1.0
+        if not self.total:
+            return 0.0
+        return min(1.0, self.fetched / self.total)
+
+    def to_dict(self) -> Dict:
+        return {
+            'name': self.name,
+            'total': self.total,
+            'fetched': self.fetched,
+            'rows_upserted': self.rows_upserted,
+            'errors': self.errors,
+            'completed': self.completed,
+        }
+
+
+class FetchProgress:
+    """Progress of one data fetch job. Without a job_id nothing is saved, so
+    untracked fetches can share the same instrumented code."""
+
+    def __init__(self, stages: List[str], db_pool: Optional[asyncpg.Pool] = None,
+                 job_id: Optional[int] = None, clock: Callable[[], float] = time.monotonic):
+        self.db_pool = db_pool
+        self.job_id = job_id
+        self._clock = clock
+        self._started = clock()
+        self._last_persisted: Optional[float] = None
+        self.stages: Dict[str, StageProgress] = {name: StageProgress(name) for name in stages}
+        self.current: Optional[StageProgress] = None
+        self.recent_errors: List[str] = []
+
+    def start_stage(self, name: str, total: Optional[int] = None):
+        """Begin a stage, completing the previous one"""
+        if self.current and self.current.name != name:
+            self.current.completed = True
+        if name not in self.stages:
+            self.stages[name] = StageProgress(name)
+        self.current = self.stages[name]
+        if total is not None:
+            self.current.total = total
+
+    def add_total(self, count: int):
+        """Grow the current stage's total, for stages that span several seasons"""
+        if self.current:
+            self.current.total = (self.current.total or 0) + count
+
+    def advance(self, count: int = 1):
+        """Count entities fetched in the current stage"""
+        if self.current:
+            self.current.fetched += count
+
+    def add_rows(self, count: int = 1):
+        """Count rows written by the current stage"""
+        if self.current:
+            self.current.rows_upserted += count
+
+    def record_error(self, message: str):
+        """Count a failed entity and keep its message"""
+        if self.current:
+            self.current.errors += 1
+        self.recent_errors.append(message)
+        del self.recent_errors[:-MAX_RECORDED_ERRORS]
+
+    def complete(self):
+        """Mark every stage finished"""
+        for stage in self.stages.values():
+            stage.completed = True
+
+    def fraction(self) -> float:
+        """Share of the job done, weighting each stage equally"""
+        if not self.stages:
+            return 0.0
+        return sum(stage.fraction() for stage in self.stages.values()) / len(self.stages)
+
+    def eta_seconds(self) -> Optional[float]:
+        """Seconds left at the pace so far, or None before any progress"""
+        fraction = self.fraction()
+        if fraction <= 0:
+            return None
+        if fraction >= 1:
+            return 0.0
+        elapsed = self._clock() - self._started
+        return round(elapsed * (1 - fraction) / fraction, 1)
+
+    def snapshot(self) -> Dict:
+        stages = list(self.stages.values())
+        return {
+            'current_stage': self.current.name if self.current else None,
+            'percent_complete': round(self.fraction() * 100, 1),
+            'eta_seconds': self.eta_seconds(),
+            'elapsed_seconds': round(self._clock() - self._started, 1),
+            'entities_fetched': sum(stage.fetched for stage in stages),
+            'rows_upserted': sum(stage.rows_upserted for stage in stages),
+            'errors': sum(stage.errors for stage in stages),
+            'recent_errors': list(self.recent_errors),
+            'stages': [stage.to_dict() for stage in stages],
+        }
+
+    async def persist(self, force: bool = False):
+        """Save a snapshot, at most every PERSIST_INTERVAL_SECONDS unless forced"""
+        if self.db_pool is None or self.job_id is None:
+            return
+        now = self._clock()
+        if not force and self._last_persisted is not None and \
+                now - self._last_persisted < PERSIST_INTERVAL_SECONDS:
+            return
+        self._last_persisted = now
+        try:
+            await self.db_pool.execute("""
+                UPDATE data_fetch_status SET progress = $2::jsonb WHERE id = $1
+            """, self.job_id, json.dumps(self.snapshot()))
+        except Exception as e:
+            # Progress is best effort; never fail the fetch over it
+            logger.warning(f"Failed to save progress for fetch job {self.job_id}: {e}")
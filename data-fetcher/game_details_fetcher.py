@@ -82,6 +82,9 @@ class GameDetailsFetcher:
             # Update weather data
             await self._update_weather(game_uuid, game_data)
 
+            # Record how long the game took
+            await self._update_duration(game_uuid, game_data)
+
             # Save box scores
             await self._save_box_scores(game_uuid, live_data.get("boxscore", {}))
 
@@ -162,6 +165,29 @@ class GameDetailsFetcher:
         except Exception as e:
             logger.error(f"Failed to update weather for game {game_uuid}: {e}")
 
+    async def _update_duration(self, game_uuid: UUID, game_data: Dict):
+        """Store the game's length, which calibrates the simulation duration model"""
+        game_info = game_data.get("gameInfo", {})
+        minutes = game_info.get("gameDurationMinutes")
+        if not minutes:
+            return
+
+        # Rain and other delays say nothing about pace of play
+        minutes -= game_info.get("delayDurationMinutes") or 0
+
+        try:
+            await self.db_pool.execute(
+                """
+                UPDATE games
+                SET duration_minutes = $1
+                WHERE id = $2
+                """,
+                int(minutes),
+                game_uuid
+            )
+        except Exception as e:
+            logger.error(f"Failed to update duration for game {game_uuid}: {e}")
+
     async def _save_box_scores(self, game_uuid: UUID, boxscore: Dict):
         """Save batting and pitching box scores"""
         try:
@@ -419,7 +445,7 @@ async def fetch_all_game_details(db_pool: asyncpg.Pool, limit: Optional[int] = N
             SELECT g.id, g.game_id
             FROM games g
             LEFT JOIN game_box_score_batting b ON g.id = b.game_id
-            WHERE g.status = 'Final'
+            WHERE g.status = 'completed'
             AND b.id IS NULL
             ORDER BY g.game_date DESC
         """
@@ -0,0 +1,71 @@
+"""
+Game states
+A game is stored as scheduled, live or completed. Final scores are only
+stored once a game is completed; while it is being played its running score,
+inning and outs go in the current_* columns, so stats queries that filter on
+completed games never count a game in progress.
+"""
+from datetime import datetime
+from typing import Dict, Optional
+
+STATUS_SCHEDULED = 'scheduled'
+STATUS_LIVE = 'live'
+STATUS_COMPLETED = 'completed'
+
+# MLB detailed states of games that aren't stored
+SKIPPED_STATES = ('postponed', 'suspended', 'cancelled')
+
+
+def schedule_game_state(game: Dict) -> Optional[Dict]:
+    """The status, final score and live state to store for a schedule entry
+    (hydrated with its linescore), or None when the game isn't stored:
+    postponed, suspended and cancelled games, and final games without a
+    score."""
+    status = game.get('status', {})
+    coded_state = status.get('codedGameState', '')
+    detailed_state = status.get('detailedState', '')
+    abstract_state = status.get('abstractGameState', '')
+
+    if any(state in detailed_state.lower() for state in SKIPPED_STATES):
+        return None
+
+    teams = game.get('teams', {})
+    home_score = teams.get('home', {}).get('score')
+    away_score = teams.get('away', {}).get('score')
+
+    if abstract_state == 'Final' and coded_state == 'F':
+        if home_score is None or away_score is None:
+            return None
+        return {'status': STATUS_COMPLETED, 'home_score': home_score,
+                'away_score': away_score, 'live': None}
+
+    if abstract_state == 'Live':
+        linescore = game.get('linescore', {})
+        line_teams = linescore.get('teams', {})
+        return {'status': STATUS_LIVE, 'home_score': None, 'away_score': None, 'live': {
+            'home_score': line_teams.get('home', {}).get('runs', home_score or 0),
+            'away_score': line_teams.get('away', {}).get('runs', away_score or 0),
+            'inning': linescore.get('currentInning'),
+            'inning_half': (linescore.get('inningState') or linescore.get('inningHalf') or '').lower() or None,
+            'outs': linescore.get('outs'),
+        }}
+
+    if abstract_state == 'Preview':
+        return {'status': STATUS_SCHEDULED, 'home_score': None,
+                'away_score': None, 'live': None}
+
+    return None
+
+
+def schedule_first_pitch(game: Dict) -> Optional[datetime]:
+    """The scheduled first pitch of a schedule entry as an aware UTC datetime,
+    or None when the start time is still to be determined or missing"""
+    if game.get('status', {}).get('startTimeTBD'):
+        return None
+    value = game.get('gameDate')
+    if not value:
+        return None
+    try:
+        return datetime.fromisoformat(value.replace('Z', '+00:00'))
+    except ValueError:
+        return None
@@ -0,0 +1,286 @@
+"""
+Stadium coordinate geocoding
+Fills in latitude/longitude for stadiums the MLB venue feed left without
+them, so the sim engine's weather service can fetch a real forecast instead
+of falling back to defaults. Providers are pluggable; manual overrides set
+through the API are never replaced.
+"""
+import asyncio
+import logging
+import time
+from typing import Awaitable, Callable, Dict, List, Optional, Tuple
+
+import asyncpg
+import httpx
+
+from aliases import resolve_entity_id
+
+logger = logging.getLogger(__name__)
+
+# Where stored coordinates came from
+SOURCE_MLB = 'mlb'
+SOURCE_MANUAL = 'manual'
+
+# Roof types that expose the field to the weather. NULL is treated as open,
+# matching the column's default.
+WEATHER_EXPOSED_ROOF_TYPES = ('open', 'retractable')
+
+# Home plate coordinates of current and recent MLB parks
+KNOWN_PARK_COORDINATES: Dict[str, Tuple[float, float]] = {
+    "American Family Field": (43.0280, -87.9712),
+    "Angel Stadium": (33.8003, -117.8827),
+    "Busch Stadium": (38.6226, -90.1928),
+    "Chase Field": (33.4453, -112.0667),
+    "Citi Field": (40.7571, -73.8458),
+    "Citizens Bank Park": (39.9061, -75.1665),
+    "Comerica Park": (42.3390, -83.0485),
+    "Coors Field": (39.7559, -104.9942),
+    "Daikin Park": (29.7573, -95.3555),
+    "Dodger Stadium": (34.0739, -118.2400),
+    "Fenway Park": (42.3467, -71.0972),
+    "George M. Steinbrenner Field": (27.9803, -82.5067),
+    "Globe Life Field": (32.7473, -97.0847),
+    "Great American Ball Park": (39.0975, -84.5066),
+    "Guaranteed Rate Field": (41.8299, -87.6338),
+    "Kauffman Stadium": (39.0517, -94.4803),
+    "loanDepot park": (25.7781, -80.2197),
+    "Minute Maid Park": (29.7573, -95.3555),
+    "Nationals Park": (38.8730, -77.0074),
+    "Oakland Coliseum": (37.7516, -122.2005),
+    "Oracle Park": (37.7786, -122.3893),
+    "Oriole Park at Camden Yards": (39.2840, -76.6217),
+    "Petco Park": (32.7073, -117.1566),
+    "PNC Park": (40.4469, -80.0057),
+    "Progressive Field": (41.4962, -81.6852),
+    "Rate Field": (41.8299, -87.6338),
+    "Rogers Centre": (43.6414, -79.3894),
+    "Sutter Health Park": (38.5804, -121.5136),
+    "T-Mobile Park": (47.5914, -122.3325),
+    "Target Field": (44.9817, -93.2776),
+    "Tropicana Field": (27.7682, -82.6534),
+    "Truist Park": (33.8908, -84.4678),
+    "Wrigley Field": (41.9484, -87.6553),
+    "Yankee Stadium": (40.8296, -73.9262),
+}
+
+
+def valid_coordinates(latitude, longitude) -> Optional[Tuple[float, float]]:
+    """Return the pair as floats when it is a usable location, else None.
+    (0, 0) is rejected: the weather service reads it as "no coordinates"."""
+    try:
+        lat, lon = float(latitude), float(longitude)
+    except (TypeError, ValueError):
+        return None
+    if not (-90 <= lat <= 90 and -180 <= lon <= 180):
+        return None
+    if lat == 0 and lon == 0:
+        return None
+    return lat, lon
+
+
+def venue_coordinates(venue: Dict) -> Optional[Tuple[float, float]]:
+    """Coordinates from an MLB venue's hydrated location, if present"""
+    coords = (venue.get('location') or {}).get('defaultCoordinates') or {}
+    return valid_coordinates(coords.get('latitude'), coords.get('longitude'))
+
+
+class GeocodingProvider:
+    """Resolves a stadium to coordinates. Subclasses set name, which is
+    stored as the coordinates' source."""
+
+    name = 'none'
+
+    async def geocode(self, stadium_name: str, location: Optional[str]) -> Optional[Tuple[float, float]]:
+        raise NotImplementedError
+
+    async def close(self):
+        pass
+
+
+class StaticGeocodingProvider(GeocodingProvider):
+    """Looks parks up in a built-in table; needs no network access"""
+
+    name = 'static'
+
+    def __init__(self, coordinates: Optional[Dict[str, Tuple[float, float]]] = None):
+        table = coordinates if coordinates is not None else KNOWN_PARK_COORDINATES
+        self._coordinates = {name.lower(): coords for name, coords in table.items()}
+
+    async def geocode(self, stadium_name: str, location: Optional[str]) -> Optional[Tuple[float, float]]:
+        coords = self._coordinates.get((stadium_name or '').strip().lower())
+        return valid_coordinates(*coords) if coords else None
+
+
+class NominatimGeocodingProvider(GeocodingProvider):
+    """Searches OpenStreetMap's Nominatim for the park name and city. The
+    public instance allows one request a second, so requests are spaced at
+    least min_interval seconds apart, even when geocode is called
+    concurrently."""
+
+    name = 'nominatim'
+
+    def __init__(self, base_url: str = "https://nominatim.openstreetmap.org",
+                 client: Optional[httpx.AsyncClient] = None, min_interval: float = 1.0,
+                 clock: Callable[[], float] = time.monotonic,
+                 sleep: Callable[[float], Awaitable[None]] = asyncio.sleep):
+        self.base_url = base_url.rstrip('/')
+        self._owns_client = client is None
+        self.client = client or httpx.AsyncClient(
+            timeout=10,
+            headers={'User-Agent': 'BaseballSimulation/2.0'},
+        )
+        self.min_interval = min_interval
+        self._clock = clock
+        self._sleep = sleep
+        self._lock = asyncio.Lock()
+        self._last_request: Optional[float] = None
+
+    async def _throttle(self):
+        """Wait until min_interval has passed since the previous request.
+        Callers hold the lock, so concurrent lookups queue up."""
+        if self._last_request is not None:
+            wait = self._last_request + self.min_interval - self._clock()
+            if wait > 0:
+                await self._sleep(wait)
+        self._last_request = self._clock()
+
+    async def geocode(self, stadium_name: str, location: Optional[str]) -> Optional[Tuple[float, float]]:
+        query = ', '.join(part for part in (stadium_name, location) if part)
+        async with self._lock:
+            await self._throttle()
+            response = await self.client.get(
+                f"{self.base_url}/search",
+                params={'q': query, 'format': 'json', 'limit': 1},
+            )
+        response.raise_for_status()
+        results = response.json()
+        if not results:
+            return None
+        return valid_coordinates(results[0].get('lat'), results[0].get('lon'))
+
+    async def close(self):
+        if self._owns_client:
+            await self.client.aclose()
+
+
+class ChainedGeocodingProvider(GeocodingProvider):
+    """Tries each provider in turn; the first to resolve a park wins"""
+
+    def __init__(self, providers: List[GeocodingProvider]):
+        self.providers = providers
+        self.name = '+'.join(p.name for p in providers)
+        self.last_provider: Optional[GeocodingProvider] = None
+
+    async def geocode(self, stadium_name: str, location: Optional[str]) -> Optional[Tuple[float, float]]:
+        for provider in self.providers:
+            try:
+                coords = await provider.geocode(stadium_name, location)
+            except Exception as e:
+                logger.warning(f"{provider.name} geocoding failed for {stadium_name}: {e}")
+                continue
+            if coords:
+                self.last_provider = provider
+                return coords
+        return None
+
+    async def close(self):
+        for provider in self.providers:
+            await provider.close()
+
+
+def get_geocoding_provider(name: str) -> GeocodingProvider:
+    """Build the provider named by GEOCODING_PROVIDER. "nominatim" still
+    checks the built-in table first to avoid needless requests."""
+    name = (name or 'static').strip().lower()
+    if name == 'static':
+        return StaticGeocodingProvider()
+    if name == 'nominatim':
+        return ChainedGeocodingProvider([StaticGeocodingProvider(), NominatimGeocodingProvider()])
+    raise ValueError(f"Unknown geocoding provider {name!r} (expected static or nominatim)")
+
+
+def _source_of(provider: GeocodingProvider) -> str:
+    if isinstance(provider, ChainedGeocodingProvider) and provider.last_provider:
+        return provider.last_provider.name
+    return provider.name
+
+
+async def backfill_stadium_coordinates(db_pool: asyncpg.Pool, provider: GeocodingProvider) -> Dict:
+    """Geocode every stadium without coordinates and report what is still
+    missing afterwards"""
+    stadiums = await db_pool.fetch("""
+        SELECT stadium_id, name, location
+        FROM stadiums
+        WHERE latitude IS NULL OR longitude IS NULL
+        ORDER BY name
+    """)
+
+    updated, unresolved = [], []
+    for stadium in stadiums:
+        try:
+            coords = await provider.geocode(stadium['name'], stadium['location'])
+        except Exception as e:
+            logger.warning(f"Geocoding failed for {stadium['name']}: {e}")
+            coords = None
+        if not coords:
+            unresolved.append(stadium['name'])
+            continue
+
+        await db_pool.execute("""
+            UPDATE stadiums
+            SET latitude = $2, longitude = $3,
+                coordinates_source = $4, coordinates_updated_at = NOW()
+            WHERE stadium_id = $1
+              AND coordinates_source IS DISTINCT FROM 'manual'
+        """, stadium['stadium_id'], coords[0], coords[1], _source_of(provider))
+        updated.append(stadium['name'])
+
+    missing = await weather_exposed_stadiums_missing_coordinates(db_pool)
+    if missing:
+        logger.warning(
+            "Outdoor stadiums still missing coordinates, weather will use defaults: "
+            + ', '.join(s['name'] for s in missing)
+        )
+
+    return {
+        'provider': provider.name,
+        'checked': len(stadiums),
+        'updated': updated,
+        'unresolved': unresolved,
+        'missing_outdoor': missing,
+    }
+
+
+async def weather_exposed_stadiums_missing_coordinates(db_pool: asyncpg.Pool) -> List[Dict]:
+    """Open-air and retractable-roof stadiums that have no coordinates, with
+    the number of upcoming games each hosts"""
+    rows = await db_pool.fetch("""
+        SELECT s.stadium_id, s.name, s.location, COALESCE(s.roof_type, 'open') AS roof_type,
+               COUNT(g.id) FILTER (WHERE g.game_date >= CURRENT_DATE) AS upcoming_games
+        FROM stadiums s
+        LEFT JOIN games g ON g.stadium_id = s.id
+        WHERE (s.latitude IS NULL OR s.longitude IS NULL)
+          AND COALESCE(s.roof_type, 'open') = ANY($1::text[])
+        GROUP BY s.id
+        ORDER BY upcoming_games DESC, s.name
+    """, list(WEATHER_EXPOSED_ROOF_TYPES))
+    return [dict(row) for row in rows]
+
+
+async def set_manual_coordinates(db_pool: asyncpg.Pool, stadium_id: str,
+                                 latitude: float, longitude: float) -> Optional[Dict]:
+    """Override a stadium's coordinates; stadium_id may be any identifier
+    resolve_entity_id accepts. Returns the updated stadium, or None when it
+    doesn't exist."""
+    stadium_uuid = await resolve_entity_id(db_pool, 'stadium', stadium_id)
+    if stadium_uuid is None:
+        return None
+    row = await db_pool.fetchrow("""
+        UPDATE stadiums
+        SET latitude = $2, longitude = $3,
+            coordinates_source = 'manual', coordinates_updated_at = NOW()
+        WHERE id = $1::uuid
+        RETURNING id::text AS id, stadium_id, name, latitude, longitude,
+                  coordinates_source, coordinates_updated_at
+    """, stadium_uuid, latitude, longitude)
+    return dict(row) if row else None
@@ -2,19 +2,30 @@
 Simplified MLB Data Fetcher Service
 """
 import os
+import json
 import asyncio
 import logging
 from datetime import datetime, timedelta
 from contextlib import asynccontextmanager
 
 import asyncpg
+import httpx
 import uvicorn
-from fastapi import FastAPI, HTTPException, Depends, BackgroundTasks
+from fastapi import FastAPI, HTTPException, Depends, BackgroundTasks, Request
 from fastapi.middleware.cors import CORSMiddleware
+from fastapi.responses import JSONResponse
 
 from config import settings
-from models import PlayerStatsRequest, LeaderboardRequest, FetchRequest, DataFetchStatus, FetchType, HistoricalStatsRequest, ErrorResponse, CatcherMetricsRequest, OutfielderMetricsRequest, CatcherLeaderboardRequest, OutfielderLeaderboardRequest
+from models import PlayerStatsRequest, LeaderboardRequest, FetchRequest, DataFetchStatus, FetchJobStatus, FetchType, HistoricalStatsRequest, StadiumCoordinatesRequest, GameVenueRequest, ContractsIngestRequest, AliasesIngestRequest, TeamBrandingRequest, ErrorResponse, CatcherMetricsRequest, OutfielderMetricsRequest, CatcherLeaderboardRequest, OutfielderLeaderboardRequest
 from mlb_stats_api import MLBStatsAPI
+from fetch_progress import FetchProgress, FETCH_STAGES
+from demo_data import seed_demo_data
+from war_calculator import WAR_METHODOLOGY
+from geocoding import set_manual_coordinates, weather_exposed_stadiums_missing_coordinates
+from contracts import upsert_player_contracts
+from aliases import AmbiguousIDError, upsert_entity_aliases
+from venues import set_manual_game_venue
+from branding import set_team_branding
 
 # Configure logging
 logging.basicConfig(
@@ -53,10 +64,19 @@ async def lifespan(app: FastAPI):
     # Ensure required tables exist
     logger.info("Connected to database with existing scheme.")
 
-    # Start background fetch task
-    app.state.fetch_task = asyncio.create_task(
-        periodic_data_fetch(app.state.db_pool)
-    )
+    app.state.fetch_task = None
+    if settings.demo_mode:
+        # Demo data stands in for the MLB API, so there is nothing to fetch
+        logger.info("DEMO_MODE enabled: seeding demo data, scheduled fetches disabled")
+        try:
+            await seed_demo_data(app.state.db_pool)
+        except Exception as e:
+            logger.error(f"Failed to seed demo data: {e}")
+    else:
+        # Start background fetch task
+        app.state.fetch_task = asyncio.create_task(
+            periodic_data_fetch(app.state.db_pool)
+        )
 
     yield
 
@@ -64,11 +84,12 @@ async def lifespan(app: FastAPI):
     logger.info("Shutting down MLB Data Fetcher service...")
 
     # Cancel background task
-    app.state.fetch_task.cancel()
-    try:
-        await app.state.fetch_task
-    except asyncio.CancelledError:
-        pass
+    if app.state.fetch_task:
+        app.state.fetch_task.cancel()
+        try:
+            await app.state.fetch_task
+        except asyncio.CancelledError:
+            pass
 
     # Close database pool
     await app.state.db_pool.close()
@@ -92,6 +113,25 @@ app.add_middleware(
 )
 
 
+@app.exception_handler(AmbiguousIDError)
+async def ambiguous_id_handler(request: Request, exc: AmbiguousIDError):
+    """An ID matching several entities is a conflict, as in the gateway"""
+    return JSONResponse(status_code=409, content={"detail": str(exc), "matches": exc.matches})
+
+
+async def notify_data_refreshed():
+    """Tell the sim engine to drop cached rosters and resolved IDs so new runs
+    see fresh data and aliases"""
+    try:
+        async with httpx.AsyncClient(timeout=5) as client:
+            response = await client.post(f"{settings.sim_engine_url}/admin/invalidate-cache")
+            response.raise_for_status()
+            logger.info(f"Sim engine roster cache invalidated: {response.json().get('rosters_evicted')} evicted")
+    except Exception as e:
+        # The cache TTL bounds staleness if the sim engine can't be reached
+        logger.warning(f"Failed to invalidate sim engine cache: {e}")
+
+
 async def periodic_data_fetch(db_pool: asyncpg.Pool):
     """Background task to fetch data periodically"""
     while True:
@@ -99,19 +139,19 @@ async def periodic_data_fetch(db_pool: asyncpg.Pool):
             logger.info("Starting scheduled data fetch...")
 
             # Record fetch start
-            await db_pool.execute("""
-                INSERT INTO data_fetch_status (started_at, status)
-                VALUES ($1, 'running')
-            """, datetime.utcnow())
+            job_id = await start_fetch_job(db_pool, FetchType.all)
+            progress = FetchProgress(FETCH_STAGES[FetchType.all.value], db_pool, job_id)
 
             # Create MLB API client and fetch data
-            async with MLBStatsAPI(db_pool) as mlb_api:
+            async with MLBStatsAPI(db_pool, progress) as mlb_api:
                 # Include today for live/postseason games
                 end_date = datetime.now()
                 start_date = end_date - timedelta(days=7)
                 await mlb_api.fetch_all_data(start_date, end_date)
 
             # Record successful completion
+            progress.complete()
+            await progress.persist(force=True)
             await db_pool.execute("""
                 UPDATE data_fetch_status
                 SET completed_at = $1, status = 'completed'
@@ -119,6 +159,7 @@ async def periodic_data_fetch(db_pool: asyncpg.Pool):
             """, datetime.utcnow())
 
             logger.info("Data fetch completed successfully")
+            await notify_data_refreshed()
 
         except Exception as e:
             logger.error(f"Error during data fetch: {e}")
@@ -146,12 +187,34 @@ async def health_check():
         return {"status": "unhealthy", "timestamp": datetime.utcnow()}
 
 
+async def start_fetch_job(db_pool: asyncpg.Pool, fetch_type: FetchType) -> int:
+    """Record a running fetch and return its job ID"""
+    return await db_pool.fetchval("""
+        INSERT INTO data_fetch_status (started_at, status, fetch_type)
+        VALUES ($1, 'running', $2)
+        RETURNING id
+    """, datetime.utcnow(), fetch_type.value)
+
+
+def fetch_job_status(row: asyncpg.Record) -> FetchJobStatus:
+    """Build a job's status from its data_fetch_status row"""
+    return FetchJobStatus(
+        job_id=row['id'],
+        fetch_type=row['fetch_type'],
+        status=row['status'],
+        started_at=row['started_at'],
+        completed_at=row['completed_at'],
+        error_message=row['error_message'],
+        progress=json.loads(row['progress']) if row['progress'] else None
+    )
+
+
 @app.get("/status", response_model=DataFetchStatus)
 async def get_fetch_status():
     """Get current data fetch status"""
     # Get latest fetch status
     status = await app.state.db_pool.fetchrow("""
-        SELECT started_at, completed_at, status, error_message
+        SELECT id, started_at, completed_at, status, error_message, fetch_type, progress
         FROM data_fetch_status
         ORDER BY id DESC
         LIMIT 1
@@ -181,10 +244,24 @@ async def get_fetch_status():
         last_error=status['error_message'] if status else None,
         total_teams=counts['teams'],
         total_players=counts['players'],
-        total_games=counts['games']
+        total_games=counts['games'],
+        current_job=fetch_job_status(status) if status else None
     )
 
 
+@app.get("/fetch/{job_id}", response_model=FetchJobStatus)
+async def get_fetch_job(job_id: int):
+    """Get a fetch job's progress: entities fetched, rows upserted, errors and ETA"""
+    row = await app.state.db_pool.fetchrow("""
+        SELECT id, started_at, completed_at, status, error_message, fetch_type, progress
+        FROM data_fetch_status
+        WHERE id = $1
+    """, job_id)
+    if not row:
+        raise HTTPException(status_code=404, detail="Fetch job not found")
+    return fetch_job_status(row)
+
+
 @app.post("/fetch")
 async def trigger_manual_fetch(
     request: FetchRequest,
@@ -202,14 +279,18 @@ async def trigger_manual_fetch(
     if status:
         raise HTTPException(status_code=409, detail="Fetch already in progress")
 
+    # Record the job before starting it so callers can poll its progress
+    job_id = await start_fetch_job(app.state.db_pool, request.fetch_type)
+
     # Add fetch task to background
     background_tasks.add_task(
         manual_fetch,
         app.state.db_pool,
-        request
+        request,
+        job_id
     )
 
-    return {"message": "Fetch triggered successfully"}
+    return {"message": "Fetch triggered successfully", "job_id": job_id}
 
 @app.post("/fetch/historical-stats")
 async def fetch_historical_stats(
@@ -244,16 +325,13 @@ async def fetch_stats_for_years(db_pool: asyncpg.Pool, start_year: int, end_year
         logger.error(f"Error in historical stats fetch: {e}")
 
 
-async def manual_fetch(db_pool: asyncpg.Pool, request: FetchRequest):
+async def manual_fetch(db_pool: asyncpg.Pool, request: FetchRequest, job_id: int):
     """Perform manual data fetch"""
+    progress = FetchProgress(FETCH_STAGES[request.fetch_type.value], db_pool, job_id)
     try:
-        # Record fetch start
-        await db_pool.execute("""
-            INSERT INTO data_fetch_status (started_at, status)
-            VALUES ($1, 'running')
-        """, datetime.utcnow())
+        await progress.persist(force=True)
 
-        async with MLBStatsAPI(db_pool) as mlb_api:
+        async with MLBStatsAPI(db_pool, progress) as mlb_api:
             # Default date range if not provided
             end_date = request.end_date or (datetime.now() - timedelta(days=1))  # Changed
             start_date = request.start_date or (end_date - timedelta(days=30))
@@ -276,19 +354,24 @@ async def manual_fetch(db_pool: asyncpg.Pool, request: FetchRequest):
                 await mlb_api.fetch_season_stats(request.season)
 
         # Record completion
+        progress.complete()
+        await progress.persist(force=True)
         await db_pool.execute("""
             UPDATE data_fetch_status
             SET completed_at = $1, status = 'completed'
-            WHERE id = (SELECT MAX(id) FROM data_fetch_status)
-        """, datetime.utcnow())
+            WHERE id = $2
+        """, datetime.utcnow(), job_id)
+        await notify_data_refreshed()
 
     except Exception as e:
         logger.error(f"Manual fetch error: {e}")
+        progress.record_error(str(e))
+        await progress.persist(force=True)
         await db_pool.execute("""
             UPDATE data_fetch_status
             SET completed_at = $1, status = 'error', error_message = $2
-            WHERE id = (SELECT MAX(id) FROM data_fetch_status)
-        """, datetime.utcnow(), str(e))
+            WHERE id = $3
+        """, datetime.utcnow(), str(e), job_id)
 
 
 @app.get("/teams")
@@ -304,6 +387,74 @@ async def get_teams():
     return [dict(team) for team in teams]
 
 
+@app.post("/stadiums/geocode")
+async def geocode_stadiums():
+    """Geocode stadiums that have no coordinates using the configured provider"""
+    async with MLBStatsAPI(app.state.db_pool) as api:
+        report = await api.backfill_stadium_coordinates()
+    if report is None:
+        raise HTTPException(status_code=500, detail="Stadium geocoding failed")
+    return report
+
+
+@app.get("/stadiums/coordinates/missing")
+async def get_stadiums_missing_coordinates():
+    """Open-air and retractable-roof stadiums without coordinates; their games
+    are simulated with default weather"""
+    stadiums = await weather_exposed_stadiums_missing_coordinates(app.state.db_pool)
+    return {"stadiums": stadiums, "count": len(stadiums)}
+
+
+@app.put("/stadiums/{stadium_id}/coordinates")
+async def put_stadium_coordinates(stadium_id: str, request: StadiumCoordinatesRequest):
+    """Manually set a stadium's coordinates; later fetches and backfills keep them"""
+    stadium = await set_manual_coordinates(app.state.db_pool, stadium_id, request.latitude, request.longitude)
+    if not stadium:
+        raise HTTPException(status_code=404, detail="Stadium not found")
+    return stadium
+
+
+@app.put("/games/{game_id}/venue")
+async def put_game_venue(game_id: str, request: GameVenueRequest):
+    """Manually move a game to another stadium, e.g. a neutral-site game the
+    schedule lists at the home team's park; later fetches keep the override"""
+    result = await set_manual_game_venue(app.state.db_pool, game_id, request.stadium_id)
+    if result.get('error') == 'game_not_found':
+        raise HTTPException(status_code=404, detail="Game not found")
+    if result.get('error') == 'stadium_not_found':
+        raise HTTPException(status_code=422, detail="Stadium not found")
+    return result
+
+
+@app.put("/teams/{team_id}/branding")
+async def put_team_branding(team_id: str, request: TeamBrandingRequest):
+    """Replace a team's colors and logo slug; omitted fields are cleared"""
+    team = await set_team_branding(app.state.db_pool, team_id, request.primary_color,
+                                   request.secondary_color, request.logo_slug)
+    if not team:
+        raise HTTPException(status_code=404, detail="Team not found")
+    return team
+
+
+@app.post("/contracts")
+async def ingest_contracts(request: ContractsIngestRequest):
+    """Store player salaries and contract lengths; contracts already stored
+    for the same player and season are replaced"""
+    contracts = [contract.dict() for contract in request.contracts]
+    return await upsert_player_contracts(app.state.db_pool, contracts, request.source)
+
+
+@app.post("/aliases")
+async def ingest_aliases(request: AliasesIngestRequest):
+    """Store extra identifiers such as Retrosheet IDs; an alias already stored
+    is repointed at the new entity"""
+    aliases = [alias.dict() for alias in request.aliases]
+    result = await upsert_entity_aliases(app.state.db_pool, aliases, request.source)
+    # The sim engine caches resolved IDs, so a repointed alias needs a reset
+    await notify_data_refreshed()
+    return result
+
+
 @app.get("/players/{team_id}")
 async def get_team_roster(team_id: str):
     """Get roster for a specific team"""
@@ -388,6 +539,8 @@ async def get_leaderboards(request: LeaderboardRequest = Depends()):
     leaderboard = []
     for i, row in enumerate(results):
         stats = row['aggregated_stats']
+        if isinstance(stats, str):
+            stats = json.loads(stats)
         leaderboard.append({
             "rank": i + 1,
             "player_id": row['player_id'],
@@ -397,13 +550,19 @@ async def get_leaderboards(request: LeaderboardRequest = Depends()):
             "games_played": row['games_played']
         })
 
-    return {
+    response = {
         "season": request.season,
         "stats_type": request.stats_type,
         "stat_name": request.stat_name,
         "leaderboard": leaderboard
     }
 
+    # WAR is our own estimate, so label how it was computed
+    if request.stat_name.startswith("WAR"):
+        response["methodology"] = WAR_METHODOLOGY
+
+    return response
+
 
 # Position-Specific Endpoints
 
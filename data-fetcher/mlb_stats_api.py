@@ -5,7 +5,7 @@ Focuses on core functionality without over-engineering
 import asyncio
 import logging
 from datetime import datetime, date, timedelta
-from typing import List, Dict, Optional, Any
+from typing import List, Dict, Optional, Any, Tuple
 import json
 
 import asyncpg
@@ -16,15 +16,36 @@ from config import settings
 from stats_calculator import StatsCalculator
 from umpire_scraper import update_umpire_scorecards
 from game_details_fetcher import GameDetailsFetcher
+from name_normalization import normalize_name, player_name_aliases
+from fetch_progress import FetchProgress
+from venues import schedule_venue
+from game_state import STATUS_COMPLETED, STATUS_LIVE, schedule_first_pitch, schedule_game_state
+from geocoding import SOURCE_MLB, venue_coordinates, get_geocoding_provider, backfill_stadium_coordinates
 
 logger = logging.getLogger(__name__)
 
+# Outfield wall heights (feet) for parks with notable walls. The Stats API's
+# fieldInfo only carries distances.
+STADIUM_WALL_HEIGHTS = {
+    "Fenway Park": {"left_field_wall": 37, "center_field_wall": 17, "right_field_wall": 5},
+    "Oriole Park at Camden Yards": {"left_field_wall": 13, "center_field_wall": 7, "right_field_wall": 25},
+    "Oracle Park": {"left_field_wall": 8, "center_field_wall": 20, "right_field_wall": 24},
+    "Minute Maid Park": {"left_field_wall": 19, "center_field_wall": 9, "right_field_wall": 7},
+    "Daikin Park": {"left_field_wall": 19, "center_field_wall": 9, "right_field_wall": 7},
+    "Wrigley Field": {"left_field_wall": 15, "center_field_wall": 11, "right_field_wall": 15},
+    "PNC Park": {"left_field_wall": 6, "center_field_wall": 10, "right_field_wall": 21},
+    "Great American Ball Park": {"left_field_wall": 12, "center_field_wall": 8, "right_field_wall": 8},
+    "Dodger Stadium": {"left_field_wall": 4, "center_field_wall": 8, "right_field_wall": 4},
+    "Coors Field": {"left_field_wall": 8, "center_field_wall": 8, "right_field_wall": 17},
+}
+
 
 class MLBStatsAPI:
     """Simple MLB Stats API Client"""
     
-    def __init__(self, db_pool: asyncpg.Pool):
+    def __init__(self, db_pool: asyncpg.Pool, progress: Optional[FetchProgress] = None):
         self.db_pool = db_pool
+        self.progress = progress or FetchProgress([])
         self.client = httpx.AsyncClient(
             timeout=settings.request_timeout,
             headers={'User-Agent': 'BaseballSimulation/2.0'},
@@ -66,6 +87,9 @@ class MLBStatsAPI:
             # 1. Fetch teams and venues
             await self.fetch_teams_and_venues()
             
+            # Geocode any stadiums the venue feed left without coordinates
+            await self.backfill_stadium_coordinates()
+
             # 2. Fetch all players
             await self.fetch_all_players()
             
@@ -78,12 +102,16 @@ class MLBStatsAPI:
             await self.fetch_park_factors(current_year)
 
             # 6. Fetch umpire scorecard data
+            self.progress.start_stage('umpires', total=1)
             try:
                 logger.info("Fetching umpire scorecard data...")
                 await update_umpire_scorecards(self.db_pool)
+                self.progress.advance()
             except Exception as e:
                 logger.error(f"Failed to fetch umpire scorecards: {e}")
+                self.progress.record_error(f"Umpire scorecards: {e}")
                 # Don't fail the entire fetch if umpire data fails
+            await self.progress.persist()
             
             # 5. Fetch stats for multiple seasons
             seasons_to_fetch = []
@@ -98,12 +126,14 @@ class MLBStatsAPI:
             
             logger.info(f"Will fetch stats for seasons: {seasons_to_fetch}")
             
+            self.progress.start_stage('stats')
             for season in seasons_to_fetch:
                 try:
                     logger.info(f"Fetching stats for {season} season...")
                     await self.fetch_season_stats(season)
                 except Exception as e:
                     logger.error(f"Failed to fetch stats for {season}: {e}")
+                    self.progress.record_error(f"Stats for {season}: {e}")
             
             logger.info("MLB data fetch completed successfully")
             
@@ -115,8 +145,9 @@ class MLBStatsAPI:
         """Fetch all teams and their venues"""
         logger.info("Fetching teams and venues...")
         
-        data = await self._get("/teams", {"sportId": 1})
+        data = await self._get("/teams", {"sportId": 1, "hydrate": "venue(location,fieldInfo)"})
         teams = data.get("teams", [])
+        self.progress.start_stage('teams', total=sum(1 for team in teams if team.get("active", False)))
         
         # Process venues first
         venues_processed = set()
@@ -131,6 +162,8 @@ class MLBStatsAPI:
         for team in teams:
             if team.get("active", False):
                 await self._save_team(team)
+                self.progress.advance()
+        await self.progress.persist()
         
         logger.info(f"Saved {len(teams)} teams and {len(venues_processed)} venues")
     
@@ -160,7 +193,14 @@ class MLBStatsAPI:
                 roster_tasks.append(self._fetch_team_roster(mlb_team_id))
         
         # Fetch ALL rosters in parallel
+        self.progress.start_stage('players', total=len(roster_tasks))
         roster_results = await asyncio.gather(*roster_tasks, return_exceptions=True)
+        for players in roster_results:
+            if isinstance(players, Exception):
+                self.progress.record_error(f"Roster fetch: {players}")
+            else:
+                self.progress.advance()
+        await self.progress.persist()
         
         total_players = sum(len(players) if not isinstance(players, Exception) else 0 
                         for players in roster_results)
@@ -177,6 +217,7 @@ class MLBStatsAPI:
         while current_date <= end_date:
             dates_to_fetch.append(current_date)
             current_date += timedelta(days=1)
+        self.progress.start_stage('games', total=len(dates_to_fetch))
         
         # Optimized batch processing with semaphore for concurrency control
         batch_size = 50  # Increased from 30 for better throughput
@@ -198,6 +239,10 @@ class MLBStatsAPI:
             for result in results:
                 if not isinstance(result, Exception):
                     total_games += len(result)
+                else:
+                    self.progress.record_error(f"Games fetch: {result}")
+            self.progress.advance(len(batch))
+            await self.progress.persist()
 
             # Minimal delay between batches
             if i + batch_size < len(dates_to_fetch):
@@ -218,6 +263,8 @@ class MLBStatsAPI:
         """)
         
         logger.info(f"Found {len(players)} active players to fetch stats for")
+        self.progress.start_stage('stats')
+        self.progress.add_total(len(players))
         
         # Track success/failure
         success_count = 0
@@ -244,8 +291,11 @@ class MLBStatsAPI:
                     error_count += 1
                     player = batch[j]
                     logger.error(f"Failed to fetch stats for {player['full_name']} ({player['mlb_id']}): {result}")
+                    self.progress.record_error(f"Stats for {player['full_name']} ({season}): {result}")
                 else:
                     success_count += 1
+            self.progress.advance(len(batch))
+            await self.progress.persist()
 
             logger.info(f"Processed batch {i//batch_size + 1}/{(len(players) + batch_size - 1)//batch_size}")
             await asyncio.sleep(0.1)  # Reduced from 0.2
@@ -309,6 +359,8 @@ class MLBStatsAPI:
                 'throws': person.get("pitchHand", {}).get("code"),
                 'first_name': person.get("firstName"),
                 'last_name': person.get("lastName"),
+                'use_name': person.get("useName"),
+                'full_fml_name': person.get("fullFMLName"),
                 'jersey_number': person.get("primaryNumber"),
                 'position': person.get("primaryPosition", {}).get("abbreviation"),
                 'debut_date': person.get("mlbDebutDate"),
@@ -323,7 +375,8 @@ class MLBStatsAPI:
         date_str = date.strftime("%Y-%m-%d")
 
         try:
-            data = await self._get("/schedule", {"sportId": 1, "date": date_str})
+            data = await self._get("/schedule", {"sportId": 1, "date": date_str,
+                                                  "hydrate": "venue(location,fieldInfo),linescore"})
             games = []
             game_detail_tasks = []
 
@@ -340,56 +393,39 @@ class MLBStatsAPI:
                     
                     game_status = game.get("status", {})
                     game_pk = game["gamePk"]
-                    
+
                     # Log game status for debugging
                     logger.debug(f"Game {game_pk} status: {game_status.get('codedGameState')} - {game_status.get('detailedState')}")
 
-                    # Process both final and scheduled games
-                    coded_state = game_status.get("codedGameState", "")
-                    detailed_state = game_status.get("detailedState", "")
-                    abstract_state = game_status.get("abstractGameState", "")
-
-                    # Skip postponed/suspended/cancelled games
-                    if any(status in detailed_state.lower() for status in ["postponed", "suspended", "cancelled"]):
-                        logger.debug(f"Skipping game {game_pk} - {detailed_state}")
-                        continue
-
-                    # Fetch scheduled games (for simulations) and final games (for historical data)
-                    is_scheduled = coded_state == "S" and abstract_state == "Preview"
-                    is_final = coded_state == "F" and abstract_state == "Final"
-
-                    if not (is_scheduled or is_final):
-                        logger.debug(f"Skipping game {game_pk} - not scheduled or final: {detailed_state}")
+                    # Scheduled games (for simulations), live games (for the
+                    # current score) and final games (for historical data)
+                    state = schedule_game_state(game)
+                    if state is None:
+                        logger.debug(f"Skipping game {game_pk} - {game_status.get('detailedState')}")
                         continue
 
-                    # Get scores (will be None for scheduled games)
-                    home_score = game["teams"]["home"].get("score")
-                    away_score = game["teams"]["away"].get("score")
-
-                    # For final games, require valid scores
-                    if is_final and (home_score is None or away_score is None):
-                        logger.debug(f"Skipping final game {game_pk} - no valid scores")
-                        continue
-
-                    # Determine game status
-                    game_status_str = "scheduled" if is_scheduled else detailed_state
-
                     game_info = {
                         'game_pk': game_pk,
                         'game_date': date,
+                        'first_pitch_at': schedule_first_pitch(game),
                         'home_team_id': game["teams"]["home"]["team"]["id"],
                         'away_team_id': game["teams"]["away"]["team"]["id"],
-                        'home_score': home_score,
-                        'away_score': away_score,
-                        'status': game_status_str
+                        'home_score': state['home_score'],
+                        'away_score': state['away_score'],
+                        'status': state['status'],
+                        'live': state['live'],
+                        'game_type': game_type or None,
+                        'venue': schedule_venue(game)
                     }
                     
                     # Save basic game info
                     await self._save_game(game_info)
                     games.append(game_info)
                     
-                    # Queue game stats fetch
-                    game_detail_tasks.append(self.fetch_game_stats(game_pk))
+                    # Queue game stats fetch; a live game's box score is
+                    # incomplete, so it waits until the game is final
+                    if state['status'] != STATUS_LIVE:
+                        game_detail_tasks.append(self.fetch_game_stats(game_pk))
             
             # Fetch all game details in parallel with error handling
             if game_detail_tasks:
@@ -480,9 +516,26 @@ class MLBStatsAPI:
                             games_played = EXCLUDED.games_played,
                             last_updated = NOW()
                     """, player_uuid, season, stats_type, json.dumps(stat), games_played)
+                    self.progress.add_rows()
     
     # Save methods
     
+    async def backfill_stadium_coordinates(self) -> Optional[Dict]:
+        """Run the geocoding backfill; failures are logged, not raised"""
+        try:
+            provider = get_geocoding_provider(settings.geocoding_provider)
+        except ValueError as e:
+            logger.error(f"Stadium geocoding skipped: {e}")
+            return None
+        try:
+            return await backfill_stadium_coordinates(self.db_pool, provider)
+        except Exception as e:
+            logger.error(f"Stadium geocoding failed: {e}")
+            self.progress.record_error(f"Stadium geocoding: {e}")
+            return None
+        finally:
+            await provider.close()
+
     async def _save_venue(self, venue: Dict):
         """Save venue to database"""
         try:
@@ -492,6 +545,8 @@ class MLBStatsAPI:
             if venue.get('location', {}).get('state'):
                 location_parts.append(venue['location']['state'])
             location = ', '.join(location_parts) if location_parts else None
+            dimensions = self._venue_dimensions(venue)
+            dimensions_json = json.dumps(dimensions) if dimensions else None
             
             # First check if updated_at column exists
             has_updated_at = await self.db_pool.fetchval("""
@@ -503,30 +558,67 @@ class MLBStatsAPI:
             
             if has_updated_at:
                 await self.db_pool.execute("""
-                    INSERT INTO stadiums (stadium_id, name, location, capacity)
-                    VALUES ($1, $2, $3, $4)
+                    INSERT INTO stadiums (stadium_id, name, location, capacity, dimensions)
+                    VALUES ($1, $2, $3, $4, $5::jsonb)
                     ON CONFLICT (stadium_id) DO UPDATE
                     SET name = EXCLUDED.name,
                         location = EXCLUDED.location,
                         capacity = EXCLUDED.capacity,
+                        dimensions = COALESCE(EXCLUDED.dimensions, stadiums.dimensions),
                         updated_at = NOW()
                 """, str(venue.get("id")), venue.get("name"), 
                     location,
-                    venue.get("capacity"))
+                    venue.get("capacity"), dimensions_json)
             else:
                 await self.db_pool.execute("""
-                    INSERT INTO stadiums (stadium_id, name, location, capacity)
-                    VALUES ($1, $2, $3, $4)
+                    INSERT INTO stadiums (stadium_id, name, location, capacity, dimensions)
+                    VALUES ($1, $2, $3, $4, $5::jsonb)
                     ON CONFLICT (stadium_id) DO UPDATE
                     SET name = EXCLUDED.name,
                         location = EXCLUDED.location,
-                        capacity = EXCLUDED.capacity
+                        capacity = EXCLUDED.capacity,
+                        dimensions = COALESCE(EXCLUDED.dimensions, stadiums.dimensions)
                 """, str(venue.get("id")), venue.get("name"), 
                     location,
-                    venue.get("capacity"))
+                    venue.get("capacity"), dimensions_json)
+            await self._save_venue_coordinates(venue)
+            self.progress.add_rows()
         except Exception as e:
             logger.error(f"Failed to save venue {venue.get('id')}: {e}")
+            self.progress.record_error(f"Venue {venue.get('id')}: {e}")
     
+    async def _save_venue_coordinates(self, venue: Dict):
+        """Store the venue's coordinates from the feed unless they were set by hand"""
+        coords = venue_coordinates(venue)
+        if not coords:
+            return
+        await self.db_pool.execute("""
+            UPDATE stadiums
+            SET latitude = $2, longitude = $3,
+                coordinates_source = $4, coordinates_updated_at = NOW()
+            WHERE stadium_id = $1
+              AND coordinates_source IS DISTINCT FROM 'manual'
+              AND (latitude, longitude) IS DISTINCT FROM ($2, $3)
+        """, str(venue.get("id")), coords[0], coords[1], SOURCE_MLB)
+
+    def _venue_dimensions(self, venue: Dict) -> Optional[Dict]:
+        """Build fence distances from the venue's fieldInfo plus known wall heights"""
+        field_info = venue.get('fieldInfo', {})
+        distances = {
+            'left_field': field_info.get('leftLine'),
+            'left_center': field_info.get('leftCenter'),
+            'center': field_info.get('center'),
+            'right_center': field_info.get('rightCenter'),
+            'right_field': field_info.get('rightLine'),
+        }
+        dimensions = {k: int(v) for k, v in distances.items() if v}
+        if not dimensions:
+            return None
+
+        # The Stats API has no wall heights; unlisted parks use the sim default
+        dimensions.update(STADIUM_WALL_HEIGHTS.get(venue.get('name'), {}))
+        return dimensions
+
     async def _save_team(self, team: Dict):
         """Save team to database"""
         try:
@@ -557,9 +649,11 @@ class MLBStatsAPI:
             
             if team_uuid:
                     self._team_cache[team.get("id")] = team_uuid
+            self.progress.add_rows()
             
         except Exception as e:
             logger.error(f"Failed to save team {team.get('id')}: {e}")
+            self.progress.record_error(f"Team {team.get('id')}: {e}")
     
     async def _save_player(self, player: Dict):
         """Save player to database with proper UUID handling"""
@@ -578,6 +672,10 @@ class MLBStatsAPI:
                     # Query database
                     team_uuid = await self._get_team_uuid_by_mlb_id(mlb_team_id)
             
+            # Fold a duplicate record of this player into the MLB one
+            mlb_player_id = f"mlb_{player['mlb_id']}"
+            await self._merge_duplicate_player(mlb_player_id, player)
+            
             # Save player
             player_uuid = await self.db_pool.fetchval("""
                 INSERT INTO players (
@@ -596,7 +694,7 @@ class MLBStatsAPI:
                     status = EXCLUDED.status,
                     updated_at = NOW()
                 RETURNING id
-            """, f"mlb_{player['mlb_id']}", 
+            """, mlb_player_id, 
                 player.get('first_name'), 
                 player.get('last_name'),
                 player['full_name'],
@@ -623,11 +721,21 @@ class MLBStatsAPI:
                 ON CONFLICT (player_id) DO NOTHING
             """, player_uuid, player['mlb_id'])
             
+            # Record use names and legal names so search finds them
+            for alias in player_name_aliases(player):
+                await self.db_pool.execute("""
+                    INSERT INTO player_aliases (player_id, alias, source)
+                    VALUES ($1, $2, 'ingestion')
+                    ON CONFLICT (player_id, alias) DO NOTHING
+                """, player_uuid, alias)
+            
             # Cache the mapping
             self._player_cache[player['mlb_id']] = player_uuid
+            self.progress.add_rows()
             
         except Exception as e:
             logger.error(f"Failed to save player {player.get('mlb_id')}: {e}")
+            self.progress.record_error(f"Player {player.get('mlb_id')}: {e}")
     
     async def _save_game(self, game: Dict):
         """Save game to database and fetch detailed game information"""
@@ -635,28 +743,49 @@ class MLBStatsAPI:
             home_team_uuid = await self._get_team_uuid_by_mlb_id(game['home_team_id'])
             away_team_uuid = await self._get_team_uuid_by_mlb_id(game['away_team_id'])
 
-            # Get stadium from home team
-            stadium_uuid = await self.db_pool.fetchval("""
-                SELECT stadium_id FROM teams WHERE id = $1
-            """, home_team_uuid)
+            # Games are at the scheduled venue, which for neutral-site games
+            # isn't the home team's park; fall back to the home team's stadium
+            stadium_uuid, venue_source = await self._game_stadium(game.get('venue'), home_team_uuid)
 
-            # Save game
+            # Save game. Only completed games have final scores; a live
+            # game's state is replaced on every fetch and cleared once it ends
+            live = game.get('live') or {}
             result = await self.db_pool.fetchrow("""
                 INSERT INTO games (
                     game_id, game_date, home_team_id, away_team_id,
-                    stadium_id, season, status, final_score_home, final_score_away
+                    stadium_id, season, status, final_score_home, final_score_away,
+                    game_type, venue_source,
+                    current_score_home, current_score_away, current_inning,
+                    current_inning_half, current_outs, live_updated_at, first_pitch_at
                 )
-                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
+                        CASE WHEN $12::integer IS NOT NULL THEN NOW() END, $17)
                 ON CONFLICT (game_id) DO UPDATE
-                SET final_score_home = EXCLUDED.final_score_home,
+                SET first_pitch_at = COALESCE(EXCLUDED.first_pitch_at, games.first_pitch_at),
+                    final_score_home = EXCLUDED.final_score_home,
                     final_score_away = EXCLUDED.final_score_away,
                     status = EXCLUDED.status,
+                    current_score_home = EXCLUDED.current_score_home,
+                    current_score_away = EXCLUDED.current_score_away,
+                    current_inning = EXCLUDED.current_inning,
+                    current_inning_half = EXCLUDED.current_inning_half,
+                    current_outs = EXCLUDED.current_outs,
+                    live_updated_at = EXCLUDED.live_updated_at,
+                    game_type = COALESCE(EXCLUDED.game_type, games.game_type),
+                    stadium_id = CASE WHEN games.venue_source = 'manual' THEN games.stadium_id
+                                      ELSE COALESCE(EXCLUDED.stadium_id, games.stadium_id) END,
+                    venue_source = CASE WHEN games.venue_source = 'manual' THEN games.venue_source
+                                        ELSE COALESCE(EXCLUDED.venue_source, games.venue_source) END,
                     updated_at = NOW()
                 RETURNING id
             """, str(game['game_pk']), game['game_date'].date(),
                 home_team_uuid, away_team_uuid, stadium_uuid,
-                game['game_date'].year, game.get('status', 'Final'),
-                game.get('home_score'), game.get('away_score'))
+                game['game_date'].year, game.get('status', STATUS_COMPLETED),
+                game.get('home_score'), game.get('away_score'),
+                game.get('game_type'), venue_source,
+                live.get('home_score'), live.get('away_score'), live.get('inning'),
+                live.get('inning_half'), live.get('outs'), game.get('first_pitch_at'))
+            self.progress.add_rows()
 
             # Fetch game details (box score, play-by-play, weather) for completed games
             game_uuid = result['id']
@@ -669,7 +798,7 @@ class MLBStatsAPI:
                 )
             """, game_uuid)
 
-            if not has_box_score:
+            if not has_box_score and game.get('status') != STATUS_LIVE:
                 logger.info(f"Fetching details for game {game_id}")
                 try:
                     await self.game_details_fetcher.fetch_game_details(game_id, game_uuid)
@@ -678,9 +807,73 @@ class MLBStatsAPI:
 
         except Exception as e:
             logger.error(f"Failed to save game {game.get('game_pk')}: {e}")
+            self.progress.record_error(f"Game {game.get('game_pk')}: {e}")
     
+    async def _game_stadium(self, venue: Optional[Dict], home_team_uuid) -> Tuple[Optional[str], Optional[str]]:
+        """The stadium UUID a game is played at and where it came from.
+        Venues not seen yet (most neutral sites) are saved first."""
+        if venue:
+            venue_id = str(venue['id'])
+            stadium_uuid = await self.db_pool.fetchval(
+                "SELECT id FROM stadiums WHERE stadium_id = $1", venue_id)
+            if stadium_uuid is None:
+                await self._save_venue(venue)
+                stadium_uuid = await self.db_pool.fetchval(
+                    "SELECT id FROM stadiums WHERE stadium_id = $1", venue_id)
+            if stadium_uuid is not None:
+                return stadium_uuid, 'mlb'
+
+        stadium_uuid = await self.db_pool.fetchval("""
+            SELECT stadium_id FROM teams WHERE id = $1
+        """, home_team_uuid)
+        return stadium_uuid, 'home_team' if stadium_uuid else None
+
     # Utility methods
     
+    async def _merge_duplicate_player(self, mlb_player_id: str, player: Dict):
+        """Re-key a non-MLB record of the same player (matched on normalized
+        name or alias, with no conflicting birth date) to the MLB player ID so
+        the upsert updates it instead of adding a second row. Ambiguous
+        matches are left alone."""
+        exists = await self.db_pool.fetchval(
+            "SELECT 1 FROM players WHERE player_id = $1", mlb_player_id)
+        if exists:
+            return
+        
+        normalized = normalize_name(player.get('full_name'))
+        if not normalized:
+            return
+        birth_date = datetime.strptime(player['birth_date'], '%Y-%m-%d').date() if player.get('birth_date') else None
+        
+        candidates = await self.db_pool.fetch("""
+            SELECT p.id, p.player_id, p.full_name
+            FROM players p
+            WHERE p.player_id NOT LIKE 'mlb\\_%'
+              AND (p.normalized_name = $1
+                   OR EXISTS (SELECT 1 FROM player_aliases pa
+                              WHERE pa.player_id = p.id AND pa.normalized_alias = $1))
+              AND (p.birth_date IS NULL OR $2::date IS NULL OR p.birth_date = $2)
+            LIMIT 2
+        """, normalized, birth_date)
+        if len(candidates) != 1:
+            if candidates:
+                logger.warning(f"Not merging {player.get('full_name')}: {len(candidates)} possible duplicates")
+            return
+        
+        duplicate = candidates[0]
+        async with self.db_pool.acquire() as conn:
+            async with conn.transaction():
+                await conn.execute("""
+                    UPDATE players SET player_id = $2, updated_at = NOW() WHERE id = $1
+                """, duplicate['id'], mlb_player_id)
+                if normalize_name(duplicate['full_name']) != normalized:
+                    await conn.execute("""
+                        INSERT INTO player_aliases (player_id, alias, source)
+                        VALUES ($1, $2, 'merge')
+                        ON CONFLICT (player_id, alias) DO NOTHING
+                    """, duplicate['id'], duplicate['full_name'])
+        logger.info(f"Merged player {duplicate['player_id']} into {mlb_player_id}")
+    
     def _normalize_player_names(self, player: Dict) -> Dict:
         """Normalize player names to ensure consistency"""
         # Get any version of full name
@@ -954,9 +1147,9 @@ class MLBStatsAPI:
                         plate_x = coordinates.get('pX')
                         plate_z = coordinates.get('pZ')
                         
-                        # Result
+                        # Result is the call ("Called Strike", "Ball", ...), not the pitch type
                         details = event.get('details', {})
-                        result = details.get('type', {}).get('description')
+                        result = details.get('call', {}).get('description') or details.get('description')
                         
                         # Hit data if applicable
                         hit_data = play.get('result', {}).get('hitData', {})
@@ -1022,6 +1215,7 @@ class MLBStatsAPI:
         try:
             # Get all stadiums
             stadiums = await self.db_pool.fetch("SELECT id, stadium_id FROM stadiums")
+            self.progress.start_stage('park_factors', total=len(stadiums))
             
             for stadium in stadiums:
                 # MLB API doesn't directly provide park factors, so we'll set defaults
@@ -1040,39 +1234,63 @@ class MLBStatsAPI:
                         ON CONFLICT (stadium_id, season, factor_type, handedness) DO UPDATE
                         SET factor_value = EXCLUDED.factor_value
                     """, stadium['id'], season, factor_type, value)
+                    self.progress.add_rows()
+                self.progress.advance()
+            await self.progress.persist()
                     
         except Exception as e:
             logger.error(f"Error fetching park factors: {e}")
+            self.progress.record_error(f"Park factors: {e}")
     
     async def _process_umpires(self, game_pk: int, game_data: Dict):
         """Process and save umpire data from game feed"""
         try:
             officials = game_data.get('officials', [])
+            positions = {
+                'Home Plate': 'HP',
+                'First Base': '1B',
+                'Second Base': '2B',
+                'Third Base': '3B',
+                'Left Field': 'LF',
+                'Right Field': 'RF',
+            }
             
             for official in officials:
-                if official.get('officialType') == 'Home Plate':
-                    official_data = official.get('official', {})
-                    ump_id = str(official_data.get('id', ''))
-                    ump_name = official_data.get('fullName', '')
+                position = positions.get(official.get('officialType'))
+                if not position:
+                    continue
+
+                official_data = official.get('official', {})
+                ump_id = str(official_data.get('id', ''))
+                ump_name = official_data.get('fullName', '')
+                
+                if ump_id and ump_name:
+                    # Save umpire
+                    ump_uuid = await self.db_pool.fetchval("""
+                        INSERT INTO umpires (umpire_id, name)
+                        VALUES ($1, $2)
+                        ON CONFLICT (umpire_id) DO UPDATE
+                        SET name = EXCLUDED.name
+                        RETURNING id
+                    """, ump_id, ump_name)
                     
-                    if ump_id and ump_name:
-                        # Save umpire
-                        ump_uuid = await self.db_pool.fetchval("""
-                            INSERT INTO umpires (umpire_id, name)
-                            VALUES ($1, $2)
-                            ON CONFLICT (umpire_id) DO UPDATE
-                            SET name = EXCLUDED.name
-                            RETURNING id
-                        """, ump_id, ump_name)
-                        
-                        # Update game with umpire
+                    # Record crew assignment (migration 013)
+                    await self.db_pool.execute("""
+                        INSERT INTO game_umpires (game_id, umpire_id, position)
+                        SELECT id, $1, $3 FROM games WHERE game_id = $2
+                        ON CONFLICT (game_id, position) DO UPDATE
+                        SET umpire_id = EXCLUDED.umpire_id, updated_at = NOW()
+                    """, ump_uuid, str(game_pk), position)
+                    
+                    if position == 'HP':
+                        # Update game with plate umpire
                         await self.db_pool.execute("""
                             UPDATE games 
                             SET home_plate_umpire_id = $1
                             WHERE game_id = $2
                         """, ump_uuid, str(game_pk))
-                        
-                        logger.debug(f"Saved umpire {ump_name} for game {game_pk}")
+                    
+                    logger.debug(f"Saved umpire {ump_name} ({position}) for game {game_pk}")
                     
         except Exception as e:
             logger.error(f"Error processing umpires for game {game_pk}: {e}")
@@ -6,6 +6,8 @@ from datetime import datetime, date
 from typing import Optional, List, Dict, Any
 from enum import Enum
 
+from branding import normalize_color, normalize_logo_slug
+
 
 class StatsType(str, Enum):
     batting = "batting"
@@ -114,6 +116,90 @@ class FetchRequest(BaseModel):
     season: Optional[int] = Field(default=None, ge=1876, le=datetime.now().year + 1)
 
 
+class StadiumCoordinatesRequest(BaseModel):
+    latitude: float = Field(..., ge=-90, le=90)
+    longitude: float = Field(..., ge=-180, le=180)
+
+    @validator('longitude')
+    def validate_not_null_island(cls, v, values):
+        if v == 0 and values.get('latitude') == 0:
+            raise ValueError('latitude and longitude cannot both be 0')
+        return v
+
+
+class PlayerContract(BaseModel):
+    player_id: str = Field(..., min_length=1, max_length=50)  # UUID or MLB player ID
+    season: int = Field(..., ge=1876, le=datetime.now().year + 10)
+    salary: int = Field(..., ge=0, le=100_000_000)  # whole US dollars for the season
+    contract_years: Optional[int] = Field(default=None, ge=1, le=15)
+    contract_end_season: Optional[int] = Field(default=None, ge=1876)
+
+    @validator('contract_end_season')
+    def validate_end_season(cls, v, values):
+        if v is not None and 'season' in values and v < values['season']:
+            raise ValueError('contract_end_season cannot be before season')
+        return v
+
+
+class ContractsIngestRequest(BaseModel):
+    contracts: List[PlayerContract] = Field(..., min_length=1, max_length=2000)
+    source: Optional[str] = Field(default=None, max_length=50)
+
+
+class EntityAlias(BaseModel):
+    entity_type: str = Field(..., pattern=r'^(team|player|game|umpire|stadium)$')
+    entity_id: str = Field(..., min_length=1, max_length=50)  # UUID or MLB ID
+    alias_type: str = Field(default='retrosheet', pattern=r'^[a-z][a-z0-9_]{0,19}$')
+    alias: str = Field(..., min_length=1, max_length=50)
+
+    @validator('alias_type')
+    def validate_alias_type(cls, v):
+        # These namespaces are the entity's own columns, not aliases
+        if v in ('uuid', 'mlbam', 'mlb', 'code'):
+            raise ValueError(f'{v} is not an alias type')
+        return v
+
+    @validator('alias')
+    def validate_alias(cls, v):
+        v = v.strip()
+        if not v:
+            raise ValueError('alias cannot be blank')
+        return v
+
+
+class AliasesIngestRequest(BaseModel):
+    aliases: List[EntityAlias] = Field(..., min_length=1, max_length=5000)
+    source: Optional[str] = Field(default=None, max_length=50)
+
+
+class GameVenueRequest(BaseModel):
+    stadium_id: str = Field(..., min_length=1, max_length=50)  # UUID or MLB venue ID
+
+
+class TeamBrandingRequest(BaseModel):
+    primary_color: Optional[str] = None  # hex, normalized to #RRGGBB
+    secondary_color: Optional[str] = None
+    logo_slug: Optional[str] = Field(default=None, max_length=50)
+
+    @validator('primary_color', 'secondary_color')
+    def validate_color(cls, v):
+        return normalize_color(v)
+
+    @validator('logo_slug')
+    def validate_logo_slug(cls, v):
+        return normalize_logo_slug(v)
+
+
+class FetchJobStatus(BaseModel):
+    job_id: int
+    fetch_type: Optional[str]
+    status: Optional[str]
+    started_at: Optional[datetime]
+    completed_at: Optional[datetime]
+    error_message: Optional[str]
+    progress: Optional[Dict[str, Any]]
+
+
 class DataFetchStatus(BaseModel):
     last_fetch: Optional[datetime]
     next_fetch: Optional[datetime]
@@ -122,6 +208,7 @@ class DataFetchStatus(BaseModel):
     total_teams: int
     total_players: int
     total_games: int
+    current_job: Optional[FetchJobStatus] = None
 
 
 class ErrorResponse(BaseModel):
@@ -0,0 +1,51 @@
+"""
+Player name normalization
+Mirrors the database's normalize_name() so ingestion can compare names the
+same way search does
+"""
+import re
+import unicodedata
+from typing import Dict, List, Optional
+
+# Letters NFKD doesn't decompose into a base letter plus accent
+_SPECIAL_LETTERS = str.maketrans({
+    'ø': 'o', 'Ø': 'O', 'ł': 'l', 'Ł': 'L', 'đ': 'd', 'Đ': 'D',
+    'ß': 'ss', 'æ': 'ae', 'Æ': 'AE', 'œ': 'oe', 'Œ': 'OE',
+})
+
+_DROPPED_PUNCTUATION = re.compile(r"[.'’]")
+_SEPARATORS = re.compile(r"[^a-z0-9]+")
+
+
+def normalize_name(name: Optional[str]) -> str:
+    """Lowercase, strip accents and punctuation, and collapse whitespace:
+    'Ronald Acuña Jr.' -> 'ronald acuna jr', 'J.P. Crawford' -> 'jp crawford'"""
+    if not name:
+        return ''
+    decomposed = unicodedata.normalize('NFKD', name.translate(_SPECIAL_LETTERS))
+    stripped = ''.join(c for c in decomposed if not unicodedata.combining(c))
+    stripped = _DROPPED_PUNCTUATION.sub('', stripped.lower())
+    return _SEPARATORS.sub(' ', stripped).strip()
+
+
+def player_name_aliases(player: Dict) -> List[str]:
+    """Other names the MLB Stats API knows a player by that normalize
+    differently from their full name: the use name with the last name
+    ('Nate Eovaldi' for Nathan) and the full legal name"""
+    full = normalize_name(player.get('full_name'))
+    candidates = []
+    use_name, last_name = player.get('use_name'), player.get('last_name')
+    if use_name and last_name:
+        candidates.append(f"{use_name} {last_name}")
+    if player.get('full_fml_name'):
+        candidates.append(player['full_fml_name'])
+    if player.get('first_name') and last_name:
+        candidates.append(f"{player['first_name']} {last_name}")
+
+    aliases, seen = [], {full}
+    for candidate in candidates:
+        normalized = normalize_name(candidate)
+        if normalized and normalized not in seen:
+            seen.add(normalized)
+            aliases.append(candidate.strip())
+    return aliases
@@ -11,6 +11,8 @@ from dataclasses import dataclass
 
 import asyncpg
 
+from war_calculator import calculate_position_player_war, calculate_pitcher_war
+
 logger = logging.getLogger(__name__)
 
 
@@ -69,6 +71,9 @@ class StatsCalculator:
         # Calculate position-specific metrics
         await self._calculate_position_specific_stats(season)
 
+        # WAR builds on the advanced batting, fielding and pitching stats above
+        await self._calculate_war(season)
+
         logger.info(f"Completed enhanced stats calculation for {season}")
 
     async def _calculate_player_stats(self, player_id: str, season: int, stats_type: str):
@@ -119,6 +124,45 @@ class StatsCalculator:
         # Calculate outfielder metrics
         await self._calculate_outfielder_stats(season)
 
+    async def _calculate_war(self, season: int):
+        """Calculate WAR for every player-season and store it with the batting and pitching stats"""
+        logger.info(f"Calculating WAR for {season}")
+
+        rows = await self.db_pool.fetch("""
+            SELECT psa.player_id, psa.stats_type, psa.aggregated_stats, p.position
+            FROM player_season_aggregates psa
+            JOIN players p ON p.id = psa.player_id
+            WHERE psa.season = $1
+        """, season)
+
+        players: Dict[str, Dict] = {}
+        for row in rows:
+            player = players.setdefault(row['player_id'], {'position': row['position']})
+            player[row['stats_type']] = json.loads(row['aggregated_stats'])
+
+        for player_id, player in players.items():
+            for stats_type in ('batting', 'pitching'):
+                stats = player.get(stats_type)
+                if stats is None:
+                    continue
+
+                if stats_type == 'batting':
+                    war = calculate_position_player_war(stats, player.get('fielding'), player['position'])
+                else:
+                    war = calculate_pitcher_war(stats)
+                if not war:
+                    continue
+
+                stats.update(war)
+                try:
+                    await self.db_pool.execute("""
+                        UPDATE player_season_aggregates
+                        SET aggregated_stats = $4, last_updated = NOW()
+                        WHERE player_id = $1 AND season = $2 AND stats_type = $3
+                    """, player_id, season, stats_type, json.dumps(stats))
+                except Exception as e:
+                    logger.error(f"Error saving WAR for player {player_id}: {e}")
+
     async def _calculate_catcher_stats(self, season: int):
         """Calculate advanced catcher metrics"""
         logger.info(f"Calculating catcher stats for {season}")
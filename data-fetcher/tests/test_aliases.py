@@ -0,0 +1,100 @@
+"""
+Unit tests for entity alias ingestion and ID resolution
+"""
+import asyncio
+
+import pytest
+
+from aliases import AmbiguousIDError, id_lookup_query, parse_id_ref, resolve_entity_id, upsert_entity_aliases
+
+
+class FakePool:
+    """Resolves IDs from a table of (table, id) -> UUIDs and records inserts"""
+
+    def __init__(self, known_entities):
+        self.known_entities = known_entities
+        self.inserts = []
+
+    async def fetch(self, query, *args):
+        table = query.split('FROM ')[1].split()[0]
+        return [{'id': uuid} for uuid in self.known_entities.get((table, args[0]), [])]
+
+    async def execute(self, query, *args):
+        self.inserts.append(args)
+
+
+class TestParseIDRef:
+    def test_prefixes(self):
+        assert parse_id_ref(' 592450 ') == ('', '592450')
+        assert parse_id_ref('MLB:592450') == ('mlbam', '592450')
+        assert parse_id_ref('retro:judga001') == ('retrosheet', 'judga001')
+        assert parse_id_ref('code:nyy') == ('code', 'nyy')
+
+    def test_unknown_prefix_is_part_of_the_value(self):
+        assert parse_id_ref('bbref:judgeaa01') == ('', 'bbref:judgeaa01')
+
+
+class TestIDLookupQuery:
+    def test_uuid_matches_primary_key(self):
+        query, args = id_lookup_query('game', '', '3f2b8a7e-6c1d-4e5f-8a9b-0c1d2e3f4a5b')
+        assert 'WHERE id = $1::uuid' in query
+        assert id_lookup_query('game', 'uuid', '745340') == (None, [])
+
+    def test_unprefixed_searches_every_namespace(self):
+        query, args = id_lookup_query('team', '', 'NYA')
+        for fragment in ('team_id = $1', 'UPPER(abbreviation) = UPPER($1)', 'a.alias = $1'):
+            assert fragment in query
+        assert args == ['NYA', 'team']
+
+    def test_prefixed_alias_searches_that_type(self):
+        query, args = id_lookup_query('team', 'retrosheet', 'NYA')
+        assert 'team_id = $1' not in query
+        assert 'a.alias_type = $3' in query
+        assert args == ['NYA', 'team', 'retrosheet']
+
+    def test_stadiums_have_no_codes(self):
+        assert id_lookup_query('stadium', 'code', 'YS') == (None, [])
+
+
+class TestResolveEntityID:
+    def test_resolves_and_dedupes(self):
+        pool = FakePool({('players', '592450'): ['uuid-judge', 'uuid-judge']})
+        assert asyncio.run(resolve_entity_id(pool, 'player', '592450')) == 'uuid-judge'
+        assert asyncio.run(resolve_entity_id(pool, 'player', '1')) is None
+        assert asyncio.run(resolve_entity_id(pool, 'player', ' ')) is None
+
+    def test_ambiguous(self):
+        pool = FakePool({('teams', 'NYA'): ['uuid-b', 'uuid-a']})
+        with pytest.raises(AmbiguousIDError) as e:
+            asyncio.run(resolve_entity_id(pool, 'team', 'NYA'))
+        assert e.value.matches == ['uuid-a', 'uuid-b']
+
+
+class TestUpsertEntityAliases:
+    """Aliases resolve their entity like any other ID and skip unknown
+    entities"""
+
+    def test_stores_known_entities(self):
+        pool = FakePool({('players', '592450'): ['uuid-judge'], ('teams', 'NYY'): ['uuid-nyy']})
+        report = asyncio.run(upsert_entity_aliases(pool, [
+            {'entity_type': 'player', 'entity_id': '592450',
+             'alias_type': 'retrosheet', 'alias': 'judga001'},
+            {'entity_type': 'team', 'entity_id': 'NYY',
+             'alias_type': 'retrosheet', 'alias': 'NYA'},
+        ], source='retrosheet'))
+
+        assert report == {'updated': 2, 'unknown_entities': []}
+        assert pool.inserts[0] == ('uuid-judge', 'player', 'retrosheet', 'judga001', 'retrosheet')
+        assert pool.inserts[1] == ('uuid-nyy', 'team', 'retrosheet', 'NYA', 'retrosheet')
+
+    def test_reports_unknown_entities(self):
+        # The MLB ID exists, but for a player rather than an umpire
+        pool = FakePool({('players', '592450'): ['uuid-judge']})
+        report = asyncio.run(upsert_entity_aliases(pool, [
+            {'entity_type': 'umpire', 'entity_id': '592450',
+             'alias_type': 'retrosheet', 'alias': 'westj901'},
+        ]))
+
+        assert report == {'updated': 0,
+                          'unknown_entities': [{'entity_type': 'umpire', 'entity_id': '592450'}]}
+        assert pool.inserts == []
@@ -0,0 +1,63 @@
+"""
+Unit tests for team branding
+"""
+import asyncio
+
+import pytest
+
+from branding import normalize_color, normalize_logo_slug, set_team_branding
+
+
+class TestNormalization:
+    """Colors become uppercase #RRGGBB; slugs are lowercase words and hyphens"""
+
+    def test_normalizes_colors(self):
+        assert normalize_color('#0c2340') == '#0C2340'
+        assert normalize_color('bd3039') == '#BD3039'
+        assert normalize_color(' #fa0 ') == '#FFAA00'
+        assert normalize_color(None) is None
+
+    def test_rejects_bad_colors(self):
+        for bad in ('navy', '#12345', '#GGGGGG', ''):
+            with pytest.raises(ValueError):
+                normalize_color(bad)
+
+    def test_normalizes_slugs(self):
+        assert normalize_logo_slug('Boston-Red-Sox') == 'boston-red-sox'
+        assert normalize_logo_slug(None) is None
+        for bad in ('red sox', '-sox', 'red--sox'):
+            with pytest.raises(ValueError):
+                normalize_logo_slug(bad)
+
+
+class FakePool:
+    """Resolves MLB team IDs to UUIDs and records updates"""
+
+    def __init__(self, teams):
+        self.teams = teams
+        self.updates = []
+
+    async def fetch(self, query, *args):
+        return [{'id': 'uuid-' + args[0]}] if args[0] in self.teams else []
+
+    async def fetchrow(self, query, *args):
+        self.updates.append(args)
+        return {'id': args[0], 'team_id': '111', 'name': self.teams[args[0][len('uuid-'):]],
+                'primary_color': args[1], 'secondary_color': args[2], 'logo_slug': args[3],
+                'branding_updated_at': None}
+
+
+class TestSetTeamBranding:
+    """Branding is replaced on the team the ID resolves to"""
+
+    def test_sets_branding(self):
+        pool = FakePool({'111': 'Boston Red Sox'})
+        team = asyncio.run(set_team_branding(pool, '111', '#BD3039', None, 'boston-red-sox'))
+
+        assert pool.updates == [('uuid-111', '#BD3039', None, 'boston-red-sox')]
+        assert team['secondary_color'] is None
+
+    def test_unknown_team(self):
+        pool = FakePool({})
+        assert asyncio.run(set_team_branding(pool, '999', '#000000', None, None)) is None
+        assert pool.updates == []
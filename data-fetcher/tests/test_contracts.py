@@ -0,0 +1,46 @@
+"""
+Unit tests for player contract ingestion
+"""
+import asyncio
+
+from contracts import upsert_player_contracts
+
+
+class FakePool:
+    """Resolves player IDs to UUIDs and records inserts"""
+
+    def __init__(self, known_players):
+        self.known_players = known_players
+        self.inserts = []
+
+    async def fetch(self, query, *args):
+        return [{'id': self.known_players[args[0]]}] if args[0] in self.known_players else []
+
+    async def execute(self, query, *args):
+        self.inserts.append(args)
+
+
+class TestUpsertPlayerContracts:
+    """Contracts resolve their player like any other ID and skip unknown
+    players"""
+
+    def test_stores_known_players(self):
+        pool = FakePool({'592450': 'uuid-judge', 'uuid-cole': 'uuid-cole'})
+        report = asyncio.run(upsert_player_contracts(pool, [
+            {'player_id': '592450', 'season': 2026, 'salary': 40_000_000,
+             'contract_years': 9, 'contract_end_season': 2031},
+            {'player_id': 'uuid-cole', 'season': 2026, 'salary': 36_000_000},
+        ], source='spotrac'))
+
+        assert report == {'updated': 2, 'unknown_players': []}
+        assert pool.inserts[0] == ('uuid-judge', 2026, 40_000_000, 9, 2031, 'spotrac')
+        assert pool.inserts[1] == ('uuid-cole', 2026, 36_000_000, None, None, 'spotrac')
+
+    def test_reports_unknown_players(self):
+        pool = FakePool({'592450': 'uuid-judge'})
+        report = asyncio.run(upsert_player_contracts(pool, [
+            {'player_id': '999999', 'season': 2026, 'salary': 740_000},
+        ]))
+
+        assert report == {'updated': 0, 'unknown_players': ['999999']}
+        assert pool.inserts == []
@@ -0,0 +1,138 @@
+"""
+Unit tests for the demo dataset
+"""
+import asyncio
+from datetime import date
+
+from demo_data import (
+    DEMO_FINAL_GAMES, DEMO_SCHEDULED_GAMES, DEMO_TEAMS, demo_players, demo_schedule,
+    demo_season_stats, innings_pitched, play_game, seed_demo_data,
+)
+
+TODAY = date(2026, 6, 15)
+
+
+class TestDemoRosters:
+    """Rosters are big enough for the sim engine's lineups and rotations"""
+
+    def test_each_team_has_a_lineup_rotation_and_bullpen(self):
+        players = demo_players()
+        for team in DEMO_TEAMS:
+            roster = [p for p in players if p['team_id'] == team['team_id']]
+            hitters = [p for p in roster if p['position'] != 'P']
+            pitchers = [p for p in roster if p['position'] == 'P']
+            assert len(hitters) >= 9
+            assert len(pitchers) > 5
+            assert len({p['jersey_number'] for p in roster}) == len(roster)
+
+    def test_ids_are_unique_and_prefixed(self):
+        ids = [p['player_id'] for p in demo_players()]
+        assert len(ids) == len(set(ids))
+        assert all(i.startswith('demo-') for i in ids)
+
+    def test_deterministic(self):
+        assert demo_players() == demo_players()
+
+    def test_every_player_has_season_stats(self):
+        players = demo_players()
+        stats = demo_season_stats(players)
+        assert len(stats) == len(players)
+        batting = [s['stats'] for s in stats if s['stats_type'] == 'batting']
+        assert all(0.150 < line['AVG'] < 0.350 for line in batting)
+        assert all(line['OBP'] > line['AVG'] for line in batting)
+
+
+class TestDemoSchedule:
+    def test_final_games_before_today_then_scheduled(self):
+        games = demo_schedule(TODAY)
+        assert len(games) == DEMO_FINAL_GAMES + DEMO_SCHEDULED_GAMES
+        final = [g for g in games if g['status'] == 'completed']
+        scheduled = [g for g in games if g['status'] == 'scheduled']
+        assert len(final) == DEMO_FINAL_GAMES
+        assert all(g['game_date'] < TODAY for g in final)
+        assert all(g['game_date'] >= TODAY for g in scheduled)
+        assert all(g['home_team_id'] != g['away_team_id'] for g in games)
+
+
+class TestPlayGame:
+    """Box scores agree with the plays they were built from"""
+
+    def play(self, seed=0):
+        game = demo_schedule(TODAY)[0]
+        return play_game(game, demo_players(), seed=seed)
+
+    def test_final_score_matches_plays_and_box_scores(self):
+        for seed in range(5):
+            result = self.play(seed)
+            last = result['plays'][-1]
+            assert (last['home_score'], last['away_score']) == (result['home_score'], result['away_score'])
+            assert result['home_score'] != result['away_score']
+            assert sum(line['runs'] for line in result['batting']) == result['home_score'] + result['away_score']
+            assert sum(line['runs_allowed'] for line in result['pitching']) == result['home_score'] + result['away_score']
+            assert sum(p['runs_scored'] for p in result['plays']) == result['home_score'] + result['away_score']
+
+    def test_hits_and_strikeouts_balance(self):
+        result = self.play()
+        assert sum(line['hits'] for line in result['batting']) == sum(line['hits_allowed'] for line in result['pitching'])
+        assert sum(line['strikeouts'] for line in result['batting']) == sum(line['strikeouts'] for line in result['pitching'])
+
+    def test_one_winner_and_one_loser(self):
+        result = self.play()
+        assert sum(line['win'] for line in result['pitching']) == 1
+        assert sum(line['loss'] for line in result['pitching']) == 1
+
+    def test_innings_pitched_notation(self):
+        assert innings_pitched(19) == 6.1
+        assert innings_pitched(27) == 9
+
+
+class FakeConnection:
+    def __init__(self, team_count):
+        self.team_count = team_count
+        self.executed = []
+
+    async def fetchval(self, query, *args):
+        if 'COUNT(*) FROM teams' in query:
+            return self.team_count
+        self.executed.append(query)
+        return f'uuid-{len(self.executed)}'
+
+    async def execute(self, query, *args):
+        self.executed.append(query)
+
+    def transaction(self):
+        return FakeContext(None)
+
+
+class FakeContext:
+    def __init__(self, value):
+        self.value = value
+
+    async def __aenter__(self):
+        return self.value
+
+    async def __aexit__(self, *exc):
+        return False
+
+
+class FakePool:
+    def __init__(self, conn):
+        self.conn = conn
+
+    def acquire(self):
+        return FakeContext(self.conn)
+
+
+class TestSeedDemoData:
+    def test_skips_a_database_that_has_teams(self):
+        conn = FakeConnection(team_count=30)
+        assert asyncio.run(seed_demo_data(FakePool(conn), TODAY)) is False
+        assert conn.executed == []
+
+    def test_seeds_an_empty_database(self):
+        conn = FakeConnection(team_count=0)
+        assert asyncio.run(seed_demo_data(FakePool(conn), TODAY)) is True
+        inserted = ' '.join(conn.executed)
+        for table in ('stadiums', 'teams', 'umpires', 'players', 'player_season_aggregates',
+                      'games', 'game_box_score_batting', 'game_box_score_pitching', 'game_plays'):
+            assert f'INSERT INTO {table}' in inserted
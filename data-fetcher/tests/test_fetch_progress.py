@@ -0,0 +1,75 @@
+"""
+Unit tests for data fetch progress tracking
+"""
+from fetch_progress import FetchProgress, MAX_RECORDED_ERRORS
+
+
+class FakeClock:
+    def __init__(self):
+        self.now = 0.0
+
+    def __call__(self):
+        return self.now
+
+
+class TestFetchProgress:
+    """Test stage counters, completion and ETA"""
+
+    def test_counts_by_stage(self):
+        progress = FetchProgress(['teams', 'players'])
+        progress.start_stage('teams', total=30)
+        progress.advance(30)
+        progress.add_rows(32)
+        progress.start_stage('players', total=30)
+        progress.advance(10)
+        progress.add_rows(400)
+        progress.record_error('Player 1: timeout')
+
+        snapshot = progress.snapshot()
+        assert snapshot['current_stage'] == 'players'
+        assert snapshot['entities_fetched'] == 40
+        assert snapshot['rows_upserted'] == 432
+        assert snapshot['errors'] == 1
+        assert snapshot['recent_errors'] == ['Player 1: timeout']
+        assert snapshot['stages'][0]['completed'] is True
+        # teams done, players a third of the way
+        assert snapshot['percent_complete'] == 66.7
+
+    def test_eta_from_pace(self):
+        clock = FakeClock()
+        progress = FetchProgress(['games'], clock=clock)
+        assert progress.eta_seconds() is None
+
+        progress.start_stage('games', total=100)
+        progress.advance(25)
+        clock.now = 30.0
+        assert progress.eta_seconds() == 90.0
+
+        progress.complete()
+        assert progress.eta_seconds() == 0.0
+
+    def test_totals_grow_across_seasons(self):
+        progress = FetchProgress(['stats'])
+        progress.start_stage('stats')
+        progress.add_total(100)
+        progress.advance(100)
+        progress.start_stage('stats')
+        progress.add_total(100)
+        assert progress.stages['stats'].total == 200
+        assert progress.fraction() == 0.5
+
+    def test_keeps_recent_errors(self):
+        progress = FetchProgress(['games'])
+        progress.start_stage('games')
+        for i in range(MAX_RECORDED_ERRORS + 5):
+            progress.record_error(f'Game {i}')
+        snapshot = progress.snapshot()
+        assert snapshot['errors'] == MAX_RECORDED_ERRORS + 5
+        assert len(snapshot['recent_errors']) == MAX_RECORDED_ERRORS
+        assert snapshot['recent_errors'][-1] == f'Game {MAX_RECORDED_ERRORS + 4}'
+
+    def test_unlisted_stage(self):
+        progress = FetchProgress([])
+        progress.start_stage('park_factors', total=2)
+        progress.advance()
+        assert progress.fraction() == 0.5
@@ -0,0 +1,64 @@
+"""
+Unit tests for schedule game states
+"""
+from datetime import datetime, timezone
+
+from game_state import STATUS_COMPLETED, STATUS_LIVE, STATUS_SCHEDULED, schedule_first_pitch, schedule_game_state
+
+
+def schedule_game(abstract, coded, detailed, home=None, away=None, linescore=None):
+    game = {
+        'status': {'abstractGameState': abstract, 'codedGameState': coded, 'detailedState': detailed},
+        'teams': {'home': {'score': home}, 'away': {'score': away}},
+    }
+    if linescore is not None:
+        game['linescore'] = linescore
+    return game
+
+
+class TestScheduleGameState:
+    def test_scheduled(self):
+        state = schedule_game_state(schedule_game('Preview', 'S', 'Scheduled'))
+        assert state == {'status': STATUS_SCHEDULED, 'home_score': None, 'away_score': None, 'live': None}
+
+    def test_final_keeps_score(self):
+        state = schedule_game_state(schedule_game('Final', 'F', 'Final', home=5, away=3))
+        assert state['status'] == STATUS_COMPLETED
+        assert (state['home_score'], state['away_score']) == (5, 3)
+        assert state['live'] is None
+
+    def test_final_without_score_is_skipped(self):
+        assert schedule_game_state(schedule_game('Final', 'F', 'Final', home=5)) is None
+
+    def test_live_score_is_not_final(self):
+        linescore = {'currentInning': 6, 'inningState': 'Bottom', 'outs': 1,
+                     'teams': {'home': {'runs': 2}, 'away': {'runs': 4}}}
+        state = schedule_game_state(schedule_game('Live', 'I', 'In Progress', home=2, away=4,
+                                                  linescore=linescore))
+        assert state['status'] == STATUS_LIVE
+        assert state['home_score'] is None and state['away_score'] is None
+        assert state['live'] == {'home_score': 2, 'away_score': 4, 'inning': 6,
+                                 'inning_half': 'bottom', 'outs': 1}
+
+    def test_live_before_first_run(self):
+        state = schedule_game_state(schedule_game('Live', 'I', 'Warmup'))
+        assert state['live']['home_score'] == 0 and state['live']['away_score'] == 0
+        assert state['live']['inning'] is None and state['live']['inning_half'] is None
+
+    def test_postponed_is_skipped(self):
+        assert schedule_game_state(schedule_game('Final', 'D', 'Postponed')) is None
+        assert schedule_game_state(schedule_game('Live', 'U', 'Suspended: Rain', home=1, away=0)) is None
+
+
+class TestScheduleFirstPitch:
+    def test_parses_game_date(self):
+        first_pitch = schedule_first_pitch({'gameDate': '2026-04-01T23:05:00Z', 'status': {}})
+        assert first_pitch == datetime(2026, 4, 1, 23, 5, tzinfo=timezone.utc)
+
+    def test_time_to_be_determined(self):
+        game = {'gameDate': '2026-04-01T07:33:00Z', 'status': {'startTimeTBD': True}}
+        assert schedule_first_pitch(game) is None
+
+    def test_missing_or_malformed(self):
+        assert schedule_first_pitch({}) is None
+        assert schedule_first_pitch({'gameDate': 'soon'}) is None
@@ -0,0 +1,159 @@
+"""
+Unit tests for stadium coordinate geocoding
+"""
+import asyncio
+
+from geocoding import (
+    ChainedGeocodingProvider, GeocodingProvider, NominatimGeocodingProvider, StaticGeocodingProvider,
+    backfill_stadium_coordinates, get_geocoding_provider, valid_coordinates,
+    venue_coordinates,
+)
+
+
+class TestCoordinateValidation:
+    """Coordinates must be in range and not the (0, 0) placeholder"""
+
+    def test_accepts_valid_pair(self):
+        assert valid_coordinates('42.3467', -71.0972) == (42.3467, -71.0972)
+
+    def test_rejects_out_of_range(self):
+        assert valid_coordinates(91, 0.5) is None
+        assert valid_coordinates(40, -181) is None
+
+    def test_rejects_null_island_and_missing(self):
+        assert valid_coordinates(0, 0) is None
+        assert valid_coordinates(None, -71.0) is None
+        assert valid_coordinates('n/a', -71.0) is None
+
+    def test_reads_venue_default_coordinates(self):
+        venue = {'location': {'city': 'Boston', 'defaultCoordinates': {'latitude': 42.34, 'longitude': -71.09}}}
+        assert venue_coordinates(venue) == (42.34, -71.09)
+        assert venue_coordinates({'location': {'city': 'Boston'}}) is None
+        assert venue_coordinates({}) is None
+
+
+class FailingProvider(GeocodingProvider):
+    name = 'failing'
+
+    async def geocode(self, stadium_name, location):
+        raise RuntimeError('service unavailable')
+
+
+class FixedProvider(GeocodingProvider):
+    name = 'fixed'
+
+    async def geocode(self, stadium_name, location):
+        return (35.0, -80.0)
+
+
+class TestProviders:
+    """Provider selection and fallback"""
+
+    def test_static_lookup_ignores_case(self):
+        provider = StaticGeocodingProvider()
+        assert asyncio.run(provider.geocode('fenway park', 'Boston, MA')) == (42.3467, -71.0972)
+        assert asyncio.run(provider.geocode('Unknown Field', None)) is None
+
+    def test_chain_falls_through_failures(self):
+        chain = ChainedGeocodingProvider([StaticGeocodingProvider({}), FailingProvider(), FixedProvider()])
+        assert asyncio.run(chain.geocode('New Park', 'Charlotte, NC')) == (35.0, -80.0)
+        assert chain.last_provider.name == 'fixed'
+
+    def test_provider_by_name(self):
+        assert get_geocoding_provider('static').name == 'static'
+        assert get_geocoding_provider('nominatim').name == 'static+nominatim'
+        try:
+            get_geocoding_provider('google')
+            assert False, 'expected ValueError'
+        except ValueError:
+            pass
+
+
+class FakeResponse:
+    def raise_for_status(self):
+        pass
+
+    def json(self):
+        return [{'lat': '35.0', 'lon': '-80.0'}]
+
+
+class FakeClient:
+    def __init__(self, clock):
+        self.clock = clock
+        self.requested_at = []
+
+    async def get(self, url, params=None):
+        self.requested_at.append(self.clock.now)
+        return FakeResponse()
+
+
+class FakeClock:
+    def __init__(self):
+        self.now = 100.0
+
+    def __call__(self):
+        return self.now
+
+    async def sleep(self, seconds):
+        self.now += seconds
+
+
+class TestNominatimThrottle:
+    """Requests to the public Nominatim instance stay a second apart"""
+
+    def test_spaces_concurrent_requests(self):
+        clock = FakeClock()
+        client = FakeClient(clock)
+        provider = NominatimGeocodingProvider(client=client, clock=clock, sleep=clock.sleep)
+
+        async def lookup_all():
+            return await asyncio.gather(*(provider.geocode(f'Park {i}', None) for i in range(3)))
+
+        assert asyncio.run(lookup_all()) == [(35.0, -80.0)] * 3
+        assert client.requested_at == [100.0, 101.0, 102.0]
+
+        # No wait once the interval has already passed
+        clock.now += 5
+        asyncio.run(provider.geocode('Park 3', None))
+        assert client.requested_at[-1] == 107.0
+
+
+class FakePool:
+    def __init__(self, stadiums, missing):
+        self.stadiums = stadiums
+        self.missing = missing
+        self.updates = []
+
+    async def fetch(self, query, *args):
+        if 'upcoming_games' in query:
+            return self.missing
+        return self.stadiums
+
+    async def execute(self, query, *args):
+        self.updates.append(args)
+
+
+class TestBackfill:
+    """The backfill geocodes stadiums without coordinates and reports the rest"""
+
+    def test_updates_resolved_and_reports_missing(self):
+        pool = FakePool(
+            stadiums=[
+                {'stadium_id': '3', 'name': 'Fenway Park', 'location': 'Boston, MA'},
+                {'stadium_id': '999', 'name': 'Spring Complex', 'location': None},
+            ],
+            missing=[{'stadium_id': '999', 'name': 'Spring Complex', 'roof_type': 'open', 'upcoming_games': 2}],
+        )
+        report = asyncio.run(backfill_stadium_coordinates(pool, StaticGeocodingProvider()))
+
+        assert report['checked'] == 2
+        assert report['updated'] == ['Fenway Park']
+        assert report['unresolved'] == ['Spring Complex']
+        assert report['missing_outdoor'][0]['name'] == 'Spring Complex'
+        assert pool.updates == [('3', 42.3467, -71.0972, 'static')]
+
+    def test_records_the_provider_that_resolved(self):
+        pool = FakePool(stadiums=[{'stadium_id': '7', 'name': 'New Park', 'location': None}], missing=[])
+        chain = ChainedGeocodingProvider([StaticGeocodingProvider(), FixedProvider()])
+        asyncio.run(backfill_stadium_coordinates(pool, chain))
+        assert pool.updates == [('7', 35.0, -80.0, 'fixed')]
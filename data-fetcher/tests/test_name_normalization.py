@@ -0,0 +1,52 @@
+"""
+Unit tests for player name normalization
+"""
+from name_normalization import normalize_name, player_name_aliases
+
+
+class TestNormalizeName:
+    """Test name normalization matches the database's normalize_name()"""
+
+    def test_strips_accents(self):
+        assert normalize_name('Ronald Acuña Jr.') == 'ronald acuna jr'
+        assert normalize_name('José Ramírez') == 'jose ramirez'
+
+    def test_letters_without_decomposition(self):
+        assert normalize_name('Jørgen Łukasz') == 'jorgen lukasz'
+
+    def test_punctuation_and_whitespace(self):
+        assert normalize_name('J.P. Crawford') == 'jp crawford'
+        assert normalize_name("Travis d'Arnaud") == 'travis darnaud'
+        assert normalize_name('Isiah Kiner-Falefa') == 'isiah kiner falefa'
+        assert normalize_name('  Mike   Trout ') == 'mike trout'
+
+    def test_empty(self):
+        assert normalize_name(None) == ''
+        assert normalize_name('') == ''
+
+
+class TestPlayerNameAliases:
+    """Test alias extraction from MLB person details"""
+
+    def test_use_name_and_legal_name(self):
+        player = {
+            'full_name': 'Nathan Eovaldi',
+            'first_name': 'Nathan',
+            'last_name': 'Eovaldi',
+            'use_name': 'Nate',
+            'full_fml_name': 'Nathan Edward Eovaldi',
+        }
+        assert player_name_aliases(player) == ['Nate Eovaldi', 'Nathan Edward Eovaldi']
+
+    def test_skips_names_that_normalize_like_full_name(self):
+        player = {
+            'full_name': 'Ronald Acuña Jr.',
+            'first_name': 'Ronald',
+            'last_name': 'Acuña',
+            'use_name': 'Ronald',
+            'full_fml_name': 'Ronald Acuña Jr.',
+        }
+        assert player_name_aliases(player) == ['Ronald Acuña']
+
+    def test_missing_details(self):
+        assert player_name_aliases({'full_name': 'Mike Trout'}) == []
@@ -0,0 +1,58 @@
+"""
+Unit tests for per-game venue overrides
+"""
+import asyncio
+
+from venues import schedule_venue, set_manual_game_venue
+
+
+class FakePool:
+    """Resolves stadium and game IDs to UUIDs and records updates"""
+
+    def __init__(self, stadiums, games):
+        self.known = {('stadiums', k): v for k, v in stadiums.items()}
+        self.known.update({('games', k): v for k, v in games.items()})
+        self.updates = []
+
+    async def fetch(self, query, *args):
+        table = query.split('FROM ')[1].split()[0]
+        uuid = self.known.get((table, args[0]))
+        return [{'id': uuid}] if uuid else []
+
+    async def fetchrow(self, query, *args):
+        game_uuid, stadium_uuid = args
+        self.updates.append(args)
+        return {'id': game_uuid, 'game_id': '778899', 'stadium_id': stadium_uuid,
+                'stadium_name': 'London Stadium', 'venue_source': 'manual', 'neutral_site': True}
+
+
+class TestSetManualGameVenue:
+    """Overrides resolve the game and stadium IDs before updating"""
+
+    def test_moves_game(self):
+        pool = FakePool({'2681': 'uuid-london'}, {'778899': 'uuid-game'})
+        result = asyncio.run(set_manual_game_venue(pool, '778899', '2681'))
+
+        assert pool.updates == [('uuid-game', 'uuid-london')]
+        assert result['stadium_id'] == 'uuid-london'
+        assert result['neutral_site'] is True
+
+    def test_unknown_stadium(self):
+        pool = FakePool({}, {'778899': 'uuid-game'})
+        assert asyncio.run(set_manual_game_venue(pool, '778899', '9999')) == {'error': 'stadium_not_found'}
+        assert pool.updates == []
+
+    def test_unknown_game(self):
+        pool = FakePool({'2681': 'uuid-london'}, {})
+        assert asyncio.run(set_manual_game_venue(pool, '1', '2681')) == {'error': 'game_not_found'}
+        assert pool.updates == []
+
+
+class TestScheduleVenue:
+    def test_reads_venue(self):
+        venue = {'id': 2681, 'name': 'London Stadium'}
+        assert schedule_venue({'venue': venue}) == venue
+
+    def test_missing_venue(self):
+        assert schedule_venue({}) is None
+        assert schedule_venue({'venue': {'name': 'TBD'}}) is None
@@ -0,0 +1,78 @@
+"""
+Unit tests for the simplified WAR calculation
+"""
+from war_calculator import (
+    WAR_METHOD_VERSION,
+    calculate_pitcher_war,
+    calculate_position_player_war,
+    innings_to_float,
+    pitcher_role,
+)
+
+
+class TestPositionPlayerWAR:
+    """Test position player WAR components"""
+
+    batting = {
+        'atBats': 540, 'baseOnBalls': 50, 'hitByPitch': 5, 'sacFlies': 5,
+        'gamesPlayed': 162, 'wRAA': 20.0, 'BSR': 2.0,
+    }
+
+    def test_components_sum_to_war(self):
+        war = calculate_position_player_war(self.batting, {'UZR': 5.0}, 'SS')
+        assert war['WAR_replacement_runs'] == 20.0
+        assert war['WAR_positional_runs'] == 7.5
+        assert war['WAR_fielding_runs'] == 5.0
+        # (20 + 2 + 5 + 7.5 + 20) / 10
+        assert war['WAR'] == 5.5
+        assert war['WAR_method'] == WAR_METHOD_VERSION
+
+    def test_positional_adjustment_scales_with_games(self):
+        batting = dict(self.batting, gamesPlayed=81)
+        war = calculate_position_player_war(batting, None, 'DH')
+        assert war['WAR_positional_runs'] == -8.8
+        assert war['WAR_fielding_runs'] == 0.0
+
+    def test_unknown_position_has_no_adjustment(self):
+        war = calculate_position_player_war(self.batting, None, 'TWP')
+        assert war['WAR_positional_runs'] == 0.0
+
+    def test_no_plate_appearances(self):
+        assert calculate_position_player_war({'gamesPlayed': 3}, None, 'C') == {}
+
+
+class TestPitcherWAR:
+    """Test FIP-based pitcher WAR"""
+
+    def test_league_average_starter(self):
+        war = calculate_pitcher_war({'inningsPitched': '180.0', 'FIP': 4.05, 'gamesPitched': 30, 'gamesStarted': 30})
+        assert war['WAR_role'] == 'starter'
+        assert war['WAR_pitching_runs'] == 0.0
+        # 1.2 runs per 9 over 180 innings
+        assert war['WAR'] == 2.4
+
+    def test_reliever_replacement_level(self):
+        war = calculate_pitcher_war({'inningsPitched': '63.0', 'FIP': 3.15, 'gamesPitched': 60, 'gamesStarted': 0})
+        assert war['WAR_role'] == 'reliever'
+        assert war['WAR_pitching_runs'] == 6.3
+        assert war['WAR_replacement_runs'] == 4.2
+        assert war['WAR'] == 1.1
+
+    def test_requires_innings_and_fip(self):
+        assert calculate_pitcher_war({'inningsPitched': '0.0', 'FIP': 3.0}) == {}
+        assert calculate_pitcher_war({'inningsPitched': '10.0'}) == {}
+
+
+class TestHelpers:
+    """Test innings parsing and role detection"""
+
+    def test_innings_thirds(self):
+        assert innings_to_float('123.2') == 123 + 2 / 3
+        assert innings_to_float('7.1') == 7 + 1 / 3
+        assert innings_to_float(None) == 0.0
+        assert innings_to_float('bad') == 0.0
+
+    def test_pitcher_role(self):
+        assert pitcher_role({'gamesPitched': 10, 'gamesStarted': 5}) == 'starter'
+        assert pitcher_role({'gamesPitched': 10, 'gamesStarted': 4}) == 'reliever'
+        assert pitcher_role({}) == 'reliever'
@@ -0,0 +1,52 @@
+"""
+Game venues
+Most games are played at the home team's park, but neutral-site games
+(international series, the Little League Classic, temporary homes) are not.
+The schedule's venue is stored per game so park factors, weather and altitude
+come from where the game is actually played; operators can override it when
+the feed is wrong or late.
+"""
+import logging
+from typing import Dict, Optional
+
+import asyncpg
+
+from aliases import resolve_entity_id
+
+logger = logging.getLogger(__name__)
+
+
+async def set_manual_game_venue(db_pool: asyncpg.Pool, game_id: str,
+                                stadium_id: str) -> Dict:
+    """Move a game to another stadium; later fetches keep the override.
+    game_id and stadium_id may each be any identifier resolve_entity_id
+    accepts. Returns the updated game, or an 'error' of 'game_not_found' or
+    'stadium_not_found'."""
+    stadium_uuid = await resolve_entity_id(db_pool, 'stadium', stadium_id)
+    if stadium_uuid is None:
+        return {'error': 'stadium_not_found'}
+    game_uuid = await resolve_entity_id(db_pool, 'game', game_id)
+    if game_uuid is None:
+        return {'error': 'game_not_found'}
+
+    row = await db_pool.fetchrow("""
+        UPDATE games g
+        SET stadium_id = $2::uuid, venue_source = 'manual', updated_at = NOW()
+        FROM stadiums s
+        WHERE g.id = $1::uuid AND s.id = $2::uuid
+        RETURNING g.id::text AS id, g.game_id, s.id::text AS stadium_id,
+                  s.name AS stadium_name, g.venue_source,
+                  g.stadium_id IS DISTINCT FROM (
+                      SELECT t.stadium_id FROM teams t WHERE t.id = g.home_team_id
+                  ) AS neutral_site
+    """, game_uuid, stadium_uuid)
+    if row is None:
+        return {'error': 'game_not_found'}
+    logger.info(f"Game {row['game_id']} moved to {row['stadium_name']}")
+    return dict(row)
+
+
+def schedule_venue(game: Dict) -> Optional[Dict]:
+    """The venue the schedule lists for a game, or None when it has no ID"""
+    venue = game.get('venue') or {}
+    return venue if venue.get('id') else None
@@ -0,0 +1,108 @@
+"""
+Simplified WAR (Wins Above Replacement)
+Position players: batting + baserunning + fielding + positional adjustment + replacement level
+Pitchers: FIP-based runs above average + replacement level
+"""
+from typing import Dict, Optional
+
+# Bump the version whenever the formula or constants change so stored values
+# can be told apart from ones computed under an older method
+WAR_METHOD_VERSION = "bsim-war-v1"
+
+WAR_METHODOLOGY = {
+    "version": WAR_METHOD_VERSION,
+    "position_players": "(batting runs + baserunning runs + fielding runs + positional adjustment + replacement runs) / runs per win",
+    "pitchers": "((league FIP - FIP) * IP / 9 + replacement runs) / runs per win",
+    "notes": "Simplified estimate from season aggregates; not comparable to FanGraphs or Baseball-Reference WAR",
+}
+
+RUNS_PER_WIN = 10.0
+REPLACEMENT_RUNS_PER_600_PA = 20.0
+LEAGUE_FIP = 4.05
+
+# Positional adjustment in runs per 162 games
+POSITIONAL_ADJUSTMENT = {
+    'C': 12.5,
+    'SS': 7.5,
+    '2B': 2.5,
+    '3B': 2.5,
+    'CF': 2.5,
+    'LF': -7.5,
+    'RF': -7.5,
+    '1B': -12.5,
+    'DH': -17.5,
+}
+
+# Runs per nine innings between a league-average and a replacement pitcher.
+# Replacement relievers are closer to average than replacement starters.
+REPLACEMENT_RUNS_PER_9 = {
+    'starter': 1.2,
+    'reliever': 0.6,
+}
+
+
+def innings_to_float(innings) -> float:
+    """Convert MLB innings notation ("123.2" = 123 and two thirds) to innings"""
+    if innings in (None, ''):
+        return 0.0
+    whole, _, thirds = str(innings).partition('.')
+    try:
+        return int(whole or 0) + int(thirds[:1] or 0) / 3
+    except ValueError:
+        return 0.0
+
+
+def pitcher_role(stats: Dict) -> str:
+    """Pitchers who started at least half their games are valued as starters"""
+    games = stats.get('gamesPitched') or stats.get('gamesPlayed') or 0
+    starts = stats.get('gamesStarted', 0)
+    if games > 0 and starts / games >= 0.5:
+        return 'starter'
+    return 'reliever'
+
+
+def calculate_position_player_war(batting: Dict, fielding: Optional[Dict], position: Optional[str]) -> Dict:
+    """WAR for a batting season; expects the advanced batting and fielding stats
+    (wRAA, BSR, UZR) to have been calculated already"""
+    pa = (batting.get('atBats', 0) + batting.get('baseOnBalls', 0) +
+          batting.get('hitByPitch', 0) + batting.get('sacFlies', 0))
+    if pa == 0:
+        return {}
+
+    games = batting.get('gamesPlayed', 0)
+    batting_runs = float(batting.get('wRAA', 0))
+    baserunning_runs = float(batting.get('BSR', 0))
+    fielding_runs = float((fielding or {}).get('UZR', 0))
+    positional_runs = POSITIONAL_ADJUSTMENT.get(position or '', 0.0) * games / 162
+    replacement_runs = REPLACEMENT_RUNS_PER_600_PA * pa / 600
+
+    total = batting_runs + baserunning_runs + fielding_runs + positional_runs + replacement_runs
+    return {
+        'WAR': round(total / RUNS_PER_WIN, 1),
+        'WAR_batting_runs': round(batting_runs, 1),
+        'WAR_baserunning_runs': round(baserunning_runs, 1),
+        'WAR_fielding_runs': round(fielding_runs, 1),
+        'WAR_positional_runs': round(positional_runs, 1),
+        'WAR_replacement_runs': round(replacement_runs, 1),
+        'WAR_method': WAR_METHOD_VERSION,
+    }
+
+
+def calculate_pitcher_war(pitching: Dict) -> Dict:
+    """WAR for a pitching season from FIP; expects FIP to have been calculated already"""
+    ip = innings_to_float(pitching.get('inningsPitched'))
+    if ip == 0 or 'FIP' not in pitching:
+        return {}
+
+    fip = float(pitching['FIP'])
+    role = pitcher_role(pitching)
+    pitching_runs = (LEAGUE_FIP - fip) * ip / 9
+    replacement_runs = REPLACEMENT_RUNS_PER_9[role] * ip / 9
+
+    return {
+        'WAR': round((pitching_runs + replacement_runs) / RUNS_PER_WIN, 1),
+        'WAR_pitching_runs': round(pitching_runs, 1),
+        'WAR_replacement_runs': round(replacement_runs, 1),
+        'WAR_role': role,
+        'WAR_method': WAR_METHOD_VERSION,
+    }
@@ -4,19 +4,19 @@
 -- Insert sample stadiums
 INSERT INTO stadiums (stadium_id, name, location, capacity, dimensions, park_factors, altitude, surface, roof_type) VALUES
 ('fenway', 'Fenway Park', 'Boston, MA', 37755, 
- '{"left_field": 310, "center_field": 420, "right_field": 302}'::jsonb,
+ '{"left_field": 310, "left_center": 379, "center": 420, "right_center": 380, "right_field": 302, "left_field_wall": 37, "center_field_wall": 17, "right_field_wall": 5}'::jsonb,
  '{"home_run_factor": 1.05, "doubles_factor": 1.12, "triples_factor": 0.85}'::jsonb,
  20, 'Natural Grass', 'Open'),
 ('yankee', 'Yankee Stadium', 'Bronx, NY', 47309,
- '{"left_field": 318, "center_field": 408, "right_field": 314}'::jsonb,
+ '{"left_field": 318, "left_center": 399, "center": 408, "right_center": 385, "right_field": 314, "left_field_wall": 8, "center_field_wall": 8, "right_field_wall": 8}'::jsonb,
  '{"home_run_factor": 1.08, "doubles_factor": 0.98, "triples_factor": 0.75}'::jsonb,
  55, 'Natural Grass', 'Open'),
 ('wrigley', 'Wrigley Field', 'Chicago, IL', 41649,
- '{"left_field": 355, "center_field": 400, "right_field": 353}'::jsonb,
+ '{"left_field": 355, "left_center": 368, "center": 400, "right_center": 368, "right_field": 353, "left_field_wall": 15, "center_field_wall": 11, "right_field_wall": 15}'::jsonb,
  '{"home_run_factor": 1.02, "doubles_factor": 1.05, "triples_factor": 1.15}'::jsonb,
  595, 'Natural Grass', 'Open'),
 ('coors', 'Coors Field', 'Denver, CO', 50398,
- '{"left_field": 347, "center_field": 415, "right_field": 350}'::jsonb,
+ '{"left_field": 347, "left_center": 390, "center": 415, "right_center": 375, "right_field": 350, "left_field_wall": 8, "center_field_wall": 8, "right_field_wall": 17}'::jsonb,
  '{"home_run_factor": 1.25, "doubles_factor": 1.18, "triples_factor": 1.30}'::jsonb,
  5200, 'Natural Grass', 'Open');
 
@@ -0,0 +1,183 @@
+-- Denormalized Games Read Model
+-- Migration 011: Flattened games table for list endpoints
+--
+-- The /games, /games/date/{date} and /teams/{id}/games endpoints previously
+-- joined games -> teams (x2) -> stadiums on every request. This table keeps
+-- one pre-joined row per game and is maintained by triggers on the source
+-- tables, so list queries become single-table index scans.
+
+CREATE TABLE IF NOT EXISTS games_read_model (
+    id UUID PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+    game_id VARCHAR(50) NOT NULL,
+    season INTEGER,
+    game_type VARCHAR(20),
+    game_date DATE NOT NULL,
+    game_time TIME,
+    status VARCHAR(20),
+    final_score_home INTEGER,
+    final_score_away INTEGER,
+    home_team_id UUID,
+    home_team_external_id VARCHAR(50),
+    home_team_name VARCHAR(100),
+    home_team_city VARCHAR(100),
+    home_team_abbr VARCHAR(10),
+    away_team_id UUID,
+    away_team_external_id VARCHAR(50),
+    away_team_name VARCHAR(100),
+    away_team_city VARCHAR(100),
+    away_team_abbr VARCHAR(10),
+    stadium_id UUID,
+    stadium_name VARCHAR(200),
+    stadium_location VARCHAR(200),
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    refreshed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_games_read_model_season_date
+ON games_read_model(season, game_date DESC);
+
+CREATE INDEX IF NOT EXISTS idx_games_read_model_date
+ON games_read_model(game_date DESC);
+
+CREATE INDEX IF NOT EXISTS idx_games_read_model_home_team
+ON games_read_model(home_team_id, season);
+
+CREATE INDEX IF NOT EXISTS idx_games_read_model_away_team
+ON games_read_model(away_team_id, season);
+
+CREATE INDEX IF NOT EXISTS idx_games_read_model_status
+ON games_read_model(status);
+
+-- Rebuild the read model row for a single game
+CREATE OR REPLACE FUNCTION refresh_games_read_model_row(p_game_id UUID)
+RETURNS VOID AS $$
+BEGIN
+    INSERT INTO games_read_model (
+        id, game_id, season, game_type, game_date, game_time, status,
+        final_score_home, final_score_away,
+        home_team_id, home_team_external_id, home_team_name, home_team_city, home_team_abbr,
+        away_team_id, away_team_external_id, away_team_name, away_team_city, away_team_abbr,
+        stadium_id, stadium_name, stadium_location,
+        created_at, updated_at, refreshed_at
+    )
+    SELECT g.id, g.game_id, g.season, g.game_type, g.game_date, g.game_time, g.status,
+           g.final_score_home, g.final_score_away,
+           g.home_team_id, ht.team_id, ht.name, ht.city, ht.abbreviation,
+           g.away_team_id, at.team_id, at.name, at.city, at.abbreviation,
+           g.stadium_id, s.name, s.location,
+           g.created_at, g.updated_at, NOW()
+    FROM games g
+    LEFT JOIN teams ht ON g.home_team_id = ht.id
+    LEFT JOIN teams at ON g.away_team_id = at.id
+    LEFT JOIN stadiums s ON g.stadium_id = s.id
+    WHERE g.id = p_game_id
+    ON CONFLICT (id) DO UPDATE SET
+        game_id = EXCLUDED.game_id,
+        season = EXCLUDED.season,
+        game_type = EXCLUDED.game_type,
+        game_date = EXCLUDED.game_date,
+        game_time = EXCLUDED.game_time,
+        status = EXCLUDED.status,
+        final_score_home = EXCLUDED.final_score_home,
+        final_score_away = EXCLUDED.final_score_away,
+        home_team_id = EXCLUDED.home_team_id,
+        home_team_external_id = EXCLUDED.home_team_external_id,
+        home_team_name = EXCLUDED.home_team_name,
+        home_team_city = EXCLUDED.home_team_city,
+        home_team_abbr = EXCLUDED.home_team_abbr,
+        away_team_id = EXCLUDED.away_team_id,
+        away_team_external_id = EXCLUDED.away_team_external_id,
+        away_team_name = EXCLUDED.away_team_name,
+        away_team_city = EXCLUDED.away_team_city,
+        away_team_abbr = EXCLUDED.away_team_abbr,
+        stadium_id = EXCLUDED.stadium_id,
+        stadium_name = EXCLUDED.stadium_name,
+        stadium_location = EXCLUDED.stadium_location,
+        created_at = EXCLUDED.created_at,
+        updated_at = EXCLUDED.updated_at,
+        refreshed_at = NOW();
+END;
+$$ LANGUAGE plpgsql;
+
+-- Games writes refresh their own row (deletes cascade via the FK)
+CREATE OR REPLACE FUNCTION games_read_model_on_game_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_games_read_model_row(NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS games_read_model_game_sync ON games;
+CREATE TRIGGER games_read_model_game_sync AFTER INSERT OR UPDATE ON games
+    FOR EACH ROW EXECUTE FUNCTION games_read_model_on_game_change();
+
+-- Team renames/relabels propagate to every game that references the team
+CREATE OR REPLACE FUNCTION games_read_model_on_team_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE games_read_model SET
+        home_team_external_id = NEW.team_id,
+        home_team_name = NEW.name,
+        home_team_city = NEW.city,
+        home_team_abbr = NEW.abbreviation,
+        refreshed_at = NOW()
+    WHERE home_team_id = NEW.id;
+
+    UPDATE games_read_model SET
+        away_team_external_id = NEW.team_id,
+        away_team_name = NEW.name,
+        away_team_city = NEW.city,
+        away_team_abbr = NEW.abbreviation,
+        refreshed_at = NOW()
+    WHERE away_team_id = NEW.id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS games_read_model_team_sync ON teams;
+CREATE TRIGGER games_read_model_team_sync AFTER UPDATE ON teams
+    FOR EACH ROW EXECUTE FUNCTION games_read_model_on_team_change();
+
+-- Stadium changes propagate to games played there
+CREATE OR REPLACE FUNCTION games_read_model_on_stadium_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE games_read_model SET
+        stadium_name = NEW.name,
+        stadium_location = NEW.location,
+        refreshed_at = NOW()
+    WHERE stadium_id = NEW.id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS games_read_model_stadium_sync ON stadiums;
+CREATE TRIGGER games_read_model_stadium_sync AFTER UPDATE ON stadiums
+    FOR EACH ROW EXECUTE FUNCTION games_read_model_on_stadium_change();
+
+-- Backfill existing games
+INSERT INTO games_read_model (
+    id, game_id, season, game_type, game_date, game_time, status,
+    final_score_home, final_score_away,
+    home_team_id, home_team_external_id, home_team_name, home_team_city, home_team_abbr,
+    away_team_id, away_team_external_id, away_team_name, away_team_city, away_team_abbr,
+    stadium_id, stadium_name, stadium_location,
+    created_at, updated_at
+)
+SELECT g.id, g.game_id, g.season, g.game_type, g.game_date, g.game_time, g.status,
+       g.final_score_home, g.final_score_away,
+       g.home_team_id, ht.team_id, ht.name, ht.city, ht.abbreviation,
+       g.away_team_id, at.team_id, at.name, at.city, at.abbreviation,
+       g.stadium_id, s.name, s.location,
+       g.created_at, g.updated_at
+FROM games g
+LEFT JOIN teams ht ON g.home_team_id = ht.id
+LEFT JOIN teams at ON g.away_team_id = at.id
+LEFT JOIN stadiums s ON g.stadium_id = s.id
+ON CONFLICT (id) DO NOTHING;
+
+ANALYZE games_read_model;
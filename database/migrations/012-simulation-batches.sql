@@ -0,0 +1,21 @@
+-- Simulation Batches
+-- Migration 012: Group simulation runs created by a single batch request
+
+CREATE TABLE IF NOT EXISTS simulation_batches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    filters JSONB NOT NULL, -- team, date range, game_type used to select games
+    config JSONB,
+    simulation_runs INTEGER,
+    games_count INTEGER DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE simulation_runs
+ADD COLUMN IF NOT EXISTS batch_id UUID REFERENCES simulation_batches(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_simulation_runs_batch
+ON simulation_runs(batch_id)
+WHERE batch_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_simulation_batches_created
+ON simulation_batches(created_at DESC);
@@ -0,0 +1,32 @@
+-- Game Umpire Assignments
+-- Migration 013: Per-game umpire crew with positions and call accuracy
+-- games.home_plate_umpire_id only records the plate umpire; this table keeps
+-- the whole crew so umpire game logs can show every assignment
+
+CREATE TABLE IF NOT EXISTS game_umpires (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    umpire_id UUID NOT NULL REFERENCES umpires(id) ON DELETE CASCADE,
+    position VARCHAR(5) NOT NULL CHECK (position IN ('HP', '1B', '2B', '3B', 'LF', 'RF')),
+
+    -- Call accuracy (home plate only, populated when pitch-level data is available)
+    accuracy_pct DECIMAL(5,2),
+    correct_calls INTEGER,
+    incorrect_calls INTEGER,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(game_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_umpires_umpire_id ON game_umpires(umpire_id);
+CREATE INDEX IF NOT EXISTS idx_game_umpires_game_id ON game_umpires(game_id);
+
+-- Backfill plate assignments already recorded on games
+INSERT INTO game_umpires (game_id, umpire_id, position)
+SELECT id, home_plate_umpire_id, 'HP'
+FROM games
+WHERE home_plate_umpire_id IS NOT NULL
+ON CONFLICT (game_id, position) DO NOTHING;
+
+ANALYZE game_umpires;
@@ -0,0 +1,22 @@
+-- League Environment
+-- Migration 014: Per-season run environment constants used to calibrate simulations
+
+CREATE TABLE IF NOT EXISTS league_environment (
+    season INTEGER PRIMARY KEY,
+    league_woba DECIMAL(5,3) NOT NULL,
+    woba_scale DECIMAL(5,3) NOT NULL,
+    fip_constant DECIMAL(5,3) NOT NULL,
+    league_fip DECIMAL(5,2) NOT NULL, -- equals league ERA by construction
+    runs_per_pa DECIMAL(5,3) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Seed recent seasons (FanGraphs Guts! values)
+INSERT INTO league_environment (season, league_woba, woba_scale, fip_constant, league_fip, runs_per_pa) VALUES
+    (2019, 0.320, 1.157, 3.214, 4.51, 0.126),
+    (2020, 0.320, 1.185, 3.191, 4.44, 0.122),
+    (2021, 0.314, 1.209, 3.170, 4.26, 0.118),
+    (2022, 0.310, 1.259, 3.112, 3.97, 0.114),
+    (2023, 0.318, 1.204, 3.255, 4.33, 0.122),
+    (2024, 0.310, 1.242, 3.166, 4.08, 0.117)
+ON CONFLICT (season) DO NOTHING;
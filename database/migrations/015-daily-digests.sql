@@ -0,0 +1,13 @@
+-- Daily Simulation Digests
+-- Migration 015: League-wide summary of each day's simulated games
+
+CREATE TABLE IF NOT EXISTS simulation_daily_digests (
+    digest_date DATE PRIMARY KEY,
+    batch_id UUID REFERENCES simulation_batches(id) ON DELETE SET NULL,
+    games_count INTEGER DEFAULT 0,
+    digest JSONB NOT NULL, -- games, favorites, upset picks and projected totals
+    generated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_simulation_batches_date
+ON simulation_batches((filters->>'date'), created_at DESC);
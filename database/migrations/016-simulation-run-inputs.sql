@@ -0,0 +1,9 @@
+-- Simulation Run Inputs
+-- Migration 016: Snapshot the inputs each run simulated with so runs for the
+-- same game can be compared factor by factor
+
+ALTER TABLE simulation_runs
+ADD COLUMN IF NOT EXISTS inputs JSONB; -- engine version, weather, starters and lineups
+
+CREATE INDEX IF NOT EXISTS idx_simulation_runs_game_created
+ON simulation_runs(game_id, created_at DESC);
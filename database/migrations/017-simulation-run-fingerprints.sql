@@ -0,0 +1,13 @@
+-- Simulation Run Fingerprints
+-- Migration 017: Stamp runs with the engine build, model parameter hash and
+-- data snapshot they were produced with so accuracy shifts can be traced to
+-- model changes
+
+ALTER TABLE simulation_runs
+ADD COLUMN IF NOT EXISTS engine_version VARCHAR(64),
+ADD COLUMN IF NOT EXISTS model_param_hash VARCHAR(16),
+ADD COLUMN IF NOT EXISTS data_snapshot_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_simulation_runs_fingerprint
+ON simulation_runs(engine_version, model_param_hash)
+WHERE engine_version IS NOT NULL;
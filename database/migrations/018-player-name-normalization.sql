@@ -0,0 +1,69 @@
+-- Player Name Normalization
+-- Migration 018: Accent-insensitive player names, a nickname table and player
+-- aliases so search finds "Acuna" and "Nate" and ingestion can merge duplicates
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE because its dictionary could change; pinning the
+-- dictionary lets the wrapper back generated columns and indexes.
+-- Lowercases, strips accents and punctuation, and collapses whitespace:
+-- 'Ronald Acuña Jr.' -> 'ronald acuna jr', 'J.P. Crawford' -> 'jp crawford'
+CREATE OR REPLACE FUNCTION normalize_name(name TEXT) RETURNS TEXT AS $$
+    SELECT btrim(regexp_replace(
+        regexp_replace(lower(public.unaccent('public.unaccent'::regdictionary, COALESCE(name, ''))),
+                       '[.''’]', '', 'g'),
+        '[^a-z0-9]+', ' ', 'g'))
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+ALTER TABLE players
+ADD COLUMN IF NOT EXISTS normalized_name TEXT
+GENERATED ALWAYS AS (normalize_name(full_name)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_players_normalized_name
+ON players(normalized_name text_pattern_ops);
+
+-- Common first-name nicknames. Search swaps a query's leading name both ways
+-- so "Nate Eovaldi" and "Nathan Eovaldi" find each other.
+CREATE TABLE IF NOT EXISTS first_name_aliases (
+    alias VARCHAR(30) NOT NULL,     -- normalized nickname, e.g. 'nate'
+    canonical VARCHAR(30) NOT NULL, -- normalized formal name, e.g. 'nathan'
+    PRIMARY KEY (alias, canonical)
+);
+
+CREATE INDEX IF NOT EXISTS idx_first_name_aliases_canonical
+ON first_name_aliases(canonical);
+
+INSERT INTO first_name_aliases (alias, canonical) VALUES
+    ('alex', 'alexander'), ('andy', 'andrew'), ('ben', 'benjamin'),
+    ('bill', 'william'), ('billy', 'william'), ('bob', 'robert'),
+    ('bobby', 'robert'), ('cam', 'cameron'), ('chris', 'christopher'),
+    ('dan', 'daniel'), ('danny', 'daniel'), ('dave', 'david'),
+    ('ed', 'edward'), ('eddie', 'edward'), ('fred', 'frederick'),
+    ('freddie', 'frederick'), ('greg', 'gregory'), ('jake', 'jacob'),
+    ('jim', 'james'), ('jimmy', 'james'), ('joe', 'joseph'),
+    ('joey', 'joseph'), ('jon', 'jonathan'), ('josh', 'joshua'),
+    ('ken', 'kenneth'), ('kenny', 'kenneth'), ('matt', 'matthew'),
+    ('max', 'maxwell'), ('mike', 'michael'), ('mitch', 'mitchell'),
+    ('nate', 'nathan'), ('nate', 'nathaniel'), ('nick', 'nicholas'),
+    ('nico', 'nicholas'), ('pat', 'patrick'), ('rick', 'richard'),
+    ('ricky', 'richard'), ('rob', 'robert'), ('ron', 'ronald'),
+    ('sam', 'samuel'), ('steve', 'steven'), ('steve', 'stephen'),
+    ('ted', 'theodore'), ('tim', 'timothy'), ('tom', 'thomas'),
+    ('tommy', 'thomas'), ('tony', 'anthony'), ('vinnie', 'vincent'),
+    ('will', 'william'), ('zach', 'zachary'), ('zack', 'zachary')
+ON CONFLICT DO NOTHING;
+
+-- Other names a player is known by: MLB use names and full legal names from
+-- ingestion, names of duplicate records merged into this one, manual entries
+CREATE TABLE IF NOT EXISTS player_aliases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    alias VARCHAR(200) NOT NULL,
+    normalized_alias TEXT GENERATED ALWAYS AS (normalize_name(alias)) STORED,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual', -- 'manual', 'ingestion', 'merge'
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (player_id, alias)
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_aliases_normalized
+ON player_aliases(normalized_alias text_pattern_ops);
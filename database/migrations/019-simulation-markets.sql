@@ -0,0 +1,6 @@
+-- Simulation Markets
+-- Migration 019: Store run line, game total and first-five-innings
+-- probabilities computed from each run's joint simulated scores
+
+ALTER TABLE simulation_aggregates
+ADD COLUMN IF NOT EXISTS markets JSONB; -- run_line, totals (6.5-13.5), first_five
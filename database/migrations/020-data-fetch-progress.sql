@@ -0,0 +1,7 @@
+-- Data Fetch Progress
+-- Migration 020: Record each fetch job's type and a progress snapshot
+-- (entities fetched, rows upserted, errors, ETA) while it runs
+
+ALTER TABLE data_fetch_status
+ADD COLUMN IF NOT EXISTS fetch_type VARCHAR(20),
+ADD COLUMN IF NOT EXISTS progress JSONB;
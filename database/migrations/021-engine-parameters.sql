@@ -0,0 +1,36 @@
+-- Engine Parameters
+-- Migration 021: Tuning parameters the sim-engine loads at startup and on
+-- POST /admin/reload-params. Seeded with the built-in defaults; names not
+-- listed here keep their defaults.
+
+CREATE TABLE IF NOT EXISTS engine_parameters (
+    name VARCHAR(64) PRIMARY KEY,
+    value DOUBLE PRECISION NOT NULL,
+    description TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO engine_parameters (name, value, description) VALUES
+    ('home_field_woba', 0, 'wOBA added to home batters'),
+    ('count_adjustment_3_0', 0.080, 'wOBA shift in a 3-0 count'),
+    ('count_adjustment_3_1', 0.060, 'wOBA shift in a 3-1 count'),
+    ('count_adjustment_2_0', 0.040, 'wOBA shift in a 2-0 count'),
+    ('count_adjustment_0_2', -0.060, 'wOBA shift in an 0-2 count'),
+    ('count_adjustment_1_2', -0.040, 'wOBA shift in a 1-2 count'),
+    ('count_adjustment_2_2', -0.020, 'wOBA shift in a 2-2 count'),
+    ('weather_wind_per_mph', 0.001, 'wOBA per MPH of wind blowing out (negative when in)'),
+    ('weather_cold_temperature', 50, 'Temperature (F) below which the cold adjustment applies'),
+    ('weather_cold_adjustment', -0.010, 'wOBA shift in cold weather'),
+    ('weather_hot_temperature', 80, 'Temperature (F) above which the hot adjustment applies'),
+    ('weather_hot_adjustment', 0.005, 'wOBA shift in hot weather'),
+    ('weather_humidity_threshold', 80, 'Humidity (%) above which the humidity adjustment applies'),
+    ('weather_humidity_adjustment', -0.005, 'wOBA shift in high humidity'),
+    ('leverage_late_inning', 7, 'First inning that adds leverage'),
+    ('leverage_per_late_inning', 0.3, 'Leverage added per inning from the late inning on'),
+    ('leverage_close_margin', 3, 'Run margin within which the score adds leverage'),
+    ('leverage_per_close_run', 0.2, 'Leverage added per run of closeness'),
+    ('leverage_per_runner', 0.1, 'Leverage added per runner on base'),
+    ('leverage_two_outs', 0.3, 'Leverage added with two outs'),
+    ('leverage_ninth_inning', 0.5, 'Leverage added from the ninth inning on'),
+    ('high_leverage_threshold', 1.5, 'Leverage above which clutch splits apply')
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,6 @@
+-- Simulation Inning Scoring
+-- Migration 022: Store per-inning scoring probabilities, first-to-score and
+-- first-inning-run probabilities computed from each simulated linescore
+
+ALTER TABLE simulation_aggregates
+ADD COLUMN IF NOT EXISTS inning_scoring JSONB; -- home/away innings 1-9 plus extras, first_to_score
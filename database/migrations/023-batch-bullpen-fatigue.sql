@@ -0,0 +1,6 @@
+-- Batch Bullpen Fatigue
+-- Migration 023: Record the reliever workload carry-over options a batch was
+-- run with. Each run's resulting reliever availability is kept in its inputs.
+
+ALTER TABLE simulation_batches
+ADD COLUMN IF NOT EXISTS bullpen_fatigue JSONB; -- source (actual/simulated) and fatigue rules
@@ -0,0 +1,6 @@
+-- Game Durations
+-- Migration 024: Store each completed game's actual length so the simulation
+-- engine can calibrate its duration model against real pace of play.
+
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS duration_minutes INTEGER; -- excludes delays, from the MLB live feed
@@ -0,0 +1,17 @@
+-- Stadium Coordinates
+-- Migration 025: Store each park's latitude and longitude so the weather
+-- service can fetch a forecast instead of falling back to defaults. The
+-- source records where coordinates came from; manual overrides are never
+-- replaced by the MLB feed or the geocoding backfill.
+
+ALTER TABLE stadiums
+ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION,
+ADD COLUMN IF NOT EXISTS coordinates_source VARCHAR(20), -- 'mlb', 'geocoder' or 'manual'
+ADD COLUMN IF NOT EXISTS coordinates_updated_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE stadiums DROP CONSTRAINT IF EXISTS stadiums_coordinates_range;
+ALTER TABLE stadiums ADD CONSTRAINT stadiums_coordinates_range CHECK (
+    (latitude IS NULL OR latitude BETWEEN -90 AND 90)
+    AND (longitude IS NULL OR longitude BETWEEN -180 AND 180)
+);
@@ -0,0 +1,6 @@
+-- Simulation Lineups
+-- Migration 026: Store the lineup card each team used in a run: batting order
+-- with fielding positions, starting pitcher, bench and any coverage issues
+
+ALTER TABLE simulation_aggregates
+ADD COLUMN IF NOT EXISTS lineups JSONB; -- home/away lineup cards
@@ -0,0 +1,18 @@
+-- Notification Targets
+-- Migration 027: Slack, Discord and webhook targets that receive the daily
+-- simulation digest when a daily batch completes. Each target belongs to the
+-- API key that registered it.
+
+CREATE TABLE IF NOT EXISTS notification_targets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    api_key_hash CHAR(64) NOT NULL, -- SHA-256 of the owning API key
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('slack', 'discord', 'webhook')),
+    url TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_sent_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT, -- set when the latest delivery failed
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_targets_owner
+ON notification_targets(api_key_hash);
@@ -0,0 +1,21 @@
+-- Player Contracts
+-- Migration 028: Optional salary and contract length per player-season, used
+-- for team payroll and value ($/WAR) reporting. The MLB Stats API doesn't
+-- publish salaries, so rows are loaded through the data fetcher's ingestion
+-- endpoint.
+
+CREATE TABLE IF NOT EXISTS player_contracts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    season INTEGER NOT NULL,
+    salary BIGINT NOT NULL CHECK (salary >= 0), -- whole US dollars for the season
+    contract_years SMALLINT CHECK (contract_years > 0), -- total length of the deal
+    contract_end_season INTEGER, -- last season the deal covers
+    source VARCHAR(50),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (player_id, season),
+    CHECK (contract_end_season IS NULL OR contract_end_season >= season)
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_contracts_season
+ON player_contracts(season);
@@ -0,0 +1,12 @@
+-- Game Venues
+-- Migration 029: Record where each game's stadium came from. Neutral-site
+-- games (international series, the Little League Classic, temporary homes)
+-- are played away from the home team's park, so the schedule's venue is
+-- stored rather than assuming the home team's stadium. Manual overrides are
+-- never replaced by later fetches.
+
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS venue_source VARCHAR(20); -- 'home_team', 'mlb' or 'manual'
+
+UPDATE games SET venue_source = 'home_team'
+WHERE venue_source IS NULL AND stadium_id IS NOT NULL;
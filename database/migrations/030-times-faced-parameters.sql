@@ -0,0 +1,10 @@
+-- Times-Faced Parameters
+-- Migration 030: Seed the within-game batter adjustment so it can be tuned
+-- from engine_parameters. Each earlier plate appearance against a pitcher
+-- adds times_faced_woba to the batter's expected wOBA; a diverse pitch mix
+-- removes up to times_faced_mix_mitigation of it.
+
+INSERT INTO engine_parameters (name, value, description) VALUES
+    ('times_faced_woba', 0.015, 'wOBA added per earlier plate appearance against the same pitcher in the game'),
+    ('times_faced_mix_mitigation', 0.5, 'Share of the times-faced adjustment removed for a fully diverse pitch mix')
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,20 @@
+-- Entity Aliases
+-- Migration 031: Extra identifiers for teams, players, games, umpires and
+-- stadiums beyond the internal UUID and MLB (MLBAM) ID, e.g. Retrosheet IDs.
+-- The gateway and sim engine resolve any of them to the internal UUID. Rows
+-- are loaded through the data fetcher's ingestion endpoint.
+
+CREATE TABLE IF NOT EXISTS entity_aliases (
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('team', 'player', 'game', 'umpire', 'stadium')),
+    alias_type VARCHAR(20) NOT NULL, -- namespace, e.g. retrosheet
+    alias VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL, -- id in the table entity_type names
+    source VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (entity_type, alias_type, alias)
+);
+
+-- Unprefixed lookups search every alias type
+CREATE INDEX IF NOT EXISTS idx_entity_aliases_alias
+ON entity_aliases(entity_type, alias);
@@ -0,0 +1,24 @@
+-- Notes
+-- Migration 032: Freeform analyst notes and tags on games, players and
+-- simulation runs, written through the gateway. entity_id is the internal
+-- UUID of the annotated row; author_hash is the SHA-256 of the writer's API
+-- key, so keys are never stored.
+
+CREATE TABLE IF NOT EXISTS notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('game', 'player', 'simulation_run')),
+    entity_id UUID NOT NULL,
+    author_hash CHAR(64) NOT NULL,
+    body TEXT NOT NULL CHECK (length(body) BETWEEN 1 AND 4000),
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notes_entity
+ON notes(entity_type, entity_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notes_tags
+ON notes USING GIN (tags);
+
+CREATE INDEX IF NOT EXISTS idx_notes_body_search
+ON notes USING GIN (to_tsvector('english', body));
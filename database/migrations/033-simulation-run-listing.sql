@@ -0,0 +1,9 @@
+-- Simulation Run Listing
+-- Migration 033: Index runs for the engine's GET /simulations listing,
+-- which pages newest first and is usually filtered by status.
+
+CREATE INDEX IF NOT EXISTS idx_simulation_runs_created
+ON simulation_runs(created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_simulation_runs_status_created
+ON simulation_runs(status, created_at DESC);
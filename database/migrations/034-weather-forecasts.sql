@@ -0,0 +1,18 @@
+-- Weather Forecasts
+-- Migration 034: Share fetched forecasts between sim-engine replicas so each
+-- stadium and hour is fetched from the provider once rather than once per
+-- replica. Rows expire with the engine's forecast cache and are deleted by
+-- its cleanup.
+
+CREATE TABLE IF NOT EXISTS weather_forecasts (
+    stadium_key TEXT NOT NULL,              -- stadium UUID, or name when unknown
+    forecast_hour TIMESTAMPTZ NOT NULL,     -- game time rounded to the hour
+    provider VARCHAR(50) NOT NULL,
+    weather JSONB NOT NULL,
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (stadium_key, forecast_hour)
+);
+
+CREATE INDEX IF NOT EXISTS idx_weather_forecasts_expires
+ON weather_forecasts(expires_at);
@@ -0,0 +1,22 @@
+-- Team Ratings
+-- Migration 035: Each team's record against a synthetic league-average or
+-- replacement-level opponent in a neutral park, written by the sim-engine's
+-- /ratings and read by the gateway's power rankings. Rerunning a season and
+-- opponent replaces its rows.
+
+CREATE TABLE IF NOT EXISTS team_ratings (
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    season INTEGER NOT NULL,
+    opponent VARCHAR(20) NOT NULL,          -- league_average or replacement
+    games INTEGER NOT NULL,
+    wins INTEGER NOT NULL,
+    win_pct DECIMAL(5,4) NOT NULL,
+    runs_scored_per_game DECIMAL(5,2) NOT NULL,
+    runs_allowed_per_game DECIMAL(5,2) NOT NULL,
+    model_param_hash VARCHAR(64),
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, season, opponent)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_ratings_season
+ON team_ratings(season, opponent, win_pct DESC);
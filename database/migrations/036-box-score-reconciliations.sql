@@ -0,0 +1,16 @@
+-- Box Score Reconciliations
+-- Migration 036: The gateway's last check of each completed game's stored
+-- box score against totals derived from its plays (hits, runs, strikeouts per
+-- team). Mismatches are kept with per-field diffs so ingestion bugs surface
+-- instead of silently corrupting stats.
+
+CREATE TABLE IF NOT EXISTS box_score_reconciliations (
+    game_id UUID PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+    mismatch_count INTEGER NOT NULL DEFAULT 0,
+    mismatches JSONB NOT NULL DEFAULT '[]', -- [{team, field, box_score, plays, diff}]
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_box_score_reconciliations_mismatched
+ON box_score_reconciliations(checked_at)
+WHERE mismatch_count > 0;
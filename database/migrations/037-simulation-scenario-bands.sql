@@ -0,0 +1,7 @@
+-- Simulation Scenario Bands
+-- Migration 037: Store the pessimistic, median and optimistic variants of runs
+-- made with "scenario_bands": true in their config, and the win probability
+-- and score envelope across them
+
+ALTER TABLE simulation_aggregates
+ADD COLUMN IF NOT EXISTS scenario_bands JSONB; -- null unless the run asked for bands
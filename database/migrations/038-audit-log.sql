@@ -0,0 +1,21 @@
+-- Audit Log
+-- Migration 038: Who did what through the gateway's state-changing and
+-- administrative endpoints. Callers are identified by the SHA-256 of their
+-- API key (NULL for anonymous requests), never the key itself. The gateway
+-- deletes entries older than its retention period.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    api_key_hash VARCHAR(64),
+    tier VARCHAR(20) NOT NULL,
+    action VARCHAR(50) NOT NULL, -- e.g. simulation.created, data.refresh_triggered
+    target VARCHAR(100),         -- ID of the affected simulation, game, job...
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    status INTEGER NOT NULL,
+    client_ip VARCHAR(45)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log(occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log(action, occurred_at);
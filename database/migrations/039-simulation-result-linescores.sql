@@ -0,0 +1,10 @@
+-- Simulation Result Linescores
+-- Migration 039: Store each simulated game's runs by inning and its score
+-- after five complete innings, so first-inning and first-five markets can be
+-- recomputed from stored results rather than only from final scores
+
+ALTER TABLE simulation_results
+ADD COLUMN IF NOT EXISTS final_state JSONB, -- written by the engine but never declared
+ADD COLUMN IF NOT EXISTS linescore JSONB, -- {"away": [runs by inning], "home": [...]}
+ADD COLUMN IF NOT EXISTS first_five_home_score INTEGER, -- null when the game ended before five innings
+ADD COLUMN IF NOT EXISTS first_five_away_score INTEGER;
@@ -0,0 +1,7 @@
+-- Simulation Effective Config
+-- Migration 040: Store the full configuration each run started with (the
+-- request's options with engine defaults filled in, rules, calibration and
+-- engine version), since the config column only holds what the client sent
+
+ALTER TABLE simulation_runs
+ADD COLUMN IF NOT EXISTS effective_config JSONB; -- null for runs started before this migration
@@ -0,0 +1,7 @@
+-- Simulation Availability Impact
+-- Migration 041: Store how each questionable player's availability moved a
+-- run's home win probability, for runs given "play_probability" in their
+-- config
+
+ALTER TABLE simulation_aggregates
+ADD COLUMN IF NOT EXISTS availability_impact JSONB; -- null unless the run had questionable players
@@ -0,0 +1,62 @@
+-- Team Branding
+-- Migration 042: Store each team's colors and logo slug so clients and
+-- generated reports can theme from the API instead of a hardcoded mapping.
+-- Colors are normalized to uppercase #RRGGBB; the logo slug names the team's
+-- logo asset. Operators maintain them through PUT /admin/teams/{id}/branding.
+
+ALTER TABLE teams
+ADD COLUMN IF NOT EXISTS primary_color VARCHAR(7),
+ADD COLUMN IF NOT EXISTS secondary_color VARCHAR(7),
+ADD COLUMN IF NOT EXISTS logo_slug VARCHAR(50),
+ADD COLUMN IF NOT EXISTS branding_updated_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_primary_color_check;
+ALTER TABLE teams ADD CONSTRAINT teams_primary_color_check
+    CHECK (primary_color IS NULL OR primary_color ~ '^#[0-9A-F]{6}$');
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_secondary_color_check;
+ALTER TABLE teams ADD CONSTRAINT teams_secondary_color_check
+    CHECK (secondary_color IS NULL OR secondary_color ~ '^#[0-9A-F]{6}$');
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_logo_slug_check;
+ALTER TABLE teams ADD CONSTRAINT teams_logo_slug_check
+    CHECK (logo_slug IS NULL OR logo_slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$');
+
+-- Defaults for the 30 MLB clubs by MLB team ID; branding already set is kept
+UPDATE teams t
+SET primary_color = b.primary_color,
+    secondary_color = b.secondary_color,
+    logo_slug = b.logo_slug,
+    branding_updated_at = NOW()
+FROM (VALUES
+    ('108', '#BA0021', '#003263', 'los-angeles-angels'),
+    ('109', '#A71930', '#E3D4AD', 'arizona-diamondbacks'),
+    ('110', '#DF4601', '#000000', 'baltimore-orioles'),
+    ('111', '#BD3039', '#0C2340', 'boston-red-sox'),
+    ('112', '#0E3386', '#CC3433', 'chicago-cubs'),
+    ('113', '#C6011F', '#000000', 'cincinnati-reds'),
+    ('114', '#00385D', '#E50022', 'cleveland-guardians'),
+    ('115', '#333366', '#C4CED4', 'colorado-rockies'),
+    ('116', '#0C2340', '#FA4616', 'detroit-tigers'),
+    ('117', '#002D62', '#EB6E1F', 'houston-astros'),
+    ('118', '#004687', '#BD9B60', 'kansas-city-royals'),
+    ('119', '#005A9C', '#EF3E42', 'los-angeles-dodgers'),
+    ('120', '#AB0003', '#14225A', 'washington-nationals'),
+    ('121', '#002D72', '#FF5910', 'new-york-mets'),
+    ('133', '#003831', '#EFB21E', 'athletics'),
+    ('134', '#27251F', '#FDB827', 'pittsburgh-pirates'),
+    ('135', '#2F241D', '#FFC425', 'san-diego-padres'),
+    ('136', '#0C2C56', '#005C5C', 'seattle-mariners'),
+    ('137', '#FD5A1E', '#27251F', 'san-francisco-giants'),
+    ('138', '#C41E3A', '#0C2340', 'st-louis-cardinals'),
+    ('139', '#092C5C', '#8FBCE6', 'tampa-bay-rays'),
+    ('140', '#003278', '#C0111F', 'texas-rangers'),
+    ('141', '#134A8E', '#1D2D5C', 'toronto-blue-jays'),
+    ('142', '#002B5C', '#D31145', 'minnesota-twins'),
+    ('143', '#E81828', '#002D72', 'philadelphia-phillies'),
+    ('144', '#CE1141', '#13274F', 'atlanta-braves'),
+    ('145', '#27251F', '#C4CED4', 'chicago-white-sox'),
+    ('146', '#00A3E0', '#EF3340', 'miami-marlins'),
+    ('147', '#003087', '#E4002C', 'new-york-yankees'),
+    ('158', '#12284B', '#FFC52F', 'milwaukee-brewers')
+) AS b(team_id, primary_color, secondary_color, logo_slug)
+WHERE t.team_id = b.team_id
+  AND t.primary_color IS NULL AND t.secondary_color IS NULL AND t.logo_slug IS NULL;
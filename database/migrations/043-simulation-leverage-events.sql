@@ -0,0 +1,43 @@
+-- Simulation Leverage Events
+-- Migration 043: High-leverage key events (leverage of at least 2.0) as rows,
+-- indexed by game, leverage and event type, so clutch moments can be queried
+-- across runs. The full key_events JSONB on simulation_results is unchanged.
+
+CREATE TABLE IF NOT EXISTS simulation_events (
+    id BIGSERIAL PRIMARY KEY,
+    run_id UUID NOT NULL REFERENCES simulation_runs(id),
+    game_id UUID REFERENCES games(id), -- copied from the run so game queries skip the join
+    simulation_number INTEGER NOT NULL,
+    event_type VARCHAR(30) NOT NULL,
+    inning INTEGER NOT NULL,
+    inning_half VARCHAR(6) NOT NULL,
+    batter_id VARCHAR(50),
+    pitcher_id VARCHAR(50),
+    result VARCHAR(50),
+    description TEXT,
+    runs INTEGER NOT NULL DEFAULT 0,
+    outs INTEGER NOT NULL DEFAULT 0,
+    leverage DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_simulation_events_game_leverage ON simulation_events(game_id, leverage DESC);
+CREATE INDEX IF NOT EXISTS idx_simulation_events_run_leverage ON simulation_events(run_id, leverage DESC);
+CREATE INDEX IF NOT EXISTS idx_simulation_events_type_leverage ON simulation_events(event_type, leverage DESC);
+CREATE INDEX IF NOT EXISTS idx_simulation_events_leverage ON simulation_events(leverage DESC);
+
+-- Backfill from results stored before this migration
+INSERT INTO simulation_events (
+    run_id, game_id, simulation_number, event_type, inning, inning_half,
+    batter_id, pitcher_id, result, description, runs, outs, leverage, created_at
+)
+SELECT sr.run_id, r.game_id, sr.simulation_number,
+       e.event->>'type', (e.event->>'inning')::int, e.event->>'inning_half',
+       e.event->>'batter_id', e.event->>'pitcher_id', e.event->>'result', e.event->>'description',
+       COALESCE((e.event->>'runs')::int, 0), COALESCE((e.event->>'outs')::int, 0),
+       (e.event->>'leverage')::float8, sr.created_at
+FROM simulation_results sr
+JOIN simulation_runs r ON r.id = sr.run_id
+CROSS JOIN LATERAL jsonb_array_elements(COALESCE(sr.key_events, '[]'::jsonb)) AS e(event)
+WHERE (e.event->>'leverage')::float8 >= 2.0
+  AND NOT EXISTS (SELECT 1 FROM simulation_events se WHERE se.run_id = sr.run_id);
@@ -0,0 +1,12 @@
+-- Simulation Weather Re-simulation
+-- Migration 044: The engine re-checks the forecast about three hours before
+-- first pitch and re-runs a game whose forecast changed enough. The earlier
+-- run is kept but points at the run that replaced it.
+
+ALTER TABLE simulation_runs
+ADD COLUMN IF NOT EXISTS weather_checked_at TIMESTAMP WITH TIME ZONE, -- when the game-time forecast was compared
+ADD COLUMN IF NOT EXISTS superseded_by UUID REFERENCES simulation_runs(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS superseded_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN IF NOT EXISTS superseded_reason TEXT; -- e.g. "temperature 64°F → 52°F"
+
+CREATE INDEX IF NOT EXISTS idx_simulation_runs_superseded_by ON simulation_runs(superseded_by);
@@ -0,0 +1,131 @@
+-- Franchises
+-- Migration 045: Group a club's historical identities (relocations and
+-- renames) under one franchise ID so season lookups and multi-season
+-- history follow the franchise rather than a single teams row. Franchise IDs
+-- are the current club's slug, e.g. washington-nationals. A first_season of
+-- NULL means the identity covers every earlier season on record; a
+-- last_season of NULL marks the current identity.
+
+CREATE TABLE IF NOT EXISTS franchise_identities (
+    id SERIAL PRIMARY KEY,
+    franchise_id VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    city VARCHAR(100),
+    abbreviation VARCHAR(5),
+    first_season INTEGER,
+    last_season INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (first_season IS NULL OR last_season IS NULL OR first_season <= last_season)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_franchise_identities_era
+ON franchise_identities(franchise_id, COALESCE(first_season, 0));
+
+CREATE INDEX IF NOT EXISTS idx_franchise_identities_name
+ON franchise_identities(name);
+
+ALTER TABLE teams
+ADD COLUMN IF NOT EXISTS franchise_id VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_teams_franchise_id ON teams(franchise_id);
+
+INSERT INTO franchise_identities (franchise_id, name, city, abbreviation, first_season, last_season)
+VALUES
+    ('arizona-diamondbacks', 'Arizona Diamondbacks', 'Phoenix', 'ARI', NULL, NULL),
+    ('athletics', 'Philadelphia Athletics', 'Philadelphia', 'PHA', NULL, 1954),
+    ('athletics', 'Kansas City Athletics', 'Kansas City', 'KCA', 1955, 1967),
+    ('athletics', 'Oakland Athletics', 'Oakland', 'OAK', 1968, 2024),
+    ('athletics', 'Athletics', 'West Sacramento', 'ATH', 2025, NULL),
+    ('atlanta-braves', 'Boston Braves', 'Boston', 'BSN', NULL, 1952),
+    ('atlanta-braves', 'Milwaukee Braves', 'Milwaukee', 'MLN', 1953, 1965),
+    ('atlanta-braves', 'Atlanta Braves', 'Atlanta', 'ATL', 1966, NULL),
+    ('baltimore-orioles', 'St. Louis Browns', 'St. Louis', 'SLB', NULL, 1953),
+    ('baltimore-orioles', 'Baltimore Orioles', 'Baltimore', 'BAL', 1954, NULL),
+    ('boston-red-sox', 'Boston Red Sox', 'Boston', 'BOS', NULL, NULL),
+    ('chicago-cubs', 'Chicago Cubs', 'Chicago', 'CHC', NULL, NULL),
+    ('chicago-white-sox', 'Chicago White Sox', 'Chicago', 'CWS', NULL, NULL),
+    ('cincinnati-reds', 'Cincinnati Reds', 'Cincinnati', 'CIN', NULL, NULL),
+    ('cleveland-guardians', 'Cleveland Indians', 'Cleveland', 'CLE', NULL, 2021),
+    ('cleveland-guardians', 'Cleveland Guardians', 'Cleveland', 'CLE', 2022, NULL),
+    ('colorado-rockies', 'Colorado Rockies', 'Denver', 'COL', NULL, NULL),
+    ('detroit-tigers', 'Detroit Tigers', 'Detroit', 'DET', NULL, NULL),
+    ('houston-astros', 'Houston Colt .45s', 'Houston', 'HOU', NULL, 1964),
+    ('houston-astros', 'Houston Astros', 'Houston', 'HOU', 1965, NULL),
+    ('kansas-city-royals', 'Kansas City Royals', 'Kansas City', 'KC', NULL, NULL),
+    ('los-angeles-angels', 'Los Angeles Angels', 'Los Angeles', 'LAA', NULL, 1964),
+    ('los-angeles-angels', 'California Angels', 'Anaheim', 'CAL', 1965, 1996),
+    ('los-angeles-angels', 'Anaheim Angels', 'Anaheim', 'ANA', 1997, 2004),
+    ('los-angeles-angels', 'Los Angeles Angels of Anaheim', 'Anaheim', 'LAA', 2005, 2015),
+    ('los-angeles-angels', 'Los Angeles Angels', 'Anaheim', 'LAA', 2016, NULL),
+    ('los-angeles-dodgers', 'Brooklyn Dodgers', 'Brooklyn', 'BRO', NULL, 1957),
+    ('los-angeles-dodgers', 'Los Angeles Dodgers', 'Los Angeles', 'LAD', 1958, NULL),
+    ('miami-marlins', 'Florida Marlins', 'Miami', 'FLA', NULL, 2011),
+    ('miami-marlins', 'Miami Marlins', 'Miami', 'MIA', 2012, NULL),
+    ('milwaukee-brewers', 'Seattle Pilots', 'Seattle', 'SEP', NULL, 1969),
+    ('milwaukee-brewers', 'Milwaukee Brewers', 'Milwaukee', 'MIL', 1970, NULL),
+    ('minnesota-twins', 'Washington Senators', 'Washington', 'WSH', NULL, 1960),
+    ('minnesota-twins', 'Minnesota Twins', 'Minneapolis', 'MIN', 1961, NULL),
+    ('new-york-mets', 'New York Mets', 'New York', 'NYM', NULL, NULL),
+    ('new-york-yankees', 'New York Highlanders', 'New York', 'NYH', NULL, 1912),
+    ('new-york-yankees', 'New York Yankees', 'New York', 'NYY', 1913, NULL),
+    ('philadelphia-phillies', 'Philadelphia Phillies', 'Philadelphia', 'PHI', NULL, NULL),
+    ('pittsburgh-pirates', 'Pittsburgh Pirates', 'Pittsburgh', 'PIT', NULL, NULL),
+    ('san-diego-padres', 'San Diego Padres', 'San Diego', 'SD', NULL, NULL),
+    ('san-francisco-giants', 'New York Giants', 'New York', 'NYG', NULL, 1957),
+    ('san-francisco-giants', 'San Francisco Giants', 'San Francisco', 'SF', 1958, NULL),
+    ('seattle-mariners', 'Seattle Mariners', 'Seattle', 'SEA', NULL, NULL),
+    ('st-louis-cardinals', 'St. Louis Cardinals', 'St. Louis', 'STL', NULL, NULL),
+    ('tampa-bay-rays', 'Tampa Bay Devil Rays', 'St. Petersburg', 'TBD', NULL, 2007),
+    ('tampa-bay-rays', 'Tampa Bay Rays', 'St. Petersburg', 'TB', 2008, NULL),
+    ('texas-rangers', 'Washington Senators', 'Washington', 'WSA', NULL, 1971),
+    ('texas-rangers', 'Texas Rangers', 'Arlington', 'TEX', 1972, NULL),
+    ('toronto-blue-jays', 'Toronto Blue Jays', 'Toronto', 'TOR', NULL, NULL),
+    ('washington-nationals', 'Montreal Expos', 'Montreal', 'MON', NULL, 2004),
+    ('washington-nationals', 'Washington Nationals', 'Washington', 'WSH', 2005, NULL)
+ON CONFLICT DO NOTHING;
+
+-- A team row joins the franchise whose identity has its name, unless the name
+-- is shared by more than one franchise (the two Washington Senators)
+CREATE OR REPLACE FUNCTION assign_team_franchise()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.franchise_id IS NULL THEN
+        SELECT MIN(fi.franchise_id) INTO NEW.franchise_id
+        FROM franchise_identities fi
+        WHERE fi.name = NEW.name
+        HAVING COUNT(DISTINCT fi.franchise_id) = 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS assign_teams_franchise ON teams;
+CREATE TRIGGER assign_teams_franchise BEFORE INSERT OR UPDATE ON teams
+    FOR EACH ROW EXECUTE FUNCTION assign_team_franchise();
+
+-- Current clubs by MLB team ID, then every other row by identity name
+UPDATE teams t
+SET franchise_id = f.franchise_id
+FROM (VALUES
+    ('108', 'los-angeles-angels'), ('109', 'arizona-diamondbacks'), ('110', 'baltimore-orioles'),
+    ('111', 'boston-red-sox'), ('112', 'chicago-cubs'), ('113', 'cincinnati-reds'),
+    ('114', 'cleveland-guardians'), ('115', 'colorado-rockies'), ('116', 'detroit-tigers'),
+    ('117', 'houston-astros'), ('118', 'kansas-city-royals'), ('119', 'los-angeles-dodgers'),
+    ('120', 'washington-nationals'), ('121', 'new-york-mets'), ('133', 'athletics'),
+    ('134', 'pittsburgh-pirates'), ('135', 'san-diego-padres'), ('136', 'seattle-mariners'),
+    ('137', 'san-francisco-giants'), ('138', 'st-louis-cardinals'), ('139', 'tampa-bay-rays'),
+    ('140', 'texas-rangers'), ('141', 'toronto-blue-jays'), ('142', 'minnesota-twins'),
+    ('143', 'philadelphia-phillies'), ('144', 'atlanta-braves'), ('145', 'chicago-white-sox'),
+    ('146', 'miami-marlins'), ('147', 'new-york-yankees'), ('158', 'milwaukee-brewers')
+) AS f(team_id, franchise_id)
+WHERE t.team_id = f.team_id AND t.franchise_id IS NULL;
+
+UPDATE teams t
+SET franchise_id = m.franchise_id
+FROM (
+    SELECT name, MIN(franchise_id) AS franchise_id
+    FROM franchise_identities
+    GROUP BY name
+    HAVING COUNT(DISTINCT franchise_id) = 1
+) m
+WHERE t.name = m.name AND t.franchise_id IS NULL;
@@ -0,0 +1,17 @@
+-- Daily Simulation Schedule
+-- Migration 046: The engine starts the daily batch itself at a configured
+-- time. Each date it owes gets one row, claimed before the batch starts so
+-- replicas don't both run it, and kept so days missed while the engine was
+-- down are caught up after a restart.
+
+CREATE TABLE IF NOT EXISTS simulation_daily_schedule_runs (
+    run_date DATE PRIMARY KEY,
+    trigger VARCHAR(20) NOT NULL, -- scheduled (today) or catch_up (a missed day)
+    status VARCHAR(20) NOT NULL, -- claimed, started, no_games, existing or failed
+    batch_id UUID REFERENCES simulation_batches(id) ON DELETE SET NULL,
+    games_count INTEGER DEFAULT 0,
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL, -- the date's configured run time
+    triggered_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
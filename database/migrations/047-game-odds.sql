@@ -0,0 +1,23 @@
+-- Game Odds
+-- Migration 047: Market moneylines and totals per game and bookmaker, from
+-- an odds provider or posted by hand. Each row is the prices at one moment;
+-- a poll that finds them unchanged adds nothing, so the last row before
+-- first pitch is the closing line.
+
+CREATE TABLE IF NOT EXISTS game_odds (
+    id BIGSERIAL PRIMARY KEY,
+    game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    source VARCHAR(50) NOT NULL, -- provider name, or manual
+    bookmaker VARCHAR(50) NOT NULL,
+    home_moneyline INTEGER, -- American odds, e.g. -150 or +130
+    away_moneyline INTEGER,
+    total_line NUMERIC(4, 1), -- whole or half runs
+    over_price INTEGER,
+    under_price INTEGER,
+    captured_at TIMESTAMP WITH TIME ZONE NOT NULL, -- when the bookmaker posted the prices
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (game_id, bookmaker, captured_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_odds_game_captured
+ON game_odds(game_id, captured_at DESC);
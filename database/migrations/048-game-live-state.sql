@@ -0,0 +1,118 @@
+-- Game Live State
+-- Migration 048: Games in progress keep their running score, inning and
+-- outs in current_* columns; final_score_home/away only ever hold the score
+-- of a finished game. Statuses are normalised to scheduled, live and
+-- completed (the loaders used to store MLB's "Final"), so stats queries that
+-- filter on status = 'completed' see every finished game and no live one.
+
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS current_score_home INTEGER,
+    ADD COLUMN IF NOT EXISTS current_score_away INTEGER,
+    ADD COLUMN IF NOT EXISTS current_inning INTEGER,
+    ADD COLUMN IF NOT EXISTS current_inning_half VARCHAR(10), -- top, middle, bottom or end
+    ADD COLUMN IF NOT EXISTS current_outs INTEGER CHECK (current_outs BETWEEN 0 AND 3),
+    ADD COLUMN IF NOT EXISTS live_updated_at TIMESTAMP WITH TIME ZONE;
+
+-- MLB's final states carry a reason, e.g. "Final: Tied" or "Completed Early:
+-- Rain", so they're matched by prefix before any score is cleared
+UPDATE games SET status = 'completed'
+WHERE LOWER(status) LIKE 'final%'
+   OR LOWER(status) LIKE 'game over%'
+   OR LOWER(status) LIKE 'completed early%';
+
+UPDATE games SET status = 'live'
+WHERE LOWER(status) IN ('in progress', 'in_progress');
+
+-- Scores stored for unfinished games were running or placeholder scores
+UPDATE games SET final_score_home = NULL, final_score_away = NULL
+WHERE status IS DISTINCT FROM 'completed'
+  AND (final_score_home IS NOT NULL OR final_score_away IS NOT NULL);
+
+ALTER TABLE games DROP CONSTRAINT IF EXISTS games_final_score_completed;
+ALTER TABLE games ADD CONSTRAINT games_final_score_completed
+    CHECK (status = 'completed' OR (final_score_home IS NULL AND final_score_away IS NULL));
+
+ALTER TABLE games_read_model
+    ADD COLUMN IF NOT EXISTS current_score_home INTEGER,
+    ADD COLUMN IF NOT EXISTS current_score_away INTEGER,
+    ADD COLUMN IF NOT EXISTS current_inning INTEGER,
+    ADD COLUMN IF NOT EXISTS current_inning_half VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS current_outs INTEGER,
+    ADD COLUMN IF NOT EXISTS live_updated_at TIMESTAMP WITH TIME ZONE;
+
+-- Rebuild the read model row for a single game, now with the live state
+CREATE OR REPLACE FUNCTION refresh_games_read_model_row(p_game_id UUID)
+RETURNS VOID AS $$
+BEGIN
+    INSERT INTO games_read_model (
+        id, game_id, season, game_type, game_date, game_time, status,
+        final_score_home, final_score_away,
+        current_score_home, current_score_away, current_inning, current_inning_half,
+        current_outs, live_updated_at,
+        home_team_id, home_team_external_id, home_team_name, home_team_city, home_team_abbr,
+        away_team_id, away_team_external_id, away_team_name, away_team_city, away_team_abbr,
+        stadium_id, stadium_name, stadium_location,
+        created_at, updated_at, refreshed_at
+    )
+    SELECT g.id, g.game_id, g.season, g.game_type, g.game_date, g.game_time, g.status,
+           g.final_score_home, g.final_score_away,
+           g.current_score_home, g.current_score_away, g.current_inning, g.current_inning_half,
+           g.current_outs, g.live_updated_at,
+           g.home_team_id, ht.team_id, ht.name, ht.city, ht.abbreviation,
+           g.away_team_id, at.team_id, at.name, at.city, at.abbreviation,
+           g.stadium_id, s.name, s.location,
+           g.created_at, g.updated_at, NOW()
+    FROM games g
+    LEFT JOIN teams ht ON g.home_team_id = ht.id
+    LEFT JOIN teams at ON g.away_team_id = at.id
+    LEFT JOIN stadiums s ON g.stadium_id = s.id
+    WHERE g.id = p_game_id
+    ON CONFLICT (id) DO UPDATE SET
+        game_id = EXCLUDED.game_id,
+        season = EXCLUDED.season,
+        game_type = EXCLUDED.game_type,
+        game_date = EXCLUDED.game_date,
+        game_time = EXCLUDED.game_time,
+        status = EXCLUDED.status,
+        final_score_home = EXCLUDED.final_score_home,
+        final_score_away = EXCLUDED.final_score_away,
+        current_score_home = EXCLUDED.current_score_home,
+        current_score_away = EXCLUDED.current_score_away,
+        current_inning = EXCLUDED.current_inning,
+        current_inning_half = EXCLUDED.current_inning_half,
+        current_outs = EXCLUDED.current_outs,
+        live_updated_at = EXCLUDED.live_updated_at,
+        home_team_id = EXCLUDED.home_team_id,
+        home_team_external_id = EXCLUDED.home_team_external_id,
+        home_team_name = EXCLUDED.home_team_name,
+        home_team_city = EXCLUDED.home_team_city,
+        home_team_abbr = EXCLUDED.home_team_abbr,
+        away_team_id = EXCLUDED.away_team_id,
+        away_team_external_id = EXCLUDED.away_team_external_id,
+        away_team_name = EXCLUDED.away_team_name,
+        away_team_city = EXCLUDED.away_team_city,
+        away_team_abbr = EXCLUDED.away_team_abbr,
+        stadium_id = EXCLUDED.stadium_id,
+        stadium_name = EXCLUDED.stadium_name,
+        stadium_location = EXCLUDED.stadium_location,
+        created_at = EXCLUDED.created_at,
+        updated_at = EXCLUDED.updated_at,
+        refreshed_at = NOW();
+END;
+$$ LANGUAGE plpgsql;
+
+-- The status and score updates above went through the sync trigger; this
+-- fills in the new columns for every other game
+UPDATE games_read_model rm SET
+    status = g.status,
+    final_score_home = g.final_score_home,
+    final_score_away = g.final_score_away,
+    current_score_home = g.current_score_home,
+    current_score_away = g.current_score_away,
+    current_inning = g.current_inning,
+    current_inning_half = g.current_inning_half,
+    current_outs = g.current_outs,
+    live_updated_at = g.live_updated_at,
+    refreshed_at = NOW()
+FROM games g
+WHERE rm.id = g.id;
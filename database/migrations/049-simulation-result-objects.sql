@@ -0,0 +1,9 @@
+-- Simulation Result Objects
+-- Migration 049: Record where a run's raw results are kept when the
+-- sim-engine writes them to S3-compatible object storage (RESULT_STORAGE=s3)
+-- as one gzipped newline-delimited JSON object per run, instead of one
+-- simulation_results row per simulation. High-leverage events are still
+-- archived to simulation_events either way.
+
+ALTER TABLE simulation_runs
+ADD COLUMN IF NOT EXISTS results_object TEXT; -- object key; null when results are simulation_results rows
@@ -0,0 +1,15 @@
+-- Audit Log Client Addresses
+-- Migration 050: Store audit_log.client_ip as INET. The gateway only writes
+-- parsed addresses now; earlier values that don't parse become NULL.
+
+CREATE OR REPLACE FUNCTION pg_temp.audit_client_inet(value TEXT)
+RETURNS INET AS $$
+BEGIN
+    RETURN value::inet;
+EXCEPTION WHEN others THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE audit_log
+    ALTER COLUMN client_ip TYPE INET USING pg_temp.audit_client_inet(client_ip);
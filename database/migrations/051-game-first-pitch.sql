@@ -0,0 +1,14 @@
+-- Game First Pitch
+-- Migration 051: The scheduled first pitch as a real instant. The data
+-- fetcher stores MLB's gameDate here; game_time is a naive local time that
+-- the MLB ingest never fills. Closing lines, closing line value and the
+-- accuracy report compare against this, and games without one count from the
+-- start of their date.
+
+ALTER TABLE games ADD COLUMN IF NOT EXISTS first_pitch_at TIMESTAMPTZ;
+
+-- Rows that only have a local game time (the demo schedule) are taken as
+-- Eastern time
+UPDATE games
+SET first_pitch_at = (game_date + game_time) AT TIME ZONE 'America/New_York'
+WHERE first_pitch_at IS NULL AND game_time IS NOT NULL;
@@ -0,0 +1,9 @@
+-- Update Time Indexes
+-- Migration 052: The gateway reads MAX(updated_at) of the tables behind each
+-- response to report how fresh its data is. These indexes let that be an
+-- index lookup instead of a scan of every row.
+
+CREATE INDEX IF NOT EXISTS idx_teams_updated_at ON teams(updated_at);
+CREATE INDEX IF NOT EXISTS idx_players_updated_at ON players(updated_at);
+CREATE INDEX IF NOT EXISTS idx_games_updated_at ON games(updated_at);
+CREATE INDEX IF NOT EXISTS idx_player_season_aggregates_last_updated ON player_season_aggregates(last_updated);
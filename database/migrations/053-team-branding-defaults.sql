@@ -0,0 +1,85 @@
+-- Team Branding Defaults
+-- Migration 053: Migration 042 seeded branding only for teams that already
+-- existed, so a team loaded afterwards had none. The defaults now live in
+-- their own table and a trigger applies them to every new team. Teams are
+-- matched by MLB team ID or by abbreviation, since the data fetcher stores
+-- the lowercase abbreviation as team_id. Operators still override them
+-- through PUT /admin/teams/{id}/branding.
+
+CREATE TABLE IF NOT EXISTS team_branding_defaults (
+    team_id VARCHAR(10) PRIMARY KEY,   -- MLB team ID
+    abbreviation VARCHAR(5) NOT NULL UNIQUE,
+    primary_color VARCHAR(7) NOT NULL,
+    secondary_color VARCHAR(7) NOT NULL,
+    logo_slug VARCHAR(50) NOT NULL
+);
+
+INSERT INTO team_branding_defaults (team_id, abbreviation, primary_color, secondary_color, logo_slug)
+VALUES
+    ('108', 'LAA', '#BA0021', '#003263', 'los-angeles-angels'),
+    ('109', 'AZ', '#A71930', '#E3D4AD', 'arizona-diamondbacks'),
+    ('110', 'BAL', '#DF4601', '#000000', 'baltimore-orioles'),
+    ('111', 'BOS', '#BD3039', '#0C2340', 'boston-red-sox'),
+    ('112', 'CHC', '#0E3386', '#CC3433', 'chicago-cubs'),
+    ('113', 'CIN', '#C6011F', '#000000', 'cincinnati-reds'),
+    ('114', 'CLE', '#00385D', '#E50022', 'cleveland-guardians'),
+    ('115', 'COL', '#333366', '#C4CED4', 'colorado-rockies'),
+    ('116', 'DET', '#0C2340', '#FA4616', 'detroit-tigers'),
+    ('117', 'HOU', '#002D62', '#EB6E1F', 'houston-astros'),
+    ('118', 'KC', '#004687', '#BD9B60', 'kansas-city-royals'),
+    ('119', 'LAD', '#005A9C', '#EF3E42', 'los-angeles-dodgers'),
+    ('120', 'WSH', '#AB0003', '#14225A', 'washington-nationals'),
+    ('121', 'NYM', '#002D72', '#FF5910', 'new-york-mets'),
+    ('133', 'ATH', '#003831', '#EFB21E', 'athletics'),
+    ('134', 'PIT', '#27251F', '#FDB827', 'pittsburgh-pirates'),
+    ('135', 'SD', '#2F241D', '#FFC425', 'san-diego-padres'),
+    ('136', 'SEA', '#0C2C56', '#005C5C', 'seattle-mariners'),
+    ('137', 'SF', '#FD5A1E', '#27251F', 'san-francisco-giants'),
+    ('138', 'STL', '#C41E3A', '#0C2340', 'st-louis-cardinals'),
+    ('139', 'TB', '#092C5C', '#8FBCE6', 'tampa-bay-rays'),
+    ('140', 'TEX', '#003278', '#C0111F', 'texas-rangers'),
+    ('141', 'TOR', '#134A8E', '#1D2D5C', 'toronto-blue-jays'),
+    ('142', 'MIN', '#002B5C', '#D31145', 'minnesota-twins'),
+    ('143', 'PHI', '#E81828', '#002D72', 'philadelphia-phillies'),
+    ('144', 'ATL', '#CE1141', '#13274F', 'atlanta-braves'),
+    ('145', 'CWS', '#27251F', '#C4CED4', 'chicago-white-sox'),
+    ('146', 'MIA', '#00A3E0', '#EF3340', 'miami-marlins'),
+    ('147', 'NYY', '#003087', '#E4002C', 'new-york-yankees'),
+    ('158', 'MIL', '#12284B', '#FFC52F', 'milwaukee-brewers')
+ON CONFLICT (team_id) DO UPDATE SET
+    abbreviation = EXCLUDED.abbreviation,
+    primary_color = EXCLUDED.primary_color,
+    secondary_color = EXCLUDED.secondary_color,
+    logo_slug = EXCLUDED.logo_slug;
+
+-- New teams without branding get their club's defaults. Updates are left
+-- alone so an operator can clear a team's branding.
+CREATE OR REPLACE FUNCTION apply_team_branding_defaults()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.primary_color IS NULL AND NEW.secondary_color IS NULL AND NEW.logo_slug IS NULL THEN
+        SELECT d.primary_color, d.secondary_color, d.logo_slug, NOW()
+        INTO NEW.primary_color, NEW.secondary_color, NEW.logo_slug, NEW.branding_updated_at
+        FROM team_branding_defaults d
+        WHERE d.team_id = NEW.team_id OR d.abbreviation = UPPER(NEW.abbreviation)
+        ORDER BY d.team_id = NEW.team_id DESC
+        LIMIT 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS teams_branding_defaults ON teams;
+CREATE TRIGGER teams_branding_defaults BEFORE INSERT ON teams
+    FOR EACH ROW EXECUTE FUNCTION apply_team_branding_defaults();
+
+-- Teams loaded since 042, or stored under their abbreviation, that still
+-- have no branding
+UPDATE teams t
+SET primary_color = d.primary_color,
+    secondary_color = d.secondary_color,
+    logo_slug = d.logo_slug,
+    branding_updated_at = NOW()
+FROM team_branding_defaults d
+WHERE (d.team_id = t.team_id OR d.abbreviation = UPPER(t.abbreviation))
+  AND t.primary_color IS NULL AND t.secondary_color IS NULL AND t.logo_slug IS NULL;
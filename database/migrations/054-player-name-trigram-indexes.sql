@@ -0,0 +1,13 @@
+-- Player Name Trigram Indexes
+-- Migration 054: Player search matches any part of a name ("acuna" finds
+-- "ronald acuna jr"), a LIKE '%...%' that the text_pattern_ops indexes from
+-- migration 018 can't serve. Trigram indexes let those substring matches use
+-- an index; the btree indexes stay for exact and prefix lookups.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_players_normalized_name_trgm
+ON players USING gin (normalized_name gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_player_aliases_normalized_trgm
+ON player_aliases USING gin (normalized_alias gin_trgm_ops);
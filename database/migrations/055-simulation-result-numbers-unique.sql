@@ -0,0 +1,14 @@
+-- Unique Simulation Result Numbers
+-- Migration 055: A result write retried after its commit went unacknowledged,
+-- or replayed from the dead-letter spill, must not store a simulation twice.
+-- Existing duplicates are removed and (run_id, simulation_number) made unique
+-- so the sim-engine's insert can skip results that are already stored.
+
+DELETE FROM simulation_results a
+USING simulation_results b
+WHERE a.run_id = b.run_id
+  AND a.simulation_number = b.simulation_number
+  AND a.id > b.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_simulation_results_run_number
+ON simulation_results(run_id, simulation_number);
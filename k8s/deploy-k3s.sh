@@ -7,13 +7,13 @@ echo "🏗️  Building Docker images for k3s..."
 
 # Build images with k3s-compatible tags
 echo "Building API Gateway..."
-cd api-gateway && docker build -t baseball-sim/api-gateway:latest .
+docker build -f api-gateway/dockerfile -t baseball-sim/api-gateway:latest .
 
 echo "Building Simulation Engine..."
-cd ../sim-engine && docker build -t baseball-sim/sim-engine:latest .
+docker build -f sim-engine/Dockerfile -t baseball-sim/sim-engine:latest .
 
 echo "Building Data Fetcher..."
-cd ../data-fetcher && docker build -t baseball-sim/data-fetcher:latest .
+cd data-fetcher && docker build -t baseball-sim/data-fetcher:latest .
 
 echo "Building Frontend..."
 cd ../frontend && docker build -t baseball-sim/frontend:latest .
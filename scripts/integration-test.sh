@@ -0,0 +1,48 @@
+#!/bin/bash
+
+# Go integration tests: starts a disposable Postgres, applies the schema,
+# migrations and seed data, then runs the api-gateway integration suite,
+# which boots the gateway in-process and builds and starts the sim-engine.
+# Extra arguments go to `go test`, e.g. -run TestIntegrationSimulationHappyPath.
+
+set -e
+
+ROOT="$(cd "$(dirname "$0")/.." && pwd)"
+COMPOSE="docker compose -f $ROOT/docker-compose.test.yml"
+
+export INTEGRATION_DB_HOST=localhost
+export INTEGRATION_DB_PORT=${INTEGRATION_DB_PORT:-55432}
+export INTEGRATION_DB_USER=baseball_user
+export INTEGRATION_DB_PASSWORD=baseball_pass
+export INTEGRATION_DB_NAME=baseball_sim_test
+
+cleanup() {
+    if [ -z "$KEEP_TEST_DB" ]; then
+        $COMPOSE down -v >/dev/null 2>&1 || true
+    fi
+}
+trap cleanup EXIT
+
+echo "🐘 Starting test database..."
+$COMPOSE up -d --wait test-database
+
+# ON_ERROR_STOP makes psql exit non-zero on the first failed statement, so a
+# broken migration or seed stops the run instead of scrolling past
+psql_test() {
+    $COMPOSE exec -T test-database psql -q -v ON_ERROR_STOP=1 -U "$INTEGRATION_DB_USER" -d "$INTEGRATION_DB_NAME" "$@"
+}
+
+# Migrations run in the same order as `deploy.sh db migrate`
+echo "📜 Applying migrations..."
+for migration in "$ROOT"/database/migrations/*.sql; do
+    psql_test -f "/migrations/$(basename "$migration")" >/dev/null
+done
+
+echo "🌱 Loading seed data..."
+for seed in "$ROOT"/database/dev-data/*.sql; do
+    psql_test -f "/seeds/$(basename "$seed")" >/dev/null
+done
+
+echo "🧪 Running integration tests..."
+cd "$ROOT/api-gateway"
+go test -tags integration -count=1 -run '^TestIntegration' "$@" .
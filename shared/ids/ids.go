@@ -0,0 +1,259 @@
+// Package ids resolves the identifiers clients send for teams, players,
+// games, umpires and stadiums (internal UUID, MLB (MLBAM) ID, team
+// abbreviation or an alias such as a Retrosheet ID) to the entity they name.
+// The API gateway and the sim engine both use it, so an ID accepted by one is
+// accepted by the other.
+package ids
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Kind is a table whose rows can be addressed by ID
+type Kind struct {
+	Name           string // entity_aliases.entity_type
+	Label          string // used in error messages
+	table          string
+	externalColumn string // MLBAM ID column
+	codeColumn     string // optional short code matched case-insensitively
+}
+
+// Entity kinds the resolver knows
+var (
+	Team    = Kind{Name: "team", Label: "Team", table: "teams", externalColumn: "team_id", codeColumn: "abbreviation"}
+	Player  = Kind{Name: "player", Label: "Player", table: "players", externalColumn: "player_id"}
+	Game    = Kind{Name: "game", Label: "Game", table: "games", externalColumn: "game_id"}
+	Umpire  = Kind{Name: "umpire", Label: "Umpire", table: "umpires", externalColumn: "umpire_id"}
+	Stadium = Kind{Name: "stadium", Label: "Stadium", table: "stadiums", externalColumn: "stadium_id"}
+)
+
+// Namespaces an identifier can be prefixed with, e.g. "retrosheet:NYA"
+const (
+	NamespaceUUID       = "uuid"
+	NamespaceMLBAM      = "mlbam"
+	NamespaceCode       = "code"
+	NamespaceRetrosheet = "retrosheet"
+)
+
+var prefixes = map[string]string{
+	"uuid":       NamespaceUUID,
+	"mlbam":      NamespaceMLBAM,
+	"mlb":        NamespaceMLBAM,
+	"code":       NamespaceCode,
+	"retrosheet": NamespaceRetrosheet,
+	"retro":      NamespaceRetrosheet,
+}
+
+// Resolved is the entity an identifier refers to
+type Resolved struct {
+	ID         string `json:"id"`          // internal UUID
+	ExternalID string `json:"external_id"` // MLBAM ID
+	MatchedBy  string `json:"matched_by"`  // namespace the identifier matched in
+}
+
+// ErrNotFound means no entity has the identifier
+var ErrNotFound = errors.New("id not found")
+
+// AmbiguousError means an unprefixed identifier matches different entities
+// in different namespaces
+type AmbiguousError struct {
+	Kind    Kind
+	Ref     string
+	Matches []Resolved
+}
+
+func (e *AmbiguousError) Error() string {
+	candidates := make([]string, len(e.Matches))
+	for i, m := range e.Matches {
+		candidates[i] = fmt.Sprintf("%s (%s)", m.ID, m.MatchedBy)
+	}
+	return fmt.Sprintf("%s ID %q is ambiguous; prefix it with mlbam: or retrosheet: (matches %s)",
+		e.Kind.Label, e.Ref, strings.Join(candidates, ", "))
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Ref is an identifier split into its namespace ("" for any) and value
+type Ref struct {
+	Namespace string
+	Value     string
+}
+
+// Parse splits off a known namespace prefix; an unknown prefix is part of
+// the value
+func Parse(raw string) Ref {
+	raw = strings.TrimSpace(raw)
+	if prefix, value, ok := strings.Cut(raw, ":"); ok {
+		if namespace, known := prefixes[strings.ToLower(prefix)]; known {
+			return Ref{Namespace: namespace, Value: strings.TrimSpace(value)}
+		}
+	}
+	return Ref{Value: raw}
+}
+
+const (
+	// DefaultTTL is how long a resolved identifier is reused. Only hits are
+	// cached.
+	DefaultTTL = 10 * time.Minute
+
+	maxCached  = 10000
+	maxMatches = 10
+)
+
+// Lookup finds every entity of a kind matching a reference
+type Lookup func(ctx context.Context, kind Kind, ref Ref) ([]Resolved, error)
+
+type cached struct {
+	resolved Resolved
+	expires  time.Time
+}
+
+// Resolver resolves identifiers, caching hits
+type Resolver struct {
+	lookup Lookup
+	ttl    time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]cached
+}
+
+// NewResolver creates a resolver reading from the pool db returns
+func NewResolver(db func() *pgxpool.Pool) *Resolver {
+	return New(DatabaseLookup(db), DefaultTTL)
+}
+
+// New creates a resolver with a custom lookup
+func New(lookup Lookup, ttl time.Duration) *Resolver {
+	return &Resolver{lookup: lookup, ttl: ttl, cache: make(map[string]cached)}
+}
+
+// Resolve returns the entity raw refers to, ErrNotFound, or an
+// *AmbiguousError listing the candidates
+func (r *Resolver) Resolve(ctx context.Context, kind Kind, raw string) (Resolved, error) {
+	ref := Parse(raw)
+	if ref.Value == "" {
+		return Resolved{}, ErrNotFound
+	}
+
+	key := kind.Name + "\x00" + ref.Namespace + "\x00" + ref.Value
+	r.mu.RLock()
+	hit, ok := r.cache[key]
+	r.mu.RUnlock()
+	if ok && time.Now().Before(hit.expires) {
+		return hit.resolved, nil
+	}
+
+	matches, err := r.lookup(ctx, kind, ref)
+	if err != nil {
+		return Resolved{}, err
+	}
+
+	// The same entity matched in two namespaces is not ambiguous
+	var distinct []Resolved
+	seen := make(map[string]bool)
+	for _, match := range matches {
+		if !seen[match.ID] {
+			seen[match.ID] = true
+			distinct = append(distinct, match)
+		}
+	}
+	switch len(distinct) {
+	case 0:
+		return Resolved{}, ErrNotFound
+	case 1:
+	default:
+		sort.Slice(distinct, func(i, j int) bool { return distinct[i].MatchedBy < distinct[j].MatchedBy })
+		return Resolved{}, &AmbiguousError{Kind: kind, Ref: raw, Matches: distinct}
+	}
+
+	r.mu.Lock()
+	if len(r.cache) >= maxCached {
+		r.cache = make(map[string]cached)
+	}
+	r.cache[key] = cached{resolved: distinct[0], expires: time.Now().Add(r.ttl)}
+	r.mu.Unlock()
+	return distinct[0], nil
+}
+
+// Clear forgets every resolved identifier, e.g. after aliases change
+func (r *Resolver) Clear() {
+	r.mu.Lock()
+	r.cache = make(map[string]cached)
+	r.mu.Unlock()
+}
+
+// DatabaseLookup matches a UUID by primary key, and anything else against
+// the MLBAM ID column, any code column and entity_aliases in one query. The
+// ID column and aliases are indexed; codes only exist on the small teams
+// table.
+func DatabaseLookup(db func() *pgxpool.Pool) Lookup {
+	return func(ctx context.Context, kind Kind, ref Ref) ([]Resolved, error) {
+		query, args := lookupQuery(kind, ref)
+		if query == "" {
+			return nil, nil
+		}
+
+		rows, err := db().Query(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s id: %w", kind.Name, err)
+		}
+		defer rows.Close()
+
+		var matches []Resolved
+		for rows.Next() {
+			var match Resolved
+			if err := rows.Scan(&match.ID, &match.ExternalID, &match.MatchedBy); err != nil {
+				return nil, fmt.Errorf("failed to resolve %s id: %w", kind.Name, err)
+			}
+			matches = append(matches, match)
+		}
+		return matches, rows.Err()
+	}
+}
+
+// lookupQuery builds the resolution query, or "" when ref can't match
+func lookupQuery(kind Kind, ref Ref) (string, []interface{}) {
+	args := []interface{}{ref.Value}
+	isUUID := uuidPattern.MatchString(ref.Value)
+	switch {
+	case ref.Namespace == NamespaceUUID && !isUUID:
+		return "", nil
+	case ref.Namespace == NamespaceUUID || (ref.Namespace == "" && isUUID):
+		return fmt.Sprintf(`SELECT id::text, %s, '%s' FROM %s WHERE id = $1::uuid`,
+			kind.externalColumn, NamespaceUUID, kind.table), args
+	}
+
+	var parts []string
+	if ref.Namespace == "" || ref.Namespace == NamespaceMLBAM {
+		parts = append(parts, fmt.Sprintf(`SELECT id::text, %s, '%s' FROM %s WHERE %s = $1`,
+			kind.externalColumn, NamespaceMLBAM, kind.table, kind.externalColumn))
+	}
+	if kind.codeColumn != "" && (ref.Namespace == "" || ref.Namespace == NamespaceCode) {
+		parts = append(parts, fmt.Sprintf(`SELECT id::text, %s, '%s' FROM %s WHERE UPPER(%s) = UPPER($1)`,
+			kind.externalColumn, NamespaceCode, kind.table, kind.codeColumn))
+	}
+	if ref.Namespace != NamespaceMLBAM && ref.Namespace != NamespaceCode {
+		alias := fmt.Sprintf(`SELECT t.id::text, t.%s, a.alias_type
+			FROM entity_aliases a JOIN %s t ON t.id = a.entity_id
+			WHERE a.entity_type = $2 AND a.alias = $1`, kind.externalColumn, kind.table)
+		args = append(args, kind.Name)
+		if ref.Namespace != "" {
+			alias += ` AND a.alias_type = $3`
+			args = append(args, ref.Namespace)
+		}
+		parts = append(parts, alias)
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+	return strings.Join(parts, " UNION ALL ") + fmt.Sprintf(" LIMIT %d", maxMatches), args
+}
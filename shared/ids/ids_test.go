@@ -0,0 +1,110 @@
+package ids
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]Ref{
+		" 745340 ":             {Value: "745340"},
+		"mlb:745340":           {Namespace: NamespaceMLBAM, Value: "745340"},
+		"RETROSHEET:NYA202404": {Namespace: NamespaceRetrosheet, Value: "NYA202404"},
+		"code:nyy":             {Namespace: NamespaceCode, Value: "nyy"},
+		"bbref:NYY":            {Value: "bbref:NYY"},
+	}
+	for raw, want := range tests {
+		if got := Parse(raw); got != want {
+			t.Errorf("Parse(%q) = %+v, want %+v", raw, got, want)
+		}
+	}
+}
+
+func TestLookupQuery(t *testing.T) {
+	const id = "3f2b8a7e-6c1d-4e5f-8a9b-0c1d2e3f4a5b"
+	if query, _ := lookupQuery(Game, Parse(id)); !strings.Contains(query, "WHERE id = $1::uuid") {
+		t.Errorf("UUID should match the primary key, got %q", query)
+	}
+	if query, _ := lookupQuery(Game, Parse("uuid:745340")); query != "" {
+		t.Errorf("a malformed uuid: reference can't match, got %q", query)
+	}
+	if query, _ := lookupQuery(Stadium, Parse("code:YS")); query != "" {
+		t.Errorf("stadiums have no code column, got %q", query)
+	}
+
+	query, args := lookupQuery(Team, Parse("NYA"))
+	for _, fragment := range []string{"team_id = $1", "UPPER(abbreviation) = UPPER($1)", "a.alias = $1"} {
+		if !strings.Contains(query, fragment) {
+			t.Errorf("query missing %q: %s", fragment, query)
+		}
+	}
+	if len(args) != 2 {
+		t.Errorf("got %d args, want 2", len(args))
+	}
+
+	query, args = lookupQuery(Team, Parse("retrosheet:NYA"))
+	if strings.Contains(query, "team_id = $1 ") || !strings.Contains(query, "a.alias_type = $3") || len(args) != 3 {
+		t.Errorf("prefixed alias should only search that alias type: %s %v", query, args)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	calls := 0
+	resolver := New(func(ctx context.Context, kind Kind, ref Ref) ([]Resolved, error) {
+		calls++
+		switch ref.Value {
+		case "745340":
+			return []Resolved{{ID: "g1", ExternalID: "745340", MatchedBy: NamespaceMLBAM}}, nil
+		case "NYA":
+			return []Resolved{
+				{ID: "t2", ExternalID: "999", MatchedBy: NamespaceRetrosheet},
+				{ID: "t1", ExternalID: "147", MatchedBy: NamespaceCode},
+				{ID: "t1", ExternalID: "147", MatchedBy: NamespaceRetrosheet},
+			}, nil
+		case "down":
+			return nil, errors.New("connection refused")
+		}
+		return nil, nil
+	}, time.Minute)
+	ctx := context.Background()
+
+	resolved, err := resolver.Resolve(ctx, Game, "745340")
+	if err != nil || resolved.ExternalID != "745340" {
+		t.Fatalf("Resolve = %+v, %v", resolved, err)
+	}
+	resolver.Resolve(ctx, Game, "745340")
+	if calls != 1 {
+		t.Errorf("hits should be cached, got %d lookups", calls)
+	}
+
+	if _, err := resolver.Resolve(ctx, Game, "1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := resolver.Resolve(ctx, Game, "down"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("lookup errors should pass through, got %v", err)
+	}
+
+	_, err = resolver.Resolve(ctx, Team, "NYA")
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected an ambiguous error, got %v", err)
+	}
+	if len(ambiguous.Matches) != 2 || ambiguous.Matches[0].MatchedBy != NamespaceCode {
+		t.Errorf("unexpected matches %+v", ambiguous.Matches)
+	}
+	if !strings.Contains(err.Error(), "prefix it with") {
+		t.Errorf("error should say how to disambiguate: %v", err)
+	}
+
+	// Misses aren't cached, and Clear forgets hits
+	calls = 0
+	resolver.Resolve(ctx, Game, "1")
+	resolver.Clear()
+	resolver.Resolve(ctx, Game, "745340")
+	if calls != 2 {
+		t.Errorf("expected a lookup for the miss and one after Clear, got %d", calls)
+	}
+}
@@ -0,0 +1,48 @@
+// Package runconfig lists the simulation run config keys the sim engine
+// reads. The API gateway rejects any other key before a run is created, and
+// the engine records one that reaches it anyway as ignored.
+package runconfig
+
+import "sort"
+
+// Run config keys
+const (
+	AsOf                = "as_of"
+	StadiumID           = "stadium_id"
+	MaxDurationSeconds  = "max_duration_seconds"
+	RainDelays          = "rain_delays"
+	ScenarioBands       = "scenario_bands"
+	PlatoonChanges      = "platoon_changes"
+	BullpenAvailability = "bullpen_availability"
+	PlayProbability     = "play_probability"
+	StarterRoles        = "starter_roles"
+)
+
+var keys = map[string]bool{
+	AsOf:                true,
+	StadiumID:           true,
+	MaxDurationSeconds:  true,
+	RainDelays:          true,
+	ScenarioBands:       true,
+	PlatoonChanges:      true,
+	BullpenAvailability: true,
+	PlayProbability:     true,
+	StarterRoles:        true,
+}
+
+// Known reports whether the engine reads key
+func Known(key string) bool {
+	return keys[key]
+}
+
+// UnknownKeys returns config's keys the engine doesn't read, sorted
+func UnknownKeys(config map[string]interface{}) []string {
+	var unknown []string
+	for key := range config {
+		if !keys[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -0,0 +1,24 @@
+package runconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnknownKeys(t *testing.T) {
+	config := map[string]interface{}{
+		StadiumID:        "15",
+		RainDelays:       true,
+		"wether_effects": true,
+		"advanced":       false,
+	}
+	if got, want := UnknownKeys(config), []string{"advanced", "wether_effects"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("UnknownKeys = %v, want %v", got, want)
+	}
+	if got := UnknownKeys(nil); got != nil {
+		t.Errorf("UnknownKeys(nil) = %v, want nil", got)
+	}
+	if !Known(MaxDurationSeconds) || Known("seed") {
+		t.Error("Known should match the engine's keys")
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"sim-engine/simulation"
+)
+
+// accuracyOutcome pairs a run's pregame projection with the game's final score
+type accuracyOutcome struct {
+	fingerprint        simulation.RunFingerprint
+	simulatedAt        time.Time
+	homeWinProbability float64
+	expectedTotal      float64
+	homeScore          int
+	awayScore          int
+}
+
+// AccuracyGroup scores the runs produced by one engine version and model
+// parameter set against actual results
+type AccuracyGroup struct {
+	EngineVersion  string    `json:"engine_version"`
+	ModelParamHash string    `json:"model_param_hash"`
+	Games          int       `json:"games"`
+	BrierScore     float64   `json:"brier_score"`
+	LogLoss        float64   `json:"log_loss"`
+	PickAccuracy   float64   `json:"pick_accuracy"`
+	TotalRunsMAE   float64   `json:"total_runs_mae"`
+	FirstRunAt     time.Time `json:"first_run_at"`
+	LastRunAt      time.Time `json:"last_run_at"`
+}
+
+// AccuracyReport compares projections to results, grouped by model fingerprint
+type AccuracyReport struct {
+	Season *int            `json:"season,omitempty"`
+	Groups []AccuracyGroup `json:"groups"`
+}
+
+// summarizeAccuracy scores outcomes per engine version and parameter hash,
+// oldest model first so shifts read in order
+func summarizeAccuracy(outcomes []accuracyOutcome) []AccuracyGroup {
+	type key struct{ version, hash string }
+	groups := make(map[key]*AccuracyGroup)
+	var order []key
+
+	for _, o := range outcomes {
+		k := key{o.fingerprint.EngineVersion, o.fingerprint.ModelParamHash}
+		group, ok := groups[k]
+		if !ok {
+			group = &AccuracyGroup{
+				EngineVersion:  k.version,
+				ModelParamHash: k.hash,
+				FirstRunAt:     o.simulatedAt,
+				LastRunAt:      o.simulatedAt,
+			}
+			groups[k] = group
+			order = append(order, k)
+		}
+
+		homeWon := 0.0
+		if o.homeScore > o.awayScore {
+			homeWon = 1
+		}
+		// Clamp so a certain-but-wrong projection doesn't make log loss infinite
+		p := math.Min(math.Max(o.homeWinProbability, 0.001), 0.999)
+
+		group.Games++
+		group.BrierScore += (o.homeWinProbability - homeWon) * (o.homeWinProbability - homeWon)
+		group.LogLoss -= homeWon*math.Log(p) + (1-homeWon)*math.Log(1-p)
+		if (o.homeWinProbability >= 0.5) == (homeWon == 1) {
+			group.PickAccuracy++
+		}
+		group.TotalRunsMAE += math.Abs(o.expectedTotal - float64(o.homeScore+o.awayScore))
+		if o.simulatedAt.Before(group.FirstRunAt) {
+			group.FirstRunAt = o.simulatedAt
+		}
+		if o.simulatedAt.After(group.LastRunAt) {
+			group.LastRunAt = o.simulatedAt
+		}
+	}
+
+	result := make([]AccuracyGroup, 0, len(order))
+	for _, k := range order {
+		group := groups[k]
+		n := float64(group.Games)
+		group.BrierScore = math.Round(group.BrierScore/n*10000) / 10000
+		group.LogLoss = math.Round(group.LogLoss/n*10000) / 10000
+		group.PickAccuracy = math.Round(group.PickAccuracy/n*10000) / 10000
+		group.TotalRunsMAE = math.Round(group.TotalRunsMAE/n*100) / 100
+		result = append(result, *group)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].FirstRunAt.Before(result[j].FirstRunAt) })
+	return result
+}
+
+// accuracyHandler scores each game's latest pregame run per model fingerprint
+// against the final score, optionally for one ?season=
+func (s *Server) accuracyHandler(w http.ResponseWriter, r *http.Request) {
+	report := AccuracyReport{Groups: []AccuracyGroup{}}
+	if seasonStr := r.URL.Query().Get("season"); seasonStr != "" {
+		season, err := strconv.Atoi(seasonStr)
+		if err != nil {
+			http.Error(w, "Invalid season parameter", http.StatusBadRequest)
+			return
+		}
+		report.Season = &season
+	}
+
+	// Only runs started before first pitch count as projections
+	rows, err := s.db.Query(r.Context(), `
+		SELECT DISTINCT ON (sr.game_id, sr.engine_version, sr.model_param_hash)
+		       sr.engine_version, COALESCE(sr.model_param_hash, ''), sr.created_at,
+		       sa.home_win_probability::float8,
+		       (sa.expected_home_score + sa.expected_away_score)::float8,
+		       g.final_score_home, g.final_score_away
+		FROM simulation_runs sr
+		JOIN simulation_aggregates sa ON sa.run_id = sr.id
+		JOIN games g ON sr.game_id = g.id
+		WHERE sr.status IN ('completed', $2)
+		  AND sr.engine_version IS NOT NULL
+		  AND g.status = 'completed'
+		  AND g.final_score_home IS NOT NULL AND g.final_score_away IS NOT NULL
+		  AND sr.created_at < COALESCE(g.first_pitch_at, g.game_date::timestamptz)
+		  AND ($1::int IS NULL OR g.season = $1)
+		ORDER BY sr.game_id, sr.engine_version, sr.model_param_hash, sr.created_at DESC
+	`, report.Season, simulation.RunStatusPartial)
+	if err != nil {
+		log.Printf("Failed to query accuracy report: %v", err)
+		http.Error(w, "Failed to build accuracy report", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	var outcomes []accuracyOutcome
+	for rows.Next() {
+		var o accuracyOutcome
+		if err := rows.Scan(&o.fingerprint.EngineVersion, &o.fingerprint.ModelParamHash, &o.simulatedAt,
+			&o.homeWinProbability, &o.expectedTotal, &o.homeScore, &o.awayScore); err != nil {
+			log.Printf("Error scanning accuracy row: %v", err)
+			continue
+		}
+		outcomes = append(outcomes, o)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to read accuracy report: %v", err)
+		http.Error(w, "Failed to build accuracy report", http.StatusInternalServerError)
+		return
+	}
+
+	report.Groups = summarizeAccuracy(outcomes)
+	writeJSON(w, report)
+}
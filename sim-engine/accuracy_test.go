@@ -0,0 +1,50 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"sim-engine/simulation"
+)
+
+func TestSummarizeAccuracy(t *testing.T) {
+	day := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	v1 := simulation.RunFingerprint{EngineVersion: "1.0.0", ModelParamHash: "aaa"}
+	v2 := simulation.RunFingerprint{EngineVersion: "1.1.0", ModelParamHash: "bbb"}
+
+	groups := summarizeAccuracy([]accuracyOutcome{
+		{fingerprint: v2, simulatedAt: day.AddDate(0, 0, 10), homeWinProbability: 0.7, expectedTotal: 9, homeScore: 5, awayScore: 3},
+		{fingerprint: v1, simulatedAt: day, homeWinProbability: 0.6, expectedTotal: 8, homeScore: 2, awayScore: 4},
+		{fingerprint: v1, simulatedAt: day.AddDate(0, 0, 1), homeWinProbability: 0.4, expectedTotal: 10, homeScore: 1, awayScore: 7},
+	})
+
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 fingerprint groups, got %d", len(groups))
+	}
+	if groups[0].EngineVersion != "1.0.0" || groups[1].EngineVersion != "1.1.0" {
+		t.Errorf("Expected groups oldest first, got %s then %s", groups[0].EngineVersion, groups[1].EngineVersion)
+	}
+
+	old := groups[0]
+	if old.Games != 2 {
+		t.Errorf("Expected 2 games for 1.0.0, got %d", old.Games)
+	}
+	// (0.36 + 0.16) / 2
+	if math.Abs(old.BrierScore-0.26) > 1e-9 {
+		t.Errorf("Expected Brier score 0.26, got %f", old.BrierScore)
+	}
+	if old.PickAccuracy != 0.5 {
+		t.Errorf("Expected pick accuracy 0.5, got %f", old.PickAccuracy)
+	}
+	// (|8-6| + |10-8|) / 2
+	if old.TotalRunsMAE != 2 {
+		t.Errorf("Expected total runs MAE 2, got %f", old.TotalRunsMAE)
+	}
+	if !old.LastRunAt.Equal(day.AddDate(0, 0, 1)) {
+		t.Errorf("Expected last run on day two, got %v", old.LastRunAt)
+	}
+	if groups[1].PickAccuracy != 1 {
+		t.Errorf("Expected 1.1.0 to pick its only game, got %f", groups[1].PickAccuracy)
+	}
+}
@@ -0,0 +1,478 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"sim-engine/models"
+	"sim-engine/simulation"
+)
+
+// BatchFilters selects the games included in a batch simulation
+type BatchFilters struct {
+	Date      string `json:"date,omitempty"`       // single day, YYYY-MM-DD
+	StartDate string `json:"start_date,omitempty"` // inclusive, YYYY-MM-DD
+	EndDate   string `json:"end_date,omitempty"`   // inclusive, YYYY-MM-DD
+	Team      string `json:"team,omitempty"`       // team UUID, MLB ID, abbreviation or alias
+	GameType  string `json:"game_type,omitempty"`  // regular, playoff, spring or a raw game_type code
+	Status    string `json:"status,omitempty"`     // defaults to scheduled
+}
+
+// BatchSimulationRequest creates simulations for every game matching the filters
+type BatchSimulationRequest struct {
+	BatchFilters
+	SimulationRuns int                    `json:"simulation_runs"`
+	Config         map[string]interface{} `json:"config,omitempty"`
+	BullpenFatigue *BullpenFatigueOptions `json:"bullpen_fatigue,omitempty"`
+}
+
+// BatchSimulationResponse is returned when a batch is created
+type BatchSimulationResponse struct {
+	BatchID        string                 `json:"batch_id"`
+	Filters        BatchFilters           `json:"filters"`
+	BullpenFatigue *BullpenFatigueOptions `json:"bullpen_fatigue,omitempty"`
+	GamesCount     int                    `json:"games_count"`
+	Simulations    []GameSimulation       `json:"simulations"`
+	StartedAt      time.Time              `json:"started_at"`
+	Message        string                 `json:"message"`
+}
+
+// BatchRunSummary is the top-line result of one child run in a batch
+type BatchRunSummary struct {
+	RunID              string   `json:"run_id"`
+	GameID             string   `json:"game_id"`
+	GameDate           string   `json:"game_date"`
+	HomeTeam           string   `json:"home_team"`
+	AwayTeam           string   `json:"away_team"`
+	Status             string   `json:"status"`
+	Progress           float64  `json:"progress"`
+	HomeWinProbability *float64 `json:"home_win_probability,omitempty"`
+	AwayWinProbability *float64 `json:"away_win_probability,omitempty"`
+	ExpectedHomeScore  *float64 `json:"expected_home_score,omitempty"`
+	ExpectedAwayScore  *float64 `json:"expected_away_score,omitempty"`
+}
+
+// BatchStatus aggregates the state of all runs in a batch
+type BatchStatus struct {
+	BatchID        string                 `json:"batch_id"`
+	Filters        BatchFilters           `json:"filters"`
+	BullpenFatigue *BullpenFatigueOptions `json:"bullpen_fatigue,omitempty"`
+	Status         string                 `json:"status"`
+	TotalRuns      int                    `json:"total_runs"`
+	StatusCounts   map[string]int         `json:"status_counts"`
+	Progress       float64                `json:"progress"`
+	CreatedAt      time.Time              `json:"created_at"`
+	Runs           []BatchRunSummary      `json:"runs"`
+}
+
+// gameTypeCodes maps friendly game_type filter values to stored codes
+var gameTypeCodes = map[string][]string{
+	"regular": {"R", "regular"},
+	"playoff": {"P", "F", "D", "L", "W", "playoff"},
+	"spring":  {"S", "spring"},
+}
+
+type batchGame struct {
+	GameID   string
+	GameDate time.Time
+	HomeTeam string
+	AwayTeam string
+}
+
+// buildBatchGamesQuery builds the game selection query for the given
+// filters. A team filter must already be resolved to its UUID.
+func buildBatchGamesQuery(filters BatchFilters) (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filters.Date != "" {
+		if filters.StartDate != "" || filters.EndDate != "" {
+			return "", nil, fmt.Errorf("date cannot be combined with start_date/end_date")
+		}
+		filters.StartDate = filters.Date
+		filters.EndDate = filters.Date
+	}
+
+	if filters.StartDate != "" {
+		start, err := time.Parse("2006-01-02", filters.StartDate)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid start_date, use YYYY-MM-DD")
+		}
+		conditions = append(conditions, "g.game_date >= $"+strconv.Itoa(argIndex))
+		args = append(args, start)
+		argIndex++
+	}
+
+	if filters.EndDate != "" {
+		end, err := time.Parse("2006-01-02", filters.EndDate)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid end_date, use YYYY-MM-DD")
+		}
+		conditions = append(conditions, "g.game_date <= $"+strconv.Itoa(argIndex))
+		args = append(args, end)
+		argIndex++
+	}
+
+	if filters.StartDate == "" && filters.EndDate == "" {
+		return "", nil, fmt.Errorf("a date or date range is required")
+	}
+
+	if filters.Team != "" {
+		idx := strconv.Itoa(argIndex)
+		conditions = append(conditions, "(g.home_team_id = $"+idx+" OR g.away_team_id = $"+idx+")")
+		args = append(args, filters.Team)
+		argIndex++
+	}
+
+	if filters.GameType != "" {
+		codes, ok := gameTypeCodes[strings.ToLower(filters.GameType)]
+		if !ok {
+			codes = []string{filters.GameType}
+		}
+		conditions = append(conditions, "g.game_type = ANY($"+strconv.Itoa(argIndex)+")")
+		args = append(args, codes)
+		argIndex++
+	}
+
+	status := filters.Status
+	if status == "" {
+		status = "scheduled"
+	}
+	conditions = append(conditions, "g.status = $"+strconv.Itoa(argIndex))
+	args = append(args, status)
+
+	query := `
+		SELECT g.game_id, g.game_date, ht.name as home_team, at.name as away_team
+		FROM games g
+		JOIN teams ht ON g.home_team_id = ht.id
+		JOIN teams at ON g.away_team_id = at.id
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY g.game_date, g.game_time`
+
+	return query, args, nil
+}
+
+// simulateBatchHandler starts simulations for all games matching the filters
+func (s *Server) simulateBatchHandler(w http.ResponseWriter, r *http.Request) {
+	var req BatchSimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if !s.validateBatchRequest(w, r, &req) {
+		return
+	}
+
+	response, err := s.startBatch(r.Context(), req)
+	if err != nil {
+		if _, ok := err.(batchFilterError); ok {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if isResolutionError(err) {
+			writeIDError(w, ids.Team, err)
+			return
+		}
+		log.Printf("Failed to start batch: %v", err)
+		http.Error(w, "Failed to start batch", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, response)
+}
+
+// validateBatchRequest resolves a batch's run count and checks its config and
+// the queue, writing the error response and returning false on failure. The
+// batch and daily endpoints share it so both start runs under the same rules.
+func (s *Server) validateBatchRequest(w http.ResponseWriter, r *http.Request, req *BatchSimulationRequest) bool {
+	simulationRuns, err := s.resolveSimulationRuns(r, req.SimulationRuns)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return false
+	}
+	req.SimulationRuns = simulationRuns
+
+	// as_of replays every game from the same date, or each from its own
+	if _, err := simulation.AsOfFromConfig(req.Config, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return false
+	}
+
+	// Questionable players sit out games across the batch
+	if _, err := simulation.PlayProbabilityFromConfig(req.Config); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return false
+	}
+
+	// Starter roles apply to every game in the batch
+	if _, err := simulation.StarterRolesFromConfig(req.Config); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return false
+	}
+
+	// A stadium override moves every game in the batch, e.g. a neutral-site series
+	if !s.validateStadiumOverride(r.Context(), w, req.Config) {
+		return false
+	}
+
+	// A batch is turned away only when the queue is already full; its runs
+	// are then admitted one at a time as room opens up
+	if backlog := s.simEngine.Backlog(); s.config.MaxQueueDepth > 0 && backlog.Runs >= s.config.MaxQueueDepth {
+		s.writeQueueFull(w, backlog)
+		return false
+	}
+
+	return true
+}
+
+// batchFilterError marks invalid filter input so handlers can return 400
+type batchFilterError struct{ error }
+
+// startBatch selects games, records the batch and launches one run per game
+func (s *Server) startBatch(ctx context.Context, req BatchSimulationRequest) (*BatchSimulationResponse, error) {
+	// The query matches the team by UUID; the stored filters keep the ID
+	// the caller sent
+	filters := req.BatchFilters
+	if filters.Team != "" {
+		team, err := s.simEngine.ResolveID(ctx, ids.Team, filters.Team)
+		if err != nil {
+			return nil, err
+		}
+		filters.Team = team.ID
+	}
+
+	query, args, err := buildBatchGamesQuery(filters)
+	if err != nil {
+		return nil, batchFilterError{err}
+	}
+
+	var fatigueRules models.BullpenFatigueRules
+	if req.BullpenFatigue != nil {
+		if fatigueRules, err = req.BullpenFatigue.rules(); err != nil {
+			return nil, batchFilterError{err}
+		}
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query games: %w", err)
+	}
+
+	var games []batchGame
+	for rows.Next() {
+		var game batchGame
+		if err := rows.Scan(&game.GameID, &game.GameDate, &game.HomeTeam, &game.AwayTeam); err != nil {
+			log.Printf("Error scanning game: %v", err)
+			continue
+		}
+		games = append(games, game)
+	}
+	rows.Close()
+
+	simulationRuns := req.SimulationRuns
+	if simulationRuns == 0 {
+		simulationRuns = s.config.SimulationRuns
+	}
+
+	batchID := uuid.New().String()
+	filtersJSON, _ := json.Marshal(req.BatchFilters)
+	configJSON, _ := json.Marshal(req.Config)
+	var fatigueJSON []byte
+	if req.BullpenFatigue != nil {
+		fatigueJSON, _ = json.Marshal(req.BullpenFatigue)
+	}
+
+	_, err = s.db.Exec(ctx, `
+		INSERT INTO simulation_batches (id, filters, config, simulation_runs, games_count, bullpen_fatigue)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, batchID, filtersJSON, configJSON, simulationRuns, len(games), fatigueJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	simulations := []GameSimulation{}
+	var created []batchRun
+	for _, game := range games {
+		runID := uuid.New().String()
+
+		_, err := s.db.Exec(ctx, `
+			INSERT INTO simulation_runs (id, game_id, config, total_runs, status, batch_id)
+			VALUES ($1, (SELECT id FROM games WHERE game_id = $2), $3, $4, 'pending', $5)
+		`, runID, game.GameID, configJSON, simulationRuns, batchID)
+
+		if err != nil {
+			log.Printf("Failed to create simulation run for game %s: %v", game.GameID, err)
+			simulations = append(simulations, GameSimulation{
+				GameID:   game.GameID,
+				HomeTeam: game.HomeTeam,
+				AwayTeam: game.AwayTeam,
+				RunID:    runID,
+				Status:   "error",
+				Error:    fmt.Sprintf("Failed to create simulation: %v", err),
+			})
+			continue
+		}
+
+		// Runs start in the background below, through the run queue
+		created = append(created, batchRun{RunID: runID, Game: game})
+
+		simulations = append(simulations, GameSimulation{
+			GameID:   game.GameID,
+			HomeTeam: game.HomeTeam,
+			AwayTeam: game.AwayTeam,
+			RunID:    runID,
+			Status:   "started",
+		})
+
+		log.Printf("Batch %s: queued simulation for game %s (%s vs %s)", batchID, game.GameID, game.AwayTeam, game.HomeTeam)
+	}
+
+	// With bullpen fatigue the runs start a day at a time
+	if req.BullpenFatigue != nil && len(created) > 0 {
+		go s.runBatchWithFatigue(batchID, created, simulationRuns, req.Config, *req.BullpenFatigue, fatigueRules)
+	} else if len(created) > 0 {
+		go s.runBatch(created, simulationRuns, req.Config)
+	}
+
+	message := fmt.Sprintf("Started simulations for %d games", len(simulations))
+	if len(games) == 0 {
+		message = "No games matched the batch filters"
+	}
+
+	return &BatchSimulationResponse{
+		BatchID:        batchID,
+		Filters:        req.BatchFilters,
+		BullpenFatigue: req.BullpenFatigue,
+		GamesCount:     len(games),
+		Simulations:    simulations,
+		StartedAt:      time.Now().UTC(),
+		Message:        message,
+	}, nil
+}
+
+// runBatch starts a batch's runs in game order, each once the run queue has
+// room for it
+func (s *Server) runBatch(runs []batchRun, simulationRuns int, runConfig map[string]interface{}) {
+	for _, run := range runs {
+		s.admitWhenRoom(run.RunID, run.Game.GameID, simulationRuns, time.Sleep)
+		go s.simEngine.RunSimulation(run.RunID, run.Game.GameID, simulationRuns, runConfig)
+	}
+}
+
+// batchStatusHandler aggregates status and top-line results of a batch's runs
+func (s *Server) batchStatusHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	batchID := vars["id"]
+
+	if _, err := uuid.Parse(batchID); err != nil {
+		http.Error(w, "Invalid batch ID", http.StatusBadRequest)
+		return
+	}
+
+	var batch BatchStatus
+	var filtersJSON, fatigueJSON []byte
+	err := s.db.QueryRow(r.Context(), `
+		SELECT id::text, filters, bullpen_fatigue, created_at FROM simulation_batches WHERE id = $1
+	`, batchID).Scan(&batch.BatchID, &filtersJSON, &fatigueJSON, &batch.CreatedAt)
+	if err != nil {
+		http.Error(w, "Batch not found", http.StatusNotFound)
+		return
+	}
+	json.Unmarshal(filtersJSON, &batch.Filters)
+	if len(fatigueJSON) > 0 {
+		batch.BullpenFatigue = &BullpenFatigueOptions{}
+		json.Unmarshal(fatigueJSON, batch.BullpenFatigue)
+	}
+
+	rows, err := s.db.Query(r.Context(), `
+		SELECT sr.id::text, g.game_id, g.game_date, ht.name, at.name,
+		       sr.status, sr.total_runs, sr.completed_runs,
+		       sa.home_win_probability, sa.away_win_probability,
+		       sa.expected_home_score, sa.expected_away_score
+		FROM simulation_runs sr
+		JOIN games g ON sr.game_id = g.id
+		JOIN teams ht ON g.home_team_id = ht.id
+		JOIN teams at ON g.away_team_id = at.id
+		LEFT JOIN simulation_aggregates sa ON sa.run_id = sr.id
+		WHERE sr.batch_id = $1
+		ORDER BY g.game_date, g.game_time
+	`, batchID)
+	if err != nil {
+		log.Printf("Failed to query batch runs: %v", err)
+		http.Error(w, "Failed to query batch runs", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	batch.StatusCounts = make(map[string]int)
+	batch.Runs = []BatchRunSummary{}
+	var totalSims, completedSims int
+
+	for rows.Next() {
+		var run BatchRunSummary
+		var gameDate time.Time
+		var total, completed int
+		if err := rows.Scan(&run.RunID, &run.GameID, &gameDate, &run.HomeTeam, &run.AwayTeam,
+			&run.Status, &total, &completed,
+			&run.HomeWinProbability, &run.AwayWinProbability,
+			&run.ExpectedHomeScore, &run.ExpectedAwayScore); err != nil {
+			log.Printf("Error scanning batch run: %v", err)
+			continue
+		}
+
+		// Prefer live in-memory progress for runs still executing
+		if status, exists := s.simEngine.GetRunStatus(run.RunID); exists && status.Status == "running" {
+			completed = status.CompletedRuns
+		}
+
+		run.GameDate = gameDate.Format("2006-01-02")
+		if total > 0 {
+			run.Progress = float64(completed) / float64(total)
+		}
+		totalSims += total
+		completedSims += completed
+
+		batch.StatusCounts[run.Status]++
+		batch.Runs = append(batch.Runs, run)
+	}
+
+	batch.TotalRuns = len(batch.Runs)
+	if totalSims > 0 {
+		batch.Progress = float64(completedSims) / float64(totalSims)
+	}
+	batch.Status = summarizeBatchStatus(batch.StatusCounts, batch.TotalRuns)
+
+	writeJSON(w, batch)
+}
+
+// summarizeBatchStatus derives an overall batch status from child run counts
+func summarizeBatchStatus(counts map[string]int, total int) string {
+	switch {
+	case total == 0:
+		return "empty"
+	case counts["completed"] == total:
+		return "completed"
+	case counts["completed"]+counts[simulation.RunStatusPartial] == total:
+		return "completed_partial"
+	case counts["failed"] == total:
+		return "failed"
+	case counts["pending"]+counts["running"] > 0:
+		return "running"
+	default:
+		return "completed_with_errors"
+	}
+}
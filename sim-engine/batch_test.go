@@ -0,0 +1,132 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sim-engine/simulation"
+)
+
+func TestBuildBatchGamesQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		filters  BatchFilters
+		wantErr  bool
+		wantArgs int
+		contains []string
+	}{
+		{
+			name:     "single date defaults to scheduled",
+			filters:  BatchFilters{Date: "2024-07-04"},
+			wantArgs: 3,
+			contains: []string{"g.game_date >= $1", "g.game_date <= $2", "g.status = $3"},
+		},
+		{
+			name:     "range with team and playoff filter",
+			filters:  BatchFilters{StartDate: "2024-10-01", EndDate: "2024-10-31", Team: "5b8c1d2e-3f4a-4b5c-8d6e-7f8091a2b3c4", GameType: "playoff"},
+			wantArgs: 5,
+			contains: []string{"(g.home_team_id = $3 OR g.away_team_id = $3)", "g.game_type = ANY($4)", "g.status = $5"},
+		},
+		{
+			name:    "missing dates",
+			filters: BatchFilters{Team: "NYY"},
+			wantErr: true,
+		},
+		{
+			name:    "date combined with range",
+			filters: BatchFilters{Date: "2024-07-04", StartDate: "2024-07-01"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid end date",
+			filters: BatchFilters{StartDate: "2024-07-01", EndDate: "07/04/2024"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := buildBatchGamesQuery(tt.filters)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got query %q", query)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(args) != tt.wantArgs {
+				t.Errorf("got %d args, want %d", len(args), tt.wantArgs)
+			}
+			for _, fragment := range tt.contains {
+				if !strings.Contains(query, fragment) {
+					t.Errorf("query missing %q:\n%s", fragment, query)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeBatchStatus(t *testing.T) {
+	tests := []struct {
+		counts map[string]int
+		total  int
+		want   string
+	}{
+		{map[string]int{}, 0, "empty"},
+		{map[string]int{"completed": 3}, 3, "completed"},
+		{map[string]int{"failed": 2}, 2, "failed"},
+		{map[string]int{"completed": 1, "running": 2}, 3, "running"},
+		{map[string]int{"completed": 2, "failed": 1}, 3, "completed_with_errors"},
+		{map[string]int{"completed": 2, "partial": 1}, 3, "completed_partial"},
+	}
+
+	for _, tt := range tests {
+		if got := summarizeBatchStatus(tt.counts, tt.total); got != tt.want {
+			t.Errorf("summarizeBatchStatus(%v, %d) = %s, want %s", tt.counts, tt.total, got, tt.want)
+		}
+	}
+}
+
+// TestDailyValidatesLikeBatch tests /simulate/daily checks config and the
+// queue the same way /simulate/batch does before starting anything
+func TestDailyValidatesLikeBatch(t *testing.T) {
+	engine := simulation.NewSimulationEngine(nil, 4, 1000)
+	s := &Server{config: &Config{SimulationRuns: 1000, MaxQueueDepth: 1}, simEngine: engine}
+
+	tests := []struct {
+		name       string
+		body       string
+		queueFull  bool
+		wantStatus int
+	}{
+		{name: "invalid as_of", body: `{"config":{"as_of":"last week"}}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "invalid play probability", body: `{"config":{"play_probability":{"660271":2}}}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "invalid starter role", body: `{"config":{"starter_roles":{"home":"closer"}}}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "negative runs", body: `{"simulation_runs":-1}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "queue full", body: `{}`, queueFull: true, wantStatus: http.StatusTooManyRequests},
+	}
+
+	handlers := map[string]http.HandlerFunc{
+		"/simulate/batch": s.simulateBatchHandler,
+		"/simulate/daily": s.simulateDailyHandler,
+	}
+	for _, tt := range tests {
+		for path, handler := range handlers {
+			t.Run(tt.name+" "+path, func(t *testing.T) {
+				if tt.queueFull {
+					engine.AdmitRun("running", "game-1", 1000, 0)
+					defer engine.ReleaseRun("running")
+				}
+				w := httptest.NewRecorder()
+				handler(w, httptest.NewRequest("POST", path, strings.NewReader(tt.body)))
+				if w.Code != tt.wantStatus {
+					t.Errorf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+				}
+			})
+		}
+	}
+}
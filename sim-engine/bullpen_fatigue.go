@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"sim-engine/models"
+	"sim-engine/simulation"
+)
+
+// Where a batch takes reliever workload from
+const (
+	// Real box scores from the days before each game
+	fatigueSourceActual = "actual"
+
+	// The batch's own earlier days, falling back to real box scores for days
+	// before the batch starts. Days are simulated one after another.
+	fatigueSourceSimulated = "simulated"
+)
+
+// BullpenFatigueOptions carry reliever workload over between a batch's games.
+// Unset rules use the engine defaults.
+type BullpenFatigueOptions struct {
+	Source               string   `json:"source"`
+	DayAfterAvailability *float64 `json:"day_after_availability,omitempty"`
+	MaxConsecutiveDays   int      `json:"max_consecutive_days,omitempty"`
+	HeavyUsagePitches    float64  `json:"heavy_usage_pitches,omitempty"`
+}
+
+// rules validates the options and fills in defaults
+func (o *BullpenFatigueOptions) rules() (models.BullpenFatigueRules, error) {
+	rules := models.DefaultBullpenFatigueRules()
+	if o.Source != fatigueSourceActual && o.Source != fatigueSourceSimulated {
+		return rules, fmt.Errorf("bullpen_fatigue.source must be %q or %q", fatigueSourceActual, fatigueSourceSimulated)
+	}
+	if o.DayAfterAvailability != nil {
+		if *o.DayAfterAvailability < 0 || *o.DayAfterAvailability > 1 {
+			return rules, fmt.Errorf("bullpen_fatigue.day_after_availability must be between 0 and 1")
+		}
+		rules.DayAfterAvailability = *o.DayAfterAvailability
+	}
+	if o.MaxConsecutiveDays != 0 {
+		if o.MaxConsecutiveDays < 1 || o.MaxConsecutiveDays > models.MaxFatigueDays {
+			return rules, fmt.Errorf("bullpen_fatigue.max_consecutive_days must be between 1 and %d", models.MaxFatigueDays)
+		}
+		rules.MaxConsecutiveDays = o.MaxConsecutiveDays
+	}
+	if o.HeavyUsagePitches != 0 {
+		if o.HeavyUsagePitches < 0 {
+			return rules, fmt.Errorf("bullpen_fatigue.heavy_usage_pitches must be positive")
+		}
+		rules.HeavyUsagePitches = o.HeavyUsagePitches
+	}
+	return rules, nil
+}
+
+// batchRun is a created run waiting to start
+type batchRun struct {
+	RunID string
+	Game  batchGame
+}
+
+// groupRunsByDate splits runs into per-day groups in date order. Runs must
+// already be sorted by date.
+func groupRunsByDate(runs []batchRun) [][]batchRun {
+	var groups [][]batchRun
+	for _, run := range runs {
+		if n := len(groups); n > 0 && sameDay(groups[n-1][0].Game.GameDate, run.Game.GameDate) {
+			groups[n-1] = append(groups[n-1], run)
+			continue
+		}
+		groups = append(groups, []batchRun{run})
+	}
+	return groups
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
+
+// mergeSimulatedUsage overlays the batch's simulated days on real usage for
+// the days before date. A simulated day replaces that day's box scores.
+func mergeSimulatedUsage(actual map[string][]models.ReliefUsage, simulated map[string]map[string]models.ReliefUsage,
+	date time.Time, days int) map[string][]models.ReliefUsage {
+
+	merged := make(map[string][]models.ReliefUsage)
+	for daysBefore := 1; daysBefore <= days; daysBefore++ {
+		day := date.AddDate(0, 0, -daysBefore).Format("2006-01-02")
+		if simulatedDay, ok := simulated[day]; ok {
+			for playerID, usage := range simulatedDay {
+				usage.DaysBefore = daysBefore
+				merged[playerID] = append(merged[playerID], usage)
+			}
+			continue
+		}
+		for playerID, appearances := range actual {
+			for _, usage := range appearances {
+				if usage.DaysBefore == daysBefore {
+					merged[playerID] = append(merged[playerID], usage)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// runBatchWithFatigue starts a batch's runs a day at a time, giving each
+// day's games the reliever availability left by the days before. With
+// simulated usage each day waits for the previous one to finish.
+func (s *Server) runBatchWithFatigue(batchID string, runs []batchRun, simulationRuns int,
+	config map[string]interface{}, options BullpenFatigueOptions, rules models.BullpenFatigueRules) {
+
+	ctx := context.Background()
+	simulated := make(map[string]map[string]models.ReliefUsage) // by date
+
+	for _, group := range groupRunsByDate(runs) {
+		date := group[0].Game.GameDate
+
+		usage, err := s.simEngine.LoadActualReliefUsage(ctx, date, rules.MaxConsecutiveDays)
+		if err != nil {
+			log.Printf("Batch %s: no real relief usage before %s: %v", batchID, date.Format("2006-01-02"), err)
+		}
+		if options.Source == fatigueSourceSimulated {
+			usage = mergeSimulatedUsage(usage, simulated, date, rules.MaxConsecutiveDays)
+		}
+
+		runConfig := config
+		if availability := models.BullpenAvailability(usage, rules); len(availability) > 0 {
+			runConfig = simulation.WithBullpenAvailability(config, availability)
+		}
+
+		var wg sync.WaitGroup
+		for _, run := range group {
+			s.admitWhenRoom(run.RunID, run.Game.GameID, simulationRuns, time.Sleep)
+			wg.Add(1)
+			go func(run batchRun) {
+				defer wg.Done()
+				s.simEngine.RunSimulation(run.RunID, run.Game.GameID, simulationRuns, runConfig)
+			}(run)
+		}
+		if options.Source != fatigueSourceSimulated {
+			continue
+		}
+		wg.Wait()
+
+		dayUsage := make(map[string]models.ReliefUsage)
+		for _, run := range group {
+			result, err := s.simEngine.GetRunResult(ctx, run.RunID)
+			if err != nil {
+				log.Printf("Batch %s: no relief usage from run %s: %v", batchID, run.RunID, err)
+				continue
+			}
+			for playerID, pitcherUsage := range simulation.SimulatedReliefUsage(result) {
+				dayUsage[playerID] = pitcherUsage
+			}
+		}
+		simulated[date.Format("2006-01-02")] = dayUsage
+	}
+}
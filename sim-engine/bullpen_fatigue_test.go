@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"sim-engine/models"
+)
+
+func TestBullpenFatigueOptionsRules(t *testing.T) {
+	rules, err := (&BullpenFatigueOptions{Source: fatigueSourceActual}).rules()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rules != models.DefaultBullpenFatigueRules() {
+		t.Errorf("unset options should use the defaults, got %+v", rules)
+	}
+
+	never := 0.0
+	rules, err = (&BullpenFatigueOptions{Source: fatigueSourceSimulated, DayAfterAvailability: &never, MaxConsecutiveDays: 3}).rules()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rules.DayAfterAvailability != 0 || rules.MaxConsecutiveDays != 3 {
+		t.Errorf("rules = %+v, want day-after 0 and 3 consecutive days", rules)
+	}
+
+	tooLikely := 1.5
+	for _, options := range []BullpenFatigueOptions{
+		{Source: "projected"},
+		{Source: fatigueSourceActual, DayAfterAvailability: &tooLikely},
+		{Source: fatigueSourceActual, MaxConsecutiveDays: models.MaxFatigueDays + 1},
+		{Source: fatigueSourceActual, HeavyUsagePitches: -5},
+	} {
+		if _, err := options.rules(); err == nil {
+			t.Errorf("expected %+v to be rejected", options)
+		}
+	}
+}
+
+func TestGroupRunsByDate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 0, 0, 0, 0, time.UTC) }
+	runs := []batchRun{
+		{RunID: "a", Game: batchGame{GameDate: day(1)}},
+		{RunID: "b", Game: batchGame{GameDate: day(1)}},
+		{RunID: "c", Game: batchGame{GameDate: day(2)}},
+	}
+
+	groups := groupRunsByDate(runs)
+	if len(groups) != 2 || len(groups[0]) != 2 || groups[1][0].RunID != "c" {
+		t.Errorf("groups = %+v, want [[a b] [c]]", groups)
+	}
+}
+
+func TestMergeSimulatedUsage(t *testing.T) {
+	date := time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)
+	actual := map[string][]models.ReliefUsage{
+		"real_yesterday": {{DaysBefore: 1, AppearanceRate: 1, Pitches: 20}},
+		"real_day_two":   {{DaysBefore: 2, AppearanceRate: 1, Pitches: 20}},
+	}
+	simulated := map[string]map[string]models.ReliefUsage{
+		"2024-07-02": {"sim_yesterday": {AppearanceRate: 0.4, Pitches: 15}},
+	}
+
+	merged := mergeSimulatedUsage(actual, simulated, date, 2)
+	if _, ok := merged["real_yesterday"]; ok {
+		t.Error("a simulated day should replace that day's box scores")
+	}
+	if got := merged["sim_yesterday"]; len(got) != 1 || got[0].DaysBefore != 1 {
+		t.Errorf("simulated usage = %+v, want one appearance the day before", got)
+	}
+	if got := merged["real_day_two"]; len(got) != 1 || got[0].DaysBefore != 2 {
+		t.Errorf("real usage before the batch should be kept, got %+v", got)
+	}
+}
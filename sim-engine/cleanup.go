@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"sim-engine/simulation"
+)
+
+// deleteSimulationHandler deletes a finished run with its results,
+// aggregates and metadata
+func (s *Server) deleteSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	err := s.simEngine.DeleteRun(r.Context(), runID)
+	switch {
+	case errors.Is(err, simulation.ErrRunNotFound):
+		http.Error(w, "Simulation run not found", http.StatusNotFound)
+	case errors.Is(err, simulation.ErrRunActive):
+		http.Error(w, "Simulation run is still in progress", http.StatusConflict)
+	case err != nil:
+		log.Printf("Failed to delete simulation run %s: %v", runID, err)
+		http.Error(w, "Failed to delete simulation run", http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// deleteSimulationsHandler deletes every finished run created before
+// ?before=YYYY-MM-DD (UTC midnight)
+func (s *Server) deleteSimulationsHandler(w http.ResponseWriter, r *http.Request) {
+	beforeStr := r.URL.Query().Get("before")
+	if beforeStr == "" {
+		http.Error(w, "before is required (YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	before, err := time.Parse("2006-01-02", beforeStr)
+	if err != nil {
+		http.Error(w, "Invalid before date, use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := s.simEngine.DeleteRunsBefore(r.Context(), before)
+	if err != nil {
+		log.Printf("Failed to delete simulation runs before %s: %v", beforeStr, err)
+		http.Error(w, "Failed to delete simulation runs", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Deleted %d simulation runs created before %s", deleted, beforeStr)
+	writeJSON(w, map[string]interface{}{"deleted": deleted, "before": beforeStr})
+}
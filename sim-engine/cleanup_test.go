@@ -0,0 +1,18 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeleteSimulationsHandlerRequiresDate(t *testing.T) {
+	s := &Server{}
+	for _, query := range []string{"", "?before=last-week", "?before=2026-13-01"} {
+		rec := httptest.NewRecorder()
+		s.deleteSimulationsHandler(rec, httptest.NewRequest(http.MethodDelete, "/simulations"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%q: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"sim-engine/simulation"
+)
+
+const (
+	defaultDailyScheduleTime   = "09:00"
+	defaultDailyCatchUpDays    = 3
+	dailyScheduleCheckInterval = time.Minute
+
+	// A failed date is retried on later checks up to this many attempts
+	dailyScheduleMaxAttempts = 3
+
+	// A date claimed this long ago without an outcome belonged to a replica
+	// that died while starting it, and may be claimed again
+	dailyScheduleClaimTimeout = 10 * time.Minute
+
+	// Dates listed by GET /simulate/daily/schedule, at least
+	dailyScheduleHistory = 14
+)
+
+// Why the scheduler started a date
+const (
+	dailyTriggerScheduled = "scheduled"
+	dailyTriggerCatchUp   = "catch_up"
+)
+
+// Outcomes recorded in simulation_daily_schedule_runs
+const (
+	dailyRunClaimed  = "claimed"
+	dailyRunStarted  = "started"
+	dailyRunNoGames  = "no_games"
+	dailyRunExisting = "existing" // a daily batch was already started by hand
+	dailyRunFailed   = "failed"
+)
+
+// DailySchedule is the time of day the engine starts the daily batch
+type DailySchedule struct {
+	Hour     int
+	Minute   int
+	Location *time.Location
+
+	// Missed days before today replayed after downtime
+	CatchUpDays int
+}
+
+// parseDailySchedule reads an "HH:MM" run time in timezone (empty for the
+// process's local zone). "off" or an empty time disables the schedule.
+func parseDailySchedule(clock, timezone string, catchUpDays int) (*DailySchedule, error) {
+	if clock == "" || strings.EqualFold(clock, "off") {
+		return nil, nil
+	}
+
+	runAt, err := time.Parse("15:04", clock)
+	if err != nil {
+		return nil, fmt.Errorf("daily schedule time must be HH:MM or off, got %q", clock)
+	}
+
+	location := time.Local
+	if timezone != "" {
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("unknown daily schedule timezone %q: %w", timezone, err)
+		}
+	}
+
+	if catchUpDays < 0 {
+		catchUpDays = 0
+	}
+	return &DailySchedule{
+		Hour:        runAt.Hour(),
+		Minute:      runAt.Minute(),
+		Location:    location,
+		CatchUpDays: catchUpDays,
+	}, nil
+}
+
+// Clock formats the run time as HH:MM
+func (d *DailySchedule) Clock() string {
+	return fmt.Sprintf("%02d:%02d", d.Hour, d.Minute)
+}
+
+// today is midnight of now's date in the schedule's zone
+func (d *DailySchedule) today(now time.Time) time.Time {
+	year, month, day := now.In(d.Location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, d.Location)
+}
+
+// runTime is when the batch for a date is due
+func (d *DailySchedule) runTime(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, d.Hour, d.Minute, 0, 0, d.Location)
+}
+
+// NextRun is the first run time after now
+func (d *DailySchedule) NextRun(now time.Time) time.Time {
+	today := d.today(now)
+	if next := d.runTime(today); next.After(now) {
+		return next
+	}
+	return d.runTime(today.AddDate(0, 0, 1))
+}
+
+// DueDates lists the dates, oldest first, whose run time has passed and
+// which the scheduler may still owe. It picks up at lastRun (see
+// resumeDailyDate), but goes back at most CatchUpDays before today. With nothing
+// recorded only today is owed, so turning the schedule on doesn't replay
+// old days.
+func (d *DailySchedule) DueDates(now, lastRun time.Time) []time.Time {
+	today := d.today(now)
+	end := today
+	if now.Before(d.runTime(today)) {
+		end = today.AddDate(0, 0, -1)
+	}
+
+	start := today
+	if !lastRun.IsZero() {
+		// lastRun is a DATE column, so only its calendar day counts
+		year, month, day := lastRun.Date()
+		start = time.Date(year, month, day, 0, 0, 0, 0, d.Location)
+		if earliest := today.AddDate(0, 0, -d.CatchUpDays); start.Before(earliest) {
+			start = earliest
+		}
+	}
+
+	var dates []time.Time
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// startDailySchedule checks for due dates every minute, starting now, so
+// a restart catches up on the days the engine missed. A nil schedule does
+// nothing.
+func (s *Server) startDailySchedule() {
+	schedule := s.config.DailySchedule
+	if schedule == nil {
+		return
+	}
+	log.Printf("Daily simulations scheduled at %s (%s), next at %s",
+		schedule.Clock(), schedule.Location, schedule.NextRun(time.Now()).Format(time.RFC3339))
+
+	go func() {
+		ticker := time.NewTicker(dailyScheduleCheckInterval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			s.runDueDailyBatches(ctx, schedule, time.Now())
+			cancel()
+			<-ticker.C
+		}
+	}()
+}
+
+// runDueDailyBatches starts the batch for every due date this replica
+// manages to claim
+func (s *Server) runDueDailyBatches(ctx context.Context, schedule *DailySchedule, now time.Time) {
+	// The newest rows cover every date in the catch-up window
+	runs, err := s.loadDailyRuns(ctx, schedule.CatchUpDays+1)
+	if err != nil {
+		log.Printf("Failed to check the daily schedule: %v", err)
+		return
+	}
+	for _, date := range schedule.DueDates(now, resumeDailyDate(runs, now)) {
+		s.runScheduledDailyBatch(ctx, schedule, date, now)
+	}
+}
+
+// retryable reports whether the scheduler may claim a recorded date again:
+// it failed with attempts to spare, or its claim was abandoned
+func (run DailyScheduleRun) retryable(now time.Time) bool {
+	switch run.Status {
+	case dailyRunFailed:
+		return run.Attempts < dailyScheduleMaxAttempts
+	case dailyRunClaimed:
+		return run.TriggeredAt.Before(now.Add(-dailyScheduleClaimTimeout))
+	}
+	return false
+}
+
+// resumeDailyDate is where the scheduler picks up among recorded runs,
+// newest first: the earliest date it may retry, or else the latest date.
+// Zero when nothing is recorded.
+func resumeDailyDate(runs []DailyScheduleRun, now time.Time) time.Time {
+	var resume time.Time
+	for i, run := range runs {
+		if i == 0 || run.retryable(now) {
+			if date, err := time.Parse("2006-01-02", run.Date); err == nil {
+				resume = date
+			}
+		}
+	}
+	return resume
+}
+
+// runScheduledDailyBatch starts one date's daily batch and records the
+// outcome. Today's batch covers its scheduled games like POST
+// /simulate/daily. Those games have been played by the time a missed day is
+// caught up, so its batch replays the completed games as of their date.
+func (s *Server) runScheduledDailyBatch(ctx context.Context, schedule *DailySchedule, date, now time.Time) {
+	day := date.Format("2006-01-02")
+	trigger := dailyTriggerScheduled
+	req := BatchSimulationRequest{BatchFilters: BatchFilters{Date: day}}
+	if date.Before(schedule.today(now)) {
+		trigger = dailyTriggerCatchUp
+		req.Status = "completed"
+		req.Config = map[string]interface{}{"as_of": simulation.AsOfGameDate}
+	}
+
+	claimed, err := s.claimDailyRun(ctx, day, trigger, schedule.runTime(date))
+	if err != nil {
+		log.Printf("Failed to claim daily simulations for %s: %v", day, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	// The digest is built from the date's latest batch, so one started by
+	// hand or by an external cron already covers the date
+	var existingID string
+	var existingGames int
+	err = s.db.QueryRow(ctx, `
+		SELECT id::text, COALESCE(games_count, 0) FROM simulation_batches
+		WHERE filters->>'date' = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, day).Scan(&existingID, &existingGames)
+	switch {
+	case err == nil:
+		s.recordDailyRun(ctx, day, dailyRunExisting, existingID, existingGames, "")
+		log.Printf("Daily simulations for %s already started as batch %s", day, existingID)
+		return
+	case err != pgx.ErrNoRows:
+		s.recordDailyRun(ctx, day, dailyRunFailed, "", 0, err.Error())
+		log.Printf("Failed to look up daily batch for %s: %v", day, err)
+		return
+	}
+
+	response, err := s.startDailyBatch(ctx, req)
+	if err != nil {
+		s.recordDailyRun(ctx, day, dailyRunFailed, "", 0, err.Error())
+		log.Printf("Failed to start %s daily simulations for %s: %v", trigger, day, err)
+		return
+	}
+
+	status := dailyRunStarted
+	if response.GamesCount == 0 {
+		status = dailyRunNoGames
+	}
+	s.recordDailyRun(ctx, day, status, response.BatchID, response.GamesCount, "")
+	log.Printf("Started %s daily simulations for %s: batch %s, %d games", trigger, day, response.BatchID, response.GamesCount)
+}
+
+// claimDailyRun takes a date for this replica. A date is free when it has no
+// row, failed with attempts to spare, or was claimed by a replica that never
+// recorded an outcome.
+func (s *Server) claimDailyRun(ctx context.Context, day, trigger string, scheduledFor time.Time) (bool, error) {
+	var claimed time.Time
+	err := s.db.QueryRow(ctx, `
+		INSERT INTO simulation_daily_schedule_runs (run_date, trigger, status, scheduled_for, triggered_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (run_date) DO UPDATE
+		SET trigger = EXCLUDED.trigger,
+		    status = EXCLUDED.status,
+		    batch_id = NULL,
+		    games_count = 0,
+		    error = NULL,
+		    attempts = simulation_daily_schedule_runs.attempts + 1,
+		    triggered_at = NOW()
+		WHERE (simulation_daily_schedule_runs.status = $5 AND simulation_daily_schedule_runs.attempts < $6)
+		   OR (simulation_daily_schedule_runs.status = $3 AND simulation_daily_schedule_runs.triggered_at < $7)
+		RETURNING run_date
+	`, day, trigger, dailyRunClaimed, scheduledFor, dailyRunFailed, dailyScheduleMaxAttempts,
+		time.Now().Add(-dailyScheduleClaimTimeout)).Scan(&claimed)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// recordDailyRun stores the outcome of a claimed date
+func (s *Server) recordDailyRun(ctx context.Context, day, status, batchID string, gamesCount int, errMsg string) {
+	_, err := s.db.Exec(ctx, `
+		UPDATE simulation_daily_schedule_runs
+		SET status = $2, batch_id = NULLIF($3, '')::uuid, games_count = $4, error = NULLIF($5, '')
+		WHERE run_date = $1
+	`, day, status, batchID, gamesCount, errMsg)
+	if err != nil {
+		log.Printf("Failed to record daily simulations for %s: %v", day, err)
+	}
+}
+
+// DailyScheduleRun is the scheduler's record of one date
+type DailyScheduleRun struct {
+	Date         string    `json:"date"`
+	Trigger      string    `json:"trigger"`
+	Status       string    `json:"status"`
+	BatchID      *string   `json:"batch_id,omitempty"`
+	GamesCount   int       `json:"games_count"`
+	Error        *string   `json:"error,omitempty"`
+	Attempts     int       `json:"attempts"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+	TriggeredAt  time.Time `json:"triggered_at"`
+}
+
+// DailyScheduleStatus reports the schedule and what it has run
+type DailyScheduleStatus struct {
+	Enabled      bool               `json:"enabled"`
+	Time         string             `json:"time,omitempty"`
+	Timezone     string             `json:"timezone,omitempty"`
+	CatchUpDays  int                `json:"catch_up_days"`
+	NextRunAt    *time.Time         `json:"next_run_at,omitempty"`
+	PendingDates []string           `json:"pending_dates"` // due dates the next check will start
+	RecentRuns   []DailyScheduleRun `json:"recent_runs"`
+}
+
+// pendingDailyDates returns the due dates not yet started, including failed
+// dates that will be retried
+func pendingDailyDates(due []time.Time, runs []DailyScheduleRun, now time.Time) []string {
+	recorded := make(map[string]DailyScheduleRun, len(runs))
+	for _, run := range runs {
+		recorded[run.Date] = run
+	}
+
+	pending := []string{}
+	for _, date := range due {
+		day := date.Format("2006-01-02")
+		run, ok := recorded[day]
+		if !ok || run.retryable(now) {
+			pending = append(pending, day)
+		}
+	}
+	return pending
+}
+
+// dailyScheduleHandler reports the daily schedule, its next run, the dates
+// still to catch up on and the dates it has run, newest first
+func (s *Server) dailyScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	schedule := s.config.DailySchedule
+
+	limit := dailyScheduleHistory
+	if schedule != nil && schedule.CatchUpDays+1 > limit {
+		limit = schedule.CatchUpDays + 1
+	}
+
+	runs, err := s.loadDailyRuns(r.Context(), limit)
+	if err != nil {
+		log.Printf("Failed to load daily schedule runs: %v", err)
+		http.Error(w, "Failed to load daily schedule", http.StatusInternalServerError)
+		return
+	}
+
+	status := DailyScheduleStatus{PendingDates: []string{}, RecentRuns: runs}
+	if schedule != nil {
+		now := time.Now()
+		nextRun := schedule.NextRun(now)
+
+		status.Enabled = true
+		status.Time = schedule.Clock()
+		status.Timezone = schedule.Location.String()
+		status.CatchUpDays = schedule.CatchUpDays
+		status.NextRunAt = &nextRun
+		status.PendingDates = pendingDailyDates(schedule.DueDates(now, resumeDailyDate(runs, now)), runs, now)
+	}
+
+	writeJSON(w, status)
+}
+
+// loadDailyRuns returns the scheduler's latest limit dates, newest first
+func (s *Server) loadDailyRuns(ctx context.Context, limit int) ([]DailyScheduleRun, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT run_date, trigger, status, batch_id::text, COALESCE(games_count, 0), error,
+		       attempts, scheduled_for, triggered_at
+		FROM simulation_daily_schedule_runs
+		ORDER BY run_date DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []DailyScheduleRun{}
+	for rows.Next() {
+		var run DailyScheduleRun
+		var runDate time.Time
+		if err := rows.Scan(&runDate, &run.Trigger, &run.Status, &run.BatchID, &run.GamesCount, &run.Error,
+			&run.Attempts, &run.ScheduledFor, &run.TriggeredAt); err != nil {
+			log.Printf("Error scanning daily schedule run: %v", err)
+			continue
+		}
+		run.Date = runDate.Format("2006-01-02")
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
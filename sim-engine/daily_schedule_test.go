@@ -0,0 +1,138 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDailySchedule(t *testing.T) {
+	schedule, err := parseDailySchedule("09:30", "America/New_York", 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if schedule.Clock() != "09:30" || schedule.Location.String() != "America/New_York" || schedule.CatchUpDays != 3 {
+		t.Errorf("Unexpected schedule %+v", schedule)
+	}
+
+	for _, clock := range []string{"", "off", "OFF"} {
+		if schedule, err := parseDailySchedule(clock, "", 3); schedule != nil || err != nil {
+			t.Errorf("Expected %q to disable the schedule, got %+v, %v", clock, schedule, err)
+		}
+	}
+
+	if _, err := parseDailySchedule("9am", "", 3); err == nil {
+		t.Error("Expected an error for a malformed time")
+	}
+	if _, err := parseDailySchedule("09:00", "Mars/Olympus_Mons", 3); err == nil {
+		t.Error("Expected an error for an unknown timezone")
+	}
+	if schedule, _ := parseDailySchedule("09:00", "UTC", -2); schedule.CatchUpDays != 0 {
+		t.Errorf("Expected negative catch-up days to clamp to 0, got %d", schedule.CatchUpDays)
+	}
+}
+
+func TestDailyScheduleNextRun(t *testing.T) {
+	eastern, _ := time.LoadLocation("America/New_York")
+	schedule := &DailySchedule{Hour: 9, Location: eastern}
+
+	// 12:30 UTC is 08:30 in New York, before today's run
+	before := time.Date(2024, 7, 4, 12, 30, 0, 0, time.UTC)
+	if got := schedule.NextRun(before); !got.Equal(time.Date(2024, 7, 4, 9, 0, 0, 0, eastern)) {
+		t.Errorf("Expected today's 09:00, got %v", got)
+	}
+
+	atRun := time.Date(2024, 7, 4, 9, 0, 0, 0, eastern)
+	if got := schedule.NextRun(atRun); !got.Equal(time.Date(2024, 7, 5, 9, 0, 0, 0, eastern)) {
+		t.Errorf("Expected tomorrow's 09:00, got %v", got)
+	}
+}
+
+func TestDailyScheduleDueDates(t *testing.T) {
+	schedule := &DailySchedule{Hour: 9, Location: time.UTC, CatchUpDays: 3}
+	now := time.Date(2024, 7, 10, 11, 0, 0, 0, time.UTC)
+	early := time.Date(2024, 7, 10, 8, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		lastRun time.Time
+		want    string
+	}{
+		{name: "nothing recorded runs only today", now: now, want: "2024-07-10"},
+		{name: "nothing recorded before the run time", now: early, want: ""},
+		{name: "today already recorded", now: now, lastRun: day(10), want: "2024-07-10"},
+		{name: "yesterday recorded", now: now, lastRun: day(9), want: "2024-07-09,2024-07-10"},
+		{name: "catches up on missed days", now: now, lastRun: day(8), want: "2024-07-08,2024-07-09,2024-07-10"},
+		{name: "catch-up is capped", now: now, lastRun: day(1), want: "2024-07-07,2024-07-08,2024-07-09,2024-07-10"},
+		{name: "today not yet due", now: early, lastRun: day(8), want: "2024-07-08,2024-07-09"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, date := range schedule.DueDates(tt.now, tt.lastRun) {
+				got = append(got, date.Format("2006-01-02"))
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, strings.Join(got, ","))
+			}
+		})
+	}
+}
+
+func TestPendingDailyDates(t *testing.T) {
+	due := []time.Time{
+		time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC),
+	}
+	runs := []DailyScheduleRun{
+		{Date: "2024-07-10", Status: dailyRunStarted, Attempts: 1},
+		{Date: "2024-07-09", Status: dailyRunFailed, Attempts: 1},
+		{Date: "2024-07-08", Status: dailyRunFailed, Attempts: dailyScheduleMaxAttempts},
+	}
+
+	got := strings.Join(pendingDailyDates(due, runs, time.Now()), ",")
+	if got != "2024-07-07,2024-07-09" {
+		t.Errorf("Expected the unrecorded and retryable dates, got %q", got)
+	}
+}
+
+func TestResumeDailyDate(t *testing.T) {
+	now := time.Date(2024, 7, 10, 11, 0, 0, 0, time.UTC)
+	schedule := &DailySchedule{Hour: 9, Location: time.UTC, CatchUpDays: 3}
+
+	if !resumeDailyDate(nil, now).IsZero() {
+		t.Error("Expected nothing to resume from with no runs")
+	}
+
+	// A failed date before later successes is still owed
+	runs := []DailyScheduleRun{
+		{Date: "2024-07-10", Status: dailyRunStarted, Attempts: 1},
+		{Date: "2024-07-09", Status: dailyRunStarted, Attempts: 1},
+		{Date: "2024-07-08", Status: dailyRunFailed, Attempts: 1},
+		{Date: "2024-07-07", Status: dailyRunFailed, Attempts: dailyScheduleMaxAttempts},
+	}
+	resume := resumeDailyDate(runs, now)
+	if want := time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC); !resume.Equal(want) {
+		t.Fatalf("Expected to resume at the retryable 2024-07-08, got %v", resume)
+	}
+	got := strings.Join(pendingDailyDates(schedule.DueDates(now, resume), runs, now), ",")
+	if got != "2024-07-08" {
+		t.Errorf("Expected the failed date to be pending, got %q", got)
+	}
+
+	// So is a date whose claim was abandoned, but not one still being started
+	runs[2] = DailyScheduleRun{Date: "2024-07-08", Status: dailyRunClaimed, Attempts: 1,
+		TriggeredAt: now.Add(-dailyScheduleClaimTimeout - time.Minute)}
+	if resume := resumeDailyDate(runs, now); resume.Day() != 8 {
+		t.Errorf("Expected to resume at the abandoned claim, got %v", resume)
+	}
+	runs[2].TriggeredAt = now.Add(-time.Minute)
+	if resume := resumeDailyDate(runs, now); resume.Day() != 10 {
+		t.Errorf("Expected to resume at the latest date, got %v", resume)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"sim-engine/simulation"
+)
+
+// deadLettersHandler reports how many failed result writes are waiting to be
+// replayed
+func (s *Server) deadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	pending, err := s.simEngine.PendingDeadLetters()
+	if err != nil {
+		log.Printf("Failed to list dead letters: %v", err)
+		http.Error(w, "Failed to list dead letters", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"dir":     s.config.DeadLetterDir,
+		"pending": pending,
+	})
+}
+
+// replayDeadLettersHandler writes spilled results into the database. A replay
+// that stops on a database error still returns 200 with the error and what
+// remains, so it can simply be retried.
+func (s *Server) replayDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 50*time.Second)
+	defer cancel()
+
+	report, err := s.simEngine.ReplayDeadLetters(ctx)
+	if err != nil {
+		log.Printf("Dead-letter replay failed: %v", err)
+		http.Error(w, "Failed to replay dead letters", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Replayed %d dead letters (%d results, %d aggregates), %d remaining",
+		report.Replayed, report.ResultsStored, report.AggregatesStored, report.Remaining)
+	writeJSON(w, report)
+}
+
+// replayDeadLettersCommand replays the dead-letter directory without starting
+// the server, for recovering spills after the database comes back
+func replayDeadLettersCommand(config *Config) error {
+	db, err := connectDB(config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	engine := simulation.NewSimulationEngine(db, config.Workers, config.SimulationRuns)
+	engine.SetDeadLetterDir(config.DeadLetterDir)
+	if err := configureResultStorage(engine, config); err != nil {
+		return err
+	}
+
+	report, err := engine.ReplayDeadLetters(context.Background())
+	if err != nil {
+		return err
+	}
+	log.Printf("Replayed %d dead letters from %s (%d results, %d aggregates), %d remaining",
+		report.Replayed, config.DeadLetterDir, report.ResultsStored, report.AggregatesStored, report.Remaining)
+	if report.Error != "" {
+		return fmt.Errorf("replay stopped early: %s", report.Error)
+	}
+	return nil
+}
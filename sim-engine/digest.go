@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"sim-engine/simulation"
+)
+
+const (
+	digestPollInterval = 5 * time.Second
+	digestMaxWait      = 3 * time.Hour
+	digestHighlights   = 3
+)
+
+// DigestGame is one game's line in the daily digest
+type DigestGame struct {
+	GameID             string   `json:"game_id"`
+	RunID              string   `json:"run_id"`
+	RunURL             string   `json:"run_url"`
+	HomeTeam           string   `json:"home_team"`
+	AwayTeam           string   `json:"away_team"`
+	Status             string   `json:"status"`
+	HomeWinProbability *float64 `json:"home_win_probability,omitempty"`
+	AwayWinProbability *float64 `json:"away_win_probability,omitempty"`
+	ExpectedHomeScore  *float64 `json:"expected_home_score,omitempty"`
+	ExpectedAwayScore  *float64 `json:"expected_away_score,omitempty"`
+	ProjectedTotal     *float64 `json:"projected_total,omitempty"`
+	Favorite           string   `json:"favorite,omitempty"`
+	FavoriteProb       float64  `json:"favorite_probability,omitempty"`
+
+	// Chance rain wipes the game out, from the forecast the run used
+	PostponementProbability *float64 `json:"postponement_probability,omitempty"`
+
+	// Season win percentages before the game, used to flag upset picks
+	homeWinPct *float64
+	awayWinPct *float64
+}
+
+// DailyDigest summarizes every simulated game on a date for the homepage
+type DailyDigest struct {
+	Date             string       `json:"date"`
+	BatchID          string       `json:"batch_id"`
+	Status           string       `json:"status"` // "complete" once stored, "in_progress" while runs finish
+	GamesCount       int          `json:"games_count"`
+	CompletedGames   int          `json:"completed_games"`
+	Games            []DigestGame `json:"games"`
+	BiggestFavorites []DigestGame `json:"biggest_favorites"`
+	UpsetPicks       []DigestGame `json:"upset_picks"`
+	HighestTotals    []DigestGame `json:"highest_totals"`
+	GeneratedAt      time.Time    `json:"generated_at"`
+}
+
+// watchDailyDigest waits for a daily batch's runs to finish and stores its digest
+func (s *Server) watchDailyDigest(batchID, date string) {
+	ctx, cancel := context.WithTimeout(context.Background(), digestMaxWait)
+	defer cancel()
+
+	ticker := time.NewTicker(digestPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Daily digest for %s not generated: batch %s did not finish in %v", date, batchID, digestMaxWait)
+			return
+		case <-ticker.C:
+		}
+
+		var unfinished int
+		err := s.db.QueryRow(ctx, `
+			SELECT COUNT(*) FROM simulation_runs
+			WHERE batch_id = $1 AND status IN ('pending', 'running')
+		`, batchID).Scan(&unfinished)
+		if err != nil {
+			log.Printf("Failed to check daily batch %s: %v", batchID, err)
+			continue
+		}
+		if unfinished > 0 {
+			continue
+		}
+
+		digest, err := s.buildDailyDigest(ctx, batchID, date)
+		if err != nil {
+			log.Printf("Failed to build daily digest for %s: %v", date, err)
+			return
+		}
+		digest.Status = "complete"
+
+		digestJSON, _ := json.Marshal(digest)
+		_, err = s.db.Exec(ctx, `
+			INSERT INTO simulation_daily_digests (digest_date, batch_id, games_count, digest, generated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (digest_date) DO UPDATE
+			SET batch_id = EXCLUDED.batch_id,
+			    games_count = EXCLUDED.games_count,
+			    digest = EXCLUDED.digest,
+			    generated_at = EXCLUDED.generated_at
+		`, date, batchID, digest.GamesCount, digestJSON, digest.GeneratedAt)
+		if err != nil {
+			log.Printf("Failed to store daily digest for %s: %v", date, err)
+			return
+		}
+
+		log.Printf("Stored daily digest for %s (%d games)", date, digest.GamesCount)
+		s.sendDigestNotifications(ctx, digest)
+		return
+	}
+}
+
+// buildDailyDigest loads a batch's runs and derives the digest highlights
+func (s *Server) buildDailyDigest(ctx context.Context, batchID, date string) (*DailyDigest, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT sr.id::text, g.game_id, ht.name, at.name, sr.status,
+		       sa.home_win_probability, sa.away_win_probability,
+		       sa.expected_home_score, sa.expected_away_score,
+		       hr.win_pct, ar.win_pct,
+		       (sr.inputs->'rain_risk'->>'postponement_probability')::float8
+		FROM simulation_runs sr
+		JOIN games g ON sr.game_id = g.id
+		JOIN teams ht ON g.home_team_id = ht.id
+		JOIN teams at ON g.away_team_id = at.id
+		LEFT JOIN simulation_aggregates sa ON sa.run_id = sr.id
+		LEFT JOIN LATERAL (
+			SELECT AVG(CASE WHEN (x.home_team_id = ht.id AND x.final_score_home > x.final_score_away)
+			                  OR (x.away_team_id = ht.id AND x.final_score_away > x.final_score_home)
+			                THEN 1.0 ELSE 0.0 END)::float8 AS win_pct
+			FROM games x
+			WHERE x.season = g.season AND x.game_date < g.game_date AND x.status = 'completed'
+			  AND (x.home_team_id = ht.id OR x.away_team_id = ht.id)
+		) hr ON true
+		LEFT JOIN LATERAL (
+			SELECT AVG(CASE WHEN (x.home_team_id = at.id AND x.final_score_home > x.final_score_away)
+			                  OR (x.away_team_id = at.id AND x.final_score_away > x.final_score_home)
+			                THEN 1.0 ELSE 0.0 END)::float8 AS win_pct
+			FROM games x
+			WHERE x.season = g.season AND x.game_date < g.game_date AND x.status = 'completed'
+			  AND (x.home_team_id = at.id OR x.away_team_id = at.id)
+		) ar ON true
+		WHERE sr.batch_id = $1
+		ORDER BY g.game_date, g.game_time
+	`, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query digest games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []DigestGame
+	for rows.Next() {
+		var game DigestGame
+		if err := rows.Scan(&game.RunID, &game.GameID, &game.HomeTeam, &game.AwayTeam, &game.Status,
+			&game.HomeWinProbability, &game.AwayWinProbability,
+			&game.ExpectedHomeScore, &game.ExpectedAwayScore,
+			&game.homeWinPct, &game.awayWinPct,
+			&game.PostponementProbability); err != nil {
+			log.Printf("Error scanning digest game: %v", err)
+			continue
+		}
+		games = append(games, game)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	digest := summarizeDigest(games)
+	digest.Date = date
+	digest.BatchID = batchID
+	return digest, nil
+}
+
+// summarizeDigest fills favorites, totals and highlight lists from game lines
+func summarizeDigest(games []DigestGame) *DailyDigest {
+	digest := &DailyDigest{
+		Games:            []DigestGame{},
+		BiggestFavorites: []DigestGame{},
+		UpsetPicks:       []DigestGame{},
+		HighestTotals:    []DigestGame{},
+		GeneratedAt:      time.Now().UTC(),
+	}
+
+	var decided []DigestGame
+	for _, game := range games {
+		game.RunURL = "/api/v1/simulations/" + game.RunID
+
+		if game.ExpectedHomeScore != nil && game.ExpectedAwayScore != nil {
+			total := *game.ExpectedHomeScore + *game.ExpectedAwayScore
+			game.ProjectedTotal = &total
+		}
+		if game.HomeWinProbability != nil && game.AwayWinProbability != nil {
+			if *game.HomeWinProbability >= *game.AwayWinProbability {
+				game.Favorite, game.FavoriteProb = game.HomeTeam, *game.HomeWinProbability
+			} else {
+				game.Favorite, game.FavoriteProb = game.AwayTeam, *game.AwayWinProbability
+			}
+			decided = append(decided, game)
+		}
+		if game.Status == "completed" || game.Status == simulation.RunStatusPartial {
+			digest.CompletedGames++
+		}
+		digest.Games = append(digest.Games, game)
+	}
+	digest.GamesCount = len(digest.Games)
+
+	sort.SliceStable(decided, func(i, j int) bool { return decided[i].FavoriteProb > decided[j].FavoriteProb })
+	for i := 0; i < len(decided) && i < digestHighlights; i++ {
+		digest.BiggestFavorites = append(digest.BiggestFavorites, decided[i])
+	}
+
+	// An upset pick is a game where the simulations favor the team with the
+	// worse record coming in, ranked by how strongly they favor it
+	for _, game := range decided {
+		if game.homeWinPct == nil || game.awayWinPct == nil || *game.homeWinPct == *game.awayWinPct {
+			continue
+		}
+		favoriteIsHome := game.Favorite == game.HomeTeam
+		if favoriteIsHome == (*game.homeWinPct < *game.awayWinPct) {
+			digest.UpsetPicks = append(digest.UpsetPicks, game)
+		}
+	}
+	if len(digest.UpsetPicks) > digestHighlights {
+		digest.UpsetPicks = digest.UpsetPicks[:digestHighlights]
+	}
+
+	var totals []DigestGame
+	for _, game := range digest.Games {
+		if game.ProjectedTotal != nil {
+			totals = append(totals, game)
+		}
+	}
+	sort.SliceStable(totals, func(i, j int) bool { return *totals[i].ProjectedTotal > *totals[j].ProjectedTotal })
+	for i := 0; i < len(totals) && i < digestHighlights; i++ {
+		digest.HighestTotals = append(digest.HighestTotals, totals[i])
+	}
+
+	return digest
+}
+
+// dailyDigestHandler returns the stored digest for a date, or a live digest
+// built from the date's latest batch while its runs are still finishing
+func (s *Server) dailyDigestHandler(w http.ResponseWriter, r *http.Request) {
+	date := mux.Vars(r)["date"]
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		http.Error(w, "Invalid date format, use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	var digestJSON []byte
+	err := s.db.QueryRow(r.Context(), `
+		SELECT digest FROM simulation_daily_digests WHERE digest_date = $1
+	`, date).Scan(&digestJSON)
+	if err == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(digestJSON)
+		return
+	}
+
+	var batchID string
+	err = s.db.QueryRow(r.Context(), `
+		SELECT id::text FROM simulation_batches
+		WHERE filters->>'date' = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, date).Scan(&batchID)
+	if err != nil {
+		http.Error(w, "No daily simulations found for this date", http.StatusNotFound)
+		return
+	}
+
+	digest, err := s.buildDailyDigest(r.Context(), batchID, date)
+	if err != nil {
+		log.Printf("Failed to build live daily digest for %s: %v", date, err)
+		http.Error(w, "Failed to build daily digest", http.StatusInternalServerError)
+		return
+	}
+	digest.Status = "in_progress"
+
+	writeJSON(w, digest)
+}
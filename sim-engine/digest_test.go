@@ -0,0 +1,67 @@
+package main
+
+import "testing"
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestSummarizeDigest(t *testing.T) {
+	games := []DigestGame{
+		{GameID: "1", RunID: "r1", HomeTeam: "Home A", AwayTeam: "Away A", Status: "completed",
+			HomeWinProbability: floatPtr(0.70), AwayWinProbability: floatPtr(0.30),
+			ExpectedHomeScore: floatPtr(5.1), ExpectedAwayScore: floatPtr(3.2),
+			homeWinPct: floatPtr(0.600), awayWinPct: floatPtr(0.450)},
+		{GameID: "2", RunID: "r2", HomeTeam: "Home B", AwayTeam: "Away B", Status: "completed",
+			HomeWinProbability: floatPtr(0.45), AwayWinProbability: floatPtr(0.55),
+			ExpectedHomeScore: floatPtr(6.0), ExpectedAwayScore: floatPtr(6.5),
+			homeWinPct: floatPtr(0.550), awayWinPct: floatPtr(0.480)},
+		{GameID: "3", RunID: "r3", HomeTeam: "Home C", AwayTeam: "Away C", Status: "running"},
+	}
+
+	digest := summarizeDigest(games)
+
+	if digest.GamesCount != 3 || digest.CompletedGames != 2 {
+		t.Errorf("Expected 3 games with 2 completed, got %d/%d", digest.GamesCount, digest.CompletedGames)
+	}
+	if digest.Games[0].RunURL != "/api/v1/simulations/r1" {
+		t.Errorf("Unexpected run URL %q", digest.Games[0].RunURL)
+	}
+
+	if len(digest.BiggestFavorites) != 2 || digest.BiggestFavorites[0].Favorite != "Home A" {
+		t.Errorf("Expected Home A as the biggest favorite, got %+v", digest.BiggestFavorites)
+	}
+
+	// Away B has the worse record but is favored
+	if len(digest.UpsetPicks) != 1 || digest.UpsetPicks[0].GameID != "2" {
+		t.Errorf("Expected game 2 as the only upset pick, got %+v", digest.UpsetPicks)
+	}
+
+	if len(digest.HighestTotals) != 2 || digest.HighestTotals[0].GameID != "2" {
+		t.Errorf("Expected game 2 to have the highest projected total, got %+v", digest.HighestTotals)
+	}
+	if digest.HighestTotals[0].ProjectedTotal == nil || *digest.HighestTotals[0].ProjectedTotal != 12.5 {
+		t.Error("Expected a projected total of 12.5")
+	}
+}
+
+func TestDigestNotification(t *testing.T) {
+	digest := summarizeDigest([]DigestGame{
+		{GameID: "1", RunID: "r1", HomeTeam: "Home A", AwayTeam: "Away A", Status: "completed",
+			HomeWinProbability: floatPtr(0.70), AwayWinProbability: floatPtr(0.30),
+			ExpectedHomeScore: floatPtr(5.1), ExpectedAwayScore: floatPtr(3.2)},
+	})
+	digest.Date = "2026-06-15"
+
+	summary := digestNotification(digest)
+	if summary.GamesCount != 1 || summary.DigestURL != "/api/v1/simulations/daily/2026-06-15" {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if len(summary.Favorites) != 1 || summary.Favorites[0].Favorite != "Home A" || summary.Favorites[0].Probability != 0.70 {
+		t.Errorf("Unexpected favorites %+v", summary.Favorites)
+	}
+	if len(summary.Totals) != 1 || summary.Totals[0].ProjectedTotal != 5.1+3.2 {
+		t.Errorf("Unexpected totals %+v", summary.Totals)
+	}
+	if len(summary.Upsets) != 0 {
+		t.Errorf("Expected no upsets without records, got %+v", summary.Upsets)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"sim-engine/models"
+	"sim-engine/simulation"
+)
+
+const (
+	defaultEventsLimit = 200
+	maxEventsLimit     = 5000
+)
+
+// EventsResponse lists a run's key events at or above a leverage threshold
+type EventsResponse struct {
+	RunID       string                     `json:"run_id"`
+	MinLeverage float64                    `json:"min_leverage"`
+	Limit       int                        `json:"limit"`
+	Returned    int                        `json:"returned"`
+	Source      string                     `json:"source"` // "memory", "object_storage" or "database"
+	Events      []simulation.LeverageEvent `json:"events"`
+}
+
+// simulationEventsHandler returns up to ?limit= key events with leverage of
+// at least ?min_leverage=, highest leverage first
+func (s *Server) simulationEventsHandler(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	minLeverage := simulation.HighLeverageThreshold
+	if minStr := r.URL.Query().Get("min_leverage"); minStr != "" {
+		parsed, err := strconv.ParseFloat(minStr, 64)
+		if err != nil || parsed < 0 {
+			http.Error(w, "min_leverage must be a non-negative number", http.StatusBadRequest)
+			return
+		}
+		minLeverage = parsed
+	}
+
+	limit := defaultEventsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > maxEventsLimit {
+			http.Error(w, "limit must be between 1 and 5000", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	response := EventsResponse{RunID: runID, MinLeverage: minLeverage, Limit: limit}
+
+	// Completed runs still held in memory are scanned with a bounded heap
+	if status, exists := s.simEngine.GetRunStatus(runID); exists && len(status.Results) > 0 {
+		response.Source = "memory"
+		response.Events = simulation.CollectLeverageEvents(status.Results, limit, minLeverage)
+		response.Returned = len(response.Events)
+		writeJSON(w, response)
+		return
+	}
+
+	// Runs kept in object storage are read whole and scanned the same way
+	if results, ok, err := s.simEngine.StoredResults(r.Context(), runID); ok {
+		if err != nil {
+			log.Printf("Failed to load results object for run %s: %v", runID, err)
+			http.Error(w, "Failed to load events", http.StatusInternalServerError)
+			return
+		}
+		response.Source = "object_storage"
+		response.Events = simulation.CollectLeverageEvents(results, limit, minLeverage)
+		response.Returned = len(response.Events)
+		writeJSON(w, response)
+		return
+	}
+
+	var exists bool
+	if err := s.db.QueryRow(r.Context(),
+		"SELECT EXISTS (SELECT 1 FROM simulation_runs WHERE id = $1)", runID).Scan(&exists); err != nil || !exists {
+		http.Error(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+
+	rows, err := s.db.Query(r.Context(), `
+		SELECT sr.simulation_number, e.event
+		FROM simulation_results sr
+		CROSS JOIN LATERAL jsonb_array_elements(COALESCE(sr.key_events, '[]'::jsonb)) AS e(event)
+		WHERE sr.run_id = $1 AND (e.event->>'leverage')::float8 >= $2
+		ORDER BY (e.event->>'leverage')::float8 DESC, sr.simulation_number
+		LIMIT $3
+	`, runID, minLeverage, limit)
+	if err != nil {
+		log.Printf("Failed to query simulation events: %v", err)
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	response.Events = []simulation.LeverageEvent{}
+	for rows.Next() {
+		var simNumber int
+		var eventJSON []byte
+		if err := rows.Scan(&simNumber, &eventJSON); err != nil {
+			log.Printf("Error scanning simulation event: %v", err)
+			continue
+		}
+		var event models.GameEvent
+		if err := json.Unmarshal(eventJSON, &event); err != nil {
+			continue
+		}
+		response.Events = append(response.Events, simulation.LeverageEvent{SimulationNumber: simNumber, GameEvent: event})
+	}
+
+	response.Source = "database"
+	response.Returned = len(response.Events)
+	writeJSON(w, response)
+}
+
+// ArchivedEventsResponse lists archived high-leverage events across runs
+type ArchivedEventsResponse struct {
+	GameID      string                     `json:"game_id,omitempty"`
+	RunID       string                     `json:"run_id,omitempty"`
+	EventType   string                     `json:"event_type,omitempty"`
+	Inning      int                        `json:"inning,omitempty"`
+	MinLeverage float64                    `json:"min_leverage"`
+	Limit       int                        `json:"limit"`
+	Returned    int                        `json:"returned"`
+	Events      []simulation.ArchivedEvent `json:"events"`
+}
+
+// archivedEventsHandler returns high-leverage events from every stored run,
+// filtered by ?game_id=, ?run_id=, ?event_type=, ?inning= and ?min_leverage=,
+// highest leverage first. Only events with leverage of at least
+// simulation.HighLeverageThreshold are archived, so lower thresholds are
+// rejected rather than silently returning a partial set.
+func (s *Server) archivedEventsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := simulation.EventArchiveFilter{
+		RunID:       query.Get("run_id"),
+		EventType:   query.Get("event_type"),
+		MinLeverage: simulation.HighLeverageThreshold,
+		Limit:       defaultEventsLimit,
+	}
+
+	if minStr := query.Get("min_leverage"); minStr != "" {
+		parsed, err := strconv.ParseFloat(minStr, 64)
+		if err != nil || parsed < simulation.HighLeverageThreshold {
+			http.Error(w, "min_leverage must be a number of at least 2.0", http.StatusBadRequest)
+			return
+		}
+		filter.MinLeverage = parsed
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > maxEventsLimit {
+			http.Error(w, "limit must be between 1 and 5000", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = parsed
+	}
+	if inningStr := query.Get("inning"); inningStr != "" {
+		parsed, err := strconv.Atoi(inningStr)
+		if err != nil || parsed < 1 {
+			http.Error(w, "inning must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		filter.Inning = parsed
+	}
+	if filter.RunID != "" {
+		if _, err := uuid.Parse(filter.RunID); err != nil {
+			http.Error(w, "run_id must be a UUID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	response := ArchivedEventsResponse{
+		RunID:       filter.RunID,
+		EventType:   filter.EventType,
+		Inning:      filter.Inning,
+		MinLeverage: filter.MinLeverage,
+		Limit:       filter.Limit,
+	}
+	if raw := query.Get("game_id"); raw != "" {
+		game, ok := s.resolveID(r.Context(), w, ids.Game, raw)
+		if !ok {
+			return
+		}
+		filter.GameID = game.ID
+		response.GameID = game.ID
+	}
+
+	events, err := s.simEngine.ArchivedLeverageEvents(r.Context(), filter)
+	if err != nil {
+		log.Printf("Failed to load archived events: %v", err)
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+	response.Events = events
+	response.Returned = len(events)
+	writeJSON(w, response)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"sim-engine/simulation"
+)
+
+const (
+	defaultExplainSimulations = 500
+	maxExplainSimulations     = 5000
+
+	// materialDelta is the home win probability change worth explaining
+	materialDelta = 0.02
+)
+
+var errRunNotComparable = errors.New("run has no stored inputs or results")
+
+// explainRun is one side of a run comparison
+type explainRun struct {
+	RunID              string    `json:"run_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	HomeWinProbability float64   `json:"home_win_probability"`
+	EngineVersion      string    `json:"engine_version"`
+	ModelParamHash     string    `json:"model_param_hash"`
+
+	gameID string
+	inputs simulation.RunInputs
+}
+
+// ExplainResponse attributes the change between two runs of the same game
+type ExplainResponse struct {
+	GameID      string                       `json:"game_id"`
+	Baseline    explainRun                   `json:"baseline"`
+	Current     explainRun                   `json:"current"`
+	Delta       float64                      `json:"home_win_probability_delta"`
+	Material    bool                         `json:"material"`
+	Explanation *simulation.DeltaExplanation `json:"explanation"`
+	Unexplained float64                      `json:"unexplained_delta"`
+}
+
+// simulationExplainHandler explains why a run's home win probability differs
+// from an earlier run of the same game. ?baseline= picks the run to compare
+// against, defaulting to the most recent earlier finished run.
+func (s *Server) simulationExplainHandler(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	simulations := defaultExplainSimulations
+	if simStr := r.URL.Query().Get("simulations"); simStr != "" {
+		parsed, err := strconv.Atoi(simStr)
+		if err != nil || parsed < 100 || parsed > maxExplainSimulations {
+			http.Error(w, "simulations must be between 100 and 5000", http.StatusBadRequest)
+			return
+		}
+		simulations = parsed
+	}
+
+	current, err := s.loadExplainRun(r.Context(), runID)
+	if err != nil {
+		if errors.Is(err, errRunNotComparable) {
+			http.Error(w, "Simulation has no stored inputs to compare; re-run it to enable explanations", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+
+	baselineID := r.URL.Query().Get("baseline")
+	if baselineID == "" {
+		err := s.db.QueryRow(r.Context(), `
+			SELECT prev.id::text
+			FROM simulation_runs cur
+			JOIN simulation_runs prev ON prev.game_id = cur.game_id
+			WHERE cur.id = $1 AND prev.id <> cur.id
+			  AND prev.created_at < cur.created_at
+			  AND prev.status IN ('completed', $2)
+			  AND prev.inputs IS NOT NULL
+			ORDER BY prev.created_at DESC
+			LIMIT 1
+		`, runID, simulation.RunStatusPartial).Scan(&baselineID)
+		if err != nil {
+			http.Error(w, "No earlier run of this game to compare against", http.StatusNotFound)
+			return
+		}
+	}
+
+	baseline, err := s.loadExplainRun(r.Context(), baselineID)
+	if err != nil {
+		if errors.Is(err, errRunNotComparable) {
+			http.Error(w, "Baseline simulation has no stored inputs to compare", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Baseline simulation not found", http.StatusNotFound)
+		return
+	}
+	if baseline.gameID != current.gameID {
+		http.Error(w, "Baseline and current runs are for different games", http.StatusBadRequest)
+		return
+	}
+
+	explanation, err := s.simEngine.ExplainDelta(r.Context(), current.gameID, baseline.inputs, current.inputs, simulations)
+	if err != nil {
+		log.Printf("Failed to explain run %s against %s: %v", runID, baselineID, err)
+		http.Error(w, "Failed to explain simulation change", http.StatusInternalServerError)
+		return
+	}
+
+	delta := math.Round((current.HomeWinProbability-baseline.HomeWinProbability)*10000) / 10000
+	writeJSON(w, ExplainResponse{
+		GameID:      current.gameID,
+		Baseline:    *baseline,
+		Current:     *current,
+		Delta:       delta,
+		Material:    math.Abs(delta) >= materialDelta,
+		Explanation: explanation,
+		Unexplained: math.Round((delta-explanation.ExplainedDelta)*10000) / 10000,
+	})
+}
+
+// loadExplainRun loads a finished run's inputs and home win probability
+func (s *Server) loadExplainRun(ctx context.Context, runID string) (*explainRun, error) {
+	run := &explainRun{RunID: runID}
+	var status string
+	var inputsJSON []byte
+	var homeWinProb *float64
+
+	err := s.db.QueryRow(ctx, `
+		SELECT g.game_id, sr.status, sr.created_at, sr.inputs, sa.home_win_probability::float8
+		FROM simulation_runs sr
+		JOIN games g ON sr.game_id = g.id
+		LEFT JOIN simulation_aggregates sa ON sa.run_id = sr.id
+		WHERE sr.id = $1
+	`, runID).Scan(&run.gameID, &status, &run.CreatedAt, &inputsJSON, &homeWinProb)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputsJSON) == 0 || homeWinProb == nil ||
+		(status != "completed" && status != simulation.RunStatusPartial) {
+		return nil, errRunNotComparable
+	}
+	if err := json.Unmarshal(inputsJSON, &run.inputs); err != nil {
+		return nil, errRunNotComparable
+	}
+
+	run.HomeWinProbability = *homeWinProb
+	run.EngineVersion = run.inputs.EngineVersion
+	run.ModelParamHash = run.inputs.ModelParamHash
+	return run, nil
+}
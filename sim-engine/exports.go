@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+
+	"sim-engine/exports"
+	"sim-engine/models"
+)
+
+// ExportRequest starts an export. simulation_results needs run_id and
+// season_simulations needs season.
+type ExportRequest struct {
+	Kind   string `json:"kind"`
+	RunID  string `json:"run_id,omitempty"`
+	Season int    `json:"season,omitempty"`
+}
+
+// exportProgressEvery is how many rows are written between progress updates
+const exportProgressEvery = 500
+
+// createExportHandler queues an export and returns the job for polling
+func (s *Server) createExportHandler(w http.ResponseWriter, r *http.Request) {
+	var req ExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var job exports.Job
+	switch req.Kind {
+	case exports.KindSimulationResults:
+		if req.RunID == "" {
+			http.Error(w, "run_id is required", http.StatusUnprocessableEntity)
+			return
+		}
+		var status string
+		err := s.db.QueryRow(r.Context(),
+			`SELECT COALESCE(status, '') FROM simulation_runs WHERE id::text = $1`, req.RunID).Scan(&status)
+		if err == pgx.ErrNoRows {
+			http.Error(w, "Simulation run not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Printf("Database error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if status == "pending" || status == "running" {
+			http.Error(w, "Simulation run is still in progress", http.StatusConflict)
+			return
+		}
+		job = s.exports.Start(req.Kind, map[string]string{"run_id": req.RunID}, s.writeSimulationResultsExport(req.RunID))
+
+	case exports.KindSeasonSimulations:
+		if req.Season < 1876 {
+			http.Error(w, "season is required", http.StatusUnprocessableEntity)
+			return
+		}
+		job = s.exports.Start(req.Kind, map[string]string{"season": strconv.Itoa(req.Season)}, s.writeSeasonSimulationsExport(req.Season))
+
+	default:
+		http.Error(w, fmt.Sprintf("kind must be %s or %s", exports.KindSimulationResults, exports.KindSeasonSimulations),
+			http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, job)
+}
+
+// exportStatusHandler reports an export's progress and, once it completes,
+// a signed download URL
+func (s *Server) exportStatusHandler(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.exports.Get(mux.Vars(r)["id"])
+	if !ok {
+		http.Error(w, "Export not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, job)
+}
+
+// downloadExportHandler serves a finished artifact to anyone holding a valid
+// signed URL
+func (s *Server) downloadExportHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	file, job, err := s.exports.Open(mux.Vars(r)["id"], query.Get("expires"), query.Get("signature"))
+	switch {
+	case errors.Is(err, exports.ErrInvalidSignature), errors.Is(err, exports.ErrLinkExpired):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case errors.Is(err, exports.ErrNotFound):
+		http.Error(w, "Export not found", http.StatusNotFound)
+		return
+	case errors.Is(err, exports.ErrNotReady):
+		http.Error(w, "Export is not finished", http.StatusConflict)
+		return
+	case err != nil:
+		log.Printf("Failed to open export: %v", err)
+		http.Error(w, "Failed to open export", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	// Large artifacts take longer to send than the server's write timeout
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, job.Filename()))
+	w.Header().Set("Content-Length", strconv.FormatInt(job.SizeBytes, 10))
+	if _, err := io.Copy(w, file); err != nil {
+		log.Printf("Failed to send export %s: %v", job.ID, err)
+	}
+}
+
+// writeSimulationResultsExport writes every simulated game of a run, from
+// simulation_results or the run's results object
+func (s *Server) writeSimulationResultsExport(runID string) exports.WriteFunc {
+	return func(ctx context.Context, w *csv.Writer, progress func(done, total int)) (int, error) {
+		stored, ok, err := s.simEngine.StoredResults(ctx, runID)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return writeStoredResultsExport(w, runID, stored, progress), nil
+		}
+
+		var total int
+		if err := s.db.QueryRow(ctx,
+			`SELECT COUNT(*) FROM simulation_results WHERE run_id::text = $1`, runID).Scan(&total); err != nil {
+			return 0, fmt.Errorf("failed to count results: %w", err)
+		}
+
+		rows, err := s.db.Query(ctx, `
+			SELECT simulation_number, home_score, away_score, total_pitches, game_duration_minutes
+			FROM simulation_results
+			WHERE run_id::text = $1
+			ORDER BY simulation_number
+		`, runID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to query results: %w", err)
+		}
+		defer rows.Close()
+
+		w.Write(simulationResultsExportHeader)
+		written := 0
+		for rows.Next() {
+			var number, home, away int
+			var pitches, duration *int
+			if err := rows.Scan(&number, &home, &away, &pitches, &duration); err != nil {
+				return written, fmt.Errorf("failed to scan result: %w", err)
+			}
+			w.Write([]string{runID, strconv.Itoa(number), strconv.Itoa(home), strconv.Itoa(away),
+				winnerFor(home, away), formatOptionalInt(pitches), formatOptionalInt(duration)})
+			written++
+			if written%exportProgressEvery == 0 {
+				progress(written, total)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return written, fmt.Errorf("failed to read results: %w", err)
+		}
+		return written, nil
+	}
+}
+
+var simulationResultsExportHeader = []string{"run_id", "simulation_number", "home_score", "away_score", "winner",
+	"total_pitches", "game_duration_minutes"}
+
+// writeStoredResultsExport writes a run's results read from object storage,
+// already in simulation order
+func writeStoredResultsExport(w *csv.Writer, runID string, results []models.SimulationResult, progress func(done, total int)) int {
+	w.Write(simulationResultsExportHeader)
+	for i, result := range results {
+		w.Write([]string{runID, strconv.Itoa(result.SimulationNumber),
+			strconv.Itoa(result.HomeScore), strconv.Itoa(result.AwayScore),
+			winnerFor(result.HomeScore, result.AwayScore),
+			strconv.Itoa(result.TotalPitches), strconv.Itoa(result.GameDuration)})
+		if (i+1)%exportProgressEvery == 0 {
+			progress(i+1, len(results))
+		}
+	}
+	return len(results)
+}
+
+// seasonSimulationsQuery selects the latest finished run of each game in a
+// season with its aggregate
+const seasonSimulationsQuery = `
+	SELECT g.game_id, g.game_date, COALESCE(g.game_type, ''), at.name, ht.name,
+	       sr.id::text, sr.status, sr.total_runs,
+	       sa.home_win_probability, sa.away_win_probability,
+	       sa.expected_home_score, sa.expected_away_score,
+	       g.final_score_home, g.final_score_away
+	FROM games g
+	JOIN teams ht ON g.home_team_id = ht.id
+	JOIN teams at ON g.away_team_id = at.id
+	JOIN LATERAL (
+		SELECT r.id, r.status, r.total_runs
+		FROM simulation_runs r
+		WHERE r.game_id = g.id AND r.status IN ('completed', 'partial')
+		ORDER BY r.created_at DESC
+		LIMIT 1
+	) sr ON true
+	JOIN simulation_aggregates sa ON sa.run_id = sr.id
+	WHERE g.season = $1`
+
+// writeSeasonSimulationsExport writes the latest simulation of every game
+// in a season, alongside the actual score for games already played
+func (s *Server) writeSeasonSimulationsExport(season int) exports.WriteFunc {
+	return func(ctx context.Context, w *csv.Writer, progress func(done, total int)) (int, error) {
+		var total int
+		if err := s.db.QueryRow(ctx,
+			`SELECT COUNT(*) FROM (`+seasonSimulationsQuery+`) x`, season).Scan(&total); err != nil {
+			return 0, fmt.Errorf("failed to count games: %w", err)
+		}
+
+		rows, err := s.db.Query(ctx, seasonSimulationsQuery+` ORDER BY g.game_date, g.game_id`, season)
+		if err != nil {
+			return 0, fmt.Errorf("failed to query season simulations: %w", err)
+		}
+		defer rows.Close()
+
+		w.Write([]string{"game_id", "game_date", "game_type", "away_team", "home_team",
+			"run_id", "run_status", "simulations",
+			"home_win_probability", "away_win_probability", "expected_home_score", "expected_away_score",
+			"actual_home_score", "actual_away_score"})
+		written := 0
+		for rows.Next() {
+			var gameID, gameType, awayTeam, homeTeam, runID, status string
+			var gameDate time.Time
+			var simulations int
+			var homeWin, awayWin, expectedHome, expectedAway float64
+			var actualHome, actualAway *int
+			if err := rows.Scan(&gameID, &gameDate, &gameType, &awayTeam, &homeTeam,
+				&runID, &status, &simulations, &homeWin, &awayWin, &expectedHome, &expectedAway,
+				&actualHome, &actualAway); err != nil {
+				return written, fmt.Errorf("failed to scan season simulation: %w", err)
+			}
+			w.Write([]string{gameID, gameDate.Format("2006-01-02"), gameType, awayTeam, homeTeam,
+				runID, status, strconv.Itoa(simulations),
+				formatExportFloat(homeWin), formatExportFloat(awayWin),
+				formatExportFloat(expectedHome), formatExportFloat(expectedAway),
+				formatOptionalInt(actualHome), formatOptionalInt(actualAway)})
+			written++
+			if written%exportProgressEvery == 0 {
+				progress(written, total)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return written, fmt.Errorf("failed to read season simulations: %w", err)
+		}
+		return written, nil
+	}
+}
+
+func formatExportFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 4, 64)
+}
+
+// formatOptionalInt leaves NULLs as empty cells
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
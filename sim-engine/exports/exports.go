@@ -0,0 +1,339 @@
+package exports
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Export kinds
+const (
+	KindSimulationResults = "simulation_results" // every simulated game of one run
+	KindSeasonSimulations = "season_simulations" // latest finished run for each game of a season
+)
+
+// Job statuses
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+const (
+	// DefaultDir is where artifacts are written, relative to the working
+	// directory
+	DefaultDir = "export-artifacts"
+
+	// DefaultTTL is how long jobs and their artifacts are kept
+	DefaultTTL = 24 * time.Hour
+
+	// DefaultURLTTL is how long a signed download URL works
+	DefaultURLTTL = 15 * time.Minute
+
+	// DefaultMaxConcurrent is how many exports run at once; the rest wait
+	DefaultMaxConcurrent = 2
+
+	// DownloadPath prefixes download URLs, which are relative to the API host
+	DownloadPath = "/api/v1/exports/"
+)
+
+// Download errors
+var (
+	ErrNotFound         = errors.New("export not found")
+	ErrNotReady         = errors.New("export is not finished")
+	ErrInvalidSignature = errors.New("invalid download signature")
+	ErrLinkExpired      = errors.New("download link has expired")
+)
+
+// Job is one export and, once it completes, its CSV artifact
+type Job struct {
+	ID          string            `json:"id"`
+	Kind        string            `json:"kind"`
+	Params      map[string]string `json:"params"`
+	Status      string            `json:"status"`
+	Progress    float64           `json:"progress"` // 0-1; stays 0 while the row count is unknown
+	Rows        int               `json:"rows"`
+	Error       string            `json:"error,omitempty"`
+	SizeBytes   int64             `json:"size_bytes,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+	ExpiresAt   time.Time         `json:"expires_at"` // when the job and artifact are deleted
+
+	// Set on completed jobs; each lookup signs a fresh URL
+	DownloadURL       string     `json:"download_url,omitempty"`
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+}
+
+// Filename is the name the artifact downloads as
+func (j Job) Filename() string {
+	return fmt.Sprintf("%s-%s.csv", j.Kind, j.ID)
+}
+
+// WriteFunc writes an export's rows, reporting progress as rows done out of
+// total (0 when unknown), and returns how many rows it wrote
+type WriteFunc func(ctx context.Context, w *csv.Writer, progress func(done, total int)) (int, error)
+
+// Manager runs export jobs in the background and serves their artifacts
+// through signed, expiring URLs. Jobs and the signing key live in memory, so
+// neither survives a restart.
+type Manager struct {
+	mu     sync.Mutex
+	jobs   map[string]*Job
+	dir    string
+	key    []byte
+	ttl    time.Duration
+	urlTTL time.Duration
+	slots  chan struct{}
+	now    func() time.Time
+}
+
+// NewManager creates a manager writing artifacts to dir
+func NewManager(dir string, ttl, urlTTL time.Duration, maxConcurrent int) *Manager {
+	signingKey := make([]byte, 32)
+	if _, err := rand.Read(signingKey); err != nil {
+		log.Printf("Failed to generate export signing key: %v", err)
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if urlTTL <= 0 {
+		urlTTL = DefaultURLTTL
+	}
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrent
+	}
+	return &Manager{
+		jobs:   make(map[string]*Job),
+		dir:    dir,
+		key:    signingKey,
+		ttl:    ttl,
+		urlTTL: urlTTL,
+		slots:  make(chan struct{}, maxConcurrent),
+		now:    time.Now,
+	}
+}
+
+// Start queues an export and returns the pending job
+func (m *Manager) Start(kind string, params map[string]string, write WriteFunc) Job {
+	now := m.now()
+	job := &Job{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		Params:    params,
+		Status:    StatusPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(m.ttl),
+	}
+
+	m.mu.Lock()
+	m.jobs[job.ID] = job
+	snapshot := *job
+	m.mu.Unlock()
+
+	go m.run(job.ID, write)
+	return snapshot
+}
+
+// run writes the artifact under a temporary name and renames it once
+// complete, so a download never reads a partial file
+func (m *Manager) run(id string, write WriteFunc) {
+	m.slots <- struct{}{}
+	defer func() { <-m.slots }()
+	m.update(id, func(job *Job) { job.Status = StatusRunning })
+
+	path := m.artifactPath(id)
+	rows, size, err := m.writeArtifact(path, write, func(done, total int) {
+		m.update(id, func(job *Job) {
+			job.Rows = done
+			if total > 0 {
+				job.Progress = float64(done) / float64(total)
+				if job.Progress > 1 {
+					job.Progress = 1
+				}
+			}
+		})
+	})
+
+	completed := m.now()
+	m.update(id, func(job *Job) {
+		job.CompletedAt = &completed
+		if err != nil {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = StatusCompleted
+		job.Rows = rows
+		job.Progress = 1
+		job.SizeBytes = size
+	})
+	if err != nil {
+		log.Printf("Export %s failed: %v", id, err)
+	}
+}
+
+func (m *Manager) writeArtifact(path string, write WriteFunc, progress func(done, total int)) (int, int64, error) {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return 0, 0, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.ttl)
+	defer cancel()
+	w := csv.NewWriter(file)
+	rows, err := write(ctx, w, progress)
+	if err == nil {
+		w.Flush()
+		err = w.Error()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return 0, 0, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	return rows, info.Size(), nil
+}
+
+func (m *Manager) update(id string, change func(*Job)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[id]; ok {
+		change(job)
+	}
+}
+
+func (m *Manager) artifactPath(id string) string {
+	return filepath.Join(m.dir, id+".csv")
+}
+
+// Get returns a job, with a freshly signed download URL once it completes
+func (m *Manager) Get(id string) (Job, bool) {
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	var snapshot Job
+	if ok {
+		snapshot = *job
+	}
+	m.mu.Unlock()
+	if !ok {
+		return Job{}, false
+	}
+
+	if snapshot.Status == StatusCompleted {
+		expires := m.now().Add(m.urlTTL)
+		if expires.After(snapshot.ExpiresAt) {
+			expires = snapshot.ExpiresAt
+		}
+		snapshot.DownloadURL = fmt.Sprintf("%s%s/download?expires=%d&signature=%s",
+			DownloadPath, id, expires.Unix(), m.sign(id, expires.Unix()))
+		snapshot.DownloadExpiresAt = &expires
+	}
+	return snapshot, true
+}
+
+// sign is the HMAC-SHA256 of the job ID and expiry
+func (m *Manager) sign(id string, expires int64) string {
+	mac := hmac.New(sha256.New, m.key)
+	fmt.Fprintf(mac, "%s:%d", id, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Open checks a download link and opens the artifact it points to
+func (m *Manager) Open(id, expires, signature string) (*os.File, Job, error) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(m.sign(id, expiresAt))) {
+		return nil, Job{}, ErrInvalidSignature
+	}
+	if m.now().Unix() > expiresAt {
+		return nil, Job{}, ErrLinkExpired
+	}
+
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	var snapshot Job
+	if ok {
+		snapshot = *job
+	}
+	m.mu.Unlock()
+	if !ok {
+		return nil, Job{}, ErrNotFound
+	}
+	if snapshot.Status != StatusCompleted {
+		return nil, Job{}, ErrNotReady
+	}
+
+	file, err := os.Open(m.artifactPath(id))
+	if os.IsNotExist(err) {
+		return nil, Job{}, ErrNotFound
+	}
+	if err != nil {
+		return nil, Job{}, err
+	}
+	return file, snapshot, nil
+}
+
+// Cleanup deletes expired finished jobs and their artifacts, returning how
+// many were deleted
+func (m *Manager) Cleanup() int {
+	now := m.now()
+	var expired []string
+	m.mu.Lock()
+	for id, job := range m.jobs {
+		if now.After(job.ExpiresAt) && (job.Status == StatusCompleted || job.Status == StatusFailed) {
+			expired = append(expired, id)
+			delete(m.jobs, id)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, id := range expired {
+		if err := os.Remove(m.artifactPath(id)); err != nil && !os.IsNotExist(err) {
+			log.Printf("Failed to delete export %s: %v", id, err)
+		}
+	}
+	return len(expired)
+}
+
+// StartCleanup deletes expired exports every interval
+func (m *Manager) StartCleanup(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if deleted := m.Cleanup(); deleted > 0 {
+				log.Printf("Deleted %d expired exports", deleted)
+			}
+		}
+	}()
+}
@@ -0,0 +1,135 @@
+package exports
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeRows(n int) WriteFunc {
+	return func(ctx context.Context, w *csv.Writer, progress func(done, total int)) (int, error) {
+		w.Write([]string{"simulation_number", "home_score"})
+		for i := 1; i <= n; i++ {
+			w.Write([]string{"1", "4"})
+			progress(i, n)
+		}
+		return n, nil
+	}
+}
+
+// waitFor polls until the job leaves pending and running
+func waitFor(t *testing.T, m *Manager, id string) Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if job, _ := m.Get(id); job.Status == StatusCompleted || job.Status == StatusFailed {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("export %s did not finish", id)
+	return Job{}
+}
+
+// downloadParams splits a signed URL into the id, expires and signature
+func downloadParams(t *testing.T, downloadURL string) (string, string, string) {
+	t.Helper()
+	parsed, err := url.Parse(downloadURL)
+	if err != nil {
+		t.Fatalf("bad download URL %q: %v", downloadURL, err)
+	}
+	id := strings.TrimSuffix(strings.TrimPrefix(parsed.Path, DownloadPath), "/download")
+	return id, parsed.Query().Get("expires"), parsed.Query().Get("signature")
+}
+
+func TestExportCompletesWithSignedDownload(t *testing.T) {
+	m := NewManager(t.TempDir(), time.Hour, time.Minute, 1)
+	pending := m.Start(KindSimulationResults, map[string]string{"run_id": "run-1"}, writeRows(3))
+	if pending.Status != StatusPending || pending.DownloadURL != "" {
+		t.Fatalf("new export = %+v, want pending without a URL", pending)
+	}
+
+	job := waitFor(t, m, pending.ID)
+	if job.Status != StatusCompleted || job.Rows != 3 || job.Progress != 1 || job.SizeBytes == 0 {
+		t.Fatalf("finished export = %+v", job)
+	}
+	if !strings.HasPrefix(job.DownloadURL, DownloadPath+job.ID+"/download?") {
+		t.Errorf("download URL = %q", job.DownloadURL)
+	}
+
+	id, expires, signature := downloadParams(t, job.DownloadURL)
+	file, opened, err := m.Open(id, expires, signature)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer file.Close()
+	body, _ := io.ReadAll(file)
+	if lines := strings.Count(string(body), "\n"); lines != 4 {
+		t.Errorf("artifact has %d lines, want header and 3 rows", lines)
+	}
+	if opened.Filename() != "simulation_results-"+job.ID+".csv" {
+		t.Errorf("filename = %q", opened.Filename())
+	}
+}
+
+func TestExportDownloadRejectsBadLinks(t *testing.T) {
+	m := NewManager(t.TempDir(), time.Hour, time.Minute, 1)
+	job := waitFor(t, m, m.Start(KindSeasonSimulations, nil, writeRows(1)).ID)
+	id, expires, signature := downloadParams(t, job.DownloadURL)
+
+	if _, _, err := m.Open(id, expires, strings.Repeat("0", len(signature))); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("forged signature: err = %v", err)
+	}
+	if _, _, err := m.Open(id, "9999999999", signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("extended expiry: err = %v", err)
+	}
+
+	m.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if _, _, err := m.Open(id, expires, signature); !errors.Is(err, ErrLinkExpired) {
+		t.Errorf("expired link: err = %v", err)
+	}
+}
+
+func TestExportFailure(t *testing.T) {
+	dir := t.TempDir()
+	m := NewManager(dir, time.Hour, time.Minute, 1)
+	failing := func(ctx context.Context, w *csv.Writer, progress func(done, total int)) (int, error) {
+		w.Write([]string{"partial"})
+		return 0, errors.New("database unavailable")
+	}
+
+	job := waitFor(t, m, m.Start(KindSimulationResults, nil, failing).ID)
+	if job.Status != StatusFailed || job.Error != "database unavailable" || job.DownloadURL != "" {
+		t.Errorf("failed export = %+v", job)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("failed export left %d files behind", len(entries))
+	}
+}
+
+func TestExportCleanup(t *testing.T) {
+	dir := t.TempDir()
+	m := NewManager(dir, time.Hour, time.Minute, 1)
+	job := waitFor(t, m, m.Start(KindSimulationResults, nil, writeRows(1)).ID)
+
+	if deleted := m.Cleanup(); deleted != 0 {
+		t.Fatalf("deleted %d unexpired exports", deleted)
+	}
+	m.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if deleted := m.Cleanup(); deleted != 1 {
+		t.Fatalf("deleted %d exports, want 1", deleted)
+	}
+	if _, ok := m.Get(job.ID); ok {
+		t.Error("expired export is still listed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, job.ID+".csv")); !os.IsNotExist(err) {
+		t.Error("expired artifact was not deleted")
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"sim-engine/models"
+	"sim-engine/simulation"
+)
+
+// FantasyResponse is a run's projected fantasy points per player
+type FantasyResponse struct {
+	RunID            string                     `json:"run_id"`
+	System           models.ScoringSystem       `json:"system"`
+	TotalSimulations int                        `json:"total_simulations"`
+	Source           string                     `json:"source"` // "memory" or "database"
+	Players          []models.FantasyProjection `json:"players"`
+}
+
+// simulationFantasyHandler projects fantasy points from a run's simulated
+// player lines under ?system= dk, fd or custom (with ?scoring=). Runs still
+// held in memory are scored game by game, giving each player's distribution.
+// Stored runs only keep per-game averages, so they get mean projections
+// without quality starts.
+func (s *Server) simulationFantasyHandler(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	system, msg := fantasySystemFromQuery(r)
+	if msg != "" {
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	response := FantasyResponse{RunID: runID, System: system}
+
+	if status, exists := s.simEngine.GetRunStatus(runID); exists && status.AggregatedResult != nil && len(status.Results) > 0 {
+		response.Source = "memory"
+		response.TotalSimulations = len(status.Results)
+		response.Players = fantasyFromResults(status.Results, status.AggregatedResult.PlayerPerformance, system)
+		writeJSON(w, response)
+		return
+	}
+
+	var status string
+	if err := s.db.QueryRow(r.Context(),
+		"SELECT status FROM simulation_runs WHERE id = $1", runID).Scan(&status); err != nil {
+		http.Error(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+	if status != "completed" && status != simulation.RunStatusPartial {
+		http.Error(w, "Simulation not yet complete", http.StatusAccepted)
+		return
+	}
+
+	aggregated, err := s.simEngine.GetRunResult(r.Context(), runID)
+	if err != nil {
+		http.Error(w, "Results not available", http.StatusInternalServerError)
+		return
+	}
+
+	response.Source = "database"
+	response.TotalSimulations = aggregated.TotalSimulations
+	response.Players = fantasyFromAverages(aggregated.PlayerPerformance, system)
+	writeJSON(w, response)
+}
+
+// fantasySystemFromQuery returns the scoring system named by ?system=,
+// defaulting to DraftKings, or a message when it's invalid
+func fantasySystemFromQuery(r *http.Request) (models.ScoringSystem, string) {
+	name := r.URL.Query().Get("system")
+	if name == "" {
+		name = "dk"
+	}
+	if name == "custom" {
+		system, err := models.ParseCustomScoring(r.URL.Query().Get("scoring"))
+		if err != nil {
+			return models.ScoringSystem{}, err.Error()
+		}
+		return system, ""
+	}
+	system, ok := models.FantasyScoringSystems[name]
+	if !ok {
+		return models.ScoringSystem{}, "system must be dk, fd or custom"
+	}
+	return system, ""
+}
+
+// fantasyFromResults scores every simulated game and names the players from
+// the run's aggregated performance
+func fantasyFromResults(results []models.SimulationResult, performance *models.AggregatedPlayerPerformance,
+	system models.ScoringSystem) []models.FantasyProjection {
+	tally := models.NewFantasyTally(system)
+	for _, result := range results {
+		tally.Add(result)
+	}
+	projections := tally.Projections()
+	if performance == nil {
+		return projections
+	}
+	for i := range projections {
+		team := performance.AwayTeam
+		if projections[i].Team == "home" {
+			team = performance.HomeTeam
+		}
+		if projections[i].Role == "batter" {
+			projections[i].PlayerName = team.Batting[projections[i].PlayerID].PlayerName
+		} else {
+			projections[i].PlayerName = team.Pitching[projections[i].PlayerID].PlayerName
+		}
+	}
+	return projections
+}
+
+// fantasyFromAverages scores each player's per-game averages
+func fantasyFromAverages(performance *models.AggregatedPlayerPerformance, system models.ScoringSystem) []models.FantasyProjection {
+	projections := []models.FantasyProjection{}
+	if performance == nil {
+		return projections
+	}
+	for _, team := range []struct {
+		side  string
+		stats models.TeamPerformance
+	}{{"home", performance.HomeTeam}, {"away", performance.AwayTeam}} {
+		for id, batting := range team.stats.Batting {
+			projections = append(projections, models.FantasyProjection{
+				PlayerID: id, PlayerName: batting.PlayerName, Team: team.side, Role: "batter",
+				Points: roundFantasyPoints(system.AverageBattingPoints(batting)),
+			})
+		}
+		for id, pitching := range team.stats.Pitching {
+			projections = append(projections, models.FantasyProjection{
+				PlayerID: id, PlayerName: pitching.PlayerName, Team: team.side, Role: "pitcher",
+				Points: roundFantasyPoints(system.AveragePitchingPoints(pitching)),
+			})
+		}
+	}
+	models.SortFantasyProjections(projections)
+	return projections
+}
+
+// roundFantasyPoints rounds to two decimal places like the per-game tally
+func roundFantasyPoints(points float64) float64 {
+	return math.Round(points*100) / 100
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestFantasySystemFromQuery(t *testing.T) {
+	system, msg := fantasySystemFromQuery(httptest.NewRequest("GET", "/simulation/x/fantasy", nil))
+	if msg != "" || system.Name != "DraftKings" {
+		t.Errorf("default system = %q (%s), want DraftKings", system.Name, msg)
+	}
+
+	system, msg = fantasySystemFromQuery(httptest.NewRequest("GET", "/simulation/x/fantasy?system=custom&scoring=bat.HR%3D4", nil))
+	if msg != "" || system.Batting[models.FantasyHR] != 4 {
+		t.Errorf("custom system = %+v (%s)", system, msg)
+	}
+
+	for _, query := range []string{"system=yahoo", "system=custom", "system=custom&scoring=bat.SB%3D2"} {
+		if _, msg := fantasySystemFromQuery(httptest.NewRequest("GET", "/simulation/x/fantasy?"+query, nil)); msg == "" {
+			t.Errorf("?%s accepted, want a message", query)
+		}
+	}
+}
+
+func TestFantasyFromAverages(t *testing.T) {
+	performance := &models.AggregatedPlayerPerformance{
+		HomeTeam: models.TeamPerformance{
+			Batting: map[string]models.PlayerBattingStats{
+				"judge": {PlayerName: "Aaron Judge", Singles: 0.6, HR: 0.3, R: 0.8, RBI: 0.9, BB: 0.5},
+			},
+		},
+		AwayTeam: models.TeamPerformance{
+			Pitching: map[string]models.PlayerPitchingStats{
+				"cole": {PlayerName: "Gerrit Cole", IP: 6, K: 7.5, ER: 2.5, H: 5, BB: 1.5},
+			},
+		},
+	}
+
+	projections := fantasyFromAverages(performance, models.FantasyScoringSystems["dk"])
+	if len(projections) != 2 {
+		t.Fatalf("got %d projections, want 2", len(projections))
+	}
+	cole, judge := projections[0], projections[1]
+	if cole.PlayerName != "Gerrit Cole" || cole.Team != "away" || cole.Points != 13.5+15-5-3-0.9 {
+		t.Errorf("cole = %+v, want 19.6 points", cole)
+	}
+	if judge.Role != "batter" || judge.Points != 1.8+3+1.6+1.8+1 || judge.Distribution != nil {
+		t.Errorf("judge = %+v, want 9.2 points without a distribution", judge)
+	}
+}
@@ -3,6 +3,7 @@ module sim-engine
 go 1.24
 
 require (
+	github.com/baseball-sim/shared v0.0.0
 	github.com/google/uuid v1.4.0
 	github.com/gorilla/mux v1.8.1
 	github.com/jackc/pgx/v5 v5.5.4
@@ -16,3 +17,6 @@ require (
 	golang.org/x/sync v0.11.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
 )
+
+// The ID resolver is shared with the API gateway
+replace github.com/baseball-sim/shared => ../shared
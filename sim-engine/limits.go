@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"sim-engine/simulation"
+)
+
+// simulationRunLimitHeader carries the caller's per-API-key run limit from
+// the gateway; 0 or absent means only the engine-wide cap applies
+const simulationRunLimitHeader = "X-Simulation-Run-Limit"
+
+// runLimitError marks a simulation_runs value the engine will not accept
+type runLimitError struct {
+	Requested int
+	Limit     int
+}
+
+func (e runLimitError) Error() string {
+	if e.Requested < 0 {
+		return "simulation_runs must be non-negative"
+	}
+	return fmt.Sprintf("simulation_runs %d exceeds the allowed maximum of %d", e.Requested, e.Limit)
+}
+
+// resolveSimulationRuns applies the default run count and rechecks it
+// against the engine cap and any limit forwarded by the gateway
+func (s *Server) resolveSimulationRuns(r *http.Request, requested int) (int, error) {
+	if requested < 0 {
+		return 0, runLimitError{Requested: requested}
+	}
+
+	runs := requested
+	if runs == 0 {
+		runs = s.config.SimulationRuns
+	}
+
+	limit := s.config.MaxSimulationRuns
+	if header := r.Header.Get(simulationRunLimitHeader); header != "" {
+		if keyLimit, err := strconv.Atoi(header); err == nil && keyLimit > 0 && (limit <= 0 || keyLimit < limit) {
+			limit = keyLimit
+		}
+	}
+
+	if limit > 0 && runs > limit {
+		return 0, runLimitError{Requested: runs, Limit: limit}
+	}
+
+	return runs, nil
+}
+
+// defaultMaxQueueDepth is how many runs may be pending or running before new
+// ones are turned away
+const defaultMaxQueueDepth = 50
+
+// maxAdmissionWait caps how long admitWhenRoom sleeps between attempts
+const maxAdmissionWait = 30 * time.Second
+
+// queueFullResponse is the body of a 429 sent while the run queue is full
+type queueFullResponse struct {
+	Error                string  `json:"error"`
+	QueueDepth           int     `json:"queue_depth"`
+	MaxQueueDepth        int     `json:"max_queue_depth"`
+	RemainingSimulations int     `json:"remaining_simulations"`
+	EstimatedWaitSeconds float64 `json:"estimated_wait_seconds"`
+}
+
+// writeQueueFull turns a run away with 429, a Retry-After of the time the
+// current backlog should take and the backlog behind it
+func (s *Server) writeQueueFull(w http.ResponseWriter, backlog simulation.Backlog) {
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(backlog.EstimatedWaitSeconds)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(queueFullResponse{
+		Error:                fmt.Sprintf("simulation queue is full (%d runs pending or running)", backlog.Runs),
+		QueueDepth:           backlog.Runs,
+		MaxQueueDepth:        s.config.MaxQueueDepth,
+		RemainingSimulations: backlog.RemainingSimulations,
+		EstimatedWaitSeconds: backlog.EstimatedWaitSeconds,
+	})
+}
+
+// retryAfterSeconds rounds a wait up to whole seconds, at least one
+func retryAfterSeconds(wait float64) int {
+	return max(1, int(math.Ceil(wait)))
+}
+
+// admitWhenRoom admits a run the engine already accepted as part of a batch
+// but starts later, such as a later day of a bullpen fatigue batch. There is
+// no caller to send a 429 to, so it waits for the backlog to drain.
+func (s *Server) admitWhenRoom(runID, gameID string, simulationRuns int, sleep func(time.Duration)) {
+	for {
+		backlog, ok := s.simEngine.AdmitRun(runID, gameID, simulationRuns, s.config.MaxQueueDepth)
+		if ok {
+			return
+		}
+		sleep(min(time.Duration(retryAfterSeconds(backlog.EstimatedWaitSeconds))*time.Second, maxAdmissionWait))
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sim-engine/simulation"
+)
+
+func TestResolveSimulationRuns(t *testing.T) {
+	s := &Server{config: &Config{SimulationRuns: 1000, MaxSimulationRuns: 100000}}
+
+	tests := []struct {
+		name      string
+		requested int
+		header    string
+		want      int
+		wantErr   bool
+	}{
+		{name: "default applied", requested: 0, want: 1000},
+		{name: "within engine cap", requested: 50000, want: 50000},
+		{name: "above engine cap", requested: 200000, wantErr: true},
+		{name: "negative", requested: -1, wantErr: true},
+		{name: "within key limit", requested: 2000, header: "2000", want: 2000},
+		{name: "above key limit", requested: 2001, header: "2000", wantErr: true},
+		{name: "key limit cannot raise engine cap", requested: 150000, header: "500000", wantErr: true},
+		{name: "invalid header ignored", requested: 5000, header: "lots", want: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/simulate", nil)
+			if tt.header != "" {
+				r.Header.Set(simulationRunLimitHeader, tt.header)
+			}
+
+			got, err := s.resolveSimulationRuns(r, tt.requested)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %d runs", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %d runs, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestWriteQueueFull tests a full queue is a 429 with Retry-After and the backlog
+func TestWriteQueueFull(t *testing.T) {
+	s := &Server{config: &Config{MaxQueueDepth: 2}}
+	w := httptest.NewRecorder()
+
+	s.writeQueueFull(w, simulation.Backlog{Runs: 2, RemainingSimulations: 1500, EstimatedWaitSeconds: 3.2})
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "4" {
+		t.Errorf("Expected Retry-After 4, got %q", got)
+	}
+
+	var body queueFullResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected JSON body: %v", err)
+	}
+	if body.QueueDepth != 2 || body.MaxQueueDepth != 2 || body.RemainingSimulations != 1500 || body.EstimatedWaitSeconds != 3.2 {
+		t.Errorf("Unexpected body %+v", body)
+	}
+
+	if got := retryAfterSeconds(0); got != 1 {
+		t.Errorf("Expected an empty backlog to retry after 1 second, got %d", got)
+	}
+}
+
+// TestAdmitWhenRoom tests a batch run waits for the queue to drain instead
+// of starting past the depth
+func TestAdmitWhenRoom(t *testing.T) {
+	engine := simulation.NewSimulationEngine(nil, 4, 1000)
+	s := &Server{config: &Config{MaxQueueDepth: 1}, simEngine: engine}
+	engine.AdmitRun("running", "game-1", 1000, 0)
+
+	var waits []time.Duration
+	s.admitWhenRoom("batch-run", "game-2", 1000, func(d time.Duration) {
+		waits = append(waits, d)
+		engine.ReleaseRun("running")
+	})
+
+	if len(waits) != 1 || waits[0] < time.Second || waits[0] > maxAdmissionWait {
+		t.Errorf("Expected one wait of 1-%v, got %v", maxAdmissionWait, waits)
+	}
+	if got := engine.Backlog().Runs; got != 1 {
+		t.Errorf("Expected the batch run to hold the one place, got %d runs", got)
+	}
+}
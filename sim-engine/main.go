@@ -9,13 +9,20 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/baseball-sim/shared/ids"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"sim-engine/exports"
+	"sim-engine/models"
+	"sim-engine/notifications"
+	"sim-engine/objectstore"
+	"sim-engine/odds"
 	"sim-engine/simulation"
 	"sim-engine/weather"
 )
@@ -26,6 +33,9 @@ type Server struct {
 	httpServer *http.Server
 	config     *Config
 	simEngine  *simulation.SimulationEngine
+	notifier   *notifications.Notifier
+	exports    *exports.Manager
+	odds       *odds.Store
 }
 
 type Config struct {
@@ -37,6 +47,64 @@ type Config struct {
 	DBName         string
 	Workers        int
 	SimulationRuns int
+
+	// Engine-wide cap on simulation_runs per request (0 = unlimited)
+	MaxSimulationRuns int
+
+	// New runs are turned away with a 429 while this many are pending or
+	// running (0 = unlimited)
+	MaxQueueDepth int
+
+	// Temperature (°F) below which pitchers lose velocity and spin
+	ColdWeatherThreshold int
+
+	// How long loaded rosters are reused between runs (0 = no caching)
+	RosterCacheTTL time.Duration
+
+	// How long pre-warmed game contexts are reused (0 = no warm pool) and how
+	// often today's games are pre-warmed (0 = only on request)
+	WarmPoolTTL      time.Duration
+	WarmPoolInterval time.Duration
+
+	// Every WeatherWatchInterval, today's games starting within
+	// WeatherRefreshLead get a fresh forecast and are re-run when it moved
+	// by ResimTemperatureDelta °F or the wind flipped (0 interval = off)
+	WeatherWatchInterval  time.Duration
+	WeatherRefreshLead    time.Duration
+	ResimTemperatureDelta int
+
+	// Result writes are tried this many times, then spilled as JSON to
+	// DeadLetterDir until they are replayed
+	ResultWriteAttempts int
+	DeadLetterDir       string
+
+	// New runs' raw results are written to ResultStorage: "postgres" rows,
+	// or "s3" for one object per run in ResultObjectStore under
+	// ResultObjectPrefix. Runs already in the bucket stay readable while it
+	// is configured, whichever is selected.
+	ResultStorage      string
+	ResultObjectStore  objectstore.Config
+	ResultObjectPrefix string
+
+	// Finished runs older than RunTTL are deleted every RunCleanupInterval
+	// (0 keeps runs forever)
+	RunTTL             time.Duration
+	RunCleanupInterval time.Duration
+
+	// Export artifacts are written to ExportDir and kept for ExportTTL;
+	// signed download URLs last ExportURLTTL
+	ExportDir    string
+	ExportTTL    time.Duration
+	ExportURLTTL time.Duration
+
+	// Lines are fetched from the odds provider every OddsPollInterval (0 =
+	// manual entry only), limited to OddsBookmakers when any are listed
+	OddsPollInterval time.Duration
+	OddsBookmakers   []string
+
+	// The daily batch starts at DailySchedule's time each day, catching up
+	// on days missed while the engine was down (nil = off)
+	DailySchedule *DailySchedule
 }
 
 // Remove the local definition since we're importing from simulation package
@@ -47,6 +115,14 @@ type SimulationRequest struct {
 	Config         map[string]interface{} `json:"config,omitempty"`
 }
 
+// EstimateRequest describes a prospective simulation job to cost
+type EstimateRequest struct {
+	SimulationRuns int                    `json:"simulation_runs,omitempty"`
+	Games          int                    `json:"games,omitempty"`
+	GameIDs        []string               `json:"game_ids,omitempty"`
+	Config         map[string]interface{} `json:"config,omitempty"`
+}
+
 type SimulationResponse struct {
 	RunID     string    `json:"run_id"`
 	Status    string    `json:"status"`
@@ -66,24 +142,27 @@ type SimulationStatus struct {
 }
 
 type SimulationResult struct {
-	RunID                 string                 `json:"run_id"`
-	GameID                string                 `json:"game_id"`
-	HomeTeam              string                 `json:"home_team"`
-	AwayTeam              string                 `json:"away_team"`
-	TotalSimulations      int                    `json:"total_simulations"`
-	HomeWins              int                    `json:"home_wins"`
-	AwayWins              int                    `json:"away_wins"`
-	HomeWinProbability    float64                `json:"home_win_probability"`
-	AwayWinProbability    float64                `json:"away_win_probability"`
-	ExpectedHomeScore     float64                `json:"expected_home_score"`
-	ExpectedAwayScore     float64                `json:"expected_away_score"`
-	HomeScoreDistribution map[int]int            `json:"home_score_distribution"`
-	AwayScoreDistribution map[int]int            `json:"away_score_distribution"`
-	PlayerPerformance     interface{}            `json:"player_performance,omitempty"`
-	Weather               map[string]interface{} `json:"weather,omitempty"`
-	ParkFactors           map[string]interface{} `json:"park_factors,omitempty"`
-	Umpire                map[string]interface{} `json:"umpire,omitempty"`
-	Metadata              map[string]interface{} `json:"metadata,omitempty"`
+	RunID                 string                     `json:"run_id"`
+	GameID                string                     `json:"game_id"`
+	HomeTeam              string                     `json:"home_team"`
+	AwayTeam              string                     `json:"away_team"`
+	TotalSimulations      int                        `json:"total_simulations"`
+	HomeWins              int                        `json:"home_wins"`
+	AwayWins              int                        `json:"away_wins"`
+	HomeWinProbability    float64                    `json:"home_win_probability"`
+	AwayWinProbability    float64                    `json:"away_win_probability"`
+	ExpectedHomeScore     float64                    `json:"expected_home_score"`
+	ExpectedAwayScore     float64                    `json:"expected_away_score"`
+	HomeScoreDistribution map[int]int                `json:"home_score_distribution"`
+	AwayScoreDistribution map[int]int                `json:"away_score_distribution"`
+	PlayerPerformance     interface{}                `json:"player_performance,omitempty"`
+	Weather               map[string]interface{}     `json:"weather,omitempty"`
+	ParkFactors           map[string]interface{}     `json:"park_factors,omitempty"`
+	Umpire                map[string]interface{}     `json:"umpire,omitempty"`
+	Markets               *models.Markets            `json:"markets,omitempty"`
+	InningScoring         *models.InningDistributions `json:"inning_scoring,omitempty"`
+	Metadata              map[string]interface{}     `json:"metadata,omitempty"`
+	Fingerprint           *simulation.RunFingerprint `json:"fingerprint,omitempty"`
 }
 
 func NewConfig() *Config {
@@ -97,6 +176,119 @@ func NewConfig() *Config {
 		fmt.Sscanf(envRuns, "%d", &simulationRuns)
 	}
 
+	maxSimulationRuns := 100000
+	if envMax := os.Getenv("MAX_SIMULATION_RUNS"); envMax != "" {
+		fmt.Sscanf(envMax, "%d", &maxSimulationRuns)
+	}
+
+	maxQueueDepth := defaultMaxQueueDepth
+	if envDepth := os.Getenv("MAX_QUEUE_DEPTH"); envDepth != "" {
+		fmt.Sscanf(envDepth, "%d", &maxQueueDepth)
+	}
+
+	coldWeatherThreshold := models.DefaultColdWeatherThreshold
+	if envCold := os.Getenv("COLD_WEATHER_THRESHOLD"); envCold != "" {
+		fmt.Sscanf(envCold, "%d", &coldWeatherThreshold)
+	}
+
+	rosterCacheTTL := simulation.DefaultRosterCacheTTL
+	if envTTL := os.Getenv("ROSTER_CACHE_TTL"); envTTL != "" {
+		if parsed, err := time.ParseDuration(envTTL); err == nil {
+			rosterCacheTTL = parsed
+		}
+	}
+
+	warmPoolTTL := simulation.DefaultWarmPoolTTL
+	if envTTL := os.Getenv("WARM_POOL_TTL"); envTTL != "" {
+		if parsed, err := time.ParseDuration(envTTL); err == nil {
+			warmPoolTTL = parsed
+		}
+	}
+
+	warmPoolInterval := time.Hour
+	if envInterval := os.Getenv("WARM_POOL_INTERVAL"); envInterval != "" {
+		if parsed, err := time.ParseDuration(envInterval); err == nil {
+			warmPoolInterval = parsed
+		}
+	}
+
+	weatherWatchInterval := 15 * time.Minute
+	if envInterval := os.Getenv("WEATHER_WATCH_INTERVAL"); envInterval != "" {
+		if parsed, err := time.ParseDuration(envInterval); err == nil {
+			weatherWatchInterval = parsed
+		}
+	}
+
+	weatherRefreshLead := simulation.DefaultWeatherRefreshLead
+	if envLead := os.Getenv("WEATHER_REFRESH_LEAD"); envLead != "" {
+		if parsed, err := time.ParseDuration(envLead); err == nil {
+			weatherRefreshLead = parsed
+		}
+	}
+
+	resimTemperatureDelta := simulation.DefaultResimTemperatureDelta
+	if envDelta := os.Getenv("RESIM_TEMPERATURE_DELTA"); envDelta != "" {
+		fmt.Sscanf(envDelta, "%d", &resimTemperatureDelta)
+	}
+
+	resultWriteAttempts := simulation.DefaultResultWriteAttempts
+	if envAttempts := os.Getenv("RESULT_WRITE_ATTEMPTS"); envAttempts != "" {
+		fmt.Sscanf(envAttempts, "%d", &resultWriteAttempts)
+	}
+
+	var runTTL time.Duration
+	if envTTL := os.Getenv("SIMULATION_RUN_TTL"); envTTL != "" {
+		if parsed, err := time.ParseDuration(envTTL); err == nil {
+			runTTL = parsed
+		}
+	}
+
+	runCleanupInterval := time.Hour
+	if envInterval := os.Getenv("RUN_CLEANUP_INTERVAL"); envInterval != "" {
+		if parsed, err := time.ParseDuration(envInterval); err == nil {
+			runCleanupInterval = parsed
+		}
+	}
+
+	exportTTL := exports.DefaultTTL
+	if envTTL := os.Getenv("EXPORT_TTL"); envTTL != "" {
+		if parsed, err := time.ParseDuration(envTTL); err == nil {
+			exportTTL = parsed
+		}
+	}
+
+	exportURLTTL := exports.DefaultURLTTL
+	if envTTL := os.Getenv("EXPORT_URL_TTL"); envTTL != "" {
+		if parsed, err := time.ParseDuration(envTTL); err == nil {
+			exportURLTTL = parsed
+		}
+	}
+
+	oddsPollInterval := 30 * time.Minute
+	if envInterval := os.Getenv("ODDS_POLL_INTERVAL"); envInterval != "" {
+		if parsed, err := time.ParseDuration(envInterval); err == nil {
+			oddsPollInterval = parsed
+		}
+	}
+
+	var oddsBookmakers []string
+	for _, bookmaker := range strings.Split(os.Getenv("ODDS_BOOKMAKERS"), ",") {
+		if bookmaker = strings.TrimSpace(bookmaker); bookmaker != "" {
+			oddsBookmakers = append(oddsBookmakers, bookmaker)
+		}
+	}
+
+	dailyCatchUpDays := defaultDailyCatchUpDays
+	if envDays := os.Getenv("DAILY_CATCH_UP_DAYS"); envDays != "" {
+		fmt.Sscanf(envDays, "%d", &dailyCatchUpDays)
+	}
+
+	dailySchedule, err := parseDailySchedule(getEnv("DAILY_SCHEDULE_TIME", defaultDailyScheduleTime),
+		os.Getenv("DAILY_SCHEDULE_TIMEZONE"), dailyCatchUpDays)
+	if err != nil {
+		log.Printf("Warning: %v; daily simulations will not be scheduled", err)
+	}
+
 	return &Config{
 		Port:           getEnv("PORT", "8081"),
 		DBHost:         getEnv("DB_HOST", "localhost"),
@@ -106,11 +298,50 @@ func NewConfig() *Config {
 		DBName:         getEnv("DB_NAME", "baseball_sim"),
 		Workers:        workers,
 		SimulationRuns: simulationRuns,
+
+		MaxSimulationRuns: maxSimulationRuns,
+		MaxQueueDepth:     maxQueueDepth,
+
+		ColdWeatherThreshold: coldWeatherThreshold,
+
+		RosterCacheTTL: rosterCacheTTL,
+
+		WarmPoolTTL:      warmPoolTTL,
+		WarmPoolInterval: warmPoolInterval,
+
+		WeatherWatchInterval:  weatherWatchInterval,
+		WeatherRefreshLead:    weatherRefreshLead,
+		ResimTemperatureDelta: resimTemperatureDelta,
+
+		ResultWriteAttempts: resultWriteAttempts,
+		DeadLetterDir:       getEnv("DEAD_LETTER_DIR", simulation.DefaultDeadLetterDir),
+
+		ResultStorage: getEnv("RESULT_STORAGE", simulation.ResultStoragePostgres),
+		ResultObjectStore: objectstore.Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          getEnv("S3_REGION", objectstore.DefaultRegion),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		},
+		ResultObjectPrefix: getEnv("RESULT_OBJECT_PREFIX", simulation.DefaultResultObjectPrefix),
+
+		RunTTL:             runTTL,
+		RunCleanupInterval: runCleanupInterval,
+
+		ExportDir:    getEnv("EXPORT_DIR", exports.DefaultDir),
+		ExportTTL:    exportTTL,
+		ExportURLTTL: exportURLTTL,
+
+		OddsPollInterval: oddsPollInterval,
+		OddsBookmakers:   oddsBookmakers,
+
+		DailySchedule: dailySchedule,
 	}
 }
 
-func NewServer(config *Config) (*Server, error) {
-	// Database connection
+// connectDB opens the connection pool and checks the database is reachable
+func connectDB(config *Config) (*pgxpool.Pool, error) {
 	dbURL := fmt.Sprintf("postgresql://%s:%s@%s:%s/%s",
 		config.DBUser, config.DBPassword, config.DBHost, config.DBPort, config.DBName)
 
@@ -134,14 +365,60 @@ func NewServer(config *Config) (*Server, error) {
 	if err := db.Ping(context.Background()); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
+	return db, nil
+}
+
+// configureResultStorage connects the results bucket, when one is
+// configured, and selects where new runs' raw results are written
+func configureResultStorage(engine *simulation.SimulationEngine, config *Config) error {
+	if config.ResultObjectStore.Bucket != "" {
+		store, err := objectstore.NewS3(config.ResultObjectStore)
+		if err != nil {
+			return fmt.Errorf("invalid result object storage: %w", err)
+		}
+		engine.SetResultObjectStore(store, config.ResultObjectPrefix)
+	}
+	if err := engine.SetResultStorage(config.ResultStorage); err != nil {
+		return fmt.Errorf("invalid RESULT_STORAGE: %w", err)
+	}
+	return nil
+}
+
+func NewServer(config *Config) (*Server, error) {
+	db, err := connectDB(config)
+	if err != nil {
+		return nil, err
+	}
 
 	simEngine := simulation.NewSimulationEngine(db, config.Workers, config.SimulationRuns)
+	simEngine.SetResultWriteAttempts(config.ResultWriteAttempts)
+	simEngine.SetDeadLetterDir(config.DeadLetterDir)
+	simEngine.SetMaxQueueDepth(config.MaxQueueDepth)
+	if err := configureResultStorage(simEngine, config); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if config.ResultStorage == simulation.ResultStorageS3 {
+		log.Printf("Storing raw simulation results in bucket %s", config.ResultObjectStore.Bucket)
+	}
+	simEngine.SetColdWeatherThreshold(config.ColdWeatherThreshold)
+	simEngine.SetRosterCacheTTL(config.RosterCacheTTL)
+	simEngine.SetWarmPoolTTL(config.WarmPoolTTL)
 	simEngine.StartPerformanceMonitoring()
 
+	// Load calibration from engine_parameters; built-in defaults otherwise
+	paramsCtx, cancelParams := context.WithTimeout(context.Background(), 5*time.Second)
+	if _, err := simEngine.ReloadTuningParameters(paramsCtx); err != nil {
+		log.Printf("Warning: Failed to load engine parameters, using defaults: %v", err)
+	}
+	cancelParams()
+
 	// Initialize weather service if API key is configured
 	weatherAPIKey := os.Getenv("OPENWEATHER_API_KEY")
 	if weatherAPIKey != "" {
 		weatherService := weather.NewService(weatherAPIKey)
+		// Share fetched forecasts with the other engine replicas
+		weatherService.SetStore(weather.NewPostgresStore(db))
 		weatherService.StartCacheCleanup()
 
 		// Validate API key
@@ -160,13 +437,37 @@ func NewServer(config *Config) (*Server, error) {
 		log.Printf("No OPENWEATHER_API_KEY configured, simulations will use default weather")
 	}
 
+	// Pre-warm game day contexts once weather is available
+	simEngine.StartWarmPoolRefresh(config.WarmPoolInterval)
+
+	// Expire old runs when a TTL is configured
+	simEngine.StartRunCleanup(config.RunTTL, config.RunCleanupInterval)
+
 	s := &Server{
 		db:        db,
 		config:    config,
 		router:    mux.NewRouter(),
 		simEngine: simEngine,
+		notifier:  notifications.NewNotifier(),
+		exports:   exports.NewManager(config.ExportDir, config.ExportTTL, config.ExportURLTTL, exports.DefaultMaxConcurrent),
+		odds:      odds.NewStore(db),
+	}
+	s.exports.StartCleanup(time.Hour)
+
+	// Re-run games whose forecast changed shortly before first pitch
+	simEngine.StartWeatherWatch(config.WeatherWatchInterval, config.WeatherRefreshLead,
+		config.ResimTemperatureDelta, s.sendResimulationNotifications)
+
+	// Poll the odds provider when one is configured; lines can always be posted by hand
+	if oddsAPIKey := os.Getenv("ODDS_API_KEY"); oddsAPIKey != "" {
+		s.odds.StartPolling(odds.NewTheOddsAPI(oddsAPIKey, config.OddsBookmakers), config.OddsPollInterval)
+	} else {
+		log.Printf("No ODDS_API_KEY configured, odds must be posted to /odds")
 	}
 
+	// Start each day's daily batch without an external cron
+	s.startDailySchedule()
+
 	s.setupRoutes()
 	return s, nil
 }
@@ -179,9 +480,55 @@ func (s *Server) setupRoutes() {
 	s.router.HandleFunc("/simulate", s.simulateHandler).Methods("POST")
 	s.router.HandleFunc("/simulation/{id}/status", s.simulationStatusHandler).Methods("GET")
 	s.router.HandleFunc("/simulation/{id}/result", s.simulationResultHandler).Methods("GET")
-
-	// Daily simulation endpoint
+	s.router.HandleFunc("/simulation/{id}/samples", s.simulationSamplesHandler).Methods("GET")
+	s.router.HandleFunc("/simulation/{id}/events", s.simulationEventsHandler).Methods("GET")
+	s.router.HandleFunc("/simulation/{id}/explain", s.simulationExplainHandler).Methods("GET")
+	s.router.HandleFunc("/simulation/{id}/fantasy", s.simulationFantasyHandler).Methods("GET")
+	s.router.HandleFunc("/simulation/{id}/config", s.simulationConfigHandler).Methods("GET")
+	s.router.HandleFunc("/simulation/{id}/odds", s.simulationOddsHandler).Methods("GET")
+	s.router.HandleFunc("/simulation/{id}/sensitivity", s.simulationSensitivityHandler).Methods("POST")
+	s.router.HandleFunc("/simulation/{id}", s.deleteSimulationHandler).Methods("DELETE")
+	s.router.HandleFunc("/simulations", s.listSimulationsHandler).Methods("GET")
+	s.router.HandleFunc("/simulations", s.deleteSimulationsHandler).Methods("DELETE")
+	s.router.HandleFunc("/simulations/events", s.archivedEventsHandler).Methods("GET")
+
+	// Daily and batch simulation endpoints
+	s.router.HandleFunc("/simulate/estimate", s.estimateHandler).Methods("POST")
 	s.router.HandleFunc("/simulate/daily", s.simulateDailyHandler).Methods("POST")
+	s.router.HandleFunc("/simulate/daily/schedule", s.dailyScheduleHandler).Methods("GET")
+	s.router.HandleFunc("/simulate/daily/{date}", s.dailyDigestHandler).Methods("GET")
+	s.router.HandleFunc("/simulate/batch", s.simulateBatchHandler).Methods("POST")
+	s.router.HandleFunc("/simulate/batch/{id}", s.batchStatusHandler).Methods("GET")
+
+	// Asynchronous CSV exports; downloads are authorized by signed URL
+	s.router.HandleFunc("/exports", s.createExportHandler).Methods("POST")
+	s.router.HandleFunc("/exports/{id}", s.exportStatusHandler).Methods("GET")
+	s.router.HandleFunc("/exports/{id}/download", s.downloadExportHandler).Methods("GET")
+
+	// Daily digest notification targets, owned by the caller's API key
+	s.router.HandleFunc("/notifications/targets", s.listNotificationTargetsHandler).Methods("GET")
+	s.router.HandleFunc("/notifications/targets", s.createNotificationTargetHandler).Methods("POST")
+	s.router.HandleFunc("/notifications/targets/{id}", s.deleteNotificationTargetHandler).Methods("DELETE")
+
+	// Metadata endpoints
+	s.router.HandleFunc("/meta/stats", s.statGlossaryHandler).Methods("GET")
+	s.router.HandleFunc("/meta/win-expectancy", s.winExpectancyHandler).Methods("GET")
+	s.router.HandleFunc("/accuracy", s.accuracyHandler).Methods("GET")
+
+	// Market odds, posted by hand or polled from a provider
+	s.router.HandleFunc("/odds", s.ingestOddsHandler).Methods("POST")
+	s.router.HandleFunc("/odds", s.gameOddsHandler).Methods("GET")
+	s.router.HandleFunc("/odds/clv", s.clvHandler).Methods("GET")
+
+	// Context-free team strength against a synthetic opponent
+	s.router.HandleFunc("/ratings", s.ratingsHandler).Methods("POST")
+
+	// Admin endpoints
+	s.router.HandleFunc("/admin/reload-params", s.reloadParamsHandler).Methods("POST")
+	s.router.HandleFunc("/admin/invalidate-cache", s.invalidateCacheHandler).Methods("POST")
+	s.router.HandleFunc("/admin/prewarm", s.prewarmHandler).Methods("POST")
+	s.router.HandleFunc("/admin/dead-letters", s.deadLettersHandler).Methods("GET")
+	s.router.HandleFunc("/admin/dead-letters/replay", s.replayDeadLettersHandler).Methods("POST")
 
 	// Apply middleware
 	s.router.Use(s.loggingMiddleware)
@@ -215,10 +562,12 @@ func (s *Server) Shutdown(ctx context.Context) error {
 // Handlers
 func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	health := map[string]interface{}{
-		"status":   "healthy",
-		"time":     time.Now().UTC(),
-		"workers":  s.config.Workers,
-		"database": "connected",
+		"status":    "healthy",
+		"time":      time.Now().UTC(),
+		"workers":   s.config.Workers,
+		"database":  "connected",
+		"rosters":   s.simEngine.RosterCacheStats(),
+		"warm_pool": s.simEngine.WarmPoolStats(),
 	}
 
 	// Check database connection
@@ -234,6 +583,14 @@ func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, health)
 }
 
+// statGlossaryHandler returns the registry of stat keys emitted in simulation results
+func (s *Server) statGlossaryHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"stats": models.SimulationStatRegistry,
+		"count": len(models.SimulationStatRegistry),
+	})
+}
+
 func (s *Server) simulateHandler(w http.ResponseWriter, r *http.Request) {
 	var req SimulationRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -241,39 +598,62 @@ func (s *Server) simulateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate game exists
-	var gameExists bool
-	err := s.db.QueryRow(r.Context(),
-		"SELECT EXISTS(SELECT 1 FROM games WHERE game_id = $1)",
-		req.GameID).Scan(&gameExists)
-
+	simulationRuns, err := s.resolveSimulationRuns(r, req.SimulationRuns)
 	if err != nil {
-		log.Printf("Database error: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
 
-	if !gameExists {
-		http.Error(w, "Game not found", http.StatusNotFound)
+	if _, err := simulation.MaxDurationFromConfig(req.Config); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
 
+	if _, err := simulation.AsOfFromConfig(req.Config, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if _, err := simulation.PlayProbabilityFromConfig(req.Config); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if _, err := simulation.StarterRolesFromConfig(req.Config); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if !s.validateStadiumOverride(r.Context(), w, req.Config) {
+		return
+	}
+
+	// The game may be named by any ID the resolver accepts; runs use the
+	// MLB game ID
+	game, ok := s.resolveID(r.Context(), w, ids.Game, req.GameID)
+	if !ok {
+		return
+	}
+	req.GameID = game.ExternalID
+
 	// Create simulation run
 	runID := uuid.New().String()
-	simulationRuns := req.SimulationRuns
-	if simulationRuns == 0 {
-		simulationRuns = s.config.SimulationRuns
+
+	if backlog, ok := s.simEngine.AdmitRun(runID, req.GameID, simulationRuns, s.config.MaxQueueDepth); !ok {
+		s.writeQueueFull(w, backlog)
+		return
 	}
 
 	configJSON, _ := json.Marshal(req.Config)
 
 	_, err = s.db.Exec(r.Context(), `
 		INSERT INTO simulation_runs (id, game_id, config, total_runs, status)
-		VALUES ($1, (SELECT id FROM games WHERE game_id = $2), $3, $4, 'pending')
-	`, runID, req.GameID, configJSON, simulationRuns)
+		VALUES ($1, $2, $3, $4, 'pending')
+	`, runID, game.ID, configJSON, simulationRuns)
 
 	if err != nil {
 		log.Printf("Failed to create simulation run: %v", err)
+		s.simEngine.ReleaseRun(runID)
 		http.Error(w, "Failed to create simulation", http.StatusInternalServerError)
 		return
 	}
@@ -291,6 +671,33 @@ func (s *Server) simulateHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, response)
 }
 
+// estimateHandler projects the wall-clock, CPU and storage cost of a job
+// from recent engine throughput without starting any simulations
+func (s *Server) estimateHandler(w http.ResponseWriter, r *http.Request) {
+	var req EstimateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.SimulationRuns < 0 || req.Games < 0 {
+		http.Error(w, "simulation_runs and games must be non-negative", http.StatusBadRequest)
+		return
+	}
+
+	simulationRuns := req.SimulationRuns
+	if simulationRuns == 0 {
+		simulationRuns = s.config.SimulationRuns
+	}
+
+	games := req.Games
+	if len(req.GameIDs) > 0 {
+		games = len(req.GameIDs)
+	}
+
+	writeJSON(w, s.simEngine.EstimateCost(simulationRuns, games))
+}
+
 func (s *Server) simulationStatusHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	runID := vars["id"]
@@ -342,15 +749,21 @@ func (s *Server) simulationResultHandler(w http.ResponseWriter, r *http.Request)
 
 	// Check if simulation is complete
 	var status string
-	err := s.db.QueryRow(r.Context(),
-		"SELECT status FROM simulation_runs WHERE id = $1", runID).Scan(&status)
+	var totalRuns int
+	var engineVersion, modelParamHash *string
+	var dataSnapshotAt *time.Time
+	var simulatedWeatherJSON []byte
+	err := s.db.QueryRow(r.Context(), `
+		SELECT status, total_runs, engine_version, model_param_hash, data_snapshot_at, inputs->'weather'
+		FROM simulation_runs WHERE id = $1
+	`, runID).Scan(&status, &totalRuns, &engineVersion, &modelParamHash, &dataSnapshotAt, &simulatedWeatherJSON)
 
 	if err != nil {
 		http.Error(w, "Simulation not found", http.StatusNotFound)
 		return
 	}
 
-	if status != "completed" {
+	if status != "completed" && status != simulation.RunStatusPartial {
 		http.Error(w, "Simulation not yet complete", http.StatusAccepted)
 		return
 	}
@@ -415,6 +828,8 @@ func (s *Server) simulationResultHandler(w http.ResponseWriter, r *http.Request)
 		HomeScoreDistribution: aggregatedResult.HomeScoreDistribution,
 		AwayScoreDistribution: aggregatedResult.AwayScoreDistribution,
 		PlayerPerformance:     aggregatedResult.PlayerPerformance,
+		Markets:               aggregatedResult.Markets,
+		InningScoring:         aggregatedResult.InningScoring,
 		Metadata: map[string]interface{}{
 			"average_game_duration": aggregatedResult.AverageGameDuration,
 			"average_pitches":       aggregatedResult.AveragePitches,
@@ -423,6 +838,38 @@ func (s *Server) simulationResultHandler(w http.ResponseWriter, r *http.Request)
 		},
 	}
 
+	// Runs created before fingerprinting have no engine version stored
+	if engineVersion != nil {
+		result.Fingerprint = &simulation.RunFingerprint{EngineVersion: *engineVersion, DataSnapshotAt: dataSnapshotAt}
+		if modelParamHash != nil {
+			result.Fingerprint.ModelParamHash = *modelParamHash
+		}
+	}
+
+	// The weather the engine actually simulated, which may be a fresher
+	// forecast than the game's stored conditions
+	if len(simulatedWeatherJSON) > 0 {
+		var simulatedWeather models.Weather
+		if json.Unmarshal(simulatedWeatherJSON, &simulatedWeather) == nil {
+			result.Metadata["simulated_weather"] = simulatedWeather
+		}
+	}
+
+	// Pitchers whose strikeout and home run rates were adjusted for the cold
+	if coldPitchers := aggregatedResult.PlayerPerformance.ColdWeatherPitchers(); len(coldPitchers) > 0 {
+		result.Metadata["cold_weather_pitchers"] = coldPitchers
+	}
+
+	// Runs stopped at their time budget report the achieved sample size
+	if status == simulation.RunStatusPartial {
+		partial := aggregatedResult.Partial
+		if partial == nil {
+			partial = models.NewPartialRunMetadata(totalRuns, aggregatedResult.TotalSimulations, aggregatedResult.HomeWinProbability)
+		}
+		result.Metadata["status"] = status
+		result.Metadata["partial"] = partial
+	}
+
 	// Add simulation context (weather, park, umpire) if available
 	if err == nil {
 		// Parse and add weather
@@ -478,10 +925,12 @@ type DailySimulationRequest struct {
 	Date           string                 `json:"date"`            // YYYY-MM-DD format, defaults to today
 	SimulationRuns int                    `json:"simulation_runs"` // Optional override
 	Config         map[string]interface{} `json:"config,omitempty"`
+	BullpenFatigue *BullpenFatigueOptions `json:"bullpen_fatigue,omitempty"` // Optional reliever workload carry-over
 }
 
 // DailySimulationResponse contains all simulations for the day
 type DailySimulationResponse struct {
+	BatchID      string              `json:"batch_id"`
 	Date         string              `json:"date"`
 	GamesCount   int                 `json:"games_count"`
 	Simulations  []GameSimulation    `json:"simulations"`
@@ -519,128 +968,115 @@ func (s *Server) simulateDailyHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Query scheduled games for the target date
-	query := `
-		SELECT g.game_id, ht.name as home_team, at.name as away_team
-		FROM games g
-		JOIN teams ht ON g.home_team_id = ht.id
-		JOIN teams at ON g.away_team_id = at.id
-		WHERE g.game_date = $1 AND g.status = 'scheduled'
-		ORDER BY g.game_time
-	`
+	batch := BatchSimulationRequest{
+		BatchFilters:   BatchFilters{Date: targetDate.Format("2006-01-02")},
+		SimulationRuns: req.SimulationRuns,
+		Config:         req.Config,
+		BullpenFatigue: req.BullpenFatigue,
+	}
+	if !s.validateBatchRequest(w, r, &batch) {
+		return
+	}
 
-	rows, err := s.db.Query(r.Context(), query, targetDate)
+	response, err := s.startDailyBatch(r.Context(), batch)
 	if err != nil {
-		log.Printf("Failed to query games: %v", err)
+		if _, ok := err.(batchFilterError); ok {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Failed to start daily batch: %v", err)
 		http.Error(w, "Failed to query games", http.StatusInternalServerError)
 		return
 	}
-	defer rows.Close()
 
-	var games []struct {
-		GameID   string
-		HomeTeam string
-		AwayTeam string
-	}
+	writeJSON(w, response)
+}
 
-	for rows.Next() {
-		var game struct {
-			GameID   string
-			HomeTeam string
-			AwayTeam string
-		}
-		if err := rows.Scan(&game.GameID, &game.HomeTeam, &game.AwayTeam); err != nil {
-			log.Printf("Error scanning game: %v", err)
-			continue
-		}
-		games = append(games, game)
+// startDailyBatch starts a batch over a single date's games, which becomes
+// that date's daily simulations, and builds its digest once the runs finish
+func (s *Server) startDailyBatch(ctx context.Context, req BatchSimulationRequest) (*DailySimulationResponse, error) {
+	batch, err := s.startBatch(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(games) == 0 {
-		response := DailySimulationResponse{
-			Date:        targetDate.Format("2006-01-02"),
-			GamesCount:  0,
-			Simulations: []GameSimulation{},
-			StartedAt:   time.Now(),
-			Message:     "No scheduled games found for this date",
-		}
-		writeJSON(w, response)
-		return
+	message := batch.Message
+	if batch.GamesCount == 0 {
+		message = "No scheduled games found for this date"
+	} else {
+		// Build the homepage digest once every game's run has finished
+		go s.watchDailyDigest(batch.BatchID, req.Date)
 	}
 
-	// Start simulations for all games
-	simulationRuns := req.SimulationRuns
-	if simulationRuns == 0 {
-		simulationRuns = s.config.SimulationRuns
+	return &DailySimulationResponse{
+		BatchID:     batch.BatchID,
+		Date:        req.Date,
+		GamesCount:  batch.GamesCount,
+		Simulations: batch.Simulations,
+		StartedAt:   batch.StartedAt,
+		Message:     message,
+	}, nil
+}
+
+// reloadParamsHandler re-reads engine_parameters so calibration changes apply
+// to new runs without a redeploy. Runs already in progress are unaffected.
+func (s *Server) reloadParamsHandler(w http.ResponseWriter, r *http.Request) {
+	changed, err := s.simEngine.ReloadTuningParameters(r.Context())
+	if err != nil {
+		log.Printf("Failed to reload engine parameters: %v", err)
+		http.Error(w, fmt.Sprintf("Failed to reload engine parameters: %v", err), http.StatusInternalServerError)
+		return
 	}
+	log.Printf("Reloaded engine parameters; changed: %v", changed)
 
-	var simulations []GameSimulation
-
-	for _, game := range games {
-		// Create simulation run for this game
-		runID := uuid.New().String()
-
-		// Validate game exists in database
-		var gameExists bool
-		err := s.db.QueryRow(r.Context(),
-			"SELECT EXISTS(SELECT 1 FROM games WHERE game_id = $1)",
-			game.GameID).Scan(&gameExists)
-
-		if err != nil || !gameExists {
-			simulations = append(simulations, GameSimulation{
-				GameID:   game.GameID,
-				HomeTeam: game.HomeTeam,
-				AwayTeam: game.AwayTeam,
-				RunID:    runID,
-				Status:   "error",
-				Error:    "Game not found in database",
-			})
-			continue
-		}
+	writeJSON(w, map[string]interface{}{
+		"model_param_hash": models.ModelParameterHash(),
+		"changed":          changed,
+		"parameters":       models.CurrentTuningParameters().Values(),
+	})
+}
 
-		// Insert simulation run
-		configJSON, _ := json.Marshal(req.Config)
-		_, err = s.db.Exec(r.Context(), `
-			INSERT INTO simulation_runs (id, game_id, config, total_runs, status)
-			VALUES ($1, (SELECT id FROM games WHERE game_id = $2), $3, $4, 'pending')
-		`, runID, game.GameID, configJSON, simulationRuns)
+// invalidateCacheHandler drops cached rosters, pre-warmed games and resolved
+// IDs after a data refresh or alias load so new runs pick up the latest
+// players, stats and aliases. Runs already in progress are unaffected.
+func (s *Server) invalidateCacheHandler(w http.ResponseWriter, r *http.Request) {
+	evicted := s.simEngine.InvalidateRosterCache()
+	gamesEvicted := s.simEngine.InvalidateWarmPool()
+	s.simEngine.ClearResolvedIDs()
+	log.Printf("Invalidated roster cache; %d rosters and %d pre-warmed games evicted", evicted, gamesEvicted)
+
+	writeJSON(w, map[string]interface{}{
+		"rosters_evicted": evicted,
+		"games_evicted":   gamesEvicted,
+		"rosters":         s.simEngine.RosterCacheStats(),
+		"warm_pool":       s.simEngine.WarmPoolStats(),
+	})
+}
 
+// prewarmHandler pre-loads game context and rosters for every scheduled game
+// on ?date= (default today) so simulations of them start without DB loads
+func (s *Server) prewarmHandler(w http.ResponseWriter, r *http.Request) {
+	date := time.Now()
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
 		if err != nil {
-			log.Printf("Failed to create simulation run for game %s: %v", game.GameID, err)
-			simulations = append(simulations, GameSimulation{
-				GameID:   game.GameID,
-				HomeTeam: game.HomeTeam,
-				AwayTeam: game.AwayTeam,
-				RunID:    runID,
-				Status:   "error",
-				Error:    fmt.Sprintf("Failed to create simulation: %v", err),
-			})
-			continue
+			http.Error(w, "Invalid date format, use YYYY-MM-DD", http.StatusBadRequest)
+			return
 		}
-
-		// Start simulation in background
-		go s.simEngine.RunSimulation(runID, game.GameID, simulationRuns, req.Config)
-
-		simulations = append(simulations, GameSimulation{
-			GameID:   game.GameID,
-			HomeTeam: game.HomeTeam,
-			AwayTeam: game.AwayTeam,
-			RunID:    runID,
-			Status:   "started",
-		})
-
-		log.Printf("Started simulation for game %s (%s vs %s)", game.GameID, game.AwayTeam, game.HomeTeam)
+		date = parsed
 	}
 
-	response := DailySimulationResponse{
-		Date:        targetDate.Format("2006-01-02"),
-		GamesCount:  len(games),
-		Simulations: simulations,
-		StartedAt:   time.Now(),
-		Message:     fmt.Sprintf("Started simulations for %d games", len(simulations)),
-	}
+	ctx, cancel := context.WithTimeout(r.Context(), 50*time.Second)
+	defer cancel()
 
-	writeJSON(w, response)
+	report, err := s.simEngine.PrewarmGames(ctx, date)
+	if err != nil {
+		log.Printf("Pre-warm failed for %s: %v", date.Format("2006-01-02"), err)
+		http.Error(w, "Failed to pre-warm games", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Pre-warmed %d/%d games for %s in %dms", report.Warmed, report.Games, report.Date, report.DurationMS)
+	writeJSON(w, report)
 }
 
 // Middleware
@@ -699,6 +1135,14 @@ func getEnv(key, defaultValue string) string {
 func main() {
 	config := NewConfig()
 
+	// `sim-engine replay-dead-letters` writes spilled results and exits
+	if len(os.Args) > 1 && os.Args[1] == "replay-dead-letters" {
+		if err := replayDeadLettersCommand(config); err != nil {
+			log.Fatal("Dead-letter replay failed: ", err)
+		}
+		return
+	}
+
 	server, err := NewServer(config)
 	if err != nil {
 		log.Fatal("Failed to create server:", err)
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// AvailabilityImpact is how a questionable player's availability moved a
+// run's home win probability. The run rolls whether they play in each
+// simulated game, so its games split into those with and without them.
+type AvailabilityImpact struct {
+	PlayerID        string  `json:"player_id"`
+	PlayerName      string  `json:"player_name,omitempty"`
+	Team            string  `json:"team"` // home or away
+	PlayProbability float64 `json:"play_probability"`
+	GamesPlayed     int     `json:"games_played"`
+	GamesSidelined  int     `json:"games_sidelined"`
+
+	// Home win probability in the games they played and sat out; nil when
+	// there were none
+	HomeWinProbabilityPlaying   *float64 `json:"home_win_probability_playing"`
+	HomeWinProbabilitySidelined *float64 `json:"home_win_probability_sidelined"`
+
+	// Swing is playing less sidelined, nil unless both happened
+	Swing *float64 `json:"home_win_probability_swing"`
+}
+
+// NewAvailabilityImpact splits a run's results by whether the player sat out
+func NewAvailabilityImpact(playerID, playerName, team string, playProbability float64, results []SimulationResult) AvailabilityImpact {
+	impact := AvailabilityImpact{
+		PlayerID:        playerID,
+		PlayerName:      playerName,
+		Team:            team,
+		PlayProbability: playProbability,
+	}
+	var winsPlaying, winsSidelined int
+	for _, result := range results {
+		sidelined := false
+		for _, id := range result.Sidelined {
+			if id == playerID {
+				sidelined = true
+				break
+			}
+		}
+		homeWin := 0
+		if result.Winner == "home" {
+			homeWin = 1
+		}
+		if sidelined {
+			impact.GamesSidelined++
+			winsSidelined += homeWin
+		} else {
+			impact.GamesPlayed++
+			winsPlaying += homeWin
+		}
+	}
+
+	var playing, sidelined float64
+	if impact.GamesPlayed > 0 {
+		playing = float64(winsPlaying) / float64(impact.GamesPlayed)
+		p := roundProbability(playing)
+		impact.HomeWinProbabilityPlaying = &p
+	}
+	if impact.GamesSidelined > 0 {
+		sidelined = float64(winsSidelined) / float64(impact.GamesSidelined)
+		p := roundProbability(sidelined)
+		impact.HomeWinProbabilitySidelined = &p
+	}
+	if impact.GamesPlayed > 0 && impact.GamesSidelined > 0 {
+		swing := roundProbability(playing - sidelined)
+		impact.Swing = &swing
+	}
+	return impact
+}
+
+// SortAvailabilityImpacts orders impacts by the size of their swing, largest
+// first, with players who never or always played last
+func SortAvailabilityImpacts(impacts []AvailabilityImpact) {
+	size := func(impact AvailabilityImpact) float64 {
+		if impact.Swing == nil {
+			return -1
+		}
+		return math.Abs(*impact.Swing)
+	}
+	sort.Slice(impacts, func(i, j int) bool {
+		if si, sj := size(impacts[i]), size(impacts[j]); si != sj {
+			return si > sj
+		}
+		return impacts[i].PlayerID < impacts[j].PlayerID
+	})
+}
@@ -0,0 +1,37 @@
+package models
+
+import "testing"
+
+func TestNewAvailabilityImpact(t *testing.T) {
+	results := []SimulationResult{
+		{Winner: "home"},
+		{Winner: "away"},
+		{Winner: "home"},
+		{Winner: "away", Sidelined: []string{"p1"}},
+		{Winner: "home", Sidelined: []string{"p2", "p1"}},
+		{Winner: "away", Sidelined: []string{"p1"}},
+	}
+
+	impact := NewAvailabilityImpact("p1", "Questionable", "home", 0.5, results)
+	if impact.GamesPlayed != 3 || impact.GamesSidelined != 3 {
+		t.Fatalf("played %d, sidelined %d; want 3 each", impact.GamesPlayed, impact.GamesSidelined)
+	}
+	if *impact.HomeWinProbabilityPlaying != 0.6667 || *impact.HomeWinProbabilitySidelined != 0.3333 {
+		t.Errorf("win probabilities %v and %v, want 0.6667 and 0.3333",
+			*impact.HomeWinProbabilityPlaying, *impact.HomeWinProbabilitySidelined)
+	}
+	if *impact.Swing != 0.3333 {
+		t.Errorf("swing = %v, want 0.3333", *impact.Swing)
+	}
+
+	always := NewAvailabilityImpact("p3", "", "away", 0.9, results)
+	if always.HomeWinProbabilitySidelined != nil || always.Swing != nil {
+		t.Error("a player who never sat has no sidelined probability or swing")
+	}
+
+	impacts := []AvailabilityImpact{always, impact}
+	SortAvailabilityImpacts(impacts)
+	if impacts[0].PlayerID != "p1" {
+		t.Error("impacts with a swing sort before those without")
+	}
+}
@@ -0,0 +1,116 @@
+package models
+
+import "math"
+
+const (
+	// LeagueStealSuccessRate is the league-average share of stolen base
+	// attempts that succeed
+	LeagueStealSuccessRate = 0.78
+
+	// League-average steal attempts per plate appearance by a runner on
+	// first with second open, and by a runner on second with third open
+	baseStealSecondAttemptRate = 0.05
+	baseStealThirdAttemptRate  = 0.008
+
+	// Change in the log-odds of a successful steal per point (20-80 scale)
+	// of runner speed, pitcher delivery and catcher arm above average
+	stealSpeedLogOdds    = 0.04
+	stealDeliveryLogOdds = 0.025
+	stealArmLogOdds      = 0.03
+
+	// Runners try more often when they are faster: attempts grow by about
+	// half per 10 points of speed
+	stealAttemptSpeedGrowth = 0.04
+
+	// armPointsPerRun converts a catcher's arm runs into 20-80 points
+	armPointsPerRun = 2.0
+)
+
+// TeamBaserunning counts a batting team's steal attempts over one
+// simulated game
+type TeamBaserunning struct {
+	StolenBases    int `json:"stolen_bases"`
+	CaughtStealing int `json:"caught_stealing"`
+}
+
+// Add accumulates another game's counts
+func (b *TeamBaserunning) Add(other TeamBaserunning) {
+	b.StolenBases += other.StolenBases
+	b.CaughtStealing += other.CaughtStealing
+}
+
+// Attempts is stolen bases plus caught stealing
+func (b TeamBaserunning) Attempts() int {
+	return b.StolenBases + b.CaughtStealing
+}
+
+// SuccessRate is the share of attempts that succeeded
+func (b TeamBaserunning) SuccessRate() float64 {
+	if b.Attempts() == 0 {
+		return 0
+	}
+	return float64(b.StolenBases) / float64(b.Attempts())
+}
+
+// BaserunningSummary is both batting teams' steal counts over one
+// simulated game
+type BaserunningSummary struct {
+	Home TeamBaserunning `json:"home"`
+	Away TeamBaserunning `json:"away"`
+}
+
+// GetCatcherArmRating returns a catcher's arm against the running game on
+// the 20-80 scale. Arm runs are preferred when known, then the ARM rating;
+// a nil catcher or one without either is average.
+func GetCatcherArmRating(catcher *Player) float64 {
+	if catcher == nil {
+		return 50
+	}
+	rating := 50.0
+	if catcher.Fielding.ArmRuns != 0 {
+		rating = 50 + catcher.Fielding.ArmRuns*armPointsPerRun
+	} else if catcher.Fielding.ARM > 0 {
+		rating = catcher.Fielding.ARM
+	}
+	return math.Max(20, math.Min(80, rating))
+}
+
+// GetStealSuccessRate returns the chance a runner of the given speed (20-80)
+// steals the next base. A quick delivery and a strong catcher's arm cut it.
+// A nil pitcher or catcher is treated as league average.
+func GetStealSuccessRate(runnerSpeed int, pitcher, catcher *Player) float64 {
+	delivery := 50
+	if pitcher != nil && pitcher.Attributes.Delivery > 0 {
+		delivery = pitcher.Attributes.Delivery
+	}
+
+	logOdds := math.Log(LeagueStealSuccessRate / (1 - LeagueStealSuccessRate))
+	logOdds += stealSpeedLogOdds * float64(speedOrAverage(runnerSpeed)-50)
+	logOdds -= stealDeliveryLogOdds * float64(delivery-50)
+	logOdds -= stealArmLogOdds * (GetCatcherArmRating(catcher) - 50)
+
+	rate := 1 / (1 + math.Exp(-logOdds))
+	return math.Max(0.35, math.Min(0.97, rate))
+}
+
+// GetStealAttemptRate returns the chance a runner tries to steal targetBase
+// (2 or 3) before a plate appearance. Faster runners go more often, and all
+// runners go less when the battery makes success unlikely.
+func GetStealAttemptRate(runnerSpeed, targetBase int, successRate float64) float64 {
+	base := baseStealSecondAttemptRate
+	if targetBase == 3 {
+		base = baseStealThirdAttemptRate
+	}
+
+	rate := base * math.Exp(stealAttemptSpeedGrowth*float64(speedOrAverage(runnerSpeed)-50))
+	rate *= math.Pow(successRate/LeagueStealSuccessRate, 3)
+	return math.Min(0.4, rate)
+}
+
+// speedOrAverage treats a missing speed grade as average
+func speedOrAverage(speed int) int {
+	if speed <= 0 {
+		return 50
+	}
+	return speed
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGetStealSuccessRate tests runner speed, delivery and catcher arm
+func TestGetStealSuccessRate(t *testing.T) {
+	if rate := GetStealSuccessRate(50, nil, nil); math.Abs(rate-LeagueStealSuccessRate) > 1e-9 {
+		t.Errorf("Expected league rate %f for an average matchup, got %f", LeagueStealSuccessRate, rate)
+	}
+	if rate := GetStealSuccessRate(0, &Player{}, &Player{}); math.Abs(rate-LeagueStealSuccessRate) > 1e-9 {
+		t.Errorf("Expected missing grades to count as average, got %f", rate)
+	}
+
+	if GetStealSuccessRate(70, nil, nil) <= GetStealSuccessRate(40, nil, nil) {
+		t.Error("Expected faster runners to succeed more often")
+	}
+
+	quick := &Player{Attributes: PlayerAttributes{Delivery: 70}}
+	slow := &Player{Attributes: PlayerAttributes{Delivery: 35}}
+	if GetStealSuccessRate(50, quick, nil) >= GetStealSuccessRate(50, slow, nil) {
+		t.Error("Expected a quick delivery to cut the success rate")
+	}
+
+	strongArm := &Player{Fielding: FieldingStats{ArmRuns: 5}}
+	weakArm := &Player{Fielding: FieldingStats{ARM: 35}}
+	if GetStealSuccessRate(50, nil, strongArm) >= GetStealSuccessRate(50, nil, weakArm) {
+		t.Error("Expected a strong catcher's arm to cut the success rate")
+	}
+
+	if rate := GetStealSuccessRate(80, slow, &Player{Fielding: FieldingStats{ARM: 20}}); rate > 0.97 {
+		t.Errorf("Expected success capped at 0.97, got %f", rate)
+	}
+}
+
+func TestGetCatcherArmRating(t *testing.T) {
+	if rating := GetCatcherArmRating(nil); rating != 50 {
+		t.Errorf("Expected an average arm without a catcher, got %f", rating)
+	}
+	if rating := GetCatcherArmRating(&Player{Fielding: FieldingStats{ArmRuns: 4, ARM: 30}}); rating != 58 {
+		t.Errorf("Expected arm runs preferred over ARM, got %f", rating)
+	}
+	if rating := GetCatcherArmRating(&Player{Fielding: FieldingStats{ARM: 65}}); rating != 65 {
+		t.Errorf("Expected the ARM rating, got %f", rating)
+	}
+	if rating := GetCatcherArmRating(&Player{Fielding: FieldingStats{ArmRuns: 30}}); rating != 80 {
+		t.Errorf("Expected the rating capped at 80, got %f", rating)
+	}
+}
+
+func TestGetStealAttemptRate(t *testing.T) {
+	second := GetStealAttemptRate(50, 2, LeagueStealSuccessRate)
+	if math.Abs(second-baseStealSecondAttemptRate) > 1e-9 {
+		t.Errorf("Expected league attempt rate %f, got %f", baseStealSecondAttemptRate, second)
+	}
+	if GetStealAttemptRate(50, 3, LeagueStealSuccessRate) >= second {
+		t.Error("Expected fewer attempts at third than at second")
+	}
+	if GetStealAttemptRate(65, 2, LeagueStealSuccessRate) <= second {
+		t.Error("Expected faster runners to try more often")
+	}
+	if GetStealAttemptRate(50, 2, 0.6) >= second {
+		t.Error("Expected fewer attempts when success is unlikely")
+	}
+	if rate := GetStealAttemptRate(80, 2, 0.97); rate > 0.4 {
+		t.Errorf("Expected attempts capped at 0.4, got %f", rate)
+	}
+}
+
+func TestTeamBaserunning(t *testing.T) {
+	var running TeamBaserunning
+	if running.SuccessRate() != 0 {
+		t.Error("Expected zero success rate without attempts")
+	}
+	running.Add(TeamBaserunning{StolenBases: 2, CaughtStealing: 1})
+	running.Add(TeamBaserunning{StolenBases: 1})
+	if running.Attempts() != 4 || running.SuccessRate() != 0.75 {
+		t.Errorf("Expected 3 of 4 attempts successful, got %+v", running)
+	}
+}
@@ -0,0 +1,314 @@
+package models
+
+import "sort"
+
+const (
+	// StarterPitchLimit is the pitch count after which a starter is relieved
+	// at the end of an inning
+	StarterPitchLimit = 100
+
+	// Relievers work an inning, or until they reach RelieverPitchLimit
+	RelieverMaxOuts    = 3
+	RelieverPitchLimit = 30
+
+	// Bullpen fatigue defaults
+	DefaultDayAfterAvailability = 0.5
+	DefaultMaxConsecutiveDays   = 2
+	DefaultHeavyUsagePitches    = 30
+
+	// MaxFatigueDays bounds MaxConsecutiveDays and how far back usage counts
+	MaxFatigueDays = 5
+
+	// MinBattersFaced is the three-batter minimum: a pitcher faces this many
+	// batters, or finishes the half-inning, before being removed
+	MinBattersFaced = 3
+
+	// Mid-inning platoon changes start in this inning and need a reliever
+	// whose expected wOBA against the next MinBattersFaced batters is at
+	// least PlatoonChangeMargin below the current pitcher's
+	PlatoonChangeInning = 7
+	PlatoonChangeMargin = 0.020
+)
+
+// BullpenFatigueRules decide how recent work limits a reliever's availability
+type BullpenFatigueRules struct {
+	// DayAfterAvailability is the chance a reliever who pitched the day
+	// before can pitch today
+	DayAfterAvailability float64 `json:"day_after_availability"`
+
+	// MaxConsecutiveDays is how many days in a row a reliever can pitch; one
+	// who pitched that many days running up to yesterday is unavailable
+	MaxConsecutiveDays int `json:"max_consecutive_days"`
+
+	// HeavyUsagePitches is the pitch count that rules a reliever out the
+	// next day
+	HeavyUsagePitches float64 `json:"heavy_usage_pitches"`
+}
+
+// DefaultBullpenFatigueRules returns the built-in fatigue rules
+func DefaultBullpenFatigueRules() BullpenFatigueRules {
+	return BullpenFatigueRules{
+		DayAfterAvailability: DefaultDayAfterAvailability,
+		MaxConsecutiveDays:   DefaultMaxConsecutiveDays,
+		HeavyUsagePitches:    DefaultHeavyUsagePitches,
+	}
+}
+
+// ReliefUsage is a pitcher's work on one day before a game. Real games have
+// an appearance rate of 1; simulated ones use the share of simulations the
+// pitcher appeared in.
+type ReliefUsage struct {
+	DaysBefore     int     `json:"days_before"` // 1 = the day before the game
+	AppearanceRate float64 `json:"appearance_rate"`
+	Pitches        float64 `json:"pitches"` // thrown when appearing
+}
+
+// BullpenAvailability turns recent usage into each pitcher's chance of being
+// available, omitting pitchers who are fully rested. Appearances on different
+// days are treated as independent.
+func BullpenAvailability(usage map[string][]ReliefUsage, rules BullpenFatigueRules) map[string]float64 {
+	availability := make(map[string]float64)
+	for playerID, days := range usage {
+		var byDay [MaxFatigueDays + 1]ReliefUsage
+		for _, day := range days {
+			if day.DaysBefore >= 1 && day.DaysBefore < len(byDay) {
+				byDay[day.DaysBefore] = day
+			}
+		}
+
+		yesterday := byDay[1]
+		if yesterday.AppearanceRate <= 0 {
+			continue
+		}
+
+		// Given they pitched yesterday: ruled out by a heavy outing or by
+		// pitching every day of the consecutive-day limit
+		availableAfter := 0.0
+		if yesterday.Pitches < rules.HeavyUsagePitches {
+			streak := 1.0
+			for day := 2; day <= rules.MaxConsecutiveDays && day < len(byDay); day++ {
+				streak *= byDay[day].AppearanceRate
+			}
+			availableAfter = rules.DayAfterAvailability * (1 - streak)
+		}
+
+		rate := clampUnit(yesterday.AppearanceRate)
+		availability[playerID] = roundProbability(1 - rate + rate*availableAfter)
+	}
+	return availability
+}
+
+func clampUnit(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	if x > 1 {
+		return 1
+	}
+	return x
+}
+
+// ReliefAvailability is the chance a reliever can pitch in this game
+func (r *Roster) ReliefAvailability(playerID string) float64 {
+	if availability, ok := r.BullpenAvailability[playerID]; ok {
+		return availability
+	}
+	return 1
+}
+
+// PitchingStaff tracks one team's pitchers through a game
+type PitchingStaff struct {
+	roster      *Roster
+	Current     *Player
+	Used        []*Player       // every pitcher who has appeared, in order
+	ruledOut    map[string]bool // relievers who failed their availability roll
+	outs        int             // recorded by the current pitcher
+	pitches     int             // thrown by the current pitcher
+	batters     int             // faced by the current pitcher
+	starterDone bool            // the starter can't continue, e.g. after a long rain delay
+	starterRole string          // StarterRoleNormal unless set with SetStarterRole
+	longMan     string          // the opener's bulk pitcher or the short-rest starter's long man, once in
+
+	// PlatoonChanges counts mid-inning changes made for the matchup
+	PlatoonChanges int
+}
+
+// NewPitchingStaff starts a game with starter on the mound
+func NewPitchingStaff(roster *Roster, starter *Player) *PitchingStaff {
+	return &PitchingStaff{
+		roster:   roster,
+		Current:  starter,
+		Used:     []*Player{starter},
+		ruledOut: make(map[string]bool),
+	}
+}
+
+// Record counts one plate appearance's outs and pitches by the current
+// pitcher
+func (ps *PitchingStaff) Record(outs, pitches int) {
+	ps.outs += outs
+	ps.pitches += pitches
+	ps.batters++
+}
+
+// RecordOut counts an out made on the bases while the current pitcher is in
+func (ps *PitchingStaff) RecordOut() {
+	ps.outs++
+}
+
+// SetStarterRole plans the game around the starter working on short rest or
+// as an opener
+func (ps *PitchingStaff) SetStarterRole(role string) {
+	ps.starterRole = role
+}
+
+// NeedsReliever reports whether the current pitcher should come out before
+// the next inning
+func (ps *PitchingStaff) NeedsReliever() bool {
+	switch {
+	case len(ps.Used) == 1 && ps.starterRole == StarterRoleOpener:
+		return ps.starterDone || ps.outs >= OpenerMaxOuts || ps.pitches >= StarterPitchLimit
+	case len(ps.Used) == 1 && ps.starterRole == StarterRoleShortRest:
+		return ps.starterDone || ps.pitches >= ShortRestPitchLimit
+	case len(ps.Used) == 1:
+		return ps.starterDone || ps.pitches >= StarterPitchLimit
+	case ps.isLongMan() && ps.starterRole == StarterRoleOpener:
+		return ps.pitches >= BulkPitchLimit
+	case ps.isLongMan() && ps.starterRole == StarterRoleShortRest:
+		return ps.outs >= LongRelieverMaxOuts || ps.pitches >= LongRelieverPitchLimit
+	}
+	return ps.outs >= RelieverMaxOuts || ps.pitches >= RelieverPitchLimit
+}
+
+// isLongMan reports whether the current pitcher is the designated bulk
+// pitcher or long man
+func (ps *PitchingStaff) isLongMan() bool {
+	return ps.longMan != "" && ps.Current != nil && ps.Current.ID == ps.longMan
+}
+
+// EndStarterOuting relieves the starter, if still in, at the next chance
+func (ps *PitchingStaff) EndStarterOuting() {
+	ps.starterDone = true
+}
+
+// ChangePitcher brings in the best unused reliever who is available, trying
+// the bullpen in order. Each reliever's availability is rolled once per game
+// when first considered; roll returns a value in [0, 1). The current pitcher
+// stays in when nobody is available.
+func (ps *PitchingStaff) ChangePitcher(roll func() float64) bool {
+	relievingStarter := len(ps.Used) == 1
+
+	// An opener hands the game to the rotation's next starter, who works in
+	// bulk; without one the bullpen follows as usual
+	if ps.starterRole == StarterRoleOpener && relievingStarter {
+		for _, id := range ps.roster.Rotation {
+			if !ps.hasPitched(id) && ps.bringIn(id) {
+				ps.longMan = id
+				return true
+			}
+		}
+	}
+
+	for _, id := range ps.roster.Bullpen {
+		if ps.hasPitched(id) || ps.ruledOut[id] {
+			continue
+		}
+		if roll() >= ps.roster.ReliefAvailability(id) {
+			ps.ruledOut[id] = true
+			continue
+		}
+		if ps.bringIn(id) {
+			if ps.starterRole == StarterRoleShortRest && relievingStarter {
+				ps.longMan = id
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// PlatoonChange brings in a reliever mid-inning when one matches up clearly
+// better against the upcoming batters, LOOGY-style. The current pitcher must
+// have faced MinBattersFaced batters first. Candidates are tried best matchup
+// first, each rolling availability as in ChangePitcher.
+func (ps *PitchingStaff) PlatoonChange(upcoming []*Player, league *LeagueEnvironment, roll func() float64) bool {
+	if ps.batters < MinBattersFaced || len(upcoming) == 0 {
+		return false
+	}
+	threshold := matchupWOBA(ps.Current, upcoming, league) - PlatoonChangeMargin
+
+	type candidate struct {
+		id   string
+		woba float64
+	}
+	var candidates []candidate
+	for _, id := range ps.roster.Bullpen {
+		if ps.hasPitched(id) || ps.ruledOut[id] {
+			continue
+		}
+		reliever := ps.roster.findPlayer(id)
+		if reliever == nil {
+			continue
+		}
+		if woba := matchupWOBA(reliever, upcoming, league); woba <= threshold {
+			candidates = append(candidates, candidate{id, woba})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].woba < candidates[j].woba })
+
+	for _, c := range candidates {
+		if roll() >= ps.roster.ReliefAvailability(c.id) {
+			ps.ruledOut[c.id] = true
+			continue
+		}
+		if ps.bringIn(c.id) {
+			ps.PlatoonChanges++
+			return true
+		}
+	}
+	return false
+}
+
+// matchupWOBA is the mean expected wOBA of batters against pitcher from
+// their platoon splits alone, before situational adjustments
+func matchupWOBA(pitcher *Player, batters []*Player, league *LeagueEnvironment) float64 {
+	total := 0.0
+	for _, batter := range batters {
+		batterSplit := batter.Batting.GetSplitStats(pitcher.Hand, false, false)
+		pitcherSplit := pitcher.Pitching.GetSplitStatsForLeague(league, batter.Hand, false, false)
+		total += (batterSplit.WOBA + pitcherSplit.WOBA) / 2
+	}
+	return total / float64(len(batters))
+}
+
+// bringIn makes a rostered pitcher the current pitcher
+func (ps *PitchingStaff) bringIn(playerID string) bool {
+	reliever := ps.roster.findPlayer(playerID)
+	if reliever == nil {
+		return false
+	}
+	ps.Current = reliever
+	ps.Used = append(ps.Used, reliever)
+	ps.outs, ps.pitches, ps.batters = 0, 0, 0
+	return true
+}
+
+// findPlayer returns a copy of a rostered player, or nil
+func (r *Roster) findPlayer(playerID string) *Player {
+	for i := range r.Players {
+		if r.Players[i].ID == playerID {
+			player := r.Players[i]
+			return &player
+		}
+	}
+	return nil
+}
+
+func (ps *PitchingStaff) hasPitched(playerID string) bool {
+	for _, pitcher := range ps.Used {
+		if pitcher.ID == playerID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBullpenAvailabilityRealUsage(t *testing.T) {
+	rules := DefaultBullpenFatigueRules()
+	usage := map[string][]ReliefUsage{
+		"rested":       {{DaysBefore: 2, AppearanceRate: 1, Pitches: 15}},
+		"yesterday":    {{DaysBefore: 1, AppearanceRate: 1, Pitches: 15}},
+		"heavy":        {{DaysBefore: 1, AppearanceRate: 1, Pitches: 35}},
+		"back_to_back": {{DaysBefore: 1, AppearanceRate: 1, Pitches: 12}, {DaysBefore: 2, AppearanceRate: 1, Pitches: 12}},
+	}
+
+	availability := BullpenAvailability(usage, rules)
+	if _, ok := availability["rested"]; ok {
+		t.Error("a reliever who didn't pitch yesterday should be fully available")
+	}
+	if got := availability["yesterday"]; got != DefaultDayAfterAvailability {
+		t.Errorf("pitched yesterday: availability = %v, want %v", got, DefaultDayAfterAvailability)
+	}
+	if got := availability["heavy"]; got != 0 {
+		t.Errorf("heavy usage yesterday: availability = %v, want 0", got)
+	}
+	if got := availability["back_to_back"]; got != 0 {
+		t.Errorf("pitched the last %d days: availability = %v, want 0", DefaultMaxConsecutiveDays, got)
+	}
+}
+
+func TestBullpenAvailabilitySimulatedUsage(t *testing.T) {
+	rules := DefaultBullpenFatigueRules()
+	usage := map[string][]ReliefUsage{
+		"closer": {{DaysBefore: 1, AppearanceRate: 0.4, Pitches: 15}, {DaysBefore: 2, AppearanceRate: 0.5, Pitches: 15}},
+	}
+
+	// Not pitched (0.6) plus pitched yesterday but not the day before and
+	// passing the day-after roll (0.4 * 0.5 * 0.5)
+	want := 0.6 + 0.4*0.5*0.5
+	if got := BullpenAvailability(usage, rules)["closer"]; math.Abs(got-want) > 1e-9 {
+		t.Errorf("availability = %v, want %v", got, want)
+	}
+
+	rules.MaxConsecutiveDays = 1
+	if got := BullpenAvailability(usage, rules)["closer"]; math.Abs(got-0.6) > 1e-9 {
+		t.Errorf("with no back-to-backs allowed, availability = %v, want 0.6", got)
+	}
+}
+
+func bullpenRoster() *Roster {
+	return &Roster{
+		Players: []Player{
+			{ID: "sp", Position: "P"},
+			{ID: "rp1", Position: "P"},
+			{ID: "rp2", Position: "P"},
+		},
+		Rotation: []string{"sp"},
+		Bullpen:  []string{"rp1", "rp2"},
+	}
+}
+
+func TestPitchingStaffChanges(t *testing.T) {
+	roster := bullpenRoster()
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+
+	staff.Record(3, StarterPitchLimit-1)
+	if staff.NeedsReliever() {
+		t.Fatal("starter under the pitch limit should stay in")
+	}
+	staff.Record(0, 1)
+	if !staff.NeedsReliever() {
+		t.Fatal("starter at the pitch limit should come out")
+	}
+
+	never := func() float64 { return 0 }
+	if !staff.ChangePitcher(never) || staff.Current.ID != "rp1" {
+		t.Fatalf("expected rp1 to relieve, got %s", staff.Current.ID)
+	}
+	staff.Record(RelieverMaxOuts, 10)
+	if !staff.NeedsReliever() || !staff.ChangePitcher(never) || staff.Current.ID != "rp2" {
+		t.Fatalf("expected rp2 after rp1's inning, got %s", staff.Current.ID)
+	}
+	staff.Record(RelieverMaxOuts, 10)
+	if staff.ChangePitcher(never) {
+		t.Error("an empty bullpen should leave the current pitcher in")
+	}
+	if len(staff.Used) != 3 {
+		t.Errorf("used %d pitchers, want 3", len(staff.Used))
+	}
+}
+
+func TestPitchingStaffSkipsUnavailableRelievers(t *testing.T) {
+	roster := bullpenRoster()
+	roster.BullpenAvailability = map[string]float64{"rp1": 0}
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+
+	rolls := 0
+	roll := func() float64 { rolls++; return 0.5 }
+	if !staff.ChangePitcher(roll) || staff.Current.ID != "rp2" {
+		t.Fatalf("expected unavailable rp1 to be skipped for rp2, got %s", staff.Current.ID)
+	}
+
+	// rp1's roll stands for the rest of the game
+	rolls = 0
+	staff.ChangePitcher(roll)
+	if rolls != 0 {
+		t.Errorf("rp1 was rolled again after being ruled out")
+	}
+}
+
+func TestPitchingStaffEndStarterOuting(t *testing.T) {
+	roster := bullpenRoster()
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+	staff.Record(6, 45)
+
+	staff.EndStarterOuting()
+	if !staff.NeedsReliever() {
+		t.Fatal("a starter whose outing ended should come out")
+	}
+	if !staff.ChangePitcher(func() float64 { return 0 }) || staff.Current.ID != "rp1" {
+		t.Fatalf("expected rp1 to relieve, got %s", staff.Current.ID)
+	}
+	if staff.NeedsReliever() {
+		t.Error("ending the starter's outing shouldn't affect relievers")
+	}
+}
+
+func platoonRoster() *Roster {
+	split := func(woba float64) SplitStats { return SplitStats{WOBA: woba, PA: 200} }
+	return &Roster{
+		Players: []Player{
+			{ID: "sp", Position: "P", Hand: "R", Pitching: PitchingStats{VsLHB: split(0.360), VsRHB: split(0.300)}},
+			{ID: "rhp", Position: "P", Hand: "R", Pitching: PitchingStats{VsLHB: split(0.350), VsRHB: split(0.290)}},
+			{ID: "loogy", Position: "P", Hand: "L", Pitching: PitchingStats{VsLHB: split(0.250), VsRHB: split(0.340)}},
+		},
+		Rotation: []string{"sp"},
+		Bullpen:  []string{"rhp", "loogy"},
+	}
+}
+
+func TestPitchingStaffPlatoonChange(t *testing.T) {
+	roster := platoonRoster()
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+	lefty := &Player{ID: "lhb", Hand: "L", Batting: BattingStats{
+		VsLHP: SplitStats{WOBA: 0.280, PA: 150}, VsRHP: SplitStats{WOBA: 0.370, PA: 400}}}
+	upcoming := []*Player{lefty, lefty, lefty}
+	never := func() float64 { return 0 }
+
+	// The three-batter minimum comes first
+	staff.Record(1, 4)
+	staff.Record(0, 5)
+	if staff.PlatoonChange(upcoming, DefaultLeagueEnvironment(), never) {
+		t.Fatal("a pitcher who has faced two batters can't be removed")
+	}
+
+	staff.Record(1, 3)
+	if !staff.PlatoonChange(upcoming, DefaultLeagueEnvironment(), never) || staff.Current.ID != "loogy" {
+		t.Fatalf("expected the lefty specialist for left-handed batters, got %s", staff.Current.ID)
+	}
+	if staff.PlatoonChanges != 1 {
+		t.Errorf("platoon changes = %d, want 1", staff.PlatoonChanges)
+	}
+
+	// The new pitcher has to face three batters too
+	staff.Record(1, 4)
+	if staff.PlatoonChange(upcoming, DefaultLeagueEnvironment(), never) {
+		t.Error("a reliever who just entered must face three batters")
+	}
+}
+
+func TestPitchingStaffPlatoonChangeNeedsMargin(t *testing.T) {
+	roster := platoonRoster()
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+	righty := &Player{ID: "rhb", Hand: "R", Batting: BattingStats{
+		VsLHP: SplitStats{WOBA: 0.340, PA: 150}, VsRHP: SplitStats{WOBA: 0.310, PA: 400}}}
+	for i := 0; i < MinBattersFaced; i++ {
+		staff.Record(0, 4)
+	}
+
+	// The right-handed reliever is only slightly better against righties
+	rolls := 0
+	roll := func() float64 { rolls++; return 0 }
+	if staff.PlatoonChange([]*Player{righty, righty, righty}, DefaultLeagueEnvironment(), roll) {
+		t.Errorf("changed to %s without a clear matchup edge", staff.Current.ID)
+	}
+	if rolls != 0 {
+		t.Error("relievers without an edge shouldn't roll availability")
+	}
+}
+
+func TestPitchingStaffPlatoonChangeUnavailable(t *testing.T) {
+	roster := platoonRoster()
+	roster.BullpenAvailability = map[string]float64{"loogy": 0}
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+	lefty := &Player{ID: "lhb", Hand: "L", Batting: BattingStats{
+		VsLHP: SplitStats{WOBA: 0.280, PA: 150}, VsRHP: SplitStats{WOBA: 0.370, PA: 400}}}
+	for i := 0; i < MinBattersFaced; i++ {
+		staff.Record(0, 4)
+	}
+
+	if staff.PlatoonChange([]*Player{lefty}, DefaultLeagueEnvironment(), func() float64 { return 0.5 }) {
+		t.Errorf("brought in %s although the only edge reliever is unavailable", staff.Current.ID)
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import "math"
+
+const (
+	// Called-strike probability shift per framing run saved (season scale).
+	// An elite +15 run framer steals roughly 2% more edge strikes.
+	framingShiftPerRun = 0.0015
+
+	// League-average wild pitch + passed ball rate per plate appearance
+	// with runners on base
+	baseBatteryErrorRate = 0.011
+
+	// Share of battery errors charged as passed balls for an average catcher
+	basePassedBallShare = 0.25
+
+	// Approximate run values used to attribute catcher defense
+	runValueStrikeout = 0.28
+	runValueWalk      = 0.32
+	runValueAdvance   = 0.25
+)
+
+// CatcherImpact accumulates a catcher's run impact over one simulated game.
+// Positive values are runs saved for the defense.
+type CatcherImpact struct {
+	FramingRuns  float64 `json:"framing_runs"`
+	BlockingRuns float64 `json:"blocking_runs"`
+	WildPitches  int     `json:"wild_pitches"`
+	PassedBalls  int     `json:"passed_balls"`
+}
+
+// CatcherImpactSummary holds both teams' catcher impact for a simulated game
+type CatcherImpactSummary struct {
+	Home CatcherImpact `json:"home"`
+	Away CatcherImpact `json:"away"`
+}
+
+// GetFramingStrikeShift returns the change in called-strike probability at
+// the zone edge contributed by the catcher. Umpires who already expand the
+// edges leave less to steal, and inconsistent umpires are easier to sway.
+func GetFramingStrikeShift(catcher *Player, umpire *UmpireTendencies) float64 {
+	if catcher == nil {
+		return 0.0
+	}
+
+	shift := catcher.Fielding.FramingRuns * framingShiftPerRun
+
+	if umpire != nil {
+		edge := umpire.EdgeTendency
+		if edge <= 0 {
+			edge = 100.0
+		}
+		// Tight-edge umpires (<100) leave more borderline pitches to frame
+		edgeFactor := math.Max(0.5, math.Min(1.5, 2.0-edge/100.0))
+
+		consistency := umpire.Consistency
+		if consistency <= 0 {
+			consistency = 50.0
+		}
+		consistencyFactor := 1.0 + (50.0-consistency)/200.0
+
+		shift *= edgeFactor * consistencyFactor
+	}
+
+	// Cap the effect so even extreme framers stay realistic
+	return math.Max(-0.04, math.Min(0.04, shift))
+}
+
+// GetFramingAdjustments converts a called-strike shift into strikeout and
+// walk probability deltas plus the expected runs saved on the plate appearance
+func GetFramingAdjustments(strikeShift float64) (kDelta, bbDelta, runsSaved float64) {
+	kDelta = strikeShift * 0.5
+	bbDelta = -strikeShift * 0.4
+	runsSaved = kDelta*runValueStrikeout - bbDelta*runValueWalk
+	return kDelta, bbDelta, runsSaved
+}
+
+// GetBatteryErrorRate returns the wild pitch/passed ball probability per
+// plate appearance with runners on, scaled by the catcher's blocking.
+// A nil catcher is treated as league average.
+func GetBatteryErrorRate(catcher *Player) float64 {
+	if catcher == nil {
+		return baseBatteryErrorRate
+	}
+
+	// Each blocking run saved removes ~4% of battery errors
+	multiplier := 1.0 - catcher.Fielding.BlockingRuns*0.04
+	multiplier = math.Max(0.3, math.Min(1.7, multiplier))
+
+	return baseBatteryErrorRate * multiplier
+}
+
+// GetPassedBallShare returns the share of battery errors charged to the
+// catcher as passed balls rather than to the pitcher as wild pitches
+func GetPassedBallShare(catcher *Player) float64 {
+	if catcher == nil {
+		return basePassedBallShare
+	}
+	share := basePassedBallShare * (1.0 - catcher.Fielding.BlockingRuns*0.04)
+	return math.Max(0.05, math.Min(0.5, share))
+}
+
+// GetBlockingRunsSaved returns the expected runs saved by the catcher's
+// blocking on one plate appearance with runners on base
+func GetBlockingRunsSaved(catcher *Player) float64 {
+	return (baseBatteryErrorRate - GetBatteryErrorRate(catcher)) * runValueAdvance
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+)
+
+// TestGetFramingStrikeShift tests framing interaction with umpire tendencies
+func TestGetFramingStrikeShift(t *testing.T) {
+	elite := &Player{Fielding: FieldingStats{FramingRuns: 12}}
+	poor := &Player{Fielding: FieldingStats{FramingRuns: -8}}
+
+	if shift := GetFramingStrikeShift(nil, nil); shift != 0 {
+		t.Errorf("Expected no shift without a catcher, got %f", shift)
+	}
+
+	if shift := GetFramingStrikeShift(elite, nil); shift <= 0 {
+		t.Errorf("Expected positive shift for elite framer, got %f", shift)
+	}
+
+	if shift := GetFramingStrikeShift(poor, nil); shift >= 0 {
+		t.Errorf("Expected negative shift for poor framer, got %f", shift)
+	}
+
+	tight := &UmpireTendencies{EdgeTendency: 90, Consistency: 50}
+	wide := &UmpireTendencies{EdgeTendency: 110, Consistency: 50}
+	if GetFramingStrikeShift(elite, tight) <= GetFramingStrikeShift(elite, wide) {
+		t.Error("Expected framing to matter more with a tight-edge umpire")
+	}
+
+	consistent := &UmpireTendencies{EdgeTendency: 100, Consistency: 90}
+	inconsistent := &UmpireTendencies{EdgeTendency: 100, Consistency: 20}
+	if GetFramingStrikeShift(elite, inconsistent) <= GetFramingStrikeShift(elite, consistent) {
+		t.Error("Expected framing to matter more with an inconsistent umpire")
+	}
+
+	extreme := &Player{Fielding: FieldingStats{FramingRuns: 200}}
+	if shift := GetFramingStrikeShift(extreme, tight); shift > 0.04 {
+		t.Errorf("Expected shift capped at 0.04, got %f", shift)
+	}
+}
+
+// TestGetFramingAdjustments tests conversion of strike shift to K/BB deltas
+func TestGetFramingAdjustments(t *testing.T) {
+	kDelta, bbDelta, runsSaved := GetFramingAdjustments(0.02)
+	if kDelta <= 0 || bbDelta >= 0 || runsSaved <= 0 {
+		t.Errorf("Expected more Ks, fewer BBs and runs saved, got k=%f bb=%f runs=%f", kDelta, bbDelta, runsSaved)
+	}
+
+	_, _, runsLost := GetFramingAdjustments(-0.02)
+	if runsLost >= 0 {
+		t.Errorf("Expected runs lost for negative shift, got %f", runsLost)
+	}
+}
+
+// TestGetBatteryErrorRate tests blocking effect on wild pitches and passed balls
+func TestGetBatteryErrorRate(t *testing.T) {
+	average := GetBatteryErrorRate(nil)
+	good := GetBatteryErrorRate(&Player{Fielding: FieldingStats{BlockingRuns: 5}})
+	bad := GetBatteryErrorRate(&Player{Fielding: FieldingStats{BlockingRuns: -5}})
+
+	if !(good < average && average < bad) {
+		t.Errorf("Expected good < average < bad, got %f, %f, %f", good, average, bad)
+	}
+
+	if runs := GetBlockingRunsSaved(nil); runs != 0 {
+		t.Errorf("Expected no blocking runs for average catcher, got %f", runs)
+	}
+
+	if runs := GetBlockingRunsSaved(&Player{Fielding: FieldingStats{BlockingRuns: 5}}); runs <= 0 {
+		t.Errorf("Expected positive blocking runs for good blocker, got %f", runs)
+	}
+
+	floor := GetBatteryErrorRate(&Player{Fielding: FieldingStats{BlockingRuns: 100}})
+	if floor < baseBatteryErrorRate*0.3-1e-9 {
+		t.Errorf("Expected rate floored at 30%% of average, got %f", floor)
+	}
+}
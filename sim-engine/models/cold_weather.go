@@ -0,0 +1,111 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// DefaultColdWeatherThreshold is the temperature (°F) below which
+	// pitchers lose velocity and spin
+	DefaultColdWeatherThreshold = 45
+
+	// Strikeout rate lost per degree below the threshold by a pitcher with a
+	// league-average K/9. Strikeouts lean on velocity and spin, so the loss
+	// scales with how much a pitcher relies on them.
+	coldStrikeoutLossPerDegree = 0.008
+	maxColdStrikeoutLoss       = 0.15
+
+	// Home run rate gained per degree below the threshold. Flatter pitches get
+	// squared up more regardless of pitcher type; the ball's reduced carry is
+	// already in the batter-side weather adjustment.
+	coldHomeRunGainPerDegree = 0.004
+	maxColdHomeRunGain       = 0.08
+
+	// coldStuffReliance bounds how far K/9 scales the strikeout loss
+	coldStuffRelianceMin = 0.5
+	coldStuffRelianceMax = 1.5
+
+	leagueKPer9 = 8.5
+)
+
+// ColdWeatherPenalty is how cold weather changes one pitcher's strikeout and
+// home run rates
+type ColdWeatherPenalty struct {
+	Temperature  int     `json:"temperature"`
+	Threshold    int     `json:"threshold"`
+	KMultiplier  float64 `json:"k_multiplier"`  // applied to the strikeout probability
+	HRMultiplier float64 `json:"hr_multiplier"` // applied to the home run probability on contact
+}
+
+// PitcherColdWeatherPenalty is a penalty applied to a named pitcher
+type PitcherColdWeatherPenalty struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	ColdWeatherPenalty
+}
+
+// ColdWeatherPitcherPenalty returns the penalty for pitching at temperature,
+// or nil when it's at or above threshold. A temperature of 0 means there's no
+// weather for the game and is neutral.
+func ColdWeatherPitcherPenalty(pitcher *Player, temperature, threshold int) *ColdWeatherPenalty {
+	if temperature == 0 {
+		return nil
+	}
+	degreesBelow := float64(threshold - temperature)
+	if degreesBelow <= 0 {
+		return nil
+	}
+
+	reliance := 1.0
+	if pitcher != nil && pitcher.Pitching.KPer9 > 0 {
+		reliance = math.Max(coldStuffRelianceMin, math.Min(coldStuffRelianceMax, pitcher.Pitching.KPer9/leagueKPer9))
+	}
+	kLoss := math.Min(maxColdStrikeoutLoss, degreesBelow*coldStrikeoutLossPerDegree*reliance)
+	hrGain := math.Min(maxColdHomeRunGain, degreesBelow*coldHomeRunGainPerDegree)
+
+	return &ColdWeatherPenalty{
+		Temperature:  temperature,
+		Threshold:    threshold,
+		KMultiplier:  math.Round((1-kLoss)*1000) / 1000,
+		HRMultiplier: math.Round((1+hrGain)*1000) / 1000,
+	}
+}
+
+// ColdWeatherPenaltyFor returns the penalty the game's weather applies to
+// pitcher, or nil in normal conditions and under a roof
+func (gs *GameState) ColdWeatherPenaltyFor(pitcher *Player) *ColdWeatherPenalty {
+	if gs.Indoor {
+		return nil
+	}
+	threshold := gs.ColdWeatherThreshold
+	if threshold == 0 {
+		threshold = DefaultColdWeatherThreshold
+	}
+	return ColdWeatherPitcherPenalty(pitcher, gs.Weather.Temperature, threshold)
+}
+
+// ColdWeatherPitchers lists the pitchers who were penalized for the cold,
+// home staff first
+func (p *AggregatedPlayerPerformance) ColdWeatherPitchers() []PitcherColdWeatherPenalty {
+	if p == nil {
+		return nil
+	}
+	var penalties []PitcherColdWeatherPenalty
+	for _, staff := range []map[string]PlayerPitchingStats{p.HomeTeam.Pitching, p.AwayTeam.Pitching} {
+		start := len(penalties)
+		for id, stats := range staff {
+			if stats.ColdWeatherPenalty == nil {
+				continue
+			}
+			penalties = append(penalties, PitcherColdWeatherPenalty{
+				PlayerID:           id,
+				PlayerName:         stats.PlayerName,
+				ColdWeatherPenalty: *stats.ColdWeatherPenalty,
+			})
+		}
+		staffPenalties := penalties[start:]
+		sort.Slice(staffPenalties, func(i, j int) bool { return staffPenalties[i].PlayerID < staffPenalties[j].PlayerID })
+	}
+	return penalties
+}
@@ -0,0 +1,90 @@
+package models
+
+import "testing"
+
+func TestColdWeatherPitcherPenaltyAboveThreshold(t *testing.T) {
+	if penalty := ColdWeatherPitcherPenalty(&Player{}, 45, 45); penalty != nil {
+		t.Errorf("penalty at threshold = %+v, want nil", penalty)
+	}
+	if penalty := ColdWeatherPitcherPenalty(&Player{}, 72, 45); penalty != nil {
+		t.Errorf("penalty at 72°F = %+v, want nil", penalty)
+	}
+}
+
+func TestColdWeatherPitcherPenaltyScalesStrikeoutsByStuff(t *testing.T) {
+	average := &Player{Pitching: PitchingStats{KPer9: 8.5}}
+	power := &Player{Pitching: PitchingStats{KPer9: 12.75}}
+	finesse := &Player{Pitching: PitchingStats{KPer9: 4.0}}
+
+	avg := ColdWeatherPitcherPenalty(average, 35, 45)
+	pow := ColdWeatherPitcherPenalty(power, 35, 45)
+	fin := ColdWeatherPitcherPenalty(finesse, 35, 45)
+
+	// 10 degrees below: 8% for an average pitcher, 1.5x for a power arm,
+	// floored at 0.5x for a finesse pitcher
+	if avg.KMultiplier != 0.92 || pow.KMultiplier != 0.88 || fin.KMultiplier != 0.96 {
+		t.Errorf("K multipliers = %v / %v / %v, want 0.92 / 0.88 / 0.96", avg.KMultiplier, pow.KMultiplier, fin.KMultiplier)
+	}
+
+	// Home run gain doesn't depend on pitcher type
+	if avg.HRMultiplier != 1.04 || pow.HRMultiplier != 1.04 || fin.HRMultiplier != 1.04 {
+		t.Errorf("HR multipliers = %v / %v / %v, want 1.04", avg.HRMultiplier, pow.HRMultiplier, fin.HRMultiplier)
+	}
+}
+
+func TestColdWeatherPitcherPenaltyCaps(t *testing.T) {
+	penalty := ColdWeatherPitcherPenalty(&Player{Pitching: PitchingStats{KPer9: 14}}, 10, 45)
+	if penalty.KMultiplier != 0.85 || penalty.HRMultiplier != 1.08 {
+		t.Errorf("capped penalty = %+v, want K 0.85 and HR 1.08", penalty)
+	}
+}
+
+func TestGameStateColdWeatherThreshold(t *testing.T) {
+	gs := &GameState{Weather: Weather{Temperature: 42}}
+	if gs.ColdWeatherPenaltyFor(&Player{}) == nil {
+		t.Error("42°F should be penalized under the default threshold")
+	}
+
+	gs.ColdWeatherThreshold = 40
+	if penalty := gs.ColdWeatherPenaltyFor(&Player{}); penalty != nil {
+		t.Errorf("42°F with a 40°F threshold = %+v, want nil", penalty)
+	}
+}
+
+func TestColdWeatherPenaltyNeutralWithoutWeatherOrIndoors(t *testing.T) {
+	if penalty := ColdWeatherPitcherPenalty(&Player{}, 0, 45); penalty != nil {
+		t.Errorf("penalty without weather = %+v, want nil", penalty)
+	}
+
+	gs := &GameState{Weather: Weather{Temperature: 38}, Indoor: true}
+	if penalty := gs.ColdWeatherPenaltyFor(&Player{}); penalty != nil {
+		t.Errorf("penalty under a roof = %+v, want nil", penalty)
+	}
+}
+
+func TestColdWeatherPitchers(t *testing.T) {
+	penalty := &ColdWeatherPenalty{Temperature: 38, Threshold: 45, KMultiplier: 0.94, HRMultiplier: 1.028}
+	perf := &AggregatedPlayerPerformance{
+		HomeTeam: TeamPerformance{Pitching: map[string]PlayerPitchingStats{
+			"h2": {PlayerName: "Home Two", ColdWeatherPenalty: penalty},
+			"h1": {PlayerName: "Home One", ColdWeatherPenalty: penalty},
+		}},
+		AwayTeam: TeamPerformance{Pitching: map[string]PlayerPitchingStats{
+			"a1": {PlayerName: "Away One", ColdWeatherPenalty: penalty},
+			"a2": {PlayerName: "Away Two"},
+		}},
+	}
+
+	pitchers := perf.ColdWeatherPitchers()
+	if len(pitchers) != 3 {
+		t.Fatalf("got %d penalized pitchers, want 3", len(pitchers))
+	}
+	if pitchers[0].PlayerID != "h1" || pitchers[1].PlayerID != "h2" || pitchers[2].PlayerID != "a1" {
+		t.Errorf("order = %s, %s, %s; want h1, h2, a1", pitchers[0].PlayerID, pitchers[1].PlayerID, pitchers[2].PlayerID)
+	}
+
+	var none *AggregatedPlayerPerformance
+	if pitchers := none.ColdWeatherPitchers(); pitchers != nil {
+		t.Errorf("nil performance = %v, want nil", pitchers)
+	}
+}
@@ -0,0 +1,237 @@
+package models
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Batted ball types, classified by launch angle
+const (
+	BattedBallGround = "ground_ball"
+	BattedBallLine   = "line_drive"
+	BattedBallFly    = "fly_ball"
+	BattedBallPopup  = "popup"
+)
+
+// wOBA linear weights used for expected stats, matching the data fetcher's
+// season calculations so expected and actual wOBA are comparable
+const (
+	WOBAWeightBB  = 0.707
+	WOBAWeightHBP = 0.739
+	WOBAWeight1B  = 0.902
+	WOBAWeight2B  = 1.28
+	WOBAWeight3B  = 1.62
+	WOBAWeightHR  = 2.07
+)
+
+// BattedBall describes the contact on a ball put in play
+type BattedBall struct {
+	Type         string  `json:"type"`
+	ExitVelocity float64 `json:"exit_velocity"` // mph
+	LaunchAngle  float64 `json:"launch_angle"`  // degrees
+}
+
+// ExpectedOutcome holds the hit probabilities for a batted ball
+type ExpectedOutcome struct {
+	Single  float64 `json:"single"`
+	Double  float64 `json:"double"`
+	Triple  float64 `json:"triple"`
+	HomeRun float64 `json:"home_run"`
+}
+
+// HitProbability is the chance the ball falls for any hit (its xBA)
+func (eo ExpectedOutcome) HitProbability() float64 {
+	return eo.Single + eo.Double + eo.Triple + eo.HomeRun
+}
+
+// WOBAValue is the ball's expected wOBA numerator contribution
+func (eo ExpectedOutcome) WOBAValue() float64 {
+	return eo.Single*WOBAWeight1B + eo.Double*WOBAWeight2B + eo.Triple*WOBAWeight3B + eo.HomeRun*WOBAWeightHR
+}
+
+// TotalBases is the ball's expected total bases (its xSLG contribution)
+func (eo ExpectedOutcome) TotalBases() float64 {
+	return eo.Single + 2*eo.Double + 3*eo.Triple + 4*eo.HomeRun
+}
+
+// ClassifyLaunchAngle maps a launch angle to a batted ball type
+func ClassifyLaunchAngle(launchAngle float64) string {
+	switch {
+	case launchAngle < 10:
+		return BattedBallGround
+	case launchAngle < 25:
+		return BattedBallLine
+	case launchAngle < 50:
+		return BattedBallFly
+	default:
+		return BattedBallPopup
+	}
+}
+
+// ExpectedOutcomeFor estimates hit probabilities from exit velocity and
+// launch angle. It is a smooth approximation of the league-wide Statcast
+// tables: hard line drives fall most often, home runs need both a fly ball
+// angle near 28 degrees and 100+ mph, and popups are almost always caught.
+func ExpectedOutcomeFor(exitVelocity, launchAngle float64) ExpectedOutcome {
+	switch ClassifyLaunchAngle(launchAngle) {
+	case BattedBallGround:
+		hit := clamp(0.24+(exitVelocity-88)*0.009, 0.08, 0.55)
+		return ExpectedOutcome{Single: hit * 0.94, Double: hit * 0.06}
+
+	case BattedBallLine:
+		hit := clamp(0.66+(exitVelocity-90)*0.012, 0.35, 0.92)
+		hr := 0.0
+		if launchAngle >= 20 && exitVelocity >= 100 {
+			hr = clamp((exitVelocity-100)*0.04, 0, 0.35)
+		}
+		nonHR := math.Max(0, hit-hr)
+		extraBase := clamp(0.15+(exitVelocity-90)*0.015, 0.05, 0.45)
+		return ExpectedOutcome{
+			Single:  nonHR * (1 - extraBase),
+			Double:  nonHR * extraBase * 0.93,
+			Triple:  nonHR * extraBase * 0.07,
+			HomeRun: hr,
+		}
+
+	case BattedBallFly:
+		angleFit := math.Max(0, 1-math.Abs(launchAngle-28)/22)
+		hr := angleFit / (1 + math.Exp(-(exitVelocity-100)*0.45))
+		other := clamp(0.12+(exitVelocity-90)*0.006, 0.03, 0.25) * (1 - hr)
+		return ExpectedOutcome{
+			Single:  other * 0.4,
+			Double:  other * 0.5,
+			Triple:  other * 0.1,
+			HomeRun: hr,
+		}
+
+	default:
+		return ExpectedOutcome{Single: 0.02}
+	}
+}
+
+// GenerateBattedBall draws contact for a ball in play consistent with its
+// outcome. Outs follow the pitcher's ground ball / fly ball profile; hits
+// skew toward harder contact.
+func GenerateBattedBall(resultType string, pitcher *Player) *BattedBall {
+	var ballType string
+	var exitVelocity float64
+
+	switch resultType {
+	case "home_run":
+		ballType = BattedBallFly
+		exitVelocity = rand.NormFloat64()*3.5 + 104
+	case "double", "triple":
+		ballType = pickBattedBallType(0, 0.55, 0.45, 0)
+		exitVelocity = rand.NormFloat64()*6 + 97
+	case "single":
+		ballType = pickBattedBallType(0.45, 0.45, 0.10, 0)
+		exitVelocity = rand.NormFloat64()*8 + 90
+	default:
+		gb, ld, fb := 45.0, 20.0, 35.0
+		if pitcher != nil && pitcher.Pitching.GroundBallPercent+pitcher.Pitching.LinedrivePercent+pitcher.Pitching.FlyBallPercent > 0 {
+			gb, ld, fb = pitcher.Pitching.GroundBallPercent, pitcher.Pitching.LinedrivePercent, pitcher.Pitching.FlyBallPercent
+		}
+		// Roughly a quarter of fly balls are infield popups
+		ballType = pickBattedBallType(gb, ld, fb*0.75, fb*0.25)
+		switch ballType {
+		case BattedBallGround:
+			exitVelocity = rand.NormFloat64()*9 + 84
+		case BattedBallLine:
+			exitVelocity = rand.NormFloat64()*8 + 88
+		case BattedBallFly:
+			exitVelocity = rand.NormFloat64()*9 + 89
+		default:
+			exitVelocity = rand.NormFloat64()*8 + 80
+		}
+	}
+
+	var launchAngle float64
+	switch ballType {
+	case BattedBallGround:
+		launchAngle = clamp(rand.NormFloat64()*7-3, -40, 9.9)
+	case BattedBallLine:
+		launchAngle = clamp(rand.NormFloat64()*4+17, 10, 24.9)
+	case BattedBallFly:
+		if resultType == "home_run" {
+			launchAngle = clamp(rand.NormFloat64()*4+29, 25, 45)
+		} else {
+			launchAngle = clamp(rand.NormFloat64()*6+35, 25, 49.9)
+		}
+	default:
+		launchAngle = clamp(rand.NormFloat64()*5+58, 50, 80)
+	}
+
+	return &BattedBall{
+		Type:         ballType,
+		ExitVelocity: math.Round(clamp(exitVelocity, 40, 121)*10) / 10,
+		LaunchAngle:  math.Round(launchAngle*10) / 10,
+	}
+}
+
+// pickBattedBallType draws a type from relative ground/line/fly/popup weights
+func pickBattedBallType(ground, line, fly, popup float64) string {
+	roll := rand.Float64() * (ground + line + fly + popup)
+	switch {
+	case roll < ground:
+		return BattedBallGround
+	case roll < ground+line:
+		return BattedBallLine
+	case roll < ground+line+fly:
+		return BattedBallFly
+	default:
+		return BattedBallPopup
+	}
+}
+
+// ExpectedValue returns a plate appearance's expected hits and wOBA
+// numerator. Balls in play are credited by contact quality rather than
+// outcome; walks and strikeouts count as they happened.
+func ExpectedValue(result AtBatResult) (xHits, xWOBA float64) {
+	switch result.Type {
+	case "walk":
+		return 0, WOBAWeightBB
+	case "hit_by_pitch":
+		return 0, WOBAWeightHBP
+	case "strikeout":
+		return 0, 0
+	}
+
+	if result.BattedBall != nil {
+		outcome := ExpectedOutcomeFor(result.BattedBall.ExitVelocity, result.BattedBall.LaunchAngle)
+		return outcome.HitProbability(), outcome.WOBAValue()
+	}
+
+	// No contact data; fall back to the actual result
+	switch result.Type {
+	case "single":
+		return 1, WOBAWeight1B
+	case "double":
+		return 1, WOBAWeight2B
+	case "triple":
+		return 1, WOBAWeight3B
+	case "home_run":
+		return 1, WOBAWeightHR
+	}
+	return 0, 0
+}
+
+// outDescription names an out by its batted ball type
+func outDescription(ball *BattedBall) string {
+	if ball == nil {
+		return "Groundout"
+	}
+	switch ball.Type {
+	case BattedBallLine:
+		return "Lineout"
+	case BattedBallFly:
+		return "Flyout"
+	case BattedBallPopup:
+		return "Pop out"
+	default:
+		return "Groundout"
+	}
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
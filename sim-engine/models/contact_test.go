@@ -0,0 +1,75 @@
+package models
+
+import "testing"
+
+func TestClassifyLaunchAngle(t *testing.T) {
+	cases := map[float64]string{
+		-12: BattedBallGround,
+		15:  BattedBallLine,
+		30:  BattedBallFly,
+		62:  BattedBallPopup,
+	}
+	for angle, want := range cases {
+		if got := ClassifyLaunchAngle(angle); got != want {
+			t.Errorf("Launch angle %.0f: expected %s, got %s", angle, want, got)
+		}
+	}
+}
+
+func TestExpectedOutcomeFor(t *testing.T) {
+	barrel := ExpectedOutcomeFor(108, 28)
+	if barrel.HomeRun < 0.8 {
+		t.Errorf("Expected a 108 mph / 28 degree ball to be a likely home run, got %.3f", barrel.HomeRun)
+	}
+
+	hardLiner := ExpectedOutcomeFor(100, 15)
+	weakGrounder := ExpectedOutcomeFor(70, -10)
+	if hardLiner.HitProbability() <= weakGrounder.HitProbability() {
+		t.Errorf("Hard line drive xBA %.3f should exceed weak grounder %.3f",
+			hardLiner.HitProbability(), weakGrounder.HitProbability())
+	}
+
+	if popup := ExpectedOutcomeFor(85, 65); popup.HitProbability() > 0.05 {
+		t.Errorf("Popups should almost never fall, got %.3f", popup.HitProbability())
+	}
+
+	for _, o := range []ExpectedOutcome{barrel, hardLiner, weakGrounder} {
+		if p := o.HitProbability(); p < 0 || p > 1 {
+			t.Errorf("Hit probability out of range: %.3f", p)
+		}
+	}
+}
+
+func TestExpectedValue(t *testing.T) {
+	if xh, xw := ExpectedValue(AtBatResult{Type: "walk"}); xh != 0 || xw != WOBAWeightBB {
+		t.Errorf("Walk should be worth %.3f xwOBA and no hits, got %.3f / %.3f", WOBAWeightBB, xw, xh)
+	}
+	if xh, xw := ExpectedValue(AtBatResult{Type: "strikeout"}); xh != 0 || xw != 0 {
+		t.Errorf("Strikeouts have no expected value, got %.3f / %.3f", xh, xw)
+	}
+
+	// A hard-hit out still earns expected credit
+	out := AtBatResult{Type: "out", BattedBall: &BattedBall{Type: BattedBallLine, ExitVelocity: 105, LaunchAngle: 18}}
+	if xh, _ := ExpectedValue(out); xh < 0.5 {
+		t.Errorf("Expected a 105 mph liner out to carry xBA above .500, got %.3f", xh)
+	}
+
+	// Without contact data the actual result is used
+	if xh, xw := ExpectedValue(AtBatResult{Type: "double"}); xh != 1 || xw != WOBAWeight2B {
+		t.Errorf("Expected a double without contact data to count as hit, got %.3f / %.3f", xh, xw)
+	}
+}
+
+func TestGenerateBattedBallMatchesOutcome(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		hr := GenerateBattedBall("home_run", nil)
+		if hr.Type != BattedBallFly || ClassifyLaunchAngle(hr.LaunchAngle) != hr.Type {
+			t.Fatalf("Home runs should be fly balls, got %+v", hr)
+		}
+
+		pitcher := &Player{Pitching: PitchingStats{GroundBallPercent: 100}}
+		if out := GenerateBattedBall("out", pitcher); out.Type != BattedBallGround {
+			t.Fatalf("An extreme ground ball pitcher should only allow grounders, got %s", out.Type)
+		}
+	}
+}
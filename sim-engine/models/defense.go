@@ -0,0 +1,74 @@
+package models
+
+import "math"
+
+const (
+	// LeagueFieldingPct is a league-average fielding percentage, used for
+	// fielders without fielding stats
+	LeagueFieldingPct = 0.985
+
+	// League-average share of batted-ball outs that become errors instead,
+	// about half an error per team per game
+	baseFieldingErrorRate = 0.03
+)
+
+// TeamDefense counts a defense's balls in play and errors over one
+// simulated game
+type TeamDefense struct {
+	Outs        int `json:"outs"`          // outs recorded while in the field
+	BallsInPlay int `json:"balls_in_play"` // batted balls other than home runs
+	HitsInPlay  int `json:"hits_in_play"`  // singles, doubles and triples
+	Errors      int `json:"errors"`        // batters reaching on a misplay
+}
+
+// Add accumulates another game's counts
+func (d *TeamDefense) Add(other TeamDefense) {
+	d.Outs += other.Outs
+	d.BallsInPlay += other.BallsInPlay
+	d.HitsInPlay += other.HitsInPlay
+	d.Errors += other.Errors
+}
+
+// DefensiveEfficiency is the share of balls in play turned into outs
+func (d TeamDefense) DefensiveEfficiency() float64 {
+	if d.BallsInPlay == 0 {
+		return 0
+	}
+	return 1 - float64(d.HitsInPlay+d.Errors)/float64(d.BallsInPlay)
+}
+
+// ErrorsPer9 is errors per nine defensive innings
+func (d TeamDefense) ErrorsPer9() float64 {
+	if d.Outs == 0 {
+		return 0
+	}
+	return float64(d.Errors) * 27 / float64(d.Outs)
+}
+
+// DefenseSummary is both defenses' counts over one simulated game
+type DefenseSummary struct {
+	Home TeamDefense `json:"home"`
+	Away TeamDefense `json:"away"`
+}
+
+// GetFieldingErrorRate returns the chance a batted-ball out becomes an error
+// behind the given fielders, scaled by their mean fielding percentage.
+// Fielders without one count as league average.
+func GetFieldingErrorRate(fielders []Player) float64 {
+	total := 0.0
+	for _, fielder := range fielders {
+		fpct := fielder.Fielding.FPCT
+		if fpct <= 0 || fpct > 1 {
+			fpct = LeagueFieldingPct
+		}
+		total += fpct
+	}
+	fpct := LeagueFieldingPct
+	if len(fielders) > 0 {
+		fpct = total / float64(len(fielders))
+	}
+
+	multiplier := (1 - fpct) / (1 - LeagueFieldingPct)
+	multiplier = math.Max(0.3, math.Min(2.0, multiplier))
+	return baseFieldingErrorRate * multiplier
+}
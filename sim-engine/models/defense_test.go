@@ -0,0 +1,48 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGetFieldingErrorRate tests scaling errors by fielding percentage
+func TestGetFieldingErrorRate(t *testing.T) {
+	average := []Player{{Fielding: FieldingStats{FPCT: LeagueFieldingPct}}}
+	if rate := GetFieldingErrorRate(average); math.Abs(rate-baseFieldingErrorRate) > 1e-9 {
+		t.Errorf("Expected league rate %f, got %f", baseFieldingErrorRate, rate)
+	}
+	if rate := GetFieldingErrorRate(nil); math.Abs(rate-baseFieldingErrorRate) > 1e-9 {
+		t.Errorf("Expected league rate without fielders, got %f", rate)
+	}
+	if rate := GetFieldingErrorRate([]Player{{}}); math.Abs(rate-baseFieldingErrorRate) > 1e-9 {
+		t.Errorf("Expected fielders without stats to count as average, got %f", rate)
+	}
+
+	sure := []Player{{Fielding: FieldingStats{FPCT: 0.992}}, {Fielding: FieldingStats{FPCT: 0.990}}}
+	shaky := []Player{{Fielding: FieldingStats{FPCT: 0.975}}, {Fielding: FieldingStats{FPCT: 0.970}}}
+	if GetFieldingErrorRate(sure) >= GetFieldingErrorRate(shaky) {
+		t.Error("Expected surer hands to make fewer errors")
+	}
+
+	if rate := GetFieldingErrorRate([]Player{{Fielding: FieldingStats{FPCT: 0.5}}}); rate > baseFieldingErrorRate*2 {
+		t.Errorf("Expected rate capped at twice league average, got %f", rate)
+	}
+}
+
+// TestTeamDefense tests defensive efficiency and errors per 9
+func TestTeamDefense(t *testing.T) {
+	var defense TeamDefense
+	if defense.DefensiveEfficiency() != 0 || defense.ErrorsPer9() != 0 {
+		t.Error("Expected zeros without balls in play")
+	}
+
+	defense.Add(TeamDefense{Outs: 27, BallsInPlay: 26, HitsInPlay: 7, Errors: 1})
+	defense.Add(TeamDefense{Outs: 27, BallsInPlay: 24, HitsInPlay: 6, Errors: 0})
+
+	if got := defense.DefensiveEfficiency(); math.Abs(got-0.72) > 1e-9 {
+		t.Errorf("Expected efficiency 0.72, got %f", got)
+	}
+	if got := defense.ErrorsPer9(); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("Expected 0.5 errors per 9, got %f", got)
+	}
+}
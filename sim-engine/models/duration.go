@@ -0,0 +1,170 @@
+package models
+
+import (
+	"errors"
+	"math"
+)
+
+// MinDurationSamples is the fewest completed games with a recorded duration
+// needed to fit a DurationModel
+const MinDurationSamples = 50
+
+// GamePace holds the factors that drive how long a game takes
+type GamePace struct {
+	Pitches         int `json:"pitches"`
+	Runs            int `json:"runs"`
+	PitchingChanges int `json:"pitching_changes"`
+	Innings         int `json:"innings"`
+}
+
+// ExtraInnings returns the innings played past the ninth
+func (p GamePace) ExtraInnings() int {
+	if p.Innings > RegulationInnings {
+		return p.Innings - RegulationInnings
+	}
+	return 0
+}
+
+func (p GamePace) features() [4]float64 {
+	return [4]float64{float64(p.Pitches), float64(p.Runs), float64(p.PitchingChanges), float64(p.ExtraInnings())}
+}
+
+// DurationSample is a completed game's pace and its actual length
+type DurationSample struct {
+	Pace    GamePace
+	Minutes float64
+}
+
+// DurationModel estimates game length in minutes as a linear function of
+// pace. Extra innings add time for half-inning breaks on top of their pitches.
+type DurationModel struct {
+	Intercept         float64 `json:"intercept"`
+	PerPitch          float64 `json:"per_pitch"`
+	PerRun            float64 `json:"per_run"`
+	PerPitchingChange float64 `json:"per_pitching_change"`
+	PerExtraInning    float64 `json:"per_extra_inning"`
+	Samples           int     `json:"samples"` // 0 for the defaults
+}
+
+// DefaultDurationModel returns coefficients matching recent pitch clock era
+// games (about 160 minutes for 290 pitches, 9 runs and 6 pitching changes)
+func DefaultDurationModel() *DurationModel {
+	return &DurationModel{
+		Intercept:         22,
+		PerPitch:          0.40,
+		PerRun:            1.2,
+		PerPitchingChange: 2.0,
+		PerExtraInning:    4.0,
+	}
+}
+
+// Estimate returns the expected length of a game with the given pace. A nil
+// model uses the defaults.
+func (m *DurationModel) Estimate(pace GamePace) int {
+	if m == nil {
+		m = DefaultDurationModel()
+	}
+	f := pace.features()
+	minutes := m.Intercept + m.PerPitch*f[0] + m.PerRun*f[1] + m.PerPitchingChange*f[2] + m.PerExtraInning*f[3]
+	return int(math.Round(math.Max(minutes, 60)))
+}
+
+// FitDurationModel fits a DurationModel to completed games by least squares.
+// It fails when there are too few samples or the fit isn't physically sensible
+// (more pitches must mean a longer game).
+func FitDurationModel(samples []DurationSample) (*DurationModel, error) {
+	if len(samples) < MinDurationSamples {
+		return nil, errors.New("not enough games with recorded durations")
+	}
+
+	// Normal equations (XᵀX)β = Xᵀy with an intercept column
+	const n = 5
+	var xtx [n][n]float64
+	var xty [n]float64
+	for _, sample := range samples {
+		f := sample.Pace.features()
+		row := [n]float64{1, f[0], f[1], f[2], f[3]}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				xtx[i][j] += row[i] * row[j]
+			}
+			xty[i] += row[i] * sample.Minutes
+		}
+	}
+
+	// Without any extra-inning games that column is all zeros; pin its
+	// coefficient to the default rather than leaving the system singular
+	if xtx[4][4] == 0 {
+		xtx[4][4] = 1
+		xty[4] = DefaultDurationModel().PerExtraInning
+	}
+
+	beta, ok := solveLinearSystem(xtx, xty)
+	if !ok {
+		return nil, errors.New("duration fit is singular")
+	}
+	if beta[1] <= 0 {
+		return nil, errors.New("duration fit has a non-positive per-pitch cost")
+	}
+
+	return &DurationModel{
+		Intercept:         beta[0],
+		PerPitch:          beta[1],
+		PerRun:            beta[2],
+		PerPitchingChange: beta[3],
+		PerExtraInning:    beta[4],
+		Samples:           len(samples),
+	}, nil
+}
+
+// solveLinearSystem solves a·x = b by Gaussian elimination with partial pivoting
+func solveLinearSystem(a [5][5]float64, b [5]float64) ([5]float64, bool) {
+	const n = 5
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-9 {
+			return [n]float64{}, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	var x [n]float64
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, true
+}
+
+// PitchesForOutcome draws how many pitches a plate appearance took given how
+// it ended, averaging close to the league's ~3.9 pitches per plate appearance.
+// intn is rand.Intn or a deterministic stand-in.
+func PitchesForOutcome(outcome string, intn func(int) int) int {
+	switch outcome {
+	case "strikeout":
+		return 3 + intn(5) // 3-7
+	case "walk":
+		return 4 + intn(4) // 4-7
+	case "hit_by_pitch":
+		return 1 + intn(4) // 1-4
+	default:
+		return 1 + intn(6) // 1-6, balls in play
+	}
+}
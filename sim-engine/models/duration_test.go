@@ -0,0 +1,105 @@
+package models
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDefaultDurationModelTypicalGame(t *testing.T) {
+	minutes := DefaultDurationModel().Estimate(GamePace{Pitches: 290, Runs: 9, PitchingChanges: 6, Innings: 9})
+	if minutes < 150 || minutes > 175 {
+		t.Errorf("typical game = %d minutes, want 150-175", minutes)
+	}
+
+	var nilModel *DurationModel
+	if got := nilModel.Estimate(GamePace{Pitches: 290, Runs: 9, PitchingChanges: 6, Innings: 9}); got != minutes {
+		t.Errorf("nil model = %d minutes, want the default %d", got, minutes)
+	}
+}
+
+func TestDurationGrowsWithPace(t *testing.T) {
+	model := DefaultDurationModel()
+	base := GamePace{Pitches: 280, Runs: 7, PitchingChanges: 5, Innings: 9}
+
+	for name, pace := range map[string]GamePace{
+		"pitches":          {Pitches: 330, Runs: 7, PitchingChanges: 5, Innings: 9},
+		"runs":             {Pitches: 280, Runs: 15, PitchingChanges: 5, Innings: 9},
+		"pitching changes": {Pitches: 280, Runs: 7, PitchingChanges: 10, Innings: 9},
+		"extra innings":    {Pitches: 280, Runs: 7, PitchingChanges: 5, Innings: 11},
+	} {
+		if model.Estimate(pace) <= model.Estimate(base) {
+			t.Errorf("more %s should lengthen the game", name)
+		}
+	}
+}
+
+func TestFitDurationModelRecoversCoefficients(t *testing.T) {
+	want := DurationModel{Intercept: 30, PerPitch: 0.35, PerRun: 1.5, PerPitchingChange: 2.5, PerExtraInning: 6}
+	rng := rand.New(rand.NewSource(1))
+
+	var samples []DurationSample
+	for i := 0; i < 400; i++ {
+		pace := GamePace{
+			Pitches:         240 + rng.Intn(100),
+			Runs:            rng.Intn(18),
+			PitchingChanges: 2 + rng.Intn(9),
+			Innings:         9,
+		}
+		if i%10 == 0 {
+			pace.Innings = 10 + rng.Intn(3)
+		}
+		samples = append(samples, DurationSample{Pace: pace, Minutes: float64(want.Estimate(pace))})
+	}
+
+	got, err := FitDurationModel(samples)
+	if err != nil {
+		t.Fatalf("fit failed: %v", err)
+	}
+	if got.Samples != len(samples) {
+		t.Errorf("samples = %d, want %d", got.Samples, len(samples))
+	}
+	// Estimates are rounded to whole minutes, so allow a little slack
+	for name, pair := range map[string][2]float64{
+		"per pitch":           {got.PerPitch, want.PerPitch},
+		"per run":             {got.PerRun, want.PerRun},
+		"per pitching change": {got.PerPitchingChange, want.PerPitchingChange},
+		"per extra inning":    {got.PerExtraInning, want.PerExtraInning},
+	} {
+		if math.Abs(pair[0]-pair[1]) > 0.1*math.Max(1, pair[1]) {
+			t.Errorf("%s = %.3f, want about %.3f", name, pair[0], pair[1])
+		}
+	}
+}
+
+func TestFitDurationModelRejectsBadData(t *testing.T) {
+	if _, err := FitDurationModel(make([]DurationSample, MinDurationSamples-1)); err == nil {
+		t.Error("expected an error with too few samples")
+	}
+
+	// Every game identical: the system is singular
+	same := make([]DurationSample, MinDurationSamples)
+	for i := range same {
+		same[i] = DurationSample{Pace: GamePace{Pitches: 290, Runs: 8, PitchingChanges: 6, Innings: 9}, Minutes: 160}
+	}
+	if _, err := FitDurationModel(same); err == nil {
+		t.Error("expected an error for a singular fit")
+	}
+}
+
+func TestPitchesForOutcome(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	outcomes := map[string][2]int{
+		"strikeout":    {3, 7},
+		"walk":         {4, 7},
+		"hit_by_pitch": {1, 4},
+		"single":       {1, 6},
+	}
+	for outcome, bounds := range outcomes {
+		for i := 0; i < 200; i++ {
+			if n := PitchesForOutcome(outcome, rng.Intn); n < bounds[0] || n > bounds[1] {
+				t.Fatalf("%s took %d pitches, want %d-%d", outcome, n, bounds[0], bounds[1])
+			}
+		}
+	}
+}
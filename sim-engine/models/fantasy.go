@@ -0,0 +1,297 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fantasy stat keys. The simulator doesn't track stolen bases, hit by
+// pitches or pitcher wins per player, so systems can't score them.
+const (
+	FantasySingle = "1B"
+	FantasyDouble = "2B"
+	FantasyTriple = "3B"
+	FantasyHR     = "HR"
+	FantasyRBI    = "RBI"
+	FantasyRun    = "R"
+	FantasyWalk   = "BB"
+	FantasyK      = "K"
+	FantasyIP     = "IP"
+	FantasyER     = "ER"
+	FantasyHits   = "H"
+	FantasyQS     = "QS" // quality start: 6+ innings, 3 or fewer earned runs
+)
+
+const (
+	qualityStartOuts = 18
+	qualityStartER   = 3
+)
+
+// fantasyPercentiles are the points reported for each player's distribution
+var fantasyPercentiles = []int{10, 25, 50, 75, 90}
+
+// ScoringSystem is the points a fantasy contest awards per stat, separately
+// for hitters and pitchers
+type ScoringSystem struct {
+	Name     string             `json:"name"`
+	Batting  map[string]float64 `json:"batting"`
+	Pitching map[string]float64 `json:"pitching"`
+}
+
+// FantasyScoringSystems are the built-in systems by their ?system= name
+var FantasyScoringSystems = map[string]ScoringSystem{
+	"dk": {
+		Name:     "DraftKings",
+		Batting:  map[string]float64{FantasySingle: 3, FantasyDouble: 5, FantasyTriple: 8, FantasyHR: 10, FantasyRBI: 2, FantasyRun: 2, FantasyWalk: 2},
+		Pitching: map[string]float64{FantasyIP: 2.25, FantasyK: 2, FantasyER: -2, FantasyHits: -0.6, FantasyWalk: -0.6},
+	},
+	"fd": {
+		Name:     "FanDuel",
+		Batting:  map[string]float64{FantasySingle: 3, FantasyDouble: 6, FantasyTriple: 9, FantasyHR: 12, FantasyRBI: 3.5, FantasyRun: 3.2, FantasyWalk: 3},
+		Pitching: map[string]float64{FantasyIP: 3, FantasyK: 3, FantasyER: -3, FantasyQS: 4},
+	},
+}
+
+// fantasyBattingStats and fantasyPitchingStats are the keys a system may score
+var (
+	fantasyBattingStats  = []string{FantasySingle, FantasyDouble, FantasyTriple, FantasyHR, FantasyRBI, FantasyRun, FantasyWalk, FantasyK}
+	fantasyPitchingStats = []string{FantasyIP, FantasyK, FantasyER, FantasyHits, FantasyWalk, FantasyHR, FantasyQS}
+)
+
+// ParseCustomScoring reads a custom system as "bat.HR=10,bat.R=2,pit.K=3",
+// where bat. and pit. scope the stat to hitters or pitchers
+func ParseCustomScoring(spec string) (ScoringSystem, error) {
+	system := ScoringSystem{Name: "Custom", Batting: make(map[string]float64), Pitching: make(map[string]float64)}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return ScoringSystem{}, fmt.Errorf("scoring entry %q must be scope.STAT=points", entry)
+		}
+		points, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return ScoringSystem{}, fmt.Errorf("scoring entry %q has invalid points", entry)
+		}
+		scope, stat, _ := strings.Cut(strings.TrimSpace(key), ".")
+		switch {
+		case scope == "bat" && containsStat(fantasyBattingStats, stat):
+			system.Batting[stat] = points
+		case scope == "pit" && containsStat(fantasyPitchingStats, stat):
+			system.Pitching[stat] = points
+		default:
+			return ScoringSystem{}, fmt.Errorf("unknown scoring stat %q; use bat.%s or pit.%s",
+				key, strings.Join(fantasyBattingStats, "|"), strings.Join(fantasyPitchingStats, "|"))
+		}
+	}
+	if len(system.Batting) == 0 && len(system.Pitching) == 0 {
+		return ScoringSystem{}, fmt.Errorf("custom scoring needs at least one stat")
+	}
+	return system, nil
+}
+
+func containsStat(stats []string, stat string) bool {
+	for _, s := range stats {
+		if s == stat {
+			return true
+		}
+	}
+	return false
+}
+
+// BattingPoints scores one hitter's game
+func (s ScoringSystem) BattingPoints(b *PlayerGameBatting) float64 {
+	return s.Batting[FantasySingle]*float64(b.Singles) +
+		s.Batting[FantasyDouble]*float64(b.Doubles) +
+		s.Batting[FantasyTriple]*float64(b.Triples) +
+		s.Batting[FantasyHR]*float64(b.HR) +
+		s.Batting[FantasyRBI]*float64(b.RBI) +
+		s.Batting[FantasyRun]*float64(b.R) +
+		s.Batting[FantasyWalk]*float64(b.BB) +
+		s.Batting[FantasyK]*float64(b.K)
+}
+
+// PitchingPoints scores one pitcher's game
+func (s ScoringSystem) PitchingPoints(p *PlayerGamePitching) float64 {
+	points := s.Pitching[FantasyIP]*float64(p.Outs)/3 +
+		s.Pitching[FantasyK]*float64(p.K) +
+		s.Pitching[FantasyER]*float64(p.ER) +
+		s.Pitching[FantasyHits]*float64(p.H) +
+		s.Pitching[FantasyWalk]*float64(p.BB) +
+		s.Pitching[FantasyHR]*float64(p.HR)
+	if p.Outs >= qualityStartOuts && p.ER <= qualityStartER {
+		points += s.Pitching[FantasyQS]
+	}
+	return points
+}
+
+// AverageBattingPoints scores a hitter's per-game averages. Every batting
+// stat is scored linearly, so this is the mean of the per-game points.
+func (s ScoringSystem) AverageBattingPoints(b PlayerBattingStats) float64 {
+	return s.Batting[FantasySingle]*b.Singles +
+		s.Batting[FantasyDouble]*b.Doubles +
+		s.Batting[FantasyTriple]*b.Triples +
+		s.Batting[FantasyHR]*b.HR +
+		s.Batting[FantasyRBI]*b.RBI +
+		s.Batting[FantasyRun]*b.R +
+		s.Batting[FantasyWalk]*b.BB +
+		s.Batting[FantasyK]*b.K
+}
+
+// AveragePitchingPoints scores a pitcher's per-game averages. Quality
+// starts depend on each game's line, so they can't be scored from averages
+// and are left out.
+func (s ScoringSystem) AveragePitchingPoints(p PlayerPitchingStats) float64 {
+	return s.Pitching[FantasyIP]*p.IP +
+		s.Pitching[FantasyK]*p.K +
+		s.Pitching[FantasyER]*p.ER +
+		s.Pitching[FantasyHits]*p.H +
+		s.Pitching[FantasyWalk]*p.BB +
+		s.Pitching[FantasyHR]*p.HR
+}
+
+// FantasyDistribution summarizes a player's points across simulated games
+type FantasyDistribution struct {
+	StdDev      float64         `json:"std_dev"`
+	Percentiles map[int]float64 `json:"percentiles"` // 10th, 25th, 50th, 75th and 90th
+	Max         float64         `json:"max"`
+}
+
+// FantasyProjection is a player's projected fantasy points
+type FantasyProjection struct {
+	PlayerID     string               `json:"player_id"`
+	PlayerName   string               `json:"player_name,omitempty"`
+	Team         string               `json:"team"` // home or away
+	Role         string               `json:"role"` // batter or pitcher
+	Points       float64              `json:"points"`
+	Distribution *FantasyDistribution `json:"distribution,omitempty"`
+}
+
+// fantasyPlayer accumulates one player's points by game
+type fantasyPlayer struct {
+	team, role string
+	points     []float64
+}
+
+// FantasyTally scores each simulated game's player lines under one system
+type FantasyTally struct {
+	system  ScoringSystem
+	games   int
+	players map[string]*fantasyPlayer
+}
+
+// NewFantasyTally creates an empty tally for a scoring system
+func NewFantasyTally(system ScoringSystem) *FantasyTally {
+	return &FantasyTally{system: system, players: make(map[string]*fantasyPlayer)}
+}
+
+// Add scores one simulated game. Results without player stats still count
+// as games, so players' averages are per simulated game.
+func (t *FantasyTally) Add(result SimulationResult) {
+	t.games++
+	stats := result.PlayerStats
+	if stats == nil {
+		return
+	}
+	for _, side := range []struct {
+		team     string
+		batting  map[string]*PlayerGameBatting
+		pitching map[string]*PlayerGamePitching
+	}{
+		{"home", stats.HomeBatting, stats.HomePitching},
+		{"away", stats.AwayBatting, stats.AwayPitching},
+	} {
+		for id, line := range side.batting {
+			t.player(id, side.team, "batter").add(t.system.BattingPoints(line))
+		}
+		for id, line := range side.pitching {
+			t.player(id, side.team, "pitcher").add(t.system.PitchingPoints(line))
+		}
+	}
+}
+
+func (p *fantasyPlayer) add(points float64) {
+	p.points = append(p.points, points)
+}
+
+func (t *FantasyTally) player(id, team, role string) *fantasyPlayer {
+	key := role + ":" + id
+	player, ok := t.players[key]
+	if !ok {
+		player = &fantasyPlayer{team: team, role: role}
+		t.players[key] = player
+	}
+	return player
+}
+
+// Projections returns each player's mean points and distribution, highest
+// projection first. Games a player sat out count as zero points.
+func (t *FantasyTally) Projections() []FantasyProjection {
+	projections := make([]FantasyProjection, 0, len(t.players))
+	if t.games == 0 {
+		return projections
+	}
+	for key, player := range t.players {
+		points := make([]float64, t.games)
+		copy(points, player.points)
+		sort.Float64s(points)
+
+		var sum float64
+		for _, p := range points {
+			sum += p
+		}
+		mean := sum / float64(len(points))
+		var variance float64
+		for _, p := range points {
+			variance += (p - mean) * (p - mean)
+		}
+
+		distribution := &FantasyDistribution{
+			StdDev:      roundPoints(math.Sqrt(variance / float64(len(points)))),
+			Percentiles: make(map[int]float64, len(fantasyPercentiles)),
+			Max:         roundPoints(points[len(points)-1]),
+		}
+		for _, pct := range fantasyPercentiles {
+			distribution.Percentiles[pct] = roundPoints(percentile(points, pct))
+		}
+
+		projections = append(projections, FantasyProjection{
+			PlayerID:     strings.TrimPrefix(key, player.role+":"),
+			Team:         player.team,
+			Role:         player.role,
+			Points:       roundPoints(mean),
+			Distribution: distribution,
+		})
+	}
+	SortFantasyProjections(projections)
+	return projections
+}
+
+// SortFantasyProjections orders projections by points, highest first
+func SortFantasyProjections(projections []FantasyProjection) {
+	sort.Slice(projections, func(i, j int) bool {
+		if projections[i].Points != projections[j].Points {
+			return projections[i].Points > projections[j].Points
+		}
+		return projections[i].PlayerID < projections[j].PlayerID
+	})
+}
+
+// percentile is the nearest-rank percentile of sorted values
+func percentile(sorted []float64, pct int) float64 {
+	rank := int(math.Ceil(float64(pct)/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// roundPoints rounds fantasy points to two decimal places
+func roundPoints(points float64) float64 {
+	return math.Round(points*100) / 100
+}
@@ -0,0 +1,90 @@
+package models
+
+import "testing"
+
+func TestScoringSystemPoints(t *testing.T) {
+	dk := FantasyScoringSystems["dk"]
+
+	// A single, a home run, a walk, two runs and three RBI
+	batting := &PlayerGameBatting{Singles: 1, HR: 1, BB: 1, R: 2, RBI: 3, K: 1}
+	if points := dk.BattingPoints(batting); points != 3+10+2+4+6 {
+		t.Errorf("DraftKings batting points = %v, want 25", points)
+	}
+
+	// Six innings, seven strikeouts, two earned runs on five hits and a walk
+	pitching := &PlayerGamePitching{Outs: 18, K: 7, ER: 2, H: 5, BB: 1}
+	if points := dk.PitchingPoints(pitching); points < 19.89 || points > 19.91 {
+		t.Errorf("DraftKings pitching points = %v, want 19.9", points)
+	}
+
+	fd := FantasyScoringSystems["fd"]
+	if points := fd.PitchingPoints(pitching); points != 18+21-6+4 {
+		t.Errorf("FanDuel pitching points = %v, want 37 with the quality start", points)
+	}
+	pitching.ER = 4
+	if points := fd.PitchingPoints(pitching); points != 18+21-12 {
+		t.Errorf("FanDuel pitching points = %v, want 27 without a quality start", points)
+	}
+}
+
+func TestParseCustomScoring(t *testing.T) {
+	system, err := ParseCustomScoring("bat.HR=4, bat.K=-1,pit.K=1.5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if system.Batting[FantasyHR] != 4 || system.Batting[FantasyK] != -1 || system.Pitching[FantasyK] != 1.5 {
+		t.Errorf("custom system = %+v", system)
+	}
+
+	for _, spec := range []string{"", "bat.HR", "bat.HR=x", "bat.SB=5", "pit.RBI=1", "HR=4"} {
+		if _, err := ParseCustomScoring(spec); err == nil {
+			t.Errorf("ParseCustomScoring(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestFantasyTallyProjections(t *testing.T) {
+	tally := NewFantasyTally(ScoringSystem{Batting: map[string]float64{FantasyHR: 10}, Pitching: map[string]float64{FantasyK: 1}})
+	for i := 0; i < 10; i++ {
+		stats := &GamePlayerStats{
+			HomeBatting:  map[string]*PlayerGameBatting{"slugger": {}},
+			HomePitching: map[string]*PlayerGamePitching{},
+			AwayBatting:  map[string]*PlayerGameBatting{},
+			AwayPitching: map[string]*PlayerGamePitching{"ace": {K: 5}},
+		}
+		if i < 3 {
+			stats.HomeBatting["slugger"].HR = 1
+		}
+		if i == 9 {
+			delete(stats.AwayPitching, "ace") // didn't pitch
+		}
+		tally.Add(SimulationResult{PlayerStats: stats})
+	}
+
+	projections := tally.Projections()
+	if len(projections) != 2 || projections[0].PlayerID != "ace" || projections[1].PlayerID != "slugger" {
+		t.Fatalf("projections = %+v, want ace then slugger", projections)
+	}
+
+	ace := projections[0]
+	if ace.Points != 4.5 || ace.Team != "away" || ace.Role != "pitcher" {
+		t.Errorf("ace = %+v, want 4.5 points with the missed game as zero", ace)
+	}
+	if ace.Distribution.Percentiles[10] != 0 || ace.Distribution.Percentiles[50] != 5 || ace.Distribution.Max != 5 {
+		t.Errorf("ace distribution = %+v", ace.Distribution)
+	}
+
+	slugger := projections[1]
+	if slugger.Points != 3 || slugger.Distribution.Percentiles[75] != 10 || slugger.Distribution.Percentiles[50] != 0 {
+		t.Errorf("slugger = %+v / %+v", slugger, slugger.Distribution)
+	}
+	if slugger.Distribution.StdDev < 4.58 || slugger.Distribution.StdDev > 4.59 {
+		t.Errorf("slugger std dev = %v, want about 4.58", slugger.Distribution.StdDev)
+	}
+}
+
+func TestFantasyTallyEmpty(t *testing.T) {
+	if projections := NewFantasyTally(FantasyScoringSystems["fd"]).Projections(); len(projections) != 0 {
+		t.Errorf("empty tally = %+v", projections)
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ModelParameters lists the named calibration constants the at-bat model runs
+// on, including the active tuning parameters. Runs whose parameters hash the
+// same were produced by the same model.
+func ModelParameters() map[string]float64 {
+	return ModelParametersFor(CurrentTuningParameters())
+}
+
+// ModelParametersFor lists the model's constants with the given tuning
+// parameters (nil = current)
+func ModelParametersFor(tuning *TuningParameters) map[string]float64 {
+	if tuning == nil {
+		tuning = CurrentTuningParameters()
+	}
+	league := DefaultLeagueEnvironment()
+	params := map[string]float64{
+		"framing_shift_per_run":   framingShiftPerRun,
+		"base_battery_error_rate": baseBatteryErrorRate,
+		"base_passed_ball_share":  basePassedBallShare,
+		"run_value_strikeout":     runValueStrikeout,
+		"run_value_walk":          runValueWalk,
+		"run_value_advance":       runValueAdvance,
+		"woba_weight_bb":          WOBAWeightBB,
+		"woba_weight_hbp":         WOBAWeightHBP,
+		"woba_weight_1b":          WOBAWeight1B,
+		"woba_weight_2b":          WOBAWeight2B,
+		"woba_weight_3b":          WOBAWeight3B,
+		"woba_weight_hr":          WOBAWeightHR,
+		"default_league_woba":     league.WOBA,
+		"default_woba_scale":      league.WOBAScale,
+		"default_fip_constant":    league.FIPConstant,
+		"default_league_fip":      league.LeagueFIP,
+		"default_runs_per_pa":     league.RunsPerPA,
+		"starter_pitch_limit":     StarterPitchLimit,
+		"reliever_max_outs":       RelieverMaxOuts,
+		"reliever_pitch_limit":    RelieverPitchLimit,
+	}
+	for name, value := range tuning.Values() {
+		params[name] = value
+	}
+	return params
+}
+
+// ModelParameterHash returns a short, stable fingerprint of ModelParameters
+func ModelParameterHash() string {
+	return ModelParameterHashFor(CurrentTuningParameters())
+}
+
+// ModelParameterHashFor fingerprints ModelParametersFor(tuning)
+func ModelParameterHashFor(tuning *TuningParameters) string {
+	// encoding/json sorts map keys, so equal parameters always hash the same
+	encoded, _ := json.Marshal(ModelParametersFor(tuning))
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,16 @@
+package models
+
+import "testing"
+
+func TestModelParameterHash(t *testing.T) {
+	hash := ModelParameterHash()
+	if len(hash) != 12 {
+		t.Errorf("Expected a 12 character hash, got %q", hash)
+	}
+	if again := ModelParameterHash(); again != hash {
+		t.Errorf("Expected a stable hash, got %q then %q", hash, again)
+	}
+	if len(ModelParameters()) == 0 {
+		t.Error("Expected model parameters to be listed")
+	}
+}
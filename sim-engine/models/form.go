@@ -0,0 +1,22 @@
+package models
+
+// FormGames is how many of a team's most recent games the form prior uses
+const FormGames = 10
+
+// TeamForm is a team's record over its most recent completed games before
+// the simulated one
+type TeamForm struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+}
+
+// FormAdjustment is the expected wOBA shift for a team's batters from recent
+// form. Games over .500 are divided by the full FormGames window, so a team
+// with only a few games played is pulled toward even. A zero FormWOBA (the
+// default) disables the prior.
+func (tp *TuningParameters) FormAdjustment(form *TeamForm) float64 {
+	if form == nil || tp.FormWOBA == 0 {
+		return 0
+	}
+	return tp.FormWOBA * float64(form.Wins-form.Losses) / (2 * FormGames)
+}
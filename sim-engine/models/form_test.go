@@ -0,0 +1,34 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormPriorDisabledByDefault(t *testing.T) {
+	tuning := DefaultTuningParameters()
+	if adj := tuning.FormAdjustment(&TeamForm{Wins: 10}); adj != 0 {
+		t.Errorf("default form adjustment = %v, want 0", adj)
+	}
+}
+
+func TestFormAdjustment(t *testing.T) {
+	tuning := DefaultTuningParameters()
+	tuning.FormWOBA = 0.02
+
+	cases := []struct {
+		form *TeamForm
+		want float64
+	}{
+		{&TeamForm{Wins: 7, Losses: 3}, 0.004},
+		{&TeamForm{Wins: 3, Losses: 7}, -0.004},
+		{&TeamForm{Wins: 10}, 0.01},
+		{&TeamForm{Wins: 2}, 0.002}, // few games played stay close to even
+		{nil, 0},
+	}
+	for _, c := range cases {
+		if got := tuning.FormAdjustment(c.form); math.Abs(got-c.want) > 1e-12 {
+			t.Errorf("form %+v: adjustment = %v, want %v", c.form, got, c.want)
+		}
+	}
+}
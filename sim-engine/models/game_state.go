@@ -20,6 +20,40 @@ type GameState struct {
 	CreatedAt  time.Time `json:"created_at"`
 	IsComplete bool      `json:"is_complete"`
 	WinnerTeam string    `json:"winner_team,omitempty"`
+	Linescore  Linescore `json:"linescore"`
+
+	// LeagueEnv calibrates at-bat math to the game's season (nil = defaults)
+	LeagueEnv *LeagueEnvironment `json:"-"`
+
+	// ColdWeatherThreshold is the °F below which pitchers are penalized
+	// (0 = DefaultColdWeatherThreshold)
+	ColdWeatherThreshold int `json:"-"`
+
+	// TuningParams is the calibration the game started with (nil = current)
+	TuningParams *TuningParameters `json:"-"`
+
+	// NeutralSite drops the home team's home-field edge
+	NeutralSite bool `json:"-"`
+
+	// Indoor games are played under a roof, out of the cold
+	Indoor bool `json:"-"`
+
+	// HomeFormWOBA and AwayFormWOBA shift each side's batters for recent form
+	// and scenario band variants (0 unless either applies)
+	HomeFormWOBA float64 `json:"-"`
+	AwayFormWOBA float64 `json:"-"`
+
+	// ShortRestPitchers are starters working on short rest, who pitch with
+	// the short-rest penalties
+	ShortRestPitchers map[string]bool `json:"-"`
+}
+
+// Linescore holds each team's runs by inning. An inning appears once the
+// team has come to bat in it, so a home team that didn't need the bottom of
+// the 9th has eight entries.
+type Linescore struct {
+	Away []int `json:"away"`
+	Home []int `json:"home"`
 }
 
 // BaseState represents which bases are occupied
@@ -51,7 +85,8 @@ type AtBat struct {
 	BatterHand  string  `json:"batter_hand"`  // "L" or "R"
 	PitcherHand string  `json:"pitcher_hand"` // "L" or "R"
 	PitchCount  int     `json:"pitch_count"`
-	Leverage    float64 `json:"leverage"` // Leverage index
+	Leverage    float64 `json:"leverage"`    // Leverage index
+	TimesFaced  int     `json:"times_faced"` // Earlier plate appearances against this pitcher in the game
 }
 
 // Weather represents game conditions
@@ -61,6 +96,11 @@ type Weather struct {
 	WindDir     string  `json:"wind_dir"`    // "in", "out", "left", "right"
 	Humidity    int     `json:"humidity"`    // Percentage
 	Pressure    float64 `json:"pressure"`    // Inches of mercury
+
+	// Forecast rain at game time: chance of precipitation (0-1) and expected
+	// rain volume over three hours
+	PrecipProbability float64 `json:"precip_probability,omitempty"`
+	RainMM            float64 `json:"rain_mm,omitempty"`
 }
 
 // GameEvent represents something that happened in the game
@@ -77,6 +117,7 @@ type GameEvent struct {
 	RBI         int                    `json:"rbi,omitempty"`
 	Outs        int                    `json:"outs,omitempty"`
 	Leverage    float64                `json:"leverage"`
+	BattedBall  *BattedBall            `json:"batted_ball,omitempty"`
 	Timestamp   time.Time              `json:"timestamp"`
 }
 
@@ -93,6 +134,32 @@ type SimulationResult struct {
 	FinalState       GameState   `json:"final_state"`
 	CreatedAt        time.Time   `json:"created_at"`
 	PlayerStats      *GamePlayerStats `json:"player_stats,omitempty"`
+	CatcherImpact    *CatcherImpactSummary `json:"catcher_impact,omitempty"`
+	Defense          *DefenseSummary       `json:"defense,omitempty"`
+	Baserunning      *BaserunningSummary   `json:"baserunning,omitempty"`
+	FirstFive        *ScoreSnapshot        `json:"first_five,omitempty"` // Score after five complete innings
+	PitchingChanges  *PitchingChanges      `json:"pitching_changes,omitempty"`
+	Sidelined        []string              `json:"sidelined,omitempty"` // Questionable players who sat this game out
+}
+
+// PitchingChanges counts each team's pitching changes in a game. The
+// mid-inning counts are the changes made for a platoon matchup.
+type PitchingChanges struct {
+	Home          int `json:"home"`
+	Away          int `json:"away"`
+	HomeMidInning int `json:"home_mid_inning"`
+	AwayMidInning int `json:"away_mid_inning"`
+}
+
+// Total is both teams' pitching changes
+func (pc PitchingChanges) Total() int {
+	return pc.Home + pc.Away
+}
+
+// ScoreSnapshot is the score at a point in a game
+type ScoreSnapshot struct {
+	HomeScore int `json:"home_score"`
+	AwayScore int `json:"away_score"`
 }
 
 // GamePlayerStats tracks player performance for a single simulated game
@@ -117,6 +184,9 @@ type PlayerGameBatting struct {
 	R        int // Runs scored
 	BB       int
 	K        int
+
+	XH         float64 // Expected hits from contact quality
+	XWOBAValue float64 // Expected wOBA numerator
 }
 
 // PlayerGamePitching tracks pitching stats for one game
@@ -151,6 +221,13 @@ type AggregatedResult struct {
 	HighLeverageEvents    []GameEvent        `json:"high_leverage_events"`
 	Statistics            map[string]float64 `json:"statistics"`
 	PlayerPerformance     *AggregatedPlayerPerformance `json:"player_performance,omitempty"`
+	Partial               *PartialRunMetadata          `json:"partial,omitempty"` // Set when the run stopped at its time budget
+	Degraded              bool                         `json:"degraded,omitempty"` // A roster needed placeholders; see lineups' roster_gaps
+	Markets               *Markets                     `json:"markets,omitempty"`
+	InningScoring         *InningDistributions         `json:"inning_scoring,omitempty"`
+	Lineups               *LineupCards                 `json:"lineups,omitempty"` // Lineup cards both teams used
+	ScenarioBands         *ScenarioBands               `json:"scenario_bands,omitempty"`
+	AvailabilityImpact    []AvailabilityImpact         `json:"availability_impact,omitempty"` // Set when the run's config gave play probabilities
 }
 
 // AggregatedPlayerPerformance contains averaged player statistics across all simulations
@@ -186,12 +263,17 @@ type PlayerBattingStats struct {
 	AVG        float64 `json:"avg"`  // Batting average (H/AB)
 	OBP        float64 `json:"obp"`  // On-base percentage
 	SLG        float64 `json:"slg"`  // Slugging percentage
+	XH         float64 `json:"xh"`          // Expected hits from contact quality
+	XWOBAValue float64 `json:"xwoba_value"` // Expected wOBA numerator
+	XBA        float64 `json:"xba"`         // Expected batting average (XH/AB)
+	XWOBA      float64 `json:"xwoba"`       // Expected wOBA
 }
 
 // PlayerPitchingStats represents average pitching performance across simulations
 type PlayerPitchingStats struct {
 	PlayerID    string  `json:"player_id"`
 	PlayerName  string  `json:"player_name"`
+	G           float64 `json:"g"`    // Appearances (share of simulations pitched in)
 	IP          float64 `json:"ip"`   // Innings pitched
 	H           float64 `json:"h"`    // Hits allowed
 	R           float64 `json:"r"`    // Runs allowed
@@ -202,6 +284,8 @@ type PlayerPitchingStats struct {
 	Pitches     float64 `json:"pitches"` // Total pitches
 	ERA         float64 `json:"era"`  // Earned run average
 	WHIP        float64 `json:"whip"` // Walks + Hits per inning pitched
+
+	ColdWeatherPenalty *ColdWeatherPenalty `json:"cold_weather_penalty,omitempty"`
 }
 
 // NewGameState creates a new game state for simulation
@@ -252,6 +336,7 @@ func (gs *GameState) AdvanceInning() {
 	gs.Outs = 0
 	gs.Count = Count{Balls: 0, Strikes: 0}
 	gs.Bases = BaseState{} // Clear bases
+	gs.recordLinescore(0)  // Scoreless halves still count as batted
 
 	if gs.InningHalf == "top" {
 		gs.InningHalf = "bottom"
@@ -268,6 +353,22 @@ func (gs *GameState) AddRuns(runs int) {
 	} else {
 		gs.HomeScore += runs
 	}
+	gs.recordLinescore(runs)
+}
+
+// recordLinescore adds runs to the batting team's current inning
+func (gs *GameState) recordLinescore(runs int) {
+	if gs.Inning < 1 {
+		return
+	}
+	innings := &gs.Linescore.Away
+	if gs.InningHalf == "bottom" {
+		innings = &gs.Linescore.Home
+	}
+	for len(*innings) < gs.Inning {
+		*innings = append(*innings, 0)
+	}
+	(*innings)[gs.Inning-1] += runs
 }
 
 // GetBaseRunners returns a slice of all base runners
@@ -316,32 +417,33 @@ func (bs *BaseState) ClearBases() {
 func (gs *GameState) CalculateLeverage() float64 {
 	// Simplified leverage calculation
 	// Real leverage index is more complex, considering inning, score differential, runners, outs
+	tuning := gs.Tuning()
 
 	baseLeverage := 1.0
 
 	// Inning multiplier
-	if gs.Inning >= 7 {
-		baseLeverage += float64(gs.Inning-6) * 0.3
+	if float64(gs.Inning) >= tuning.LeverageLateInning {
+		baseLeverage += (float64(gs.Inning) - tuning.LeverageLateInning + 1) * tuning.LeveragePerLateInning
 	}
 
 	// Score differential impact
-	scoreDiff := abs(gs.HomeScore - gs.AwayScore)
-	if scoreDiff <= 3 {
-		baseLeverage += (4 - float64(scoreDiff)) * 0.2
+	scoreDiff := float64(abs(gs.HomeScore - gs.AwayScore))
+	if scoreDiff <= tuning.LeverageCloseMargin {
+		baseLeverage += (tuning.LeverageCloseMargin + 1 - scoreDiff) * tuning.LeveragePerCloseRun
 	}
 
 	// Runners on base
 	runners := gs.Bases.GetBaseCount()
-	baseLeverage += float64(runners) * 0.1
+	baseLeverage += float64(runners) * tuning.LeveragePerRunner
 
 	// Out situation
 	if gs.Outs == 2 {
-		baseLeverage += 0.3
+		baseLeverage += tuning.LeverageTwoOuts
 	}
 
 	// Late inning bonus
 	if gs.Inning >= 9 {
-		baseLeverage += 0.5
+		baseLeverage += tuning.LeverageNinthInning
 	}
 
 	return baseLeverage
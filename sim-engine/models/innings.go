@@ -0,0 +1,173 @@
+package models
+
+import "math"
+
+// RegulationInnings is the number of innings reported individually; later
+// innings are combined into the extra innings entry
+const RegulationInnings = 9
+
+// inningRunBuckets label the run distribution; the last bucket is open-ended
+var inningRunBuckets = []string{"0", "1", "2", "3+"}
+
+// InningScoring is one team's scoring in an inning across the games in which
+// it came to bat in that inning
+type InningScoring struct {
+	Inning             int                `json:"inning,omitempty"` // 0 for the extra innings entry
+	GamesBatted        int                `json:"games_batted"`
+	ScoringProbability float64            `json:"scoring_probability"`
+	ExpectedRuns       float64            `json:"expected_runs"`
+	RunDistribution    map[string]float64 `json:"run_distribution"` // share of halves scoring 0, 1, 2 and 3+ runs
+}
+
+// TeamInningScoring is a team's scoring by inning. Extra innings are counted
+// per half-inning batted rather than per game.
+type TeamInningScoring struct {
+	Innings      []InningScoring `json:"innings"`
+	ExtraInnings *InningScoring  `json:"extra_innings,omitempty"`
+}
+
+// FirstToScore is the probability of each team scoring the game's first run
+type FirstToScore struct {
+	Home    float64 `json:"home"`
+	Away    float64 `json:"away"`
+	Neither float64 `json:"neither"`
+}
+
+// InningDistributions are per-inning scoring probabilities derived from each
+// simulated game's linescore
+type InningDistributions struct {
+	Home                      TeamInningScoring `json:"home"`
+	Away                      TeamInningScoring `json:"away"`
+	FirstToScore              FirstToScore      `json:"first_to_score"`
+	FirstInningRunProbability float64           `json:"first_inning_run_probability"` // either team scores in the 1st
+}
+
+// inningCounts accumulates one team's halves of one inning
+type inningCounts struct {
+	batted int
+	scored int
+	runs   int
+	byRuns [4]int // indexed like inningRunBuckets
+}
+
+func (c *inningCounts) add(runs int) {
+	c.batted++
+	c.runs += runs
+	if runs > 0 {
+		c.scored++
+	}
+	c.byRuns[min(runs, len(c.byRuns)-1)]++
+}
+
+func (c *inningCounts) scoring(inning int) InningScoring {
+	scoring := InningScoring{
+		Inning:          inning,
+		GamesBatted:     c.batted,
+		RunDistribution: make(map[string]float64, len(inningRunBuckets)),
+	}
+	for _, bucket := range inningRunBuckets {
+		scoring.RunDistribution[bucket] = 0
+	}
+	if c.batted == 0 {
+		return scoring
+	}
+	n := float64(c.batted)
+	scoring.ScoringProbability = roundProbability(float64(c.scored) / n)
+	scoring.ExpectedRuns = math.Round(float64(c.runs)/n*1000) / 1000
+	for i, bucket := range inningRunBuckets {
+		scoring.RunDistribution[bucket] = roundProbability(float64(c.byRuns[i]) / n)
+	}
+	return scoring
+}
+
+// teamInningCounts accumulates one team's linescores
+type teamInningCounts struct {
+	innings [RegulationInnings]inningCounts
+	extra   inningCounts
+}
+
+func (t *teamInningCounts) add(linescore []int) {
+	for i, runs := range linescore {
+		if i < RegulationInnings {
+			t.innings[i].add(runs)
+		} else {
+			t.extra.add(runs)
+		}
+	}
+}
+
+func (t *teamInningCounts) scoring() TeamInningScoring {
+	scoring := TeamInningScoring{Innings: make([]InningScoring, 0, RegulationInnings)}
+	for i := range t.innings {
+		scoring.Innings = append(scoring.Innings, t.innings[i].scoring(i+1))
+	}
+	if t.extra.batted > 0 {
+		extra := t.extra.scoring(0)
+		scoring.ExtraInnings = &extra
+	}
+	return scoring
+}
+
+// InningTally counts simulated linescores toward the inning distributions
+type InningTally struct {
+	games          int
+	home, away     teamInningCounts
+	firstHome      int
+	firstAway      int
+	firstInningRun int
+}
+
+// NewInningTally creates an empty tally
+func NewInningTally() *InningTally {
+	return &InningTally{}
+}
+
+// Add counts one simulated game's linescore
+func (t *InningTally) Add(result SimulationResult) {
+	linescore := result.FinalState.Linescore
+	t.games++
+	t.away.add(linescore.Away)
+	t.home.add(linescore.Home)
+
+	if inningRuns(linescore.Away, 0)+inningRuns(linescore.Home, 0) > 0 {
+		t.firstInningRun++
+	}
+
+	// The away team bats first in every inning
+	for i := 0; i < len(linescore.Away) || i < len(linescore.Home); i++ {
+		if inningRuns(linescore.Away, i) > 0 {
+			t.firstAway++
+			return
+		}
+		if inningRuns(linescore.Home, i) > 0 {
+			t.firstHome++
+			return
+		}
+	}
+}
+
+// inningRuns is the runs scored in an inning, or 0 if the team didn't bat
+func inningRuns(innings []int, i int) int {
+	if i >= len(innings) {
+		return 0
+	}
+	return innings[i]
+}
+
+// Distributions converts the tally into probabilities, or nil when no games were counted
+func (t *InningTally) Distributions() *InningDistributions {
+	if t.games == 0 {
+		return nil
+	}
+	n := float64(t.games)
+	return &InningDistributions{
+		Home: t.home.scoring(),
+		Away: t.away.scoring(),
+		FirstToScore: FirstToScore{
+			Home:    roundProbability(float64(t.firstHome) / n),
+			Away:    roundProbability(float64(t.firstAway) / n),
+			Neither: roundProbability(float64(t.games-t.firstHome-t.firstAway) / n),
+		},
+		FirstInningRunProbability: roundProbability(float64(t.firstInningRun) / n),
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import "testing"
+
+func linescoreGame(away, home []int) SimulationResult {
+	return SimulationResult{FinalState: GameState{Linescore: Linescore{Away: away, Home: home}}}
+}
+
+func TestLinescoreRecordsEveryHalfBatted(t *testing.T) {
+	gs := NewGameState("game", "run")
+	gs.AddRuns(2)      // top 1st
+	gs.AdvanceInning() // to bottom 1st
+	gs.AdvanceInning() // scoreless bottom 1st, to top 2nd
+	gs.AdvanceInning() // scoreless top 2nd, to bottom 2nd
+	gs.AddRuns(1)
+	gs.AddRuns(1)
+
+	if got := gs.Linescore.Away; len(got) != 2 || got[0] != 2 || got[1] != 0 {
+		t.Errorf("away linescore = %v, want [2 0]", got)
+	}
+	if got := gs.Linescore.Home; len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Errorf("home linescore = %v, want [0 2]", got)
+	}
+}
+
+func TestInningTallyFirstInning(t *testing.T) {
+	tally := NewInningTally()
+	tally.Add(linescoreGame([]int{1, 0}, []int{0, 0}))
+	tally.Add(linescoreGame([]int{0, 0}, []int{3, 0}))
+	tally.Add(linescoreGame([]int{0, 0}, []int{0, 0}))
+	tally.Add(linescoreGame([]int{0, 2}, []int{0, 0}))
+
+	dist := tally.Distributions()
+	away, home := dist.Away.Innings[0], dist.Home.Innings[0]
+	if away.ScoringProbability != 0.25 || home.ScoringProbability != 0.25 {
+		t.Errorf("1st inning scoring = %v away / %v home, want 0.25 / 0.25", away.ScoringProbability, home.ScoringProbability)
+	}
+	if home.ExpectedRuns != 0.75 {
+		t.Errorf("home 1st inning expected runs = %v, want 0.75", home.ExpectedRuns)
+	}
+	if home.RunDistribution["0"] != 0.75 || home.RunDistribution["3+"] != 0.25 {
+		t.Errorf("home 1st inning distribution = %v, want 0: 0.75, 3+: 0.25", home.RunDistribution)
+	}
+	if dist.FirstInningRunProbability != 0.5 {
+		t.Errorf("first inning run probability = %v, want 0.5", dist.FirstInningRunProbability)
+	}
+}
+
+func TestInningTallyFirstToScore(t *testing.T) {
+	tally := NewInningTally()
+	tally.Add(linescoreGame([]int{0, 1}, []int{1, 0})) // home in the 1st
+	tally.Add(linescoreGame([]int{0, 1}, []int{0, 1})) // away in the 2nd, before home bats
+	tally.Add(linescoreGame([]int{1, 0}, []int{0, 0})) // away in the 1st
+	tally.Add(linescoreGame([]int{0, 0}, []int{0, 0})) // nobody
+
+	first := tally.Distributions().FirstToScore
+	if first.Home != 0.25 || first.Away != 0.5 || first.Neither != 0.25 {
+		t.Errorf("first to score = %+v, want home 0.25, away 0.5, neither 0.25", first)
+	}
+}
+
+func TestInningTallyOnlyCountsInningsBatted(t *testing.T) {
+	regulation := make([]int, RegulationInnings)
+	noNinth := make([]int, RegulationInnings-1)
+	extras := make([]int, RegulationInnings+2)
+	extras[RegulationInnings+1] = 2
+
+	tally := NewInningTally()
+	tally.Add(linescoreGame(regulation, noNinth))
+	tally.Add(linescoreGame(extras, extras))
+
+	dist := tally.Distributions()
+	if got := dist.Home.Innings[RegulationInnings-1].GamesBatted; got != 1 {
+		t.Errorf("home batted in the 9th in %d games, want 1", got)
+	}
+	extra := dist.Away.ExtraInnings
+	if extra == nil {
+		t.Fatal("expected an extra innings entry")
+	}
+	if extra.GamesBatted != 2 || extra.ScoringProbability != 0.5 || extra.ExpectedRuns != 1 {
+		t.Errorf("away extra innings = %+v, want 2 halves, 0.5 scoring, 1 expected run", *extra)
+	}
+	if dist.Home.Innings[0].Inning != 1 || len(dist.Home.Innings) != RegulationInnings {
+		t.Errorf("home innings should be numbered 1 to %d", RegulationInnings)
+	}
+}
+
+func TestInningTallyEmpty(t *testing.T) {
+	if NewInningTally().Distributions() != nil {
+		t.Error("expected no distributions for an empty tally")
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+// LeagueEnvironment holds the per-season run environment constants used to
+// calibrate at-bat and projection math
+type LeagueEnvironment struct {
+	Season      int     `json:"season"`
+	WOBA        float64 `json:"league_woba"`
+	WOBAScale   float64 `json:"woba_scale"`
+	FIPConstant float64 `json:"fip_constant"`
+	LeagueFIP   float64 `json:"league_fip"`
+	RunsPerPA   float64 `json:"runs_per_pa"`
+}
+
+// DefaultLeagueEnvironment returns the constants used when no season row is available
+func DefaultLeagueEnvironment() *LeagueEnvironment {
+	return &LeagueEnvironment{
+		WOBA:        0.320,
+		WOBAScale:   1.25,
+		FIPConstant: 3.10,
+		LeagueFIP:   4.20,
+		RunsPerPA:   0.118,
+	}
+}
+
+// League returns the game's league environment, falling back to the defaults
+func (gs *GameState) League() *LeagueEnvironment {
+	if gs == nil || gs.LeagueEnv == nil {
+		return DefaultLeagueEnvironment()
+	}
+	return gs.LeagueEnv
+}
+
+// FIPToWOBA converts a pitcher's FIP into the wOBA allowed by a league
+// average batter. Each run of FIP above league average is worth roughly
+// 30 points of wOBA.
+func (le *LeagueEnvironment) FIPToWOBA(fip float64) float64 {
+	return le.WOBA + (fip-le.LeagueFIP)*0.03
+}
+
+// CalculateFIP computes FIP from component counts with this season's constant
+func (le *LeagueEnvironment) CalculateFIP(hr, bb, hbp, so int, ip float64) float64 {
+	if ip <= 0 {
+		return le.LeagueFIP
+	}
+	return float64(13*hr+3*(bb+hbp)-2*so)/ip + le.FIPConstant
+}
+
+// WRAA converts a wOBA over a number of plate appearances into runs above average
+func (le *LeagueEnvironment) WRAA(woba float64, pa int) float64 {
+	if le.WOBAScale <= 0 {
+		return 0
+	}
+	return (woba - le.WOBA) / le.WOBAScale * float64(pa)
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLeagueEnvironmentDefaults(t *testing.T) {
+	var gs *GameState
+	if gs.League().WOBA != 0.320 {
+		t.Errorf("Expected default league wOBA 0.320, got %.3f", gs.League().WOBA)
+	}
+
+	env := &LeagueEnvironment{Season: 2022, WOBA: 0.310, WOBAScale: 1.259, FIPConstant: 3.112, LeagueFIP: 3.97}
+	gs = NewGameState("g", "r")
+	gs.LeagueEnv = env
+	if gs.League() != env {
+		t.Error("Expected game state to use its league environment")
+	}
+}
+
+func TestFIPToWOBA(t *testing.T) {
+	env := &LeagueEnvironment{WOBA: 0.310, LeagueFIP: 3.97}
+
+	if got := env.FIPToWOBA(3.97); math.Abs(got-0.310) > 1e-9 {
+		t.Errorf("League average FIP should map to league wOBA, got %.4f", got)
+	}
+	if env.FIPToWOBA(5.00) <= env.FIPToWOBA(3.00) {
+		t.Error("Higher FIP should allow a higher wOBA")
+	}
+}
+
+func TestCalculateFIP(t *testing.T) {
+	env := &LeagueEnvironment{FIPConstant: 3.10, LeagueFIP: 4.20}
+
+	// (13*20 + 3*(50+5) - 2*180) / 180 + 3.10
+	want := float64(13*20+3*55-2*180)/180 + 3.10
+	if got := env.CalculateFIP(20, 50, 5, 180, 180); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected FIP %.3f, got %.3f", want, got)
+	}
+	if got := env.CalculateFIP(1, 1, 0, 0, 0); got != 4.20 {
+		t.Errorf("Expected league FIP with no innings, got %.2f", got)
+	}
+}
+
+func TestWRAA(t *testing.T) {
+	env := &LeagueEnvironment{WOBA: 0.320, WOBAScale: 1.25}
+	if got := env.WRAA(0.370, 500); math.Abs(got-20) > 1e-9 {
+		t.Errorf("Expected 20 wRAA, got %.2f", got)
+	}
+}
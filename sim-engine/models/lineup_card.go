@@ -0,0 +1,39 @@
+package models
+
+// LineupCards are the lineups both teams used in a run
+type LineupCards struct {
+	Home LineupCard `json:"home"`
+	Away LineupCard `json:"away"`
+}
+
+// LineupCard is one team's lineup: batting order with fielding positions,
+// starting pitcher and the position players left on the bench
+type LineupCard struct {
+	TeamID          string        `json:"team_id"`
+	BattingOrder    []LineupEntry `json:"batting_order"`
+	StartingPitcher *LineupEntry  `json:"starting_pitcher,omitempty"`
+	Bench           []LineupEntry `json:"bench"`
+
+	// CoverageIssues lists positions the roster couldn't fill properly, such
+	// as a non-catcher behind the plate
+	CoverageIssues []string `json:"coverage_issues,omitempty"`
+
+	// RosterGaps lists what replacement-level placeholders were added to an
+	// incomplete roster; a card with gaps makes the run degraded
+	RosterGaps []string `json:"roster_gaps,omitempty"`
+}
+
+// LineupEntry is a player on a lineup card
+type LineupEntry struct {
+	Order          int    `json:"order,omitempty"` // batting order, 1-9
+	PlayerID       string `json:"player_id"`
+	Name           string `json:"name"`
+	Position       string `json:"position"`                  // where the player fields in this game
+	ListedPosition string `json:"listed_position,omitempty"` // roster position, when different
+	Placeholder    bool   `json:"placeholder,omitempty"`     // replacement-level stand-in
+}
+
+// Degraded reports whether either team's roster needed placeholders
+func (c LineupCards) Degraded() bool {
+	return len(c.Home.RosterGaps) > 0 || len(c.Away.RosterGaps) > 0
+}
@@ -0,0 +1,225 @@
+package models
+
+import "math"
+
+const (
+	// RunLineSpread is the standard MLB run line
+	RunLineSpread = 1.5
+
+	// Half-run game totals offered in the markets block
+	MinTotalLine = 6.5
+	MaxTotalLine = 13.5
+
+	// FirstFiveInnings is the length of the first-five-innings market
+	FirstFiveInnings = 5
+
+	// Half-run first-five totals
+	MinFirstFiveTotalLine = 2.5
+	MaxFirstFiveTotalLine = 6.5
+)
+
+// RunLineMarket is the probability of each side covering the 1.5-run spread
+type RunLineMarket struct {
+	HomeMinus float64 `json:"home_minus_1_5"` // home wins by 2 or more
+	AwayPlus  float64 `json:"away_plus_1_5"`  // away wins or loses by 1
+	AwayMinus float64 `json:"away_minus_1_5"` // away wins by 2 or more
+	HomePlus  float64 `json:"home_plus_1_5"`  // home wins or loses by 1
+}
+
+// TotalMarket is the over/under probability for one game total. Half-run
+// lines can't push, so Over and Under sum to 1.
+type TotalMarket struct {
+	Line  float64 `json:"line"`
+	Over  float64 `json:"over"`
+	Under float64 `json:"under"`
+}
+
+// FirstFiveMarket is the outcome after five complete innings
+type FirstFiveMarket struct {
+	HomeWinProbability    float64       `json:"home_win_probability"`
+	AwayWinProbability    float64       `json:"away_win_probability"`
+	TieProbability        float64       `json:"tie_probability"`
+	ExpectedHomeScore     float64       `json:"expected_home_score"`
+	ExpectedAwayScore     float64       `json:"expected_away_score"`
+	HomeScoreDistribution map[int]int   `json:"home_score_distribution"`
+	AwayScoreDistribution map[int]int   `json:"away_score_distribution"`
+	Totals                []TotalMarket `json:"totals"`
+}
+
+// FirstInningMarket is the probability of runs in the 1st inning. Either is
+// "yes run first inning" and Neither "no run first inning".
+type FirstInningMarket struct {
+	HomeScores float64 `json:"home_scores"`
+	AwayScores float64 `json:"away_scores"`
+	Either     float64 `json:"either"`
+	Neither    float64 `json:"neither"`
+}
+
+// Markets are betting-style probabilities derived from the joint simulated
+// scores rather than from each team's score distribution independently
+type Markets struct {
+	RunLine     RunLineMarket      `json:"run_line"`
+	Totals      []TotalMarket      `json:"totals"`
+	FirstFive   *FirstFiveMarket   `json:"first_five,omitempty"`
+	FirstInning *FirstInningMarket `json:"first_inning,omitempty"`
+}
+
+// MarketTally counts simulated games toward each market
+type MarketTally struct {
+	games         int
+	homeByTwo     int
+	awayByTwo     int
+	totalRuns     map[int]int
+	firstFive     int
+	firstFiveHome int
+	firstFiveAway int
+	firstFiveTies int
+	f5HomeRuns    int
+	f5AwayRuns    int
+	f5HomeScores  map[int]int
+	f5AwayScores  map[int]int
+	f5TotalRuns   map[int]int
+	firstInning   int
+	firstHome     int
+	firstAway     int
+	firstEither   int
+}
+
+// NewMarketTally creates an empty tally
+func NewMarketTally() *MarketTally {
+	return &MarketTally{
+		totalRuns:    make(map[int]int),
+		f5HomeScores: make(map[int]int),
+		f5AwayScores: make(map[int]int),
+		f5TotalRuns:  make(map[int]int),
+	}
+}
+
+// Add counts one simulated game. Games that ended before five complete
+// innings don't count toward the first-five market, and games without a
+// linescore don't count toward the first-inning market.
+func (t *MarketTally) Add(result SimulationResult) {
+	t.games++
+	margin := result.HomeScore - result.AwayScore
+	switch {
+	case margin >= 2:
+		t.homeByTwo++
+	case margin <= -2:
+		t.awayByTwo++
+	}
+	t.totalRuns[result.HomeScore+result.AwayScore]++
+
+	if linescore := result.FinalState.Linescore; len(linescore.Away) > 0 {
+		t.firstInning++
+		home, away := inningRuns(linescore.Home, 0), inningRuns(linescore.Away, 0)
+		if home > 0 {
+			t.firstHome++
+		}
+		if away > 0 {
+			t.firstAway++
+		}
+		if home+away > 0 {
+			t.firstEither++
+		}
+	}
+
+	if result.FirstFive == nil {
+		return
+	}
+	t.firstFive++
+	t.f5HomeRuns += result.FirstFive.HomeScore
+	t.f5AwayRuns += result.FirstFive.AwayScore
+	t.f5HomeScores[result.FirstFive.HomeScore]++
+	t.f5AwayScores[result.FirstFive.AwayScore]++
+	t.f5TotalRuns[result.FirstFive.HomeScore+result.FirstFive.AwayScore]++
+	switch {
+	case result.FirstFive.HomeScore > result.FirstFive.AwayScore:
+		t.firstFiveHome++
+	case result.FirstFive.AwayScore > result.FirstFive.HomeScore:
+		t.firstFiveAway++
+	default:
+		t.firstFiveTies++
+	}
+}
+
+// OverProbability is the share of games whose combined runs exceed line
+func (t *MarketTally) OverProbability(line float64) float64 {
+	return overProbability(t.totalRuns, t.games, line)
+}
+
+// overProbability is the share of games whose combined runs, counted in
+// totalRuns, exceed line
+func overProbability(totalRuns map[int]int, games int, line float64) float64 {
+	if games == 0 {
+		return 0
+	}
+	over := 0
+	for runs, count := range totalRuns {
+		if float64(runs) > line {
+			over += count
+		}
+	}
+	return float64(over) / float64(games)
+}
+
+// totalMarkets are the over/under probabilities for each half-run line
+// from min to max
+func totalMarkets(totalRuns map[int]int, games int, min, max float64) []TotalMarket {
+	var totals []TotalMarket
+	for line := min; line <= max; line++ {
+		over := overProbability(totalRuns, games, line)
+		totals = append(totals, TotalMarket{
+			Line:  line,
+			Over:  roundProbability(over),
+			Under: roundProbability(1 - over),
+		})
+	}
+	return totals
+}
+
+// Markets converts the tally into probabilities, or nil when no games were counted
+func (t *MarketTally) Markets() *Markets {
+	if t.games == 0 {
+		return nil
+	}
+	n := float64(t.games)
+	markets := &Markets{
+		RunLine: RunLineMarket{
+			HomeMinus: roundProbability(float64(t.homeByTwo) / n),
+			AwayPlus:  roundProbability(1 - float64(t.homeByTwo)/n),
+			AwayMinus: roundProbability(float64(t.awayByTwo) / n),
+			HomePlus:  roundProbability(1 - float64(t.awayByTwo)/n),
+		},
+		Totals: totalMarkets(t.totalRuns, t.games, MinTotalLine, MaxTotalLine),
+	}
+
+	if t.firstFive > 0 {
+		f5 := float64(t.firstFive)
+		markets.FirstFive = &FirstFiveMarket{
+			HomeWinProbability:    roundProbability(float64(t.firstFiveHome) / f5),
+			AwayWinProbability:    roundProbability(float64(t.firstFiveAway) / f5),
+			TieProbability:        roundProbability(float64(t.firstFiveTies) / f5),
+			ExpectedHomeScore:     math.Round(float64(t.f5HomeRuns)/f5*100) / 100,
+			ExpectedAwayScore:     math.Round(float64(t.f5AwayRuns)/f5*100) / 100,
+			HomeScoreDistribution: t.f5HomeScores,
+			AwayScoreDistribution: t.f5AwayScores,
+			Totals:                totalMarkets(t.f5TotalRuns, t.firstFive, MinFirstFiveTotalLine, MaxFirstFiveTotalLine),
+		}
+	}
+
+	if t.firstInning > 0 {
+		n := float64(t.firstInning)
+		markets.FirstInning = &FirstInningMarket{
+			HomeScores: roundProbability(float64(t.firstHome) / n),
+			AwayScores: roundProbability(float64(t.firstAway) / n),
+			Either:     roundProbability(float64(t.firstEither) / n),
+			Neither:    roundProbability(1 - float64(t.firstEither)/n),
+		}
+	}
+	return markets
+}
+
+// roundProbability rounds to four decimal places
+func roundProbability(p float64) float64 {
+	return math.Round(p*10000) / 10000
+}
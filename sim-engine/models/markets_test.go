@@ -0,0 +1,120 @@
+package models
+
+import "testing"
+
+func marketGame(home, away, f5Home, f5Away int) SimulationResult {
+	return SimulationResult{
+		HomeScore: home,
+		AwayScore: away,
+		FirstFive: &ScoreSnapshot{HomeScore: f5Home, AwayScore: f5Away},
+	}
+}
+
+func TestMarketTallyRunLine(t *testing.T) {
+	tally := NewMarketTally()
+	tally.Add(marketGame(5, 2, 3, 1)) // home by 3
+	tally.Add(marketGame(4, 3, 2, 2)) // home by 1
+	tally.Add(marketGame(1, 6, 0, 4)) // away by 5
+	tally.Add(marketGame(2, 3, 1, 0)) // away by 1
+
+	markets := tally.Markets()
+	if markets.RunLine.HomeMinus != 0.25 || markets.RunLine.AwayPlus != 0.75 {
+		t.Errorf("home -1.5 / away +1.5 = %v / %v, want 0.25 / 0.75", markets.RunLine.HomeMinus, markets.RunLine.AwayPlus)
+	}
+	if markets.RunLine.AwayMinus != 0.25 || markets.RunLine.HomePlus != 0.75 {
+		t.Errorf("away -1.5 / home +1.5 = %v / %v, want 0.25 / 0.75", markets.RunLine.AwayMinus, markets.RunLine.HomePlus)
+	}
+}
+
+func TestMarketTallyTotals(t *testing.T) {
+	tally := NewMarketTally()
+	tally.Add(marketGame(5, 2, 3, 1)) // 7
+	tally.Add(marketGame(4, 3, 2, 2)) // 7
+	tally.Add(marketGame(1, 6, 0, 4)) // 7
+	tally.Add(marketGame(8, 6, 1, 0)) // 14
+
+	markets := tally.Markets()
+	if len(markets.Totals) != 8 {
+		t.Fatalf("got %d total lines, want 8 (6.5 through 13.5)", len(markets.Totals))
+	}
+	first, last := markets.Totals[0], markets.Totals[len(markets.Totals)-1]
+	if first.Line != 6.5 || first.Over != 1 || first.Under != 0 {
+		t.Errorf("6.5 line = %+v, want every game over", first)
+	}
+	if markets.Totals[1].Line != 7.5 || markets.Totals[1].Over != 0.25 {
+		t.Errorf("7.5 line = %+v, want 0.25 over", markets.Totals[1])
+	}
+	if last.Line != 13.5 || last.Over != 0.25 || last.Under != 0.75 {
+		t.Errorf("13.5 line = %+v, want 0.25 over", last)
+	}
+}
+
+func TestMarketTallyFirstFive(t *testing.T) {
+	tally := NewMarketTally()
+	tally.Add(marketGame(5, 2, 3, 1))
+	tally.Add(marketGame(4, 3, 2, 2))
+	tally.Add(marketGame(1, 6, 0, 4))
+	tally.Add(SimulationResult{HomeScore: 2, AwayScore: 0}) // no first-five snapshot
+
+	f5 := tally.Markets().FirstFive
+	if f5 == nil {
+		t.Fatal("expected a first-five market")
+	}
+	if f5.HomeWinProbability != 0.3333 || f5.AwayWinProbability != 0.3333 || f5.TieProbability != 0.3333 {
+		t.Errorf("first five = %+v, want a third each", f5)
+	}
+	if f5.ExpectedHomeScore != 1.67 || f5.ExpectedAwayScore != 2.33 {
+		t.Errorf("first five scores = %v-%v, want 1.67-2.33", f5.ExpectedHomeScore, f5.ExpectedAwayScore)
+	}
+}
+
+func TestMarketTallyEmpty(t *testing.T) {
+	if markets := NewMarketTally().Markets(); markets != nil {
+		t.Errorf("empty tally = %+v, want nil", markets)
+	}
+}
+
+func TestMarketTallyFirstFiveTotals(t *testing.T) {
+	tally := NewMarketTally()
+	tally.Add(marketGame(5, 2, 3, 1)) // 4 through five
+	tally.Add(marketGame(4, 3, 2, 2)) // 4
+	tally.Add(marketGame(1, 6, 0, 4)) // 4
+	tally.Add(marketGame(8, 6, 1, 0)) // 1
+
+	f5 := tally.Markets().FirstFive
+	if len(f5.Totals) != 5 || f5.Totals[0].Line != 2.5 || f5.Totals[4].Line != 6.5 {
+		t.Fatalf("first-five totals = %+v, want 2.5 through 6.5", f5.Totals)
+	}
+	if f5.Totals[1].Over != 0.75 || f5.Totals[2].Over != 0 || f5.Totals[2].Under != 1 {
+		t.Errorf("first-five 3.5 / 4.5 = %+v / %+v, want 0.75 and 0 over", f5.Totals[1], f5.Totals[2])
+	}
+	if f5.HomeScoreDistribution[2] != 1 || f5.HomeScoreDistribution[0] != 1 || f5.AwayScoreDistribution[4] != 1 {
+		t.Errorf("first-five distributions = %v / %v", f5.HomeScoreDistribution, f5.AwayScoreDistribution)
+	}
+}
+
+func TestMarketTallyFirstInning(t *testing.T) {
+	withLinescore := func(away, home []int) SimulationResult {
+		result := SimulationResult{}
+		result.FinalState.Linescore = Linescore{Away: away, Home: home}
+		return result
+	}
+
+	tally := NewMarketTally()
+	tally.Add(withLinescore([]int{1, 0}, []int{0, 0})) // away scores in the 1st
+	tally.Add(withLinescore([]int{0, 2}, []int{2, 0})) // home scores in the 1st
+	tally.Add(withLinescore([]int{1, 0}, []int{3, 0})) // both do
+	tally.Add(withLinescore([]int{0, 0}, []int{0, 1})) // nobody does
+	tally.Add(SimulationResult{HomeScore: 1})          // no linescore
+
+	first := tally.Markets().FirstInning
+	if first == nil {
+		t.Fatal("expected a first-inning market")
+	}
+	if first.AwayScores != 0.5 || first.HomeScores != 0.5 {
+		t.Errorf("first inning = %+v, want each team scoring half the time", first)
+	}
+	if first.Either != 0.75 || first.Neither != 0.25 {
+		t.Errorf("first inning either / neither = %v / %v, want 0.75 / 0.25", first.Either, first.Neither)
+	}
+}
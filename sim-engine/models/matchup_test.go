@@ -0,0 +1,23 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+// TestExpectedMatchupWOBA tests that pitchers who allow more help the batter
+func TestExpectedMatchupWOBA(t *testing.T) {
+	league := DefaultLeagueEnvironment()
+	batter := 0.340
+
+	ace := expectedMatchupWOBA(batter, league.FIPToWOBA(2.50))
+	average := expectedMatchupWOBA(batter, league.FIPToWOBA(league.LeagueFIP))
+	struggling := expectedMatchupWOBA(batter, league.FIPToWOBA(5.50))
+
+	if !(ace < average && average < struggling) {
+		t.Errorf("expected wOBA should rise with the pitcher's FIP, got %.3f, %.3f, %.3f", ace, average, struggling)
+	}
+	if want := (batter + league.WOBA) / 2; math.Abs(average-want) > 0.001 {
+		t.Errorf("a league-average pitcher should meet the batter halfway to %.3f, got %.3f", want, average)
+	}
+}
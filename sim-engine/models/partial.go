@@ -0,0 +1,49 @@
+package models
+
+import "math"
+
+// PartialRunMetadata describes the precision given up when a run stops at
+// its time budget before completing every requested simulation
+type PartialRunMetadata struct {
+	RequestedSimulations int        `json:"requested_simulations"`
+	AchievedSimulations  int        `json:"achieved_simulations"`
+	SampleFraction       float64    `json:"sample_fraction"`
+	HomeWinCI95          [2]float64 `json:"home_win_probability_ci95"`
+	MarginOfError        float64    `json:"margin_of_error"`
+	RequestedMargin      float64    `json:"margin_of_error_at_requested"`
+	IntervalWidening     float64    `json:"interval_widening"` // achieved margin / requested margin
+}
+
+// WinProbabilityMargin is the 95% normal-approximation margin of error for a
+// probability estimated from n simulations
+func WinProbabilityMargin(p float64, n int) float64 {
+	if n <= 0 {
+		return 0
+	}
+	return 1.96 * math.Sqrt(p*(1-p)/float64(n))
+}
+
+// NewPartialRunMetadata compares the achieved sample against the requested one
+func NewPartialRunMetadata(requested, achieved int, homeWinProbability float64) *PartialRunMetadata {
+	margin := WinProbabilityMargin(homeWinProbability, achieved)
+	requestedMargin := WinProbabilityMargin(homeWinProbability, requested)
+
+	meta := &PartialRunMetadata{
+		RequestedSimulations: requested,
+		AchievedSimulations:  achieved,
+		MarginOfError:        margin,
+		RequestedMargin:      requestedMargin,
+		HomeWinCI95: [2]float64{
+			math.Max(0, homeWinProbability-margin),
+			math.Min(1, homeWinProbability+margin),
+		},
+	}
+	if requested > 0 {
+		meta.SampleFraction = float64(achieved) / float64(requested)
+	}
+	if achieved > 0 {
+		// Margins shrink with the square root of the sample size
+		meta.IntervalWidening = math.Sqrt(float64(requested) / float64(achieved))
+	}
+	return meta
+}
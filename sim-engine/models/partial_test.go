@@ -0,0 +1,25 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewPartialRunMetadata(t *testing.T) {
+	meta := NewPartialRunMetadata(10000, 2500, 0.55)
+
+	if meta.SampleFraction != 0.25 {
+		t.Errorf("Expected sample fraction 0.25, got %.3f", meta.SampleFraction)
+	}
+	// A quarter of the sample doubles the margin of error
+	if math.Abs(meta.IntervalWidening-2.0) > 1e-9 {
+		t.Errorf("Expected interval widening 2.0, got %.3f", meta.IntervalWidening)
+	}
+	if math.Abs(meta.MarginOfError-2*meta.RequestedMargin) > 1e-9 {
+		t.Errorf("Expected achieved margin to be twice the requested margin, got %.4f vs %.4f",
+			meta.MarginOfError, meta.RequestedMargin)
+	}
+	if meta.HomeWinCI95[0] >= 0.55 || meta.HomeWinCI95[1] <= 0.55 {
+		t.Errorf("Expected interval to contain the estimate, got %v", meta.HomeWinCI95)
+	}
+}
@@ -16,6 +16,10 @@ type Player struct {
 	Pitching   PitchingStats    `json:"pitching"`
 	Fielding   FieldingStats    `json:"fielding"`
 	Attributes PlayerAttributes `json:"attributes"`
+
+	// Placeholder marks a replacement-level stand-in added to fill a gap in
+	// an incomplete roster
+	Placeholder bool `json:"placeholder,omitempty"`
 }
 
 // BattingStats contains offensive statistics
@@ -150,6 +154,7 @@ type PlayerAttributes struct {
 	Accuracy    int `json:"accuracy"`     // 20-80 scale
 	Range       int `json:"range"`        // 20-80 scale
 	Hands       int `json:"hands"`        // 20-80 scale (fielding)
+	Delivery    int `json:"delivery"`     // 20-80 scale (pitchers: quickness to the plate against the running game)
 
 	// Physical
 	Height int `json:"height"` // inches
@@ -169,6 +174,13 @@ type Roster struct {
 	Lineup   []string `json:"lineup"`   // Player IDs in batting order
 	Rotation []string `json:"rotation"` // Starting pitcher IDs
 	Bullpen  []string `json:"bullpen"`  // Relief pitcher IDs
+
+	// BullpenAvailability is each tired reliever's chance of being available
+	// (0-1); relievers not listed are fully rested
+	BullpenAvailability map[string]float64 `json:"bullpen_availability,omitempty"`
+
+	// Gaps describes what placeholders were added to complete the roster
+	Gaps []string `json:"gaps,omitempty"`
 }
 
 // GetSplitStats returns appropriate split stats for the situation
@@ -215,11 +227,16 @@ func (bs *BattingStats) GetSplitStats(pitcherHand string, risp bool, highLeverag
 
 // GetSplitStats returns appropriate pitching splits for the situation
 func (ps *PitchingStats) GetSplitStats(batterHand string, risp bool, highLeverage bool) SplitStats {
+	return ps.GetSplitStatsForLeague(DefaultLeagueEnvironment(), batterHand, risp, highLeverage)
+}
+
+// GetSplitStatsForLeague returns pitching splits calibrated to a season's league environment
+func (ps *PitchingStats) GetSplitStatsForLeague(league *LeagueEnvironment, batterHand string, risp bool, highLeverage bool) SplitStats {
 	var split SplitStats
 
 	// Convert pitching stats to "offensive" equivalent for easier calculation
-	// Higher ERA/WHIP = worse for pitcher = better wOBA equivalent for batter
-	baseWOBA := 0.320 + (ps.FIP-3.70)*0.03 // Rough conversion
+	// Higher FIP = worse for pitcher = better wOBA equivalent for batter
+	baseWOBA := league.FIPToWOBA(ps.FIP)
 
 	split = SplitStats{
 		WOBA: math.Max(0.200, math.Min(0.500, baseWOBA)),
@@ -255,26 +272,55 @@ func (p *Player) SimulateAtBat(pitcher *Player, gameState *GameState, weather We
 // SimulateAtBatWithContext simulates a plate appearance with full context
 func (p *Player) SimulateAtBatWithContext(pitcher *Player, gameState *GameState, weather Weather,
 	umpire *UmpireTendencies, parkFactors *ParkFactors, stadium *StadiumDimensions) AtBatResult {
+	return p.SimulateAtBatWithDefense(pitcher, nil, gameState, weather, umpire, parkFactors, stadium)
+}
+
+// expectedMatchupWOBA is the expected wOBA of a plate appearance: the average of
+// the batter's wOBA and the wOBA the pitcher allows, so a pitcher who allows
+// more than league average helps the batter
+func expectedMatchupWOBA(batterWOBA, pitcherWOBAAllowed float64) float64 {
+	return (batterWOBA + pitcherWOBAAllowed) / 2
+}
+
+// SimulateAtBatWithDefense simulates a plate appearance with full context and
+// the defensive catcher, whose framing shifts edge calls. catcher may be nil.
+func (p *Player) SimulateAtBatWithDefense(pitcher *Player, catcher *Player, gameState *GameState, weather Weather,
+	umpire *UmpireTendencies, parkFactors *ParkFactors, stadium *StadiumDimensions) AtBatResult {
 
 	// Get situational stats
 	risp := gameState.Bases.Second != nil || gameState.Bases.Third != nil
-	highLeverage := gameState.CalculateLeverage() > 1.5
+	tuning := gameState.Tuning()
+	highLeverage := gameState.CalculateLeverage() > tuning.HighLeverageThreshold
 
+	league := gameState.League()
 	batterSplit := p.Batting.GetSplitStats(pitcher.Hand, risp, highLeverage)
-	pitcherSplit := pitcher.Pitching.GetSplitStats(p.Hand, risp, highLeverage)
+	pitcherSplit := pitcher.Pitching.GetSplitStatsForLeague(league, p.Hand, risp, highLeverage)
 
 	// Calculate matchup advantage
-	// Average the batter's expected performance with pitcher's expected performance
-	expectedWOBA := (batterSplit.WOBA + (0.320*2 - pitcherSplit.WOBA)) / 2
+	expectedWOBA := expectedMatchupWOBA(batterSplit.WOBA, pitcherSplit.WOBA)
 
 	// Apply count effects
-	countAdjustment := getCountAdjustment(gameState.Count)
+	countAdjustment := tuning.CountAdjustment(gameState.Count)
 	expectedWOBA += countAdjustment
 
 	// Apply weather effects
-	weatherAdjustment := getWeatherAdjustment(weather)
+	weatherAdjustment := tuning.WeatherAdjustment(weather)
 	expectedWOBA += weatherAdjustment
 
+	// Batters who have already seen the pitcher today do better
+	expectedWOBA += tuning.TimesFacedAdjustment(gameState.CurrentAB.TimesFaced, pitcher.Pitching.PitchMix)
+
+	// Home batters get the home-field edge, except at neutral sites; both
+	// sides get any form prior
+	if gameState.InningHalf == "bottom" {
+		expectedWOBA += gameState.HomeFormWOBA
+		if !gameState.NeutralSite {
+			expectedWOBA += tuning.HomeFieldWOBA
+		}
+	} else {
+		expectedWOBA += gameState.AwayFormWOBA
+	}
+
 	// Apply umpire effects if available
 	if umpire != nil {
 		leverage := gameState.CalculateLeverage()
@@ -286,12 +332,12 @@ func (p *Player) SimulateAtBatWithContext(pitcher *Player, gameState *GameState,
 	expectedWOBA = math.Max(0.200, math.Min(0.500, expectedWOBA))
 
 	// Simulate outcome based on expected wOBA with park factors
-	return simulateOutcomeWithParkFactors(expectedWOBA, p, pitcher, gameState, umpire, parkFactors, stadium)
+	return simulateOutcomeWithParkFactors(expectedWOBA, p, pitcher, catcher, gameState, umpire, parkFactors, stadium)
 }
 
 // AtBatResult represents the outcome of a plate appearance
 type AtBatResult struct {
-	Type        string         `json:"type"`        // "single", "double", "triple", "home_run", "walk", "strikeout", "out", "hit_by_pitch"
+	Type        string         `json:"type"`        // "single", "double", "triple", "home_run", "walk", "strikeout", "out", "hit_by_pitch", "error"
 	Description string         `json:"description"` // Detailed description
 	Bases       int            `json:"bases"`       // 0=out, 1=single, 2=double, 3=triple, 4=HR
 	IsHit       bool           `json:"is_hit"`
@@ -299,60 +345,16 @@ type AtBatResult struct {
 	Outs        int            `json:"outs"`        // Outs made on this play
 	Advancement map[string]int `json:"advancement"` // How runners advance
 	Leverage    float64        `json:"leverage"`
-	WPA         float64        `json:"wpa"` // Win Probability Added
-}
-
-func getCountAdjustment(count Count) float64 {
-	// Hitter's counts favor the batter, pitcher's counts favor the pitcher
-	switch {
-	case count.Balls == 3 && count.Strikes == 0:
-		return 0.080 // 3-0 count
-	case count.Balls == 3 && count.Strikes == 1:
-		return 0.060 // 3-1 count
-	case count.Balls == 2 && count.Strikes == 0:
-		return 0.040 // 2-0 count
-	case count.Balls == 0 && count.Strikes == 2:
-		return -0.060 // 0-2 count
-	case count.Balls == 1 && count.Strikes == 2:
-		return -0.040 // 1-2 count
-	case count.Balls == 2 && count.Strikes == 2:
-		return -0.020 // 2-2 count
-	default:
-		return 0.0 // Even counts
-	}
-}
-
-func getWeatherAdjustment(weather Weather) float64 {
-	adjustment := 0.0
-
-	// Wind effects
-	switch weather.WindDir {
-	case "out":
-		adjustment += float64(weather.WindSpeed) * 0.001 // Helps fly balls
-	case "in":
-		adjustment -= float64(weather.WindSpeed) * 0.001 // Hurts fly balls
-	}
-
-	// Temperature effects (cold weather hurts offense)
-	if weather.Temperature < 50 {
-		adjustment -= 0.010
-	} else if weather.Temperature > 80 {
-		adjustment += 0.005
-	}
-
-	// Humidity effects (high humidity hurts fly balls slightly)
-	if weather.Humidity > 80 {
-		adjustment -= 0.005
-	}
-
-	return adjustment
+	WPA         float64        `json:"wpa"`                    // Win Probability Added
+	FramingRuns float64        `json:"framing_runs,omitempty"` // Expected runs saved by catcher framing
+	BattedBall  *BattedBall    `json:"batted_ball,omitempty"`  // Contact quality for balls in play
 }
 
 func simulateOutcome(expectedWOBA float64, batter *Player, pitcher *Player, gameState *GameState) AtBatResult {
-	return simulateOutcomeWithParkFactors(expectedWOBA, batter, pitcher, gameState, nil, nil, nil)
+	return simulateOutcomeWithParkFactors(expectedWOBA, batter, pitcher, nil, gameState, nil, nil, nil)
 }
 
-func simulateOutcomeWithParkFactors(expectedWOBA float64, batter *Player, pitcher *Player,
+func simulateOutcomeWithParkFactors(expectedWOBA float64, batter *Player, pitcher *Player, catcher *Player,
 	gameState *GameState, umpire *UmpireTendencies, parkFactors *ParkFactors, stadium *StadiumDimensions) AtBatResult {
 
 	// Use wOBA to determine outcome probabilities
@@ -361,8 +363,9 @@ func simulateOutcomeWithParkFactors(expectedWOBA float64, batter *Player, pitche
 	roll := rand.Float64()
 
 	// Base walk and strikeout probabilities
-	baseWalkProb := batter.Batting.BBPercent / 100.0 * (1.0 + (expectedWOBA-0.320)*2.0)
-	baseKProb := batter.Batting.KPercent / 100.0 * (1.0 - (expectedWOBA-0.320)*2.0)
+	leagueWOBA := gameState.League().WOBA
+	baseWalkProb := batter.Batting.BBPercent / 100.0 * (1.0 + (expectedWOBA-leagueWOBA)*2.0)
+	baseKProb := batter.Batting.KPercent / 100.0 * (1.0 - (expectedWOBA-leagueWOBA)*2.0)
 
 	// Apply umpire effects if available
 	if umpire != nil {
@@ -378,12 +381,36 @@ func simulateOutcomeWithParkFactors(expectedWOBA float64, batter *Player, pitche
 		baseWalkProb = math.Max(0.03, math.Min(0.20, baseWalkProb))
 	}
 
+	// Apply catcher framing: edge strikes turn walks into strikeouts
+	framingRuns := 0.0
+	if catcher != nil {
+		kDelta, bbDelta, runsSaved := GetFramingAdjustments(GetFramingStrikeShift(catcher, umpire))
+		baseKProb = math.Max(0.05, math.Min(0.40, baseKProb+kDelta))
+		baseWalkProb = math.Max(0.03, math.Min(0.20, baseWalkProb+bbDelta))
+		framingRuns = runsSaved
+	}
+
 	// Apply park factors to walk/strikeout if available
 	if parkFactors != nil {
 		baseWalkProb *= parkFactors.GetParkFactorMultiplier("walk", batter.Hand)
 		baseKProb *= parkFactors.GetParkFactorMultiplier("strikeout", batter.Hand)
 	}
 
+	// Cold costs the pitcher velocity and spin: fewer strikeouts, more
+	// home runs on contact
+	hrMultiplier := 1.0
+	if penalty := gameState.ColdWeatherPenaltyFor(pitcher); penalty != nil {
+		baseKProb *= penalty.KMultiplier
+		hrMultiplier = penalty.HRMultiplier
+	}
+
+	// A starter on short rest has less stuff and command
+	if gameState.ShortRestPenaltyFor(pitcher) {
+		baseKProb *= ShortRestKMultiplier
+		baseWalkProb *= ShortRestBBMultiplier
+		hrMultiplier *= ShortRestHRMultiplier
+	}
+
 	// Walk probability
 	walkProb := baseWalkProb
 	if roll < walkProb {
@@ -395,6 +422,7 @@ func simulateOutcomeWithParkFactors(expectedWOBA float64, batter *Player, pitche
 			IsOut:       false,
 			Outs:        0,
 			Leverage:    gameState.CalculateLeverage(),
+			FramingRuns: framingRuns,
 		}
 	}
 
@@ -409,6 +437,7 @@ func simulateOutcomeWithParkFactors(expectedWOBA float64, batter *Player, pitche
 			IsOut:       true,
 			Outs:        1,
 			Leverage:    gameState.CalculateLeverage(),
+			FramingRuns: framingRuns,
 		}
 	}
 
@@ -416,49 +445,59 @@ func simulateOutcomeWithParkFactors(expectedWOBA float64, batter *Player, pitche
 	hitProb := kProb + (expectedWOBA * 1.2) // Rough conversion
 	if roll < hitProb {
 		// Determine hit type with park factors
-		return simulateHitTypeWithParkFactors(expectedWOBA, batter, pitcher, parkFactors, stadium)
+		result := simulateHitTypeWithParkFactors(expectedWOBA, batter, pitcher, parkFactors, stadium, hrMultiplier)
+		result.FramingRuns = framingRuns
+		result.BattedBall = GenerateBattedBall(result.Type, pitcher)
+		return result
 	}
 
 	// Otherwise it's an out
+	battedBall := GenerateBattedBall("out", pitcher)
 	return AtBatResult{
 		Type:        "out",
-		Description: "Groundout",
+		Description: outDescription(battedBall),
 		Bases:       0,
 		IsHit:       false,
 		IsOut:       true,
 		Outs:        1,
 		Leverage:    gameState.CalculateLeverage(),
+		FramingRuns: framingRuns,
+		BattedBall:  battedBall,
 	}
 }
 
 func simulateHitType(expectedWOBA float64, batter *Player, pitcher *Player) AtBatResult {
-	return simulateHitTypeWithParkFactors(expectedWOBA, batter, pitcher, nil, nil)
+	return simulateHitTypeWithParkFactors(expectedWOBA, batter, pitcher, nil, nil, 1.0)
 }
 
+// parkHitMultipliers scales home runs and doubles for the park. Measured park
+// factors already reflect the fences, so the dimensions are only used for
+// parks without them.
+func parkHitMultipliers(parkFactors *ParkFactors, stadium *StadiumDimensions, batter, pitcher *Player) (hr, double float64) {
+	switch {
+	case parkFactors != nil:
+		return parkFactors.GetParkFactorMultiplier("home_run", batter.Hand),
+			parkFactors.GetParkFactorMultiplier("double", batter.Hand)
+	case stadium != nil:
+		return stadium.GetHRMultiplier(batter.Hand, pitcher.Hand),
+			stadium.GetDoubleMultiplier(batter.Hand, pitcher.Hand)
+	}
+	return 1, 1
+}
+
+// simulateHitTypeWithParkFactors picks the hit type; hrMultiplier scales the
+// home run probability for conditions on the pitcher's side
 func simulateHitTypeWithParkFactors(expectedWOBA float64, batter *Player, pitcher *Player,
-	parkFactors *ParkFactors, stadium *StadiumDimensions) AtBatResult {
+	parkFactors *ParkFactors, stadium *StadiumDimensions, hrMultiplier float64) AtBatResult {
 
 	roll := rand.Float64()
 
 	// Power factor influences extra base hits
 	powerFactor := float64(batter.Attributes.Power) / 50.0 // Normalize to ~1.0
 
-	// Base home run probability
-	baseHRProb := math.Min(0.15, (expectedWOBA-0.250)*0.3*powerFactor)
-
-	// Apply park factors to home runs
-	if parkFactors != nil {
-		hrMultiplier := parkFactors.GetParkFactorMultiplier("home_run", batter.Hand)
-		baseHRProb *= hrMultiplier
-	}
-
-	// Apply altitude effect if stadium info available
-	if stadium != nil && stadium.LeftField > 0 {
-		// Estimate altitude from park factors or use stored value
-		// For now, we'll use a simplified approach
-		// In practice, altitude would be passed separately
-		baseHRProb *= 1.0 // Placeholder for altitude adjustment
-	}
+	// Base home run probability, adjusted for the park
+	parkHR, parkDouble := parkHitMultipliers(parkFactors, stadium, batter, pitcher)
+	baseHRProb := math.Min(0.15, (expectedWOBA-0.250)*0.3*powerFactor) * hrMultiplier * parkHR
 
 	// Home run probability
 	hrProb := baseHRProb
@@ -492,10 +531,7 @@ func simulateHitTypeWithParkFactors(expectedWOBA float64, batter *Player, pitche
 	}
 
 	// Double probability
-	baseDoubleProb := math.Min(0.25, (expectedWOBA-0.250)*0.5*powerFactor)
-	if parkFactors != nil {
-		baseDoubleProb *= parkFactors.GetParkFactorMultiplier("double", batter.Hand)
-	}
+	baseDoubleProb := math.Min(0.25, (expectedWOBA-0.250)*0.5*powerFactor) * parkDouble
 
 	doubleProb := tripleProb + baseDoubleProb
 	if roll < doubleProb {
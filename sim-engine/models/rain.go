@@ -0,0 +1,80 @@
+package models
+
+import "math"
+
+const (
+	// RainDelayStarterThreshold is the delay length (minutes) after which a
+	// starter doesn't come back out; arms cool down in long delays
+	RainDelayStarterThreshold = 45
+
+	// Given rain at game time, light rain is usually played through. Delay and
+	// postponement odds rise with intensity (mm per hour) up to their caps.
+	rainDelayBase         = 0.30
+	rainDelayPerMMHour    = 0.15
+	maxRainDelay          = 0.90
+	rainPostponeBase      = 0.03
+	rainPostponePerMMHour = 0.08
+	maxRainPostpone       = 0.50
+
+	// Expected delay length given a delay
+	rainDelayBaseMinutes      = 30
+	rainDelayMinutesPerMMHour = 12
+	maxRainDelayMinutes       = 150
+)
+
+// RainRisk is the chance rain interrupts or wipes out a game
+type RainRisk struct {
+	PrecipProbability       float64 `json:"precip_probability"`
+	DelayProbability        float64 `json:"delay_probability"` // played after a delay
+	PostponementProbability float64 `json:"postponement_probability"`
+	ExpectedDelayMinutes    int     `json:"expected_delay_minutes"` // given a delay
+}
+
+// RoofKeepsWeatherOut reports whether a park's roof shelters the game from
+// rain and cold. Retractable roofs close for both.
+func RoofKeepsWeatherOut(roofType string) bool {
+	switch roofType {
+	case "dome", "indoor", "fixed_roof", "closed", "retractable":
+		return true
+	}
+	return false
+}
+
+// RainRiskFor estimates rain risk from the forecast at game time. Fixed and
+// retractable roofs keep the game dry.
+func RainRiskFor(weather Weather, roofType string) RainRisk {
+	if RoofKeepsWeatherOut(roofType) {
+		return RainRisk{}
+	}
+	chance := math.Max(0, math.Min(1, weather.PrecipProbability))
+	if chance == 0 {
+		return RainRisk{}
+	}
+
+	// Forecast rain volume covers three hours
+	intensity := math.Max(0, weather.RainMM) / 3
+	postpone := math.Min(maxRainPostpone, rainPostponeBase+rainPostponePerMMHour*intensity)
+	delay := math.Min(maxRainDelay, rainDelayBase+rainDelayPerMMHour*intensity) * (1 - postpone)
+
+	return RainRisk{
+		PrecipProbability:       chance,
+		DelayProbability:        roundProbability(chance * delay),
+		PostponementProbability: roundProbability(chance * postpone),
+		ExpectedDelayMinutes:    int(math.Min(maxRainDelayMinutes, rainDelayBaseMinutes+rainDelayMinutesPerMMHour*intensity)),
+	}
+}
+
+// DelayProbabilityIfPlayed is the chance of a delay in a game that is played,
+// which is every game the engine simulates
+func (r RainRisk) DelayProbabilityIfPlayed() float64 {
+	if r.PostponementProbability >= 1 {
+		return 0
+	}
+	return r.DelayProbability / (1 - r.PostponementProbability)
+}
+
+// DrawDelayMinutes draws a delay's length around the expected length; roll
+// returns a value in [0, 1)
+func (r RainRisk) DrawDelayMinutes(roll func() float64) int {
+	return int(float64(r.ExpectedDelayMinutes) * (0.5 + roll()))
+}
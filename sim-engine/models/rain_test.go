@@ -0,0 +1,45 @@
+package models
+
+import "testing"
+
+func TestRainRiskCoveredAndDry(t *testing.T) {
+	wet := Weather{PrecipProbability: 0.8, RainMM: 6}
+	for _, roof := range []string{"dome", "retractable"} {
+		if risk := RainRiskFor(wet, roof); risk != (RainRisk{}) {
+			t.Errorf("%s roof risk = %+v, want none", roof, risk)
+		}
+	}
+	if risk := RainRiskFor(Weather{}, "open"); risk != (RainRisk{}) {
+		t.Errorf("dry forecast risk = %+v, want none", risk)
+	}
+}
+
+func TestRainRiskGrowsWithIntensity(t *testing.T) {
+	light := RainRiskFor(Weather{PrecipProbability: 0.6, RainMM: 0.5}, "open")
+	heavy := RainRiskFor(Weather{PrecipProbability: 0.6, RainMM: 12}, "open")
+
+	if heavy.PostponementProbability <= light.PostponementProbability {
+		t.Errorf("postponement %.3f (heavy) should exceed %.3f (light)", heavy.PostponementProbability, light.PostponementProbability)
+	}
+	if heavy.DelayProbability <= light.DelayProbability {
+		t.Errorf("delay %.3f (heavy) should exceed %.3f (light)", heavy.DelayProbability, light.DelayProbability)
+	}
+	if heavy.ExpectedDelayMinutes <= light.ExpectedDelayMinutes {
+		t.Errorf("delay length %d (heavy) should exceed %d (light)", heavy.ExpectedDelayMinutes, light.ExpectedDelayMinutes)
+	}
+
+	// Delays and postponements are separate outcomes of rain at game time
+	if total := heavy.DelayProbability + heavy.PostponementProbability; total > heavy.PrecipProbability {
+		t.Errorf("delay + postponement = %.3f, more than the %.3f chance of rain", total, heavy.PrecipProbability)
+	}
+}
+
+func TestRainRiskDelayIfPlayed(t *testing.T) {
+	risk := RainRisk{DelayProbability: 0.3, PostponementProbability: 0.4}
+	if got := risk.DelayProbabilityIfPlayed(); got != 0.5 {
+		t.Errorf("delay if played = %v, want 0.5", got)
+	}
+	if got := (RainRisk{PostponementProbability: 1}).DelayProbabilityIfPlayed(); got != 0 {
+		t.Errorf("certain postponement delay if played = %v, want 0", got)
+	}
+}
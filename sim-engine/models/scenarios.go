@@ -0,0 +1,77 @@
+package models
+
+import "math"
+
+// Scenario band variants, named from the home team's point of view
+const (
+	ScenarioPessimistic = "pessimistic"
+	ScenarioMedian      = "median"
+	ScenarioOptimistic  = "optimistic"
+)
+
+const (
+	// scenarioQuartileZ is the standard normal score of the 75th percentile
+	scenarioQuartileZ = 0.6745
+
+	// Spread of true-talent estimates around a projection, in wOBA: a
+	// hitter's wOBA and the wOBA a pitcher allows
+	ScenarioBatterWOBASD  = 0.020
+	ScenarioPitcherWOBASD = 0.015
+)
+
+// ScenarioWOBAShift is how far each side's batters move when the home team's
+// players perform at their 25th (homeZ -1), 50th (0) or 75th (+1) percentile
+// and the away team's at awayZ. Better pitching moves the opposing batters
+// down.
+func ScenarioWOBAShift(homeZ, awayZ float64) (home, away float64) {
+	home = scenarioQuartileZ * (homeZ*ScenarioBatterWOBASD - awayZ*ScenarioPitcherWOBASD)
+	away = scenarioQuartileZ * (awayZ*ScenarioBatterWOBASD - homeZ*ScenarioPitcherWOBASD)
+	return home, away
+}
+
+// ScenarioOutcome is one variant's simulated result
+type ScenarioOutcome struct {
+	Scenario           string  `json:"scenario"`
+	HomePercentile     int     `json:"home_percentile"` // player performance percentile
+	AwayPercentile     int     `json:"away_percentile"`
+	Simulations        int     `json:"simulations"`
+	HomeWinProbability float64 `json:"home_win_probability"`
+	ExpectedHomeScore  float64 `json:"expected_home_score"`
+	ExpectedAwayScore  float64 `json:"expected_away_score"`
+	ExpectedTotal      float64 `json:"expected_total"`
+}
+
+// ScenarioRange is a low-median-high envelope across the variants
+type ScenarioRange struct {
+	Low    float64 `json:"low"`
+	Median float64 `json:"median"`
+	High   float64 `json:"high"`
+}
+
+// ScenarioBands is a run's uncertainty envelope: the run itself is the
+// median, and the pessimistic and optimistic variants put the home team's
+// players at their 25th and 75th percentile and the away team's at the
+// opposite one
+type ScenarioBands struct {
+	Scenarios          []ScenarioOutcome `json:"scenarios"` // pessimistic, median, optimistic
+	HomeWinProbability ScenarioRange     `json:"home_win_probability"`
+	HomeScore          ScenarioRange     `json:"home_score"`
+	AwayScore          ScenarioRange     `json:"away_score"`
+	Total              ScenarioRange     `json:"total"`
+}
+
+// NewScenarioBands builds the envelope from the three variants
+func NewScenarioBands(pessimistic, median, optimistic ScenarioOutcome) *ScenarioBands {
+	envelope := func(value func(ScenarioOutcome) float64) ScenarioRange {
+		low := math.Min(value(pessimistic), math.Min(value(median), value(optimistic)))
+		high := math.Max(value(pessimistic), math.Max(value(median), value(optimistic)))
+		return ScenarioRange{Low: low, Median: value(median), High: high}
+	}
+	return &ScenarioBands{
+		Scenarios:          []ScenarioOutcome{pessimistic, median, optimistic},
+		HomeWinProbability: envelope(func(o ScenarioOutcome) float64 { return o.HomeWinProbability }),
+		HomeScore:          envelope(func(o ScenarioOutcome) float64 { return o.ExpectedHomeScore }),
+		AwayScore:          envelope(func(o ScenarioOutcome) float64 { return o.ExpectedAwayScore }),
+		Total:              envelope(func(o ScenarioOutcome) float64 { return o.ExpectedTotal }),
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScenarioWOBAShift(t *testing.T) {
+	home, away := ScenarioWOBAShift(0, 0)
+	if home != 0 || away != 0 {
+		t.Errorf("Expected no shift at the median, got %.4f and %.4f", home, away)
+	}
+
+	// Home players at their 75th percentile, away players at their 25th:
+	// home hitters gain on both counts and away hitters lose on both
+	home, away = ScenarioWOBAShift(1, -1)
+	want := scenarioQuartileZ * (ScenarioBatterWOBASD + ScenarioPitcherWOBASD)
+	if math.Abs(home-want) > 1e-9 || math.Abs(away+want) > 1e-9 {
+		t.Errorf("Expected shifts of %+.4f and %+.4f, got %+.4f and %+.4f", want, -want, home, away)
+	}
+}
+
+func TestNewScenarioBands(t *testing.T) {
+	bands := NewScenarioBands(
+		ScenarioOutcome{Scenario: ScenarioPessimistic, HomeWinProbability: 0.46, ExpectedHomeScore: 4.1, ExpectedAwayScore: 4.8, ExpectedTotal: 8.9},
+		ScenarioOutcome{Scenario: ScenarioMedian, HomeWinProbability: 0.54, ExpectedHomeScore: 4.6, ExpectedAwayScore: 4.3, ExpectedTotal: 8.9},
+		ScenarioOutcome{Scenario: ScenarioOptimistic, HomeWinProbability: 0.62, ExpectedHomeScore: 5.1, ExpectedAwayScore: 3.9, ExpectedTotal: 9.0},
+	)
+	if got := bands.HomeWinProbability; got != (ScenarioRange{Low: 0.46, Median: 0.54, High: 0.62}) {
+		t.Errorf("Unexpected win probability band %+v", got)
+	}
+	if got := bands.AwayScore; got != (ScenarioRange{Low: 3.9, Median: 4.3, High: 4.8}) {
+		t.Errorf("Unexpected away score band %+v", got)
+	}
+	if got := bands.Total; got != (ScenarioRange{Low: 8.9, Median: 8.9, High: 9.0}) {
+		t.Errorf("Unexpected total band %+v", got)
+	}
+	if len(bands.Scenarios) != 3 || bands.Scenarios[1].Scenario != ScenarioMedian {
+		t.Errorf("Expected the three variants in order, got %+v", bands.Scenarios)
+	}
+}
@@ -1,5 +1,7 @@
 package models
 
+import "math"
+
 // StadiumDimensions represents the physical dimensions of a ballpark
 type StadiumDimensions struct {
 	LeftField       int `json:"left_field"`        // Distance in feet
@@ -150,3 +152,79 @@ func DefaultDimensions() StadiumDimensions {
 		RightFieldWall:  8,
 	}
 }
+
+// WithDefaults fills any missing distance or wall height from DefaultDimensions
+func (sd StadiumDimensions) WithDefaults() StadiumDimensions {
+	def := DefaultDimensions()
+	fill := func(v *int, fallback int) {
+		if *v <= 0 {
+			*v = fallback
+		}
+	}
+	fill(&sd.LeftField, def.LeftField)
+	fill(&sd.LeftCenter, def.LeftCenter)
+	fill(&sd.Center, def.Center)
+	fill(&sd.RightCenter, def.RightCenter)
+	fill(&sd.RightField, def.RightField)
+	fill(&sd.LeftFieldWall, def.LeftFieldWall)
+	fill(&sd.CenterFieldWall, def.CenterFieldWall)
+	fill(&sd.RightFieldWall, def.RightFieldWall)
+	return sd
+}
+
+// pullSide returns the line distance, gap distance and wall height of the
+// field a batter pulls toward. Switch hitters bat opposite the pitcher.
+func (sd *StadiumDimensions) pullSide(batterHand, pitcherHand string) (line, gap, wall int) {
+	if batterHand == "S" {
+		batterHand = "R"
+		if pitcherHand == "R" {
+			batterHand = "L"
+		}
+	}
+	if batterHand == "L" {
+		return sd.RightField, sd.RightCenter, sd.RightFieldWall
+	}
+	return sd.LeftField, sd.LeftCenter, sd.LeftFieldWall
+}
+
+// effectiveDistance weights the pull line, pull gap and center field the
+// way fly balls are distributed (most home runs are pulled)
+func effectiveDistance(line, gap, center int) float64 {
+	return 0.5*float64(line) + 0.3*float64(gap) + 0.2*float64(center)
+}
+
+// effectiveWall weights the pull-side wall over the center field wall
+func effectiveWall(pullWall, centerWall int) float64 {
+	return 0.8*float64(pullWall) + 0.2*float64(centerWall)
+}
+
+// GetHRMultiplier returns how the fences change home run rates for a batter.
+// Each foot of effective distance is worth about 1% of home runs, and every
+// foot of wall above 8 feet knocks down about 0.8% of them (the Green
+// Monster turns wall-scrapers into doubles).
+func (sd *StadiumDimensions) GetHRMultiplier(batterHand, pitcherHand string) float64 {
+	dims := sd.WithDefaults()
+	def := DefaultDimensions()
+
+	line, gap, wall := dims.pullSide(batterHand, pitcherHand)
+	distance := effectiveDistance(line, gap, dims.Center)
+	baseline := effectiveDistance(def.LeftField, def.LeftCenter, def.Center)
+
+	multiplier := math.Exp(-(distance - baseline) * 0.01)
+	multiplier *= 1.0 - (effectiveWall(wall, dims.CenterFieldWall)-8.0)*0.008
+
+	return math.Max(0.6, math.Min(1.5, multiplier))
+}
+
+// GetDoubleMultiplier returns how the fences change double rates. Tall walls
+// keep balls in play off the wall and deep gaps give more room to run.
+func (sd *StadiumDimensions) GetDoubleMultiplier(batterHand, pitcherHand string) float64 {
+	dims := sd.WithDefaults()
+	def := DefaultDimensions()
+
+	_, gap, wall := dims.pullSide(batterHand, pitcherHand)
+	multiplier := 1.0 + (effectiveWall(wall, dims.CenterFieldWall)-8.0)*0.01
+	multiplier += float64(gap-def.LeftCenter) * 0.003
+
+	return math.Max(0.8, math.Min(1.4, multiplier))
+}
@@ -268,3 +268,67 @@ func TestDimensionSymmetry(t *testing.T) {
 		t.Error("Left and right field walls should be symmetric by default")
 	}
 }
+
+// TestDimensionMultipliers tests fence distance and wall height effects
+func TestDimensionMultipliers(t *testing.T) {
+	neutral := DefaultDimensions()
+	if m := neutral.GetHRMultiplier("R", "R"); m < 0.999 || m > 1.001 {
+		t.Errorf("Default dimensions HR multiplier = %f, want 1.0", m)
+	}
+
+	// Fenway: short left field behind a 37 foot wall
+	fenway := StadiumDimensions{LeftField: 310, LeftCenter: 379, Center: 420,
+		RightCenter: 380, RightField: 302, LeftFieldWall: 37, CenterFieldWall: 17, RightFieldWall: 5}
+	if fenway.GetHRMultiplier("R", "R") >= 1.0 {
+		t.Error("Green Monster should suppress right-handed home runs")
+	}
+	if fenway.GetDoubleMultiplier("R", "R") <= fenway.GetDoubleMultiplier("L", "R") {
+		t.Error("Green Monster should boost right-handed doubles more than left-handed")
+	}
+
+	// Yankee Stadium: short right field porch
+	yankee := StadiumDimensions{LeftField: 318, LeftCenter: 399, Center: 408,
+		RightCenter: 385, RightField: 314, LeftFieldWall: 8, CenterFieldWall: 8, RightFieldWall: 8}
+	if yankee.GetHRMultiplier("L", "R") <= 1.0 {
+		t.Error("Short porch should boost left-handed home runs")
+	}
+
+	// Switch hitters bat left against right-handed pitchers
+	if yankee.GetHRMultiplier("S", "R") != yankee.GetHRMultiplier("L", "R") {
+		t.Error("Switch hitter should use the left-handed pull side against RHP")
+	}
+}
+
+// TestDimensionsWithDefaults tests partial dimension records
+func TestDimensionsWithDefaults(t *testing.T) {
+	dims := StadiumDimensions{LeftField: 310, LeftFieldWall: 37}.WithDefaults()
+	if dims.LeftField != 310 || dims.LeftFieldWall != 37 {
+		t.Error("WithDefaults should keep provided values")
+	}
+	if dims.Center != 400 || dims.RightFieldWall != 8 {
+		t.Error("WithDefaults should fill missing values")
+	}
+}
+
+// TestParkHitMultipliers tests park factors and fence dimensions aren't
+// both applied
+func TestParkHitMultipliers(t *testing.T) {
+	batter, pitcher := &Player{Hand: "L"}, &Player{Hand: "R"}
+	porch := &StadiumDimensions{LeftField: 318, LeftCenter: 399, Center: 408,
+		RightCenter: 385, RightField: 314, LeftFieldWall: 8, CenterFieldWall: 8, RightFieldWall: 8}
+	factors := &ParkFactors{HRFactor: 110, LHBHRFactor: 120, DoublesFactor: 95}
+
+	hr, double := parkHitMultipliers(factors, porch, batter, pitcher)
+	if hr != 1.2 || double != 0.95 {
+		t.Errorf("with park factors got HR %.3f, doubles %.3f; want 1.200 and 0.950", hr, double)
+	}
+
+	hr, double = parkHitMultipliers(nil, porch, batter, pitcher)
+	if hr != porch.GetHRMultiplier("L", "R") || double != porch.GetDoubleMultiplier("L", "R") {
+		t.Errorf("without park factors the dimensions should apply, got HR %.3f, doubles %.3f", hr, double)
+	}
+
+	if hr, double = parkHitMultipliers(nil, nil, batter, pitcher); hr != 1 || double != 1 {
+		t.Errorf("with neither, got HR %.3f, doubles %.3f; want neutral", hr, double)
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+// Starter roles a run can assign each side's starting pitcher
+const (
+	StarterRoleNormal    = "normal"
+	StarterRoleShortRest = "short_rest"
+	StarterRoleOpener    = "opener"
+)
+
+const (
+	// A starter on short rest is relieved at the end of the inning after
+	// ShortRestPitchLimit pitches and pitches with less stuff and command:
+	// fewer strikeouts, more walks and more home runs on contact
+	ShortRestPitchLimit   = 75
+	ShortRestKMultiplier  = 0.92
+	ShortRestBBMultiplier = 1.08
+	ShortRestHRMultiplier = 1.06
+
+	// The first reliever after a short-rest starter is a long man who can
+	// cover two innings
+	LongRelieverMaxOuts    = 6
+	LongRelieverPitchLimit = 45
+
+	// An opener works at most two innings (or StarterPitchLimit pitches).
+	// The rotation's next starter follows as the bulk pitcher and works
+	// until BulkPitchLimit pitches.
+	OpenerMaxOuts  = 6
+	BulkPitchLimit = 85
+)
+
+// ValidStarterRole reports whether role is a known starter role
+func ValidStarterRole(role string) bool {
+	switch role {
+	case StarterRoleNormal, StarterRoleShortRest, StarterRoleOpener:
+		return true
+	}
+	return false
+}
+
+// ShortRestPenaltyFor reports whether pitcher is a starter working on short
+// rest in this game
+func (gs *GameState) ShortRestPenaltyFor(pitcher *Player) bool {
+	return pitcher != nil && gs.ShortRestPitchers[pitcher.ID]
+}
@@ -0,0 +1,113 @@
+package models
+
+import "testing"
+
+func TestPitchingStaffShortRest(t *testing.T) {
+	roster := bullpenRoster()
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+	staff.SetStarterRole(StarterRoleShortRest)
+
+	staff.Record(3, ShortRestPitchLimit-1)
+	if staff.NeedsReliever() {
+		t.Fatal("short-rest starter under the limit should stay in")
+	}
+	staff.Record(0, 1)
+	if !staff.NeedsReliever() {
+		t.Fatal("short-rest starter at the limit should come out")
+	}
+
+	never := func() float64 { return 0 }
+	if !staff.ChangePitcher(never) || staff.Current.ID != "rp1" {
+		t.Fatalf("expected rp1 to relieve, got %s", staff.Current.ID)
+	}
+	staff.Record(RelieverMaxOuts, 15)
+	if staff.NeedsReliever() {
+		t.Error("the long man should cover a second inning")
+	}
+	staff.Record(LongRelieverMaxOuts-RelieverMaxOuts, 15)
+	if !staff.NeedsReliever() || !staff.ChangePitcher(never) || staff.Current.ID != "rp2" {
+		t.Fatalf("expected rp2 after the long man's two innings, got %s", staff.Current.ID)
+	}
+	staff.Record(RelieverMaxOuts, 10)
+	if !staff.NeedsReliever() {
+		t.Error("later relievers work a normal inning")
+	}
+}
+
+func TestPitchingStaffOpener(t *testing.T) {
+	roster := bullpenRoster()
+	roster.Players = append(roster.Players, Player{ID: "sp2", Position: "P"})
+	roster.Rotation = []string{"sp", "sp2"}
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+	staff.SetStarterRole(StarterRoleOpener)
+
+	staff.Record(3, 15)
+	if staff.NeedsReliever() {
+		t.Fatal("the opener should work a second inning")
+	}
+	staff.Record(3, 15)
+	if !staff.NeedsReliever() {
+		t.Fatal("the opener should come out after two innings")
+	}
+
+	// Relievers are never rolled for the planned bulk pitcher
+	rolled := false
+	if !staff.ChangePitcher(func() float64 { rolled = true; return 0 }) || staff.Current.ID != "sp2" || rolled {
+		t.Fatalf("expected the next starter sp2 to follow the opener, got %s", staff.Current.ID)
+	}
+	staff.Record(9, BulkPitchLimit-1)
+	if staff.NeedsReliever() {
+		t.Fatal("the bulk pitcher should stay in under the limit")
+	}
+	staff.Record(0, 1)
+	if !staff.NeedsReliever() || !staff.ChangePitcher(func() float64 { return 0 }) || staff.Current.ID != "rp1" {
+		t.Fatalf("expected rp1 after the bulk pitcher, got %s", staff.Current.ID)
+	}
+}
+
+func TestPitchingStaffOpenerWithoutBulkPitcher(t *testing.T) {
+	roster := bullpenRoster()
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+	staff.SetStarterRole(StarterRoleOpener)
+	staff.Record(OpenerMaxOuts, 30)
+
+	if !staff.ChangePitcher(func() float64 { return 0 }) || staff.Current.ID != "rp1" {
+		t.Fatalf("with no other starter the bullpen should follow the opener, got %s", staff.Current.ID)
+	}
+
+	// A reliever standing in for the bulk pitcher keeps a reliever's limits
+	staff.Record(RelieverMaxOuts-1, RelieverPitchLimit-1)
+	if staff.NeedsReliever() {
+		t.Fatal("the reliever should stay in under the reliever limits")
+	}
+	staff.Record(1, 1)
+	if !staff.NeedsReliever() {
+		t.Error("the reliever should come out after an inning, not work as the bulk pitcher")
+	}
+}
+
+func TestPitchingStaffShortRestPlatoonChange(t *testing.T) {
+	roster := bullpenRoster()
+	staff := NewPitchingStaff(roster, &roster.Players[0])
+	staff.SetStarterRole(StarterRoleShortRest)
+
+	// A matchup reliever who replaces the starter mid-inning isn't the long man
+	staff.bringIn("rp1")
+	staff.Record(RelieverMaxOuts, 10)
+	if !staff.NeedsReliever() {
+		t.Error("a reliever brought in for the matchup should work a normal inning")
+	}
+}
+
+func TestShortRestPenaltyFor(t *testing.T) {
+	state := &GameState{ShortRestPitchers: map[string]bool{"sp": true}}
+	if !state.ShortRestPenaltyFor(&Player{ID: "sp"}) {
+		t.Error("the short-rest starter should be penalized")
+	}
+	if state.ShortRestPenaltyFor(&Player{ID: "rp1"}) || state.ShortRestPenaltyFor(nil) {
+		t.Error("other pitchers should not be penalized")
+	}
+	if (&GameState{}).ShortRestPenaltyFor(&Player{ID: "sp"}) {
+		t.Error("no starter is on short rest by default")
+	}
+}
@@ -0,0 +1,84 @@
+package models
+
+// StatDefinition describes a stat key emitted in simulation output
+type StatDefinition struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	Formula     string `json:"formula,omitempty"`
+	Direction   string `json:"direction"` // higher, lower or neutral
+	Format      string `json:"format"`    // percent, decimal, integer, rate
+	Precision   int    `json:"precision"`
+}
+
+// Stat keys written into AggregatedResult.Statistics
+const (
+	StatTotalRunsAverage     = "total_runs_average"
+	StatScoreVariance        = "score_variance"
+	StatBlowoutPercentage    = "blowout_percentage"
+	StatOneRunGamePercentage = "one_run_game_percentage"
+	StatShutoutPercentage    = "shutout_percentage"
+	StatHighScoringPercent   = "high_scoring_percentage"
+
+	StatHomeCatcherFramingRuns  = "home_catcher_framing_runs"
+	StatAwayCatcherFramingRuns  = "away_catcher_framing_runs"
+	StatHomeCatcherBlockingRuns = "home_catcher_blocking_runs"
+	StatAwayCatcherBlockingRuns = "away_catcher_blocking_runs"
+	StatBatteryErrorsPerGame    = "wild_pitches_passed_balls_per_game"
+
+	StatHomeErrorsPer9          = "home_errors_per_9"
+	StatAwayErrorsPer9          = "away_errors_per_9"
+	StatHomeDefensiveEfficiency = "home_defensive_efficiency"
+	StatAwayDefensiveEfficiency = "away_defensive_efficiency"
+
+	StatHomeStolenBasesPerGame    = "home_stolen_bases_per_game"
+	StatAwayStolenBasesPerGame    = "away_stolen_bases_per_game"
+	StatHomeCaughtStealingPerGame = "home_caught_stealing_per_game"
+	StatAwayCaughtStealingPerGame = "away_caught_stealing_per_game"
+	StatHomeStealSuccessRate      = "home_steal_success_rate"
+	StatAwayStealSuccessRate      = "away_steal_success_rate"
+
+	StatPitchingChangesPerGame  = "pitching_changes_per_game"
+	StatMidInningChangesPerGame = "mid_inning_changes_per_game"
+)
+
+// SimulationStatRegistry lists every stat key the simulation engine emits.
+// Aggregation code uses the constants above so the glossary cannot drift.
+var SimulationStatRegistry = []StatDefinition{
+	{Key: "home_win_probability", Name: "Home Win Probability", Category: "simulation", Description: "Share of simulations won by the home team", Formula: "home_wins / total_simulations", Direction: "higher", Format: "percent", Precision: 1},
+	{Key: "away_win_probability", Name: "Away Win Probability", Category: "simulation", Description: "Share of simulations won by the away team", Formula: "away_wins / total_simulations", Direction: "higher", Format: "percent", Precision: 1},
+	{Key: "expected_home_score", Name: "Expected Home Runs", Category: "simulation", Description: "Mean runs scored by the home team across simulations", Direction: "neutral", Format: "decimal", Precision: 2},
+	{Key: "expected_away_score", Name: "Expected Away Runs", Category: "simulation", Description: "Mean runs scored by the away team across simulations", Direction: "neutral", Format: "decimal", Precision: 2},
+	{Key: "average_game_duration", Name: "Average Duration", Category: "simulation", Description: "Mean simulated game length in minutes, from pitches, runs, pitching changes and extra innings calibrated against actual game durations", Direction: "neutral", Format: "decimal", Precision: 0},
+	{Key: "average_pitches", Name: "Average Pitches", Category: "simulation", Description: "Mean total pitches thrown per simulated game", Direction: "neutral", Format: "decimal", Precision: 0},
+	{Key: StatTotalRunsAverage, Name: "Total Runs", Category: "simulation", Description: "Mean combined runs per simulated game", Formula: "expected_home_score + expected_away_score", Direction: "neutral", Format: "decimal", Precision: 2},
+	{Key: StatScoreVariance, Name: "Score Variance", Category: "simulation", Description: "Variance of combined runs around the mean", Direction: "neutral", Format: "decimal", Precision: 2},
+	{Key: StatBlowoutPercentage, Name: "Blowout %", Category: "simulation", Description: "Share of simulations decided by 7 or more runs", Direction: "neutral", Format: "percent", Precision: 1},
+	{Key: StatOneRunGamePercentage, Name: "One-Run Game %", Category: "simulation", Description: "Share of simulations decided by exactly one run", Direction: "neutral", Format: "percent", Precision: 1},
+	{Key: StatShutoutPercentage, Name: "Shutout %", Category: "simulation", Description: "Share of simulations where either team scored zero", Direction: "neutral", Format: "percent", Precision: 1},
+	{Key: StatHighScoringPercent, Name: "High Scoring %", Category: "simulation", Description: "Share of simulations with 12 or more combined runs", Direction: "neutral", Format: "percent", Precision: 1},
+	{Key: StatHomeCatcherFramingRuns, Name: "Home Framing Runs", Category: "simulation_defense", Description: "Mean runs saved per game by the home catcher's pitch framing", Direction: "higher", Format: "decimal", Precision: 2},
+	{Key: StatAwayCatcherFramingRuns, Name: "Away Framing Runs", Category: "simulation_defense", Description: "Mean runs saved per game by the away catcher's pitch framing", Direction: "higher", Format: "decimal", Precision: 2},
+	{Key: StatHomeCatcherBlockingRuns, Name: "Home Blocking Runs", Category: "simulation_defense", Description: "Mean runs saved per game by the home catcher preventing wild pitches and passed balls", Direction: "higher", Format: "decimal", Precision: 2},
+	{Key: StatAwayCatcherBlockingRuns, Name: "Away Blocking Runs", Category: "simulation_defense", Description: "Mean runs saved per game by the away catcher preventing wild pitches and passed balls", Direction: "higher", Format: "decimal", Precision: 2},
+	{Key: StatBatteryErrorsPerGame, Name: "WP + PB per Game", Category: "simulation_defense", Description: "Mean combined wild pitches and passed balls per simulated game", Formula: "(WP + PB) / total_simulations", Direction: "neutral", Format: "decimal", Precision: 2},
+	{Key: StatHomeErrorsPer9, Name: "Home Errors per 9", Category: "simulation_defense", Description: "Errors made by the home defense per nine simulated innings in the field", Formula: "27 * E / outs", Direction: "lower", Format: "decimal", Precision: 2},
+	{Key: StatAwayErrorsPer9, Name: "Away Errors per 9", Category: "simulation_defense", Description: "Errors made by the away defense per nine simulated innings in the field", Formula: "27 * E / outs", Direction: "lower", Format: "decimal", Precision: 2},
+	{Key: StatHomeDefensiveEfficiency, Name: "Home Defensive Efficiency", Category: "simulation_defense", Description: "Share of balls in play the home defense turned into outs", Formula: "1 - (H - HR + E) / balls in play", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: StatAwayDefensiveEfficiency, Name: "Away Defensive Efficiency", Category: "simulation_defense", Description: "Share of balls in play the away defense turned into outs", Formula: "1 - (H - HR + E) / balls in play", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: StatHomeStolenBasesPerGame, Name: "Home SB per Game", Category: "simulation_baserunning", Description: "Mean bases stolen by the home team per simulated game", Formula: "SB / total_simulations", Direction: "higher", Format: "decimal", Precision: 2},
+	{Key: StatAwayStolenBasesPerGame, Name: "Away SB per Game", Category: "simulation_baserunning", Description: "Mean bases stolen by the away team per simulated game", Formula: "SB / total_simulations", Direction: "higher", Format: "decimal", Precision: 2},
+	{Key: StatHomeCaughtStealingPerGame, Name: "Home CS per Game", Category: "simulation_baserunning", Description: "Mean home runners caught stealing per simulated game", Formula: "CS / total_simulations", Direction: "lower", Format: "decimal", Precision: 2},
+	{Key: StatAwayCaughtStealingPerGame, Name: "Away CS per Game", Category: "simulation_baserunning", Description: "Mean away runners caught stealing per simulated game", Formula: "CS / total_simulations", Direction: "lower", Format: "decimal", Precision: 2},
+	{Key: StatHomeStealSuccessRate, Name: "Home Steal Success", Category: "simulation_baserunning", Description: "Share of the home team's steal attempts that succeeded, to compare with the team's real SB / (SB + CS)", Formula: "SB / (SB + CS)", Direction: "higher", Format: "percent", Precision: 1},
+	{Key: StatAwayStealSuccessRate, Name: "Away Steal Success", Category: "simulation_baserunning", Description: "Share of the away team's steal attempts that succeeded, to compare with the team's real SB / (SB + CS)", Formula: "SB / (SB + CS)", Direction: "higher", Format: "percent", Precision: 1},
+	{Key: StatPitchingChangesPerGame, Name: "Pitching Changes", Category: "simulation", Description: "Mean combined pitching changes per simulated game", Direction: "neutral", Format: "decimal", Precision: 1},
+	{Key: StatMidInningChangesPerGame, Name: "Mid-Inning Changes", Category: "simulation", Description: "Mean combined mid-inning pitching changes made for platoon matchups per simulated game (runs with platoon_changes on)", Direction: "neutral", Format: "decimal", Precision: 2},
+	{Key: "over_8_5", Name: "Over 8.5", Category: "simulation", Description: "Probability combined runs exceed 8.5", Direction: "neutral", Format: "percent", Precision: 1},
+	{Key: "over_9_5", Name: "Over 9.5", Category: "simulation", Description: "Probability combined runs exceed 9.5", Direction: "neutral", Format: "percent", Precision: 1},
+	{Key: "over_10_5", Name: "Over 10.5", Category: "simulation", Description: "Probability combined runs exceed 10.5", Direction: "neutral", Format: "percent", Precision: 1},
+	{Key: "avg", Name: "Simulated AVG", Category: "simulation_batting", Description: "Hits per at-bat across simulated games", Formula: "H / AB", Direction: "higher", Format: "rate", Precision: 3},
+	{Key: "era", Name: "Simulated ERA", Category: "simulation_pitching", Description: "Earned runs allowed per nine simulated innings", Formula: "9 * ER / IP", Direction: "lower", Format: "decimal", Precision: 2},
+	{Key: "whip", Name: "Simulated WHIP", Category: "simulation_pitching", Description: "Walks plus hits per simulated inning", Formula: "(BB + H) / IP", Direction: "lower", Format: "decimal", Precision: 2},
+}
@@ -0,0 +1,54 @@
+package models
+
+import "math"
+
+// DiverseMixPitches is how many evenly used pitch types count as a fully
+// diverse mix; more pitch types than this add nothing
+const DiverseMixPitches = 4
+
+// Diversity scores how hard a pitch mix is to sit on, from 0 (one pitch) to
+// 1 (DiverseMixPitches or more pitches used evenly). It is the mix's Shannon
+// entropy relative to an even DiverseMixPitches-pitch mix. Usage may be in
+// percent or fractions; a mix with no usage recorded scores 0.
+func (pm PitchMix) Diversity() float64 {
+	usage := []float64{pm.Fastball, pm.Slider, pm.Changeup, pm.Curveball,
+		pm.Cutter, pm.Sinker, pm.Knuckleball, pm.Other}
+	total := 0.0
+	for _, u := range usage {
+		if u > 0 {
+			total += u
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	entropy := 0.0
+	for _, u := range usage {
+		if u > 0 {
+			share := u / total
+			entropy -= share * math.Log(share)
+		}
+	}
+	return math.Min(1, entropy/math.Log(DiverseMixPitches))
+}
+
+// TimesFacedAdjustment is the expected wOBA shift for a batter who has
+// already faced the pitcher timesFaced times in the game. Each earlier look
+// adds TimesFacedWOBA; a diverse pitch mix gives the batter less to learn,
+// removing up to TimesFacedMixMitigation of it. This is independent of the
+// pitch count, so it applies to a fresh starter's third trip through the
+// order as much as a tired one's.
+func (tp *TuningParameters) TimesFacedAdjustment(timesFaced int, mix PitchMix) float64 {
+	if timesFaced <= 0 || tp.TimesFacedWOBA == 0 {
+		return 0
+	}
+	mitigation := math.Max(0, math.Min(1, tp.TimesFacedMixMitigation*mix.Diversity()))
+	return tp.TimesFacedWOBA * float64(timesFaced) * (1 - mitigation)
+}
+
+// MatchupKey identifies a batter-pitcher pair for counting times faced
+type MatchupKey struct {
+	BatterID  string
+	PitcherID string
+}
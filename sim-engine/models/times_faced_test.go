@@ -0,0 +1,56 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPitchMixDiversity(t *testing.T) {
+	cases := []struct {
+		name string
+		mix  PitchMix
+		want float64
+	}{
+		{"no data", PitchMix{}, 0},
+		{"one pitch", PitchMix{Knuckleball: 100}, 0},
+		{"even four-pitch mix", PitchMix{Fastball: 25, Slider: 25, Changeup: 25, Curveball: 25}, 1},
+		{"even six-pitch mix", PitchMix{Fastball: 1, Slider: 1, Changeup: 1, Curveball: 1, Cutter: 1, Sinker: 1}, 1},
+		{"even two-pitch mix", PitchMix{Fastball: 0.5, Slider: 0.5}, 0.5},
+	}
+	for _, c := range cases {
+		if got := c.mix.Diversity(); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("%s: diversity = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	// A fastball-heavy mix is less diverse than an even one with the same pitches
+	heavy := PitchMix{Fastball: 80, Slider: 10, Changeup: 10}
+	even := PitchMix{Fastball: 34, Slider: 33, Changeup: 33}
+	if heavy.Diversity() >= even.Diversity() {
+		t.Errorf("fastball-heavy diversity %v should be below even %v", heavy.Diversity(), even.Diversity())
+	}
+}
+
+func TestTimesFacedAdjustment(t *testing.T) {
+	tuning := DefaultTuningParameters()
+	tuning.TimesFacedWOBA = 0.02
+	tuning.TimesFacedMixMitigation = 0.5
+
+	oneTrick := PitchMix{Fastball: 100}
+	fourPitch := PitchMix{Fastball: 25, Slider: 25, Changeup: 25, Curveball: 25}
+
+	if adj := tuning.TimesFacedAdjustment(0, oneTrick); adj != 0 {
+		t.Errorf("first look: adjustment = %v, want 0", adj)
+	}
+	if adj := tuning.TimesFacedAdjustment(2, oneTrick); math.Abs(adj-0.04) > 1e-12 {
+		t.Errorf("third look at a one-pitch pitcher: adjustment = %v, want 0.04", adj)
+	}
+	if adj := tuning.TimesFacedAdjustment(2, fourPitch); math.Abs(adj-0.02) > 1e-12 {
+		t.Errorf("third look at a four-pitch pitcher: adjustment = %v, want 0.02", adj)
+	}
+
+	tuning.TimesFacedWOBA = 0
+	if adj := tuning.TimesFacedAdjustment(3, oneTrick); adj != 0 {
+		t.Errorf("disabled: adjustment = %v, want 0", adj)
+	}
+}
@@ -0,0 +1,225 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+)
+
+// TuningParameters are the at-bat and leverage calibration knobs that can be
+// changed without a rebuild. Defaults reproduce the engine's built-in model.
+type TuningParameters struct {
+	// HomeFieldWOBA is added to home batters' expected wOBA
+	HomeFieldWOBA float64 `json:"home_field_woba"`
+
+	// FormWOBA scales a small expected wOBA prior from each team's record over
+	// its last FormGames games; a 10-0 team gets +FormWOBA/2. 0 disables it.
+	FormWOBA float64 `json:"form_woba"`
+
+	// Expected wOBA shifts by count; counts not listed are even
+	Count30 float64 `json:"count_adjustment_3_0"`
+	Count31 float64 `json:"count_adjustment_3_1"`
+	Count20 float64 `json:"count_adjustment_2_0"`
+	Count02 float64 `json:"count_adjustment_0_2"`
+	Count12 float64 `json:"count_adjustment_1_2"`
+	Count22 float64 `json:"count_adjustment_2_2"`
+
+	// Batters learn a pitcher within a game: expected wOBA rises by
+	// TimesFacedWOBA per earlier plate appearance against the pitcher, less
+	// up to TimesFacedMixMitigation of it for a diverse pitch mix
+	TimesFacedWOBA          float64 `json:"times_faced_woba"`
+	TimesFacedMixMitigation float64 `json:"times_faced_mix_mitigation"`
+
+	// Batter-side weather adjustments to expected wOBA
+	WindPerMPH         float64 `json:"weather_wind_per_mph"`
+	ColdTemperature    float64 `json:"weather_cold_temperature"` // °F below which ColdAdjustment applies
+	ColdAdjustment     float64 `json:"weather_cold_adjustment"`
+	HotTemperature     float64 `json:"weather_hot_temperature"` // °F above which HotAdjustment applies
+	HotAdjustment      float64 `json:"weather_hot_adjustment"`
+	HumidityThreshold  float64 `json:"weather_humidity_threshold"` // % above which HumidityAdjustment applies
+	HumidityAdjustment float64 `json:"weather_humidity_adjustment"`
+
+	// Leverage index weights
+	LeverageLateInning    float64 `json:"leverage_late_inning"`     // first inning that adds leverage
+	LeveragePerLateInning float64 `json:"leverage_per_late_inning"` // per inning from LeverageLateInning on
+	LeverageCloseMargin   float64 `json:"leverage_close_margin"`    // runs within which the score adds leverage
+	LeveragePerCloseRun   float64 `json:"leverage_per_close_run"`   // per run closer than LeverageCloseMargin+1
+	LeveragePerRunner     float64 `json:"leverage_per_runner"`
+	LeverageTwoOuts       float64 `json:"leverage_two_outs"`
+	LeverageNinthInning   float64 `json:"leverage_ninth_inning"`
+	HighLeverageThreshold float64 `json:"high_leverage_threshold"` // leverage above which clutch splits apply
+}
+
+// DefaultTuningParameters returns the built-in calibration
+func DefaultTuningParameters() *TuningParameters {
+	return &TuningParameters{
+		HomeFieldWOBA: 0,
+		FormWOBA:      0,
+
+		Count30: 0.080,
+		Count31: 0.060,
+		Count20: 0.040,
+		Count02: -0.060,
+		Count12: -0.040,
+		Count22: -0.020,
+
+		// Roughly the league's times-through-the-order penalty: about +.010
+		// wOBA per trip for a typical three-pitch starter
+		TimesFacedWOBA:          0.015,
+		TimesFacedMixMitigation: 0.5,
+
+		WindPerMPH:         0.001,
+		ColdTemperature:    50,
+		ColdAdjustment:     -0.010,
+		HotTemperature:     80,
+		HotAdjustment:      0.005,
+		HumidityThreshold:  80,
+		HumidityAdjustment: -0.005,
+
+		LeverageLateInning:    7,
+		LeveragePerLateInning: 0.3,
+		LeverageCloseMargin:   3,
+		LeveragePerCloseRun:   0.2,
+		LeveragePerRunner:     0.1,
+		LeverageTwoOuts:       0.3,
+		LeverageNinthInning:   0.5,
+		HighLeverageThreshold: 1.5,
+	}
+}
+
+// tuningFields maps each parameter name, as stored in engine_parameters, to
+// its field
+func (tp *TuningParameters) tuningFields() map[string]*float64 {
+	return map[string]*float64{
+		"home_field_woba":             &tp.HomeFieldWOBA,
+		"form_woba":                   &tp.FormWOBA,
+		"count_adjustment_3_0":        &tp.Count30,
+		"count_adjustment_3_1":        &tp.Count31,
+		"count_adjustment_2_0":        &tp.Count20,
+		"count_adjustment_0_2":        &tp.Count02,
+		"count_adjustment_1_2":        &tp.Count12,
+		"count_adjustment_2_2":        &tp.Count22,
+		"times_faced_woba":            &tp.TimesFacedWOBA,
+		"times_faced_mix_mitigation":  &tp.TimesFacedMixMitigation,
+		"weather_wind_per_mph":        &tp.WindPerMPH,
+		"weather_cold_temperature":    &tp.ColdTemperature,
+		"weather_cold_adjustment":     &tp.ColdAdjustment,
+		"weather_hot_temperature":     &tp.HotTemperature,
+		"weather_hot_adjustment":      &tp.HotAdjustment,
+		"weather_humidity_threshold":  &tp.HumidityThreshold,
+		"weather_humidity_adjustment": &tp.HumidityAdjustment,
+		"leverage_late_inning":        &tp.LeverageLateInning,
+		"leverage_per_late_inning":    &tp.LeveragePerLateInning,
+		"leverage_close_margin":       &tp.LeverageCloseMargin,
+		"leverage_per_close_run":      &tp.LeveragePerCloseRun,
+		"leverage_per_runner":         &tp.LeveragePerRunner,
+		"leverage_two_outs":           &tp.LeverageTwoOuts,
+		"leverage_ninth_inning":       &tp.LeverageNinthInning,
+		"high_leverage_threshold":     &tp.HighLeverageThreshold,
+	}
+}
+
+// Values returns every parameter by name
+func (tp *TuningParameters) Values() map[string]float64 {
+	values := make(map[string]float64)
+	for name, field := range tp.tuningFields() {
+		values[name] = *field
+	}
+	return values
+}
+
+// TuningParametersFrom overlays named values on the defaults. Unknown names
+// are an error so a typo in the table can't silently do nothing.
+func TuningParametersFrom(values map[string]float64) (*TuningParameters, error) {
+	params := DefaultTuningParameters()
+	fields := params.tuningFields()
+
+	var unknown []string
+	for name, value := range values {
+		field, ok := fields[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		*field = value
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown tuning parameters: %v", unknown)
+	}
+	return params, nil
+}
+
+// currentTuning is the calibration new games start with
+var currentTuning atomic.Pointer[TuningParameters]
+
+// CurrentTuningParameters returns the active calibration
+func CurrentTuningParameters() *TuningParameters {
+	if params := currentTuning.Load(); params != nil {
+		return params
+	}
+	return DefaultTuningParameters()
+}
+
+// SetTuningParameters replaces the active calibration. Games already in
+// progress keep the parameters they started with.
+func SetTuningParameters(params *TuningParameters) {
+	currentTuning.Store(params)
+}
+
+// Tuning returns the game's calibration, falling back to the active one
+func (gs *GameState) Tuning() *TuningParameters {
+	if gs == nil || gs.TuningParams == nil {
+		return CurrentTuningParameters()
+	}
+	return gs.TuningParams
+}
+
+// CountAdjustment is the expected wOBA shift for a count
+func (tp *TuningParameters) CountAdjustment(count Count) float64 {
+	// Hitter's counts favor the batter, pitcher's counts favor the pitcher
+	switch {
+	case count.Balls == 3 && count.Strikes == 0:
+		return tp.Count30
+	case count.Balls == 3 && count.Strikes == 1:
+		return tp.Count31
+	case count.Balls == 2 && count.Strikes == 0:
+		return tp.Count20
+	case count.Balls == 0 && count.Strikes == 2:
+		return tp.Count02
+	case count.Balls == 1 && count.Strikes == 2:
+		return tp.Count12
+	case count.Balls == 2 && count.Strikes == 2:
+		return tp.Count22
+	default:
+		return 0.0 // Even counts
+	}
+}
+
+// WeatherAdjustment is the batter-side expected wOBA shift for the weather
+func (tp *TuningParameters) WeatherAdjustment(weather Weather) float64 {
+	adjustment := 0.0
+
+	// Wind effects
+	switch weather.WindDir {
+	case "out":
+		adjustment += float64(weather.WindSpeed) * tp.WindPerMPH // Helps fly balls
+	case "in":
+		adjustment -= float64(weather.WindSpeed) * tp.WindPerMPH // Hurts fly balls
+	}
+
+	// Temperature effects (cold weather hurts offense)
+	temperature := float64(weather.Temperature)
+	if temperature < tp.ColdTemperature {
+		adjustment += tp.ColdAdjustment
+	} else if temperature > tp.HotTemperature {
+		adjustment += tp.HotAdjustment
+	}
+
+	// Humidity effects (high humidity hurts fly balls slightly)
+	if float64(weather.Humidity) > tp.HumidityThreshold {
+		adjustment += tp.HumidityAdjustment
+	}
+
+	return adjustment
+}
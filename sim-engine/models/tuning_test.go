@@ -0,0 +1,64 @@
+package models
+
+import "testing"
+
+func TestDefaultTuningReproducesBuiltInModel(t *testing.T) {
+	tuning := DefaultTuningParameters()
+
+	if adj := tuning.CountAdjustment(Count{Balls: 3, Strikes: 0}); adj != 0.080 {
+		t.Errorf("3-0 adjustment = %v, want 0.080", adj)
+	}
+	if adj := tuning.CountAdjustment(Count{Balls: 1, Strikes: 1}); adj != 0 {
+		t.Errorf("1-1 adjustment = %v, want 0", adj)
+	}
+
+	weather := Weather{Temperature: 45, WindSpeed: 10, WindDir: "in", Humidity: 85}
+	if adj := tuning.WeatherAdjustment(weather); adj < -0.02501 || adj > -0.02499 {
+		t.Errorf("weather adjustment = %v, want -0.025", adj)
+	}
+
+	// Bottom of the 9th, one-run game, runner on second, two outs
+	gs := &GameState{Inning: 9, InningHalf: "bottom", Outs: 2, HomeScore: 3, AwayScore: 4,
+		Bases: BaseState{Second: &BaseRunner{}}}
+	if leverage := gs.CalculateLeverage(); leverage < 3.399 || leverage > 3.401 {
+		t.Errorf("leverage = %v, want 3.4", leverage)
+	}
+}
+
+func TestTuningParametersFrom(t *testing.T) {
+	params, err := TuningParametersFrom(map[string]float64{"home_field_woba": 0.004, "leverage_two_outs": 0.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.HomeFieldWOBA != 0.004 || params.LeverageTwoOuts != 0.5 {
+		t.Errorf("overrides not applied: %+v", params)
+	}
+	if params.Count30 != 0.080 {
+		t.Errorf("unlisted parameter changed: 3-0 adjustment = %v", params.Count30)
+	}
+
+	if _, err := TuningParametersFrom(map[string]float64{"home_feild_woba": 0.004}); err == nil {
+		t.Error("expected an unknown parameter to be rejected")
+	}
+}
+
+func TestGameStateKeepsItsTuning(t *testing.T) {
+	defer SetTuningParameters(nil)
+
+	started := DefaultTuningParameters()
+	gs := &GameState{TuningParams: started}
+
+	reloaded := DefaultTuningParameters()
+	reloaded.HomeFieldWOBA = 0.01
+	SetTuningParameters(reloaded)
+
+	if gs.Tuning() != started {
+		t.Error("a game in progress should keep the parameters it started with")
+	}
+	if (&GameState{}).Tuning() != reloaded {
+		t.Error("a game without parameters should use the active calibration")
+	}
+	if ModelParameterHashFor(started) == ModelParameterHashFor(reloaded) {
+		t.Error("changing a tuning parameter should change the model hash")
+	}
+}
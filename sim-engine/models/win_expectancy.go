@@ -0,0 +1,393 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+const (
+	// weMaxRuns lumps bigger innings into this many runs
+	weMaxRuns = 12
+
+	// wePlateAppearances bounds how far a half-inning is played out; the
+	// probability of an inning lasting longer is negligible
+	wePlateAppearances = 40
+
+	// weMaxLead is the score difference past which the game is treated as decided
+	weMaxLead = 25
+)
+
+// weOutcome is one plate appearance result the win expectancy model plays
+type weOutcome struct {
+	Type        string
+	Probability float64
+}
+
+// weOutcomes is a league-average plate appearance mix. Outs include
+// strikeouts and walks include hit by pitches; runners don't advance on outs.
+var weOutcomes = []weOutcome{
+	{Type: "out", Probability: 0.685},
+	{Type: "walk", Probability: 0.090},
+	{Type: "single", Probability: 0.145},
+	{Type: "double", Probability: 0.045},
+	{Type: "triple", Probability: 0.005},
+	{Type: "home_run", Probability: 0.030},
+}
+
+// WinExpectancySituation is a point in a game: the half-inning, outs,
+// occupied bases and the batting team's lead (negative when trailing)
+type WinExpectancySituation struct {
+	Inning     int
+	InningHalf string  // "top" or "bottom"
+	Outs       int     // 0-2
+	Bases      [3]bool // first, second, third
+	ScoreDiff  int     // batting team runs minus fielding team runs
+}
+
+// ParseBaseState reads bases as three digits for first, second and third,
+// so "110" is runners on first and second
+func ParseBaseState(raw string) ([3]bool, error) {
+	var bases [3]bool
+	if len(raw) != 3 || strings.Trim(raw, "01") != "" {
+		return bases, fmt.Errorf("base_state must be three 0/1 digits for first, second and third, e.g. 110")
+	}
+	for i := range bases {
+		bases[i] = raw[i] == '1'
+	}
+	return bases, nil
+}
+
+// Validate checks the situation can occur in a game
+func (s WinExpectancySituation) Validate() error {
+	switch {
+	case s.Inning < 1:
+		return fmt.Errorf("inning must be at least 1")
+	case s.InningHalf != "top" && s.InningHalf != "bottom":
+		return fmt.Errorf("half must be top or bottom")
+	case s.Outs < 0 || s.Outs > 2:
+		return fmt.Errorf("outs must be 0, 1 or 2")
+	case s.InningHalf == "bottom" && s.Inning >= RegulationInnings && s.ScoreDiff > 0:
+		return fmt.Errorf("the game is over once the home team leads in the bottom of inning %d or later", RegulationInnings)
+	}
+	return nil
+}
+
+// baseMask packs bases into bits: 1 first, 2 second, 4 third
+func baseMask(bases [3]bool) int {
+	mask := 0
+	for i, occupied := range bases {
+		if occupied {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
+// advance applies a plate appearance outcome to the bases, returning the
+// new bases, runs scored and whether it was an out
+func advance(outcome string, bases int) (int, int, bool) {
+	runners := func(mask int) int {
+		count := 0
+		for ; mask > 0; mask >>= 1 {
+			count += mask & 1
+		}
+		return count
+	}
+
+	switch outcome {
+	case "out":
+		return bases, 0, true
+	case "walk":
+		switch {
+		case bases&1 == 0:
+			return bases | 1, 0, false
+		case bases&2 == 0:
+			return bases | 2, 0, false
+		case bases&4 == 0:
+			return bases | 4, 0, false
+		}
+		return bases, 1, false
+	case "single":
+		// Runners on second and third score; a runner on first stops at second
+		return 1 | (bases&1)<<1, runners(bases & 6), false
+	case "double":
+		return 2 | (bases&1)<<2, runners(bases & 6), false
+	case "triple":
+		return 4, runners(bases), false
+	default: // home_run
+		return 0, runners(bases) + 1, false
+	}
+}
+
+// halfInningRuns plays a half-inning out from outs and bases, returning the
+// probability of each number of further runs (the last entry lumps bigger
+// innings)
+func halfInningRuns(outs, bases int) []float64 {
+	dist := make([]float64, weMaxRuns+1)
+
+	// mass[outs][bases][runs] is the probability of reaching that state
+	var mass [3][8][weMaxRuns + 1]float64
+	mass[outs][bases][0] = 1
+	for pa := 0; pa < wePlateAppearances; pa++ {
+		var next [3][8][weMaxRuns + 1]float64
+		for o := 0; o < 3; o++ {
+			for b := 0; b < 8; b++ {
+				for r, p := range mass[o][b] {
+					if p == 0 {
+						continue
+					}
+					for _, outcome := range weOutcomes {
+						newBases, runs, out := advance(outcome.Type, b)
+						total := min(r+runs, weMaxRuns)
+						if out && o == 2 {
+							dist[total] += p * outcome.Probability
+							continue
+						}
+						newOuts := o
+						if out {
+							newOuts++
+						}
+						next[newOuts][newBases][total] += p * outcome.Probability
+					}
+				}
+			}
+		}
+		mass = next
+	}
+
+	// Innings still going after the cap end where they stand
+	for o := 0; o < 3; o++ {
+		for b := 0; b < 8; b++ {
+			for r, p := range mass[o][b] {
+				dist[r] += p
+			}
+		}
+	}
+	return dist
+}
+
+// winExpectancyModel holds the run distributions from every base-out state
+// and memoized home win probabilities at the start of each half-inning
+type winExpectancyModel struct {
+	runs     [3][8][]float64
+	extraTie float64 // home win probability of a game tied after regulation
+	top      map[[2]int]float64
+	bottom   map[[2]int]float64
+	mu       sync.Mutex
+}
+
+// winExpectancy is built once; the model has no inputs that change
+var winExpectancy = sync.OnceValue(func() *winExpectancyModel {
+	m := &winExpectancyModel{top: map[[2]int]float64{}, bottom: map[[2]int]float64{}}
+	for outs := 0; outs < 3; outs++ {
+		for bases := 0; bases < 8; bases++ {
+			m.runs[outs][bases] = halfInningRuns(outs, bases)
+		}
+	}
+
+	// A tie after regulation replays the same extra inning until it's
+	// broken, so its value x solves x = a + t*x. Evaluating the inning
+	// with x = 0 gives a, and with x = 1 gives a + t.
+	m.extraTie = 0
+	a := m.extraInning()
+	m.extraTie = 1
+	t := m.extraInning() - a
+	m.extraTie = a / (1 - t)
+	return m
+})
+
+// extraInning is the home win probability of a tied extra inning, given
+// m.extraTie for the innings after it
+func (m *winExpectancyModel) extraInning() float64 {
+	p := 0.0
+	for awayRuns, pAway := range m.runs[0][0] {
+		p += pAway * m.bottomUnmemoized(RegulationInnings, -awayRuns)
+	}
+	return p
+}
+
+// topHalf is the home win probability at the start of the top of inning with
+// the home team leading by lead
+func (m *winExpectancyModel) topHalf(inning, lead int) float64 {
+	if decided, p := decidedLead(lead); decided {
+		return p
+	}
+	if inning >= RegulationInnings && lead == 0 {
+		return m.extraTie
+	}
+	key := [2]int{min(inning, RegulationInnings), lead}
+	if p, ok := m.memo(m.top, key); ok {
+		return p
+	}
+
+	p := 0.0
+	for awayRuns, pAway := range m.runs[0][0] {
+		p += pAway * m.bottomHalf(inning, lead-awayRuns)
+	}
+	m.store(m.top, key, p)
+	return p
+}
+
+// bottomHalf is the home win probability at the start of the bottom of
+// inning with the home team leading by lead
+func (m *winExpectancyModel) bottomHalf(inning, lead int) float64 {
+	if inning >= RegulationInnings && lead > 0 {
+		return 1
+	}
+	if decided, p := decidedLead(lead); decided {
+		return p
+	}
+	key := [2]int{min(inning, RegulationInnings), lead}
+	if p, ok := m.memo(m.bottom, key); ok {
+		return p
+	}
+	p := m.bottomUnmemoized(inning, lead)
+	m.store(m.bottom, key, p)
+	return p
+}
+
+func (m *winExpectancyModel) bottomUnmemoized(inning, lead int) float64 {
+	p := 0.0
+	for homeRuns, pHome := range m.runs[0][0] {
+		p += pHome * m.afterBottom(inning, lead+homeRuns)
+	}
+	return p
+}
+
+// afterBottom is the home win probability once the bottom of inning ends
+// with the home team leading by lead. From the ninth on a home lead is a
+// walk-off and the game ends as soon as one team leads after an inning.
+func (m *winExpectancyModel) afterBottom(inning, lead int) float64 {
+	if inning < RegulationInnings {
+		return m.topHalf(inning+1, lead)
+	}
+	switch {
+	case lead > 0:
+		return 1
+	case lead < 0:
+		return 0
+	}
+	return m.extraTie
+}
+
+func (m *winExpectancyModel) memo(table map[[2]int]float64, key [2]int) (float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := table[key]
+	return p, ok
+}
+
+func (m *winExpectancyModel) store(table map[[2]int]float64, key [2]int, p float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	table[key] = p
+}
+
+// decidedLead treats leads past weMaxLead as final
+func decidedLead(lead int) (bool, float64) {
+	switch {
+	case lead > weMaxLead:
+		return true, 1
+	case lead < -weMaxLead:
+		return true, 0
+	}
+	return false, 0
+}
+
+// homeWinProbability is the home team's chance of winning from the situation
+func (m *winExpectancyModel) homeWinProbability(s WinExpectancySituation) float64 {
+	runs := m.runs[s.Outs][baseMask(s.Bases)]
+	p := 0.0
+	if s.InningHalf == "top" {
+		homeLead := -s.ScoreDiff
+		for awayRuns, pAway := range runs {
+			p += pAway * m.bottomHalf(s.Inning, homeLead-awayRuns)
+		}
+		return p
+	}
+	for homeRuns, pHome := range runs {
+		p += pHome * m.afterBottom(s.Inning, s.ScoreDiff+homeRuns)
+	}
+	return p
+}
+
+// WinExpectancyResult is the model's view of a game situation
+type WinExpectancyResult struct {
+	HomeWinProbability        float64 `json:"home_win_probability"`
+	BattingTeamWinProbability float64 `json:"batting_team_win_probability"`
+
+	// ExpectedRuns is the batting team's mean further runs this half-inning
+	ExpectedRuns float64 `json:"expected_runs"`
+
+	// ExpectedSwing is the mean absolute change in the batting team's win
+	// probability over the next plate appearance, which is what a leverage
+	// index measures
+	ExpectedSwing float64 `json:"expected_swing"`
+}
+
+// WinExpectancy plays the situation out with a league-average plate
+// appearance mix for both teams: a Markov chain over the 24 base-out states
+// gives each half-inning's runs, and those are rolled forward through the
+// remaining innings, walk-offs and extra innings. Both teams are equal, so
+// a tied game at the start is a coin flip.
+func WinExpectancy(s WinExpectancySituation) WinExpectancyResult {
+	m := winExpectancy()
+	home := m.homeWinProbability(s)
+	batting := battingTeamProbability(s, home)
+
+	result := WinExpectancyResult{
+		HomeWinProbability:        roundProbability(home),
+		BattingTeamWinProbability: roundProbability(batting),
+	}
+
+	for runs, p := range m.runs[s.Outs][baseMask(s.Bases)] {
+		result.ExpectedRuns += float64(runs) * p
+	}
+	result.ExpectedRuns = math.Round(result.ExpectedRuns*1000) / 1000
+
+	for _, outcome := range weOutcomes {
+		after := m.afterPlateAppearance(s, outcome.Type)
+		result.ExpectedSwing += outcome.Probability * math.Abs(after-batting)
+	}
+	result.ExpectedSwing = roundProbability(result.ExpectedSwing)
+
+	return result
+}
+
+// afterPlateAppearance is the batting team's win probability after one
+// plate appearance outcome
+func (m *winExpectancyModel) afterPlateAppearance(s WinExpectancySituation, outcome string) float64 {
+	bases, runs, out := advance(outcome, baseMask(s.Bases))
+	next := s
+	next.ScoreDiff += runs
+	for i := range next.Bases {
+		next.Bases[i] = bases&(1<<i) != 0
+	}
+
+	if s.InningHalf == "bottom" && s.Inning >= RegulationInnings && next.ScoreDiff > 0 {
+		return 1 // walk-off
+	}
+	if !out {
+		return battingTeamProbability(next, m.homeWinProbability(next))
+	}
+	if s.Outs < 2 {
+		next.Outs++
+		return battingTeamProbability(next, m.homeWinProbability(next))
+	}
+
+	// Third out: the fielding team bats next
+	if s.InningHalf == "top" {
+		return 1 - m.bottomHalf(s.Inning, -next.ScoreDiff)
+	}
+	return m.afterBottom(s.Inning, next.ScoreDiff)
+}
+
+// battingTeamProbability turns a home win probability into the batting team's
+func battingTeamProbability(s WinExpectancySituation, home float64) float64 {
+	if s.InningHalf == "bottom" {
+		return home
+	}
+	return 1 - home
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+// TestHalfInningRuns tests the run distributions sum to one and match the
+// familiar run expectancy ordering
+func TestHalfInningRuns(t *testing.T) {
+	expected := func(outs, bases int) float64 {
+		total, mean := 0.0, 0.0
+		for runs, p := range halfInningRuns(outs, bases) {
+			total += p
+			mean += float64(runs) * p
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf("Expected probabilities from %d outs, bases %03b to sum to 1, got %f", outs, bases, total)
+		}
+		return mean
+	}
+
+	empty := expected(0, 0)
+	if empty < 0.4 || empty > 0.6 {
+		t.Errorf("Expected roughly half a run per inning, got %.3f", empty)
+	}
+	if loaded := expected(0, 7); loaded <= empty {
+		t.Errorf("Expected bases loaded to beat empty, got %.3f vs %.3f", loaded, empty)
+	}
+	if twoOuts := expected(2, 0); twoOuts >= empty {
+		t.Errorf("Expected two outs to lower run expectancy, got %.3f vs %.3f", twoOuts, empty)
+	}
+}
+
+// TestWinExpectancy tests situations with well-known answers
+func TestWinExpectancy(t *testing.T) {
+	start := WinExpectancy(WinExpectancySituation{Inning: 1, InningHalf: "top"})
+	if start.HomeWinProbability != 0.5 {
+		t.Errorf("Expected evenly matched teams to start at 0.5, got %f", start.HomeWinProbability)
+	}
+
+	trailing := WinExpectancy(WinExpectancySituation{Inning: 7, InningHalf: "bottom", Outs: 1, Bases: [3]bool{true, true, false}, ScoreDiff: -1})
+	if trailing.BattingTeamWinProbability < 0.35 || trailing.BattingTeamWinProbability > 0.5 {
+		t.Errorf("Expected a one-run deficit with two on to be a little under even, got %f", trailing.BattingTeamWinProbability)
+	}
+	if trailing.HomeWinProbability != trailing.BattingTeamWinProbability {
+		t.Errorf("Expected the home team to be batting in the bottom half")
+	}
+
+	walkOff := WinExpectancy(WinExpectancySituation{Inning: 9, InningHalf: "bottom", Outs: 2, Bases: [3]bool{false, false, true}})
+	if walkOff.HomeWinProbability <= 0.5 {
+		t.Errorf("Expected a tied bottom of the ninth with a runner on third to favour the home team, got %f", walkOff.HomeWinProbability)
+	}
+
+	blowout := WinExpectancy(WinExpectancySituation{Inning: 3, InningHalf: "top", ScoreDiff: 10})
+	if blowout.BattingTeamWinProbability < 0.98 {
+		t.Errorf("Expected a 10-run lead to be nearly certain, got %f", blowout.BattingTeamWinProbability)
+	}
+	if blowout.ExpectedSwing >= walkOff.ExpectedSwing {
+		t.Errorf("Expected a blowout to swing less than a tied ninth, got %f vs %f", blowout.ExpectedSwing, walkOff.ExpectedSwing)
+	}
+
+	// Extra innings play like the ninth
+	ninth := WinExpectancy(WinExpectancySituation{Inning: 9, InningHalf: "top", ScoreDiff: 1})
+	twelfth := WinExpectancy(WinExpectancySituation{Inning: 12, InningHalf: "top", ScoreDiff: 1})
+	if ninth != twelfth {
+		t.Errorf("Expected the 12th to match the 9th, got %+v vs %+v", twelfth, ninth)
+	}
+}
+
+// TestWinExpectancySituationValidate tests impossible situations are rejected
+func TestWinExpectancySituationValidate(t *testing.T) {
+	valid := WinExpectancySituation{Inning: 7, InningHalf: "bottom", Outs: 1, ScoreDiff: -1}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected a valid situation, got %v", err)
+	}
+
+	for name, s := range map[string]WinExpectancySituation{
+		"inning zero":        {Inning: 0, InningHalf: "top"},
+		"unknown half":       {Inning: 1, InningHalf: "middle"},
+		"three outs":         {Inning: 1, InningHalf: "top", Outs: 3},
+		"home leads in 9th":  {Inning: 9, InningHalf: "bottom", ScoreDiff: 1},
+		"home leads in 11th": {Inning: 11, InningHalf: "bottom", ScoreDiff: 2},
+	} {
+		if err := s.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if bases, err := ParseBaseState("101"); err != nil || bases != [3]bool{true, false, true} {
+		t.Errorf("Expected runners on first and third, got %v (%v)", bases, err)
+	}
+	for _, raw := range []string{"", "11", "1101", "12x"} {
+		if _, err := ParseBaseState(raw); err == nil {
+			t.Errorf("Expected %q to be rejected", raw)
+		}
+	}
+}
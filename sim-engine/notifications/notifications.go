@@ -0,0 +1,323 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Target kinds
+const (
+	KindSlack   = "slack"
+	KindDiscord = "discord"
+	KindWebhook = "webhook"
+)
+
+const (
+	// Timeout for each delivery
+	requestTimeout = 10 * time.Second
+
+	// Event names generic webhooks receive
+	EventDailyDigest        = "daily_digest.completed"
+	EventWeatherResimulated = "simulation.weather_resimulated"
+)
+
+// ErrInternalAddress is returned for targets on loopback, private or
+// link-local addresses, which would let a webhook reach the database, other
+// services or cloud metadata endpoints
+var ErrInternalAddress = errors.New("target must not resolve to a loopback, private or link-local address")
+
+// Address ranges that aren't reachable on the public internet and that
+// net.IP's own checks don't cover
+var nonPublicNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),     // "this" network
+	mustParseCIDR("100.64.0.0/10"), // carrier-grade NAT
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return ipNet
+}
+
+// publicAddress reports whether ip is on the public internet
+func publicAddress(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, n := range nonPublicNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Target is where a notification is delivered
+type Target struct {
+	ID   string
+	Kind string
+	URL  string
+}
+
+// Pick is one highlighted game in a digest notification
+type Pick struct {
+	AwayTeam       string  `json:"away_team"`
+	HomeTeam       string  `json:"home_team"`
+	Favorite       string  `json:"favorite,omitempty"`
+	Probability    float64 `json:"probability,omitempty"`
+	ProjectedTotal float64 `json:"projected_total,omitempty"`
+}
+
+// Digest is the compact summary of a completed daily batch that is pushed
+// to targets
+type Digest struct {
+	Date       string `json:"date"`
+	GamesCount int    `json:"games_count"`
+	Favorites  []Pick `json:"favorites"`
+	Upsets     []Pick `json:"upsets"`
+	Totals     []Pick `json:"totals"`
+	DigestURL  string `json:"digest_url"` // full digest, relative to the API base
+}
+
+// Resimulation is a game re-run because its forecast changed shortly before
+// first pitch
+type Resimulation struct {
+	GameID    string    `json:"game_id"`
+	AwayTeam  string    `json:"away_team"`
+	HomeTeam  string    `json:"home_team"`
+	GameTime  time.Time `json:"game_time"`
+	Reasons   []string  `json:"reasons"` // temperature, wind_direction
+	Summary   string    `json:"summary"` // e.g. "temperature 64°F → 52°F"
+	RunID     string    `json:"run_id"`  // superseded run
+	NewRunID  string    `json:"new_run_id"`
+	ResultURL string    `json:"result_url"` // new run, relative to the API base
+}
+
+// validateTargetURL checks a target's kind and that its URL is https
+func validateTargetURL(kind, rawURL string) (*url.URL, error) {
+	switch kind {
+	case KindSlack, KindDiscord, KindWebhook:
+	default:
+		return nil, fmt.Errorf("unknown target kind %q (expected slack, discord or webhook)", kind)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid target URL")
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("%s targets must use https", kind)
+	}
+	return u, nil
+}
+
+// ValidateTarget checks a target's kind and URL, which must use https and
+// resolve only to public addresses. Deliveries check the address again when
+// they connect, since DNS can change after registration.
+func (n *Notifier) ValidateTarget(ctx context.Context, kind, rawURL string) error {
+	u, err := validateTargetURL(kind, rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !n.allowed(ip) {
+			return ErrInternalAddress
+		}
+		return nil
+	}
+	addrs, err := n.lookup(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("target host %s does not resolve", host)
+	}
+	for _, addr := range addrs {
+		if !n.allowed(addr.IP) {
+			return ErrInternalAddress
+		}
+	}
+	return nil
+}
+
+// MaskURL hides a webhook URL's path, which carries its secret token
+func MaskURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "***"
+	}
+	path := strings.TrimRight(u.Path, "/")
+	tail := path
+	if len(tail) > 4 {
+		tail = tail[len(tail)-4:]
+	}
+	return u.Scheme + "://" + u.Host + "/***" + tail
+}
+
+// Message renders a digest as the short text posted to chat targets
+func Message(digest Digest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Daily simulations for %s (%d games)", digest.Date, digest.GamesCount)
+
+	writePicks := func(title string, picks []Pick, line func(Pick) string) {
+		if len(picks) == 0 {
+			return
+		}
+		lines := make([]string, len(picks))
+		for i, pick := range picks {
+			lines[i] = line(pick)
+		}
+		fmt.Fprintf(&b, "\n%s: %s", title, strings.Join(lines, "; "))
+	}
+
+	favorite := func(p Pick) string {
+		return fmt.Sprintf("%s %.0f%% (%s at %s)", p.Favorite, p.Probability*100, p.AwayTeam, p.HomeTeam)
+	}
+	writePicks("Favorites", digest.Favorites, favorite)
+	writePicks("Upset picks", digest.Upsets, favorite)
+	writePicks("Highest totals", digest.Totals, func(p Pick) string {
+		return fmt.Sprintf("%s at %s %.1f runs", p.AwayTeam, p.HomeTeam, p.ProjectedTotal)
+	})
+
+	if digest.DigestURL != "" {
+		fmt.Fprintf(&b, "\nFull digest: %s", digest.DigestURL)
+	}
+	return b.String()
+}
+
+// ResimulationMessage renders a re-simulation as the text posted to chat targets
+func ResimulationMessage(resim Resimulation) string {
+	msg := fmt.Sprintf("Forecast changed for %s at %s (%s): %s. Re-simulating",
+		resim.AwayTeam, resim.HomeTeam, resim.GameTime.Format("Jan 2 3:04 PM"), resim.Summary)
+	if resim.ResultURL != "" {
+		msg += "; results at " + resim.ResultURL
+	}
+	return msg
+}
+
+// ResimulationPayload builds the request body a target kind expects
+func ResimulationPayload(kind string, resim Resimulation) ([]byte, error) {
+	switch kind {
+	case KindSlack:
+		return json.Marshal(map[string]string{"text": ResimulationMessage(resim)})
+	case KindDiscord:
+		return json.Marshal(map[string]string{"content": ResimulationMessage(resim)})
+	case KindWebhook:
+		return json.Marshal(struct {
+			Event        string       `json:"event"`
+			Resimulation Resimulation `json:"resimulation"`
+		}{EventWeatherResimulated, resim})
+	default:
+		return nil, fmt.Errorf("unknown target kind %q", kind)
+	}
+}
+
+// Payload builds the request body a target kind expects
+func Payload(kind string, digest Digest) ([]byte, error) {
+	switch kind {
+	case KindSlack:
+		return json.Marshal(map[string]string{"text": Message(digest)})
+	case KindDiscord:
+		return json.Marshal(map[string]string{"content": Message(digest)})
+	case KindWebhook:
+		return json.Marshal(struct {
+			Event  string `json:"event"`
+			Digest Digest `json:"digest"`
+		}{EventDailyDigest, digest})
+	default:
+		return nil, fmt.Errorf("unknown target kind %q", kind)
+	}
+}
+
+// Notifier delivers digests to targets
+type Notifier struct {
+	httpClient *http.Client
+	lookup     func(ctx context.Context, host string) ([]net.IPAddr, error)
+	allowed    func(net.IP) bool // addresses targets may resolve and connect to
+}
+
+// NewNotifier creates a notifier with a bounded request timeout that only
+// connects to public addresses and doesn't follow redirects
+func NewNotifier() *Notifier {
+	n := &Notifier{lookup: net.DefaultResolver.LookupIPAddr, allowed: publicAddress}
+
+	// The address is checked as it is dialled, after DNS resolution, so a
+	// host that re-resolves to an internal address is still refused
+	dialer := &net.Dialer{
+		Timeout: requestTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !n.allowed(ip) {
+				return ErrInternalAddress
+			}
+			return nil
+		},
+	}
+	n.httpClient = &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: requestTimeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		// A redirect could point anywhere; only the registered URL is posted to
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return n
+}
+
+// Send posts a digest to one target
+func (n *Notifier) Send(ctx context.Context, target Target, digest Digest) error {
+	body, err := Payload(target.Kind, digest)
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, target, body)
+}
+
+// SendResimulation posts a weather re-simulation to one target
+func (n *Notifier) SendResimulation(ctx context.Context, target Target, resim Resimulation) error {
+	body, err := ResimulationPayload(target.Kind, resim)
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, target, body)
+}
+
+// post delivers a prepared body to a target
+func (n *Notifier) post(ctx context.Context, target Target, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("delivery failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("target returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testDigest = Digest{
+	Date:       "2026-06-15",
+	GamesCount: 15,
+	Favorites:  []Pick{{AwayTeam: "Rockies", HomeTeam: "Dodgers", Favorite: "Dodgers", Probability: 0.712}},
+	Upsets:     []Pick{{AwayTeam: "Royals", HomeTeam: "Yankees", Favorite: "Royals", Probability: 0.54}},
+	Totals:     []Pick{{AwayTeam: "Rockies", HomeTeam: "Dodgers", ProjectedTotal: 11.24}},
+	DigestURL:  "/api/v1/simulations/daily/2026-06-15",
+}
+
+func TestValidateTarget(t *testing.T) {
+	notifier := NewNotifier()
+	hosts := map[string][]string{
+		"hooks.slack.com":   {"34.204.10.20"},
+		"discord.com":       {"162.159.135.232", "2606:4700::6810:84e5"},
+		"hooks.example.com": {"203.0.113.10"},
+		"db.internal":       {"10.0.3.4"},
+		"rebound.example":   {"203.0.113.11", "127.0.0.1"},
+	}
+	notifier.lookup = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		var addrs []net.IPAddr
+		for _, ip := range hosts[host] {
+			addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+		}
+		if len(addrs) == 0 {
+			return nil, errors.New("no such host")
+		}
+		return addrs, nil
+	}
+
+	valid := [][2]string{
+		{KindSlack, "https://hooks.slack.com/services/T000/B000/XXXX"},
+		{KindDiscord, "https://discord.com/api/webhooks/1/abc"},
+		{KindWebhook, "https://hooks.example.com/digest"},
+		{KindWebhook, "https://203.0.113.10:8443/digest"},
+	}
+	for _, tc := range valid {
+		if err := notifier.ValidateTarget(context.Background(), tc[0], tc[1]); err != nil {
+			t.Errorf("ValidateTarget(%s, %s) = %v", tc[0], tc[1], err)
+		}
+	}
+
+	invalid := [][2]string{
+		{"email", "https://example.com"},
+		{KindSlack, "http://hooks.slack.com/services/T000"},
+		{KindWebhook, "http://hooks.example.com/digest"},
+		{KindWebhook, "ftp://example.com/hook"},
+		{KindWebhook, "not a url"},
+		{KindWebhook, "https://missing.example/hook"},
+		{KindWebhook, "https://db.internal/hook"},
+		{KindWebhook, "https://rebound.example/hook"},
+		{KindWebhook, "https://127.0.0.1/hook"},
+		{KindWebhook, "https://169.254.169.254/latest/meta-data"},
+		{KindWebhook, "https://[::1]/hook"},
+		{KindWebhook, "https://[::ffff:10.0.0.1]/hook"},
+		{KindWebhook, "https://100.64.0.1/hook"},
+	}
+	for _, tc := range invalid {
+		if err := notifier.ValidateTarget(context.Background(), tc[0], tc[1]); err == nil {
+			t.Errorf("ValidateTarget(%s, %s) accepted an invalid target", tc[0], tc[1])
+		}
+	}
+}
+
+func TestMaskURL(t *testing.T) {
+	if got := MaskURL("https://hooks.slack.com/services/T000/B000/XXXXabcd"); got != "https://hooks.slack.com/***abcd" {
+		t.Errorf("MaskURL = %q", got)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	want := "Daily simulations for 2026-06-15 (15 games)\n" +
+		"Favorites: Dodgers 71% (Rockies at Dodgers)\n" +
+		"Upset picks: Royals 54% (Royals at Yankees)\n" +
+		"Highest totals: Rockies at Dodgers 11.2 runs\n" +
+		"Full digest: /api/v1/simulations/daily/2026-06-15"
+	if got := Message(testDigest); got != want {
+		t.Errorf("Message =\n%s\nwant\n%s", got, want)
+	}
+
+	// Empty sections are left out
+	if got := Message(Digest{Date: "2026-06-15"}); got != "Daily simulations for 2026-06-15 (0 games)" {
+		t.Errorf("Message = %q", got)
+	}
+}
+
+func TestPayload(t *testing.T) {
+	for kind, field := range map[string]string{KindSlack: "text", KindDiscord: "content"} {
+		body, err := Payload(kind, testDigest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var payload map[string]string
+		json.Unmarshal(body, &payload)
+		if !strings.HasPrefix(payload[field], "Daily simulations for 2026-06-15") {
+			t.Errorf("%s payload = %s", kind, body)
+		}
+	}
+
+	body, _ := Payload(KindWebhook, testDigest)
+	var payload struct {
+		Event  string `json:"event"`
+		Digest Digest `json:"digest"`
+	}
+	json.Unmarshal(body, &payload)
+	if payload.Event != EventDailyDigest || payload.Digest.GamesCount != 15 {
+		t.Errorf("webhook payload = %s", body)
+	}
+}
+
+func TestSend(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		switch r.URL.Path {
+		case "/broken":
+			w.WriteHeader(http.StatusGone)
+		case "/redirect":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	// Deliveries refuse internal addresses when they connect
+	notifier := NewNotifier()
+	err := notifier.Send(context.Background(), Target{Kind: KindSlack, URL: server.URL + "/hook"}, testDigest)
+	if !errors.Is(err, ErrInternalAddress) {
+		t.Fatalf("Send to a loopback target = %v, want ErrInternalAddress", err)
+	}
+
+	// The test server is on loopback, so let it through from here on
+	notifier.allowed = func(net.IP) bool { return true }
+	if err := notifier.Send(context.Background(), Target{Kind: KindSlack, URL: server.URL + "/hook"}, testDigest); err != nil {
+		t.Fatalf("Send = %v", err)
+	}
+	if !strings.Contains(received, `"text"`) {
+		t.Errorf("target received %s", received)
+	}
+
+	if err := notifier.Send(context.Background(), Target{Kind: KindWebhook, URL: server.URL + "/broken"}, testDigest); err == nil {
+		t.Error("expected an error for a failing target")
+	}
+	if err := notifier.Send(context.Background(), Target{Kind: KindWebhook, URL: server.URL + "/redirect"}, testDigest); err == nil {
+		t.Error("expected redirects not to be followed")
+	}
+}
+
+func TestResimulationPayload(t *testing.T) {
+	resim := Resimulation{
+		GameID:    "746123",
+		AwayTeam:  "Cubs",
+		HomeTeam:  "Cardinals",
+		GameTime:  time.Date(2026, 6, 15, 19, 15, 0, 0, time.UTC),
+		Reasons:   []string{"temperature"},
+		Summary:   "temperature 78°F → 66°F",
+		RunID:     "old-run",
+		NewRunID:  "new-run",
+		ResultURL: "/api/v1/simulations/new-run",
+	}
+
+	want := "Forecast changed for Cubs at Cardinals (Jun 15 7:15 PM): temperature 78°F → 66°F. " +
+		"Re-simulating; results at /api/v1/simulations/new-run"
+	if got := ResimulationMessage(resim); got != want {
+		t.Errorf("ResimulationMessage =\n%s\nwant\n%s", got, want)
+	}
+
+	body, err := ResimulationPayload(KindDiscord, resim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chat map[string]string
+	json.Unmarshal(body, &chat)
+	if chat["content"] != want {
+		t.Errorf("discord payload = %s", body)
+	}
+
+	body, _ = ResimulationPayload(KindWebhook, resim)
+	var payload struct {
+		Event        string       `json:"event"`
+		Resimulation Resimulation `json:"resimulation"`
+	}
+	json.Unmarshal(body, &payload)
+	if payload.Event != EventWeatherResimulated || payload.Resimulation.NewRunID != "new-run" {
+		t.Errorf("webhook payload = %s", body)
+	}
+
+	if _, err := ResimulationPayload("email", resim); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
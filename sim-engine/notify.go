@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"sim-engine/notifications"
+	"sim-engine/simulation"
+)
+
+// apiKeyHashHeader carries the SHA-256 of the caller's API key from the
+// gateway; notification targets belong to the key that created them
+const apiKeyHashHeader = "X-API-Key-Hash"
+
+var apiKeyHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+const (
+	// maxNotificationTargets caps how many targets one API key may register
+	maxNotificationTargets = 10
+
+	// notificationConcurrency bounds deliveries in flight, so one slow target
+	// doesn't hold up the rest
+	notificationConcurrency = 8
+)
+
+// NotificationTarget is a stored delivery target. URLs are masked in
+// responses since webhook paths embed their secret.
+type NotificationTarget struct {
+	ID         string     `json:"id"`
+	Kind       string     `json:"kind"`
+	URL        string     `json:"url"`
+	Enabled    bool       `json:"enabled"`
+	LastSentAt *time.Time `json:"last_sent_at"`
+	LastError  *string    `json:"last_error"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// NotificationTargetRequest registers a target
+type NotificationTargetRequest struct {
+	Kind string `json:"kind"`
+	URL  string `json:"url"`
+}
+
+// notificationOwner returns the caller's API key hash, writing a 401 when the
+// gateway didn't supply one
+func notificationOwner(w http.ResponseWriter, r *http.Request) (string, bool) {
+	owner := r.Header.Get(apiKeyHashHeader)
+	if !apiKeyHashPattern.MatchString(owner) {
+		http.Error(w, "Notification targets require an API key", http.StatusUnauthorized)
+		return "", false
+	}
+	return owner, true
+}
+
+// listNotificationTargetsHandler lists the caller's targets
+func (s *Server) listNotificationTargetsHandler(w http.ResponseWriter, r *http.Request) {
+	owner, ok := notificationOwner(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := s.db.Query(r.Context(), `
+		SELECT id::text, kind, url, enabled, last_sent_at, last_error, created_at
+		FROM notification_targets
+		WHERE api_key_hash = $1
+		ORDER BY created_at
+	`, owner)
+	if err != nil {
+		log.Printf("Failed to query notification targets: %v", err)
+		http.Error(w, "Failed to query notification targets", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	targets := []NotificationTarget{}
+	for rows.Next() {
+		var target NotificationTarget
+		if err := rows.Scan(&target.ID, &target.Kind, &target.URL, &target.Enabled,
+			&target.LastSentAt, &target.LastError, &target.CreatedAt); err != nil {
+			log.Printf("Error scanning notification target: %v", err)
+			continue
+		}
+		target.URL = notifications.MaskURL(target.URL)
+		targets = append(targets, target)
+	}
+
+	writeJSON(w, map[string]interface{}{"targets": targets})
+}
+
+// createNotificationTargetHandler registers a Slack, Discord or webhook target
+// for the caller's key
+func (s *Server) createNotificationTargetHandler(w http.ResponseWriter, r *http.Request) {
+	owner, ok := notificationOwner(w, r)
+	if !ok {
+		return
+	}
+
+	var req NotificationTargetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := s.notifier.ValidateTarget(r.Context(), req.Kind, req.URL); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// A plain count-then-insert lets concurrent requests both see room under
+	// READ COMMITTED, so the owner's requests are serialized on an advisory
+	// lock held until the transaction ends
+	ctx := r.Context()
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin notification target insert: %v", err)
+		http.Error(w, "Failed to store notification target", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, owner); err != nil {
+		log.Printf("Failed to lock notification targets: %v", err)
+		http.Error(w, "Failed to store notification target", http.StatusInternalServerError)
+		return
+	}
+	var count int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM notification_targets WHERE api_key_hash = $1`,
+		owner).Scan(&count); err != nil {
+		log.Printf("Failed to count notification targets: %v", err)
+		http.Error(w, "Failed to store notification target", http.StatusInternalServerError)
+		return
+	}
+	if count >= maxNotificationTargets {
+		http.Error(w, fmt.Sprintf("At most %d notification targets per API key; delete one first",
+			maxNotificationTargets), http.StatusConflict)
+		return
+	}
+
+	target := NotificationTarget{Kind: req.Kind, URL: notifications.MaskURL(req.URL), Enabled: true}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO notification_targets (api_key_hash, kind, url)
+		VALUES ($1, $2, $3)
+		RETURNING id::text, created_at
+	`, owner, req.Kind, req.URL).Scan(&target.ID, &target.CreatedAt); err != nil {
+		log.Printf("Failed to store notification target: %v", err)
+		http.Error(w, "Failed to store notification target", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit notification target: %v", err)
+		http.Error(w, "Failed to store notification target", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, target)
+}
+
+// deleteNotificationTargetHandler removes one of the caller's targets
+func (s *Server) deleteNotificationTargetHandler(w http.ResponseWriter, r *http.Request) {
+	owner, ok := notificationOwner(w, r)
+	if !ok {
+		return
+	}
+
+	tag, err := s.db.Exec(r.Context(), `
+		DELETE FROM notification_targets
+		WHERE id::text = $1 AND api_key_hash = $2
+	`, mux.Vars(r)["id"], owner)
+	if err != nil {
+		log.Printf("Failed to delete notification target: %v", err)
+		http.Error(w, "Failed to delete notification target", http.StatusInternalServerError)
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		http.Error(w, "Notification target not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// digestNotification condenses a daily digest to its highlights
+func digestNotification(digest *DailyDigest) notifications.Digest {
+	picks := func(games []DigestGame) []notifications.Pick {
+		out := make([]notifications.Pick, 0, len(games))
+		for _, game := range games {
+			pick := notifications.Pick{
+				AwayTeam:    game.AwayTeam,
+				HomeTeam:    game.HomeTeam,
+				Favorite:    game.Favorite,
+				Probability: game.FavoriteProb,
+			}
+			if game.ProjectedTotal != nil {
+				pick.ProjectedTotal = *game.ProjectedTotal
+			}
+			out = append(out, pick)
+		}
+		return out
+	}
+
+	return notifications.Digest{
+		Date:       digest.Date,
+		GamesCount: digest.GamesCount,
+		Favorites:  picks(digest.BiggestFavorites),
+		Upsets:     picks(digest.UpsetPicks),
+		Totals:     picks(digest.HighestTotals),
+		DigestURL:  "/api/v1/simulations/daily/" + digest.Date,
+	}
+}
+
+// enabledNotificationTargets loads every target that receives notifications
+func (s *Server) enabledNotificationTargets(ctx context.Context) []notifications.Target {
+	rows, err := s.db.Query(ctx, `
+		SELECT id::text, kind, url FROM notification_targets WHERE enabled
+	`)
+	if err != nil {
+		log.Printf("Failed to load notification targets: %v", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var targets []notifications.Target
+	for rows.Next() {
+		var target notifications.Target
+		if err := rows.Scan(&target.ID, &target.Kind, &target.URL); err != nil {
+			log.Printf("Error scanning notification target: %v", err)
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// recordNotification stores the outcome of a delivery on its target and
+// reports whether it succeeded
+func (s *Server) recordNotification(ctx context.Context, target notifications.Target, sendErr error) bool {
+	var lastError *string
+	if sendErr != nil {
+		msg := sendErr.Error()
+		lastError = &msg
+		log.Printf("Failed to notify %s target %s: %v", target.Kind, target.ID, sendErr)
+	}
+
+	if _, err := s.db.Exec(ctx, `
+		UPDATE notification_targets
+		SET last_sent_at = CASE WHEN $2::text IS NULL THEN NOW() ELSE last_sent_at END,
+		    last_error = $2
+		WHERE id::text = $1
+	`, target.ID, lastError); err != nil {
+		log.Printf("Failed to record notification for target %s: %v", target.ID, err)
+	}
+	return sendErr == nil
+}
+
+// deliverNotifications sends to every target, several at a time, records
+// each outcome and returns how many succeeded
+func (s *Server) deliverNotifications(ctx context.Context, targets []notifications.Target,
+	send func(context.Context, notifications.Target) error) int {
+
+	var wg sync.WaitGroup
+	var sent atomic.Int64
+	slots := make(chan struct{}, notificationConcurrency)
+	for _, target := range targets {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(target notifications.Target) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if s.recordNotification(ctx, target, send(ctx, target)) {
+				sent.Add(1)
+			}
+		}(target)
+	}
+	wg.Wait()
+	return int(sent.Load())
+}
+
+// sendDigestNotifications posts a completed daily digest to every enabled
+// target and records each delivery's outcome
+func (s *Server) sendDigestNotifications(ctx context.Context, digest *DailyDigest) {
+	targets := s.enabledNotificationTargets(ctx)
+	summary := digestNotification(digest)
+	sent := s.deliverNotifications(ctx, targets, func(ctx context.Context, target notifications.Target) error {
+		return s.notifier.Send(ctx, target, summary)
+	})
+
+	if len(targets) > 0 {
+		log.Printf("Sent daily digest for %s to %d of %d notification targets", digest.Date, sent, len(targets))
+	}
+}
+
+// resimulationNotification condenses a weather re-simulation for targets
+func resimulationNotification(resim simulation.WeatherResimulation) notifications.Resimulation {
+	return notifications.Resimulation{
+		GameID:    resim.GameID,
+		AwayTeam:  resim.AwayTeam,
+		HomeTeam:  resim.HomeTeam,
+		GameTime:  resim.GameTime,
+		Reasons:   resim.Reasons,
+		Summary:   resim.Summary,
+		RunID:     resim.RunID,
+		NewRunID:  resim.NewRunID,
+		ResultURL: "/api/v1/simulations/" + resim.NewRunID,
+	}
+}
+
+// sendResimulationNotifications posts each game re-run for a changed
+// forecast to every enabled target
+func (s *Server) sendResimulationNotifications(ctx context.Context, resims []simulation.WeatherResimulation) {
+	targets := s.enabledNotificationTargets(ctx)
+	for _, resim := range resims {
+		summary := resimulationNotification(resim)
+		s.deliverNotifications(ctx, targets, func(ctx context.Context, target notifications.Target) error {
+			return s.notifier.SendResimulation(ctx, target, summary)
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRegion is used when none is configured; MinIO and most other
+	// S3-compatible services accept it
+	DefaultRegion = "us-east-1"
+
+	// Timeout for one object request
+	requestTimeout = 60 * time.Second
+
+	// Hash of an empty payload, sent with GET and DELETE requests
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// ErrNotFound is returned by Get for a key with no object
+var ErrNotFound = errors.New("object not found")
+
+// Config locates a bucket in S3-compatible storage
+type Config struct {
+	Endpoint        string // e.g. https://s3.us-east-1.amazonaws.com or http://minio:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// Validate checks every setting needed to sign requests is present
+func (c Config) Validate() error {
+	switch {
+	case c.Endpoint == "":
+		return errors.New("object storage endpoint is required")
+	case c.Bucket == "":
+		return errors.New("object storage bucket is required")
+	case c.AccessKeyID == "" || c.SecretAccessKey == "":
+		return errors.New("object storage credentials are required")
+	}
+	if _, err := url.Parse(c.Endpoint); err != nil {
+		return fmt.Errorf("invalid object storage endpoint: %w", err)
+	}
+	return nil
+}
+
+// S3 stores objects in one bucket of an S3-compatible service, addressed by
+// path (endpoint/bucket/key) so it works with MinIO and R2 as well as AWS
+type S3 struct {
+	config     Config
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3 creates a client for the configured bucket
+func NewS3(config Config) (*S3, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Region == "" {
+		config.Region = DefaultRegion
+	}
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	return &S3{
+		config:     config,
+		httpClient: &http.Client{Timeout: requestTimeout},
+		now:        time.Now,
+	}, nil
+}
+
+// Put writes an object, replacing any object with the same key
+func (s *S3) Put(ctx context.Context, key string, body []byte, contentType, contentEncoding string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError("put", key, resp)
+	}
+	return nil
+}
+
+// Get opens an object for reading; the caller closes it. Objects are
+// returned as stored, without decoding any Content-Encoding.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the transport from transparently gunzipping the object
+	req.Header.Set("Accept-Encoding", "identity")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, responseError("get", key, resp)
+	}
+}
+
+// Delete removes an object. Deleting a missing object is not an error.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return responseError("delete", key, resp)
+	}
+	return nil
+}
+
+// newRequest builds a signed request for a key in the bucket
+func (s *S3) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	target := s.config.Endpoint + "/" + url.PathEscape(s.config.Bucket) + "/" + escapeKey(key)
+
+	var reader io.Reader
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		reader = bytes.NewReader(body)
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build object storage request: %w", err)
+	}
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	return req, nil
+}
+
+// do signs and sends a request
+func (s *S3) do(req *http.Request) (*http.Response, error) {
+	signV4(req, req.Header.Get("X-Amz-Content-Sha256"), s.config.Region, "s3",
+		s.config.AccessKeyID, s.config.SecretAccessKey, s.now())
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("object storage request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// responseError describes a failed request with the start of the service's
+// error document
+func responseError(op, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("object storage %s %s returned %d: %s", op, key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// escapeKey escapes each segment of a key, keeping its slashes
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// uriEncode escapes everything but the unreserved characters, as Signature
+// Version 4 requires
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// signV4 adds an AWS Signature Version 4 Authorization header, signing the
+// host, the content headers and every x-amz-* header
+func signV4(req *http.Request, payloadHash, region, service, accessKeyID, secretAccessKey string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-type" || lower == "content-encoding" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery sorts and encodes query parameters for signing
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
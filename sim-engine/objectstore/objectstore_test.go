@@ -0,0 +1,131 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSignV4 checks the signer against the get-vanilla case of AWS's
+// Signature Version 4 test suite
+func TestSignV4(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	signV4(req, emptyPayloadHash, "us-east-1", "service",
+		"AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+	if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+		t.Errorf("X-Amz-Date = %q", got)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := Config{Endpoint: "http://minio:9000", Bucket: "results", AccessKeyID: "key", SecretAccessKey: "secret"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	for name, config := range map[string]Config{
+		"no endpoint":    {Bucket: "results", AccessKeyID: "key", SecretAccessKey: "secret"},
+		"no bucket":      {Endpoint: "http://minio:9000", AccessKeyID: "key", SecretAccessKey: "secret"},
+		"no credentials": {Endpoint: "http://minio:9000", Bucket: "results"},
+	} {
+		if err := config.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// fakeS3 keeps objects in memory, checking each request is signed
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	headers map[string]http.Header
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+		f.headers[r.URL.Path] = r.Header.Clone()
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3PutGetDelete(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}, headers: map[string]http.Header{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3(Config{Endpoint: server.URL + "/", Bucket: "results", AccessKeyID: "key", SecretAccessKey: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "runs/run-1.ndjson.gz", []byte("payload"), "application/x-ndjson", "gzip"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	stored := fake.headers["/results/runs/run-1.ndjson.gz"]
+	if stored == nil {
+		t.Fatalf("Expected the object under the bucket path, got %v", fake.objects)
+	}
+	if stored.Get("Content-Encoding") != "gzip" || stored.Get("X-Amz-Content-Sha256") == emptyPayloadHash {
+		t.Errorf("Unexpected put headers %v", stored)
+	}
+
+	body, err := store.Get(ctx, "runs/run-1.ndjson.gz")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "payload" {
+		t.Errorf("Get returned %q", data)
+	}
+
+	if err := store.Delete(ctx, "runs/run-1.ndjson.gz"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Get(ctx, "runs/run-1.ndjson.gz"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if err := store.Delete(ctx, "runs/run-1.ndjson.gz"); err != nil {
+		t.Errorf("Deleting a missing object should succeed, got %v", err)
+	}
+
+	denied, _ := NewS3(Config{Endpoint: server.URL, Bucket: "results", AccessKeyID: "other", SecretAccessKey: "secret"})
+	if err := denied.Put(ctx, "runs/run-2.ndjson.gz", []byte("payload"), "application/x-ndjson", ""); err == nil ||
+		!strings.Contains(err.Error(), "403") {
+		t.Errorf("Expected a 403 error, got %v", err)
+	}
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/gorilla/mux"
+
+	"sim-engine/odds"
+	"sim-engine/simulation"
+)
+
+const (
+	// maxOddsLinesPerRequest bounds one POST /odds
+	maxOddsLinesPerRequest = 1000
+
+	// defaultCLVDays is the window /odds/clv covers without a start_date
+	defaultCLVDays = 30
+)
+
+// OddsIngestRequest posts lines by hand. game_id is any game ID the
+// gateway accepts; captured_at defaults to now.
+type OddsIngestRequest struct {
+	Lines []odds.Line `json:"lines"`
+}
+
+// ingestOddsHandler stores manually entered lines, skipping unknown games
+func (s *Server) ingestOddsHandler(w http.ResponseWriter, r *http.Request) {
+	var req OddsIngestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Lines) == 0 {
+		http.Error(w, "lines is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.Lines) > maxOddsLinesPerRequest {
+		http.Error(w, fmt.Sprintf("at most %d lines per request", maxOddsLinesPerRequest), http.StatusBadRequest)
+		return
+	}
+	for i, line := range req.Lines {
+		if line.GameID == "" {
+			http.Error(w, fmt.Sprintf("lines[%d]: game_id is required", i), http.StatusUnprocessableEntity)
+			return
+		}
+		if err := line.Validate(); err != nil {
+			http.Error(w, fmt.Sprintf("lines[%d]: %v", i, err), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	now := time.Now()
+	stored, unchanged := 0, 0
+	unknownGames := []string{}
+	for _, line := range req.Lines {
+		game, err := s.simEngine.ResolveID(r.Context(), ids.Game, line.GameID)
+		if isResolutionError(err) {
+			unknownGames = append(unknownGames, line.GameID)
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to resolve game %s for odds: %v", line.GameID, err)
+			http.Error(w, "Failed to store odds", http.StatusInternalServerError)
+			return
+		}
+
+		line.GameID = game.ID
+		line.Source = odds.SourceManual
+		if line.CapturedAt.IsZero() {
+			line.CapturedAt = now
+		}
+		saved, err := s.odds.Save(r.Context(), line)
+		if err != nil {
+			log.Printf("Failed to store odds for game %s: %v", game.ID, err)
+			http.Error(w, "Failed to store odds", http.StatusInternalServerError)
+			return
+		}
+		if saved {
+			stored++
+		} else {
+			unchanged++
+		}
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"stored":        stored,
+		"unchanged":     unchanged,
+		"unknown_games": unknownGames,
+	})
+}
+
+// gameOddsHandler lists a game's stored lines, newest first, with its
+// closing line once the game has started
+func (s *Server) gameOddsHandler(w http.ResponseWriter, r *http.Request) {
+	raw := r.URL.Query().Get("game_id")
+	if raw == "" {
+		http.Error(w, "game_id is required", http.StatusBadRequest)
+		return
+	}
+	game, ok := s.resolveID(r.Context(), w, ids.Game, raw)
+	if !ok {
+		return
+	}
+	bookmaker := r.URL.Query().Get("bookmaker")
+
+	lines, err := s.odds.GameLines(r.Context(), game.ID, bookmaker)
+	if err != nil {
+		log.Printf("Failed to load odds for game %s: %v", game.ID, err)
+		http.Error(w, "Failed to load odds", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{"game_id": game.ID, "lines": lines}
+	closing, ok, err := s.odds.ClosingLine(r.Context(), game.ID, bookmaker)
+	if err != nil {
+		log.Printf("Failed to load closing line for game %s: %v", game.ID, err)
+	} else if ok {
+		response["closing_line"] = closing
+	}
+	writeJSON(w, response)
+}
+
+// OddsComparison sets a run's probabilities against one market line
+type OddsComparison struct {
+	RunID     string                    `json:"run_id"`
+	GameID    string                    `json:"game_id"`
+	LineType  string                    `json:"line_type"` // latest, run or closing
+	Line      odds.Line                 `json:"line"`
+	Moneyline *odds.MoneylineComparison `json:"moneyline,omitempty"`
+	Total     *odds.TotalComparison     `json:"total,omitempty"`
+}
+
+// simulationOddsHandler compares a finished run with the market. ?line=
+// picks the latest line (default), the line when the run started (run) or
+// the closing line (closing); ?bookmaker= limits it to one bookmaker.
+func (s *Server) simulationOddsHandler(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+	lineType := r.URL.Query().Get("line")
+	if lineType == "" {
+		lineType = "latest"
+	}
+	if lineType != "latest" && lineType != "run" && lineType != "closing" {
+		http.Error(w, "line must be latest, run or closing", http.StatusBadRequest)
+		return
+	}
+	bookmaker := r.URL.Query().Get("bookmaker")
+
+	var status, gameID string
+	var createdAt time.Time
+	if err := s.db.QueryRow(r.Context(), `
+		SELECT status, game_id::text, created_at FROM simulation_runs WHERE id = $1
+	`, runID).Scan(&status, &gameID, &createdAt); err != nil {
+		http.Error(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+	if status != "completed" && status != simulation.RunStatusPartial {
+		http.Error(w, "Simulation not yet complete", http.StatusAccepted)
+		return
+	}
+
+	aggregated, err := s.simEngine.GetRunResult(r.Context(), runID)
+	if err != nil {
+		http.Error(w, "Results not available", http.StatusInternalServerError)
+		return
+	}
+
+	var line odds.Line
+	var found bool
+	switch lineType {
+	case "latest":
+		line, found, err = s.odds.LineAt(r.Context(), gameID, bookmaker, time.Now())
+	case "run":
+		line, found, err = s.odds.LineAt(r.Context(), gameID, bookmaker, createdAt)
+	case "closing":
+		line, found, err = s.odds.ClosingLine(r.Context(), gameID, bookmaker)
+	}
+	if err != nil {
+		log.Printf("Failed to load odds for run %s: %v", runID, err)
+		http.Error(w, "Failed to load odds", http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		http.Error(w, "No odds found for this game", http.StatusNotFound)
+		return
+	}
+
+	comparison := OddsComparison{
+		RunID:     runID,
+		GameID:    gameID,
+		LineType:  lineType,
+		Line:      line,
+		Moneyline: odds.CompareMoneyline(line, aggregated.HomeWinProbability),
+	}
+	if aggregated.Markets != nil {
+		comparison.Total = odds.CompareTotal(line, aggregated.Markets.Totals)
+	}
+	writeJSON(w, comparison)
+}
+
+// clvCandidate is a game's pregame run with the line it could have been bet
+// at and the closing line from the same bookmaker
+type clvCandidate struct {
+	gameID             string
+	gameDate           time.Time
+	runID              string
+	homeWinProbability float64
+	homeScore          *int // nil until the game is final
+	awayScore          *int
+	bet                odds.Line
+	closing            odds.Line
+}
+
+// CLVGame is one moneyline pick and how the market moved after it
+type CLVGame struct {
+	GameID               string    `json:"game_id"`
+	GameDate             string    `json:"game_date"`
+	RunID                string    `json:"run_id"`
+	Bookmaker            string    `json:"bookmaker"`
+	Pick                 string    `json:"pick"` // home or away
+	SimulatedProbability float64   `json:"simulated_probability"`
+	Edge                 float64   `json:"edge"`
+	BetPrice             int       `json:"bet_price"`
+	BetProbability       float64   `json:"bet_probability"` // vig removed
+	BetCapturedAt        time.Time `json:"bet_captured_at"`
+	ClosingPrice         int       `json:"closing_price"`
+	ClosingProbability   float64   `json:"closing_probability"` // vig removed
+	ClosingCapturedAt    time.Time `json:"closing_captured_at"`
+	CLV                  float64   `json:"clv"`              // closing minus bet probability
+	Result               string    `json:"result"`           // won, lost or pending
+	Profit               *float64  `json:"profit,omitempty"` // units at the bet price once settled
+}
+
+// CLVSummary totals the picks
+type CLVSummary struct {
+	Picks           int     `json:"picks"`
+	AverageEdge     float64 `json:"average_edge"`
+	AverageCLV      float64 `json:"average_clv"`
+	PositiveCLVRate float64 `json:"positive_clv_rate"`
+	Settled         int     `json:"settled"`
+	Wins            int     `json:"wins"`
+	Losses          int     `json:"losses"`
+	Units           float64 `json:"units"`
+	ROI             float64 `json:"roi"` // units per settled pick
+}
+
+// clvPick takes the moneyline side the run rated above the bet line by at
+// least minEdge, reporting false when neither side qualifies
+func clvPick(c clvCandidate, minEdge float64) (CLVGame, bool) {
+	comparison := odds.CompareMoneyline(c.bet, c.homeWinProbability)
+	closing := odds.CompareMoneyline(c.closing, c.homeWinProbability)
+	if comparison == nil || closing == nil {
+		return CLVGame{}, false
+	}
+
+	pick, side, closingSide := "home", comparison.Home, closing.Home
+	if comparison.Away.Edge > comparison.Home.Edge {
+		pick, side, closingSide = "away", comparison.Away, closing.Away
+	}
+	if side.Edge <= 0 || side.Edge < minEdge {
+		return CLVGame{}, false
+	}
+
+	game := CLVGame{
+		GameID:               c.gameID,
+		GameDate:             c.gameDate.Format("2006-01-02"),
+		RunID:                c.runID,
+		Bookmaker:            c.bet.Bookmaker,
+		Pick:                 pick,
+		SimulatedProbability: side.SimulatedProbability,
+		Edge:                 side.Edge,
+		BetPrice:             side.Price,
+		BetProbability:       side.MarketProbability,
+		BetCapturedAt:        c.bet.CapturedAt,
+		ClosingPrice:         closingSide.Price,
+		ClosingProbability:   closingSide.MarketProbability,
+		ClosingCapturedAt:    c.closing.CapturedAt,
+		CLV:                  math.Round((closingSide.MarketProbability-side.MarketProbability)*10000) / 10000,
+		Result:               "pending",
+	}
+
+	if c.homeScore != nil && c.awayScore != nil && *c.homeScore != *c.awayScore {
+		won := (*c.homeScore > *c.awayScore) == (pick == "home")
+		profit := -1.0
+		game.Result = "lost"
+		if won {
+			profit = math.Round(odds.Payout(side.Price)*10000) / 10000
+			game.Result = "won"
+		}
+		game.Profit = &profit
+	}
+	return game, true
+}
+
+// summarizeCLV averages edge and CLV over every pick and scores the settled
+// ones at their bet prices
+func summarizeCLV(games []CLVGame) CLVSummary {
+	var summary CLVSummary
+	positive := 0
+	for _, game := range games {
+		summary.Picks++
+		summary.AverageEdge += game.Edge
+		summary.AverageCLV += game.CLV
+		if game.CLV > 0 {
+			positive++
+		}
+		if game.Profit != nil {
+			summary.Settled++
+			summary.Units += *game.Profit
+			if game.Result == "won" {
+				summary.Wins++
+			} else {
+				summary.Losses++
+			}
+		}
+	}
+	if summary.Picks > 0 {
+		n := float64(summary.Picks)
+		summary.AverageEdge = math.Round(summary.AverageEdge/n*10000) / 10000
+		summary.AverageCLV = math.Round(summary.AverageCLV/n*10000) / 10000
+		summary.PositiveCLVRate = math.Round(float64(positive)/n*10000) / 10000
+	}
+	if summary.Settled > 0 {
+		summary.ROI = math.Round(summary.Units/float64(summary.Settled)*10000) / 10000
+	}
+	summary.Units = math.Round(summary.Units*100) / 100
+	return summary
+}
+
+// clvHandler tracks closing line value for the games between ?start_date=
+// and ?end_date= (default the last 30 days). Each game's latest pregame run
+// picks the moneyline side it rates above the market by ?min_edge= (default
+// any edge), at the last line captured before the run, or the first after
+// it when there was none. That price is compared with the same bookmaker's
+// closing line.
+func (s *Server) clvHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	endDate := time.Now()
+	if raw := query.Get("end_date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			http.Error(w, "Invalid end_date, use YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		endDate = parsed
+	}
+	startDate := endDate.AddDate(0, 0, -defaultCLVDays)
+	if raw := query.Get("start_date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			http.Error(w, "Invalid start_date, use YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		startDate = parsed
+	}
+	if startDate.After(endDate) {
+		http.Error(w, "start_date must not be after end_date", http.StatusBadRequest)
+		return
+	}
+	minEdge := 0.0
+	if raw := query.Get("min_edge"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 0 || parsed >= 1 {
+			http.Error(w, "min_edge must be a probability between 0 and 1", http.StatusBadRequest)
+			return
+		}
+		minEdge = parsed
+	}
+	bookmaker := query.Get("bookmaker")
+
+	rows, err := s.db.Query(r.Context(), `
+		SELECT g.id::text, g.game_date, sr.id::text, sa.home_win_probability::float8,
+		       CASE WHEN g.status = 'completed' THEN g.final_score_home END,
+		       CASE WHEN g.status = 'completed' THEN g.final_score_away END,
+		       bet.bookmaker, bet.home_moneyline, bet.away_moneyline, bet.captured_at,
+		       close.home_moneyline, close.away_moneyline, close.captured_at
+		FROM games g
+		JOIN LATERAL (
+			SELECT r.id, r.created_at FROM simulation_runs r
+			WHERE r.game_id = g.id AND r.status IN ('completed', $4)
+			  AND r.created_at < COALESCE(g.first_pitch_at, g.game_date::timestamptz)
+			ORDER BY r.created_at DESC
+			LIMIT 1
+		) sr ON true
+		JOIN simulation_aggregates sa ON sa.run_id = sr.id
+		JOIN LATERAL (
+			SELECT o.bookmaker, o.home_moneyline, o.away_moneyline, o.captured_at FROM game_odds o
+			WHERE o.game_id = g.id AND ($3 = '' OR o.bookmaker = $3)
+			  AND o.home_moneyline IS NOT NULL AND o.away_moneyline IS NOT NULL
+			  AND o.captured_at < COALESCE(g.first_pitch_at, g.game_date::timestamptz)
+			ORDER BY o.captured_at <= sr.created_at DESC,
+			         CASE WHEN o.captured_at <= sr.created_at THEN o.captured_at END DESC,
+			         o.captured_at
+			LIMIT 1
+		) bet ON true
+		JOIN LATERAL (
+			SELECT o.home_moneyline, o.away_moneyline, o.captured_at FROM game_odds o
+			WHERE o.game_id = g.id AND o.bookmaker = bet.bookmaker
+			  AND o.home_moneyline IS NOT NULL AND o.away_moneyline IS NOT NULL
+			  AND o.captured_at < COALESCE(g.first_pitch_at, g.game_date::timestamptz)
+			ORDER BY o.captured_at DESC
+			LIMIT 1
+		) close ON true
+		WHERE g.game_date BETWEEN $1 AND $2
+		ORDER BY g.game_date, g.first_pitch_at
+	`, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), bookmaker, simulation.RunStatusPartial)
+	if err != nil {
+		log.Printf("Failed to query closing line value: %v", err)
+		http.Error(w, "Failed to build closing line value report", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	games := []CLVGame{}
+	for rows.Next() {
+		var c clvCandidate
+		c.bet.HomeMoneyline, c.bet.AwayMoneyline = new(int), new(int)
+		c.closing.HomeMoneyline, c.closing.AwayMoneyline = new(int), new(int)
+		if err := rows.Scan(&c.gameID, &c.gameDate, &c.runID, &c.homeWinProbability, &c.homeScore, &c.awayScore,
+			&c.bet.Bookmaker, c.bet.HomeMoneyline, c.bet.AwayMoneyline, &c.bet.CapturedAt,
+			c.closing.HomeMoneyline, c.closing.AwayMoneyline, &c.closing.CapturedAt); err != nil {
+			log.Printf("Error scanning closing line value row: %v", err)
+			continue
+		}
+		if game, ok := clvPick(c, minEdge); ok {
+			games = append(games, game)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to read closing line value: %v", err)
+		http.Error(w, "Failed to build closing line value report", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"start_date": startDate.Format("2006-01-02"),
+		"end_date":   endDate.Format("2006-01-02"),
+		"min_edge":   minEdge,
+		"summary":    summarizeCLV(games),
+		"games":      games,
+	}
+	if bookmaker != "" {
+		response["bookmaker"] = bookmaker
+	}
+	writeJSON(w, response)
+}
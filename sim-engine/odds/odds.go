@@ -0,0 +1,200 @@
+package odds
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"sim-engine/models"
+)
+
+// SourceManual is recorded with lines posted to POST /odds
+const SourceManual = "manual"
+
+// Line is one bookmaker's prices for a game at one moment. Prices are
+// American odds; a market the bookmaker didn't offer is nil.
+type Line struct {
+	GameID        string    `json:"game_id"`
+	Source        string    `json:"source"`
+	Bookmaker     string    `json:"bookmaker"`
+	HomeMoneyline *int      `json:"home_moneyline,omitempty"`
+	AwayMoneyline *int      `json:"away_moneyline,omitempty"`
+	Total         *float64  `json:"total,omitempty"`
+	OverPrice     *int      `json:"over_price,omitempty"`
+	UnderPrice    *int      `json:"under_price,omitempty"`
+	CapturedAt    time.Time `json:"captured_at"`
+}
+
+// HasMoneyline reports whether both sides of the moneyline are priced
+func (l Line) HasMoneyline() bool {
+	return l.HomeMoneyline != nil && l.AwayMoneyline != nil
+}
+
+// HasTotal reports whether the total and both its sides are priced
+func (l Line) HasTotal() bool {
+	return l.Total != nil && l.OverPrice != nil && l.UnderPrice != nil
+}
+
+// Validate checks a line has a bookmaker, at least one complete market and
+// real American prices
+func (l Line) Validate() error {
+	if l.Bookmaker == "" {
+		return fmt.Errorf("bookmaker is required")
+	}
+	if (l.HomeMoneyline == nil) != (l.AwayMoneyline == nil) {
+		return fmt.Errorf("home_moneyline and away_moneyline must be sent together")
+	}
+	if (l.Total == nil) != (l.OverPrice == nil) || (l.Total == nil) != (l.UnderPrice == nil) {
+		return fmt.Errorf("total, over_price and under_price must be sent together")
+	}
+	if !l.HasMoneyline() && !l.HasTotal() {
+		return fmt.Errorf("a moneyline or total is required")
+	}
+	for _, price := range []*int{l.HomeMoneyline, l.AwayMoneyline, l.OverPrice, l.UnderPrice} {
+		if price != nil && !validPrice(*price) {
+			return fmt.Errorf("prices must be American odds of at least +100 or at most -100, got %d", *price)
+		}
+	}
+	if l.Total != nil && (*l.Total <= 0 || math.Mod(*l.Total*2, 1) != 0) {
+		return fmt.Errorf("total must be a positive whole or half run, got %g", *l.Total)
+	}
+	return nil
+}
+
+// validPrice reports whether price is American odds; nothing lies strictly
+// between -100 and +100
+func validPrice(price int) bool {
+	return price >= 100 || price <= -100
+}
+
+// ImpliedProbability is the break-even win probability of an American price,
+// vig included
+func ImpliedProbability(price int) float64 {
+	if price < 0 {
+		return float64(-price) / float64(-price+100)
+	}
+	return 100 / float64(price+100)
+}
+
+// Payout is the profit per unit staked when an American price wins
+func Payout(price int) float64 {
+	if price < 0 {
+		return 100 / float64(-price)
+	}
+	return float64(price) / 100
+}
+
+// RemoveVig scales two sides' implied probabilities to sum to 1, the
+// market's fair probability of each
+func RemoveVig(first, second float64) (float64, float64) {
+	sum := first + second
+	if sum <= 0 {
+		return 0, 0
+	}
+	return first / sum, second / sum
+}
+
+// SideComparison sets the simulated probability of one side against the
+// market's price for it
+type SideComparison struct {
+	Price                int     `json:"price"`
+	ImpliedProbability   float64 `json:"implied_probability"`   // vig included
+	MarketProbability    float64 `json:"market_probability"`    // vig removed
+	SimulatedProbability float64 `json:"simulated_probability"` // excluding pushes, like the market's
+	Edge                 float64 `json:"edge"`                  // simulated minus market probability
+	ExpectedValue        float64 `json:"expected_value"`        // profit per unit staked at the price
+}
+
+// MoneylineComparison compares both sides of the moneyline
+type MoneylineComparison struct {
+	Home SideComparison `json:"home"`
+	Away SideComparison `json:"away"`
+}
+
+// TotalComparison compares the over and under. On a whole-number total
+// PushProbability is the simulated chance of landing on it; the stake comes
+// back, so pushes count toward neither side's edge.
+type TotalComparison struct {
+	Line            float64        `json:"line"`
+	PushProbability float64        `json:"push_probability"`
+	Over            SideComparison `json:"over"`
+	Under           SideComparison `json:"under"`
+}
+
+// compareSides prices two outcomes that can also push. first and second are
+// each side's simulated probability with pushes included.
+func compareSides(firstPrice, secondPrice int, first, second float64) (SideComparison, SideComparison) {
+	firstImplied, secondImplied := ImpliedProbability(firstPrice), ImpliedProbability(secondPrice)
+	firstMarket, secondMarket := RemoveVig(firstImplied, secondImplied)
+	firstSim, secondSim := RemoveVig(first, second)
+
+	side := func(price int, implied, market, sim, win, lose float64) SideComparison {
+		return SideComparison{
+			Price:                price,
+			ImpliedProbability:   round(implied),
+			MarketProbability:    round(market),
+			SimulatedProbability: round(sim),
+			Edge:                 round(sim - market),
+			ExpectedValue:        round(win*Payout(price) - lose),
+		}
+	}
+	return side(firstPrice, firstImplied, firstMarket, firstSim, first, second),
+		side(secondPrice, secondImplied, secondMarket, secondSim, second, first)
+}
+
+// CompareMoneyline compares a run's home win probability with the line's
+// moneyline, or returns nil when the line has none
+func CompareMoneyline(line Line, homeWinProbability float64) *MoneylineComparison {
+	if !line.HasMoneyline() {
+		return nil
+	}
+	home, away := compareSides(*line.HomeMoneyline, *line.AwayMoneyline, homeWinProbability, 1-homeWinProbability)
+	return &MoneylineComparison{Home: home, Away: away}
+}
+
+// CompareTotal compares a run's simulated totals with the line's total. The
+// run's totals are half-run lines, so a whole-number total reads the over
+// from the half run above and the under from the half run below. It returns
+// nil when the line has no total or the run didn't simulate it.
+func CompareTotal(line Line, totals []models.TotalMarket) *TotalComparison {
+	if !line.HasTotal() {
+		return nil
+	}
+	at := func(l float64) (models.TotalMarket, bool) {
+		for _, total := range totals {
+			if total.Line == l {
+				return total, true
+			}
+		}
+		return models.TotalMarket{}, false
+	}
+
+	var over, under float64
+	if math.Mod(*line.Total, 1) != 0 {
+		total, ok := at(*line.Total)
+		if !ok {
+			return nil
+		}
+		over, under = total.Over, total.Under
+	} else {
+		above, okAbove := at(*line.Total + 0.5)
+		below, okBelow := at(*line.Total - 0.5)
+		if !okAbove || !okBelow {
+			return nil
+		}
+		over, under = above.Over, below.Under
+	}
+
+	overSide, underSide := compareSides(*line.OverPrice, *line.UnderPrice, over, under)
+	return &TotalComparison{
+		Line:            *line.Total,
+		PushProbability: round(math.Max(0, 1-over-under)),
+		Over:            overSide,
+		Under:           underSide,
+	}
+}
+
+// round keeps four decimal places
+func round(x float64) float64 {
+	return math.Round(x*10000) / 10000
+}
@@ -0,0 +1,185 @@
+package odds
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sim-engine/models"
+)
+
+func intPtr(v int) *int           { return &v }
+func floatPtr(v float64) *float64 { return &v }
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-4
+}
+
+// TestImpliedProbability tests American prices convert to break-even rates
+func TestImpliedProbability(t *testing.T) {
+	tests := []struct {
+		price   int
+		implied float64
+		payout  float64
+	}{
+		{-150, 0.6, 0.6667},
+		{130, 0.4348, 1.3},
+		{-110, 0.5238, 0.9091},
+		{100, 0.5, 1},
+	}
+	for _, tt := range tests {
+		if got := ImpliedProbability(tt.price); !almostEqual(got, tt.implied) {
+			t.Errorf("ImpliedProbability(%d) = %.4f, want %.4f", tt.price, got, tt.implied)
+		}
+		if got := Payout(tt.price); !almostEqual(got, tt.payout) {
+			t.Errorf("Payout(%d) = %.4f, want %.4f", tt.price, got, tt.payout)
+		}
+	}
+
+	home, away := RemoveVig(ImpliedProbability(-110), ImpliedProbability(-110))
+	if home != 0.5 || away != 0.5 {
+		t.Errorf("Expected an even market without vig, got %.4f / %.4f", home, away)
+	}
+}
+
+// TestLineValidate tests incomplete markets and impossible prices are rejected
+func TestLineValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  Line
+		valid bool
+	}{
+		{"moneyline", Line{Bookmaker: "dk", HomeMoneyline: intPtr(-150), AwayMoneyline: intPtr(130)}, true},
+		{"total", Line{Bookmaker: "dk", Total: floatPtr(8.5), OverPrice: intPtr(-110), UnderPrice: intPtr(-110)}, true},
+		{"no bookmaker", Line{HomeMoneyline: intPtr(-150), AwayMoneyline: intPtr(130)}, false},
+		{"one side", Line{Bookmaker: "dk", HomeMoneyline: intPtr(-150)}, false},
+		{"total without prices", Line{Bookmaker: "dk", Total: floatPtr(8.5)}, false},
+		{"no markets", Line{Bookmaker: "dk"}, false},
+		{"price inside +/-100", Line{Bookmaker: "dk", HomeMoneyline: intPtr(-50), AwayMoneyline: intPtr(130)}, false},
+		{"quarter-run total", Line{Bookmaker: "dk", Total: floatPtr(8.25), OverPrice: intPtr(-110), UnderPrice: intPtr(-110)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.line.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+// TestCompareMoneyline tests edges are measured against the vig-free market
+func TestCompareMoneyline(t *testing.T) {
+	line := Line{Bookmaker: "dk", HomeMoneyline: intPtr(-150), AwayMoneyline: intPtr(130)}
+	comparison := CompareMoneyline(line, 0.65)
+
+	// -150 / +130 implies .600 / .435, or .580 / .420 without the vig
+	if !almostEqual(comparison.Home.MarketProbability, 0.5798) {
+		t.Errorf("Expected home market probability .5798, got %.4f", comparison.Home.MarketProbability)
+	}
+	if !almostEqual(comparison.Home.Edge, 0.0702) || !almostEqual(comparison.Away.Edge, -0.0702) {
+		t.Errorf("Expected edges of +/-.0702, got %.4f / %.4f", comparison.Home.Edge, comparison.Away.Edge)
+	}
+	// .65 * 0.6667 - .35
+	if !almostEqual(comparison.Home.ExpectedValue, 0.0833) {
+		t.Errorf("Expected home EV .0833, got %.4f", comparison.Home.ExpectedValue)
+	}
+
+	if CompareMoneyline(Line{Bookmaker: "dk"}, 0.65) != nil {
+		t.Error("Expected no comparison without a moneyline")
+	}
+}
+
+// TestCompareTotal tests half-run totals read the run's market directly and
+// whole-number totals split off the push
+func TestCompareTotal(t *testing.T) {
+	totals := []models.TotalMarket{
+		{Line: 7.5, Over: 0.60, Under: 0.40},
+		{Line: 8.5, Over: 0.52, Under: 0.48},
+	}
+
+	half := CompareTotal(Line{Total: floatPtr(8.5), OverPrice: intPtr(-110), UnderPrice: intPtr(-110)}, totals)
+	if half == nil || half.PushProbability != 0 || !almostEqual(half.Over.Edge, 0.02) {
+		t.Fatalf("Unexpected half-run comparison %+v", half)
+	}
+
+	whole := CompareTotal(Line{Total: floatPtr(8), OverPrice: intPtr(-110), UnderPrice: intPtr(-110)}, totals)
+	if whole == nil {
+		t.Fatal("Expected a whole-number comparison")
+	}
+	// Over 8 is over 8.5 (.52), under 8 is under 7.5 (.40), the rest pushes
+	if !almostEqual(whole.PushProbability, 0.08) {
+		t.Errorf("Expected push probability .08, got %.4f", whole.PushProbability)
+	}
+	if !almostEqual(whole.Over.SimulatedProbability, 0.5652) {
+		t.Errorf("Expected over probability .5652 excluding pushes, got %.4f", whole.Over.SimulatedProbability)
+	}
+	// .52 * 0.9091 - .40
+	if !almostEqual(whole.Over.ExpectedValue, 0.0727) {
+		t.Errorf("Expected over EV .0727, got %.4f", whole.Over.ExpectedValue)
+	}
+
+	if CompareTotal(Line{Total: floatPtr(11.5), OverPrice: intPtr(-110), UnderPrice: intPtr(-110)}, totals) != nil {
+		t.Error("Expected no comparison for a total the run didn't simulate")
+	}
+}
+
+// TestTheOddsAPIFetchLines tests events become one line per bookmaker
+func TestTheOddsAPIFetchLines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apiKey") != "test_key" || r.URL.Query().Get("bookmakers") != "draftkings" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[{
+			"commence_time": "2024-07-04T23:05:00Z",
+			"home_team": "New York Yankees",
+			"away_team": "Boston Red Sox",
+			"bookmakers": [{
+				"key": "draftkings",
+				"last_update": "2024-07-04T15:00:00Z",
+				"markets": [
+					{"key": "h2h", "outcomes": [
+						{"name": "Boston Red Sox", "price": 125},
+						{"name": "New York Yankees", "price": -145}
+					]},
+					{"key": "totals", "outcomes": [
+						{"name": "Over", "price": -105, "point": 8.5},
+						{"name": "Under", "price": -115, "point": 8.5}
+					]}
+				]
+			}, {
+				"key": "incomplete",
+				"last_update": "2024-07-04T15:00:00Z",
+				"markets": [{"key": "h2h", "outcomes": [{"name": "New York Yankees", "price": -145}]}]
+			}]
+		}]`))
+	}))
+	defer server.Close()
+
+	provider := NewTheOddsAPI("test_key", []string{"draftkings"})
+	provider.baseURL = server.URL
+
+	lines, err := provider.FetchLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(lines) != 1 {
+		t.Fatalf("Expected the complete bookmaker's line only, got %d", len(lines))
+	}
+
+	line := lines[0]
+	if line.HomeTeam != "New York Yankees" || line.Bookmaker != "draftkings" || line.Source != providerTheOddsAPI {
+		t.Errorf("Unexpected line %+v", line)
+	}
+	if *line.HomeMoneyline != -145 || *line.AwayMoneyline != 125 {
+		t.Errorf("Expected -145 / +125, got %d / %d", *line.HomeMoneyline, *line.AwayMoneyline)
+	}
+	if *line.Total != 8.5 || *line.OverPrice != -105 || *line.UnderPrice != -115 {
+		t.Errorf("Unexpected total %v o%d u%d", *line.Total, *line.OverPrice, *line.UnderPrice)
+	}
+	if !line.CommenceTime.Equal(time.Date(2024, 7, 4, 23, 5, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected commence time %v", line.CommenceTime)
+	}
+}
@@ -0,0 +1,149 @@
+package odds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// The Odds API's MLB odds endpoint
+	theOddsAPIURL = "https://api.the-odds-api.com/v4/sports/baseball_mlb/odds"
+
+	// providerTheOddsAPI is recorded as the source of lines it returns
+	providerTheOddsAPI = "the-odds-api"
+
+	// Timeout for provider requests
+	requestTimeout = 15 * time.Second
+)
+
+// ProviderLine is a line as a provider reports it, naming the game by its
+// teams and start time rather than by our game ID
+type ProviderLine struct {
+	Line
+	HomeTeam     string
+	AwayTeam     string
+	CommenceTime time.Time
+}
+
+// Provider fetches current lines for upcoming games
+type Provider interface {
+	Name() string
+	FetchLines(ctx context.Context) ([]ProviderLine, error)
+}
+
+// TheOddsAPI fetches MLB moneylines and totals from the-odds-api.com
+type TheOddsAPI struct {
+	apiKey     string
+	baseURL    string
+	bookmakers []string // empty for every US bookmaker
+	httpClient *http.Client
+}
+
+// NewTheOddsAPI creates a provider limited to the given bookmaker keys
+// (e.g. "draftkings"); none means every US bookmaker
+func NewTheOddsAPI(apiKey string, bookmakers []string) *TheOddsAPI {
+	return &TheOddsAPI{
+		apiKey:     apiKey,
+		baseURL:    theOddsAPIURL,
+		bookmakers: bookmakers,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Name identifies the provider in stored lines
+func (p *TheOddsAPI) Name() string {
+	return providerTheOddsAPI
+}
+
+// theOddsAPIEvent is one game in The Odds API's response
+type theOddsAPIEvent struct {
+	CommenceTime time.Time `json:"commence_time"`
+	HomeTeam     string    `json:"home_team"`
+	AwayTeam     string    `json:"away_team"`
+	Bookmakers   []struct {
+		Key        string    `json:"key"`
+		LastUpdate time.Time `json:"last_update"`
+		Markets    []struct {
+			Key      string `json:"key"` // h2h or totals
+			Outcomes []struct {
+				Name  string   `json:"name"` // team name, Over or Under
+				Price float64  `json:"price"`
+				Point *float64 `json:"point,omitempty"`
+			} `json:"outcomes"`
+		} `json:"markets"`
+	} `json:"bookmakers"`
+}
+
+// FetchLines returns one line per game and bookmaker
+func (p *TheOddsAPI) FetchLines(ctx context.Context) ([]ProviderLine, error) {
+	params := url.Values{}
+	params.Set("apiKey", p.apiKey)
+	params.Set("markets", "h2h,totals")
+	params.Set("oddsFormat", "american")
+	if len(p.bookmakers) > 0 {
+		params.Set("bookmakers", strings.Join(p.bookmakers, ","))
+	} else {
+		params.Set("regions", "us")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch odds: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("odds API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var events []theOddsAPIEvent
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("failed to decode odds: %w", err)
+	}
+
+	var lines []ProviderLine
+	for _, event := range events {
+		for _, bookmaker := range event.Bookmakers {
+			line := ProviderLine{
+				Line: Line{
+					Source:     providerTheOddsAPI,
+					Bookmaker:  bookmaker.Key,
+					CapturedAt: bookmaker.LastUpdate,
+				},
+				HomeTeam:     event.HomeTeam,
+				AwayTeam:     event.AwayTeam,
+				CommenceTime: event.CommenceTime,
+			}
+			for _, market := range bookmaker.Markets {
+				for _, outcome := range market.Outcomes {
+					price := int(outcome.Price)
+					switch {
+					case market.Key == "h2h" && outcome.Name == event.HomeTeam:
+						line.HomeMoneyline = &price
+					case market.Key == "h2h" && outcome.Name == event.AwayTeam:
+						line.AwayMoneyline = &price
+					case market.Key == "totals" && outcome.Name == "Over":
+						line.Total, line.OverPrice = outcome.Point, &price
+					case market.Key == "totals" && outcome.Name == "Under":
+						line.UnderPrice = &price
+					}
+				}
+			}
+			if line.Validate() == nil {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,225 @@
+package odds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store keeps lines in the game_odds table
+type Store struct {
+	db *pgxpool.Pool
+}
+
+// NewStore creates a line store backed by the database
+func NewStore(db *pgxpool.Pool) *Store {
+	return &Store{db: db}
+}
+
+// Save records a line unless it repeats the bookmaker's latest prices for
+// the game, reporting whether it was stored. Polling an unchanged market
+// therefore keeps the time the prices were first seen.
+func (s *Store) Save(ctx context.Context, line Line) (bool, error) {
+	tag, err := s.db.Exec(ctx, `
+		INSERT INTO game_odds (game_id, source, bookmaker, home_moneyline, away_moneyline,
+		                       total_line, over_price, under_price, captured_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9
+		WHERE NOT EXISTS (
+			SELECT 1 FROM (
+				SELECT home_moneyline, away_moneyline, total_line, over_price, under_price
+				FROM game_odds
+				WHERE game_id = $1 AND bookmaker = $3 AND captured_at <= $9
+				ORDER BY captured_at DESC
+				LIMIT 1
+			) latest
+			WHERE latest.home_moneyline IS NOT DISTINCT FROM $4::int
+			  AND latest.away_moneyline IS NOT DISTINCT FROM $5::int
+			  AND latest.total_line IS NOT DISTINCT FROM $6::numeric
+			  AND latest.over_price IS NOT DISTINCT FROM $7::int
+			  AND latest.under_price IS NOT DISTINCT FROM $8::int
+		)
+		ON CONFLICT (game_id, bookmaker, captured_at) DO NOTHING
+	`, line.GameID, line.Source, line.Bookmaker, line.HomeMoneyline, line.AwayMoneyline,
+		line.Total, line.OverPrice, line.UnderPrice, line.CapturedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to save line: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// MatchGame finds the game a provider line is for: the home and away teams'
+// full names and the scheduled first pitch nearest its start time, within
+// a day
+func (s *Store) MatchGame(ctx context.Context, homeTeam, awayTeam string, commence time.Time) (string, bool, error) {
+	var gameID string
+	err := s.db.QueryRow(ctx, `
+		SELECT g.id::text
+		FROM games g
+		JOIN teams ht ON g.home_team_id = ht.id
+		JOIN teams at ON g.away_team_id = at.id
+		WHERE LOWER(ht.name) = LOWER($1) AND LOWER(at.name) = LOWER($2)
+		  AND g.game_date BETWEEN ($3::timestamptz - INTERVAL '1 day')::date AND ($3::timestamptz + INTERVAL '1 day')::date
+		ORDER BY ABS(EXTRACT(EPOCH FROM COALESCE(g.first_pitch_at, g.game_date::timestamptz) - $3::timestamptz))
+		LIMIT 1
+	`, homeTeam, awayTeam, commence).Scan(&gameID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to match game: %w", err)
+	}
+	return gameID, true, nil
+}
+
+// lineColumns selects a Line from game_odds aliased o
+const lineColumns = `o.game_id::text, o.source, o.bookmaker, o.home_moneyline, o.away_moneyline,
+	o.total_line::float8, o.over_price, o.under_price, o.captured_at`
+
+func scanLine(row pgx.Row) (Line, error) {
+	var line Line
+	err := row.Scan(&line.GameID, &line.Source, &line.Bookmaker, &line.HomeMoneyline, &line.AwayMoneyline,
+		&line.Total, &line.OverPrice, &line.UnderPrice, &line.CapturedAt)
+	return line, err
+}
+
+// GameLines returns every stored line for a game, newest first, optionally
+// for one bookmaker
+func (s *Store) GameLines(ctx context.Context, gameID, bookmaker string) ([]Line, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT `+lineColumns+`
+		FROM game_odds o
+		WHERE o.game_id = $1 AND ($2 = '' OR o.bookmaker = $2)
+		ORDER BY o.captured_at DESC, o.bookmaker
+	`, gameID, bookmaker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []Line{}
+	for rows.Next() {
+		line, err := scanLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read line: %w", err)
+		}
+		lines = append(lines, line)
+	}
+	return lines, rows.Err()
+}
+
+// LineAt returns the latest line captured at or before the given time, from
+// one bookmaker or, with bookmaker empty, whichever moved last
+func (s *Store) LineAt(ctx context.Context, gameID, bookmaker string, at time.Time) (Line, bool, error) {
+	line, err := scanLine(s.db.QueryRow(ctx, `
+		SELECT `+lineColumns+`
+		FROM game_odds o
+		WHERE o.game_id = $1 AND ($2 = '' OR o.bookmaker = $2) AND o.captured_at <= $3
+		ORDER BY o.captured_at DESC
+		LIMIT 1
+	`, gameID, bookmaker, at))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Line{}, false, nil
+	}
+	if err != nil {
+		return Line{}, false, fmt.Errorf("failed to load line: %w", err)
+	}
+	return line, true, nil
+}
+
+// ClosingLine returns the last line captured before the game's first pitch
+func (s *Store) ClosingLine(ctx context.Context, gameID, bookmaker string) (Line, bool, error) {
+	line, err := scanLine(s.db.QueryRow(ctx, `
+		SELECT `+lineColumns+`
+		FROM game_odds o
+		JOIN games g ON o.game_id = g.id
+		WHERE o.game_id = $1 AND ($2 = '' OR o.bookmaker = $2)
+		  AND o.captured_at < COALESCE(g.first_pitch_at, g.game_date::timestamptz)
+		ORDER BY o.captured_at DESC
+		LIMIT 1
+	`, gameID, bookmaker))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Line{}, false, nil
+	}
+	if err != nil {
+		return Line{}, false, fmt.Errorf("failed to load closing line: %w", err)
+	}
+	return line, true, nil
+}
+
+// IngestResult counts what one provider fetch did with its lines
+type IngestResult struct {
+	Fetched   int `json:"fetched"`
+	Stored    int `json:"stored"`
+	Unchanged int `json:"unchanged"`
+	Started   int `json:"started"`   // in-game prices, which aren't pregame lines
+	Unmatched int `json:"unmatched"` // no game for the teams and start time
+}
+
+// Ingest fetches the provider's current lines and stores those for games
+// that haven't started
+func (s *Store) Ingest(ctx context.Context, provider Provider, now time.Time) (IngestResult, error) {
+	lines, err := provider.FetchLines(ctx)
+	if err != nil {
+		return IngestResult{}, err
+	}
+
+	result := IngestResult{Fetched: len(lines)}
+	for _, line := range lines {
+		if !line.CommenceTime.After(now) {
+			result.Started++
+			continue
+		}
+		gameID, ok, err := s.MatchGame(ctx, line.HomeTeam, line.AwayTeam, line.CommenceTime)
+		if err != nil {
+			return result, err
+		}
+		if !ok {
+			result.Unmatched++
+			continue
+		}
+
+		line.GameID = gameID
+		if line.CapturedAt.IsZero() || line.CapturedAt.After(now) {
+			line.CapturedAt = now
+		}
+		stored, err := s.Save(ctx, line.Line)
+		if err != nil {
+			return result, err
+		}
+		if stored {
+			result.Stored++
+		} else {
+			result.Unchanged++
+		}
+	}
+	return result, nil
+}
+
+// StartPolling ingests the provider's lines every interval, starting now. A
+// zero interval does nothing.
+func (s *Store) StartPolling(provider Provider, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			result, err := s.Ingest(ctx, provider, time.Now())
+			cancel()
+			if err != nil {
+				log.Printf("Failed to ingest %s odds: %v", provider.Name(), err)
+			} else {
+				log.Printf("Ingested %s odds: %d lines stored, %d unchanged, %d unmatched",
+					provider.Name(), result.Stored, result.Unchanged, result.Unmatched)
+			}
+			<-ticker.C
+		}
+	}()
+}
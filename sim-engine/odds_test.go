@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"sim-engine/odds"
+)
+
+func moneyline(bookmaker string, home, away int) odds.Line {
+	return odds.Line{Bookmaker: bookmaker, HomeMoneyline: &home, AwayMoneyline: &away}
+}
+
+func TestCLVPick(t *testing.T) {
+	homeScore, awayScore := 5, 3
+	candidate := clvCandidate{
+		gameID:             "game-1",
+		gameDate:           time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC),
+		runID:              "run-1",
+		homeWinProbability: 0.60,
+		homeScore:          &homeScore,
+		awayScore:          &awayScore,
+		bet:                moneyline("dk", 110, -130),
+		closing:            moneyline("dk", -120, 100),
+	}
+
+	game, ok := clvPick(candidate, 0)
+	if !ok {
+		t.Fatal("Expected a pick")
+	}
+	if game.Pick != "home" || game.BetPrice != 110 || game.ClosingPrice != -120 {
+		t.Errorf("Unexpected pick %+v", game)
+	}
+	// The home side went from .4612 to .5238 without the vig
+	if game.CLV <= 0.06 || game.CLV >= 0.07 {
+		t.Errorf("Expected CLV of about .063, got %.4f", game.CLV)
+	}
+	if game.Result != "won" || game.Profit == nil || *game.Profit != 1.1 {
+		t.Errorf("Expected a win paying 1.1 units, got %s %v", game.Result, game.Profit)
+	}
+
+	if _, ok := clvPick(candidate, 0.2); ok {
+		t.Error("Expected no pick below min_edge")
+	}
+
+	candidate.homeScore, candidate.awayScore = nil, nil
+	candidate.homeWinProbability = 0.30
+	game, ok = clvPick(candidate, 0)
+	if !ok || game.Pick != "away" || game.Result != "pending" || game.Profit != nil {
+		t.Errorf("Expected a pending away pick, got %+v", game)
+	}
+	if game.CLV >= 0 {
+		t.Errorf("Expected negative CLV when the market moved away, got %.4f", game.CLV)
+	}
+}
+
+func TestSummarizeCLV(t *testing.T) {
+	won, lost := 1.5, -1.0
+	games := []CLVGame{
+		{Edge: 0.04, CLV: 0.02, Result: "won", Profit: &won},
+		{Edge: 0.02, CLV: -0.01, Result: "lost", Profit: &lost},
+		{Edge: 0.06, CLV: 0.02, Result: "pending"},
+	}
+
+	summary := summarizeCLV(games)
+	if summary.Picks != 3 || summary.Settled != 2 || summary.Wins != 1 || summary.Losses != 1 {
+		t.Errorf("Unexpected counts %+v", summary)
+	}
+	if summary.AverageEdge != 0.04 || summary.AverageCLV != 0.01 || summary.PositiveCLVRate != 0.6667 {
+		t.Errorf("Unexpected averages %+v", summary)
+	}
+	if summary.Units != 0.5 || summary.ROI != 0.25 {
+		t.Errorf("Expected +0.5 units at .25 ROI, got %+v", summary)
+	}
+
+	if empty := summarizeCLV(nil); empty.Picks != 0 || empty.ROI != 0 {
+		t.Errorf("Expected an empty summary, got %+v", empty)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+
+	"sim-engine/simulation"
+)
+
+const (
+	// defaultRatingGames is how many games each team plays against the
+	// synthetic opponent when the request doesn't say
+	defaultRatingGames = 200
+	maxRatingGames     = 2000
+
+	// ratingsTimeout bounds a background ratings job
+	ratingsTimeout = 15 * time.Minute
+)
+
+// RatingsRequest selects the teams and opponent to rate. No team_ids means
+// every team.
+type RatingsRequest struct {
+	Season   int      `json:"season"`
+	Opponent string   `json:"opponent"`
+	Games    int      `json:"games"`
+	TeamIDs  []string `json:"team_ids"`
+}
+
+// normalize applies defaults and validates the request
+func (req *RatingsRequest) normalize() error {
+	if req.Season == 0 {
+		req.Season = time.Now().Year()
+	}
+	if req.Opponent == "" {
+		req.Opponent = simulation.OpponentLeagueAverage
+	}
+	if !simulation.IsOpponentLevel(req.Opponent) {
+		return fmt.Errorf("invalid opponent, supported: %s, %s", simulation.OpponentLeagueAverage, simulation.OpponentReplacement)
+	}
+	if req.Games == 0 {
+		req.Games = defaultRatingGames
+	}
+	if req.Games < 2 || req.Games > maxRatingGames {
+		return fmt.Errorf("games must be between 2 and %d", maxRatingGames)
+	}
+	return nil
+}
+
+// ratingsHandler starts rating teams against a synthetic league-average or
+// replacement-level opponent in a neutral park. The ratings are stored for
+// the gateway's power rankings when the job finishes.
+func (s *Server) ratingsHandler(w http.ResponseWriter, r *http.Request) {
+	var req RatingsRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	if err := req.normalize(); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	teamIDs := make([]string, 0, len(req.TeamIDs))
+	for _, raw := range req.TeamIDs {
+		resolved, ok := s.resolveID(r.Context(), w, ids.Team, raw)
+		if !ok {
+			return
+		}
+		teamIDs = append(teamIDs, resolved.ID)
+	}
+
+	// Every team is a few thousand games, longer than callers should wait
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), ratingsTimeout)
+		defer cancel()
+
+		started := time.Now()
+		ratings, err := s.simEngine.RateTeams(ctx, teamIDs, req.Season, req.Opponent, req.Games)
+		if err != nil {
+			log.Printf("Team ratings failed for %d against %s: %v", req.Season, req.Opponent, err)
+			return
+		}
+		log.Printf("Rated %d teams against %s for %d in %s", len(ratings), req.Opponent, req.Season, time.Since(started))
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, map[string]interface{}{
+		"season":   req.Season,
+		"opponent": req.Opponent,
+		"games":    req.Games,
+		"team_ids": teamIDs,
+		"status":   "running",
+	})
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRatingsRequestNormalize(t *testing.T) {
+	var req RatingsRequest
+	if err := req.normalize(); err != nil {
+		t.Fatalf("Expected defaults to validate, got %v", err)
+	}
+	if req.Season != time.Now().Year() || req.Opponent != "league_average" || req.Games != defaultRatingGames {
+		t.Errorf("Expected current season, league_average and %d games, got %+v", defaultRatingGames, req)
+	}
+
+	tests := []struct {
+		name    string
+		req     RatingsRequest
+		wantErr bool
+	}{
+		{name: "replacement", req: RatingsRequest{Opponent: "replacement", Games: 500}},
+		{name: "unknown opponent", req: RatingsRequest{Opponent: "yankees"}, wantErr: true},
+		{name: "too many games", req: RatingsRequest{Games: maxRatingGames + 1}, wantErr: true},
+		{name: "one game", req: RatingsRequest{Games: 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.normalize(); (err != nil) != tt.wantErr {
+				t.Errorf("normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/baseball-sim/shared/ids"
+)
+
+// resolveID resolves a client-supplied identifier, writing a 404 when
+// nothing matches and a 409 naming the candidates when several do
+func (s *Server) resolveID(ctx context.Context, w http.ResponseWriter, kind ids.Kind, raw string) (ids.Resolved, bool) {
+	resolved, err := s.simEngine.ResolveID(ctx, kind, raw)
+	if err != nil {
+		writeIDError(w, kind, err)
+		return ids.Resolved{}, false
+	}
+	return resolved, true
+}
+
+// writeIDError reports a failed resolution
+func writeIDError(w http.ResponseWriter, kind ids.Kind, err error) {
+	var ambiguous *ids.AmbiguousError
+	switch {
+	case errors.Is(err, ids.ErrNotFound):
+		http.Error(w, kind.Label+" not found", http.StatusNotFound)
+	case errors.As(err, &ambiguous):
+		http.Error(w, ambiguous.Error(), http.StatusConflict)
+	default:
+		log.Printf("Database error: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// isResolutionError reports whether err means the identifier matched no
+// entity or several, rather than a lookup failure
+func isResolutionError(err error) bool {
+	var ambiguous *ids.AmbiguousError
+	return errors.Is(err, ids.ErrNotFound) || errors.As(err, &ambiguous)
+}
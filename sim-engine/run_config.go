@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"sim-engine/simulation"
+)
+
+// RunConfigResponse echoes the configuration a run used
+type RunConfigResponse struct {
+	RunID     string                     `json:"run_id"`
+	Status    string                     `json:"status"`
+	Source    string                     `json:"source"` // "recorded" or "reconstructed"
+	Requested map[string]interface{}     `json:"requested"`
+	Effective simulation.EffectiveConfig `json:"effective"`
+}
+
+// simulationConfigHandler returns the full configuration a run was simulated
+// with, so it can be reproduced: the client's config as sent, and the
+// effective config with defaults, rules, calibration and engine version.
+// Runs that started before effective configs were recorded, or haven't
+// started yet, get one rebuilt from their stored config and inputs; its
+// tuning is left out when the run's model fingerprint no longer matches.
+func (s *Server) simulationConfigHandler(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	response := RunConfigResponse{RunID: runID}
+	var totalRuns int
+	var configJSON, effectiveJSON, inputsJSON []byte
+	err := s.db.QueryRow(r.Context(), `
+		SELECT status, total_runs, config, effective_config, inputs
+		FROM simulation_runs
+		WHERE id = $1
+	`, runID).Scan(&response.Status, &totalRuns, &configJSON, &effectiveJSON, &inputsJSON)
+	if err != nil {
+		http.Error(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+
+	if len(configJSON) > 0 {
+		json.Unmarshal(configJSON, &response.Requested)
+	}
+	if response.Requested == nil {
+		response.Requested = map[string]interface{}{}
+	}
+
+	if len(effectiveJSON) > 0 && json.Unmarshal(effectiveJSON, &response.Effective) == nil {
+		response.Source = "recorded"
+		writeJSON(w, response)
+		return
+	}
+
+	var inputs simulation.RunInputs
+	if len(inputsJSON) > 0 {
+		json.Unmarshal(inputsJSON, &inputs)
+	}
+	var asOf time.Time
+	if inputs.AsOf != "" {
+		asOf, _ = time.Parse("2006-01-02", inputs.AsOf)
+	}
+
+	response.Source = "reconstructed"
+	response.Effective = simulation.NewEffectiveConfig(response.Requested, totalRuns, asOf, nil)
+	if inputs.ModelParamHash != "" {
+		if inputs.ModelParamHash != response.Effective.ModelParamHash {
+			response.Effective.Tuning = nil
+		}
+		response.Effective.ModelParamHash = inputs.ModelParamHash
+		response.Effective.EngineVersion = inputs.EngineVersion
+	}
+	writeJSON(w, response)
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRunsLimit = 50
+	maxRunsLimit     = 500
+)
+
+// runStatuses are the statuses a run can be listed by; "active" is shorthand
+// for pending and running
+var runStatuses = map[string][]string{
+	"pending":   {"pending"},
+	"running":   {"running"},
+	"active":    {"pending", "running"},
+	"completed": {"completed"},
+	"partial":   {"partial"},
+	"error":     {"error"},
+}
+
+// RunListFilters selects the runs listed by GET /simulations
+type RunListFilters struct {
+	Statuses      []string
+	GameID        string // game UUID, resolved from the ?game_id= the caller sent
+	BatchID       string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Limit         int
+	Offset        int
+}
+
+// parseRunListFilters reads ?status= (comma-separated), ?batch_id=,
+// ?created_after=, ?created_before= (YYYY-MM-DD or RFC 3339), ?limit= and
+// ?offset=. ?game_id= is resolved by the handler.
+func parseRunListFilters(query url.Values) (RunListFilters, error) {
+	filters := RunListFilters{Limit: defaultRunsLimit}
+
+	if statusStr := query.Get("status"); statusStr != "" {
+		seen := make(map[string]bool)
+		for _, name := range strings.Split(statusStr, ",") {
+			statuses, ok := runStatuses[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return filters, fmt.Errorf("unknown status %q; use pending, running, active, completed, partial or error", name)
+			}
+			for _, status := range statuses {
+				if !seen[status] {
+					seen[status] = true
+					filters.Statuses = append(filters.Statuses, status)
+				}
+			}
+		}
+	}
+
+	filters.BatchID = query.Get("batch_id")
+	if filters.BatchID != "" {
+		if _, err := uuid.Parse(filters.BatchID); err != nil {
+			return filters, fmt.Errorf("batch_id must be a UUID")
+		}
+	}
+
+	for _, bound := range []struct {
+		name string
+		dst  **time.Time
+	}{{"created_after", &filters.CreatedAfter}, {"created_before", &filters.CreatedBefore}} {
+		value := query.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if parsed, err = time.Parse("2006-01-02", value); err != nil {
+				return filters, fmt.Errorf("invalid %s, use YYYY-MM-DD or RFC 3339", bound.name)
+			}
+		}
+		*bound.dst = &parsed
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxRunsLimit {
+			return filters, fmt.Errorf("limit must be between 1 and %d", maxRunsLimit)
+		}
+		filters.Limit = limit
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return filters, fmt.Errorf("offset must be a non-negative integer")
+		}
+		filters.Offset = offset
+	}
+	return filters, nil
+}
+
+// whereClause builds the run list conditions, aliased sr
+func (f RunListFilters) whereClause() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if len(f.Statuses) > 0 {
+		add("COALESCE(sr.status, 'pending') = ANY($%d)", f.Statuses)
+	}
+	if f.GameID != "" {
+		add("sr.game_id = $%d::uuid", f.GameID)
+	}
+	if f.BatchID != "" {
+		add("sr.batch_id = $%d::uuid", f.BatchID)
+	}
+	if f.CreatedAfter != nil {
+		add("sr.created_at >= $%d", *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		add("sr.created_at < $%d", *f.CreatedBefore)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// RunSummary is one run in a listing
+type RunSummary struct {
+	SimulationStatus
+	BatchID  *string `json:"batch_id,omitempty"`
+	HomeTeam string  `json:"home_team"`
+	AwayTeam string  `json:"away_team"`
+	GameDate string  `json:"game_date"`
+
+	// Set when the run was re-run after its game's forecast changed
+	SupersededBy     *string `json:"superseded_by,omitempty"`
+	SupersededReason *string `json:"superseded_reason,omitempty"`
+}
+
+// RunList is one page of runs, newest first
+type RunList struct {
+	Runs       []RunSummary `json:"runs"`
+	Total      int          `json:"total"`
+	Limit      int          `json:"limit"`
+	Offset     int          `json:"offset"`
+	NextOffset *int         `json:"next_offset,omitempty"` // null on the last page
+}
+
+// listSimulationsHandler lists runs newest first so operators can see the
+// active and recent workload. Progress of runs still in memory is live.
+func (s *Server) listSimulationsHandler(w http.ResponseWriter, r *http.Request) {
+	filters, err := parseRunListFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if gameID := r.URL.Query().Get("game_id"); gameID != "" {
+		game, ok := s.resolveID(r.Context(), w, ids.Game, gameID)
+		if !ok {
+			return
+		}
+		filters.GameID = game.ID
+	}
+
+	where, args := filters.whereClause()
+	list := RunList{Runs: []RunSummary{}, Limit: filters.Limit, Offset: filters.Offset}
+	if err := s.db.QueryRow(r.Context(), `SELECT COUNT(*) FROM simulation_runs sr`+where, args...).Scan(&list.Total); err != nil {
+		log.Printf("Failed to count simulation runs: %v", err)
+		http.Error(w, "Failed to list simulation runs", http.StatusInternalServerError)
+		return
+	}
+
+	rows, err := s.db.Query(r.Context(), fmt.Sprintf(`
+		SELECT sr.id::text, COALESCE(g.game_id, ''), COALESCE(sr.status, 'pending'),
+		       COALESCE(sr.total_runs, 0), COALESCE(sr.completed_runs, 0), sr.created_at, sr.completed_at,
+		       sr.batch_id::text, COALESCE(ht.name, ''), COALESCE(at.name, ''),
+		       COALESCE(TO_CHAR(g.game_date, 'YYYY-MM-DD'), ''), sr.superseded_by::text, sr.superseded_reason
+		FROM simulation_runs sr
+		LEFT JOIN games g ON sr.game_id = g.id
+		LEFT JOIN teams ht ON g.home_team_id = ht.id
+		LEFT JOIN teams at ON g.away_team_id = at.id%s
+		ORDER BY sr.created_at DESC, sr.id
+		LIMIT %d OFFSET %d`, where, filters.Limit, filters.Offset), args...)
+	if err != nil {
+		log.Printf("Failed to list simulation runs: %v", err)
+		http.Error(w, "Failed to list simulation runs", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var run RunSummary
+		if err := rows.Scan(&run.RunID, &run.GameID, &run.Status, &run.TotalRuns, &run.CompletedRuns,
+			&run.CreatedAt, &run.CompletedAt, &run.BatchID, &run.HomeTeam, &run.AwayTeam, &run.GameDate,
+			&run.SupersededBy, &run.SupersededReason); err != nil {
+			log.Printf("Error scanning simulation run: %v", err)
+			continue
+		}
+		// The database only records progress every 100 simulations
+		if active, ok := s.simEngine.GetRunStatus(run.RunID); ok {
+			run.Status = active.Status
+			run.CompletedRuns = active.CompletedRuns
+		}
+		if run.TotalRuns > 0 {
+			run.Progress = float64(run.CompletedRuns) / float64(run.TotalRuns)
+		}
+		list.Runs = append(list.Runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to list simulation runs: %v", err)
+		http.Error(w, "Failed to list simulation runs", http.StatusInternalServerError)
+		return
+	}
+
+	if next := filters.Offset + len(list.Runs); next < list.Total {
+		list.NextOffset = &next
+	}
+	writeJSON(w, list)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseRunListFilters(t *testing.T) {
+	filters, err := parseRunListFilters(url.Values{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filters.Limit != defaultRunsLimit || filters.Offset != 0 || filters.Statuses != nil {
+		t.Errorf("unexpected defaults %+v", filters)
+	}
+
+	filters, err = parseRunListFilters(url.Values{
+		"status":         {"active, Running,partial"},
+		"batch_id":       {"5b8c1d2e-3f4a-4b5c-8d6e-7f8091a2b3c4"},
+		"created_after":  {"2024-07-01"},
+		"created_before": {"2024-07-02T12:00:00Z"},
+		"limit":          {"10"},
+		"offset":         {"20"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(filters.Statuses, ","); got != "pending,running,partial" {
+		t.Errorf("statuses = %s", got)
+	}
+	if filters.CreatedAfter == nil || filters.CreatedAfter.Format("2006-01-02") != "2024-07-01" {
+		t.Errorf("created_after = %v", filters.CreatedAfter)
+	}
+	if filters.CreatedBefore == nil || filters.CreatedBefore.Hour() != 12 {
+		t.Errorf("created_before = %v", filters.CreatedBefore)
+	}
+	if filters.Limit != 10 || filters.Offset != 20 {
+		t.Errorf("limit/offset = %d/%d", filters.Limit, filters.Offset)
+	}
+
+	for name, query := range map[string]url.Values{
+		"unknown status": {"status": {"finished"}},
+		"bad batch":      {"batch_id": {"42"}},
+		"bad date":       {"created_after": {"07/01/2024"}},
+		"zero limit":     {"limit": {"0"}},
+		"huge limit":     {"limit": {"100000"}},
+		"negative":       {"offset": {"-1"}},
+	} {
+		if _, err := parseRunListFilters(query); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestRunListWhereClause(t *testing.T) {
+	if where, args := (RunListFilters{}).whereClause(); where != "" || args != nil {
+		t.Errorf("no filters should give no clause, got %q %v", where, args)
+	}
+
+	filters, _ := parseRunListFilters(url.Values{"status": {"error"}, "created_after": {"2024-07-01"}})
+	filters.GameID = "3f2b8a7e-6c1d-4e5f-8a9b-0c1d2e3f4a5b"
+	where, args := filters.whereClause()
+	want := " WHERE COALESCE(sr.status, 'pending') = ANY($1) AND sr.game_id = $2::uuid AND sr.created_at >= $3"
+	if where != want {
+		t.Errorf("where = %q, want %q", where, want)
+	}
+	if len(args) != 3 {
+		t.Errorf("got %d args, want 3", len(args))
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"sim-engine/models"
+)
+
+const (
+	defaultSampleSize = 100
+	maxSampleSize     = 1000
+)
+
+// SimulationSample is one raw simulated game outcome
+type SimulationSample struct {
+	SimulationNumber int                   `json:"simulation_number"`
+	HomeScore        int                   `json:"home_score"`
+	AwayScore        int                   `json:"away_score"`
+	Winner           string                `json:"winner"`
+	TotalPitches     int                   `json:"total_pitches"`
+	DurationMinutes  int                   `json:"duration_minutes"`
+	Linescore        *models.Linescore     `json:"linescore,omitempty"`  // Absent for results stored before linescores were kept
+	FirstFive        *models.ScoreSnapshot `json:"first_five,omitempty"` // Absent when the game ended before five innings
+}
+
+// SamplesResponse is a random sample of a run's per-simulation outcomes
+type SamplesResponse struct {
+	RunID            string             `json:"run_id"`
+	Requested        int                `json:"requested"`
+	Returned         int                `json:"returned"`
+	TotalSimulations int                `json:"total_simulations"`
+	Seed             int64              `json:"seed"`
+	Source           string             `json:"source"` // "memory", "object_storage" or "database"
+	Samples          []SimulationSample `json:"samples"`
+}
+
+// simulationSamplesHandler returns ?n= random simulated outcomes for a run.
+// Passing the returned seed back as ?seed= reproduces the same sample.
+func (s *Server) simulationSamplesHandler(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	n := defaultSampleSize
+	if nStr := r.URL.Query().Get("n"); nStr != "" {
+		parsed, err := strconv.Atoi(nStr)
+		if err != nil || parsed < 1 || parsed > maxSampleSize {
+			http.Error(w, "n must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		n = parsed
+	}
+
+	seed := time.Now().UnixNano()
+	if seedStr := r.URL.Query().Get("seed"); seedStr != "" {
+		parsed, err := strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid seed parameter", http.StatusBadRequest)
+			return
+		}
+		seed = parsed
+	}
+
+	response := SamplesResponse{RunID: runID, Requested: n, Seed: seed}
+
+	// Completed runs still held in memory can be sampled without a query
+	if status, exists := s.simEngine.GetRunStatus(runID); exists && len(status.Results) > 0 {
+		response.Source = "memory"
+		response.TotalSimulations = len(status.Results)
+		response.Samples = sampleResults(status.Results, n, seed)
+		response.Returned = len(response.Samples)
+		writeJSON(w, response)
+		return
+	}
+
+	// Runs kept in object storage are read whole and sampled the same way
+	if results, ok, err := s.simEngine.StoredResults(r.Context(), runID); ok {
+		if err != nil {
+			log.Printf("Failed to load results object for run %s: %v", runID, err)
+			http.Error(w, "Failed to load samples", http.StatusInternalServerError)
+			return
+		}
+		response.Source = "object_storage"
+		response.TotalSimulations = len(results)
+		response.Samples = sampleResults(results, n, seed)
+		response.Returned = len(response.Samples)
+		writeJSON(w, response)
+		return
+	}
+
+	var completed int
+	err := s.db.QueryRow(r.Context(),
+		"SELECT completed_runs FROM simulation_runs WHERE id = $1", runID).Scan(&completed)
+	if err != nil {
+		http.Error(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+
+	// Hash ordering keyed by the seed gives a stable pseudo-random sample
+	rows, err := s.db.Query(r.Context(), `
+		SELECT simulation_number, home_score, away_score,
+		       COALESCE(total_pitches, 0), COALESCE(game_duration_minutes, 0),
+		       linescore, first_five_home_score, first_five_away_score
+		FROM simulation_results
+		WHERE run_id = $1
+		ORDER BY md5(simulation_number::text || $2)
+		LIMIT $3
+	`, runID, strconv.FormatInt(seed, 10), n)
+	if err != nil {
+		log.Printf("Failed to query simulation samples: %v", err)
+		http.Error(w, "Failed to load samples", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	response.Samples = []SimulationSample{}
+	for rows.Next() {
+		var sample SimulationSample
+		var linescoreJSON []byte
+		var firstFiveHome, firstFiveAway *int
+		if err := rows.Scan(&sample.SimulationNumber, &sample.HomeScore, &sample.AwayScore,
+			&sample.TotalPitches, &sample.DurationMinutes,
+			&linescoreJSON, &firstFiveHome, &firstFiveAway); err != nil {
+			log.Printf("Error scanning simulation sample: %v", err)
+			continue
+		}
+		if linescoreJSON != nil {
+			var linescore models.Linescore
+			if err := json.Unmarshal(linescoreJSON, &linescore); err == nil {
+				sample.Linescore = &linescore
+			}
+		}
+		if firstFiveHome != nil && firstFiveAway != nil {
+			sample.FirstFive = &models.ScoreSnapshot{HomeScore: *firstFiveHome, AwayScore: *firstFiveAway}
+		}
+		sample.Winner = winnerFor(sample.HomeScore, sample.AwayScore)
+		response.Samples = append(response.Samples, sample)
+	}
+
+	response.Source = "database"
+	response.TotalSimulations = completed
+	response.Returned = len(response.Samples)
+	writeJSON(w, response)
+}
+
+// sampleResults draws up to n results without replacement using a seeded
+// partial Fisher-Yates shuffle over indexes
+func sampleResults(results []models.SimulationResult, n int, seed int64) []SimulationSample {
+	if n > len(results) {
+		n = len(results)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	indexes := make([]int, len(results))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	samples := make([]SimulationSample, 0, n)
+	for i := 0; i < n; i++ {
+		j := i + rng.Intn(len(indexes)-i)
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+
+		result := results[indexes[i]]
+		samples = append(samples, SimulationSample{
+			SimulationNumber: result.SimulationNumber,
+			HomeScore:        result.HomeScore,
+			AwayScore:        result.AwayScore,
+			Winner:           result.Winner,
+			TotalPitches:     result.TotalPitches,
+			DurationMinutes:  result.GameDuration,
+			Linescore:        &result.FinalState.Linescore,
+			FirstFive:        result.FirstFive,
+		})
+	}
+
+	return samples
+}
+
+func winnerFor(homeScore, awayScore int) string {
+	switch {
+	case homeScore > awayScore:
+		return "home"
+	case awayScore > homeScore:
+		return "away"
+	default:
+		return "tie"
+	}
+}
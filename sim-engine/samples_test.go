@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestSampleResults(t *testing.T) {
+	results := make([]models.SimulationResult, 50)
+	for i := range results {
+		results[i] = models.SimulationResult{SimulationNumber: i + 1, HomeScore: i % 7, AwayScore: i % 5, Winner: "home"}
+	}
+
+	samples := sampleResults(results, 10, 42)
+	if len(samples) != 10 {
+		t.Fatalf("Expected 10 samples, got %d", len(samples))
+	}
+
+	seen := make(map[int]bool)
+	for _, sample := range samples {
+		if seen[sample.SimulationNumber] {
+			t.Errorf("Simulation %d sampled twice", sample.SimulationNumber)
+		}
+		seen[sample.SimulationNumber] = true
+	}
+
+	// Same seed reproduces the same sample
+	again := sampleResults(results, 10, 42)
+	for i := range samples {
+		if samples[i].SimulationNumber != again[i].SimulationNumber {
+			t.Errorf("Expected identical sample for same seed at index %d", i)
+		}
+	}
+
+	// Asking for more than available returns everything
+	if all := sampleResults(results, 500, 1); len(all) != len(results) {
+		t.Errorf("Expected %d samples, got %d", len(results), len(all))
+	}
+}
+
+func TestWinnerFor(t *testing.T) {
+	if winnerFor(5, 3) != "home" || winnerFor(2, 4) != "away" || winnerFor(3, 3) != "tie" {
+		t.Error("Unexpected winner mapping")
+	}
+}
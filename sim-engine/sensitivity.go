@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"sim-engine/simulation"
+)
+
+const (
+	// Five inputs run two perturbations each on top of the unperturbed check,
+	// so an analysis costs up to eleven times the per-scenario count
+	defaultSensitivitySimulations = 300
+	maxSensitivitySimulations     = 2000
+)
+
+// SensitivityResponse is tornado chart data for a finished run
+type SensitivityResponse struct {
+	RunID              string                          `json:"run_id"`
+	GameID             string                          `json:"game_id"`
+	HomeWinProbability float64                         `json:"home_win_probability"`
+	Analysis           *simulation.SensitivityAnalysis `json:"analysis"`
+}
+
+// simulationSensitivityHandler perturbs a finished run's key inputs and
+// reports which move the home win probability most. ?simulations= sets the
+// quick simulations per scenario.
+func (s *Server) simulationSensitivityHandler(w http.ResponseWriter, r *http.Request) {
+	runID := mux.Vars(r)["id"]
+
+	simulations := defaultSensitivitySimulations
+	if simStr := r.URL.Query().Get("simulations"); simStr != "" {
+		parsed, err := strconv.Atoi(simStr)
+		if err != nil || parsed < 100 || parsed > maxSensitivitySimulations {
+			http.Error(w, "simulations must be between 100 and 2000", http.StatusBadRequest)
+			return
+		}
+		simulations = parsed
+	}
+
+	run, err := s.loadExplainRun(r.Context(), runID)
+	if err != nil {
+		if errors.Is(err, errRunNotComparable) {
+			http.Error(w, "Simulation has no stored inputs to perturb; re-run it to enable sensitivity analysis", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+
+	analysis, err := s.simEngine.AnalyzeSensitivity(r.Context(), run.gameID, run.inputs, simulations)
+	if err != nil {
+		log.Printf("Failed to analyze sensitivity of run %s: %v", runID, err)
+		http.Error(w, "Failed to analyze simulation sensitivity", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, SensitivityResponse{
+		RunID:              runID,
+		GameID:             run.gameID,
+		HomeWinProbability: run.HomeWinProbability,
+		Analysis:           analysis,
+	})
+}
@@ -0,0 +1,431 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"sim-engine/models"
+)
+
+const (
+	// asOfConfigKey is the run config key that replays a game with rosters
+	// and stats as they stood on a past date
+	asOfConfigKey = "as_of"
+
+	// AsOfGameDate as the as_of value replays each game as of its own date,
+	// so a batch can backtest a whole season
+	AsOfGameDate = "game_date"
+
+	// asOfRosterDays is how far back appearances count toward a replayed
+	// roster, ending at the team's last game before the as-of date
+	asOfRosterDays = 30
+
+	// Season-to-date lines smaller than these are topped up with the
+	// previous season's, so early-season replays aren't all noise
+	asOfMinPlateAppearances = 100
+	asOfMinOuts             = 90
+)
+
+// Linear weights for rebuilding wOBA from box score counts
+const (
+	wobaWeightBB     = 0.69
+	wobaWeightSingle = 0.88
+	wobaWeightDouble = 1.25
+	wobaWeightTriple = 1.58
+	wobaWeightHR     = 2.03
+)
+
+// AsOfFromConfig reads the date a run replays its game as of: "YYYY-MM-DD",
+// or "game_date" for the game's own date. Only games before that date feed
+// the rosters and stats. The zero time means the run uses current data. A
+// date after gameDate would leak the game's own result and is rejected.
+func AsOfFromConfig(config map[string]interface{}, gameDate time.Time) (time.Time, error) {
+	raw, ok := config[asOfConfigKey]
+	if !ok || raw == nil {
+		return time.Time{}, nil
+	}
+
+	value, ok := raw.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s must be a date (YYYY-MM-DD) or %q", asOfConfigKey, AsOfGameDate)
+	}
+	gameDay := time.Date(gameDate.Year(), gameDate.Month(), gameDate.Day(), 0, 0, 0, 0, time.UTC)
+	if value == AsOfGameDate {
+		return gameDay, nil
+	}
+
+	asOf, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date (YYYY-MM-DD) or %q", asOfConfigKey, AsOfGameDate)
+	}
+	if asOf.After(gameDay) {
+		return time.Time{}, fmt.Errorf("%s must not be after the game date", asOfConfigKey)
+	}
+	return asOf, nil
+}
+
+// battingTotals are a player's counting stats summed from box scores
+type battingTotals struct {
+	AB, H, Doubles, Triples, HR, BB, SO, RBI, SB, CS int
+}
+
+func (t *battingTotals) add(other battingTotals) {
+	t.AB += other.AB
+	t.H += other.H
+	t.Doubles += other.Doubles
+	t.Triples += other.Triples
+	t.HR += other.HR
+	t.BB += other.BB
+	t.SO += other.SO
+	t.RBI += other.RBI
+	t.SB += other.SB
+	t.CS += other.CS
+}
+
+// plateAppearances is at-bats plus walks; box scores don't carry HBP or
+// sacrifices
+func (t battingTotals) plateAppearances() int {
+	return t.AB + t.BB
+}
+
+// stats converts the totals into the keys season aggregates use
+func (t battingTotals) stats(league *models.LeagueEnvironment) map[string]interface{} {
+	pa := t.plateAppearances()
+	stats := map[string]interface{}{
+		"PA": pa, "AB": t.AB, "H": t.H, "2B": t.Doubles, "3B": t.Triples, "HR": t.HR,
+		"RBI": t.RBI, "SB": t.SB, "CS": t.CS,
+	}
+	if t.AB == 0 || pa == 0 {
+		return stats
+	}
+
+	singles := t.H - t.Doubles - t.Triples - t.HR
+	totalBases := singles + 2*t.Doubles + 3*t.Triples + 4*t.HR
+	avg := float64(t.H) / float64(t.AB)
+	slg := float64(totalBases) / float64(t.AB)
+	woba := (wobaWeightBB*float64(t.BB) + wobaWeightSingle*float64(singles) + wobaWeightDouble*float64(t.Doubles) +
+		wobaWeightTriple*float64(t.Triples) + wobaWeightHR*float64(t.HR)) / float64(pa)
+
+	stats["AVG"] = avg
+	stats["OBP"] = float64(t.H+t.BB) / float64(pa)
+	stats["SLG"] = slg
+	stats["ISO"] = slg - avg
+	stats["wOBA"] = woba
+	stats["BB%"] = 100 * float64(t.BB) / float64(pa)
+	stats["K%"] = 100 * float64(t.SO) / float64(pa)
+	if inPlay := t.AB - t.SO - t.HR; inPlay > 0 {
+		stats["BABIP"] = float64(t.H-t.HR) / float64(inPlay)
+	}
+	if league.RunsPerPA > 0 && league.WOBAScale > 0 {
+		wrcPlus := 100 * ((woba-league.WOBA)/league.WOBAScale + league.RunsPerPA) / league.RunsPerPA
+		stats["wRC+"] = int(math.Round(wrcPlus))
+	}
+	return stats
+}
+
+// pitchingTotals are a pitcher's counting stats summed from box scores
+type pitchingTotals struct {
+	Outs, H, ER, BB, SO, HR, W, L, SV int
+}
+
+func (t *pitchingTotals) add(other pitchingTotals) {
+	t.Outs += other.Outs
+	t.H += other.H
+	t.ER += other.ER
+	t.BB += other.BB
+	t.SO += other.SO
+	t.HR += other.HR
+	t.W += other.W
+	t.L += other.L
+	t.SV += other.SV
+}
+
+// stats converts the totals into the keys season aggregates use
+func (t pitchingTotals) stats(league *models.LeagueEnvironment) map[string]interface{} {
+	ip := float64(t.Outs) / 3
+	stats := map[string]interface{}{
+		"IP": ip, "H": t.H, "ER": t.ER, "BB": t.BB, "SO": t.SO, "HR": t.HR,
+		"W": t.W, "L": t.L, "SV": t.SV,
+	}
+	if t.Outs == 0 {
+		return stats
+	}
+
+	stats["ERA"] = 9 * float64(t.ER) / ip
+	stats["WHIP"] = float64(t.BB+t.H) / ip
+	stats["K/9"] = 9 * float64(t.SO) / ip
+	stats["BB/9"] = 9 * float64(t.BB) / ip
+	stats["HR/9"] = 9 * float64(t.HR) / ip
+	stats["FIP"] = league.CalculateFIP(t.HR, t.BB, 0, t.SO, ip)
+	if t.BB > 0 {
+		stats["K/BB"] = float64(t.SO) / float64(t.BB)
+	}
+	return stats
+}
+
+// loadTeamRostersAsOf rebuilds both teams' rosters as they stood on asOf.
+// Replayed rosters bypass the roster cache.
+func (se *SimulationEngine) loadTeamRostersAsOf(ctx context.Context, homeTeamID, awayTeamID string, asOf time.Time,
+	league *models.LeagueEnvironment) (*models.Roster, *models.Roster, error) {
+	homeRoster, err := se.loadTeamRosterAsOf(ctx, homeTeamID, asOf, league)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load home roster as of %s: %w", asOf.Format("2006-01-02"), err)
+	}
+
+	awayRoster, err := se.loadTeamRosterAsOf(ctx, awayTeamID, asOf, league)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load away roster as of %s: %w", asOf.Format("2006-01-02"), err)
+	}
+
+	return homeRoster, awayRoster, nil
+}
+
+// loadTeamRosterAsOf reconstructs a team's roster from its box scores: the
+// players who appeared in the asOfRosterDays before its last game before
+// asOf. Anyone who pitched is a pitcher; everyone else plays the position
+// they were listed at most.
+func (se *SimulationEngine) loadTeamRosterAsOf(ctx context.Context, teamID string, asOf time.Time,
+	league *models.LeagueEnvironment) (*models.Roster, error) {
+	rows, err := se.db.Query(ctx, `
+		WITH last_game AS (
+			SELECT MAX(g.game_date::date) AS game_day
+			FROM games g
+			WHERE (g.home_team_id = $1 OR g.away_team_id = $1)
+			  AND g.game_date::date < $2::date
+			  AND EXISTS (SELECT 1 FROM game_box_score_batting b WHERE b.game_id = g.id)
+		),
+		appearances AS (
+			SELECT b.player_id, b.position, FALSE AS pitched, g.game_date::date AS game_day
+			FROM game_box_score_batting b
+			JOIN games g ON g.id = b.game_id, last_game lg
+			WHERE b.team_id = $1
+			  AND g.game_date::date > lg.game_day - $3::int
+			  AND g.game_date::date <= lg.game_day
+			UNION ALL
+			SELECT bp.player_id, 'P', TRUE, g.game_date::date
+			FROM game_box_score_pitching bp
+			JOIN games g ON g.id = bp.game_id, last_game lg
+			WHERE bp.team_id = $1
+			  AND g.game_date::date > lg.game_day - $3::int
+			  AND g.game_date::date <= lg.game_day
+		),
+		positions AS (
+			SELECT player_id,
+			       CASE WHEN BOOL_OR(pitched) THEN 'P'
+			            ELSE MODE() WITHIN GROUP (ORDER BY position)
+			                 FILTER (WHERE position NOT IN ('PH', 'PR'))
+			       END AS position,
+			       MAX(game_day) FILTER (WHERE pitched) AS last_pitched
+			FROM appearances
+			GROUP BY player_id
+		)
+		SELECT p.id, p.first_name, p.last_name, COALESCE(pos.position, p.position),
+		       p.bats, p.throws, p.birth_date, pos.last_pitched
+		FROM positions pos
+		JOIN players p ON p.id = pos.player_id
+		ORDER BY 4, p.last_name
+	`, teamID, asOf, asOfRosterDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query players: %w", err)
+	}
+	defer rows.Close()
+
+	var players []models.Player
+	lastPitched := make(map[string]time.Time)
+	for rows.Next() {
+		var player models.Player
+		var birthDate, pitched *time.Time
+		var firstName, lastName string
+
+		if err := rows.Scan(&player.ID, &firstName, &lastName, &player.Position,
+			&player.Hand, &player.Hand, &birthDate, &pitched); err != nil {
+			log.Printf("Error scanning player: %v", err)
+			continue
+		}
+
+		player.Name = fmt.Sprintf("%s %s", firstName, lastName)
+		player.TeamID = teamID
+		if pitched != nil {
+			lastPitched[player.ID] = *pitched
+		}
+
+		// Players are the age they were on the replayed date
+		if birthDate != nil {
+			player.Attributes.Age = int(asOf.Sub(*birthDate).Hours() / 24 / 365.25)
+		} else {
+			player.Attributes.Age = 27
+		}
+
+		players = append(players, player)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read players: %w", err)
+	}
+	if len(players) == 0 {
+		return nil, fmt.Errorf("no box score appearances for team %s before %s", teamID, asOf.Format("2006-01-02"))
+	}
+
+	if err := se.loadPlayerStatisticsAsOf(ctx, players, asOf, league); err != nil {
+		log.Printf("Warning: failed to load player statistics as of %s: %v", asOf.Format("2006-01-02"), err)
+		se.setDefaultStatistics(players, league)
+	}
+
+	roster := &models.Roster{
+		TeamID:  teamID,
+		Players: players,
+	}
+	se.generateLineups(roster)
+	orderRotationByRest(roster, lastPitched)
+	se.fillRosterGaps(roster, league)
+
+	return roster, nil
+}
+
+// orderRotationByRest puts the rotation in turn order as of the replayed
+// date: whoever pitched longest ago starts, and pitchers with no appearance
+// in the window go first. Ties keep the rotation's FIP order.
+func orderRotationByRest(roster *models.Roster, lastPitched map[string]time.Time) {
+	sort.SliceStable(roster.Rotation, func(i, j int) bool {
+		return lastPitched[roster.Rotation[i]].Before(lastPitched[roster.Rotation[j]])
+	})
+}
+
+// loadPlayerStatisticsAsOf rebuilds players' batting and pitching lines from
+// box scores of games before asOf. Fielding comes from the last completed
+// season's aggregates, which can't include anything after asOf.
+func (se *SimulationEngine) loadPlayerStatisticsAsOf(ctx context.Context, players []models.Player, asOf time.Time,
+	league *models.LeagueEnvironment) error {
+	playerIDs := make([]string, len(players))
+	for i, player := range players {
+		playerIDs[i] = player.ID
+	}
+	season := asOf.Year()
+
+	currentBatting, previousBatting, err := se.loadBattingTotalsAsOf(ctx, playerIDs, asOf, season)
+	if err != nil {
+		return err
+	}
+	currentPitching, previousPitching, err := se.loadPitchingTotalsAsOf(ctx, playerIDs, asOf, season)
+	if err != nil {
+		return err
+	}
+	fieldingStats, err := se.loadSeasonAggregates(ctx, playerIDs, season-1, "fielding")
+	if err != nil {
+		return err
+	}
+
+	// Small season-to-date lines are topped up with last season's
+	for playerID, prior := range previousBatting {
+		line := currentBatting[playerID]
+		if line.plateAppearances() < asOfMinPlateAppearances {
+			line.add(prior)
+			currentBatting[playerID] = line
+		}
+	}
+	for playerID, prior := range previousPitching {
+		line := currentPitching[playerID]
+		if line.Outs < asOfMinOuts {
+			line.add(prior)
+			currentPitching[playerID] = line
+		}
+	}
+
+	battingStats := make(map[string]map[string]interface{}, len(currentBatting))
+	for playerID, line := range currentBatting {
+		battingStats[playerID] = line.stats(league)
+	}
+	pitchingStats := make(map[string]map[string]interface{}, len(currentPitching))
+	for playerID, line := range currentPitching {
+		pitchingStats[playerID] = line.stats(league)
+	}
+
+	se.applyPlayerStatistics(players, battingStats, pitchingStats, fieldingStats, league)
+	return nil
+}
+
+// loadBattingTotalsAsOf sums players' batting box scores before asOf for
+// season and the season before it
+func (se *SimulationEngine) loadBattingTotalsAsOf(ctx context.Context, playerIDs []string, asOf time.Time,
+	season int) (current, previous map[string]battingTotals, err error) {
+	rows, err := se.db.Query(ctx, `
+		SELECT b.player_id, g.season,
+		       SUM(b.at_bats)::int, SUM(b.hits)::int, SUM(b.doubles)::int, SUM(b.triples)::int,
+		       SUM(b.home_runs)::int, SUM(b.walks)::int, SUM(b.strikeouts)::int, SUM(b.rbis)::int,
+		       SUM(b.stolen_bases)::int, SUM(b.caught_stealing)::int
+		FROM game_box_score_batting b
+		JOIN games g ON g.id = b.game_id
+		WHERE b.player_id = ANY($1)
+		  AND g.game_date::date < $2::date
+		  AND g.season IN ($3, $3 - 1)
+		GROUP BY b.player_id, g.season
+	`, playerIDs, asOf, season)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query batting box scores: %w", err)
+	}
+	defer rows.Close()
+
+	current = make(map[string]battingTotals)
+	previous = make(map[string]battingTotals)
+	for rows.Next() {
+		var playerID string
+		var rowSeason int
+		var t battingTotals
+		if err := rows.Scan(&playerID, &rowSeason, &t.AB, &t.H, &t.Doubles, &t.Triples,
+			&t.HR, &t.BB, &t.SO, &t.RBI, &t.SB, &t.CS); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan batting box scores: %w", err)
+		}
+		if rowSeason == season {
+			current[playerID] = t
+		} else {
+			previous[playerID] = t
+		}
+	}
+	return current, previous, rows.Err()
+}
+
+// loadPitchingTotalsAsOf sums players' pitching box scores before asOf for
+// season and the season before it. Innings are stored as whole innings plus
+// outs after the decimal point (6.2 is 20 outs).
+func (se *SimulationEngine) loadPitchingTotalsAsOf(ctx context.Context, playerIDs []string, asOf time.Time,
+	season int) (current, previous map[string]pitchingTotals, err error) {
+	rows, err := se.db.Query(ctx, `
+		SELECT bp.player_id, g.season,
+		       SUM(FLOOR(bp.innings_pitched) * 3 + ROUND((bp.innings_pitched - FLOOR(bp.innings_pitched)) * 10))::int,
+		       SUM(bp.hits_allowed)::int, SUM(bp.earned_runs)::int, SUM(bp.walks_allowed)::int,
+		       SUM(bp.strikeouts)::int, SUM(bp.home_runs_allowed)::int,
+		       COUNT(*) FILTER (WHERE bp.win)::int, COUNT(*) FILTER (WHERE bp.loss)::int,
+		       COUNT(*) FILTER (WHERE bp.save)::int
+		FROM game_box_score_pitching bp
+		JOIN games g ON g.id = bp.game_id
+		WHERE bp.player_id = ANY($1)
+		  AND g.game_date::date < $2::date
+		  AND g.season IN ($3, $3 - 1)
+		GROUP BY bp.player_id, g.season
+	`, playerIDs, asOf, season)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query pitching box scores: %w", err)
+	}
+	defer rows.Close()
+
+	current = make(map[string]pitchingTotals)
+	previous = make(map[string]pitchingTotals)
+	for rows.Next() {
+		var playerID string
+		var rowSeason int
+		var t pitchingTotals
+		if err := rows.Scan(&playerID, &rowSeason, &t.Outs, &t.H, &t.ER, &t.BB,
+			&t.SO, &t.HR, &t.W, &t.L, &t.SV); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan pitching box scores: %w", err)
+		}
+		if rowSeason == season {
+			current[playerID] = t
+		} else {
+			previous[playerID] = t
+		}
+	}
+	return current, previous, rows.Err()
+}
@@ -0,0 +1,113 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"sim-engine/models"
+)
+
+func TestAsOfFromConfig(t *testing.T) {
+	gameDate := time.Date(2024, 6, 15, 19, 10, 0, 0, time.UTC)
+	day := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	if asOf, err := AsOfFromConfig(nil, gameDate); err != nil || !asOf.IsZero() {
+		t.Errorf("Expected current data without config, got %v (%v)", asOf, err)
+	}
+
+	asOf, err := AsOfFromConfig(map[string]interface{}{"as_of": "2024-05-01"}, gameDate)
+	if err != nil || !asOf.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2024-05-01, got %v (%v)", asOf, err)
+	}
+
+	if asOf, err := AsOfFromConfig(map[string]interface{}{"as_of": "2024-06-15"}, gameDate); err != nil || !asOf.Equal(day) {
+		t.Errorf("Expected the game's own date to be allowed, got %v (%v)", asOf, err)
+	}
+
+	if asOf, err := AsOfFromConfig(map[string]interface{}{"as_of": AsOfGameDate}, gameDate); err != nil || !asOf.Equal(day) {
+		t.Errorf("Expected game_date to replay from the game's day, got %v (%v)", asOf, err)
+	}
+
+	for _, bad := range []interface{}{"2024-06-16", "06/01/2024", "", 20240601.0} {
+		if _, err := AsOfFromConfig(map[string]interface{}{"as_of": bad}, gameDate); err == nil {
+			t.Errorf("Expected %v to be rejected", bad)
+		}
+	}
+}
+
+func TestBattingTotalsStats(t *testing.T) {
+	league := models.DefaultLeagueEnvironment()
+	totals := battingTotals{AB: 400, H: 100, Doubles: 20, Triples: 2, HR: 18, BB: 50, SO: 90, RBI: 60, SB: 8, CS: 3}
+	stats := totals.stats(league)
+
+	if stats["PA"] != 450 {
+		t.Errorf("Expected 450 PA from at-bats plus walks, got %v", stats["PA"])
+	}
+	checks := map[string]float64{
+		"AVG":   0.250,
+		"OBP":   150.0 / 450,
+		"SLG":   178.0 / 400,
+		"ISO":   78.0 / 400,
+		"K%":    20.0,
+		"BABIP": 82.0 / 292,
+	}
+	for key, want := range checks {
+		if got := stats[key].(float64); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Expected %s %.4f, got %.4f", key, want, got)
+		}
+	}
+
+	// A league-average wOBA is a 100 wRC+
+	woba := stats["wOBA"].(float64)
+	league.WOBA = woba
+	if got := totals.stats(league)["wRC+"]; got != 100 {
+		t.Errorf("Expected league-average wOBA to give 100 wRC+, got %v", got)
+	}
+
+	// Players without at-bats keep the defaults for rate stats
+	if _, ok := (battingTotals{BB: 1}).stats(league)["AVG"]; ok {
+		t.Error("Expected no AVG without at-bats")
+	}
+}
+
+func TestPitchingTotalsStats(t *testing.T) {
+	league := models.DefaultLeagueEnvironment()
+	totals := pitchingTotals{Outs: 540, H: 160, ER: 70, BB: 50, SO: 180, HR: 20, W: 12, L: 8}
+	stats := totals.stats(league)
+
+	if stats["IP"] != 180.0 {
+		t.Errorf("Expected 180 IP from 540 outs, got %v", stats["IP"])
+	}
+	if got := stats["ERA"].(float64); math.Abs(got-3.50) > 1e-9 {
+		t.Errorf("Expected 3.50 ERA, got %.3f", got)
+	}
+	if got := stats["WHIP"].(float64); math.Abs(got-210.0/180) > 1e-9 {
+		t.Errorf("Expected WHIP %.3f, got %.3f", 210.0/180, got)
+	}
+	wantFIP := float64(13*20+3*50-2*180)/180 + league.FIPConstant
+	if got := stats["FIP"].(float64); math.Abs(got-wantFIP) > 1e-9 {
+		t.Errorf("Expected FIP %.3f, got %.3f", wantFIP, got)
+	}
+
+	if _, ok := (pitchingTotals{}).stats(league)["ERA"]; ok {
+		t.Error("Expected no ERA without outs")
+	}
+}
+
+func TestOrderRotationByRest(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 6, d, 0, 0, 0, 0, time.UTC) }
+	roster := &models.Roster{Rotation: []string{"ace", "two", "three", "four", "five"}}
+
+	// The ace started yesterday; "four" hasn't pitched in the window
+	orderRotationByRest(roster, map[string]time.Time{
+		"ace": day(14), "two": day(10), "three": day(11), "five": day(13),
+	})
+
+	want := []string{"four", "two", "three", "five", "ace"}
+	for i, id := range want {
+		if roster.Rotation[i] != id {
+			t.Fatalf("rotation = %v, want %v", roster.Rotation, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package simulation
+
+import "sim-engine/models"
+
+// playProbabilityConfigKey is the run config key holding each questionable
+// player's chance of playing, keyed by player ID
+const playProbabilityConfigKey = "play_probability"
+
+// PlayProbabilityFromConfig reads questionable players' chances of playing
+// from a run's config, e.g. {"play_probability": {"660271": 0.7}} for a
+// day-to-day player 70% likely to start. Players not listed always play.
+func PlayProbabilityFromConfig(config map[string]interface{}) (map[string]float64, error) {
+	return playerProbabilitiesFromConfig(config, playProbabilityConfigKey)
+}
+
+// sidelinePlayers rolls whether each of the roster's questionable players
+// plays this game, returning the roster without those who sit and their IDs.
+// A sidelined hitter's lineup spot is filled from the bench and a sidelined
+// starter's turn goes to the next in the rotation. A team's last pitcher
+// always plays. roll returns a value in [0, 1).
+func sidelinePlayers(roster *models.Roster, playProbability map[string]float64, roll func() float64) (*models.Roster, []string) {
+	if len(playProbability) == 0 {
+		return roster, nil
+	}
+
+	out := make(map[string]bool)
+	var sidelined []string
+	pitchers := 0
+	for _, player := range roster.Players {
+		if player.Position == "P" {
+			pitchers++
+		}
+	}
+	for _, player := range roster.Players {
+		probability, ok := playProbability[player.ID]
+		if !ok || roll() < probability {
+			continue
+		}
+		if player.Position == "P" {
+			if pitchers == 1 {
+				continue
+			}
+			pitchers--
+		}
+		out[player.ID] = true
+		sidelined = append(sidelined, player.ID)
+	}
+	if len(sidelined) == 0 {
+		return roster, nil
+	}
+
+	without := *roster
+	without.Players = make([]models.Player, 0, len(roster.Players))
+	for _, player := range roster.Players {
+		if !out[player.ID] {
+			without.Players = append(without.Players, player)
+		}
+	}
+	without.Lineup = withoutIDs(roster.Lineup, out)
+	without.Rotation = withoutIDs(roster.Rotation, out)
+	without.Bullpen = withoutIDs(roster.Bullpen, out)
+	return &without, sidelined
+}
+
+func withoutIDs(ids []string, out map[string]bool) []string {
+	kept := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !out[id] {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
+// availabilityImpacts reports how each questionable player on either roster
+// moved the run's home win probability. Players on neither roster are left
+// out.
+func availabilityImpacts(playProbability map[string]float64, homeRoster, awayRoster *models.Roster,
+	results []models.SimulationResult) []models.AvailabilityImpact {
+	var impacts []models.AvailabilityImpact
+	for _, side := range []struct {
+		team   string
+		roster *models.Roster
+	}{{"home", homeRoster}, {"away", awayRoster}} {
+		for _, player := range side.roster.Players {
+			if probability, ok := playProbability[player.ID]; ok {
+				impacts = append(impacts, models.NewAvailabilityImpact(player.ID, player.Name, side.team, probability, results))
+			}
+		}
+	}
+	models.SortAvailabilityImpacts(impacts)
+	return impacts
+}
@@ -0,0 +1,84 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestPlayProbabilityFromConfig(t *testing.T) {
+	probabilities, err := PlayProbabilityFromConfig(map[string]interface{}{
+		playProbabilityConfigKey: map[string]interface{}{"b1": 0.7},
+	})
+	if err != nil || probabilities["b1"] != 0.7 {
+		t.Errorf("got %v, %v; want b1 at 0.7", probabilities, err)
+	}
+	if probabilities, err := PlayProbabilityFromConfig(nil); err != nil || probabilities != nil {
+		t.Errorf("a missing key should read as nil, got %v, %v", probabilities, err)
+	}
+	if _, err := PlayProbabilityFromConfig(map[string]interface{}{
+		playProbabilityConfigKey: map[string]interface{}{"b1": 70.0},
+	}); err == nil {
+		t.Error("probabilities above 1 should be rejected")
+	}
+}
+
+func TestSidelinePlayers(t *testing.T) {
+	roster := &models.Roster{
+		Players: []models.Player{
+			{ID: "b1", Position: "SS"}, {ID: "b2", Position: "CF"},
+			{ID: "sp1", Position: "P"}, {ID: "sp2", Position: "P"},
+		},
+		Lineup:   []string{"b1", "b2"},
+		Rotation: []string{"sp1", "sp2"},
+	}
+	rolls := func(values ...float64) func() float64 {
+		return func() float64 {
+			value := values[0]
+			values = values[1:]
+			return value
+		}
+	}
+	probabilities := map[string]float64{"b1": 0.7, "sp1": 0.5}
+
+	// b1 rolls under 0.7 and plays; sp1 rolls over 0.5 and sits
+	without, sidelined := sidelinePlayers(roster, probabilities, rolls(0.6, 0.9))
+	if len(sidelined) != 1 || sidelined[0] != "sp1" {
+		t.Fatalf("sidelined = %v, want sp1", sidelined)
+	}
+	if len(without.Players) != 3 || without.Rotation[0] != "sp2" || len(without.Lineup) != 2 {
+		t.Errorf("sp1 should be off the roster with sp2 starting, got %+v", without)
+	}
+	if len(roster.Rotation) != 2 {
+		t.Error("the original roster should be unchanged")
+	}
+
+	// A team's last pitcher always plays
+	single := &models.Roster{Players: []models.Player{{ID: "sp1", Position: "P"}}, Rotation: []string{"sp1"}}
+	if _, sidelined := sidelinePlayers(single, probabilities, rolls(0.99)); len(sidelined) != 0 {
+		t.Errorf("sidelined = %v, want the only pitcher kept", sidelined)
+	}
+
+	if same, sidelined := sidelinePlayers(roster, nil, nil); same != roster || sidelined != nil {
+		t.Error("without play probabilities the roster is used as is")
+	}
+}
+
+func TestAvailabilityImpacts(t *testing.T) {
+	home := &models.Roster{Players: []models.Player{{ID: "b1", Name: "Home Hitter"}}}
+	away := &models.Roster{Players: []models.Player{{ID: "a1", Name: "Away Ace"}}}
+	results := []models.SimulationResult{
+		{Winner: "home"}, {Winner: "home"}, {Winner: "away", Sidelined: []string{"b1"}}, {Winner: "home", Sidelined: []string{"a1"}},
+	}
+
+	impacts := availabilityImpacts(map[string]float64{"b1": 0.7, "a1": 0.9, "gone": 0.5}, home, away, results)
+	if len(impacts) != 2 {
+		t.Fatalf("got %d impacts, want one per rostered player", len(impacts))
+	}
+	if impacts[0].PlayerID != "b1" || impacts[0].Team != "home" || impacts[0].PlayerName != "Home Hitter" {
+		t.Errorf("largest swing first, got %+v", impacts[0])
+	}
+	if impacts[1].Team != "away" {
+		t.Errorf("a1 is on the away roster, got %q", impacts[1].Team)
+	}
+}
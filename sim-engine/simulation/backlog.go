@@ -0,0 +1,97 @@
+package simulation
+
+import (
+	"errors"
+	"time"
+)
+
+// RunStatusPending marks a run the engine has admitted but not yet started
+const RunStatusPending = "pending"
+
+// ErrQueueFull is returned when a run the engine starts itself finds the run
+// queue full
+var ErrQueueFull = errors.New("simulation run queue is full")
+
+// Backlog is the work the engine has accepted but not finished
+type Backlog struct {
+	Runs                 int     `json:"runs"`
+	RemainingSimulations int     `json:"remaining_simulations"`
+	EstimatedWaitSeconds float64 `json:"estimated_wait_seconds"`
+}
+
+// Backlog reports the engine's unfinished runs and how long they should take
+// at recent throughput
+func (se *SimulationEngine) Backlog() Backlog {
+	se.mu.RLock()
+	defer se.mu.RUnlock()
+	return se.backlogLocked()
+}
+
+// backlogLocked sums pending and running runs; se.mu must be held
+func (se *SimulationEngine) backlogLocked() Backlog {
+	var backlog Backlog
+	for _, status := range se.activeRuns {
+		if status.Status != RunStatusPending && status.Status != "running" {
+			continue
+		}
+		backlog.Runs++
+		if remaining := status.TotalRuns - status.CompletedRuns; remaining > 0 {
+			backlog.RemainingSimulations += remaining
+		}
+	}
+	if backlog.RemainingSimulations > 0 {
+		backlog.EstimatedWaitSeconds = se.EstimateCost(backlog.RemainingSimulations, 1).EstimatedWallSeconds
+	}
+	return backlog
+}
+
+// AdmitRun reserves a place for runID unless maxRuns runs are already
+// unfinished, returning the backlog the run joins or was turned away by. A
+// maxRuns of 0 admits every run. Reserving under the same lock as the count
+// keeps a burst of requests from all slipping in under the limit.
+func (se *SimulationEngine) AdmitRun(runID, gameID string, simulationRuns, maxRuns int) (Backlog, bool) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+
+	backlog := se.backlogLocked()
+	if maxRuns > 0 && backlog.Runs >= maxRuns {
+		return backlog, false
+	}
+
+	se.activeRuns[runID] = &RunStatus{
+		RunID:     runID,
+		GameID:    gameID,
+		TotalRuns: simulationRuns,
+		Status:    RunStatusPending,
+		StartTime: time.Now(),
+	}
+	return backlog, true
+}
+
+// ReleaseRun drops an admitted run that was never started
+func (se *SimulationEngine) ReleaseRun(runID string) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+
+	if status, exists := se.activeRuns[runID]; exists && status.Status == RunStatusPending {
+		delete(se.activeRuns, runID)
+	}
+}
+
+// SetMaxQueueDepth sets how many unfinished runs the engine allows before
+// turning away runs it would start itself, such as weather re-runs
+func (se *SimulationEngine) SetMaxQueueDepth(maxRuns int) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	se.maxQueueDepth = maxRuns
+}
+
+// admitOwnRun admits a run the engine starts itself against the configured
+// queue depth
+func (se *SimulationEngine) admitOwnRun(runID, gameID string, simulationRuns int) bool {
+	se.mu.RLock()
+	maxRuns := se.maxQueueDepth
+	se.mu.RUnlock()
+	_, ok := se.AdmitRun(runID, gameID, simulationRuns, maxRuns)
+	return ok
+}
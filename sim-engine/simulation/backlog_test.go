@@ -0,0 +1,60 @@
+package simulation
+
+import "testing"
+
+// TestAdmitRunQueueDepth tests runs are turned away once maxRuns are unfinished
+func TestAdmitRunQueueDepth(t *testing.T) {
+	se := NewSimulationEngine(nil, 4, 1000)
+
+	if _, ok := se.AdmitRun("run-1", "game-1", 1000, 2); !ok {
+		t.Fatal("Expected the first run to be admitted")
+	}
+	if backlog, ok := se.AdmitRun("run-2", "game-2", 500, 2); !ok || backlog.Runs != 1 {
+		t.Fatalf("Expected the second run to join a backlog of 1, got %+v admitted=%v", backlog, ok)
+	}
+
+	backlog, ok := se.AdmitRun("run-3", "game-3", 1000, 2)
+	if ok {
+		t.Fatal("Expected the third run to be turned away")
+	}
+	if backlog.Runs != 2 || backlog.RemainingSimulations != 1500 {
+		t.Errorf("Expected 2 runs and 1500 simulations queued, got %+v", backlog)
+	}
+	if backlog.EstimatedWaitSeconds <= 0 {
+		t.Errorf("Expected a positive wait, got %f", backlog.EstimatedWaitSeconds)
+	}
+
+	// Finished runs and released reservations free their place
+	se.activeRuns["run-1"].Status = "completed"
+	se.ReleaseRun("run-2")
+	if backlog, ok := se.AdmitRun("run-3", "game-3", 1000, 2); !ok || backlog.Runs != 0 {
+		t.Errorf("Expected room after runs finished, got %+v admitted=%v", backlog, ok)
+	}
+}
+
+// TestAdmitRunUnlimited tests a depth of 0 admits every run
+func TestAdmitRunUnlimited(t *testing.T) {
+	se := NewSimulationEngine(nil, 4, 1000)
+	for _, runID := range []string{"a", "b", "c"} {
+		if _, ok := se.AdmitRun(runID, "game", 1000, 0); !ok {
+			t.Fatalf("Expected run %s to be admitted", runID)
+		}
+	}
+	if got := se.Backlog().Runs; got != 3 {
+		t.Errorf("Expected 3 pending runs, got %d", got)
+	}
+}
+
+// TestAdmitOwnRunUsesQueueDepth tests runs the engine starts itself, like
+// weather re-runs, are held to the configured depth
+func TestAdmitOwnRunUsesQueueDepth(t *testing.T) {
+	se := NewSimulationEngine(nil, 4, 1000)
+	se.SetMaxQueueDepth(1)
+
+	if !se.admitOwnRun("rerun-1", "game-1", 1000) {
+		t.Fatal("Expected the first re-run to be admitted")
+	}
+	if se.admitOwnRun("rerun-2", "game-2", 1000) {
+		t.Error("Expected the second re-run to be turned away")
+	}
+}
@@ -0,0 +1,51 @@
+package simulation
+
+import "sim-engine/models"
+
+// runnerSpeeds maps each lineup player to their speed grade, for runners
+// on base
+func runnerSpeeds(lineups ...[]models.Player) map[string]int {
+	speeds := make(map[string]int)
+	for _, lineup := range lineups {
+		for _, player := range lineup {
+			speeds[player.ID] = player.Attributes.Speed
+		}
+	}
+	return speeds
+}
+
+// simulateStealAttempt rolls for the lead runner stealing the open base
+// ahead before the plate appearance: third from second, otherwise second
+// from first. roll returns a value in [0, 1). A successful steal moves the
+// runner up; a caught runner is removed and the caller records the out.
+func simulateStealAttempt(bases *models.BaseState, speeds map[string]int, pitcher, catcher *models.Player,
+	running *models.TeamBaserunning, roll func() float64) (attempted, caught bool) {
+
+	var runner **models.BaseRunner
+	var next **models.BaseRunner
+	targetBase := 0
+	switch {
+	case bases.Second != nil && bases.Third == nil:
+		runner, next, targetBase = &bases.Second, &bases.Third, 3
+	case bases.First != nil && bases.Second == nil:
+		runner, next, targetBase = &bases.First, &bases.Second, 2
+	default:
+		return false, false
+	}
+
+	speed := speeds[(*runner).PlayerID]
+	success := models.GetStealSuccessRate(speed, pitcher, catcher)
+	if roll() >= models.GetStealAttemptRate(speed, targetBase, success) {
+		return false, false
+	}
+
+	if roll() < success {
+		running.StolenBases++
+		*next = *runner
+	} else {
+		running.CaughtStealing++
+		caught = true
+	}
+	*runner = nil
+	return true, caught
+}
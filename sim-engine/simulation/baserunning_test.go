@@ -0,0 +1,83 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+// fixedRolls returns the given rolls in order
+func fixedRolls(rolls ...float64) func() float64 {
+	return func() float64 {
+		roll := rolls[0]
+		rolls = rolls[1:]
+		return roll
+	}
+}
+
+func TestSimulateStealAttempt(t *testing.T) {
+	speeds := map[string]int{"fast": 70}
+
+	// Nobody on, or every base ahead taken
+	var running models.TeamBaserunning
+	if attempted, _ := simulateStealAttempt(&models.BaseState{}, speeds, nil, nil, &running, fixedRolls()); attempted {
+		t.Error("Expected no attempt with the bases empty")
+	}
+	loaded := models.BaseState{First: &models.BaseRunner{}, Second: &models.BaseRunner{}, Third: &models.BaseRunner{}}
+	if attempted, _ := simulateStealAttempt(&loaded, speeds, nil, nil, &running, fixedRolls()); attempted {
+		t.Error("Expected no attempt with the bases loaded")
+	}
+
+	// A runner on first steals second
+	bases := models.BaseState{First: &models.BaseRunner{PlayerID: "fast"}}
+	attempted, caught := simulateStealAttempt(&bases, speeds, nil, nil, &running, fixedRolls(0, 0))
+	if !attempted || caught || bases.First != nil || bases.Second == nil || bases.Second.PlayerID != "fast" {
+		t.Errorf("Expected the runner to steal second, got %+v", bases)
+	}
+
+	// The lead runner tries for third and is caught
+	bases = models.BaseState{First: &models.BaseRunner{PlayerID: "trail"}, Second: &models.BaseRunner{PlayerID: "fast"}}
+	attempted, caught = simulateStealAttempt(&bases, speeds, nil, nil, &running, fixedRolls(0, 0.999))
+	if !attempted || !caught || bases.Second != nil || bases.Third != nil || bases.First == nil {
+		t.Errorf("Expected the lead runner thrown out at third, got %+v", bases)
+	}
+
+	if running.StolenBases != 1 || running.CaughtStealing != 1 {
+		t.Errorf("Expected one steal and one caught stealing, got %+v", running)
+	}
+
+	// A runner who doesn't go stays put
+	bases = models.BaseState{First: &models.BaseRunner{PlayerID: "fast"}}
+	if attempted, _ := simulateStealAttempt(&bases, speeds, nil, nil, &running, fixedRolls(0.999)); attempted || bases.First == nil {
+		t.Errorf("Expected no attempt, got %+v", bases)
+	}
+}
+
+// TestSimulatedStealRates checks league-average teams steal at about the
+// real league rates: roughly 0.9 attempts per team per game at 78% success
+func TestSimulatedStealRates(t *testing.T) {
+	se := &SimulationEngine{}
+	league := models.DefaultLeagueEnvironment()
+	gameData := neutralGameData(league, models.DefaultDurationModel(), models.CurrentTuningParameters())
+
+	roster := se.syntheticRoster(OpponentLeagueAverage, league)
+	for i := range roster.Players {
+		roster.Players[i].Attributes.Speed = 50
+	}
+
+	var totals models.TeamBaserunning
+	games := 1000
+	for i := 0; i < games; i++ {
+		result := se.simulateGame("", i+1, gameData, roster, roster, nil)
+		totals.Add(result.Baserunning.Home)
+		totals.Add(result.Baserunning.Away)
+	}
+
+	attempts := float64(totals.Attempts()) / float64(2*games)
+	if attempts < 0.6 || attempts > 1.3 {
+		t.Errorf("Expected about 0.9 steal attempts per team game, got %.2f", attempts)
+	}
+	if success := totals.SuccessRate(); success < 0.70 || success > 0.86 {
+		t.Errorf("Expected about %.2f steal success, got %.3f", models.LeagueStealSuccessRate, success)
+	}
+}
@@ -0,0 +1,166 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sim-engine/models"
+)
+
+// bullpenAvailabilityConfigKey is the run config key holding each tired
+// reliever's chance of being available, keyed by player ID
+const bullpenAvailabilityConfigKey = "bullpen_availability"
+
+// platoonChangesConfigKey is the run config key that turns on mid-inning
+// platoon pitching changes
+const platoonChangesConfigKey = "platoon_changes"
+
+// PlatoonChangesFromConfig reports whether a run makes mid-inning pitching
+// changes for the platoon matchup. They are off unless the config sets
+// platoon_changes to true.
+func PlatoonChangesFromConfig(config map[string]interface{}) bool {
+	enabled, _ := config[platoonChangesConfigKey].(bool)
+	return enabled
+}
+
+// platoonChangeSituation reports whether a manager would play matchups with
+// the next batter: late in a close, high-leverage game
+func platoonChangeSituation(gameState *models.GameState) bool {
+	return gameState.Inning >= models.PlatoonChangeInning &&
+		gameState.CalculateLeverage() > gameState.Tuning().HighLeverageThreshold
+}
+
+// upcomingBatters returns the next n batters due up, wrapping around the
+// order
+func upcomingBatters(lineup []models.Player, index, n int) []*models.Player {
+	if n > len(lineup) {
+		n = len(lineup)
+	}
+	batters := make([]*models.Player, 0, n)
+	for i := 0; i < n; i++ {
+		batters = append(batters, &lineup[(index+i)%len(lineup)])
+	}
+	return batters
+}
+
+// WithBullpenAvailability returns a copy of config carrying reliever
+// availability for a run
+func WithBullpenAvailability(config map[string]interface{}, availability map[string]float64) map[string]interface{} {
+	withAvailability := make(map[string]interface{}, len(config)+1)
+	for key, value := range config {
+		withAvailability[key] = value
+	}
+	withAvailability[bullpenAvailabilityConfigKey] = availability
+	return withAvailability
+}
+
+// BullpenAvailabilityFromConfig reads reliever availability from a run's
+// config. A missing key means every reliever is rested.
+func BullpenAvailabilityFromConfig(config map[string]interface{}) (map[string]float64, error) {
+	return playerProbabilitiesFromConfig(config, bullpenAvailabilityConfigKey)
+}
+
+// playerProbabilitiesFromConfig reads a config key mapping player IDs to
+// probabilities between 0 and 1. A missing key reads as nil.
+func playerProbabilitiesFromConfig(config map[string]interface{}, key string) (map[string]float64, error) {
+	raw, ok := config[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	// Config arrives decoded from JSON or built in-process
+	probabilities := make(map[string]float64)
+	switch values := raw.(type) {
+	case map[string]float64:
+		for playerID, value := range values {
+			probabilities[playerID] = value
+		}
+	case map[string]interface{}:
+		for playerID, value := range values {
+			number, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s.%s must be a number", key, playerID)
+			}
+			probabilities[playerID] = number
+		}
+	default:
+		return nil, fmt.Errorf("%s must map player IDs to probabilities", key)
+	}
+
+	for playerID, value := range probabilities {
+		if value < 0 || value > 1 {
+			return nil, fmt.Errorf("%s.%s must be between 0 and 1", key, playerID)
+		}
+	}
+	return probabilities, nil
+}
+
+// applyBullpenAvailability gives each roster the availability of its own
+// relievers
+func applyBullpenAvailability(availability map[string]float64, rosters ...*models.Roster) {
+	if len(availability) == 0 {
+		return
+	}
+	for _, roster := range rosters {
+		roster.BullpenAvailability = make(map[string]float64)
+		for _, playerID := range roster.Bullpen {
+			if value, ok := availability[playerID]; ok {
+				roster.BullpenAvailability[playerID] = value
+			}
+		}
+	}
+}
+
+// LoadActualReliefUsage returns every pitcher's real appearances in the days
+// before date, from box scores, keyed by player ID. Doubleheader outings on
+// the same day are combined.
+func (se *SimulationEngine) LoadActualReliefUsage(ctx context.Context, date time.Time, days int) (map[string][]models.ReliefUsage, error) {
+	rows, err := se.db.Query(ctx, `
+		SELECT p.player_id, ($1::date - g.game_date::date)::int AS days_before,
+		       SUM(COALESCE(bp.pitches_thrown, 0))::float8
+		FROM game_box_score_pitching bp
+		JOIN games g ON bp.game_id = g.id
+		JOIN players p ON bp.player_id = p.id
+		WHERE g.game_date::date >= $1::date - $2::int
+		  AND g.game_date::date < $1::date
+		GROUP BY p.player_id, g.game_date::date
+	`, date, days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relief usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := make(map[string][]models.ReliefUsage)
+	for rows.Next() {
+		var playerID string
+		var day models.ReliefUsage
+		if err := rows.Scan(&playerID, &day.DaysBefore, &day.Pitches); err != nil {
+			return nil, fmt.Errorf("failed to scan relief usage: %w", err)
+		}
+		day.AppearanceRate = 1
+		usage[playerID] = append(usage[playerID], day)
+	}
+	return usage, rows.Err()
+}
+
+// SimulatedReliefUsage is each pitcher's expected work in a completed run:
+// the share of simulations they appeared in and their pitches when they did
+func SimulatedReliefUsage(result *models.AggregatedResult) map[string]models.ReliefUsage {
+	usage := make(map[string]models.ReliefUsage)
+	if result == nil || result.PlayerPerformance == nil {
+		return usage
+	}
+	for _, team := range []models.TeamPerformance{result.PlayerPerformance.HomeTeam, result.PlayerPerformance.AwayTeam} {
+		for playerID, stats := range team.Pitching {
+			if stats.G <= 0 {
+				continue
+			}
+			usage[playerID] = models.ReliefUsage{
+				AppearanceRate: stats.G,
+				Pitches:        stats.Pitches / stats.G,
+			}
+		}
+	}
+	return usage
+}
@@ -0,0 +1,80 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestBullpenAvailabilityFromConfig(t *testing.T) {
+	config := WithBullpenAvailability(map[string]interface{}{"weather_effects": true}, map[string]float64{"rp1": 0.5})
+	if config["weather_effects"] != true {
+		t.Error("existing config keys should be kept")
+	}
+	availability, err := BullpenAvailabilityFromConfig(config)
+	if err != nil || availability["rp1"] != 0.5 {
+		t.Errorf("got %v, %v; want rp1 at 0.5", availability, err)
+	}
+
+	// Config stored as JSON decodes to interface maps
+	decoded := map[string]interface{}{bullpenAvailabilityConfigKey: map[string]interface{}{"rp1": 0.25}}
+	if availability, err := BullpenAvailabilityFromConfig(decoded); err != nil || availability["rp1"] != 0.25 {
+		t.Errorf("got %v, %v; want rp1 at 0.25", availability, err)
+	}
+
+	for _, bad := range []interface{}{"tired", map[string]interface{}{"rp1": "x"}, map[string]interface{}{"rp1": 1.5}} {
+		if _, err := BullpenAvailabilityFromConfig(map[string]interface{}{bullpenAvailabilityConfigKey: bad}); err == nil {
+			t.Errorf("expected %v to be rejected", bad)
+		}
+	}
+}
+
+func TestApplyBullpenAvailability(t *testing.T) {
+	home := &models.Roster{Bullpen: []string{"h1", "h2"}}
+	away := &models.Roster{Bullpen: []string{"a1"}}
+	applyBullpenAvailability(map[string]float64{"h1": 0.5, "a1": 0, "other": 0}, home, away)
+
+	if len(home.BullpenAvailability) != 1 || home.BullpenAvailability["h1"] != 0.5 {
+		t.Errorf("home availability = %v, want only h1", home.BullpenAvailability)
+	}
+	if away.ReliefAvailability("a1") != 0 || away.ReliefAvailability("a2") != 1 {
+		t.Errorf("away availability = %v", away.BullpenAvailability)
+	}
+}
+
+func TestSimulatedReliefUsage(t *testing.T) {
+	result := &models.AggregatedResult{PlayerPerformance: &models.AggregatedPlayerPerformance{
+		HomeTeam: models.TeamPerformance{Pitching: map[string]models.PlayerPitchingStats{
+			"rp1": {G: 0.4, Pitches: 6},
+			"sp":  {G: 1, Pitches: 95},
+		}},
+	}}
+
+	usage := SimulatedReliefUsage(result)
+	if got := usage["rp1"]; got.AppearanceRate != 0.4 || got.Pitches != 15 {
+		t.Errorf("rp1 usage = %+v, want 0.4 appearance rate and 15 pitches per appearance", got)
+	}
+	if len(SimulatedReliefUsage(&models.AggregatedResult{})) != 0 {
+		t.Error("a result without player performance has no usage")
+	}
+}
+
+func TestPlatoonChangesFromConfig(t *testing.T) {
+	if PlatoonChangesFromConfig(nil) || PlatoonChangesFromConfig(map[string]interface{}{"platoon_changes": "yes"}) {
+		t.Error("platoon changes should be off unless platoon_changes is true")
+	}
+	if !PlatoonChangesFromConfig(map[string]interface{}{"platoon_changes": true}) {
+		t.Error("platoon_changes: true should turn platoon changes on")
+	}
+}
+
+func TestUpcomingBatters(t *testing.T) {
+	lineup := []models.Player{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	batters := upcomingBatters(lineup, 2, models.MinBattersFaced)
+	if len(batters) != 3 || batters[0].ID != "c" || batters[1].ID != "d" || batters[2].ID != "a" {
+		t.Errorf("upcoming batters wrap around the order, got %v", batters)
+	}
+	if got := upcomingBatters(lineup[:2], 0, models.MinBattersFaced); len(got) != 2 {
+		t.Errorf("a short lineup has %d batters due up, want 2", len(got))
+	}
+}
@@ -0,0 +1,160 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrRunNotFound and ErrRunActive are returned by DeleteRun
+var (
+	ErrRunNotFound = errors.New("simulation run not found")
+	ErrRunActive   = errors.New("simulation run is still in progress")
+)
+
+// Runs in these states are never deleted; their workers still write to them
+var activeRunStatuses = []string{"pending", "running"}
+
+// isActiveRunStatus reports whether a run is still being simulated
+func isActiveRunStatus(status string) bool {
+	for _, active := range activeRunStatuses {
+		if status == active {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteRun deletes one finished run and everything stored for it
+func (se *SimulationEngine) DeleteRun(ctx context.Context, runID string) error {
+	var status string
+	err := se.db.QueryRow(ctx, `SELECT COALESCE(status, '') FROM simulation_runs WHERE id::text = $1`, runID).Scan(&status)
+	if err == pgx.ErrNoRows {
+		return ErrRunNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up run: %w", err)
+	}
+	if isActiveRunStatus(status) {
+		return ErrRunActive
+	}
+
+	deleted, err := se.deleteRuns(ctx, `id::text = $1`, runID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		// Finished or removed between the lookup and the delete
+		return ErrRunNotFound
+	}
+	return nil
+}
+
+// DeleteRunsBefore deletes every finished run created before the cutoff and
+// returns how many were deleted
+func (se *SimulationEngine) DeleteRunsBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	return se.deleteRuns(ctx, `created_at < $1`, cutoff)
+}
+
+// deleteRuns removes the finished runs matching a condition on
+// simulation_runs, with their results, aggregates and metadata, in one
+// transaction. Result objects are deleted once it commits.
+func (se *SimulationEngine) deleteRuns(ctx context.Context, condition string, arg interface{}) (int, error) {
+	tx, err := se.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin delete: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id::text, results_object FROM simulation_runs
+		WHERE `+condition+` AND COALESCE(status, '') <> ALL($2)
+		FOR UPDATE
+	`, arg, activeRunStatuses)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select runs: %w", err)
+	}
+	var runIDs, objectKeys []string
+	for rows.Next() {
+		var id string
+		var objectKey *string
+		if err := rows.Scan(&id, &objectKey); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan run: %w", err)
+		}
+		runIDs = append(runIDs, id)
+		if objectKey != nil {
+			objectKeys = append(objectKeys, *objectKey)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to select runs: %w", err)
+	}
+	if len(runIDs) == 0 {
+		return 0, nil
+	}
+
+	// simulation_metadata is created on first use, so it may not exist yet
+	tables := []string{"simulation_results", "simulation_events", "simulation_aggregates"}
+	var hasMetadata bool
+	if err := tx.QueryRow(ctx, `SELECT to_regclass('simulation_metadata') IS NOT NULL`).Scan(&hasMetadata); err != nil {
+		return 0, fmt.Errorf("failed to check for simulation_metadata: %w", err)
+	}
+	if hasMetadata {
+		tables = append(tables, "simulation_metadata")
+	}
+	for _, table := range tables {
+		if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE run_id = ANY($1::uuid[])`, runIDs); err != nil {
+			return 0, fmt.Errorf("failed to delete from %s: %w", table, err)
+		}
+	}
+	// Notes on a run have no foreign key to cascade from
+	if _, err := tx.Exec(ctx, `DELETE FROM notes WHERE entity_type = 'simulation_run' AND entity_id = ANY($1::uuid[])`, runIDs); err != nil {
+		return 0, fmt.Errorf("failed to delete notes: %w", err)
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM simulation_runs WHERE id = ANY($1::uuid[])`, runIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete runs: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit delete: %w", err)
+	}
+
+	se.mu.Lock()
+	for _, id := range runIDs {
+		delete(se.activeRuns, id)
+	}
+	se.mu.Unlock()
+
+	se.deleteResultObjects(ctx, objectKeys)
+
+	return int(tag.RowsAffected()), nil
+}
+
+// StartRunCleanup deletes finished runs older than ttl every interval. A ttl
+// of 0 keeps runs forever.
+func (se *SimulationEngine) StartRunCleanup(ttl, interval time.Duration) {
+	if ttl <= 0 || interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			deleted, err := se.DeleteRunsBefore(ctx, time.Now().Add(-ttl))
+			cancel()
+			if err != nil {
+				log.Printf("Simulation run cleanup failed: %v", err)
+			} else if deleted > 0 {
+				log.Printf("Deleted %d simulation runs older than %v", deleted, ttl)
+			}
+			<-ticker.C
+		}
+	}()
+}
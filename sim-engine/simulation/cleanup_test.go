@@ -0,0 +1,18 @@
+package simulation
+
+import "testing"
+
+func TestIsActiveRunStatus(t *testing.T) {
+	for status, want := range map[string]bool{
+		"pending":        true,
+		"running":        true,
+		"completed":      false,
+		RunStatusPartial: false,
+		"error":          false,
+		"":               false,
+	} {
+		if got := isActiveRunStatus(status); got != want {
+			t.Errorf("isActiveRunStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
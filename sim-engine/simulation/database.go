@@ -12,6 +12,13 @@ import (
 
 // updateRunStatus updates the simulation run status in the database
 func (se *SimulationEngine) updateRunStatus(runID, status string) {
+	// Keep the in-memory status in step so failed runs leave the backlog
+	se.mu.Lock()
+	if run, exists := se.activeRuns[runID]; exists {
+		run.Status = status
+	}
+	se.mu.Unlock()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -54,29 +61,49 @@ func (se *SimulationEngine) updateProgress(runID string) {
 	}
 }
 
-// storeSimulationResult stores an individual simulation result
-func (se *SimulationEngine) storeSimulationResult(ctx context.Context, result models.SimulationResult) error {
+// storeSimulationResult stores an individual simulation result and returns
+// the approximate number of bytes written
+func (se *SimulationEngine) storeSimulationResult(ctx context.Context, result models.SimulationResult) (int, error) {
 	keyEventsJSON, err := json.Marshal(result.KeyEvents)
 	if err != nil {
-		return fmt.Errorf("failed to marshal key events: %w", err)
+		return 0, fmt.Errorf("failed to marshal key events: %w", err)
 	}
 
 	finalStateJSON, err := json.Marshal(result.FinalState)
 	if err != nil {
-		return fmt.Errorf("failed to marshal final state: %w", err)
+		return 0, fmt.Errorf("failed to marshal final state: %w", err)
+	}
+
+	linescoreJSON, err := json.Marshal(result.FinalState.Linescore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal linescore: %w", err)
+	}
+
+	var firstFiveHome, firstFiveAway *int
+	if result.FirstFive != nil {
+		firstFiveHome, firstFiveAway = &result.FirstFive.HomeScore, &result.FirstFive.AwayScore
 	}
 
 	query := `
 		INSERT INTO simulation_results (
 			id, run_id, simulation_number, home_score, away_score, 
 			total_pitches, game_duration_minutes, key_events, 
-			final_state, created_at
+			final_state, linescore, first_five_home_score, first_five_away_score, created_at
 		) VALUES (
-			uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9
+			uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 		)
+		ON CONFLICT (run_id, simulation_number) DO NOTHING
 	`
 
-	_, err = se.db.Exec(ctx, query,
+	// The result and its archived events land together so a retried write
+	// never leaves a result without its events or events twice
+	tx, err := se.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin result write: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, query,
 		result.RunID,
 		result.SimulationNumber,
 		result.HomeScore,
@@ -85,14 +112,27 @@ func (se *SimulationEngine) storeSimulationResult(ctx context.Context, result mo
 		result.GameDuration,
 		keyEventsJSON,
 		finalStateJSON,
+		linescoreJSON,
+		firstFiveHome,
+		firstFiveAway,
 		result.CreatedAt,
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to store simulation result: %w", err)
+		return 0, fmt.Errorf("failed to store simulation result: %w", err)
+	}
+	// An earlier attempt whose commit went unacknowledged already stored it
+	if tag.RowsAffected() == 0 {
+		return 0, nil
+	}
+	if err := archiveLeverageEvents(ctx, tx, result); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit simulation result: %w", err)
 	}
 
-	return nil
+	return len(keyEventsJSON) + len(finalStateJSON) + len(linescoreJSON) + rowOverheadBytes, nil
 }
 
 // storeAggregatedResults stores the aggregated simulation results
@@ -122,9 +162,9 @@ func (se *SimulationEngine) storeAggregatedResults(ctx context.Context, result *
 			id, run_id, home_win_probability, away_win_probability,
 			expected_home_score, expected_away_score, 
 			home_score_distribution, away_score_distribution,
-			total_score_over_under, created_at
+			total_score_over_under, markets, inning_scoring, lineups, scenario_bands, availability_impact, created_at
 		) VALUES (
-			uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, NOW()
+			uuid_generate_v4(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW()
 		)
 		ON CONFLICT (run_id) DO UPDATE SET
 			home_win_probability = EXCLUDED.home_win_probability,
@@ -133,18 +173,67 @@ func (se *SimulationEngine) storeAggregatedResults(ctx context.Context, result *
 			expected_away_score = EXCLUDED.expected_away_score,
 			home_score_distribution = EXCLUDED.home_score_distribution,
 			away_score_distribution = EXCLUDED.away_score_distribution,
-			total_score_over_under = EXCLUDED.total_score_over_under
+			total_score_over_under = EXCLUDED.total_score_over_under,
+			markets = EXCLUDED.markets,
+			inning_scoring = EXCLUDED.inning_scoring,
+			lineups = EXCLUDED.lineups,
+			scenario_bands = EXCLUDED.scenario_bands,
+			availability_impact = EXCLUDED.availability_impact
 	`
 
-	// Calculate total score over/under probabilities
+	// Legacy over/under keys, kept for existing readers of the column
 	totalScoreOverUnder := make(map[string]interface{})
 	totalScoreOverUnder["average"] = result.ExpectedHomeScore + result.ExpectedAwayScore
-	totalScoreOverUnder["over_8_5"] = se.calculateOverUnderProbability(result, 8.5)
-	totalScoreOverUnder["over_9_5"] = se.calculateOverUnderProbability(result, 9.5)
-	totalScoreOverUnder["over_10_5"] = se.calculateOverUnderProbability(result, 10.5)
+	if result.Markets != nil {
+		for _, total := range result.Markets.Totals {
+			switch total.Line {
+			case 8.5:
+				totalScoreOverUnder["over_8_5"] = total.Over
+			case 9.5:
+				totalScoreOverUnder["over_9_5"] = total.Over
+			case 10.5:
+				totalScoreOverUnder["over_10_5"] = total.Over
+			}
+		}
+	}
 
 	totalScoreOverUnderJSON, _ := json.Marshal(totalScoreOverUnder)
 
+	var marketsJSON []byte
+	if result.Markets != nil {
+		if marketsJSON, err = json.Marshal(result.Markets); err != nil {
+			return fmt.Errorf("failed to marshal markets: %w", err)
+		}
+	}
+
+	var inningScoringJSON []byte
+	if result.InningScoring != nil {
+		if inningScoringJSON, err = json.Marshal(result.InningScoring); err != nil {
+			return fmt.Errorf("failed to marshal inning scoring: %w", err)
+		}
+	}
+
+	var lineupsJSON []byte
+	if result.Lineups != nil {
+		if lineupsJSON, err = json.Marshal(result.Lineups); err != nil {
+			return fmt.Errorf("failed to marshal lineups: %w", err)
+		}
+	}
+
+	var scenarioBandsJSON []byte
+	if result.ScenarioBands != nil {
+		if scenarioBandsJSON, err = json.Marshal(result.ScenarioBands); err != nil {
+			return fmt.Errorf("failed to marshal scenario bands: %w", err)
+		}
+	}
+
+	var availabilityImpactJSON []byte
+	if len(result.AvailabilityImpact) > 0 {
+		if availabilityImpactJSON, err = json.Marshal(result.AvailabilityImpact); err != nil {
+			return fmt.Errorf("failed to marshal availability impact: %w", err)
+		}
+	}
+
 	_, err = se.db.Exec(ctx, query,
 		result.RunID,
 		result.HomeWinProbability,
@@ -154,6 +243,11 @@ func (se *SimulationEngine) storeAggregatedResults(ctx context.Context, result *
 		homeScoreDistJSON,
 		awayScoreDistJSON,
 		totalScoreOverUnderJSON,
+		marketsJSON,
+		inningScoringJSON,
+		lineupsJSON,
+		scenarioBandsJSON,
+		availabilityImpactJSON,
 	)
 
 	if err != nil {
@@ -256,7 +350,13 @@ func (se *SimulationEngine) calculateAggregatedResults(runID string, results []m
 
 	var totalHomeScore, totalAwayScore float64
 	var totalDuration, totalPitches float64
-	var allHighLeverageEvents []models.GameEvent
+	var totalPitchingChanges, totalMidInningChanges float64
+	highLeverage := NewLeverageCollector(summaryLeverageEvents, HighLeverageThreshold)
+	var catcherTotals models.CatcherImpactSummary
+	var defenseTotals models.DefenseSummary
+	var baserunningTotals models.BaserunningSummary
+	markets := models.NewMarketTally()
+	innings := models.NewInningTally()
 
 	// Initialize player stat accumulators
 	homeBattingAccum := make(map[string]*models.PlayerBattingStats)
@@ -279,6 +379,8 @@ func (se *SimulationEngine) calculateAggregatedResults(runID string, results []m
 		// Score distributions
 		aggregated.HomeScoreDistribution[result.HomeScore]++
 		aggregated.AwayScoreDistribution[result.AwayScore]++
+		markets.Add(result)
+		innings.Add(result)
 
 		// Running totals
 		totalHomeScore += float64(result.HomeScore)
@@ -286,11 +388,28 @@ func (se *SimulationEngine) calculateAggregatedResults(runID string, results []m
 		totalDuration += float64(result.GameDuration)
 		totalPitches += float64(result.TotalPitches)
 
-		// Collect high leverage events
+		// Collect the highest leverage events
 		for _, event := range result.KeyEvents {
-			if event.Leverage > 2.0 { // Very high leverage
-				allHighLeverageEvents = append(allHighLeverageEvents, event)
-			}
+			highLeverage.Add(result.SimulationNumber, event)
+		}
+
+		if result.PitchingChanges != nil {
+			totalPitchingChanges += float64(result.PitchingChanges.Total())
+			totalMidInningChanges += float64(result.PitchingChanges.HomeMidInning + result.PitchingChanges.AwayMidInning)
+		}
+
+		// Catcher defense run impact
+		if result.CatcherImpact != nil {
+			addCatcherImpact(&catcherTotals.Home, result.CatcherImpact.Home)
+			addCatcherImpact(&catcherTotals.Away, result.CatcherImpact.Away)
+		}
+		if result.Defense != nil {
+			defenseTotals.Home.Add(result.Defense.Home)
+			defenseTotals.Away.Add(result.Defense.Away)
+		}
+		if result.Baserunning != nil {
+			baserunningTotals.Home.Add(result.Baserunning.Home)
+			baserunningTotals.Away.Add(result.Baserunning.Away)
 		}
 
 		// Aggregate player stats
@@ -313,21 +432,40 @@ func (se *SimulationEngine) calculateAggregatedResults(runID string, results []m
 	aggregated.ExpectedAwayScore = totalAwayScore / totalSims
 	aggregated.AverageGameDuration = totalDuration / totalSims
 	aggregated.AveragePitches = totalPitches / totalSims
+	aggregated.Markets = markets.Markets()
+	aggregated.InningScoring = innings.Distributions()
 
 	// Additional statistics
-	aggregated.Statistics["total_runs_average"] = aggregated.ExpectedHomeScore + aggregated.ExpectedAwayScore
-	aggregated.Statistics["score_variance"] = se.calculateScoreVariance(results, aggregated.ExpectedHomeScore, aggregated.ExpectedAwayScore)
-	aggregated.Statistics["blowout_percentage"] = se.calculateBlowoutPercentage(results)
-	aggregated.Statistics["one_run_game_percentage"] = se.calculateOneRunGamePercentage(results)
-	aggregated.Statistics["shutout_percentage"] = se.calculateShutoutPercentage(results)
-	aggregated.Statistics["high_scoring_percentage"] = se.calculateHighScoringPercentage(results)
-
-	// Limit high leverage events to most significant
-	if len(allHighLeverageEvents) > 50 {
-		// Sort by leverage and take top 50
-		allHighLeverageEvents = se.selectTopLeverageEvents(allHighLeverageEvents, 50)
+	aggregated.Statistics[models.StatTotalRunsAverage] = aggregated.ExpectedHomeScore + aggregated.ExpectedAwayScore
+	aggregated.Statistics[models.StatScoreVariance] = se.calculateScoreVariance(results, aggregated.ExpectedHomeScore, aggregated.ExpectedAwayScore)
+	aggregated.Statistics[models.StatBlowoutPercentage] = se.calculateBlowoutPercentage(results)
+	aggregated.Statistics[models.StatOneRunGamePercentage] = se.calculateOneRunGamePercentage(results)
+	aggregated.Statistics[models.StatShutoutPercentage] = se.calculateShutoutPercentage(results)
+	aggregated.Statistics[models.StatHighScoringPercent] = se.calculateHighScoringPercentage(results)
+	aggregated.Statistics[models.StatHomeCatcherFramingRuns] = catcherTotals.Home.FramingRuns / totalSims
+	aggregated.Statistics[models.StatAwayCatcherFramingRuns] = catcherTotals.Away.FramingRuns / totalSims
+	aggregated.Statistics[models.StatHomeCatcherBlockingRuns] = catcherTotals.Home.BlockingRuns / totalSims
+	aggregated.Statistics[models.StatAwayCatcherBlockingRuns] = catcherTotals.Away.BlockingRuns / totalSims
+	aggregated.Statistics[models.StatBatteryErrorsPerGame] = float64(catcherTotals.Home.WildPitches+catcherTotals.Home.PassedBalls+
+		catcherTotals.Away.WildPitches+catcherTotals.Away.PassedBalls) / totalSims
+	aggregated.Statistics[models.StatHomeErrorsPer9] = defenseTotals.Home.ErrorsPer9()
+	aggregated.Statistics[models.StatAwayErrorsPer9] = defenseTotals.Away.ErrorsPer9()
+	aggregated.Statistics[models.StatHomeDefensiveEfficiency] = defenseTotals.Home.DefensiveEfficiency()
+	aggregated.Statistics[models.StatAwayDefensiveEfficiency] = defenseTotals.Away.DefensiveEfficiency()
+	aggregated.Statistics[models.StatHomeStolenBasesPerGame] = float64(baserunningTotals.Home.StolenBases) / totalSims
+	aggregated.Statistics[models.StatAwayStolenBasesPerGame] = float64(baserunningTotals.Away.StolenBases) / totalSims
+	aggregated.Statistics[models.StatHomeCaughtStealingPerGame] = float64(baserunningTotals.Home.CaughtStealing) / totalSims
+	aggregated.Statistics[models.StatAwayCaughtStealingPerGame] = float64(baserunningTotals.Away.CaughtStealing) / totalSims
+	aggregated.Statistics[models.StatHomeStealSuccessRate] = baserunningTotals.Home.SuccessRate()
+	aggregated.Statistics[models.StatAwayStealSuccessRate] = baserunningTotals.Away.SuccessRate()
+	aggregated.Statistics[models.StatPitchingChangesPerGame] = totalPitchingChanges / totalSims
+	aggregated.Statistics[models.StatMidInningChangesPerGame] = totalMidInningChanges / totalSims
+
+	// Keep the most significant high leverage events
+	aggregated.HighLeverageEvents = []models.GameEvent{}
+	for _, event := range highLeverage.Events() {
+		aggregated.HighLeverageEvents = append(aggregated.HighLeverageEvents, event.GameEvent)
 	}
-	aggregated.HighLeverageEvents = allHighLeverageEvents
 
 	// Average player stats across all simulations
 	numSims := float64(len(results))
@@ -356,26 +494,12 @@ func (se *SimulationEngine) calculateAggregatedResults(runID string, results []m
 	return aggregated
 }
 
-// calculateOverUnderProbability calculates the probability of the total score going over a threshold
-func (se *SimulationEngine) calculateOverUnderProbability(result *models.AggregatedResult, threshold float64) float64 {
-	overCount := 0
-	totalCount := 0
-
-	for homeScore, homeCount := range result.HomeScoreDistribution {
-		for awayScore, awayCount := range result.AwayScoreDistribution {
-			totalScore := float64(homeScore + awayScore)
-			if totalScore > threshold {
-				overCount += homeCount * awayCount
-			}
-			totalCount += homeCount * awayCount
-		}
-	}
-
-	if totalCount == 0 {
-		return 0.0
-	}
-
-	return float64(overCount) / float64(totalCount)
+// addCatcherImpact accumulates one game's catcher impact into a running total
+func addCatcherImpact(total *models.CatcherImpact, game models.CatcherImpact) {
+	total.FramingRuns += game.FramingRuns
+	total.BlockingRuns += game.BlockingRuns
+	total.WildPitches += game.WildPitches
+	total.PassedBalls += game.PassedBalls
 }
 
 // calculateScoreVariance calculates the variance in total scoring
@@ -445,24 +569,6 @@ func (se *SimulationEngine) calculateHighScoringPercentage(results []models.Simu
 	return float64(highScoring) / float64(len(results)) * 100.0
 }
 
-// selectTopLeverageEvents selects the highest leverage events
-func (se *SimulationEngine) selectTopLeverageEvents(events []models.GameEvent, limit int) []models.GameEvent {
-	if len(events) <= limit {
-		return events
-	}
-
-	// Sort by leverage (descending)
-	for i := 0; i < len(events)-1; i++ {
-		for j := i + 1; j < len(events); j++ {
-			if events[i].Leverage < events[j].Leverage {
-				events[i], events[j] = events[j], events[i]
-			}
-		}
-	}
-
-	return events[:limit]
-}
-
 // GetRunStatus returns the current status of a simulation run
 func (se *SimulationEngine) GetRunStatus(runID string) (*RunStatus, bool) {
 	se.mu.RLock()
@@ -484,13 +590,13 @@ func (se *SimulationEngine) GetRunResult(ctx context.Context, runID string) (*mo
 
 	// Load from database
 	var result models.AggregatedResult
-	var homeScoreDist, awayScoreDist, totalScoreOverUnder []byte
+	var homeScoreDist, awayScoreDist, totalScoreOverUnder, marketsJSON, inningScoringJSON, lineupsJSON, scenarioBandsJSON, availabilityImpactJSON []byte
 
 	query := `
 		SELECT sa.run_id, sa.home_win_probability, sa.away_win_probability,
 		       sa.expected_home_score, sa.expected_away_score,
 		       sa.home_score_distribution, sa.away_score_distribution,
-		       sa.total_score_over_under,
+		       sa.total_score_over_under, sa.markets, sa.inning_scoring, sa.lineups, sa.scenario_bands, sa.availability_impact,
 		       COALESCE(sm.total_simulations, 0) as total_simulations,
 		       COALESCE(sm.home_wins, 0) as home_wins,
 		       COALESCE(sm.away_wins, 0) as away_wins,
@@ -516,6 +622,11 @@ func (se *SimulationEngine) GetRunResult(ctx context.Context, runID string) (*mo
 		&homeScoreDist,
 		&awayScoreDist,
 		&totalScoreOverUnder,
+		&marketsJSON,
+		&inningScoringJSON,
+		&lineupsJSON,
+		&scenarioBandsJSON,
+		&availabilityImpactJSON,
 		&result.TotalSimulations,
 		&result.HomeWins,
 		&result.AwayWins,
@@ -552,6 +663,50 @@ func (se *SimulationEngine) GetRunResult(ctx context.Context, runID string) (*mo
 		result.Statistics = make(map[string]float64)
 	}
 
+	// Runs stored before markets were added have none
+	if len(marketsJSON) > 0 {
+		var markets models.Markets
+		if err := json.Unmarshal(marketsJSON, &markets); err != nil {
+			log.Printf("Failed to parse markets: %v", err)
+		} else {
+			result.Markets = &markets
+		}
+	}
+
+	if len(inningScoringJSON) > 0 {
+		var inningScoring models.InningDistributions
+		if err := json.Unmarshal(inningScoringJSON, &inningScoring); err != nil {
+			log.Printf("Failed to parse inning scoring: %v", err)
+		} else {
+			result.InningScoring = &inningScoring
+		}
+	}
+
+	if len(lineupsJSON) > 0 {
+		var lineups models.LineupCards
+		if err := json.Unmarshal(lineupsJSON, &lineups); err != nil {
+			log.Printf("Failed to parse lineups: %v", err)
+		} else {
+			result.Lineups = &lineups
+			result.Degraded = lineups.Degraded()
+		}
+	}
+
+	if len(scenarioBandsJSON) > 0 {
+		var bands models.ScenarioBands
+		if err := json.Unmarshal(scenarioBandsJSON, &bands); err != nil {
+			log.Printf("Failed to parse scenario bands: %v", err)
+		} else {
+			result.ScenarioBands = &bands
+		}
+	}
+
+	if len(availabilityImpactJSON) > 0 {
+		if err := json.Unmarshal(availabilityImpactJSON, &result.AvailabilityImpact); err != nil {
+			log.Printf("Failed to parse availability impact: %v", err)
+		}
+	}
+
 	// Parse player performance
 	if len(playerPerfJSON) > 2 { // Check if it's more than just "{}"
 		var playerPerf models.AggregatedPlayerPerformance
@@ -604,6 +759,8 @@ func (se *SimulationEngine) aggregatePlayerStats(accum map[string]*models.Player
 		stats.R += float64(gameStats.R)
 		stats.BB += float64(gameStats.BB)
 		stats.K += float64(gameStats.K)
+		stats.XH += gameStats.XH
+		stats.XWOBAValue += gameStats.XWOBAValue
 	}
 }
 
@@ -617,6 +774,7 @@ func (se *SimulationEngine) aggregatePitcherStats(accum map[string]*models.Playe
 		}
 
 		stats := accum[playerID]
+		stats.G++
 		stats.IP += float64(gameStats.Outs) / 3.0 // Convert outs to innings
 		stats.H += float64(gameStats.H)
 		stats.R += float64(gameStats.R)
@@ -648,6 +806,8 @@ func (se *SimulationEngine) averagePlayerStats(accum map[string]*models.PlayerBa
 			R:          stats.R / numSims,
 			BB:         stats.BB / numSims,
 			K:          stats.K / numSims,
+			XH:         stats.XH / numSims,
+			XWOBAValue: stats.XWOBAValue / numSims,
 		}
 
 		// Calculate derived stats
@@ -655,9 +815,11 @@ func (se *SimulationEngine) averagePlayerStats(accum map[string]*models.PlayerBa
 			avgStats.AVG = avgStats.H / avgStats.AB
 			totalBases := avgStats.Singles + (avgStats.Doubles * 2) + (avgStats.Triples * 3) + (avgStats.HR * 4)
 			avgStats.SLG = totalBases / avgStats.AB
+			avgStats.XBA = avgStats.XH / avgStats.AB
 		}
 		if avgStats.PA > 0 {
 			avgStats.OBP = (avgStats.H + avgStats.BB) / avgStats.PA
+			avgStats.XWOBA = avgStats.XWOBAValue / avgStats.PA
 		}
 
 		result[playerID] = avgStats
@@ -674,6 +836,7 @@ func (se *SimulationEngine) averagePitcherStats(accum map[string]*models.PlayerP
 		avgStats := models.PlayerPitchingStats{
 			PlayerID:   stats.PlayerID,
 			PlayerName: stats.PlayerName,
+			G:          stats.G / numSims,
 			IP:         stats.IP / numSims,
 			H:          stats.H / numSims,
 			R:          stats.R / numSims,
@@ -721,3 +884,31 @@ func (se *SimulationEngine) enrichWithPitcherNames(ctx context.Context, stats ma
 		}
 	}
 }
+
+// annotateColdWeatherPenalties records on each pitcher's aggregated line the
+// cold-weather penalty the simulations applied to them
+func (se *SimulationEngine) annotateColdWeatherPenalties(aggregated *models.AggregatedResult, gameData *GameData,
+	homeRoster, awayRoster *models.Roster) {
+	if aggregated.PlayerPerformance == nil {
+		return
+	}
+
+	state := models.GameState{
+		Weather:              gameData.Weather,
+		ColdWeatherThreshold: se.coldWeatherThreshold,
+		Indoor:               models.RoofKeepsWeatherOut(gameData.Stadium.RoofType),
+	}
+	annotate := func(stats map[string]models.PlayerPitchingStats, roster *models.Roster) {
+		for i := range roster.Players {
+			player := &roster.Players[i]
+			stat, ok := stats[player.ID]
+			if !ok {
+				continue
+			}
+			stat.ColdWeatherPenalty = state.ColdWeatherPenaltyFor(player)
+			stats[player.ID] = stat
+		}
+	}
+	annotate(aggregated.PlayerPerformance.HomeTeam.Pitching, homeRoster)
+	annotate(aggregated.PlayerPerformance.AwayTeam.Pitching, awayRoster)
+}
@@ -0,0 +1,310 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"sim-engine/models"
+)
+
+const (
+	// DefaultResultWriteAttempts is how many times a result write is tried
+	// before it is spilled to the dead-letter directory
+	DefaultResultWriteAttempts = 4
+
+	// DefaultDeadLetterDir is where failed result writes are spilled,
+	// relative to the working directory
+	DefaultDeadLetterDir = "dead-letter"
+
+	// Backoff between write attempts doubles from the base up to the cap
+	resultWriteBaseDelay = 250 * time.Millisecond
+	resultWriteMaxDelay  = 5 * time.Second
+)
+
+// Dead letter kinds
+const (
+	DeadLetterSimulationResults = "simulation_results"
+	DeadLetterAggregatedResult  = "aggregated_result"
+	DeadLetterResultObject      = "result_object" // a whole run bound for object storage
+)
+
+// DeadLetter is a result write that failed every attempt, kept on disk until
+// it is replayed into the database
+type DeadLetter struct {
+	Kind      string                    `json:"kind"`
+	RunID     string                    `json:"run_id"`
+	Error     string                    `json:"error"`
+	FailedAt  time.Time                 `json:"failed_at"`
+	Results   []models.SimulationResult `json:"results,omitempty"`
+	Aggregate *models.AggregatedResult  `json:"aggregate,omitempty"`
+}
+
+// DeadLetterReplay reports a replay of the dead-letter directory
+type DeadLetterReplay struct {
+	Replayed         int    `json:"replayed"` // spill files fully written and removed
+	ResultsStored    int    `json:"results_stored"`
+	AggregatesStored int    `json:"aggregates_stored"`
+	Remaining        int    `json:"remaining"`       // spill files still on disk
+	Error            string `json:"error,omitempty"` // why the replay stopped early
+}
+
+// writeRetryPolicy bounds how long a result write is retried
+type writeRetryPolicy struct {
+	attempts  int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	sleep     func(context.Context, time.Duration) error
+}
+
+func newWriteRetryPolicy(attempts int) writeRetryPolicy {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return writeRetryPolicy{
+		attempts:  attempts,
+		baseDelay: resultWriteBaseDelay,
+		maxDelay:  resultWriteMaxDelay,
+		sleep:     sleepContext,
+	}
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// do runs write until it succeeds or the attempts run out, returning the
+// last error
+func (p writeRetryPolicy) do(ctx context.Context, write func(context.Context) error) error {
+	delay := p.baseDelay
+	var err error
+	for attempt := 1; attempt <= p.attempts; attempt++ {
+		if err = write(ctx); err == nil {
+			return nil
+		}
+		if attempt == p.attempts {
+			break
+		}
+		if sleepErr := p.sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+		delay *= 2
+		if delay > p.maxDelay {
+			delay = p.maxDelay
+		}
+	}
+	return fmt.Errorf("after %d attempts: %w", p.attempts, err)
+}
+
+// deadLetterQueue spills failed writes to JSON files, one per letter
+type deadLetterQueue struct {
+	mu  sync.Mutex
+	dir string
+}
+
+func newDeadLetterQueue(dir string) *deadLetterQueue {
+	return &deadLetterQueue{dir: dir}
+}
+
+func (q *deadLetterQueue) setDir(dir string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.dir = dir
+}
+
+// spill writes a letter to its own file. The file is written under a
+// temporary name and renamed so a replay never reads a partial spill.
+func (q *deadLetterQueue) spill(letter DeadLetter) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := os.MkdirAll(q.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create dead-letter directory: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s-%s.json",
+		letter.FailedAt.UTC().Format("20060102T150405.000000000"), letter.Kind, letter.RunID)
+	path := filepath.Join(q.dir, name)
+	if err := writeDeadLetterFile(path, letter); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func writeDeadLetterFile(path string, letter DeadLetter) error {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// files lists spilled letters, oldest first
+func (q *deadLetterQueue) files() ([]string, error) {
+	q.mu.Lock()
+	dir := q.dir
+	q.mu.Unlock()
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// replay writes each spilled letter with the given store functions, oldest
+// first, removing letters once they are written. Results already written are
+// dropped from a letter that fails partway. Replay stops at the first failed
+// write, since the database is most likely still unavailable.
+func (q *deadLetterQueue) replay(ctx context.Context,
+	storeResult func(context.Context, models.SimulationResult) error,
+	storeAggregate func(context.Context, *models.AggregatedResult) error,
+	storeRun func(context.Context, string, []models.SimulationResult) error) (DeadLetterReplay, error) {
+
+	var report DeadLetterReplay
+	paths, err := q.files()
+	if err != nil {
+		return report, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return report, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+		}
+		var letter DeadLetter
+		if err := json.Unmarshal(data, &letter); err != nil {
+			// Leave unreadable spills for an operator rather than losing them
+			log.Printf("Skipping unreadable dead letter %s: %v", filepath.Base(path), err)
+			report.Remaining++
+			continue
+		}
+
+		var writeErr error
+		switch letter.Kind {
+		case DeadLetterSimulationResults:
+			for len(letter.Results) > 0 {
+				if writeErr = storeResult(ctx, letter.Results[0]); writeErr != nil {
+					break
+				}
+				letter.Results = letter.Results[1:]
+				report.ResultsStored++
+			}
+			if writeErr != nil {
+				if err := writeDeadLetterFile(path, letter); err != nil {
+					log.Printf("Failed to rewrite dead letter %s: %v", filepath.Base(path), err)
+				}
+			}
+		case DeadLetterResultObject:
+			if writeErr = storeRun(ctx, letter.RunID, letter.Results); writeErr == nil {
+				report.ResultsStored += len(letter.Results)
+			}
+		case DeadLetterAggregatedResult:
+			if letter.Aggregate == nil {
+				break
+			}
+			if writeErr = storeAggregate(ctx, letter.Aggregate); writeErr == nil {
+				report.AggregatesStored++
+			}
+		default:
+			log.Printf("Skipping dead letter %s of unknown kind %q", filepath.Base(path), letter.Kind)
+			report.Remaining++
+			continue
+		}
+
+		if writeErr != nil {
+			report.Remaining += len(paths) - i
+			report.Error = writeErr.Error()
+			return report, nil
+		}
+		if err := os.Remove(path); err != nil {
+			return report, fmt.Errorf("failed to remove replayed dead letter: %w", err)
+		}
+		report.Replayed++
+	}
+	return report, nil
+}
+
+// SetDeadLetterDir sets where failed result writes are spilled
+func (se *SimulationEngine) SetDeadLetterDir(dir string) {
+	se.deadLetters.setDir(dir)
+}
+
+// SetResultWriteAttempts sets how many times each result write is tried
+// before it is spilled
+func (se *SimulationEngine) SetResultWriteAttempts(attempts int) {
+	se.writeRetry = newWriteRetryPolicy(attempts)
+}
+
+// PendingDeadLetters counts spilled writes waiting to be replayed
+func (se *SimulationEngine) PendingDeadLetters() (int, error) {
+	paths, err := se.deadLetters.files()
+	return len(paths), err
+}
+
+// ReplayDeadLetters writes spilled results into the database
+func (se *SimulationEngine) ReplayDeadLetters(ctx context.Context) (DeadLetterReplay, error) {
+	storeResult := func(ctx context.Context, result models.SimulationResult) error {
+		_, err := se.storeSimulationResult(ctx, result)
+		return err
+	}
+	storeRun := func(ctx context.Context, runID string, results []models.SimulationResult) error {
+		_, err := se.storeResultsObject(ctx, runID, results)
+		return err
+	}
+	return se.deadLetters.replay(ctx, storeResult, se.storeReplayedAggregate, storeRun)
+}
+
+// storeReplayedAggregate stores a spilled aggregate and marks its run
+// completed again if it finished every simulation
+func (se *SimulationEngine) storeReplayedAggregate(ctx context.Context, aggregate *models.AggregatedResult) error {
+	if err := se.storeAggregatedResults(ctx, aggregate); err != nil {
+		return err
+	}
+	_, err := se.db.Exec(ctx, `
+		UPDATE simulation_runs
+		SET status = 'completed', updated_at = NOW()
+		WHERE id = $1 AND status = $2 AND completed_runs >= total_runs
+	`, aggregate.RunID, RunStatusPartial)
+	return err
+}
+
+// spillDeadLetter saves writes that failed every attempt. If even the spill
+// fails, the results are lost and only the log records it.
+func (se *SimulationEngine) spillDeadLetter(letter DeadLetter) {
+	letter.FailedAt = time.Now()
+	path, err := se.deadLetters.spill(letter)
+	if err != nil {
+		log.Printf("Failed to spill %s for run %s, results lost: %v", letter.Kind, letter.RunID, err)
+		return
+	}
+	log.Printf("Spilled %s for run %s to %s", letter.Kind, letter.RunID, path)
+}
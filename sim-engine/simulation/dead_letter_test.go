@@ -0,0 +1,190 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"sim-engine/models"
+)
+
+func TestWriteRetryPolicyBacksOff(t *testing.T) {
+	var delays []time.Duration
+	policy := newWriteRetryPolicy(5)
+	policy.maxDelay = 600 * time.Millisecond
+	policy.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	calls := 0
+	err := policy.do(context.Background(), func(context.Context) error {
+		calls++
+		return errors.New("connection refused")
+	})
+	if err == nil || calls != 5 {
+		t.Fatalf("expected 5 failed attempts, got %d calls and err %v", calls, err)
+	}
+	want := []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, 600 * time.Millisecond, 600 * time.Millisecond}
+	if len(delays) != len(want) {
+		t.Fatalf("delays = %v, want %v", delays, want)
+	}
+	for i := range want {
+		if delays[i] != want[i] {
+			t.Errorf("delay %d = %v, want %v", i, delays[i], want[i])
+		}
+	}
+
+	// A write that recovers stops retrying
+	calls = 0
+	err = policy.do(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 2 {
+			return errors.New("deadlock detected")
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("expected success on the 2nd attempt, got %d calls and err %v", calls, err)
+	}
+}
+
+func TestWriteRetryPolicyStopsWhenCancelled(t *testing.T) {
+	policy := newWriteRetryPolicy(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := policy.do(ctx, func(context.Context) error {
+		calls++
+		return errors.New("timeout")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("expected one attempt once cancelled, got %d calls and err %v", calls, err)
+	}
+}
+
+func TestDeadLetterSpillAndReplay(t *testing.T) {
+	queue := newDeadLetterQueue(t.TempDir())
+	results := []models.SimulationResult{
+		{RunID: "run-1", SimulationNumber: 1, HomeScore: 5, AwayScore: 3, KeyEvents: []models.GameEvent{{Inning: 1, Description: "Home run"}}},
+		{RunID: "run-1", SimulationNumber: 2, HomeScore: 2, AwayScore: 4},
+		{RunID: "run-1", SimulationNumber: 3, HomeScore: 1, AwayScore: 0},
+	}
+	aggregate := &models.AggregatedResult{RunID: "run-1", HomeWinProbability: 0.55,
+		HomeScoreDistribution: map[int]int{5: 1, 2: 1, 1: 1}}
+
+	if _, err := queue.spill(DeadLetter{Kind: DeadLetterSimulationResults, RunID: "run-1",
+		FailedAt: time.Date(2026, 10, 1, 19, 0, 0, 0, time.UTC), Results: results}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queue.spill(DeadLetter{Kind: DeadLetterAggregatedResult, RunID: "run-1",
+		FailedAt: time.Date(2026, 10, 1, 19, 0, 1, 0, time.UTC), Aggregate: aggregate}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The database fails on the third result: the first two are written and
+	// dropped from the spill, and the aggregate waits behind it
+	var stored []int
+	failOn := 3
+	storeResult := func(_ context.Context, result models.SimulationResult) error {
+		if result.SimulationNumber == failOn {
+			return errors.New("connection reset")
+		}
+		stored = append(stored, result.SimulationNumber)
+		return nil
+	}
+	var storedAggregate *models.AggregatedResult
+	storeAggregate := func(_ context.Context, result *models.AggregatedResult) error {
+		storedAggregate = result
+		return nil
+	}
+
+	report, err := queue.replay(context.Background(), storeResult, storeAggregate, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.ResultsStored != 2 || report.Replayed != 0 || report.Remaining != 2 || report.Error == "" {
+		t.Errorf("first replay = %+v, want 2 results stored and 2 letters remaining", report)
+	}
+	if storedAggregate != nil {
+		t.Error("aggregate should wait until the results before it are written")
+	}
+
+	failOn = 0
+	report, err = queue.replay(context.Background(), storeResult, storeAggregate, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.ResultsStored != 1 || report.AggregatesStored != 1 || report.Replayed != 2 || report.Remaining != 0 {
+		t.Errorf("second replay = %+v, want everything replayed", report)
+	}
+	if len(stored) != 3 || stored[2] != 3 {
+		t.Errorf("stored results = %v, want each exactly once", stored)
+	}
+	if storedAggregate == nil || storedAggregate.HomeScoreDistribution[5] != 1 {
+		t.Errorf("aggregate did not round-trip: %+v", storedAggregate)
+	}
+
+	if files, _ := queue.files(); len(files) != 0 {
+		t.Errorf("expected replayed spills to be removed, found %v", files)
+	}
+}
+
+func TestDeadLetterReplayKeepsUnreadableSpills(t *testing.T) {
+	dir := t.TempDir()
+	queue := newDeadLetterQueue(dir)
+	if err := os.WriteFile(dir+"/20261001T190000.000000000-aggregated_result-run-2.json", []byte("{truncated"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := queue.replay(context.Background(),
+		func(context.Context, models.SimulationResult) error { return nil },
+		func(context.Context, *models.AggregatedResult) error { return nil },
+		func(context.Context, string, []models.SimulationResult) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Remaining != 1 || report.Replayed != 0 {
+		t.Errorf("report = %+v, want the unreadable spill left in place", report)
+	}
+}
+
+func TestDeadLetterReplayWithoutDirectory(t *testing.T) {
+	queue := newDeadLetterQueue(t.TempDir() + "/missing")
+	report, err := queue.replay(context.Background(), nil, nil, nil)
+	if err != nil || report.Remaining != 0 {
+		t.Errorf("expected an empty replay, got %+v, %v", report, err)
+	}
+}
+
+func TestDeadLetterReplayResultObject(t *testing.T) {
+	queue := newDeadLetterQueue(t.TempDir())
+	results := []models.SimulationResult{
+		{RunID: "run-3", SimulationNumber: 1, HomeScore: 3, AwayScore: 2},
+		{RunID: "run-3", SimulationNumber: 2, HomeScore: 0, AwayScore: 6},
+	}
+	if _, err := queue.spill(DeadLetter{Kind: DeadLetterResultObject, RunID: "run-3",
+		FailedAt: time.Date(2026, 10, 1, 19, 0, 0, 0, time.UTC), Results: results}); err != nil {
+		t.Fatal(err)
+	}
+
+	var storedRun string
+	var storedResults []models.SimulationResult
+	storeRun := func(_ context.Context, runID string, results []models.SimulationResult) error {
+		storedRun, storedResults = runID, results
+		return nil
+	}
+	report, err := queue.replay(context.Background(), nil, nil, storeRun)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Replayed != 1 || report.ResultsStored != 2 || report.Remaining != 0 {
+		t.Errorf("report = %+v, want the run's results stored as one write", report)
+	}
+	if storedRun != "run-3" || len(storedResults) != 2 {
+		t.Errorf("stored %q with %d results", storedRun, len(storedResults))
+	}
+}
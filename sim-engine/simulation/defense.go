@@ -0,0 +1,40 @@
+package simulation
+
+import "sim-engine/models"
+
+// findFielders returns the lineup players at fielding positions
+func findFielders(lineup []models.Player) []models.Player {
+	var fielders []models.Player
+	for _, player := range lineup {
+		if isFieldingPosition(player.Position) {
+			fielders = append(fielders, player)
+		}
+	}
+	return fielders
+}
+
+// reachedOnError turns a batted-ball out into the batter reaching on an
+// error. Contact quality is kept for expected stats.
+func reachedOnError(result models.AtBatResult) models.AtBatResult {
+	result.Type = "error"
+	result.Description = "Reached on error"
+	result.IsOut = false
+	result.Outs = 0
+	result.Bases = 1
+	return result
+}
+
+// recordDefense counts a plate appearance against the defense in the field
+func recordDefense(defense *models.TeamDefense, result models.AtBatResult, outs int) {
+	defense.Outs += outs
+	switch result.Type {
+	case "single", "double", "triple":
+		defense.BallsInPlay++
+		defense.HitsInPlay++
+	case "out":
+		defense.BallsInPlay++
+	case "error":
+		defense.BallsInPlay++
+		defense.Errors++
+	}
+}
@@ -0,0 +1,61 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestFindFielders(t *testing.T) {
+	lineup := []models.Player{{ID: "c", Position: "C"}, {ID: "dh", Position: "DH"}, {ID: "ss", Position: "SS"}}
+	fielders := findFielders(lineup)
+	if len(fielders) != 2 || fielders[0].ID != "c" || fielders[1].ID != "ss" {
+		t.Errorf("Expected the catcher and shortstop, got %+v", fielders)
+	}
+}
+
+func TestReachedOnError(t *testing.T) {
+	out := models.AtBatResult{Type: "out", IsOut: true, Outs: 1, BattedBall: &models.BattedBall{ExitVelocity: 95}}
+	result := reachedOnError(out)
+	if result.Type != "error" || result.IsOut || result.Outs != 0 || result.Bases != 1 {
+		t.Errorf("Expected the batter safe at first, got %+v", result)
+	}
+	if result.BattedBall == nil {
+		t.Error("Expected contact quality kept for expected stats")
+	}
+
+	se := &SimulationEngine{}
+	gameState := models.NewGameState("game", "run")
+	gameState.Bases.Third = &models.BaseRunner{PlayerID: "r3"}
+	runs, outs := se.processAtBatResult(gameState, result)
+	if runs != 1 || outs != 0 || gameState.Bases.First == nil {
+		t.Errorf("Expected runner from third to score and batter on first, got runs=%d outs=%d", runs, outs)
+	}
+
+	// Runs scoring on an error are unearned
+	pitching := &models.PlayerPitchingStats{}
+	se.updatePitcherStats(pitching, result, runs, 3)
+	if pitching.R != 1 || pitching.ER != 0 || pitching.IP != 0 {
+		t.Errorf("Expected one unearned run and no out, got %+v", pitching)
+	}
+
+	batting := &models.PlayerBattingStats{}
+	se.updateBatterStats(batting, result, runs)
+	if batting.AB != 1 || batting.H != 0 || batting.RBI != 0 {
+		t.Errorf("Expected an at-bat without a hit or RBI, got %+v", batting)
+	}
+}
+
+func TestRecordDefense(t *testing.T) {
+	var defense models.TeamDefense
+	for _, play := range []struct {
+		result string
+		outs   int
+	}{{"single", 0}, {"out", 1}, {"error", 0}, {"strikeout", 1}, {"home_run", 0}, {"walk", 0}} {
+		recordDefense(&defense, models.AtBatResult{Type: play.result}, play.outs)
+	}
+	want := models.TeamDefense{Outs: 2, BallsInPlay: 3, HitsInPlay: 1, Errors: 1}
+	if defense != want {
+		t.Errorf("Expected %+v, got %+v", want, defense)
+	}
+}
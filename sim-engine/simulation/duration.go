@@ -0,0 +1,93 @@
+package simulation
+
+import (
+	"context"
+	"log"
+
+	"sim-engine/models"
+)
+
+// durationCalibrationGames caps how many recent games the duration model is
+// fitted to, keeping it close to the current pace of play
+const durationCalibrationGames = 2000
+
+// loadDurationModel fits the game duration model to the most recent completed
+// games up to a season, falling back to the defaults when too few games have
+// recorded durations. Results are cached per season for the life of the engine;
+// a failed query falls back to the defaults without caching them.
+func (se *SimulationEngine) loadDurationModel(ctx context.Context, season int) *models.DurationModel {
+	se.leagueMu.Lock()
+	model, ok := se.durationModels[season]
+	se.leagueMu.Unlock()
+	if ok {
+		return model
+	}
+
+	model = models.DefaultDurationModel()
+	samples, err := se.loadDurationSamples(ctx, season)
+	if err != nil {
+		log.Printf("Failed to load game durations for season %d, using default duration model: %v", season, err)
+		return model
+	}
+	if fitted, err := models.FitDurationModel(samples); err != nil {
+		log.Printf("Using default duration model for season %d (%d games): %v", season, len(samples), err)
+	} else {
+		model = fitted
+		log.Printf("Fitted duration model for season %d from %d games: %.1f + %.3f/pitch + %.2f/run + %.2f/pitching change + %.2f/extra inning",
+			season, model.Samples, model.Intercept, model.PerPitch, model.PerRun, model.PerPitchingChange, model.PerExtraInning)
+	}
+
+	se.leagueMu.Lock()
+	defer se.leagueMu.Unlock()
+	if cached, ok := se.durationModels[season]; ok {
+		return cached
+	}
+	se.durationModels[season] = model
+	return model
+}
+
+// loadDurationSamples reads the pace and actual length of recent completed games
+func (se *SimulationEngine) loadDurationSamples(ctx context.Context, season int) ([]models.DurationSample, error) {
+	rows, err := se.db.Query(ctx, `
+		WITH pitching AS (
+			SELECT game_id, SUM(pitches_thrown) AS pitches, COUNT(*) AS pitchers
+			FROM game_box_score_pitching
+			GROUP BY game_id
+		),
+		innings AS (
+			SELECT game_id, MAX(inning) AS innings
+			FROM game_plays
+			GROUP BY game_id
+		)
+		SELECT g.duration_minutes, p.pitches,
+		       g.final_score_home + g.final_score_away,
+		       GREATEST(p.pitchers - 2, 0),
+		       COALESCE(i.innings, 9)
+		FROM games g
+		JOIN pitching p ON p.game_id = g.id
+		LEFT JOIN innings i ON i.game_id = g.id
+		WHERE g.season <= $1
+		  AND g.duration_minutes > 0
+		  AND p.pitches > 0
+		  AND g.status = 'completed'
+		  AND g.final_score_home IS NOT NULL
+		  AND g.final_score_away IS NOT NULL
+		ORDER BY g.game_date DESC
+		LIMIT $2
+	`, season, durationCalibrationGames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []models.DurationSample
+	for rows.Next() {
+		var minutes int
+		var pace models.GamePace
+		if err := rows.Scan(&minutes, &pace.Pitches, &pace.Runs, &pace.PitchingChanges, &pace.Innings); err != nil {
+			return nil, err
+		}
+		samples = append(samples, models.DurationSample{Pace: pace, Minutes: float64(minutes)})
+	}
+	return samples, rows.Err()
+}
@@ -5,8 +5,10 @@ import (
 	"log"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/baseball-sim/shared/ids"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"sim-engine/models"
 	"sim-engine/weather"
@@ -20,6 +22,30 @@ type SimulationEngine struct {
 	mu             sync.RWMutex
 	activeRuns     map[string]*RunStatus
 	weatherService WeatherService
+	throughput     throughputTracker
+	leagueMu       sync.Mutex // guards leagueEnvs and durationModels
+	leagueEnvs     map[int]*models.LeagueEnvironment
+	durationModels map[int]*models.DurationModel
+	rosters        *rosterCache
+	warm           *warmPool
+	ids            *ids.Resolver
+
+	// Failed result writes are retried, then spilled to disk for replay
+	writeRetry  writeRetryPolicy
+	deadLetters *deadLetterQueue
+
+	// Runs the engine starts itself are admitted against this queue depth
+	// (0 = unlimited)
+	maxQueueDepth int
+
+	// Raw results are kept in object storage, one object per run, when
+	// writeResultObjects is set
+	resultObjects      ObjectStore
+	resultObjectPrefix string
+	writeResultObjects bool
+
+	// coldWeatherThreshold is the °F below which pitchers are penalized
+	coldWeatherThreshold int
 }
 
 // WeatherService interface for fetching weather data
@@ -29,6 +55,7 @@ type WeatherService interface {
 
 // StadiumInfo matches the weather service stadium info structure
 type StadiumInfo = struct {
+	ID        string
 	Name      string
 	Location  string
 	Latitude  float64
@@ -57,15 +84,41 @@ func NewSimulationEngine(db *pgxpool.Pool, workers, simulationRuns int) *Simulat
 		workers:        workers,
 		simulationRuns: simulationRuns,
 		activeRuns:     make(map[string]*RunStatus),
+		leagueEnvs:     make(map[int]*models.LeagueEnvironment),
+		durationModels: make(map[int]*models.DurationModel),
+		rosters:        newRosterCache(DefaultRosterCacheTTL),
+		warm:           newWarmPool(DefaultWarmPoolTTL),
+		ids:            ids.NewResolver(func() *pgxpool.Pool { return db }),
 		weatherService: nil, // Will be set via SetWeatherService
+		writeRetry:     newWriteRetryPolicy(DefaultResultWriteAttempts),
+		deadLetters:    newDeadLetterQueue(DefaultDeadLetterDir),
+
+		coldWeatherThreshold: models.DefaultColdWeatherThreshold,
 	}
 }
 
+// ResolveID resolves a client-supplied game, team or stadium identifier
+func (se *SimulationEngine) ResolveID(ctx context.Context, kind ids.Kind, raw string) (ids.Resolved, error) {
+	return se.ids.Resolve(ctx, kind, raw)
+}
+
+// ClearResolvedIDs forgets resolved identifiers so repointed aliases take
+// effect straight away
+func (se *SimulationEngine) ClearResolvedIDs() {
+	se.ids.Clear()
+}
+
 // SetWeatherService sets the weather service for the engine
 func (se *SimulationEngine) SetWeatherService(ws WeatherService) {
 	se.weatherService = ws
 }
 
+// SetColdWeatherThreshold sets the temperature (°F) below which pitchers
+// lose velocity and spin
+func (se *SimulationEngine) SetColdWeatherThreshold(threshold int) {
+	se.coldWeatherThreshold = threshold
+}
+
 // RunSimulation executes a complete simulation run
 func (se *SimulationEngine) RunSimulation(runID, gameID string, simulationRuns int, config map[string]interface{}) {
 	ctx := context.Background()
@@ -86,40 +139,80 @@ func (se *SimulationEngine) RunSimulation(runID, gameID string, simulationRuns i
 	}
 	se.mu.Unlock()
 
-	// Load game data
-	gameData, err := se.loadGameData(ctx, gameID)
+	// Workers stop starting new games once the run's time budget is spent
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+	if maxDuration, _ := MaxDurationFromConfig(config); maxDuration > 0 {
+		var cancelBudget context.CancelFunc
+		runCtx, cancelBudget = context.WithTimeout(runCtx, maxDuration)
+		defer cancelBudget()
+	}
+
+	// Load game data, weather and calibration, pre-warmed on game day
+	gameData, err := se.gameContext(ctx, gameID)
 	if err != nil {
 		log.Printf("Failed to load game data for %s: %v", gameID, err)
 		se.updateRunStatus(runID, "error")
 		return
 	}
 
-	// Fetch real-time weather if weather service is available
-	if se.weatherService != nil && gameData.Stadium.Name != "" {
-		// Convert stadium info for weather service
-		stadiumInfo := se.convertToWeatherStadiumInfo(gameData.Stadium)
-
-		weather, err := se.weatherService.GetWeatherForGame(ctx, stadiumInfo, gameData.GameTime)
-		if err != nil {
-			log.Printf("Failed to fetch weather for %s: %v, using default", gameData.Stadium.Name, err)
-		} else {
-			gameData.Weather = weather
-			log.Printf("Fetched weather for %s: %d°F, wind %d mph %s",
-				gameData.Stadium.Name, weather.Temperature, weather.WindSpeed, weather.WindDir)
+	// A run may move the game to another venue
+	if stadiumID, _ := StadiumOverrideFromConfig(config); stadiumID != "" {
+		stadium, found, err := se.LoadStadium(ctx, stadiumID)
+		if err != nil || !found {
+			log.Printf("Failed to load stadium %s for %s: %v", stadiumID, gameID, err)
+			se.updateRunStatus(runID, "error")
+			return
 		}
+		se.moveToStadium(ctx, gameData, stadium)
+	}
+
+	// Historical replays use only what was known on the as_of date, with the
+	// last completed season's league environment
+	asOf, err := AsOfFromConfig(config, gameData.Date)
+	if err != nil {
+		log.Printf("Invalid as_of for %s: %v", gameID, err)
+		se.updateRunStatus(runID, "error")
+		return
 	}
 
 	// Load team rosters
-	homeRoster, awayRoster, err := se.loadTeamRosters(ctx, gameData.HomeTeamID, gameData.AwayTeamID)
+	var homeRoster, awayRoster *models.Roster
+	if asOf.IsZero() {
+		homeRoster, awayRoster, err = se.loadTeamRosters(ctx, gameData.HomeTeamID, gameData.AwayTeamID, gameData.League)
+	} else {
+		gameData.League = se.loadLeagueEnvironment(ctx, asOf.Year()-1)
+		gameData.HomeForm, gameData.AwayForm = nil, nil
+		se.applyFormPrior(ctx, gameData, asOf)
+		homeRoster, awayRoster, err = se.loadTeamRostersAsOf(ctx, gameData.HomeTeamID, gameData.AwayTeamID, asOf, gameData.League)
+	}
 	if err != nil {
 		log.Printf("Failed to load team rosters for %s: %v", gameID, err)
 		se.updateRunStatus(runID, "error")
 		return
 	}
 
+	// Relievers worked by earlier games in a batch are less available
+	availability, err := BullpenAvailabilityFromConfig(config)
+	if err != nil {
+		log.Printf("Ignoring bullpen availability for %s: %v", runID, err)
+	}
+	applyBullpenAvailability(availability, homeRoster, awayRoster)
+
+	// Snapshot the inputs so later runs of this game can be explained against it
+	inputs := captureRunInputs(gameData, homeRoster, awayRoster)
+	inputs.DataSnapshotAt = se.loadDataSnapshot(ctx)
+	if !asOf.IsZero() {
+		inputs.AsOf = asOf.Format("2006-01-02")
+	}
+	se.recordRunInputs(runID, inputs)
+	se.recordEffectiveConfig(runID, NewEffectiveConfig(config, simulationRuns, asOf, gameData.Tuning))
+
 	// Run simulations concurrently
 	resultsChan := make(chan models.SimulationResult, simulationRuns)
 	var wg sync.WaitGroup
+	var cpuNanos atomic.Int64
+	simStart := time.Now()
 
 	// Create worker goroutines
 	simulationsPerWorker := simulationRuns / se.workers
@@ -137,8 +230,13 @@ func (se *SimulationEngine) RunSimulation(runID, gameID string, simulationRuns i
 			defer wg.Done()
 
 			for j := 0; j < simCount; j++ {
+				if runCtx.Err() != nil {
+					return
+				}
 				simNumber := workerID*simulationsPerWorker + j + 1
+				gameStart := time.Now()
 				result := se.simulateGame(runID, simNumber, gameData, homeRoster, awayRoster, config)
+				cpuNanos.Add(int64(time.Since(gameStart)))
 				resultsChan <- result
 
 				// Update progress
@@ -154,28 +252,133 @@ func (se *SimulationEngine) RunSimulation(runID, gameID string, simulationRuns i
 	}()
 
 	var results []models.SimulationResult
+	var storedBytes int64
+	var unstored []models.SimulationResult
+	var storeErr error
 	for result := range resultsChan {
 		results = append(results, result)
 
+		// Object storage takes the whole run at once, once it is collected
+		if se.writeResultObjects {
+			continue
+		}
+
+		// Once a write has failed every retry, the rest of the run goes
+		// straight to the spill instead of waiting out each retry
+		if storeErr != nil {
+			unstored = append(unstored, result)
+			continue
+		}
+
 		// Store individual result in database
-		if err := se.storeSimulationResult(ctx, result); err != nil {
-			log.Printf("Failed to store simulation result: %v", err)
+		var written int
+		storeErr = se.writeRetry.do(ctx, func(ctx context.Context) error {
+			var err error
+			written, err = se.storeSimulationResult(ctx, result)
+			return err
+		})
+		if storeErr != nil {
+			log.Printf("Failed to store simulation result for run %s: %v", runID, storeErr)
+			unstored = append(unstored, result)
+			continue
+		}
+		storedBytes += int64(written)
+	}
+	if len(unstored) > 0 {
+		se.spillDeadLetter(DeadLetter{
+			Kind:    DeadLetterSimulationResults,
+			RunID:   runID,
+			Error:   storeErr.Error(),
+			Results: unstored,
+		})
+	}
+	if se.writeResultObjects && len(results) > 0 {
+		var written int
+		if err := se.writeRetry.do(ctx, func(ctx context.Context) error {
+			var err error
+			written, err = se.storeResultsObject(ctx, runID, results)
+			return err
+		}); err != nil {
+			log.Printf("Failed to store results object for run %s: %v", runID, err)
+			se.spillDeadLetter(DeadLetter{
+				Kind:    DeadLetterResultObject,
+				RunID:   runID,
+				Error:   err.Error(),
+				Results: results,
+			})
+		} else {
+			storedBytes = int64(written)
 		}
 	}
 
+	// Record throughput for cost estimates
+	se.throughput.record(ThroughputSample{
+		Simulations: len(results),
+		WallTime:    time.Since(simStart),
+		CPUTime:     time.Duration(cpuNanos.Load()),
+		StoredBytes: storedBytes,
+		Workers:     se.workers,
+		CompletedAt: time.Now(),
+	})
+
+	if len(results) == 0 {
+		log.Printf("Simulation run %s hit its time budget before any simulation finished", runID)
+		se.updateRunStatus(runID, "error")
+		return
+	}
+
 	// Calculate aggregated results
 	aggregated := se.calculateAggregatedResults(runID, results)
+	aggregated.Lineups = &models.LineupCards{
+		Home: se.buildLineupCard(homeRoster),
+		Away: se.buildLineupCard(awayRoster),
+	}
+	aggregated.Degraded = aggregated.Lineups.Degraded()
+	se.annotateColdWeatherPenalties(aggregated, gameData, homeRoster, awayRoster)
+
+	// Win probability with and without each questionable player
+	if playProbability, _ := PlayProbabilityFromConfig(config); len(playProbability) > 0 {
+		aggregated.AvailabilityImpact = availabilityImpacts(playProbability, homeRoster, awayRoster, results)
+	}
 
-	// Store aggregated results
-	if err := se.storeAggregatedResults(ctx, aggregated); err != nil {
-		log.Printf("Failed to store aggregated results: %v", err)
+	// Pessimistic and optimistic variants bracket the run, if asked for
+	if ScenarioBandsFromConfig(config) && len(results) == simulationRuns {
+		bands, err := se.scenarioBands(runCtx, gameData, homeRoster, awayRoster, config, aggregated)
+		if err != nil {
+			log.Printf("Skipping scenario bands for run %s: %v", runID, err)
+		} else {
+			aggregated.ScenarioBands = bands
+		}
+	}
+
+	// A run cut short by its time budget keeps what finished, with the
+	// precision lost noted alongside the results
+	finalStatus := "completed"
+	if len(results) < simulationRuns {
+		finalStatus = RunStatusPartial
+		aggregated.Partial = models.NewPartialRunMetadata(simulationRuns, len(results), aggregated.HomeWinProbability)
+	}
+
+	// Store aggregated results. A run whose aggregate is waiting in the spill
+	// isn't complete in the database, so it is marked partial until replayed.
+	if err := se.writeRetry.do(ctx, func(ctx context.Context) error {
+		return se.storeAggregatedResults(ctx, aggregated)
+	}); err != nil {
+		log.Printf("Failed to store aggregated results for run %s: %v", runID, err)
+		se.spillDeadLetter(DeadLetter{
+			Kind:      DeadLetterAggregatedResult,
+			RunID:     runID,
+			Error:     err.Error(),
+			Aggregate: aggregated,
+		})
+		finalStatus = RunStatusPartial
 	}
 
 	// Update final status
 	se.mu.Lock()
 	if status, exists := se.activeRuns[runID]; exists {
-		status.Status = "completed"
-		status.CompletedRuns = simulationRuns
+		status.Status = finalStatus
+		status.CompletedRuns = len(results)
 		completedTime := time.Now()
 		status.CompletedTime = &completedTime
 		status.Results = results
@@ -183,10 +386,14 @@ func (se *SimulationEngine) RunSimulation(runID, gameID string, simulationRuns i
 	}
 	se.mu.Unlock()
 
-	se.updateRunStatus(runID, "completed")
+	if finalStatus == RunStatusPartial {
+		se.recordPartialRun(runID, len(results))
+	} else {
+		se.updateRunStatus(runID, finalStatus)
+	}
 
-	log.Printf("Simulation run %s completed: %d simulations in %v",
-		runID, simulationRuns, time.Since(se.activeRuns[runID].StartTime))
+	log.Printf("Simulation run %s %s: %d of %d simulations in %v",
+		runID, finalStatus, len(results), simulationRuns, time.Since(se.activeRuns[runID].StartTime))
 }
 
 // simulateGame simulates a single baseball game
@@ -196,6 +403,18 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 	// Initialize game state
 	gameState := models.NewGameState(gameData.GameID, runID)
 	gameState.Weather = gameData.Weather
+	gameState.LeagueEnv = gameData.League
+	gameState.ColdWeatherThreshold = se.coldWeatherThreshold
+	gameState.NeutralSite = gameData.NeutralSite()
+	gameState.Indoor = models.RoofKeepsWeatherOut(gameData.Stadium.RoofType)
+	gameState.TuningParams = gameData.Tuning
+	gameState.HomeFormWOBA = gameState.Tuning().FormAdjustment(gameData.HomeForm) + gameData.HomeScenarioWOBA
+	gameState.AwayFormWOBA = gameState.Tuning().FormAdjustment(gameData.AwayForm) + gameData.AwayScenarioWOBA
+
+	// Questionable players sit out games at their play probability
+	playProbability, _ := PlayProbabilityFromConfig(config)
+	homeRoster, homeSidelined := sidelinePlayers(homeRoster, playProbability, rand.Float64)
+	awayRoster, awaySidelined := sidelinePlayers(awayRoster, playProbability, rand.Float64)
 
 	// Initialize lineups
 	homeLineup := se.createLineup(homeRoster)
@@ -222,14 +441,46 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 	}
 
 	var events []models.GameEvent
+	var firstFive *models.ScoreSnapshot
 	pitchCount := 0
+	timesFaced := make(map[models.MatchupKey]int)
 	homeBatterIndex := 0
 	awayBatterIndex := 0
 
 	// Get starting pitchers
 	homePitcher := se.getStartingPitcher(homeRoster)
 	awayPitcher := se.getStartingPitcher(awayRoster)
-	currentPitcher := awayPitcher // Away team pitches first
+	homeStaff := models.NewPitchingStaff(homeRoster, homePitcher)
+	awayStaff := models.NewPitchingStaff(awayRoster, awayPitcher)
+
+	// Starters on short rest or working as openers change the pitching plan
+	starterRoles, _ := StarterRolesFromConfig(config)
+	applyStarterRoles(gameState, starterRoles, homeStaff, awayStaff)
+	var currentPitcher *models.Player
+	var currentStaff *models.PitchingStaff
+
+	// Mid-inning changes for the platoon matchup, when the run allows them
+	platoonChanges := PlatoonChangesFromConfig(config)
+
+	// A long rain delay ends both starters' outings when play resumes
+	rainDelayInning := 0
+	if RainDelaysFromConfig(config) {
+		rainDelayInning = drawStarterRainDelay(gameData.RainRisk, rand.Float64, rand.Intn)
+	}
+
+	// Defensive catchers (nil when the lineup has no catcher; treated as average)
+	homeCatcher := findCatcher(homeLineup)
+	awayCatcher := findCatcher(awayLineup)
+	catcherImpact := &models.CatcherImpactSummary{}
+
+	// Batted-ball outs become errors at a rate set by each defense's fielders
+	homeErrorRate := models.GetFieldingErrorRate(findFielders(homeLineup))
+	awayErrorRate := models.GetFieldingErrorRate(findFielders(awayLineup))
+	defenseSummary := &models.DefenseSummary{}
+
+	// Runners steal at rates set by their speed and the battery holding them
+	speeds := runnerSpeeds(homeLineup, awayLineup)
+	baserunning := &models.BaserunningSummary{}
 
 	// Initialize pitcher stats
 	pitcherStats[homePitcher.ID] = &models.PlayerPitchingStats{
@@ -241,25 +492,78 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 		PlayerName: awayPitcher.Name,
 	}
 
+	// endHalfInning moves to the next half-inning, making pitching changes
+	// between innings
+	endHalfInning := func() {
+		gameState.AdvanceInning()
+
+		if gameState.Inning == rainDelayInning && gameState.InningHalf == "top" {
+			homeStaff.EndStarterOuting()
+			awayStaff.EndStarterOuting()
+		}
+
+		// Pitching changes happen between innings
+		defense := homeStaff
+		if gameState.InningHalf == "bottom" {
+			defense = awayStaff
+		}
+		if defense.NeedsReliever() && defense.ChangePitcher(rand.Float64) {
+			pitcherStats[defense.Current.ID] = &models.PlayerPitchingStats{
+				PlayerID:   defense.Current.ID,
+				PlayerName: defense.Current.Name,
+			}
+		}
+
+		if firstFive == nil && gameState.Inning > models.FirstFiveInnings {
+			firstFive = &models.ScoreSnapshot{HomeScore: gameState.HomeScore, AwayScore: gameState.AwayScore}
+		}
+	}
+
 	// Simulate game
 	for !gameState.IsGameOver() {
 		// Determine current batter and lineup
 		var currentBatter *models.Player
 		var currentLineup []models.Player
 		var batterIndex *int
+		var currentCatcher *models.Player
+		var defenseImpact *models.CatcherImpact
+		var fielding *models.TeamDefense
+		var errorRate float64
+		var running *models.TeamBaserunning
 
 		if gameState.InningHalf == "top" {
 			currentLineup = awayLineup
 			batterIndex = &awayBatterIndex
-			currentPitcher = homePitcher
+			currentStaff = homeStaff
+			currentCatcher = homeCatcher
+			defenseImpact = &catcherImpact.Home
+			fielding = &defenseSummary.Home
+			errorRate = homeErrorRate
+			running = &baserunning.Away
 		} else {
 			currentLineup = homeLineup
 			batterIndex = &homeBatterIndex
-			currentPitcher = awayPitcher
+			currentStaff = awayStaff
+			currentCatcher = awayCatcher
+			defenseImpact = &catcherImpact.Away
+			fielding = &defenseSummary.Away
+			errorRate = awayErrorRate
+			running = &baserunning.Home
 		}
 
 		currentBatter = &currentLineup[*batterIndex]
 
+		// The defense may go to the bullpen for the batters due up
+		if platoonChanges && platoonChangeSituation(gameState) &&
+			currentStaff.PlatoonChange(upcomingBatters(currentLineup, *batterIndex, models.MinBattersFaced), gameState.League(), rand.Float64) {
+			pitcherStats[currentStaff.Current.ID] = &models.PlayerPitchingStats{
+				PlayerID:   currentStaff.Current.ID,
+				PlayerName: currentStaff.Current.Name,
+			}
+		}
+		currentPitcher = currentStaff.Current
+		matchup := models.MatchupKey{BatterID: currentBatter.ID, PitcherID: currentPitcher.ID}
+
 		// Set up at-bat
 		gameState.CurrentAB = models.AtBat{
 			BatterID:    currentBatter.ID,
@@ -270,15 +574,48 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 			PitcherHand: currentPitcher.Hand,
 			PitchCount:  0,
 			Leverage:    gameState.CalculateLeverage(),
+			TimesFaced:  timesFaced[matchup],
 		}
 
-		// Simulate at-bat with full context (umpire, park factors, stadium)
-		atBatResult := se.simulateAtBatWithContext(currentBatter, currentPitcher, gameState, gameData)
-		atBatPitches := rand.Intn(6) + 3 // 3-8 pitches per at-bat
+		// The lead runner may try to steal; a runner caught for the third out
+		// ends the half-inning with the batter leading off the next
+		if _, caught := simulateStealAttempt(&gameState.Bases, speeds, currentPitcher, currentCatcher, running, rand.Float64); caught {
+			gameState.Outs++
+			fielding.Outs++
+			pitcherStats[currentPitcher.ID].IP += 1.0 / 3.0
+			currentStaff.RecordOut()
+			if gameState.IsInningOver() {
+				endHalfInning()
+				continue
+			}
+		}
+
+		// Wild pitches and passed balls can move runners before the plate appearance ends
+		if batteryRuns, passedBall, ok := se.simulateBatteryError(gameState, currentCatcher, defenseImpact); ok {
+			earned := batteryRuns
+			if passedBall {
+				earned = 0 // Runs scoring on passed balls are unearned
+			}
+			pitcherStats[currentPitcher.ID].R += float64(batteryRuns)
+			pitcherStats[currentPitcher.ID].ER += float64(earned)
+			gameState.AddRuns(batteryRuns)
+			if gameState.IsGameOver() {
+				break
+			}
+		}
+
+		// Simulate at-bat with full context (umpire, park factors, stadium, catcher)
+		atBatResult := se.simulateAtBatWithContext(currentBatter, currentPitcher, currentCatcher, gameState, gameData)
+		if atBatResult.Type == "out" && rand.Float64() < errorRate {
+			atBatResult = reachedOnError(atBatResult)
+		}
+		atBatPitches := models.PitchesForOutcome(atBatResult.Type, rand.Intn)
 		pitchCount += atBatPitches
+		defenseImpact.FramingRuns += atBatResult.FramingRuns
 
 		// Process at-bat result
 		runs, outs := se.processAtBatResult(gameState, atBatResult)
+		recordDefense(fielding, atBatResult, outs)
 
 		// Track batter stats
 		se.updateBatterStats(batterStats[currentBatter.ID], atBatResult, runs)
@@ -298,6 +635,7 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 			Runs:        runs,
 			Outs:        outs,
 			Leverage:    atBatResult.Leverage,
+			BattedBall:  atBatResult.BattedBall,
 			Timestamp:   time.Now(),
 		}
 
@@ -307,6 +645,8 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 		}
 
 		// Update game state
+		currentStaff.Record(outs, atBatPitches)
+		timesFaced[matchup]++
 		gameState.Outs += outs
 		gameState.AddRuns(runs)
 
@@ -315,7 +655,7 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 
 		// Check if inning is over
 		if gameState.IsInningOver() {
-			gameState.AdvanceInning()
+			endHalfInning()
 		}
 
 		// Reset count for next at-bat
@@ -330,12 +670,21 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 		winner = "away"
 	}
 
-	// Calculate game duration (rough estimate)
-	baseDuration := 150 + rand.Intn(60) // 150-210 minutes
-	if gameState.Inning > 9 {
-		baseDuration += (gameState.Inning - 9) * 20 // Extra innings
+	pitchingChanges := &models.PitchingChanges{
+		Home:          len(homeStaff.Used) - 1,
+		Away:          len(awayStaff.Used) - 1,
+		HomeMidInning: homeStaff.PlatoonChanges,
+		AwayMidInning: awayStaff.PlatoonChanges,
 	}
 
+	// Game length follows from its pace
+	gameDuration := gameData.Duration.Estimate(models.GamePace{
+		Pitches:         pitchCount,
+		Runs:            gameState.HomeScore + gameState.AwayScore,
+		PitchingChanges: pitchingChanges.Total(),
+		Innings:         gameState.Inning,
+	})
+
 	gameState.IsComplete = true
 	gameState.WinnerTeam = winner
 
@@ -363,11 +712,15 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 
 	homePitching := make(map[string]*models.PlayerGamePitching)
 	awayPitching := make(map[string]*models.PlayerGamePitching)
-	if stats, ok := pitcherStats[homePitcher.ID]; ok {
-		homePitching[homePitcher.ID] = se.convertToGamePitching(stats)
+	for _, pitcher := range homeStaff.Used {
+		if stats, ok := pitcherStats[pitcher.ID]; ok {
+			homePitching[pitcher.ID] = se.convertToGamePitching(stats)
+		}
 	}
-	if stats, ok := pitcherStats[awayPitcher.ID]; ok {
-		awayPitching[awayPitcher.ID] = se.convertToGamePitching(stats)
+	for _, pitcher := range awayStaff.Used {
+		if stats, ok := pitcherStats[pitcher.ID]; ok {
+			awayPitching[pitcher.ID] = se.convertToGamePitching(stats)
+		}
 	}
 
 	return models.SimulationResult{
@@ -377,7 +730,7 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 		AwayScore:        gameState.AwayScore,
 		Winner:           winner,
 		TotalPitches:     pitchCount,
-		GameDuration:     baseDuration,
+		GameDuration:     gameDuration,
 		KeyEvents:        events,
 		FinalState:       *gameState,
 		CreatedAt:        time.Now(),
@@ -387,6 +740,12 @@ func (se *SimulationEngine) simulateGame(runID string, simNumber int, gameData *
 			HomePitching: homePitching,
 			AwayPitching: awayPitching,
 		},
+		CatcherImpact:   catcherImpact,
+		Defense:         defenseSummary,
+		Baserunning:     baserunning,
+		FirstFive:       firstFive,
+		PitchingChanges: pitchingChanges,
+		Sidelined:       append(homeSidelined, awaySidelined...),
 	}
 }
 
@@ -397,7 +756,7 @@ func (se *SimulationEngine) simulateAtBat(batter, pitcher *models.Player, gameSt
 }
 
 // simulateAtBatWithContext simulates a plate appearance with full game context
-func (se *SimulationEngine) simulateAtBatWithContext(batter, pitcher *models.Player, gameState *models.GameState, gameData *GameData) models.AtBatResult {
+func (se *SimulationEngine) simulateAtBatWithContext(batter, pitcher, catcher *models.Player, gameState *models.GameState, gameData *GameData) models.AtBatResult {
 	// Apply altitude effect to home run probability
 	altitude := gameData.Stadium.Altitude
 	if altitude > 1000 {
@@ -407,8 +766,9 @@ func (se *SimulationEngine) simulateAtBatWithContext(batter, pitcher *models.Pla
 	}
 
 	// Call player's at-bat simulation with full context
-	return batter.SimulateAtBatWithContext(
+	return batter.SimulateAtBatWithDefense(
 		pitcher,
+		catcher,
 		gameState,
 		gameState.Weather,
 		&gameData.Umpire.Tendencies,
@@ -420,6 +780,7 @@ func (se *SimulationEngine) simulateAtBatWithContext(batter, pitcher *models.Pla
 // convertToWeatherStadiumInfo converts stadium data to weather service format
 func (se *SimulationEngine) convertToWeatherStadiumInfo(stadium StadiumData) weather.StadiumInfo {
 	return weather.StadiumInfo{
+		ID:        stadium.ID,
 		Name:      stadium.Name,
 		Location:  stadium.Location,
 		Latitude:  stadium.Latitude,
@@ -432,7 +793,8 @@ func (se *SimulationEngine) convertToWeatherStadiumInfo(stadium StadiumData) wea
 // processAtBatResult updates the game state based on the at-bat outcome
 func (se *SimulationEngine) processAtBatResult(gameState *models.GameState, result models.AtBatResult) (runs, outs int) {
 	switch result.Type {
-	case "single":
+	case "single", "error":
+		// Runners advance on an error as on a single
 		return se.processSingle(gameState)
 	case "double":
 		return se.processDouble(gameState)
@@ -601,6 +963,40 @@ func (se *SimulationEngine) processWalk(gameState *models.GameState) (runs, outs
 	return runs, 0
 }
 
+// simulateBatteryError rolls for a wild pitch or passed ball with runners on
+// base. Every runner advances one base; the catcher's blocking sets the rate.
+// Returns ok=false when no battery error occurred.
+func (se *SimulationEngine) simulateBatteryError(gameState *models.GameState, catcher *models.Player,
+	impact *models.CatcherImpact) (runs int, passedBall bool, ok bool) {
+
+	bases := &gameState.Bases
+	if bases.First == nil && bases.Second == nil && bases.Third == nil {
+		return 0, false, false
+	}
+
+	impact.BlockingRuns += models.GetBlockingRunsSaved(catcher)
+
+	if rand.Float64() >= models.GetBatteryErrorRate(catcher) {
+		return 0, false, false
+	}
+
+	passedBall = rand.Float64() < models.GetPassedBallShare(catcher)
+	if passedBall {
+		impact.PassedBalls++
+	} else {
+		impact.WildPitches++
+	}
+
+	if bases.Third != nil {
+		runs++
+	}
+	bases.Third = bases.Second
+	bases.Second = bases.First
+	bases.First = nil
+
+	return runs, passedBall, true
+}
+
 // GameData represents the basic game information needed for simulation
 type GameData struct {
 	GameID       string
@@ -611,6 +1007,21 @@ type GameData struct {
 	GameTime     time.Time
 	Stadium      StadiumData
 	Umpire       UmpireData
+	League       *models.LeagueEnvironment
+	Duration     *models.DurationModel
+	Tuning       *models.TuningParameters
+	HomeForm     *models.TeamForm // nil unless the form prior is enabled
+	AwayForm     *models.TeamForm
+	RainRisk     models.RainRisk
+
+	// HomeScenarioWOBA and AwayScenarioWOBA shift each side's batters in a
+	// scenario band variant (0 in the run itself)
+	HomeScenarioWOBA float64
+	AwayScenarioWOBA float64
+
+	// HomeStadiumID is the home team's own park; Stadium differs from it at
+	// neutral sites
+	HomeStadiumID string
 }
 
 // StadiumData contains stadium information for simulation
@@ -638,6 +1049,11 @@ type UmpireData struct {
 func (se *SimulationEngine) updateBatterStats(stats *models.PlayerBattingStats, result models.AtBatResult, runsScored int) {
 	stats.PA++ // Every at-bat is a plate appearance
 
+	// Expected stats credit contact quality regardless of the outcome
+	xHits, xWOBA := models.ExpectedValue(result)
+	stats.XH += xHits
+	stats.XWOBAValue += xWOBA
+
 	switch result.Type {
 	case "single":
 		stats.AB++
@@ -666,7 +1082,8 @@ func (se *SimulationEngine) updateBatterStats(stats *models.PlayerBattingStats,
 	case "strikeout":
 		stats.AB++
 		stats.K++
-	case "out":
+	case "out", "error":
+		// Reaching on an error is an at-bat without a hit
 		stats.AB++
 	}
 }
@@ -691,9 +1108,11 @@ func (se *SimulationEngine) updatePitcherStats(stats *models.PlayerPitchingStats
 		}
 	}
 
-	// Track runs allowed (these are assumed to be earned)
+	// Track runs allowed; runs scoring on an error are unearned
 	stats.R += float64(runsAllowed)
-	stats.ER += float64(runsAllowed)
+	if result.Type != "error" {
+		stats.ER += float64(runsAllowed)
+	}
 }
 
 // calculateDerivedBattingStats calculates AVG, OBP, SLG from counting stats
@@ -704,11 +1123,13 @@ func (se *SimulationEngine) calculateDerivedBattingStats(stats *models.PlayerBat
 		// Calculate total bases for SLG
 		totalBases := stats.Singles + (stats.Doubles * 2) + (stats.Triples * 3) + (stats.HR * 4)
 		stats.SLG = totalBases / stats.AB
+		stats.XBA = stats.XH / stats.AB
 	}
 
 	if stats.PA > 0 {
 		// OBP = (H + BB) / PA
 		stats.OBP = (stats.H + stats.BB) / stats.PA
+		stats.XWOBA = stats.XWOBAValue / stats.PA
 	}
 }
 
@@ -738,6 +1159,9 @@ func (se *SimulationEngine) convertToGameBatting(stats *models.PlayerBattingStat
 		R:        int(stats.R),
 		BB:       int(stats.BB),
 		K:        int(stats.K),
+
+		XH:         stats.XH,
+		XWOBAValue: stats.XWOBAValue,
 	}
 }
 
@@ -0,0 +1,152 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"sim-engine/models"
+)
+
+// ArchivedEvent is a high-leverage key event from the simulation_events
+// archive, which holds every stored result's events with leverage of at
+// least HighLeverageThreshold
+type ArchivedEvent struct {
+	RunID            string    `json:"run_id"`
+	GameID           *string   `json:"game_id"`
+	SimulationNumber int       `json:"simulation_number"`
+	Type             string    `json:"type"`
+	Inning           int       `json:"inning"`
+	InningHalf       string    `json:"inning_half"`
+	BatterID         string    `json:"batter_id,omitempty"`
+	PitcherID        string    `json:"pitcher_id,omitempty"`
+	Result           string    `json:"result,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	Runs             int       `json:"runs"`
+	Outs             int       `json:"outs"`
+	Leverage         float64   `json:"leverage"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// EventArchiveFilter selects archived events. Empty fields don't filter.
+type EventArchiveFilter struct {
+	GameID      string // internal UUID
+	RunID       string
+	EventType   string
+	Inning      int
+	MinLeverage float64
+	Limit       int
+}
+
+// query builds the archive query for the filter, highest leverage first
+func (f EventArchiveFilter) query() (string, []interface{}) {
+	conditions := []string{"leverage >= $1"}
+	args := []interface{}{f.MinLeverage}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if f.GameID != "" {
+		add("game_id = $%d", f.GameID)
+	}
+	if f.RunID != "" {
+		add("run_id = $%d", f.RunID)
+	}
+	if f.EventType != "" {
+		add("event_type = $%d", f.EventType)
+	}
+	if f.Inning > 0 {
+		add("inning = $%d", f.Inning)
+	}
+	args = append(args, f.Limit)
+
+	return fmt.Sprintf(`
+		SELECT run_id::text, game_id::text, simulation_number, event_type, inning, inning_half,
+		       COALESCE(batter_id, ''), COALESCE(pitcher_id, ''), COALESCE(result, ''),
+		       COALESCE(description, ''), runs, outs, leverage, created_at
+		FROM simulation_events
+		WHERE %s
+		ORDER BY leverage DESC, created_at DESC, id
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), len(args)), args
+}
+
+// ArchivedLeverageEvents returns archived events matching the filter
+func (se *SimulationEngine) ArchivedLeverageEvents(ctx context.Context, filter EventArchiveFilter) ([]ArchivedEvent, error) {
+	query, args := filter.query()
+	rows, err := se.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query simulation events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []ArchivedEvent{}
+	for rows.Next() {
+		var event ArchivedEvent
+		if err := rows.Scan(&event.RunID, &event.GameID, &event.SimulationNumber, &event.Type,
+			&event.Inning, &event.InningHalf, &event.BatterID, &event.PitcherID, &event.Result,
+			&event.Description, &event.Runs, &event.Outs, &event.Leverage, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan simulation event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// archivableEvents returns a result's key events worth archiving
+func archivableEvents(events []models.GameEvent) []models.GameEvent {
+	var archived []models.GameEvent
+	for _, event := range events {
+		if event.Leverage >= HighLeverageThreshold {
+			archived = append(archived, event)
+		}
+	}
+	return archived
+}
+
+// archiveLeverageEvents writes a result's high-leverage events to the
+// archive, taking the game from the result's run
+func archiveLeverageEvents(ctx context.Context, tx pgx.Tx, result models.SimulationResult) error {
+	events := archivableEvents(result.KeyEvents)
+	if len(events) == 0 {
+		return nil
+	}
+
+	n := len(events)
+	types, halves := make([]string, n), make([]string, n)
+	batters, pitchers := make([]string, n), make([]string, n)
+	results, descriptions := make([]string, n), make([]string, n)
+	innings, runs, outs := make([]int32, n), make([]int32, n), make([]int32, n)
+	leverages := make([]float64, n)
+	for i, event := range events {
+		types[i], halves[i] = event.Type, event.InningHalf
+		batters[i], pitchers[i] = event.BatterID, event.PitcherID
+		results[i], descriptions[i] = event.Result, event.Description
+		innings[i], runs[i], outs[i] = int32(event.Inning), int32(event.Runs), int32(event.Outs)
+		leverages[i] = event.Leverage
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO simulation_events (
+			run_id, game_id, simulation_number, event_type, inning, inning_half,
+			batter_id, pitcher_id, result, description, runs, outs, leverage, created_at
+		)
+		SELECT r.id, r.game_id, $2, e.event_type, e.inning, e.inning_half,
+		       NULLIF(e.batter_id, ''), NULLIF(e.pitcher_id, ''), NULLIF(e.result, ''),
+		       NULLIF(e.description, ''), e.runs, e.outs, e.leverage, $3
+		FROM simulation_runs r,
+		     unnest($4::text[], $5::int[], $6::text[], $7::text[], $8::text[], $9::text[],
+		            $10::text[], $11::int[], $12::int[], $13::float8[])
+		         AS e(event_type, inning, inning_half, batter_id, pitcher_id, result,
+		              description, runs, outs, leverage)
+		WHERE r.id = $1
+	`, result.RunID, result.SimulationNumber, result.CreatedAt,
+		types, innings, halves, batters, pitchers, results, descriptions, runs, outs, leverages)
+	if err != nil {
+		return fmt.Errorf("failed to archive leverage events: %w", err)
+	}
+	return nil
+}
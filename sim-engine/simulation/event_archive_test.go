@@ -0,0 +1,50 @@
+package simulation
+
+import (
+	"strings"
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestArchivableEvents(t *testing.T) {
+	events := []models.GameEvent{
+		{Type: "home_run", Leverage: 3.1},
+		{Type: "strikeout", Leverage: 1.2},
+		{Type: "walk", Leverage: HighLeverageThreshold},
+	}
+
+	archived := archivableEvents(events)
+
+	if len(archived) != 2 || archived[0].Type != "home_run" || archived[1].Type != "walk" {
+		t.Errorf("archived = %+v, want the home run and the walk at the threshold", archived)
+	}
+	if archivableEvents(events[1:2]) != nil {
+		t.Error("low-leverage events should not be archived")
+	}
+}
+
+func TestEventArchiveFilterQuery(t *testing.T) {
+	query, args := EventArchiveFilter{MinLeverage: 2.5, Limit: 100}.query()
+	if !strings.Contains(query, "WHERE leverage >= $1\n") || !strings.Contains(query, "LIMIT $2") {
+		t.Errorf("unexpected query for leverage alone:\n%s", query)
+	}
+	if len(args) != 2 || args[0] != 2.5 || args[1] != 100 {
+		t.Errorf("args = %v", args)
+	}
+
+	query, args = EventArchiveFilter{
+		GameID:      "game-uuid",
+		EventType:   "home_run",
+		Inning:      9,
+		MinLeverage: 2,
+		Limit:       10,
+	}.query()
+	want := "WHERE leverage >= $1 AND game_id = $2 AND event_type = $3 AND inning = $4"
+	if !strings.Contains(query, want) || !strings.Contains(query, "LIMIT $5") {
+		t.Errorf("query should contain %q and LIMIT $5:\n%s", want, query)
+	}
+	if len(args) != 5 || args[1] != "game-uuid" || args[2] != "home_run" || args[3] != 9 || args[4] != 10 {
+		t.Errorf("args = %v", args)
+	}
+}
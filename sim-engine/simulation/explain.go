@@ -0,0 +1,230 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"sim-engine/models"
+)
+
+// Inputs that can differ between two runs of the same game
+const (
+	FactorLineup        = "lineup"
+	FactorWeather       = "weather"
+	FactorStarter       = "starter"
+	FactorEngineVersion = "engine_version"
+)
+
+// FactorAttribution is one changed input and how much of the win probability
+// move it accounts for
+type FactorAttribution struct {
+	Factor   string      `json:"factor"`
+	Baseline interface{} `json:"baseline"`
+	Current  interface{} `json:"current"`
+	// Impact is the current run's quick-check home win probability minus the
+	// same check with only this factor reverted; nil when it can't be re-run
+	Impact *float64 `json:"home_win_probability_impact,omitempty"`
+	Note   string   `json:"note,omitempty"`
+}
+
+// DeltaExplanation attributes a win probability change to changed inputs
+type DeltaExplanation struct {
+	QuickSimulations      int                 `json:"quick_simulations"`
+	QuickWinProbability   float64             `json:"quick_home_win_probability"`
+	QuickCheckMargin      float64             `json:"quick_check_margin"` // 95% margin on each factor's impact
+	Factors               []FactorAttribution `json:"factors"`
+	ExplainedDelta        float64             `json:"explained_delta"`
+	UnchangedInputsReason string              `json:"unchanged_inputs_reason,omitempty"`
+}
+
+// ChangedFactors lists the inputs that differ between two runs
+func ChangedFactors(baseline, current RunInputs) []string {
+	var changed []string
+	if !sameIDs(baseline.HomeLineup, current.HomeLineup) || !sameIDs(baseline.AwayLineup, current.AwayLineup) {
+		changed = append(changed, FactorLineup)
+	}
+	if baseline.Weather != current.Weather {
+		changed = append(changed, FactorWeather)
+	}
+	if baseline.HomeStarterID != current.HomeStarterID || baseline.AwayStarterID != current.AwayStarterID {
+		changed = append(changed, FactorStarter)
+	}
+	if baseline.EngineVersion != current.EngineVersion || baseline.ModelParamHash != current.ModelParamHash {
+		changed = append(changed, FactorEngineVersion)
+	}
+	return changed
+}
+
+// ExplainDelta re-runs quick simulations of a game with the current run's
+// inputs, then again with each changed factor swapped back to the baseline
+// one at a time. A factor's impact is how far reverting it moves the home win
+// probability. Engine version changes can't be re-run and are reported
+// without an impact.
+func (se *SimulationEngine) ExplainDelta(ctx context.Context, gameID string, baseline, current RunInputs,
+	simulations int) (*DeltaExplanation, error) {
+
+	explanation := &DeltaExplanation{QuickSimulations: simulations, Factors: []FactorAttribution{}}
+
+	changed := ChangedFactors(baseline, current)
+	if len(changed) == 0 {
+		explanation.UnchangedInputsReason = "Both runs used the same inputs; the difference is simulation noise or data refreshed between runs"
+		return explanation, nil
+	}
+
+	currentGame, currentHome, currentAway, err := se.loadRunScenario(ctx, gameID, current)
+	if err != nil {
+		return nil, err
+	}
+
+	currentProb, err := se.quickWinProbability(ctx, &currentGame, currentHome, currentAway, simulations)
+	if err != nil {
+		return nil, err
+	}
+	explanation.QuickWinProbability = currentProb
+	// Each impact is the difference of two independent estimates
+	explanation.QuickCheckMargin = models.WinProbabilityMargin(currentProb, simulations) * math.Sqrt2
+
+	for _, factor := range changed {
+		attribution := FactorAttribution{Factor: factor}
+		game, home, away := currentGame, currentHome, currentAway
+
+		switch factor {
+		case FactorLineup:
+			attribution.Baseline = map[string][]string{"home": baseline.HomeLineup, "away": baseline.AwayLineup}
+			attribution.Current = map[string][]string{"home": current.HomeLineup, "away": current.AwayLineup}
+			home = withLineup(home, baseline.HomeLineup)
+			away = withLineup(away, baseline.AwayLineup)
+		case FactorWeather:
+			attribution.Baseline = baseline.Weather
+			attribution.Current = current.Weather
+			game.Weather = baseline.Weather
+		case FactorStarter:
+			attribution.Baseline = map[string]string{"home": baseline.HomeStarterID, "away": baseline.AwayStarterID}
+			attribution.Current = map[string]string{"home": current.HomeStarterID, "away": current.AwayStarterID}
+			homeStarter, homeOK := withStarter(home, baseline.HomeStarterID)
+			awayStarter, awayOK := withStarter(away, baseline.AwayStarterID)
+			if !homeOK || !awayOK {
+				attribution.Note = "Baseline starter is no longer on the roster"
+				explanation.Factors = append(explanation.Factors, attribution)
+				continue
+			}
+			home, away = homeStarter, awayStarter
+		case FactorEngineVersion:
+			attribution.Baseline = map[string]string{"engine_version": baseline.EngineVersion, "model_param_hash": baseline.ModelParamHash}
+			attribution.Current = map[string]string{"engine_version": current.EngineVersion, "model_param_hash": current.ModelParamHash}
+			attribution.Note = "Earlier engine versions and model parameters can't be re-run; their effect is part of the unexplained delta"
+			explanation.Factors = append(explanation.Factors, attribution)
+			continue
+		}
+
+		swappedProb, err := se.quickWinProbability(ctx, &game, home, away, simulations)
+		if err != nil {
+			return nil, err
+		}
+		impact := math.Round((currentProb-swappedProb)*10000) / 10000
+		attribution.Impact = &impact
+		explanation.ExplainedDelta += impact
+		explanation.Factors = append(explanation.Factors, attribution)
+	}
+
+	// Largest movers first; factors without an impact go last
+	sort.SliceStable(explanation.Factors, func(i, j int) bool {
+		a, b := explanation.Factors[i].Impact, explanation.Factors[j].Impact
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return math.Abs(*a) > math.Abs(*b)
+	})
+	explanation.ExplainedDelta = math.Round(explanation.ExplainedDelta*10000) / 10000
+
+	return explanation, nil
+}
+
+// loadRunScenario rebuilds a run's game and rosters from today's data with the
+// run's recorded weather, lineups and starters
+func (se *SimulationEngine) loadRunScenario(ctx context.Context, gameID string, inputs RunInputs) (GameData,
+	*models.Roster, *models.Roster, error) {
+
+	gameData, err := se.loadGameData(ctx, gameID)
+	if err != nil {
+		return GameData{}, nil, nil, err
+	}
+	gameData.League = se.loadLeagueEnvironment(ctx, gameData.Date.Year())
+	homeRoster, awayRoster, err := se.loadTeamRosters(ctx, gameData.HomeTeamID, gameData.AwayTeamID, gameData.League)
+	if err != nil {
+		return GameData{}, nil, nil, err
+	}
+
+	game := *gameData
+	game.Weather = inputs.Weather
+	home := withLineup(homeRoster, inputs.HomeLineup)
+	away := withLineup(awayRoster, inputs.AwayLineup)
+	if starter, ok := withStarter(home, inputs.HomeStarterID); ok {
+		home = starter
+	}
+	if starter, ok := withStarter(away, inputs.AwayStarterID); ok {
+		away = starter
+	}
+	return game, home, away, nil
+}
+
+// quickWinProbability simulates a scenario across the engine's workers and
+// returns the home win probability
+func (se *SimulationEngine) quickWinProbability(ctx context.Context, gameData *GameData,
+	homeRoster, awayRoster *models.Roster, simulations int) (float64, error) {
+
+	workers := se.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	var homeWins, played int64
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			var wins, games int64
+			for n := worker; n < simulations; n += workers {
+				if ctx.Err() != nil {
+					break
+				}
+				result := se.simulateGame("", n+1, gameData, homeRoster, awayRoster, nil)
+				games++
+				if result.Winner == "home" {
+					wins++
+				}
+			}
+			mu.Lock()
+			homeWins += wins
+			played += games
+			mu.Unlock()
+		}(w)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("quick simulations interrupted: %w", err)
+	}
+	if played == 0 {
+		return 0, fmt.Errorf("no quick simulations ran")
+	}
+	return float64(homeWins) / float64(played), nil
+}
+
+// sameIDs reports whether two batting orders match
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
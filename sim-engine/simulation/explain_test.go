@@ -0,0 +1,60 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestChangedFactors(t *testing.T) {
+	baseline := RunInputs{
+		EngineVersion: "1.0.0",
+		Weather:       models.Weather{Temperature: 72, WindSpeed: 5, WindDir: "in"},
+		HomeStarterID: "p1",
+		AwayStarterID: "p2",
+		HomeLineup:    []string{"a", "b", "c"},
+		AwayLineup:    []string{"x", "y", "z"},
+	}
+
+	if changed := ChangedFactors(baseline, baseline); len(changed) != 0 {
+		t.Errorf("Expected no changed factors, got %v", changed)
+	}
+
+	current := baseline
+	current.Weather.WindDir = "out"
+	current.AwayLineup = []string{"y", "x", "z"}
+	current.EngineVersion = "1.1.0"
+
+	changed := ChangedFactors(baseline, current)
+	expected := []string{FactorLineup, FactorWeather, FactorEngineVersion}
+	if len(changed) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, changed)
+	}
+	for i := range expected {
+		if changed[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, changed)
+		}
+	}
+}
+
+func TestWithStarter(t *testing.T) {
+	roster := &models.Roster{
+		Players:  []models.Player{{ID: "p1"}, {ID: "p2"}, {ID: "p3"}},
+		Rotation: []string{"p1", "p2", "p3"},
+	}
+
+	swapped, ok := withStarter(roster, "p3")
+	if !ok {
+		t.Fatal("Expected p3 to be found on the roster")
+	}
+	if swapped.Rotation[0] != "p3" || len(swapped.Rotation) != 3 {
+		t.Errorf("Expected p3 to lead a three man rotation, got %v", swapped.Rotation)
+	}
+	if roster.Rotation[0] != "p1" {
+		t.Errorf("Expected original rotation untouched, got %v", roster.Rotation)
+	}
+
+	if _, ok := withStarter(roster, "traded"); ok {
+		t.Error("Expected a starter missing from the roster to be rejected")
+	}
+}
@@ -0,0 +1,59 @@
+package simulation
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"sim-engine/models"
+)
+
+// loadTeamForm returns a team's record over its last FormGames completed
+// regular-season games before a date
+func (se *SimulationEngine) loadTeamForm(ctx context.Context, teamID string, before time.Time) (*models.TeamForm, error) {
+	form := &models.TeamForm{}
+	err := se.db.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE runs_for > runs_against),
+		       COUNT(*) FILTER (WHERE runs_for < runs_against)
+		FROM (
+			SELECT CASE WHEN g.home_team_id::text = $1 THEN g.final_score_home ELSE g.final_score_away END AS runs_for,
+			       CASE WHEN g.home_team_id::text = $1 THEN g.final_score_away ELSE g.final_score_home END AS runs_against
+			FROM games g
+			WHERE (g.home_team_id::text = $1 OR g.away_team_id::text = $1)
+			  AND g.game_date < $2
+			  AND g.status = 'completed'
+			  AND g.final_score_home IS NOT NULL
+			  AND g.final_score_away IS NOT NULL
+			  AND (g.game_type IS NULL OR g.game_type IN ('R', 'regular'))
+			ORDER BY g.game_date DESC, g.game_number DESC
+			LIMIT $3
+		) recent
+	`, teamID, before, models.FormGames).Scan(&form.Wins, &form.Losses)
+	if err != nil {
+		return nil, err
+	}
+	return form, nil
+}
+
+// applyFormPrior loads both teams' recent form before a date (the game's, or
+// a replay's as_of date) when the form prior is enabled
+func (se *SimulationEngine) applyFormPrior(ctx context.Context, gameData *GameData, before time.Time) {
+	if gameData.Tuning == nil || gameData.Tuning.FormWOBA == 0 {
+		return
+	}
+
+	for _, side := range []struct {
+		teamID string
+		form   **models.TeamForm
+	}{
+		{gameData.HomeTeamID, &gameData.HomeForm},
+		{gameData.AwayTeamID, &gameData.AwayForm},
+	} {
+		form, err := se.loadTeamForm(ctx, side.teamID, before)
+		if err != nil {
+			log.Printf("Skipping form prior for team %s: %v", side.teamID, err)
+			continue
+		}
+		*side.form = form
+	}
+}
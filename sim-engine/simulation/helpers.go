@@ -15,24 +15,23 @@ import (
 // loadGameData retrieves game information from the database
 func (se *SimulationEngine) loadGameData(ctx context.Context, gameID string) (*GameData, error) {
 	var gameData GameData
-	var weatherJSON, dimensionsJSON, parkFactorsJSON, umpireTendenciesJSON []byte
+	var weatherJSON, umpireTendenciesJSON []byte
 	var gameTime *time.Time
 
 	query := `
 		SELECT g.game_id, g.home_team_id, g.away_team_id, g.game_date, g.game_time,
-		       g.weather_data,
-		       s.id, s.name, s.location, s.latitude, s.longitude, s.altitude, s.surface, s.roof_type,
-		       s.dimensions, s.park_factors,
+		       g.weather_data, ht.stadium_id::text,
+		       ` + stadiumColumns + `,
 		       u.id, u.name, u.tendencies
 		FROM games g
+		LEFT JOIN teams ht ON g.home_team_id = ht.id
 		LEFT JOIN stadiums s ON g.stadium_id = s.id
 		LEFT JOIN umpires u ON g.home_plate_umpire_id = u.id
 		WHERE g.game_id = $1
 	`
 
-	var stadiumID, stadiumName, stadiumLocation, stadiumSurface, stadiumRoofType *string
-	var stadiumLatitude, stadiumLongitude *float64
-	var stadiumAltitude *int
+	var homeStadiumID *string
+	var stadium stadiumRow
 	var umpireID, umpireName *string
 
 	err := se.db.QueryRow(ctx, query, gameID).Scan(
@@ -42,16 +41,17 @@ func (se *SimulationEngine) loadGameData(ctx context.Context, gameID string) (*G
 		&gameData.Date,
 		&gameTime,
 		&weatherJSON,
-		&stadiumID,
-		&stadiumName,
-		&stadiumLocation,
-		&stadiumLatitude,
-		&stadiumLongitude,
-		&stadiumAltitude,
-		&stadiumSurface,
-		&stadiumRoofType,
-		&dimensionsJSON,
-		&parkFactorsJSON,
+		&homeStadiumID,
+		&stadium.id,
+		&stadium.name,
+		&stadium.location,
+		&stadium.latitude,
+		&stadium.longitude,
+		&stadium.altitude,
+		&stadium.surface,
+		&stadium.roofType,
+		&stadium.dimensionsJSON,
+		&stadium.parkFactorsJSON,
 		&umpireID,
 		&umpireName,
 		&umpireTendenciesJSON,
@@ -84,55 +84,12 @@ func (se *SimulationEngine) loadGameData(ctx context.Context, gameID string) (*G
 		)
 	}
 
-	// Parse stadium data
-	if stadiumID != nil {
-		gameData.Stadium.ID = *stadiumID
-	}
-	if stadiumName != nil {
-		gameData.Stadium.Name = *stadiumName
-	}
-	if stadiumLocation != nil {
-		gameData.Stadium.Location = *stadiumLocation
-	}
-	if stadiumLatitude != nil {
-		gameData.Stadium.Latitude = *stadiumLatitude
-	}
-	if stadiumLongitude != nil {
-		gameData.Stadium.Longitude = *stadiumLongitude
-	}
-	if stadiumAltitude != nil {
-		gameData.Stadium.Altitude = *stadiumAltitude
-	}
-	if stadiumSurface != nil {
-		gameData.Stadium.Surface = *stadiumSurface
-	}
-	if stadiumRoofType != nil {
-		gameData.Stadium.RoofType = *stadiumRoofType
-	}
-
-	// Parse stadium dimensions
-	if len(dimensionsJSON) > 0 {
-		if err := json.Unmarshal(dimensionsJSON, &gameData.Stadium.Dimensions); err != nil {
-			log.Printf("Failed to parse stadium dimensions: %v", err)
-			gameData.Stadium.Dimensions = models.DefaultDimensions()
-		}
-	} else {
-		gameData.Stadium.Dimensions = models.DefaultDimensions()
-	}
-
-	// Parse park factors
-	if len(parkFactorsJSON) > 0 {
-		if err := json.Unmarshal(parkFactorsJSON, &gameData.Stadium.ParkFactors); err != nil {
-			log.Printf("Failed to parse park factors: %v", err)
-			gameData.Stadium.ParkFactors = models.DefaultParkFactors()
-		}
-	} else {
-		gameData.Stadium.ParkFactors = models.DefaultParkFactors()
+	// The game's venue, which for neutral-site games isn't the home team's park
+	gameData.Stadium = stadium.toStadiumData()
+	if homeStadiumID != nil {
+		gameData.HomeStadiumID = *homeStadiumID
 	}
 
-	// Coordinates are now loaded directly from the database query above (lines 98-103)
-	// No need to call getStadiumCoordinates anymore
-
 	// Parse umpire data
 	if umpireID != nil {
 		gameData.Umpire.ID = *umpireID
@@ -160,6 +117,9 @@ func (se *SimulationEngine) loadGameData(ctx context.Context, gameID string) (*G
 		}
 	}
 
+	// Every game in the run uses the calibration active when it was loaded
+	gameData.Tuning = models.CurrentTuningParameters()
+
 	return &gameData, nil
 }
 
@@ -199,14 +159,48 @@ func (se *SimulationEngine) getStadiumCoordinates(ctx context.Context, stadiumID
 	return 0, 0
 }
 
-// loadTeamRosters loads the rosters for both teams
-func (se *SimulationEngine) loadTeamRosters(ctx context.Context, homeTeamID, awayTeamID string) (*models.Roster, *models.Roster, error) {
-	homeRoster, err := se.loadTeamRoster(ctx, homeTeamID)
+// prepareGameData loads a game and everything a run needs around it: the
+// weather forecast, the season's league environment and duration model, and
+// team form when the form prior is enabled
+func (se *SimulationEngine) prepareGameData(ctx context.Context, gameID string) (*GameData, error) {
+	gameData, err := se.loadGameData(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch real-time weather if weather service is available
+	if se.weatherService != nil && gameData.Stadium.Name != "" {
+		// Convert stadium info for weather service
+		stadiumInfo := se.convertToWeatherStadiumInfo(gameData.Stadium)
+
+		weather, err := se.weatherService.GetWeatherForGame(ctx, stadiumInfo, gameData.GameTime)
+		if err != nil {
+			log.Printf("Failed to fetch weather for %s: %v, using default", gameData.Stadium.Name, err)
+		} else {
+			gameData.Weather = weather
+			log.Printf("Fetched weather for %s: %d°F, wind %d mph %s",
+				gameData.Stadium.Name, weather.Temperature, weather.WindSpeed, weather.WindDir)
+		}
+	}
+
+	gameData.RainRisk = models.RainRiskFor(gameData.Weather, gameData.Stadium.RoofType)
+
+	// Calibrate to the game's season
+	gameData.League = se.loadLeagueEnvironment(ctx, gameData.Date.Year())
+	gameData.Duration = se.loadDurationModel(ctx, gameData.Date.Year())
+	se.applyFormPrior(ctx, gameData, gameData.Date)
+	return gameData, nil
+}
+
+// loadTeamRosters loads the rosters for both teams, reusing cached ones
+func (se *SimulationEngine) loadTeamRosters(ctx context.Context, homeTeamID, awayTeamID string,
+	league *models.LeagueEnvironment) (*models.Roster, *models.Roster, error) {
+	homeRoster, err := se.cachedTeamRoster(ctx, homeTeamID, league)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load home roster: %w", err)
 	}
 
-	awayRoster, err := se.loadTeamRoster(ctx, awayTeamID)
+	awayRoster, err := se.cachedTeamRoster(ctx, awayTeamID, league)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load away roster: %w", err)
 	}
@@ -215,7 +209,7 @@ func (se *SimulationEngine) loadTeamRosters(ctx context.Context, homeTeamID, awa
 }
 
 // loadTeamRoster loads a single team's roster with statistics
-func (se *SimulationEngine) loadTeamRoster(ctx context.Context, teamID string) (*models.Roster, error) {
+func (se *SimulationEngine) loadTeamRoster(ctx context.Context, teamID string, league *models.LeagueEnvironment) (*models.Roster, error) {
 	// Load players for the team
 	playersQuery := `
 		SELECT p.id, p.player_id, p.first_name, p.last_name, p.position,
@@ -271,10 +265,10 @@ func (se *SimulationEngine) loadTeamRoster(ctx context.Context, teamID string) (
 
 	// Load current season statistics for all players
 	currentYear := time.Now().Year()
-	if err := se.loadPlayerStatistics(ctx, players, currentYear); err != nil {
+	if err := se.loadPlayerStatistics(ctx, players, currentYear, league); err != nil {
 		log.Printf("Warning: failed to load player statistics: %v", err)
 		// Continue with default stats
-		se.setDefaultStatistics(players)
+		se.setDefaultStatistics(players, league)
 	}
 
 	// Create roster with lineups
@@ -285,12 +279,14 @@ func (se *SimulationEngine) loadTeamRoster(ctx context.Context, teamID string) (
 
 	// Generate lineup orders
 	se.generateLineups(roster)
+	se.fillRosterGaps(roster, league)
 
 	return roster, nil
 }
 
 // loadPlayerStatistics loads current season stats for players
-func (se *SimulationEngine) loadPlayerStatistics(ctx context.Context, players []models.Player, season int) error {
+func (se *SimulationEngine) loadPlayerStatistics(ctx context.Context, players []models.Player, season int,
+	league *models.LeagueEnvironment) error {
 	if len(players) == 0 {
 		return nil
 	}
@@ -301,80 +297,65 @@ func (se *SimulationEngine) loadPlayerStatistics(ctx context.Context, players []
 		playerIDs[i] = player.ID
 	}
 
-	// Load batting stats
-	battingQuery := `
-		SELECT player_id, aggregated_stats
-		FROM player_season_aggregates
-		WHERE player_id = ANY($1) AND season = $2 AND stats_type = 'batting'
-	`
-
-	rows, err := se.db.Query(ctx, battingQuery, playerIDs, season)
+	battingStats, err := se.loadSeasonAggregates(ctx, playerIDs, season, "batting")
 	if err != nil {
-		return fmt.Errorf("failed to query batting stats: %w", err)
+		return err
 	}
-	defer rows.Close()
-
-	battingStats := make(map[string]map[string]interface{})
-	for rows.Next() {
-		var playerID string
-		var statsJSON []byte
-
-		if err := rows.Scan(&playerID, &statsJSON); err != nil {
-			continue
-		}
-
-		var stats map[string]interface{}
-		if err := json.Unmarshal(statsJSON, &stats); err != nil {
-			continue
-		}
-
-		battingStats[playerID] = stats
+	pitchingStats, err := se.loadSeasonAggregates(ctx, playerIDs, season, "pitching")
+	if err != nil {
+		return err
 	}
-
-	// Load pitching stats
-	pitchingQuery := `
-		SELECT player_id, aggregated_stats
-		FROM player_season_aggregates
-		WHERE player_id = ANY($1) AND season = $2 AND stats_type = 'pitching'
-	`
-
-	rows, err = se.db.Query(ctx, pitchingQuery, playerIDs, season)
+	fieldingStats, err := se.loadSeasonAggregates(ctx, playerIDs, season, "fielding")
 	if err != nil {
-		return fmt.Errorf("failed to query pitching stats: %w", err)
+		return err
 	}
-	defer rows.Close()
 
-	pitchingStats := make(map[string]map[string]interface{})
-	for rows.Next() {
-		var playerID string
-		var statsJSON []byte
+	se.applyPlayerStatistics(players, battingStats, pitchingStats, fieldingStats, league)
+	return nil
+}
 
-		if err := rows.Scan(&playerID, &statsJSON); err != nil {
-			continue
+// applyPlayerStatistics applies each player's batting, pitching and fielding
+// stats, keyed by player ID, then fills in default attributes
+func (se *SimulationEngine) applyPlayerStatistics(players []models.Player, battingStats, pitchingStats,
+	fieldingStats map[string]map[string]interface{}, league *models.LeagueEnvironment) {
+	for i := range players {
+		playerID := players[i].ID
+
+		// Apply batting stats
+		if batting, exists := battingStats[playerID]; exists {
+			se.applyBattingStats(&players[i], batting, league)
 		}
 
-		var stats map[string]interface{}
-		if err := json.Unmarshal(statsJSON, &stats); err != nil {
-			continue
+		// Apply pitching stats
+		if pitching, exists := pitchingStats[playerID]; exists {
+			se.applyPitchingStats(&players[i], pitching, league)
 		}
 
-		pitchingStats[playerID] = stats
+		// Apply fielding stats
+		if fielding, exists := fieldingStats[playerID]; exists {
+			se.applyFieldingStats(&players[i], fielding)
+		}
+
+		// Set default attributes if not loaded
+		se.setDefaultAttributes(&players[i])
 	}
+}
 
-	// Load fielding stats
-	fieldingQuery := `
+// loadSeasonAggregates reads one type of season aggregate for players, keyed
+// by player ID. Rows that fail to decode are skipped.
+func (se *SimulationEngine) loadSeasonAggregates(ctx context.Context, playerIDs []string, season int,
+	statsType string) (map[string]map[string]interface{}, error) {
+	rows, err := se.db.Query(ctx, `
 		SELECT player_id, aggregated_stats
 		FROM player_season_aggregates
-		WHERE player_id = ANY($1) AND season = $2 AND stats_type = 'fielding'
-	`
-
-	rows, err = se.db.Query(ctx, fieldingQuery, playerIDs, season)
+		WHERE player_id = ANY($1) AND season = $2 AND stats_type = $3
+	`, playerIDs, season, statsType)
 	if err != nil {
-		return fmt.Errorf("failed to query fielding stats: %w", err)
+		return nil, fmt.Errorf("failed to query %s stats: %w", statsType, err)
 	}
 	defer rows.Close()
 
-	fieldingStats := make(map[string]map[string]interface{})
+	aggregates := make(map[string]map[string]interface{})
 	for rows.Next() {
 		var playerID string
 		var statsJSON []byte
@@ -388,42 +369,18 @@ func (se *SimulationEngine) loadPlayerStatistics(ctx context.Context, players []
 			continue
 		}
 
-		fieldingStats[playerID] = stats
+		aggregates[playerID] = stats
 	}
-
-	// Apply stats to players
-	for i := range players {
-		playerID := players[i].ID
-
-		// Apply batting stats
-		if batting, exists := battingStats[playerID]; exists {
-			se.applyBattingStats(&players[i], batting)
-		}
-
-		// Apply pitching stats
-		if pitching, exists := pitchingStats[playerID]; exists {
-			se.applyPitchingStats(&players[i], pitching)
-		}
-
-		// Apply fielding stats
-		if fielding, exists := fieldingStats[playerID]; exists {
-			se.applyFieldingStats(&players[i], fielding)
-		}
-
-		// Set default attributes if not loaded
-		se.setDefaultAttributes(&players[i])
-	}
-
-	return nil
+	return aggregates, nil
 }
 
 // applyBattingStats applies batting statistics to a player
-func (se *SimulationEngine) applyBattingStats(player *models.Player, stats map[string]interface{}) {
+func (se *SimulationEngine) applyBattingStats(player *models.Player, stats map[string]interface{}, league *models.LeagueEnvironment) {
 	player.Batting.AVG = getFloatFromStats(stats, "AVG", 0.250)
 	player.Batting.OBP = getFloatFromStats(stats, "OBP", 0.320)
 	player.Batting.SLG = getFloatFromStats(stats, "SLG", 0.400)
 	player.Batting.OPS = player.Batting.OBP + player.Batting.SLG
-	player.Batting.WOBA = getFloatFromStats(stats, "wOBA", 0.320)
+	player.Batting.WOBA = getFloatFromStats(stats, "wOBA", league.WOBA)
 	player.Batting.WRCPlus = getIntFromStats(stats, "wRC+", 100)
 	player.Batting.ISO = getFloatFromStats(stats, "ISO", 0.150)
 	player.Batting.BABIP = getFloatFromStats(stats, "BABIP", 0.300)
@@ -443,11 +400,11 @@ func (se *SimulationEngine) applyBattingStats(player *models.Player, stats map[s
 }
 
 // applyPitchingStats applies pitching statistics to a player
-func (se *SimulationEngine) applyPitchingStats(player *models.Player, stats map[string]interface{}) {
+func (se *SimulationEngine) applyPitchingStats(player *models.Player, stats map[string]interface{}, league *models.LeagueEnvironment) {
 	player.Pitching.ERA = getFloatFromStats(stats, "ERA", 4.50)
 	player.Pitching.WHIP = getFloatFromStats(stats, "WHIP", 1.35)
-	player.Pitching.FIP = getFloatFromStats(stats, "FIP", 4.20)
-	player.Pitching.XFIP = getFloatFromStats(stats, "xFIP", 4.20)
+	player.Pitching.FIP = getFloatFromStats(stats, "FIP", league.LeagueFIP)
+	player.Pitching.XFIP = getFloatFromStats(stats, "xFIP", league.LeagueFIP)
 	player.Pitching.ERAPlus = getIntFromStats(stats, "ERA+", 100)
 	player.Pitching.KPer9 = getFloatFromStats(stats, "K/9", 8.5)
 	player.Pitching.BBPer9 = getFloatFromStats(stats, "BB/9", 3.2)
@@ -464,6 +421,14 @@ func (se *SimulationEngine) applyPitchingStats(player *models.Player, stats map[
 	player.Pitching.W = getIntFromStats(stats, "W", 8)
 	player.Pitching.L = getIntFromStats(stats, "L", 8)
 
+	// Derive FIP with the season's constant when it wasn't precomputed
+	if _, ok := stats["FIP"]; !ok {
+		if _, hasIP := stats["IP"]; hasIP {
+			player.Pitching.FIP = league.CalculateFIP(player.Pitching.HR, player.Pitching.BB,
+				getIntFromStats(stats, "HBP", 0), player.Pitching.SO, player.Pitching.IP)
+		}
+	}
+
 	// Contact management
 	player.Pitching.GroundBallPercent = getFloatFromStats(stats, "GB%", 45.0)
 	player.Pitching.FlyBallPercent = getFloatFromStats(stats, "FB%", 35.0)
@@ -472,7 +437,8 @@ func (se *SimulationEngine) applyPitchingStats(player *models.Player, stats map[
 
 // applyFieldingStats applies fielding statistics to a player
 func (se *SimulationEngine) applyFieldingStats(player *models.Player, stats map[string]interface{}) {
-	player.Fielding.FPCT = getFloatFromStats(stats, "FPCT", 0.975)
+	// Stats API rows keep fielding percentage under "fielding"
+	player.Fielding.FPCT = getFloatFromStats(stats, "FPCT", getFloatFromStats(stats, "fielding", models.LeagueFieldingPct))
 	player.Fielding.Errors = getIntFromStats(stats, "E", 8)
 	player.Fielding.PO = getIntFromStats(stats, "PO", 200)
 	player.Fielding.A = getIntFromStats(stats, "A", 300)
@@ -569,6 +535,14 @@ func (se *SimulationEngine) setDefaultAttributes(player *models.Player) {
 	if player.Attributes.Hands == 0 {
 		player.Attributes.Hands = 50
 	}
+	if player.Attributes.Delivery == 0 {
+		// Left-handers face first base and hold runners closer
+		delivery := 50
+		if player.Hand == "L" {
+			delivery = 55
+		}
+		player.Attributes.Delivery = delivery
+	}
 	if player.Attributes.Height == 0 {
 		player.Attributes.Height = 72 // 6'0"
 	}
@@ -587,7 +561,7 @@ func (se *SimulationEngine) setDefaultAttributes(player *models.Player) {
 }
 
 // setDefaultStatistics sets league average statistics for players without data
-func (se *SimulationEngine) setDefaultStatistics(players []models.Player) {
+func (se *SimulationEngine) setDefaultStatistics(players []models.Player, league *models.LeagueEnvironment) {
 	for i := range players {
 		player := &players[i]
 
@@ -596,7 +570,7 @@ func (se *SimulationEngine) setDefaultStatistics(players []models.Player) {
 		player.Batting.OBP = 0.320
 		player.Batting.SLG = 0.400
 		player.Batting.OPS = 0.720
-		player.Batting.WOBA = 0.320
+		player.Batting.WOBA = league.WOBA
 		player.Batting.WRCPlus = 100
 		player.Batting.ISO = 0.150
 		player.Batting.BABIP = 0.300
@@ -610,13 +584,13 @@ func (se *SimulationEngine) setDefaultStatistics(players []models.Player) {
 		// Set default pitching stats
 		player.Pitching.ERA = 4.50
 		player.Pitching.WHIP = 1.35
-		player.Pitching.FIP = 4.20
+		player.Pitching.FIP = league.LeagueFIP
 		player.Pitching.KPer9 = 8.5
 		player.Pitching.BBPer9 = 3.2
 		player.Pitching.IP = 150
 
 		// Set default fielding stats
-		player.Fielding.FPCT = 0.975
+		player.Fielding.FPCT = models.LeagueFieldingPct
 		player.Fielding.UZR = 0.0
 		player.Fielding.DRS = 0
 
@@ -766,7 +740,19 @@ func (se *SimulationEngine) createLineup(roster *models.Roster) []models.Player
 		}
 	}
 
-	return lineup
+	// Make sure someone who plays each position is in the lineup, starting
+	// with a catcher, when the bench has one
+	return ensurePositionCoverage(lineup, benchPlayers(roster, lineup))
+}
+
+// findCatcher returns the catcher in the lineup, or nil if none is playing
+func findCatcher(lineup []models.Player) *models.Player {
+	for i := range lineup {
+		if lineup[i].Position == "C" {
+			return &lineup[i]
+		}
+	}
+	return nil
 }
 
 // getStartingPitcher returns the starting pitcher for the team
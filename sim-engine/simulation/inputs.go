@@ -0,0 +1,143 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"sim-engine/models"
+)
+
+// EngineVersion identifies the simulation engine build. Override it at build
+// time with -ldflags "-X sim-engine/simulation.EngineVersion=<version>".
+var EngineVersion = "dev"
+
+// RunFingerprint identifies the engine build, model parameters and data a run
+// was produced with, so accuracy shifts can be traced to model changes
+type RunFingerprint struct {
+	EngineVersion  string     `json:"engine_version"`
+	ModelParamHash string     `json:"model_param_hash"`
+	DataSnapshotAt *time.Time `json:"data_snapshot_at,omitempty"`
+}
+
+// RunInputs snapshots what a run simulated with, so two runs of the same game
+// can be compared one input at a time
+type RunInputs struct {
+	EngineVersion  string          `json:"engine_version"`
+	ModelParamHash string          `json:"model_param_hash"`
+	DataSnapshotAt *time.Time      `json:"data_snapshot_at,omitempty"` // newest team/player/stat row the run read
+	AsOf           string          `json:"as_of,omitempty"`            // replay date the rosters and stats stood at
+	StadiumID      string          `json:"stadium_id,omitempty"`
+	StadiumName    string          `json:"stadium_name,omitempty"`
+	NeutralSite    bool            `json:"neutral_site,omitempty"` // played away from the home team's park
+	Weather        models.Weather  `json:"weather"`
+	RainRisk       models.RainRisk `json:"rain_risk"`
+	HomeStarterID  string          `json:"home_starter_id,omitempty"`
+	AwayStarterID  string          `json:"away_starter_id,omitempty"`
+	HomeLineup     []string        `json:"home_lineup"`
+	AwayLineup     []string        `json:"away_lineup"`
+
+	// Tired relievers' availability, when carried over from earlier games
+	HomeBullpenAvailability map[string]float64 `json:"home_bullpen_availability,omitempty"`
+	AwayBullpenAvailability map[string]float64 `json:"away_bullpen_availability,omitempty"`
+}
+
+// captureRunInputs records the weather, starters and lineups a run will use
+func captureRunInputs(gameData *GameData, homeRoster, awayRoster *models.Roster) RunInputs {
+	inputs := RunInputs{
+		EngineVersion:  EngineVersion,
+		ModelParamHash: models.ModelParameterHashFor(gameData.Tuning),
+		StadiumID:      gameData.Stadium.ID,
+		StadiumName:    gameData.Stadium.Name,
+		NeutralSite:    gameData.NeutralSite(),
+		Weather:        gameData.Weather,
+		RainRisk:       gameData.RainRisk,
+		HomeLineup:     append([]string{}, homeRoster.Lineup...),
+		AwayLineup:     append([]string{}, awayRoster.Lineup...),
+
+		HomeBullpenAvailability: homeRoster.BullpenAvailability,
+		AwayBullpenAvailability: awayRoster.BullpenAvailability,
+	}
+	if len(homeRoster.Rotation) > 0 {
+		inputs.HomeStarterID = homeRoster.Rotation[0]
+	}
+	if len(awayRoster.Rotation) > 0 {
+		inputs.AwayStarterID = awayRoster.Rotation[0]
+	}
+	return inputs
+}
+
+// recordRunInputs stamps a run with its inputs and model fingerprint
+func (se *SimulationEngine) recordRunInputs(runID string, inputs RunInputs) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	inputsJSON, err := json.Marshal(inputs)
+	if err != nil {
+		log.Printf("Failed to marshal inputs for run %s: %v", runID, err)
+		return
+	}
+	_, err = se.db.Exec(ctx, `
+		UPDATE simulation_runs
+		SET inputs = $2, engine_version = $3, model_param_hash = $4, data_snapshot_at = $5
+		WHERE id = $1
+	`, runID, inputsJSON, inputs.EngineVersion, inputs.ModelParamHash, inputs.DataSnapshotAt)
+	if err != nil {
+		log.Printf("Failed to store inputs for run %s: %v", runID, err)
+	}
+}
+
+// loadDataSnapshot returns when the team, player and stat data a run reads was
+// last refreshed, or nil if it can't be determined
+func (se *SimulationEngine) loadDataSnapshot(ctx context.Context) *time.Time {
+	var snapshot *time.Time
+	err := se.db.QueryRow(ctx, `
+		SELECT MAX(updated) FROM (
+			SELECT MAX(updated_at) AS updated FROM teams
+			UNION ALL
+			SELECT MAX(updated_at) FROM players
+			UNION ALL
+			SELECT MAX(updated_at) FROM games
+			UNION ALL
+			SELECT MAX(last_updated) FROM player_season_aggregates
+		) combined
+	`).Scan(&snapshot)
+	if err != nil {
+		log.Printf("Failed to load data snapshot time: %v", err)
+		return nil
+	}
+	return snapshot
+}
+
+// withLineup returns a copy of the roster batting the given order. Players no
+// longer on the roster are dropped and createLineup fills the gaps.
+func withLineup(roster *models.Roster, lineup []string) *models.Roster {
+	swapped := *roster
+	swapped.Lineup = append([]string{}, lineup...)
+	return &swapped
+}
+
+// withStarter returns a copy of the roster starting the given pitcher, or
+// false when the pitcher is not on the roster
+func withStarter(roster *models.Roster, starterID string) (*models.Roster, bool) {
+	found := false
+	for _, player := range roster.Players {
+		if player.ID == starterID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return roster, false
+	}
+
+	swapped := *roster
+	swapped.Rotation = []string{starterID}
+	for _, id := range roster.Rotation {
+		if id != starterID {
+			swapped.Rotation = append(swapped.Rotation, id)
+		}
+	}
+	return &swapped, true
+}
@@ -0,0 +1,49 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+
+	"sim-engine/models"
+)
+
+// loadLeagueEnvironment returns the league constants for a season, using the
+// nearest earlier season when the exact one hasn't been loaded yet. Results
+// are cached per season for the life of the engine; a failed query falls back
+// to the defaults without caching them, so the next run tries again.
+func (se *SimulationEngine) loadLeagueEnvironment(ctx context.Context, season int) *models.LeagueEnvironment {
+	se.leagueMu.Lock()
+	env, ok := se.leagueEnvs[season]
+	se.leagueMu.Unlock()
+	if ok {
+		return env
+	}
+
+	env = models.DefaultLeagueEnvironment()
+	err := se.db.QueryRow(ctx, `
+		SELECT season, league_woba, woba_scale, fip_constant, league_fip, runs_per_pa
+		FROM league_environment
+		WHERE season <= $1
+		ORDER BY season DESC
+		LIMIT 1
+	`, season).Scan(&env.Season, &env.WOBA, &env.WOBAScale, &env.FIPConstant, &env.LeagueFIP, &env.RunsPerPA)
+	if err != nil {
+		log.Printf("No league environment for season %d, using defaults: %v", season, err)
+		env = models.DefaultLeagueEnvironment()
+		env.Season = season
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return env
+		}
+	}
+
+	se.leagueMu.Lock()
+	defer se.leagueMu.Unlock()
+	if cached, ok := se.leagueEnvs[season]; ok {
+		return cached
+	}
+	se.leagueEnvs[season] = env
+	return env
+}
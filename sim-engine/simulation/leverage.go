@@ -0,0 +1,92 @@
+package simulation
+
+import (
+	"container/heap"
+	"sort"
+
+	"sim-engine/models"
+)
+
+const (
+	// HighLeverageThreshold is the leverage above which key events are
+	// summarized in aggregated results
+	HighLeverageThreshold = 2.0
+
+	// summaryLeverageEvents is how many events aggregated results keep;
+	// the rest are available from the run's events endpoint
+	summaryLeverageEvents = 50
+)
+
+// LeverageEvent is a key event tagged with the simulation it came from
+type LeverageEvent struct {
+	SimulationNumber int `json:"simulation_number"`
+	models.GameEvent
+}
+
+// leverageHeap is a min-heap on leverage so the weakest kept event is on top
+type leverageHeap []LeverageEvent
+
+func (h leverageHeap) Len() int           { return len(h) }
+func (h leverageHeap) Less(i, j int) bool { return h[i].Leverage < h[j].Leverage }
+func (h leverageHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *leverageHeap) Push(x interface{}) { *h = append(*h, x.(LeverageEvent)) }
+
+func (h *leverageHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// LeverageCollector keeps the highest-leverage events seen, bounded by a
+// limit, in O(n log limit) time and O(limit) memory
+type LeverageCollector struct {
+	limit       int
+	minLeverage float64
+	events      leverageHeap
+}
+
+// NewLeverageCollector creates a collector for events with leverage of at
+// least minLeverage, keeping no more than limit of them
+func NewLeverageCollector(limit int, minLeverage float64) *LeverageCollector {
+	return &LeverageCollector{limit: limit, minLeverage: minLeverage}
+}
+
+// Add offers an event to the collector
+func (lc *LeverageCollector) Add(simulationNumber int, event models.GameEvent) {
+	if lc.limit <= 0 || event.Leverage < lc.minLeverage {
+		return
+	}
+
+	if len(lc.events) < lc.limit {
+		heap.Push(&lc.events, LeverageEvent{SimulationNumber: simulationNumber, GameEvent: event})
+		return
+	}
+
+	// Replace the weakest kept event when this one beats it
+	if event.Leverage > lc.events[0].Leverage {
+		lc.events[0] = LeverageEvent{SimulationNumber: simulationNumber, GameEvent: event}
+		heap.Fix(&lc.events, 0)
+	}
+}
+
+// Events returns the collected events ordered by leverage, highest first
+func (lc *LeverageCollector) Events() []LeverageEvent {
+	events := make([]LeverageEvent, len(lc.events))
+	copy(events, lc.events)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Leverage > events[j].Leverage })
+	return events
+}
+
+// CollectLeverageEvents returns the top events across results
+func CollectLeverageEvents(results []models.SimulationResult, limit int, minLeverage float64) []LeverageEvent {
+	collector := NewLeverageCollector(limit, minLeverage)
+	for _, result := range results {
+		for _, event := range result.KeyEvents {
+			collector.Add(result.SimulationNumber, event)
+		}
+	}
+	return collector.Events()
+}
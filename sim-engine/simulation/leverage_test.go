@@ -0,0 +1,47 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+// TestLeverageCollector tests bounded top-k collection
+func TestLeverageCollector(t *testing.T) {
+	collector := NewLeverageCollector(3, 2.0)
+	for i, leverage := range []float64{1.5, 2.5, 4.0, 2.0, 3.1, 6.2, 2.2} {
+		collector.Add(i+1, models.GameEvent{Leverage: leverage})
+	}
+
+	events := collector.Events()
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+
+	want := []float64{6.2, 4.0, 3.1}
+	for i, event := range events {
+		if event.Leverage != want[i] {
+			t.Errorf("Event %d: expected leverage %.1f, got %.1f", i, want[i], event.Leverage)
+		}
+	}
+	if events[0].SimulationNumber != 6 {
+		t.Errorf("Expected top event from simulation 6, got %d", events[0].SimulationNumber)
+	}
+}
+
+// TestCollectLeverageEvents tests collection across simulation results
+func TestCollectLeverageEvents(t *testing.T) {
+	results := []models.SimulationResult{
+		{SimulationNumber: 1, KeyEvents: []models.GameEvent{{Leverage: 1.8}, {Leverage: 2.4}}},
+		{SimulationNumber: 2, KeyEvents: []models.GameEvent{{Leverage: 3.0}}},
+	}
+
+	events := CollectLeverageEvents(results, 10, 1.5)
+	if len(events) != 3 || events[0].SimulationNumber != 2 {
+		t.Errorf("Expected 3 events led by simulation 2, got %+v", events)
+	}
+
+	if got := CollectLeverageEvents(results, 10, 2.5); len(got) != 1 {
+		t.Errorf("Expected 1 event above 2.5, got %d", len(got))
+	}
+}
@@ -0,0 +1,205 @@
+package simulation
+
+import (
+	"fmt"
+
+	"sim-engine/models"
+)
+
+// fieldingPositions are the positions a lineup must cover besides the
+// pitcher, hardest to fill first so a catcher is placed before anything else
+var fieldingPositions = []string{"C", "SS", "CF", "2B", "3B", "RF", "LF", "1B"}
+
+// positionGroups lets a listed infielder or outfielder cover another spot on
+// the same side of the diamond. Catchers only catch.
+var positionGroups = map[string]string{
+	"1B": "IF", "2B": "IF", "3B": "IF", "SS": "IF", "IF": "IF",
+	"LF": "OF", "CF": "OF", "RF": "OF", "OF": "OF",
+}
+
+func isFieldingPosition(position string) bool {
+	for _, p := range fieldingPositions {
+		if p == position {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldingAssignment is where each lineup player fields
+type fieldingAssignment struct {
+	positions []string // per lineup slot; DH for players without a position
+	needs     []string // positions filled out of position or not at all, in fieldingPositions order
+	issues    []string
+}
+
+// assignFieldingPositions places lineup players at their listed positions,
+// then moves infielders and outfielders within their group, then puts anyone
+// left wherever a position is still open. The remaining player is the DH.
+func assignFieldingPositions(lineup []models.Player) fieldingAssignment {
+	positions := make([]string, len(lineup))
+	filled := make(map[string]bool)
+	assign := func(i int, position string) {
+		positions[i] = position
+		filled[position] = true
+	}
+
+	for i, player := range lineup {
+		if isFieldingPosition(player.Position) && !filled[player.Position] {
+			assign(i, player.Position)
+		}
+	}
+
+	for _, position := range fieldingPositions {
+		group := positionGroups[position]
+		if filled[position] || group == "" {
+			continue
+		}
+		for i, player := range lineup {
+			if positions[i] == "" && positionGroups[player.Position] == group {
+				assign(i, position)
+				break
+			}
+		}
+	}
+
+	var result fieldingAssignment
+	for _, position := range fieldingPositions {
+		if filled[position] {
+			continue
+		}
+		placed := false
+		for i, player := range lineup {
+			if positions[i] == "" {
+				assign(i, position)
+				result.issues = append(result.issues, fmt.Sprintf("%s playing %s out of position (listed %s)", player.Name, position, player.Position))
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			result.issues = append(result.issues, fmt.Sprintf("no player available at %s", position))
+		}
+		result.needs = append(result.needs, position)
+	}
+
+	for i := range positions {
+		if positions[i] == "" {
+			positions[i] = "DH"
+		}
+	}
+	result.positions = positions
+	return result
+}
+
+// benchPlayers returns the roster's position players not in the lineup
+func benchPlayers(roster *models.Roster, lineup []models.Player) []models.Player {
+	starting := make(map[string]bool, len(lineup))
+	for _, player := range lineup {
+		starting[player.ID] = true
+	}
+
+	var bench []models.Player
+	for _, player := range roster.Players {
+		if player.Position != "P" && !starting[player.ID] {
+			bench = append(bench, player)
+		}
+	}
+	return bench
+}
+
+// benchPlayerFor finds a bench player who can play the position: one listed
+// there, else one from the same group. It returns -1 when there is none.
+func benchPlayerFor(bench []models.Player, position string) int {
+	for i, player := range bench {
+		if player.Position == position {
+			return i
+		}
+	}
+	if group := positionGroups[position]; group != "" {
+		for i, player := range bench {
+			if positionGroups[player.Position] == group {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// ensurePositionCoverage swaps bench players in for the DH until every
+// position the bench can fill is played by someone who plays it, catcher
+// first. The bench player bats in the DH's slot.
+func ensurePositionCoverage(lineup, bench []models.Player) []models.Player {
+	bench = append([]models.Player(nil), bench...)
+	for len(bench) > 0 {
+		fielding := assignFieldingPositions(lineup)
+		dh := -1
+		for i, position := range fielding.positions {
+			if position == "DH" {
+				dh = i
+				break
+			}
+		}
+
+		swapped := false
+		for _, position := range fielding.needs {
+			j := benchPlayerFor(bench, position)
+			if j < 0 {
+				continue
+			}
+			if dh >= 0 {
+				lineup[dh] = bench[j]
+			} else if len(lineup) < 9 {
+				lineup = append(lineup, bench[j])
+			} else {
+				break
+			}
+			bench = append(bench[:j], bench[j+1:]...)
+			swapped = true
+			break
+		}
+		if !swapped {
+			break
+		}
+	}
+	return lineup
+}
+
+// buildLineupCard describes the lineup createLineup fields for a roster
+func (se *SimulationEngine) buildLineupCard(roster *models.Roster) models.LineupCard {
+	lineup := se.createLineup(roster)
+	fielding := assignFieldingPositions(lineup)
+
+	card := models.LineupCard{
+		TeamID:         roster.TeamID,
+		BattingOrder:   make([]models.LineupEntry, 0, len(lineup)),
+		Bench:          []models.LineupEntry{},
+		CoverageIssues: fielding.issues,
+		RosterGaps:     roster.Gaps,
+	}
+	for i, player := range lineup {
+		card.BattingOrder = append(card.BattingOrder, lineupEntry(player, fielding.positions[i], i+1))
+	}
+	if starter := se.getStartingPitcher(roster); starter != nil {
+		entry := lineupEntry(*starter, "P", 0)
+		card.StartingPitcher = &entry
+	}
+	for _, player := range benchPlayers(roster, lineup) {
+		card.Bench = append(card.Bench, lineupEntry(player, player.Position, 0))
+	}
+	return card
+}
+
+func lineupEntry(player models.Player, position string, order int) models.LineupEntry {
+	entry := models.LineupEntry{
+		Order:       order,
+		PlayerID:    player.ID,
+		Name:        player.Name,
+		Position:    position,
+		Placeholder: player.Placeholder,
+	}
+	if player.Position != position {
+		entry.ListedPosition = player.Position
+	}
+	return entry
+}
@@ -0,0 +1,97 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func testRoster(positions ...string) *models.Roster {
+	roster := &models.Roster{TeamID: "team-1"}
+	for i, position := range positions {
+		player := models.Player{ID: string(rune('a' + i)), Name: "Player " + string(rune('A'+i)), Position: position}
+		roster.Players = append(roster.Players, player)
+		if position != "P" && len(roster.Lineup) < 9 {
+			roster.Lineup = append(roster.Lineup, player.ID)
+		}
+	}
+	return roster
+}
+
+func TestCreateLineupBringsInACatcher(t *testing.T) {
+	// Nine non-catchers bat first; the catcher is tenth on the depth chart
+	roster := testRoster("1B", "2B", "SS", "3B", "LF", "CF", "RF", "DH", "OF", "C", "P")
+
+	se := &SimulationEngine{}
+	lineup := se.createLineup(roster)
+	if len(lineup) != 9 {
+		t.Fatalf("lineup has %d players, want 9", len(lineup))
+	}
+	if findCatcher(lineup) == nil {
+		t.Fatal("lineup has no catcher")
+	}
+
+	// The catcher replaces the extra outfielder, who had no position to play
+	fielding := assignFieldingPositions(lineup)
+	if len(fielding.issues) != 0 {
+		t.Errorf("unexpected coverage issues: %v", fielding.issues)
+	}
+	if lineup[8].Position != "C" || fielding.positions[8] != "C" {
+		t.Errorf("slot 9 = %s fielding %s, want the catcher", lineup[8].Position, fielding.positions[8])
+	}
+}
+
+func TestAssignFieldingPositionsWithinGroups(t *testing.T) {
+	lineup := testRoster("C", "1B", "IF", "IF", "3B", "OF", "OF", "RF", "DH").Players
+
+	fielding := assignFieldingPositions(lineup)
+	want := []string{"C", "1B", "SS", "2B", "3B", "CF", "LF", "RF", "DH"}
+	for i := range want {
+		if fielding.positions[i] != want[i] {
+			t.Errorf("slot %d fields %s, want %s", i+1, fielding.positions[i], want[i])
+		}
+	}
+	if len(fielding.needs) != 0 {
+		t.Errorf("needs = %v, want none", fielding.needs)
+	}
+}
+
+func TestAssignFieldingPositionsReportsGaps(t *testing.T) {
+	// No catcher on the roster at all: someone has to catch
+	lineup := testRoster("1B", "2B", "SS", "3B", "LF", "CF", "RF", "DH").Players
+
+	fielding := assignFieldingPositions(lineup)
+	if fielding.positions[7] != "C" {
+		t.Errorf("DH fields %s, want C", fielding.positions[7])
+	}
+	if len(fielding.needs) != 1 || fielding.needs[0] != "C" {
+		t.Errorf("needs = %v, want [C]", fielding.needs)
+	}
+	if len(fielding.issues) != 1 {
+		t.Errorf("issues = %v, want one", fielding.issues)
+	}
+}
+
+func TestBuildLineupCard(t *testing.T) {
+	roster := testRoster("C", "1B", "2B", "SS", "3B", "LF", "CF", "RF", "DH", "OF", "P", "P")
+	roster.Rotation = []string{"l"}
+
+	se := &SimulationEngine{}
+	card := se.buildLineupCard(roster)
+
+	if card.TeamID != "team-1" || len(card.BattingOrder) != 9 {
+		t.Fatalf("card = %+v", card)
+	}
+	if card.BattingOrder[0].Order != 1 || card.BattingOrder[0].Position != "C" {
+		t.Errorf("leadoff = %+v", card.BattingOrder[0])
+	}
+	if card.StartingPitcher == nil || card.StartingPitcher.PlayerID != "l" {
+		t.Errorf("starting pitcher = %+v, want l", card.StartingPitcher)
+	}
+	if len(card.Bench) != 1 || card.Bench[0].Position != "OF" {
+		t.Errorf("bench = %+v, want the extra outfielder", card.Bench)
+	}
+	if len(card.CoverageIssues) != 0 {
+		t.Errorf("coverage issues = %v", card.CoverageIssues)
+	}
+}
@@ -0,0 +1,54 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// RunStatusPartial marks a run stopped by its max_duration_seconds budget,
+	// or one whose aggregate is waiting in the dead-letter spill
+	RunStatusPartial = "partial"
+
+	// maxDurationConfigKey is the run config key holding the time budget
+	maxDurationConfigKey = "max_duration_seconds"
+
+	// maxRunDuration caps the time budget a client may request
+	maxRunDuration = 6 * time.Hour
+)
+
+// MaxDurationFromConfig reads a run's max_duration_seconds budget. Zero means
+// the run is unbounded.
+func MaxDurationFromConfig(config map[string]interface{}) (time.Duration, error) {
+	raw, ok := config[maxDurationConfigKey]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+
+	seconds, ok := raw.(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s must be a number", maxDurationConfigKey)
+	}
+	duration := time.Duration(seconds * float64(time.Second))
+	if duration <= 0 || duration > maxRunDuration {
+		return 0, fmt.Errorf("%s must be between 0 and %d", maxDurationConfigKey, int(maxRunDuration.Seconds()))
+	}
+	return duration, nil
+}
+
+// recordPartialRun stores the achieved sample size and marks the run partial
+func (se *SimulationEngine) recordPartialRun(runID string, achieved int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := se.db.Exec(ctx, `
+		UPDATE simulation_runs
+		SET status = $2, completed_runs = $3, completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, runID, RunStatusPartial, achieved)
+	if err != nil {
+		log.Printf("Failed to mark run %s partial: %v", runID, err)
+	}
+}
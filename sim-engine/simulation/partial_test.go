@@ -0,0 +1,23 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaxDurationFromConfig(t *testing.T) {
+	if d, err := MaxDurationFromConfig(nil); err != nil || d != 0 {
+		t.Errorf("Expected no budget without config, got %v (%v)", d, err)
+	}
+
+	d, err := MaxDurationFromConfig(map[string]interface{}{"max_duration_seconds": 2.5})
+	if err != nil || d != 2500*time.Millisecond {
+		t.Errorf("Expected 2.5s budget, got %v (%v)", d, err)
+	}
+
+	for _, bad := range []interface{}{"30", -1.0, 0.0, 1e9} {
+		if _, err := MaxDurationFromConfig(map[string]interface{}{"max_duration_seconds": bad}); err == nil {
+			t.Errorf("Expected %v to be rejected", bad)
+		}
+	}
+}
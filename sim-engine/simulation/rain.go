@@ -0,0 +1,29 @@
+package simulation
+
+import "sim-engine/models"
+
+// rainDelaysConfigKey is the run config key that turns on simulated rain
+// delays
+const rainDelaysConfigKey = "rain_delays"
+
+// RainDelaysFromConfig reports whether a run simulates rain delays. They are
+// off unless the config sets rain_delays to true.
+func RainDelaysFromConfig(config map[string]interface{}) bool {
+	enabled, _ := config[rainDelaysConfigKey].(bool)
+	return enabled
+}
+
+// drawStarterRainDelay decides whether a simulated game has a rain delay long
+// enough to end both starters' outings, returning the inning it clears before
+// (0 for none). Delays before the first pitch don't affect the starters, so
+// the delay falls between the 2nd and 9th innings. roll returns a value in
+// [0, 1) and intn is rand.Intn or a deterministic stand-in.
+func drawStarterRainDelay(risk models.RainRisk, roll func() float64, intn func(int) int) int {
+	if roll() >= risk.DelayProbabilityIfPlayed() {
+		return 0
+	}
+	if risk.DrawDelayMinutes(roll) < models.RainDelayStarterThreshold {
+		return 0
+	}
+	return 2 + intn(models.RegulationInnings-1)
+}
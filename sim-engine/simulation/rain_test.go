@@ -0,0 +1,41 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestRainDelaysFromConfig(t *testing.T) {
+	if RainDelaysFromConfig(nil) || RainDelaysFromConfig(map[string]interface{}{"rain_delays": "yes"}) {
+		t.Error("rain delays should be off unless rain_delays is true")
+	}
+	if !RainDelaysFromConfig(map[string]interface{}{"rain_delays": true}) {
+		t.Error("rain_delays: true should turn rain delays on")
+	}
+}
+
+func TestDrawStarterRainDelay(t *testing.T) {
+	risk := models.RainRisk{DelayProbability: 0.5, ExpectedDelayMinutes: 60}
+	fixed := func(values ...float64) func() float64 {
+		return func() float64 {
+			value := values[0]
+			values = values[1:]
+			return value
+		}
+	}
+	last := func(n int) int { return n - 1 }
+
+	// No delay
+	if inning := drawStarterRainDelay(risk, fixed(0.6), last); inning != 0 {
+		t.Errorf("inning = %d, want 0 without a delay", inning)
+	}
+	// A 30 minute delay is too short to end the starters' outings
+	if inning := drawStarterRainDelay(risk, fixed(0.1, 0), last); inning != 0 {
+		t.Errorf("inning = %d, want 0 after a short delay", inning)
+	}
+	// A 90 minute delay does, at the latest before the 9th
+	if inning := drawStarterRainDelay(risk, fixed(0.1, 0.99), last); inning != models.RegulationInnings {
+		t.Errorf("inning = %d, want %d", inning, models.RegulationInnings)
+	}
+}
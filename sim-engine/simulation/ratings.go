@@ -0,0 +1,262 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"sim-engine/models"
+)
+
+// Synthetic opponents a team can be rated against
+const (
+	OpponentLeagueAverage = "league_average"
+	OpponentReplacement   = "replacement"
+)
+
+const (
+	// Replacement-level players: a bench bat around 80 wRC+ and a
+	// freely available arm about 0.9 runs worse than average
+	replacementWOBADrop = 0.030
+	replacementFIPRise  = 0.90
+
+	// syntheticHandedness is the batting side of each synthetic lineup slot
+	syntheticHandedness = "RLRRLRLRR"
+)
+
+// syntheticPositions are the synthetic lineup's fielding positions, in order
+var syntheticPositions = []string{"C", "1B", "2B", "3B", "SS", "LF", "CF", "RF", "DH"}
+
+// IsOpponentLevel reports whether name is a synthetic opponent
+func IsOpponentLevel(name string) bool {
+	return name == OpponentLeagueAverage || name == OpponentReplacement
+}
+
+// syntheticRoster builds an opponent whose every player is league average,
+// or replacement level: nine hitters, five starters and eight relievers
+func (se *SimulationEngine) syntheticRoster(level string, league *models.LeagueEnvironment) *models.Roster {
+	var players []models.Player
+	for i, position := range syntheticPositions {
+		players = append(players, models.Player{
+			ID:       fmt.Sprintf("%s-%s", level, position),
+			Name:     fmt.Sprintf("Synthetic %s", position),
+			Position: position,
+			Hand:     string(syntheticHandedness[i]),
+		})
+	}
+	for i := 1; i <= 13; i++ {
+		hand := "R"
+		if i%3 == 0 {
+			hand = "L"
+		}
+		players = append(players, models.Player{
+			ID:       fmt.Sprintf("%s-P%d", level, i),
+			Name:     fmt.Sprintf("Synthetic P%d", i),
+			Position: "P",
+			Hand:     hand,
+		})
+	}
+
+	se.setDefaultStatistics(players, league)
+	if level == OpponentReplacement {
+		for i := range players {
+			applyReplacementLevel(&players[i])
+		}
+	}
+
+	roster := &models.Roster{TeamID: level, Players: players}
+	se.generateLineups(roster)
+	return roster
+}
+
+// applyReplacementLevel lowers a league-average player to replacement level
+func applyReplacementLevel(player *models.Player) {
+	player.Batting.WOBA -= replacementWOBADrop
+	player.Batting.WRCPlus = 80
+	player.Pitching.FIP += replacementFIPRise
+	player.Pitching.ERA += replacementFIPRise
+}
+
+// neutralGameData is a context-free game: a neutral park, indoor weather and
+// an average umpire
+func neutralGameData(league *models.LeagueEnvironment, duration *models.DurationModel, tuning *models.TuningParameters) *GameData {
+	now := time.Now()
+	return &GameData{
+		Weather:  models.Weather{Temperature: 72, WindDir: "calm", Humidity: 50, Pressure: 29.92},
+		Date:     now,
+		GameTime: now,
+		Stadium: StadiumData{
+			Name:        "Neutral park",
+			RoofType:    "dome",
+			Dimensions:  models.DefaultDimensions(),
+			ParkFactors: models.DefaultParkFactors(),
+		},
+		Umpire:   UmpireData{Tendencies: models.DefaultUmpireTendencies()},
+		League:   league,
+		Duration: duration,
+		Tuning:   tuning,
+	}
+}
+
+// TeamRating is a team's record against a synthetic opponent, half the
+// games at home, so it doesn't depend on schedule or park
+type TeamRating struct {
+	TeamID             string    `json:"team_id"`
+	Season             int       `json:"season"`
+	Opponent           string    `json:"opponent"`
+	Games              int       `json:"games"`
+	Wins               int       `json:"wins"`
+	WinPct             float64   `json:"win_pct"`
+	RunsScoredPerGame  float64   `json:"runs_scored_per_game"`
+	RunsAllowedPerGame float64   `json:"runs_allowed_per_game"`
+	ModelParamHash     string    `json:"model_param_hash"`
+	ComputedAt         time.Time `json:"computed_at"`
+}
+
+// rateTeam simulates games between a roster and the opponent, alternating
+// home and away. Ties count as half a win.
+func (se *SimulationEngine) rateTeam(gameData *GameData, roster, opponent *models.Roster, games int) TeamRating {
+	rating := TeamRating{TeamID: roster.TeamID, Games: games}
+	var wins float64
+	var runsFor, runsAgainst int
+	for i := 0; i < games; i++ {
+		home, away := roster, opponent
+		if i%2 == 1 {
+			home, away = opponent, roster
+		}
+		result := se.simulateGame("", i+1, gameData, home, away, nil)
+
+		scored, allowed := result.HomeScore, result.AwayScore
+		if home != roster {
+			scored, allowed = allowed, scored
+		}
+		runsFor += scored
+		runsAgainst += allowed
+		switch {
+		case scored > allowed:
+			wins++
+		case scored == allowed:
+			wins += 0.5
+		}
+	}
+
+	if games > 0 {
+		rating.Wins = int(wins)
+		rating.WinPct = wins / float64(games)
+		rating.RunsScoredPerGame = float64(runsFor) / float64(games)
+		rating.RunsAllowedPerGame = float64(runsAgainst) / float64(games)
+	}
+	return rating
+}
+
+// RateTeams rates each team (every team when teamIDs is empty) against a
+// synthetic opponent over the given number of games, spread across the
+// engine's workers, and saves the ratings
+func (se *SimulationEngine) RateTeams(ctx context.Context, teamIDs []string, season int, opponent string, games int) ([]TeamRating, error) {
+	if !IsOpponentLevel(opponent) {
+		return nil, fmt.Errorf("unknown opponent %q", opponent)
+	}
+	if len(teamIDs) == 0 {
+		var err error
+		if teamIDs, err = se.allTeamIDs(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	league := se.loadLeagueEnvironment(ctx, season)
+	tuning := models.CurrentTuningParameters()
+	gameData := neutralGameData(league, se.loadDurationModel(ctx, season), tuning)
+	opponentRoster := se.syntheticRoster(opponent, league)
+
+	ratings := make([]TeamRating, len(teamIDs))
+	errs := make([]error, len(teamIDs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < se.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				roster, err := se.cachedTeamRoster(ctx, teamIDs[i], league)
+				if err != nil {
+					errs[i] = fmt.Errorf("failed to load roster for %s: %w", teamIDs[i], err)
+					continue
+				}
+				ratings[i] = se.rateTeam(gameData, roster, opponentRoster, games)
+			}
+		}()
+	}
+	for i := range teamIDs {
+		if ctx.Err() != nil {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	hash := models.ModelParameterHashFor(tuning)
+	computedAt := time.Now()
+	var rated []TeamRating
+	for i, rating := range ratings {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if rating.TeamID == "" {
+			continue // cancelled before this team started
+		}
+		rating.Season = season
+		rating.Opponent = opponent
+		rating.ModelParamHash = hash
+		rating.ComputedAt = computedAt
+		rated = append(rated, rating)
+	}
+	if err := ctx.Err(); err != nil {
+		return rated, err
+	}
+	return rated, se.saveTeamRatings(ctx, rated)
+}
+
+// allTeamIDs lists every team
+func (se *SimulationEngine) allTeamIDs(ctx context.Context) ([]string, error) {
+	rows, err := se.db.Query(ctx, `SELECT id::text FROM teams ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams: %w", err)
+	}
+	defer rows.Close()
+
+	var teamIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teamIDs = append(teamIDs, id)
+	}
+	return teamIDs, rows.Err()
+}
+
+// saveTeamRatings replaces each team's rating for the season and opponent
+func (se *SimulationEngine) saveTeamRatings(ctx context.Context, ratings []TeamRating) error {
+	for _, rating := range ratings {
+		_, err := se.db.Exec(ctx, `
+			INSERT INTO team_ratings (team_id, season, opponent, games, wins, win_pct,
+			                          runs_scored_per_game, runs_allowed_per_game, model_param_hash, computed_at)
+			VALUES ($1::uuid, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (team_id, season, opponent) DO UPDATE SET
+				games = EXCLUDED.games,
+				wins = EXCLUDED.wins,
+				win_pct = EXCLUDED.win_pct,
+				runs_scored_per_game = EXCLUDED.runs_scored_per_game,
+				runs_allowed_per_game = EXCLUDED.runs_allowed_per_game,
+				model_param_hash = EXCLUDED.model_param_hash,
+				computed_at = EXCLUDED.computed_at
+		`, rating.TeamID, rating.Season, rating.Opponent, rating.Games, rating.Wins, rating.WinPct,
+			rating.RunsScoredPerGame, rating.RunsAllowedPerGame, rating.ModelParamHash, rating.ComputedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save rating for %s: %w", rating.TeamID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestSyntheticRoster(t *testing.T) {
+	se := &SimulationEngine{}
+	league := models.DefaultLeagueEnvironment()
+
+	average := se.syntheticRoster(OpponentLeagueAverage, league)
+	if len(average.Lineup) != 9 || len(average.Rotation) != 5 || len(average.Bullpen) != 8 {
+		t.Fatalf("Expected 9 hitters, 5 starters and 8 relievers, got %d, %d and %d",
+			len(average.Lineup), len(average.Rotation), len(average.Bullpen))
+	}
+
+	replacement := se.syntheticRoster(OpponentReplacement, league)
+	avgHitter, repHitter := average.Players[0], replacement.Players[0]
+	if diff := avgHitter.Batting.WOBA - repHitter.Batting.WOBA; diff < 0.029 || diff > 0.031 {
+		t.Errorf("Expected replacement hitters %.3f wOBA worse, got %.3f", replacementWOBADrop, diff)
+	}
+	avgPitcher, repPitcher := average.Players[len(average.Players)-1], replacement.Players[len(replacement.Players)-1]
+	if diff := repPitcher.Pitching.FIP - avgPitcher.Pitching.FIP; diff < 0.89 || diff > 0.91 {
+		t.Errorf("Expected replacement pitchers %.2f FIP worse, got %.2f", replacementFIPRise, diff)
+	}
+}
+
+func TestRateTeam(t *testing.T) {
+	se := &SimulationEngine{}
+	league := models.DefaultLeagueEnvironment()
+	gameData := neutralGameData(league, models.DefaultDurationModel(), models.CurrentTuningParameters())
+
+	team := se.syntheticRoster(OpponentLeagueAverage, league)
+	team.TeamID = "team"
+	rating := se.rateTeam(gameData, team, se.syntheticRoster(OpponentReplacement, league), 400)
+
+	if rating.TeamID != "team" || rating.Games != 400 {
+		t.Errorf("Expected 400 games for team, got %+v", rating)
+	}
+	if rating.WinPct <= 0.5 {
+		t.Errorf("Expected a league-average team to beat replacement level, got %.3f", rating.WinPct)
+	}
+	if rating.RunsScoredPerGame <= rating.RunsAllowedPerGame {
+		t.Errorf("Expected a positive run differential, got %.2f scored and %.2f allowed",
+			rating.RunsScoredPerGame, rating.RunsAllowedPerGame)
+	}
+}
+
+func TestIsOpponentLevel(t *testing.T) {
+	for name, want := range map[string]bool{"league_average": true, "replacement": true, "": false, "yankees": false} {
+		if got := IsOpponentLevel(name); got != want {
+			t.Errorf("IsOpponentLevel(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package simulation
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+
+	"sim-engine/models"
+)
+
+// Result storage backends, chosen with RESULT_STORAGE
+const (
+	ResultStoragePostgres = "postgres" // one simulation_results row per simulation
+	ResultStorageS3       = "s3"       // one compressed object per run
+)
+
+// DefaultResultObjectPrefix is the key prefix of result objects
+const DefaultResultObjectPrefix = "simulation-results"
+
+// ErrResultObjectStoreUnavailable is returned when results are to be read
+// from or written to object storage but none is configured
+var ErrResultObjectStoreUnavailable = errors.New("object storage for results is not configured")
+
+// ObjectStore is S3-compatible storage for raw results
+type ObjectStore interface {
+	Put(ctx context.Context, key string, body []byte, contentType, contentEncoding string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// SetResultObjectStore connects the object storage results are kept in,
+// under prefix. Runs already stored there can be read and deleted whichever
+// backend new runs are written to.
+func (se *SimulationEngine) SetResultObjectStore(store ObjectStore, prefix string) {
+	se.resultObjects = store
+	se.resultObjectPrefix = prefix
+}
+
+// SetResultStorage chooses where new runs' raw results are written:
+// simulation_results rows, or one gzipped object of newline-delimited JSON
+// per run, which needs SetResultObjectStore first
+func (se *SimulationEngine) SetResultStorage(backend string) error {
+	switch backend {
+	case ResultStoragePostgres:
+		se.writeResultObjects = false
+	case ResultStorageS3:
+		if se.resultObjects == nil {
+			return ErrResultObjectStoreUnavailable
+		}
+		se.writeResultObjects = true
+	default:
+		return fmt.Errorf("unknown result storage %q (want %q or %q)", backend, ResultStoragePostgres, ResultStorageS3)
+	}
+	return nil
+}
+
+// resultObjectKey is where a run's results object is stored
+func resultObjectKey(prefix, runID string) string {
+	return path.Join(prefix, "runs", runID+".ndjson.gz")
+}
+
+// encodeResults writes results as gzipped newline-delimited JSON in
+// simulation number order
+func encodeResults(results []models.SimulationResult) ([]byte, error) {
+	ordered := append([]models.SimulationResult(nil), results...)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].SimulationNumber < ordered[j].SimulationNumber })
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	for _, result := range ordered {
+		if err := encoder.Encode(result); err != nil {
+			return nil, fmt.Errorf("failed to encode simulation result: %w", err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress simulation results: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeResults reads results written by encodeResults
+func decodeResults(r io.Reader) ([]models.SimulationResult, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress simulation results: %w", err)
+	}
+	defer gz.Close()
+
+	var results []models.SimulationResult
+	decoder := json.NewDecoder(bufio.NewReader(gz))
+	for {
+		var result models.SimulationResult
+		err := decoder.Decode(&result)
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode simulation result: %w", err)
+		}
+		results = append(results, result)
+	}
+}
+
+// storeResultsObject uploads a run's results as one object, then records its
+// key on the run and archives the results' high-leverage events together.
+// Returns the compressed size. Retrying overwrites the same object.
+func (se *SimulationEngine) storeResultsObject(ctx context.Context, runID string, results []models.SimulationResult) (int, error) {
+	if se.resultObjects == nil {
+		return 0, ErrResultObjectStoreUnavailable
+	}
+
+	body, err := encodeResults(results)
+	if err != nil {
+		return 0, err
+	}
+	key := resultObjectKey(se.resultObjectPrefix, runID)
+	if err := se.resultObjects.Put(ctx, key, body, "application/x-ndjson", "gzip"); err != nil {
+		return 0, fmt.Errorf("failed to upload simulation results: %w", err)
+	}
+
+	tx, err := se.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin result write: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Events from an earlier attempt that committed are replaced, not doubled
+	if _, err := tx.Exec(ctx, `DELETE FROM simulation_events WHERE run_id = $1`, runID); err != nil {
+		return 0, fmt.Errorf("failed to clear archived events: %w", err)
+	}
+	for _, result := range results {
+		if err := archiveLeverageEvents(ctx, tx, result); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := tx.Exec(ctx, `UPDATE simulation_runs SET results_object = $2 WHERE id = $1`, runID, key); err != nil {
+		return 0, fmt.Errorf("failed to record results object: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit results object: %w", err)
+	}
+	return len(body), nil
+}
+
+// StoredResults returns a run's raw results when they are kept in object
+// storage, in simulation number order. ok is false for runs whose results
+// are simulation_results rows (or that have none), which callers query
+// directly.
+func (se *SimulationEngine) StoredResults(ctx context.Context, runID string) (results []models.SimulationResult, ok bool, err error) {
+	var key *string
+	err = se.db.QueryRow(ctx, `SELECT results_object FROM simulation_runs WHERE id::text = $1`, runID).Scan(&key)
+	if err == pgx.ErrNoRows || (err == nil && key == nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up results object: %w", err)
+	}
+	if se.resultObjects == nil {
+		return nil, true, ErrResultObjectStoreUnavailable
+	}
+
+	body, err := se.resultObjects.Get(ctx, *key)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to fetch results object %s: %w", *key, err)
+	}
+	defer body.Close()
+
+	results, err = decodeResults(body)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read results object %s: %w", *key, err)
+	}
+	return results, true, nil
+}
+
+// deleteResultObjects removes deleted runs' result objects. Runs are already
+// gone from the database, so a failure only leaves an orphaned object.
+func (se *SimulationEngine) deleteResultObjects(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	if se.resultObjects == nil {
+		log.Printf("Left %d result objects in storage: object storage is not configured", len(keys))
+		return
+	}
+	for _, key := range keys {
+		if err := se.resultObjects.Delete(ctx, key); err != nil {
+			log.Printf("Failed to delete result object %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package simulation
+
+import (
+	"bytes"
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestEncodeDecodeResults(t *testing.T) {
+	results := []models.SimulationResult{
+		{RunID: "run-1", SimulationNumber: 2, HomeScore: 2, AwayScore: 4, GameDuration: 171},
+		{RunID: "run-1", SimulationNumber: 1, HomeScore: 5, AwayScore: 3, GameDuration: 204,
+			KeyEvents: []models.GameEvent{{Inning: 10, InningHalf: "bottom", Description: "Walk-off single", Leverage: 3.2}}},
+	}
+
+	body, err := encodeResults(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].SimulationNumber != 2 {
+		t.Error("encoding should not reorder the caller's results")
+	}
+
+	decoded, err := decodeResults(bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || decoded[0].SimulationNumber != 1 || decoded[1].SimulationNumber != 2 {
+		t.Fatalf("decoded = %+v, want both results in simulation order", decoded)
+	}
+	if decoded[0].GameDuration != 204 || len(decoded[0].KeyEvents) != 1 ||
+		decoded[0].KeyEvents[0].Description != "Walk-off single" {
+		t.Errorf("result did not round-trip: %+v", decoded[0])
+	}
+
+	if _, err := decodeResults(bytes.NewReader([]byte("not gzip"))); err == nil {
+		t.Error("expected an error for a corrupt object")
+	}
+}
+
+func TestResultObjectKey(t *testing.T) {
+	if got := resultObjectKey("simulation-results", "run-1"); got != "simulation-results/runs/run-1.ndjson.gz" {
+		t.Errorf("key = %q", got)
+	}
+	if got := resultObjectKey("", "run-1"); got != "runs/run-1.ndjson.gz" {
+		t.Errorf("key without a prefix = %q", got)
+	}
+}
+
+func TestSetResultStorage(t *testing.T) {
+	se := &SimulationEngine{}
+	if err := se.SetResultStorage(ResultStorageS3); err != ErrResultObjectStoreUnavailable {
+		t.Errorf("s3 without a store: got %v", err)
+	}
+	if err := se.SetResultStorage("dynamo"); err == nil {
+		t.Error("expected an error for an unknown backend")
+	}
+
+	se.SetResultObjectStore(nil, DefaultResultObjectPrefix)
+	if err := se.SetResultStorage(ResultStoragePostgres); err != nil || se.writeResultObjects {
+		t.Errorf("postgres: got %v, writeResultObjects %v", err, se.writeResultObjects)
+	}
+}
@@ -0,0 +1,167 @@
+package simulation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sim-engine/models"
+)
+
+// DefaultRosterCacheTTL is how long a loaded roster is reused. Data refreshes
+// invalidate the cache explicitly, so the TTL only bounds staleness when a
+// refresh notification is missed.
+const DefaultRosterCacheTTL = 6 * time.Hour
+
+// rosterCache keeps rosters, with their season stats applied and lineups
+// built, so repeated simulations of the same teams skip the player and stats
+// queries
+type rosterCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration // 0 disables caching
+	now     func() time.Time
+	entries map[rosterCacheKey]rosterCacheEntry
+	hits    int64
+	misses  int64
+}
+
+// Rosters depend on the league environment they were calibrated against
+type rosterCacheKey struct {
+	teamID string
+	season int
+}
+
+type rosterCacheEntry struct {
+	roster   *models.Roster
+	loadedAt time.Time
+}
+
+// RosterCacheStats reports roster cache usage since startup
+type RosterCacheStats struct {
+	Entries    int     `json:"entries"`
+	Hits       int64   `json:"hits"`
+	Misses     int64   `json:"misses"`
+	HitRate    float64 `json:"hit_rate"`
+	TTLSeconds float64 `json:"ttl_seconds"`
+}
+
+func newRosterCache(ttl time.Duration) *rosterCache {
+	return &rosterCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[rosterCacheKey]rosterCacheEntry),
+	}
+}
+
+// get returns a copy of a cached roster that is still fresh
+func (c *rosterCache) get(key rosterCacheKey) (*models.Roster, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && c.now().Sub(entry.loadedAt) >= c.ttl {
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	return copyRoster(entry.roster), true
+}
+
+func (c *rosterCache) put(key rosterCacheKey, roster *models.Roster) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// An empty roster means the team's players haven't been loaded yet
+	if c.ttl <= 0 || len(roster.Players) == 0 {
+		return
+	}
+	c.entries[key] = rosterCacheEntry{roster: copyRoster(roster), loadedAt: c.now()}
+}
+
+// invalidate drops every cached roster and returns how many there were
+func (c *rosterCache) invalidate() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	evicted := len(c.entries)
+	c.entries = make(map[rosterCacheKey]rosterCacheEntry)
+	return evicted
+}
+
+func (c *rosterCache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttl = ttl
+	if ttl <= 0 {
+		c.entries = make(map[rosterCacheKey]rosterCacheEntry)
+	}
+}
+
+func (c *rosterCache) stats() RosterCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := RosterCacheStats{
+		Entries:    len(c.entries),
+		Hits:       c.hits,
+		Misses:     c.misses,
+		TTLSeconds: c.ttl.Seconds(),
+	}
+	if total := c.hits + c.misses; total > 0 {
+		stats.HitRate = float64(c.hits) / float64(total)
+	}
+	return stats
+}
+
+// copyRoster copies a roster so a run can adjust it (bullpen availability,
+// sensitivity shifts) without touching the cached one. Players are plain
+// values, so copying the slices is enough.
+func copyRoster(roster *models.Roster) *models.Roster {
+	return &models.Roster{
+		TeamID:   roster.TeamID,
+		Players:  append([]models.Player(nil), roster.Players...),
+		Lineup:   append([]string(nil), roster.Lineup...),
+		Rotation: append([]string(nil), roster.Rotation...),
+		Bullpen:  append([]string(nil), roster.Bullpen...),
+		Gaps:     append([]string(nil), roster.Gaps...),
+	}
+}
+
+// SetRosterCacheTTL sets how long loaded rosters are reused; 0 disables the
+// cache
+func (se *SimulationEngine) SetRosterCacheTTL(ttl time.Duration) {
+	se.rosters.setTTL(ttl)
+}
+
+// InvalidateRosterCache drops every cached roster so the next run reloads
+// players and stats. Call it after a data refresh. It returns how many
+// rosters were dropped.
+func (se *SimulationEngine) InvalidateRosterCache() int {
+	return se.rosters.invalidate()
+}
+
+// RosterCacheStats reports roster cache usage
+func (se *SimulationEngine) RosterCacheStats() RosterCacheStats {
+	return se.rosters.stats()
+}
+
+// cachedTeamRoster returns a team's roster from the cache, loading it on a
+// miss
+func (se *SimulationEngine) cachedTeamRoster(ctx context.Context, teamID string, league *models.LeagueEnvironment) (*models.Roster, error) {
+	key := rosterCacheKey{teamID: teamID, season: league.Season}
+	if roster, ok := se.rosters.get(key); ok {
+		return roster, nil
+	}
+
+	roster, err := se.loadTeamRoster(ctx, teamID, league)
+	if err != nil {
+		return nil, err
+	}
+	se.rosters.put(key, roster)
+	return roster, nil
+}
@@ -0,0 +1,113 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"sim-engine/models"
+)
+
+func cacheTestRoster() *models.Roster {
+	return &models.Roster{
+		TeamID:  "team",
+		Players: []models.Player{{ID: "p1", Name: "First"}, {ID: "p2", Name: "Second"}},
+		Lineup:  []string{"p1"},
+		Bullpen: []string{"p2"},
+	}
+}
+
+func TestRosterCacheExpires(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	cache := newRosterCache(time.Hour)
+	cache.now = func() time.Time { return now }
+	key := rosterCacheKey{teamID: "team", season: 2024}
+
+	if _, ok := cache.get(key); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+	cache.put(key, cacheTestRoster())
+
+	now = now.Add(59 * time.Minute)
+	if _, ok := cache.get(key); !ok {
+		t.Error("expected a hit within the TTL")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := cache.get(key); ok {
+		t.Error("expected a miss once the TTL has passed")
+	}
+
+	stats := cache.stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Entries != 0 {
+		t.Errorf("stats = %+v, want 1 hit, 2 misses, 0 entries", stats)
+	}
+}
+
+func TestRosterCacheReturnsCopies(t *testing.T) {
+	cache := newRosterCache(time.Hour)
+	key := rosterCacheKey{teamID: "team", season: 2024}
+	loaded := cacheTestRoster()
+	cache.put(key, loaded)
+
+	// Changes by the loader or a run don't reach the cache
+	loaded.Players[0].Name = "Changed"
+	first, _ := cache.get(key)
+	first.Players[1].Name = "Changed"
+	first.Lineup[0] = "p2"
+	first.BullpenAvailability = map[string]float64{"p2": 0.5}
+
+	second, _ := cache.get(key)
+	if second.Players[0].Name != "First" || second.Players[1].Name != "Second" {
+		t.Errorf("cached players changed: %+v", second.Players)
+	}
+	if second.Lineup[0] != "p1" {
+		t.Errorf("cached lineup changed: %v", second.Lineup)
+	}
+	if second.BullpenAvailability != nil {
+		t.Error("cached roster should not carry a run's bullpen availability")
+	}
+}
+
+func TestRosterCacheKeepsGaps(t *testing.T) {
+	cache := newRosterCache(time.Hour)
+	key := rosterCacheKey{teamID: "team", season: 2024}
+	loaded := cacheTestRoster()
+	loaded.Gaps = []string{"C"}
+	cache.put(key, loaded)
+
+	// A degraded roster is still flagged when served from the cache
+	first, _ := cache.get(key)
+	if len(first.Gaps) != 1 || first.Gaps[0] != "C" {
+		t.Fatalf("cache hit gaps = %v, want [C]", first.Gaps)
+	}
+	first.Gaps[0] = "SS"
+	if second, _ := cache.get(key); second.Gaps[0] != "C" {
+		t.Errorf("cached gaps changed: %v", second.Gaps)
+	}
+}
+
+func TestRosterCacheInvalidate(t *testing.T) {
+	cache := newRosterCache(time.Hour)
+	cache.put(rosterCacheKey{teamID: "home", season: 2024}, cacheTestRoster())
+	cache.put(rosterCacheKey{teamID: "away", season: 2024}, cacheTestRoster())
+
+	if evicted := cache.invalidate(); evicted != 2 {
+		t.Errorf("evicted %d rosters, want 2", evicted)
+	}
+	if _, ok := cache.get(rosterCacheKey{teamID: "home", season: 2024}); ok {
+		t.Error("expected a miss after invalidation")
+	}
+}
+
+func TestRosterCacheSkipsEmptyAndDisabled(t *testing.T) {
+	cache := newRosterCache(time.Hour)
+	cache.put(rosterCacheKey{teamID: "empty", season: 2024}, &models.Roster{TeamID: "empty"})
+	if cache.stats().Entries != 0 {
+		t.Error("an empty roster should not be cached")
+	}
+
+	cache.setTTL(0)
+	cache.put(rosterCacheKey{teamID: "team", season: 2024}, cacheTestRoster())
+	if _, ok := cache.get(rosterCacheKey{teamID: "team", season: 2024}); ok {
+		t.Error("expected no caching with a zero TTL")
+	}
+}
@@ -0,0 +1,102 @@
+package simulation
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"sim-engine/models"
+)
+
+const (
+	// lineupSize is the number of hitters a lineup needs
+	lineupSize = 9
+
+	// Placeholder staffs match a synthetic roster's: five starters and
+	// eight relievers
+	placeholderStarters  = 5
+	placeholderStaffSize = 13
+)
+
+// fillRosterGaps completes a roster with fewer than nine hitters or no
+// pitcher with stats using replacement-level placeholders, so the run goes
+// ahead without missing lineup spots or statless pitchers rated as aces.
+// Placeholders are named "Replacement ..." and flagged, and roster.Gaps
+// records what was filled. Pitchers without stats are kept off a
+// placeholder staff.
+func (se *SimulationEngine) fillRosterGaps(roster *models.Roster, league *models.LeagueEnvironment) {
+	hitters, pitchersWithStats := 0, 0
+	positions := make(map[string]bool)
+	for _, player := range roster.Players {
+		if player.Position == "P" {
+			if player.Pitching.IP > 0 {
+				pitchersWithStats++
+			}
+			continue
+		}
+		hitters++
+		positions[player.Position] = true
+	}
+
+	var placeholders []models.Player
+	var gaps []string
+	if hitters < lineupSize {
+		added := 0
+		for i, position := range syntheticPositions {
+			if hitters+added == lineupSize {
+				break
+			}
+			if positions[position] {
+				continue
+			}
+			placeholders = append(placeholders, placeholderPlayer(roster.TeamID, position, string(syntheticHandedness[i])))
+			added++
+		}
+		gaps = append(gaps, fmt.Sprintf("%d of %d hitters, %d replacement-level hitters added", hitters, lineupSize, added))
+	}
+
+	var staff []string
+	if pitchersWithStats == 0 {
+		for i := 1; i <= placeholderStaffSize; i++ {
+			hand := "R"
+			if i%3 == 0 {
+				hand = "L"
+			}
+			pitcher := placeholderPlayer(roster.TeamID, fmt.Sprintf("P%d", i), hand)
+			pitcher.Position = "P"
+			placeholders = append(placeholders, pitcher)
+			staff = append(staff, pitcher.ID)
+		}
+		gaps = append(gaps, "no pitchers with stats, replacement-level staff used")
+	}
+
+	if len(gaps) == 0 {
+		return
+	}
+
+	se.setDefaultStatistics(placeholders, league)
+	for i := range placeholders {
+		applyReplacementLevel(&placeholders[i])
+	}
+	roster.Players = append(roster.Players, placeholders...)
+	se.generateLineups(roster)
+	if staff != nil {
+		roster.Rotation = staff[:placeholderStarters]
+		roster.Bullpen = staff[placeholderStarters:]
+	}
+	roster.Gaps = gaps
+	log.Printf("Roster for team %s is incomplete: %s", roster.TeamID, strings.Join(gaps, "; "))
+}
+
+// placeholderPlayer is a replacement-level stand-in at position, with an ID
+// unique to the team
+func placeholderPlayer(teamID, position, hand string) models.Player {
+	return models.Player{
+		ID:          fmt.Sprintf("replacement-%s-%s", teamID, position),
+		Name:        fmt.Sprintf("Replacement %s", position),
+		Position:    position,
+		TeamID:      teamID,
+		Hand:        hand,
+		Placeholder: true,
+	}
+}
@@ -0,0 +1,98 @@
+package simulation
+
+import (
+	"strings"
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestFillRosterGapsLeavesCompleteRosters(t *testing.T) {
+	se := &SimulationEngine{}
+	league := models.DefaultLeagueEnvironment()
+
+	roster := se.syntheticRoster(OpponentLeagueAverage, league)
+	players := len(roster.Players)
+	se.fillRosterGaps(roster, league)
+	if len(roster.Players) != players || roster.Gaps != nil {
+		t.Errorf("Expected a complete roster to be left alone, got %d players and gaps %v", len(roster.Players), roster.Gaps)
+	}
+}
+
+func TestFillRosterGapsAddsPlaceholders(t *testing.T) {
+	se := &SimulationEngine{}
+	league := models.DefaultLeagueEnvironment()
+
+	// Six hitters and two pitchers who never loaded stats
+	roster := &models.Roster{TeamID: "short"}
+	for _, position := range []string{"C", "1B", "2B", "SS", "LF", "CF"} {
+		roster.Players = append(roster.Players, models.Player{ID: "h-" + position, Position: position})
+	}
+	se.setDefaultStatistics(roster.Players, league)
+	roster.Players = append(roster.Players,
+		models.Player{ID: "p-1", Position: "P"}, models.Player{ID: "p-2", Position: "P"})
+	se.generateLineups(roster)
+
+	se.fillRosterGaps(roster, league)
+	if len(roster.Gaps) != 2 {
+		t.Fatalf("Expected hitter and pitcher gaps, got %v", roster.Gaps)
+	}
+
+	var added []string
+	for _, player := range roster.Players {
+		if player.Placeholder {
+			if !strings.HasPrefix(player.Name, "Replacement ") || !strings.Contains(player.ID, "short") {
+				t.Errorf("Expected a labeled, team-scoped placeholder, got %s (%s)", player.Name, player.ID)
+			}
+			if player.Position != "P" {
+				added = append(added, player.Position)
+			}
+		}
+	}
+	if strings.Join(added, ",") != "3B,RF,DH" {
+		t.Errorf("Expected placeholders at the uncovered positions 3B, RF and DH, got %v", added)
+	}
+	if len(roster.Lineup) != 9 {
+		t.Errorf("Expected a nine-man lineup, got %d", len(roster.Lineup))
+	}
+
+	// Statless pitchers would otherwise rate as aces with a 0.00 FIP
+	if len(roster.Rotation) != placeholderStarters || len(roster.Bullpen) != placeholderStaffSize-placeholderStarters {
+		t.Fatalf("Expected a placeholder staff, got %v and %v", roster.Rotation, roster.Bullpen)
+	}
+	for _, id := range append(roster.Rotation, roster.Bullpen...) {
+		if id == "p-1" || id == "p-2" {
+			t.Errorf("Expected statless pitcher %s off the staff", id)
+		}
+	}
+	if starter := se.getStartingPitcher(roster); starter == nil || !starter.Placeholder ||
+		starter.Pitching.FIP < league.LeagueFIP+replacementFIPRise-0.01 {
+		t.Errorf("Expected a replacement-level placeholder starter, got %+v", starter)
+	}
+
+	card := se.buildLineupCard(roster)
+	if len(card.RosterGaps) != 2 || !card.StartingPitcher.Placeholder {
+		t.Errorf("Expected the lineup card to carry the gaps and flag placeholders, got %+v", card)
+	}
+	if !(models.LineupCards{Home: card}).Degraded() {
+		t.Error("Expected lineup cards with gaps to be degraded")
+	}
+}
+
+func TestSimulateGameWithEmptyRoster(t *testing.T) {
+	se := &SimulationEngine{}
+	league := models.DefaultLeagueEnvironment()
+	gameData := neutralGameData(league, models.DefaultDurationModel(), models.CurrentTuningParameters())
+
+	empty := &models.Roster{TeamID: "empty"}
+	se.generateLineups(empty)
+	se.fillRosterGaps(empty, league)
+	opponent := se.syntheticRoster(OpponentLeagueAverage, league)
+
+	for i := 0; i < 20; i++ {
+		result := se.simulateGame("", i+1, gameData, empty, opponent, nil)
+		if result.HomeScore < 0 || result.AwayScore < 0 {
+			t.Fatalf("Expected a completed game, got %+v", result)
+		}
+	}
+}
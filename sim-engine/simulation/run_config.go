@@ -0,0 +1,159 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/baseball-sim/shared/runconfig"
+
+	"sim-engine/models"
+)
+
+// runConfigKeys are the run config keys the engine reads; anything else a
+// client sends is stored but has no effect. The list lives in the shared
+// runconfig package so the gateway can reject other keys up front.
+var runConfigKeys = []string{
+	asOfConfigKey,
+	stadiumConfigKey,
+	maxDurationConfigKey,
+	rainDelaysConfigKey,
+	scenarioBandsConfigKey,
+	platoonChangesConfigKey,
+	bullpenAvailabilityConfigKey,
+	playProbabilityConfigKey,
+	starterRolesConfigKey,
+}
+
+// RunOptions are a run's config options with the engine's defaults filled in
+type RunOptions struct {
+	AsOf                string             `json:"as_of"`                // "" for current data
+	StadiumID           string             `json:"stadium_id"`           // "" for the scheduled venue
+	MaxDurationSeconds  float64            `json:"max_duration_seconds"` // 0 for no budget
+	RainDelays          bool               `json:"rain_delays"`
+	ScenarioBands       bool               `json:"scenario_bands"`
+	PlatoonChanges      bool               `json:"platoon_changes"`
+	BullpenAvailability map[string]float64 `json:"bullpen_availability"`
+	PlayProbability     map[string]float64 `json:"play_probability"`
+	StarterRoles        map[string]string  `json:"starter_roles"` // home and away
+}
+
+// RunRules are the game rules built into the engine
+type RunRules struct {
+	Innings             int     `json:"innings"`
+	FirstFiveInnings    int     `json:"first_five_innings"`
+	StarterPitchLimit   int     `json:"starter_pitch_limit"`
+	RelieverPitchLimit  int     `json:"reliever_pitch_limit"`
+	RelieverMaxOuts     int     `json:"reliever_max_outs"`
+	MinBattersFaced     int     `json:"min_batters_faced"`
+	PlatoonChangeInning int     `json:"platoon_change_inning"`
+	PlatoonChangeMargin float64 `json:"platoon_change_margin"`
+
+	// Limits for starters on short rest or working as openers
+	ShortRestPitchLimit    int `json:"short_rest_pitch_limit"`
+	LongRelieverMaxOuts    int `json:"long_reliever_max_outs"`
+	LongRelieverPitchLimit int `json:"long_reliever_pitch_limit"`
+	OpenerMaxOuts          int `json:"opener_max_outs"`
+	BulkPitchLimit         int `json:"bulk_pitch_limit"`
+}
+
+// EffectiveConfig is everything a run was simulated with: the request's
+// options with defaults filled in, the rules and calibration in force and
+// the engine build. Seed is always null; games draw from the process-wide
+// random source, so a rerun reproduces the distribution, not each game.
+type EffectiveConfig struct {
+	SimulationRuns int                      `json:"simulation_runs"`
+	Options        RunOptions               `json:"options"`
+	Ignored        map[string]interface{}   `json:"ignored,omitempty"` // sent by the client but not read by the engine
+	Rules          RunRules                 `json:"rules"`
+	Tuning         *models.TuningParameters `json:"tuning"`
+	ModelParamHash string                   `json:"model_param_hash"`
+	EngineVersion  string                   `json:"engine_version"`
+	Seed           *int64                   `json:"seed"`
+}
+
+// DefaultRunRules returns the rules the engine simulates under
+func DefaultRunRules() RunRules {
+	return RunRules{
+		Innings:             9,
+		FirstFiveInnings:    models.FirstFiveInnings,
+		StarterPitchLimit:   models.StarterPitchLimit,
+		RelieverPitchLimit:  models.RelieverPitchLimit,
+		RelieverMaxOuts:     models.RelieverMaxOuts,
+		MinBattersFaced:     models.MinBattersFaced,
+		PlatoonChangeInning: models.PlatoonChangeInning,
+		PlatoonChangeMargin: models.PlatoonChangeMargin,
+
+		ShortRestPitchLimit:    models.ShortRestPitchLimit,
+		LongRelieverMaxOuts:    models.LongRelieverMaxOuts,
+		LongRelieverPitchLimit: models.LongRelieverPitchLimit,
+		OpenerMaxOuts:          models.OpenerMaxOuts,
+		BulkPitchLimit:         models.BulkPitchLimit,
+	}
+}
+
+// NewEffectiveConfig fills in a run's config with the engine's defaults.
+// asOf is the replay date the run resolved, or zero for current data. Invalid
+// options read as their defaults, as the engine treats them.
+func NewEffectiveConfig(config map[string]interface{}, simulationRuns int, asOf time.Time, tuning *models.TuningParameters) EffectiveConfig {
+	if tuning == nil {
+		tuning = models.CurrentTuningParameters()
+	}
+	effective := EffectiveConfig{
+		SimulationRuns: simulationRuns,
+		Options: RunOptions{
+			RainDelays:     RainDelaysFromConfig(config),
+			ScenarioBands:  ScenarioBandsFromConfig(config),
+			PlatoonChanges: PlatoonChangesFromConfig(config),
+		},
+		Rules:          DefaultRunRules(),
+		Tuning:         tuning,
+		ModelParamHash: models.ModelParameterHashFor(tuning),
+		EngineVersion:  EngineVersion,
+	}
+	if !asOf.IsZero() {
+		effective.Options.AsOf = asOf.Format("2006-01-02")
+	}
+	effective.Options.StadiumID, _ = StadiumOverrideFromConfig(config)
+	if maxDuration, _ := MaxDurationFromConfig(config); maxDuration > 0 {
+		effective.Options.MaxDurationSeconds = maxDuration.Seconds()
+	}
+	effective.Options.BullpenAvailability, _ = BullpenAvailabilityFromConfig(config)
+	if effective.Options.BullpenAvailability == nil {
+		effective.Options.BullpenAvailability = map[string]float64{}
+	}
+	effective.Options.PlayProbability, _ = PlayProbabilityFromConfig(config)
+	if effective.Options.PlayProbability == nil {
+		effective.Options.PlayProbability = map[string]float64{}
+	}
+	roles, _ := StarterRolesFromConfig(config)
+	effective.Options.StarterRoles = map[string]string{
+		"home": starterRole(roles, "home"),
+		"away": starterRole(roles, "away"),
+	}
+
+	for _, key := range runconfig.UnknownKeys(config) {
+		if effective.Ignored == nil {
+			effective.Ignored = make(map[string]interface{})
+		}
+		effective.Ignored[key] = config[key]
+	}
+	return effective
+}
+
+// recordEffectiveConfig stores the configuration a run started with
+func (se *SimulationEngine) recordEffectiveConfig(runID string, effective EffectiveConfig) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	effectiveJSON, err := json.Marshal(effective)
+	if err != nil {
+		log.Printf("Failed to marshal effective config for run %s: %v", runID, err)
+		return
+	}
+	if _, err := se.db.Exec(ctx, `UPDATE simulation_runs SET effective_config = $2 WHERE id = $1`,
+		runID, effectiveJSON); err != nil {
+		log.Printf("Failed to store effective config for run %s: %v", runID, err)
+	}
+}
@@ -0,0 +1,93 @@
+package simulation
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/baseball-sim/shared/runconfig"
+
+	"sim-engine/models"
+)
+
+func TestNewEffectiveConfigDefaults(t *testing.T) {
+	effective := NewEffectiveConfig(nil, 1000, time.Time{}, nil)
+
+	if effective.SimulationRuns != 1000 {
+		t.Errorf("simulation runs = %d, want 1000", effective.SimulationRuns)
+	}
+	options := effective.Options
+	if options.AsOf != "" || options.StadiumID != "" || options.MaxDurationSeconds != 0 ||
+		options.RainDelays || options.ScenarioBands || options.PlatoonChanges {
+		t.Errorf("empty config should get every default, got %+v", options)
+	}
+	if options.BullpenAvailability == nil {
+		t.Error("bullpen availability should be an empty map, not null")
+	}
+	if options.StarterRoles["home"] != models.StarterRoleNormal || options.StarterRoles["away"] != models.StarterRoleNormal {
+		t.Errorf("both starters should default to normal, got %v", options.StarterRoles)
+	}
+	if effective.Tuning == nil || effective.ModelParamHash != models.ModelParameterHashFor(models.CurrentTuningParameters()) {
+		t.Error("missing tuning should fall back to the active calibration")
+	}
+	if effective.EngineVersion != EngineVersion {
+		t.Errorf("engine version = %q, want %q", effective.EngineVersion, EngineVersion)
+	}
+	if effective.Rules.Innings != 9 || effective.Rules.MinBattersFaced != models.MinBattersFaced {
+		t.Errorf("unexpected rules %+v", effective.Rules)
+	}
+	if effective.Ignored != nil {
+		t.Errorf("nothing should be ignored, got %v", effective.Ignored)
+	}
+}
+
+func TestNewEffectiveConfigOptions(t *testing.T) {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+		"as_of": "game_date",
+		"stadium_id": 3313,
+		"max_duration_seconds": 90,
+		"rain_delays": true,
+		"platoon_changes": "yes",
+		"bullpen_availability": {"p1": 0.5},
+		"weather_effects": true
+	}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	tuning := models.DefaultTuningParameters()
+	tuning.HomeFieldWOBA = 0.01
+	asOf := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	effective := NewEffectiveConfig(config, 500, asOf, tuning)
+
+	options := effective.Options
+	if options.AsOf != "2024-06-01" {
+		t.Errorf("as_of = %q, want the resolved date", options.AsOf)
+	}
+	if options.StadiumID != "3313" {
+		t.Errorf("stadium_id = %q, want 3313", options.StadiumID)
+	}
+	if options.MaxDurationSeconds != 90 || !options.RainDelays || options.ScenarioBands {
+		t.Errorf("unexpected options %+v", options)
+	}
+	if options.PlatoonChanges {
+		t.Error("a non-boolean platoon_changes is off, as the engine reads it")
+	}
+	if options.BullpenAvailability["p1"] != 0.5 {
+		t.Errorf("bullpen availability = %v", options.BullpenAvailability)
+	}
+	if effective.Tuning != tuning || effective.ModelParamHash != models.ModelParameterHashFor(tuning) {
+		t.Error("the run's own calibration should be echoed")
+	}
+	if len(effective.Ignored) != 1 || effective.Ignored["weather_effects"] != true {
+		t.Errorf("ignored = %v, want only weather_effects", effective.Ignored)
+	}
+}
+
+func TestRunConfigKeysShared(t *testing.T) {
+	for _, key := range runConfigKeys {
+		if !runconfig.Known(key) {
+			t.Errorf("%s is read by the engine but missing from runconfig", key)
+		}
+	}
+}
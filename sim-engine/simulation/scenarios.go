@@ -0,0 +1,125 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"sim-engine/models"
+)
+
+const (
+	// scenarioBandsConfigKey is the run config key that adds pessimistic and
+	// optimistic variants to a run
+	scenarioBandsConfigKey = "scenario_bands"
+
+	// maxScenarioSimulations caps each variant; they run after the main
+	// simulations, so a large run doesn't triple in length
+	maxScenarioSimulations = 2000
+)
+
+// ScenarioBandsFromConfig reports whether a run computes scenario bands.
+// They are off unless the config sets scenario_bands to true.
+func ScenarioBandsFromConfig(config map[string]interface{}) bool {
+	enabled, _ := config[scenarioBandsConfigKey].(bool)
+	return enabled
+}
+
+// scenarioBands brackets a finished run with a pessimistic variant (home
+// players at their 25th percentile, away players at their 75th) and an
+// optimistic one (the reverse). The run itself is the median variant.
+func (se *SimulationEngine) scenarioBands(ctx context.Context, gameData *GameData, homeRoster, awayRoster *models.Roster,
+	config map[string]interface{}, median *models.AggregatedResult) (*models.ScenarioBands, error) {
+
+	simulations := median.TotalSimulations
+	if simulations > maxScenarioSimulations {
+		simulations = maxScenarioSimulations
+	}
+
+	pessimistic, err := se.simulateScenario(ctx, gameData, homeRoster, awayRoster, config, models.ScenarioPessimistic, -1, simulations)
+	if err != nil {
+		return nil, err
+	}
+	optimistic, err := se.simulateScenario(ctx, gameData, homeRoster, awayRoster, config, models.ScenarioOptimistic, 1, simulations)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewScenarioBands(pessimistic, models.ScenarioOutcome{
+		Scenario:           models.ScenarioMedian,
+		HomePercentile:     50,
+		AwayPercentile:     50,
+		Simulations:        median.TotalSimulations,
+		HomeWinProbability: math.Round(median.HomeWinProbability*10000) / 10000,
+		ExpectedHomeScore:  math.Round(median.ExpectedHomeScore*100) / 100,
+		ExpectedAwayScore:  math.Round(median.ExpectedAwayScore*100) / 100,
+		ExpectedTotal:      math.Round((median.ExpectedHomeScore+median.ExpectedAwayScore)*100) / 100,
+	}, optimistic), nil
+}
+
+// simulateScenario runs one variant with the home team's players homeZ
+// quartiles from their projection (-1 for the 25th percentile, 1 for the
+// 75th) and the away team's the opposite way
+func (se *SimulationEngine) simulateScenario(ctx context.Context, gameData *GameData, homeRoster, awayRoster *models.Roster,
+	config map[string]interface{}, scenario string, homeZ float64, simulations int) (models.ScenarioOutcome, error) {
+
+	outcome := models.ScenarioOutcome{
+		Scenario:       scenario,
+		HomePercentile: 50 + int(homeZ)*25,
+		AwayPercentile: 50 - int(homeZ)*25,
+	}
+
+	variant := *gameData
+	variant.HomeScenarioWOBA, variant.AwayScenarioWOBA = models.ScenarioWOBAShift(homeZ, -homeZ)
+
+	workers := se.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	var homeWins, played, homeRuns, awayRuns int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			var wins, games, home, away int
+			for n := worker; n < simulations; n += workers {
+				if ctx.Err() != nil {
+					break
+				}
+				result := se.simulateGame("", n+1, &variant, homeRoster, awayRoster, config)
+				games++
+				home += result.HomeScore
+				away += result.AwayScore
+				if result.Winner == "home" {
+					wins++
+				}
+			}
+			mu.Lock()
+			homeWins += wins
+			played += games
+			homeRuns += home
+			awayRuns += away
+			mu.Unlock()
+		}(w)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return outcome, fmt.Errorf("%s scenario interrupted: %w", scenario, err)
+	}
+	if played == 0 {
+		return outcome, fmt.Errorf("no %s scenario simulations ran", scenario)
+	}
+
+	games := float64(played)
+	outcome.Simulations = played
+	outcome.HomeWinProbability = math.Round(float64(homeWins)/games*10000) / 10000
+	outcome.ExpectedHomeScore = math.Round(float64(homeRuns)/games*100) / 100
+	outcome.ExpectedAwayScore = math.Round(float64(awayRuns)/games*100) / 100
+	outcome.ExpectedTotal = math.Round(float64(homeRuns+awayRuns)/games*100) / 100
+	return outcome, nil
+}
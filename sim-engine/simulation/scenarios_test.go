@@ -0,0 +1,47 @@
+package simulation
+
+import (
+	"context"
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestScenarioBandsFromConfig(t *testing.T) {
+	if ScenarioBandsFromConfig(nil) || ScenarioBandsFromConfig(map[string]interface{}{"scenario_bands": "yes"}) {
+		t.Error("scenario bands should be off unless scenario_bands is true")
+	}
+	if !ScenarioBandsFromConfig(map[string]interface{}{"scenario_bands": true}) {
+		t.Error("scenario_bands: true should turn scenario bands on")
+	}
+}
+
+func TestScenarioBands(t *testing.T) {
+	se := &SimulationEngine{workers: 2}
+	league := models.DefaultLeagueEnvironment()
+	gameData := neutralGameData(league, models.DefaultDurationModel(), models.CurrentTuningParameters())
+	home := se.syntheticRoster(OpponentLeagueAverage, league)
+	away := se.syntheticRoster(OpponentLeagueAverage, league)
+
+	median := &models.AggregatedResult{TotalSimulations: 600, HomeWinProbability: 0.53, ExpectedHomeScore: 4.5, ExpectedAwayScore: 4.4}
+	bands, err := se.scenarioBands(context.Background(), gameData, home, away, nil, median)
+	if err != nil {
+		t.Fatalf("scenarioBands: %v", err)
+	}
+
+	pessimistic, optimistic := bands.Scenarios[0], bands.Scenarios[2]
+	if pessimistic.HomePercentile != 25 || pessimistic.AwayPercentile != 75 || optimistic.HomePercentile != 75 {
+		t.Errorf("Unexpected percentiles: %+v and %+v", pessimistic, optimistic)
+	}
+	if pessimistic.Simulations != 600 || bands.Scenarios[1].Simulations != 600 {
+		t.Errorf("Expected each variant to run the run's 600 simulations, got %d", pessimistic.Simulations)
+	}
+	if pessimistic.HomeWinProbability >= optimistic.HomeWinProbability {
+		t.Errorf("Expected the optimistic variant to favor the home team more, got %.3f and %.3f",
+			pessimistic.HomeWinProbability, optimistic.HomeWinProbability)
+	}
+	if pessimistic.ExpectedHomeScore >= optimistic.ExpectedHomeScore {
+		t.Errorf("Expected the home team to score more in the optimistic variant, got %.2f and %.2f",
+			pessimistic.ExpectedHomeScore, optimistic.ExpectedHomeScore)
+	}
+}
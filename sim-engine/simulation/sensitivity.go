@@ -0,0 +1,254 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"sim-engine/models"
+)
+
+const (
+	// SensitivityStarterERADelta is how far each starter's run prevention is
+	// moved. The engine prices pitchers on FIP, so ERA and FIP move together.
+	SensitivityStarterERADelta = 0.5
+
+	// SensitivityWindDelta is how far wind speed is moved, in MPH
+	SensitivityWindDelta = 10
+)
+
+// Inputs perturbed by a sensitivity analysis
+const (
+	SensitivityHomeStarterERA = "home_starter_era"
+	SensitivityAwayStarterERA = "away_starter_era"
+	SensitivityHomeLineup     = "home_lineup_order"
+	SensitivityAwayLineup     = "away_lineup_order"
+	SensitivityWindSpeed      = "wind_speed"
+)
+
+// lineupSwaps are the batting order slots exchanged for the lineup inputs
+var lineupSwaps = [][2]int{{0, 1}, {2, 3}}
+
+// SensitivityScenario is one perturbed re-run of an input
+type SensitivityScenario struct {
+	Label              string  `json:"label"`
+	HomeWinProbability float64 `json:"home_win_probability"`
+	Impact             float64 `json:"home_win_probability_impact"` // versus the unperturbed quick check
+}
+
+// SensitivityInput is one tornado chart bar: how far the home win probability
+// moves across an input's perturbations
+type SensitivityInput struct {
+	Input      string                `json:"input"`
+	Baseline   interface{}           `json:"baseline"`
+	Scenarios  []SensitivityScenario `json:"scenarios"`
+	LowImpact  float64               `json:"low_impact"`  // most negative impact, or 0
+	HighImpact float64               `json:"high_impact"` // most positive impact, or 0
+	Swing      float64               `json:"swing"`       // HighImpact - LowImpact
+	Note       string                `json:"note,omitempty"`
+}
+
+// SensitivityAnalysis ranks a run's inputs by how much they move the home win
+// probability, largest swing first
+type SensitivityAnalysis struct {
+	QuickSimulations    int                `json:"quick_simulations"`
+	QuickWinProbability float64            `json:"quick_home_win_probability"`
+	QuickCheckMargin    float64            `json:"quick_check_margin"` // 95% margin on each scenario's impact
+	Inputs              []SensitivityInput `json:"inputs"`
+}
+
+// sensitivityScenario is a perturbed copy of a run's game and rosters
+type sensitivityScenario struct {
+	label      string
+	game       GameData
+	home, away *models.Roster
+}
+
+// AnalyzeSensitivity re-runs quick simulations of a run's scenario with each
+// key input nudged in turn: each starter's ERA by ±0.5, two batting order
+// swaps per lineup and wind speed by ±10 MPH. An input's swing is the spread
+// of home win probability across its perturbations, including the
+// unperturbed check.
+func (se *SimulationEngine) AnalyzeSensitivity(ctx context.Context, gameID string, inputs RunInputs,
+	simulations int) (*SensitivityAnalysis, error) {
+
+	game, home, away, err := se.loadRunScenario(ctx, gameID, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	baseProb, err := se.quickWinProbability(ctx, &game, home, away, simulations)
+	if err != nil {
+		return nil, err
+	}
+	analysis := &SensitivityAnalysis{
+		QuickSimulations:    simulations,
+		QuickWinProbability: math.Round(baseProb*10000) / 10000,
+		QuickCheckMargin:    models.WinProbabilityMargin(baseProb, simulations) * math.Sqrt2,
+		Inputs:              []SensitivityInput{},
+	}
+
+	for _, input := range sensitivityInputs(game, home, away) {
+		for _, scenario := range input.scenarios {
+			prob, err := se.quickWinProbability(ctx, &scenario.game, scenario.home, scenario.away, simulations)
+			if err != nil {
+				return nil, err
+			}
+			input.result.Scenarios = append(input.result.Scenarios, SensitivityScenario{
+				Label:              scenario.label,
+				HomeWinProbability: math.Round(prob*10000) / 10000,
+				Impact:             math.Round((prob-baseProb)*10000) / 10000,
+			})
+		}
+		analysis.Inputs = append(analysis.Inputs, summarizeSensitivity(input.result))
+	}
+
+	sort.SliceStable(analysis.Inputs, func(i, j int) bool {
+		return analysis.Inputs[i].Swing > analysis.Inputs[j].Swing
+	})
+	return analysis, nil
+}
+
+// pendingSensitivityInput is an input with the scenarios still to be run
+type pendingSensitivityInput struct {
+	result    SensitivityInput
+	scenarios []sensitivityScenario
+}
+
+// sensitivityInputs builds the perturbed scenarios for every input. Inputs
+// that can't be perturbed are returned with a note and no scenarios.
+func sensitivityInputs(game GameData, home, away *models.Roster) []pendingSensitivityInput {
+	var inputs []pendingSensitivityInput
+
+	for _, side := range []struct {
+		input  string
+		roster *models.Roster
+		isHome bool
+	}{
+		{SensitivityHomeStarterERA, home, true},
+		{SensitivityAwayStarterERA, away, false},
+	} {
+		pending := pendingSensitivityInput{result: SensitivityInput{Input: side.input}}
+		starter := startingPitcher(side.roster)
+		if starter == nil {
+			pending.result.Note = "No starting pitcher to perturb"
+			inputs = append(inputs, pending)
+			continue
+		}
+		pending.result.Baseline = map[string]interface{}{
+			"player_id": starter.ID, "name": starter.Name, "era": starter.Pitching.ERA, "fip": starter.Pitching.FIP,
+		}
+		for _, delta := range []float64{-SensitivityStarterERADelta, SensitivityStarterERADelta} {
+			scenario := sensitivityScenario{
+				label: fmt.Sprintf("ERA %+.1f", delta),
+				game:  game, home: home, away: away,
+			}
+			if side.isHome {
+				scenario.home = withStarterERAShift(home, delta)
+			} else {
+				scenario.away = withStarterERAShift(away, delta)
+			}
+			pending.scenarios = append(pending.scenarios, scenario)
+		}
+		inputs = append(inputs, pending)
+	}
+
+	for _, side := range []struct {
+		input  string
+		roster *models.Roster
+		isHome bool
+	}{
+		{SensitivityHomeLineup, home, true},
+		{SensitivityAwayLineup, away, false},
+	} {
+		pending := pendingSensitivityInput{result: SensitivityInput{
+			Input:    side.input,
+			Baseline: append([]string{}, side.roster.Lineup...),
+		}}
+		for _, swap := range lineupSwaps {
+			if swap[1] >= len(side.roster.Lineup) {
+				pending.result.Note = "Lineup is too short to swap batting order slots"
+				continue
+			}
+			scenario := sensitivityScenario{
+				label: fmt.Sprintf("Swap batting order slots %d and %d", swap[0]+1, swap[1]+1),
+				game:  game, home: home, away: away,
+			}
+			if side.isHome {
+				scenario.home = withLineupSwap(home, swap[0], swap[1])
+			} else {
+				scenario.away = withLineupSwap(away, swap[0], swap[1])
+			}
+			pending.scenarios = append(pending.scenarios, scenario)
+		}
+		inputs = append(inputs, pending)
+	}
+
+	wind := pendingSensitivityInput{result: SensitivityInput{
+		Input:    SensitivityWindSpeed,
+		Baseline: map[string]interface{}{"wind_speed": game.Weather.WindSpeed, "wind_dir": game.Weather.WindDir},
+	}}
+	for _, delta := range []int{-SensitivityWindDelta, SensitivityWindDelta} {
+		speed := game.Weather.WindSpeed + delta
+		if speed < 0 {
+			speed = 0
+		}
+		if speed == game.Weather.WindSpeed {
+			continue
+		}
+		scenario := sensitivityScenario{label: fmt.Sprintf("Wind %d MPH", speed), game: game, home: home, away: away}
+		scenario.game.Weather.WindSpeed = speed
+		wind.scenarios = append(wind.scenarios, scenario)
+	}
+	inputs = append(inputs, wind)
+
+	return inputs
+}
+
+// summarizeSensitivity computes an input's tornado bar from its scenarios.
+// The unperturbed check anchors the bar at zero.
+func summarizeSensitivity(input SensitivityInput) SensitivityInput {
+	if input.Scenarios == nil {
+		input.Scenarios = []SensitivityScenario{}
+	}
+	for _, scenario := range input.Scenarios {
+		input.LowImpact = math.Min(input.LowImpact, scenario.Impact)
+		input.HighImpact = math.Max(input.HighImpact, scenario.Impact)
+	}
+	input.Swing = math.Round((input.HighImpact-input.LowImpact)*10000) / 10000
+	return input
+}
+
+// startingPitcher returns the roster's scheduled starter, or nil
+func startingPitcher(roster *models.Roster) *models.Player {
+	if len(roster.Rotation) == 0 {
+		return nil
+	}
+	for i := range roster.Players {
+		if roster.Players[i].ID == roster.Rotation[0] {
+			return &roster.Players[i]
+		}
+	}
+	return nil
+}
+
+// withStarterERAShift returns a copy of the roster whose starter's ERA and
+// FIP are moved by delta. Other players are shared with the original.
+func withStarterERAShift(roster *models.Roster, delta float64) *models.Roster {
+	shifted := *roster
+	shifted.Players = append([]models.Player{}, roster.Players...)
+	if starter := startingPitcher(&shifted); starter != nil {
+		starter.Pitching.ERA = math.Max(0, starter.Pitching.ERA+delta)
+		starter.Pitching.FIP = math.Max(0, starter.Pitching.FIP+delta)
+	}
+	return &shifted
+}
+
+// withLineupSwap returns a copy of the roster with two batting order slots
+// exchanged
+func withLineupSwap(roster *models.Roster, i, j int) *models.Roster {
+	swapped := withLineup(roster, roster.Lineup)
+	swapped.Lineup[i], swapped.Lineup[j] = swapped.Lineup[j], swapped.Lineup[i]
+	return swapped
+}
@@ -0,0 +1,88 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestSummarizeSensitivity(t *testing.T) {
+	input := summarizeSensitivity(SensitivityInput{
+		Input: SensitivityHomeStarterERA,
+		Scenarios: []SensitivityScenario{
+			{Label: "ERA -0.5", Impact: 0.031},
+			{Label: "ERA +0.5", Impact: -0.027},
+		},
+	})
+	if input.LowImpact != -0.027 || input.HighImpact != 0.031 || input.Swing != 0.058 {
+		t.Errorf("Expected -0.027 to 0.031 with a 0.058 swing, got %+v", input)
+	}
+
+	// One-sided perturbations are anchored at the unperturbed check
+	oneSided := summarizeSensitivity(SensitivityInput{Scenarios: []SensitivityScenario{{Impact: 0.01}, {Impact: 0.004}}})
+	if oneSided.LowImpact != 0 || oneSided.Swing != 0.01 {
+		t.Errorf("Expected a 0 to 0.01 bar, got %+v", oneSided)
+	}
+
+	if empty := summarizeSensitivity(SensitivityInput{}); empty.Scenarios == nil || empty.Swing != 0 {
+		t.Errorf("Expected an empty bar with no scenarios, got %+v", empty)
+	}
+}
+
+func TestWithStarterERAShift(t *testing.T) {
+	roster := &models.Roster{
+		Players:  []models.Player{{ID: "p1", Pitching: models.PitchingStats{ERA: 3.2, FIP: 3.4}}, {ID: "p2"}},
+		Rotation: []string{"p1", "p2"},
+	}
+
+	shifted := withStarterERAShift(roster, 0.5)
+	starter := startingPitcher(shifted)
+	if starter.Pitching.ERA != 3.7 || starter.Pitching.FIP != 3.9 {
+		t.Errorf("Expected ERA 3.7 and FIP 3.9, got %.2f and %.2f", starter.Pitching.ERA, starter.Pitching.FIP)
+	}
+	if roster.Players[0].Pitching.ERA != 3.2 {
+		t.Errorf("Expected original roster untouched, got ERA %.2f", roster.Players[0].Pitching.ERA)
+	}
+}
+
+func TestWithLineupSwap(t *testing.T) {
+	roster := &models.Roster{Lineup: []string{"a", "b", "c", "d"}}
+
+	swapped := withLineupSwap(roster, 2, 3)
+	if !sameIDs(swapped.Lineup, []string{"a", "b", "d", "c"}) {
+		t.Errorf("Expected slots 3 and 4 swapped, got %v", swapped.Lineup)
+	}
+	if !sameIDs(roster.Lineup, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected original lineup untouched, got %v", roster.Lineup)
+	}
+}
+
+func TestSensitivityInputsClampWind(t *testing.T) {
+	home := &models.Roster{Lineup: []string{"a"}}
+	away := &models.Roster{Lineup: []string{"x", "y", "z", "w"}}
+	game := GameData{Weather: models.Weather{WindSpeed: 4, WindDir: "out"}}
+
+	inputs := sensitivityInputs(game, home, away)
+	byName := make(map[string]pendingSensitivityInput)
+	for _, input := range inputs {
+		byName[input.result.Input] = input
+	}
+
+	wind := byName[SensitivityWindSpeed]
+	if len(wind.scenarios) != 2 || wind.scenarios[0].game.Weather.WindSpeed != 0 || wind.scenarios[1].game.Weather.WindSpeed != 14 {
+		t.Errorf("Expected wind scenarios of 0 and 14 MPH, got %+v", wind.scenarios)
+	}
+	if game.Weather.WindSpeed != 4 {
+		t.Errorf("Expected baseline weather untouched, got %d MPH", game.Weather.WindSpeed)
+	}
+
+	if starter := byName[SensitivityHomeStarterERA]; len(starter.scenarios) != 0 || starter.result.Note == "" {
+		t.Errorf("Expected a note for a roster without a rotation, got %+v", starter.result)
+	}
+	if lineup := byName[SensitivityHomeLineup]; len(lineup.scenarios) != 0 || lineup.result.Note == "" {
+		t.Errorf("Expected a note for a one-man lineup, got %+v", lineup.result)
+	}
+	if lineup := byName[SensitivityAwayLineup]; len(lineup.scenarios) != len(lineupSwaps) {
+		t.Errorf("Expected %d away lineup swaps, got %d", len(lineupSwaps), len(lineup.scenarios))
+	}
+}
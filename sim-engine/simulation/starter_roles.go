@@ -0,0 +1,76 @@
+package simulation
+
+import (
+	"fmt"
+
+	"sim-engine/models"
+)
+
+// starterRolesConfigKey is the run config key marking a side's starter as on
+// short rest or an opener
+const starterRolesConfigKey = "starter_roles"
+
+// StarterRolesFromConfig reads each side's starter role from a run's config,
+// e.g. {"starter_roles": {"home": "short_rest", "away": "opener"}}. Sides not
+// listed start normally.
+func StarterRolesFromConfig(config map[string]interface{}) (map[string]string, error) {
+	raw, ok := config[starterRolesConfigKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	// Config arrives decoded from JSON or built in-process
+	roles := make(map[string]string)
+	switch values := raw.(type) {
+	case map[string]string:
+		for side, role := range values {
+			roles[side] = role
+		}
+	case map[string]interface{}:
+		for side, value := range values {
+			role, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s.%s must be a string", starterRolesConfigKey, side)
+			}
+			roles[side] = role
+		}
+	default:
+		return nil, fmt.Errorf("%s must map home and away to a role", starterRolesConfigKey)
+	}
+
+	for side, role := range roles {
+		if side != "home" && side != "away" {
+			return nil, fmt.Errorf("%s keys must be home or away, got %q", starterRolesConfigKey, side)
+		}
+		if !models.ValidStarterRole(role) {
+			return nil, fmt.Errorf("%s.%s must be normal, short_rest or opener", starterRolesConfigKey, side)
+		}
+	}
+	return roles, nil
+}
+
+// starterRole returns a side's starter role, normal when unset
+func starterRole(roles map[string]string, side string) string {
+	if role, ok := roles[side]; ok {
+		return role
+	}
+	return models.StarterRoleNormal
+}
+
+// applyStarterRoles plans each staff around its starter's role and marks
+// short-rest starters for their penalties
+func applyStarterRoles(gameState *models.GameState, roles map[string]string, homeStaff, awayStaff *models.PitchingStaff) {
+	for _, side := range []struct {
+		name  string
+		staff *models.PitchingStaff
+	}{{"home", homeStaff}, {"away", awayStaff}} {
+		role := starterRole(roles, side.name)
+		side.staff.SetStarterRole(role)
+		if role == models.StarterRoleShortRest && side.staff.Current != nil {
+			if gameState.ShortRestPitchers == nil {
+				gameState.ShortRestPitchers = make(map[string]bool)
+			}
+			gameState.ShortRestPitchers[side.staff.Current.ID] = true
+		}
+	}
+}
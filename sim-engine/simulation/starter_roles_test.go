@@ -0,0 +1,57 @@
+package simulation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestStarterRolesFromConfig(t *testing.T) {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"starter_roles": {"home": "short_rest", "away": "opener"}}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	roles, err := StarterRolesFromConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roles["home"] != models.StarterRoleShortRest || roles["away"] != models.StarterRoleOpener {
+		t.Errorf("roles = %v", roles)
+	}
+
+	if roles, err := StarterRolesFromConfig(nil); roles != nil || err != nil {
+		t.Errorf("missing key = %v, %v; want nil, nil", roles, err)
+	}
+
+	for _, raw := range []string{
+		`{"starter_roles": "opener"}`,
+		`{"starter_roles": {"home": 3}}`,
+		`{"starter_roles": {"bullpen": "opener"}}`,
+		`{"starter_roles": {"away": "piggyback"}}`,
+	} {
+		var bad map[string]interface{}
+		json.Unmarshal([]byte(raw), &bad)
+		if _, err := StarterRolesFromConfig(bad); err == nil {
+			t.Errorf("%s should be rejected", raw)
+		}
+	}
+}
+
+func TestApplyStarterRoles(t *testing.T) {
+	homeStarter := &models.Player{ID: "home-sp"}
+	awayStarter := &models.Player{ID: "away-sp"}
+	homeStaff := models.NewPitchingStaff(&models.Roster{}, homeStarter)
+	awayStaff := models.NewPitchingStaff(&models.Roster{}, awayStarter)
+	state := &models.GameState{}
+
+	applyStarterRoles(state, map[string]string{"away": models.StarterRoleShortRest}, homeStaff, awayStaff)
+
+	if !state.ShortRestPenaltyFor(awayStarter) || state.ShortRestPenaltyFor(homeStarter) {
+		t.Errorf("short-rest pitchers = %v, want only away-sp", state.ShortRestPitchers)
+	}
+	awayStaff.Record(3, models.ShortRestPitchLimit)
+	if !awayStaff.NeedsReliever() {
+		t.Error("the away staff should use the short-rest pitch limit")
+	}
+}
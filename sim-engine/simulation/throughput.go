@@ -0,0 +1,145 @@
+package simulation
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// throughputWindow is how many recent runs feed the cost estimate
+	throughputWindow = 20
+
+	// Fallbacks used until the engine has completed a run
+	defaultCPUPerSimulation   = 2 * time.Millisecond
+	defaultBytesPerSimulation = 4096
+
+	// Fixed per-run storage for the run row and aggregated result
+	bytesPerRunOverhead = 64 * 1024
+
+	// Approximate Postgres row and index overhead per stored simulation result
+	rowOverheadBytes = 200
+)
+
+// ThroughputSample records the cost of one completed simulation run
+type ThroughputSample struct {
+	Simulations int
+	WallTime    time.Duration
+	CPUTime     time.Duration
+	StoredBytes int64
+	Workers     int
+	CompletedAt time.Time
+}
+
+// CostEstimate is the projected cost of a simulation job
+type CostEstimate struct {
+	SimulationRuns       int     `json:"simulation_runs"`
+	Games                int     `json:"games"`
+	TotalSimulations     int     `json:"total_simulations"`
+	Workers              int     `json:"workers"`
+	EstimatedWallSeconds float64 `json:"estimated_wall_seconds"`
+	EstimatedCPUSeconds  float64 `json:"estimated_cpu_seconds"`
+	EstimatedStorageMB   float64 `json:"estimated_storage_mb"`
+	SimulationsPerSecond float64 `json:"simulations_per_second"`
+	SampleRuns           int     `json:"sample_runs"`
+	Basis                string  `json:"basis"` // "recent_runs" or "defaults"
+}
+
+// throughputTracker keeps a rolling window of recent run costs
+type throughputTracker struct {
+	mu      sync.RWMutex
+	samples []ThroughputSample
+}
+
+func (tt *throughputTracker) record(sample ThroughputSample) {
+	if sample.Simulations <= 0 {
+		return
+	}
+
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	tt.samples = append(tt.samples, sample)
+	if len(tt.samples) > throughputWindow {
+		tt.samples = tt.samples[len(tt.samples)-throughputWindow:]
+	}
+}
+
+// perSimulation returns the average CPU time, wall time (at the sampled
+// worker count) and stored bytes per simulated game
+func (tt *throughputTracker) perSimulation() (cpu time.Duration, wall time.Duration, bytes float64, workers float64, n int) {
+	tt.mu.RLock()
+	defer tt.mu.RUnlock()
+
+	var totalSims int
+	var totalCPU, totalWall time.Duration
+	var totalBytes int64
+	var totalWorkers int
+	for _, sample := range tt.samples {
+		totalSims += sample.Simulations
+		totalCPU += sample.CPUTime
+		totalWall += sample.WallTime
+		totalBytes += sample.StoredBytes
+		totalWorkers += sample.Workers
+	}
+
+	n = len(tt.samples)
+	if totalSims == 0 {
+		return 0, 0, 0, 0, n
+	}
+
+	cpu = totalCPU / time.Duration(totalSims)
+	wall = totalWall / time.Duration(totalSims)
+	bytes = float64(totalBytes) / float64(totalSims)
+	workers = float64(totalWorkers) / float64(n)
+	return cpu, wall, bytes, workers, n
+}
+
+// EstimateCost projects wall-clock time, CPU time and storage for running
+// simulationRuns simulations of each of games games on this engine
+func (se *SimulationEngine) EstimateCost(simulationRuns, games int) CostEstimate {
+	if games <= 0 {
+		games = 1
+	}
+	total := simulationRuns * games
+
+	estimate := CostEstimate{
+		SimulationRuns:   simulationRuns,
+		Games:            games,
+		TotalSimulations: total,
+		Workers:          se.workers,
+		Basis:            "defaults",
+	}
+
+	cpuPerSim, wallPerSim, bytesPerSim, sampledWorkers, n := se.throughput.perSimulation()
+	estimate.SampleRuns = n
+
+	if cpuPerSim > 0 {
+		estimate.Basis = "recent_runs"
+	} else {
+		cpuPerSim = defaultCPUPerSimulation
+		bytesPerSim = defaultBytesPerSimulation
+		sampledWorkers = float64(se.workers)
+	}
+
+	// Wall time scales with parallelism; prefer observed wall time when the
+	// samples came from the same worker count, since it includes storage
+	wallSeconds := cpuPerSim.Seconds() * float64(total) / math.Max(1, float64(se.workers))
+	if wallPerSim > 0 && int(math.Round(sampledWorkers)) == se.workers {
+		wallSeconds = math.Max(wallSeconds, wallPerSim.Seconds()*float64(total))
+	}
+
+	estimate.EstimatedCPUSeconds = roundTo(cpuPerSim.Seconds()*float64(total), 2)
+	estimate.EstimatedWallSeconds = roundTo(wallSeconds, 2)
+	estimate.EstimatedStorageMB = roundTo((bytesPerSim*float64(total)+float64(bytesPerRunOverhead*games))/(1024*1024), 2)
+	if wallSeconds > 0 {
+		estimate.SimulationsPerSecond = roundTo(float64(total)/wallSeconds, 1)
+	}
+
+	return estimate
+}
+
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,65 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+)
+
+// TestEstimateCostDefaults tests estimates before any run has completed
+func TestEstimateCostDefaults(t *testing.T) {
+	se := NewSimulationEngine(nil, 4, 1000)
+
+	estimate := se.EstimateCost(1000, 0)
+	if estimate.Basis != "defaults" {
+		t.Errorf("Expected defaults basis, got %s", estimate.Basis)
+	}
+	if estimate.Games != 1 || estimate.TotalSimulations != 1000 {
+		t.Errorf("Expected 1 game and 1000 simulations, got %d and %d", estimate.Games, estimate.TotalSimulations)
+	}
+	if estimate.EstimatedCPUSeconds <= 0 || estimate.EstimatedWallSeconds <= 0 || estimate.EstimatedStorageMB <= 0 {
+		t.Errorf("Expected positive estimates, got %+v", estimate)
+	}
+	if estimate.EstimatedWallSeconds > estimate.EstimatedCPUSeconds {
+		t.Errorf("Expected parallel wall time below CPU time, got wall=%f cpu=%f",
+			estimate.EstimatedWallSeconds, estimate.EstimatedCPUSeconds)
+	}
+}
+
+// TestEstimateCostRecentRuns tests estimates derived from recorded throughput
+func TestEstimateCostRecentRuns(t *testing.T) {
+	se := NewSimulationEngine(nil, 4, 1000)
+	se.throughput.record(ThroughputSample{
+		Simulations: 1000,
+		WallTime:    2 * time.Second,
+		CPUTime:     8 * time.Second,
+		StoredBytes: 1000 * 2048,
+		Workers:     4,
+	})
+
+	estimate := se.EstimateCost(100000, 1)
+	if estimate.Basis != "recent_runs" || estimate.SampleRuns != 1 {
+		t.Errorf("Expected recent_runs basis from 1 sample, got %s from %d", estimate.Basis, estimate.SampleRuns)
+	}
+	if estimate.EstimatedCPUSeconds != 800 {
+		t.Errorf("Expected 800 CPU seconds, got %f", estimate.EstimatedCPUSeconds)
+	}
+	if estimate.EstimatedWallSeconds != 200 {
+		t.Errorf("Expected 200 wall seconds, got %f", estimate.EstimatedWallSeconds)
+	}
+	if estimate.EstimatedStorageMB < 195 || estimate.EstimatedStorageMB > 197 {
+		t.Errorf("Expected ~195.4 MB of storage, got %f", estimate.EstimatedStorageMB)
+	}
+}
+
+// TestThroughputWindow tests that only recent samples are kept
+func TestThroughputWindow(t *testing.T) {
+	var tt throughputTracker
+	for i := 0; i < throughputWindow+5; i++ {
+		tt.record(ThroughputSample{Simulations: 10, CPUTime: time.Second, Workers: 1})
+	}
+	tt.record(ThroughputSample{Simulations: 0})
+
+	if _, _, _, _, n := tt.perSimulation(); n != throughputWindow {
+		t.Errorf("Expected %d samples, got %d", throughputWindow, n)
+	}
+}
@@ -0,0 +1,55 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"sim-engine/models"
+)
+
+// ReloadTuningParameters reads engine_parameters and makes them the active
+// calibration for games loaded from now on. Parameters missing from the table
+// keep their defaults. It returns the names whose values changed.
+func (se *SimulationEngine) ReloadTuningParameters(ctx context.Context) ([]string, error) {
+	rows, err := se.db.Query(ctx, `SELECT name, value FROM engine_parameters`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query engine parameters: %w", err)
+	}
+	defer rows.Close()
+
+	values := make(map[string]float64)
+	for rows.Next() {
+		var name string
+		var value float64
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan engine parameter: %w", err)
+		}
+		values[name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read engine parameters: %w", err)
+	}
+
+	params, err := models.TuningParametersFrom(values)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := changedTuningParameters(models.CurrentTuningParameters(), params)
+	models.SetTuningParameters(params)
+	return changed, nil
+}
+
+// changedTuningParameters lists, sorted, the parameters that differ
+func changedTuningParameters(before, after *models.TuningParameters) []string {
+	previous := before.Values()
+	changed := []string{}
+	for name, value := range after.Values() {
+		if previous[name] != value {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
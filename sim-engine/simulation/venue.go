@@ -0,0 +1,155 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/baseball-sim/shared/ids"
+	"github.com/jackc/pgx/v5"
+
+	"sim-engine/models"
+)
+
+// stadiumConfigKey is the run config key that moves a game to another
+// venue, e.g. a neutral-site series the schedule doesn't reflect yet
+const stadiumConfigKey = "stadium_id"
+
+// stadiumColumns are the stadium fields a simulation reads, aliased s
+const stadiumColumns = `s.id::text, s.name, s.location, s.latitude, s.longitude, s.altitude, s.surface, s.roof_type,
+		       s.dimensions, s.park_factors`
+
+// stadiumRow holds the nullable stadiumColumns as scanned
+type stadiumRow struct {
+	id, name, location, surface, roofType *string
+	latitude, longitude                   *float64
+	altitude                              *int
+	dimensionsJSON, parkFactorsJSON       []byte
+}
+
+// toStadiumData fills in defaults for anything the stadium row lacks
+func (row stadiumRow) toStadiumData() StadiumData {
+	var stadium StadiumData
+	if row.id != nil {
+		stadium.ID = *row.id
+	}
+	if row.name != nil {
+		stadium.Name = *row.name
+	}
+	if row.location != nil {
+		stadium.Location = *row.location
+	}
+	if row.latitude != nil {
+		stadium.Latitude = *row.latitude
+	}
+	if row.longitude != nil {
+		stadium.Longitude = *row.longitude
+	}
+	if row.altitude != nil {
+		stadium.Altitude = *row.altitude
+	}
+	if row.surface != nil {
+		stadium.Surface = *row.surface
+	}
+	if row.roofType != nil {
+		stadium.RoofType = *row.roofType
+	}
+
+	if len(row.dimensionsJSON) > 0 {
+		if err := json.Unmarshal(row.dimensionsJSON, &stadium.Dimensions); err != nil {
+			log.Printf("Failed to parse stadium dimensions: %v", err)
+			stadium.Dimensions = models.DefaultDimensions()
+		}
+		stadium.Dimensions = stadium.Dimensions.WithDefaults()
+	} else {
+		stadium.Dimensions = models.DefaultDimensions()
+	}
+
+	if len(row.parkFactorsJSON) > 0 {
+		if err := json.Unmarshal(row.parkFactorsJSON, &stadium.ParkFactors); err != nil {
+			log.Printf("Failed to parse park factors: %v", err)
+			stadium.ParkFactors = models.DefaultParkFactors()
+		}
+	} else {
+		stadium.ParkFactors = models.DefaultParkFactors()
+	}
+	return stadium
+}
+
+// NeutralSite reports whether the game is played away from the home team's
+// park. The home team still bats last but gets no home-field edge.
+func (gd *GameData) NeutralSite() bool {
+	return gd.Stadium.ID != "" && gd.HomeStadiumID != "" && gd.Stadium.ID != gd.HomeStadiumID
+}
+
+// StadiumOverrideFromConfig reads the venue a run moves its game to, by
+// stadium UUID or MLB venue ID. Empty means the scheduled venue.
+func StadiumOverrideFromConfig(config map[string]interface{}) (string, error) {
+	raw, ok := config[stadiumConfigKey]
+	if !ok || raw == nil {
+		return "", nil
+	}
+	switch v := raw.(type) {
+	case string:
+		if v == "" {
+			return "", fmt.Errorf("%s must not be empty", stadiumConfigKey)
+		}
+		return v, nil
+	case float64:
+		// MLB venue IDs are numeric
+		return fmt.Sprintf("%d", int64(v)), nil
+	default:
+		return "", fmt.Errorf("%s must be a stadium ID", stadiumConfigKey)
+	}
+}
+
+// LoadStadium loads a stadium by any ID the resolver accepts, returning
+// ok=false when there is none. An ambiguous ID is an *ids.AmbiguousError.
+func (se *SimulationEngine) LoadStadium(ctx context.Context, stadiumID string) (StadiumData, bool, error) {
+	resolved, err := se.ids.Resolve(ctx, ids.Stadium, stadiumID)
+	if errors.Is(err, ids.ErrNotFound) {
+		return StadiumData{}, false, nil
+	}
+	if err != nil {
+		return StadiumData{}, false, err
+	}
+
+	var row stadiumRow
+	err = se.db.QueryRow(ctx, `
+		SELECT `+stadiumColumns+`
+		FROM stadiums s
+		WHERE s.id = $1::uuid
+	`, resolved.ID).Scan(&row.id, &row.name, &row.location, &row.latitude, &row.longitude,
+		&row.altitude, &row.surface, &row.roofType, &row.dimensionsJSON, &row.parkFactorsJSON)
+	if err == pgx.ErrNoRows {
+		return StadiumData{}, false, nil
+	}
+	if err != nil {
+		return StadiumData{}, false, fmt.Errorf("failed to load stadium: %w", err)
+	}
+	return row.toStadiumData(), true, nil
+}
+
+// moveToStadium plays a game at another venue: park factors, dimensions,
+// altitude and the forecast all come from the new park. Stored weather
+// belongs to the scheduled venue, so without a weather service the game
+// falls back to default conditions.
+func (se *SimulationEngine) moveToStadium(ctx context.Context, gameData *GameData, stadium StadiumData) {
+	if stadium.ID == gameData.Stadium.ID {
+		return
+	}
+	gameData.Stadium = stadium
+	gameData.Weather = models.Weather{}
+
+	if se.weatherService != nil && stadium.Name != "" {
+		weather, err := se.weatherService.GetWeatherForGame(ctx, se.convertToWeatherStadiumInfo(stadium), gameData.GameTime)
+		if err != nil {
+			log.Printf("Failed to fetch weather for %s: %v, using default", stadium.Name, err)
+		} else {
+			gameData.Weather = weather
+		}
+	}
+	gameData.RainRisk = models.RainRiskFor(gameData.Weather, stadium.RoofType)
+}
@@ -0,0 +1,57 @@
+package simulation
+
+import (
+	"testing"
+
+	"sim-engine/models"
+)
+
+func TestStadiumOverrideFromConfig(t *testing.T) {
+	if id, err := StadiumOverrideFromConfig(nil); id != "" || err != nil {
+		t.Errorf("no override = %q, %v; want empty", id, err)
+	}
+	if id, err := StadiumOverrideFromConfig(map[string]interface{}{"stadium_id": "3289"}); id != "3289" || err != nil {
+		t.Errorf("string override = %q, %v", id, err)
+	}
+	// MLB venue IDs arrive as JSON numbers
+	if id, err := StadiumOverrideFromConfig(map[string]interface{}{"stadium_id": float64(2681)}); id != "2681" || err != nil {
+		t.Errorf("numeric override = %q, %v", id, err)
+	}
+	for _, bad := range []interface{}{"", true, []interface{}{"1"}} {
+		if _, err := StadiumOverrideFromConfig(map[string]interface{}{"stadium_id": bad}); err == nil {
+			t.Errorf("stadium_id %#v should be rejected", bad)
+		}
+	}
+}
+
+func TestStadiumRowDefaults(t *testing.T) {
+	name := "Estadio Alfredo Harp Helú"
+	altitude := 7349
+	stadium := stadiumRow{name: &name, altitude: &altitude, parkFactorsJSON: []byte("not json")}.toStadiumData()
+
+	if stadium.Name != name || stadium.Altitude != altitude {
+		t.Errorf("stadium = %+v", stadium)
+	}
+	if stadium.Dimensions != models.DefaultDimensions() {
+		t.Errorf("dimensions = %+v, want defaults", stadium.Dimensions)
+	}
+	if stadium.ParkFactors != models.DefaultParkFactors() {
+		t.Errorf("park factors = %+v, want defaults for unparseable JSON", stadium.ParkFactors)
+	}
+}
+
+func TestNeutralSite(t *testing.T) {
+	gd := &GameData{HomeStadiumID: "home", Stadium: StadiumData{ID: "home"}}
+	if gd.NeutralSite() {
+		t.Error("a game at the home team's park is not a neutral site")
+	}
+	gd.Stadium.ID = "london"
+	if !gd.NeutralSite() {
+		t.Error("a game away from the home team's park is a neutral site")
+	}
+	// Without a known home park there's nothing to compare against
+	gd.HomeStadiumID = ""
+	if gd.NeutralSite() {
+		t.Error("unknown home park should not count as a neutral site")
+	}
+}
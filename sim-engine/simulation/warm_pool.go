@@ -0,0 +1,253 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"sim-engine/models"
+)
+
+// DefaultWarmPoolTTL is how long a pre-loaded game context is reused. It is
+// kept short because the context includes the weather forecast; the
+// scheduled pre-warm refreshes it well within the TTL.
+const DefaultWarmPoolTTL = 2 * time.Hour
+
+// warmPool keeps fully prepared game contexts (game row, stadium, umpire,
+// weather, league environment, duration model and form) so a simulation of a
+// pre-warmed game starts without any loading
+type warmPool struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]warmPoolEntry
+	hits    int64
+	misses  int64
+}
+
+type warmPoolEntry struct {
+	game     *GameData
+	loadedAt time.Time
+}
+
+// WarmPoolStats reports warm pool usage since startup
+type WarmPoolStats struct {
+	Games      int     `json:"games"`
+	Hits       int64   `json:"hits"`
+	Misses     int64   `json:"misses"`
+	HitRate    float64 `json:"hit_rate"`
+	TTLSeconds float64 `json:"ttl_seconds"`
+}
+
+// WarmPoolReport summarizes one pre-warm pass
+type WarmPoolReport struct {
+	Date       string            `json:"date"`
+	Games      int               `json:"games"`
+	Warmed     int               `json:"warmed"`
+	Failed     map[string]string `json:"failed,omitempty"` // game ID to error
+	DurationMS int64             `json:"duration_ms"`
+	Pool       WarmPoolStats     `json:"pool"`
+}
+
+func newWarmPool(ttl time.Duration) *warmPool {
+	return &warmPool{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]warmPoolEntry),
+	}
+}
+
+// get returns a copy of a pre-loaded game context that is still fresh. Runs
+// only replace the context's fields, so a shallow copy keeps them apart.
+func (p *warmPool) get(gameID string) (*GameData, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.entries[gameID]
+	if ok && p.now().Sub(entry.loadedAt) >= p.ttl {
+		delete(p.entries, gameID)
+		ok = false
+	}
+	if !ok {
+		p.misses++
+		return nil, false
+	}
+	p.hits++
+	game := *entry.game
+	return &game, true
+}
+
+func (p *warmPool) put(game *GameData) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ttl <= 0 {
+		return
+	}
+	stored := *game
+	p.entries[game.GameID] = warmPoolEntry{game: &stored, loadedAt: p.now()}
+}
+
+// drop removes one game's pre-loaded context, if any
+func (p *warmPool) drop(gameID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.entries, gameID)
+}
+
+// invalidate drops every pre-loaded game and returns how many there were
+func (p *warmPool) invalidate() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	evicted := len(p.entries)
+	p.entries = make(map[string]warmPoolEntry)
+	return evicted
+}
+
+func (p *warmPool) setTTL(ttl time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.ttl = ttl
+	if ttl <= 0 {
+		p.entries = make(map[string]warmPoolEntry)
+	}
+}
+
+func (p *warmPool) stats() WarmPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := WarmPoolStats{
+		Games:      len(p.entries),
+		Hits:       p.hits,
+		Misses:     p.misses,
+		TTLSeconds: p.ttl.Seconds(),
+	}
+	if total := p.hits + p.misses; total > 0 {
+		stats.HitRate = float64(p.hits) / float64(total)
+	}
+	return stats
+}
+
+// SetWarmPoolTTL sets how long pre-loaded game contexts are reused; 0
+// disables the pool
+func (se *SimulationEngine) SetWarmPoolTTL(ttl time.Duration) {
+	se.warm.setTTL(ttl)
+}
+
+// InvalidateWarmPool drops every pre-loaded game context. Call it after a
+// data refresh alongside InvalidateRosterCache. It returns how many games
+// were dropped.
+func (se *SimulationEngine) InvalidateWarmPool() int {
+	return se.warm.invalidate()
+}
+
+// WarmPoolStats reports warm pool usage
+func (se *SimulationEngine) WarmPoolStats() WarmPoolStats {
+	return se.warm.stats()
+}
+
+// gameContext returns a game's prepared context from the warm pool, loading
+// it on a miss. A context warmed under older tuning parameters picks up the
+// current ones, reloading form when the form prior depends on them.
+func (se *SimulationEngine) gameContext(ctx context.Context, gameID string) (*GameData, error) {
+	game, ok := se.warm.get(gameID)
+	if !ok {
+		return se.prepareGameData(ctx, gameID)
+	}
+
+	if current := models.CurrentTuningParameters(); game.Tuning != current {
+		game.Tuning = current
+		game.HomeForm, game.AwayForm = nil, nil
+		se.applyFormPrior(ctx, game, game.Date)
+	}
+	return game, nil
+}
+
+// PrewarmGames pre-loads the context and both rosters of every scheduled game
+// on a date so interactive simulations of them start instantly
+func (se *SimulationEngine) PrewarmGames(ctx context.Context, date time.Time) (WarmPoolReport, error) {
+	start := time.Now()
+	report := WarmPoolReport{Date: date.Format("2006-01-02")}
+
+	rows, err := se.db.Query(ctx, `
+		SELECT game_id
+		FROM games
+		WHERE game_date = $1::date AND status = 'scheduled'
+		ORDER BY game_time
+	`, report.Date)
+	if err != nil {
+		return report, fmt.Errorf("failed to query games: %w", err)
+	}
+	var gameIDs []string
+	for rows.Next() {
+		var gameID string
+		if err := rows.Scan(&gameID); err != nil {
+			rows.Close()
+			return report, fmt.Errorf("failed to scan game: %w", err)
+		}
+		gameIDs = append(gameIDs, gameID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return report, fmt.Errorf("failed to query games: %w", err)
+	}
+
+	report.Games = len(gameIDs)
+	for _, gameID := range gameIDs {
+		if err := se.prewarmGame(ctx, gameID); err != nil {
+			if report.Failed == nil {
+				report.Failed = make(map[string]string)
+			}
+			report.Failed[gameID] = err.Error()
+			continue
+		}
+		report.Warmed++
+	}
+
+	report.DurationMS = time.Since(start).Milliseconds()
+	report.Pool = se.warm.stats()
+	return report, nil
+}
+
+// prewarmGame loads one game's context into the pool and its rosters into the
+// roster cache
+func (se *SimulationEngine) prewarmGame(ctx context.Context, gameID string) error {
+	game, err := se.prepareGameData(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if _, _, err := se.loadTeamRosters(ctx, game.HomeTeamID, game.AwayTeamID, game.League); err != nil {
+		return err
+	}
+	se.warm.put(game)
+	return nil
+}
+
+// StartWarmPoolRefresh pre-warms today's games now and then every interval,
+// keeping game-day contexts and weather fresh. A zero interval does nothing.
+func (se *SimulationEngine) StartWarmPoolRefresh(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			report, err := se.PrewarmGames(ctx, time.Now())
+			cancel()
+			if err != nil {
+				log.Printf("Warm pool refresh failed: %v", err)
+			} else {
+				log.Printf("Warm pool refreshed for %s: %d/%d games in %dms",
+					report.Date, report.Warmed, report.Games, report.DurationMS)
+			}
+			<-ticker.C
+		}
+	}()
+}
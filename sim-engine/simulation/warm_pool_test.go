@@ -0,0 +1,65 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"sim-engine/models"
+)
+
+func TestWarmPoolExpires(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	pool := newWarmPool(time.Hour)
+	pool.now = func() time.Time { return now }
+
+	if _, ok := pool.get("745001"); ok {
+		t.Fatal("expected a miss on an empty pool")
+	}
+	pool.put(&GameData{GameID: "745001", HomeTeamID: "home"})
+
+	now = now.Add(59 * time.Minute)
+	if game, ok := pool.get("745001"); !ok || game.HomeTeamID != "home" {
+		t.Errorf("expected a hit within the TTL, got %+v", game)
+	}
+	now = now.Add(time.Minute)
+	if _, ok := pool.get("745001"); ok {
+		t.Error("expected a miss once the TTL has passed")
+	}
+
+	stats := pool.stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Games != 0 {
+		t.Errorf("stats = %+v, want 1 hit, 2 misses, 0 games", stats)
+	}
+}
+
+func TestWarmPoolReturnsCopies(t *testing.T) {
+	pool := newWarmPool(time.Hour)
+	loaded := &GameData{GameID: "745001", Weather: models.Weather{Temperature: 72}}
+	pool.put(loaded)
+
+	// Changes by the loader or a run don't reach the pool
+	loaded.Weather.Temperature = 40
+	first, _ := pool.get("745001")
+	first.Weather.Temperature = 90
+	first.HomeForm = &models.TeamForm{Wins: 8, Losses: 2}
+
+	second, _ := pool.get("745001")
+	if second.Weather.Temperature != 72 || second.HomeForm != nil {
+		t.Errorf("pooled game changed: %+v", second)
+	}
+}
+
+func TestWarmPoolDisabledAndInvalidate(t *testing.T) {
+	pool := newWarmPool(time.Hour)
+	pool.put(&GameData{GameID: "a"})
+	pool.put(&GameData{GameID: "b"})
+	if evicted := pool.invalidate(); evicted != 2 {
+		t.Errorf("invalidate evicted %d games, want 2", evicted)
+	}
+
+	pool.setTTL(0)
+	pool.put(&GameData{GameID: "a"})
+	if _, ok := pool.get("a"); ok {
+		t.Error("a disabled pool should not keep games")
+	}
+}
@@ -24,6 +24,7 @@ func NewWeatherServiceAdapter(service *weather.Service) *WeatherServiceAdapter {
 func (w *WeatherServiceAdapter) GetWeatherForGame(ctx context.Context, stadium StadiumInfo, gameTime time.Time) (models.Weather, error) {
 	// Convert simulation.StadiumInfo to weather.StadiumInfo
 	weatherStadiumInfo := weather.StadiumInfo{
+		ID:        stadium.ID,
 		Name:      stadium.Name,
 		Location:  stadium.Location,
 		Latitude:  stadium.Latitude,
@@ -34,3 +35,8 @@ func (w *WeatherServiceAdapter) GetWeatherForGame(ctx context.Context, stadium S
 
 	return w.service.GetWeatherForGame(ctx, weatherStadiumInfo, gameTime)
 }
+
+// RefreshWeatherForGame implements the ForecastRefresher interface
+func (w *WeatherServiceAdapter) RefreshWeatherForGame(ctx context.Context, stadium StadiumInfo, gameTime time.Time) (models.Weather, error) {
+	return w.service.RefreshWeatherForGame(ctx, weather.StadiumInfo(stadium), gameTime)
+}
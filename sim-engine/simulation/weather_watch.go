@@ -0,0 +1,283 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"sim-engine/models"
+)
+
+const (
+	// DefaultWeatherRefreshLead is how long before first pitch a game's
+	// forecast is checked again
+	DefaultWeatherRefreshLead = 3 * time.Hour
+
+	// DefaultResimTemperatureDelta is the forecast temperature change, in
+	// °F, that re-runs a game
+	DefaultResimTemperatureDelta = 8
+)
+
+// Reasons a game-time forecast re-runs a game
+const (
+	ResimReasonTemperature   = "temperature"
+	ResimReasonWindDirection = "wind_direction"
+)
+
+// ForecastRefresher is implemented by weather services that can fetch a new
+// forecast past their cache
+type ForecastRefresher interface {
+	RefreshWeatherForGame(ctx context.Context, stadium StadiumInfo, gameTime time.Time) (models.Weather, error)
+}
+
+// WeatherResimulation is a run replaced because its game's forecast changed
+type WeatherResimulation struct {
+	GameID   string         `json:"game_id"` // MLB game ID
+	GameTime time.Time      `json:"game_time"`
+	HomeTeam string         `json:"home_team"`
+	AwayTeam string         `json:"away_team"`
+	RunID    string         `json:"run_id"`     // superseded run
+	NewRunID string         `json:"new_run_id"` // run started with the new forecast
+	Reasons  []string       `json:"reasons"`
+	Summary  string         `json:"summary"`
+	Previous models.Weather `json:"previous_weather"`
+	Current  models.Weather `json:"current_weather"`
+}
+
+// windFlips pairs each wind direction with its opposite
+var windFlips = map[string]string{"in": "out", "out": "in", "left": "right", "right": "left"}
+
+// ForecastChanges compares the weather a run simulated with a newer forecast
+// and returns why it should be re-run, if at all: a temperature change of at
+// least tempDelta °F, or wind that now blows the opposite way (in and out,
+// or left and right)
+func ForecastChanges(previous, current models.Weather, tempDelta int) []string {
+	var reasons []string
+	if diff := current.Temperature - previous.Temperature; diff >= tempDelta || -diff >= tempDelta {
+		reasons = append(reasons, ResimReasonTemperature)
+	}
+	if windFlips[previous.WindDir] == current.WindDir {
+		reasons = append(reasons, ResimReasonWindDirection)
+	}
+	return reasons
+}
+
+// forecastChangeSummary describes the changes, e.g. "temperature 64°F → 52°F"
+func forecastChangeSummary(previous, current models.Weather, reasons []string) string {
+	parts := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		switch reason {
+		case ResimReasonTemperature:
+			parts = append(parts, fmt.Sprintf("temperature %d°F → %d°F", previous.Temperature, current.Temperature))
+		case ResimReasonWindDirection:
+			parts = append(parts, fmt.Sprintf("wind %s → %s", previous.WindDir, current.WindDir))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// startsWithin reports whether a game starts after now and no more than lead
+// from it
+func startsWithin(gameTime, now time.Time, lead time.Duration) bool {
+	until := gameTime.Sub(now)
+	return until > 0 && until <= lead
+}
+
+// watchedRun is the latest finished run of one of today's games
+type watchedRun struct {
+	runID     string
+	gameID    string
+	homeTeam  string
+	awayTeam  string
+	stadiumID string // venue the run simulated, which a config may override
+	totalRuns int
+	config    []byte
+	weather   models.Weather
+}
+
+// CheckGameTimeWeather re-fetches the forecast for each of today's scheduled
+// games starting within lead and re-runs the game's latest finished run when
+// the forecast moved past the thresholds. The old run is marked superseded
+// by the new one. Each run is checked once, across replicas.
+func (se *SimulationEngine) CheckGameTimeWeather(ctx context.Context, now time.Time, lead time.Duration,
+	tempDelta int) ([]WeatherResimulation, error) {
+
+	refresher, ok := se.weatherService.(ForecastRefresher)
+	if !ok {
+		return nil, nil
+	}
+
+	rows, err := se.db.Query(ctx, `
+		SELECT DISTINCT ON (sr.game_id)
+		       sr.id::text, g.game_id, COALESCE(ht.name, ''), COALESCE(at.name, ''),
+		       COALESCE(sr.inputs->>'stadium_id', ''), sr.total_runs, COALESCE(sr.config, 'null'::jsonb),
+		       sr.inputs->'weather', sr.weather_checked_at IS NULL AND sr.superseded_by IS NULL
+		FROM simulation_runs sr
+		JOIN games g ON sr.game_id = g.id
+		LEFT JOIN teams ht ON g.home_team_id = ht.id
+		LEFT JOIN teams at ON g.away_team_id = at.id
+		WHERE g.game_date = $1::date AND g.status = 'scheduled'
+		  AND sr.status IN ('completed', $2) AND sr.inputs ? 'weather'
+		ORDER BY sr.game_id, sr.created_at DESC
+	`, now.Format("2006-01-02"), RunStatusPartial)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query today's runs: %w", err)
+	}
+	var runs []watchedRun
+	for rows.Next() {
+		var run watchedRun
+		var weatherJSON []byte
+		var unchecked bool
+		if err := rows.Scan(&run.runID, &run.gameID, &run.homeTeam, &run.awayTeam, &run.stadiumID,
+			&run.totalRuns, &run.config, &weatherJSON, &unchecked); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan run: %w", err)
+		}
+		if !unchecked || json.Unmarshal(weatherJSON, &run.weather) != nil {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query today's runs: %w", err)
+	}
+
+	var resims []WeatherResimulation
+	for _, run := range runs {
+		resim, err := se.recheckRunWeather(ctx, refresher, run, now, lead, tempDelta)
+		if err != nil {
+			log.Printf("Game-time weather check failed for run %s: %v", run.runID, err)
+			continue
+		}
+		if resim != nil {
+			resims = append(resims, *resim)
+		}
+	}
+	return resims, nil
+}
+
+// recheckRunWeather compares one run's weather with a fresh forecast, starting
+// a replacement run when it changed enough. It returns nil when the game isn't
+// in the window yet, another replica claimed the run, or nothing changed.
+func (se *SimulationEngine) recheckRunWeather(ctx context.Context, refresher ForecastRefresher, run watchedRun,
+	now time.Time, lead time.Duration, tempDelta int) (*WeatherResimulation, error) {
+
+	game, err := se.loadGameData(ctx, run.gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !startsWithin(game.GameTime, now, lead) {
+		return nil, nil
+	}
+	// A run at an overridden venue simulated another park's weather
+	if run.stadiumID != "" && run.stadiumID != game.Stadium.ID {
+		return nil, nil
+	}
+
+	// Claim the run so only one replica checks it
+	tag, err := se.db.Exec(ctx, `
+		UPDATE simulation_runs SET weather_checked_at = NOW()
+		WHERE id = $1 AND weather_checked_at IS NULL
+	`, run.runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim run: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, nil
+	}
+
+	current, err := refresher.RefreshWeatherForGame(ctx, se.convertToWeatherStadiumInfo(game.Stadium), game.GameTime)
+	if err != nil {
+		// Release the claim so the next pass tries again
+		se.db.Exec(ctx, `UPDATE simulation_runs SET weather_checked_at = NULL WHERE id = $1`, run.runID)
+		return nil, fmt.Errorf("failed to refresh forecast: %w", err)
+	}
+	reasons := ForecastChanges(run.weather, current, tempDelta)
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+
+	resim := &WeatherResimulation{
+		GameID:   run.gameID,
+		GameTime: game.GameTime,
+		HomeTeam: run.homeTeam,
+		AwayTeam: run.awayTeam,
+		RunID:    run.runID,
+		Reasons:  reasons,
+		Summary:  forecastChangeSummary(run.weather, current, reasons),
+		Previous: run.weather,
+		Current:  current,
+	}
+
+	// The replacement already simulates the game-time forecast, so it is
+	// never checked itself
+	tx, err := se.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin re-run: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO simulation_runs (game_id, config, total_runs, status, weather_checked_at)
+		SELECT game_id, config, total_runs, 'pending', NOW() FROM simulation_runs WHERE id = $1
+		RETURNING id::text
+	`, run.runID).Scan(&resim.NewRunID); err != nil {
+		return nil, fmt.Errorf("failed to create re-run: %w", err)
+	}
+	// The re-run takes a place in the queue like any other run. When there
+	// is none, the claim is released so a later pass tries again.
+	if !se.admitOwnRun(resim.NewRunID, run.gameID, run.totalRuns) {
+		se.db.Exec(ctx, `UPDATE simulation_runs SET weather_checked_at = NULL WHERE id = $1`, run.runID)
+		return nil, ErrQueueFull
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE simulation_runs
+		SET superseded_by = $2, superseded_at = NOW(), superseded_reason = $3
+		WHERE id = $1
+	`, run.runID, resim.NewRunID, resim.Summary); err != nil {
+		se.ReleaseRun(resim.NewRunID)
+		return nil, fmt.Errorf("failed to supersede run: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		se.ReleaseRun(resim.NewRunID)
+		return nil, fmt.Errorf("failed to commit re-run: %w", err)
+	}
+
+	var config map[string]interface{}
+	json.Unmarshal(run.config, &config)
+
+	// A pre-warmed context still holds the old forecast
+	se.warm.drop(run.gameID)
+	go se.RunSimulation(resim.NewRunID, run.gameID, run.totalRuns, config)
+
+	log.Printf("Re-running game %s as %s after forecast change (%s); run %s superseded",
+		run.gameID, resim.NewRunID, resim.Summary, run.runID)
+	return resim, nil
+}
+
+// StartWeatherWatch checks today's games every interval, handing any
+// re-simulations to notify. A zero interval or lead does nothing.
+func (se *SimulationEngine) StartWeatherWatch(interval, lead time.Duration, tempDelta int,
+	notify func(context.Context, []WeatherResimulation)) {
+	if interval <= 0 || lead <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			resims, err := se.CheckGameTimeWeather(ctx, time.Now(), lead, tempDelta)
+			if err != nil {
+				log.Printf("Game-time weather check failed: %v", err)
+			} else if len(resims) > 0 && notify != nil {
+				notify(ctx, resims)
+			}
+			cancel()
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,62 @@
+package simulation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"sim-engine/models"
+)
+
+func TestForecastChanges(t *testing.T) {
+	previous := models.Weather{Temperature: 64, WindSpeed: 10, WindDir: "out"}
+	tests := []struct {
+		name    string
+		current models.Weather
+		want    []string
+	}{
+		{"unchanged", previous, nil},
+		{"small temperature drop", models.Weather{Temperature: 57, WindDir: "out"}, nil},
+		{"temperature drop", models.Weather{Temperature: 56, WindDir: "out"}, []string{ResimReasonTemperature}},
+		{"temperature rise", models.Weather{Temperature: 72, WindDir: "out"}, []string{ResimReasonTemperature}},
+		{"crosswind", models.Weather{Temperature: 64, WindDir: "left"}, nil},
+		{"wind flip", models.Weather{Temperature: 64, WindDir: "in"}, []string{ResimReasonWindDirection}},
+		{"both", models.Weather{Temperature: 52, WindDir: "in"}, []string{ResimReasonTemperature, ResimReasonWindDirection}},
+	}
+	for _, tc := range tests {
+		if got := ForecastChanges(previous, tc.current, DefaultResimTemperatureDelta); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: ForecastChanges = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	// Crosswinds flip too; variable wind never does
+	if got := ForecastChanges(models.Weather{WindDir: "left"}, models.Weather{WindDir: "right"}, 8); len(got) != 1 {
+		t.Errorf("left to right should flip, got %v", got)
+	}
+	if got := ForecastChanges(models.Weather{WindDir: "varies"}, models.Weather{WindDir: "in"}, 8); got != nil {
+		t.Errorf("variable wind should not flip, got %v", got)
+	}
+}
+
+func TestForecastChangeSummary(t *testing.T) {
+	previous := models.Weather{Temperature: 64, WindDir: "out"}
+	current := models.Weather{Temperature: 52, WindDir: "in"}
+	got := forecastChangeSummary(previous, current, ForecastChanges(previous, current, 8))
+	if want := "temperature 64°F → 52°F, wind out → in"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+}
+
+func TestStartsWithin(t *testing.T) {
+	now := time.Date(2026, 6, 15, 16, 0, 0, 0, time.UTC)
+	lead := DefaultWeatherRefreshLead
+	if !startsWithin(now.Add(3*time.Hour), now, lead) || !startsWithin(now.Add(time.Minute), now, lead) {
+		t.Error("games starting within the lead should be checked")
+	}
+	if startsWithin(now.Add(3*time.Hour+time.Minute), now, lead) {
+		t.Error("games starting later than the lead should wait")
+	}
+	if startsWithin(now.Add(-time.Minute), now, lead) {
+		t.Error("games already started should be skipped")
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/baseball-sim/shared/ids"
+
+	"sim-engine/simulation"
+)
+
+// validateStadiumOverride checks that a run's stadium_id names a known
+// stadium. A bad override is the caller's error, so it's reported before the
+// run is created rather than failing the run later.
+func (s *Server) validateStadiumOverride(ctx context.Context, w http.ResponseWriter, config map[string]interface{}) bool {
+	stadiumID, err := simulation.StadiumOverrideFromConfig(config)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return false
+	}
+	if stadiumID == "" {
+		return true
+	}
+	if _, found, err := s.simEngine.LoadStadium(ctx, stadiumID); err != nil {
+		writeIDError(w, ids.Stadium, err)
+		return false
+	} else if !found {
+		http.Error(w, fmt.Sprintf("Unknown stadium %q", stadiumID), http.StatusUnprocessableEntity)
+		return false
+	}
+	return true
+}
@@ -30,6 +30,7 @@ type Service struct {
 	apiKey     string
 	httpClient *http.Client
 	cache      *forecastCache
+	store      ForecastStore // shared with other replicas; nil keeps forecasts in memory only
 	mu         sync.RWMutex
 }
 
@@ -81,6 +82,7 @@ type OpenWeatherResponse struct {
 
 // StadiumInfo contains stadium data needed for weather decisions
 type StadiumInfo struct {
+	ID        string
 	Name      string
 	Location  string
 	Latitude  float64
@@ -102,7 +104,16 @@ func NewService(apiKey string) *Service {
 	}
 }
 
-// GetWeatherForGame fetches weather data for a specific game
+// SetStore shares forecasts through a store so replicas don't each fetch
+// the same stadium and hour
+func (s *Service) SetStore(store ForecastStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store = store
+}
+
+// GetWeatherForGame fetches weather data for a specific game. Forecasts come
+// from the in-memory cache, then the shared store, then OpenWeatherMap.
 func (s *Service) GetWeatherForGame(ctx context.Context, stadium StadiumInfo, gameTime time.Time) (models.Weather, error) {
 	// Check if stadium has dome or retractable roof (closed by default in bad weather)
 	if s.isDome(stadium.RoofType) {
@@ -123,6 +134,20 @@ func (s *Service) GetWeatherForGame(ctx context.Context, stadium StadiumInfo, ga
 		return s.getDefaultWeather(stadium), nil
 	}
 
+	// Another replica may already have fetched it
+	store := s.getStore()
+	stadiumKey, hour := s.getStoreKey(stadium, gameTime)
+	if store != nil {
+		stored, ok, err := store.Get(ctx, stadiumKey, hour)
+		if err != nil {
+			log.Printf("Warning: Failed to read shared weather for %s: %v", stadium.Name, err)
+		} else if ok {
+			log.Printf("Using shared weather for %s fetched at %s", stadium.Name, stored.FetchedAt.Format(time.RFC3339))
+			s.cacheForecastUntil(cacheKey, stored.Weather, stored.ExpiresAt)
+			return stored.Weather, nil
+		}
+	}
+
 	// Fetch forecast from OpenWeatherMap
 	weather, err := s.fetchForecast(ctx, stadium, gameTime)
 	if err != nil {
@@ -130,12 +155,54 @@ func (s *Service) GetWeatherForGame(ctx context.Context, stadium StadiumInfo, ga
 		return s.getDefaultWeather(stadium), nil
 	}
 
-	// Cache the result
-	s.cacheForecast(cacheKey, weather)
+	s.saveForecast(ctx, stadium, gameTime, weather)
+	return weather, nil
+}
 
+// RefreshWeatherForGame fetches a new forecast for a game, skipping the cache
+// and shared store, and replaces what they hold so later runs use it. Unlike
+// GetWeatherForGame it returns an error rather than default conditions when
+// the fetch fails.
+func (s *Service) RefreshWeatherForGame(ctx context.Context, stadium StadiumInfo, gameTime time.Time) (models.Weather, error) {
+	if s.isDome(stadium.RoofType) {
+		return s.getControlledConditions(), nil
+	}
+	if stadium.Latitude == 0 && stadium.Longitude == 0 {
+		return s.getDefaultWeather(stadium), nil
+	}
+
+	weather, err := s.fetchForecast(ctx, stadium, gameTime)
+	if err != nil {
+		return models.Weather{}, err
+	}
+	s.saveForecast(ctx, stadium, gameTime, weather)
 	return weather, nil
 }
 
+// saveForecast caches a fetched forecast and shares it with other replicas
+func (s *Service) saveForecast(ctx context.Context, stadium StadiumInfo, gameTime time.Time, weather models.Weather) {
+	fetchedAt := time.Now()
+	s.cacheForecastUntil(s.getCacheKey(stadium, gameTime), weather, fetchedAt.Add(cacheDuration))
+
+	store := s.getStore()
+	if store == nil {
+		return
+	}
+	stadiumKey, hour := s.getStoreKey(stadium, gameTime)
+	err := store.Put(ctx, StoredForecast{StadiumKey: stadiumKey, ForecastHour: hour, Provider: providerOpenWeather,
+		Weather: weather, FetchedAt: fetchedAt, ExpiresAt: fetchedAt.Add(cacheDuration)})
+	if err != nil {
+		log.Printf("Warning: Failed to share weather for %s: %v", stadium.Name, err)
+	}
+}
+
+// getStore returns the shared forecast store, if any
+func (s *Service) getStore() ForecastStore {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.store
+}
+
 // isDome checks if the stadium is domed or indoor
 func (s *Service) isDome(roofType string) bool {
 	switch roofType {
@@ -301,6 +368,11 @@ func (s *Service) findClosestForecast(resp OpenWeatherResponse, gameTime time.Ti
 		WindDir:     s.degreesToDirection(closestEntry.Wind.Deg),
 		Humidity:    closestEntry.Main.Humidity,
 		Pressure:    closestEntry.Main.Pressure,
+
+		PrecipProbability: closestEntry.Pop,
+	}
+	if closestEntry.Rain != nil {
+		weather.RainMM = closestEntry.Rain.ThreeH
 	}
 
 	// Adjust pressure for altitude if needed
@@ -353,6 +425,16 @@ func (s *Service) getCacheKey(stadium StadiumInfo, gameTime time.Time) string {
 	return fmt.Sprintf("%s_%s", stadium.Name, rounded.Format("2006-01-02T15"))
 }
 
+// getStoreKey keys a shared forecast by stadium ID (name when there's no ID)
+// and the game time rounded to the hour, like the in-memory cache
+func (s *Service) getStoreKey(stadium StadiumInfo, gameTime time.Time) (string, time.Time) {
+	key := stadium.ID
+	if key == "" {
+		key = stadium.Name
+	}
+	return key, gameTime.Round(time.Hour).UTC()
+}
+
 // getCachedForecast retrieves cached forecast if not expired
 func (s *Service) getCachedForecast(key string) (models.Weather, bool) {
 	s.cache.mu.RLock()
@@ -370,12 +452,17 @@ func (s *Service) getCachedForecast(key string) (models.Weather, bool) {
 
 // cacheForecast stores a forecast in the cache
 func (s *Service) cacheForecast(key string, weather models.Weather) {
+	s.cacheForecastUntil(key, weather, time.Now().Add(cacheDuration))
+}
+
+// cacheForecastUntil stores a forecast in the cache until the given time
+func (s *Service) cacheForecastUntil(key string, weather models.Weather, expiresAt time.Time) {
 	s.cache.mu.Lock()
 	defer s.cache.mu.Unlock()
 
 	s.cache.data[key] = &cachedForecast{
 		weather:   weather,
-		expiresAt: time.Now().Add(cacheDuration),
+		expiresAt: expiresAt,
 	}
 }
 
@@ -401,6 +488,16 @@ func (s *Service) StartCacheCleanup() {
 		for range ticker.C {
 			s.CleanExpiredCache()
 			log.Printf("Weather cache cleaned: %d entries remaining", len(s.cache.data))
+
+			if store := s.getStore(); store != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+				if deleted, err := store.DeleteExpired(ctx, time.Now()); err != nil {
+					log.Printf("Warning: %v", err)
+				} else if deleted > 0 {
+					log.Printf("Deleted %d expired shared weather forecasts", deleted)
+				}
+				cancel()
+			}
 		}
 	}()
 }
@@ -320,3 +320,64 @@ func TestGetCacheStats(t *testing.T) {
 		t.Errorf("Expected 2 cache entries, got %v", stats["entries"])
 	}
 }
+
+// fakeStore is an in-memory ForecastStore
+type fakeStore struct {
+	forecasts map[string]StoredForecast
+	gets      int
+	puts      int
+}
+
+func (f *fakeStore) Get(ctx context.Context, stadiumKey string, hour time.Time) (StoredForecast, bool, error) {
+	f.gets++
+	forecast, ok := f.forecasts[stadiumKey+hour.Format(time.RFC3339)]
+	return forecast, ok && time.Now().Before(forecast.ExpiresAt), nil
+}
+
+func (f *fakeStore) Put(ctx context.Context, forecast StoredForecast) error {
+	f.puts++
+	f.forecasts[forecast.StadiumKey+forecast.ForecastHour.Format(time.RFC3339)] = forecast
+	return nil
+}
+
+func (f *fakeStore) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// TestGetWeatherForGame_SharedStore tests reading forecasts fetched by another replica
+func TestGetWeatherForGame_SharedStore(t *testing.T) {
+	service := NewService("") // No API key, so any fetch would fall back to defaults
+	store := &fakeStore{forecasts: make(map[string]StoredForecast)}
+	service.SetStore(store)
+	ctx := context.Background()
+
+	stadium := StadiumInfo{ID: "stadium-1", Name: "Fenway Park", RoofType: "outdoor", Latitude: 42.35, Longitude: -71.1}
+	gameTime := time.Date(2024, 10, 6, 19, 10, 0, 0, time.FixedZone("EDT", -4*3600))
+
+	key, hour := service.getStoreKey(stadium, gameTime)
+	if key != "stadium-1" || !hour.Equal(time.Date(2024, 10, 6, 23, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected store key %s %s", key, hour)
+	}
+	store.Put(ctx, StoredForecast{StadiumKey: key, ForecastHour: hour, Provider: providerOpenWeather,
+		Weather: models.Weather{Temperature: 51, WindSpeed: 14}, FetchedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+
+	weather, err := service.GetWeatherForGame(ctx, stadium, gameTime)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if weather.Temperature != 51 || weather.WindSpeed != 14 {
+		t.Errorf("Expected the shared forecast, got %+v", weather)
+	}
+
+	// The shared forecast is cached in memory too
+	service.GetWeatherForGame(ctx, stadium, gameTime)
+	if store.gets != 1 {
+		t.Errorf("Expected 1 store read, got %d", store.gets)
+	}
+
+	// Default weather from a failed fetch isn't shared
+	service.GetWeatherForGame(ctx, stadium, gameTime.Add(24*time.Hour))
+	if store.puts != 1 {
+		t.Errorf("Expected only the seeded forecast to be stored, got %d puts", store.puts)
+	}
+}
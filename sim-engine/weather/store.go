@@ -0,0 +1,98 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"sim-engine/models"
+)
+
+// providerOpenWeather is recorded with forecasts fetched from OpenWeatherMap
+const providerOpenWeather = "openweathermap"
+
+// StoredForecast is a forecast shared between engine replicas
+type StoredForecast struct {
+	StadiumKey   string
+	ForecastHour time.Time // game time rounded to the hour, UTC
+	Provider     string
+	Weather      models.Weather
+	FetchedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// ForecastStore shares fetched forecasts so each stadium and hour is fetched
+// once across replicas rather than once per replica
+type ForecastStore interface {
+	// Get returns the unexpired forecast for the stadium and hour, if any
+	Get(ctx context.Context, stadiumKey string, hour time.Time) (StoredForecast, bool, error)
+	Put(ctx context.Context, forecast StoredForecast) error
+	// DeleteExpired removes forecasts that expired before the given time
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// PostgresStore keeps forecasts in the weather_forecasts table
+type PostgresStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresStore creates a forecast store backed by the database
+func NewPostgresStore(db *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Get returns the unexpired forecast for the stadium and hour
+func (p *PostgresStore) Get(ctx context.Context, stadiumKey string, hour time.Time) (StoredForecast, bool, error) {
+	forecast := StoredForecast{StadiumKey: stadiumKey, ForecastHour: hour}
+	var data []byte
+	err := p.db.QueryRow(ctx, `
+		SELECT provider, weather, fetched_at, expires_at
+		FROM weather_forecasts
+		WHERE stadium_key = $1 AND forecast_hour = $2 AND expires_at > NOW()
+	`, stadiumKey, hour).Scan(&forecast.Provider, &data, &forecast.FetchedAt, &forecast.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return forecast, false, nil
+	}
+	if err != nil {
+		return forecast, false, fmt.Errorf("failed to load forecast: %w", err)
+	}
+	if err := json.Unmarshal(data, &forecast.Weather); err != nil {
+		return forecast, false, fmt.Errorf("failed to decode forecast: %w", err)
+	}
+	return forecast, true, nil
+}
+
+// Put saves a forecast, replacing any earlier fetch for the stadium and hour
+func (p *PostgresStore) Put(ctx context.Context, forecast StoredForecast) error {
+	data, err := json.Marshal(forecast.Weather)
+	if err != nil {
+		return fmt.Errorf("failed to encode forecast: %w", err)
+	}
+	_, err = p.db.Exec(ctx, `
+		INSERT INTO weather_forecasts (stadium_key, forecast_hour, provider, weather, fetched_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (stadium_key, forecast_hour) DO UPDATE SET
+			provider = EXCLUDED.provider,
+			weather = EXCLUDED.weather,
+			fetched_at = EXCLUDED.fetched_at,
+			expires_at = EXCLUDED.expires_at
+	`, forecast.StadiumKey, forecast.ForecastHour, forecast.Provider, data, forecast.FetchedAt, forecast.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save forecast: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes forecasts that expired before the given time
+func (p *PostgresStore) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := p.db.Exec(ctx, `DELETE FROM weather_forecasts WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired forecasts: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"sim-engine/models"
+)
+
+// WinExpectancyResponse is the model's win probability for a game situation
+// alongside the leverage the engine assigns it
+type WinExpectancyResponse struct {
+	Inning    int    `json:"inning"`
+	Half      string `json:"half"`
+	Outs      int    `json:"outs"`
+	BaseState string `json:"base_state"`
+	Diff      int    `json:"diff"`
+	models.WinExpectancyResult
+
+	// Leverage is CalculateLeverage's index for the situation, as recorded
+	// on simulated events
+	Leverage float64 `json:"leverage"`
+	Model    string  `json:"model"`
+}
+
+// winExpectancyHandler returns the win expectancy for ?inning=, ?half=,
+// ?outs=, ?base_state= (first, second, third as 0/1, e.g. 110) and ?diff=,
+// the batting team's lead
+func (s *Server) winExpectancyHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	intParam := func(name string, fallback int) (int, bool) {
+		raw := query.Get(name)
+		if raw == "" {
+			return fallback, true
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, name+" must be an integer", http.StatusBadRequest)
+			return 0, false
+		}
+		return value, true
+	}
+
+	inning, ok := intParam("inning", 1)
+	if !ok {
+		return
+	}
+	outs, ok := intParam("outs", 0)
+	if !ok {
+		return
+	}
+	diff, ok := intParam("diff", 0)
+	if !ok {
+		return
+	}
+
+	half := query.Get("half")
+	if half == "" {
+		half = "top"
+	}
+	baseState := query.Get("base_state")
+	if baseState == "" {
+		baseState = "000"
+	}
+	bases, err := models.ParseBaseState(baseState)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	situation := models.WinExpectancySituation{
+		Inning:     inning,
+		InningHalf: half,
+		Outs:       outs,
+		Bases:      bases,
+		ScoreDiff:  diff,
+	}
+	if err := situation.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, WinExpectancyResponse{
+		Inning:              inning,
+		Half:                half,
+		Outs:                outs,
+		BaseState:           baseState,
+		Diff:                diff,
+		WinExpectancyResult: models.WinExpectancy(situation),
+		Leverage:            situationLeverage(situation),
+		Model:               "markov_league_average",
+	})
+}
+
+// situationLeverage scores the situation with the engine's leverage index
+func situationLeverage(s models.WinExpectancySituation) float64 {
+	state := models.GameState{Inning: s.Inning, InningHalf: s.InningHalf, Outs: s.Outs}
+	if s.InningHalf == "bottom" {
+		state.HomeScore = max(s.ScoreDiff, 0)
+		state.AwayScore = max(-s.ScoreDiff, 0)
+	} else {
+		state.AwayScore = max(s.ScoreDiff, 0)
+		state.HomeScore = max(-s.ScoreDiff, 0)
+	}
+	runner := &models.BaseRunner{}
+	if s.Bases[0] {
+		state.Bases.First = runner
+	}
+	if s.Bases[1] {
+		state.Bases.Second = runner
+	}
+	if s.Bases[2] {
+		state.Bases.Third = runner
+	}
+	return state.CalculateLeverage()
+}
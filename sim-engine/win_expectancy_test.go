@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWinExpectancyHandler(t *testing.T) {
+	s := &Server{}
+
+	rec := httptest.NewRecorder()
+	s.winExpectancyHandler(rec, httptest.NewRequest(http.MethodGet, "/meta/win-expectancy?inning=7&half=bottom&outs=1&base_state=110&diff=-1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp WinExpectancyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Expected JSON: %v", err)
+	}
+	if resp.BaseState != "110" || resp.Diff != -1 || resp.Half != "bottom" {
+		t.Errorf("Expected the situation echoed back, got %+v", resp)
+	}
+	if resp.BattingTeamWinProbability <= 0 || resp.BattingTeamWinProbability >= 0.5 {
+		t.Errorf("Expected the trailing home team under 0.5, got %f", resp.BattingTeamWinProbability)
+	}
+	if resp.Leverage <= 1 {
+		t.Errorf("Expected a late, close situation with runners on to carry leverage, got %f", resp.Leverage)
+	}
+
+	for _, query := range []string{
+		"?inning=x",
+		"?half=middle",
+		"?outs=3",
+		"?base_state=12",
+		"?inning=9&half=bottom&diff=1",
+	} {
+		rec := httptest.NewRecorder()
+		s.winExpectancyHandler(rec, httptest.NewRequest(http.MethodGet, "/meta/win-expectancy"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}